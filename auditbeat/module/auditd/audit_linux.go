@@ -30,6 +30,7 @@ import (
 
 	"github.com/elastic/beats/v7/auditbeat/ab"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -78,6 +79,9 @@ func init() {
 		mb.WithHostParser(parse.EmptyHostParser),
 		mb.WithNamespace(namespace),
 	)
+	seccomp.RegisterRequirements(seccomp.KindModule, moduleName, seccomp.Requirements{
+		Capabilities: []string{"CAP_AUDIT_CONTROL", "CAP_AUDIT_READ"},
+	})
 }
 
 // MetricSet listens for audit messages from the Linux kernel using a netlink
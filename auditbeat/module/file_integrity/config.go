@@ -96,18 +96,30 @@ func (b *Backend) Unpack(v string) error {
 // Config contains the configuration parameters for the file integrity
 // metricset.
 type Config struct {
-	Paths               []string        `config:"paths" validate:"required"`
-	HashTypes           []HashType      `config:"hash_types"`
-	FileParsers         []string        `config:"file_parsers"`
-	MaxFileSize         string          `config:"max_file_size"`
-	MaxFileSizeBytes    uint64          `config:",ignore"`
-	ScanAtStart         bool            `config:"scan_at_start"`
-	ScanRatePerSec      string          `config:"scan_rate_per_sec"`
-	ScanRateBytesPerSec uint64          `config:",ignore"`
-	Recursive           bool            `config:"recursive"` // Recursive enables recursive monitoring of directories.
-	ExcludeFiles        []match.Matcher `config:"exclude_files"`
-	IncludeFiles        []match.Matcher `config:"include_files"`
-	Backend             Backend         `config:"backend"`
+	Paths               []string          `config:"paths" validate:"required"`
+	HashTypes           []HashType        `config:"hash_types"`
+	FileParsers         []string          `config:"file_parsers"`
+	MaxFileSize         string            `config:"max_file_size"`
+	MaxFileSizeBytes    uint64            `config:",ignore"`
+	ScanAtStart         bool              `config:"scan_at_start"`
+	ScanRatePerSec      string            `config:"scan_rate_per_sec"`
+	ScanRateBytesPerSec uint64            `config:",ignore"`
+	Recursive           bool              `config:"recursive"` // Recursive enables recursive monitoring of directories.
+	ExcludeFiles        []match.Matcher   `config:"exclude_files"`
+	IncludeFiles        []match.Matcher   `config:"include_files"`
+	Backend             Backend           `config:"backend"`
+	ContentDiff         ContentDiffConfig `config:"content_diff"`
+}
+
+// ContentDiffConfig configures the reporting of content diffs for changed
+// text files.
+type ContentDiffConfig struct {
+	Enabled          bool     `config:"enabled"`
+	Paths            []string `config:"paths"` // Paths to diff, defaults to all watched paths.
+	MaxFileSize      string   `config:"max_file_size"`
+	MaxFileSizeBytes uint64   `config:",ignore"`
+	MaxDiffSize      string   `config:"max_diff_size"`
+	MaxDiffSizeBytes uint64   `config:",ignore"`
 }
 
 // Validate validates the config data and return an error explaining all the
@@ -183,6 +195,26 @@ nextHash:
 		errs = append(errs, fmt.Errorf("invalid scan_rate_per_sec value: %w", err))
 	}
 
+	if c.ContentDiff.Enabled {
+		for i, p := range c.ContentDiff.Paths {
+			if p, err := filepath.Abs(p); err == nil {
+				c.ContentDiff.Paths[i] = filepath.Clean(p)
+			}
+		}
+
+		c.ContentDiff.MaxFileSizeBytes, err = humanize.ParseBytes(c.ContentDiff.MaxFileSize)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid content_diff.max_file_size value: %w", err))
+		} else if c.ContentDiff.MaxFileSizeBytes == 0 {
+			errs = append(errs, fmt.Errorf("content_diff.max_file_size value (%v) must be positive", c.ContentDiff.MaxFileSize))
+		}
+
+		c.ContentDiff.MaxDiffSizeBytes, err = humanize.ParseBytes(c.ContentDiff.MaxDiffSize)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid content_diff.max_diff_size value: %w", err))
+		}
+	}
+
 	if c.Backend != "" && c.Backend != BackendAuto && runtime.GOOS != "linux" {
 		errs = append(errs, errors.New("backend can only be specified on linux"))
 	}
@@ -235,4 +267,10 @@ var defaultConfig = Config{
 	MaxFileSizeBytes: 100 * 1024 * 1024,
 	ScanAtStart:      true,
 	ScanRatePerSec:   "50 MiB",
+	ContentDiff: ContentDiffConfig{
+		MaxFileSize:      "64 KiB",
+		MaxFileSizeBytes: 64 * 1024,
+		MaxDiffSize:      "16 KiB",
+		MaxDiffSizeBytes: 16 * 1024,
+	},
 }
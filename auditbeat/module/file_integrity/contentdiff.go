@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package file_integrity
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/elastic/beats/v7/auditbeat/datastore"
+)
+
+const contentBucketName = "file.content.v1"
+
+// contentDiff is the difference between the previously stored and the
+// current content of a text file.
+type contentDiff struct {
+	unified   string
+	truncated bool
+}
+
+// contentDiffer keeps a copy of the content of small text files so that a
+// unified diff can be reported when they change.
+type contentDiffer struct {
+	config ContentDiffConfig
+	bucket datastore.Bucket
+}
+
+func newContentDiffer(c ContentDiffConfig, bucket datastore.Bucket) *contentDiffer {
+	return &contentDiffer{config: c, bucket: bucket}
+}
+
+// matches reports whether the path is under one of the configured paths.
+func (d *contentDiffer) matches(path string) bool {
+	if len(d.config.Paths) == 0 {
+		return true
+	}
+	for _, p := range d.config.Paths {
+		if path == p || strings.HasPrefix(path, p+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// update stores the current content of the file of the event and returns the
+// diff against the previously stored content. A nil diff is returned if the
+// file is not eligible or there was no previous content to compare against.
+func (d *contentDiffer) update(event *Event) (*contentDiff, error) {
+	if !d.matches(event.Path) {
+		return nil, nil
+	}
+
+	if event.Info == nil {
+		return nil, d.bucket.Delete(event.Path)
+	}
+	if event.Info.Type != FileType || event.Info.Size > d.config.MaxFileSizeBytes {
+		return nil, d.bucket.Delete(event.Path)
+	}
+
+	current, err := readLimited(event.Path, d.config.MaxFileSizeBytes)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || !isText(current) {
+		return nil, d.bucket.Delete(event.Path)
+	}
+
+	var (
+		previous []byte
+		found    bool
+	)
+	err = d.bucket.Load(event.Path, func(blob []byte) error {
+		previous = append([]byte(nil), blob...)
+		found = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.bucket.Store(event.Path, current); err != nil {
+		return nil, err
+	}
+	if !found || bytes.Equal(previous, current) {
+		return nil, nil
+	}
+
+	return unifiedDiff(event.Path, previous, current, d.config.MaxDiffSizeBytes)
+}
+
+// readLimited reads the whole file, returning nil if it grew beyond the
+// size limit since it was last stat'ed.
+func readLimited(path string, limit uint64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) > limit {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// isText reports whether the content looks like UTF-8 text.
+func isText(data []byte) bool {
+	return bytes.IndexByte(data, 0) < 0 && utf8.Valid(data)
+}
+
+func unifiedDiff(path string, previous, current []byte, limit uint64) (*contentDiff, error) {
+	unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(previous)),
+		B:        difflib.SplitLines(string(current)),
+		FromFile: path,
+		ToFile:   path,
+		Context:  3,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute diff: %w", err)
+	}
+
+	diff := &contentDiff{unified: unified}
+	if limit > 0 && uint64(len(unified)) > limit {
+		// Cut at a line boundary to avoid breaking multi-byte characters.
+		cut := unified[:limit]
+		if i := strings.LastIndexByte(cut, '\n'); i >= 0 {
+			cut = cut[:i+1]
+		}
+		diff.unified = cut
+		diff.truncated = true
+	}
+	return diff, nil
+}
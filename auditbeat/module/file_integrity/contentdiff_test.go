@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package file_integrity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/auditbeat/datastore"
+)
+
+func TestContentDiffer(t *testing.T) {
+	dir := t.TempDir()
+	ds := datastore.New(filepath.Join(dir, "beat.db"), 0o600)
+	bucket, err := ds.OpenBucket(contentBucketName)
+	require.NoError(t, err)
+	defer bucket.Close()
+
+	differ := newContentDiffer(ContentDiffConfig{
+		Enabled:          true,
+		MaxFileSizeBytes: 1024,
+		MaxDiffSizeBytes: 1024,
+	}, bucket)
+
+	path := filepath.Join(dir, "app.conf")
+	write := func(content string) *Event {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return &Event{Path: path, Info: &Metadata{Type: FileType, Size: uint64(len(content))}}
+	}
+
+	// The first time a file is seen there is nothing to compare with.
+	diff, err := differ.update(write("listen 80\nuser nobody\n"))
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	diff, err = differ.update(write("listen 8080\nuser nobody\n"))
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.truncated)
+	assert.Contains(t, diff.unified, "-listen 80\n")
+	assert.Contains(t, diff.unified, "+listen 8080\n")
+	assert.Contains(t, diff.unified, " user nobody\n")
+
+	// Unchanged content produces no diff.
+	diff, err = differ.update(write("listen 8080\nuser nobody\n"))
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// Binary content is not diffed and the stored copy is dropped.
+	diff, err = differ.update(write("\x00\x01\x02"))
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+	diff, err = differ.update(write("listen 80\n"))
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// Deleting the file removes the stored copy.
+	diff, err = differ.update(&Event{Path: path})
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+	diff, err = differ.update(write("listen 81\n"))
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+}
+
+func TestContentDifferPaths(t *testing.T) {
+	differ := newContentDiffer(ContentDiffConfig{
+		Paths: []string{filepath.FromSlash("/etc/nginx")},
+	}, nil)
+
+	assert.True(t, differ.matches(filepath.FromSlash("/etc/nginx")))
+	assert.True(t, differ.matches(filepath.FromSlash("/etc/nginx/nginx.conf")))
+	assert.False(t, differ.matches(filepath.FromSlash("/etc/nginx2/nginx.conf")))
+	assert.False(t, differ.matches(filepath.FromSlash("/etc/passwd")))
+}
+
+func TestUnifiedDiffTruncated(t *testing.T) {
+	var previous, current strings.Builder
+	for i := 0; i < 100; i++ {
+		previous.WriteString("old line\n")
+		current.WriteString("new line\n")
+	}
+
+	diff, err := unifiedDiff("/tmp/file", []byte(previous.String()), []byte(current.String()), 100)
+	require.NoError(t, err)
+	assert.True(t, diff.truncated)
+	assert.LessOrEqual(t, len(diff.unified), 100)
+	assert.True(t, strings.HasSuffix(diff.unified, "\n"))
+}
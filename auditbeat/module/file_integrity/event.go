@@ -141,6 +141,7 @@ type Event struct {
 	rtt        time.Duration // Time taken to collect the info.
 	errors     []error       // Errors that occurred while collecting the info.
 	hashFailed bool          // Set when hashing the file failed.
+	diff       *contentDiff  // Content changes, when content_diff is enabled.
 }
 
 // Process contain information about a process.
@@ -383,6 +384,13 @@ func buildMetricbeatEvent(e *Event, existedBefore bool) mb.Event {
 		}
 	}
 
+	if e.diff != nil {
+		file["diff"] = mapstr.M{
+			"unified":   e.diff.unified,
+			"truncated": e.diff.truncated,
+		}
+	}
+
 	if e.Process != nil {
 		process := mapstr.M{
 			"pid":       e.Process.PID,
@@ -32,5 +32,5 @@ func init() {
 // AssetFileIntegrity returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/file_integrity.
 func AssetFileIntegrity() string {
-	return "eJzsW11v47oRfc+vmLfdLRrHVuzE9kOBFHU2RbNogCy6CxSFd0SNTDYSaZCUY99ff0HqI3Kus5HytUmu3mJKOjPDGc7Rmdj7cEWbKcQiobmQlhZa2M0egBU2oSmcioTgn7X1iAzTYmmFklP4yskQoCawnCAWlEQGFiRJo6UIwk2xXseGVEVZQr09KB6Y7gHsg8SUcjf2AADsZklTWGiVLf3nLbPeKbRWizCzZHr+jhuwGzhKYv+5Cmd2fgpnhBHpYn1HOEUYTEmLQsK5kNkaZmtimcUwIbdw5f84VTpFCx9n56efICWLEVrslQZvBeBMxZgldu7xp2B1RsWVuus3zi/UXKRLpa0pLgAktKJkCrS2JCOKqvXcWJygtSRr62IhlaY5hmpFUxj0g2F1aSvwc2EsqBhyexTBZwUJykWGCwJKKCVpvVcGUEbenOnVoLYCizExdGcscw8zJ2m1Wm4aRpYouaiWYr/tU5BZGlZ5bB7rJUcplYTCA2CYsCxBdxFirVJfskmxIZ9VsSePDHeF+heG/B/UAiUjh/Oy8c85Gt4w4ivaXCvdqHgNV9pO4QQcvPPT+Vyv2cJpEBJQ+lPvOktv9/Z8aIsDtGZJFgm5AGNRRqgjSESoUW/+uF0AJ7JYzc0wlBASZIYisApiIRekl1pIC6GQqAUZoBVJwNiSBk1MpUtRZEhpUJbXagCAqYj2/daC1ShNXixCSQMcVwSKsUxriv4K11wwDtcqSyJgHOWCIFWueWuMhHsCk9zFFSaZO+E1K185ASYLpYXlaeV8WTxU7pzZpKEqUFyrQNAUkyZXfSJd5q3Ee1fDdgSCKxSJb6v/5aTpfx+5tUszPThYCMuzsMdUekAJGivYgVWZVVmqUpXip96HConW6Ey4YgmjiIVDRjQM+uN+fHzExuNoEseTo+FwMmhZy8ZqsVzWCvbnhRwqlRDKFoX8jZNLasWWIAwglGZ90sM4MwyL3kwVG91R0pdkXXocx4CIb+FuAYDl6AsTOEYgrClSaO4y77Lq98kBo4RMNvHt3m1+k/RQ8eVTcOT7YIxn2JKXJJHHEMcdz/68/9fa4MOYoNb/a1jPygTC+EaSx7jd1cu9+CZkpK4NXMzcDQ5qV5+OhoNozKLJOO73w6A/pMm4359MxsTi8TA4pja1Yoj5UHtv7eQUjjc4GGWoKTKutoXNF2R8/99ttY17ClT4f2I254dS0uRwnarpVE2nal6nqilP/ON1TR2pUzadsumUzbMom5enu6cXJW+E3zpZ9p5lWXvmu/PpdyvNwGykkpvUF4rZpHcKsEPG4sFkFB4FOAmOJ+yQDSYYD/rj/nByTOwhAqxhSUgytlmXKyriMscHIavNKxPcpCIkoNa4KbWPe8VBWYkfpYGcGCqC2Al8653hijYm5xjDfbZCgqUm406XkO5xQwW+8S8CEcVC5v8WM1m4n+ujGmgmI9KS0HL44SVer9K0f/lRy14bMfzKO3Zz7funEvo7gnUfn77ZbkVUFHt5zvz7hDP7MIeXfGMEw2RuxG9NPd+ZH/e2Vlt+TDSlT+B8emDRCW2z1xVV4dIDgsqJoePwX83hz8LMZZaXZdqLUeHFrO2YsBrmKp0rq9q3IT5ezLoxYTcm7MaEr3RMeDF7ihFhgdKNB7vxYDce7MaD3XiwGw++2vFgK8bb+eR7kBT3jwVvfy/j2ed4TzGQu/lGzktO45bUjeK6UdwLjuIuZu9lDHc7krc/grsdUYvxW+2XLhU7/vSXLn8r8M7QcDJlE8xJqup6XkVUCqXG3JwKNnC3lKMZTsBpDSQdC0UQiQW5IOqsseunNGGCVxSE82B0tHd3hW85//fzk3/NgnA/GB1tcW6tf99GPxwP26IfjodN0UeDoC36aBDch55GowJBrUhfa2Fpa8B1r60v/xjdZ8NwHDzOyOXZyaCBlSBonIDLs5MguHfvHWZVMg/3vUENGY4tyufy7KRB5TjMebs98fc3w21+lHLcZnvQ5hB53Kb70OL4eNwGZ8dwvEF9eHU0ttQyl6NBcNAsmx67VT499v0ZXa/5UWOXv38/2uXs7wEAAP//hnz1Jw=="
+	return "eJzsW99v47gRfs9fMW+7VzSOrdhZ2w8F0tZ7WzSHDZBr74CicEbiyGRXIgWS8o/+9QVpSZZzzlrKryY5vSVU9PGbmY8znLFzCt9oM4VYJDQX0tJCC7s5AbDCJjSFzyIh+FttnZGJtMisUHIKP3MyBKgJLCeIBSXMwIIkabTEINwU63VsSBXLE+qdQPHC9ATgFCSmtKVxAgBgNxlNYaFVnvnf97b1pNBaLcLcknFQdbAdHCWxf1aZM7v6DF8IGeli/YA5hRmRkhaFhCsh8zXM1hTlFsOE3MI3/8NnpVO08HF29fkHSMkiQ4u9csM7BgAwijFP7NzjT8HqnIondeo78gs1F2mmtDXFA4CElpRMgdaWJCNWrW+9FSdoLcnaulhIpWmOoVrSFAb9YFg92jP8ShgLKobtfsTgRwUJykWOCwJKKCVpfYQMoGQ+OIXXDxgWY2LoXlvmbsHMSVqtsk1DyxIlF9VS7N0+BZmnIenWtt5wlFJJKBhAhEmUJ+gEDbFWqZdsUjjkR1X45JHmLlH/H03+J2qBMiKIlX5Z++ccDW9o8TfarJRmTawzXGk7hUtw8E64LmZ1zRakQUhA6U+9yyy9w+750BYHaB0lORNyAcaiZKgZJCLUqDe/dRfApSxWt9tEKCEkyA0xsApiIRekMy2khVBI1IIM0JIkYGxJg6ZIpZkoIqQ0KMtrGgCIFKNTf37AapRmKxahpAGOSwIVRbnWxP4IKy4iDiuVJwwijnJBkCqXvDUy4eAx2VJcYpK7vFrb5WdOgMlCaWF5WpEvxUOl58wmDVWB4lIFgqaYNDn1iTTbphLProbtCgguUSQ+rf6Lk6Z/f+TWZmZ6drYQludhL1LpGSVorIjOrMqtylOVqhR/6H2okGiNbgsnlpCxKBxGRMOgP+7Hny6i8ZhN4nhyMRxOBi21bKwWWUasoZBDpRJC2ULIv3ByQa2qJQgDCOW2PuhhnJsIi9xMVTW6R9I3ZJ22XI0BEd/B3QMAy9G6eAFHBsKaIoTmvu1dVL2fHDBKyGUTbkfdvJ8v30h5qOrlU9TI91ExnsElL1lEDif83mEvfWj07vfzf4UMD6wEtfxfw3rWSiCMTyRb/+xn9dIXvwjJ1MrA9cy5xXn4UJ5mwwEbR2wyjvv9MOgPaTLu9yeTMUXxeBh8ojZaMRQ5Aqb31k5OQbzBwShNTTHiar+x+Qkjfvq1bW/j3gIV/oci67VatTTuwenXrqvpupquq3mdXU154h/f19SRus6m62y6zuZZOpuGLn7Ccvd0N/A3Vt+e0QNvteS9o7asfeW79+1325qB2UglN6kXitmk9zZg51EUDyaj8CLASfBpEp1HgwnGg/64P5x8oqiNKsoGrKEkJBlLbRRxs8UHISvnlQFuoggJqDVuyt7HDW9RVs2P0kCuGSqMOAhcITtHk9O02dYYw30jHRJkmoxLuUI62Roq8I3/jIxRLOT2YzGTh6f+gO28BZBLRloSWg63vsXrlS7t/eG29+Ehsei98oxd8Ow9yLa3losfZaz7taGVLZLtnkWF2Mtz5u8TbtuHEc74xogIk7kR/6XHxMfd1uTiSawpOYHj9DCzlkLb/HVZVVB6gFHbwtDQjK6GP1sNf5bKXEY5o/1R4fWs7ZiwGuYq7duf+rchPl7PujFhNybsxoSvdEx4PWuRZI+hdOPBbjzYjQe78WA3HuzGg692PNiq4h188/cxFrz7vYy7rW7D2DWf4z3FQG73jZyXnMZl1I3iulHcC47irmfvZQx3PXtvI7jr2aPGb6U5TMTxd8YltQ3/VCwC/EVJ66rztgAYlxQRLK1tUbe+ymRTZb0VJwm3boZD0s7ddrcgdhmOpLsFs6JZyTQthcrdzCfblMnWgUIBACt0N2OlifW+O7nJpYjFbwLgSB525z+2fw+OIIRkV0Q+Xe8oOYZuwZdCaStGNZYN/L5jaHUu/a39SPtyrLHwjJ1bKkD3F3se76W49j94sd4eoVn7R6jq8nRXGweV8QUNJ1MPW29XFFHXG9jaxY5TcVlwn04VSM4uTmsg6S4pDJhYkLH7l4p63Eu+YYLfKAjnweji5P4EuEf+z1eXf58F4Wkwuti7ktXK+1308/GwLfr5eNgUfTQI2qKPBsEx9JSNCgS1JL3SwtLe/PPoXj/9dXRsD8Nx8LhNbr5cDhrsEgTDFohBcNT3DnN08WjuDTRkOLaQz82XywbKcZjzdj45nzfzynmbo+TYzhv6oM0h8rhN/dDi+HjcBmfHcNyhPlwdjXdqGcvRIDhrFk2P3SqeHvt4RNdrftGY8q+/Xhwi+78BAP0ToW8="
 }
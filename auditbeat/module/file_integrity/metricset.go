@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/auditbeat/ab"
 	"github.com/elastic/beats/v7/auditbeat/datastore"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -51,6 +52,7 @@ func init() {
 		mb.WithHostParser(parse.EmptyHostParser),
 		mb.WithNamespace(namespace),
 	)
+	seccomp.RegisterBase(seccomp.KindModule, moduleName)
 }
 
 // EventProducer produces events.
@@ -79,6 +81,7 @@ type MetricSet struct {
 
 	// Runtime params that are initialized on Run().
 	bucket    datastore.BoltBucket
+	differ    *contentDiffer
 	scanStart time.Time
 	scanChan  <-chan Event
 	eventChan <-chan Event
@@ -172,6 +175,11 @@ func (ms *MetricSet) Run(reporter mb.PushReporterV2) {
 
 // Close cleans up the MetricSet when it finishes.
 func (ms *MetricSet) Close() error {
+	if ms.differ != nil {
+		if err := ms.differ.bucket.Close(); err != nil {
+			ms.log.Errorw("Failed to close content datastore", "error", err)
+		}
+	}
 	if ms.bucket != nil {
 		return ms.bucket.Close()
 	}
@@ -188,6 +196,17 @@ func (ms *MetricSet) init(reporter mb.PushReporterV2) bool {
 	}
 	ms.bucket = bucket.(datastore.BoltBucket)
 
+	if ms.config.ContentDiff.Enabled {
+		contentBucket, err := datastore.OpenBucket(contentBucketName)
+		if err != nil {
+			err = fmt.Errorf("failed to open persistent content datastore: %w", err)
+			reporter.Error(err)
+			ms.log.Errorw("Failed to initialize", "error", err)
+			return false
+		}
+		ms.differ = newContentDiffer(ms.config.ContentDiff, contentBucket)
+	}
+
 	ms.eventChan, err = ms.reader.Start(reporter.Done())
 	if err != nil {
 		err = fmt.Errorf("failed to start event producer: %w", err)
@@ -259,6 +278,13 @@ func (ms *MetricSet) reportEvent(reporter mb.PushReporterV2, event *Event) bool
 	}
 
 	changed, lastEvent := ms.hasFileChangedSinceLastEvent(event)
+	if changed && ms.differ != nil {
+		diff, err := ms.differ.update(event)
+		if err != nil {
+			ms.log.Warnw("Failed to compute content diff", "file_path", event.Path, "error", err)
+		}
+		event.diff = diff
+	}
 	if changed {
 		// Publish event if it changed.
 		if ok := reporter.Event(buildMetricbeatEvent(event, lastEvent != nil)); !ok {
@@ -337,6 +363,11 @@ func (ms *MetricSet) purgeDeleted(reporter mb.PushReporterV2) {
 		}
 
 		for _, e := range deleted {
+			if ms.differ != nil {
+				if err := ms.differ.bucket.Delete(e.Path); err != nil {
+					ms.log.Errorw("Failed during content DB delete", "error", err)
+				}
+			}
 			// Don't persist!
 			if !ms.config.IsExcludedPath(e.Path) {
 				reporter.Event(buildMetricbeatEvent(e, true))
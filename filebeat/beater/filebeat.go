@@ -31,6 +31,7 @@ import (
 	"github.com/elastic/beats/v7/filebeat/fileset"
 	_ "github.com/elastic/beats/v7/filebeat/include"
 	"github.com/elastic/beats/v7/filebeat/input"
+	"github.com/elastic/beats/v7/filebeat/input/filestream"
 	"github.com/elastic/beats/v7/filebeat/input/filestream/takeover"
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/filebeat/input/v2/compat"
@@ -305,6 +306,15 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 	// Make sure all events that were published in
 	registrarChannel := newRegistrarLogger(registrar)
 
+	// Inputs setting output_profile publish to the dedicated queue and output
+	// of the profile instead of the global ones.
+	outputProfiles, err := newOutputProfiles(b.NewPipeline, config.OutputProfiles)
+	if err != nil {
+		logp.Err("Could not init output profiles: %v", err)
+		return err
+	}
+	defer outputProfiles.Close()
+
 	// setup event counting for startup and a global common ACKer, such that all events will be
 	// routed to the reigstrar after they've been ACKed.
 	// Events with Private==nil or the type of private != file.State are directly
@@ -312,7 +322,7 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 	// to the registrar via `registrarChannel`, which finally forwards the events to finishedLogger as well.
 	// The finishedLogger decrements the counters in wgEvents after all events have been securely processed
 	// by the registry.
-	fb.pipeline = withPipelineEventCounter(b.Publisher, wgEvents)
+	fb.pipeline = withPipelineEventCounter(pipetool.WithOutputProfiles(b.Publisher, outputProfiles.connectors()), wgEvents)
 	fb.pipeline = pipetool.WithACKer(fb.pipeline, eventACKer(finishedLogger, registrarChannel))
 
 	// Filebeat by default required infinite retry. Let's configure this for all
@@ -331,6 +341,8 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 		logp.Warn(pipelinesWarning)
 	}
 
+	filestream.SetGlobalReadRateLimit(int64(config.Filestream.ReadRateLimit))
+
 	inputsLogger := logp.NewLogger("input")
 	v2Inputs := fb.pluginFactory(b.Info, inputsLogger, stateStore)
 	v2InputLoader, err := v2.NewLoader(inputsLogger, v2Inputs, "type", cfg.DefaultType)
@@ -502,7 +514,7 @@ func newPipelineLoaderFactory(esConfig *conf.C) fileset.PipelineLoaderFactory {
 // some of the filestreams might want to take over the loginput state
 // if their `take_over` flag is set to `true`.
 func processLogInputTakeOver(stateStore StateStore, config *cfg.Config) error {
-	inputs, err := fetchInputConfiguration(config)
+	inputs, err := FetchInputConfiguration(config)
 	if err != nil {
 		return fmt.Errorf("Failed to fetch input configuration when attempting take over: %w", err)
 	}
@@ -525,8 +537,8 @@ func processLogInputTakeOver(stateStore StateStore, config *cfg.Config) error {
 	return takeover.TakeOverLogInputStates(logger, store, backuper, inputs)
 }
 
-// fetches all the defined input configuration available at Filebeat startup including external files.
-func fetchInputConfiguration(config *cfg.Config) (inputs []*conf.C, err error) {
+// FetchInputConfiguration fetches all the defined input configuration available at Filebeat startup including external files.
+func FetchInputConfiguration(config *cfg.Config) (inputs []*conf.C, err error) {
 	if len(config.Inputs) == 0 {
 		inputs = []*conf.C{}
 	} else {
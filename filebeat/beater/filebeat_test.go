@@ -145,7 +145,7 @@ output.console:
 			err = rawConfig.Unpack(&cfg)
 			require.NoError(t, err)
 
-			inputs, err := fetchInputConfiguration(&cfg.Filebeat)
+			inputs, err := FetchInputConfiguration(&cfg.Filebeat)
 			require.NoError(t, err)
 
 			actual := []inputEntry{}
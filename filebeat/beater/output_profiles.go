@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"errors"
+	"fmt"
+
+	cfg "github.com/elastic/beats/v7/filebeat/config"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// defaultOutputProfileQueue is the queue of the output profiles that don't
+// configure one. It is kept small and flushed early, so that the few events
+// of the inputs of a profile are not delayed.
+var defaultOutputProfileQueue = map[string]interface{}{
+	"mem": map[string]interface{}{
+		"events":           512,
+		"flush.min_events": 128,
+		"flush.timeout":    "1s",
+	},
+}
+
+// outputProfiles holds the pipelines of the output profiles, by name.
+type outputProfiles map[string]beat.ClosablePipeline
+
+// newOutputProfiles creates a pipeline, with its own queue and output, for
+// each output profile.
+func newOutputProfiles(newPipeline beat.PipelineFactory, profiles map[string]cfg.OutputProfile) (outputProfiles, error) {
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	if newPipeline == nil {
+		return nil, errors.New("output profiles are not supported by this beat")
+	}
+
+	pipelines := make(outputProfiles, len(profiles))
+	for name, profile := range profiles {
+		queue := profile.Queue
+		if !queue.IsSet() {
+			if err := queue.Unpack(conf.MustNewConfigFrom(defaultOutputProfileQueue)); err != nil {
+				pipelines.Close()
+				return nil, err
+			}
+		}
+
+		p, err := newPipeline(name, queue, profile.Output)
+		if err != nil {
+			pipelines.Close()
+			return nil, fmt.Errorf("failed to create output profile '%s': %w", name, err)
+		}
+		logp.Info("Output profile '%s' publishes to the %s output", name, profile.Output.Name())
+		pipelines[name] = p
+	}
+	return pipelines, nil
+}
+
+// connectors returns the pipelines as connectors, by name.
+func (p outputProfiles) connectors() map[string]beat.PipelineConnector {
+	connectors := make(map[string]beat.PipelineConnector, len(p))
+	for name, pipeline := range p {
+		connectors[name] = pipeline
+	}
+	return connectors
+}
+
+// Close closes the pipelines. It must be called once the inputs using them
+// are stopped.
+func (p outputProfiles) Close() {
+	for name, pipeline := range p {
+		if err := pipeline.Close(); err != nil {
+			logp.Err("Failed to close output profile '%s': %v", name, err)
+		}
+	}
+}
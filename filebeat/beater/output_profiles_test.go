@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/elastic/beats/v7/filebeat/config"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher/pipeline"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+type closablePipeline struct {
+	beat.Pipeline
+	closed bool
+}
+
+func (p *closablePipeline) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestNewOutputProfiles(t *testing.T) {
+	var config cfg.Config
+	err := conf.MustNewConfigFrom(`
+output_profiles:
+  audit:
+    output.console: {}
+  bulk:
+    queue.mem.events: 8192
+    output.file.path: /tmp
+`).Unpack(&config)
+	require.NoError(t, err)
+
+	queues := map[string]conf.Namespace{}
+	created := map[string]*closablePipeline{}
+	newPipeline := func(name string, queue, output conf.Namespace) (beat.ClosablePipeline, error) {
+		queues[name] = queue
+		created[name] = &closablePipeline{Pipeline: pipeline.NewNilPipeline()}
+		return created[name], nil
+	}
+
+	profiles, err := newOutputProfiles(newPipeline, config.OutputProfiles)
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	assert.Len(t, profiles.connectors(), 2)
+
+	assert.Equal(t, "mem", queues["audit"].Name(), "default queue")
+	events, err := queues["audit"].Config().Int("events", -1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 512, events)
+
+	assert.Equal(t, "mem", queues["bulk"].Name())
+	events, err = queues["bulk"].Config().Int("events", -1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8192, events)
+
+	profiles.Close()
+	assert.True(t, created["audit"].closed)
+	assert.True(t, created["bulk"].closed)
+}
+
+func TestNewOutputProfilesErrors(t *testing.T) {
+	var config cfg.Config
+	err := conf.MustNewConfigFrom(`output_profiles.audit.queue.mem.events: 64`).Unpack(&config)
+	assert.ErrorContains(t, err, "output must be set")
+
+	config = cfg.Config{OutputProfiles: map[string]cfg.OutputProfile{"audit": {}}}
+	_, err = newOutputProfiles(nil, config.OutputProfiles)
+	assert.Error(t, err, "beats without additional pipelines must reject profiles")
+
+	profiles, err := newOutputProfiles(nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, profiles)
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package channel
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultDataStreamType      = "logs"
+	defaultDataStreamDataset   = "generic"
+	defaultDataStreamNamespace = "default"
+
+	// Elasticsearch limits index names to 255 bytes. The dataset and
+	// namespace limits follow the data stream naming scheme.
+	maxDataStreamDatasetLen   = 100
+	maxDataStreamNamespaceLen = 100
+	maxDataStreamNameLen      = 255
+)
+
+// dataStreamTypes lists the data stream types that have built-in index
+// templates in Elasticsearch.
+var dataStreamTypes = []string{"logs", "metrics", "traces", "synthetics"}
+
+// dataStreamConfig configures the data stream events of an input are routed
+// to. The target data stream is named <type>-<dataset>-<namespace>.
+type dataStreamConfig struct {
+	Type      string `config:"type"`
+	Dataset   string `config:"dataset"`
+	Namespace string `config:"namespace"`
+}
+
+// withDefaults returns a copy of the configuration with all unset
+// settings filled in with their defaults.
+func (c dataStreamConfig) withDefaults() dataStreamConfig {
+	if c.Type == "" {
+		c.Type = defaultDataStreamType
+	}
+	if c.Dataset == "" {
+		c.Dataset = defaultDataStreamDataset
+	}
+	if c.Namespace == "" {
+		c.Namespace = defaultDataStreamNamespace
+	}
+	return c
+}
+
+// name returns the name of the data stream events are written to.
+func (c dataStreamConfig) name() string {
+	c = c.withDefaults()
+	return c.Type + "-" + c.Dataset + "-" + c.Namespace
+}
+
+// Validate checks that the configured values form a valid data stream name.
+func (c *dataStreamConfig) Validate() error {
+	cfg := c.withDefaults()
+
+	if !contains(dataStreamTypes, cfg.Type) {
+		return fmt.Errorf("invalid data_stream.type '%s', must be one of %s",
+			cfg.Type, strings.Join(dataStreamTypes, ", "))
+	}
+	if err := validateDataStreamPart("dataset", cfg.Dataset, maxDataStreamDatasetLen); err != nil {
+		return err
+	}
+	if err := validateDataStreamPart("namespace", cfg.Namespace, maxDataStreamNamespaceLen); err != nil {
+		return err
+	}
+	if name := cfg.name(); len(name) > maxDataStreamNameLen {
+		return fmt.Errorf("data stream name '%s' exceeds %d bytes", name, maxDataStreamNameLen)
+	}
+	return nil
+}
+
+func validateDataStreamPart(setting, value string, maxLen int) error {
+	if len(value) > maxLen {
+		return fmt.Errorf("data_stream.%s '%s' exceeds %d bytes", setting, value, maxLen)
+	}
+	if value != strings.ToLower(value) {
+		return fmt.Errorf("data_stream.%s '%s' must be lowercase", setting, value)
+	}
+	if strings.ContainsAny(value, `-\/*?"<>|,#: `) {
+		return fmt.Errorf("data_stream.%s '%s' contains invalid characters", setting, value)
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
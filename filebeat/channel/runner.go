@@ -18,7 +18,10 @@
 package channel
 
 import (
+	"errors"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/processors"
@@ -59,6 +62,20 @@ type commonInputConfig struct {
 	// Output meta data settings
 	Pipeline string                   `config:"pipeline"` // ES Ingest pipeline name
 	Index    fmtstr.EventFormatString `config:"index"`    // ES output index pattern
+
+	// Data stream routing settings
+	DataStream *dataStreamConfig `config:"data_stream"`
+
+	// Output profile publishing the events instead of the global output
+	OutputProfile string `config:"output_profile"`
+}
+
+// Validate checks that conflicting output settings are not configured together.
+func (c *commonInputConfig) Validate() error {
+	if c.DataStream != nil && !c.Index.IsEmpty() {
+		return errors.New("'index' and 'data_stream' can not be used together")
+	}
+	return nil
 }
 
 func (f *onCreateFactory) CheckConfig(cfg *conf.C) error {
@@ -83,6 +100,8 @@ func (f *onCreateFactory) Create(pipeline beat.PipelineConnector, cfg *conf.C) (
 //   - *_ fileset_name* (hidden setting):
 //   - *pipeline*: Configure the ES Ingest Node pipeline name to be used for events from this input
 //   - *index*: Configure the index name for events to be collected from this input
+//   - *data_stream*: Route events from this input to the <type>-<dataset>-<namespace> data stream
+//   - *output_profile*: Publish the events of this input to the queue and output of a named output profile
 //   - *type*: implicit event type
 //   - *service.type*: implicit event type
 func RunnerFactoryWithCommonInputSettings(info beat.Info, f cfgfile.RunnerFactory) cfgfile.RunnerFactory {
@@ -165,6 +184,16 @@ func newCommonConfigEditor(
 			}
 			fields["event"] = event
 		}
+		if config.DataStream != nil {
+			ds := config.DataStream.withDefaults()
+			setOptional(meta, events.FieldMetaRawIndex, ds.name())
+			fields["data_stream"] = mapstr.M{
+				"type":      ds.Type,
+				"dataset":   ds.Dataset,
+				"namespace": ds.Namespace,
+			}
+			_, _ = fields.Put("event.dataset", ds.Dataset)
+		}
 
 		// assemble the processors. Ordering is important.
 		// 1. add support for index configuration via processor
@@ -187,6 +216,9 @@ func newCommonConfigEditor(
 		clientCfg.Processing.Processor = procs
 		clientCfg.Processing.KeepNull = config.KeepNull
 		clientCfg.Processing.DisableHost = config.PublisherPipeline.DisableHost
+		if config.OutputProfile != "" {
+			clientCfg.OutputProfile = config.OutputProfile
+		}
 
 		return clientCfg, nil
 	}, nil
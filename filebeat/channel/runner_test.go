@@ -249,3 +249,111 @@ index: "%{[fields.log_type]}-%{[agent.version]}-%{+yyyy.MM.dd}"
 
 	rf.Assert(t)
 }
+
+func TestCommonConfigEditorDataStream(t *testing.T) {
+	testCases := map[string]struct {
+		configStr      string
+		expectedIndex  string
+		expectedFields mapstr.M
+	}{
+		"defaults": {
+			configStr:     "data_stream: {}",
+			expectedIndex: "logs-generic-default",
+			expectedFields: mapstr.M{
+				"data_stream": mapstr.M{
+					"type":      "logs",
+					"dataset":   "generic",
+					"namespace": "default",
+				},
+				"event": mapstr.M{"dataset": "generic"},
+			},
+		},
+		"dataset and namespace": {
+			configStr:     "data_stream: {dataset: nginx.access, namespace: prod}",
+			expectedIndex: "logs-nginx.access-prod",
+			expectedFields: mapstr.M{
+				"data_stream": mapstr.M{
+					"type":      "logs",
+					"dataset":   "nginx.access",
+					"namespace": "prod",
+				},
+				"event": mapstr.M{"dataset": "nginx.access"},
+			},
+		},
+		"overrides module dataset": {
+			configStr:     "{_module_name: nginx, _fileset_name: access, data_stream.dataset: web}",
+			expectedIndex: "logs-web-default",
+			expectedFields: mapstr.M{
+				"data_stream": mapstr.M{
+					"type":      "logs",
+					"dataset":   "web",
+					"namespace": "default",
+				},
+				"event": mapstr.M{
+					"module":  "nginx",
+					"dataset": "web",
+				},
+				"fileset": mapstr.M{"name": "access"},
+				"service": mapstr.M{"type": "nginx"},
+			},
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := conf.NewConfigFrom(test.configStr)
+			require.NoError(t, err)
+
+			editor, err := newCommonConfigEditor(beat.Info{}, cfg)
+			require.NoError(t, err)
+
+			clientCfg, err := editor(beat.ClientConfig{})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedIndex, clientCfg.Processing.Meta[events.FieldMetaRawIndex])
+			assert.Equal(t, test.expectedFields, clientCfg.Processing.Fields)
+		})
+	}
+}
+
+func TestCommonConfigEditorDataStreamErrors(t *testing.T) {
+	testCases := map[string]string{
+		"index and data_stream":   "{index: test, data_stream.dataset: nginx}",
+		"unknown type":            "data_stream.type: events",
+		"uppercase dataset":       "data_stream.dataset: Nginx",
+		"dash in dataset":         "data_stream.dataset: nginx-access",
+		"invalid namespace chars": "data_stream.namespace: 'prod:eu'",
+	}
+
+	for name, configStr := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := conf.NewConfigFrom(configStr)
+			require.NoError(t, err)
+
+			_, err = newCommonConfigEditor(beat.Info{}, cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCommonConfigEditorOutputProfile(t *testing.T) {
+	cfg, err := conf.NewConfigFrom("output_profile: audit")
+	require.NoError(t, err)
+
+	editor, err := newCommonConfigEditor(beat.Info{}, cfg)
+	require.NoError(t, err)
+
+	clientCfg, err := editor(beat.ClientConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "audit", clientCfg.OutputProfile)
+
+	cfg, err = conf.NewConfigFrom("type: log")
+	require.NoError(t, err)
+
+	editor, err = newCommonConfigEditor(beat.Info{}, cfg)
+	require.NoError(t, err)
+
+	clientCfg, err = editor(beat.ClientConfig{OutputProfile: "audit"})
+	require.NoError(t, err)
+	assert.Equal(t, "audit", clientCfg.OutputProfile, "profile set by the input must be kept")
+}
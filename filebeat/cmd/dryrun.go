@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// dryRunPrinter prints the details of an input dry run.
+type dryRunPrinter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newDryRunPrinter(w io.Writer) *dryRunPrinter {
+	return &dryRunPrinter{w: w}
+}
+
+func (p *dryRunPrinter) State(name string, state interface{}) {
+	p.section(name, state)
+}
+
+func (p *dryRunPrinter) Event(event beat.Event, private interface{}) {
+	fields := event.Fields.Clone()
+	fields["@timestamp"] = event.Timestamp
+	if len(event.Meta) != 0 {
+		fields["@metadata"] = event.Meta
+	}
+	p.section("event", fields)
+	if private != nil {
+		p.section("cursor", private)
+	}
+}
+
+func (p *dryRunPrinter) section(name string, v interface{}) {
+	var b []byte
+	if s, ok := v.(fmt.Stringer); ok {
+		b = []byte(s.String())
+	} else {
+		var err error
+		b, err = json.Marshal(v)
+		if err != nil {
+			b = []byte(fmt.Sprintf("%+v", v))
+		}
+	}
+	var buf bytes.Buffer
+	if json.Indent(&buf, b, "", "  ") == nil {
+		b = buf.Bytes()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprintf(p.w, "--- %s ---\n%s\n", name, b)
+}
+
+func (p *dryRunPrinter) printf(format string, args ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprintf(p.w, format, args...)
+}
+
+// transport returns a transport printing the requests sent through rt.
+// Request headers are not printed as they may hold credentials.
+func (p *dryRunPrinter) transport(rt http.RoundTripper) http.RoundTripper {
+	return requestPrinter{printer: p, transport: rt}
+}
+
+type requestPrinter struct {
+	printer   *dryRunPrinter
+	transport http.RoundTripper
+}
+
+func (t requestPrinter) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.printer.printf("--> %s %s\n", req.Method, req.URL.Redacted())
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		t.printer.printf("<-- %v\n", err)
+		return nil, err
+	}
+	t.printer.printf("<-- %s\n", resp.Status)
+	return resp, nil
+}
+
+// fixtureTransport replays recorded HTTP responses in order, regardless of
+// the requests.
+type fixtureTransport struct {
+	mu        sync.Mutex
+	responses []fixtureResponse
+}
+
+// fixtureResponse is a recorded HTTP response. A body holding a JSON string
+// is replayed as the content of the string, any other JSON value is replayed
+// as is.
+type fixtureResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header"`
+	Body       json.RawMessage `json:"body"`
+}
+
+func readFixture(path string) (*fixtureTransport, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var responses []fixtureResponse
+	if err := json.Unmarshal(b, &responses); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("fixture %s has no responses", path)
+	}
+	return &fixtureTransport{responses: responses}, nil
+}
+
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	t.mu.Lock()
+	if len(t.responses) == 0 {
+		t.mu.Unlock()
+		return nil, errors.New("no recorded response left in fixture")
+	}
+	r := t.responses[0]
+	t.responses = t.responses[1:]
+	t.mu.Unlock()
+
+	body := []byte(r.Body)
+	var s string
+	if json.Unmarshal(r.Body, &s) == nil {
+		body = []byte(s)
+	}
+	code := r.StatusCode
+	if code == 0 {
+		code = http.StatusOK
+	}
+	header := r.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
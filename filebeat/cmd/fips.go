@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/filebeat/beater"
+	cfg "github.com/elastic/beats/v7/filebeat/config"
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// fipsInputs returns the function reporting the FIPS capability of the
+// enabled inputs of filebeat.inputs and of their processors. The inputs are
+// configured without being started, with their state kept in memory, so that
+// inputs implementing v2.FIPSAwareInput can report their capability.
+func fipsInputs(plugins beater.PluginFactory) func(beat.Info, *conf.C) ([]fips.Component, error) {
+	return func(info beat.Info, beatConfig *conf.C) ([]fips.Component, error) {
+		config := cfg.DefaultConfig
+		if err := beatConfig.Unpack(&config); err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+		if err := config.FetchConfigs(); err != nil {
+			return nil, err
+		}
+
+		log := logp.NewLogger("fips")
+		loader, err := v2.NewLoader(log, plugins(info, log, newMemoryStateStore()), "type", cfg.DefaultType)
+		if err != nil {
+			return nil, err
+		}
+
+		var components []fips.Component
+		for _, inputConfig := range config.Inputs {
+			input := struct {
+				Type       string    `config:"type"`
+				ID         string    `config:"id"`
+				Enabled    bool      `config:"enabled"`
+				Processors []*conf.C `config:"processors"`
+			}{Type: cfg.DefaultType, Enabled: true}
+			if err := inputConfig.Unpack(&input); err != nil {
+				return nil, err
+			}
+			if !input.Enabled {
+				continue
+			}
+
+			c := fips.Check(fips.KindInput, input.Type, input.ID, inputConfig)
+			if c.Status != fips.StatusIncapable {
+				inp, err := loader.Configure(inputConfig)
+				if err != nil && !v2.IsUnknownInputError(err) {
+					return nil, fmt.Errorf("failed to configure %s input %s: %w", input.Type, input.ID, err)
+				}
+				if aware, ok := inp.(v2.FIPSAwareInput); ok {
+					if reported := fips.CheckAware(fips.KindInput, input.Type, input.ID, aware); reported.Status != fips.StatusUnknown {
+						c = reported
+					}
+				}
+			}
+			components = append(components, c)
+
+			processors, err := fips.CheckProcessors(input.ID, input.Processors)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, processors...)
+		}
+		return components, nil
+	}
+}
@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/elastic/beats/v7/filebeat/backup"
+	"github.com/elastic/beats/v7/filebeat/beater"
+	cfg "github.com/elastic/beats/v7/filebeat/config"
+	"github.com/elastic/beats/v7/filebeat/input/filestream/takeover"
+	"github.com/elastic/beats/v7/filebeat/registrar"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance/locks"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend/memlog"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+type migrateRegistryOptions struct {
+	dryRun bool
+	output string
+}
+
+func genMigrateRegistryCmd(settings instance.Settings) *cobra.Command {
+	var opts migrateRegistryOptions
+	cmd := &cobra.Command{
+		Use:   "migrate-registry",
+		Short: "Migrate the log inputs and their registry states to filestream",
+		Long: `Migrate the log inputs and their registry states to filestream.
+
+The registry states of the files read by the log inputs are converted to
+filestream states, so the filestream inputs continue to read the files where
+the log inputs stopped. The registry is backed up before it is modified.
+Filebeat must not be running.
+
+The configuration of the inputs, with the log inputs converted to filestream
+inputs, is written to the --output file or printed. It must replace the input
+configuration before Filebeat is started again. Log inputs without an ID are
+given one derived from their paths, that must be kept.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := migrateRegistry(settings, opts, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error migrating the registry: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the migration without modifying the registry")
+	cmd.Flags().StringVar(&opts.output, "output", "", "File the migrated input configuration is written to")
+	return cmd
+}
+
+func migrateRegistry(settings instance.Settings, opts migrateRegistryOptions, w io.Writer) error {
+	b, err := instance.NewInitializedBeat(settings)
+	if err != nil {
+		return fmt.Errorf("error initializing beat: %w", err)
+	}
+
+	rawConfig, err := b.BeatConfig()
+	if err != nil {
+		return err
+	}
+	config := cfg.DefaultConfig
+	if err := rawConfig.Unpack(&config); err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+	if err := config.FetchConfigs(); err != nil {
+		return err
+	}
+	inputs, err := beater.FetchInputConfiguration(&config)
+	if err != nil {
+		return err
+	}
+
+	// The registry must not be written by a running Filebeat.
+	lock := locks.New(b.Info)
+	if err := lock.Lock(); err != nil {
+		return err
+	}
+	defer func() {
+		_ = lock.Unlock()
+	}()
+
+	if !opts.dryRun {
+		if err := registrar.NewMigrator(config.Registry).Run(); err != nil {
+			return fmt.Errorf("failed to migrate registry file: %w", err)
+		}
+	}
+
+	log := logp.NewLogger("migrate-registry")
+	registryRoot := paths.Resolve(paths.Data, config.Registry.Path)
+	backend, err := memlog.New(log, memlog.Settings{
+		Root:     registryRoot,
+		FileMode: config.Registry.Permissions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open the registry: %w", err)
+	}
+	registry := statestore.NewRegistry(backend)
+	defer registry.Close()
+	store, err := registry.Get(b.Info.Beat)
+	if err != nil {
+		return fmt.Errorf("failed to access the registry: %w", err)
+	}
+	defer store.Close()
+
+	migration, err := takeover.PlanMigration(log, store, inputs)
+	if err != nil {
+		return err
+	}
+	if len(migration.Migrated) == 0 {
+		fmt.Fprintln(w, "no log input to migrate")
+		return nil
+	}
+
+	for _, input := range migration.Migrated {
+		fmt.Fprintf(w, "log input %s: %d state(s) to migrate\n", input.ID, input.States)
+		for _, warning := range input.Warnings {
+			fmt.Fprintf(w, "  warning: %s\n", warning)
+		}
+	}
+
+	out, err := yaml.Marshal(mapstr.M{"filebeat.inputs": migration.Inputs})
+	if err != nil {
+		return fmt.Errorf("failed to encode the input configuration: %w", err)
+	}
+	if opts.output != "" && !opts.dryRun {
+		if err := os.WriteFile(opts.output, out, 0o600); err != nil {
+			return fmt.Errorf("failed to write the input configuration: %w", err)
+		}
+		fmt.Fprintf(w, "input configuration written to %s\n", opts.output)
+	} else {
+		fmt.Fprintf(w, "--- input configuration ---\n%s", out)
+	}
+
+	if opts.dryRun {
+		return nil
+	}
+	backuper := backup.NewRegistryBackuper(log, filepath.Join(registryRoot, b.Info.Beat))
+	if err := migration.Apply(store, backuper); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%d state(s) migrated\n", migration.States())
+	return nil
+}
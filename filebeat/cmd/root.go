@@ -59,11 +59,14 @@ func FilebeatSettings() instance.Settings {
 
 // Filebeat build the beat root command for executing filebeat and it's subcommands.
 func Filebeat(inputs beater.PluginFactory, settings instance.Settings) *cmd.BeatsRootCmd {
+	settings.FIPSInputs = fipsInputs(inputs)
 	command := cmd.GenRootCmdWithSettings(beater.New(inputs), settings)
 	command.PersistentFlags().AddGoFlag(flag.CommandLine.Lookup("M"))
 	command.TestCmd.Flags().AddGoFlag(flag.CommandLine.Lookup("modules"))
+	command.TestCmd.AddCommand(genTestInputCmd(settings, inputs))
 	command.SetupCmd.Flags().AddGoFlag(flag.CommandLine.Lookup("modules"))
 	command.AddCommand(cmd.GenModulesCmd(Name, "", buildModulesManager))
 	command.AddCommand(genGenerateCmd())
+	command.AddCommand(genMigrateRegistryCmd(settings))
 	return command
 }
@@ -0,0 +1,182 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/filebeat/beater"
+	cfg "github.com/elastic/beats/v7/filebeat/config"
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/storetest"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type testInputOptions struct {
+	id      string
+	dryRun  bool
+	fixture string
+}
+
+func genTestInputCmd(settings instance.Settings, plugins beater.PluginFactory) *cobra.Command {
+	var opts testInputOptions
+	cmd := &cobra.Command{
+		Use:   "input",
+		Short: "Test the input with the given ID by using the current settings",
+		Long: `Test the input with the given ID by using the current settings.
+
+With --dry-run, a single iteration of the input is executed, printing the
+input state, the HTTP requests and the resulting events without publishing
+them. The input state is not persisted. Dry runs are supported by the cel and
+httpjson inputs.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := testInput(settings, plugins, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error testing input %s: %v\n", opts.id, err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&opts.id, "id", "", "ID of the input to test")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Execute a single iteration of the input without publishing events")
+	cmd.Flags().StringVar(&opts.fixture, "fixture", "", "File of recorded HTTP responses replayed by the dry run instead of sending requests")
+	_ = cmd.MarkFlagRequired("id")
+	return cmd
+}
+
+func testInput(settings instance.Settings, plugins beater.PluginFactory, opts testInputOptions) error {
+	if opts.fixture != "" && !opts.dryRun {
+		return errors.New("--fixture requires --dry-run")
+	}
+
+	b, err := instance.NewInitializedBeat(settings)
+	if err != nil {
+		return fmt.Errorf("error initializing beat: %w", err)
+	}
+
+	rawConfig, err := b.BeatConfig()
+	if err != nil {
+		return err
+	}
+	config := cfg.DefaultConfig
+	if err := rawConfig.Unpack(&config); err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+	if err := config.FetchConfigs(); err != nil {
+		return err
+	}
+
+	inputConfig, err := findInput(config.Inputs, opts.id)
+	if err != nil {
+		return err
+	}
+
+	// The input state is kept in memory, so that testing does not touch the
+	// registry of a running Filebeat.
+	log := logp.NewLogger("input").With("id", opts.id)
+	loader, err := v2.NewLoader(log, plugins(b.Info, log, newMemoryStateStore()), "type", cfg.DefaultType)
+	if err != nil {
+		return err
+	}
+	inp, err := loader.Configure(inputConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if !opts.dryRun {
+		err := inp.Test(v2.TestContext{Logger: log, Agent: b.Info, Cancelation: ctx})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("input %s: OK\n", opts.id)
+		return nil
+	}
+
+	dr, ok := inp.(v2.DryRunner)
+	if !ok {
+		return v2.ErrDryRunUnsupported
+	}
+
+	var replay *fixtureTransport
+	if opts.fixture != "" {
+		replay, err = readFixture(opts.fixture)
+		if err != nil {
+			return err
+		}
+	}
+	reporter := newDryRunPrinter(os.Stdout)
+	return dr.DryRun(v2.DryRunContext{
+		Context: v2.Context{
+			Logger:      log,
+			ID:          opts.id,
+			Agent:       b.Info,
+			Cancelation: ctx,
+		},
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			if replay != nil {
+				rt = replay
+			}
+			return reporter.transport(rt)
+		},
+		Reporter: reporter,
+	})
+}
+
+// findInput returns the configuration of the input with the given ID.
+func findInput(inputs []*conf.C, id string) (*conf.C, error) {
+	for _, c := range inputs {
+		var input struct {
+			ID string `config:"id"`
+		}
+		if err := c.Unpack(&input); err != nil {
+			return nil, err
+		}
+		if input.ID == id {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no input with ID %q found in filebeat.inputs", id)
+}
+
+// memoryStateStore is an input state store that is not persisted.
+type memoryStateStore struct {
+	registry *statestore.Registry
+}
+
+func newMemoryStateStore() memoryStateStore {
+	return memoryStateStore{registry: statestore.NewRegistry(storetest.NewMemoryStoreBackend())}
+}
+
+func (s memoryStateStore) Access() (*statestore.Store, error) {
+	return s.registry.Get("filebeat")
+}
+
+func (memoryStateStore) CleanupInterval() time.Duration { return 0 }
@@ -18,6 +18,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/autodiscover"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
 	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -39,15 +41,40 @@ const (
 )
 
 type Config struct {
-	Inputs             []*conf.C            `config:"inputs"`
-	Registry           Registry             `config:"registry"`
-	ConfigDir          string               `config:"config_dir"`
-	ShutdownTimeout    time.Duration        `config:"shutdown_timeout"`
-	Modules            []*conf.C            `config:"modules"`
-	ConfigInput        *conf.C              `config:"config.inputs"`
-	ConfigModules      *conf.C              `config:"config.modules"`
-	Autodiscover       *autodiscover.Config `config:"autodiscover"`
-	OverwritePipelines bool                 `config:"overwrite_pipelines"`
+	Inputs             []*conf.C                `config:"inputs"`
+	Registry           Registry                 `config:"registry"`
+	ConfigDir          string                   `config:"config_dir"`
+	ShutdownTimeout    time.Duration            `config:"shutdown_timeout"`
+	Modules            []*conf.C                `config:"modules"`
+	ConfigInput        *conf.C                  `config:"config.inputs"`
+	ConfigModules      *conf.C                  `config:"config.modules"`
+	Autodiscover       *autodiscover.Config     `config:"autodiscover"`
+	OverwritePipelines bool                     `config:"overwrite_pipelines"`
+	Filestream         Filestream               `config:"filestream"`
+	OutputProfiles     map[string]OutputProfile `config:"output_profiles"`
+}
+
+// OutputProfile is a named queue and output that inputs can publish to
+// instead of the global ones, with the output_profile input setting.
+type OutputProfile struct {
+	// Queue is the queue of the profile. A small memory queue is used when
+	// it is not set.
+	Queue conf.Namespace `config:"queue"`
+	// Output is the output of the profile.
+	Output conf.Namespace `config:"output"`
+}
+
+func (p *OutputProfile) Validate() error {
+	if !p.Output.IsSet() {
+		return errors.New("output must be set")
+	}
+	return nil
+}
+
+// Filestream holds the settings shared by all filestream inputs.
+type Filestream struct {
+	// ReadRateLimit caps the bytes per second read by all filestream inputs combined.
+	ReadRateLimit cfgtype.ByteSize `config:"read_rate_limit" validate:"min=0"`
 }
 
 type Registry struct {
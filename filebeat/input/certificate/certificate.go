@@ -0,0 +1,345 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"context"
+	"crypto/dsa" //nolint:staticcheck // DSA keys are reported as weak
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// Status of the validity period of a certificate chain.
+const (
+	statusValid       = "valid"
+	statusExpiring    = "expiring"
+	statusExpired     = "expired"
+	statusNotYetValid = "not_yet_valid"
+	statusUnavailable = "unavailable"
+)
+
+// Weaknesses of the posture of a certificate chain or TLS connection.
+const (
+	weakKey        = "weak_key"
+	weakSignature  = "weak_signature"
+	legacyTLS      = "legacy_tls_version"
+	insecureCipher = "insecure_cipher"
+)
+
+// observation is the state of the certificate of a source. It is stored in
+// the registry so that only changes are reported, across restarts too.
+type observation struct {
+	// SHA256 is the fingerprint of the leaf certificate.
+	SHA256 string `json:"sha256,omitempty"`
+	// Chain are the fingerprints of the other certificates of the chain.
+	Chain []string `json:"chain,omitempty"`
+	// NotAfter is the earliest expiry of the certificates of the chain.
+	NotAfter time.Time `json:"not_after"`
+	Status   string    `json:"status"`
+	Trusted  bool      `json:"trusted"`
+	// Weaknesses are the weaknesses of the posture, sorted.
+	Weaknesses []string `json:"weaknesses,omitempty"`
+	TLSVersion string   `json:"tls_version,omitempty"`
+	Cipher     string   `json:"cipher,omitempty"`
+	// Error is why the certificate could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// changes returns the aspects of the observation that differ from prev.
+func (o observation) changes(prev observation) []string {
+	var changes []string
+	if o.SHA256 != prev.SHA256 {
+		changes = append(changes, "certificate")
+	}
+	if !equal(o.Chain, prev.Chain) {
+		changes = append(changes, "chain")
+	}
+	if o.Status != prev.Status {
+		changes = append(changes, "status")
+	}
+	if o.Trusted != prev.Trusted {
+		changes = append(changes, "trust")
+	}
+	if !equal(o.Weaknesses, prev.Weaknesses) || o.TLSVersion != prev.TLSVersion || o.Cipher != prev.Cipher {
+		changes = append(changes, "posture")
+	}
+	if o.Error != prev.Error {
+		changes = append(changes, "error")
+	}
+	return changes
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// inspection is the certificate chain read from a source.
+type inspection struct {
+	// chain starts with the leaf certificate.
+	chain []*x509.Certificate
+	// conn is the state of the TLS connection, for hosts only.
+	conn *tls.ConnectionState
+	// verifyErr is why the chain is not trusted.
+	verifyErr error
+	// err is why the chain could not be read.
+	err error
+}
+
+// inspector reads and assesses certificate chains.
+type inspector struct {
+	config config
+	roots  *x509.CertPool
+	tls    *tlscommon.TLSConfig
+	now    func() time.Time
+}
+
+func newInspector(config config) (*inspector, error) {
+	tlsConfig, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	in := &inspector{config: config, tls: tlsConfig, now: time.Now}
+	if tlsConfig != nil {
+		in.roots = tlsConfig.RootCAs
+	}
+	return in, nil
+}
+
+// readFile reads the certificate chain of a PEM or DER encoded file.
+func (in *inspector) readFile(path string) inspection {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return inspection{err: err}
+	}
+	chain, err := parseCertificates(data)
+	if err != nil {
+		return inspection{err: err}
+	}
+	return in.verify(inspection{chain: chain}, "")
+}
+
+// parseCertificates parses the certificates of PEM data, other PEM blocks
+// like private keys are ignored. Data without PEM blocks is parsed as DER.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	sawPEM := false
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		sawPEM = true
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate %d: %w", len(chain), err)
+		}
+		chain = append(chain, cert)
+	}
+	if !sawPEM {
+		return x509.ParseCertificates(data)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	return chain, nil
+}
+
+// dial reads the certificate chain presented by host in a TLS handshake.
+func (in *inspector) dial(ctx context.Context, host string) inspection {
+	addr := host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		addr = net.JoinHostPort(host, "443")
+	}
+	serverName, _, _ := net.SplitHostPort(addr)
+
+	tlsConfig := &tls.Config{} //nolint:gosec // the minimum version is reported, not enforced
+	if in.tls != nil {
+		tlsConfig = in.tls.BuildModuleClientConfig(serverName)
+	}
+	// The chain is verified after the handshake, so that untrusted and
+	// expired certificates are reported as well.
+	tlsConfig.InsecureSkipVerify = true //nolint:gosec // verified by verify
+	tlsConfig.VerifyConnection = nil
+	tlsConfig.VerifyPeerCertificate = nil
+	tlsConfig.ServerName = serverName
+	tlsConfig.MinVersion = tls.VersionTLS10
+
+	ctx, cancel := context.WithTimeout(ctx, in.config.Timeout)
+	defer cancel()
+	dialer := &tls.Dialer{Config: tlsConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return inspection{err: err}
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState() //nolint:errcheck // tls.Dialer returns *tls.Conn
+	if len(state.PeerCertificates) == 0 {
+		return inspection{conn: &state, err: errors.New("no certificate presented")}
+	}
+	return in.verify(inspection{chain: state.PeerCertificates, conn: &state}, serverName)
+}
+
+// verify verifies the chain of ins, with serverName for hosts.
+func (in *inspector) verify(ins inspection, serverName string) inspection {
+	intermediates := x509.NewCertPool()
+	for _, cert := range ins.chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, ins.verifyErr = ins.chain[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         in.roots,
+		Intermediates: intermediates,
+		CurrentTime:   in.now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return ins
+}
+
+// observe returns the observation of an inspection.
+func (in *inspector) observe(ins inspection) observation {
+	if ins.err != nil {
+		return observation{Status: statusUnavailable, Error: ins.err.Error()}
+	}
+
+	obs := observation{
+		SHA256:  fingerprint(ins.chain[0]),
+		Trusted: ins.verifyErr == nil,
+	}
+	for _, cert := range ins.chain[1:] {
+		obs.Chain = append(obs.Chain, fingerprint(cert))
+	}
+	obs.Status, obs.NotAfter = in.validity(ins.chain)
+	if ins.conn != nil {
+		obs.TLSVersion = tlscommon.TLSVersion(ins.conn.Version).String()
+		obs.Cipher = tlscommon.ResolveCipherSuite(ins.conn.CipherSuite)
+	}
+	obs.Weaknesses = in.weaknesses(ins)
+	return obs
+}
+
+// validity returns the status of the validity period of the chain, and the
+// earliest expiry of its certificates.
+func (in *inspector) validity(chain []*x509.Certificate) (string, time.Time) {
+	notBefore, notAfter := chain[0].NotBefore, chain[0].NotAfter
+	for _, cert := range chain[1:] {
+		if cert.NotBefore.After(notBefore) {
+			notBefore = cert.NotBefore
+		}
+		if cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+
+	now := in.now()
+	switch {
+	case now.After(notAfter):
+		return statusExpired, notAfter
+	case now.Before(notBefore):
+		return statusNotYetValid, notAfter
+	case notAfter.Sub(now) < in.config.ExpiryWarning:
+		return statusExpiring, notAfter
+	default:
+		return statusValid, notAfter
+	}
+}
+
+// weaknesses returns the weaknesses of the chain and the connection, sorted.
+func (in *inspector) weaknesses(ins inspection) []string {
+	found := map[string]bool{}
+	for _, cert := range ins.chain {
+		if in.weakKey(cert) {
+			found[weakKey] = true
+		}
+		// The signatures of self-signed roots are not checked by clients.
+		if isSelfSigned(cert) && len(ins.chain) > 1 {
+			continue
+		}
+		switch cert.SignatureAlgorithm {
+		case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+			found[weakSignature] = true
+		}
+	}
+	if ins.conn != nil {
+		if ins.conn.Version < tls.VersionTLS12 {
+			found[legacyTLS] = true
+		}
+		for _, suite := range tls.InsecureCipherSuites() {
+			if suite.ID == ins.conn.CipherSuite {
+				found[insecureCipher] = true
+			}
+		}
+	}
+
+	weaknesses := make([]string, 0, len(found))
+	for w := range found {
+		weaknesses = append(weaknesses, w)
+	}
+	sort.Strings(weaknesses)
+	if len(weaknesses) == 0 {
+		return nil
+	}
+	return weaknesses
+}
+
+func (in *inspector) weakKey(cert *x509.Certificate) bool {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen() < in.config.MinRSAKeySize
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize < 256
+	case *dsa.PublicKey:
+		return true
+	default:
+		return false
+	}
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(cert) == nil
+}
+
+func fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
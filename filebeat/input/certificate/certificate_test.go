@@ -0,0 +1,243 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var testNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// newTestCert creates a certificate signed by parent, or self-signed if
+// parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert, key crypto.Signer, notAfter time.Time) *testCert {
+	t.Helper()
+	if key == nil {
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             testNow.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		DNSNames:              []string{name},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	signer, signerCert := key, template
+	if parent != nil {
+		signer, signerCert = parent.key, parent.cert
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, key.Public(), signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func writePEM(t *testing.T, certs ...*testCert) string {
+	t.Helper()
+	buf := pemOf(certs...)
+	buf = append(buf, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("ignored")})...)
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(path, buf, 0o600))
+	return path
+}
+
+func newTestInspector(t *testing.T, roots ...*testCert) *inspector {
+	t.Helper()
+	in, err := newInspector(defaultConfig())
+	require.NoError(t, err)
+	in.now = func() time.Time { return testNow }
+	in.roots = x509.NewCertPool()
+	for _, r := range roots {
+		in.roots.AddCert(r.cert)
+	}
+	return in
+}
+
+func TestParseCertificates(t *testing.T) {
+	ca := newTestCert(t, "ca", nil, nil, testNow.Add(365*24*time.Hour))
+	leaf := newTestCert(t, "leaf", ca, nil, testNow.Add(90*24*time.Hour))
+
+	data, err := os.ReadFile(writePEM(t, leaf, ca))
+	require.NoError(t, err)
+	chain, err := parseCertificates(data)
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	assert.Equal(t, "leaf", chain[0].Subject.CommonName)
+
+	chain, err = parseCertificates(leaf.cert.Raw)
+	require.NoError(t, err)
+	require.Len(t, chain, 1)
+
+	_, err = parseCertificates(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))
+	assert.ErrorContains(t, err, "no certificate found")
+}
+
+func TestObserveFile(t *testing.T) {
+	ca := newTestCert(t, "ca", nil, nil, testNow.Add(20*24*time.Hour))
+	leaf := newTestCert(t, "leaf", ca, nil, testNow.Add(90*24*time.Hour))
+	in := newTestInspector(t, ca)
+
+	obs := in.observe(in.readFile(writePEM(t, leaf, ca)))
+	assert.Equal(t, observation{
+		SHA256:   fingerprint(leaf.cert),
+		Chain:    []string{fingerprint(ca.cert)},
+		NotAfter: ca.cert.NotAfter,
+		// The CA expires within the expiry warning.
+		Status:  statusExpiring,
+		Trusted: true,
+	}, obs)
+
+	obs = in.observe(in.readFile(filepath.Join(t.TempDir(), "missing.pem")))
+	assert.Equal(t, statusUnavailable, obs.Status)
+	assert.NotEmpty(t, obs.Error)
+}
+
+func TestValidity(t *testing.T) {
+	in := newTestInspector(t)
+	for name, tc := range map[string]struct {
+		notAfter time.Time
+		status   string
+	}{
+		"valid":    {testNow.Add(60 * 24 * time.Hour), statusValid},
+		"expiring": {testNow.Add(10 * 24 * time.Hour), statusExpiring},
+		"expired":  {testNow.Add(-time.Hour), statusExpired},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cert := newTestCert(t, "leaf", nil, nil, tc.notAfter)
+			status, notAfter := in.validity([]*x509.Certificate{cert.cert})
+			assert.Equal(t, tc.status, status)
+			assert.Equal(t, cert.cert.NotAfter, notAfter)
+		})
+	}
+}
+
+func TestWeaknesses(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	weak := newTestCert(t, "weak", nil, key, testNow.Add(90*24*time.Hour))
+	strong := newTestCert(t, "strong", nil, nil, testNow.Add(90*24*time.Hour))
+
+	in := newTestInspector(t)
+	assert.Equal(t, []string{weakKey}, in.weaknesses(inspection{chain: []*x509.Certificate{weak.cert}}))
+	assert.Nil(t, in.weaknesses(inspection{chain: []*x509.Certificate{strong.cert}}))
+}
+
+func TestObserveHost(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	in := newTestInspector(t)
+	in.now = time.Now
+	ins := in.dial(context.Background(), host)
+	require.NoError(t, ins.err)
+	require.NotNil(t, ins.conn)
+
+	obs := in.observe(ins)
+	assert.Equal(t, fingerprint(server.Certificate()), obs.SHA256)
+	assert.False(t, obs.Trusted, "the test server certificate is not signed by the roots")
+	assert.Equal(t, "TLSv1.3", obs.TLSVersion)
+	assert.Empty(t, obs.Weaknesses)
+
+	in.roots.AddCert(server.Certificate())
+	assert.True(t, in.observe(in.dial(context.Background(), host)).Trusted)
+}
+
+func TestNewEvent(t *testing.T) {
+	ca := newTestCert(t, "ca", nil, nil, testNow.Add(365*24*time.Hour))
+	leaf := newTestCert(t, "leaf", ca, nil, testNow.Add(90*24*time.Hour))
+	in := newTestInspector(t, ca)
+	path := writePEM(t, leaf, ca)
+	src := fileSource(path)
+
+	ins := in.readFile(path)
+	obs := in.observe(ins)
+	event, ok := newEvent(src, ins, obs, nil, testNow)
+	require.True(t, ok)
+	assert.Equal(t, "Certificate of "+path+" is valid", event.Fields["message"])
+	assert.Equal(t, mapstr.M{
+		"kind":   "state",
+		"type":   []string{"info"},
+		"action": "certificate-discovered",
+	}, event.Fields["event"])
+	cert := event.Fields["certificate"].(mapstr.M)
+	assert.Equal(t, 90, cert["expires_in_days"])
+	assert.Len(t, cert["chain"], 2)
+	assert.NotContains(t, cert, "changes")
+	cn, err := event.Fields.GetValue("file.x509.subject.common_name")
+	require.NoError(t, err)
+	assert.Equal(t, "leaf", cn)
+
+	// Nothing is reported while the certificate doesn't change.
+	_, ok = newEvent(src, ins, obs, &obs, testNow)
+	assert.False(t, ok)
+
+	// The certificate is renewed with a new CA that is not trusted.
+	otherCA := newTestCert(t, "other-ca", nil, nil, testNow.Add(365*24*time.Hour))
+	renewed := newTestCert(t, "leaf", otherCA, nil, testNow.Add(180*24*time.Hour))
+	require.NoError(t, os.WriteFile(path, pemOf(renewed, otherCA), 0o600))
+	ins = in.readFile(path)
+	next := in.observe(ins)
+	event, ok = newEvent(src, ins, next, &obs, testNow)
+	require.True(t, ok)
+	assert.Equal(t, "Certificate of "+path+" changed: certificate, chain, trust", event.Fields["message"])
+	cert = event.Fields["certificate"].(mapstr.M)
+	assert.Equal(t, []string{"certificate", "chain", "trust"}, cert["changes"])
+	assert.Equal(t, mapstr.M{"sha256": obs.SHA256, "status": statusValid}, cert["previous"])
+	assert.Contains(t, cert, "verify_error")
+}
+
+func pemOf(certs ...*testCert) []byte {
+	var buf []byte
+	for _, c := range certs {
+		buf = append(buf, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})...)
+	}
+	return buf
+}
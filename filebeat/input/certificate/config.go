@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// config stores the options of a certificate input.
+type config struct {
+	// Paths are the certificate files to watch, PEM or DER encoded.
+	Paths []string `config:"paths"`
+
+	// Hosts are the TLS endpoints to watch, as host:port.
+	Hosts []string `config:"hosts"`
+
+	// Period is the interval between two checks of a certificate.
+	Period time.Duration `config:"period" validate:"positive,nonzero"`
+
+	// Timeout is the timeout of the TLS handshake with the hosts.
+	Timeout time.Duration `config:"timeout" validate:"positive,nonzero"`
+
+	// ExpiryWarning is how long before the expiry of a certificate it is
+	// reported as expiring.
+	ExpiryWarning time.Duration `config:"expiry_warning" validate:"min=0"`
+
+	// MinRSAKeySize is the size in bits under which RSA keys are weak.
+	MinRSAKeySize int `config:"min_rsa_key_size" validate:"min=0"`
+
+	// TLS configures the certificate authorities the certificates are
+	// verified with, and the client certificate sent to the hosts.
+	TLS *tlscommon.Config `config:"ssl"`
+}
+
+func defaultConfig() config {
+	return config{
+		Period:        time.Hour,
+		Timeout:       10 * time.Second,
+		ExpiryWarning: 30 * 24 * time.Hour,
+		MinRSAKeySize: 2048,
+	}
+}
+
+func (c *config) Validate() error {
+	if len(c.Paths) == 0 && len(c.Hosts) == 0 {
+		return errors.New("no paths or hosts were configured")
+	}
+	return nil
+}
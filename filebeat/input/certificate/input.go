@@ -0,0 +1,300 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	input "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const pluginName = "certificate"
+
+// Plugin creates the certificate input plugin.
+func Plugin(log *logp.Logger, store cursor.StateStore) input.Plugin {
+	return input.Plugin{
+		Name:       pluginName,
+		Stability:  feature.Beta,
+		Deprecated: false,
+		Info:       "certificate input",
+		Doc:        "The certificate input watches certificate files and TLS endpoints, and reports the changes of their certificates",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       pluginName,
+			Configure:  configure,
+		},
+	}
+}
+
+// fileSource is a certificate file.
+type fileSource string
+
+func (p fileSource) Name() string { return "file::" + string(p) }
+
+// hostSource is a TLS endpoint.
+type hostSource string
+
+func (h hostSource) Name() string { return "tls::" + string(h) }
+
+type certificateInput struct {
+	config    config
+	inspector *inspector
+}
+
+func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, err
+	}
+	in, err := newInspector(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources := make([]cursor.Source, 0, len(config.Paths)+len(config.Hosts))
+	for _, p := range config.Paths {
+		sources = append(sources, fileSource(p))
+	}
+	for _, h := range config.Hosts {
+		sources = append(sources, hostSource(h))
+	}
+	return sources, &certificateInput{config: config, inspector: in}, nil
+}
+
+func (inp *certificateInput) Name() string { return pluginName }
+
+// FIPSCapable returns nil, as the input only relies on TLS, whose settings
+// are checked with the ones of the other inputs.
+func (inp *certificateInput) FIPSCapable() error { return nil }
+
+func (inp *certificateInput) Test(src cursor.Source, ctx input.TestContext) error {
+	ins := inp.inspect(ctxtool.FromCanceller(ctx.Cancelation), src)
+	return ins.err
+}
+
+func (inp *certificateInput) inspect(ctx context.Context, src cursor.Source) inspection {
+	switch s := src.(type) {
+	case fileSource:
+		return inp.inspector.readFile(string(s))
+	case hostSource:
+		return inp.inspector.dial(ctx, string(s))
+	default:
+		return inspection{err: fmt.Errorf("unknown source %s", src.Name())}
+	}
+}
+
+func (inp *certificateInput) Run(ctx input.Context, src cursor.Source, crsr cursor.Cursor, publisher cursor.Publisher) error {
+	log := ctx.Logger.With("certificate.source", src.Name())
+	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
+
+	var prev *observation
+	if !crsr.IsNew() {
+		var obs observation
+		if err := crsr.Unpack(&obs); err != nil {
+			log.Errorf("Reset certificate state. Failed to read state from registry: %v", err)
+		} else {
+			prev = &obs
+		}
+	}
+
+	for {
+		ins := inp.inspect(stdCtx, src)
+		if stdCtx.Err() != nil {
+			return nil
+		}
+		obs := inp.inspector.observe(ins)
+		if event, ok := newEvent(src, ins, obs, prev, inp.inspector.now()); ok {
+			log.Debugw("Certificate changed", "certificate.changes", event.Fields["certificate"].(mapstr.M)["changes"])
+			if err := publisher.Publish(event, obs); err != nil {
+				if stdCtx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			prev = &obs
+		}
+		if err := timed.Wait(stdCtx, inp.config.Period); err != nil {
+			return nil
+		}
+	}
+}
+
+// newEvent returns the event reporting obs, if it is the first observation
+// of the source or if it changed since prev.
+func newEvent(src cursor.Source, ins inspection, obs observation, prev *observation, now time.Time) (beat.Event, bool) {
+	var (
+		changes []string
+		action  = "certificate-discovered"
+		typ     = "info"
+	)
+	if prev != nil {
+		changes = obs.changes(*prev)
+		if len(changes) == 0 {
+			return beat.Event{}, false
+		}
+		action, typ = "certificate-changed", "change"
+	}
+
+	var target string
+	fields := mapstr.M{}
+	cert := mapstr.M{
+		"status":  obs.Status,
+		"trusted": obs.Trusted,
+	}
+	switch s := src.(type) {
+	case fileSource:
+		target = string(s)
+		file := mapstr.M{"path": target}
+		if len(ins.chain) > 0 {
+			file["x509"] = x509Fields(ins.chain[0])
+		}
+		fields["file"] = file
+	case hostSource:
+		target = string(s)
+		server := mapstr.M{"address": target}
+		if host, port, err := net.SplitHostPort(target); err == nil {
+			server["address"] = host
+			if p, err := strconv.Atoi(port); err == nil {
+				server["port"] = p
+			}
+		}
+		fields["server"] = server
+		if ins.conn != nil {
+			fields["tls"] = tlsFields(ins)
+		}
+	}
+
+	if len(ins.chain) > 0 {
+		cert["not_after"] = obs.NotAfter
+		cert["expires_in_days"] = int(obs.NotAfter.Sub(now).Hours() / 24)
+		chain := make([]mapstr.M, 0, len(ins.chain))
+		for _, c := range ins.chain {
+			chain = append(chain, mapstr.M{
+				"sha256":    fingerprint(c),
+				"subject":   c.Subject.String(),
+				"issuer":    c.Issuer.String(),
+				"not_after": c.NotAfter,
+			})
+		}
+		cert["chain"] = chain
+	}
+	if ins.verifyErr != nil {
+		cert["verify_error"] = ins.verifyErr.Error()
+	}
+	if len(obs.Weaknesses) > 0 {
+		cert["weaknesses"] = obs.Weaknesses
+	}
+	if prev != nil {
+		cert["changes"] = changes
+		previous := mapstr.M{"status": prev.Status}
+		if prev.SHA256 != "" {
+			previous["sha256"] = prev.SHA256
+		}
+		cert["previous"] = previous
+	}
+	fields["certificate"] = cert
+
+	if obs.Error != "" {
+		fields["error"] = mapstr.M{"message": obs.Error}
+	}
+	fields["event"] = mapstr.M{
+		"kind":   "state",
+		"type":   []string{typ},
+		"action": action,
+	}
+	if prev == nil {
+		fields["message"] = fmt.Sprintf("Certificate of %s is %s", target, obs.Status)
+	} else {
+		fields["message"] = fmt.Sprintf("Certificate of %s changed: %s", target, strings.Join(changes, ", "))
+	}
+
+	return beat.Event{Timestamp: now, Fields: fields}, true
+}
+
+// tlsFields returns the ECS tls fields of the connection of ins.
+func tlsFields(ins inspection) mapstr.M {
+	fields := mapstr.M{
+		"established": true,
+		"cipher":      tlscommon.ResolveCipherSuite(ins.conn.CipherSuite),
+	}
+	if details := tlscommon.TLSVersion(ins.conn.Version).Details(); details != nil {
+		fields["version"] = details.Version
+		fields["version_protocol"] = details.Protocol
+	}
+	if len(ins.chain) > 0 {
+		fields["server"] = mapstr.M{
+			"hash": mapstr.M{"sha256": fingerprint(ins.chain[0])},
+			"x509": x509Fields(ins.chain[0]),
+		}
+	}
+	return fields
+}
+
+// x509Fields returns the ECS x509 fields of cert.
+func x509Fields(cert *x509.Certificate) mapstr.M {
+	fields := mapstr.M{
+		"version_number":       strconv.Itoa(cert.Version),
+		"serial_number":        cert.SerialNumber.Text(16),
+		"signature_algorithm":  cert.SignatureAlgorithm.String(),
+		"public_key_algorithm": cert.PublicKeyAlgorithm.String(),
+		"not_before":           cert.NotBefore,
+		"not_after":            cert.NotAfter,
+		"subject": mapstr.M{
+			"common_name":        cert.Subject.CommonName,
+			"distinguished_name": cert.Subject.String(),
+		},
+		"issuer": mapstr.M{
+			"common_name":        cert.Issuer.CommonName,
+			"distinguished_name": cert.Issuer.String(),
+		},
+	}
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		fields["public_key_size"] = key.N.BitLen()
+		fields["public_key_exponent"] = key.E
+	case *ecdsa.PublicKey:
+		fields["public_key_size"] = key.Curve.Params().BitSize
+		fields["public_key_curve"] = key.Curve.Params().Name
+	}
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	if len(names) > 0 {
+		fields["alternative_names"] = names
+	}
+	return fields
+}
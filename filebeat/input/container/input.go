@@ -23,6 +23,7 @@ import (
 	"github.com/elastic/beats/v7/filebeat/channel"
 	"github.com/elastic/beats/v7/filebeat/input"
 	"github.com/elastic/beats/v7/filebeat/input/log"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -32,6 +33,7 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	seccomp.RegisterBase(seccomp.KindInput, "container")
 }
 
 // NewInput creates a new container input
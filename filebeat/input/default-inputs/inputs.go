@@ -19,8 +19,10 @@ package inputs
 
 import (
 	"github.com/elastic/beats/v7/filebeat/beater"
+	"github.com/elastic/beats/v7/filebeat/input/certificate"
 	"github.com/elastic/beats/v7/filebeat/input/filestream"
 	"github.com/elastic/beats/v7/filebeat/input/kafka"
+	"github.com/elastic/beats/v7/filebeat/input/pipe"
 	"github.com/elastic/beats/v7/filebeat/input/tcp"
 	"github.com/elastic/beats/v7/filebeat/input/udp"
 	"github.com/elastic/beats/v7/filebeat/input/unix"
@@ -38,8 +40,10 @@ func Init(info beat.Info, log *logp.Logger, components beater.StateStore) []v2.P
 
 func genericInputs(log *logp.Logger, components beater.StateStore) []v2.Plugin {
 	return []v2.Plugin{
+		certificate.Plugin(log, components),
 		filestream.Plugin(log, components),
 		kafka.Plugin(),
+		pipe.Plugin(log, components),
 		tcp.Plugin(),
 		udp.Plugin(),
 		unix.Plugin(),
@@ -23,6 +23,7 @@ import (
 
 	"github.com/dustin/go-humanize"
 
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
 	"github.com/elastic/beats/v7/libbeat/common/match"
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile"
@@ -45,6 +46,21 @@ type config struct {
 	IgnoreInactive ignoreInactiveType `config:"ignore_inactive"`
 	Rotation       *conf.Namespace    `config:"rotation"`
 	TakeOver       bool               `config:"take_over"`
+	Format         *conf.Namespace    `config:"format"`
+
+	LifecycleEvents lifecycleEventsConfig `config:"lifecycle_events"`
+	ExactlyOnce     exactlyOnceConfig     `config:"exactly_once"`
+}
+
+type lifecycleEventsConfig struct {
+	Enabled bool   `config:"enabled"`
+	Dataset string `config:"dataset" validate:"required"`
+}
+
+type exactlyOnceConfig struct {
+	Enabled    bool          `config:"enabled"`
+	TTL        time.Duration `config:"ttl" validate:"min=0"`
+	MaxEntries int           `config:"max_entries" validate:"min=1"`
 }
 
 type closerConfig struct {
@@ -72,6 +88,7 @@ type readerConfig struct {
 	IncludeLines   []match.Matcher         `config:"include_lines"`
 	LineTerminator readfile.LineTerminator `config:"line_terminator"`
 	MaxBytes       int                     `config:"message_max_bytes" validate:"min=0,nonzero"`
+	ReadRateLimit  cfgtype.ByteSize        `config:"read_rate_limit" validate:"min=0"`
 	Tail           bool                    `config:"seek_to_tail"`
 
 	Parsers parser.Config `config:",inline"`
@@ -102,6 +119,15 @@ func defaultConfig() config {
 		CleanRemoved:   true,
 		HarvesterLimit: 0,
 		IgnoreOlder:    0,
+		LifecycleEvents: lifecycleEventsConfig{
+			Enabled: false,
+			Dataset: "filestream.lifecycle",
+		},
+		ExactlyOnce: exactlyOnceConfig{
+			Enabled:    false,
+			TTL:        30 * 24 * time.Hour,
+			MaxEntries: 100000,
+		},
 	}
 }
 
@@ -138,5 +164,18 @@ func (c *config) Validate() error {
 		return fmt.Errorf("no path is configured")
 	}
 
+	if c.ExactlyOnce.Enabled && c.ID == "" {
+		return fmt.Errorf("exactly_once requires the input id to be set")
+	}
+
+	if c.Format != nil && c.Format.IsSet() {
+		if c.Reader.Parsers.HasParsers() {
+			return fmt.Errorf("parsers cannot be used with the %s format", c.Format.Name())
+		}
+		if len(c.Reader.IncludeLines) > 0 || len(c.Reader.ExcludeLines) > 0 {
+			return fmt.Errorf("include_lines and exclude_lines cannot be used with the %s format", c.Format.Name())
+		}
+	}
+
 	return nil
 }
@@ -29,4 +29,14 @@ func TestConfigValidate(t *testing.T) {
 		err := c.Validate()
 		require.Error(t, err)
 	})
+
+	t.Run("exactly_once requires an input id", func(t *testing.T) {
+		c := defaultConfig()
+		c.Paths = []string{"/var/drop/*"}
+		c.ExactlyOnce.Enabled = true
+		require.Error(t, c.Validate())
+
+		c.ID = "drop-folder"
+		require.NoError(t, c.Validate())
+	})
 }
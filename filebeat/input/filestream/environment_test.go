@@ -385,6 +385,23 @@ func getIDFromPath(filepath, inputID string, fi os.FileInfo) string {
 }
 
 // waitUntilEventCount waits until total count events arrive to the client.
+// waitUntilContentInLedger waits until the content hash of the file is
+// recorded in the ledger of the input.
+func (e *inputTestingEnvironment) waitUntilContentInLedger(filename, inputID string) {
+	hash, _, err := hashFile(e.abspath(filename))
+	require.NoError(e.t, err)
+
+	require.Eventuallyf(e.t, func() bool {
+		store, err := e.stateStore.Access()
+		if err != nil {
+			return false
+		}
+		defer store.Close()
+		has, err := store.Has(ledgerKeyPrefix + inputID + "::" + hash)
+		return err == nil && has
+	}, 10*time.Second, 10*time.Millisecond, "content hash of %s is not in the ledger", filename)
+}
+
 func (e *inputTestingEnvironment) waitUntilEventCount(count int) {
 	msg := &strings.Builder{}
 	require.Eventuallyf(e.t, func() bool {
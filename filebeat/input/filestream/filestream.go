@@ -27,6 +27,7 @@ import (
 	"github.com/elastic/go-concert/ctxtool"
 	"github.com/elastic/go-concert/timed"
 	"github.com/elastic/go-concert/unison"
+	"golang.org/x/time/rate"
 
 	input "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/common/backoff"
@@ -39,6 +40,21 @@ var (
 	ErrClosed       = errors.New("reader closed")
 )
 
+// fileRemovedError is returned by the reader when it was closed because
+// the file was removed. It wraps ErrClosed.
+type fileRemovedError struct {
+	// size of the file when the removal was detected, -1 if unknown
+	size int64
+}
+
+func (e *fileRemovedError) Error() string {
+	return "reader closed: file was removed"
+}
+
+func (e *fileRemovedError) Unwrap() error {
+	return ErrClosed
+}
+
 // logFile contains all log related data
 type logFile struct {
 	file      *os.File
@@ -57,6 +73,14 @@ type logFile struct {
 	lastTimeRead time.Time
 	backoff      backoff.Backoff
 	tg           *unison.TaskGroup
+
+	// rateLimiters throttle reading, see `read_rate_limit`.
+	rateLimiters []*rate.Limiter
+
+	// removed and removedSize are set by the state check before the
+	// reader context is cancelled.
+	removed     bool
+	removedSize int64
 }
 
 // newFileReader creates a new log instance to read log sources
@@ -106,6 +130,7 @@ func (f *logFile) Read(buf []byte) (int, error) {
 		if n > 0 {
 			f.offset += int64(n)
 			f.lastTimeRead = time.Now()
+			f.throttle(n)
 		}
 		totalN += n
 
@@ -132,9 +157,20 @@ func (f *logFile) Read(buf []byte) (int, error) {
 		f.backoff.Wait()
 	}
 
+	if f.removed {
+		return 0, &fileRemovedError{size: f.removedSize}
+	}
 	return 0, ErrClosed
 }
 
+// throttle blocks until all rate limiters allow n more bytes to be read.
+// Errors are ignored, as the reader context being cancelled is handled by Read.
+func (f *logFile) throttle(n int) {
+	for _, limiter := range f.rateLimiters {
+		_ = waitReadTokens(f.readerCtx, limiter, n)
+	}
+}
+
 func (f *logFile) startFileMonitoringIfNeeded() {
 	if f.closeInactive > 0 || f.closeRemoved || f.closeRenamed {
 		err := f.tg.Go(func(ctx context.Context) error {
@@ -193,6 +229,7 @@ func (f *logFile) shouldBeClosed() bool {
 		// return early if the file does not exist anymore and the reader should be closed
 		if f.closeRemoved && errors.Is(statErr, os.ErrNotExist) {
 			f.log.Debugf("close.on_state_change.removed is enabled and file %s has been removed", f.file.Name())
+			f.removed, f.removedSize = true, -1
 			return true
 		}
 
@@ -213,6 +250,7 @@ func (f *logFile) shouldBeClosed() bool {
 		// Check if the file name exists. See https://github.com/elastic/filebeat/issues/93
 		if file.IsRemoved(f.file) {
 			f.log.Debugf("close.on_state_change.removed is enabled and file %s has been removed", f.file.Name())
+			f.removed, f.removedSize = true, info.Size()
 			return true
 		}
 	}
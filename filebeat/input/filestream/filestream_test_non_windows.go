@@ -93,6 +93,11 @@ func TestLogFileRemoved(t *testing.T) {
 	_, err = reader.Read(buf)
 	assert.Nil(t, err)
 
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatalf("error while getting file info: %+v", err)
+	}
+
 	err = os.Remove(f.Name())
 	if err != nil {
 		t.Fatalf("error while remove file: %+v", err)
@@ -100,5 +105,9 @@ func TestLogFileRemoved(t *testing.T) {
 
 	err = readUntilError(reader)
 
-	assert.Equal(t, ErrClosed, err)
+	assert.ErrorIs(t, err, ErrClosed)
+	var removedErr *fileRemovedError
+	if assert.ErrorAs(t, err, &removedErr) {
+		assert.Equal(t, info.Size(), removedErr.size)
+	}
 }
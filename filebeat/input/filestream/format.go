@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/reader"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// FormatReader reads the records of a structured file, like a Parquet file.
+type FormatReader interface {
+	// Next returns the fields of the next record and the size of the record
+	// once decoded, or io.EOF after the last record.
+	Next() (fields mapstr.M, size int, err error)
+
+	// Close releases the resources of the reader. The file is closed by the
+	// input afterwards.
+	Close() error
+}
+
+// FormatFactory creates a FormatReader for a file. cfg holds the settings of
+// the `format.<name>` namespace.
+type FormatFactory func(f *os.File, cfg *conf.C) (FormatReader, error)
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]FormatFactory{}
+)
+
+// RegisterFormat makes a structured file format available to the `format`
+// setting of the filestream input. It panics if the name is already taken.
+func RegisterFormat(name string, factory FormatFactory) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	if _, exists := formats[name]; exists {
+		panic(fmt.Sprintf("filestream format %q is already registered", name))
+	}
+	formats[name] = factory
+}
+
+func findFormat(name string) (FormatFactory, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	factory, ok := formats[name]
+	return factory, ok
+}
+
+// formatConfig is the configuration of the structured file format read by
+// the input, if any.
+type formatConfig struct {
+	name    string
+	factory FormatFactory
+	cfg     *conf.C
+	target  string
+}
+
+func newFormatConfig(ns *conf.Namespace) (*formatConfig, error) {
+	if ns == nil || !ns.IsSet() {
+		return nil, nil
+	}
+
+	factory, ok := findFormat(ns.Name())
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q", ns.Name())
+	}
+
+	common := struct {
+		Target string `config:"target"`
+	}{}
+	if err := ns.Config().Unpack(&common); err != nil {
+		return nil, err
+	}
+
+	return &formatConfig{
+		name:    ns.Name(),
+		factory: factory,
+		cfg:     ns.Config(),
+		target:  common.Target,
+	}, nil
+}
+
+// openFormat opens a structured file, skipping the records that were already
+// published. Structured files are only complete once written, so they are
+// always read from the first record to the last one, without following them.
+func (inp *filestream) openFormat(log *logp.Logger, fs fileSource, s state) (reader.Reader, error) {
+	f, err := os.OpenFile(fs.newPath, os.O_RDONLY, os.FileMode(0))
+	if err != nil {
+		return nil, fmt.Errorf("failed opening %s: %w", fs.newPath, err)
+	}
+
+	// The offset of a structured file is only set when the file is ignored.
+	if s.Offset > 0 {
+		log.Debugf("Structured file was ignored, it is not read. Path='%s'", fs.newPath)
+		return &formatFileReader{file: f}, nil
+	}
+
+	fr, err := inp.format.factory(f, inp.format.cfg)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read %s as %s: %w", fs.newPath, inp.format.name, err)
+	}
+
+	r := &formatFileReader{
+		reader:      fr,
+		file:        f,
+		path:        fs.newPath,
+		fingerprint: fs.desc.Fingerprint,
+		target:      inp.format.target,
+		record:      s.Records,
+	}
+	for i := int64(0); i < s.Records; i++ {
+		if _, _, err := fr.Next(); err != nil {
+			r.Close()
+			if errors.Is(err, io.EOF) {
+				log.Debugf("All the records of the structured file were already read. Path='%s'", fs.newPath)
+				return &formatFileReader{}, nil
+			}
+			return nil, fmt.Errorf("failed to skip the records already read from %s: %w", fs.newPath, err)
+		}
+	}
+	return r, nil
+}
+
+// formatFileReader turns the records of a structured file into messages.
+type formatFileReader struct {
+	reader      FormatReader // nil when there is nothing to read
+	file        *os.File
+	path        string
+	fingerprint string
+	target      string
+
+	record int64 // index of the next record, published as log.offset
+	closed atomic.Bool
+}
+
+func (r *formatFileReader) Next() (reader.Message, error) {
+	if r.closed.Load() {
+		return reader.Message{}, ErrClosed
+	}
+	if r.reader == nil {
+		return reader.Message{}, io.EOF
+	}
+
+	fields, size, err := r.reader.Next()
+	if err != nil {
+		if r.closed.Load() {
+			return reader.Message{}, ErrClosed
+		}
+		return reader.Message{}, err
+	}
+
+	if r.target != "" {
+		fields = mapstr.M{r.target: fields}
+	}
+	fields.DeepUpdate(mapstr.M{
+		"log": mapstr.M{
+			"offset": r.record,
+			"file": mapstr.M{
+				"path": r.path,
+			},
+		},
+	})
+	if r.fingerprint != "" {
+		_, _ = fields.Put("log.file.fingerprint", r.fingerprint)
+	}
+	r.record++
+
+	// Bytes is never 0, so records are not dropped as empty messages.
+	return reader.Message{
+		Ts:     time.Now(),
+		Bytes:  max(size, 1),
+		Fields: fields,
+	}, nil
+}
+
+func (r *formatFileReader) Close() error {
+	if r.closed.Swap(true) {
+		return nil
+	}
+
+	var err error
+	if r.reader != nil {
+		err = r.reader.Close()
+	}
+	if r.file != nil {
+		// The format reader might have closed the file already.
+		if closeErr := r.file.Close(); err == nil && !errors.Is(closeErr, os.ErrClosed) {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	RegisterFormat("test-records", newTestRecordsReader)
+}
+
+// testRecordsReader reads one JSON object per line as a record.
+type testRecordsReader struct {
+	scanner *bufio.Scanner
+}
+
+func newTestRecordsReader(f *os.File, _ *conf.C) (FormatReader, error) {
+	return &testRecordsReader{scanner: bufio.NewScanner(f)}, nil
+}
+
+func (r *testRecordsReader) Next() (mapstr.M, int, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, io.EOF
+	}
+	var fields mapstr.M
+	if err := json.Unmarshal(r.scanner.Bytes(), &fields); err != nil {
+		return nil, 0, err
+	}
+	return fields, len(r.scanner.Bytes()), nil
+}
+
+func (r *testRecordsReader) Close() error { return nil }
+
+func TestFormat(t *testing.T) {
+	logp.TestingSetup()
+
+	filename := filepath.Join(t.TempDir(), "records.json")
+	content := ""
+	for i := 0; i < 3; i++ {
+		content += fmt.Sprintf(`{"id": %d, "nested": {"value": "v%d"}}`+"\n", i, i)
+	}
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0o644))
+
+	cfg := `
+type: filestream
+prospector.scanner.check_interval: 1s
+format.test-records:
+  target: record
+paths:
+    - ` + filename + `
+`
+	runner := createFilestreamTestRunner(context.Background(), t, "test-format", cfg, 3, true)
+	events := runner(t)
+	require.Len(t, events, 3)
+
+	for i, event := range events {
+		id, err := event.GetValue("record.id")
+		require.NoError(t, err)
+		assert.EqualValues(t, i, id)
+
+		value, err := event.GetValue("record.nested.value")
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("v%d", i), value)
+
+		offset, err := event.GetValue("log.offset")
+		require.NoError(t, err)
+		assert.EqualValues(t, i, offset)
+
+		path, err := event.GetValue("log.file.path")
+		require.NoError(t, err)
+		assert.Equal(t, filename, path)
+
+		_, err = event.GetValue("message")
+		assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+	}
+}
+
+func TestFormatSkipsPublishedRecords(t *testing.T) {
+	logp.TestingSetup()
+
+	filename := filepath.Join(t.TempDir(), "records.json")
+	require.NoError(t, os.WriteFile(filename, []byte("{\"id\": 0}\n{\"id\": 1}\n{\"id\": 2}\n"), 0o644))
+
+	format, err := newFormatConfig(mustNamespace(t, "test-records"))
+	require.NoError(t, err)
+	inp := &filestream{format: format}
+
+	r, _, err := inp.open(logp.L(), nil, fileSource{newPath: filename}, state{Records: 2})
+	require.NoError(t, err)
+	defer r.Close()
+
+	msg, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{"id": float64(2), "log": mapstr.M{"offset": int64(2), "file": mapstr.M{"path": filename}}}, msg.Fields)
+
+	_, err = r.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestFormatConfig(t *testing.T) {
+	_, err := newFormatConfig(mustNamespace(t, "unknown"))
+	assert.ErrorContains(t, err, `unknown format "unknown"`)
+
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"paths":               []string{"/var/data/*.parquet"},
+		"format.test-records": map[string]interface{}{},
+		"parsers": []interface{}{
+			map[string]interface{}{"ndjson": map[string]interface{}{"target": ""}},
+		},
+	})
+	require.NoError(t, err)
+	config := defaultConfig()
+	assert.ErrorContains(t, c.Unpack(&config), "parsers cannot be used with the test-records format")
+}
+
+func mustNamespace(t *testing.T, name string) *conf.Namespace {
+	t.Helper()
+
+	c, err := conf.NewConfigFrom(map[string]interface{}{name: map[string]interface{}{}})
+	require.NoError(t, err)
+	var ns conf.Namespace
+	require.NoError(t, c.Unpack(&ns))
+	return &ns
+}
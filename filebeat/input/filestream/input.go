@@ -25,14 +25,17 @@ import (
 	"time"
 
 	"golang.org/x/text/transform"
+	"golang.org/x/time/rate"
 
 	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
 
 	loginp "github.com/elastic/beats/v7/filebeat/input/filestream/internal/input-logfile"
 	input "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/common/cleanup"
 	"github.com/elastic/beats/v7/libbeat/common/file"
 	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/reader"
 	"github.com/elastic/beats/v7/libbeat/reader/debug"
@@ -46,8 +49,15 @@ import (
 
 const pluginName = "filestream"
 
+// ledgerACKCheckInterval is how often the harvester checks if the events of a
+// completely read file are ACKed before its content hash is recorded.
+const ledgerACKCheckInterval = 100 * time.Millisecond
+
 type state struct {
 	Offset int64 `json:"offset" struct:"offset"`
+
+	// Records is the number of records published from a structured file.
+	Records int64 `json:"records,omitempty" struct:"records,omitempty"`
 }
 
 type fileMeta struct {
@@ -63,6 +73,26 @@ type filestream struct {
 	closerConfig    closerConfig
 	parsers         parser.Config
 	takeOver        bool
+	format          *formatConfig // format is nil for line based files.
+	lifecycleEvents lifecycleEventsConfig
+
+	// readRateLimiter is shared by all harvesters of the input, it is nil if
+	// `read_rate_limit` is not set.
+	readRateLimiter *rate.Limiter
+
+	// ledger is nil if `exactly_once` is disabled.
+	ledger *contentLedger
+}
+
+// fileContent describes the content of a harvested file when `exactly_once`
+// is enabled.
+type fileContent struct {
+	hash string
+	size int64
+}
+
+func init() {
+	seccomp.RegisterBase(seccomp.KindInput, pluginName)
 }
 
 // Plugin creates a new filestream input plugin for creating a stateful input.
@@ -77,12 +107,14 @@ func Plugin(log *logp.Logger, store loginp.StateStore) input.Plugin {
 			Logger:     log,
 			StateStore: store,
 			Type:       pluginName,
-			Configure:  configure,
+			Configure: func(cfg *conf.C) (loginp.Prospector, loginp.Harvester, error) {
+				return configure(cfg, store)
+			},
 		},
 	}
 }
 
-func configure(cfg *conf.C) (loginp.Prospector, loginp.Harvester, error) {
+func configure(cfg *conf.C, store loginp.StateStore) (loginp.Prospector, loginp.Harvester, error) {
 	config := defaultConfig()
 	if err := cfg.Unpack(&config); err != nil {
 		return nil, nil, err
@@ -98,12 +130,25 @@ func configure(cfg *conf.C) (loginp.Prospector, loginp.Harvester, error) {
 		return nil, nil, fmt.Errorf("unknown encoding('%v')", config.Reader.Encoding)
 	}
 
+	format, err := newFormatConfig(config.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	filestream := &filestream{
 		readerConfig:    config.Reader,
 		encodingFactory: encodingFactory,
 		closerConfig:    config.Close,
 		parsers:         config.Reader.Parsers,
 		takeOver:        config.TakeOver,
+		format:          format,
+		lifecycleEvents: config.LifecycleEvents,
+		readRateLimiter: newReadRateLimiter(int64(config.Reader.ReadRateLimit)),
+	}
+	if config.ExactlyOnce.Enabled {
+		// Files are only recorded in the ledger once they are completely read.
+		filestream.closerConfig.Reader.OnEOF = true
+		filestream.ledger = newContentLedger(store, config.ID, config.ExactlyOnce)
 	}
 
 	return prospector, filestream, nil
@@ -111,13 +156,17 @@ func configure(cfg *conf.C) (loginp.Prospector, loginp.Harvester, error) {
 
 func (inp *filestream) Name() string { return pluginName }
 
+// FIPSCapable returns nil, as files are fingerprinted and deduplicated with
+// SHA-256 only.
+func (inp *filestream) FIPSCapable() error { return nil }
+
 func (inp *filestream) Test(src loginp.Source, ctx input.TestContext) error {
 	fs, ok := src.(fileSource)
 	if !ok {
 		return fmt.Errorf("not file source")
 	}
 
-	reader, _, err := inp.open(ctx.Logger, ctx.Cancelation, fs, 0)
+	reader, _, err := inp.open(ctx.Logger, ctx.Cancelation, fs, state{})
 	if err != nil {
 		return err
 	}
@@ -137,9 +186,29 @@ func (inp *filestream) Run(
 	}
 
 	log := ctx.Logger.With("path", fs.newPath).With("state-id", src.Name())
+	discovered := cursor.IsNew()
 	state := initState(log, cursor, fs)
 
-	r, truncated, err := inp.open(log, ctx.Cancelation, fs, state.Offset)
+	var content *fileContent
+	if inp.ledger != nil {
+		hash, size, err := hashFile(fs.newPath)
+		if err != nil {
+			log.Errorf("File content could not be hashed: %v", err)
+			return err
+		}
+		ingested, err := inp.ledger.contains(hash)
+		if err != nil {
+			return fmt.Errorf("failed to look up content hash: %w", err)
+		}
+		if ingested {
+			log.Infof("File content was already ingested, skipping file. Path='%s', content hash='%s'", fs.newPath, hash)
+			inp.publishLifecycleEvent(log, publisher, lifecycleSkipped, fs.newPath, state.Offset, -1)
+			return nil
+		}
+		content = &fileContent{hash: hash, size: size}
+	}
+
+	r, truncated, err := inp.open(log, ctx.Cancelation, fs, state)
 	if err != nil {
 		log.Errorf("File could not be opened for reading: %v", err)
 		return err
@@ -149,6 +218,13 @@ func (inp *filestream) Run(
 		state.Offset = 0
 	}
 
+	if discovered {
+		inp.publishLifecycleEvent(log, publisher, lifecycleDiscovered, fs.newPath, state.Offset, -1)
+	}
+	if truncated || fs.truncated {
+		inp.publishLifecycleEvent(log, publisher, lifecycleTruncated, fs.newPath, state.Offset, -1)
+	}
+
 	metrics.FilesActive.Inc()
 	metrics.HarvesterRunning.Inc()
 	defer metrics.FilesActive.Dec()
@@ -163,7 +239,7 @@ func (inp *filestream) Run(
 	})
 	defer streamCancel()
 
-	return inp.readFromSource(ctx, log, r, fs.newPath, state, publisher, metrics)
+	return inp.readFromSource(ctx, log, r, fs.newPath, state, publisher, metrics, cursor, content)
 }
 
 func initState(log *logp.Logger, c loginp.Cursor, s fileSource) state {
@@ -184,9 +260,14 @@ func (inp *filestream) open(
 	log *logp.Logger,
 	canceler input.Canceler,
 	fs fileSource,
-	offset int64,
+	s state,
 ) (reader.Reader, bool, error) {
+	if inp.format != nil {
+		r, err := inp.openFormat(log, fs, s)
+		return r, false, err
+	}
 
+	offset := s.Offset
 	f, encoding, truncated, err := inp.openFile(log, fs.newPath, offset)
 	if err != nil {
 		return nil, truncated, err
@@ -220,6 +301,7 @@ func (inp *filestream) open(
 	if err != nil {
 		return nil, truncated, err
 	}
+	logReader.rateLimiters = inp.rateLimiters()
 
 	dbgReader, err := debug.AppendReaders(logReader)
 	if err != nil {
@@ -255,6 +337,19 @@ func (inp *filestream) open(
 	return r, truncated, nil
 }
 
+// rateLimiters returns the limiters of the input and the global limiter
+// that are configured.
+func (inp *filestream) rateLimiters() []*rate.Limiter {
+	var limiters []*rate.Limiter
+	if inp.readRateLimiter != nil {
+		limiters = append(limiters, inp.readRateLimiter)
+	}
+	if global := globalReadRateLimiter.Load(); global != nil {
+		limiters = append(limiters, global)
+	}
+	return limiters
+}
+
 // openFile opens a file and checks for the encoding. In case the encoding cannot be detected
 // or the file cannot be opened because for example of failing read permissions, an error
 // is returned and the harvester is closed. The file will be picked up again the next time
@@ -347,6 +442,8 @@ func (inp *filestream) readFromSource(
 	s state,
 	p loginp.Publisher,
 	metrics *loginp.Metrics,
+	cursor loginp.Cursor,
+	content *fileContent,
 ) error {
 	metrics.FilesOpened.Inc()
 	metrics.HarvesterOpenFiles.Inc()
@@ -358,12 +455,30 @@ func (inp *filestream) readFromSource(
 	for ctx.Cancelation.Err() == nil {
 		message, err := r.Next()
 		if err != nil {
+			var removedErr *fileRemovedError
 			if errors.Is(err, ErrFileTruncate) {
 				log.Infof("File was truncated, nothing to read. Path='%s'", path)
+				inp.publishLifecycleEvent(log, p, lifecycleTruncated, path, s.Offset, -1)
+			} else if errors.As(err, &removedErr) {
+				log.Infof("File was removed. Closing. Path='%s'", path)
+				bytesLost := int64(-1)
+				if removedErr.size >= 0 {
+					bytesLost = max(removedErr.size-s.Offset, 0)
+				}
+				inp.publishLifecycleEvent(log, p, lifecycleDeleted, path, s.Offset, bytesLost)
 			} else if errors.Is(err, ErrClosed) {
 				log.Infof("Reader was closed. Closing. Path='%s'", path)
 			} else if errors.Is(err, io.EOF) {
 				log.Debugf("EOF has been reached. Closing. Path='%s'", path)
+				inp.publishLifecycleEvent(log, p, lifecycleCompleted, path, s.Offset, -1)
+				if content != nil {
+					offset := s.Offset
+					if inp.format != nil {
+						// Structured files are always read whole.
+						offset = content.size
+					}
+					inp.recordContent(ctx, log, cursor, path, *content, offset)
+				}
 			} else {
 				log.Errorf("Read line error: %v", err)
 				metrics.ProcessingErrors.Inc()
@@ -372,7 +487,13 @@ func (inp *filestream) readFromSource(
 			return nil
 		}
 
-		s.Offset += int64(message.Bytes) + int64(message.Offset)
+		if inp.format != nil {
+			// The records of structured files don't map to a range of bytes
+			// of the file, they are counted instead.
+			s.Records++
+		} else {
+			s.Offset += int64(message.Bytes) + int64(message.Offset)
+		}
 
 		metrics.MessagesRead.Inc()
 		if message.IsEmpty() || inp.isDroppedLine(log, string(message.Content)) {
@@ -397,6 +518,36 @@ func (inp *filestream) readFromSource(
 	return nil
 }
 
+// recordContent adds the content hash of a completely read file to the ledger
+// once all events read from the file are ACKed. The hash is not recorded if
+// the input is stopped before, so the file is read again on restart.
+func (inp *filestream) recordContent(
+	ctx input.Context,
+	log *logp.Logger,
+	cursor loginp.Cursor,
+	path string,
+	content fileContent,
+	offset int64,
+) {
+	if offset != content.size {
+		log.Warnf("File was not read up to the size it had when it was hashed, content hash is not recorded. Path='%s'", path)
+		return
+	}
+
+	for !cursor.Acked() {
+		if err := timed.Wait(ctx.Cancelation, ledgerACKCheckInterval); err != nil {
+			log.Debugf("Input stopped before all events were ACKed, content hash is not recorded. Path='%s'", path)
+			return
+		}
+	}
+
+	if err := inp.ledger.add(content.hash, path); err != nil {
+		log.Errorf("Failed to record content hash: %v", err)
+		return
+	}
+	log.Debugf("Recorded content hash '%s' of file '%s'", content.hash, path)
+}
+
 // isDroppedLine decides if the line is exported or not based on
 // the include_lines and exclude_lines options.
 func (inp *filestream) isDroppedLine(log *logp.Logger, line string) bool {
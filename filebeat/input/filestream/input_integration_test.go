@@ -1060,3 +1060,36 @@ func TestRotatingCloseInactiveLowWriteRate(t *testing.T) {
 	cancelInput()
 	env.waitUntilInputStops()
 }
+
+func TestFilestreamExactlyOnceSkipsIngestedContent(t *testing.T) {
+	env := newInputTestingEnvironment(t)
+
+	inp := env.mustCreateInput(map[string]interface{}{
+		"id":                                "fake-ID",
+		"paths":                             []string{env.abspath("*.log")},
+		"prospector.scanner.check_interval": "1ms",
+		"exactly_once.enabled":              true,
+		"lifecycle_events.enabled":          true,
+	})
+
+	testlines := []byte("first log line\nsecond log line\n")
+	env.mustWriteToFile("upload.log", testlines)
+
+	ctx, cancelInput := context.WithCancel(context.Background())
+	env.startInput(ctx, inp)
+
+	// file-discovered, two lines and file-completed
+	env.waitUntilEventCount(4)
+	env.waitUntilContentInLedger("upload.log", "fake-ID")
+
+	// the same content delivered again under another name is skipped
+	env.mustWriteToFile("retry.log", testlines)
+	env.waitUntilEventCount(5)
+	env.requireEventContents(4, "event.action", lifecycleSkipped)
+	env.requireEventContents(4, "log.file.path", env.abspath("retry.log"))
+
+	cancelInput()
+	env.waitUntilInputStops()
+
+	env.requireOffsetInRegistry("upload.log", "fake-ID", len(testlines))
+}
@@ -158,6 +158,38 @@ paths:
 	}
 }
 
+func TestLifecycleEvents(t *testing.T) {
+	filename := generateFile(t, t.TempDir(), 5)
+	cfg := fmt.Sprintf(`
+type: filestream
+prospector.scanner.check_interval: 1s
+close.reader.on_eof: true
+lifecycle_events.enabled: true
+paths:
+    - %s`, filename)
+	runner := createFilestreamTestRunner(context.Background(), t, "test-lifecycle-events", cfg, 7, true)
+	events := runner(t)
+	require.Len(t, events, 7)
+
+	for i, expected := range map[int]string{0: lifecycleDiscovered, 6: lifecycleCompleted} {
+		action, err := events[i].GetValue("event.action")
+		require.NoError(t, err)
+		require.Equal(t, expected, action)
+		dataset, err := events[i].GetValue("event.dataset")
+		require.NoError(t, err)
+		require.Equal(t, "filestream.lifecycle", dataset)
+		path, err := events[i].GetValue("log.file.path")
+		require.NoError(t, err)
+		require.Equal(t, filename, path)
+	}
+
+	offset, err := events[6].GetValue("log.offset")
+	require.NoError(t, err)
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), offset)
+}
+
 // runFilestreamBenchmark runs the entire filestream input with the in-memory registry and the test pipeline.
 // `testID` must be unique for each test run
 // `cfg` must be a valid YAML string containing valid filestream configuration
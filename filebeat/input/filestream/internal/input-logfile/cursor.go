@@ -31,6 +31,10 @@ func makeCursor(res *resource) Cursor {
 // for the current Source.
 func (c Cursor) IsNew() bool { return c.resource.IsNew() }
 
+// Acked returns true if all cursor updates published for the current Source
+// have been ACKed and written to the persistent store.
+func (c Cursor) Acked() bool { return !c.resource.hasPendingUpdates() }
+
 // Unpack deserialized the cursor state into to. Unpack fails if no pointer is
 // given, or if the structure to points to is not compatible with the document
 // stored.
@@ -68,6 +68,35 @@ func TestCursor_IsNew(t *testing.T) {
 	})
 }
 
+func TestCursor_Acked(t *testing.T) {
+	t.Run("true if no updates are pending", func(t *testing.T) {
+		store := testOpenStore(t, "test", createSampleStore(t, map[string]state{
+			"test::key": {Cursor: "test"},
+		}))
+		defer store.Release()
+
+		cursor := makeCursor(store.Get("test::key"))
+		require.True(t, cursor.Acked())
+	})
+
+	t.Run("false until pending updates are executed", func(t *testing.T) {
+		store := testOpenStore(t, "test", createSampleStore(t, map[string]state{
+			"test::key": {Cursor: "test"},
+		}))
+		defer store.Release()
+
+		res := store.Get("test::key")
+		op, err := createUpdateOp(res, "test-state-update")
+		require.NoError(t, err)
+
+		cursor := makeCursor(res)
+		require.False(t, cursor.Acked())
+
+		op.Execute(store, 1)
+		require.True(t, cursor.Acked())
+	})
+}
+
 func TestCursor_Unpack(t *testing.T) {
 	t.Run("nothing to unpack if key is new", func(t *testing.T) {
 		store := testOpenStore(t, "test", createSampleStore(t, nil))
@@ -25,6 +25,7 @@ import (
 	input "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
 	"github.com/elastic/go-concert/ctxtool"
 )
 
@@ -48,6 +49,14 @@ func (inp *managedInput) Test(ctx input.TestContext) error {
 	return inp.prospector.Test()
 }
 
+// FIPSCapable returns the FIPS capability of the harvester, if it reports it.
+func (inp *managedInput) FIPSCapable() error {
+	if a, ok := inp.harvester.(fips.Aware); ok {
+		return a.FIPSCapable()
+	}
+	return fips.ErrUnknownCapability
+}
+
 // Run
 func (inp *managedInput) Run(
 	ctx input.Context,
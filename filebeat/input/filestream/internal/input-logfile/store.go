@@ -454,6 +454,14 @@ func (r *resource) IsNew() bool {
 	return r.pendingCursorValue == nil && r.pendingUpdate == nil && r.cursor == nil
 }
 
+// hasPendingUpdates returns true if there are update operations in the
+// pipeline that have not been ACKed yet.
+func (r *resource) hasPendingUpdates() bool {
+	r.stateMutex.Lock()
+	defer r.stateMutex.Unlock()
+	return r.activeCursorOperations != 0
+}
+
 func (r *resource) isDeleted() bool {
 	return !r.internalState.Updated.IsZero() && r.internalState.TTL == 0
 }
@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	loginp "github.com/elastic/beats/v7/filebeat/input/filestream/internal/input-logfile"
+	"github.com/elastic/beats/v7/libbeat/common/file"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+)
+
+// ledgerKeyPrefix is the prefix of the registry keys of the content ledger.
+// It must not start with the prefix of the filestream file states.
+const ledgerKeyPrefix = "filestream-ledger::"
+
+// contentLedger records the hash of the content of the files ingested by an
+// input with `exactly_once` enabled. Entries are persisted in the registry,
+// expire after the configured TTL and the oldest entries are removed once the
+// ledger holds more than the configured number of entries.
+type contentLedger struct {
+	stateStore loginp.StateStore
+	prefix     string
+	ttl        time.Duration
+	maxEntries int
+
+	// mu must be held to access the fields below.
+	mu      sync.Mutex
+	loaded  bool
+	entries map[string]time.Time
+}
+
+type ledgerEntry struct {
+	Ingested time.Time `json:"ingested" struct:"ingested"`
+	Source   string    `json:"source" struct:"source"`
+}
+
+func newContentLedger(stateStore loginp.StateStore, inputID string, cfg exactlyOnceConfig) *contentLedger {
+	return &contentLedger{
+		stateStore: stateStore,
+		prefix:     ledgerKeyPrefix + inputID + "::",
+		ttl:        cfg.TTL,
+		maxEntries: cfg.MaxEntries,
+		entries:    map[string]time.Time{},
+	}
+}
+
+// contains returns true if a file with the given content hash was ingested
+// and the entry has not expired yet.
+func (l *contentLedger) contains(hash string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	store, err := l.access()
+	if err != nil {
+		return false, err
+	}
+	defer store.Close()
+
+	ingested, ok := l.entries[hash]
+	if !ok {
+		return false, nil
+	}
+	if l.expired(ingested, time.Now()) {
+		return false, l.remove(store, hash)
+	}
+	return true, nil
+}
+
+// add records the content hash of an ingested file.
+func (l *contentLedger) add(hash, source string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	store, err := l.access()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	now := time.Now()
+	if err := store.Set(l.prefix+hash, ledgerEntry{Ingested: now, Source: source}); err != nil {
+		return fmt.Errorf("failed to store content hash in the registry: %w", err)
+	}
+	l.entries[hash] = now
+
+	return l.prune(store, now)
+}
+
+// prune removes the expired entries, and the oldest entries while the ledger
+// holds more entries than allowed.
+func (l *contentLedger) prune(store *statestore.Store, now time.Time) error {
+	for hash, ingested := range l.entries {
+		if l.expired(ingested, now) {
+			if err := l.remove(store, hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	for len(l.entries) > l.maxEntries {
+		var oldest string
+		var oldestIngested time.Time
+		for hash, ingested := range l.entries {
+			if oldest == "" || ingested.Before(oldestIngested) {
+				oldest, oldestIngested = hash, ingested
+			}
+		}
+		if err := l.remove(store, oldest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *contentLedger) expired(ingested, now time.Time) bool {
+	return l.ttl > 0 && now.Sub(ingested) > l.ttl
+}
+
+func (l *contentLedger) remove(store *statestore.Store, hash string) error {
+	delete(l.entries, hash)
+	if err := store.Remove(l.prefix + hash); err != nil {
+		return fmt.Errorf("failed to remove content hash from the registry: %w", err)
+	}
+	return nil
+}
+
+// access returns the persistent store, loading the ledger from it on first use.
+// The caller must close the returned store.
+func (l *contentLedger) access() (*statestore.Store, error) {
+	store, err := l.stateStore.Access()
+	if err != nil {
+		return nil, fmt.Errorf("can't access persistent store: %w", err)
+	}
+	if l.loaded {
+		return store, nil
+	}
+
+	err = store.Each(func(key string, dec statestore.ValueDecoder) (bool, error) {
+		if !strings.HasPrefix(key, l.prefix) {
+			return true, nil
+		}
+		var entry ledgerEntry
+		if err := dec.Decode(&entry); err != nil {
+			// Skip faulty entries, they are overwritten if the content is ingested again.
+			return true, nil //nolint:nilerr // faulty entries are not an error
+		}
+		l.entries[strings.TrimPrefix(key, l.prefix)] = entry.Ingested
+		return true, nil
+	})
+	if err != nil {
+		store.Close()
+		return nil, fmt.Errorf("failed to load content ledger from the registry: %w", err)
+	}
+	l.loaded = true
+	return store, nil
+}
+
+// hashFile returns the SHA-256 hash of the content of the file and its size.
+func hashFile(path string) (string, int64, error) {
+	f, err := file.ReadOpen(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
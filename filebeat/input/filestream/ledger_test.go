@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentLedger(t *testing.T) {
+	cfg := exactlyOnceConfig{Enabled: true, TTL: time.Hour, MaxEntries: 2}
+
+	t.Run("records content hashes", func(t *testing.T) {
+		ledger := newContentLedger(createTestStore(t), "test-input", cfg)
+
+		ok, err := ledger.contains("hash-a")
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		require.NoError(t, ledger.add("hash-a", "/var/drop/a.log"))
+
+		ok, err = ledger.contains("hash-a")
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("entries are loaded from the registry", func(t *testing.T) {
+		store := createTestStore(t)
+		require.NoError(t, newContentLedger(store, "test-input", cfg).add("hash-a", "/var/drop/a.log"))
+
+		ok, err := newContentLedger(store, "test-input", cfg).contains("hash-a")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = newContentLedger(store, "other-input", cfg).contains("hash-a")
+		require.NoError(t, err)
+		require.False(t, ok, "ledgers of other inputs must not be shared")
+	})
+
+	t.Run("expired entries are ignored", func(t *testing.T) {
+		ledger := newContentLedger(createTestStore(t), "test-input", cfg)
+		require.NoError(t, ledger.add("hash-a", "/var/drop/a.log"))
+		ledger.entries["hash-a"] = time.Now().Add(-2 * cfg.TTL)
+
+		ok, err := ledger.contains("hash-a")
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.NotContains(t, ledger.entries, "hash-a")
+	})
+
+	t.Run("oldest entries are removed when the ledger is full", func(t *testing.T) {
+		store := createTestStore(t)
+		ledger := newContentLedger(store, "test-input", cfg)
+		for _, hash := range []string{"hash-a", "hash-b", "hash-c"} {
+			require.NoError(t, ledger.add(hash, "/var/drop/"+hash))
+			time.Sleep(time.Millisecond)
+		}
+		require.Len(t, ledger.entries, cfg.MaxEntries)
+
+		reloaded := newContentLedger(store, "test-input", cfg)
+		for hash, expected := range map[string]bool{"hash-a": false, "hash-b": true, "hash-c": true} {
+			ok, err := reloaded.contains(hash)
+			require.NoError(t, err)
+			require.Equal(t, expected, ok, hash)
+		}
+	})
+}
+
+func TestHashFile(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("first line\nsecond line\n")
+	for _, name := range []string{"a.log", "b.log"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.log"), []byte("other line\n"), 0o644))
+
+	hashA, size, err := hashFile(filepath.Join(dir, "a.log"))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), size)
+
+	hashB, _, err := hashFile(filepath.Join(dir, "b.log"))
+	require.NoError(t, err)
+	require.Equal(t, hashA, hashB)
+
+	hashC, _, err := hashFile(filepath.Join(dir, "c.log"))
+	require.NoError(t, err)
+	require.NotEqual(t, hashA, hashC)
+}
@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"time"
+
+	loginp "github.com/elastic/beats/v7/filebeat/input/filestream/internal/input-logfile"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Lifecycle actions published when `lifecycle_events.enabled` is set.
+const (
+	lifecycleDiscovered = "file-discovered"
+	lifecycleCompleted  = "file-completed"
+	lifecycleTruncated  = "file-truncated"
+	lifecycleDeleted    = "file-deleted"
+	lifecycleSkipped    = "file-skipped"
+)
+
+// publishLifecycleEvent publishes an event describing a change in the
+// lifecycle of the harvested file. The event does not carry a cursor update,
+// so the state of the file in the registry is left untouched.
+// bytesLost is only added to the event if it is not negative.
+func (inp *filestream) publishLifecycleEvent(
+	log *logp.Logger,
+	p loginp.Publisher,
+	action string,
+	path string,
+	offset int64,
+	bytesLost int64,
+) {
+	if !inp.lifecycleEvents.Enabled {
+		return
+	}
+
+	fields := mapstr.M{
+		"event": mapstr.M{
+			"kind":    "event",
+			"action":  action,
+			"dataset": inp.lifecycleEvents.Dataset,
+		},
+		"log": mapstr.M{
+			"file": mapstr.M{
+				"path": path,
+			},
+			"offset": offset,
+		},
+	}
+	if bytesLost >= 0 {
+		_, _ = fields.Put("filestream.bytes_lost", bytesLost)
+	}
+
+	err := p.Publish(beat.Event{Timestamp: time.Now(), Fields: fields}, nil)
+	if err != nil {
+		log.Debugf("Failed to publish %s lifecycle event: %v", action, err)
+	}
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+)
+
+// globalReadRateLimiter is shared by the harvesters of all filestream inputs.
+// It is nil if `filebeat.filestream.read_rate_limit` is not set.
+var globalReadRateLimiter atomic.Pointer[rate.Limiter]
+
+// SetGlobalReadRateLimit caps the number of bytes per second read by the
+// harvesters of all filestream inputs combined. A limit of 0 removes the cap.
+// It only affects files opened after the call.
+func SetGlobalReadRateLimit(bytesPerSec int64) {
+	globalReadRateLimiter.Store(newReadRateLimiter(bytesPerSec))
+}
+
+// newReadRateLimiter returns a token bucket that allows bursts of up to one
+// second of reading, or nil if bytesPerSec is not positive.
+func newReadRateLimiter(bytesPerSec int64) *rate.Limiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	burst := bytesPerSec
+	if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSec), int(burst))
+}
+
+// waitReadTokens blocks until the limiter allows n more bytes to be read.
+// Reads larger than the burst of the limiter are split.
+func waitReadTokens(ctx context.Context, limiter *rate.Limiter, n int) error {
+	for n > 0 {
+		tokens := n
+		if burst := limiter.Burst(); tokens > burst {
+			tokens = burst
+		}
+		if err := limiter.WaitN(ctx, tokens); err != nil {
+			return err
+		}
+		n -= tokens
+	}
+	return nil
+}
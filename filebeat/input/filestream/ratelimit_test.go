@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestNewReadRateLimiter(t *testing.T) {
+	assert.Nil(t, newReadRateLimiter(0))
+	assert.Nil(t, newReadRateLimiter(-1))
+
+	limiter := newReadRateLimiter(1024)
+	require.NotNil(t, limiter)
+	assert.Equal(t, rate.Limit(1024), limiter.Limit())
+	assert.Equal(t, 1024, limiter.Burst())
+
+	limiter = newReadRateLimiter(math.MaxInt64)
+	assert.Equal(t, math.MaxInt32, limiter.Burst())
+}
+
+func TestWaitReadTokens(t *testing.T) {
+	t.Run("reads larger than the burst are throttled", func(t *testing.T) {
+		limiter := newReadRateLimiter(100)
+
+		start := time.Now()
+		// The first 100 bytes are available right away, the rest takes 500ms
+		require.NoError(t, waitReadTokens(context.Background(), limiter, 150))
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("cancelled context stops waiting", func(t *testing.T) {
+		limiter := newReadRateLimiter(1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Error(t, waitReadTokens(ctx, limiter, 10))
+	})
+}
+
+func TestLogFileReadRateLimit(t *testing.T) {
+	f := createTestLogFile()
+	defer f.Close()
+	defer os.Remove(f.Name())
+
+	reader, err := newFileReader(logp.L(), context.TODO(), f, readerConfig{}, closerConfig{})
+	require.NoError(t, err)
+	// The test file has 60 bytes, 40 of them are available right away
+	reader.rateLimiters = []*rate.Limiter{newReadRateLimiter(40)}
+
+	start := time.Now()
+	buf := make([]byte, 1024)
+	n, err := reader.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 60, n)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
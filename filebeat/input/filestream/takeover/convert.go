@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package takeover
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// renamedLogSettings are the log input settings that have an equivalent
+// filestream setting under another name.
+var renamedLogSettings = map[string]string{
+	"exclude_files":         "prospector.scanner.exclude_files",
+	"scan_frequency":        "prospector.scanner.check_interval",
+	"symlinks":              "prospector.scanner.symlinks",
+	"harvester_buffer_size": "buffer_size",
+	"max_bytes":             "message_max_bytes",
+	"close_inactive":        "close.on_state_change.inactive",
+	"close_removed":         "close.on_state_change.removed",
+	"close_renamed":         "close.on_state_change.renamed",
+	"close_eof":             "close.reader.on_eof",
+	"close_timeout":         "close.reader.after_interval",
+	"backoff":               "backoff.init",
+	"max_backoff":           "backoff.max",
+}
+
+// sharedLogSettings are the log input settings that filestream supports
+// under the same name.
+var sharedLogSettings = map[string]bool{
+	"enabled":            true,
+	"paths":              true,
+	"fields":             true,
+	"fields_under_root":  true,
+	"tags":               true,
+	"processors":         true,
+	"pipeline":           true,
+	"index":              true,
+	"keep_null":          true,
+	"publisher_pipeline": true,
+	"encoding":           true,
+	"exclude_lines":      true,
+	"include_lines":      true,
+	"line_terminator":    true,
+	"ignore_older":       true,
+	"clean_inactive":     true,
+	"clean_removed":      true,
+	"harvester_limit":    true,
+	"file_identity":      true,
+}
+
+// convertLogInput converts the settings of a log input into the settings of
+// the filestream input with the given ID. Settings that filestream does not
+// support are dropped and reported in the returned warnings.
+func convertLogInput(src mapstr.M, id string) (dst mapstr.M, warnings []string) {
+	dst = mapstr.M{
+		"type": "filestream",
+		"id":   id,
+	}
+
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := src[key]
+		switch key {
+		case "type", "id", "json", "multiline", "docker-json":
+			// The parsers are added below, in the order of the log input readers.
+		case "tail_files":
+			// Without state, filestream reads the files updated since its
+			// start from their end.
+			if tail, ok := value.(bool); ok && tail {
+				dst["ignore_inactive"] = "since_last_start"
+			}
+		case "recursive_glob":
+			if enabled, err := mapstr.M(toMap(value)).GetValue("enabled"); err == nil {
+				_, _ = dst.Put("prospector.scanner.recursive_glob", enabled)
+			}
+		default:
+			if to, ok := renamedLogSettings[key]; ok {
+				_, _ = dst.Put(to, value)
+			} else if sharedLogSettings[key] {
+				dst[key] = value
+			} else {
+				warnings = append(warnings, fmt.Sprintf("setting %q is not supported by filestream and was dropped", key))
+			}
+		}
+	}
+
+	var parsers []interface{}
+	if value, ok := src["docker-json"]; ok {
+		docker := toMap(value)
+		container := mapstr.M{}
+		for _, key := range []string{"stream", "format"} {
+			if v, ok := docker[key]; ok {
+				container[key] = v
+			}
+		}
+		parsers = append(parsers, mapstr.M{"container": container})
+	}
+	if value, ok := src["json"]; ok {
+		ndjson := mapstr.M{}
+		target := "json"
+		for key, v := range toMap(value) {
+			if key == "keys_under_root" {
+				if under, ok := v.(bool); ok && under {
+					target = ""
+				}
+				continue
+			}
+			ndjson[key] = v
+		}
+		ndjson["target"] = target
+		parsers = append(parsers, mapstr.M{"ndjson": ndjson})
+	}
+	if value, ok := src["multiline"]; ok {
+		parsers = append(parsers, mapstr.M{"multiline": toMap(value)})
+	}
+	if len(parsers) > 0 {
+		dst["parsers"] = parsers
+	}
+
+	return dst, warnings
+}
+
+func toMap(value interface{}) map[string]interface{} {
+	switch m := value.(type) {
+	case mapstr.M:
+		return m
+	case map[string]interface{}:
+		return m
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package takeover
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/filebeat/backup"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const defaultIdentifierName = "native"
+
+// Migration is the plan to replace the log inputs with filestream inputs,
+// converting their registry states so the filestream inputs continue to
+// read the files where the log inputs stopped.
+type Migration struct {
+	// Inputs holds the configuration of all the inputs, with the log inputs
+	// replaced by the filestream inputs.
+	Inputs []mapstr.M
+	// Migrated describes the migrated log inputs.
+	Migrated []MigratedInput
+
+	toSet    map[string]mapstr.M
+	toRemove map[string]struct{}
+}
+
+// MigratedInput is a log input replaced by a filestream input.
+type MigratedInput struct {
+	ID string
+	// States is the number of registry states taken over by the input.
+	States int
+	// Warnings lists the log input settings that could not be converted.
+	Warnings []string
+}
+
+// logInput is a log input being migrated.
+type logInput struct {
+	migrated   *MigratedInput
+	identifier string
+	match      func(source string) bool
+}
+
+type logInputConfig struct {
+	Type          string          `config:"type"`
+	ID            string          `config:"id"`
+	Paths         []string        `config:"paths"`
+	RecursiveGlob bool            `config:"recursive_glob.enabled"`
+	FileIdentity  *conf.Namespace `config:"file_identity"`
+}
+
+// PlanMigration prepares the migration of the log inputs found in inputsCfg
+// and of their states found in the store. The store is not modified.
+//
+// Log inputs without an ID are given one derived from their paths. A state
+// is converted if its source file matches the paths of a log input and it
+// was created with the file identity of the input, that the filestream
+// input keeps.
+func PlanMigration(log *logp.Logger, store backend.Store, inputsCfg []*conf.C) (*Migration, error) {
+	m := &Migration{
+		toSet:    make(map[string]mapstr.M),
+		toRemove: make(map[string]struct{}),
+	}
+
+	var inputs []logInput
+	ids := make(map[string]bool)
+	for _, c := range inputsCfg {
+		var raw mapstr.M
+		if err := c.Unpack(&raw); err != nil {
+			return nil, fmt.Errorf("failed to unpack input configuration: %w", err)
+		}
+		cfg := logInputConfig{RecursiveGlob: true}
+		if err := c.Unpack(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to unpack input configuration: %w", err)
+		}
+		if cfg.Type != "log" {
+			if id, _ := raw["id"].(string); id != "" {
+				ids[id] = true
+			}
+			m.Inputs = append(m.Inputs, raw)
+			continue
+		}
+
+		id := cfg.ID
+		if id == "" {
+			id = logInputID(cfg.Paths)
+		}
+		if ids[id] {
+			return nil, fmt.Errorf("input ID `%s` is not unique, set a unique `id` on the log inputs", id)
+		}
+		ids[id] = true
+
+		converted, warnings := convertLogInput(raw, id)
+		m.Inputs = append(m.Inputs, converted)
+		m.Migrated = append(m.Migrated, MigratedInput{ID: id, Warnings: warnings})
+
+		identifier := defaultIdentifierName
+		if cfg.FileIdentity != nil {
+			identifier = cfg.FileIdentity.Name()
+		}
+		match, err := createMatcher(log, inputConfig{
+			ID:         id,
+			Paths:      cfg.Paths,
+			Prospector: prospector{Scanner: scanner{RecursiveGlob: cfg.RecursiveGlob}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log input matcher: %w", err)
+		}
+		inputs = append(inputs, logInput{identifier: identifier, match: match})
+	}
+	for i := range inputs {
+		inputs[i].migrated = &m.Migrated[i]
+	}
+	if len(inputs) == 0 {
+		return m, nil
+	}
+
+	err := store.Each(func(key string, value statestore.ValueDecoder) (bool, error) {
+		if !strings.HasPrefix(key, loginputPrefix) {
+			return true, nil
+		}
+		state := make(mapstr.M)
+		if err := value.Decode(&state); err != nil {
+			return false, err
+		}
+		source, _ := state["source"].(string)
+		if source == "" {
+			return true, nil
+		}
+
+		for _, input := range inputs {
+			if !input.match(source) {
+				continue
+			}
+			identifier, _ := state["identifier_name"].(string)
+			if identifier == "" {
+				identifier = defaultIdentifierName
+			}
+			if identifier != input.identifier {
+				log.Warnf("state `%s` of %s was created with the `%s` file identity instead of `%s`, it is not migrated",
+					key, source, identifier, input.identifier)
+				return true, nil
+			}
+
+			newKey := filestreamKey(key, state, input.migrated.ID)
+			log.Infof("found loginput state `%s` to migrate to `%s`", key, newKey)
+			m.toSet[newKey] = loginputToFilestream(state)
+			m.toRemove[key] = struct{}{}
+			input.migrated.States++
+			return true, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the log input states: %w", err)
+	}
+	return m, nil
+}
+
+// States returns the number of states converted by the migration.
+func (m *Migration) States() int {
+	return len(m.toSet)
+}
+
+// Apply converts the states in the store, after backing up the registry.
+func (m *Migration) Apply(store backend.Store, backuper backup.Backuper) error {
+	if len(m.toSet) == 0 {
+		return nil
+	}
+	return replaceStates(store, backuper, m.toSet, m.toRemove)
+}
+
+// logInputID returns a stable ID for a log input without ID.
+func logInputID(paths []string) string {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+	h := fnv.New64a()
+	for _, p := range sorted {
+		_, _ = h.Write([]byte(p))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("migrated-log-%x", h.Sum64())
+}
+
+// filestreamKey returns the key of the filestream state of the file of a log
+// input state. The file ID of the log state, which starts with a hash when the
+// log input sets meta fields, is made of the same identifier name and file
+// identity as the one of filestream.
+func filestreamKey(key string, state mapstr.M, filestreamID string) string {
+	fileID, _ := state["id"].(string)
+	if name, _ := state["identifier_name"].(string); name != "" {
+		if i := strings.Index(fileID, name+identitySep); i > 0 {
+			fileID = fileID[i:]
+		}
+	} else if fileID != "" && !strings.Contains(fileID, identitySep) {
+		// States of old versions have no identifier name.
+		fileID = defaultIdentifierName + identitySep + fileID
+	}
+	if fileID == "" {
+		fileID = strings.TrimPrefix(key, loginputPrefix)
+	}
+	return "filestream::" + filestreamID + identitySep + fileID
+}
@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package takeover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestPlanMigration(t *testing.T) {
+	inputs := newInputConfigFrom(t,
+		`
+type: log
+id: app
+paths:
+  - "/path/app*.log"
+close_inactive: 1m
+scan_frequency: 5s
+tail_files: true
+scan.order: desc
+json:
+  keys_under_root: true
+  message_key: msg
+multiline:
+  type: pattern
+  pattern: '^\['
+  negate: true
+  match: after
+`,
+		`
+type: log
+paths:
+  - "/path/other.log"
+file_identity.path: ~
+`,
+		`
+type: filestream
+id: filestream-id-1
+paths:
+  - "/path/filestream1-*.log"
+`)
+
+	states := []state{
+		{
+			key: "filebeat::logs::native::92938222-16777232",
+			value: mapstr.M{
+				"source":          "/path/app1.log",
+				"timestamp":       []int{258139663760, 1671033742},
+				"ttl":             -1,
+				"id":              "native::92938222-16777232",
+				"offset":          392012100,
+				"identifier_name": "native",
+			},
+		},
+		{
+			// log inputs with meta fields prefix the file ID with their hash
+			key: "filebeat::logs::6a8e2b5c3f4d1e0-native::92938223-16777233",
+			value: mapstr.M{
+				"source":          "/path/app2.log",
+				"timestamp":       []int{258139663761, 1671033743},
+				"ttl":             -1,
+				"id":              "6a8e2b5c3f4d1e0-native::92938223-16777233",
+				"offset":          64625356,
+				"identifier_name": "native",
+				"meta":            mapstr.M{"key": "value"},
+			},
+		},
+		{
+			key: "filebeat::logs::path::/path/other.log",
+			value: mapstr.M{
+				"source":          "/path/other.log",
+				"ttl":             -1,
+				"id":              "path::/path/other.log",
+				"offset":          10,
+				"identifier_name": "path",
+			},
+		},
+		{
+			// created with another file identity than the one of the input
+			key: "filebeat::logs::native::11111111-22222222",
+			value: mapstr.M{
+				"source":          "/path/other.log",
+				"ttl":             -1,
+				"id":              "native::11111111-22222222",
+				"offset":          20,
+				"identifier_name": "native",
+			},
+		},
+		{
+			key: "filestream::filestream-id-1::native::33333333-44444444",
+			value: mapstr.M{
+				"meta": mapstr.M{"source": "/path/filestream1-1.log"},
+			},
+		},
+	}
+	store := &storeMock{states: states}
+
+	m, err := PlanMigration(logp.NewLogger("takeover-test"), store, inputs)
+	require.NoError(t, err)
+	assert.Empty(t, store.set, "planning must not modify the store")
+
+	otherID := logInputID([]string{"/path/other.log"})
+	require.Len(t, m.Migrated, 2)
+	assert.Equal(t, MigratedInput{
+		ID:       "app",
+		States:   2,
+		Warnings: []string{`setting "scan" is not supported by filestream and was dropped`},
+	}, m.Migrated[0])
+	assert.Equal(t, MigratedInput{ID: otherID, States: 1}, m.Migrated[1])
+	assert.Equal(t, 3, m.States())
+
+	require.Len(t, m.Inputs, 3)
+	app := m.Inputs[0]
+	assert.Equal(t, "filestream", app["type"])
+	assert.Equal(t, "app", app["id"])
+	for key, want := range map[string]interface{}{
+		"close.on_state_change.inactive":    "1m",
+		"prospector.scanner.check_interval": "5s",
+		"ignore_inactive":                   "since_last_start",
+	} {
+		got, err := app.GetValue(key)
+		require.NoError(t, err, key)
+		assert.Equal(t, want, got, key)
+	}
+	parsers, ok := app["parsers"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, parsers, 2)
+	assert.Equal(t, mapstr.M{"ndjson": mapstr.M{"message_key": "msg", "target": ""}}, parsers[0])
+	assert.Contains(t, parsers[1], "multiline")
+	assert.Equal(t, otherID, m.Inputs[1]["id"])
+	assert.Equal(t, "filestream-id-1", m.Inputs[2]["id"])
+
+	backuper := &backuperMock{}
+	require.NoError(t, m.Apply(store, backuper))
+	assert.Equal(t, 1, backuper.called)
+	assert.ElementsMatch(t, []string{states[0].key, states[1].key, states[2].key}, store.removed)
+
+	keys := make([]string, 0, len(store.set))
+	for _, op := range store.set {
+		keys = append(keys, op.key)
+	}
+	assert.ElementsMatch(t, []string{
+		"filestream::app::native::92938222-16777232",
+		"filestream::app::native::92938223-16777233",
+		"filestream::" + otherID + "::path::/path/other.log",
+	}, keys)
+}
+
+func TestPlanMigrationDuplicateID(t *testing.T) {
+	inputs := newInputConfigFrom(t,
+		`
+type: filestream
+id: my-id
+paths: ["/path/a.log"]
+`,
+		`
+type: log
+id: my-id
+paths: ["/path/b.log"]
+`)
+	_, err := PlanMigration(logp.NewLogger("takeover-test"), &storeMock{}, inputs)
+	require.ErrorContains(t, err, "input ID `my-id` is not unique")
+}
@@ -35,6 +35,7 @@ import (
 
 const (
 	loginputPrefix = "filebeat::logs::"
+	identitySep    = "::"
 )
 
 type filestreamMatchers map[string]func(source string) bool
@@ -65,26 +66,36 @@ func TakeOverLogInputStates(log *logp.Logger, store backend.Store, backuper back
 		return nil
 	}
 
+	err = replaceStates(store, backuper, statesToSet, statesToRemove)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("filestream inputs took over %d file(s) from loginputs", len(statesToSet))
+	return nil
+}
+
+// replaceStates sets and removes the given states, after backing up the
+// registry files.
+func replaceStates(store backend.Store, backuper backup.Backuper, toSet map[string]mapstr.M, toRemove map[string]struct{}) error {
 	// before making changes, we backup the registry files for manual rollback if needed
-	err = backuper.Backup()
+	err := backuper.Backup()
 	if err != nil {
 		return fmt.Errorf("failed to create backup files: %w", err)
 	}
 
-	for key := range statesToSet {
-		err = store.Set(key, statesToSet[key])
+	for key := range toSet {
+		err = store.Set(key, toSet[key])
 		if err != nil {
 			return fmt.Errorf("failed to set the taken state: %w", err)
 		}
 	}
-	for key := range statesToRemove {
+	for key := range toRemove {
 		err = store.Remove(key)
 		if err != nil {
 			return fmt.Errorf("failed to remove the taken state: %w", err)
 		}
 	}
-
-	log.Infof("filestream inputs took over %d file(s) from loginputs", len(statesToSet))
 	return nil
 }
 
@@ -124,7 +135,7 @@ func takeOverStates(log *logp.Logger, store backend.Store, matchers filestreamMa
 			return true, nil
 		}
 
-		newKey := loginputToFilestreamKey(key, filestreamID)
+		newKey := filestreamKey(key, state, filestreamID)
 		log.Infof("found loginput state `%s` to take over by `%s`", key, newKey)
 
 		newState := loginputToFilestream(state)
@@ -210,10 +221,6 @@ func createMatcher(log *logp.Logger, cfg inputConfig) (matcher func(source strin
 	}, nil
 }
 
-func loginputToFilestreamKey(key, filestreamID string) string {
-	return strings.ReplaceAll(key, loginputPrefix, fmt.Sprintf("filestream::%s::", filestreamID))
-}
-
 // conversion from the log input type to the filestream input type
 func loginputToFilestream(value mapstr.M) mapstr.M {
 	newValue := make(mapstr.M)
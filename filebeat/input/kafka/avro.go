@@ -0,0 +1,374 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// avroSchema is a parsed Avro schema, able to decode the values written with
+// it in the Avro binary encoding.
+type avroSchema struct {
+	typ string
+
+	// name is the full name of named types: records, enums and fixed.
+	name string
+
+	fields  []avroField   // record
+	symbols []string      // enum
+	size    int           // fixed
+	items   *avroSchema   // array
+	values  *avroSchema   // map
+	union   []*avroSchema // union
+
+	logicalType string
+}
+
+type avroField struct {
+	name   string
+	schema *avroSchema
+}
+
+// avroNames holds the named types of a schema and of the schemas it
+// references, by full name.
+type avroNames map[string]*avroSchema
+
+var errAvroTruncated = errors.New("avro value is truncated")
+
+// parseAvroSchema parses the JSON representation of an Avro schema. The
+// named types it defines are added to names, so that the schemas referencing
+// them can be parsed afterwards.
+func parseAvroSchema(schema string, names avroNames) (*avroSchema, error) {
+	var raw interface{}
+	if err := json.Unmarshal([]byte(schema), &raw); err != nil {
+		return nil, fmt.Errorf("invalid avro schema: %w", err)
+	}
+	return names.parse(raw, "")
+}
+
+func (names avroNames) parse(raw interface{}, namespace string) (*avroSchema, error) {
+	switch v := raw.(type) {
+	case string:
+		switch v {
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+			return &avroSchema{typ: v}, nil
+		}
+		if s, ok := names[fullName(v, namespace)]; ok {
+			return s, nil
+		}
+		if s, ok := names[v]; ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("unknown avro type %q", v)
+
+	case []interface{}:
+		s := &avroSchema{typ: "union"}
+		for _, branch := range v {
+			b, err := names.parse(branch, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.union = append(s.union, b)
+		}
+		return s, nil
+
+	case map[string]interface{}:
+		return names.parseComplex(v, namespace)
+
+	default:
+		return nil, fmt.Errorf("invalid avro schema %v", raw)
+	}
+}
+
+func (names avroNames) parseComplex(v map[string]interface{}, namespace string) (*avroSchema, error) {
+	typ, ok := v["type"].(string)
+	if !ok {
+		// The type is itself a schema, like {"type": {"type": "array", ...}}.
+		return names.parse(v["type"], namespace)
+	}
+	logicalType, _ := v["logicalType"].(string)
+
+	switch typ {
+	case "record", "error", "enum", "fixed":
+		name, _ := v["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("avro %s has no name", typ)
+		}
+		if ns, ok := v["namespace"].(string); ok && !strings.Contains(name, ".") {
+			namespace = ns
+		}
+		s := &avroSchema{typ: typ, name: fullName(name, namespace), logicalType: logicalType}
+		if i := strings.LastIndexByte(s.name, '.'); i >= 0 {
+			namespace = s.name[:i]
+		}
+		// The type is registered before its fields are parsed, as they can
+		// reference it.
+		names[s.name] = s
+
+		switch typ {
+		case "enum":
+			symbols, _ := v["symbols"].([]interface{})
+			for _, sym := range symbols {
+				str, _ := sym.(string)
+				s.symbols = append(s.symbols, str)
+			}
+		case "fixed":
+			size, _ := v["size"].(float64)
+			s.size = int(size)
+		default:
+			s.typ = "record"
+			fields, _ := v["fields"].([]interface{})
+			for _, f := range fields {
+				field, _ := f.(map[string]interface{})
+				fieldName, _ := field["name"].(string)
+				fieldSchema, err := names.parse(field["type"], namespace)
+				if err != nil {
+					return nil, fmt.Errorf("invalid type of field %s of %s: %w", fieldName, s.name, err)
+				}
+				s.fields = append(s.fields, avroField{name: fieldName, schema: fieldSchema})
+			}
+		}
+		return s, nil
+
+	case "array":
+		items, err := names.parse(v["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &avroSchema{typ: typ, items: items, logicalType: logicalType}, nil
+
+	case "map":
+		values, err := names.parse(v["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &avroSchema{typ: typ, values: values, logicalType: logicalType}, nil
+
+	default:
+		s, err := names.parse(typ, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if logicalType == "" {
+			return s, nil
+		}
+		// Copy primitive types, to not share their logical type.
+		annotated := *s
+		annotated.logicalType = logicalType
+		return &annotated, nil
+	}
+}
+
+func fullName(name, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// decode decodes a value of the schema. Records and maps are decoded as
+// maps, arrays as slices, enums as their symbol, unions as the value of their
+// branch, and bytes and fixed as base64 strings. The timestamp-millis and
+// timestamp-micros logical types are decoded as times.
+func (s *avroSchema) decode(data []byte) (interface{}, error) {
+	d := avroDecoder{data: data}
+	v, err := d.decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.data) != 0 {
+		return nil, fmt.Errorf("%d bytes left after the avro value", len(d.data))
+	}
+	return v, nil
+}
+
+type avroDecoder struct {
+	data []byte
+}
+
+func (d *avroDecoder) decode(s *avroSchema) (interface{}, error) {
+	switch s.typ {
+	case "null":
+		return nil, nil
+	case "boolean":
+		if len(d.data) < 1 {
+			return nil, errAvroTruncated
+		}
+		b := d.data[0] != 0
+		d.data = d.data[1:]
+		return b, nil
+	case "int", "long":
+		n, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		switch s.logicalType {
+		case "timestamp-millis":
+			return time.UnixMilli(n).UTC(), nil
+		case "timestamp-micros":
+			return time.UnixMicro(n).UTC(), nil
+		}
+		if s.typ == "int" {
+			return int32(n), nil
+		}
+		return n, nil
+	case "float":
+		if len(d.data) < 4 {
+			return nil, errAvroTruncated
+		}
+		f := math.Float32frombits(binary.LittleEndian.Uint32(d.data))
+		d.data = d.data[4:]
+		return f, nil
+	case "double":
+		if len(d.data) < 8 {
+			return nil, errAvroTruncated
+		}
+		f := math.Float64frombits(binary.LittleEndian.Uint64(d.data))
+		d.data = d.data[8:]
+		return f, nil
+	case "bytes":
+		b, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case "string":
+		b, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case "fixed":
+		b, err := d.next(s.size)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case "enum":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.symbols)) {
+			return nil, fmt.Errorf("invalid symbol %d of avro enum %s", i, s.name)
+		}
+		return s.symbols[i], nil
+	case "union":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.union)) {
+			return nil, fmt.Errorf("invalid avro union branch %d", i)
+		}
+		return d.decode(s.union[i])
+	case "record":
+		record := make(map[string]interface{}, len(s.fields))
+		for _, f := range s.fields {
+			v, err := d.decode(f.schema)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			record[f.name] = v
+		}
+		return record, nil
+	case "array":
+		items := []interface{}{}
+		err := d.blocks(func() error {
+			v, err := d.decode(s.items)
+			items = append(items, v)
+			return err
+		})
+		return items, err
+	case "map":
+		values := map[string]interface{}{}
+		err := d.blocks(func() error {
+			k, err := d.bytes()
+			if err != nil {
+				return err
+			}
+			v, err := d.decode(s.values)
+			values[string(k)] = v
+			return err
+		})
+		return values, err
+	default:
+		return nil, fmt.Errorf("unsupported avro type %s", s.typ)
+	}
+}
+
+// blocks decodes the blocks of items of arrays and maps.
+func (d *avroDecoder) blocks(item func() error) error {
+	for {
+		n, err := d.long()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if n < 0 {
+			// A negative count is followed by the size of the block.
+			n = -n
+			if _, err := d.long(); err != nil {
+				return err
+			}
+		}
+		for ; n > 0; n-- {
+			if err := item(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (d *avroDecoder) long() (int64, error) {
+	n, size := binary.Varint(d.data)
+	if size <= 0 {
+		return 0, errAvroTruncated
+	}
+	d.data = d.data[size:]
+	return n, nil
+}
+
+func (d *avroDecoder) bytes() ([]byte, error) {
+	n, err := d.long()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("invalid avro length %d", n)
+	}
+	return d.next(int(n))
+}
+
+func (d *avroDecoder) next(n int) ([]byte, error) {
+	if n > len(d.data) {
+		return nil, errAvroTruncated
+	}
+	b := d.data[:n:n]
+	d.data = d.data[n:]
+	return b, nil
+}
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package kafka
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// avroEncoder writes values in the Avro binary encoding.
+type avroEncoder []byte
+
+func (e avroEncoder) long(n int64) avroEncoder { return binary.AppendVarint(e, n) }
+
+func (e avroEncoder) str(s string) avroEncoder { return append(e.long(int64(len(s))), s...) }
+
+func (e avroEncoder) double(f float64) avroEncoder {
+	return binary.LittleEndian.AppendUint64(e, math.Float64bits(f))
+}
+
+const testAvroSchema = `{
+	"type": "record",
+	"name": "Order",
+	"namespace": "com.example",
+	"fields": [
+		{"name": "id", "type": "string"},
+		{"name": "quantity", "type": "int"},
+		{"name": "price", "type": "double"},
+		{"name": "paid", "type": "boolean"},
+		{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "SHIPPED"]}},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "attributes", "type": {"type": "map", "values": "long"}},
+		{"name": "coupon", "type": ["null", "string"]},
+		{"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "parent", "type": ["null", "Order"]}
+	]
+}`
+
+func encodeTestOrder(e avroEncoder, id string, parent bool) avroEncoder {
+	e = e.str(id).long(3).double(9.5)
+	e = append(e, 1)                        // paid
+	e = e.long(1)                           // status
+	e = e.long(2).str("a").str("b").long(0) // tags
+	e = e.long(-1).long(6).str("k").long(7) // attributes, with a block size
+	e = e.long(0)                           // attributes end
+	e = e.long(1).str("SAVE")               // coupon
+	e = e.long(1700000000123)               // created
+	if parent {
+		return encodeTestOrder(e.long(1), "parent", false)
+	}
+	return e.long(0)
+}
+
+func TestAvroDecode(t *testing.T) {
+	schema, err := parseAvroSchema(testAvroSchema, avroNames{})
+	require.NoError(t, err)
+
+	v, err := schema.decode(encodeTestOrder(nil, "o-1", true))
+	require.NoError(t, err)
+
+	created := time.UnixMilli(1700000000123).UTC()
+	parent := map[string]interface{}{
+		"id":         "parent",
+		"quantity":   int32(3),
+		"price":      9.5,
+		"paid":       true,
+		"status":     "SHIPPED",
+		"tags":       []interface{}{"a", "b"},
+		"attributes": map[string]interface{}{"k": int64(7)},
+		"coupon":     "SAVE",
+		"created":    created,
+		"parent":     nil,
+	}
+	order := map[string]interface{}{}
+	for k, v := range parent {
+		order[k] = v
+	}
+	order["id"] = "o-1"
+	order["parent"] = parent
+	assert.Equal(t, order, v)
+}
+
+func TestAvroDecodeErrors(t *testing.T) {
+	schema, err := parseAvroSchema(testAvroSchema, avroNames{})
+	require.NoError(t, err)
+
+	data := encodeTestOrder(nil, "o-1", false)
+	_, err = schema.decode(data[:len(data)-2])
+	assert.Error(t, err, "truncated value")
+
+	_, err = schema.decode(append(data, 0))
+	assert.Error(t, err, "trailing data")
+
+	_, err = parseAvroSchema(`{"type": "record", "name": "A", "fields": [{"name": "b", "type": "B"}]}`, avroNames{})
+	assert.ErrorContains(t, err, `unknown avro type "B"`)
+}
+
+func TestAvroReferences(t *testing.T) {
+	names := avroNames{}
+	_, err := parseAvroSchema(`{"type": "enum", "name": "Status", "namespace": "com.example", "symbols": ["NEW", "SHIPPED"]}`, names)
+	require.NoError(t, err)
+
+	schema, err := parseAvroSchema(`{"type": "record", "name": "com.example.Order", "fields": [{"name": "status", "type": "Status"}]}`, names)
+	require.NoError(t, err)
+
+	v, err := schema.decode(avroEncoder(nil).long(0))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"status": "NEW"}, v)
+}
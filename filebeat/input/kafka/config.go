@@ -55,6 +55,13 @@ type kafkaInputConfig struct {
 	Sasl                     kafka.SaslConfig  `config:"sasl"`
 	ExpandEventListFromField string            `config:"expand_event_list_from_field"`
 	Parsers                  parser.Config     `config:",inline"`
+
+	// ValueDeserializer decodes the message values serialized with a schema
+	// of a schema registry.
+	ValueDeserializer *deserializerConfig `config:"value_deserializer"`
+	// HeaderFields maps message headers to the event fields their value is
+	// written to.
+	HeaderFields map[string]string `config:"header_fields"`
 }
 
 type kafkaFetch struct {
@@ -145,6 +152,10 @@ func (c *kafkaInputConfig) Validate() error {
 	if c.Username != "" && c.Password == "" {
 		return fmt.Errorf("password must be set when username is configured")
 	}
+
+	if c.ValueDeserializer != nil && c.ExpandEventListFromField != "" {
+		return errors.New("expand_event_list_from_field can't be used with value_deserializer")
+	}
 	return nil
 }
 
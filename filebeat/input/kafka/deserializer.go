@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// maxSchemaReferenceDepth limits the depth of the references followed when
+// resolving a schema, to stop at reference cycles.
+const maxSchemaReferenceDepth = 16
+
+// wireFormatMagic is the first byte of the messages serialized with a schema
+// of the schema registry, followed by the 4 bytes of the schema ID.
+const wireFormatMagic = 0
+
+type deserializerConfig struct {
+	Type           string               `config:"type" validate:"required"`
+	SchemaRegistry schemaRegistryConfig `config:"schema_registry"`
+	TargetField    string               `config:"target_field"`
+}
+
+const (
+	deserializerAvro     = "avro"
+	deserializerProtobuf = "protobuf"
+)
+
+func (c *deserializerConfig) Validate() error {
+	switch c.Type {
+	case deserializerAvro, deserializerProtobuf:
+		return nil
+	default:
+		return fmt.Errorf("unsupported value_deserializer type %q, must be avro or protobuf", c.Type)
+	}
+}
+
+// schemaDecoder decodes the payload of the messages serialized with a schema.
+type schemaDecoder interface {
+	decode(payload []byte) (interface{}, error)
+}
+
+// valueDeserializer decodes the values of the messages serialized in the
+// wire format of the Confluent serializers, with the schema of the schema
+// registry they reference.
+type valueDeserializer struct {
+	registry   *schemaRegistry
+	newDecoder func(ctx context.Context, registry *schemaRegistry, id uint32) (schemaDecoder, error)
+
+	mu       sync.Mutex
+	decoders map[uint32]schemaDecoder
+}
+
+func newValueDeserializer(config deserializerConfig, log *logp.Logger) (*valueDeserializer, error) {
+	registry, err := newSchemaRegistry(config.SchemaRegistry, log)
+	if err != nil {
+		return nil, err
+	}
+	d := &valueDeserializer{
+		registry: registry,
+		decoders: map[uint32]schemaDecoder{},
+	}
+	switch config.Type {
+	case deserializerAvro:
+		d.newDecoder = newAvroDecoder
+	case deserializerProtobuf:
+		d.newDecoder = newProtobufDecoder
+	}
+	return d, nil
+}
+
+// deserialize decodes a message value. The schemas are fetched from the
+// registry the first time they are used, schemas that fail to be fetched are
+// fetched again with the next message using them.
+func (d *valueDeserializer) deserialize(ctx context.Context, value []byte) (interface{}, error) {
+	if len(value) < 5 || value[0] != wireFormatMagic {
+		return nil, errors.New("value is not serialized with a schema registry schema")
+	}
+	id := binary.BigEndian.Uint32(value[1:5])
+
+	d.mu.Lock()
+	decoder, ok := d.decoders[id]
+	d.mu.Unlock()
+	if !ok {
+		var err error
+		decoder, err = d.newDecoder(ctx, d.registry, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schema %d: %w", id, err)
+		}
+		d.mu.Lock()
+		d.decoders[id] = decoder
+		d.mu.Unlock()
+	}
+
+	v, err := decoder.decode(value[5:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode value with schema %d: %w", id, err)
+	}
+	return v, nil
+}
+
+// newAvroDecoder returns the Avro schema with the given ID, once the named
+// types of the schemas it references are parsed.
+func newAvroDecoder(ctx context.Context, registry *schemaRegistry, id uint32) (schemaDecoder, error) {
+	schema, err := registry.schemaByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if schema.SchemaType != "" && schema.SchemaType != "AVRO" {
+		return nil, fmt.Errorf("schema is a %s schema, not an Avro schema", schema.SchemaType)
+	}
+
+	names := avroNames{}
+	if err := parseAvroReferences(ctx, registry, schema.References, names, 0); err != nil {
+		return nil, err
+	}
+	return parseAvroSchema(schema.Schema, names)
+}
+
+func parseAvroReferences(ctx context.Context, registry *schemaRegistry, refs []schemaReference, names avroNames, depth int) error {
+	if depth > maxSchemaReferenceDepth {
+		return errors.New("too many nested schema references")
+	}
+	for _, ref := range refs {
+		if _, ok := names[ref.Name]; ok {
+			continue
+		}
+		schema, err := registry.schemaByReference(ctx, ref, false)
+		if err != nil {
+			return err
+		}
+		if err := parseAvroReferences(ctx, registry, schema.References, names, depth+1); err != nil {
+			return err
+		}
+		if _, err := parseAvroSchema(schema.Schema, names); err != nil {
+			return fmt.Errorf("invalid referenced schema %s: %w", ref.Name, err)
+		}
+	}
+	return nil
+}
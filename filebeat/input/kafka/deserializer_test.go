@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// testOrderProto is the descriptor of:
+//
+//	syntax = "proto3";
+//	package example;
+//	import "google/protobuf/timestamp.proto";
+//	message Order {
+//	  string id = 1;
+//	  int32 quantity = 2;
+//	  Item item = 3;
+//	  google.protobuf.Timestamp created = 4;
+//	  message Item { string sku = 1; }
+//	}
+var testOrderProto = &descriptorpb.FileDescriptorProto{
+	Name:       proto.String("order.proto"),
+	Package:    proto.String("example"),
+	Syntax:     proto.String("proto3"),
+	Dependency: []string{"google/protobuf/timestamp.proto"},
+	MessageType: []*descriptorpb.DescriptorProto{{
+		Name: proto.String("Order"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("id"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			{Name: proto.String("quantity"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			{Name: proto.String("item"), Number: proto.Int32(3), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".example.Order.Item"), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			{Name: proto.String("created"), Number: proto.Int32(4), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".google.protobuf.Timestamp"), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+		},
+		NestedType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Item"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("sku"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			},
+		}},
+	}},
+}
+
+// newTestRegistry serves the schemas by ID, and counts the requests.
+func newTestRegistry(t *testing.T, schemas map[string]registrySchema) (*schemaRegistry, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		user, pass, _ := r.BasicAuth()
+		if user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		schema, ok := schemas[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if schema.SchemaType == "PROTOBUF" && r.URL.Query().Get("format") != "serialized" {
+			http.Error(w, "only serialized schemas", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(schema)
+	}))
+	t.Cleanup(server.Close)
+
+	config := schemaRegistryConfig{URL: server.URL, Username: "user", Password: "pass"}
+	registry, err := newSchemaRegistry(config, logp.NewLogger(pluginName))
+	require.NoError(t, err)
+	return registry, &requests
+}
+
+func wireFormat(id uint32, payload []byte) []byte {
+	return append(binary.BigEndian.AppendUint32([]byte{wireFormatMagic}, id), payload...)
+}
+
+func TestDeserializeAvro(t *testing.T) {
+	registry, requests := newTestRegistry(t, map[string]registrySchema{
+		"/schemas/ids/7": {
+			Schema:     `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}, {"name": "status", "type": "Status"}]}`,
+			References: []schemaReference{{Name: "com.example.Status", Subject: "status", Version: 2}},
+		},
+		"/subjects/status/versions/2": {
+			Schema: `{"type": "enum", "name": "Status", "namespace": "com.example", "symbols": ["NEW", "SHIPPED"]}`,
+		},
+	})
+	d := &valueDeserializer{registry: registry, newDecoder: newAvroDecoder, decoders: map[uint32]schemaDecoder{}}
+
+	for i := 0; i < 2; i++ {
+		v, err := d.deserialize(context.Background(), wireFormat(7, avroEncoder(nil).str("o-1").long(1)))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": "o-1", "status": "SHIPPED"}, v)
+	}
+	assert.EqualValues(t, 2, requests.Load(), "schemas must be fetched once")
+
+	_, err := d.deserialize(context.Background(), wireFormat(8, nil))
+	assert.ErrorContains(t, err, "failed to load schema 8")
+
+	_, err = d.deserialize(context.Background(), []byte(`{"id": "o-1"}`))
+	assert.ErrorContains(t, err, "not serialized with a schema registry schema")
+}
+
+func TestDeserializeProtobuf(t *testing.T) {
+	data, err := proto.Marshal(testOrderProto)
+	require.NoError(t, err)
+	registry, _ := newTestRegistry(t, map[string]registrySchema{
+		"/schemas/ids/3": {Schema: base64.StdEncoding.EncodeToString(data), SchemaType: "PROTOBUF"},
+	})
+	d := &valueDeserializer{registry: registry, newDecoder: newProtobufDecoder, decoders: map[uint32]schemaDecoder{}}
+
+	file, err := protodesc.NewFile(testOrderProto, protobufResolver{files: &protoregistry.Files{}})
+	require.NoError(t, err)
+	orderDesc := file.Messages().Get(0)
+	itemDesc := orderDesc.Messages().Get(0)
+
+	item := dynamicpb.NewMessage(itemDesc)
+	item.Set(itemDesc.Fields().ByName("sku"), protoreflect.ValueOfString("sku-1"))
+	order := dynamicpb.NewMessage(orderDesc)
+	order.Set(orderDesc.Fields().ByName("id"), protoreflect.ValueOfString("o-1"))
+	order.Set(orderDesc.Fields().ByName("quantity"), protoreflect.ValueOfInt32(3))
+	order.Set(orderDesc.Fields().ByName("item"), protoreflect.ValueOfMessage(item))
+
+	t.Run("first message", func(t *testing.T) {
+		payload, err := proto.Marshal(order)
+		require.NoError(t, err)
+		v, err := d.deserialize(context.Background(), wireFormat(3, append(binary.AppendVarint(nil, 0), payload...)))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"id":       "o-1",
+			"quantity": float64(3),
+			"item":     map[string]interface{}{"sku": "sku-1"},
+		}, v)
+	})
+
+	t.Run("nested message", func(t *testing.T) {
+		payload, err := proto.Marshal(item)
+		require.NoError(t, err)
+		indexes := binary.AppendVarint(binary.AppendVarint(binary.AppendVarint(nil, 2), 0), 0)
+		v, err := d.deserialize(context.Background(), wireFormat(3, append(indexes, payload...)))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"sku": "sku-1"}, v)
+	})
+
+	t.Run("invalid index", func(t *testing.T) {
+		indexes := binary.AppendVarint(binary.AppendVarint(nil, 1), 4)
+		_, err := d.deserialize(context.Background(), wireFormat(3, indexes))
+		assert.ErrorContains(t, err, "invalid message index")
+	})
+}
+
+func TestComposeDeserializedMessage(t *testing.T) {
+	registry, _ := newTestRegistry(t, map[string]registrySchema{
+		"/schemas/ids/1": {Schema: `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`},
+	})
+	newHandler := func(targetField string) *groupHandler {
+		return &groupHandler{
+			deserializer: &valueDeserializer{registry: registry, newDecoder: newAvroDecoder, decoders: map[uint32]schemaDecoder{}},
+			targetField:  targetField,
+			headerFields: map[string]string{"trace-id": "trace.id"},
+			log:          logp.NewLogger(pluginName),
+		}
+	}
+	value := wireFormat(1, avroEncoder(nil).str("o-1"))
+	headers := []*sarama.RecordHeader{{Key: []byte("trace-id"), Value: []byte("abc")}, {Key: []byte("other"), Value: []byte("x")}}
+	kafkaFields := mapstr.M{"topic": "orders"}
+
+	h := newHandler("")
+	message := h.composeDeserializedMessage(time.Now(), value, kafkaFields, func() {})
+	h.mapHeaders(headers, message.Fields)
+	assert.Equal(t, mapstr.M{
+		"id":    "o-1",
+		"kafka": kafkaFields,
+		"trace": mapstr.M{"id": "abc"},
+	}, message.Fields)
+	assert.JSONEq(t, `{"id": "o-1"}`, string(message.Content))
+
+	message = newHandler("order").composeDeserializedMessage(time.Now(), value, kafkaFields, func() {})
+	assert.Equal(t, mapstr.M{
+		"order": map[string]interface{}{"id": "o-1"},
+		"kafka": kafkaFields,
+	}, message.Fields)
+
+	message = newHandler("").composeDeserializedMessage(time.Now(), []byte("plain"), kafkaFields, func() {})
+	assert.Equal(t, "plain", message.Fields["message"])
+	errMsg, err := message.Fields.GetValue("error.message")
+	require.NoError(t, err)
+	assert.Contains(t, errMsg, "not serialized with a schema registry schema")
+}
@@ -72,13 +72,22 @@ func configure(cfg *conf.C) (input.Input, error) {
 }
 
 func NewInput(config kafkaInputConfig, saramaConfig *sarama.Config) (*kafkaInput, error) {
-	return &kafkaInput{config: config, saramaConfig: saramaConfig}, nil
+	input := &kafkaInput{config: config, saramaConfig: saramaConfig}
+	if config.ValueDeserializer != nil {
+		deserializer, err := newValueDeserializer(*config.ValueDeserializer, logp.NewLogger(pluginName))
+		if err != nil {
+			return nil, err
+		}
+		input.deserializer = deserializer
+	}
+	return input, nil
 }
 
 type kafkaInput struct {
 	config          kafkaInputConfig
 	saramaConfig    *sarama.Config
 	saramaWaitGroup sync.WaitGroup // indicates a sarama consumer group is active
+	deserializer    *valueDeserializer
 }
 
 func (input *kafkaInput) Name() string { return pluginName }
@@ -196,8 +205,13 @@ func (input *kafkaInput) runConsumerGroup(log *logp.Logger, client beat.Client,
 		parsers: input.config.Parsers,
 		// expandEventListFromField will be assigned the configuration option expand_event_list_from_field
 		expandEventListFromField: input.config.ExpandEventListFromField,
+		deserializer:             input.deserializer,
+		headerFields:             input.config.HeaderFields,
 		log:                      log,
 	}
+	if input.config.ValueDeserializer != nil {
+		handler.targetField = input.config.ValueDeserializer.TargetField
+	}
 
 	input.saramaWaitGroup.Add(1)
 	defer func() {
@@ -280,7 +294,14 @@ type groupHandler struct {
 	// if the fileset using this input expects to receive multiple messages bundled under a specific field then this value is assigned
 	// ex. in this case are the azure fielsets where the events are found under the json object "records"
 	expandEventListFromField string // TODO
-	log                      *logp.Logger
+	// deserializer decodes the message values, when they are serialized
+	// with a schema registry schema. The decoded values are written to
+	// targetField, or to the root of the event when it is not set.
+	deserializer *valueDeserializer
+	targetField  string
+	// headerFields maps message headers to event fields.
+	headerFields map[string]string
+	log          *logp.Logger
 }
 
 func (h *groupHandler) Setup(session sarama.ConsumerGroupSession) error {
@@ -364,7 +385,14 @@ func (m *recordReader) Next() (reader.Message, error) {
 	ackHandler := func() {
 		m.groupHandler.ack(msg)
 	}
-	return composeMessage(timestamp, msg.Value, kafkaFields, ackHandler), nil
+	var message reader.Message
+	if m.groupHandler.deserializer != nil {
+		message = m.groupHandler.composeDeserializedMessage(timestamp, msg.Value, kafkaFields, ackHandler)
+	} else {
+		message = composeMessage(timestamp, msg.Value, kafkaFields, ackHandler)
+	}
+	m.groupHandler.mapHeaders(msg.Headers, message.Fields)
+	return message, nil
 }
 
 type listFromFieldReader struct {
@@ -399,7 +427,9 @@ func (l *listFromFieldReader) Next() (reader.Message, error) {
 		}
 	}
 	for _, message := range messages {
-		newBuffer := append(l.buffer, composeMessage(timestamp, []byte(message), kafkaFields, ackHandler))
+		composed := composeMessage(timestamp, []byte(message), kafkaFields, ackHandler)
+		l.groupHandler.mapHeaders(msg.Headers, composed.Fields)
+		newBuffer := append(l.buffer, composed)
 		l.buffer = newBuffer
 	}
 
@@ -469,6 +499,66 @@ func composeMessage(timestamp time.Time, content []byte, kafkaFields mapstr.M, a
 	}
 }
 
+// composeDeserializedMessage composes the message of a value serialized with
+// a schema registry schema. Values that can't be decoded are published as
+// is, with the error.
+func (h *groupHandler) composeDeserializedMessage(timestamp time.Time, value []byte, kafkaFields mapstr.M, ackHandler func()) reader.Message {
+	decoded, err := h.deserializer.deserialize(h.sessionContext(), value)
+	var content []byte
+	if err == nil {
+		content, err = json.Marshal(decoded)
+	}
+	if err != nil {
+		h.log.Errorw("Failed to deserialize message value", "error", err,
+			"topic", kafkaFields["topic"], "partition", kafkaFields["partition"], "offset", kafkaFields["offset"])
+		message := composeMessage(timestamp, value, kafkaFields, ackHandler)
+		_, _ = message.Fields.Put("error.message", err.Error())
+		return message
+	}
+
+	fields := mapstr.M{}
+	if obj, ok := decoded.(map[string]interface{}); ok && h.targetField == "" {
+		fields = obj
+	} else if h.targetField != "" {
+		_, _ = fields.Put(h.targetField, decoded)
+	} else {
+		fields["message"] = decoded
+	}
+	fields["kafka"] = kafkaFields
+
+	return reader.Message{
+		Ts:      timestamp,
+		Content: content,
+		Fields:  fields,
+		Private: eventMeta{
+			ackHandler: ackHandler,
+		},
+	}
+}
+
+// mapHeaders writes the value of the headers mapped with header_fields to
+// their event field.
+func (h *groupHandler) mapHeaders(headers []*sarama.RecordHeader, fields mapstr.M) {
+	if len(h.headerFields) == 0 {
+		return
+	}
+	for _, header := range headers {
+		if field, ok := h.headerFields[string(header.Key)]; ok {
+			_, _ = fields.Put(field, string(header.Value))
+		}
+	}
+}
+
+// sessionContext returns the context of the current consumer group session.
+func (h *groupHandler) sessionContext() context.Context {
+	h.Lock()
+	defer h.Unlock()
+	if h.session == nil {
+		return context.Background()
+	}
+	return h.session.Context()
+}
+
 func contains(elements []string, element string) bool {
 	for _, e := range elements {
 		if e == element {
@@ -0,0 +1,190 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Register the well-known types, imported by the schemas without being
+	// referenced in the registry.
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// protobufDecoder decodes the messages of a protobuf schema.
+type protobufDecoder struct {
+	file protoreflect.FileDescriptor
+}
+
+// newProtobufDecoder returns a decoder for the protobuf schema with the given
+// ID. The schema and the files it imports are fetched as serialized file
+// descriptors, as the registry doesn't require its clients to parse .proto
+// files.
+func newProtobufDecoder(ctx context.Context, registry *schemaRegistry, id uint32) (schemaDecoder, error) {
+	schema, err := registry.schemaByID(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if schema.SchemaType != "PROTOBUF" {
+		return nil, fmt.Errorf("schema is not a protobuf schema")
+	}
+
+	files := &protoregistry.Files{}
+	if err := registerProtobufReferences(ctx, registry, schema.References, files, 0); err != nil {
+		return nil, err
+	}
+	file, err := newProtobufFile(schema, fmt.Sprintf("schema-%d.proto", id), files)
+	if err != nil {
+		return nil, err
+	}
+	return &protobufDecoder{file: file}, nil
+}
+
+func registerProtobufReferences(ctx context.Context, registry *schemaRegistry, refs []schemaReference, files *protoregistry.Files, depth int) error {
+	if depth > maxSchemaReferenceDepth {
+		return errors.New("too many nested schema references")
+	}
+	for _, ref := range refs {
+		if _, err := files.FindFileByPath(ref.Name); err == nil {
+			continue
+		}
+		schema, err := registry.schemaByReference(ctx, ref, true)
+		if err != nil {
+			return err
+		}
+		if err := registerProtobufReferences(ctx, registry, schema.References, files, depth+1); err != nil {
+			return err
+		}
+		file, err := newProtobufFile(schema, ref.Name, files)
+		if err != nil {
+			return fmt.Errorf("invalid referenced schema %s: %w", ref.Name, err)
+		}
+		if err := files.RegisterFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newProtobufFile builds the file descriptor of a serialized schema. Its
+// imports are resolved with files, then with the well-known types.
+func newProtobufFile(schema registrySchema, path string, files *protoregistry.Files) (protoreflect.FileDescriptor, error) {
+	data, err := base64.StdEncoding.DecodeString(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("schema is not serialized: %w", err)
+	}
+	var fdp descriptorpb.FileDescriptorProto
+	if err := proto.Unmarshal(data, &fdp); err != nil {
+		return nil, fmt.Errorf("invalid file descriptor: %w", err)
+	}
+	// Files are imported by the name of their reference, which doesn't need to
+	// match the name of their descriptor.
+	fdp.Name = proto.String(path)
+	return protodesc.NewFile(&fdp, protobufResolver{files})
+}
+
+// protobufResolver resolves the imports of a file with the files of the
+// schema references first, then with the globally registered files.
+type protobufResolver struct {
+	files *protoregistry.Files
+}
+
+func (r protobufResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r protobufResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// decode decodes a message. The payload starts with the indexes of the
+// message type in the file, as nested messages, the first message of the
+// file being written as a single 0.
+func (d *protobufDecoder) decode(payload []byte) (interface{}, error) {
+	indexes, payload, err := readMessageIndexes(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := d.file.Messages()
+	var md protoreflect.MessageDescriptor
+	for _, i := range indexes {
+		if i < 0 || i >= int64(messages.Len()) {
+			return nil, fmt.Errorf("invalid message index %v", indexes)
+		}
+		md = messages.Get(int(i))
+		messages = md.Messages()
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, fmt.Errorf("invalid %s message: %w", md.FullName(), err)
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func readMessageIndexes(payload []byte) ([]int64, []byte, error) {
+	n, size := binary.Varint(payload)
+	if size <= 0 || n < 0 || n > int64(len(payload)) {
+		return nil, nil, errors.New("invalid message indexes")
+	}
+	payload = payload[size:]
+	if n == 0 {
+		return []int64{0}, payload, nil
+	}
+	indexes := make([]int64, n)
+	for i := range indexes {
+		indexes[i], size = binary.Varint(payload)
+		if size <= 0 {
+			return nil, nil, errors.New("invalid message indexes")
+		}
+		payload = payload[size:]
+	}
+	return indexes, payload, nil
+}
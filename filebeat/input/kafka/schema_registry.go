@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+type schemaRegistryConfig struct {
+	URL       string                           `config:"url" validate:"required"`
+	Username  string                           `config:"username"`
+	Password  string                           `config:"password"`
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+// schemaRegistry is a client of the Confluent Schema Registry API.
+type schemaRegistry struct {
+	url      string
+	username string
+	password string
+	client   *http.Client
+}
+
+// registrySchema is a schema returned by the schema registry.
+type registrySchema struct {
+	Schema     string            `json:"schema"`
+	SchemaType string            `json:"schemaType"`
+	References []schemaReference `json:"references"`
+}
+
+// schemaReference is a reference of a schema to a type defined by another
+// schema, or for protobuf, to an imported file.
+type schemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+func newSchemaRegistry(config schemaRegistryConfig, log *logp.Logger) (*schemaRegistry, error) {
+	client, err := config.Transport.Client(httpcommon.WithLogger(log))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the schema registry client: %w", err)
+	}
+	return &schemaRegistry{
+		url:      strings.TrimSuffix(config.URL, "/"),
+		username: config.Username,
+		password: config.Password,
+		client:   client,
+	}, nil
+}
+
+// schemaByID returns the schema with the given ID. Protobuf schemas are
+// returned as a serialized file descriptor when serialized is set.
+func (r *schemaRegistry) schemaByID(ctx context.Context, id uint32, serialized bool) (registrySchema, error) {
+	return r.get(ctx, "/schemas/ids/"+strconv.FormatUint(uint64(id), 10), serialized)
+}
+
+// schemaByReference returns the schema a reference points to.
+func (r *schemaRegistry) schemaByReference(ctx context.Context, ref schemaReference, serialized bool) (registrySchema, error) {
+	return r.get(ctx, "/subjects/"+url.PathEscape(ref.Subject)+"/versions/"+strconv.Itoa(ref.Version), serialized)
+}
+
+func (r *schemaRegistry) get(ctx context.Context, path string, serialized bool) (registrySchema, error) {
+	var schema registrySchema
+
+	u := r.url + path
+	if serialized {
+		u += "?format=serialized"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return schema, err
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return schema, fmt.Errorf("failed to get schema %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return schema, fmt.Errorf("failed to read schema %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return schema, fmt.Errorf("failed to get schema %s: status %d: %s", path, resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return schema, fmt.Errorf("invalid schema %s: %w", path, err)
+	}
+	return schema, nil
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/rcrowley/go-metrics"
@@ -41,6 +42,7 @@ type UDP struct {
 
 	monitorRegistry *monitoring.Registry
 
+	mu         sync.Mutex // protects lastPacket, Log is called by each worker of the input
 	lastPacket time.Time
 
 	device         *monitoring.String // name of the device being monitored
@@ -49,6 +51,7 @@ type UDP struct {
 	bufferLen      *monitoring.Uint   // configured read buffer length
 	rxQueue        *monitoring.Uint   // value of the rx_queue field from /proc/net/udp{,6} (only on linux systems)
 	drops          *monitoring.Uint   // number of udp drops noted in /proc/net/udp{,6}
+	overflowDrops  *monitoring.Uint   // number of drops reported by SO_RXQ_OVFL on the sockets of the workers (only on linux systems)
 	arrivalPeriod  metrics.Sample     // histogram of the elapsed time between packet arrivals
 	processingTime metrics.Sample     // histogram of the elapsed time between packet receipt and publication
 }
@@ -68,6 +71,7 @@ func NewUDP(inputName string, id string, device string, buflen uint64, poll time
 		bytes:           monitoring.NewUint(reg, "received_bytes_total"),
 		rxQueue:         monitoring.NewUint(reg, "receive_queue_length"),
 		drops:           monitoring.NewUint(reg, "system_packet_drops"),
+		overflowDrops:   monitoring.NewUint(reg, "socket_overflow_drops"),
 		arrivalPeriod:   metrics.NewUniformSample(1024),
 		processingTime:  metrics.NewUniformSample(1024),
 	}
@@ -100,10 +104,21 @@ func (m *UDP) Log(data []byte, timestamp time.Time) {
 	m.processingTime.Update(time.Since(timestamp).Nanoseconds())
 	m.packets.Add(1)
 	m.bytes.Add(uint64(len(data)))
+	m.mu.Lock()
 	if !m.lastPacket.IsZero() {
 		m.arrivalPeriod.Update(timestamp.Sub(m.lastPacket).Nanoseconds())
 	}
 	m.lastPacket = timestamp
+	m.mu.Unlock()
+}
+
+// SetOverflowDrops sets the number of packets dropped because the receive
+// queues of the sockets of the input overflowed.
+func (m *UDP) SetOverflowDrops(drops uint64) {
+	if m == nil {
+		return
+	}
+	m.overflowDrops.Set(drops)
 }
 
 // poll periodically gets UDP buffer and packet drops stats from the OS.
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipe
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+)
+
+const (
+	framingNewline        = "newline"
+	framingNUL            = "nul"
+	framingLengthPrefixed = "length_prefixed"
+)
+
+// config stores the options of a pipe input.
+type config struct {
+	// Paths are the named pipes to read.
+	Paths []string `config:"paths" validate:"required"`
+
+	// Create creates the named pipes that don't exist.
+	Create bool `config:"create"`
+
+	// Framing is how records are delimited in the pipe.
+	Framing string `config:"framing"`
+
+	// MaxMessageSize is the size of the largest record.
+	MaxMessageSize cfgtype.ByteSize `config:"max_message_size" validate:"min=1"`
+
+	// ReconnectBackoff is the time to wait for a new writer after the
+	// writer of a pipe closed it.
+	ReconnectBackoff time.Duration `config:"reconnect_backoff" validate:"min=0,nonzero"`
+}
+
+func defaultConfig() config {
+	return config{
+		Framing:          framingNewline,
+		MaxMessageSize:   cfgtype.ByteSize(10 * humanize.MiByte),
+		ReconnectBackoff: time.Second,
+	}
+}
+
+func (c *config) Validate() error {
+	if len(c.Paths) == 0 {
+		return errors.New("no paths were configured")
+	}
+	switch c.Framing {
+	case framingNewline, framingNUL, framingLengthPrefixed:
+	default:
+		return fmt.Errorf("invalid framing %q, must be newline, nul or length_prefixed", c.Framing)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// lengthPrefixSize is the size of the big endian unsigned integer holding
+// the size of the records of the length_prefixed framing.
+const lengthPrefixSize = 4
+
+// errTruncated is returned when a writer closes the pipe in the middle of a
+// length prefixed record.
+var errTruncated = errors.New("the writer closed the pipe in the middle of a record")
+
+// errTooLarge is returned when the prefix of a record is larger than
+// max_message_size.
+var errTooLarge = errors.New("record is larger than max_message_size")
+
+// splitFunc returns the split function of the framing, for records of at
+// most maxSize bytes.
+func splitFunc(framing string, maxSize int) bufio.SplitFunc {
+	switch framing {
+	case framingNUL:
+		return scanNUL
+	case framingLengthPrefixed:
+		return func(data []byte, atEOF bool) (int, []byte, error) {
+			return scanLengthPrefixed(data, atEOF, maxSize)
+		}
+	default:
+		return bufio.ScanLines
+	}
+}
+
+// scanNUL splits records delimited by NUL bytes. Data after the last NUL
+// byte is returned as a record when the writer closes the pipe.
+func scanNUL(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// scanLengthPrefixed splits records prefixed by their size.
+func scanLengthPrefixed(data []byte, atEOF bool, maxSize int) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if len(data) >= lengthPrefixSize {
+		size := int(binary.BigEndian.Uint32(data))
+		if size > maxSize {
+			return 0, nil, fmt.Errorf("%w: %d bytes", errTooLarge, size)
+		}
+		if end := lengthPrefixSize + size; len(data) >= end {
+			return end, data[lengthPrefixSize:end], nil
+		}
+	}
+	if atEOF {
+		return 0, nil, errTruncated
+	}
+	return 0, nil, nil
+}
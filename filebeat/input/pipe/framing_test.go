@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFunc(t *testing.T) {
+	lengthPrefixed := func(records ...string) string {
+		var buf bytes.Buffer
+		for _, r := range records {
+			_ = binary.Write(&buf, binary.BigEndian, uint32(len(r)))
+			buf.WriteString(r)
+		}
+		return buf.String()
+	}
+
+	tests := map[string]struct {
+		framing string
+		data    string
+		want    []string
+		err     error
+	}{
+		"newline": {
+			framing: framingNewline,
+			data:    "a\r\nb\n\nc",
+			want:    []string{"a", "b", "", "c"},
+		},
+		"nul": {
+			framing: framingNUL,
+			data:    "a\nb\x00c\x00d",
+			want:    []string{"a\nb", "c", "d"},
+		},
+		"length prefixed": {
+			framing: framingLengthPrefixed,
+			data:    lengthPrefixed("a\x00b\nc", "", "d"),
+			want:    []string{"a\x00b\nc", "", "d"},
+		},
+		"length prefixed truncated": {
+			framing: framingLengthPrefixed,
+			data:    lengthPrefixed("a", "bcd")[:8],
+			want:    []string{"a"},
+			err:     errTruncated,
+		},
+		"length prefixed too large": {
+			framing: framingLengthPrefixed,
+			data:    lengthPrefixed("a", strings.Repeat("b", 11)),
+			want:    []string{"a"},
+			err:     errTooLarge,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(tc.data))
+			scanner.Split(splitFunc(tc.framing, 10))
+			var got []string
+			for scanner.Scan() {
+				got = append(got, scanner.Text())
+			}
+			assert.Equal(t, tc.want, got)
+			if tc.err != nil {
+				require.ErrorIs(t, scanner.Err(), tc.err)
+			} else {
+				require.NoError(t, scanner.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipe
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	input "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const pluginName = "pipe"
+
+// Plugin creates the pipe input plugin.
+func Plugin(log *logp.Logger, store cursor.StateStore) input.Plugin {
+	return input.Plugin{
+		Name:       pluginName,
+		Stability:  feature.Beta,
+		Deprecated: false,
+		Info:       "named pipe input",
+		Doc:        "The pipe input reads records from named pipes, and waits for a new writer when a writer closes a pipe",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       pluginName,
+			Configure:  configure,
+		},
+	}
+}
+
+type pathSource string
+
+func (p pathSource) Name() string { return string(p) }
+
+// checkpoint is the progress of the input in a pipe. It is stored in the
+// registry once the events are acknowledged, so that the offsets of the
+// records keep increasing across restarts.
+type checkpoint struct {
+	// Offset is the number of bytes read from the pipe.
+	Offset int64
+	// Records is the number of records read from the pipe.
+	Records uint64
+	// Sessions is the number of writers that closed the pipe.
+	Sessions uint64
+}
+
+type pipeInput struct {
+	config config
+}
+
+func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, err
+	}
+
+	sources := make([]cursor.Source, len(config.Paths))
+	for i, p := range config.Paths {
+		sources[i] = pathSource(p)
+	}
+	return sources, &pipeInput{config: config}, nil
+}
+
+func (inp *pipeInput) Name() string { return pluginName }
+
+// FIPSCapable returns nil, as the input doesn't use any cryptography.
+func (inp *pipeInput) FIPSCapable() error { return nil }
+
+func (inp *pipeInput) Test(src cursor.Source, _ input.TestContext) error {
+	f, err := openPipe(src.Name(), inp.config.Create)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (inp *pipeInput) Run(ctx input.Context, src cursor.Source, crsr cursor.Cursor, publisher cursor.Publisher) error {
+	log := ctx.Logger.With("path", src.Name())
+	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
+
+	var cp checkpoint
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&cp); err != nil {
+			log.Errorf("Reset pipe checkpoint. Failed to read checkpoint from registry: %v", err)
+			cp = checkpoint{}
+		}
+	}
+
+	r := &reader{config: inp.config, path: src.Name(), log: log}
+	err := r.run(stdCtx, &cp, func(record []byte, offset int64) error {
+		event := beat.Event{
+			Timestamp: time.Now(),
+			Fields: mapstr.M{
+				"message": string(record),
+				"log": mapstr.M{
+					"offset": offset,
+					"file": mapstr.M{
+						"path": src.Name(),
+					},
+				},
+			},
+		}
+		return publisher.Publish(event, cp)
+	})
+	if stdCtx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// reader reads the records of a named pipe, one writer after the other.
+type reader struct {
+	config config
+	path   string
+	log    *logp.Logger
+}
+
+// run reads the records of the pipe until the context is cancelled. When a
+// writer closes the pipe, it waits for the next one. The checkpoint is
+// updated before each record is handled, with the offset of the record in
+// all the data read from the pipe.
+func (r *reader) run(ctx context.Context, cp *checkpoint, handle func(record []byte, offset int64) error) error {
+	for {
+		f, err := openPipe(r.path, r.config.Create)
+		if err != nil {
+			return err
+		}
+		read, err := r.readSession(ctx, f, cp, handle)
+		if read > 0 {
+			cp.Sessions++
+			r.log.Debugw("Writer closed the pipe", "records", cp.Records, "offset", cp.Offset)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			if !isSessionError(err) {
+				return err
+			}
+			r.log.Warnw("Closing the pipe, the writer is disconnected", "error", err)
+		}
+		if err := timed.Wait(ctx, r.config.ReconnectBackoff); err != nil {
+			return nil
+		}
+	}
+}
+
+// readSession reads the records of the current writer of the pipe, and
+// returns the number of bytes read.
+func (r *reader) readSession(ctx context.Context, f *os.File, cp *checkpoint, handle func(record []byte, offset int64) error) (int64, error) {
+	stop := context.AfterFunc(ctx, func() { f.Close() })
+	defer func() {
+		if stop() {
+			f.Close()
+		}
+	}()
+
+	maxSize := int(r.config.MaxMessageSize)
+	var advanced int64
+	split := splitFunc(r.config.Framing, maxSize)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSize+lengthPrefixSize+2)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		n, token, err := split(data, atEOF)
+		advanced += int64(n)
+		return n, token, err
+	})
+
+	var read int64
+	for scanner.Scan() {
+		record := scanner.Bytes()
+		if len(record) > maxSize {
+			return read, bufio.ErrTooLong
+		}
+		offset := cp.Offset
+		cp.Offset += advanced
+		read += advanced
+		advanced = 0
+		cp.Records++
+		if err := handle(record, offset); err != nil {
+			return read, err
+		}
+	}
+	return read, scanner.Err()
+}
+
+// isSessionError returns true if the error only affects the current writer
+// of the pipe.
+func isSessionError(err error) bool {
+	return errors.Is(err, bufio.ErrTooLong) || errors.Is(err, errTruncated) || errors.Is(err, errTooLarge)
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !windows
+
+package pipe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestReaderReconnects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipe")
+	r := &reader{
+		config: config{
+			Create:           true,
+			Framing:          framingNewline,
+			MaxMessageSize:   cfgtype.ByteSize(100),
+			ReconnectBackoff: 10 * time.Millisecond,
+		},
+		path: path,
+		log:  logp.NewLogger("pipe"),
+	}
+
+	type record struct {
+		message string
+		offset  int64
+	}
+	records := make(chan record, 10)
+	cp := checkpoint{Offset: 100, Records: 10}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- r.run(ctx, &cp, func(message []byte, offset int64) error {
+			records <- record{message: string(message), offset: offset}
+			return nil
+		})
+	}()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Each writer closes the pipe, the reader must wait for the next one.
+	var got []record
+	for _, session := range []struct {
+		data    string
+		records int
+	}{
+		{data: "a\nbb\n", records: 2},
+		{data: "ccc\ndd", records: 2},
+	} {
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = f.WriteString(session.data)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		for i := 0; i < session.records; i++ {
+			select {
+			case rec := <-records:
+				got = append(got, rec)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for records")
+			}
+		}
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, []record{{"a", 100}, {"bb", 102}, {"ccc", 105}, {"dd", 109}}, got)
+	assert.Equal(t, checkpoint{Offset: 111, Records: 14, Sessions: 2}, cp)
+}
@@ -18,6 +18,7 @@
 package redis
 
 import (
+	"errors"
 	"time"
 
 	"github.com/elastic/beats/v7/filebeat/harvester"
@@ -30,13 +31,59 @@ var defaultConfig = config{
 	Network:  "tcp",
 	MaxConn:  10,
 	Password: "",
+	Keyspace: keyspaceConfig{
+		Enabled:    false,
+		Patterns:   []string{"__keyevent@*__:*"},
+		SampleRate: 1,
+	},
+	Monitor: monitorConfig{
+		Enabled:     false,
+		SampleRate:  0.01,
+		MaxDuration: 10 * time.Second,
+		MaxEvents:   1000,
+	},
 }
 
 type config struct {
 	harvester.ForwarderConfig `config:",inline"`
-	Hosts                     []string      `config:"hosts" validate:"required"`
-	IdleTimeout               time.Duration `config:"idle_timeout"`
-	Network                   string        `config:"network"`
-	MaxConn                   int           `config:"maxconn" validate:"min=1"`
-	Password                  string        `config:"password"`
+	Hosts                     []string       `config:"hosts" validate:"required"`
+	IdleTimeout               time.Duration  `config:"idle_timeout"`
+	Network                   string         `config:"network"`
+	MaxConn                   int            `config:"maxconn" validate:"min=1"`
+	Password                  string         `config:"password"`
+	Keyspace                  keyspaceConfig `config:"keyspace_notifications"`
+	Monitor                   monitorConfig  `config:"monitor"`
+}
+
+// keyspaceConfig configures the consumption of Redis keyspace notifications.
+type keyspaceConfig struct {
+	Enabled bool `config:"enabled"`
+	// Events is the notify-keyspace-events value set on the server when
+	// the input subscribes. When empty the server configuration is left
+	// untouched.
+	Events     string   `config:"events"`
+	Patterns   []string `config:"patterns"`
+	SampleRate float64  `config:"sample_rate" validate:"min=0, max=1"`
+}
+
+// monitorConfig configures the sampling of the Redis MONITOR output.
+type monitorConfig struct {
+	Enabled     bool          `config:"enabled"`
+	SampleRate  float64       `config:"sample_rate" validate:"min=0, max=1"`
+	MaxDuration time.Duration `config:"max_duration" validate:"min=0"`
+	MaxEvents   int           `config:"max_events" validate:"min=0"`
+}
+
+func (c *keyspaceConfig) Validate() error {
+	if c.Enabled && len(c.Patterns) == 0 {
+		return errors.New("keyspace_notifications.patterns must not be empty")
+	}
+	return nil
+}
+
+func (c *monitorConfig) Validate() error {
+	if c.Enabled && c.MaxDuration <= 0 {
+		return errors.New("monitor.max_duration must be greater than 0")
+	}
+	return nil
 }
@@ -18,6 +18,7 @@
 package redis
 
 import (
+	"sync"
 	"time"
 
 	rd "github.com/gomodule/redigo/redis"
@@ -45,6 +46,11 @@ type Input struct {
 	config   config
 	cfg      *conf.C
 	registry *harvester.Registry
+
+	// sessions tracks the keyspace and monitor harvesters that are still
+	// running, so a new scan does not start a second one for the same host.
+	mu       sync.Mutex
+	sessions map[string]struct{}
 }
 
 // NewInput creates a new redis input
@@ -69,6 +75,7 @@ func NewInput(cfg *conf.C, connector channel.Connector, context input.Context) (
 		config:   config,
 		cfg:      cfg,
 		registry: harvester.NewRegistry(),
+		sessions: map[string]struct{}{},
 	}
 
 	return p, nil
@@ -107,43 +114,71 @@ func (p *Input) Run() {
 		if err := p.registry.Start(h); err != nil {
 			logp.Err("Harvester start failed: %s", err)
 		}
-	}
-}
 
-// Stop stops the input and all its harvesters
-func (p *Input) Stop() {
-	p.registry.Stop()
-	p.outlet.Close()
-}
+		if p.config.Keyspace.Enabled {
+			p.startSession("keyspace/"+host, func() (harvester.Harvester, error) {
+				h, err := NewKeyspaceHarvester(pool.Get(), p.config.Keyspace)
+				if err != nil {
+					return nil, err
+				}
+				h.forwarder = forwarder
+				return h, nil
+			})
+		}
 
-// Wait waits for the input to be completed. Not implemented.
-func (p *Input) Wait() {}
-
-// CreatePool creates a redis connection pool
-// NOTE: This code is copied from the redis pool handling in metricbeat
-func CreatePool(
-	host, password, network string,
-	maxConn int,
-	idleTimeout, connTimeout time.Duration,
-) *rd.Pool {
-	return &rd.Pool{
-		MaxIdle:     maxConn,
-		IdleTimeout: idleTimeout,
-		Dial: func() (rd.Conn, error) {
-			c, err := rd.Dial(network, host,
-				rd.DialConnectTimeout(connTimeout),
-				rd.DialReadTimeout(connTimeout),
-				rd.DialWriteTimeout(connTimeout))
-			if err != nil {
-				return nil, err
-			}
-			if password != "" {
-				if _, err := c.Do("AUTH", password); err != nil {
-					c.Close()
+		if p.config.Monitor.Enabled {
+			p.startSession("monitor/"+host, func() (harvester.Harvester, error) {
+				h, err := NewMonitorHarvester(pool.Get(), p.config.Monitor)
+				if err != nil {
 					return nil, err
 				}
-			}
-			return c, err
+				h.forwarder = forwarder
+				return h, nil
+			})
+		}
+	}
+}
+
+// startSession starts the harvester created by newHarvester unless a
+// harvester for the same key is still running. Keyspace subscriptions are
+// long lived and are only restarted after they fail, monitor sessions end
+// after max_duration and are restarted by the next scan.
+func (p *Input) startSession(key string, newHarvester func() (harvester.Harvester, error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, running := p.sessions[key]; running {
+		return
+	}
+
+	h, err := newHarvester()
+	if err != nil {
+		logp.Err("Failed to create harvester: %v", err)
+		return
+	}
+
+	p.sessions[key] = struct{}{}
+	err = p.registry.Start(&sessionHarvester{
+		Harvester: h,
+		release: func() {
+			p.mu.Lock()
+			delete(p.sessions, key)
+			p.mu.Unlock()
 		},
+	})
+	if err != nil {
+		delete(p.sessions, key)
+		logp.Err("Harvester start failed: %s", err)
 	}
 }
+
+// sessionHarvester releases the session of a harvester once it stops running.
+type sessionHarvester struct {
+	harvester.Harvester
+	release func()
+}
+
+func (h *sessionHarvester) Run() error {
+	defer h.release()
+	return h.Harvester.Run()
+}
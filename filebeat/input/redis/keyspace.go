@@ -0,0 +1,195 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	rd "github.com/gomodule/redigo/redis"
+
+	"github.com/elastic/beats/v7/filebeat/harvester"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// KeyspaceHarvester subscribes to Redis keyspace notifications and forwards
+// a sample of them as events until it is stopped or the connection fails.
+type KeyspaceHarvester struct {
+	id        uuid.UUID
+	done      chan struct{}
+	conn      rd.Conn
+	config    keyspaceConfig
+	forwarder *harvester.Forwarder
+}
+
+// keyspaceNotification contains the data of one keyspace notification.
+//
+//	Notifications are published on two kinds of channels:
+//	__keyspace@0__:mykey with the event name ("set") as payload
+//	__keyevent@0__:set with the key name ("mykey") as payload
+type keyspaceNotification struct {
+	db    int
+	event string
+	key   string
+}
+
+// NewKeyspaceHarvester creates a new keyspace notifications harvester with
+// the given connection.
+func NewKeyspaceHarvester(conn rd.Conn, config keyspaceConfig) (*KeyspaceHarvester, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyspaceHarvester{
+		id:     id,
+		done:   make(chan struct{}),
+		conn:   conn,
+		config: config,
+	}, nil
+}
+
+// Run subscribes to the configured channel patterns and forwards the
+// received notifications.
+func (h *KeyspaceHarvester) Run() error {
+	defer h.conn.Close()
+
+	if h.config.Events != "" {
+		if _, err := h.conn.Do("CONFIG", "SET", "notify-keyspace-events", h.config.Events); err != nil {
+			return fmt.Errorf("error enabling keyspace notifications: %w", err)
+		}
+	}
+
+	psc := rd.PubSubConn{Conn: h.conn}
+	patterns := make([]interface{}, len(h.config.Patterns))
+	for i, p := range h.config.Patterns {
+		patterns[i] = p
+	}
+	if err := psc.PSubscribe(patterns...); err != nil {
+		return fmt.Errorf("error subscribing to keyspace notifications: %w", err)
+	}
+
+	// Notifications can be rare, so reads are done without the read timeout
+	// of the connection. Closing the connection is the only way to interrupt
+	// a pending read.
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-h.done:
+			h.conn.Close()
+		case <-stopped:
+		}
+	}()
+
+	for {
+		switch v := psc.ReceiveWithTimeout(0).(type) {
+		case rd.Message:
+			if h.config.SampleRate < 1 && rand.Float64() >= h.config.SampleRate {
+				continue
+			}
+			n, ok := parseKeyspaceNotification(v.Channel, string(v.Data))
+			if !ok {
+				logp.Debug("redis", "Ignoring notification on unexpected channel %q", v.Channel)
+				continue
+			}
+			h.forwarder.Send(n.toEvent(v.Channel))
+		case rd.Subscription:
+			logp.Debug("redis", "Keyspace notifications %s %s", v.Kind, v.Channel)
+		case error:
+			select {
+			case <-h.done:
+				return nil
+			default:
+			}
+			return fmt.Errorf("error receiving keyspace notification: %w", v)
+		}
+	}
+}
+
+// parseKeyspaceNotification extracts the database, event and key from a
+// notification received on a __keyspace@ or __keyevent@ channel.
+func parseKeyspaceNotification(channel, payload string) (keyspaceNotification, bool) {
+	var n keyspaceNotification
+
+	var keyspace bool
+	rest := channel
+	switch {
+	case strings.HasPrefix(rest, "__keyspace@"):
+		keyspace = true
+		rest = strings.TrimPrefix(rest, "__keyspace@")
+	case strings.HasPrefix(rest, "__keyevent@"):
+		rest = strings.TrimPrefix(rest, "__keyevent@")
+	default:
+		return n, false
+	}
+
+	idx := strings.Index(rest, "__:")
+	if idx < 0 {
+		return n, false
+	}
+	db, err := strconv.Atoi(rest[:idx])
+	if err != nil {
+		return n, false
+	}
+	n.db = db
+
+	if keyspace {
+		n.key, n.event = rest[idx+3:], payload
+	} else {
+		n.event, n.key = rest[idx+3:], payload
+	}
+	return n, true
+}
+
+func (n keyspaceNotification) toEvent(channel string) beat.Event {
+	now := time.Now()
+	return beat.Event{
+		Timestamp: now,
+		Fields: mapstr.M{
+			"message": n.event + " " + n.key,
+			"redis": mapstr.M{
+				"keyspace": mapstr.M{
+					"channel": channel,
+					"db":      n.db,
+					"event":   n.event,
+					"key":     n.key,
+				},
+			},
+			"event": mapstr.M{
+				"created": now,
+			},
+		},
+	}
+}
+
+// Stop stops the harvester
+func (h *KeyspaceHarvester) Stop() {
+	close(h.done)
+}
+
+// ID returns the unique harvester ID
+func (h *KeyspaceHarvester) ID() uuid.UUID {
+	return h.id
+}
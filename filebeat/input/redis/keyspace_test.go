@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyspaceNotification(t *testing.T) {
+	tests := []struct {
+		channel, payload string
+		want             keyspaceNotification
+		ok               bool
+	}{
+		{"__keyspace@0__:user:42", "expired", keyspaceNotification{db: 0, event: "expired", key: "user:42"}, true},
+		{"__keyevent@5__:set", "cache:a:b", keyspaceNotification{db: 5, event: "set", key: "cache:a:b"}, true},
+		{"__keyevent@x__:set", "foo", keyspaceNotification{}, false},
+		{"__keyspace@0:foo", "set", keyspaceNotification{}, false},
+		{"news", "hello", keyspaceNotification{}, false},
+	}
+	for _, test := range tests {
+		n, ok := parseKeyspaceNotification(test.channel, test.payload)
+		assert.Equal(t, test.ok, ok, test.channel)
+		if ok {
+			assert.Equal(t, test.want, n, test.channel)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	rd "github.com/gomodule/redigo/redis"
+
+	"github.com/elastic/beats/v7/filebeat/harvester"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// MonitorHarvester runs a bounded MONITOR session and forwards a sample of
+// the commands processed by the server.
+//
+// MONITOR has a significant cost on the server, so every session is limited
+// by max_duration and max_events, and only a fraction of the commands, set by
+// sample_rate, are forwarded.
+type MonitorHarvester struct {
+	id        uuid.UUID
+	done      chan struct{}
+	conn      rd.Conn
+	config    monitorConfig
+	forwarder *harvester.Forwarder
+}
+
+// monitorEntry contains all data related to one MONITOR line
+//
+//	The data is in the following format:
+//	1339518083.107412 [0 127.0.0.1:60866] "get" "foo"
+type monitorEntry struct {
+	timestamp time.Time
+	db        int
+	client    string
+	cmd       string
+	key       string
+	args      []string
+}
+
+// NewMonitorHarvester creates a new MONITOR harvester with the given
+// connection.
+func NewMonitorHarvester(conn rd.Conn, config monitorConfig) (*MonitorHarvester, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MonitorHarvester{
+		id:     id,
+		done:   make(chan struct{}),
+		conn:   conn,
+		config: config,
+	}, nil
+}
+
+// Run starts a MONITOR session and forwards sampled commands until
+// max_duration elapses, max_events are sent or the harvester is stopped.
+func (h *MonitorHarvester) Run() error {
+	defer h.conn.Close()
+
+	ok, err := rd.String(h.conn.Do("MONITOR"))
+	if err != nil {
+		return fmt.Errorf("error starting monitor session: %w", err)
+	}
+	if ok != "OK" {
+		return fmt.Errorf("unexpected reply to MONITOR: %s", ok)
+	}
+
+	// The session is ended by closing the connection, this interrupts the
+	// pending read and releases the server side resources at once.
+	timer := time.NewTimer(h.config.MaxDuration)
+	defer timer.Stop()
+	stopped := make(chan struct{})
+	defer close(stopped)
+	expired := make(chan struct{})
+	go func() {
+		select {
+		case <-h.done:
+		case <-timer.C:
+			close(expired)
+		case <-stopped:
+			return
+		}
+		h.conn.Close()
+	}()
+
+	sent := 0
+	for h.config.MaxEvents == 0 || sent < h.config.MaxEvents {
+		line, err := rd.String(rd.ReceiveWithTimeout(h.conn, 0))
+		if err != nil {
+			select {
+			case <-h.done:
+				return nil
+			case <-expired:
+				return nil
+			default:
+			}
+			return fmt.Errorf("error receiving monitor data: %w", err)
+		}
+
+		if h.config.SampleRate < 1 && rand.Float64() >= h.config.SampleRate {
+			continue
+		}
+
+		entry, err := parseMonitorLine(line)
+		if err != nil {
+			logp.Debug("redis", "Ignoring monitor line %q: %v", line, err)
+			continue
+		}
+		h.forwarder.Send(entry.toEvent(line))
+		sent++
+	}
+	logp.Debug("redis", "Monitor session stopped after %d events", sent)
+	return nil
+}
+
+// parseMonitorLine parses a line of the MONITOR output.
+func parseMonitorLine(line string) (monitorEntry, error) {
+	var e monitorEntry
+
+	ts, rest, found := strings.Cut(line, " [")
+	if !found {
+		return e, errors.New("missing client information")
+	}
+	secs, usecs, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return e, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	var usec int64
+	if usecs != "" {
+		usec, err = strconv.ParseInt(usecs, 10, 64)
+		if err != nil {
+			return e, fmt.Errorf("invalid timestamp: %w", err)
+		}
+	}
+	e.timestamp = time.Unix(sec, usec*int64(time.Microsecond)).UTC()
+
+	client, rest, found := strings.Cut(rest, "] ")
+	if !found {
+		return e, errors.New("missing client information")
+	}
+	db, addr, _ := strings.Cut(client, " ")
+	e.db, err = strconv.Atoi(db)
+	if err != nil {
+		return e, fmt.Errorf("invalid database: %w", err)
+	}
+	e.client = addr
+
+	args, err := splitMonitorArgs(rest)
+	if err != nil {
+		return e, err
+	}
+	if len(args) == 0 {
+		return e, errors.New("missing command")
+	}
+
+	// This splits up the args into cmd, key, args like for slowlog entries.
+	e.cmd = args[0]
+	if len(args) > 1 {
+		e.key = args[1]
+	}
+	// This could contain confidential data, processors should be used to drop it if needed
+	if len(args) > 2 {
+		e.args = args[2:]
+	}
+	return e, nil
+}
+
+// splitMonitorArgs splits the quoted and escaped arguments of a MONITOR line.
+func splitMonitorArgs(s string) ([]string, error) {
+	var args []string
+	for i := 0; i < len(s); {
+		if s[i] == ' ' {
+			i++
+			continue
+		}
+		if s[i] != '"' {
+			return nil, fmt.Errorf("unexpected character %q at offset %d", s[i], i)
+		}
+		i++
+
+		var b strings.Builder
+		for {
+			if i >= len(s) {
+				return nil, errors.New("unterminated argument")
+			}
+			c := s[i]
+			if c == '"' {
+				i++
+				break
+			}
+			if c != '\\' {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+			if i+1 >= len(s) {
+				return nil, errors.New("unterminated escape sequence")
+			}
+			switch s[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'a':
+				b.WriteByte('\a')
+			case 'b':
+				b.WriteByte('\b')
+			case 'x':
+				if i+3 >= len(s) {
+					return nil, errors.New("unterminated escape sequence")
+				}
+				v, err := strconv.ParseUint(s[i+2:i+4], 16, 8)
+				if err != nil {
+					return nil, fmt.Errorf("invalid escape sequence: %w", err)
+				}
+				b.WriteByte(byte(v))
+				i += 2
+			default:
+				b.WriteByte(s[i+1])
+			}
+			i += 2
+		}
+		args = append(args, b.String())
+	}
+	return args, nil
+}
+
+func (e monitorEntry) toEvent(line string) beat.Event {
+	monitor := mapstr.M{
+		"db":  e.db,
+		"cmd": e.cmd,
+	}
+	if e.key != "" {
+		monitor["key"] = e.key
+	}
+	if e.args != nil {
+		monitor["args"] = e.args
+	}
+
+	fields := mapstr.M{
+		"message": line,
+		"redis": mapstr.M{
+			"monitor": monitor,
+		},
+		"event": mapstr.M{
+			"created": time.Now(),
+		},
+	}
+
+	if e.client != "" {
+		client := mapstr.M{"address": e.client}
+		if host, port, err := net.SplitHostPort(e.client); err == nil {
+			if ip := net.ParseIP(host); ip != nil {
+				client["ip"] = host
+			}
+			if p, err := strconv.Atoi(port); err == nil {
+				client["port"] = p
+			}
+		}
+		fields["client"] = client
+	}
+
+	return beat.Event{
+		Timestamp: e.timestamp,
+		Fields:    fields,
+	}
+}
+
+// Stop stops the harvester
+func (h *MonitorHarvester) Stop() {
+	close(h.done)
+}
+
+// ID returns the unique harvester ID
+func (h *MonitorHarvester) ID() uuid.UUID {
+	return h.id
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMonitorLine(t *testing.T) {
+	entry, err := parseMonitorLine(`1339518083.107412 [0 127.0.0.1:60866] "set" "foo" "bar \"baz\"\x00\n"`)
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1339518083, 107412000).UTC(), entry.timestamp)
+	assert.Equal(t, 0, entry.db)
+	assert.Equal(t, "127.0.0.1:60866", entry.client)
+	assert.Equal(t, "set", entry.cmd)
+	assert.Equal(t, "foo", entry.key)
+	assert.Equal(t, []string{"bar \"baz\"\x00\n"}, entry.args)
+
+	event := entry.toEvent("line")
+	ip, _ := event.Fields.GetValue("client.ip")
+	assert.Equal(t, "127.0.0.1", ip)
+	port, _ := event.Fields.GetValue("client.port")
+	assert.Equal(t, 60866, port)
+
+	entry, err = parseMonitorLine(`1339518083.107412 [3 lua] "keys"`)
+	require.NoError(t, err)
+	assert.Equal(t, 3, entry.db)
+	assert.Equal(t, "lua", entry.client)
+	assert.Equal(t, "keys", entry.cmd)
+	assert.Empty(t, entry.key)
+	assert.Nil(t, entry.args)
+
+	for _, line := range []string{
+		"",
+		"OK",
+		`1339518083.107412 [0 127.0.0.1:60866]`,
+		`1339518083.107412 [x 127.0.0.1:60866] "get" "foo"`,
+		`1339518083.107412 [0 127.0.0.1:60866] "get" "foo`,
+		`1339518083.107412 [0 127.0.0.1:60866] get`,
+	} {
+		_, err := parseMonitorLine(line)
+		assert.Error(t, err, line)
+	}
+}
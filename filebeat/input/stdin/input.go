@@ -25,6 +25,7 @@ import (
 	"github.com/elastic/beats/v7/filebeat/input"
 	"github.com/elastic/beats/v7/filebeat/input/file"
 	"github.com/elastic/beats/v7/filebeat/input/log"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -34,6 +35,7 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	seccomp.RegisterBase(seccomp.KindInput, "stdin")
 }
 
 // Input is an input for stdin
@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/filebeat/inputsource"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -95,6 +96,7 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	seccomp.RegisterRequirements(seccomp.KindInput, "syslog", seccomp.Requirements{Listen: true})
 }
 
 // Input define a syslog input
@@ -320,6 +322,9 @@ func newBeatEvent(timestamp time.Time, metadata inputsource.NetworkMetadata, fie
 	if metadata.RemoteAddr != nil {
 		event.Fields.Put("log.source.address", metadata.RemoteAddr.String())
 	}
+	if metadata.TLS != nil {
+		addTLSFields(event.Fields, metadata)
+	}
 	return event
 }
 
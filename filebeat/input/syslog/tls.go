@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/filebeat/inputsource"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// addTLSFields adds the details of the TLS connection the message was received
+// on. The identity of the client certificate is mapped into the client fields,
+// so that messages can be attributed to the device that sent them even when
+// they go through NAT or don't carry a hostname.
+func addTLSFields(fields mapstr.M, metadata inputsource.NetworkMetadata) {
+	tls := metadata.TLS
+
+	fields.Put("tls.established", true)
+	if tls.CipherSuite != "" {
+		fields.Put("tls.cipher", tls.CipherSuite)
+	}
+	if tls.ServerName != "" {
+		fields.Put("tls.client.server_name", tls.ServerName)
+	}
+
+	if metadata.RemoteAddr != nil {
+		if host, port, err := net.SplitHostPort(metadata.RemoteAddr.String()); err == nil {
+			fields.Put("client.address", host)
+			if net.ParseIP(host) != nil {
+				fields.Put("client.ip", host)
+			}
+			if p, err := strconv.Atoi(port); err == nil {
+				fields.Put("client.port", p)
+			}
+		}
+	}
+
+	cert := tls.ClientCertificate
+	if cert == nil {
+		return
+	}
+
+	if len(cert.DNSNames) > 0 {
+		fields.Put("client.domain", cert.DNSNames[0])
+	} else if cert.Subject.CommonName != "" {
+		fields.Put("client.domain", cert.Subject.CommonName)
+	}
+
+	hash := sha256.Sum256(cert.Raw)
+	fields.Put("tls.client.subject", cert.Subject.String())
+	fields.Put("tls.client.issuer", cert.Issuer.String())
+	fields.Put("tls.client.not_before", cert.NotBefore.UTC())
+	fields.Put("tls.client.not_after", cert.NotAfter.UTC())
+	fields.Put("tls.client.hash.sha256", strings.ToUpper(hex.EncodeToString(hash[:])))
+	fields.Put("tls.client.x509.serial_number", strings.ToUpper(cert.SerialNumber.Text(16)))
+	if cert.Subject.CommonName != "" {
+		fields.Put("tls.client.x509.subject.common_name", []string{cert.Subject.CommonName})
+	}
+	if len(cert.Subject.Organization) > 0 {
+		fields.Put("tls.client.x509.subject.organization", cert.Subject.Organization)
+	}
+
+	var altNames []string
+	altNames = append(altNames, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		altNames = append(altNames, ip.String())
+	}
+	altNames = append(altNames, cert.EmailAddresses...)
+	if len(altNames) > 0 {
+		fields.Put("tls.client.x509.alternative_names", altNames)
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/filebeat/inputsource"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestAddTLSFields(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0xbeef),
+		Subject:      pkix.Name{CommonName: "switch-01", Organization: []string{"Network"}},
+		DNSNames:     []string{"switch-01.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("192.0.2.10")},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	metadata := inputsource.NetworkMetadata{
+		RemoteAddr: &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 51234},
+		TLS: &inputsource.TLSMetadata{
+			CipherSuite:       "TLS-AES-128-GCM-SHA256",
+			ClientCertificate: cert,
+		},
+	}
+
+	event := newBeatEvent(time.Now(), metadata, mapstr.M{"message": "hello"})
+
+	expected := map[string]interface{}{
+		"client.address":                       "192.0.2.10",
+		"client.ip":                            "192.0.2.10",
+		"client.port":                          51234,
+		"client.domain":                        "switch-01.example.com",
+		"tls.established":                      true,
+		"tls.cipher":                           "TLS-AES-128-GCM-SHA256",
+		"tls.client.subject":                   "CN=switch-01,O=Network",
+		"tls.client.issuer":                    "CN=switch-01,O=Network",
+		"tls.client.not_before":                notBefore,
+		"tls.client.x509.serial_number":        "BEEF",
+		"tls.client.x509.subject.common_name":  []string{"switch-01"},
+		"tls.client.x509.subject.organization": []string{"Network"},
+		"tls.client.x509.alternative_names":    []string{"switch-01.example.com", "192.0.2.10"},
+	}
+	for k, v := range expected {
+		got, err := event.Fields.GetValue(k)
+		if assert.NoError(t, err, k) {
+			assert.Equal(t, v, got, k)
+		}
+	}
+	hash, err := event.Fields.GetValue("tls.client.hash.sha256")
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	t.Run("without client certificate", func(t *testing.T) {
+		metadata.TLS.ClientCertificate = nil
+		event := newBeatEvent(time.Now(), metadata, mapstr.M{"message": "hello"})
+
+		_, err := event.Fields.GetValue("client.domain")
+		assert.Error(t, err)
+		_, err = event.Fields.GetValue("tls.client")
+		assert.Error(t, err)
+		ip, _ := event.Fields.GetValue("client.ip")
+		assert.Equal(t, "192.0.2.10", ip)
+	})
+
+	t.Run("without TLS", func(t *testing.T) {
+		event := newBeatEvent(time.Now(), dummyMetadata(), mapstr.M{"message": "hello"})
+
+		_, err := event.Fields.GetValue("client")
+		assert.Error(t, err)
+		_, err = event.Fields.GetValue("tls")
+		assert.Error(t, err)
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/elastic/beats/v7/filebeat/inputsource/common/streaming"
 	"github.com/elastic/beats/v7/filebeat/inputsource/tcp"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/feature"
 
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -37,6 +38,10 @@ import (
 	"github.com/elastic/go-concert/ctxtool"
 )
 
+func init() {
+	seccomp.RegisterRequirements(seccomp.KindInput, "tcp", seccomp.Requirements{Listen: true})
+}
+
 func Plugin() input.Plugin {
 	return input.Plugin{
 		Name:       "tcp",
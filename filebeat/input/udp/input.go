@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/filebeat/inputsource"
 	"github.com/elastic/beats/v7/filebeat/inputsource/udp"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/feature"
 
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -36,6 +37,10 @@ import (
 	"github.com/elastic/go-concert/ctxtool"
 )
 
+func init() {
+	seccomp.RegisterRequirements(seccomp.KindInput, "udp", seccomp.Requirements{Listen: true})
+}
+
 func Plugin() input.Plugin {
 	return input.Plugin{
 		Name:       "udp",
@@ -98,7 +103,8 @@ func (s *server) Run(ctx input.Context, publisher stateless.Publisher) error {
 	metrics := netmetrics.NewUDP("udp", ctx.ID, s.config.Host, uint64(s.config.ReadBuffer), pollInterval, log)
 	defer metrics.Close()
 
-	server := udp.New(&s.config.Config, func(data []byte, metadata inputsource.NetworkMetadata) {
+	var server *udp.Server
+	server = udp.New(&s.config.Config, func(data []byte, metadata inputsource.NetworkMetadata) {
 		evt := beat.Event{
 			Timestamp: time.Now(),
 			Meta: mapstr.M{
@@ -121,6 +127,7 @@ func (s *server) Run(ctx input.Context, publisher stateless.Publisher) error {
 		// This must be called after publisher.Publish to measure
 		// the processing time metric.
 		metrics.Log(data, evt.Timestamp)
+		metrics.SetOverflowDrops(server.Drops())
 	})
 
 	log.Debug("udp input initialized")
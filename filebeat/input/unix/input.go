@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/filebeat/inputsource"
 	"github.com/elastic/beats/v7/filebeat/inputsource/unix"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -39,6 +40,10 @@ import (
 	"github.com/elastic/go-concert/ctxtool"
 )
 
+func init() {
+	seccomp.RegisterRequirements(seccomp.KindInput, "unix", seccomp.Requirements{Listen: true})
+}
+
 func Plugin() input.Plugin {
 	return input.Plugin{
 		Name:       "unix",
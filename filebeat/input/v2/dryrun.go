@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// ErrDryRunUnsupported is returned by DryRun if the configured input does not
+// support dry runs.
+var ErrDryRunUnsupported = errors.New("input does not support dry runs")
+
+// DryRunner is implemented by inputs that support dry runs. A dry run executes
+// a single iteration of the input, reporting the events the input would
+// publish instead of publishing them. The input state is not persisted.
+type DryRunner interface {
+	DryRun(DryRunContext) error
+}
+
+// DryRunContext provides the Input DryRun function with common environmental
+// information and services.
+type DryRunContext struct {
+	Context
+
+	// WrapTransport, if not nil, must be applied to the transport of the HTTP
+	// client used by the input. It is used to report requests or to replay
+	// recorded responses instead of sending requests.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+
+	// Reporter receives the details of the dry run.
+	Reporter DryRunReporter
+}
+
+// Transport returns rt wrapped by WrapTransport.
+func (c DryRunContext) Transport(rt http.RoundTripper) http.RoundTripper {
+	if c.WrapTransport == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return c.WrapTransport(rt)
+}
+
+// DryRunReporter receives the details of an input dry run.
+type DryRunReporter interface {
+	// State reports a snapshot of the input state, e.g. before and after
+	// the evaluation of a program.
+	State(name string, state interface{})
+
+	// Event reports an event the input would publish. private holds the
+	// input state that would be persisted on publication, if any.
+	Event(event beat.Event, private interface{})
+}
@@ -22,6 +22,11 @@ package cursor
 type Cursor struct {
 	store    *store
 	resource *resource
+
+	// parent is the resource of the source this source resumes from, as long
+	// as no cursor has been stored for the source. It is nil for sources
+	// without a parent.
+	parent *resource
 }
 
 func makeCursor(store *store, res *resource) Cursor {
@@ -29,15 +34,22 @@ func makeCursor(store *store, res *resource) Cursor {
 }
 
 // IsNew returns true if no cursor information has been stored
-// for the current Source.
-func (c Cursor) IsNew() bool { return c.resource.IsNew() }
+// for the current Source, or for its parent if it has one.
+func (c Cursor) IsNew() bool {
+	return c.resource.IsNew() && (c.parent == nil || c.parent.IsNew())
+}
 
 // Unpack deserialized the cursor state into to. Unpack fails if no pointer is
 // given, or if the structure to points to is not compatible with the document
 // stored.
+// The cursor of the parent source is used as long as no cursor has been
+// stored for the current Source.
 func (c Cursor) Unpack(to interface{}) error {
-	if c.IsNew() {
-		return nil
+	switch {
+	case !c.resource.IsNew():
+		return c.resource.UnpackCursor(to)
+	case c.parent != nil && !c.parent.IsNew():
+		return c.parent.UnpackCursor(to)
 	}
-	return c.resource.UnpackCursor(to)
+	return nil
 }
@@ -122,3 +122,44 @@ func TestCursor_Unpack(t *testing.T) {
 		require.Equal(t, "test-state-update", st)
 	})
 }
+
+func TestCursor_Parent(t *testing.T) {
+	t.Run("new if neither the key nor its parent have a cursor", func(t *testing.T) {
+		store := testOpenStore(t, "test", createSampleStore(t, nil))
+		defer store.Release()
+
+		cursor := makeCursor(store, store.Get("test::key"))
+		cursor.parent = store.Get("test::parent")
+		require.True(t, cursor.IsNew())
+	})
+
+	t.Run("unpack from parent if key has no cursor", func(t *testing.T) {
+		store := testOpenStore(t, "test", createSampleStore(t, map[string]state{
+			"test::parent": {Cursor: "parent"},
+		}))
+		defer store.Release()
+
+		cursor := makeCursor(store, store.Get("test::key"))
+		cursor.parent = store.Get("test::parent")
+		require.False(t, cursor.IsNew())
+
+		var st string
+		require.NoError(t, cursor.Unpack(&st))
+		require.Equal(t, "parent", st)
+	})
+
+	t.Run("unpack from key once it has a cursor", func(t *testing.T) {
+		store := testOpenStore(t, "test", createSampleStore(t, map[string]state{
+			"test::key":    {Cursor: "test"},
+			"test::parent": {Cursor: "parent"},
+		}))
+		defer store.Release()
+
+		cursor := makeCursor(store, store.Get("test::key"))
+		cursor.parent = store.Get("test::parent")
+
+		var st string
+		require.NoError(t, cursor.Unpack(&st))
+		require.Equal(t, "test", st)
+	})
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	input "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// DryRunner is implemented by cursor inputs that support dry runs. The dry
+// run starts without a cursor, as for a new input, and never updates the
+// cursor.
+type DryRunner interface {
+	DryRun(input.DryRunContext, Source) error
+}
+
+// DryRun executes a dry run of the input for each configured source.
+func (inp *managedInput) DryRun(ctx input.DryRunContext) error {
+	dr, ok := inp.input.(DryRunner)
+	if !ok {
+		return input.ErrDryRunUnsupported
+	}
+	for _, source := range inp.sources {
+		srcCtx := ctx
+		srcCtx.ID = ctx.ID + "::" + source.Name()
+		srcCtx.Logger = ctx.Logger.With("input_source", source.Name())
+		if err := dryRunSource(dr, srcCtx, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dryRunSource(dr DryRunner, ctx input.DryRunContext, source Source) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("input panic with: %+v\n%s", v, debug.Stack())
+		}
+	}()
+	return dr.DryRun(ctx, source)
+}
+
+// DryRunPublisher returns a Publisher reporting the events to r instead of
+// publishing them.
+func DryRunPublisher(r input.DryRunReporter) Publisher {
+	return dryRunPublisher{reporter: r}
+}
+
+type dryRunPublisher struct {
+	reporter input.DryRunReporter
+}
+
+func (p dryRunPublisher) Publish(event beat.Event, cursor interface{}) error {
+	p.reporter.Event(event, cursor)
+	return nil
+}
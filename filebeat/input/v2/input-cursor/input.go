@@ -30,6 +30,7 @@ import (
 	input "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -68,6 +69,14 @@ type managedInput struct {
 // Name is required to implement the v2.Input interface
 func (inp *managedInput) Name() string { return inp.input.Name() }
 
+// FIPSCapable returns the FIPS capability of the input, if it reports it.
+func (inp *managedInput) FIPSCapable() error {
+	if a, ok := inp.input.(fips.Aware); ok {
+		return a.FIPSCapable()
+	}
+	return fips.ErrUnknownCapability
+}
+
 // Test runs the Test method for each configured source.
 func (inp *managedInput) Test(ctx input.TestContext) error {
 	var grp unison.MultiErrGroup
@@ -162,15 +171,24 @@ func (inp *managedInput) runSource(
 	store.UpdateTTL(resource, inp.cleanTimeout)
 
 	cursor := makeCursor(store, resource)
+	if ps, ok := source.(ParentSource); ok && ps.ParentName() != "" && resource.IsNew() {
+		parent := store.Get(inp.createSourceIDFromName(ps.ParentName()))
+		defer parent.Release()
+		cursor.parent = parent
+	}
 	publisher := &cursorPublisher{canceler: ctx.Cancelation, client: client, cursor: &cursor}
 	return inp.input.Run(ctx, source, cursor, publisher)
 }
 
 func (inp *managedInput) createSourceID(s Source) string {
+	return inp.createSourceIDFromName(s.Name())
+}
+
+func (inp *managedInput) createSourceIDFromName(name string) string {
 	if inp.userID != "" {
-		return fmt.Sprintf("%v::%v::%v", inp.manager.Type, inp.userID, s.Name())
+		return fmt.Sprintf("%v::%v::%v", inp.manager.Type, inp.userID, name)
 	}
-	return fmt.Sprintf("%v::%v", inp.manager.Type, s.Name())
+	return fmt.Sprintf("%v::%v", inp.manager.Type, name)
 }
 
 func newInputACKHandler(log *logp.Logger) beat.EventListener {
@@ -75,6 +75,18 @@ type Source interface {
 	Name() string
 }
 
+// ParentSource is a Source that resumes from the cursor of another source of
+// the same input, as long as no cursor has been stored for it. It allows
+// inputs to split a source into multiple sources without collecting the data
+// of the original source again.
+type ParentSource interface {
+	Source
+
+	// ParentName returns the name of the source to resume from. It is empty
+	// if the source has no parent.
+	ParentName() string
+}
+
 var (
 	errNoSourceConfigured = errors.New("no source has been configured")
 	errNoInputRunner      = errors.New("no input runner available")
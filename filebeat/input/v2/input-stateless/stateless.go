@@ -97,3 +97,17 @@ func (si configuredInput) Run(ctx v2.Context, pipeline beat.PipelineConnector) (
 func (si configuredInput) Test(ctx v2.TestContext) error {
 	return si.input.Test(ctx)
 }
+
+// DryRun executes a dry run of the input if it implements v2.DryRunner.
+func (si configuredInput) DryRun(ctx v2.DryRunContext) (err error) {
+	dr, ok := si.input.(v2.DryRunner)
+	if !ok {
+		return v2.ErrDryRunUnsupported
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("input panic with: %+v\n%s", v, debug.Stack())
+		}
+	}()
+	return dr.DryRun(ctx)
+}
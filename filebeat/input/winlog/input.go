@@ -56,15 +56,35 @@ func Plugin(log *logp.Logger, store cursor.StateStore) input.Plugin {
 	}
 }
 
+// shardSource is an event log read by one of the shards of a channel. A new
+// shard resumes from the position of the event log it is part of.
+type shardSource struct {
+	eventlog.EventLog
+	parent string
+}
+
+func (s shardSource) ParentName() string { return s.parent }
+
 func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
 	// TODO: do we want to allow to read multiple eventLogs using a single config
 	//       as is common for other inputs?
-	eventLog, err := eventlog.New(cfg)
+	shards, err := eventlog.Shards(cfg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create new event log. %w", err)
 	}
 
-	sources := []cursor.Source{eventLog}
+	sources := make([]cursor.Source, 0, len(shards))
+	for _, shard := range shards {
+		eventLog, err := eventlog.New(shard.Config)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create new event log. %w", err)
+		}
+		if shard.Parent == "" {
+			sources = append(sources, eventLog)
+			continue
+		}
+		sources = append(sources, shardSource{EventLog: eventLog, parent: shard.Parent})
+	}
 	return sources, eventlogRunner{}, nil
 }
 
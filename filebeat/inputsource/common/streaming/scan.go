@@ -20,9 +20,19 @@ package streaming
 import (
 	"bufio"
 	"bytes"
+	"errors"
+	"fmt"
 	"strconv"
 )
 
+// maxOctetCountDigits is the maximum number of digits accepted for the
+// MSG-LEN of an octet-counted frame.
+const maxOctetCountDigits = 10
+
+// ErrInvalidOctetCount is returned when a frame doesn't start with a valid
+// MSG-LEN when strict octet counting is used.
+var ErrInvalidOctetCount = errors.New("invalid octet count")
+
 // FactoryDelimiter return a function to split line using a custom delimiter supporting multibytes
 // delimiter, the delimiter is stripped from the returned value.
 func FactoryDelimiter(delimiter []byte) bufio.SplitFunc {
@@ -87,3 +97,64 @@ func FactoryRFC6587Framing(delimiter []byte) bufio.SplitFunc {
 		return 0, nil, nil
 	}
 }
+
+// FactoryRFC5425Framing returns a function that splits based on the strict
+// octet counting framing defined in RFC5425 for syslog over TLS. Each frame is
+// made of MSG-LEN, a space and MSG-LEN octets of message. Frames not starting
+// with a valid MSG-LEN end the stream with ErrInvalidOctetCount, as there is
+// no way to find the start of the next frame.
+func FactoryRFC5425Framing() bufio.SplitFunc {
+	return func(data []byte, eof bool) (int, []byte, error) {
+		if eof && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		i := bytes.IndexByte(data, ' ')
+		if i < 0 {
+			if len(data) > maxOctetCountDigits || !isMsgLen(data) {
+				return 0, nil, fmt.Errorf("%w: %q", ErrInvalidOctetCount, truncate(data, maxOctetCountDigits+1))
+			}
+			if eof {
+				return 0, nil, fmt.Errorf("%w: stream ended within the frame header", ErrInvalidOctetCount)
+			}
+			// request more data
+			return 0, nil, nil
+		}
+
+		if i > maxOctetCountDigits || !isMsgLen(data[:i]) {
+			return 0, nil, fmt.Errorf("%w: %q", ErrInvalidOctetCount, truncate(data, i))
+		}
+		length, err := strconv.Atoi(string(data[:i]))
+		if err != nil {
+			return 0, nil, fmt.Errorf("%w: %w", ErrInvalidOctetCount, err)
+		}
+
+		end := i + 1 + length
+		if len(data) >= end {
+			return end, data[i+1 : end], nil
+		}
+		if eof {
+			return 0, nil, fmt.Errorf("%w: stream ended after %d of %d octets", ErrInvalidOctetCount, len(data)-i-1, length)
+		}
+		// request more data
+		return 0, nil, nil
+	}
+}
+
+// isMsgLen checks that data is a, possibly incomplete, MSG-LEN: a non zero
+// digit followed by digits.
+func isMsgLen(data []byte) bool {
+	for j, c := range data {
+		if c < '0' || c > '9' || (j == 0 && c == '0') {
+			return false
+		}
+	}
+	return true
+}
+
+func truncate(data []byte, n int) []byte {
+	if len(data) > n {
+		return data[:n]
+	}
+	return data
+}
@@ -178,3 +178,63 @@ func TestOctetCounting(t *testing.T) {
 		})
 	}
 }
+
+func TestStrictOctetCounting(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "frames",
+			text:     "5 hello7 bonjour12 hola\nque tal",
+			expected: []string{"hello", "bonjour", "hola\nque tal"},
+		},
+		{
+			name:     "empty stream",
+			text:     "",
+			expected: nil,
+		},
+		{
+			name:     "non transparent framing",
+			text:     "5 hello<13>Oct 11 22:14:15 mymachine su: 'su root' failed\n",
+			expected: []string{"hello"},
+			err:      true,
+		},
+		{
+			name:     "leading zero",
+			text:     "05 hello",
+			expected: nil,
+			err:      true,
+		},
+		{
+			name:     "too many digits",
+			text:     "12345678901 hello",
+			expected: nil,
+			err:      true,
+		},
+		{
+			name:     "truncated frame",
+			text:     "5 hello10 bonjour",
+			expected: []string{"hello"},
+			err:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(test.text))
+			scanner.Split(FactoryRFC5425Framing())
+			var elements []string
+			for scanner.Scan() {
+				elements = append(elements, scanner.Text())
+			}
+			assert.EqualValues(t, test.expected, elements)
+			if test.err {
+				assert.ErrorIs(t, scanner.Err(), ErrInvalidOctetCount)
+			} else {
+				assert.NoError(t, scanner.Err())
+			}
+		})
+	}
+}
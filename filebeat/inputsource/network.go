@@ -18,6 +18,7 @@
 package inputsource
 
 import (
+	"crypto/x509"
 	"net"
 )
 
@@ -40,6 +41,8 @@ type TLSMetadata struct {
 	CipherSuite      string
 	ServerName       string
 	PeerCertificates []string
+	// ClientCertificate is the leaf certificate presented by the client, if any.
+	ClientCertificate *x509.Certificate
 }
 
 // NetworkFunc defines callback executed when a new event is received from a network source.
@@ -37,12 +37,16 @@ func MetadataCallback(conn net.Conn) inputsource.NetworkMetadata {
 func extractSSLInformation(c net.Conn) *inputsource.TLSMetadata {
 	if tls, ok := c.(*tls.Conn); ok {
 		state := tls.ConnectionState()
-		return &inputsource.TLSMetadata{
+		md := &inputsource.TLSMetadata{
 			TLSVersion:       tlscommon.ResolveTLSVersion(state.Version),
 			CipherSuite:      tlscommon.ResolveCipherSuite(state.CipherSuite),
 			ServerName:       state.ServerName,
 			PeerCertificates: extractCertificate(state.PeerCertificates),
 		}
+		if len(state.PeerCertificates) > 0 {
+			md.ClientCertificate = state.PeerCertificates[0]
+		}
+		return md
 	}
 	return nil
 }
@@ -18,6 +18,8 @@
 package udp
 
 import (
+	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
@@ -29,4 +31,18 @@ type Config struct {
 	MaxMessageSize cfgtype.ByteSize `config:"max_message_size" validate:"positive,nonzero"`
 	Timeout        time.Duration    `config:"timeout"`
 	ReadBuffer     cfgtype.ByteSize `config:"read_buffer" validate:"positive"`
+	// Workers is the number of sockets opened on Host with SO_REUSEPORT,
+	// each read by its own goroutine.
+	Workers int `config:"workers" validate:"min=0"`
+	// PinWorkers locks the goroutine of each worker to an OS thread bound
+	// to a single CPU.
+	PinWorkers bool `config:"pin_workers"`
+}
+
+// Validate validates the Config option for the UDP server.
+func (c *Config) Validate() error {
+	if c.Workers > 1 && runtime.GOOS != "linux" {
+		return fmt.Errorf("workers greater than 1 is only supported on linux, got %d on %s", c.Workers, runtime.GOOS)
+	}
+	return nil
 }
@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !linux
+
+package udp
+
+import (
+	"errors"
+	"net"
+)
+
+var oobSize = 0
+
+const msgTrunc = 0
+
+var errWorkersUnsupported = errors.New("multiple UDP workers are only supported on linux")
+
+func listenReusePort(_ string) (*net.UDPConn, error) {
+	return nil, errWorkersUnsupported
+}
+
+func overflowDrops(_ []byte) (uint32, bool) {
+	return 0, false
+}
+
+func pinToCPU(_ int) error {
+	return errWorkersUnsupported
+}
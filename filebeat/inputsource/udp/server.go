@@ -18,7 +18,10 @@
 package udp
 
 import (
+	"context"
 	"net"
+	"sync"
+	"sync/atomic"
 
 	"github.com/dustin/go-humanize"
 
@@ -32,10 +35,19 @@ const Name = "udp"
 
 // Server creates a simple UDP Server and listen to a specific host:port and will send any
 // event received to the callback method.
+//
+// When more than one worker is configured, the server opens one socket per
+// worker on the same host:port with SO_REUSEPORT, the kernel spreading the
+// datagrams over the sockets. The callback is then called concurrently.
 type Server struct {
-	*dgram.Listener
-	config *Config
+	listeners []*dgram.Listener
+	config    *Config
+
+	// drops are the numbers of datagrams dropped because the receive queue
+	// of the socket of each worker overflowed.
+	drops []atomic.Uint64
 
+	mu           sync.Mutex
 	localaddress string
 }
 
@@ -43,14 +55,80 @@ type Server struct {
 func New(config *Config, callback inputsource.NetworkFunc) *Server {
 	server := &Server{config: config}
 	log := logp.NewLogger("udp").With("address", config.Host)
-	factory := dgram.DatagramReaderFactory(inputsource.FamilyUDP, log, callback)
-	server.Listener = dgram.NewListener(inputsource.FamilyUDP, config.Host, factory, server.createConn, &dgram.ListenerConfig{
+	listenerConfig := &dgram.ListenerConfig{
 		Timeout:        config.Timeout,
 		MaxMessageSize: config.MaxMessageSize,
-	})
+	}
+
+	if config.Workers <= 1 {
+		factory := dgram.DatagramReaderFactory(inputsource.FamilyUDP, log, callback)
+		server.listeners = []*dgram.Listener{
+			dgram.NewListener(inputsource.FamilyUDP, config.Host, factory, server.createConn, listenerConfig),
+		}
+		return server
+	}
+
+	server.drops = make([]atomic.Uint64, config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		id := i
+		workerLog := log.With("worker", id)
+		factory := server.workerReaderFactory(id, workerLog, callback)
+		createConn := func() (net.PacketConn, error) { return server.createWorkerConn() }
+		server.listeners = append(server.listeners,
+			dgram.NewListener(inputsource.FamilyUDP, config.Host, factory, createConn, listenerConfig))
+	}
 	return server
 }
 
+// Run runs the server until the context is cancelled.
+func (u *Server) Run(ctx context.Context) error {
+	if len(u.listeners) == 1 {
+		return u.listeners[0].Run(ctx)
+	}
+
+	var wg sync.WaitGroup
+	for _, l := range u.listeners {
+		wg.Add(1)
+		go func(l *dgram.Listener) {
+			defer wg.Done()
+			_ = l.Run(ctx)
+		}(l)
+	}
+	wg.Wait()
+	return nil
+}
+
+// Start opens the sockets of the server and starts reading from them.
+func (u *Server) Start() error {
+	for i, l := range u.listeners {
+		if err := l.Start(); err != nil {
+			for _, started := range u.listeners[:i] {
+				started.Stop()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops the server and closes its sockets.
+func (u *Server) Stop() {
+	for _, l := range u.listeners {
+		l.Stop()
+	}
+}
+
+// Drops returns the number of datagrams dropped because the receive queues
+// of the sockets of the workers overflowed. It is only reported on linux,
+// when more than one worker is configured.
+func (u *Server) Drops() uint64 {
+	var total uint64
+	for i := range u.drops {
+		total += u.drops[i].Load()
+	}
+	return total
+}
+
 func (u *Server) createConn() (net.PacketConn, error) {
 	var err error
 	udpAdddr, err := net.ResolveUDPAddr("udp", u.config.Host)
@@ -71,3 +149,29 @@ func (u *Server) createConn() (net.PacketConn, error) {
 
 	return listener, err
 }
+
+// createWorkerConn opens the socket of a worker. The sockets of the workers
+// share their address, if the configured port is 0 the sockets opened after
+// the first one bind to the port it was assigned.
+func (u *Server) createWorkerConn() (net.PacketConn, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	address := u.config.Host
+	if _, port, err := net.SplitHostPort(address); err == nil && port == "0" && u.localaddress != "" {
+		address = u.localaddress
+	}
+	listener, err := listenReusePort(address)
+	if err != nil {
+		return nil, err
+	}
+	if u.config.ReadBuffer != 0 {
+		if err := listener.SetReadBuffer(int(u.config.ReadBuffer)); err != nil {
+			listener.Close()
+			return nil, err
+		}
+	}
+	u.localaddress = listener.LocalAddr().String()
+
+	return listener, nil
+}
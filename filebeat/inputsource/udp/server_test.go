@@ -104,3 +104,47 @@ func TestReceiveEventFromUDP(t *testing.T) {
 		})
 	}
 }
+
+func TestReceiveEventFromUDPWorkers(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("multiple UDP workers are only supported on linux")
+	}
+
+	const workers = 4
+	ch := make(chan info, workers)
+	config := &Config{
+		Host:           "localhost:0",
+		MaxMessageSize: maxMessageSize,
+		Timeout:        timeout,
+		Workers:        workers,
+		PinWorkers:     true,
+	}
+	fn := func(message []byte, metadata inputsource.NetworkMetadata) {
+		ch <- info{message: message, mt: metadata}
+	}
+	s := New(config, fn)
+	err := s.Start()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Stop()
+
+	// Each client socket is hashed to one of the sockets of the workers.
+	for i := 0; i < workers*4; i++ {
+		conn, err := net.Dial("udp", s.localaddress)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = conn.Write([]byte("Hello world not so nice"))
+		conn.Close()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		info := <-ch
+		assert.Equal(t, []byte("Hello world not so n"), info.message)
+		assert.NotNil(t, info.mt.RemoteAddr)
+		assert.True(t, info.mt.Truncated)
+	}
+	assert.Equal(t, uint64(0), s.Drops())
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package udp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime"
+
+	"github.com/elastic/beats/v7/filebeat/inputsource"
+	"github.com/elastic/beats/v7/filebeat/inputsource/common/dgram"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// workerReaderFactory returns the handler reading the datagrams received by
+// the socket of a worker. Along the datagrams it reads the number of datagrams
+// the socket dropped, reported in the SO_RXQ_OVFL control messages.
+func (u *Server) workerReaderFactory(id int, logger *logp.Logger, callback inputsource.NetworkFunc) dgram.HandlerFactory {
+	return func(config dgram.ListenerConfig) dgram.ConnectionHandler {
+		return func(ctx context.Context, conn net.PacketConn) error {
+			udpConn, ok := conn.(*net.UDPConn)
+			if !ok {
+				return errors.New("worker socket is not a UDP socket")
+			}
+
+			if u.config.PinWorkers {
+				runtime.LockOSThread()
+				defer runtime.UnlockOSThread()
+				cpu := id % runtime.NumCPU()
+				if err := pinToCPU(cpu); err != nil {
+					logger.Warnf("Failed to pin worker to CPU %d: %v", cpu, err)
+				}
+			}
+
+			oob := make([]byte, oobSize)
+			for ctx.Err() == nil {
+				buffer := make([]byte, config.MaxMessageSize)
+				length, oobn, flags, addr, err := udpConn.ReadMsgUDP(buffer, oob)
+				if err != nil {
+					// don't log any deadline events.
+					var netErr net.Error
+					if errors.As(err, &netErr) && netErr.Timeout() {
+						continue
+					}
+					if errors.Is(err, net.ErrClosed) {
+						logger.Info("Connection has been closed")
+						return nil
+					}
+					logger.Errorf("Error reading from the socket %s", err)
+					continue
+				}
+
+				if drops, ok := overflowDrops(oob[:oobn]); ok {
+					u.drops[id].Store(uint64(drops))
+				}
+
+				if length > 0 {
+					metadata := inputsource.NetworkMetadata{Truncated: flags&msgTrunc != 0}
+					if addr != nil {
+						metadata.RemoteAddr = addr
+					}
+					callback(buffer[:length], metadata)
+				}
+			}
+			logger.Debug("end of connection handling")
+			return nil
+		}
+	}
+}
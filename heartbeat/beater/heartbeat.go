@@ -31,6 +31,7 @@ import (
 
 	"github.com/elastic/beats/v7/heartbeat/config"
 	"github.com/elastic/beats/v7/heartbeat/hbregistry"
+	"github.com/elastic/beats/v7/heartbeat/live"
 	"github.com/elastic/beats/v7/heartbeat/monitors"
 	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/monitorstate"
@@ -58,6 +59,7 @@ type Heartbeat struct {
 	autodiscover       *autodiscover.Autodiscover
 	replaceStateLoader func(sl monitorstate.StateLoader)
 	trace              tracer.Tracer
+	liveHub            *live.Hub
 }
 
 // New creates a new heartbeat.
@@ -116,8 +118,24 @@ func New(b *beat.Beat, rawConfig *conf.C) (beat.Beater, error) {
 
 	sched := scheduler.Create(limit, hbregistry.SchedulerRegistry, location, jobConfig, parsedConfig.RunOnce)
 
+	var liveHub *live.Hub
+	if parsedConfig.Live.Enabled {
+		if b.API == nil {
+			return nil, errors.New("heartbeat.live requires the HTTP endpoint to be enabled with http.enabled")
+		}
+		liveHub = live.NewHub(parsedConfig.Live.History)
+		if err := b.API.AttachHandler(live.Route, liveHub); err != nil {
+			return nil, fmt.Errorf("failed to attach http handler for live monitor results: %w", err)
+		}
+		logp.L().Infof("streaming live monitor results at %s", live.Route)
+	}
+
 	pipelineClientFactory := func(p beat.Pipeline) (beat.Client, error) {
-		return p.Connect()
+		client, err := p.Connect()
+		if err != nil || liveHub == nil {
+			return client, err
+		}
+		return live.WrapClient(client, liveHub), nil
 	}
 
 	bt := &Heartbeat{
@@ -135,7 +153,8 @@ func New(b *beat.Beat, rawConfig *conf.C) (beat.Beater, error) {
 			PipelineClientFactory: pipelineClientFactory,
 			BeatRunFrom:           parsedConfig.RunFrom,
 		}),
-		trace: trace,
+		trace:   trace,
+		liveHub: liveHub,
 	}
 	runFromID := "<unknown location>"
 	if parsedConfig.RunFrom != nil {
@@ -320,7 +339,12 @@ func (bt *Heartbeat) makeAutodiscover(b *beat.Beat) (*autodiscover.Autodiscover,
 
 // Stop stops the beat.
 func (bt *Heartbeat) Stop() {
-	bt.stopOnce.Do(func() { close(bt.done) })
+	bt.stopOnce.Do(func() {
+		close(bt.done)
+		if bt.liveHub != nil {
+			bt.liveHub.Close()
+		}
+	})
 }
 
 // makeESClient establishes an ES connection meant to load monitors' state
@@ -49,6 +49,15 @@ type Config struct {
 	Jobs           map[string]*JobLimit `config:"jobs"`
 	RunFrom        *LocationWithID      `config:"run_from"`
 	SocketTrace    *SocketTrace         `config:"socket_trace"`
+	Live           Live                 `config:"live"`
+}
+
+// Live holds the `heartbeat.live` settings, streaming the results of the
+// monitors from the HTTP endpoint.
+type Live struct {
+	Enabled bool `config:"enabled"`
+	// History is the number of recent results replayed to new subscribers.
+	History int `config:"history" validate:"min=0"`
 }
 
 type JobLimit struct {
@@ -85,6 +94,7 @@ func DefaultConfig() *Config {
 
 	return &Config{
 		Jobs: limits,
+		Live: Live{History: 100},
 	}
 }
 
@@ -0,0 +1,52 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package live
+
+import (
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// client forwards the summary events published by a monitor to the Hub.
+type client struct {
+	beat.Client
+	hub *Hub
+}
+
+// WrapClient returns a beat.Client publishing to c that also sends the results
+// of the monitor checks to hub.
+func WrapClient(c beat.Client, hub *Hub) beat.Client {
+	return &client{Client: c, hub: hub}
+}
+
+func (c *client) Publish(event beat.Event) {
+	c.observe(&event)
+	c.Client.Publish(event)
+}
+
+func (c *client) PublishAll(events []beat.Event) {
+	for i := range events {
+		c.observe(&events[i])
+	}
+	c.Client.PublishAll(events)
+}
+
+func (c *client) observe(event *beat.Event) {
+	if r, ok := ResultFromEvent(event); ok {
+		c.hub.Publish(r)
+	}
+}
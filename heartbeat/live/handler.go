@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// keepAliveInterval is how often a comment is sent on idle streams, so that
+// proxies and browsers don't close them.
+var keepAliveInterval = 15 * time.Second
+
+// filter selects the results sent to a subscriber from the request query
+// parameters `id`, `type` and `status`. Empty parameters match everything.
+type filter struct {
+	id, typ, status string
+}
+
+func newFilter(q url.Values) filter {
+	return filter{id: q.Get("id"), typ: q.Get("type"), status: q.Get("status")}
+}
+
+func (f filter) match(r Result) bool {
+	return (f.id == "" || f.id == r.ID) &&
+		(f.typ == "" || f.typ == r.Type) &&
+		(f.status == "" || f.status == r.Status)
+}
+
+// ServeHTTP streams the results as Server-Sent Events, starting with the
+// recent results kept by the Hub.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	f := newFilter(req.URL.Query())
+	results, recent, cancel := h.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, r := range recent {
+		if f.match(r) {
+			if err := writeResult(w, r); err != nil {
+				return
+			}
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case r, ok := <-results:
+			if !ok {
+				return
+			}
+			if !f.match(r) {
+				continue
+			}
+			if err := writeResult(w, r); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeResult(w http.ResponseWriter, r Result) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: result\ndata: %s\n\n", data)
+	return err
+}
@@ -0,0 +1,202 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package live streams the results of monitor checks to local subscribers as
+// they happen, so they can be watched while tuning a configuration without
+// waiting for the data to round-trip through Elasticsearch.
+package live
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/ecserr"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/summarizer/jobsummary"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Route is the path the stream is served on by the beat HTTP endpoint.
+const Route = "/monitors/live"
+
+// subscriberBuffer is the number of results buffered per subscriber. Results
+// are dropped for subscribers that fall further behind, the monitors never
+// wait on them.
+const subscriberBuffer = 64
+
+// Result is the summary of a single monitor check.
+type Result struct {
+	Timestamp    time.Time `json:"@timestamp"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name,omitempty"`
+	Type         string    `json:"type,omitempty"`
+	Status       string    `json:"status"`
+	DurationUs   int64     `json:"duration_us"`
+	URL          string    `json:"url,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	CheckGroup   string    `json:"check_group,omitempty"`
+	Attempt      uint16    `json:"attempt,omitempty"`
+	FinalAttempt bool      `json:"final_attempt"`
+}
+
+// ResultFromEvent extracts the Result of a summary event. It returns false for
+// any other event, such as the individual steps of a browser journey.
+func ResultFromEvent(event *beat.Event) (Result, bool) {
+	if typ, _ := event.Fields.GetValue("event.type"); typ != "heartbeat/summary" {
+		return Result{}, false
+	}
+
+	r := Result{
+		Timestamp:  event.Timestamp,
+		ID:         stringValue(event.Fields, "monitor.id"),
+		Name:       stringValue(event.Fields, "monitor.name"),
+		Type:       stringValue(event.Fields, "monitor.type"),
+		Status:     stringValue(event.Fields, "monitor.status"),
+		DurationUs: int64Value(event.Fields, "monitor.duration.us"),
+		URL:        stringValue(event.Fields, "url.full"),
+		CheckGroup: stringValue(event.Fields, "monitor.check_group"),
+	}
+
+	switch s := valueOf(event.Fields, "summary").(type) {
+	case *jobsummary.JobSummary:
+		r.Attempt, r.FinalAttempt = s.Attempt, s.FinalAttempt
+		if r.Status == "" {
+			r.Status = string(s.Status)
+		}
+	case jobsummary.JobSummary:
+		r.Attempt, r.FinalAttempt = s.Attempt, s.FinalAttempt
+		if r.Status == "" {
+			r.Status = string(s.Status)
+		}
+	}
+
+	switch e := valueOf(event.Fields, "error").(type) {
+	case *ecserr.ECSErr:
+		r.Error = e.Message
+	case mapstr.M:
+		r.Error, _ = e["message"].(string)
+	}
+
+	return r, true
+}
+
+func valueOf(m mapstr.M, key string) interface{} {
+	v, _ := m.GetValue(key)
+	return v
+}
+
+func stringValue(m mapstr.M, key string) string {
+	s, _ := valueOf(m, key).(string)
+	return s
+}
+
+func int64Value(m mapstr.M, key string) int64 {
+	switch v := valueOf(m, key).(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+// Hub fans out monitor results to its subscribers and keeps the most recent
+// ones to replay to new subscribers.
+type Hub struct {
+	mu      sync.Mutex
+	history []Result
+	next    int
+	full    bool
+	subs    map[chan Result]struct{}
+	closed  bool
+}
+
+// NewHub creates a Hub keeping the last history results.
+func NewHub(history int) *Hub {
+	return &Hub{
+		history: make([]Result, history),
+		subs:    map[chan Result]struct{}{},
+	}
+}
+
+// Publish sends the result to all subscribers without blocking.
+func (h *Hub) Publish(r Result) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	if len(h.history) > 0 {
+		h.history[h.next] = r
+		h.next = (h.next + 1) % len(h.history)
+		h.full = h.full || h.next == 0
+	}
+
+	for ch := range h.subs {
+		select {
+		case ch <- r:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a new subscriber. It returns the channel results are
+// delivered on, the recent results in the order they were published and a
+// function to unsubscribe. The channel is closed when the Hub is closed.
+func (h *Hub) Subscribe() (<-chan Result, []Result, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan Result, subscriberBuffer)
+	if h.closed {
+		close(ch)
+		return ch, nil, func() {}
+	}
+	h.subs[ch] = struct{}{}
+
+	var recent []Result
+	if h.full {
+		recent = append(recent, h.history[h.next:]...)
+	}
+	recent = append(recent, h.history[:h.next]...)
+
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, found := h.subs[ch]; found {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+	return ch, recent, cancel
+}
+
+// Close ends all subscriptions.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
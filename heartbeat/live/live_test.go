@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package live
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/ecserr"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/monitorstate"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/summarizer/jobsummary"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func summaryEvent(id, status string) beat.Event {
+	return beat.Event{
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Fields: mapstr.M{
+			"event": mapstr.M{"type": "heartbeat/summary"},
+			"monitor": mapstr.M{
+				"id":          id,
+				"name":        id + " name",
+				"type":        "http",
+				"status":      status,
+				"check_group": "abc-1",
+				"duration":    mapstr.M{"us": int64(1500)},
+			},
+			"url": mapstr.M{"full": "http://localhost:8080"},
+			"summary": &jobsummary.JobSummary{
+				Attempt:      1,
+				MaxAttempts:  2,
+				FinalAttempt: true,
+				Status:       monitorstate.StatusUp,
+			},
+		},
+	}
+}
+
+func TestResultFromEvent(t *testing.T) {
+	t.Run("summary event", func(t *testing.T) {
+		event := summaryEvent("foo", "up")
+		r, ok := ResultFromEvent(&event)
+		require.True(t, ok)
+		assert.Equal(t, Result{
+			Timestamp:    event.Timestamp,
+			ID:           "foo",
+			Name:         "foo name",
+			Type:         "http",
+			Status:       "up",
+			DurationUs:   1500,
+			URL:          "http://localhost:8080",
+			CheckGroup:   "abc-1",
+			Attempt:      1,
+			FinalAttempt: true,
+		}, r)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		event := summaryEvent("foo", "down")
+		event.Fields["error"] = mapstr.M{"type": "io", "message": "connection refused"}
+		r, ok := ResultFromEvent(&event)
+		require.True(t, ok)
+		assert.Equal(t, "connection refused", r.Error)
+
+		event.Fields["error"] = ecserr.NewECSErr("io", "BAD", "journey failed")
+		r, ok = ResultFromEvent(&event)
+		require.True(t, ok)
+		assert.Equal(t, "journey failed", r.Error)
+	})
+
+	t.Run("non summary event", func(t *testing.T) {
+		event := beat.Event{Fields: mapstr.M{"synthetics": mapstr.M{"type": "step/end"}}}
+		_, ok := ResultFromEvent(&event)
+		assert.False(t, ok)
+	})
+}
+
+func TestHub(t *testing.T) {
+	hub := NewHub(2)
+	for _, id := range []string{"a", "b", "c"} {
+		hub.Publish(Result{ID: id})
+	}
+
+	results, recent, cancel := hub.Subscribe()
+	assert.Equal(t, []Result{{ID: "b"}, {ID: "c"}}, recent)
+
+	hub.Publish(Result{ID: "d"})
+	assert.Equal(t, Result{ID: "d"}, <-results)
+
+	// Slow subscribers drop results instead of blocking publishers.
+	for i := 0; i < subscriberBuffer*2; i++ {
+		hub.Publish(Result{ID: "e"})
+	}
+	assert.Len(t, results, subscriberBuffer)
+
+	cancel()
+	cancel()
+	hub.Publish(Result{ID: "f"})
+
+	_, _, cancel = hub.Subscribe()
+	defer cancel()
+	hub.Close()
+	closed, _, _ := hub.Subscribe()
+	_, ok := <-closed
+	assert.False(t, ok)
+}
+
+func TestWrapClient(t *testing.T) {
+	hub := NewHub(10)
+	inner := &recordingClient{}
+	c := WrapClient(inner, hub)
+
+	c.Publish(summaryEvent("foo", "up"))
+	c.PublishAll([]beat.Event{
+		{Fields: mapstr.M{"event": mapstr.M{"type": "journey/start"}}},
+		summaryEvent("bar", "down"),
+	})
+
+	assert.Len(t, inner.events, 3)
+	_, recent, cancel := hub.Subscribe()
+	defer cancel()
+	require.Len(t, recent, 2)
+	assert.Equal(t, "foo", recent[0].ID)
+	assert.Equal(t, "bar", recent[1].ID)
+}
+
+func TestServeHTTP(t *testing.T) {
+	hub := NewHub(10)
+	hub.Publish(Result{ID: "foo", Type: "http", Status: "up"})
+	hub.Publish(Result{ID: "bar", Type: "tcp", Status: "down"})
+
+	srv := httptest.NewServer(hub)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?status=down", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	next := func() Result {
+		for scanner.Scan() {
+			line := scanner.Text()
+			if data, found := strings.CutPrefix(line, "data: "); found {
+				var r Result
+				require.NoError(t, json.Unmarshal([]byte(data), &r))
+				return r
+			}
+		}
+		t.Fatalf("stream ended: %v", scanner.Err())
+		return Result{}
+	}
+
+	assert.Equal(t, "bar", next().ID)
+
+	hub.Publish(Result{ID: "baz", Status: "up"})
+	hub.Publish(Result{ID: "qux", Status: "down"})
+	assert.Equal(t, "qux", next().ID)
+}
+
+type recordingClient struct {
+	events []beat.Event
+}
+
+func (c *recordingClient) Publish(e beat.Event) { c.events = append(c.events, e) }
+
+func (c *recordingClient) PublishAll(es []beat.Event) { c.events = append(c.events, es...) }
+
+func (c *recordingClient) Close() error { return nil }
@@ -23,16 +23,19 @@ import (
 	"net"
 	"time"
 
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlscheck"
 	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/transport"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
 // TLSLayer configures the TLS layer in a DialerChain.
 // The layer will update the active event with the TLS RTT and
-// crypto/cert details.
-func TLSLayer(cfg *tlscommon.TLSConfig, to time.Duration) Layer {
+// crypto/cert details. If checker is not nil, the certificate chain
+// is checked too, and the connection fails when a check fails.
+func TLSLayer(cfg *tlscommon.TLSConfig, to time.Duration, checker *tlscheck.Checker) Layer {
 	return func(event *beat.Event, next transport.Dialer) (transport.Dialer, error) {
 		var timer timer
 
@@ -51,6 +54,15 @@ func TLSLayer(cfg *tlscommon.TLSConfig, to time.Duration) Layer {
 
 			tlsmeta.AddTLSMetadata(event.Fields, connState, timer.duration())
 
+			if checker != nil {
+				tlsFields, err := checker.Check(connState)
+				event.Fields.DeepUpdate(mapstr.M{"tls": tlsFields})
+				if err != nil {
+					_ = conn.Close()
+					return nil, err
+				}
+			}
+
 			return conn, nil
 		}), nil
 	}
@@ -0,0 +1,327 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlscheck
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// maxOCSPResponseSize limits the size of the responses read from OCSP
+// responders.
+const maxOCSPResponseSize = 1024 * 1024
+
+var (
+	// ErrRevoked is returned when the OCSP status of the server
+	// certificate is revoked.
+	ErrRevoked = errors.New("server certificate is revoked")
+
+	// ErrNoSCT is returned when SCTs are required and the server
+	// certificate has none.
+	ErrNoSCT = errors.New("server certificate has no signed certificate timestamp")
+)
+
+var (
+	// oidEmbeddedSCTs is the certificate extension holding SCTs.
+	oidEmbeddedSCTs = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+	// oidOCSPSCTs is the OCSP response extension holding SCTs.
+	oidOCSPSCTs = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 5}
+)
+
+var revocationReasons = map[int]string{
+	ocsp.Unspecified:          "unspecified",
+	ocsp.KeyCompromise:        "key_compromise",
+	ocsp.CACompromise:         "ca_compromise",
+	ocsp.AffiliationChanged:   "affiliation_changed",
+	ocsp.Superseded:           "superseded",
+	ocsp.CessationOfOperation: "cessation_of_operation",
+	ocsp.CertificateHold:      "certificate_hold",
+	ocsp.RemoveFromCRL:        "remove_from_crl",
+	ocsp.PrivilegeWithdrawn:   "privilege_withdrawn",
+	ocsp.AACompromise:         "aa_compromise",
+}
+
+// Checker checks the certificate chains of TLS connections. It is safe for
+// concurrent use.
+type Checker struct {
+	config Config
+	roots  *x509.CertPool
+	client *http.Client
+}
+
+// NewChecker returns a checker verifying chains against the root CAs of
+// the TLS configuration, or the system roots when it has none. It returns
+// nil when the checks are disabled.
+func NewChecker(config Config, tlsConfig *tlscommon.TLSConfig) *Checker {
+	if !config.Enabled {
+		return nil
+	}
+	c := &Checker{
+		config: config,
+		client: &http.Client{Timeout: config.OCSPTimeout},
+	}
+	if tlsConfig != nil {
+		c.roots = tlsConfig.RootCAs
+	}
+	return c
+}
+
+// Check returns the tls fields describing the certificate chain of the
+// connection: its validity, the expiry of every certificate, the OCSP
+// status and the SCTs of the server certificate. The error is set when the
+// chain fails a check that must fail the monitor, that is a revoked server
+// certificate or missing SCTs when they are required.
+func (c *Checker) Check(connState tls.ConnectionState) (mapstr.M, error) {
+	if len(connState.PeerCertificates) == 0 {
+		return mapstr.M{}, nil
+	}
+	now := time.Now()
+	leaf := connState.PeerCertificates[0]
+
+	chain, err := c.verify(connState, now)
+	chainFields := mapstr.M{
+		"valid": err == nil,
+	}
+	if err != nil {
+		chainFields["error"] = err.Error()
+	}
+	certificates := make([]mapstr.M, 0, len(chain))
+	minDays := math.MaxInt
+	for _, cert := range chain {
+		days := daysToExpiry(cert, now)
+		if days < minDays {
+			minDays = days
+		}
+		certificates = append(certificates, certificateFields(cert, days))
+	}
+	chainFields["certificates"] = certificates
+	chainFields["days_to_expiry"] = minDays
+	fields := mapstr.M{"chain": chainFields}
+
+	var (
+		checkErr error
+		ocspSCTs int
+	)
+	if c.config.OCSP {
+		var resp *ocsp.Response
+		fields["ocsp"], resp = c.checkOCSP(connState, chain)
+		if resp != nil {
+			ocspSCTs = countOCSPSCTs(resp)
+			if resp.Status == ocsp.Revoked {
+				checkErr = ErrRevoked
+			}
+		}
+	}
+
+	scts := len(connState.SignedCertificateTimestamps) + countCertificateSCTs(leaf) + ocspSCTs
+	fields["sct"] = mapstr.M{
+		"count":   scts,
+		"present": scts > 0,
+	}
+	if c.config.RequireSCT && scts == 0 && checkErr == nil {
+		checkErr = ErrNoSCT
+	}
+
+	return fields, checkErr
+}
+
+// verify returns the chain of the server certificate, verified by the
+// handshake or by the checker. When verification fails, the certificates
+// sent by the server are returned with the error.
+func (c *Checker) verify(connState tls.ConnectionState, now time.Time) ([]*x509.Certificate, error) {
+	if len(connState.VerifiedChains) > 0 {
+		return connState.VerifiedChains[0], nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range connState.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := connState.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       connState.ServerName,
+		Roots:         c.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	})
+	if err != nil {
+		return connState.PeerCertificates, err
+	}
+	return chains[0], nil
+}
+
+// checkOCSP returns the OCSP fields of the server certificate and the OCSP
+// response, if one could be obtained.
+func (c *Checker) checkOCSP(connState tls.ConnectionState, chain []*x509.Certificate) (mapstr.M, *ocsp.Response) {
+	fields := mapstr.M{"stapled": len(connState.OCSPResponse) > 0}
+	if len(chain) < 2 {
+		fields["status"] = "unavailable"
+		fields["error"] = "the issuer of the server certificate is unknown"
+		return fields, nil
+	}
+	leaf, issuer := chain[0], chain[1]
+
+	var (
+		resp *ocsp.Response
+		err  error
+	)
+	if len(connState.OCSPResponse) > 0 {
+		resp, err = ocsp.ParseResponseForCert(connState.OCSPResponse, leaf, issuer)
+	} else {
+		if len(leaf.OCSPServer) == 0 {
+			fields["status"] = "unavailable"
+			fields["error"] = "the server certificate has no OCSP responder"
+			return fields, nil
+		}
+		resp, err = c.queryOCSP(leaf.OCSPServer[0], leaf, issuer)
+	}
+	if err != nil {
+		fields["status"] = "error"
+		fields["error"] = err.Error()
+		return fields, nil
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		fields["status"] = "good"
+	case ocsp.Revoked:
+		fields["status"] = "revoked"
+		fields["revoked_at"] = resp.RevokedAt
+		if reason, found := revocationReasons[resp.RevocationReason]; found {
+			fields["revocation_reason"] = reason
+		}
+	default:
+		fields["status"] = "unknown"
+	}
+	fields["this_update"] = resp.ThisUpdate
+	if !resp.NextUpdate.IsZero() {
+		fields["next_update"] = resp.NextUpdate
+	}
+	return fields, resp
+}
+
+// queryOCSP requests the status of a certificate from an OCSP responder.
+func (c *Checker) queryOCSP(server string, leaf, issuer *x509.Certificate) (*ocsp.Response, error) {
+	req, err := ocsp.CreateRequest(leaf, issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCSP request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server, bytes.NewReader(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCSP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("OCSP request to %s failed: %w", server, err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder %s returned status %d", server, httpResp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, maxOCSPResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCSP response from %s: %w", server, err)
+	}
+	return ocsp.ParseResponseForCert(body, leaf, issuer)
+}
+
+func certificateFields(cert *x509.Certificate, days int) mapstr.M {
+	return mapstr.M{
+		"subject":        mapstr.M{"common_name": cert.Subject.CommonName},
+		"issuer":         mapstr.M{"common_name": cert.Issuer.CommonName},
+		"serial_number":  cert.SerialNumber.String(),
+		"not_before":     cert.NotBefore,
+		"not_after":      cert.NotAfter,
+		"days_to_expiry": days,
+		"is_ca":          cert.IsCA,
+		"hash":           mapstr.M{"sha256": fmt.Sprintf("%x", sha256.Sum256(cert.Raw))},
+	}
+}
+
+// daysToExpiry returns the number of whole days until the certificate
+// expires, negative once it has expired.
+func daysToExpiry(cert *x509.Certificate, now time.Time) int {
+	return int(math.Floor(cert.NotAfter.Sub(now).Hours() / 24))
+}
+
+func countCertificateSCTs(cert *x509.Certificate) int {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidEmbeddedSCTs) {
+			return countSCTList(ext.Value)
+		}
+	}
+	return 0
+}
+
+func countOCSPSCTs(resp *ocsp.Response) int {
+	for _, ext := range resp.Extensions {
+		if ext.Id.Equal(oidOCSPSCTs) {
+			return countSCTList(ext.Value)
+		}
+	}
+	return 0
+}
+
+// countSCTList returns the number of SCTs of a SignedCertificateTimestampList
+// (RFC 6962, section 3.3), held in an octet string by the certificate and
+// OCSP response extensions.
+func countSCTList(value []byte) int {
+	var list []byte
+	if rest, err := asn1.Unmarshal(value, &list); err != nil || len(rest) > 0 {
+		return 0
+	}
+	if len(list) < 2 {
+		return 0
+	}
+	n := int(binary.BigEndian.Uint16(list))
+	list = list[2:]
+	if n > len(list) {
+		return 0
+	}
+	list = list[:n]
+
+	count := 0
+	for len(list) >= 2 {
+		l := int(binary.BigEndian.Uint16(list))
+		if l == 0 || 2+l > len(list) {
+			break
+		}
+		list = list[2+l:]
+		count++
+	}
+	return count
+}
@@ -0,0 +1,282 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlscheck
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, parentCert := crypto.Signer(key), template
+	if parent != nil {
+		signer, parentCert = parent.key, parent.cert
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, key.Public(), signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func caTemplate(serial int64, name string, notAfter time.Time) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+}
+
+// testChain is a root, an intermediate and a server certificate for
+// localhost, expiring in 3650, 365 and 30 days.
+type testChain struct {
+	root, intermediate, leaf *testCert
+}
+
+func newTestChain(t *testing.T, leafExtensions []pkix.Extension, ocspServer string) testChain {
+	now := time.Now()
+	root := newTestCert(t, caTemplate(1, "Test Root", now.Add(3650*24*time.Hour+time.Hour)), nil)
+	intermediate := newTestCert(t, caTemplate(2, "Test Intermediate", now.Add(365*24*time.Hour+time.Hour)), root)
+	leafTemplate := &x509.Certificate{
+		SerialNumber:    big.NewInt(3),
+		Subject:         pkix.Name{CommonName: "localhost"},
+		DNSNames:        []string{"localhost"},
+		NotBefore:       now.Add(-time.Hour),
+		NotAfter:        now.Add(30*24*time.Hour + time.Hour),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: leafExtensions,
+	}
+	if ocspServer != "" {
+		leafTemplate.OCSPServer = []string{ocspServer}
+	}
+	leaf := newTestCert(t, leafTemplate, intermediate)
+	return testChain{root: root, intermediate: intermediate, leaf: leaf}
+}
+
+func (c testChain) connState() tls.ConnectionState {
+	return tls.ConnectionState{
+		ServerName:       "localhost",
+		PeerCertificates: []*x509.Certificate{c.leaf.cert, c.intermediate.cert},
+	}
+}
+
+func (c testChain) roots() *tlscommon.TLSConfig {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.root.cert)
+	return &tlscommon.TLSConfig{RootCAs: pool}
+}
+
+func (c testChain) ocspResponse(t *testing.T, status int) []byte {
+	t.Helper()
+	resp, err := ocsp.CreateResponse(c.intermediate.cert, c.intermediate.cert, ocsp.Response{
+		Status:           status,
+		SerialNumber:     c.leaf.cert.SerialNumber,
+		ThisUpdate:       time.Now().Add(-time.Hour),
+		NextUpdate:       time.Now().Add(time.Hour),
+		RevokedAt:        time.Now().Add(-time.Minute),
+		RevocationReason: ocsp.KeyCompromise,
+	}, c.intermediate.key)
+	require.NoError(t, err)
+	return resp
+}
+
+// sctExtension returns a certificate extension holding n fake SCTs.
+func sctExtension(t *testing.T, n int) pkix.Extension {
+	t.Helper()
+	sct := []byte{0, 3, 1, 2, 3}
+	list := []byte{0, byte(n * len(sct))}
+	for i := 0; i < n; i++ {
+		list = append(list, sct...)
+	}
+	value, err := asn1.Marshal(list)
+	require.NoError(t, err)
+	return pkix.Extension{Id: oidEmbeddedSCTs, Value: value}
+}
+
+func enabledConfig() Config {
+	config := DefaultConfig()
+	config.Enabled = true
+	return config
+}
+
+func TestNewCheckerDisabled(t *testing.T) {
+	assert.Nil(t, NewChecker(DefaultConfig(), nil))
+}
+
+func TestCheckChain(t *testing.T) {
+	chain := newTestChain(t, nil, "")
+	config := enabledConfig()
+	config.OCSP = false
+
+	fields, err := NewChecker(config, chain.roots()).Check(chain.connState())
+	require.NoError(t, err)
+
+	assert.Equal(t, true, fields["chain"].(mapstr.M)["valid"])
+	assert.Equal(t, 30, fields["chain"].(mapstr.M)["days_to_expiry"])
+	certificates := fields["chain"].(mapstr.M)["certificates"].([]mapstr.M)
+	require.Len(t, certificates, 3)
+	for i, expected := range []struct {
+		name string
+		days int
+		isCA bool
+	}{
+		{"localhost", 30, false},
+		{"Test Intermediate", 365, true},
+		{"Test Root", 3650, true},
+	} {
+		assert.Equal(t, expected.name, certificates[i]["subject"].(mapstr.M)["common_name"])
+		assert.Equal(t, expected.days, certificates[i]["days_to_expiry"])
+		assert.Equal(t, expected.isCA, certificates[i]["is_ca"])
+	}
+	assert.Equal(t, mapstr.M{"count": 0, "present": false}, fields["sct"])
+}
+
+func TestCheckChainUnknownRoot(t *testing.T) {
+	chain := newTestChain(t, nil, "")
+	config := enabledConfig()
+	config.OCSP = false
+
+	fields, err := NewChecker(config, &tlscommon.TLSConfig{RootCAs: x509.NewCertPool()}).Check(chain.connState())
+	require.NoError(t, err)
+
+	chainFields := fields["chain"].(mapstr.M)
+	assert.Equal(t, false, chainFields["valid"])
+	assert.Contains(t, chainFields["error"], "unknown authority")
+	assert.Len(t, chainFields["certificates"], 2)
+}
+
+func TestCheckStapledOCSP(t *testing.T) {
+	chain := newTestChain(t, nil, "")
+	connState := chain.connState()
+	connState.OCSPResponse = chain.ocspResponse(t, ocsp.Good)
+
+	fields, err := NewChecker(enabledConfig(), chain.roots()).Check(connState)
+	require.NoError(t, err)
+
+	ocspFields := fields["ocsp"].(mapstr.M)
+	assert.Equal(t, "good", ocspFields["status"])
+	assert.Equal(t, true, ocspFields["stapled"])
+	assert.Contains(t, ocspFields, "next_update")
+}
+
+func TestCheckOCSPResponderRevoked(t *testing.T) {
+	var chain testChain
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req, err := ocsp.ParseRequest(body)
+		if err != nil || req.SerialNumber.Cmp(chain.leaf.cert.SerialNumber) != 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(chain.ocspResponse(t, ocsp.Revoked))
+	}))
+	defer server.Close()
+	chain = newTestChain(t, nil, server.URL)
+
+	fields, err := NewChecker(enabledConfig(), chain.roots()).Check(chain.connState())
+	assert.ErrorIs(t, err, ErrRevoked)
+
+	ocspFields := fields["ocsp"].(mapstr.M)
+	assert.Equal(t, "revoked", ocspFields["status"])
+	assert.Equal(t, false, ocspFields["stapled"])
+	assert.Equal(t, "key_compromise", ocspFields["revocation_reason"])
+}
+
+func TestCheckOCSPUnavailable(t *testing.T) {
+	chain := newTestChain(t, nil, "")
+
+	fields, err := NewChecker(enabledConfig(), chain.roots()).Check(chain.connState())
+	require.NoError(t, err)
+	assert.Equal(t, "unavailable", fields["ocsp"].(mapstr.M)["status"])
+}
+
+func TestCheckOCSPResponderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	chain := newTestChain(t, nil, server.URL)
+
+	// Responder failures are reported but don't fail the check.
+	fields, err := NewChecker(enabledConfig(), chain.roots()).Check(chain.connState())
+	require.NoError(t, err)
+	assert.Equal(t, "error", fields["ocsp"].(mapstr.M)["status"])
+	assert.Contains(t, fields["ocsp"].(mapstr.M)["error"], "returned status 500")
+}
+
+func TestCheckRequireSCT(t *testing.T) {
+	config := enabledConfig()
+	config.OCSP = false
+	config.RequireSCT = true
+
+	chain := newTestChain(t, nil, "")
+	_, err := NewChecker(config, chain.roots()).Check(chain.connState())
+	assert.ErrorIs(t, err, ErrNoSCT)
+
+	chain = newTestChain(t, []pkix.Extension{sctExtension(t, 2)}, "")
+	connState := chain.connState()
+	connState.SignedCertificateTimestamps = [][]byte{{1, 2, 3}}
+	fields, err := NewChecker(config, chain.roots()).Check(connState)
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{"count": 3, "present": true}, fields["sct"])
+}
+
+func TestCountSCTList(t *testing.T) {
+	assert.Equal(t, 2, countSCTList(sctExtension(t, 2).Value))
+	assert.Equal(t, 0, countSCTList(nil))
+	assert.Equal(t, 0, countSCTList([]byte{0x04, 0x01, 0x00}))
+
+	// A truncated list counts the complete SCTs only.
+	value, err := asn1.Marshal([]byte{0, 7, 0, 2, 1, 2, 0, 9, 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, countSCTList(value))
+}
@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlscheck
+
+import "time"
+
+// Config configures the checks of the certificate chain presented by a TLS
+// server.
+type Config struct {
+	Enabled bool `config:"enabled"`
+
+	// OCSP checks the revocation status of the server certificate, with the
+	// OCSP response stapled by the server or else with the OCSP responder of
+	// the certificate.
+	OCSP        bool          `config:"ocsp"`
+	OCSPTimeout time.Duration `config:"ocsp_timeout" validate:"positive"`
+
+	// RequireSCT fails the check when the server certificate comes with no
+	// signed certificate timestamp (SCT) of a Certificate Transparency log.
+	RequireSCT bool `config:"require_sct"`
+}
+
+// DefaultConfig returns the default configuration, with the checks
+// disabled.
+func DefaultConfig() Config {
+	return Config{
+		OCSP:        true,
+		OCSPTimeout: 5 * time.Second,
+	}
+}
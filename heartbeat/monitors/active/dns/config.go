@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+)
+
+// resolvConf is where the system resolvers are read from when none is
+// configured.
+const resolvConf = "/etc/resolv.conf"
+
+type config struct {
+	// Hosts are the names to query.
+	Hosts []string `config:"hosts" validate:"required"`
+
+	// Resolvers are the addresses of the DNS servers to query. The system
+	// resolvers are used when empty.
+	Resolvers []string `config:"resolvers"`
+
+	// QueryTypes are the record types to query for each host.
+	QueryTypes []string `config:"query_types"`
+
+	// Transport is the protocol of the queries, udp or tcp. Truncated UDP
+	// responses are queried again over TCP.
+	Transport string `config:"transport"`
+
+	Timeout time.Duration `config:"timeout"`
+
+	// DNSSEC requires the resolvers to authenticate the answers with DNSSEC.
+	DNSSEC bool `config:"dnssec"`
+
+	Check checkConfig `config:"check"`
+}
+
+type checkConfig struct {
+	// RCode is the expected response code.
+	RCode string `config:"rcode"`
+
+	Answers answersCheckConfig `config:"answers"`
+}
+
+type answersCheckConfig struct {
+	// Equals is the exact set of expected answers.
+	Equals []string `config:"equals"`
+
+	// Contains are answers that must be part of the response.
+	Contains []string `config:"contains"`
+
+	// Regex are patterns that must each match at least one answer.
+	Regex []match.Matcher `config:"regex"`
+}
+
+func defaultConfig() config {
+	return config{
+		QueryTypes: []string{"A"},
+		Transport:  "udp",
+		Timeout:    5 * time.Second,
+		Check: checkConfig{
+			RCode: "NOERROR",
+		},
+	}
+}
+
+func (c *config) Validate() error {
+	if len(c.QueryTypes) == 0 {
+		return errors.New("query_types must not be empty")
+	}
+	for _, t := range c.QueryTypes {
+		if _, ok := dns.StringToType[strings.ToUpper(t)]; !ok {
+			return fmt.Errorf("unknown query type '%s'", t)
+		}
+	}
+	if c.Transport != "udp" && c.Transport != "tcp" {
+		return fmt.Errorf("unsupported transport '%s', must be udp or tcp", c.Transport)
+	}
+	if _, ok := dns.StringToRcode[strings.ToUpper(c.Check.RCode)]; !ok {
+		return fmt.Errorf("unknown response code '%s'", c.Check.RCode)
+	}
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be greater than 0")
+	}
+	return nil
+}
+
+// resolvers returns the addresses of the resolvers to query, with the
+// default port when missing.
+func (c *config) resolvers() ([]string, error) {
+	servers := c.Resolvers
+	if len(servers) == 0 {
+		sys, err := dns.ClientConfigFromFile(resolvConf)
+		if err != nil {
+			return nil, fmt.Errorf("no resolvers configured and system resolvers unavailable: %w", err)
+		}
+		servers = sys.Servers
+	}
+
+	addrs := make([]string, 0, len(servers))
+	for _, s := range servers {
+		if _, _, err := net.SplitHostPort(s); err != nil {
+			s = net.JoinHostPort(strings.Trim(s, "[]"), "53")
+		}
+		addrs = append(addrs, s)
+	}
+	return addrs, nil
+}
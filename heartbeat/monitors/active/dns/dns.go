@@ -0,0 +1,276 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	plugin.Register("dns", create, "synthetics/dns")
+}
+
+var debugf = logp.MakeDebug("dns")
+
+var errNotAuthenticated = errors.New("response is not authenticated with DNSSEC")
+
+func create(
+	name string,
+	cfg *conf.C,
+) (p plugin.Plugin, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	resolvers, err := config.resolvers()
+	if err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	var js []jobs.Job
+	for _, host := range config.Hosts {
+		for _, resolver := range resolvers {
+			for _, typ := range config.QueryTypes {
+				q := &query{
+					config:   &config,
+					name:     dns.Fqdn(host),
+					qtype:    dns.StringToType[strings.ToUpper(typ)],
+					resolver: resolver,
+				}
+				js = append(js, wraputil.WithURLField(q.url(), jobs.MakeSimpleJob(q.run)))
+			}
+		}
+	}
+
+	return plugin.Plugin{Jobs: js, Endpoints: len(js)}, nil
+}
+
+// query is the check of a record type of a name against a resolver.
+type query struct {
+	config   *config
+	name     string
+	qtype    uint16
+	resolver string
+}
+
+func (q *query) url() *url.URL {
+	return &url.URL{
+		Scheme:   "dns",
+		Host:     q.resolver,
+		Path:     "/" + strings.TrimSuffix(q.name, "."),
+		RawQuery: url.Values{"type": []string{dns.TypeToString[q.qtype]}}.Encode(),
+	}
+}
+
+func (q *query) run(event *beat.Event) error {
+	msg := new(dns.Msg)
+	msg.SetQuestion(q.name, q.qtype)
+	if q.config.DNSSEC {
+		msg.SetEdns0(4096, true)
+		msg.AuthenticatedData = true
+	}
+
+	resp, rtt, err := q.exchange(msg, q.config.Transport)
+	if err == nil && resp.Truncated && q.config.Transport == "udp" {
+		debugf("truncated response from %s, querying again over tcp", q.resolver)
+		resp, rtt, err = q.exchange(msg, "tcp")
+	}
+	if err != nil {
+		debugf("query of %s to %s failed with: %v", q.name, q.resolver, err)
+		return reason.IOFailed(err)
+	}
+
+	eventext.MergeEventFields(event, mapstr.M{
+		"dns": q.responseFields(resp, rtt),
+	})
+	return q.check(resp)
+}
+
+func (q *query) exchange(msg *dns.Msg, transport string) (*dns.Msg, time.Duration, error) {
+	client := &dns.Client{Net: transport, Timeout: q.config.Timeout}
+	return client.Exchange(msg, q.resolver)
+}
+
+// responseFields returns the ECS dns fields of the response.
+func (q *query) responseFields(resp *dns.Msg, rtt time.Duration) mapstr.M {
+	fields := mapstr.M{
+		"question": mapstr.M{
+			"name":  strings.TrimSuffix(q.name, "."),
+			"type":  dns.TypeToString[q.qtype],
+			"class": "IN",
+		},
+		"id":            resp.Id,
+		"response_code": dns.RcodeToString[resp.Rcode],
+		"header_flags":  headerFlags(resp),
+		"resolver":      q.resolver,
+		"rtt":           look.RTT(rtt),
+	}
+
+	var answers []mapstr.M
+	var ips []string
+	for _, rr := range resp.Answer {
+		hdr := rr.Header()
+		answers = append(answers, mapstr.M{
+			"name":  strings.TrimSuffix(hdr.Name, "."),
+			"type":  dns.TypeToString[hdr.Rrtype],
+			"class": dns.ClassToString[hdr.Class],
+			"ttl":   hdr.Ttl,
+			"data":  rdata(rr),
+		})
+		switch rr := rr.(type) {
+		case *dns.A:
+			ips = append(ips, rr.A.String())
+		case *dns.AAAA:
+			ips = append(ips, rr.AAAA.String())
+		}
+	}
+	if len(answers) != 0 {
+		fields["answers"] = answers
+	}
+	if len(ips) != 0 {
+		fields["resolved_ip"] = ips
+	}
+	return fields
+}
+
+// check validates the response against the configured checks.
+func (q *query) check(resp *dns.Msg) error {
+	if want := dns.StringToRcode[strings.ToUpper(q.config.Check.RCode)]; resp.Rcode != want {
+		return reason.ValidateFailed(fmt.Errorf("unexpected response code %s, expected %s",
+			dns.RcodeToString[resp.Rcode], dns.RcodeToString[want]))
+	}
+	if q.config.DNSSEC && !resp.AuthenticatedData {
+		return reason.ValidateFailed(errNotAuthenticated)
+	}
+
+	// Only the records of the queried type are checked, the records of
+	// the CNAME chain leading to them are not.
+	var answers []string
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype == q.qtype {
+			answers = append(answers, normalize(rdata(rr)))
+		}
+	}
+	return reason.ValidateFailed(checkAnswers(&q.config.Check.Answers, answers))
+}
+
+func checkAnswers(check *answersCheckConfig, answers []string) error {
+	if len(check.Equals) != 0 {
+		expected := make([]string, 0, len(check.Equals))
+		for _, a := range check.Equals {
+			expected = append(expected, normalize(a))
+		}
+		if !sameSet(expected, answers) {
+			return fmt.Errorf("answers %v do not equal the expected answers %v", answers, expected)
+		}
+	}
+
+	for _, a := range check.Contains {
+		if !contains(answers, normalize(a)) {
+			return fmt.Errorf("answers %v do not contain %s", answers, a)
+		}
+	}
+
+	for _, m := range check.Regex {
+		if !m.MatchAnyString(answers) {
+			return fmt.Errorf("no answer of %v matches %s", answers, m.String())
+		}
+	}
+	return nil
+}
+
+// rdata returns the data of a record in presentation format.
+func rdata(rr dns.RR) string {
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// normalize removes the trailing dot of fully qualified names, so that they
+// can be configured either way.
+func normalize(s string) string {
+	return strings.TrimSuffix(s, ".")
+}
+
+func headerFlags(msg *dns.Msg) []string {
+	var flags []string
+	if msg.Authoritative {
+		flags = append(flags, "AA")
+	}
+	if msg.Truncated {
+		flags = append(flags, "TC")
+	}
+	if msg.RecursionDesired {
+		flags = append(flags, "RD")
+	}
+	if msg.RecursionAvailable {
+		flags = append(flags, "RA")
+	}
+	if msg.AuthenticatedData {
+		flags = append(flags, "AD")
+	}
+	if msg.CheckingDisabled {
+		flags = append(flags, "CD")
+	}
+	if opt := msg.IsEdns0(); opt != nil && opt.Do() {
+		flags = append(flags, "DO")
+	}
+	return flags
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dns
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// startServer starts a DNS server answering example.com with two A records
+// behind a CNAME, unsigned.example.com with an A record, and other names
+// with NXDOMAIN. The responses about example.com have the AD flag when the
+// query has the DO bit.
+func startServer(t *testing.T) string {
+	t.Helper()
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		resp.RecursionAvailable = true
+		q := req.Question[0]
+		switch {
+		case q.Name == "example.com." && q.Qtype == dns.TypeA:
+			resp.Answer = []dns.RR{
+				mustRR(t, "example.com. 300 IN CNAME web.example.com."),
+				mustRR(t, "web.example.com. 300 IN A 192.0.2.1"),
+				mustRR(t, "web.example.com. 300 IN A 192.0.2.2"),
+			}
+		case q.Name == "example.com." && q.Qtype == dns.TypeCNAME:
+			resp.Answer = []dns.RR{mustRR(t, "example.com. 300 IN CNAME web.example.com.")}
+		case q.Name == "unsigned.example.com." && q.Qtype == dns.TypeA:
+			resp.Answer = []dns.RR{mustRR(t, "unsigned.example.com. 300 IN A 192.0.2.3")}
+		case q.Name == "example.com." || q.Name == "unsigned.example.com.":
+		default:
+			resp.Rcode = dns.RcodeNameError
+		}
+		if opt := req.IsEdns0(); opt != nil && opt.Do() && q.Name == "example.com." {
+			resp.AuthenticatedData = true
+			resp.SetEdns0(4096, true)
+		}
+		_ = w.WriteMsg(resp)
+	})
+
+	server := &dns.Server{PacketConn: pc, Handler: handler}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	require.NoError(t, err)
+	return rr
+}
+
+func runMonitor(t *testing.T, settings map[string]interface{}) (mapstr.M, error) {
+	t.Helper()
+
+	p, err := create("dns", conf.MustNewConfigFrom(settings))
+	require.NoError(t, err)
+	require.Len(t, p.Jobs, 1)
+	require.Equal(t, 1, p.Endpoints)
+
+	events, err := jobs.ExecJobAndConts(t, p.Jobs[0])
+	require.Len(t, events, 1)
+	return events[0].Fields, err
+}
+
+func TestQuery(t *testing.T) {
+	resolver := startServer(t)
+
+	fields, err := runMonitor(t, map[string]interface{}{
+		"hosts":     []string{"example.com"},
+		"resolvers": []string{resolver},
+		"check.answers": map[string]interface{}{
+			"equals":   []string{"192.0.2.2", "192.0.2.1"},
+			"contains": []string{"192.0.2.1"},
+			"regex":    []string{`^192\.0\.2\.`},
+		},
+	})
+	require.NoError(t, err)
+
+	dnsFields := fields["dns"].(mapstr.M)
+	assert.Equal(t, "NOERROR", dnsFields["response_code"])
+	assert.Equal(t, resolver, dnsFields["resolver"])
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, dnsFields["resolved_ip"])
+	assert.Len(t, dnsFields["answers"], 3)
+	assert.Contains(t, dnsFields, "rtt")
+	assert.Contains(t, dnsFields["header_flags"], "RA")
+
+	full, err := fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "dns://"+resolver+"/example.com?type=A", full)
+}
+
+func TestQueryChecks(t *testing.T) {
+	resolver := startServer(t)
+
+	tests := map[string]struct {
+		settings map[string]interface{}
+		fails    bool
+	}{
+		"equals mismatch": {
+			settings: map[string]interface{}{"check.answers.equals": []string{"192.0.2.1"}},
+			fails:    true,
+		},
+		"equals name without trailing dot": {
+			settings: map[string]interface{}{
+				"query_types":          []string{"CNAME"},
+				"check.answers.equals": []string{"web.example.com"},
+			},
+		},
+		"contains missing": {
+			settings: map[string]interface{}{"check.answers.contains": []string{"192.0.2.3"}},
+			fails:    true,
+		},
+		"regex without match": {
+			settings: map[string]interface{}{"check.answers.regex": []string{`^10\.`}},
+			fails:    true,
+		},
+		"unexpected response code": {
+			settings: map[string]interface{}{"hosts": []string{"missing.example.com"}},
+			fails:    true,
+		},
+		"expected response code": {
+			settings: map[string]interface{}{
+				"hosts":       []string{"missing.example.com"},
+				"check.rcode": "NXDOMAIN",
+			},
+		},
+		"dnssec": {
+			settings: map[string]interface{}{"dnssec": true},
+		},
+		"dnssec not authenticated": {
+			settings: map[string]interface{}{
+				"hosts":  []string{"unsigned.example.com"},
+				"dnssec": true,
+			},
+			fails: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings := map[string]interface{}{
+				"hosts":     []string{"example.com"},
+				"resolvers": []string{resolver},
+			}
+			for k, v := range test.settings {
+				settings[k] = v
+			}
+
+			_, err := runMonitor(t, settings)
+			if !test.fails {
+				assert.NoError(t, err)
+				return
+			}
+			var r reason.Reason
+			require.True(t, errors.As(err, &r), "error is a reason: %v", err)
+			assert.Equal(t, "validate", r.Type())
+		})
+	}
+}
+
+func TestQueryIOError(t *testing.T) {
+	// Nothing answers on the port of a closed socket.
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := pc.LocalAddr().String()
+	pc.Close()
+
+	_, err = runMonitor(t, map[string]interface{}{
+		"hosts":     []string{"example.com"},
+		"resolvers": []string{addr},
+		"timeout":   "100ms",
+	})
+	var r reason.Reason
+	require.True(t, errors.As(err, &r))
+	assert.Equal(t, "io", r.Type())
+}
+
+func TestConfig(t *testing.T) {
+	for name, settings := range map[string]map[string]interface{}{
+		"unknown query type": {"query_types": []string{"NOPE"}},
+		"unknown rcode":      {"check.rcode": "NOPE"},
+		"unknown transport":  {"transport": "quic"},
+		"no hosts":           {"hosts": []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := map[string]interface{}{"hosts": []string{"example.com"}}
+			for k, v := range settings {
+				cfg[k] = v
+			}
+			config := defaultConfig()
+			assert.Error(t, conf.MustNewConfigFrom(cfg).Unpack(&config))
+		})
+	}
+
+	config := config{Resolvers: []string{"192.0.2.53", "192.0.2.54:5353", "2001:db8::53", "[2001:db8::54]"}}
+	resolvers, err := config.resolvers()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.53:53", "192.0.2.54:5353", "[2001:db8::53]:53", "[2001:db8::54]:53"}, resolvers)
+}
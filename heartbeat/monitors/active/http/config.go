@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlscheck"
 	"github.com/elastic/beats/v7/libbeat/conditions"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 )
@@ -43,6 +44,9 @@ type Config struct {
 	// http(s) ping validation
 	Check checkConfig `config:"check"`
 
+	// https certificate chain checks
+	TLSChecks tlscheck.Config `config:"tls_checks"`
+
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 }
 
@@ -112,6 +116,7 @@ func defaultConfig() Config {
 				RecvJSON:    nil,
 			},
 		},
+		TLSChecks: tlscheck.DefaultConfig(),
 		Transport: httpcommon.DefaultHTTPTransportSettings(),
 	}
 	cfg.Transport.Timeout = 16 * time.Second
@@ -22,12 +22,14 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlscheck"
 	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
 	"github.com/elastic/beats/v7/libbeat/version"
 	conf "github.com/elastic/elastic-agent-libs/config"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 	"github.com/elastic/elastic-agent-libs/useragent"
@@ -35,6 +37,7 @@ import (
 
 func init() {
 	plugin.Register("http", create, "synthetics/http")
+	seccomp.RegisterBase(seccomp.KindInput, "http")
 }
 
 var userAgent = useragent.UserAgent("Heartbeat", version.GetDefaultVersion(), version.Commit(), version.BuildTime().String())
@@ -74,6 +77,13 @@ func create(
 		return plugin.Plugin{}, err
 	}
 
+	// preload TLS configuration
+	tls, err := tlscommon.LoadTLSConfig(config.Transport.TLS)
+	if err != nil {
+		return plugin.Plugin{}, err
+	}
+	tlsChecker := tlscheck.NewChecker(config.TLSChecks, tls)
+
 	// Determine whether we're using a proxy or not and then use that to figure out how to
 	// run the job
 	var makeJob func(string) (jobs.Job, error)
@@ -87,18 +97,13 @@ func create(
 		}
 
 		makeJob = func(urlStr string) (jobs.Job, error) {
-			return newHTTPMonitorHostJob(urlStr, &config, transport, enc, body, validator)
+			return newHTTPMonitorHostJob(urlStr, &config, transport, enc, body, validator, tlsChecker)
 		}
 	} else {
-		// preload TLS configuration
-		tls, err := tlscommon.LoadTLSConfig(config.Transport.TLS)
-		if err != nil {
-			return plugin.Plugin{}, err
-		}
 		config.Transport.TLS = nil
 
 		makeJob = func(urlStr string) (jobs.Job, error) {
-			return newHTTPMonitorIPsJob(&config, urlStr, tls, enc, body, validator)
+			return newHTTPMonitorIPsJob(&config, urlStr, tls, enc, body, validator, tlsChecker)
 		}
 	}
 
@@ -36,6 +36,7 @@ import (
 	"github.com/elastic/elastic-agent-libs/mapstr"
 
 	"github.com/elastic/beats/v7/heartbeat/ecserr"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlscheck"
 	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
 
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
@@ -59,6 +60,7 @@ func newHTTPMonitorHostJob(
 	enc contentEncoder,
 	body []byte,
 	validator multiValidator,
+	tlsChecker *tlscheck.Checker,
 ) (jobs.Job, error) {
 
 	var reqFactory requestFactory = func() (*http.Request, error) { return buildRequest(addr, config, enc) }
@@ -77,7 +79,7 @@ func newHTTPMonitorHostJob(
 			return fmt.Errorf("could not make http request: %w", err)
 		}
 
-		_, err = execPing(event, client, req, body, config.Transport.Timeout, validator, config.Response)
+		_, err = execPing(event, client, req, body, config.Transport.Timeout, validator, config.Response, tlsChecker)
 		if len(redirects) > 0 {
 			_, _ = event.PutValue("http.response.redirects", redirects)
 		}
@@ -92,6 +94,7 @@ func newHTTPMonitorIPsJob(
 	enc contentEncoder,
 	body []byte,
 	validator multiValidator,
+	tlsChecker *tlscheck.Checker,
 ) (jobs.Job, error) {
 
 	var reqFactory requestFactory = func() (*http.Request, error) { return buildRequest(addr, config, enc) }
@@ -101,7 +104,7 @@ func newHTTPMonitorIPsJob(
 		return nil, err
 	}
 
-	pingFactory := createPingFactory(config, port, tls, reqFactory, body, validator)
+	pingFactory := createPingFactory(config, port, tls, tlsChecker, reqFactory, body, validator)
 	job, err := monitors.MakeByHostJob(hostname, config.Mode, monitors.NewStdResolver(), pingFactory)
 
 	return job, err
@@ -111,6 +114,7 @@ func createPingFactory(
 	config *Config,
 	port uint16,
 	tls *tlscommon.TLSConfig,
+	tlsChecker *tlscheck.Checker,
 	reqFactory requestFactory,
 	body []byte,
 	validator multiValidator,
@@ -130,7 +134,7 @@ func createPingFactory(
 		}
 
 		if isTLS {
-			d.AddLayer(dialchain.TLSLayer(tls, timeout))
+			d.AddLayer(dialchain.TLSLayer(tls, timeout, tlsChecker))
 		}
 
 		dialer, err := d.Build(event)
@@ -173,7 +177,8 @@ func createPingFactory(
 			Transport:     httpcommon.HeaderRoundTripper(transport, map[string]string{"User-Agent": userAgent}),
 		}
 
-		end, err := execPing(event, client, req, body, timeout, validator, config.Response)
+		// The certificate chain is checked by the TLS layer.
+		end, err := execPing(event, client, req, body, timeout, validator, config.Response, nil)
 		cbMutex.Lock()
 		defer cbMutex.Unlock()
 
@@ -231,6 +236,7 @@ func execPing(
 	timeout time.Duration,
 	validator multiValidator,
 	responseConfig responseConfig,
+	tlsChecker *tlscheck.Checker,
 ) (end time.Time, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -296,6 +302,14 @@ func execPing(
 		tlsFields := mapstr.M{}
 		tlsmeta.AddTLSMetadata(tlsFields, *resp.TLS, tlsmeta.UnknownTLSHandshakeDuration)
 		eventext.MergeEventFields(event, tlsFields)
+
+		if tlsChecker != nil {
+			checkFields, checkErr := tlsChecker.Check(*resp.TLS)
+			eventext.MergeEventFields(event, mapstr.M{"tls": checkFields})
+			if checkErr != nil && errReason == nil {
+				errReason = reason.ValidateFailed(checkErr)
+			}
+		}
 	}
 
 	// Add total HTTP RTT
@@ -32,6 +32,7 @@ import (
 	"github.com/elastic/beats/v7/heartbeat/monitors"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -39,6 +40,11 @@ var debugf = logp.MakeDebug("icmp")
 
 func init() {
 	plugin.Register("icmp", create, "synthetics/icmp")
+	// ICMP echo replies are read from a raw socket bound to all addresses.
+	seccomp.RegisterRequirements(seccomp.KindInput, "icmp", seccomp.Requirements{
+		Listen:       true,
+		Capabilities: []string{"CAP_NET_RAW"},
+	})
 }
 
 func create(
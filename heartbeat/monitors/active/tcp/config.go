@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlscheck"
 	"github.com/elastic/elastic-agent-libs/transport"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
@@ -38,6 +39,9 @@ type config struct {
 	// configure tls
 	TLS *tlscommon.Config `config:"ssl"`
 
+	// check the certificate chain
+	TLSChecks tlscheck.Config `config:"tls_checks"`
+
 	Timeout time.Duration `config:"timeout"`
 
 	// validate connection
@@ -47,8 +51,9 @@ type config struct {
 
 func defaultConfig() config {
 	return config{
-		Timeout: 16 * time.Second,
-		Mode:    monitors.DefaultIPSettings,
+		Timeout:   16 * time.Second,
+		Mode:      monitors.DefaultIPSettings,
+		TLSChecks: tlscheck.DefaultConfig(),
 	}
 }
 
@@ -28,12 +28,14 @@ import (
 	"github.com/elastic/beats/v7/heartbeat/look"
 	"github.com/elastic/beats/v7/heartbeat/monitors"
 	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlscheck"
 	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
 	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
 	"github.com/elastic/beats/v7/heartbeat/reason"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -43,6 +45,7 @@ import (
 
 func init() {
 	plugin.Register("tcp", create, "synthetics/tcp")
+	seccomp.RegisterBase(seccomp.KindInput, "tcp")
 }
 
 var debugf = logp.MakeDebug("tcp")
@@ -78,6 +81,7 @@ func createWithResolver(
 type jobFactory struct {
 	config        config
 	tlsConfig     *tlscommon.TLSConfig
+	tlsChecker    *tlscheck.Checker
 	defaultScheme string
 	endpoints     []endpoint
 	dataCheck     dataCheck
@@ -106,6 +110,8 @@ func (jf *jobFactory) loadConfig(commonCfg *conf.C) error {
 		return err
 	}
 
+	jf.tlsChecker = tlscheck.NewChecker(jf.config.TLSChecks, jf.tlsConfig)
+
 	jf.defaultScheme = "tcp"
 	if jf.tlsConfig != nil {
 		jf.defaultScheme = "ssl"
@@ -206,7 +212,7 @@ func (jf *jobFactory) dial(event *beat.Event, dialAddr string, canonicalURL *url
 	// So, the canonical URL is fixed via a ConstAddrLayer to override the TLS layer's x509 logic so it doesn't
 	// try and directly match the IP from the prior ConstAddrLayer to the cert.
 	if canonicalURL.Scheme != "tcp" && canonicalURL.Scheme != "plain" {
-		dc.AddLayer(dialchain.TLSLayer(jf.tlsConfig, jf.config.Timeout))
+		dc.AddLayer(dialchain.TLSLayer(jf.tlsConfig, jf.config.Timeout, jf.tlsChecker))
 		dc.AddLayer(dialchain.ConstAddrLayer(canonicalURL.Host))
 	}
 
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package maintenance implements the maintenance windows of monitors. Checks
+// still run during a maintenance window, but the states and summaries they
+// produce are tagged so they can be excluded from alerting.
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule/cron"
+)
+
+// Window is a recurring period of planned downtime. Its start times are
+// defined either by a cron expression or by an RRULE.
+type Window struct {
+	Cron     string        `config:"cron"`
+	RRule    string        `config:"rrule"`
+	DTStart  string        `config:"dtstart"`
+	Duration time.Duration `config:"duration" validate:"required"`
+	Timezone string        `config:"timezone"`
+
+	cron  *cron.Schedule
+	rrule *rrule
+	loc   *time.Location
+}
+
+// Windows is the list of maintenance windows of a monitor.
+type Windows []Window
+
+// Validate parses the cron expression or RRULE of the window.
+func (w *Window) Validate() error {
+	if (w.Cron == "") == (w.RRule == "") {
+		return errors.New("a maintenance window requires exactly one of cron or rrule")
+	}
+	if w.Duration <= 0 {
+		return errors.New("the duration of a maintenance window must be positive")
+	}
+
+	w.loc = time.UTC
+	if w.Timezone != "" {
+		loc, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid maintenance window timezone '%s': %w", w.Timezone, err)
+		}
+		w.loc = loc
+	}
+
+	if w.Cron != "" {
+		if w.DTStart != "" {
+			return errors.New("dtstart can only be used with rrule maintenance windows")
+		}
+		s, err := cron.Parse(w.Cron)
+		if err != nil {
+			return fmt.Errorf("invalid maintenance window cron expression '%s': %w", w.Cron, err)
+		}
+		w.cron = s
+		return nil
+	}
+
+	if w.DTStart == "" {
+		return errors.New("rrule maintenance windows require dtstart")
+	}
+	dtstart, err := parseDTStart(w.DTStart, w.loc)
+	if err != nil {
+		return fmt.Errorf("invalid maintenance window dtstart '%s': %w", w.DTStart, err)
+	}
+	r, err := parseRRule(w.RRule, dtstart)
+	if err != nil {
+		return fmt.Errorf("invalid maintenance window rrule '%s': %w", w.RRule, err)
+	}
+	w.rrule = r
+	return nil
+}
+
+// Active returns true if t is within an occurrence of the window.
+func (w *Window) Active(t time.Time) bool {
+	t = t.In(w.loc)
+	switch {
+	case w.cron != nil:
+		// Next is strictly after the given time, so the window is active if
+		// it started in (t - duration, t].
+		start := w.cron.Next(t.Add(-w.Duration))
+		return !start.IsZero() && !start.After(t)
+	case w.rrule != nil:
+		return w.rrule.active(t, w.Duration)
+	default:
+		return false
+	}
+}
+
+// Active returns true if t is within any of the windows.
+func (ws Windows) Active(t time.Time) bool {
+	for i := range ws {
+		if ws[i].Active(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDTStart parses an RFC 3339 timestamp, or a local time in loc in
+// either the RFC 3339 or the iCalendar format.
+func parseDTStart(in string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, in); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "20060102T150405"} {
+		if t, err := time.ParseInLocation(layout, in, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("expected a time like 2006-01-02T15:04:05")
+}
@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustWindow(t *testing.T, w Window) *Window {
+	t.Helper()
+	require.NoError(t, w.Validate())
+	return &w
+}
+
+func TestWindowValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  Window
+		wantErr string
+	}{
+		{"cron", Window{Cron: "0 2 * * *", Duration: time.Hour}, ""},
+		{"rrule", Window{RRule: "FREQ=DAILY", DTStart: "2024-01-01T02:00:00", Duration: time.Hour}, ""},
+		{"neither", Window{Duration: time.Hour}, "exactly one of cron or rrule"},
+		{"both", Window{Cron: "0 2 * * *", RRule: "FREQ=DAILY", Duration: time.Hour}, "exactly one of cron or rrule"},
+		{"no duration", Window{Cron: "0 2 * * *"}, "must be positive"},
+		{"bad timezone", Window{Cron: "0 2 * * *", Duration: time.Hour, Timezone: "Nowhere/Special"}, "timezone"},
+		{"bad cron", Window{Cron: "not a cron", Duration: time.Hour}, "cron expression"},
+		{"cron with dtstart", Window{Cron: "0 2 * * *", DTStart: "2024-01-01T02:00:00", Duration: time.Hour}, "dtstart"},
+		{"rrule without dtstart", Window{RRule: "FREQ=DAILY", Duration: time.Hour}, "require dtstart"},
+		{"bad dtstart", Window{RRule: "FREQ=DAILY", DTStart: "yesterday", Duration: time.Hour}, "dtstart"},
+		{"rrule with count", Window{RRule: "FREQ=DAILY;COUNT=3", DTStart: "2024-01-01T02:00:00", Duration: time.Hour}, "unsupported rule part"},
+		{"rrule without freq", Window{RRule: "INTERVAL=2", DTStart: "2024-01-01T02:00:00", Duration: time.Hour}, "FREQ is required"},
+		{"rrule with ordinal", Window{RRule: "FREQ=MONTHLY;BYDAY=1MO", DTStart: "2024-01-01T02:00:00", Duration: time.Hour}, "BYDAY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.window.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronWindowActive(t *testing.T) {
+	w := mustWindow(t, Window{Cron: "30 2 * * *", Duration: time.Hour, Timezone: "Europe/Amsterdam"})
+	ams, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+
+	require.False(t, w.Active(time.Date(2024, 3, 5, 2, 29, 59, 0, ams)))
+	require.True(t, w.Active(time.Date(2024, 3, 5, 2, 30, 0, 0, ams)))
+	require.True(t, w.Active(time.Date(2024, 3, 5, 3, 29, 59, 0, ams)))
+	require.False(t, w.Active(time.Date(2024, 3, 5, 3, 30, 0, 0, ams)))
+	// The timezone of the checked time does not matter.
+	require.True(t, w.Active(time.Date(2024, 3, 5, 1, 45, 0, 0, time.UTC)))
+}
+
+func TestRRuleWindowActive(t *testing.T) {
+	tests := []struct {
+		name     string
+		rrule    string
+		dtstart  string
+		duration time.Duration
+		active   []time.Time
+		inactive []time.Time
+	}{
+		{
+			name:     "weekends",
+			rrule:    "FREQ=WEEKLY;BYDAY=SA,SU;BYHOUR=22;BYMINUTE=0",
+			dtstart:  "2024-01-06T00:00:00",
+			duration: 4 * time.Hour,
+			active: []time.Time{
+				time.Date(2024, 3, 9, 22, 0, 0, 0, time.UTC),  // Saturday
+				time.Date(2024, 3, 10, 1, 59, 0, 0, time.UTC), // Saturday night
+				time.Date(2024, 3, 11, 1, 59, 0, 0, time.UTC), // Sunday night
+			},
+			inactive: []time.Time{
+				time.Date(2024, 3, 9, 21, 59, 0, 0, time.UTC),
+				time.Date(2024, 3, 11, 2, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 8, 22, 30, 0, 0, time.UTC), // Friday
+				time.Date(2024, 1, 5, 23, 0, 0, 0, time.UTC),  // before dtstart
+			},
+		},
+		{
+			name:     "every other day from dtstart",
+			rrule:    "RRULE:FREQ=DAILY;INTERVAL=2",
+			dtstart:  "2024-01-01T03:15:00",
+			duration: 30 * time.Minute,
+			active: []time.Time{
+				time.Date(2024, 1, 1, 3, 15, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 3, 44, 0, 0, time.UTC),
+			},
+			inactive: []time.Time{
+				time.Date(2024, 1, 2, 3, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 3, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "monthly on the first with until",
+			rrule:    "FREQ=MONTHLY;BYMONTHDAY=1;UNTIL=20240401",
+			dtstart:  "2024-01-01T00:00:00",
+			duration: 48 * time.Hour,
+			active: []time.Time{
+				time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 2, 23, 59, 0, 0, time.UTC),
+				time.Date(2024, 4, 2, 12, 0, 0, 0, time.UTC),
+			},
+			inactive: []time.Time{
+				time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "biweekly on dtstart weekday",
+			rrule:    "FREQ=WEEKLY;INTERVAL=2",
+			dtstart:  "2024-01-03T12:00:00", // Wednesday
+			duration: time.Hour,
+			active: []time.Time{
+				time.Date(2024, 1, 17, 12, 30, 0, 0, time.UTC),
+			},
+			inactive: []time.Time{
+				time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 16, 12, 30, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := mustWindow(t, Window{RRule: tt.rrule, DTStart: tt.dtstart, Duration: tt.duration})
+			for _, at := range tt.active {
+				require.True(t, w.Active(at), "expected window to be active at %s", at)
+			}
+			for _, at := range tt.inactive {
+				require.False(t, w.Active(at), "expected window to be inactive at %s", at)
+			}
+		})
+	}
+}
+
+func TestWindowsActive(t *testing.T) {
+	var ws Windows
+	require.False(t, ws.Active(time.Now()))
+
+	ws = Windows{
+		*mustWindow(t, Window{Cron: "0 1 * * *", Duration: time.Hour}),
+		*mustWindow(t, Window{Cron: "0 3 * * *", Duration: time.Hour}),
+	}
+	require.True(t, ws.Active(time.Date(2024, 1, 1, 1, 30, 0, 0, time.UTC)))
+	require.False(t, ws.Active(time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC)))
+	require.True(t, ws.Active(time.Date(2024, 1, 1, 3, 30, 0, 0, time.UTC)))
+}
@@ -0,0 +1,240 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type frequency int
+
+const (
+	daily frequency = iota
+	weekly
+	monthly
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// rrule is the subset of RFC 5545 recurrence rules supported for maintenance
+// windows: FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY (without
+// ordinals), BYMONTHDAY, BYHOUR, BYMINUTE and UNTIL.
+type rrule struct {
+	freq       frequency
+	interval   int
+	byDay      map[time.Weekday]bool
+	byMonthDay map[int]bool
+	byHour     []int
+	byMinute   []int
+	until      time.Time
+	dtstart    time.Time
+}
+
+func parseRRule(in string, dtstart time.Time) (*rrule, error) {
+	r := &rrule{interval: 1, dtstart: dtstart}
+	hasFreq := false
+
+	in = strings.TrimPrefix(strings.TrimSpace(in), "RRULE:")
+	for _, part := range strings.Split(in, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid rule part '%s'", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			hasFreq = true
+			switch strings.ToUpper(value) {
+			case "DAILY":
+				r.freq = daily
+			case "WEEKLY":
+				r.freq = weekly
+			case "MONTHLY":
+				r.freq = monthly
+			default:
+				return nil, fmt.Errorf("unsupported FREQ '%s'", value)
+			}
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(value)
+			if err != nil || r.interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL '%s'", value)
+			}
+		case "BYDAY":
+			r.byDay = map[time.Weekday]bool{}
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := weekdays[strings.ToUpper(d)]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY value '%s'", d)
+				}
+				r.byDay[wd] = true
+			}
+		case "BYMONTHDAY":
+			days, err := parseInts(value, 1, 31)
+			if err != nil {
+				return nil, fmt.Errorf("invalid BYMONTHDAY: %w", err)
+			}
+			r.byMonthDay = map[int]bool{}
+			for _, d := range days {
+				r.byMonthDay[d] = true
+			}
+		case "BYHOUR":
+			if r.byHour, err = parseInts(value, 0, 23); err != nil {
+				return nil, fmt.Errorf("invalid BYHOUR: %w", err)
+			}
+		case "BYMINUTE":
+			if r.byMinute, err = parseInts(value, 0, 59); err != nil {
+				return nil, fmt.Errorf("invalid BYMINUTE: %w", err)
+			}
+		case "UNTIL":
+			if r.until, err = parseUntil(value, dtstart.Location()); err != nil {
+				return nil, fmt.Errorf("invalid UNTIL '%s'", value)
+			}
+		case "WKST":
+			// Accepted for compatibility, weeks always start on Monday.
+		default:
+			return nil, fmt.Errorf("unsupported rule part '%s'", key)
+		}
+	}
+
+	if !hasFreq {
+		return nil, errors.New("FREQ is required")
+	}
+	if r.byHour == nil {
+		r.byHour = []int{dtstart.Hour()}
+	}
+	if r.byMinute == nil {
+		r.byMinute = []int{dtstart.Minute()}
+	}
+	return r, nil
+}
+
+func parseInts(in string, min, max int) ([]int, error) {
+	var out []int
+	for _, s := range strings.Split(in, ",") {
+		i, err := strconv.Atoi(s)
+		if err != nil || i < min || i > max {
+			return nil, fmt.Errorf("'%s' is not between %d and %d", s, min, max)
+		}
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+func parseUntil(in string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", in); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", in, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", in, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// A date UNTIL includes the whole day.
+	return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// active returns true if t is within d of an occurrence of the rule.
+func (r *rrule) active(t time.Time, d time.Duration) bool {
+	loc := r.dtstart.Location()
+	t = t.In(loc)
+
+	// Occurrences that can still be running started at most d before t.
+	lookback := int(d/(24*time.Hour)) + 1
+	for i := 0; i <= lookback; i++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()-i, 0, 0, 0, 0, loc)
+		if !r.matchesDay(day) {
+			continue
+		}
+		for _, h := range r.byHour {
+			for _, m := range r.byMinute {
+				start := time.Date(day.Year(), day.Month(), day.Day(), h, m, r.dtstart.Second(), 0, loc)
+				if start.Before(r.dtstart) || (!r.until.IsZero() && start.After(r.until)) {
+					continue
+				}
+				if !start.After(t) && t.Before(start.Add(d)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (r *rrule) matchesDay(day time.Time) bool {
+	start := civilDate(r.dtstart)
+	current := civilDate(day)
+	if current.Before(start) {
+		return false
+	}
+
+	switch r.freq {
+	case daily:
+		days := int(current.Sub(start) / (24 * time.Hour))
+		if days%r.interval != 0 {
+			return false
+		}
+		return r.byDay == nil || r.byDay[day.Weekday()]
+	case weekly:
+		weeks := int(startOfWeek(current).Sub(startOfWeek(start)) / (7 * 24 * time.Hour))
+		if weeks%r.interval != 0 {
+			return false
+		}
+		if r.byDay == nil {
+			return day.Weekday() == r.dtstart.Weekday()
+		}
+		return r.byDay[day.Weekday()]
+	case monthly:
+		months := (current.Year()-start.Year())*12 + int(current.Month()-start.Month())
+		if months%r.interval != 0 {
+			return false
+		}
+		if r.byMonthDay == nil && r.byDay == nil {
+			return day.Day() == r.dtstart.Day()
+		}
+		return (r.byMonthDay == nil || r.byMonthDay[day.Day()]) &&
+			(r.byDay == nil || r.byDay[day.Weekday()])
+	default:
+		return false
+	}
+}
+
+// civilDate returns the calendar date of t as midnight UTC, so that
+// differences between dates are not affected by DST changes.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfWeek(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7 // Monday is the first day
+	return date.AddDate(0, 0, -offset)
+}
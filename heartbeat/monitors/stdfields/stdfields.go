@@ -22,6 +22,7 @@ import (
 	"time"
 
 	hbconfig "github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/monitors/maintenance"
 	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
 	"github.com/elastic/elastic-agent-libs/config"
 )
@@ -52,6 +53,9 @@ type StdMonitorFields struct {
 		Local  *config.C `config:"local"`
 	} `config:"source"`
 	RunFrom *hbconfig.LocationWithID `config:"run_from"`
+	// Checks still run during maintenance windows, but their states and
+	// summaries are tagged as maintenance
+	MaintenanceWindows maintenance.Windows `config:"maintenance_windows"`
 	// Set to true by monitor.go if monitor configuration is unrunnable
 	// Maybe there's a more elegant way to handle this
 	BadConfig bool
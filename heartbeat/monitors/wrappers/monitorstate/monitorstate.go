@@ -51,6 +51,7 @@ func newMonitorState(sf stdfields.StdMonitorFields, status StateStatus, ctr int,
 		StartedAt:       now,
 		DurationMs:      0,
 		Status:          status,
+		Maintenance:     sf.MaintenanceWindows.Active(now),
 		flappingEnabled: flappingEnabled,
 		ctr:             ctr + 1,
 	}
@@ -71,6 +72,8 @@ type State struct {
 	// FlapHistory retains enough info so we can resume our flap
 	// computation if loading from ES or another source
 	FlapHistory []StateStatus `json:"flap_history"`
+	// Maintenance is true if the state started during a maintenance window
+	Maintenance bool `json:"maintenance,omitempty"`
 	// Ends is a pointer to the prior state if this is the start of a new state
 	Ends            *State `json:"ends"`
 	flappingEnabled bool
@@ -138,8 +141,10 @@ func (s *State) recordCheck(sf stdfields.StdMonitorFields, newStatus StateStatus
 	} else if s.Status == newStatus || !isFinalAttempt {
 		// The state is stable, no changes needed
 		s.incrementCounters(newStatus)
-	} else if s.Checks < FlappingThreshold && s.flappingEnabled {
-		// The state changed too quickly, we're now flapping
+	} else if s.Checks < FlappingThreshold && s.flappingEnabled && !sf.MaintenanceWindows.Active(time.Now()) {
+		// The state changed too quickly, we're now flapping. Flapping is suspended
+		// during maintenance windows so that transitions start new states tagged
+		// as maintenance.
 		s.incrementCounters(newStatus)
 		s.Status = StatusFlapping
 		s.FlapHistory = append(s.FlapHistory, newStatus)
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/monitors/maintenance"
 	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
 )
 
@@ -49,6 +50,24 @@ func TestRecordingAndFlapping(t *testing.T) {
 	requireMSCounts(t, ms, 1, 0)
 }
 
+func TestMaintenanceWindows(t *testing.T) {
+	// A window starting every minute that lasts two minutes is always active
+	w := maintenance.Window{Cron: "* * * * *", Duration: 2 * time.Minute}
+	require.NoError(t, w.Validate())
+	sf := TestSf
+	sf.MaintenanceWindows = maintenance.Windows{w}
+
+	ms := newMonitorState(TestSf, StatusUp, 0, true)
+	require.False(t, ms.Maintenance)
+
+	// Flapping is suspended, so the down check starts a new maintenance state
+	ms.recordCheck(sf, StatusDown, true)
+	require.Equal(t, StatusDown, ms.Status)
+	require.True(t, ms.Maintenance)
+	requireMSCounts(t, ms, 0, 1)
+	require.False(t, ms.Ends.Maintenance)
+}
+
 func TestDuration(t *testing.T) {
 	ms := newMonitorState(TestSf, StatusUp, 0, true)
 	ms.recordCheck(TestSf, StatusUp, true)
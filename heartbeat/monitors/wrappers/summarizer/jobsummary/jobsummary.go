@@ -32,6 +32,8 @@ type JobSummary struct {
 	Down         uint16                   `json:"down"`
 	Status       monitorstate.StateStatus `json:"status"`
 	RetryGroup   string                   `json:"retry_group"`
+	// Maintenance is true if the check ran during a maintenance window
+	Maintenance bool `json:"maintenance,omitempty"`
 }
 
 func NewJobSummary(attempt uint16, maxAttempts uint16, retryGroup string) *JobSummary {
@@ -19,6 +19,7 @@ package summarizer
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -163,6 +164,7 @@ func (ssp *commonSSP) BeforeSummary(event *beat.Event) BeforeSummaryActions {
 		hasAttemptsRemaining // and we are configured to actually make multiple attempts
 	// if we aren't retrying this is the final attempt
 	ssp.js.FinalAttempt = !retry
+	ssp.js.Maintenance = ssp.sf.MaintenanceWindows.Active(time.Now())
 
 	ms := ssp.stateTracker.RecordStatus(ssp.sf, ssp.js.Status, ssp.js.FinalAttempt)
 
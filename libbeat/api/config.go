@@ -17,15 +17,30 @@
 
 package api
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 // Config is the configuration for the API endpoint.
 type Config struct {
-	Enabled            bool   `config:"enabled"`
-	Host               string `config:"host"`
-	Port               int    `config:"port"`
-	User               string `config:"named_pipe.user"`
-	SecurityDescriptor string `config:"named_pipe.security_descriptor"`
+	Enabled            bool          `config:"enabled"`
+	Host               string        `config:"host"`
+	Port               int           `config:"port"`
+	User               string        `config:"named_pipe.user"`
+	SecurityDescriptor string        `config:"named_pipe.security_descriptor"`
+	Healthz            HealthzConfig `config:"healthz"`
+}
+
+// HealthzConfig configures when the /healthz/ready endpoint reports the Beat
+// as not ready.
+type HealthzConfig struct {
+	// QueueMaxFilledPct is the fraction of the queue capacity, from 0 to 1, at
+	// which the queue check fails.
+	QueueMaxFilledPct float64 `config:"queue.max_filled_pct" validate:"min=0, max=1"`
+	// OutputStallTimeout is how long the output can have events in flight
+	// without acknowledging any before the output check fails.
+	OutputStallTimeout time.Duration `config:"output.stall_timeout" validate:"positive"`
 }
 
 // DefaultConfig is the default configuration used by the API endpoint.
@@ -33,6 +48,10 @@ var DefaultConfig = Config{
 	Enabled: false,
 	Host:    "localhost",
 	Port:    5066,
+	Healthz: HealthzConfig{
+		QueueMaxFilledPct:  0.9,
+		OutputStallTimeout: time.Minute,
+	},
 }
 
 // File mode for the socket file, owner of the process can do everything, member of the group can read.
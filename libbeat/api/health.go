@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	statusOK       = "ok"
+	statusFailing  = "failing"
+	statusReady    = "ready"
+	statusNotReady = "not_ready"
+)
+
+// healthChecker decides whether the Beat is ready to receive traffic based on
+// the output and queue metrics found in the stats registry. Progress of the
+// output is tracked between calls, so the checker keeps state and is safe for
+// concurrent use.
+type healthChecker struct {
+	config HealthzConfig
+	stats  *monitoring.Registry
+	now    func() time.Time
+
+	mu           sync.Mutex
+	lastACKed    int64
+	lastProgress time.Time
+}
+
+func newHealthChecker(config HealthzConfig, stats *monitoring.Registry) *healthChecker {
+	return &healthChecker{
+		config:       config,
+		stats:        stats,
+		now:          time.Now,
+		lastProgress: time.Now(),
+	}
+}
+
+// check evaluates all readiness checks and reports whether all of them passed
+// together with the details of each check.
+func (c *healthChecker) check() (bool, mapstr.M) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := monitoring.CollectFlatSnapshot(c.stats, monitoring.Full, false)
+	output, outputOK := c.checkOutput(snapshot)
+	queue, queueOK := c.checkQueue(snapshot)
+
+	return outputOK && queueOK, mapstr.M{
+		"output": output,
+		"queue":  queue,
+	}
+}
+
+// checkOutput fails when the output has events in flight but did not
+// acknowledge any of them for longer than the configured stall timeout, which
+// happens when the output cannot connect to its destination.
+func (c *healthChecker) checkOutput(snapshot monitoring.FlatSnapshot) (mapstr.M, bool) {
+	now := c.now()
+	acked := snapshot.Ints["libbeat.output.events.acked"]
+	active := snapshot.Ints["libbeat.output.events.active"]
+
+	if acked != c.lastACKed || active == 0 {
+		c.lastACKed = acked
+		c.lastProgress = now
+	}
+
+	stalled := now.Sub(c.lastProgress)
+	result := mapstr.M{
+		"status":       statusOK,
+		"events":       mapstr.M{"acked": acked, "active": active},
+		"write_errors": snapshot.Ints["libbeat.output.write.errors"],
+	}
+	if stalled > c.config.OutputStallTimeout {
+		result["status"] = statusFailing
+		result["reason"] = fmt.Sprintf("no events acknowledged by the output for %s", stalled.Truncate(time.Second))
+		return result, false
+	}
+	return result, true
+}
+
+// checkQueue fails when the queue is filled at or above the configured
+// fraction of its capacity.
+func (c *healthChecker) checkQueue(snapshot monitoring.FlatSnapshot) (mapstr.M, bool) {
+	filled := snapshot.Floats["libbeat.pipeline.queue.filled.pct.events"]
+
+	result := mapstr.M{
+		"status":     statusOK,
+		"filled_pct": filled,
+	}
+	if filled >= c.config.QueueMaxFilledPct {
+		result["status"] = statusFailing
+		result["reason"] = fmt.Sprintf("queue is %.0f%% full, the threshold is %.0f%%", filled*100, c.config.QueueMaxFilledPct*100)
+		return result, false
+	}
+	return result, true
+}
+
+func makeLivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		prettyPrint(w, mapstr.M{"status": statusOK}, r.URL)
+	}
+}
+
+func makeReadinessHandler(checker *healthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		ready, checks := checker.check()
+		status := statusReady
+		if !ready {
+			status = statusNotReady
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		prettyPrint(w, mapstr.M{"status": status, "checks": checks}, r.URL)
+	}
+}
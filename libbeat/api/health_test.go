@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type testStats struct {
+	reg       *monitoring.Registry
+	acked     *monitoring.Uint
+	active    *monitoring.Uint
+	queueFull *monitoring.Float
+}
+
+func newTestStats() testStats {
+	reg := monitoring.NewRegistry()
+	return testStats{
+		reg:       reg,
+		acked:     monitoring.NewUint(reg, "libbeat.output.events.acked"),
+		active:    monitoring.NewUint(reg, "libbeat.output.events.active"),
+		queueFull: monitoring.NewFloat(reg, "libbeat.pipeline.queue.filled.pct.events"),
+	}
+}
+
+func TestLivenessHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	makeLivenessHandler()(rec, httptest.NewRequest(http.MethodGet, "/healthz/live", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestReadinessHandler(t *testing.T) {
+	stats := newTestStats()
+	checker := newHealthChecker(DefaultConfig.Healthz, stats.reg)
+	start := time.Now()
+	now := start
+	checker.now = func() time.Time { return now }
+	checker.lastProgress = start
+	handler := makeReadinessHandler(checker)
+
+	ready := func(t *testing.T) (int, map[string]interface{}) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/healthz/ready", nil))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+	checkStatus := func(t *testing.T, body map[string]interface{}, check string) interface{} {
+		t.Helper()
+		return body["checks"].(map[string]interface{})[check].(map[string]interface{})["status"]
+	}
+
+	t.Run("idle output is ready", func(t *testing.T) {
+		now = start.Add(time.Hour)
+		code, body := ready(t)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", body["status"])
+	})
+
+	t.Run("output making progress is ready", func(t *testing.T) {
+		stats.active.Set(10)
+		stats.acked.Set(100)
+		now = now.Add(2 * time.Minute)
+		code, _ := ready(t)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("stalled output is not ready", func(t *testing.T) {
+		now = now.Add(30 * time.Second)
+		code, _ := ready(t)
+		assert.Equal(t, http.StatusOK, code)
+
+		now = now.Add(time.Minute)
+		code, body := ready(t)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "not_ready", body["status"])
+		assert.Equal(t, "failing", checkStatus(t, body, "output"))
+		assert.Equal(t, "ok", checkStatus(t, body, "queue"))
+	})
+
+	t.Run("output recovers once events are acked", func(t *testing.T) {
+		stats.acked.Set(110)
+		code, _ := ready(t)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("full queue is not ready", func(t *testing.T) {
+		stats.queueFull.Set(0.95)
+		code, body := ready(t)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "ok", checkStatus(t, body, "output"))
+		assert.Equal(t, "failing", checkStatus(t, body, "queue"))
+
+		stats.queueFull.Set(0.5)
+		code, _ = ready(t)
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
@@ -44,6 +44,8 @@ func NewWithDefaultRoutes(log *logp.Logger, config *config.C, ns lookupFunc) (*S
 		api.AttachHandler("/state", makeAPIHandler(ns("state"))),
 		api.AttachHandler("/stats", makeAPIHandler(ns("stats"))),
 		api.AttachHandler("/dataset", makeAPIHandler(ns("dataset"))),
+		api.AttachHandler("/healthz/live", makeLivenessHandler()),
+		api.AttachHandler("/healthz/ready", makeReadinessHandler(newHealthChecker(api.config.Healthz, ns("stats").GetRegistry()))),
 	)
 	if err != nil {
 		return nil, err
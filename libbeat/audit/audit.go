@@ -0,0 +1,391 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package audit records an audit trail of the configuration changes applied
+// by the beat: reloads of configuration files, runners started and stopped
+// by autodiscover, and changes received from central management. The events
+// are written to a dedicated local file and can be published to the
+// pipeline as well.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/file"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Sources of configuration changes.
+const (
+	SourceConfigFile   = "config_file"
+	SourceAutodiscover = "autodiscover"
+	SourceManagement   = "central_management"
+)
+
+// Actions recorded in the audit trail.
+const (
+	ActionRunnersReload = "runners-reload"
+	ActionFilesChanged  = "config-files-changed"
+	ActionDiscovered    = "autodiscover-start"
+	ActionUndiscovered  = "autodiscover-stop"
+	ActionOutputReload  = "output-reload"
+	ActionOutputStop    = "output-stop"
+)
+
+// Event is a configuration change.
+type Event struct {
+	// Action is what happened, one of the Action constants.
+	Action string
+	// Source is where the change came from, one of the Source constants.
+	Source string
+	// Component is the part of the beat applying the change, like the
+	// name of a runner list or an autodiscover provider.
+	Component string
+
+	// Started and Stopped are the runners started and stopped by the
+	// change, Updated the runners whose configuration was updated in place
+	// and Unchanged is the number of runners that kept running.
+	Started   []Runner
+	Stopped   []Runner
+	Updated   []Runner
+	Unchanged int
+
+	// Files are the configuration files that changed.
+	Files []string
+
+	// Details holds additional information about the change.
+	Details mapstr.M
+
+	// Err is the error applying the change, if any.
+	Err error
+}
+
+// Runner identifies the configuration of a runner without its settings,
+// which can hold secrets.
+type Runner struct {
+	Hash   uint64
+	Type   string
+	ID     string
+	UnitID string
+}
+
+// NewRunner returns the identity of the runner configuration cfg with the
+// given hash.
+func NewRunner(hash uint64, cfg *conf.C) Runner {
+	r := Runner{Hash: hash}
+	if cfg == nil {
+		return r
+	}
+	for _, key := range []string{"type", "module"} {
+		if v, err := cfg.String(key, -1); err == nil {
+			r.Type = v
+			break
+		}
+	}
+	if v, err := cfg.String("id", -1); err == nil {
+		r.ID = v
+	}
+	return r
+}
+
+func (r Runner) fields() mapstr.M {
+	m := mapstr.M{"hash": strconv.FormatUint(r.Hash, 16)}
+	if r.Type != "" {
+		m["type"] = r.Type
+	}
+	if r.ID != "" {
+		m["id"] = r.ID
+	}
+	if r.UnitID != "" {
+		m["unit_id"] = r.UnitID
+	}
+	return m
+}
+
+// Summary returns a one line description of the change.
+func (e Event) Summary() string {
+	var parts []string
+	if len(e.Files) != 0 {
+		parts = append(parts, fmt.Sprintf("%d files changed", len(e.Files)))
+	}
+	if e.hasRunners() {
+		parts = append(parts, fmt.Sprintf("%d started, %d stopped, %d updated, %d unchanged",
+			len(e.Started), len(e.Stopped), len(e.Updated), e.Unchanged))
+	}
+	summary := e.Action
+	if e.Component != "" {
+		summary += " by " + e.Component
+	}
+	if len(parts) != 0 {
+		summary += ": " + strings.Join(parts, ", ")
+	}
+	if e.Err != nil {
+		summary += " (failed)"
+	}
+	return summary
+}
+
+func (e Event) hasRunners() bool {
+	return len(e.Started) != 0 || len(e.Stopped) != 0 || len(e.Updated) != 0 || e.Unchanged != 0
+}
+
+// Recorder writes audit events to the audit file and publishes them.
+type Recorder struct {
+	config Config
+	user   string
+	log    *logp.Logger
+
+	mu   sync.Mutex
+	file *file.Rotator
+
+	// events buffers the events to publish until Start connects to the
+	// pipeline, it is nil when publishing is disabled.
+	events chan beat.Event
+	client beat.Client
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+var (
+	defaultMu       sync.RWMutex
+	defaultRecorder *Recorder
+)
+
+// Configure creates the recorder of the beat named beatName from the audit
+// settings and installs it as the recorder used by Record. The audit file is
+// written to logsPath unless the settings give a path. It returns nil when
+// the audit trail is disabled.
+func Configure(beatName string, cfg *conf.C, logsPath string) (*Recorder, error) {
+	config := DefaultConfig(beatName)
+	if cfg != nil {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, fmt.Errorf("invalid audit settings: %w", err)
+		}
+	}
+	if !config.Enabled {
+		setDefault(nil)
+		return nil, nil
+	}
+	if config.File.Path == "" {
+		config.File.Path = logsPath
+	}
+
+	r := &Recorder{
+		config: config,
+		user:   currentUser(),
+		log:    logp.NewLogger("audit"),
+	}
+	if config.File.Enabled {
+		rotator, err := file.NewFileRotator(
+			filepath.Join(config.File.Path, config.File.Name+".ndjson"),
+			file.MaxSizeBytes(config.File.RotateEveryBytes),
+			file.MaxBackups(config.File.KeepFiles),
+			file.Permissions(os.FileMode(config.File.Permissions)),
+			file.Interval(config.File.Interval),
+			file.RotateOnStartup(config.File.RotateOnStartup),
+			file.WithLogger(logp.NewLogger("rotator").With(logp.Namespace("rotator"))),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the audit file: %w", err)
+		}
+		r.file = rotator
+	}
+	if config.Publish {
+		r.events = make(chan beat.Event, config.QueueSize)
+	}
+
+	setDefault(r)
+	return r, nil
+}
+
+func setDefault(r *Recorder) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultRecorder = r
+}
+
+// Record records e with the recorder installed by Configure. It does
+// nothing when the audit trail is disabled.
+func Record(e Event) {
+	defaultMu.RLock()
+	r := defaultRecorder
+	defaultMu.RUnlock()
+	if r != nil {
+		r.Record(e)
+	}
+}
+
+// Record writes e to the audit file and queues it for publishing.
+func (r *Recorder) Record(e Event) {
+	event := r.event(time.Now(), e)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		if err := r.write(event); err != nil {
+			r.log.Errorw("Failed to write audit event.", "error", err)
+		}
+	}
+	if r.events != nil {
+		select {
+		case r.events <- event:
+		default:
+			r.log.Warnw("Audit event queue is full, event not published.", "event.action", e.Action)
+		}
+	}
+}
+
+// write must be called with r.mu held.
+func (r *Recorder) write(event beat.Event) error {
+	fields := event.Fields.Clone()
+	fields["@timestamp"] = event.Timestamp.UTC().Format(time.RFC3339Nano)
+	line, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
+
+// Start connects to the pipeline and publishes the buffered audit events
+// and the following ones until Close is called. It does nothing when
+// publishing is disabled.
+func (r *Recorder) Start(pipeline beat.PipelineConnector) error {
+	if r.events == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != nil {
+		return errors.New("audit events are already published")
+	}
+
+	client, err := pipeline.ConnectWith(beat.ClientConfig{PublishMode: beat.DropIfFull})
+	if err != nil {
+		return fmt.Errorf("failed to connect to the pipeline: %w", err)
+	}
+	r.client = client
+	r.done = make(chan struct{})
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			select {
+			case <-r.done:
+				return
+			case event := <-r.events:
+				client.Publish(event)
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops publishing and closes the audit file.
+func (r *Recorder) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != nil {
+		close(r.done)
+		r.wg.Wait()
+		_ = r.client.Close()
+		r.client = nil
+	}
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+}
+
+// event converts an audit event to a beat event.
+func (r *Recorder) event(ts time.Time, e Event) beat.Event {
+	outcome := "success"
+	if e.Err != nil {
+		outcome = "failure"
+	}
+	audit := mapstr.M{
+		"source": e.Source,
+	}
+	if e.Component != "" {
+		audit["component"] = e.Component
+	}
+	if e.hasRunners() {
+		audit["runners"] = mapstr.M{
+			"started":   runnerFields(e.Started),
+			"stopped":   runnerFields(e.Stopped),
+			"updated":   runnerFields(e.Updated),
+			"unchanged": e.Unchanged,
+		}
+	}
+	if len(e.Files) != 0 {
+		audit["files"] = e.Files
+	}
+	if len(e.Details) != 0 {
+		audit["details"] = e.Details
+	}
+
+	fields := mapstr.M{
+		"message": e.Summary(),
+		"event": mapstr.M{
+			"kind":     "event",
+			"category": []string{"configuration"},
+			"type":     []string{"change"},
+			"action":   e.Action,
+			"outcome":  outcome,
+			"dataset":  r.config.Dataset,
+		},
+		"audit": audit,
+	}
+	if r.user != "" {
+		fields["user"] = mapstr.M{"name": r.user}
+	}
+	if e.Err != nil {
+		fields["error"] = mapstr.M{"message": e.Err.Error()}
+	}
+	return beat.Event{Timestamp: ts, Fields: fields}
+}
+
+func runnerFields(runners []Runner) []mapstr.M {
+	out := make([]mapstr.M, 0, len(runners))
+	for _, r := range runners {
+		out = append(out, r.fields())
+	}
+	return out
+}
+
+// currentUser returns the name of the user running the beat, which applies
+// the configuration changes.
+func currentUser() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	return u.Username
+}
@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestRecorder(t *testing.T, settings map[string]interface{}) (*Recorder, string) {
+	t.Helper()
+	dir := t.TempDir()
+	r, err := Configure("testbeat", conf.MustNewConfigFrom(settings), dir)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	t.Cleanup(func() {
+		r.Close()
+		setDefault(nil)
+	})
+	return r, filepath.Join(dir, "testbeat-audit.ndjson")
+}
+
+func readAuditFile(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var events []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestConfigureDisabled(t *testing.T) {
+	r, err := Configure("testbeat", conf.NewConfig(), t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, r)
+
+	// Recording without a recorder does nothing.
+	Record(Event{Action: ActionRunnersReload})
+}
+
+func TestRecordWritesFile(t *testing.T) {
+	_, path := newTestRecorder(t, map[string]interface{}{"enabled": true})
+
+	Record(Event{
+		Action:    ActionRunnersReload,
+		Source:    SourceConfigFile,
+		Component: "reload",
+		Started: []Runner{
+			NewRunner(0xabc, conf.MustNewConfigFrom(map[string]interface{}{
+				"type":     "filestream",
+				"id":       "my-logs",
+				"password": "secret",
+			})),
+		},
+		Stopped:   []Runner{{Hash: 0x123, Type: "system", UnitID: "unit-1"}},
+		Unchanged: 2,
+	})
+	Record(Event{
+		Action: ActionOutputReload,
+		Source: SourceManagement,
+		Err:    errors.New("invalid output"),
+	})
+
+	events := readAuditFile(t, path)
+	require.Len(t, events, 2)
+
+	event := events[0]
+	assert.NotEmpty(t, event["@timestamp"])
+	assert.Equal(t, "runners-reload by reload: 1 started, 1 stopped, 0 updated, 2 unchanged", event["message"])
+	assert.Equal(t, map[string]interface{}{
+		"kind":     "event",
+		"category": []interface{}{"configuration"},
+		"type":     []interface{}{"change"},
+		"action":   "runners-reload",
+		"outcome":  "success",
+		"dataset":  "testbeat.audit",
+	}, event["event"])
+	assert.Equal(t, map[string]interface{}{
+		"source":    "config_file",
+		"component": "reload",
+		"runners": map[string]interface{}{
+			"started": []interface{}{
+				map[string]interface{}{"hash": "abc", "type": "filestream", "id": "my-logs"},
+			},
+			"stopped": []interface{}{
+				map[string]interface{}{"hash": "123", "type": "system", "unit_id": "unit-1"},
+			},
+			"updated":   []interface{}{},
+			"unchanged": float64(2),
+		},
+	}, event["audit"])
+	assert.NotContains(t, events[0], "error")
+
+	event = events[1]
+	assert.Equal(t, "output-reload (failed)", event["message"])
+	assert.Equal(t, "failure", event["event"].(map[string]interface{})["outcome"])
+	assert.Equal(t, map[string]interface{}{"message": "invalid output"}, event["error"])
+	assert.NotContains(t, event["audit"], "runners")
+}
+
+func TestRecordPublishes(t *testing.T) {
+	r, path := newTestRecorder(t, map[string]interface{}{
+		"enabled":      true,
+		"publish":      true,
+		"file.enabled": false,
+	})
+
+	// Events recorded before Start are buffered.
+	Record(Event{
+		Action:  ActionDiscovered,
+		Source:  SourceAutodiscover,
+		Details: mapstr.M{"event_id": "docker:abc"},
+	})
+
+	client := pubtest.NewChanClient(10)
+	require.NoError(t, r.Start(pubtest.PublisherWithClient(client)))
+
+	event := client.ReceiveEvent()
+	assert.Equal(t, "autodiscover-start", event.Fields["event"].(mapstr.M)["action"])
+	assert.Equal(t, mapstr.M{
+		"source":  "autodiscover",
+		"details": mapstr.M{"event_id": "docker:abc"},
+	}, event.Fields["audit"])
+
+	Record(Event{Action: ActionUndiscovered, Source: SourceAutodiscover})
+	event = client.ReceiveEvent()
+	assert.Equal(t, "autodiscover-stop", event.Fields["event"].(mapstr.M)["action"])
+
+	assert.NoFileExists(t, path)
+}
+
+func TestNewRunner(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"module":     "nginx",
+		"metricsets": []string{"stubstatus"},
+	})
+	assert.Equal(t, Runner{Hash: 1, Type: "nginx"}, NewRunner(1, cfg))
+	assert.Equal(t, Runner{Hash: 2}, NewRunner(2, nil))
+}
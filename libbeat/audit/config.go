@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package audit
+
+import (
+	"time"
+)
+
+// Config configures the audit trail of configuration changes.
+type Config struct {
+	// Enabled enables the audit trail.
+	Enabled bool `config:"enabled"`
+
+	// File configures the local file the audit events are written to.
+	File FileConfig `config:"file"`
+
+	// Publish enables publishing the audit events to the pipeline as well.
+	Publish bool `config:"publish"`
+
+	// Dataset is the event.dataset of the audit events.
+	Dataset string `config:"dataset"`
+
+	// QueueSize is the number of audit events waiting to be published. Events
+	// are dropped from the pipeline, never from the file, when it is full.
+	QueueSize int `config:"queue_size" validate:"min=1"`
+}
+
+// FileConfig configures the audit file, it is rotated like the log files.
+type FileConfig struct {
+	Enabled          bool          `config:"enabled"`
+	Path             string        `config:"path"`
+	Name             string        `config:"name"`
+	RotateEveryBytes uint          `config:"rotateeverybytes" validate:"min=1"`
+	KeepFiles        uint          `config:"keepfiles" validate:"max=1024"`
+	Permissions      uint32        `config:"permissions"`
+	Interval         time.Duration `config:"interval"`
+	RotateOnStartup  bool          `config:"rotateonstartup"`
+}
+
+// DefaultConfig returns the default configuration of the beat named
+// beatName.
+func DefaultConfig(beatName string) Config {
+	return Config{
+		File: FileConfig{
+			Enabled:          true,
+			Name:             beatName + "-audit",
+			RotateEveryBytes: 10 * 1024 * 1024,
+			KeepFiles:        7,
+			Permissions:      0600,
+		},
+		Dataset:   beatName + ".audit",
+		QueueSize: 128,
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/elastic/beats/v7/libbeat/audit"
 	"github.com/elastic/beats/v7/libbeat/autodiscover/meta"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
@@ -53,6 +54,7 @@ type EventConfigurer interface {
 // Autodiscover process, it takes a beat adapter and user config and runs autodiscover process, spawning
 // new modules when any configured providers does a match
 type Autodiscover struct {
+	name            string
 	bus             bus.Bus
 	defaultPipeline beat.PipelineConnector
 	factory         cfgfile.RunnerFactory
@@ -64,6 +66,7 @@ type Autodiscover struct {
 	listener        bus.Listener
 	logger          *logp.Logger
 	debouncePeriod  time.Duration
+	diagnostics     *diagnostics
 }
 
 // NewAutodiscover instantiates and returns a new Autodiscover manager
@@ -92,6 +95,7 @@ func NewAutodiscover(
 	}
 
 	return &Autodiscover{
+		name:            name,
 		bus:             bus,
 		defaultPipeline: pipeline,
 		factory:         factory,
@@ -102,6 +106,7 @@ func NewAutodiscover(
 		meta:            meta.NewMap(),
 		logger:          logger,
 		debouncePeriod:  defaultDebouncePeriod,
+		diagnostics:     &diagnostics{},
 	}, nil
 }
 
@@ -113,6 +118,7 @@ func (a *Autodiscover) Start() {
 
 	a.logger.Info("Starting autodiscover manager")
 	a.listener = a.bus.Subscribe(a.configurer.EventFilter()...)
+	registerManager(a)
 
 	// It is important to start the worker first before starting the producer.
 	// In hosts that have large number of workloads, it is easy to have an initial
@@ -164,6 +170,7 @@ func (a *Autodiscover) worker() {
 
 				a.logger.Debugf("calling reload with %d config(s)", len(configs))
 				err := a.runners.Reload(configs)
+				a.diagnostics.setReload(len(configs), err)
 
 				// reset updated status
 				updated = false
@@ -189,8 +196,11 @@ func (a *Autodiscover) handleStart(event bus.Event) bool {
 	a.logger.Debugw("Got a start event.", "autodiscover.event", event)
 
 	eventID := getID(event)
+	diag := newEventDiagnostics("start", eventID, event)
+	defer a.diagnostics.addEvent(diag)
 	if eventID == "" {
 		a.logger.Errorf("Event didn't provide instance id: %+v, ignoring it", event)
+		diag.Reason = "event didn't provide instance id"
 		return false
 	}
 
@@ -202,8 +212,12 @@ func (a *Autodiscover) handleStart(event bus.Event) bool {
 	configs, err := a.configurer.CreateConfig(event)
 	if err != nil {
 		a.logger.Debugf("Could not generate config from event %v: %v", event, err)
+		diag.Reason = "could not generate config: " + err.Error()
 		return false
 	}
+	if len(configs) == 0 {
+		diag.Reason = "no config was generated by templates or builders"
+	}
 
 	if a.logger.IsDebug() {
 		for _, c := range configs {
@@ -221,6 +235,7 @@ func (a *Autodiscover) handleStart(event bus.Event) bool {
 		hash, err := cfgfile.HashConfig(config)
 		if err != nil {
 			a.logger.Debugf("Could not hash config %v: %v", conf.DebugString(config, true), err)
+			diag.addConfig(config, configInvalid, "could not hash config: "+err.Error())
 			continue
 		}
 
@@ -229,11 +244,13 @@ func (a *Autodiscover) handleStart(event bus.Event) bool {
 
 		if _, ok := newCfg[hash]; ok {
 			a.logger.Debugf("Config %v duplicated in start event", conf.DebugString(config, true))
+			diag.addConfig(config, configDuplicated, "config is duplicated in start event")
 			continue
 		}
 
 		if cfg, ok := a.configs[eventID][hash]; ok {
 			a.logger.Debugf("Config %v is already running", conf.DebugString(config, true))
+			diag.addConfig(config, configRunning, "")
 			newCfg[hash] = cfg
 			continue
 		}
@@ -243,8 +260,10 @@ func (a *Autodiscover) handleStart(event bus.Event) bool {
 			a.logger.Errorf(
 				"Auto discover config check failed for config '%s', won't start runner, err: %s",
 				conf.DebugString(config, true), err)
+			diag.addConfig(config, configCheckFailed, err.Error())
 			continue
 		}
+		diag.addConfig(config, configScheduled, "")
 		newCfg[hash] = &reload.ConfigWithMeta{
 			Config: config,
 			Meta:   &dynFields,
@@ -262,7 +281,29 @@ func (a *Autodiscover) handleStart(event bus.Event) bool {
 	// By replacing the config's for eventID we make sure that all old configs that are no longer in use
 	// are stopped correctly. This will ensure that a resync event is handled correctly.
 	if updated {
+		var started, stopped []audit.Runner
+		for hash, cfg := range a.configs[eventID] {
+			if _, ok := newCfg[hash]; !ok {
+				diag.addConfig(cfg.Config, configStopping, "config is no longer generated for the instance")
+				stopped = append(stopped, audit.NewRunner(hash, cfg.Config))
+			}
+		}
+		for hash, cfg := range newCfg {
+			if _, ok := a.configs[eventID][hash]; !ok {
+				started = append(started, audit.NewRunner(hash, cfg.Config))
+			}
+		}
 		a.configs[eventID] = newCfg
+
+		audit.Record(audit.Event{
+			Action:    audit.ActionDiscovered,
+			Source:    audit.SourceAutodiscover,
+			Component: "autodiscover",
+			Started:   started,
+			Stopped:   stopped,
+			Unchanged: len(newCfg) - len(started),
+			Details:   auditDetails(eventID, event),
+		})
 	}
 
 	return updated
@@ -273,8 +314,11 @@ func (a *Autodiscover) handleStop(event bus.Event) bool {
 
 	a.logger.Debugf("Got a stop event: %v", event)
 	eventID := getID(event)
+	diag := newEventDiagnostics("stop", eventID, event)
+	defer a.diagnostics.addEvent(diag)
 	if eventID == "" {
 		a.logger.Errorf("Event didn't provide instance id: %+v, ignoring it", event)
+		diag.Reason = "event didn't provide instance id"
 		return false
 	}
 
@@ -282,12 +326,37 @@ func (a *Autodiscover) handleStop(event bus.Event) bool {
 		a.logger.Debugf("Stopping %d configs", len(a.configs[eventID]))
 		updated = true
 	}
+	var stopped []audit.Runner
+	for hash, cfg := range a.configs[eventID] {
+		diag.addConfig(cfg.Config, configStopping, "")
+		stopped = append(stopped, audit.NewRunner(hash, cfg.Config))
+	}
 
 	delete(a.configs, eventID)
 
+	if updated {
+		audit.Record(audit.Event{
+			Action:    audit.ActionUndiscovered,
+			Source:    audit.SourceAutodiscover,
+			Component: "autodiscover",
+			Stopped:   stopped,
+			Details:   auditDetails(eventID, event),
+		})
+	}
+
 	return updated
 }
 
+// auditDetails returns the details of an autodiscover event recorded in the
+// audit trail.
+func auditDetails(eventID string, event bus.Event) mapstr.M {
+	details := mapstr.M{"event_id": eventID}
+	if provider, ok := event["provider"].(string); ok {
+		details["provider"] = provider
+	}
+	return details
+}
+
 func (a *Autodiscover) getMeta(event bus.Event) mapstr.M {
 	m := event["meta"]
 	if m == nil {
@@ -326,6 +395,7 @@ func (a *Autodiscover) Stop() {
 
 	// Stop listening for events
 	a.listener.Stop()
+	unregisterManager(a)
 
 	// Stop providers
 	for _, provider := range a.providers {
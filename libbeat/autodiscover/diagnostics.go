@@ -0,0 +1,190 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/elastic-agent-autodiscover/bus"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// maxDiagnosticEvents is the number of bus events each manager keeps
+// diagnostics for.
+const maxDiagnosticEvents = 100
+
+// Statuses of the configs generated from a bus event.
+const (
+	configScheduled   = "scheduled"
+	configRunning     = "running"
+	configDuplicated  = "duplicated"
+	configInvalid     = "invalid"
+	configCheckFailed = "check_failed"
+	configStopping    = "stopping"
+)
+
+// eventDiagnostics explains how a bus event was handled: the templates that
+// matched it, the configs rendered from it, and what was done with each one.
+type eventDiagnostics struct {
+	Time      time.Time           `json:"time"`
+	Type      string              `json:"type"`
+	ID        string              `json:"id,omitempty"`
+	Templates []template.Match    `json:"templates,omitempty"`
+	Configs   []configDiagnostics `json:"configs"`
+	Reason    string              `json:"reason,omitempty"`
+}
+
+// configDiagnostics holds a config rendered from a bus event, with secrets
+// masked, and the reason it was or wasn't scheduled to start.
+type configDiagnostics struct {
+	Config interface{} `json:"config"`
+	Status string      `json:"status"`
+	Reason string      `json:"reason,omitempty"`
+}
+
+// reloadDiagnostics holds the result of the last reload of the runners.
+type reloadDiagnostics struct {
+	Time    time.Time `json:"time"`
+	Configs int       `json:"configs"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// diagnostics keeps the diagnostics of the last bus events handled by an
+// autodiscover manager.
+type diagnostics struct {
+	mu     sync.Mutex
+	events []eventDiagnostics
+	next   int
+	reload *reloadDiagnostics
+}
+
+func newEventDiagnostics(typ, id string, event bus.Event) *eventDiagnostics {
+	d := &eventDiagnostics{
+		Time:    time.Now(),
+		Type:    typ,
+		ID:      id,
+		Configs: []configDiagnostics{},
+	}
+	if matches, ok := event["templates"].([]template.Match); ok {
+		d.Templates = matches
+	}
+	return d
+}
+
+func (d *eventDiagnostics) addConfig(c *conf.C, status, reason string) {
+	d.Configs = append(d.Configs, configDiagnostics{
+		Config: renderConfig(c),
+		Status: status,
+		Reason: reason,
+	})
+}
+
+// renderConfig returns the content of c with sensitive values masked.
+func renderConfig(c *conf.C) interface{} {
+	var content map[string]interface{}
+	if err := c.Unpack(&content); err != nil {
+		return "<config error> " + err.Error()
+	}
+	conf.ApplyLoggingMask(content)
+	return content
+}
+
+func (d *diagnostics) addEvent(e *eventDiagnostics) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.events) < maxDiagnosticEvents {
+		d.events = append(d.events, *e)
+		return
+	}
+	d.events[d.next] = *e
+	d.next = (d.next + 1) % maxDiagnosticEvents
+}
+
+func (d *diagnostics) setReload(configs int, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reload = &reloadDiagnostics{Time: time.Now(), Configs: configs}
+	if err != nil {
+		d.reload.Error = err.Error()
+	}
+}
+
+// managerDiagnostics is the diagnostics report of an autodiscover manager.
+type managerDiagnostics struct {
+	Name       string             `json:"name"`
+	LastReload *reloadDiagnostics `json:"last_reload,omitempty"`
+	Events     []eventDiagnostics `json:"events"`
+}
+
+func (d *diagnostics) report(name string) managerDiagnostics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	events := make([]eventDiagnostics, 0, len(d.events))
+	events = append(events, d.events[d.next:]...)
+	events = append(events, d.events[:d.next]...)
+	return managerDiagnostics{
+		Name:       name,
+		LastReload: d.reload,
+		Events:     events,
+	}
+}
+
+// managers are the running autodiscover managers, reported by DiagnosticsHandler.
+var managers = struct {
+	sync.Mutex
+	running map[*Autodiscover]struct{}
+}{running: map[*Autodiscover]struct{}{}}
+
+func registerManager(a *Autodiscover) {
+	managers.Lock()
+	defer managers.Unlock()
+	managers.running[a] = struct{}{}
+}
+
+func unregisterManager(a *Autodiscover) {
+	managers.Lock()
+	defer managers.Unlock()
+	delete(managers.running, a)
+}
+
+// DiagnosticsHandler returns an HTTP handler that reports, for the last bus
+// events of each running autodiscover manager, which config templates matched,
+// the rendered configs, and whether their runners were scheduled to start.
+func DiagnosticsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		managers.Lock()
+		reports := make([]managerDiagnostics, 0, len(managers.running))
+		for a := range managers.running {
+			reports = append(reports, a.diagnostics.report(a.name))
+		}
+		managers.Unlock()
+		sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		if _, ok := r.URL.Query()["pretty"]; ok {
+			enc.SetIndent("", "  ")
+		}
+		_ = enc.Encode(reports)
+	})
+}
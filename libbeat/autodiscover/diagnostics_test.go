@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/autodiscover/meta"
+	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/beats/v7/libbeat/common/reload"
+	"github.com/elastic/elastic-agent-autodiscover/bus"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func newDiagnosticsTestAutodiscover(adapter *mockAdapter) *Autodiscover {
+	return &Autodiscover{
+		name:        "test",
+		factory:     adapter,
+		configurer:  adapter,
+		configs:     map[string]map[uint64]*reload.ConfigWithMeta{},
+		meta:        meta.NewMap(),
+		logger:      logp.NewLogger("autodiscover"),
+		diagnostics: &diagnostics{},
+	}
+}
+
+func TestDiagnosticsStartStop(t *testing.T) {
+	broken := conf.MustNewConfigFrom(map[string]interface{}{"broken": true})
+	valid := conf.MustNewConfigFrom(map[string]interface{}{"runner": "1", "password": "secret"})
+	a := newDiagnosticsTestAutodiscover(&mockAdapter{})
+
+	matches := []template.Match{{Template: 0, Condition: "has_fields: [foo]", Matched: true}}
+	event := bus.Event{
+		"id":        "foo",
+		"provider":  "mock",
+		"start":     true,
+		"config":    []*conf.C{broken, valid, valid},
+		"templates": matches,
+	}
+	assert.True(t, a.handleStart(event))
+	assert.False(t, a.handleStart(bus.Event{"provider": "mock", "start": true}))
+	assert.True(t, a.handleStop(bus.Event{"id": "foo", "provider": "mock", "stop": true}))
+
+	report := a.diagnostics.report(a.name)
+	assert.Equal(t, "test", report.Name)
+	require.Len(t, report.Events, 3)
+
+	start := report.Events[0]
+	assert.Equal(t, "start", start.Type)
+	assert.Equal(t, "mock:foo", start.ID)
+	assert.Equal(t, matches, start.Templates)
+	require.Len(t, start.Configs, 3)
+	assert.Equal(t, configCheckFailed, start.Configs[0].Status)
+	assert.Equal(t, "Broken config", start.Configs[0].Reason)
+	assert.Equal(t, configScheduled, start.Configs[1].Status)
+	assert.Equal(t, map[string]interface{}{"runner": "1", "password": "xxxxx"}, start.Configs[1].Config)
+	assert.Equal(t, configDuplicated, start.Configs[2].Status)
+
+	assert.Equal(t, "event didn't provide instance id", report.Events[1].Reason)
+
+	stop := report.Events[2]
+	assert.Equal(t, "stop", stop.Type)
+	require.Len(t, stop.Configs, 1)
+	assert.Equal(t, configStopping, stop.Configs[0].Status)
+}
+
+func TestDiagnosticsNoConfig(t *testing.T) {
+	a := newDiagnosticsTestAutodiscover(&mockAdapter{})
+
+	assert.False(t, a.handleStart(bus.Event{"id": "foo", "provider": "mock", "start": true}))
+
+	report := a.diagnostics.report(a.name)
+	require.Len(t, report.Events, 1)
+	assert.Equal(t, "no config was generated by templates or builders", report.Events[0].Reason)
+	assert.Empty(t, report.Events[0].Configs)
+}
+
+func TestDiagnosticsMaxEvents(t *testing.T) {
+	d := &diagnostics{}
+	for i := 0; i < maxDiagnosticEvents+10; i++ {
+		d.addEvent(&eventDiagnostics{ID: string(rune('a' + i%26))})
+	}
+
+	report := d.report("test")
+	require.Len(t, report.Events, maxDiagnosticEvents)
+	// The oldest events were dropped, and the rest are kept in order.
+	assert.Equal(t, string(rune('a'+10%26)), report.Events[0].ID)
+	assert.Equal(t, string(rune('a'+(maxDiagnosticEvents+9)%26)), report.Events[maxDiagnosticEvents-1].ID)
+}
+
+func TestDiagnosticsHandler(t *testing.T) {
+	a := newDiagnosticsTestAutodiscover(&mockAdapter{})
+	registerManager(a)
+	defer unregisterManager(a)
+
+	a.handleStart(bus.Event{
+		"id":       "foo",
+		"provider": "mock",
+		"start":    true,
+		"config":   []*conf.C{conf.MustNewConfigFrom(map[string]interface{}{"runner": "1"})},
+	})
+
+	w := httptest.NewRecorder()
+	DiagnosticsHandler().ServeHTTP(w, httptest.NewRequest("GET", "/autodiscover", nil))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	var reports []managerDiagnostics
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reports))
+	require.Len(t, reports, 1)
+	require.Len(t, reports[0].Events, 1)
+	require.Len(t, reports[0].Events[0].Configs, 1)
+	assert.Equal(t, configScheduled, reports[0].Events[0].Configs[0].Status)
+}
@@ -353,6 +353,9 @@ func (d *Provider) publish(events []bus.Event) {
 	delete(event, "port")
 	delete(event, "ports")
 	event["config"] = configs
+	if matches := d.templates.Matches(events...); len(matches) > 0 {
+		event["templates"] = matches
+	}
 
 	// Call all appenders to append any extra configuration
 	d.appenders.Append(event)
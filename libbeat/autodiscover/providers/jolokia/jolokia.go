@@ -117,6 +117,9 @@ func (p *Provider) publish(event bus.Event) {
 	} else if config := p.builders.GetConfig(event); config != nil {
 		event["config"] = config
 	}
+	if matches := p.templates.Matches(event); len(matches) > 0 {
+		event["templates"] = matches
+	}
 
 	p.appenders.Append(event)
 	p.bus.Publish(event)
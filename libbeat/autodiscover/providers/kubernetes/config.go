@@ -55,6 +55,9 @@ type Config struct {
 	LeaseDuration time.Duration `config:"leader_leaseduration"`
 	RenewDeadline time.Duration `config:"leader_renewdeadline"`
 	RetryPeriod   time.Duration `config:"leader_retryperiod"`
+	// LeaderGroups are the templates enabled by their own leader lease, so
+	// that they can be spread across the Beats sharing the provider config.
+	LeaderGroups []LeaderGroup `config:"leader_groups"`
 
 	Prefix    string                  `config:"prefix"`
 	Hints     *config.C               `config:"hints"`
@@ -65,6 +68,16 @@ type Config struct {
 	AddResourceMetadata *metadata.AddResourceMetadataConfig `config:"add_resource_metadata"`
 }
 
+// LeaderGroup is a group of templates enabled only by the holder of the
+// lease of the group.
+type LeaderGroup struct {
+	Name string `config:"name" validate:"required"`
+	// Lease is the name of the lease of the group, it defaults to the
+	// leader_lease of the provider suffixed with the name of the group.
+	Lease     string                  `config:"lease"`
+	Templates template.MapperSettings `config:"templates"`
+}
+
 // DefaultCleanupTimeout Public variable, so specific beats (as Filebeat) can set a different cleanup timeout if they need it.
 var DefaultCleanupTimeout time.Duration = 0
 
@@ -89,7 +102,7 @@ func (c *Config) Validate() error {
 		c.Prefix = c.Prefix[:len(c.Prefix)-2]
 	}
 
-	if len(c.Templates) == 0 && !c.Hints.Enabled() && len(c.Builders) == 0 {
+	if len(c.Templates) == 0 && !c.Hints.Enabled() && len(c.Builders) == 0 && len(c.LeaderGroups) == 0 {
 		return fmt.Errorf("no configs or hints defined for autodiscover provider")
 	}
 
@@ -115,5 +128,27 @@ func (c *Config) Validate() error {
 		logp.L().Warnf("can only set `unique` when scope is `cluster`")
 	}
 
+	if len(c.LeaderGroups) > 0 && !c.Unique {
+		return fmt.Errorf("`leader_groups` can only be set when `unique` is enabled")
+	}
+	names := make(map[string]bool, len(c.LeaderGroups))
+	leases := make(map[string]bool, len(c.LeaderGroups))
+	for i, g := range c.LeaderGroups {
+		if names[g.Name] {
+			return fmt.Errorf("duplicated leader group %q", g.Name)
+		}
+		names[g.Name] = true
+		if len(g.Templates) == 0 {
+			return fmt.Errorf("no templates defined for leader group %q", g.Name)
+		}
+		if g.Lease == "" {
+			c.LeaderGroups[i].Lease = c.LeaderLease + "-" + g.Name
+		}
+		if lease := c.LeaderGroups[i].Lease; leases[lease] || lease == c.LeaderLease {
+			return fmt.Errorf("leader group %q must use its own lease, %q is already used", g.Name, lease)
+		}
+		leases[c.LeaderGroups[i].Lease] = true
+	}
+
 	return nil
 }
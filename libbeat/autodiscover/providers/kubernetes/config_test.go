@@ -120,6 +120,89 @@ func TestConfigLeaseFields(t *testing.T) {
 	}
 }
 
+func TestConfigLeaderGroups(t *testing.T) {
+	group := func(name, lease string) mapstr.M {
+		g := mapstr.M{
+			"name":      name,
+			"templates": []mapstr.M{{"config": []mapstr.M{{"module": "kubernetes"}}}},
+		}
+		if lease != "" {
+			g["lease"] = lease
+		}
+		return g
+	}
+
+	tests := map[string]struct {
+		cfg    mapstr.M
+		leases []string
+		err    bool
+	}{
+		"default leases": {
+			cfg: mapstr.M{
+				"unique":        true,
+				"leader_groups": []mapstr.M{group("state", ""), group("events", "")},
+			},
+			leases: []string{"metricbeat-cluster-leader-state", "metricbeat-cluster-leader-events"},
+		},
+		"custom lease": {
+			cfg: mapstr.M{
+				"unique":        true,
+				"leader_groups": []mapstr.M{group("state", "state-leader")},
+			},
+			leases: []string{"state-leader"},
+		},
+		"not unique": {
+			cfg: mapstr.M{
+				"hints.enabled": true,
+				"leader_groups": []mapstr.M{group("state", "")},
+			},
+			err: true,
+		},
+		"duplicated name": {
+			cfg: mapstr.M{
+				"unique":        true,
+				"leader_groups": []mapstr.M{group("state", ""), group("state", "other")},
+			},
+			err: true,
+		},
+		"duplicated lease": {
+			cfg: mapstr.M{
+				"unique":        true,
+				"leader_groups": []mapstr.M{group("state", "leader"), group("events", "leader")},
+			},
+			err: true,
+		},
+		"no templates": {
+			cfg: mapstr.M{
+				"unique":        true,
+				"leader_groups": []mapstr.M{{"name": "state"}},
+			},
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.cfg["resource"] = "service"
+			config := conf.MustNewConfigFrom(&test.cfg)
+			c := defaultConfig()
+			c.LeaderLease = "metricbeat-cluster-leader"
+			err := config.Unpack(&c)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var leases []string
+			for _, g := range c.LeaderGroups {
+				leases = append(leases, g.Lease)
+			}
+			assert.Equal(t, test.leases, leases)
+		})
+	}
+}
+
 type mockBuilder struct {
 }
 
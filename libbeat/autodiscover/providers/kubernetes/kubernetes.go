@@ -88,6 +88,10 @@ type leaderElectionManager struct {
 	logger               *logp.Logger
 }
 
+// leaderElectionManagers implements start/stop methods for autodiscover provider with
+// a leaderElection per leader group
+type leaderElectionManagers []EventManager
+
 // AutodiscoverBuilder builds and returns an autodiscover provider
 func AutodiscoverBuilder(
 	beatName string,
@@ -140,7 +144,9 @@ func AutodiscoverBuilder(
 		logger:    logger,
 	}
 
-	if p.config.Unique {
+	if p.config.Unique && len(p.config.LeaderGroups) > 0 {
+		p.eventManager, err = p.newLeaderGroupsManager(uuid, client, keystore, k8sKeystoreProvider)
+	} else if p.config.Unique {
 		p.eventManager, err = NewLeaderElectionManager(uuid, config, client, p.startLeading, p.stopLeading, logger)
 	} else {
 		p.eventManager, err = NewEventerManager(uuid, c, config, client, p.publish)
@@ -198,6 +204,9 @@ func (p *Provider) publish(events []bus.Event) {
 	// Remove the port to avoid ambiguity during debugging
 	delete(event, "port")
 	event["config"] = configs
+	if matches := p.templates.Matches(events...); len(matches) > 0 {
+		event["templates"] = matches
+	}
 
 	// Call all appenders to append any extra configuration
 	p.appenders.Append(event)
@@ -205,29 +214,63 @@ func (p *Provider) publish(events []bus.Event) {
 }
 
 func (p *Provider) startLeading(uuid string, eventID string) {
+	p.publishLeading(p.templates, "start", uuid, eventID)
+}
+
+func (p *Provider) stopLeading(uuid string, eventID string) {
+	p.publishLeading(p.templates, "stop", uuid, eventID)
+}
+
+// publishLeading publishes the start or stop event of the templates enabled
+// by the holder of a lease.
+func (p *Provider) publishLeading(templates template.Mapper, flag string, uuid string, eventID string) {
 	event := bus.Event{
-		"start":    true,
+		flag:       true,
 		"provider": uuid,
 		"id":       eventID,
 		"unique":   "true",
 	}
-	if config := p.templates.GetConfig(event); config != nil {
+	if config := templates.GetConfig(event); config != nil {
 		event["config"] = config
 	}
 	p.bus.Publish(event)
 }
 
-func (p *Provider) stopLeading(uuid string, eventID string) {
-	event := bus.Event{
-		"stop":     true,
-		"provider": uuid,
-		"id":       eventID,
-		"unique":   "true",
+// newLeaderGroupsManager creates a leader election per leader group, each one
+// enabling the templates of its group while holding the lease of the group.
+// The templates of the provider, if any, keep using the leader_lease.
+func (p *Provider) newLeaderGroupsManager(
+	uuid uuid.UUID,
+	client k8s.Interface,
+	keystore keystore.Keystore,
+	keystoreProvider bus.KeystoreProvider,
+) (EventManager, error) {
+	var managers leaderElectionManagers
+	if len(p.config.Templates) > 0 {
+		lem, err := NewLeaderElectionManager(uuid, p.config, client, p.startLeading, p.stopLeading, p.logger)
+		if err != nil {
+			return nil, err
+		}
+		managers = append(managers, lem)
 	}
-	if config := p.templates.GetConfig(event); config != nil {
-		event["config"] = config
+	for _, group := range p.config.LeaderGroups {
+		mapper, err := template.NewConfigMapper(group.Templates, keystore, keystoreProvider)
+		if err != nil {
+			return nil, fmt.Errorf("leader group %s: %w", group.Name, err)
+		}
+		cfg := *p.config
+		cfg.LeaderLease = group.Lease
+		lem, err := NewLeaderElectionManager(uuid, &cfg, client,
+			func(uuid string, eventID string) { p.publishLeading(mapper, "start", uuid, eventID) },
+			func(uuid string, eventID string) { p.publishLeading(mapper, "stop", uuid, eventID) },
+			p.logger.With("leader_group", group.Name),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("leader group %s: %w", group.Name, err)
+		}
+		managers = append(managers, lem)
 	}
-	p.bus.Publish(event)
+	return managers, nil
 }
 
 func NewEventerManager(
@@ -345,6 +388,25 @@ func (p *leaderElectionManager) GenerateHints(event bus.Event) bus.Event {
 	return event
 }
 
+// Start for EventManager interface.
+func (m leaderElectionManagers) Start() {
+	for _, lem := range m {
+		lem.Start()
+	}
+}
+
+// Stop signals the leader election loop routines to stop.
+func (m leaderElectionManagers) Stop() {
+	for _, lem := range m {
+		lem.Stop()
+	}
+}
+
+// GenerateHints for EventManager interface.
+func (m leaderElectionManagers) GenerateHints(event bus.Event) bus.Event {
+	return event
+}
+
 // startLeaderElectorIndefinitely starts a Leader Elector in the background with the provided config.
 // If this instance gets the lease lock and later loses it, we run the leader elector again.
 func (p *leaderElectionManager) startLeaderElectorIndefinitely(ctx context.Context, lec leaderelection.LeaderElectionConfig) {
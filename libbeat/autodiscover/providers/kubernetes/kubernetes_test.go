@@ -29,7 +29,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
+	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/elastic-agent-autodiscover/bus"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -234,3 +237,42 @@ func TestNewLeaderElectionManager(t *testing.T) {
 		<-waitForLosingLeader
 	}
 }
+
+// TestLeaderGroupsManager tests that every leader group competes for its own lease,
+// in addition to the leader lease used by the templates of the provider.
+func TestLeaderGroupsManager(t *testing.T) {
+	client := k8sfake.NewSimpleClientset()
+
+	uuid, err := uuid.NewV4()
+	require.NoError(t, err)
+
+	cfg := defaultConfig()
+	cfg.Unique = true
+	cfg.Node = "node-1"
+	cfg.LeaderLease = leaseName
+	cfg.Templates = template.MapperSettings{{}}
+	cfg.LeaderGroups = []LeaderGroup{
+		{Name: "state", Lease: leaseName + "-state", Templates: template.MapperSettings{{}}},
+		{Name: "events", Lease: leaseName + "-events", Templates: template.MapperSettings{{}}},
+	}
+
+	p := &Provider{
+		config: cfg,
+		bus:    bus.New(logp.NewLogger("bus"), "test"),
+		logger: logp.NewLogger("kubernetes-test"),
+	}
+	em, err := p.newLeaderGroupsManager(uuid, client, nil, nil)
+	require.NoError(t, err)
+
+	managers, ok := em.(leaderElectionManagers)
+	require.True(t, ok)
+	require.Len(t, managers, 3)
+
+	var leases []string
+	for _, m := range managers {
+		lock, ok := m.(*leaderElectionManager).leaderElection.Lock.(*resourcelock.LeaseLock)
+		require.True(t, ok)
+		leases = append(leases, lock.LeaseMeta.Name)
+	}
+	require.Equal(t, []string{leaseName, leaseName + "-state", leaseName + "-events"}, leases)
+}
@@ -117,6 +117,35 @@ func (c Mapper) GetConfig(event bus.Event) []*conf.C {
 	return result
 }
 
+// Match tells whether the condition of a config template matched an event.
+// Template is the index of the template in the configuration.
+type Match struct {
+	Template  int    `json:"template"`
+	Condition string `json:"condition,omitempty"`
+	Matched   bool   `json:"matched"`
+}
+
+// Matches returns, for each config template, whether its condition matches any
+// of the events. Templates without a condition match every event.
+func (c Mapper) Matches(events ...bus.Event) []Match {
+	matches := make([]Match, len(c.ConditionMaps))
+	for i, mapping := range c.ConditionMaps {
+		matches[i].Template = i
+		if mapping.Condition == nil {
+			matches[i].Matched = true
+			continue
+		}
+		matches[i].Condition = mapping.Condition.String()
+		for _, event := range events {
+			if mapping.Condition.Check(Event(event)) {
+				matches[i].Matched = true
+				break
+			}
+		}
+	}
+	return matches
+}
+
 // ApplyConfigTemplate takes a set of templated configs and applys information in an event map
 func ApplyConfigTemplate(event bus.Event, configs []*conf.C, options ...ucfg.Option) []*conf.C {
 	var result []*conf.C
@@ -311,6 +311,36 @@ func TestNilConditionConfig(t *testing.T) {
 	assert.Nil(t, mappings[0].ConditionConfig)
 }
 
+func TestMapperMatches(t *testing.T) {
+	var mappings MapperSettings
+	data := `
+- condition.has_fields: [foo]
+  config:
+    - type: config1
+- condition.has_fields: [bar]
+  config:
+    - type: config2
+- config:
+    - type: config3`
+	config, err := conf.NewConfigWithYAML([]byte(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Unpack(&mappings); err != nil {
+		t.Fatal(err)
+	}
+
+	mapper, err := NewConfigMapper(mappings, nil, nil)
+	assert.NoError(t, err)
+
+	matches := mapper.Matches(bus.Event{"baz": 1}, bus.Event{"foo": 3})
+	assert.Equal(t, []Match{
+		{Template: 0, Condition: "has_fields: [foo]", Matched: true},
+		{Template: 1, Condition: "has_fields: [bar]", Matched: false},
+		{Template: 2, Matched: true},
+	}, matches)
+}
+
 // create a keystore with an existing key
 // `PASSWORD` with the value of `secret` variable.
 func createAnExistingKeystore(path string, secret string) keystore.Keystore {
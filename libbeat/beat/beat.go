@@ -57,6 +57,11 @@ type Beat struct {
 	Info      Info     // beat metadata.
 	Publisher Pipeline // Publisher pipeline
 
+	// NewPipeline creates additional publisher pipelines, with their own queue
+	// and output, next to Publisher. It is nil when the beat does not support
+	// them.
+	NewPipeline PipelineFactory
+
 	InSetupCmd bool // this is set to true when the `setup` command is called
 
 	OverwritePipelinesCallback OverwritePipelinesCallback // ingest pipeline loader callback
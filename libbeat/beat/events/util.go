@@ -42,6 +42,13 @@ const (
 	// Bulk API encoding of the event. The key's value can be an empty string, `create`, `index`, or `delete`.
 	// If empty, `create` will be used if FieldMetaID is set; otherwise `index` will be used.
 	FieldMetaOpType = "op_type"
+
+	// FieldMetaRouting defines the Elasticsearch routing value for the event.
+	FieldMetaRouting = "routing"
+
+	// FieldMetaHeaders defines the message headers to add to the event by outputs
+	// supporting them. The value is an object of header names to string values.
+	FieldMetaHeaders = "headers"
 )
 
 // GetMetaStringValue returns the value of the given event metadata string field
@@ -20,6 +20,7 @@ package beat
 import (
 	"time"
 
+	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
@@ -33,6 +34,18 @@ type Pipeline interface {
 // PipelineConnector wraps the Pipeline interface
 type PipelineConnector = Pipeline
 
+// ClosablePipeline is a Pipeline owning its queue and outputs. Close stops
+// them, it must be called once all the clients are closed.
+type ClosablePipeline interface {
+	Pipeline
+	Close() error
+}
+
+// PipelineFactory creates an additional publisher pipeline, with its own
+// queue and output, next to the default one of the beat. The name
+// identifies the pipeline in the logs and metrics.
+type PipelineFactory func(name string, queue, output config.Namespace) (ClosablePipeline, error)
+
 // Client holds a connection to the beats publisher pipeline
 type Client interface {
 	// Publish the event
@@ -60,6 +73,11 @@ type ClientConfig struct {
 
 	// ClientListener configures callbacks for monitoring pipeline clients
 	ClientListener ClientListener
+
+	// OutputProfile is the name of the output profile the client publishes
+	// to, when the beat runs pipelines with their own queue and output.
+	// Empty for the default pipeline.
+	OutputProfile string
 }
 
 // EventListener can be registered with a Client when connecting to the pipeline.
@@ -25,10 +25,12 @@ import (
 	"github.com/joeshaw/multierror"
 	"github.com/mitchellh/hashstructure"
 
+	"github.com/elastic/beats/v7/libbeat/audit"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/diagnostics"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
+	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/publisher/pipetool"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -37,20 +39,27 @@ import (
 
 // RunnerList implements a reloadable.List of Runners
 type RunnerList struct {
+	name     string
 	runners  map[uint64]Runner
 	mutex    sync.RWMutex
 	factory  RunnerFactory
 	pipeline beat.PipelineConnector
 	logger   *logp.Logger
+
+	// identities of the running runners, recorded in the audit trail
+	// when they are stopped.
+	identities map[uint64]audit.Runner
 }
 
 // NewRunnerList builds and returns a RunnerList
 func NewRunnerList(name string, factory RunnerFactory, pipeline beat.PipelineConnector) *RunnerList {
 	return &RunnerList{
-		runners:  map[uint64]Runner{},
-		factory:  factory,
-		pipeline: pipeline,
-		logger:   logp.NewLogger(name),
+		name:       name,
+		runners:    map[uint64]Runner{},
+		factory:    factory,
+		pipeline:   pipeline,
+		logger:     logp.NewLogger(name),
+		identities: map[uint64]audit.Runner{},
 	}
 }
 
@@ -86,6 +95,7 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 	defer r.mutex.Unlock()
 
 	var errs multierror.Errors
+	var started, stopped, updated []audit.Runner
 
 	startList := map[uint64]*reload.ConfigWithMeta{}
 	stopList := r.copyRunnerList()
@@ -108,6 +118,24 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 		}
 	}
 
+	// A single runner whose config changed may be updated in place
+	// instead of being restarted.
+	if len(startList) == 1 && len(stopList) == 1 {
+		var oldHash, newHash uint64
+		for h := range stopList {
+			oldHash = h
+		}
+		for h := range startList {
+			newHash = h
+		}
+		config := startList[newHash]
+		if r.updateRunner(startList, stopList) {
+			delete(r.identities, oldHash)
+			r.identities[newHash] = r.identity(newHash, config)
+			updated = append(updated, r.identities[newHash])
+		}
+	}
+
 	r.logger.Debugf("Start list: %d, Stop list: %d", len(startList), len(stopList))
 
 	wg := sync.WaitGroup{}
@@ -116,6 +144,8 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 		wg.Add(1)
 		r.logger.Debugf("Stopping runner: %s", runner)
 		delete(r.runners, hash)
+		stopped = append(stopped, r.identities[hash])
+		delete(r.identities, hash)
 		go func(runner Runner) {
 			defer wg.Done()
 			runner.Stop()
@@ -154,6 +184,8 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 
 		r.logger.Debugf("Starting runner: %s", runner)
 		r.runners[hash] = runner
+		r.identities[hash] = r.identity(hash, config)
+		started = append(started, r.identities[hash])
 		if config.StatusReporter != nil {
 			if runnerWithStatus, ok := runner.(status.WithStatusReporter); ok {
 				runnerWithStatus.SetStatusReporter(config.StatusReporter)
@@ -180,9 +212,44 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 	// above it is done asynchronously.
 	moduleRunning.Set(int64(len(r.runners)))
 
+	if len(started) != 0 || len(stopped) != 0 || len(updated) != 0 || len(errs) != 0 {
+		audit.Record(audit.Event{
+			Action:    audit.ActionRunnersReload,
+			Source:    r.auditSource(),
+			Component: r.name,
+			Started:   started,
+			Stopped:   stopped,
+			Updated:   updated,
+			Unchanged: len(r.runners) - len(started) - len(updated),
+			Err:       errs.Err(),
+		})
+	}
+
 	return errs.Err()
 }
 
+// identity returns the identity of the runner started from config recorded
+// in the audit trail.
+func (r *RunnerList) identity(hash uint64, config *reload.ConfigWithMeta) audit.Runner {
+	id := audit.NewRunner(hash, config.Config)
+	id.UnitID = config.InputUnitID
+	return id
+}
+
+// auditSource returns the source of the configurations reloaded by the list.
+func (r *RunnerList) auditSource() string {
+	switch r.name {
+	case "reload", "load":
+		return audit.SourceConfigFile
+	case "autodiscover.cfgfile":
+		return audit.SourceAutodiscover
+	}
+	if management.UnderAgent() {
+		return audit.SourceManagement
+	}
+	return audit.SourceConfigFile
+}
+
 // Stop all runners
 func (r *RunnerList) Stop() {
 	r.mutex.Lock()
@@ -199,6 +266,7 @@ func (r *RunnerList) Stop() {
 		wg.Add(1)
 
 		delete(r.runners, hash)
+		delete(r.identities, hash)
 
 		// Stop modules in parallel
 		go func(h uint64, run Runner) {
@@ -229,6 +297,32 @@ func HashConfig(c *config.C) (uint64, error) {
 	return hashstructure.Hash(config, nil)
 }
 
+// updateRunner updates the single runner in stopList with the single config in
+// startList if the runner implements UpdatableRunner. On success both lists are
+// emptied, the runner is stored under the hash of its new config and true is
+// returned.
+func (r *RunnerList) updateRunner(startList map[uint64]*reload.ConfigWithMeta, stopList map[uint64]Runner) bool {
+	for oldHash, runner := range stopList {
+		updatable, ok := runner.(UpdatableRunner)
+		if !ok {
+			return false
+		}
+		for newHash, cfg := range startList {
+			c, _ := config.NewConfigFrom(cfg.Config)
+			if err := updatable.Update(c); err != nil {
+				r.logger.Infof("Runner '%s' can not be updated in place, restarting it: %v", runner, err)
+				return false
+			}
+			r.logger.Debugf("Updated runner '%s' in place", runner)
+			delete(r.runners, oldHash)
+			r.runners[newHash] = runner
+			delete(stopList, oldHash)
+			delete(startList, newHash)
+		}
+	}
+	return true
+}
+
 func (r *RunnerList) copyRunnerList() map[uint64]Runner {
 	list := make(map[uint64]Runner, len(r.runners))
 	for k, v := range r.runners {
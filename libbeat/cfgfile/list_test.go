@@ -237,6 +237,55 @@ func TestReloadStartStopConfigs(t *testing.T) {
 	assert.NotEqual(t, state, list.copyRunnerList())
 }
 
+type updatableRunner struct {
+	runner
+	updates int
+	fail    bool
+}
+
+func (r *updatableRunner) Update(c *conf.C) error {
+	if r.fail {
+		return fmt.Errorf("update failed")
+	}
+	r.updates++
+	return nil
+}
+
+func TestReloadUpdateConfig(t *testing.T) {
+	var created []*updatableRunner
+	factory := &runnerFactory{
+		CreateRunner: func(_ beat.PipelineConnector, _ *conf.C) (Runner, error) {
+			r := &updatableRunner{}
+			created = append(created, r)
+			return r, nil
+		},
+	}
+	list := NewRunnerList("", factory, nil)
+
+	err := list.Reload([]*reload.ConfigWithMeta{createConfig(1)})
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+
+	// A changed config is applied to the running runner.
+	err = list.Reload([]*reload.ConfigWithMeta{createConfig(2)})
+	require.NoError(t, err)
+	assert.Len(t, created, 1)
+	assert.Equal(t, 1, created[0].updates)
+	assert.False(t, created[0].stopped)
+
+	hash, err := HashConfig(createConfig(2).Config)
+	require.NoError(t, err)
+	assert.True(t, list.Has(hash))
+
+	// A failed update restarts the runner.
+	created[0].fail = true
+	err = list.Reload([]*reload.ConfigWithMeta{createConfig(3)})
+	require.NoError(t, err)
+	assert.Len(t, created, 2)
+	assert.True(t, created[0].stopped)
+	assert.True(t, created[1].started)
+}
+
 func TestStopAll(t *testing.T) {
 	factory := &runnerFactory{}
 	list := NewRunnerList("", factory, nil)
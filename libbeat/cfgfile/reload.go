@@ -25,6 +25,7 @@ import (
 
 	"github.com/joeshaw/multierror"
 
+	"github.com/elastic/beats/v7/libbeat/audit"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -94,6 +95,17 @@ type Runner interface {
 	Stop()
 }
 
+// UpdatableRunner is a Runner that can apply a changed configuration without
+// being stopped and started again.
+type UpdatableRunner interface {
+	Runner
+
+	// Update applies cfg to the running runner. If an error is returned the
+	// runner is left unchanged and is replaced by a new runner created from
+	// cfg.
+	Update(cfg *config.C) error
+}
+
 // Reloader is used to register and reload modules
 type Reloader struct {
 	pipeline beat.PipelineConnector
@@ -207,7 +219,16 @@ func (rl *Reloader) Run(runnerFactory RunnerFactory) {
 			configReloads.Add(1)
 
 			// Load all config objects
-			configs, _ := rl.loadConfigs(files)
+			configs, err := rl.loadConfigs(files)
+			if updated {
+				audit.Record(audit.Event{
+					Action:    audit.ActionFilesChanged,
+					Source:    audit.SourceConfigFile,
+					Component: "reload",
+					Files:     files,
+					Err:       err,
+				})
+			}
 
 			debugf("Number of module configs found: %v", len(configs))
 
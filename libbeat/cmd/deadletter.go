@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common/cli"
+)
+
+func genReplayDeadLetterCmd(settings instance.Settings) *cobra.Command {
+	var (
+		path    string
+		timeout time.Duration
+		remove  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "replay-deadletter",
+		Short: "Publish the events of the dead-letter files to the configured output",
+		Long: `Publish the events of the dead-letter files to the configured output.
+
+The events were written to the dead-letter files because they were dropped
+by the pipeline or the output, see pipeline.dead_letter. They are published
+as they were captured, without running the processors again. Stop ` + settings.Name + `
+before replaying the files it writes to.`,
+		Run: cli.RunWith(func(_ *cobra.Command, _ []string) error {
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				return fmt.Errorf("error initializing beat: %w", err)
+			}
+			result, err := b.ReplayDeadLetter(path, timeout, remove)
+			if len(result.Files) == 0 && err == nil {
+				fmt.Println("No dead-letter files to replay.")
+				return nil
+			}
+			fmt.Printf("Published %d events from %d dead-letter files, %d acknowledged.\n",
+				result.Published, len(result.Files), result.Acked)
+			return err
+		}),
+	}
+	cmd.Flags().StringVar(&path, "path", "", "Directory of the dead-letter files, defaults to the configured pipeline.dead_letter.path")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait for the events to be acknowledged")
+	cmd.Flags().BoolVar(&remove, "delete", false, "Delete the files once all their events are acknowledged")
+	return cmd
+}
@@ -35,6 +35,7 @@ func genExportCmd(settings instance.Settings) *cobra.Command {
 	exportCmd.AddCommand(export.GenIndexPatternConfigCmd(settings))
 	exportCmd.AddCommand(export.GenDashboardCmd(settings))
 	exportCmd.AddCommand(export.GenGetILMPolicyCmd(settings))
+	exportCmd.AddCommand(export.GenExportSeccompCmd(settings))
 
 	return exportCmd
 }
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package export
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common/cli"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
+)
+
+// GenExportSeccompCmd write to stdout the seccomp policy tailored to the
+// current configuration in the YAML format.
+func GenExportSeccompCmd(settings instance.Settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "seccomp",
+		Short: "Export seccomp policy tailored to the current config to stdout",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			return exportSeccomp(settings)
+		}),
+	}
+}
+
+type seccompSyscallGroup struct {
+	Action string   `yaml:"action"`
+	Names  []string `yaml:"names"`
+}
+
+type seccompPolicy struct {
+	DefaultAction string                `yaml:"default_action"`
+	Syscalls      []seccompSyscallGroup `yaml:"syscalls"`
+}
+
+func exportSeccomp(settings instance.Settings) error {
+	b, err := instance.NewInitializedBeat(settings)
+	if err != nil {
+		fatalfInitCmd(err)
+	}
+
+	profile, err := seccomp.Analyze(b.Info.Beat, b.RawConfig)
+	if err != nil {
+		fatalf("Error analyzing config: %+v.", err)
+	}
+
+	policy := seccomp.BasePolicy()
+	if policy == nil {
+		fatalf("No seccomp policy is available for this platform.")
+	}
+
+	var header strings.Builder
+	if profile.Tailorable() {
+		policy = seccomp.Tailor(policy, profile)
+	} else {
+		fmt.Fprintf(&header, "# The policy can not be tailored: %s\n", profile.Reason())
+	}
+	for _, c := range profile.Components {
+		fmt.Fprintf(&header, "# Component: %s\n", c)
+	}
+	if len(profile.Capabilities) > 0 {
+		fmt.Fprintf(&header, "# Required capabilities: %s\n", strings.Join(profile.Capabilities, ", "))
+	}
+
+	out := seccompPolicy{DefaultAction: policy.DefaultAction.String()}
+	for _, group := range policy.Syscalls {
+		out.Syscalls = append(out.Syscalls, seccompSyscallGroup{
+			Action: group.Action.String(),
+			Names:  group.Names,
+		})
+	}
+	res, err := yaml.Marshal(map[string]interface{}{"seccomp": out})
+	if err != nil {
+		fatalf("Error converting seccomp policy to YAML format: %+v.", err)
+	}
+
+	os.Stdout.WriteString(header.String())
+	os.Stdout.Write(res)
+	return nil
+}
@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common/cli"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+)
+
+func genFIPSCmd(settings instance.Settings) *cobra.Command {
+	fipsCmd := &cobra.Command{
+		Use:   "fips",
+		Short: "Check the FIPS capability of the configuration",
+	}
+	fipsCmd.AddCommand(genFIPSStatusCmd(settings))
+	return fipsCmd
+}
+
+func genFIPSStatusCmd(settings instance.Settings) *cobra.Command {
+	var strict, jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Report the FIPS capability of the configured inputs, outputs and processors",
+		Long: `Report the FIPS capability of the configured inputs, outputs and processors.
+
+The command fails if a component uses algorithms that are not FIPS approved.
+With --strict, it also fails if the FIPS capability of a component is unknown.`,
+		Run: cli.RunWith(func(_ *cobra.Command, _ []string) error {
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				return fmt.Errorf("error initializing beat: %w", err)
+			}
+			report, err := b.FIPSReport(settings)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				err = printFIPSReportJSON(os.Stdout, report)
+			} else {
+				err = printFIPSReport(os.Stdout, report)
+			}
+			if err != nil {
+				return err
+			}
+			if !report.Capable(strict) {
+				return errors.New("the configuration is not FIPS capable")
+			}
+			return nil
+		}),
+	}
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail if the FIPS capability of a component is unknown")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the report as JSON")
+	return cmd
+}
+
+func printFIPSReport(out io.Writer, report fips.Report) error {
+	mode := "disabled"
+	if report.Enabled {
+		mode = "enabled"
+	}
+	fmt.Fprintf(out, "FIPS mode: %s\n\n", mode)
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tTYPE\tID\tSTATUS\tREASON")
+	for _, c := range report.Components {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Kind, c.Type, c.ID, c.Status, c.Reason)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(out, "\n%d capable, %d incapable, %d unknown\n",
+		report.Count(fips.StatusCapable), report.Count(fips.StatusIncapable), report.Count(fips.StatusUnknown))
+	return err
+}
+
+func printFIPSReportJSON(out io.Writer, report fips.Report) error {
+	components := report.Components
+	if components == nil {
+		components = []fips.Component{}
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Enabled    bool             `json:"enabled"`
+		Capable    bool             `json:"capable"`
+		Components []fips.Component `json:"components"`
+	}{report.Enabled, report.Capable(false), components})
+}
@@ -39,15 +39,20 @@ import (
 
 	"github.com/gofrs/uuid"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/elastic/beats/v7/libbeat/api"
 	"github.com/elastic/beats/v7/libbeat/asset"
+	"github.com/elastic/beats/v7/libbeat/audit"
+	"github.com/elastic/beats/v7/libbeat/autodiscover"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
 	"github.com/elastic/beats/v7/libbeat/cloudid"
 	"github.com/elastic/beats/v7/libbeat/cmd/instance/locks"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgprovider"
 	"github.com/elastic/beats/v7/libbeat/common/fleetmode"
+	"github.com/elastic/beats/v7/libbeat/common/keystorereload"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/dashboards"
@@ -60,13 +65,16 @@ import (
 	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/beats/v7/libbeat/monitoring/report"
 	"github.com/elastic/beats/v7/libbeat/monitoring/report/log"
+	"github.com/elastic/beats/v7/libbeat/monitoring/selflog"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/elasticsearch"
 	"github.com/elastic/beats/v7/libbeat/plugin"
 	"github.com/elastic/beats/v7/libbeat/pprof"
+	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/libbeat/publisher/pipeline"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/hybridqueue"
 	"github.com/elastic/beats/v7/libbeat/version"
 	"github.com/elastic/elastic-agent-client/v7/pkg/proto"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -100,6 +108,11 @@ type Beat struct {
 
 	keystore   keystore.Keystore
 	processors processing.Supporter
+	selfLog    *selflog.Core
+	audit      *audit.Recorder
+
+	// outputReloader reloads the output of the pipeline.
+	outputReloader reload.Reloadable
 
 	InputQueueSize int // Size of the producer queue used by most queues.
 
@@ -128,6 +141,8 @@ type beatConfig struct {
 	Logging         *config.C              `config:"logging"`
 	EventLogging    *config.C              `config:"logging.event_data"`
 	MetricLogging   *config.C              `config:"logging.metrics"`
+	SelfLogging     *config.C              `config:"logging.selflog"`
+	Audit           *config.C              `config:"audit"`
 	Keystore        *config.C              `config:"keystore"`
 	Instrumentation instrumentation.Config `config:"instrumentation"`
 
@@ -151,6 +166,8 @@ type beatConfig struct {
 	Migration *config.C `config:"migration.6_to_7"`
 	// TimestampPrecision sets the precision of all timestamps in the Beat.
 	TimestampPrecision *config.C `config:"timestamp"`
+	// ProcessorMetrics configures the metrics recorded for each processor.
+	ProcessorMetrics *config.C `config:"processor_metrics"`
 }
 
 type certReloadConfig struct {
@@ -395,7 +412,19 @@ func (b *Beat) createBeater(bt beat.Creator) (beat.Beater, error) {
 		return nil, fmt.Errorf("error initializing publisher: %w", err)
 	}
 
-	reload.RegisterV2.MustRegisterOutput(b.makeOutputReloader(publisher.OutputReloader()))
+	b.outputReloader = b.makeOutputReloader(publisher.OutputReloader())
+	reload.RegisterV2.MustRegisterOutput(b.outputReloader)
+	if b.selfLog != nil {
+		if err := b.selfLog.Start(publisher); err != nil {
+			return nil, fmt.Errorf("error publishing log events: %w", err)
+		}
+	}
+	if b.audit != nil {
+		if err := b.audit.Start(publisher); err != nil {
+			return nil, fmt.Errorf("error publishing audit events: %w", err)
+		}
+	}
+	b.NewPipeline = b.makePipelineFactory(reg)
 
 	// TODO: some beats race on shutdown with publisher.Stop -> do not call Stop yet,
 	//       but refine publisher to disconnect clients on stop automatically
@@ -421,6 +450,16 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 			logp.Warn("Failed to close global processing: %v", err)
 		}
 	}()
+	defer func() {
+		if b.selfLog != nil {
+			b.selfLog.Stop()
+		}
+	}()
+	defer func() {
+		if b.audit != nil {
+			b.audit.Close()
+		}
+	}()
 
 	// Windows: Mark service as stopped.
 	// After this is run, a Beat service is considered by the OS to be stopped
@@ -447,6 +486,7 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 	defer svc.Cleanup()
 
 	b.registerMetrics()
+	b.registerFIPSMetrics(settings)
 
 	// Start the API Server before the Seccomp lock down, we do this so we can create the unix socket
 	// set the appropriate permission on the unix domain file without having to whitelist anything
@@ -461,6 +501,9 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 		defer func() {
 			_ = b.API.Stop()
 		}()
+		if err := b.API.AttachHandler("/autodiscover", autodiscover.DiagnosticsHandler()); err != nil {
+			return fmt.Errorf("failed to attach http handler for autodiscover diagnostics: %w", err)
+		}
 		if b.Config.HTTPPprof.IsEnabled() {
 			pprof.SetRuntimeProfilingParameters(b.Config.HTTPPprof)
 
@@ -473,7 +516,14 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 	// Do not load seccomp for osquerybeat, it was disabled before V2 in the configuration file
 	// https://github.com/elastic/beats/blob/7cf873fd340172c33f294500ccfec948afd7a47c/x-pack/osquerybeat/osquerybeat.yml#L16
 	if b.Info.Beat != "osquerybeat" {
-		if err := seccomp.LoadFilter(b.Config.Seccomp); err != nil {
+		profile, err := seccomp.Analyze(b.Info.Beat, b.RawConfig)
+		if err != nil {
+			return fmt.Errorf("failed to analyze the configuration for seccomp: %w", err)
+		}
+		if b.Manager.Enabled() {
+			profile.Incomplete = "the Beat is centrally managed"
+		}
+		if err := seccomp.LoadFilter(b.Config.Seccomp, profile); err != nil {
 			return err
 		}
 	}
@@ -524,6 +574,23 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 		return err
 	}
 
+	// Restart the Beat when a value resolved from a config provider changes.
+	if cfgprovider.RefreshEnabled() {
+		if runtime.GOOS == "windows" {
+			logp.Warn("config_providers.refresh_interval is not supported on Windows, values will not be refreshed")
+		} else {
+			go cfgprovider.Watch(ctx, func() {
+				logp.Info("values from config providers changed, restarting %s.", b.Info.Beat)
+				b.shouldReexec = true
+				b.Manager.Stop()
+			})
+		}
+	}
+
+	if err := b.startKeystoreReload(ctx); err != nil {
+		return err
+	}
+
 	logp.Info("%s start running.", b.Info.Beat)
 
 	// Allow the manager to stop a currently running beats out of bound.
@@ -760,7 +827,11 @@ func (b *Beat) configure(settings Settings) error {
 
 	// We have to initialize the keystore before any unpack or merging the cloud
 	// options.
-	store, err := LoadKeystore(cfg, b.Info.Beat)
+	// The keystore can be reloaded while the Beat runs, the configurations
+	// resolve the current values when they are unpacked.
+	store, err := keystorereload.New(func() (keystore.Keystore, error) {
+		return LoadKeystore(cfg, b.Info.Beat)
+	})
 	if err != nil {
 		return fmt.Errorf("could not initialize the keystore: %w", err)
 	}
@@ -772,6 +843,10 @@ func (b *Beat) configure(settings Settings) error {
 		config.OverwriteConfigOpts(configOpts(store))
 	}
 
+	if err := cfgprovider.Configure(cfg); err != nil {
+		return fmt.Errorf("could not initialize the config providers: %w", err)
+	}
+
 	instrumentation, err := instrumentation.New(cfg, b.Info.Beat, b.Info.Version)
 	if err != nil {
 		return err
@@ -810,13 +885,35 @@ func (b *Beat) configure(settings Settings) error {
 		return fmt.Errorf("error setting timestamp precision: %w", err)
 	}
 
-	if err := configure.LoggingWithTypedOutputs(b.Info.Beat, b.Config.Logging, b.Config.EventLogging, logp.TypeKey, logp.EventType); err != nil {
+	if err := processors.SetMetrics(b.Config.ProcessorMetrics); err != nil {
+		return err
+	}
+
+	libbeatRegistry := monitoring.Default.GetRegistry("libbeat")
+	if libbeatRegistry == nil {
+		libbeatRegistry = monitoring.Default.NewRegistry("libbeat")
+	}
+	b.selfLog, err = selflog.NewCore(b.Info.Beat, b.Config.SelfLogging, libbeatRegistry)
+	if err != nil {
+		return err
+	}
+	var logOutputs []zapcore.Core
+	if b.selfLog != nil {
+		logOutputs = append(logOutputs, b.selfLog)
+	}
+
+	if err := configure.LoggingWithTypedOutputs(b.Info.Beat, b.Config.Logging, b.Config.EventLogging, logp.TypeKey, logp.EventType, logOutputs...); err != nil {
 		return fmt.Errorf("error initializing logging: %w", err)
 	}
 
 	// log paths values to help with troubleshooting
 	logp.Info(paths.Paths.String())
 
+	b.audit, err = audit.Configure(b.Info.Beat, b.Config.Audit, paths.Resolve(paths.Logs, ""))
+	if err != nil {
+		return err
+	}
+
 	metaPath := paths.Resolve(paths.Data, "meta.json")
 	err = b.loadMeta(metaPath)
 	if err != nil {
@@ -850,6 +947,7 @@ func (b *Beat) configure(settings Settings) error {
 		return err
 	}
 	b.Manager = m
+	elasticsearch.SetStatusReporter(b.Manager)
 
 	if b.Manager.AgentInfo().Version != "" {
 		// During the manager initialization the client to connect to the agent is
@@ -1087,6 +1185,16 @@ func (b *Beat) registerESVersionCheckCallback() error {
 		if b.isConnectionToOlderVersionAllowed() || conn.IsServerless() {
 			return nil
 		}
+		// The versions of OpenSearch can't be compared with the version of
+		// the Beat, the compatibility mode adapts the requests to it.
+		if conn.IsOpenSearch() {
+			return nil
+		}
+		// Other distributions have their own versions, that can't be
+		// compared with the version of the Beat.
+		if conn.Flavor() != eslegclient.FlavorElasticsearch {
+			return fmt.Errorf("%w Cluster=%s", elasticsearch.ErrUnsupportedFlavor, conn.Capabilities())
+		}
 
 		esVersion := conn.GetVersion()
 		beatVersion, err := libversion.New(b.Info.Version)
@@ -1129,6 +1237,11 @@ func (b *Beat) registerESIndexManagement() error {
 
 func (b *Beat) indexSetupCallback() elasticsearch.ConnectCallback {
 	return func(esClient *eslegclient.Connection) error {
+		// Fail with a clear error instead of the errors of the template
+		// APIs of clusters without data streams.
+		if caps := esClient.Capabilities(); !caps.DataStreams {
+			return fmt.Errorf("index management requires data streams, which are not supported by %s; upgrade the cluster or disable setup.template and setup.ilm", caps)
+		}
 		mgmtHandler, err := idxmgmt.NewESClientHandler(esClient, b.Info, b.Config.LifecycleConfig)
 		if err != nil {
 			return fmt.Errorf("error creating index management handler: %w", err)
@@ -1173,6 +1286,40 @@ func (b *Beat) makeOutputFactory(
 	}
 }
 
+// makePipelineFactory returns the factory of the additional pipelines of
+// the beat. They share the global processors of the default pipeline, and
+// report their metrics under pipelines.<name> of reg.
+func (b *Beat) makePipelineFactory(reg *monitoring.Registry) beat.PipelineFactory {
+	return func(name string, queue, output config.Namespace) (beat.ClosablePipeline, error) {
+		pipelines := reg.GetRegistry("pipelines")
+		if pipelines == nil {
+			pipelines = reg.NewRegistry("pipelines")
+		}
+		metrics := pipelines.GetRegistry(name)
+		if metrics == nil {
+			metrics = pipelines.NewRegistry(name)
+		}
+		monitors := pipeline.Monitors{
+			Metrics: metrics,
+			Logger:  logp.L().Named("publisher").With("pipeline", name),
+			Tracer:  b.Instrumentation.Tracer(),
+		}
+		outputFactory := func(stats outputs.Observer) (string, outputs.Group, error) {
+			out, err := outputs.Load(b.IdxSupporter, b.Info, stats, output.Name(), output.Config())
+			return output.Name(), out, err
+		}
+		settings := pipeline.Settings{
+			Processors:     b.processors,
+			InputQueueSize: b.InputQueueSize,
+		}
+		p, err := pipeline.LoadWithSettings(b.Info, monitors, pipeline.Config{Queue: queue}, outputFactory, settings)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing pipeline %s: %w", name, err)
+		}
+		return p, nil
+	}
+}
+
 func (b *Beat) reloadOutputOnCertChange(cfg config.Namespace) error {
 	logger := logp.L().Named("ssl.cert.reloader")
 	// Here the output is created and we have access to the Beat struct (with the manager)
@@ -1439,6 +1586,7 @@ func configOpts(store keystore.Keystore) []ucfg.Option {
 	return []ucfg.Option{
 		ucfg.PathSep("."),
 		ucfg.Resolve(keystore.ResolverWrap(store)),
+		ucfg.Resolve(cfgprovider.Resolve),
 		ucfg.ResolveEnv,
 		ucfg.VarExp,
 	}
@@ -1569,12 +1717,18 @@ func (bc *beatConfig) Validate() error {
 		if bc.Management.Enabled() && outputPC.Queue.Config().Enabled() && outputPC.Queue.Name() == diskqueue.QueueType {
 			return fmt.Errorf("disk queue is not supported when management is enabled")
 		}
+		if bc.Management.Enabled() && outputPC.Queue.Config().Enabled() && outputPC.Queue.Name() == hybridqueue.QueueType {
+			return fmt.Errorf("hybrid queue is not supported when management is enabled")
+		}
 	}
 
 	// elastic-agent doesn't support disk queue yet
 	if bc.Management.Enabled() && bc.Pipeline.Queue.Config().Enabled() && bc.Pipeline.Queue.Name() == diskqueue.QueueType {
 		return fmt.Errorf("disk queue is not supported when management is enabled")
 	}
+	if bc.Management.Enabled() && bc.Pipeline.Queue.Config().Enabled() && bc.Pipeline.Queue.Name() == hybridqueue.QueueType {
+		return fmt.Errorf("hybrid queue is not supported when management is enabled")
+	}
 
 	return nil
 }
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
+	"github.com/elastic/beats/v7/libbeat/publisher/pipeline"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// DeadLetterReplay is the result of ReplayDeadLetter.
+type DeadLetterReplay struct {
+	Files     []string
+	Published int
+	Acked     int
+}
+
+// ReplayDeadLetter publishes the events of the dead-letter files in dir to
+// the configured output. The events were already processed, so they bypass
+// the processors. When dir is empty, the path configured in
+// pipeline.dead_letter is used. It returns once all the events are
+// acknowledged, or after timeout. The files are deleted when remove is set
+// and all the events were acknowledged.
+func (b *Beat) ReplayDeadLetter(dir string, timeout time.Duration, remove bool) (DeadLetterReplay, error) {
+	var result DeadLetterReplay
+
+	if dir == "" {
+		config := deadletter.DefaultConfig()
+		if b.Config.Pipeline.DeadLetter != nil {
+			if err := b.Config.Pipeline.DeadLetter.Unpack(&config); err != nil {
+				return result, fmt.Errorf("invalid pipeline.dead_letter settings: %w", err)
+			}
+		}
+		dir = config.Path
+		if dir == "" {
+			dir = paths.Resolve(paths.Data, "deadletter")
+		}
+	}
+	files, err := deadletter.Files(dir)
+	if err != nil {
+		return result, fmt.Errorf("failed to list dead-letter files in %s: %w", dir, err)
+	}
+	result.Files = files
+	if len(files) == 0 {
+		return result, nil
+	}
+
+	if !b.Config.Output.IsSet() {
+		return result, errors.New("no outputs are defined, please define one under the output section")
+	}
+	output := b.Config.Output
+	outputFactory := func(stats outputs.Observer) (string, outputs.Group, error) {
+		out, err := outputs.Load(b.IdxSupporter, b.Info, stats, output.Name(), output.Config())
+		return output.Name(), out, err
+	}
+	monitors := pipeline.Monitors{
+		Logger: logp.NewLogger("publisher").With("pipeline", "deadletter"),
+	}
+	p, err := pipeline.LoadWithSettings(b.Info, monitors, pipeline.Config{Queue: b.Config.Pipeline.Queue}, outputFactory, pipeline.Settings{})
+	if err != nil {
+		return result, fmt.Errorf("error initializing publisher: %w", err)
+	}
+	defer p.Close()
+
+	var acked atomic.Int64
+	client, err := p.ConnectWith(beat.ClientConfig{
+		PublishMode:   beat.GuaranteedSend,
+		EventListener: acker.Counting(func(n int) { acked.Add(int64(n)) }),
+		WaitClose:     timeout,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, path := range files {
+		err := deadletter.Read(path, func(rec deadletter.Record) error {
+			if rec.Event.Timestamp.IsZero() {
+				rec.Event.Timestamp = rec.Timestamp
+			}
+			client.Publish(rec.Event)
+			result.Published++
+			return nil
+		})
+		if err != nil {
+			client.Close()
+			result.Acked = int(acked.Load())
+			return result, err
+		}
+	}
+
+	// Close waits up to timeout for the events to be acknowledged.
+	client.Close()
+	result.Acked = int(acked.Load())
+	if result.Acked < result.Published {
+		return result, fmt.Errorf("%d of %d events were not acknowledged after %v", result.Published-result.Acked, result.Published, timeout)
+	}
+
+	if remove {
+		for _, path := range files {
+			if err := os.Remove(path); err != nil {
+				return result, fmt.Errorf("failed to delete replayed dead-letter file: %w", err)
+			}
+		}
+	}
+	return result, nil
+}
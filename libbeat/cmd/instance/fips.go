@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// FIPSReport returns the FIPS capability of the inputs, output and global
+// processors of the configuration. The inputs are only reported by the beats
+// setting Settings.FIPSInputs.
+func (b *Beat) FIPSReport(settings Settings) (fips.Report, error) {
+	report := fips.Report{Enabled: fips.Enabled}
+
+	if settings.FIPSInputs != nil {
+		beatConfig, err := b.BeatConfig()
+		if err != nil {
+			return report, err
+		}
+		inputs, err := settings.FIPSInputs(b.Info, beatConfig)
+		if err != nil {
+			return report, fmt.Errorf("failed to check inputs: %w", err)
+		}
+		report.Components = append(report.Components, inputs...)
+	}
+
+	if b.Config.Output.IsSet() {
+		report.Components = append(report.Components,
+			fips.Check(fips.KindOutput, b.Config.Output.Name(), "", b.Config.Output.Config()))
+	}
+
+	var global struct {
+		Processors []*config.C `config:"processors"`
+	}
+	if err := b.RawConfig.Unpack(&global); err != nil {
+		return report, fmt.Errorf("failed to read processors: %w", err)
+	}
+	processors, err := fips.CheckProcessors("", global.Processors)
+	if err != nil {
+		return report, err
+	}
+	report.Components = append(report.Components, processors...)
+
+	return report, nil
+}
+
+// registerFIPSMetrics reports the FIPS capability of the configuration in
+// state.fips.
+func (b *Beat) registerFIPSMetrics(settings Settings) {
+	report, err := b.FIPSReport(settings)
+	if err != nil {
+		logp.Warn("Failed to check the FIPS capability of the configuration: %v", err)
+		return
+	}
+	if !report.Capable(false) {
+		logp.Warn("The configuration is not FIPS capable, run the 'fips status' command for details.")
+	}
+	stateRegistry := monitoring.GetNamespace("state").GetRegistry()
+	monitoring.NewFunc(stateRegistry, "fips", report.ReportMetrics, monitoring.Report)
+}
@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instance
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+
+	"github.com/elastic/beats/v7/libbeat/common/keystorereload"
+	"github.com/elastic/beats/v7/libbeat/common/reload"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// startKeystoreReload reloads the keystore while the Beat runs when
+// keystore.reload.enabled is set. The keystore is read again periodically
+// and on requests to the HTTP API, the Beat then reacts to the changed keys.
+func (b *Beat) startKeystoreReload(ctx context.Context) error {
+	store, ok := b.keystore.(*keystorereload.Keystore)
+	if !ok {
+		return nil
+	}
+
+	cfg := keystorereload.DefaultConfig()
+	if b.Config.Keystore != nil && b.Config.Keystore.HasField("reload") {
+		sub, err := b.Config.Keystore.Child("reload", -1)
+		if err != nil {
+			return fmt.Errorf("error reading keystore.reload: %w", err)
+		}
+		if err := sub.Unpack(&cfg); err != nil {
+			return fmt.Errorf("error unpacking keystore.reload: %w", err)
+		}
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+
+	store.OnChange(b.onKeystoreChange)
+	go store.Watch(ctx, cfg.Period)
+
+	if b.API == nil {
+		if cfg.APIWrites {
+			logp.Warn("keystore.reload.api_writes is set but the HTTP endpoint is disabled, keys can't be updated through the API")
+		}
+		return nil
+	}
+	if err := b.API.AttachHandler("/keystore/reload", store.ReloadHandler()); err != nil {
+		return fmt.Errorf("failed to attach http handler for the keystore reload: %w", err)
+	}
+	if cfg.APIWrites {
+		if err := b.API.AttachHandler("/keystore/secrets/{key}", store.SecretsHandler()); err != nil {
+			return fmt.Errorf("failed to attach http handler for the keystore secrets: %w", err)
+		}
+	}
+	return nil
+}
+
+// onKeystoreChange reacts to keys of the keystore changing. When only the
+// output references them, the output is reloaded, the new clients connect
+// with the new values and the events in flight are retried by them. When
+// other settings reference them, the Beat is restarted so that they are
+// resolved again.
+func (b *Beat) onKeystoreChange(changed []string) {
+	log := logp.NewLogger("keystore")
+	if b.Manager.Enabled() {
+		log.Debug("The Beat is centrally managed, the configuration is resolved again when it is received")
+		return
+	}
+
+	refs, err := keystorereload.References(b.RawConfig, changed)
+	if err != nil {
+		log.Errorf("Could not find the settings referencing the changed keys: %v", err)
+		return
+	}
+
+	var settings []string
+	for name := range refs {
+		if name != "output" && name != "keystore" {
+			settings = append(settings, name)
+		}
+	}
+	sort.Strings(settings)
+
+	if len(settings) > 0 {
+		if runtime.GOOS == "windows" {
+			log.Warnf("Settings %v reference changed keys, restart %s to use the new values", settings, b.Info.Beat)
+			return
+		}
+		log.Infof("Settings %v reference changed keys, restarting %s.", settings, b.Info.Beat)
+		b.shouldReexec = true
+		b.Manager.Stop()
+		return
+	}
+
+	if _, ok := refs["output"]; !ok || b.outputReloader == nil {
+		return
+	}
+	outputCfg, err := b.RawConfig.Child("output", -1)
+	if err != nil {
+		log.Errorf("Could not read the output configuration: %v", err)
+		return
+	}
+	log.Infof("Output references changed keys %v, reloading the output", refs["output"])
+	if err := b.outputReloader.Reload(&reload.ConfigWithMeta{Config: outputCfg}); err != nil {
+		log.Errorf("Failed to reload the output: %v", err)
+	}
+}
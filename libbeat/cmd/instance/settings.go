@@ -20,11 +20,14 @@ package instance
 import (
 	"github.com/spf13/pflag"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
 	"github.com/elastic/beats/v7/libbeat/idxmgmt"
 	"github.com/elastic/beats/v7/libbeat/idxmgmt/lifecycle"
 	"github.com/elastic/beats/v7/libbeat/monitoring/report"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
+	"github.com/elastic/elastic-agent-libs/config"
 )
 
 // Settings contains basic settings for any beat to pass into GenRootCmd
@@ -53,4 +56,8 @@ type Settings struct {
 
 	// Initialize functions that are called in-order to initialize unique items for the beat.
 	Initialize []func()
+
+	// FIPSInputs returns the FIPS capability of the inputs of the beat
+	// configuration. The config object will be the beat configuration section.
+	FIPSInputs func(info beat.Info, beatConfig *config.C) ([]fips.Component, error)
 }
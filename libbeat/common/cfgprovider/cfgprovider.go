@@ -0,0 +1,346 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package cfgprovider resolves configuration variables from external
+// providers. References of the form ${<provider>:<reference>} are replaced by
+// placeholders when a configuration file is read and resolved against the
+// configured providers when the configuration is unpacked.
+package cfgprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ucfg "github.com/elastic/go-ucfg"
+	"github.com/elastic/go-ucfg/parse"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// placeholderPrefix is the prefix of the variable names references are
+// rewritten to. The names can not contain the provider separator because
+// go-ucfg would treat it as the start of a default value.
+const placeholderPrefix = "config_provider_ref_"
+
+// Provider fetches the value of a reference.
+type Provider interface {
+	Fetch(ctx context.Context, ref string) (string, error)
+}
+
+// Factory creates a provider from its configuration. The configuration is
+// empty if the provider has no section under config_providers.
+type Factory func(cfg *config.C) (Provider, error)
+
+// Config is the config_providers section of the Beat configuration. Every
+// other key is the configuration of the provider with the same name.
+type Config struct {
+	RefreshInterval time.Duration `config:"refresh_interval"`
+	Timeout         time.Duration `config:"timeout" validate:"positive"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		RefreshInterval: 0,
+		Timeout:         30 * time.Second,
+	}
+}
+
+type reference struct {
+	provider string
+	ref      string
+	value    string
+	resolved bool
+}
+
+type registry struct {
+	mu        sync.Mutex
+	factories map[string]Factory
+	providers map[string]Provider
+	config    Config
+	refs      []*reference
+	index     map[string]int
+	pattern   *regexp.Regexp
+}
+
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+	return &registry{
+		factories: map[string]Factory{},
+		providers: map[string]Provider{},
+		config:    defaultConfig(),
+		index:     map[string]int{},
+	}
+}
+
+// Register registers a provider factory under the given name. It panics if
+// a provider with the same name is already registered.
+func Register(name string, factory Factory) {
+	if err := defaultRegistry.register(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Rewrite replaces all references to registered providers in a raw
+// configuration file with placeholders that are resolved by Resolve.
+func Rewrite(raw []byte) []byte {
+	return defaultRegistry.rewrite(raw)
+}
+
+// Configure creates the providers from the config_providers section of the
+// Beat configuration. Providers without a section are created with an empty
+// configuration when they are referenced.
+func Configure(cfg *config.C) error {
+	return defaultRegistry.configure(cfg)
+}
+
+// Resolve is a go-ucfg resolver for the placeholders created by Rewrite.
+func Resolve(name string) (string, parse.Config, error) {
+	return defaultRegistry.resolve(name)
+}
+
+// Watch fetches all resolved references every refresh_interval and calls
+// onChange once if any of the values changed. It returns when the context
+// is cancelled, when onChange was called or right away if refreshing is
+// disabled.
+func Watch(ctx context.Context, onChange func()) {
+	defaultRegistry.watch(ctx, onChange)
+}
+
+// RefreshEnabled returns true if the resolved references are refreshed
+// periodically.
+func RefreshEnabled() bool {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+	return defaultRegistry.config.RefreshInterval > 0
+}
+
+func (r *registry) register(name string, factory Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name == "" || strings.ContainsAny(name, ":${}") {
+		return fmt.Errorf("invalid config provider name '%s'", name)
+	}
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("config provider '%s' is already registered", name)
+	}
+	r.factories[name] = factory
+	r.pattern = nil
+	return nil
+}
+
+func (r *registry) rewrite(raw []byte) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.factories) == 0 {
+		return raw
+	}
+	if r.pattern == nil {
+		names := make([]string, 0, len(r.factories))
+		for name := range r.factories {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+		sort.Strings(names)
+		r.pattern = regexp.MustCompile(`\$\{(` + strings.Join(names, "|") + `):([^}]+)\}`)
+	}
+
+	return r.pattern.ReplaceAllFunc(raw, func(match []byte) []byte {
+		parts := r.pattern.FindSubmatch(match)
+		key := string(parts[1]) + ":" + string(parts[2])
+		idx, exists := r.index[key]
+		if !exists {
+			idx = len(r.refs)
+			r.index[key] = idx
+			r.refs = append(r.refs, &reference{provider: string(parts[1]), ref: string(parts[2])})
+		}
+		return []byte("${" + placeholderPrefix + strconv.Itoa(idx) + "}")
+	})
+}
+
+func (r *registry) configure(cfg *config.C) error {
+	c := defaultConfig()
+	providers := map[string]Provider{}
+	if cfg != nil && cfg.HasField("config_providers") {
+		// The lock is not held while unpacking, the provider settings can
+		// reference other providers.
+		sub, err := cfg.Child("config_providers", -1)
+		if err != nil {
+			return err
+		}
+		if err := sub.Unpack(&c); err != nil {
+			return fmt.Errorf("error unpacking config_providers: %w", err)
+		}
+		for name, factory := range r.registered() {
+			if !sub.HasField(name) {
+				continue
+			}
+			providerCfg, err := sub.Child(name, -1)
+			if err != nil {
+				return fmt.Errorf("error reading config provider '%s': %w", name, err)
+			}
+			p, err := factory(providerCfg)
+			if err != nil {
+				return fmt.Errorf("error creating config provider '%s': %w", name, err)
+			}
+			providers[name] = p
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, p := range providers {
+		r.providers[name] = p
+	}
+	r.config = c
+	return nil
+}
+
+func (r *registry) registered() map[string]Factory {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	factories := make(map[string]Factory, len(r.factories))
+	for name, factory := range r.factories {
+		factories[name] = factory
+	}
+	return factories
+}
+
+func (r *registry) resolve(name string) (string, parse.Config, error) {
+	if !strings.HasPrefix(name, placeholderPrefix) {
+		return "", parse.NoopConfig, ucfg.ErrMissing
+	}
+	idx, err := strconv.Atoi(strings.TrimPrefix(name, placeholderPrefix))
+	if err != nil {
+		return "", parse.NoopConfig, ucfg.ErrMissing
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if idx < 0 || idx >= len(r.refs) {
+		return "", parse.NoopConfig, ucfg.ErrMissing
+	}
+	ref := r.refs[idx]
+	if !ref.resolved {
+		value, err := r.fetch(ref)
+		if err != nil {
+			return "", parse.NoopConfig, err
+		}
+		ref.value = value
+		ref.resolved = true
+	}
+	return ref.value, parse.DefaultConfig, nil
+}
+
+// fetch must be called with r.mu held.
+func (r *registry) fetch(ref *reference) (string, error) {
+	p, err := r.provider(ref.provider)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+	value, err := p.Fetch(ctx, ref.ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch '%s' from config provider '%s': %w", ref.ref, ref.provider, err)
+	}
+	return value, nil
+}
+
+// provider must be called with r.mu held.
+func (r *registry) provider(name string) (Provider, error) {
+	if p, exists := r.providers[name]; exists {
+		return p, nil
+	}
+	factory, exists := r.factories[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown config provider '%s'", name)
+	}
+	p, err := factory(config.NewConfig())
+	if err != nil {
+		return nil, fmt.Errorf("error creating config provider '%s': %w", name, err)
+	}
+	r.providers[name] = p
+	return p, nil
+}
+
+func (r *registry) watch(ctx context.Context, onChange func()) {
+	r.mu.Lock()
+	interval := r.config.RefreshInterval
+	r.mu.Unlock()
+	if interval <= 0 {
+		return
+	}
+
+	log := logp.NewLogger("config_providers")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.refresh()
+		if err != nil {
+			log.Warnf("failed to refresh config provider references: %v", err)
+		}
+		if len(changed) > 0 {
+			log.Infof("config provider references changed: %v", changed)
+			onChange()
+			return
+		}
+	}
+}
+
+// refresh fetches all resolved references again and returns the references
+// whose values changed. References that can not be fetched keep their value.
+func (r *registry) refresh() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		changed []string
+		errs    []error
+	)
+	for _, ref := range r.refs {
+		if !ref.resolved {
+			continue
+		}
+		value, err := r.fetch(ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if value != ref.value {
+			// Only the reference is logged, never the value.
+			changed = append(changed, ref.provider+":"+ref.ref)
+		}
+	}
+	return changed, errors.Join(errs...)
+}
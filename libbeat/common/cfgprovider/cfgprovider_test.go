@@ -0,0 +1,242 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ucfg "github.com/elastic/go-ucfg"
+	"github.com/elastic/go-ucfg/yaml"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+type mapProvider map[string]string
+
+func (p mapProvider) Fetch(_ context.Context, ref string) (string, error) {
+	v, ok := p[ref]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return v, nil
+}
+
+func TestRewriteAndResolve(t *testing.T) {
+	values := mapProvider{"db/password": "s3cret", "port": "9200"}
+	r := newRegistry()
+	require.NoError(t, r.register("test", func(*config.C) (Provider, error) { return values, nil }))
+
+	raw := r.rewrite([]byte(`
+password: ${test:db/password}
+again: ${test:db/password}
+port: ${test:port}
+env: ${HOME:/root}
+`))
+	assert.NotContains(t, string(raw), "test:")
+	assert.Contains(t, string(raw), "${HOME:/root}")
+	assert.Len(t, r.refs, 2)
+
+	c, err := yaml.NewConfig(raw, ucfg.PathSep("."), ucfg.Resolve(r.resolve), ucfg.VarExp)
+	require.NoError(t, err)
+
+	var settings struct {
+		Password string `config:"password"`
+		Again    string `config:"again"`
+		Port     int    `config:"port"`
+	}
+	require.NoError(t, c.Unpack(&settings, ucfg.PathSep("."), ucfg.Resolve(r.resolve), ucfg.VarExp))
+	assert.Equal(t, "s3cret", settings.Password)
+	assert.Equal(t, "s3cret", settings.Again)
+	assert.Equal(t, 9200, settings.Port)
+
+	_, _, err = r.resolve("unrelated")
+	assert.ErrorIs(t, err, ucfg.ErrMissing)
+}
+
+func TestResolveError(t *testing.T) {
+	r := newRegistry()
+	require.NoError(t, r.register("test", func(*config.C) (Provider, error) { return mapProvider{}, nil }))
+	r.rewrite([]byte("a: ${test:missing}"))
+
+	_, _, err := r.resolve(placeholderPrefix + "0")
+	assert.ErrorContains(t, err, "failed to fetch 'missing' from config provider 'test'")
+}
+
+func TestRefresh(t *testing.T) {
+	values := mapProvider{"a": "1", "b": "2"}
+	r := newRegistry()
+	require.NoError(t, r.register("test", func(*config.C) (Provider, error) { return values, nil }))
+	r.rewrite([]byte("a: ${test:a}\nb: ${test:b}"))
+
+	_, _, err := r.resolve(placeholderPrefix + "0")
+	require.NoError(t, err)
+
+	changed, err := r.refresh()
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// Unresolved references are not refreshed.
+	values["a"] = "changed"
+	values["b"] = "changed"
+	changed, err = r.refresh()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test:a"}, changed)
+}
+
+func TestConfigure(t *testing.T) {
+	var got string
+	r := newRegistry()
+	require.NoError(t, r.register("test", func(cfg *config.C) (Provider, error) {
+		var c struct {
+			Name string `config:"name"`
+		}
+		err := cfg.Unpack(&c)
+		got = c.Name
+		return mapProvider{}, err
+	}))
+
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"config_providers": map[string]interface{}{
+			"refresh_interval": "5m",
+			"test.name":        "configured",
+		},
+	})
+	require.NoError(t, r.configure(cfg))
+	assert.Equal(t, "configured", got)
+	assert.Contains(t, r.providers, "test")
+	assert.Equal(t, "5m0s", r.config.RefreshInterval.String())
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	r := newRegistry()
+	factory := func(*config.C) (Provider, error) { return mapProvider{}, nil }
+	require.NoError(t, r.register("test", factory))
+	assert.Error(t, r.register("test", factory))
+	assert.Error(t, r.register("a:b", factory))
+}
+
+func TestEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.env")
+	second := filepath.Join(dir, "second.env")
+	require.NoError(t, os.WriteFile(first, []byte(`
+# comment
+export HOST=localhost
+PASSWORD="quoted \"value\""
+TOKEN='single'
+`), 0o600))
+	require.NoError(t, os.WriteFile(second, []byte("HOST=override\n"), 0o600))
+
+	p, err := newEnvFile(config.MustNewConfigFrom(map[string]interface{}{
+		"paths": []string{first, second},
+	}))
+	require.NoError(t, err)
+
+	for ref, want := range map[string]string{
+		"HOST":     "override",
+		"PASSWORD": `quoted "value"`,
+		"TOKEN":    "single",
+	} {
+		got, err := p.Fetch(context.Background(), ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, want, got, ref)
+	}
+
+	_, err = p.Fetch(context.Background(), "MISSING")
+	assert.ErrorContains(t, err, "key 'MISSING' not found")
+
+	_, err = newEnvFile(config.NewConfig())
+	assert.Error(t, err)
+}
+
+func TestVault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/app/db":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"s3cret","port":5432},"metadata":{"version":1}}}`))
+		case "/v1/kv/app/db":
+			_, _ = w.Write([]byte(`{"data":{"password":"v1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	newProvider := func(t *testing.T, settings map[string]interface{}) Provider {
+		t.Helper()
+		settings["address"] = srv.URL
+		p, err := newVault(config.MustNewConfigFrom(settings))
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("kv v2", func(t *testing.T) {
+		p := newProvider(t, map[string]interface{}{"token": "root"})
+
+		got, err := p.Fetch(context.Background(), "secret/app/db#password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", got)
+
+		got, err = p.Fetch(context.Background(), "secret/app/db#port")
+		require.NoError(t, err)
+		assert.Equal(t, "5432", got)
+
+		_, err = p.Fetch(context.Background(), "secret/app/db#missing")
+		assert.ErrorContains(t, err, "key 'missing' not found")
+
+		_, err = p.Fetch(context.Background(), "secret/app/db")
+		assert.ErrorContains(t, err, "<path>#<key>")
+	})
+
+	t.Run("kv v1", func(t *testing.T) {
+		p := newProvider(t, map[string]interface{}{"token": "root", "kv_version": 1})
+
+		got, err := p.Fetch(context.Background(), "kv/app/db#password")
+		require.NoError(t, err)
+		assert.Equal(t, "v1-secret", got)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		p := newProvider(t, map[string]interface{}{"token": "wrong"})
+
+		_, err := p.Fetch(context.Background(), "secret/app/db#password")
+		assert.ErrorContains(t, err, "unexpected status code 403")
+	})
+
+	t.Run("token from environment", func(t *testing.T) {
+		t.Setenv("VAULT_TOKEN", "root")
+		p := newProvider(t, map[string]interface{}{})
+
+		got, err := p.Fetch(context.Background(), "secret/app/db#password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", got)
+	})
+}
@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgprovider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func init() {
+	Register("env_file", newEnvFile)
+}
+
+// envFile resolves references against KEY=VALUE files. Files are read on
+// every fetch so changes are picked up when references are refreshed. Later
+// files take precedence over earlier ones.
+type envFile struct {
+	paths []string
+}
+
+type envFileConfig struct {
+	Paths []string `config:"paths" validate:"required"`
+}
+
+func newEnvFile(cfg *config.C) (Provider, error) {
+	var c envFileConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	return &envFile{paths: c.Paths}, nil
+}
+
+func (p *envFile) Fetch(_ context.Context, ref string) (string, error) {
+	var (
+		value string
+		found bool
+	)
+	for _, path := range p.paths {
+		vars, err := readEnvFile(path)
+		if err != nil {
+			return "", err
+		}
+		if v, ok := vars[ref]; ok {
+			value, found = v, true
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("key '%s' not found in %v", ref, p.paths)
+	}
+	return value, nil
+}
+
+// readEnvFile parses a file with one KEY=VALUE pair per line. Empty lines and
+// lines starting with # are ignored, an optional export prefix is stripped
+// and values can be quoted.
+func readEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	vars := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: missing '='", path, n)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("%s:%d: empty key", path, n)
+		}
+		value, err = unquoteEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		vars[key] = value
+	}
+	return vars, scanner.Err()
+}
+
+func unquoteEnvValue(value string) (string, error) {
+	if len(value) < 2 {
+		return value, nil
+	}
+	switch value[0] {
+	case '"':
+		if value[len(value)-1] != '"' {
+			return "", errors.New("unterminated double quoted value")
+		}
+		return strconv.Unquote(value)
+	case '\'':
+		if value[len(value)-1] != '\'' {
+			return "", errors.New("unterminated single quoted value")
+		}
+		return value[1 : len(value)-1], nil
+	}
+	return value, nil
+}
@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+func init() {
+	Register("vault", newVault)
+}
+
+// vault resolves references of the form <mount>/<path>#<key> against the
+// HashiCorp Vault KV secrets engine.
+type vault struct {
+	address   *url.URL
+	token     string
+	namespace string
+	kvVersion int
+	client    *http.Client
+}
+
+type vaultConfig struct {
+	Address   string                           `config:"address"`
+	Token     string                           `config:"token"`
+	Namespace string                           `config:"namespace"`
+	KVVersion int                              `config:"kv_version" validate:"min=1,max=2"`
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+func newVault(cfg *config.C) (Provider, error) {
+	c := vaultConfig{
+		KVVersion: 2,
+		Transport: httpcommon.DefaultHTTPTransportSettings(),
+	}
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	// Fall back to the environment variables used by the Vault CLI.
+	if c.Address == "" {
+		c.Address = os.Getenv("VAULT_ADDR")
+	}
+	if c.Token == "" {
+		c.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if c.Namespace == "" {
+		c.Namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	if c.Address == "" {
+		return nil, errors.New("address is required")
+	}
+	if c.Token == "" {
+		return nil, errors.New("token is required")
+	}
+
+	address, err := url.Parse(c.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	client, err := c.Transport.Client()
+	if err != nil {
+		return nil, err
+	}
+	return &vault{
+		address:   address,
+		token:     c.Token,
+		namespace: c.Namespace,
+		kvVersion: c.KVVersion,
+		client:    client,
+	}, nil
+}
+
+func (p *vault) Fetch(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || key == "" {
+		return "", fmt.Errorf("reference '%s' must be of the form <path>#<key>", ref)
+	}
+	mount, secret, ok := strings.Cut(strings.Trim(path, "/"), "/")
+	if !ok || secret == "" {
+		return "", fmt.Errorf("reference '%s' must include the secrets engine mount", ref)
+	}
+	if p.kvVersion == 2 {
+		secret = "data/" + secret
+	}
+
+	u := p.address.JoinPath("v1", mount, secret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("unexpected status code %d reading secret '%s'", resp.StatusCode, path)
+	}
+
+	var body struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("error decoding secret '%s': %w", path, err)
+	}
+	data := body.Data
+	if p.kvVersion == 2 {
+		// KV version 2 nests the secret and its metadata under data.
+		raw, exists := data["data"]
+		if !exists {
+			return "", fmt.Errorf("secret '%s' has no data", path)
+		}
+		data = nil
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return "", fmt.Errorf("error decoding secret '%s': %w", path, err)
+		}
+	}
+
+	raw, exists := data[key]
+	if !exists {
+		return "", fmt.Errorf("key '%s' not found in secret '%s'", key, path)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		// Non-string values are used as they are encoded.
+		return string(raw), nil
+	}
+	return value, nil
+}
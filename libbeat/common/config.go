@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/elastic/beats/v7/libbeat/common/cfgprovider"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/file"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -83,7 +84,15 @@ func LoadFile(path string) (*config.C, error) {
 		}
 	}
 
-	c, err := yaml.NewConfigWithFile(path, configOpts...)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// References to external config providers are rewritten before parsing,
+	// go-ucfg would otherwise read the provider name as a variable name.
+	opts := append([]ucfg.Option{ucfg.MetaData(ucfg.Meta{Source: path})}, configOpts...)
+	c, err := yaml.NewConfig(cfgprovider.Rewrite(raw), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,202 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package fips reports whether the inputs, outputs and processors of a beat
+// configuration can run in FIPS mode.
+package fips
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// Kind is the kind of a configured component.
+type Kind string
+
+const (
+	KindInput     Kind = "input"
+	KindOutput    Kind = "output"
+	KindProcessor Kind = "processor"
+)
+
+// Status is the FIPS capability of a configured component.
+type Status string
+
+const (
+	// StatusCapable is the status of components whose configuration only
+	// uses FIPS approved algorithms.
+	StatusCapable Status = "capable"
+	// StatusIncapable is the status of components whose configuration
+	// uses algorithms that are not FIPS approved.
+	StatusIncapable Status = "incapable"
+	// StatusUnknown is the status of components that don't report their
+	// FIPS capability.
+	StatusUnknown Status = "unknown"
+)
+
+// Component is the FIPS capability of a configured component.
+type Component struct {
+	Kind   Kind   `json:"kind"`
+	Type   string `json:"type"`
+	ID     string `json:"id,omitempty"`
+	Status Status `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ErrUnknownCapability is returned by FIPSCapable when a component wrapping
+// another one can't tell the FIPS capability of the wrapped component.
+var ErrUnknownCapability = errors.New("FIPS capability is unknown")
+
+// Aware is implemented by the configured components that can tell whether
+// they can run in FIPS mode.
+type Aware interface {
+	// FIPSCapable returns an error describing why the component can't run in
+	// FIPS mode, or nil if it can.
+	FIPSCapable() error
+}
+
+// Checker returns an error describing why a component configured with cfg
+// can't run in FIPS mode, or nil if it can.
+type Checker func(cfg *conf.C) error
+
+var (
+	checkersMu sync.RWMutex
+	checkers   = map[Kind]map[string]Checker{}
+)
+
+// Register registers the checker of the components of the given kind and
+// type. Components without a checker have an unknown FIPS capability.
+func Register(kind Kind, typ string, check Checker) {
+	checkersMu.Lock()
+	defer checkersMu.Unlock()
+	if checkers[kind] == nil {
+		checkers[kind] = map[string]Checker{}
+	}
+	if _, exists := checkers[kind][typ]; exists {
+		panic(fmt.Sprintf("FIPS checker of %s %s is already registered", kind, typ))
+	}
+	checkers[kind][typ] = check
+}
+
+// RegisterCapable registers the components of the given kind and types as
+// FIPS capable whatever their configuration, apart from their TLS settings.
+func RegisterCapable(kind Kind, types ...string) {
+	for _, typ := range types {
+		Register(kind, typ, func(*conf.C) error { return nil })
+	}
+}
+
+func lookup(kind Kind, typ string) (Checker, bool) {
+	checkersMu.RLock()
+	defer checkersMu.RUnlock()
+	check, ok := checkers[kind][typ]
+	return check, ok
+}
+
+// Check returns the FIPS capability of a component from its configuration.
+// The TLS settings of the component are checked first, then the checker
+// registered for the component, if any.
+func Check(kind Kind, typ, id string, cfg *conf.C) Component {
+	c := Component{Kind: kind, Type: typ, ID: id, Status: StatusUnknown}
+	if err := CheckTLS(cfg); err != nil {
+		c.Status, c.Reason = StatusIncapable, err.Error()
+		return c
+	}
+	check, ok := lookup(kind, typ)
+	if !ok {
+		return c
+	}
+	if err := check(cfg); err != nil {
+		c.Status, c.Reason = StatusIncapable, err.Error()
+		return c
+	}
+	c.Status = StatusCapable
+	return c
+}
+
+// CheckAware returns the FIPS capability reported by a configured component.
+func CheckAware(kind Kind, typ, id string, a Aware) Component {
+	c := Component{Kind: kind, Type: typ, ID: id, Status: StatusCapable}
+	switch err := a.FIPSCapable(); {
+	case errors.Is(err, ErrUnknownCapability):
+		c.Status = StatusUnknown
+	case err != nil:
+		c.Status, c.Reason = StatusIncapable, err.Error()
+	}
+	return c
+}
+
+// CheckProcessors returns the FIPS capability of a list of processors. The
+// processors of if/then/else processors are checked in turn. id is set as the
+// ID of the processors, to tell apart the processors of different inputs.
+func CheckProcessors(id string, cfgs []*conf.C) ([]Component, error) {
+	var components []Component
+	for _, cfg := range cfgs {
+		if cfg.HasField("if") {
+			nested, err := checkConditional(id, cfg)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, nested...)
+			continue
+		}
+		fields := cfg.GetFields()
+		sort.Strings(fields)
+		for _, name := range fields {
+			sub, err := cfg.Child(name, -1)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the configuration of processor %s: %w", name, err)
+			}
+			components = append(components, Check(KindProcessor, name, id, sub))
+		}
+	}
+	return components, nil
+}
+
+// checkConditional checks the processors of the branches of an if/then/else
+// processor.
+func checkConditional(id string, cfg *conf.C) ([]Component, error) {
+	var conditional struct {
+		Then   []*conf.C `config:"then"`
+		ElseIf []struct {
+			Then []*conf.C `config:"then"`
+		} `config:"else_if"`
+		Else []*conf.C `config:"else"`
+	}
+	if err := cfg.Unpack(&conditional); err != nil {
+		return nil, fmt.Errorf("failed to read the configuration of if/then/else processor: %w", err)
+	}
+	branches := [][]*conf.C{conditional.Then}
+	for _, b := range conditional.ElseIf {
+		branches = append(branches, b.Then)
+	}
+	branches = append(branches, conditional.Else)
+
+	var components []Component
+	for _, b := range branches {
+		nested, err := CheckProcessors(id, b)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, nested...)
+	}
+	return components, nil
+}
@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fips
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func init() {
+	RegisterCapable(KindProcessor, "test_capable")
+	Register(KindProcessor, "test_checked", func(cfg *conf.C) error {
+		var config struct {
+			Method string `config:"method"`
+		}
+		if err := cfg.Unpack(&config); err != nil {
+			return err
+		}
+		if config.Method == "md5" {
+			return errors.New("md5 is not FIPS approved")
+		}
+		return nil
+	})
+}
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		typ    string
+		config string
+		status Status
+		reason string
+	}{
+		"capable": {
+			typ:    "test_capable",
+			config: "target: foo",
+			status: StatusCapable,
+		},
+		"checker ok": {
+			typ:    "test_checked",
+			config: "method: sha256",
+			status: StatusCapable,
+		},
+		"checker fails": {
+			typ:    "test_checked",
+			config: "method: md5",
+			status: StatusIncapable,
+			reason: "md5 is not FIPS approved",
+		},
+		"unknown": {
+			typ:    "test_unregistered",
+			config: "target: foo",
+			status: StatusUnknown,
+		},
+		"unapproved protocol": {
+			typ:    "test_unregistered",
+			config: "ssl.supported_protocols: [TLSv1.1, TLSv1.2]",
+			status: StatusIncapable,
+			reason: "ssl.supported_protocols: TLSv1.1 is not FIPS approved",
+		},
+		"unapproved cipher": {
+			typ:    "test_capable",
+			config: "ssl.cipher_suites: [ECDHE-ECDSA-AES-128-GCM-SHA256, ECDHE-RSA-CHACHA20-POLY1305]",
+			status: StatusIncapable,
+			reason: "ssl.cipher_suites: ECDHE-RSA-CHACHA20-POLY1305 is not FIPS approved",
+		},
+		"unapproved curve": {
+			typ:    "test_capable",
+			config: "ssl.curve_types: [P-256, X25519]",
+			status: StatusIncapable,
+			reason: "ssl.curve_types: X25519 is not FIPS approved",
+		},
+		"ssl disabled": {
+			typ:    "test_capable",
+			config: "ssl: {enabled: false, supported_protocols: [TLSv1.0]}",
+			status: StatusCapable,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := Check(KindProcessor, test.typ, "", conf.MustNewConfigFrom(test.config))
+			assert.Equal(t, test.status, c.Status)
+			assert.Equal(t, test.reason, c.Reason)
+		})
+	}
+}
+
+func TestCheckProcessors(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(`
+processors:
+  - test_capable: {target: foo}
+  - if.equals.foo: bar
+    then:
+      - test_checked: {method: md5}
+    else_if:
+      - if.equals.foo: baz
+        then:
+          - test_unregistered: {}
+    else:
+      - test_checked: {method: sha256}
+`)
+	var config struct {
+		Processors []*conf.C `config:"processors"`
+	}
+	require.NoError(t, cfg.Unpack(&config))
+
+	components, err := CheckProcessors("my-input", config.Processors)
+	require.NoError(t, err)
+
+	var got []string
+	for _, c := range components {
+		assert.Equal(t, KindProcessor, c.Kind)
+		assert.Equal(t, "my-input", c.ID)
+		got = append(got, c.Type+":"+string(c.Status))
+	}
+	assert.Equal(t, []string{
+		"test_capable:capable",
+		"test_checked:incapable",
+		"test_unregistered:unknown",
+		"test_checked:capable",
+	}, got)
+}
+
+func TestReportCapable(t *testing.T) {
+	r := Report{Components: []Component{
+		{Kind: KindOutput, Type: "elasticsearch", Status: StatusCapable},
+		{Kind: KindInput, Type: "filestream", Status: StatusUnknown},
+	}}
+	assert.True(t, r.Capable(false))
+	assert.False(t, r.Capable(true))
+
+	r.Components = append(r.Components, Component{Kind: KindProcessor, Type: "fingerprint", Status: StatusIncapable})
+	assert.False(t, r.Capable(false))
+	assert.Equal(t, 1, r.Count(StatusIncapable))
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fips
+
+import (
+	"strconv"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// Report is the FIPS capability of the components of a beat configuration.
+type Report struct {
+	// Enabled tells if the beat is built to run in FIPS mode.
+	Enabled    bool
+	Components []Component
+}
+
+// Capable returns true if no component is FIPS incapable. If strict is true,
+// the components with an unknown capability are considered incapable.
+func (r Report) Capable(strict bool) bool {
+	for _, c := range r.Components {
+		if c.Status == StatusIncapable || (strict && c.Status == StatusUnknown) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of components with the given status.
+func (r Report) Count(status Status) int {
+	n := 0
+	for _, c := range r.Components {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// ReportMetrics reports the FIPS capability of the components, grouped by
+// kind and keyed by their position in the configuration. It is meant to be
+// registered with monitoring.NewFunc.
+func (r Report) ReportMetrics(_ monitoring.Mode, V monitoring.Visitor) {
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	monitoring.ReportBool(V, "enabled", r.Enabled)
+	monitoring.ReportBool(V, "capable", r.Capable(false))
+	for _, kind := range []Kind{KindInput, KindOutput, KindProcessor} {
+		monitoring.ReportNamespace(V, string(kind)+"s", func() {
+			i := 0
+			for _, c := range r.Components {
+				if c.Kind != kind {
+					continue
+				}
+				monitoring.ReportNamespace(V, strconv.Itoa(i), func() {
+					monitoring.ReportString(V, "type", c.Type)
+					if c.ID != "" {
+						monitoring.ReportString(V, "id", c.ID)
+					}
+					monitoring.ReportString(V, "status", string(c.Status))
+					if c.Reason != "" {
+						monitoring.ReportString(V, "reason", c.Reason)
+					}
+				})
+				i++
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fips
+
+import (
+	"fmt"
+	"strings"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// unapprovedProtocols are the TLS versions that are not FIPS approved.
+var unapprovedProtocols = map[string]bool{
+	"SSLv3":   true,
+	"TLSv1.0": true,
+	"TLSv1.1": true,
+}
+
+// unapprovedCiphers are the parts of the names of the cipher suites using
+// algorithms that are not FIPS approved.
+var unapprovedCiphers = []string{"CHACHA20", "RC4", "3DES"}
+
+// unapprovedCurves are the elliptic curves that are not FIPS approved.
+var unapprovedCurves = map[string]bool{
+	"X25519": true,
+}
+
+// CheckTLS returns an error if the ssl settings of a component configuration
+// enable protocols, cipher suites or curves that are not FIPS approved.
+func CheckTLS(cfg *conf.C) error {
+	if cfg == nil || !cfg.HasField("ssl") {
+		return nil
+	}
+	var settings struct {
+		SSL struct {
+			Enabled            *bool    `config:"enabled"`
+			SupportedProtocols []string `config:"supported_protocols"`
+			CipherSuites       []string `config:"cipher_suites"`
+			CurveTypes         []string `config:"curve_types"`
+		} `config:"ssl"`
+	}
+	if err := cfg.Unpack(&settings); err != nil {
+		return fmt.Errorf("failed to read ssl settings: %w", err)
+	}
+	ssl := settings.SSL
+	if ssl.Enabled != nil && !*ssl.Enabled {
+		return nil
+	}
+	for _, p := range ssl.SupportedProtocols {
+		if unapprovedProtocols[p] {
+			return fmt.Errorf("ssl.supported_protocols: %s is not FIPS approved", p)
+		}
+	}
+	for _, c := range ssl.CipherSuites {
+		for _, u := range unapprovedCiphers {
+			if strings.Contains(strings.ToUpper(c), u) {
+				return fmt.Errorf("ssl.cipher_suites: %s is not FIPS approved", c)
+			}
+		}
+	}
+	for _, c := range ssl.CurveTypes {
+		if unapprovedCurves[c] {
+			return fmt.Errorf("ssl.curve_types: %s is not FIPS approved", c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jsonparse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+)
+
+// defaultMaxNesting matches the nesting limit of encoding/json.
+const defaultMaxNesting = 10000
+
+// Config holds the limits applied when decoding JSON documents. The zero
+// value is valid and behaves like encoding/json.
+type Config struct {
+	MaxNesting    int                `config:"max_nesting" validate:"min=0"`
+	MaxSize       cfgtype.ByteSize   `config:"max_size" validate:"min=0"`
+	DuplicateKeys DuplicateKeyPolicy `config:"duplicate_keys"`
+}
+
+// DuplicateKeyPolicy selects how repeated keys within a JSON object are
+// handled.
+type DuplicateKeyPolicy uint8
+
+// List of duplicate key policies.
+const (
+	// DuplicateKeysLast keeps the last value, like encoding/json does.
+	DuplicateKeysLast DuplicateKeyPolicy = iota
+	// DuplicateKeysFirst keeps the first value and ignores all others.
+	DuplicateKeysFirst
+	// DuplicateKeysError fails decoding the document.
+	DuplicateKeysError
+)
+
+var duplicateKeyPolicyNames = map[DuplicateKeyPolicy]string{
+	DuplicateKeysLast:  "last",
+	DuplicateKeysFirst: "first",
+	DuplicateKeysError: "error",
+}
+
+func (p DuplicateKeyPolicy) String() string {
+	return duplicateKeyPolicyNames[p]
+}
+
+func (p DuplicateKeyPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *DuplicateKeyPolicy) Unpack(s string) error {
+	s = strings.ToLower(s)
+	for policy, name := range duplicateKeyPolicyNames {
+		if s == name {
+			*p = policy
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid duplicate_keys policy: %v", s)
+}
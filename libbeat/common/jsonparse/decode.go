@@ -0,0 +1,470 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package jsonparse implements a JSON decoder for event data. It produces the
+// same values as encoding/json with UseNumber followed by
+// jsontransform.TransformNumbers, but does so in a single pass over the input
+// without reflection or intermediate json.Number values.
+package jsonparse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	// ErrMaxSize is returned if the document is larger than the configured
+	// max_size.
+	ErrMaxSize = errors.New("JSON document exceeds the maximum size")
+
+	// ErrMaxNesting is returned if objects and arrays are nested deeper than
+	// the configured max_nesting.
+	ErrMaxNesting = errors.New("JSON document exceeds the maximum nesting depth")
+)
+
+// SyntaxError reports invalid JSON input. Callers that need the exact error
+// messages of encoding/json can fall back to it when they see a SyntaxError.
+type SyntaxError struct {
+	Offset int
+	msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at offset %d", e.msg, e.Offset)
+}
+
+// DuplicateKeyError is returned if an object contains the same key more than
+// once and the decoder is configured with DuplicateKeysError.
+type DuplicateKeyError struct {
+	Key string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key '%s' in JSON object", e.Key)
+}
+
+// Decoder decodes JSON documents into map[string]interface{},
+// []interface{}, string, int64, float64, bool and nil values. A Decoder is
+// safe for concurrent use.
+type Decoder struct {
+	maxNesting    int
+	maxSize       int
+	duplicateKeys DuplicateKeyPolicy
+}
+
+// NewDecoder creates a Decoder applying the limits in config.
+func NewDecoder(config Config) *Decoder {
+	maxNesting := config.MaxNesting
+	if maxNesting <= 0 {
+		maxNesting = defaultMaxNesting
+	}
+	return &Decoder{
+		maxNesting:    maxNesting,
+		maxSize:       int(config.MaxSize),
+		duplicateKeys: config.DuplicateKeys,
+	}
+}
+
+// Decode decodes the first JSON value in data. It returns the value and the
+// number of bytes consumed. Content following the value is not inspected.
+func (d *Decoder) Decode(data []byte) (interface{}, int, error) {
+	if d.maxSize > 0 && len(data) > d.maxSize {
+		return nil, 0, ErrMaxSize
+	}
+
+	s := scanner{dec: d, data: data}
+	s.skipWhitespace()
+	v, err := s.value()
+	if err != nil {
+		return nil, s.pos, err
+	}
+	return v, s.pos, nil
+}
+
+type scanner struct {
+	dec   *Decoder
+	data  []byte
+	pos   int
+	depth int
+}
+
+func (s *scanner) skipWhitespace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *scanner) syntaxError(msg string) error {
+	return &SyntaxError{Offset: s.pos, msg: msg}
+}
+
+func (s *scanner) unexpected() error {
+	if s.pos >= len(s.data) {
+		return s.syntaxError("unexpected end of JSON input")
+	}
+	return s.syntaxError(fmt.Sprintf("invalid character %q", s.data[s.pos]))
+}
+
+func (s *scanner) value() (interface{}, error) {
+	if s.pos >= len(s.data) {
+		return nil, s.unexpected()
+	}
+
+	switch c := s.data[s.pos]; c {
+	case '{':
+		return s.object()
+	case '[':
+		return s.array()
+	case '"':
+		return s.string()
+	case 't':
+		return true, s.literal("true")
+	case 'f':
+		return false, s.literal("false")
+	case 'n':
+		return nil, s.literal("null")
+	default:
+		if c == '-' || (c >= '0' && c <= '9') {
+			return s.number()
+		}
+		return nil, s.unexpected()
+	}
+}
+
+func (s *scanner) literal(lit string) error {
+	if len(s.data)-s.pos < len(lit) || string(s.data[s.pos:s.pos+len(lit)]) != lit {
+		return s.syntaxError("invalid literal")
+	}
+	s.pos += len(lit)
+	return nil
+}
+
+func (s *scanner) enter() error {
+	s.depth++
+	if s.depth > s.dec.maxNesting {
+		return ErrMaxNesting
+	}
+	s.pos++
+	s.skipWhitespace()
+	return nil
+}
+
+func (s *scanner) object() (interface{}, error) {
+	if err := s.enter(); err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	if s.pos < len(s.data) && s.data[s.pos] == '}' {
+		s.pos++
+		s.depth--
+		return m, nil
+	}
+
+	for {
+		if s.pos >= len(s.data) || s.data[s.pos] != '"' {
+			return nil, s.unexpected()
+		}
+		key, err := s.string()
+		if err != nil {
+			return nil, err
+		}
+
+		s.skipWhitespace()
+		if s.pos >= len(s.data) || s.data[s.pos] != ':' {
+			return nil, s.unexpected()
+		}
+		s.pos++
+		s.skipWhitespace()
+
+		v, err := s.value()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, exists := m[key]; !exists || s.dec.duplicateKeys == DuplicateKeysLast {
+			m[key] = v
+		} else if s.dec.duplicateKeys == DuplicateKeysError {
+			return nil, &DuplicateKeyError{Key: key}
+		}
+
+		s.skipWhitespace()
+		if s.pos >= len(s.data) {
+			return nil, s.unexpected()
+		}
+		switch s.data[s.pos] {
+		case ',':
+			s.pos++
+			s.skipWhitespace()
+		case '}':
+			s.pos++
+			s.depth--
+			return m, nil
+		default:
+			return nil, s.unexpected()
+		}
+	}
+}
+
+func (s *scanner) array() (interface{}, error) {
+	if err := s.enter(); err != nil {
+		return nil, err
+	}
+
+	arr := []interface{}{}
+	if s.pos < len(s.data) && s.data[s.pos] == ']' {
+		s.pos++
+		s.depth--
+		return arr, nil
+	}
+
+	for {
+		v, err := s.value()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+
+		s.skipWhitespace()
+		if s.pos >= len(s.data) {
+			return nil, s.unexpected()
+		}
+		switch s.data[s.pos] {
+		case ',':
+			s.pos++
+			s.skipWhitespace()
+		case ']':
+			s.pos++
+			s.depth--
+			return arr, nil
+		default:
+			return nil, s.unexpected()
+		}
+	}
+}
+
+// string decodes the string starting at the current position. Strings
+// without escape sequences or non-ASCII characters are copied directly.
+func (s *scanner) string() (string, error) {
+	s.pos++
+	start := s.pos
+	for i := start; i < len(s.data); i++ {
+		c := s.data[i]
+		if c == '"' {
+			s.pos = i + 1
+			return string(s.data[start:i]), nil
+		}
+		if c == '\\' || c < 0x20 || c >= utf8.RuneSelf {
+			s.pos = i
+			return s.unquote(start)
+		}
+	}
+	s.pos = len(s.data)
+	return "", s.unexpected()
+}
+
+// unquote decodes the remainder of a string that contains escape sequences
+// or non-ASCII characters. Invalid UTF-8 and invalid surrogates are replaced
+// with U+FFFD, like encoding/json does.
+func (s *scanner) unquote(start int) (string, error) {
+	buf := make([]byte, 0, s.pos-start+16)
+	buf = append(buf, s.data[start:s.pos]...)
+
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case c == '"':
+			s.pos++
+			return string(buf), nil
+
+		case c < 0x20:
+			return "", s.unexpected()
+
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRune(s.data[s.pos:])
+			if r == utf8.RuneError && size == 1 {
+				buf = utf8.AppendRune(buf, unicode.ReplacementChar)
+			} else {
+				buf = append(buf, s.data[s.pos:s.pos+size]...)
+			}
+			s.pos += size
+
+		case c == '\\':
+			if s.pos+1 >= len(s.data) {
+				s.pos++
+				return "", s.unexpected()
+			}
+			s.pos++
+			switch esc := s.data[s.pos]; esc {
+			case '"', '\\', '/':
+				buf = append(buf, esc)
+				s.pos++
+			case 'b':
+				buf = append(buf, '\b')
+				s.pos++
+			case 'f':
+				buf = append(buf, '\f')
+				s.pos++
+			case 'n':
+				buf = append(buf, '\n')
+				s.pos++
+			case 'r':
+				buf = append(buf, '\r')
+				s.pos++
+			case 't':
+				buf = append(buf, '\t')
+				s.pos++
+			case 'u':
+				r := getu4(s.data[s.pos-1:])
+				if r < 0 {
+					return "", s.syntaxError("invalid escape sequence")
+				}
+				s.pos += 5
+				if utf16.IsSurrogate(r) {
+					if dec := utf16.DecodeRune(r, getu4(s.data[s.pos:])); dec != unicode.ReplacementChar {
+						r = dec
+						s.pos += 6
+					} else {
+						r = unicode.ReplacementChar
+					}
+				}
+				buf = utf8.AppendRune(buf, r)
+			default:
+				return "", s.syntaxError("invalid escape sequence")
+			}
+
+		default:
+			buf = append(buf, c)
+			s.pos++
+		}
+	}
+	return "", s.unexpected()
+}
+
+// getu4 decodes \uXXXX from the beginning of b. It returns -1 if b does not
+// start with a valid escape.
+func getu4(b []byte) rune {
+	if len(b) < 6 || b[0] != '\\' || b[1] != 'u' {
+		return -1
+	}
+	var r rune
+	for _, c := range b[2:6] {
+		switch {
+		case '0' <= c && c <= '9':
+			c = c - '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return -1
+		}
+		r = r*16 + rune(c)
+	}
+	return r
+}
+
+// number decodes a number into int64 if possible, float64 otherwise. Numbers
+// that fit neither are returned as string, like
+// jsontransform.TransformNumbers does.
+func (s *scanner) number() (interface{}, error) {
+	start := s.pos
+	isFloat := false
+
+	if s.data[s.pos] == '-' {
+		s.pos++
+	}
+	switch {
+	case s.pos < len(s.data) && s.data[s.pos] == '0':
+		s.pos++
+	case s.pos < len(s.data) && s.data[s.pos] >= '1' && s.data[s.pos] <= '9':
+		s.skipDigits()
+	default:
+		return nil, s.unexpected()
+	}
+
+	if s.pos < len(s.data) && s.data[s.pos] == '.' {
+		isFloat = true
+		s.pos++
+		if !s.skipDigits() {
+			return nil, s.unexpected()
+		}
+	}
+
+	if s.pos < len(s.data) && (s.data[s.pos] == 'e' || s.data[s.pos] == 'E') {
+		isFloat = true
+		s.pos++
+		if s.pos < len(s.data) && (s.data[s.pos] == '+' || s.data[s.pos] == '-') {
+			s.pos++
+		}
+		if !s.skipDigits() {
+			return nil, s.unexpected()
+		}
+	}
+
+	num := s.data[start:s.pos]
+	if !isFloat {
+		if i, ok := parseInt(num); ok {
+			return i, nil
+		}
+	}
+	str := string(num)
+	if f, err := strconv.ParseFloat(str, 64); err == nil {
+		return f, nil
+	}
+	return str, nil
+}
+
+func (s *scanner) skipDigits() bool {
+	start := s.pos
+	for s.pos < len(s.data) && s.data[s.pos] >= '0' && s.data[s.pos] <= '9' {
+		s.pos++
+	}
+	return s.pos > start
+}
+
+// parseInt parses an integer that is known to be syntactically valid. It
+// reports false if the value overflows int64.
+func parseInt(b []byte) (int64, bool) {
+	digits := b
+	if b[0] == '-' {
+		digits = b[1:]
+	}
+	// 18 digits always fit into an int64.
+	if len(digits) > 18 {
+		i, err := strconv.ParseInt(string(b), 10, 64)
+		return i, err == nil
+	}
+
+	var i int64
+	for _, c := range digits {
+		i = i*10 + int64(c-'0')
+	}
+	if len(digits) < len(b) {
+		i = -i
+	}
+	return i, true
+}
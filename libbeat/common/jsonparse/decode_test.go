@@ -0,0 +1,261 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jsonparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestDecodeMatchesEncodingJSON(t *testing.T) {
+	inputs := []string{
+		`{}`,
+		`[]`,
+		`null`,
+		`true`,
+		`"text"`,
+		`{"a": 1, "b": -2, "c": 1.5, "d": -0.25e3, "e": 1E2, "f": 0}`,
+		`{"max": 9223372036854775807, "min": -9223372036854775808}`,
+		`{"overflow": 9223372036854775808, "huge": 1e400, "long": 123456789012345678901234567890}`,
+		`{"nested": {"list": [1, "two", {"three": [3.0]}, [], {}], "null": null, "t": true, "f": false}}`,
+		` { "spaced" :	[ 1 ,2 ] }
+`,
+		`{"escapes": "quote \" backslash \\ slash \/ \b\f\n\r\t"}`,
+		`{"unicode": "héllo 世界 😀", "raw": "héllo 世界 😀"}`,
+		`{"lone surrogate": "\ud83d x", "reversed": "\ude00\ud83d", "high high": "\ud83d\ud83d"}`,
+		"{\"invalid utf8\": \"a\xffb\xc3\"}",
+		`{"dup": 1, "dup": 2}`,
+		`{"dup": {"a": 1}, "dup": {"b": 2}}`,
+	}
+
+	dec := NewDecoder(Config{})
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			var expected interface{}
+			d := json.NewDecoder(strings.NewReader(input))
+			d.UseNumber()
+			require.NoError(t, d.Decode(&expected))
+			expected = transformNumbers(expected)
+
+			actual, n, err := dec.Decode([]byte(input))
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, strings.TrimRight(input, " \n"), input[:n])
+		})
+	}
+}
+
+func TestDecodeSyntaxErrors(t *testing.T) {
+	inputs := []string{
+		``,
+		`   `,
+		`{`,
+		`{"a"`,
+		`{"a":`,
+		`{"a":1`,
+		`{"a":1,}`,
+		`{a:1}`,
+		`[1,]`,
+		`[1 2]`,
+		`"unterminated`,
+		"\"control \x01 character\"",
+		`"\x"`,
+		`"\u12"`,
+		`tru`,
+		`nul`,
+		`-`,
+		`01`,
+		`1.`,
+		`1e`,
+		`.5`,
+		`+1`,
+	}
+
+	dec := NewDecoder(Config{})
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			require.Error(t, json.Unmarshal([]byte(input), new(interface{})))
+
+			v, n, err := dec.Decode([]byte(input))
+			if err == nil {
+				// valid prefix, the caller must reject the trailing data
+				assert.NotEqual(t, len(input), n, "decoded %v", v)
+				return
+			}
+			var syntaxErr *SyntaxError
+			assert.ErrorAs(t, err, &syntaxErr)
+		})
+	}
+}
+
+func TestDecodeTrailingData(t *testing.T) {
+	input := []byte(`{"a": 1} {"b": 2}`)
+
+	v, n, err := NewDecoder(Config{}).Decode(input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(1)}, v)
+	assert.Equal(t, ` {"b": 2}`, string(input[n:]))
+}
+
+func TestDecodeLimits(t *testing.T) {
+	t.Run("max size", func(t *testing.T) {
+		dec := NewDecoder(Config{MaxSize: 10})
+		_, _, err := dec.Decode([]byte(`{"a":"b"}`))
+		require.NoError(t, err)
+		_, _, err = dec.Decode([]byte(`{"a":"bcd"}`))
+		assert.ErrorIs(t, err, ErrMaxSize)
+	})
+
+	t.Run("max nesting", func(t *testing.T) {
+		dec := NewDecoder(Config{MaxNesting: 3})
+		_, _, err := dec.Decode([]byte(`{"a":[{}]}`))
+		require.NoError(t, err)
+		_, _, err = dec.Decode([]byte(`{"a":[{"b":[]}]}`))
+		assert.ErrorIs(t, err, ErrMaxNesting)
+	})
+
+	t.Run("default max nesting", func(t *testing.T) {
+		dec := NewDecoder(Config{})
+		input := strings.Repeat("[", defaultMaxNesting) + strings.Repeat("]", defaultMaxNesting)
+		_, _, err := dec.Decode([]byte(input))
+		require.NoError(t, err)
+		_, _, err = dec.Decode([]byte("[" + input + "]"))
+		assert.ErrorIs(t, err, ErrMaxNesting)
+	})
+}
+
+func TestDecodeDuplicateKeys(t *testing.T) {
+	input := []byte(`{"a": 1, "b": {"a": 2, "a": 3}, "a": 4}`)
+
+	tests := map[string]struct {
+		policy   string
+		expected interface{}
+	}{
+		"last": {
+			policy:   "last",
+			expected: map[string]interface{}{"a": int64(4), "b": map[string]interface{}{"a": int64(3)}},
+		},
+		"first": {
+			policy:   "first",
+			expected: map[string]interface{}{"a": int64(1), "b": map[string]interface{}{"a": int64(2)}},
+		},
+		"error": {
+			policy: "error",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var c Config
+			require.NoError(t, config.MustNewConfigFrom(map[string]interface{}{"duplicate_keys": test.policy}).Unpack(&c))
+
+			v, _, err := NewDecoder(c).Decode(input)
+			if test.expected == nil {
+				var dupErr *DuplicateKeyError
+				require.ErrorAs(t, err, &dupErr)
+				assert.Equal(t, "a", dupErr.Key)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestConfigUnpack(t *testing.T) {
+	var c Config
+	err := config.MustNewConfigFrom(map[string]interface{}{
+		"max_nesting": 5,
+		"max_size":    "1KiB",
+	}).Unpack(&c)
+	require.NoError(t, err)
+	assert.Equal(t, Config{MaxNesting: 5, MaxSize: 1024, DuplicateKeys: DuplicateKeysLast}, c)
+
+	err = config.MustNewConfigFrom(map[string]interface{}{"duplicate_keys": "random"}).Unpack(&c)
+	assert.Error(t, err)
+}
+
+func transformNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		jsontransform.TransformNumbers(v)
+	case []interface{}:
+		m := map[string]interface{}{"": v}
+		jsontransform.TransformNumbers(m)
+	case json.Number:
+		m := map[string]interface{}{"": v}
+		jsontransform.TransformNumbers(m)
+		return m[""]
+	}
+	return v
+}
+
+func benchmarkDocument(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"@timestamp":"2024-01-02T03:04:05.678Z","log":{"level":"info","logger":"http"},`)
+	buf.WriteString(`"http":{"request":{"method":"GET","bytes":1234},"response":{"status_code":200,"bytes":56789}},`)
+	buf.WriteString(`"url":{"path":"/api/v1/items","query":"q=café&limit=10"},"duration":0.01234,"tags":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `"tag-%d"`, i)
+	}
+	buf.WriteString(`],"message":"GET /api/v1/items returned 200 in 12.34ms \"ok\""}`)
+	return buf.Bytes()
+}
+
+func BenchmarkDecode(b *testing.B) {
+	for _, size := range []int{1, 100} {
+		doc := benchmarkDocument(size)
+
+		b.Run(fmt.Sprintf("encoding/json/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(len(doc)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var v map[string]interface{}
+				d := json.NewDecoder(bytes.NewReader(doc))
+				d.UseNumber()
+				if err := d.Decode(&v); err != nil {
+					b.Fatal(err)
+				}
+				jsontransform.TransformNumbers(v)
+			}
+		})
+
+		b.Run(fmt.Sprintf("jsonparse/%d", size), func(b *testing.B) {
+			dec := NewDecoder(Config{})
+			b.SetBytes(int64(len(doc)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := dec.Decode(doc); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
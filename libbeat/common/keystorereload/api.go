@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystorereload
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+// maxSecretSize is the maximum size of a value set through the HTTP API.
+const maxSecretSize = 64 * 1024
+
+type changedResponse struct {
+	Changed []string `json:"changed"`
+}
+
+// ReloadHandler returns the HTTP handler reloading the keystore on POST
+// requests. It answers with the keys that changed.
+func (k *Keystore) ReloadHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		changed, err := k.Reload()
+		writeChanged(w, changed, err)
+	})
+}
+
+// SecretsHandler returns the HTTP handler setting the value of a key to the
+// body of PUT requests and removing the key on DELETE requests. The key is
+// read from the key variable of the route.
+func (k *Keystore) SecretsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := mux.Vars(r)["key"]
+		if key == "" {
+			http.Error(w, "missing key", http.StatusBadRequest)
+			return
+		}
+
+		var (
+			changed []string
+			err     error
+		)
+		switch r.Method {
+		case http.MethodPut:
+			var value []byte
+			value, err = io.ReadAll(io.LimitReader(r.Body, maxSecretSize+1))
+			if err != nil {
+				http.Error(w, "could not read the value", http.StatusBadRequest)
+				return
+			}
+			if len(value) == 0 || len(value) > maxSecretSize {
+				http.Error(w, "the value must not be empty or larger than 64KiB", http.StatusBadRequest)
+				return
+			}
+			changed, err = k.Update(key, value)
+		case http.MethodDelete:
+			changed, err = k.Remove(key)
+			if errors.Is(err, keystore.ErrKeyDoesntExists) {
+				http.NotFound(w, r)
+				return
+			}
+		default:
+			w.Header().Set("Allow", http.MethodPut+", "+http.MethodDelete)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeChanged(w, changed, err)
+	})
+}
+
+func writeChanged(w http.ResponseWriter, changed []string, err error) {
+	if err != nil {
+		// The error never contains values from the keystore.
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(changedResponse{Changed: changed})
+}
@@ -0,0 +1,341 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package keystorereload provides a keystore whose values can be updated
+// while the Beat runs. The configurations resolving values from it resolve
+// the new values the next time they are unpacked, and listeners are notified
+// of the keys that changed so that the components depending on them can be
+// reloaded.
+package keystorereload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Config is the keystore.reload section of the Beat configuration.
+type Config struct {
+	// Enabled enables reloading the keystore while the Beat runs.
+	Enabled bool `config:"enabled"`
+	// Period is the interval at which the keystore is read again from its
+	// storage. 0 disables polling, the keystore is then only reloaded through
+	// the HTTP API.
+	Period time.Duration `config:"period" validate:"min=0"`
+	// APIWrites allows adding and removing keys through the HTTP API.
+	APIWrites bool `config:"api_writes"`
+}
+
+// DefaultConfig returns the default configuration of keystore reloading.
+func DefaultConfig() Config {
+	return Config{
+		Enabled: false,
+		Period:  10 * time.Second,
+	}
+}
+
+// Opener opens the keystore from its storage.
+type Opener func() (keystore.Keystore, error)
+
+// Listener is called with the keys whose values changed, were added or were
+// removed when the keystore is reloaded.
+type Listener func(changed []string)
+
+// Keystore is a keystore that can be reloaded from its storage. It
+// implements the same interfaces as the keystore it wraps, so that it can be
+// used in place of it.
+type Keystore struct {
+	open Opener
+	log  *logp.Logger
+
+	mu    sync.RWMutex
+	store keystore.Keystore
+
+	// reloadMu serializes reloads and writes.
+	reloadMu sync.Mutex
+
+	listenersMu sync.Mutex
+	listeners   []Listener
+}
+
+// New opens a keystore that can be reloaded with open.
+func New(open Opener) (*Keystore, error) {
+	store, err := open()
+	if err != nil {
+		return nil, err
+	}
+	return &Keystore{
+		open:  open,
+		log:   logp.NewLogger("keystore"),
+		store: store,
+	}, nil
+}
+
+func (k *Keystore) current() keystore.Keystore {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.store
+}
+
+// Retrieve returns the value of key from the current keystore.
+func (k *Keystore) Retrieve(key string) (*keystore.SecureString, error) {
+	return k.current().Retrieve(key)
+}
+
+// GetConfig returns the keys and values of the current keystore.
+func (k *Keystore) GetConfig() (*config.C, error) {
+	return k.current().GetConfig()
+}
+
+// IsPersisted returns true if the current keystore is persisted.
+func (k *Keystore) IsPersisted() bool {
+	return k.current().IsPersisted()
+}
+
+// Store adds a key to the current keystore, it is not persisted until Save
+// is called.
+func (k *Keystore) Store(key string, secret []byte) error {
+	w, err := keystore.AsWritableKeystore(k.current())
+	if err != nil {
+		return err
+	}
+	return w.Store(key, secret)
+}
+
+// Delete removes a key from the current keystore.
+func (k *Keystore) Delete(key string) error {
+	w, err := keystore.AsWritableKeystore(k.current())
+	if err != nil {
+		return err
+	}
+	return w.Delete(key)
+}
+
+// Create creates an empty keystore.
+func (k *Keystore) Create(override bool) error {
+	w, err := keystore.AsWritableKeystore(k.current())
+	if err != nil {
+		return err
+	}
+	return w.Create(override)
+}
+
+// Save persists the current keystore.
+func (k *Keystore) Save() error {
+	w, err := keystore.AsWritableKeystore(k.current())
+	if err != nil {
+		return err
+	}
+	return w.Save()
+}
+
+// List returns the keys of the current keystore.
+func (k *Keystore) List() ([]string, error) {
+	l, err := keystore.AsListingKeystore(k.current())
+	if err != nil {
+		return nil, err
+	}
+	return l.List()
+}
+
+// Package returns the raw bytes of the current keystore.
+func (k *Keystore) Package() ([]byte, error) {
+	p, ok := k.current().(keystore.Packager)
+	if !ok {
+		return nil, errors.New("the configured keystore cannot be packaged")
+	}
+	return p.Package()
+}
+
+// ConfiguredPath returns the path of the current keystore.
+func (k *Keystore) ConfiguredPath() string {
+	p, ok := k.current().(keystore.Packager)
+	if !ok {
+		return ""
+	}
+	return p.ConfiguredPath()
+}
+
+// OnChange registers a listener called after every reload changing keys.
+func (k *Keystore) OnChange(l Listener) {
+	k.listenersMu.Lock()
+	defer k.listenersMu.Unlock()
+	k.listeners = append(k.listeners, l)
+}
+
+// Reload opens the keystore again from its storage and replaces the current
+// one. It returns the keys that changed, the listeners are called with them
+// if there are any.
+func (k *Keystore) Reload() ([]string, error) {
+	k.reloadMu.Lock()
+	defer k.reloadMu.Unlock()
+	return k.reload()
+}
+
+// reload must be called with k.reloadMu held.
+func (k *Keystore) reload() ([]string, error) {
+	store, err := k.open()
+	if err != nil {
+		return nil, fmt.Errorf("could not reload the keystore: %w", err)
+	}
+	changed, err := diff(k.current(), store)
+	if err != nil {
+		return nil, fmt.Errorf("could not compare the reloaded keystore: %w", err)
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	k.mu.Lock()
+	k.store = store
+	k.mu.Unlock()
+
+	// Only the keys are logged, never the values.
+	k.log.Infof("Keystore reloaded, changed keys: %v", changed)
+
+	k.listenersMu.Lock()
+	listeners := append([]Listener(nil), k.listeners...)
+	k.listenersMu.Unlock()
+	for _, l := range listeners {
+		l(changed)
+	}
+	return changed, nil
+}
+
+// Update sets the value of key in the keystore storage and reloads the
+// keystore.
+func (k *Keystore) Update(key string, value []byte) ([]string, error) {
+	return k.write(func(w keystore.WritableKeystore) error {
+		return w.Store(key, value)
+	})
+}
+
+// Remove removes key from the keystore storage and reloads the keystore. It
+// returns keystore.ErrKeyDoesntExists if the key is not in the storage.
+func (k *Keystore) Remove(key string) ([]string, error) {
+	return k.write(func(w keystore.WritableKeystore) error {
+		if store, ok := w.(keystore.Keystore); ok {
+			if _, err := store.Retrieve(key); err != nil {
+				return err
+			}
+		}
+		return w.Delete(key)
+	})
+}
+
+// write applies fn to a keystore opened from the storage, so that the
+// current keystore is only replaced once the change is persisted.
+func (k *Keystore) write(fn func(keystore.WritableKeystore) error) ([]string, error) {
+	k.reloadMu.Lock()
+	defer k.reloadMu.Unlock()
+
+	store, err := k.open()
+	if err != nil {
+		return nil, fmt.Errorf("could not open the keystore: %w", err)
+	}
+	w, err := keystore.AsWritableKeystore(store)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(w); err != nil {
+		return nil, err
+	}
+	if err := w.Save(); err != nil {
+		return nil, fmt.Errorf("could not save the keystore: %w", err)
+	}
+	return k.reload()
+}
+
+// Watch reloads the keystore every period until the context is cancelled.
+func (k *Keystore) Watch(ctx context.Context, period time.Duration) {
+	if period <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := k.Reload(); err != nil {
+			k.log.Warnf("Failed to reload the keystore: %v", err)
+		}
+	}
+}
+
+// diff returns the sorted keys added to, removed from or changed between
+// the two keystores.
+func diff(old, updated keystore.Keystore) ([]string, error) {
+	oldValues, err := values(old)
+	if err != nil {
+		return nil, err
+	}
+	updatedValues, err := values(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for key, value := range updatedValues {
+		if oldValue, ok := oldValues[key]; !ok || !bytes.Equal(oldValue, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldValues {
+		if _, ok := updatedValues[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+func values(store keystore.Keystore) (map[string][]byte, error) {
+	l, err := keystore.AsListingKeystore(store)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := l.List()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		secret, err := store.Retrieve(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := secret.Get()
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
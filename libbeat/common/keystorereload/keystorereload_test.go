@@ -0,0 +1,195 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystorereload
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+func newTestKeystore(t *testing.T, values map[string]string) (*Keystore, Opener) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.keystore")
+	open := func() (keystore.Keystore, error) {
+		return keystore.NewFileKeystore(path)
+	}
+
+	store, err := open()
+	require.NoError(t, err)
+	w, err := keystore.AsWritableKeystore(store)
+	require.NoError(t, err)
+	require.NoError(t, w.Create(true))
+	for key, value := range values {
+		require.NoError(t, w.Store(key, []byte(value)))
+	}
+	require.NoError(t, w.Save())
+
+	k, err := New(open)
+	require.NoError(t, err)
+	return k, open
+}
+
+func retrieve(t *testing.T, store keystore.Keystore, key string) string {
+	t.Helper()
+	secret, err := store.Retrieve(key)
+	require.NoError(t, err)
+	value, err := secret.Get()
+	require.NoError(t, err)
+	return string(value)
+}
+
+func TestReload(t *testing.T) {
+	k, open := newTestKeystore(t, map[string]string{"password": "old", "user": "elastic", "token": "abc"})
+
+	var notified []string
+	k.OnChange(func(changed []string) { notified = changed })
+
+	changed, err := k.Reload()
+	require.NoError(t, err)
+	assert.Empty(t, changed, "nothing changed in the storage")
+	assert.Nil(t, notified)
+
+	// Update the storage as the keystore command does.
+	store, err := open()
+	require.NoError(t, err)
+	w, err := keystore.AsWritableKeystore(store)
+	require.NoError(t, err)
+	require.NoError(t, w.Store("password", []byte("new")))
+	require.NoError(t, w.Store("api_key", []byte("id:key")))
+	require.NoError(t, w.Delete("token"))
+	require.NoError(t, w.Save())
+
+	assert.Equal(t, "old", retrieve(t, k, "password"), "the value changes on reload only")
+
+	changed, err = k.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api_key", "password", "token"}, changed)
+	assert.Equal(t, changed, notified)
+	assert.Equal(t, "new", retrieve(t, k, "password"))
+	assert.Equal(t, "elastic", retrieve(t, k, "user"))
+	_, err = k.Retrieve("token")
+	assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+}
+
+func TestUpdateAndRemove(t *testing.T) {
+	k, _ := newTestKeystore(t, map[string]string{"password": "old"})
+
+	changed, err := k.Update("password", []byte("new"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"password"}, changed)
+	assert.Equal(t, "new", retrieve(t, k, "password"))
+
+	changed, err = k.Update("password", []byte("new"))
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	changed, err = k.Remove("password")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"password"}, changed)
+	_, err = k.Retrieve("password")
+	assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+}
+
+func TestResolverUsesReloadedValues(t *testing.T) {
+	k, _ := newTestKeystore(t, map[string]string{"password": "old"})
+	resolve := keystore.ResolverWrap(k)
+
+	value, _, err := resolve("password")
+	require.NoError(t, err)
+	assert.Equal(t, "old", value)
+
+	_, err = k.Update("password", []byte("new"))
+	require.NoError(t, err)
+
+	value, _, err = resolve("password")
+	require.NoError(t, err)
+	assert.Equal(t, "new", value)
+}
+
+func TestReferences(t *testing.T) {
+	cfg := config.MustNewConfigFrom(`
+output.elasticsearch:
+  hosts: ["localhost:9200"]
+  username: "${es_user}"
+  password: "${es_pwd}"
+setup.kibana:
+  password: "prefix-${kb_pwd}"
+processors:
+  - add_fields:
+      fields:
+        token: "${es_pwd}"
+`)
+
+	refs, err := References(cfg, []string{"es_pwd", "kb_pwd", "unused"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"output":     {"es_pwd"},
+		"setup":      {"kb_pwd"},
+		"processors": {"es_pwd"},
+	}, refs)
+
+	refs, err = References(cfg, []string{"unused"})
+	require.NoError(t, err)
+	assert.Empty(t, refs)
+}
+
+func TestAPIHandlers(t *testing.T) {
+	k, _ := newTestKeystore(t, map[string]string{"password": "old"})
+
+	router := mux.NewRouter()
+	router.Handle("/keystore/reload", k.ReloadHandler())
+	router.Handle("/keystore/secrets/{key}", k.SecretsHandler())
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/keystore/reload", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"changed":[]}`, rec.Body.String())
+
+	rec = do(http.MethodGet, "/keystore/reload", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = do(http.MethodPut, "/keystore/secrets/password", "new")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"changed":["password"]}`, rec.Body.String())
+	assert.Equal(t, "new", retrieve(t, k, "password"))
+
+	rec = do(http.MethodPut, "/keystore/secrets/password", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = do(http.MethodDelete, "/keystore/secrets/password", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"changed":["password"]}`, rec.Body.String())
+
+	rec = do(http.MethodDelete, "/keystore/secrets/password", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystorereload
+
+import (
+	"sort"
+	"strings"
+
+	ucfg "github.com/elastic/go-ucfg"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// References returns, for each top level setting of cfg, the keys among keys
+// its values reference. The references are not resolved, so that only the
+// settings depending on the keys are returned.
+func References(cfg *config.C, keys []string) (map[string][]string, error) {
+	if cfg == nil || len(keys) == 0 {
+		return nil, nil
+	}
+
+	var settings map[string]interface{}
+	if err := (*ucfg.Config)(cfg).Unpack(&settings, ucfg.PathSep("."), ucfg.ResolveNOOP); err != nil {
+		return nil, err
+	}
+
+	refs := map[string][]string{}
+	for name, value := range settings {
+		var found []string
+		for _, key := range keys {
+			if references(value, key) {
+				found = append(found, key)
+			}
+		}
+		if len(found) > 0 {
+			sort.Strings(found)
+			refs[name] = found
+		}
+	}
+	return refs, nil
+}
+
+func references(value interface{}, key string) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(v, "${"+key+"}")
+	case map[string]interface{}:
+		for _, child := range v {
+			if references(child, key) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if references(child, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
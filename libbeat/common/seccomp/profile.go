@@ -0,0 +1,341 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package seccomp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/go-seccomp-bpf"
+)
+
+// Kind is the kind of a configured component.
+type Kind string
+
+// The kind matches the way the component is configured: inputs (or
+// monitors) by type, modules by name and outputs by their namespace under
+// output.
+const (
+	KindInput  Kind = "input"
+	KindModule Kind = "module"
+	KindOutput Kind = "output"
+)
+
+// ListenSyscalls are the system calls only needed by components accepting
+// network connections. They are removed from a tailored policy unless a
+// configured component requires them.
+var ListenSyscalls = []string{"accept", "accept4", "bind", "listen"}
+
+// Requirements describes what a component needs from the kernel in addition
+// to the base policy.
+type Requirements struct {
+	// Listen is set by components accepting network connections or binding
+	// sockets (see ListenSyscalls).
+	Listen bool
+
+	// Syscalls are added to the allowed system calls. The names must exist on
+	// the runtime architecture, so callers must check runtime.GOARCH like for
+	// ModifyDefaultPolicy.
+	Syscalls []string
+
+	// Capabilities are the Linux capabilities needed by the component, for
+	// example CAP_NET_RAW. They are only reported, never applied.
+	Capabilities []string
+}
+
+// Component identifies a configured input, module or output.
+type Component struct {
+	Kind Kind
+	Name string
+}
+
+func (c Component) String() string {
+	return string(c.Kind) + "/" + c.Name
+}
+
+var (
+	requirementsMu sync.RWMutex
+	requirements   = map[Component]Requirements{}
+)
+
+// RegisterRequirements registers the requirements of a component. Components
+// register from init. Registering the same component twice merges the
+// requirements.
+func RegisterRequirements(kind Kind, name string, req Requirements) {
+	requirementsMu.Lock()
+	defer requirementsMu.Unlock()
+
+	c := Component{Kind: kind, Name: name}
+	if prev, found := requirements[c]; found {
+		req = Requirements{
+			Listen:       prev.Listen || req.Listen,
+			Syscalls:     append(prev.Syscalls, req.Syscalls...),
+			Capabilities: append(prev.Capabilities, req.Capabilities...),
+		}
+	}
+	requirements[c] = req
+}
+
+// RegisterBase registers components of the given kind and names that only
+// need the base policy.
+func RegisterBase(kind Kind, names ...string) {
+	for _, name := range names {
+		RegisterRequirements(kind, name, Requirements{})
+	}
+}
+
+// Profile is the result of the analysis of a Beat configuration.
+type Profile struct {
+	// Components are the components found in the configuration.
+	Components []Component
+
+	// Unknown are the components that did not register their requirements.
+	Unknown []Component
+
+	// Incomplete is set when the configuration loads components that can
+	// not be known at startup, like external configuration files,
+	// autodiscover or central management.
+	Incomplete string
+
+	// Listen, Syscalls and Capabilities are the merged requirements of the
+	// components.
+	Listen       bool
+	Syscalls     []string
+	Capabilities []string
+}
+
+// Tailorable reports whether the policy can be tailored to the profile, that
+// is whether all components that might run are known.
+func (p *Profile) Tailorable() bool {
+	return p.Incomplete == "" && len(p.Unknown) == 0
+}
+
+// Reason returns why the policy can not be tailored to the profile.
+func (p *Profile) Reason() string {
+	if p.Incomplete != "" {
+		return p.Incomplete
+	}
+	if len(p.Unknown) > 0 {
+		names := make([]string, len(p.Unknown))
+		for i, c := range p.Unknown {
+			names[i] = c.String()
+		}
+		return "no seccomp requirements registered for " + strings.Join(names, ", ")
+	}
+	return ""
+}
+
+// profileConfig contains the parts of the Beat configuration that determine
+// which components run.
+type profileConfig struct {
+	Output config.Namespace `config:"output"`
+	HTTP   struct {
+		Enabled bool `config:"enabled"`
+	} `config:"http"`
+	Management struct {
+		Enabled bool `config:"enabled"`
+	} `config:"management"`
+}
+
+// beatConfig contains the components configured in the Beat namespace.
+type beatConfig struct {
+	Inputs   []componentConfig `config:"inputs"`
+	Monitors []componentConfig `config:"monitors"`
+	Modules  []componentConfig `config:"modules"`
+}
+
+// componentConfig is a single input, monitor or module configuration.
+type componentConfig struct {
+	Type    string `config:"type"`
+	Module  string `config:"module"`
+	Enabled *bool  `config:"enabled"`
+}
+
+func (c componentConfig) enabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// Analyze computes the profile of the Beat configuration by collecting the
+// requirements of the configured inputs, monitors, modules and output.
+func Analyze(beatName string, c *config.C) (*Profile, error) {
+	p := &Profile{}
+	if c == nil {
+		return p, nil
+	}
+
+	var pc profileConfig
+	if err := c.Unpack(&pc); err != nil {
+		return nil, err
+	}
+
+	if pc.Management.Enabled {
+		p.Incomplete = "the Beat is centrally managed"
+	}
+	if pc.HTTP.Enabled {
+		// The monitoring HTTP endpoint accepts connections while running.
+		p.Listen = true
+	}
+
+	var components []Component
+	if pc.Output.IsSet() {
+		components = append(components, Component{Kind: KindOutput, Name: pc.Output.Name()})
+	}
+
+	beat, err := c.Child(beatName, -1)
+	if err != nil {
+		// No Beat specific settings.
+		beat = config.NewConfig()
+	}
+
+	for _, field := range []string{"autodiscover", "config.inputs", "config.modules", "config.monitors"} {
+		if p.Incomplete == "" && hasEnabledField(beat, field) {
+			p.Incomplete = fmt.Sprintf("%s.%s loads configurations at runtime", beatName, field)
+		}
+	}
+
+	var bc beatConfig
+	if err := beat.Unpack(&bc); err != nil {
+		return nil, fmt.Errorf("failed to read %s settings: %w", beatName, err)
+	}
+	for _, list := range [][]componentConfig{bc.Inputs, bc.Monitors} {
+		for _, cc := range list {
+			if cc.enabled() {
+				components = append(components, Component{Kind: KindInput, Name: cc.Type})
+			}
+		}
+	}
+	for _, cc := range bc.Modules {
+		if cc.enabled() {
+			components = append(components, Component{Kind: KindModule, Name: cc.Module})
+		}
+	}
+
+	p.merge(components)
+	return p, nil
+}
+
+// hasEnabledField reports whether the setting at path exists and is not
+// explicitly disabled.
+func hasEnabledField(c *config.C, path string) bool {
+	sub, err := c.Child(path, -1)
+	if err != nil {
+		return false
+	}
+	return sub.Enabled()
+}
+
+func (p *Profile) merge(components []Component) {
+	requirementsMu.RLock()
+	defer requirementsMu.RUnlock()
+
+	syscalls := map[string]struct{}{}
+	capabilities := map[string]struct{}{}
+	seen := map[Component]struct{}{}
+	for _, c := range components {
+		if _, dup := seen[c]; dup {
+			continue
+		}
+		seen[c] = struct{}{}
+		p.Components = append(p.Components, c)
+
+		req, found := requirements[c]
+		if !found {
+			p.Unknown = append(p.Unknown, c)
+			continue
+		}
+		p.Listen = p.Listen || req.Listen
+		for _, name := range req.Syscalls {
+			syscalls[name] = struct{}{}
+		}
+		for _, name := range req.Capabilities {
+			capabilities[name] = struct{}{}
+		}
+	}
+	p.Syscalls = sortedKeys(syscalls)
+	p.Capabilities = sortedKeys(capabilities)
+}
+
+// Tailor returns a copy of the allow list policy reduced to the system calls
+// needed by the profile: the ListenSyscalls are dropped when no component
+// needs them and the syscalls required by the components are added.
+// Policies allowing system calls by default are returned unchanged.
+func Tailor(policy *seccomp.Policy, p *Profile) *seccomp.Policy {
+	if policy == nil || policy.DefaultAction == seccomp.ActionAllow {
+		return policy
+	}
+
+	drop := map[string]struct{}{}
+	if !p.Listen {
+		for _, name := range ListenSyscalls {
+			drop[name] = struct{}{}
+		}
+	}
+
+	tailored := &seccomp.Policy{DefaultAction: policy.DefaultAction}
+	allowed := map[string]struct{}{}
+	firstAllow := -1
+	for _, group := range policy.Syscalls {
+		g := seccomp.SyscallGroup{Action: group.Action}
+		for _, name := range group.Names {
+			if _, found := drop[name]; found && group.Action == seccomp.ActionAllow {
+				continue
+			}
+			g.Names = append(g.Names, name)
+			if group.Action == seccomp.ActionAllow {
+				allowed[name] = struct{}{}
+			}
+		}
+		if len(g.Names) == 0 {
+			continue
+		}
+		if group.Action == seccomp.ActionAllow && firstAllow < 0 {
+			firstAllow = len(tailored.Syscalls)
+		}
+		tailored.Syscalls = append(tailored.Syscalls, g)
+	}
+
+	var extra []string
+	for _, name := range p.Syscalls {
+		if _, found := allowed[name]; !found {
+			extra = append(extra, name)
+		}
+	}
+	if len(extra) > 0 {
+		if firstAllow < 0 {
+			tailored.Syscalls = append(tailored.Syscalls, seccomp.SyscallGroup{Action: seccomp.ActionAllow})
+			firstAllow = len(tailored.Syscalls) - 1
+		}
+		tailored.Syscalls[firstAllow].Names = append(tailored.Syscalls[firstAllow].Names, extra...)
+	}
+	return tailored
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
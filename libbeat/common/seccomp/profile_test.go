@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package seccomp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/go-seccomp-bpf"
+)
+
+func init() {
+	RegisterBase(KindOutput, "test_output")
+	RegisterBase(KindModule, "test_module")
+	RegisterRequirements(KindInput, "test_listener", Requirements{Listen: true})
+	RegisterRequirements(KindInput, "test_raw", Requirements{
+		Syscalls:     []string{"perf_event_open"},
+		Capabilities: []string{"CAP_NET_RAW"},
+	})
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := map[string]struct {
+		config     map[string]interface{}
+		components []Component
+		unknown    []Component
+		incomplete bool
+		listen     bool
+	}{
+		"inputs and output": {
+			config: map[string]interface{}{
+				"testbeat.inputs": []map[string]interface{}{
+					{"type": "test_raw"},
+					{"type": "test_listener", "enabled": false},
+				},
+				"output.test_output": map[string]interface{}{},
+			},
+			components: []Component{{KindOutput, "test_output"}, {KindInput, "test_raw"}},
+		},
+		"listener and modules": {
+			config: map[string]interface{}{
+				"testbeat.inputs":  []map[string]interface{}{{"type": "test_listener"}},
+				"testbeat.modules": []map[string]interface{}{{"module": "test_module"}},
+			},
+			components: []Component{{KindInput, "test_listener"}, {KindModule, "test_module"}},
+			listen:     true,
+		},
+		"http endpoint": {
+			config: map[string]interface{}{
+				"http.enabled": true,
+			},
+			listen: true,
+		},
+		"unknown input": {
+			config: map[string]interface{}{
+				"testbeat.inputs": []map[string]interface{}{{"type": "test_unknown"}},
+			},
+			components: []Component{{KindInput, "test_unknown"}},
+			unknown:    []Component{{KindInput, "test_unknown"}},
+		},
+		"external inputs": {
+			config: map[string]interface{}{
+				"testbeat.config.inputs.path": "inputs.d/*.yml",
+			},
+			incomplete: true,
+		},
+		"disabled external modules": {
+			config: map[string]interface{}{
+				"testbeat.config.modules.enabled": false,
+			},
+		},
+		"autodiscover": {
+			config: map[string]interface{}{
+				"testbeat.autodiscover.providers": []map[string]interface{}{{"type": "docker"}},
+			},
+			incomplete: true,
+		},
+		"managed": {
+			config: map[string]interface{}{
+				"management.enabled": true,
+			},
+			incomplete: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := config.MustNewConfigFrom(test.config)
+			p, err := Analyze("testbeat", c)
+			require.NoError(t, err)
+			assert.Equal(t, test.components, p.Components)
+			assert.Equal(t, test.unknown, p.Unknown)
+			assert.Equal(t, test.incomplete, p.Incomplete != "")
+			assert.Equal(t, test.listen, p.Listen)
+			assert.Equal(t, !test.incomplete && len(test.unknown) == 0, p.Tailorable())
+		})
+	}
+}
+
+func TestTailor(t *testing.T) {
+	base := &seccomp.Policy{
+		DefaultAction: seccomp.ActionErrno,
+		Syscalls: []seccomp.SyscallGroup{
+			{
+				Action: seccomp.ActionAllow,
+				Names:  []string{"accept", "bind", "connect", "listen", "read"},
+			},
+		},
+	}
+
+	p, err := Analyze("testbeat", config.MustNewConfigFrom(map[string]interface{}{
+		"testbeat.inputs": []map[string]interface{}{{"type": "test_raw"}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CAP_NET_RAW"}, p.Capabilities)
+
+	tailored := Tailor(base, p)
+	require.Len(t, tailored.Syscalls, 1)
+	assert.Equal(t, []string{"connect", "read", "perf_event_open"}, tailored.Syscalls[0].Names)
+	assert.Equal(t, []string{"accept", "bind", "connect", "listen", "read"}, base.Syscalls[0].Names,
+		"the base policy must not be modified")
+
+	p.Listen = true
+	tailored = Tailor(base, p)
+	assert.Equal(t, []string{"accept", "bind", "connect", "listen", "read", "perf_event_open"}, tailored.Syscalls[0].Names)
+
+	blacklist := &seccomp.Policy{DefaultAction: seccomp.ActionAllow}
+	assert.Same(t, blacklist, Tailor(blacklist, p))
+}
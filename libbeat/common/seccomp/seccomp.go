@@ -64,7 +64,9 @@ func MustRegisterPolicy(p *seccomp.Policy) {
 
 // LoadFilter loads a seccomp system call filter into the kernel for this
 // process. This feature is only available on Linux 3.17+. If c is nil or does
-// not contain a seccomp policy then a default policy will be used.
+// not contain a seccomp policy then a default policy will be used. With
+// seccomp.auto enabled the policy is tailored to the given profile (see
+// Analyze).
 //
 // An error is returned if there is a config validation problem. Otherwise any
 // errors interfacing with the kernel are logged (i.e. it is non-fatal if
@@ -72,15 +74,16 @@ func MustRegisterPolicy(p *seccomp.Policy) {
 //
 // Policy precedence order (highest to lowest):
 // - Policy values from config
+// - Application registered policy or default policy tailored to the profile
 // - Application registered policy
 // - Default policy (a simple blacklist)
-func LoadFilter(c *config.C) error {
+func LoadFilter(c *config.C, profile *Profile) error {
 	// Bail out if seccomp.enabled=false.
 	if c != nil && !c.Enabled() {
 		return nil
 	}
 
-	p, err := getPolicy(c)
+	p, err := getPolicy(c, profile)
 	if err != nil {
 		return err
 	}
@@ -125,11 +128,8 @@ func loadFilter(p *seccomp.Policy) {
 	log.Infow("Syscall filter successfully installed")
 }
 
-func getPolicy(c *config.C) (*seccomp.Policy, error) {
-	policy := defaultPolicy
-	if registeredPolicy != nil {
-		policy = registeredPolicy
-	}
+func getPolicy(c *config.C, profile *Profile) (*seccomp.Policy, error) {
+	policy := BasePolicy()
 
 	if c != nil && (c.HasField("default_action") || c.HasField("syscalls")) {
 		if policy == nil {
@@ -139,11 +139,41 @@ func getPolicy(c *config.C) (*seccomp.Policy, error) {
 		if err := c.Unpack(policy); err != nil {
 			return nil, err
 		}
+		return policy, nil
+	}
+
+	if c != nil && profile != nil {
+		var auto struct {
+			Auto bool `config:"auto"`
+		}
+		if err := c.Unpack(&auto); err != nil {
+			return nil, err
+		}
+		if auto.Auto {
+			log := logp.NewLogger("seccomp")
+			if !profile.Tailorable() {
+				log.Warnf("Using the default syscall filter, the filter can not be tailored: %s", profile.Reason())
+				return policy, nil
+			}
+			log.Infow("Tailoring syscall filter to the configuration",
+				"components", profile.Components,
+				"required_capabilities", profile.Capabilities)
+			return Tailor(policy, profile), nil
+		}
 	}
 
 	return policy, nil
 }
 
+// BasePolicy returns the application registered policy, or the default
+// policy when no policy is registered.
+func BasePolicy() *seccomp.Policy {
+	if registeredPolicy != nil {
+		return registeredPolicy
+	}
+	return defaultPolicy
+}
+
 // ModifyDefaultPolicy modifies the syscalls in the default policy. Any callers
 // of this function must first check the architecture because policies are
 // architecture specific.
@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kerberos
+
+import (
+	"errors"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// CheckFIPS returns an error if Kerberos authentication is enabled in the
+// configuration of a component, as Kerberos relies on algorithms that are
+// not FIPS approved. It is meant to be registered with fips.Register.
+func CheckFIPS(cfg *config.C) error {
+	if !cfg.HasField("kerberos") {
+		return nil
+	}
+	var settings struct {
+		Kerberos struct {
+			Enabled *bool `config:"enabled"`
+		} `config:"kerberos"`
+	}
+	if err := cfg.Unpack(&settings); err != nil {
+		return err
+	}
+	if settings.Kerberos.Enabled == nil || *settings.Kerberos.Enabled {
+		return errors.New("kerberos authentication is not FIPS capable")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package proxy implements SOCKS5 and HTTP CONNECT proxy support for outputs
+// that connect to their destination over plain TCP.
+package proxy
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Supported proxy URL schemes.
+const (
+	SchemeSOCKS5 = "socks5"
+	SchemeHTTP   = "http"
+	SchemeHTTPS  = "https"
+)
+
+// Config configures the proxy an output connects through. Credentials can be
+// part of the URL, or set with Username and Password so they can be loaded
+// from the keystore.
+type Config struct {
+	URL          string `config:"proxy_url"`
+	LocalResolve bool   `config:"proxy_use_local_resolver"`
+	Username     string `config:"proxy_username"`
+	Password     string `config:"proxy_password"`
+}
+
+// IsEnabled returns true if a proxy URL is configured.
+func (c *Config) IsEnabled() bool {
+	return c != nil && c.URL != ""
+}
+
+func (c *Config) Validate() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	u, err := c.parseURL()
+	if err != nil {
+		return err
+	}
+	if u.Host == "" {
+		return fmt.Errorf("proxy URL '%s' has no host", c.URL)
+	}
+	if c.Password != "" && c.Username == "" {
+		return fmt.Errorf("proxy_password requires proxy_username to be set")
+	}
+	return nil
+}
+
+func (c *Config) parseURL() (*url.URL, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case SchemeSOCKS5, SchemeHTTP, SchemeHTTPS:
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme '%s', must be one of %s, %s or %s",
+			u.Scheme, SchemeSOCKS5, SchemeHTTP, SchemeHTTPS)
+	}
+	return u, nil
+}
+
+// credentials returns the username and password to authenticate with. The
+// proxy_username and proxy_password settings take precedence over the URL.
+func (c *Config) credentials(u *url.URL) (string, string, bool) {
+	if c.Username != "" {
+		return c.Username, c.Password, true
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		return u.User.Username(), password, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport"
+)
+
+// NewClient creates a transport client like transport.NewClient, connecting
+// through the proxy if one is configured. The Proxy field of c is ignored.
+func NewClient(c transport.Config, proxyCfg *Config, network, host string, defaultPort int) (*transport.Client, error) {
+	dialer, err := MakeDialer(c, proxyCfg)
+	if err != nil {
+		return nil, err
+	}
+	return transport.NewClientWithDialer(dialer, c, network, host, defaultPort)
+}
+
+// MakeDialer creates the dialer chain of transport.MakeDialer, connecting
+// through the proxy if one is configured.
+func MakeDialer(c transport.Config, proxyCfg *Config) (transport.Dialer, error) {
+	dialer, err := Dialer(logp.NewLogger("transport"), proxyCfg, transport.NetDialer(c.Timeout), c.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if c.Stats != nil {
+		dialer = transport.StatsDialer(dialer, c.Stats)
+	}
+	if c.TLS != nil {
+		return transport.TLSDialer(dialer, c.TLS, c.Timeout), nil
+	}
+	return dialer, nil
+}
+
+// Dialer wraps forward so that connections are established through the
+// configured proxy. forward is returned as is if no proxy is configured.
+// The timeout limits the handshake with HTTP proxies, 0 means no limit.
+func Dialer(log *logp.Logger, cfg *Config, forward transport.Dialer, timeout time.Duration) (transport.Dialer, error) {
+	if !cfg.IsEnabled() {
+		return forward, nil
+	}
+
+	u, err := cfg.parseURL()
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("proxy host: '%s'", u.Host)
+
+	var proxyDialer transport.Dialer
+	switch u.Scheme {
+	case SchemeSOCKS5:
+		var auth *proxy.Auth
+		if user, password, ok := cfg.credentials(u); ok {
+			auth = &proxy.Auth{User: user, Password: password}
+		}
+		socksDialer, err := proxy.SOCKS5("tcp", u.Host, auth, forward)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SOCKS5 proxy dialer: %w", err)
+		}
+		var ok bool
+		proxyDialer, ok = socksDialer.(transport.Dialer)
+		// The proxy package always returns a ContextDialer, see golang/go#58376.
+		if !ok {
+			proxyDialer = transport.DialerFunc(func(_ context.Context, network, address string) (net.Conn, error) {
+				return socksDialer.Dial(network, address)
+			})
+		}
+	default:
+		d := &httpConnectDialer{
+			proxyAddr: u.Host,
+			forward:   forward,
+			timeout:   timeout,
+		}
+		if user, password, ok := cfg.credentials(u); ok {
+			d.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+		}
+		if u.Scheme == SchemeHTTPS {
+			d.tlsConfig = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+		}
+		proxyDialer = d
+	}
+
+	if !cfg.LocalResolve {
+		return proxyDialer, nil
+	}
+	return localResolveDialer(proxyDialer), nil
+}
+
+// localResolveDialer resolves the destination host locally and tries each of
+// its addresses through the proxy.
+func localResolveDialer(forward transport.Dialer) transport.Dialer {
+	return transport.DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		addresses, err := net.LookupHost(host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve '%s': %w", host, err)
+		}
+
+		for _, addr := range addresses {
+			var conn net.Conn
+			conn, err = forward.DialContext(ctx, network, net.JoinHostPort(addr, port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	})
+}
+
+// httpConnectDialer opens tunnels through an HTTP proxy using the CONNECT
+// method.
+type httpConnectDialer struct {
+	proxyAddr     string
+	authorization string
+	tlsConfig     *tls.Config
+	forward       transport.Dialer
+	timeout       time.Duration
+}
+
+func (d *httpConnectDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, _, address string) (net.Conn, error) {
+	conn, err := d.forward.DialContext(ctx, "tcp", d.proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy '%s': %w", d.proxyAddr, err)
+	}
+
+	if d.timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(d.timeout))
+	}
+	tunnel, err := d.connect(conn, address)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = tunnel.SetDeadline(time.Time{})
+	return tunnel, nil
+}
+
+func (d *httpConnectDialer) connect(conn net.Conn, address string) (net.Conn, error) {
+	if d.tlsConfig != nil {
+		tlsConn := tls.Client(conn, d.tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, fmt.Errorf("TLS handshake with proxy '%s' failed: %w", d.proxyAddr, err)
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if d.authorization != "" {
+		req.Header.Set("Proxy-Authorization", d.authorization)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to send CONNECT request to proxy '%s': %w", d.proxyAddr, err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy '%s': %w", d.proxyAddr, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy '%s' refused to connect to '%s': %s", d.proxyAddr, address, resp.Status)
+	}
+
+	if br.Buffered() > 0 {
+		// The destination already sent data, it must be read before the
+		// connection itself.
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport"
+)
+
+// startEchoServer starts a TCP server that echoes everything it receives.
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+// startConnectProxy starts an HTTP proxy supporting the CONNECT method. If
+// authorization is set, requests without matching credentials are rejected.
+func startConnectProxy(t *testing.T, authorization string) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go handleConnect(conn, authorization)
+		}
+	}()
+	return l.Addr().String()
+}
+
+func handleConnect(conn net.Conn, authorization string) {
+	defer conn.Close()
+
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil {
+		return
+	}
+	if req.Method != http.MethodConnect {
+		_, _ = io.WriteString(conn, "HTTP/1.1 405 Method Not Allowed\r\n\r\n")
+		return
+	}
+	if authorization != "" && req.Header.Get("Proxy-Authorization") != authorization {
+		_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+		return
+	}
+
+	upstream, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	defer upstream.Close()
+
+	_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+	go func() { _, _ = io.Copy(upstream, conn) }()
+	_, _ = io.Copy(conn, upstream)
+}
+
+func TestHTTPConnectDialer(t *testing.T) {
+	echoAddr := startEchoServer(t)
+	authorization := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	proxyAddr := startConnectProxy(t, authorization)
+
+	dial := func(cfg *Config) (net.Conn, error) {
+		dialer, err := Dialer(logp.NewLogger("test"), cfg, transport.NetDialer(time.Second), time.Second)
+		require.NoError(t, err)
+		return dialer.Dial("tcp", echoAddr)
+	}
+
+	t.Run("tunnels the connection", func(t *testing.T) {
+		conn, err := dial(&Config{URL: "http://" + proxyAddr, Username: "user", Password: "pass"})
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		buf := make([]byte, 5)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(buf))
+	})
+
+	t.Run("credentials from the URL", func(t *testing.T) {
+		conn, err := dial(&Config{URL: "http://user:pass@" + proxyAddr})
+		require.NoError(t, err)
+		conn.Close()
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		_, err := dial(&Config{URL: "http://" + proxyAddr, Username: "user", Password: "wrong"})
+		assert.ErrorContains(t, err, "407")
+	})
+}
+
+func TestDialerWithoutProxy(t *testing.T) {
+	forward := transport.NetDialer(time.Second)
+	dialer, err := Dialer(logp.NewLogger("test"), &Config{}, forward, time.Second)
+	require.NoError(t, err)
+	assert.NotNil(t, dialer)
+
+	dialer, err = Dialer(logp.NewLogger("test"), nil, forward, time.Second)
+	require.NoError(t, err)
+	assert.NotNil(t, dialer)
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package signing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// defaultAWSService is the signing name of Amazon OpenSearch Service. Amazon
+// OpenSearch Serverless uses "aoss".
+const defaultAWSService = "es"
+
+// awsSigner signs requests with AWS Signature Version 4.
+type awsSigner struct {
+	signer      *v4.Signer
+	credentials awssdk.CredentialsProvider
+	region      string
+	service     string
+}
+
+func newAWSSigner(config AWSConfig) (*awsSigner, error) {
+	var options []func(*awsconfig.LoadOptions) error
+	if config.Region != "" {
+		options = append(options, awsconfig.WithRegion(config.Region))
+	}
+	if config.AccessKeyID != "" {
+		options = append(options, awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			config.AccessKeyID, config.SecretAccessKey, config.SessionToken)))
+	}
+	if config.ProfileName != "" {
+		options = append(options, awsconfig.WithSharedConfigProfile(config.ProfileName))
+	}
+	if config.SharedCredentialFile != "" {
+		options = append(options, awsconfig.WithSharedCredentialsFiles([]string{config.SharedCredentialFile}))
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if awsConfig.Region == "" {
+		return nil, errors.New("aws signing requires a region")
+	}
+	if config.RoleArn != "" {
+		awsConfig.Credentials = awssdk.NewCredentialsCache(
+			stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsConfig), config.RoleArn))
+	}
+	if awsConfig.Credentials == nil {
+		return nil, errors.New("no AWS credentials found")
+	}
+
+	service := config.Service
+	if service == "" {
+		service = defaultAWSService
+	}
+
+	return &awsSigner{
+		signer:      v4.NewSigner(),
+		credentials: awsConfig.Credentials,
+		region:      awsConfig.Region,
+		service:     service,
+	}, nil
+}
+
+func (s *awsSigner) sign(req *http.Request, body []byte, now time.Time) error {
+	creds, err := s.credentials.Retrieve(req.Context())
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+
+	// Amazon OpenSearch Serverless requires the payload hash to be sent.
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	return s.signer.SignHTTP(req.Context(), creds, req, payloadHash, s.service, s.region, now)
+}
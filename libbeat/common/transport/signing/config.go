@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Supported request signing types.
+const (
+	TypeAWSSigV4 = "aws_sigv4"
+	TypeHMAC     = "hmac"
+)
+
+// Supported HMAC hash algorithms.
+const (
+	AlgorithmSHA256 = "sha256"
+	AlgorithmSHA512 = "sha512"
+)
+
+// Config configures how outgoing HTTP requests are signed.
+type Config struct {
+	Enabled *bool      `config:"enabled" yaml:"enabled,omitempty"`
+	Type    string     `config:"type" validate:"required"`
+	AWS     AWSConfig  `config:"aws"`
+	HMAC    HMACConfig `config:"hmac"`
+}
+
+// AWSConfig configures AWS Signature Version 4 signing. Credentials that are
+// not configured explicitly are loaded from the default AWS credentials chain,
+// which includes environment variables, shared credential files, web identity
+// tokens (IRSA) and instance roles.
+type AWSConfig struct {
+	Region               string `config:"region"`
+	Service              string `config:"service"`
+	AccessKeyID          string `config:"access_key_id"`
+	SecretAccessKey      string `config:"secret_access_key"`
+	SessionToken         string `config:"session_token"`
+	ProfileName          string `config:"credential_profile_name"`
+	SharedCredentialFile string `config:"shared_credential_file"`
+	RoleArn              string `config:"role_arn"`
+}
+
+// HMACConfig configures signing with a shared secret.
+type HMACConfig struct {
+	KeyID     string `config:"key_id"`
+	Secret    string `config:"secret"`
+	Algorithm string `config:"algorithm"`
+	Header    string `config:"header"`
+}
+
+// IsEnabled returns true if the `enable` field is set to true in the yaml.
+func (c *Config) IsEnabled() bool {
+	return c != nil && (c.Enabled == nil || *c.Enabled)
+}
+
+func (c *Config) Validate() error {
+	switch c.Type {
+	case TypeAWSSigV4:
+		if (c.AWS.AccessKeyID == "") != (c.AWS.SecretAccessKey == "") {
+			return errors.New("aws signing requires both access_key_id and secret_access_key to be set")
+		}
+	case TypeHMAC:
+		if c.HMAC.KeyID == "" {
+			return errors.New("hmac signing is selected, but key_id is not configured")
+		}
+		if c.HMAC.Secret == "" {
+			return errors.New("hmac signing is selected, but secret is not configured")
+		}
+		switch c.HMAC.Algorithm {
+		case "", AlgorithmSHA256, AlgorithmSHA512:
+		default:
+			return fmt.Errorf("invalid hmac algorithm '%s', must be one of %s or %s",
+				c.HMAC.Algorithm, AlgorithmSHA256, AlgorithmSHA512)
+		}
+	default:
+		return fmt.Errorf("invalid signing type '%s', must be one of %s or %s", c.Type, TypeAWSSigV4, TypeHMAC)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHMACHeader = "Authorization"
+
+	// HMACTimestampHeader carries the signing time of a request signed with
+	// the hmac signing type.
+	HMACTimestampHeader = "X-Signature-Timestamp"
+)
+
+// hmacSigner signs requests with a keyed hash of the request. The string to
+// sign is built from the request method, path, query, timestamp and the hex
+// encoded hash of the body, separated by newlines:
+//
+//	POST
+//	/_bulk
+//	filter_path=errors
+//	2024-01-02T03:04:05Z
+//	<hex(hash(body))>
+//
+// The signature is sent as "HMAC-SHA256 KeyId=<key_id>,Signature=<hex signature>"
+// in the configured header, and the timestamp in the X-Signature-Timestamp header.
+type hmacSigner struct {
+	keyID   string
+	secret  []byte
+	header  string
+	scheme  string
+	newHash func() hash.Hash
+}
+
+func newHMACSigner(config HMACConfig) (*hmacSigner, error) {
+	s := &hmacSigner{
+		keyID:   config.KeyID,
+		secret:  []byte(config.Secret),
+		header:  config.Header,
+		scheme:  "HMAC-SHA256",
+		newHash: sha256.New,
+	}
+	if s.header == "" {
+		s.header = defaultHMACHeader
+	}
+	if config.Algorithm == AlgorithmSHA512 {
+		s.scheme = "HMAC-SHA512"
+		s.newHash = sha512.New
+	}
+	return s, nil
+}
+
+func (s *hmacSigner) sign(req *http.Request, body []byte, now time.Time) error {
+	timestamp := now.Format(time.RFC3339)
+
+	bodyHash := s.newHash()
+	bodyHash.Write(body)
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		timestamp,
+		hex.EncodeToString(bodyHash.Sum(nil)),
+	}, "\n")
+
+	mac := hmac.New(s.newHash, s.secret)
+	mac.Write([]byte(stringToSign))
+
+	req.Header.Set(HMACTimestampHeader, timestamp)
+	req.Header.Set(s.header, s.scheme+" KeyId="+s.keyID+",Signature="+hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
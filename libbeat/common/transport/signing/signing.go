@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package signing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// signer adds authentication information to a request. The body is passed
+// separately, as the request body has already been consumed.
+type signer interface {
+	sign(req *http.Request, body []byte, now time.Time) error
+}
+
+// roundTripper signs every request before handing it to the next
+// http.RoundTripper.
+type roundTripper struct {
+	signer signer
+	next   http.RoundTripper
+	now    func() time.Time
+}
+
+// NewRoundTripper returns an http.RoundTripper that signs requests as
+// configured before sending them with next. It must wrap all other round
+// trippers that modify signed parts of the request.
+func NewRoundTripper(config *Config, next http.RoundTripper) (http.RoundTripper, error) {
+	var (
+		s   signer
+		err error
+	)
+	switch config.Type {
+	case TypeAWSSigV4:
+		s, err = newAWSSigner(config.AWS)
+	case TypeHMAC:
+		s, err = newHMACSigner(config.HMAC)
+	default:
+		err = fmt.Errorf("invalid signing type '%s'", config.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &roundTripper{signer: s, next: next, now: time.Now}, nil
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body for signing: %w", err)
+	}
+
+	// A RoundTripper must not modify the request, sign a copy instead.
+	signed := req.Clone(req.Context())
+	if body != nil {
+		signed.Body = io.NopCloser(bytes.NewReader(body))
+		signed.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		signed.ContentLength = int64(len(body))
+	}
+
+	if err := rt.signer.sign(signed, body, rt.now().UTC()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	return rt.next.RoundTrip(signed)
+}
+
+// readBody returns the request body, leaving the original body untouched
+// when it can be read a second time.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body := req.Body
+	if req.GetBody != nil {
+		var err error
+		body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
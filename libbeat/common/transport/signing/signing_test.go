@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+type captureRoundTripper struct {
+	req  *http.Request
+	body string
+}
+
+func (c *captureRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.req = req
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		c.body = string(body)
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+var testTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newTestRoundTripper(t *testing.T, config *Config) (*roundTripper, *captureRoundTripper) {
+	t.Helper()
+	next := &captureRoundTripper{}
+	rt, err := NewRoundTripper(config, next)
+	require.NoError(t, err)
+	signingRT := rt.(*roundTripper)
+	signingRT.now = func() time.Time { return testTime }
+	return signingRT, next
+}
+
+func TestHMACSigning(t *testing.T) {
+	rt, next := newTestRoundTripper(t, &Config{
+		Type: TypeHMAC,
+		HMAC: HMACConfig{KeyID: "beats", Secret: "secret"},
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:9200/_bulk?filter_path=errors", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	bodyHash := sha256.Sum256([]byte("payload"))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("POST\n/_bulk\nfilter_path=errors\n2024-01-02T03:04:05Z\n" + hex.EncodeToString(bodyHash[:])))
+
+	assert.Equal(t, "2024-01-02T03:04:05Z", next.req.Header.Get(HMACTimestampHeader))
+	assert.Equal(t, "HMAC-SHA256 KeyId=beats,Signature="+hex.EncodeToString(mac.Sum(nil)), next.req.Header.Get("Authorization"))
+	assert.Equal(t, "payload", next.body)
+
+	// The original request must not be modified.
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestHMACSigningOptions(t *testing.T) {
+	rt, next := newTestRoundTripper(t, &Config{
+		Type: TypeHMAC,
+		HMAC: HMACConfig{KeyID: "beats", Secret: "secret", Algorithm: AlgorithmSHA512, Header: "X-Signature"},
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:9200/", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(next.req.Header.Get("X-Signature"), "HMAC-SHA512 KeyId=beats,Signature="))
+	assert.Empty(t, next.req.Header.Get("Authorization"))
+}
+
+func TestAWSSigning(t *testing.T) {
+	rt, next := newTestRoundTripper(t, &Config{
+		Type: TypeAWSSigV4,
+		AWS: AWSConfig{
+			Region:          "eu-west-1",
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+		},
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "https://search.eu-west-1.es.amazonaws.com/_bulk", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	bodyHash := sha256.Sum256([]byte("payload"))
+	assert.Equal(t, hex.EncodeToString(bodyHash[:]), next.req.Header.Get("X-Amz-Content-Sha256"))
+	assert.Equal(t, "20240102T030405Z", next.req.Header.Get("X-Amz-Date"))
+	assert.True(t, strings.HasPrefix(next.req.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/eu-west-1/es/aws4_request"),
+		next.req.Header.Get("Authorization"))
+	assert.Equal(t, "payload", next.body)
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		config string
+		err    string
+	}{
+		"aws": {
+			config: "type: aws_sigv4\naws.region: us-east-1",
+		},
+		"hmac": {
+			config: "type: hmac\nhmac: {key_id: beats, secret: secret}",
+		},
+		"missing type": {
+			config: "hmac: {key_id: beats, secret: secret}",
+			err:    "string value is not set accessing 'type'",
+		},
+		"invalid type": {
+			config: "type: digest",
+			err:    "invalid signing type 'digest'",
+		},
+		"aws without secret": {
+			config: "type: aws_sigv4\naws.access_key_id: AKIDEXAMPLE",
+			err:    "requires both access_key_id and secret_access_key",
+		},
+		"hmac without secret": {
+			config: "type: hmac\nhmac.key_id: beats",
+			err:    "secret is not configured",
+		},
+		"hmac invalid algorithm": {
+			config: "type: hmac\nhmac: {key_id: beats, secret: secret, algorithm: md5}",
+			err:    "invalid hmac algorithm 'md5'",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := conf.NewConfigWithYAML([]byte(test.config), "")
+			require.NoError(t, err)
+
+			var config Config
+			err = cfg.Unpack(&config)
+			if test.err == "" {
+				assert.NoError(t, err)
+				assert.True(t, config.IsEnabled())
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
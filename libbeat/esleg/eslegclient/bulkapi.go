@@ -53,6 +53,7 @@ type BulkMeta struct {
 	DocType  string `json:"_type,omitempty" struct:"_type,omitempty"`
 	Pipeline string `json:"pipeline,omitempty" struct:"pipeline,omitempty"`
 	ID       string `json:"_id,omitempty" struct:"_id,omitempty"`
+	Routing  string `json:"routing,omitempty" struct:"routing,omitempty"`
 }
 
 type bulkRequest struct {
@@ -92,6 +93,48 @@ func (conn *Connection) Bulk(
 	return conn.sendBulkRequest(requ)
 }
 
+// NewBulkEncoder creates a body encoder with the compression settings of the
+// connection, for sending bulk requests with BulkWithEncoder.
+func (conn *Connection) NewBulkEncoder() (BodyEncoder, error) {
+	return newBodyEncoder(conn.Compression, conn.CompressionLevel, conn.EscapeHTML)
+}
+
+// BulkWithEncoder performs a bulk request like Bulk, but encodes the body with
+// enc and reads the response into a buffer of its own. Unlike the other
+// methods of the connection, it can be called concurrently, as long as the
+// calls use different encoders and the connection isn't reconnected.
+// The connection doesn't fall back to gzip if the server rejects zstd
+// compressed requests, the caller must check for
+// http.StatusUnsupportedMediaType.
+func (conn *Connection) BulkWithEncoder(
+	ctx context.Context,
+	enc BodyEncoder,
+	index, docType string,
+	params map[string]string, body []interface{},
+) (int, BulkResponse, error) {
+	if len(body) == 0 {
+		return 0, nil, nil
+	}
+
+	enc.Reset()
+	if err := bulkEncode(conn.log, enc, body); err != nil {
+		apm.CaptureError(ctx, err).Send()
+		return 0, nil, err
+	}
+
+	mergedParams := mergeParams(conn.ConnectionSettings.Parameters, params)
+
+	requ, err := newBulkRequest(conn.URL, index, docType, mergedParams, enc)
+	if err != nil {
+		apm.CaptureError(ctx, err).Send()
+		return 0, nil, err
+	}
+	requ.requ = apmHttpV2.RequestWithContext(ctx, requ.requ)
+
+	status, resp, err := conn.execHTTPRequestWithBuffer(requ.requ, bytes.NewBuffer(nil))
+	return status, BulkResponse(resp), err
+}
+
 func newBulkRequest(
 	urlStr string,
 	index, docType string,
@@ -161,6 +204,8 @@ func (r *bulkRequest) reset(body BodyEncoder) {
 
 func (conn *Connection) sendBulkRequest(requ *bulkRequest) (int, BulkResponse, error) {
 	status, resp, err := conn.execHTTPRequest(requ.requ)
+	// The batch is retried by the caller, using gzip from now on.
+	conn.fallbackFromZstd(status)
 	return status, BulkResponse(resp), err
 }
 
@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eslegclient
+
+import (
+	"fmt"
+	"strings"
+
+	libversion "github.com/elastic/elastic-agent-libs/version"
+)
+
+// Flavor is the kind of cluster a connection is connected to.
+type Flavor string
+
+const (
+	FlavorElasticsearch Flavor = "elasticsearch"
+	FlavorServerless    Flavor = "serverless"
+	FlavorOpenSearch    Flavor = "opensearch"
+)
+
+// Versions of Elasticsearch introducing the features gated by Capabilities.
+var (
+	versionCreateWithoutID = libversion.MustNew("7.5.0")
+	versionDataStreams     = libversion.MustNew("7.9.0")
+	versionRequireAlias    = libversion.MustNew("7.10.0")
+	versionTSDB            = libversion.MustNew("8.7.0")
+	versionESQL            = libversion.MustNew("8.11.0")
+)
+
+// Capabilities are the features supported by the cluster a connection is
+// connected to, detected from the flavor and version of the cluster.
+type Capabilities struct {
+	Flavor  Flavor
+	Version libversion.V
+
+	// DocType is set if bulk requests must have a document type.
+	DocType bool
+	// CreateWithoutID is set if the create operation is supported for
+	// documents without _id.
+	CreateWithoutID bool
+	// DataStreams is set if data streams are supported.
+	DataStreams bool
+	// RequireAlias is set if the require_alias bulk parameter is supported.
+	RequireAlias bool
+	// TSDB is set if time series data streams are supported.
+	TSDB bool
+	// ESQL is set if ES|QL queries are supported.
+	ESQL bool
+}
+
+// NewCapabilities returns the capabilities of a cluster of the flavor and
+// version. The capabilities of an unknown flavor are the ones of
+// Elasticsearch.
+func NewCapabilities(flavor Flavor, version libversion.V) Capabilities {
+	c := Capabilities{Flavor: flavor, Version: version}
+	switch flavor {
+	case FlavorServerless:
+		// Serverless is versionless and always has the latest features.
+		c.CreateWithoutID = true
+		c.DataStreams = true
+		c.RequireAlias = true
+		c.TSDB = true
+		c.ESQL = true
+	case FlavorOpenSearch:
+		// OpenSearch was forked from Elasticsearch 7.10, time series data
+		// streams were added later.
+		c.CreateWithoutID = true
+		c.DataStreams = true
+		c.RequireAlias = true
+	default:
+		c.Flavor = FlavorElasticsearch
+		c.DocType = version.Major < 7
+		c.CreateWithoutID = !version.LessThan(versionCreateWithoutID)
+		c.DataStreams = !version.LessThan(versionDataStreams)
+		c.RequireAlias = !version.LessThan(versionRequireAlias)
+		c.TSDB = !version.LessThan(versionTSDB)
+		c.ESQL = !version.LessThan(versionESQL)
+	}
+	return c
+}
+
+// Features returns the names of the supported features.
+func (c Capabilities) Features() []string {
+	var features []string
+	for _, f := range []struct {
+		name      string
+		supported bool
+	}{
+		{"doc_type", c.DocType},
+		{"create_without_id", c.CreateWithoutID},
+		{"data_streams", c.DataStreams},
+		{"require_alias", c.RequireAlias},
+		{"tsdb", c.TSDB},
+		{"esql", c.ESQL},
+	} {
+		if f.supported {
+			features = append(features, f.name)
+		}
+	}
+	return features
+}
+
+func (c Capabilities) String() string {
+	if c.Flavor == FlavorServerless {
+		return fmt.Sprintf("Elasticsearch serverless (features: %s)", strings.Join(c.Features(), ", "))
+	}
+	name := "Elasticsearch"
+	if c.Flavor == FlavorOpenSearch {
+		name = "OpenSearch"
+	}
+	return fmt.Sprintf("%s %s (features: %s)", name, c.Version.String(), strings.Join(c.Features(), ", "))
+}
+
+// detectFlavor returns the flavor of the cluster that answered the ping.
+func detectFlavor(data ESPingData) Flavor {
+	switch {
+	case data.Version.Distribution == "opensearch":
+		return FlavorOpenSearch
+	case data.Version.BuildFlavor == "serverless":
+		return FlavorServerless
+	default:
+		return FlavorElasticsearch
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eslegclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libversion "github.com/elastic/elastic-agent-libs/version"
+)
+
+func TestNewCapabilities(t *testing.T) {
+	tests := []struct {
+		flavor   Flavor
+		version  string
+		features []string
+	}{
+		{FlavorElasticsearch, "6.8.0", []string{"doc_type"}},
+		{FlavorElasticsearch, "7.5.0", []string{"create_without_id"}},
+		{FlavorElasticsearch, "7.9.3", []string{"create_without_id", "data_streams"}},
+		{FlavorElasticsearch, "7.17.0", []string{"create_without_id", "data_streams", "require_alias"}},
+		{FlavorElasticsearch, "8.7.0", []string{"create_without_id", "data_streams", "require_alias", "tsdb"}},
+		{FlavorElasticsearch, "8.15.0", []string{"create_without_id", "data_streams", "require_alias", "tsdb", "esql"}},
+		{FlavorServerless, "8.11.0", []string{"create_without_id", "data_streams", "require_alias", "tsdb", "esql"}},
+		{FlavorOpenSearch, "2.11.0", []string{"create_without_id", "data_streams", "require_alias"}},
+		{"", "8.15.0", []string{"create_without_id", "data_streams", "require_alias", "tsdb", "esql"}},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.flavor)+" "+tc.version, func(t *testing.T) {
+			caps := NewCapabilities(tc.flavor, *libversion.MustNew(tc.version))
+			assert.Equal(t, tc.features, caps.Features())
+		})
+	}
+}
+
+func TestConnectionCapabilities(t *testing.T) {
+	tests := map[string]struct {
+		body   string
+		flavor Flavor
+		want   string
+	}{
+		"elasticsearch": {
+			body:   `{"version":{"number":"8.15.0","build_flavor":"default"}}`,
+			flavor: FlavorElasticsearch,
+			want:   "Elasticsearch 8.15.0 (features: create_without_id, data_streams, require_alias, tsdb, esql)",
+		},
+		"serverless": {
+			body:   `{"version":{"number":"8.11.0","build_flavor":"serverless"}}`,
+			flavor: FlavorServerless,
+			want:   "Elasticsearch serverless (features: create_without_id, data_streams, require_alias, tsdb, esql)",
+		},
+		"opensearch": {
+			body:   `{"version":{"distribution":"opensearch","number":"2.11.0"},"tagline":"The OpenSearch Project: https://opensearch.org/"}`,
+			flavor: FlavorOpenSearch,
+			want:   "OpenSearch 2.11.0 (features: create_without_id, data_streams, require_alias)",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			conn, err := NewConnection(ConnectionSettings{URL: server.URL})
+			require.NoError(t, err)
+			require.NoError(t, conn.Connect())
+
+			assert.Equal(t, tc.flavor, conn.Flavor())
+			assert.Equal(t, tc.want, conn.Capabilities().String())
+		})
+	}
+}
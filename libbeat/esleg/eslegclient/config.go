@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+	"github.com/elastic/beats/v7/libbeat/common/transport/signing"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 )
 
@@ -32,15 +33,19 @@ type config struct {
 	Headers  map[string]string `config:"headers"`
 
 	Kerberos *kerberos.Config `config:"kerberos"`
+	Signing  *signing.Config  `config:"signing"`
 
 	Username string `config:"username"`
 	Password string `config:"password"`
 	APIKey   string `config:"api_key"`
 
-	CompressionLevel int  `config:"compression_level" validate:"min=0, max=9"`
-	EscapeHTML       bool `config:"escape_html"`
+	CompressionLevel int    `config:"compression_level" validate:"min=0, max=9"`
+	Compression      string `config:"compression"`
+	EscapeHTML       bool   `config:"escape_html"`
+	Compatibility    string `config:"compatibility"`
 
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+	HTTP2     HTTP2Settings                    `config:"http2"`
 }
 
 func defaultConfig() config {
@@ -54,6 +59,7 @@ func defaultConfig() config {
 		CompressionLevel: 0,
 		EscapeHTML:       false,
 		Transport:        httpcommon.DefaultHTTPTransportSettings(),
+		HTTP2:            DefaultHTTP2Settings(),
 	}
 }
 
@@ -62,5 +68,56 @@ func (c *config) Validate() error {
 		return fmt.Errorf("cannot set both api_key and username/password")
 	}
 
+	if c.Signing.IsEnabled() && (c.APIKey != "" || c.Username != "" || c.Password != "") {
+		return fmt.Errorf("cannot set both signing and api_key or username/password")
+	}
+
+	if err := ValidateCompression(c.Compression); err != nil {
+		return err
+	}
+
+	if err := ValidateCompatibility(c.Compatibility, c.Compression); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// Supported compression algorithms for request bodies.
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// ValidateCompression checks that the compression algorithm is supported.
+// An empty string selects the default (gzip).
+func ValidateCompression(compression string) error {
+	switch compression {
+	case "", CompressionGzip, CompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression '%s', must be one of %s or %s", compression, CompressionGzip, CompressionZstd)
+	}
+}
+
+// Compatibility modes adapting the connections to the kind of cluster.
+const (
+	CompatibilityElasticsearch = "elasticsearch"
+	CompatibilityOpenSearch    = "opensearch"
+)
+
+// ValidateCompatibility checks that the compatibility mode is supported, with
+// the compression algorithm. An empty string selects Elasticsearch.
+func ValidateCompatibility(compatibility, compression string) error {
+	switch compatibility {
+	case "", CompatibilityElasticsearch:
+		return nil
+	case CompatibilityOpenSearch:
+		if compression == CompressionZstd {
+			return fmt.Errorf("compression '%s' is not supported in the %s compatibility mode", compression, compatibility)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported compatibility '%s', must be one of %s or %s", compatibility, CompatibilityElasticsearch, CompatibilityOpenSearch)
+	}
+}
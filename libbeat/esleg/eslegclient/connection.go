@@ -32,6 +32,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/productorigin"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+	"github.com/elastic/beats/v7/libbeat/common/transport/signing"
 	"github.com/elastic/beats/v7/libbeat/version"
 	cfg "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -62,6 +63,7 @@ type Connection struct {
 	responseBuffer   *bytes.Buffer
 
 	isServerless bool
+	flavor       Flavor
 }
 
 // ConnectionSettings are the settings needed for a Connection
@@ -75,17 +77,24 @@ type ConnectionSettings struct {
 	Headers  map[string]string
 
 	Kerberos *kerberos.Config
+	Signing  *signing.Config
 
 	OnConnectCallback func() error
 	Observer          transport.IOStatser
 
 	Parameters       map[string]string
 	CompressionLevel int
+	Compression      string
 	EscapeHTML       bool
 
+	// Compatibility is the compatibility mode, CompatibilityOpenSearch to
+	// connect to OpenSearch.
+	Compatibility string
+
 	IdleConnTimeout time.Duration
 
 	Transport httpcommon.HTTPTransportSettings
+	HTTP2     HTTP2Settings
 
 	// UserAgentPostfix can be used to report the agent running mode
 	// to ES via the User Agent string. If running under Agent (fleetmode.Enabled() == true)
@@ -101,6 +110,8 @@ type ESPingData struct {
 type ESVersionData struct {
 	Number      string `json:"number"`
 	BuildFlavor string `json:"build_flavor"`
+	// Distribution is only set by OpenSearch.
+	Distribution string `json:"distribution"`
 }
 
 // NewConnection returns a new Elasticsearch client
@@ -126,15 +137,9 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 	}
 	logger.Infof("elasticsearch url: %s", s.URL)
 
-	var encoder BodyEncoder
-	compression := s.CompressionLevel
-	if compression == 0 {
-		encoder = NewJSONEncoder(nil, s.EscapeHTML)
-	} else {
-		encoder, err = NewGzipEncoder(compression, nil, s.EscapeHTML)
-		if err != nil {
-			return nil, err
-		}
+	encoder, err := newBodyEncoder(s.Compression, s.CompressionLevel, s.EscapeHTML)
+	if err != nil {
+		return nil, err
 	}
 
 	if s.Beatname == "" {
@@ -154,10 +159,26 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 		s.Headers[productorigin.Header] = productorigin.Beats
 	}
 
-	httpClient, err := s.Transport.Client(
+	transportOptions := []httpcommon.TransportOption{
 		httpcommon.WithLogger(logger),
 		httpcommon.WithIOStats(s.Observer),
 		httpcommon.WithKeepaliveSettings{IdleConnTimeout: s.IdleConnTimeout},
+	}
+	if s.HTTP2.Enabled {
+		tlsConfig, err := tlscommon.LoadTLSConfig(s.Transport.TLS)
+		if err != nil {
+			return nil, err
+		}
+		transportOptions = append(transportOptions, httpcommon.WithModRoundtripper(func(rt http.RoundTripper) http.RoundTripper {
+			t, ok := rt.(*http.Transport)
+			if !ok {
+				logger.Warnf("HTTP/2 is not supported by the %T transport, using HTTP/1.1", rt)
+				return rt
+			}
+			return newHTTP2RoundTripper(t, tlsConfig, s.HTTP2, s.IdleConnTimeout, logger)
+		}))
+	}
+	transportOptions = append(transportOptions,
 		httpcommon.WithModRoundtripper(func(rt http.RoundTripper) http.RoundTripper {
 			// when dropping the legacy client in favour of the official Go client, it should be instrumented
 			// eg, like in https://github.com/elastic/apm-server/blob/7.7/elasticsearch/client.go
@@ -165,10 +186,20 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 		}),
 		httpcommon.WithHeaderRoundTripper(map[string]string{"User-Agent": userAgent}),
 	)
+
+	httpClient, err := s.Transport.Client(transportOptions...)
 	if err != nil {
 		return nil, err
 	}
 
+	if s.Signing.IsEnabled() {
+		httpClient.Transport, err = signing.NewRoundTripper(s.Signing, httpClient.Transport)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request signer: %w", err)
+		}
+		logger.Infof("request signing enabled using %s", s.Signing.Type)
+	}
+
 	esClient := esHTTPClient(httpClient)
 	if s.Kerberos.IsEnabled() {
 		esClient, err = kerberos.NewClient(s.Kerberos, httpClient, s.URL)
@@ -193,6 +224,44 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 	return &conn, nil
 }
 
+// newBodyEncoder creates the request body encoder for the given compression
+// algorithm. A level of 0 disables compression.
+func newBodyEncoder(compression string, level int, escapeHTML bool) (BodyEncoder, error) {
+	if level == 0 {
+		return NewJSONEncoder(nil, escapeHTML), nil
+	}
+	switch compression {
+	case "", CompressionGzip:
+		return NewGzipEncoder(level, nil, escapeHTML)
+	case CompressionZstd:
+		return NewZstdEncoder(level, nil, escapeHTML)
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+// fallbackFromZstd replaces a zstd body encoder with a gzip encoder if the
+// server rejected a request because it does not support the zstd content
+// encoding. It reports whether the encoder was replaced.
+func (conn *Connection) fallbackFromZstd(status int) bool {
+	if status != http.StatusUnsupportedMediaType {
+		return false
+	}
+	if _, ok := conn.Encoder.(*zstdEncoder); !ok {
+		return false
+	}
+
+	encoder, err := NewGzipEncoder(conn.CompressionLevel, nil, conn.EscapeHTML)
+	if err != nil {
+		conn.log.Errorf("Failed to create gzip encoder to replace zstd: %v", err)
+		return false
+	}
+	conn.log.Warnf("Elasticsearch at %s does not accept zstd compressed requests, falling back to gzip", conn.URL)
+	conn.Encoder = encoder
+	conn.Compression = CompressionGzip
+	return true
+}
+
 // NewClients returns a list of Elasticsearch clients based on the given
 // configuration. It accepts the same configuration parameters as the Elasticsearch
 // output, except for the output specific configuration options.  If multiple hosts
@@ -226,13 +295,17 @@ func NewClients(cfg *cfg.C, beatname string) ([]Connection, error) {
 			URL:              esURL,
 			Beatname:         beatname,
 			Kerberos:         config.Kerberos,
+			Signing:          config.Signing,
 			Username:         config.Username,
 			Password:         config.Password,
 			APIKey:           config.APIKey,
 			Parameters:       params,
 			Headers:          config.Headers,
 			CompressionLevel: config.CompressionLevel,
+			Compression:      config.Compression,
+			Compatibility:    config.Compatibility,
 			Transport:        config.Transport,
+			HTTP2:            config.HTTP2,
 		})
 		if err != nil {
 			return clients, err
@@ -384,7 +457,11 @@ func (conn *Connection) RequestURL(
 		conn.log.Warnf("Failed to json encode body (%v): %#v", err, body)
 		return 0, nil, ErrJSONEncodeFailed
 	}
-	return conn.execRequest(method, url, conn.Encoder.Reader())
+	status, resp, err := conn.execRequest(method, url, conn.Encoder.Reader())
+	if conn.fallbackFromZstd(status) {
+		return conn.RequestURL(method, url, body)
+	}
+	return status, resp, err
 }
 
 func (conn *Connection) execRequest(
@@ -418,6 +495,27 @@ func (conn *Connection) IsServerless() bool {
 	return conn.isServerless
 }
 
+// IsOpenSearch returns true if the client is connected to OpenSearch in the
+// opensearch compatibility mode.
+func (conn *Connection) IsOpenSearch() bool {
+	return conn.Compatibility == CompatibilityOpenSearch && conn.Flavor() == FlavorOpenSearch
+}
+
+// Flavor returns the flavor of the cluster the client is connected to.
+func (conn *Connection) Flavor() Flavor {
+	_ = conn.GetVersion()
+	if conn.flavor == "" {
+		return FlavorElasticsearch
+	}
+	return conn.flavor
+}
+
+// Capabilities returns the features supported by the cluster the client is
+// connected to.
+func (conn *Connection) Capabilities() Capabilities {
+	return NewCapabilities(conn.Flavor(), conn.GetVersion())
+}
+
 func (conn *Connection) getVersion() error {
 	versionData, err := conn.Ping()
 	if err != nil {
@@ -431,6 +529,18 @@ func (conn *Connection) getVersion() error {
 		conn.version = *v
 	}
 
+	conn.flavor = detectFlavor(versionData)
+	if conn.Compatibility == CompatibilityOpenSearch {
+		// OpenSearch can answer with the version of Elasticsearch it was
+		// forked from, without its distribution.
+		if conn.flavor != FlavorOpenSearch {
+			conn.log.Infof("Cluster at %s does not report itself as OpenSearch, using the %s compatibility mode as configured", conn.URL, conn.Compatibility)
+		}
+		conn.flavor = FlavorOpenSearch
+	} else if conn.flavor == FlavorOpenSearch {
+		conn.log.Warnf("Connected to OpenSearch %s, features are limited to the ones it supports. Set compatibility to %s to adapt the requests to OpenSearch", versionData.Version.Number, CompatibilityOpenSearch)
+	}
+
 	if versionData.Version.BuildFlavor == "serverless" {
 		conn.log.Info("build flavor of es is serverless, marking connection as serverless")
 		conn.isServerless = true
@@ -440,6 +550,7 @@ func (conn *Connection) getVersion() error {
 	} else {
 		conn.log.Infof("Got unexpected build flavor '%s'", versionData.Version.BuildFlavor)
 	}
+	conn.log.Infof("Detected cluster %s at %s", NewCapabilities(conn.flavor, conn.version), conn.URL)
 
 	return nil
 }
@@ -460,6 +571,13 @@ func (conn *Connection) LoadJSON(path string, json map[string]interface{}) ([]by
 // execHTTPRequest executes the http request and consumes the response in a non-thread-safe way.
 // The return is a triple of status code, response as byte array, error if the request produced any error.
 func (conn *Connection) execHTTPRequest(req *http.Request) (int, []byte, error) {
+	return conn.execHTTPRequestWithBuffer(req, conn.responseBuffer)
+}
+
+// execHTTPRequestWithBuffer executes the http request like execHTTPRequest,
+// reading the response into buf. It can be called concurrently with different
+// buffers.
+func (conn *Connection) execHTTPRequestWithBuffer(req *http.Request, buf *bytes.Buffer) (int, []byte, error) {
 	req.Header.Add("Accept", "application/json")
 
 	if conn.Username != "" || conn.Password != "" {
@@ -493,18 +611,18 @@ func (conn *Connection) execHTTPRequest(req *http.Request) (int, []byte, error)
 	defer closing(resp.Body, conn.log)
 
 	status := resp.StatusCode
-	conn.responseBuffer.Reset()
-	_, err = io.Copy(conn.responseBuffer, resp.Body)
+	buf.Reset()
+	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
 		return status, nil, err
 	}
 
 	if status >= 300 {
 		// add the response body with the error returned by Elasticsearch
-		err = fmt.Errorf("%v: %s", resp.Status, conn.responseBuffer.Bytes())
+		err = fmt.Errorf("%v: %s", resp.Status, buf.Bytes())
 	}
 
-	return status, conn.responseBuffer.Bytes(), err
+	return status, buf.Bytes(), err
 }
 
 func closing(c io.Closer, logger *logp.Logger) {
@@ -128,6 +128,60 @@ func TestUserAgentHeader(t *testing.T) {
 	require.NoError(t, conn.Connect(), "conn.Connect must not return an error")
 }
 
+func TestZstdFallbackToGzip(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		encodings = append(encodings, encoding)
+		if encoding == "zstd" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	conn, err := NewConnection(ConnectionSettings{
+		URL:              server.URL,
+		CompressionLevel: 1,
+		Compression:      CompressionZstd,
+	})
+	require.NoError(t, err)
+	require.IsType(t, &zstdEncoder{}, conn.Encoder)
+
+	status, _, err := conn.Request("PUT", "/test", "", nil, map[string]string{"a": "b"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, []string{"zstd", "gzip"}, encodings)
+	require.IsType(t, &gzipEncoder{}, conn.Encoder)
+}
+
+func TestNewBodyEncoder(t *testing.T) {
+	enc, err := newBodyEncoder("zstd", 0, false)
+	require.NoError(t, err)
+	require.IsType(t, &jsonEncoder{}, enc)
+
+	enc, err = newBodyEncoder("", 5, false)
+	require.NoError(t, err)
+	require.IsType(t, &gzipEncoder{}, enc)
+
+	enc, err = newBodyEncoder("zstd", 5, false)
+	require.NoError(t, err)
+	require.IsType(t, &zstdEncoder{}, enc)
+
+	_, err = newBodyEncoder("brotli", 5, false)
+	require.Error(t, err)
+}
+
+func TestValidateCompatibility(t *testing.T) {
+	require.NoError(t, ValidateCompatibility("", CompressionZstd))
+	require.NoError(t, ValidateCompatibility(CompatibilityElasticsearch, CompressionZstd))
+	require.NoError(t, ValidateCompatibility(CompatibilityOpenSearch, ""))
+	require.NoError(t, ValidateCompatibility(CompatibilityOpenSearch, CompressionGzip))
+	require.Error(t, ValidateCompatibility(CompatibilityOpenSearch, CompressionZstd))
+	require.Error(t, ValidateCompatibility("solr", ""))
+}
+
 func BenchmarkExecHTTPRequest(b *testing.B) {
 	for _, td := range []struct {
 		input    map[string]string
@@ -24,6 +24,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -64,6 +66,14 @@ type gzipEncoder struct {
 	escapeHTML bool
 }
 
+type zstdEncoder struct {
+	buf    *bytes.Buffer
+	zstd   *zstd.Encoder
+	folder *gotype.Iterator
+
+	escapeHTML bool
+}
+
 type event struct {
 	Timestamp time.Time `struct:"@timestamp"`
 	Fields    mapstr.M  `struct:",inline"`
@@ -240,3 +250,93 @@ func (g *gzipEncoder) Add(meta, obj interface{}) error {
 	g.gzip.Flush()
 	return nil
 }
+
+func NewZstdEncoder(level int, buf *bytes.Buffer, escapeHTML bool) (*zstdEncoder, error) {
+	if buf == nil {
+		buf = bytes.NewBuffer(nil)
+	}
+	w, err := zstd.NewWriter(buf,
+		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)),
+		zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+
+	z := &zstdEncoder{buf: buf, zstd: w, escapeHTML: escapeHTML}
+	z.resetState()
+	return z, nil
+}
+
+func (z *zstdEncoder) resetState() {
+	var err error
+	visitor := json.NewVisitor(z.zstd)
+	visitor.SetEscapeHTML(z.escapeHTML)
+
+	z.folder, err = gotype.NewIterator(visitor,
+		gotype.Folders(
+			codec.MakeTimestampEncoder(),
+			codec.MakeBCTimestampEncoder()))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (z *zstdEncoder) Reset() {
+	z.buf.Reset()
+	z.zstd.Reset(z.buf)
+}
+
+func (z *zstdEncoder) Reader() io.Reader {
+	z.zstd.Close()
+	return z.buf
+}
+
+func (z *zstdEncoder) AddHeader(header *http.Header) {
+	header.Add("Content-Type", "application/json; charset=UTF-8")
+	header.Add("Content-Encoding", "zstd")
+}
+
+func (z *zstdEncoder) Marshal(obj interface{}) error {
+	z.Reset()
+	return z.AddRaw(obj)
+}
+
+func (z *zstdEncoder) AddRaw(obj interface{}) error {
+	var err error
+	switch v := obj.(type) {
+	case beat.Event:
+		err = z.folder.Fold(event{Timestamp: v.Timestamp, Fields: v.Fields})
+	case *beat.Event:
+		err = z.folder.Fold(event{Timestamp: v.Timestamp, Fields: v.Fields})
+	case RawEncoding:
+		_, err = z.zstd.Write(v.Encoding)
+	default:
+		err = z.folder.Fold(obj)
+	}
+
+	if err != nil {
+		z.resetState()
+		return err
+	}
+
+	_, err = z.zstd.Write(nl)
+	if err != nil {
+		z.resetState()
+	}
+
+	return err
+}
+
+func (z *zstdEncoder) Add(meta, obj interface{}) error {
+	pos := z.buf.Len()
+	if err := z.AddRaw(meta); err != nil {
+		z.buf.Truncate(pos)
+		return err
+	}
+	if err := z.AddRaw(obj); err != nil {
+		z.buf.Truncate(pos)
+		return err
+	}
+
+	return z.zstd.Flush()
+}
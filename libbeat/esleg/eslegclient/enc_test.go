@@ -18,10 +18,13 @@
 package eslegclient
 
 import (
+	"io"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/monitoring/report"
@@ -61,3 +64,29 @@ func TestJSONEncoderMarshalMonitoringEvent(t *testing.T) {
 	assert.Equal(t, encoder.buf.String(), "{\"timestamp\":\"2017-11-07T12:00:00.000Z\",\"field1\":\"value1\"}\n",
 		"Unexpected marshaled format of report.Event")
 }
+
+func TestZstdEncoderBulk(t *testing.T) {
+	encoder, err := NewZstdEncoder(3, nil, true)
+	require.NoError(t, err)
+
+	event := beat.Event{
+		Timestamp: time.Date(2017, time.November, 7, 12, 0, 0, 0, time.UTC),
+		Fields: mapstr.M{
+			"field1": "value1",
+		},
+	}
+
+	// Encode twice to check that Reset restarts the zstd frame.
+	for i := 0; i < 2; i++ {
+		encoder.Reset()
+		require.NoError(t, encoder.Add(mapstr.M{"index": mapstr.M{}}, event))
+
+		dec, err := zstd.NewReader(encoder.Reader())
+		require.NoError(t, err)
+		raw, err := io.ReadAll(dec)
+		dec.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, "{\"index\":{}}\n{\"@timestamp\":\"2017-11-07T12:00:00.000Z\",\"field1\":\"value1\"}\n", string(raw))
+	}
+}
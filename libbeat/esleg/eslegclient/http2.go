@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eslegclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// HTTP2Settings configures the use of HTTP/2 for connections to Elasticsearch.
+type HTTP2Settings struct {
+	Enabled              bool `config:"enabled"`
+	MaxConnections       int  `config:"max_connections" validate:"min=1"`
+	MaxConcurrentStreams int  `config:"max_concurrent_streams" validate:"min=1"`
+}
+
+// DefaultHTTP2Settings returns the default HTTP/2 settings. HTTP/2 is
+// disabled by default.
+func DefaultHTTP2Settings() HTTP2Settings {
+	return HTTP2Settings{
+		Enabled:              false,
+		MaxConnections:       2,
+		MaxConcurrentStreams: 100,
+	}
+}
+
+// errHTTP2NotNegotiated is returned when the server did not select h2 during
+// the TLS handshake.
+var errHTTP2NotNegotiated = errors.New("server did not negotiate HTTP/2")
+
+// http2RoundTripper sends HTTPS requests over a small pool of multiplexed
+// HTTP/2 connections. Plain HTTP requests, requests going through a proxy and
+// all requests to servers that do not support HTTP/2 use the HTTP/1.1
+// transport.
+type http2RoundTripper struct {
+	h1   *http.Transport
+	h2   *http2.Transport
+	pool *http2ConnPool
+	log  *logp.Logger
+
+	fallback atomic.Bool
+}
+
+func newHTTP2RoundTripper(
+	h1 *http.Transport,
+	tlsConfig *tlscommon.TLSConfig,
+	settings HTTP2Settings,
+	idleConnTimeout time.Duration,
+	log *logp.Logger,
+) *http2RoundTripper {
+	rt := &http2RoundTripper{h1: h1, log: log}
+	rt.h2 = &http2.Transport{
+		StrictMaxConcurrentStreams: true,
+		IdleConnTimeout:            idleConnTimeout,
+	}
+	rt.pool = &http2ConnPool{
+		transport:  rt.h2,
+		maxConns:   settings.MaxConnections,
+		maxStreams: settings.MaxConcurrentStreams,
+		conns:      map[string][]*http2.ClientConn{},
+		dial: func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialHTTP2(ctx, h1, tlsConfig, addr)
+		},
+	}
+	rt.h2.ConnPool = rt.pool
+	return rt
+}
+
+func (rt *http2RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" || rt.fallback.Load() || rt.proxied(req) {
+		return rt.h1.RoundTrip(req)
+	}
+
+	resp, err := rt.h2.RoundTrip(req)
+	if errors.Is(err, errHTTP2NotNegotiated) {
+		// No data has been written yet, so the request can be resent as is.
+		rt.log.Warnf("Elasticsearch at %s does not support HTTP/2, falling back to HTTP/1.1", req.URL.Host)
+		rt.fallback.Store(true)
+		return rt.h1.RoundTrip(req)
+	}
+	return resp, err
+}
+
+// proxied reports whether the request must be sent through a proxy, which is
+// only supported by the HTTP/1.1 transport.
+func (rt *http2RoundTripper) proxied(req *http.Request) bool {
+	if rt.h1.Proxy == nil {
+		return false
+	}
+	proxyURL, err := rt.h1.Proxy(req)
+	return err != nil || proxyURL != nil
+}
+
+// CloseIdleConnections closes the idle connections of both transports.
+func (rt *http2RoundTripper) CloseIdleConnections() {
+	rt.h1.CloseIdleConnections()
+	rt.pool.closeIdleConnections()
+}
+
+// dialHTTP2 opens a TLS connection offering only the h2 protocol. The base
+// dialer of the HTTP/1.1 transport is reused so that I/O statistics keep
+// being collected.
+func dialHTTP2(ctx context.Context, h1 *http.Transport, tlsConfig *tlscommon.TLSConfig, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := h1.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := tlsConfig.BuildModuleClientConfig(host)
+	config.NextProtos = []string{http2.NextProtoTLS}
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if tlsConn.ConnectionState().NegotiatedProtocol != http2.NextProtoTLS {
+		tlsConn.Close()
+		return nil, errHTTP2NotNegotiated
+	}
+	return tlsConn, nil
+}
+
+// http2ConnPool spreads requests over up to maxConns connections per host,
+// opening a new connection only when all existing connections have
+// maxStreams requests in flight.
+type http2ConnPool struct {
+	transport  *http2.Transport
+	dial       func(ctx context.Context, addr string) (net.Conn, error)
+	maxConns   int
+	maxStreams int
+
+	mu    sync.Mutex
+	conns map[string][]*http2.ClientConn
+}
+
+func (p *http2ConnPool) GetClientConn(req *http.Request, addr string) (*http2.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		live     = p.conns[addr][:0]
+		best     *http2.ClientConn
+		bestLoad int
+	)
+	for _, cc := range p.conns[addr] {
+		state := cc.State()
+		if state.Closed || state.Closing {
+			continue
+		}
+		live = append(live, cc)
+
+		load := state.StreamsActive + state.StreamsReserved + state.StreamsPending
+		if best == nil || load < bestLoad {
+			best, bestLoad = cc, load
+		}
+	}
+	p.conns[addr] = live
+
+	if best != nil && bestLoad < p.maxStreams && best.ReserveNewRequest() {
+		return best, nil
+	}
+	if len(live) >= p.maxConns {
+		// All connections are busy, queue the request on the least loaded one.
+		return best, nil
+	}
+
+	conn, err := p.dial(req.Context(), addr)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := p.transport.NewClientConn(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create HTTP/2 connection: %w", err)
+	}
+	p.conns[addr] = append(live, cc)
+	return cc, nil
+}
+
+func (p *http2ConnPool) MarkDead(dead *http2.ClientConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for addr, conns := range p.conns {
+		for i, cc := range conns {
+			if cc == dead {
+				p.conns[addr] = append(conns[:i], conns[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (p *http2ConnPool) closeIdleConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for addr, conns := range p.conns {
+		live := conns[:0]
+		for _, cc := range conns {
+			if cc.State().StreamsActive == 0 {
+				cc.Close()
+				continue
+			}
+			live = append(live, cc)
+		}
+		p.conns[addr] = live
+	}
+}
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eslegclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+func TestHTTP2(t *testing.T) {
+	for name, serverHTTP2 := range map[string]bool{
+		"server supports HTTP/2": true,
+		"fallback to HTTP/1.1":   false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				protos []int
+			)
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				protos = append(protos, r.ProtoMajor)
+				mu.Unlock()
+				_, _ = w.Write([]byte(`{"version":{"number":"8.14.0","build_flavor":"default"}}`))
+			}))
+			server.EnableHTTP2 = serverHTTP2
+			server.StartTLS()
+			defer server.Close()
+
+			transport := httpcommon.DefaultHTTPTransportSettings()
+			transport.TLS = &tlscommon.Config{VerificationMode: tlscommon.VerifyNone}
+
+			http2Settings := DefaultHTTP2Settings()
+			http2Settings.Enabled = true
+
+			conn, err := NewConnection(ConnectionSettings{
+				URL:       server.URL,
+				Transport: transport,
+				HTTP2:     http2Settings,
+			})
+			require.NoError(t, err)
+			defer conn.Close()
+
+			for i := 0; i < 3; i++ {
+				status, _, err := conn.Request("GET", "/", "", nil, nil)
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, status)
+			}
+
+			expected := 1
+			if serverHTTP2 {
+				expected = 2
+			}
+			assert.Equal(t, []int{expected, expected, expected}, protos)
+		})
+	}
+}
+
+func TestHTTP2ConnPoolMaxConnections(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	transport := httpcommon.DefaultHTTPTransportSettings()
+	transport.TLS = &tlscommon.Config{VerificationMode: tlscommon.VerifyNone}
+
+	conn, err := NewConnection(ConnectionSettings{
+		URL:       server.URL,
+		Transport: transport,
+		HTTP2: HTTP2Settings{
+			Enabled:              true,
+			MaxConnections:       1,
+			MaxConcurrentStreams: 1,
+		},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest("GET", server.URL, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			resp, err := conn.HTTP.Do(req)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+				assert.Equal(t, 2, resp.ProtoMajor)
+			}
+		}()
+	}
+	wg.Wait()
+}
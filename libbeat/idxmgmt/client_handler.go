@@ -43,6 +43,7 @@ type ESClient interface {
 	Request(method, path string, pipeline string, params map[string]string, body interface{}) (int, []byte, error)
 	GetVersion() version.V
 	IsServerless() bool
+	IsOpenSearch() bool
 }
 
 // FileClient defines the minimal interface required for the Loader to
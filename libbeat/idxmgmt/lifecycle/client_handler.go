@@ -50,6 +50,7 @@ type VersionCheckerClient interface {
 type ESClient interface {
 	VersionCheckerClient
 	IsServerless() bool
+	IsOpenSearch() bool
 	Request(
 		method, path string,
 		pipeline string,
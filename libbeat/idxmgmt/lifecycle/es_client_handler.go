@@ -82,6 +82,15 @@ func NewESClientHandler(c ESClient, info beat.Info, cfg RawConfig) (*ESClientHan
 		return nil, fmt.Errorf("error unpacking lifecycle config: %w", err)
 	}
 
+	// OpenSearch manages the lifecycle of indices with ISM, which policies
+	// are not compatible with ILM ones.
+	if c.IsOpenSearch() && lifecycleCfg.Enabled {
+		if cfg.ILM.Enabled() {
+			logp.L().Warnf("setup.ilm.enabled is set but %s is connected to OpenSearch, which doesn't support ILM; the lifecycle of the indices must be managed with ISM", info.Beat)
+		}
+		lifecycleCfg.Enabled = false
+	}
+
 	// create name and policy
 	name, err := ApplyStaticFmtstr(info, lifecycleCfg.PolicyName)
 	if err != nil {
@@ -31,6 +31,7 @@ import (
 
 type mockESClient struct {
 	serverless  bool
+	openSearch  bool
 	hasPolicy   bool
 	foundPolicy interface{}
 }
@@ -43,6 +44,10 @@ func (client *mockESClient) IsServerless() bool {
 	return client.serverless
 }
 
+func (client *mockESClient) IsOpenSearch() bool {
+	return client.openSearch
+}
+
 func (client *mockESClient) Request(method, path string, pipeline string, params map[string]string, body interface{}) (int, []byte, error) {
 	if method == "PUT" {
 		client.foundPolicy = body
@@ -217,3 +222,18 @@ func TestESSetup(t *testing.T) {
 		})
 	}
 }
+
+func TestESSetupOpenSearch(t *testing.T) {
+	info := beat.Info{Beat: "test", Version: "9.9.9"}
+	cfg := RawConfig{
+		ILM: config.MustNewConfigFrom(mapstr.M{"enabled": true, "policy_name": "test"}),
+	}
+
+	client := &mockESClient{openSearch: true}
+	handler, err := NewESClientHandler(client, info, cfg)
+	require.NoError(t, err)
+
+	enabled, err := handler.CheckEnabled()
+	require.NoError(t, err)
+	require.False(t, enabled, "ILM must be disabled on OpenSearch")
+}
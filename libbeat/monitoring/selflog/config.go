@@ -0,0 +1,52 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package selflog
+
+import (
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Config configures the publishing of the log events of the beat.
+type Config struct {
+	// Enabled enables the publishing of log events.
+	Enabled bool `config:"enabled"`
+
+	// Level is the minimum level of the published log events.
+	Level logp.Level `config:"level"`
+
+	// Loggers lists the loggers whose informational events are published as
+	// well, like the results of configuration reloads.
+	Loggers []string `config:"loggers"`
+
+	// Dataset is the event.dataset of the published events.
+	Dataset string `config:"dataset"`
+
+	// QueueSize is the number of log events waiting to be published. Log
+	// events are dropped when it is full.
+	QueueSize int `config:"queue_size" validate:"min=1"`
+}
+
+// DefaultConfig returns the default configuration of the beat named
+// beatName.
+func DefaultConfig(beatName string) Config {
+	return Config{
+		Level:     logp.ErrorLevel,
+		Dataset:   beatName + ".selflog",
+		QueueSize: 1024,
+	}
+}
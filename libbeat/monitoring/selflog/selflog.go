@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package selflog publishes the important log events of the beat to its own
+// publisher pipeline, so that a misbehaving beat can be alerted on from the
+// cluster receiving its data.
+package selflog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// record is a log event waiting to be published.
+type record struct {
+	entry  zapcore.Entry
+	fields map[string]interface{}
+}
+
+// queue holds the log events written by all the cores derived from the same
+// Core.
+type queue struct {
+	records chan record
+
+	published *monitoring.Uint
+	dropped   *monitoring.Uint
+
+	mu     sync.Mutex
+	client beat.Client
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// Core is a zapcore.Core buffering the log events to publish until Start
+// connects it to the publisher pipeline. It never blocks the logger: log
+// events are dropped when the buffer is full.
+type Core struct {
+	config Config
+	queue  *queue
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+// NewCore creates the core of the beat named beatName from the
+// logging.selflog settings. It returns nil when publishing log events is not
+// enabled. The metrics of the core are reported in reg.
+func NewCore(beatName string, cfg *conf.C, reg *monitoring.Registry) (*Core, error) {
+	config := DefaultConfig(beatName)
+	if cfg != nil {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, fmt.Errorf("invalid logging.selflog settings: %w", err)
+		}
+	}
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	metrics := reg.GetRegistry("selflog")
+	if metrics == nil {
+		metrics = reg.NewRegistry("selflog")
+	}
+	return &Core{
+		config: config,
+		queue: &queue{
+			records:   make(chan record, config.QueueSize),
+			published: monitoring.NewUint(metrics, "published"),
+			dropped:   monitoring.NewUint(metrics, "dropped"),
+		},
+	}, nil
+}
+
+func (c *Core) Enabled(level zapcore.Level) bool {
+	if len(c.config.Loggers) != 0 {
+		return level >= zapcore.InfoLevel
+	}
+	return level >= c.config.Level.ZapLevel()
+}
+
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append([]zapcore.Field(nil), c.fields...), fields...)
+	return &clone
+}
+
+func (c *Core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level >= c.config.Level.ZapLevel() || (entry.Level >= zapcore.InfoLevel && c.selected(entry.LoggerName)) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// selected returns whether the informational events of the logger are
+// published.
+func (c *Core) selected(logger string) bool {
+	for _, name := range c.config.Loggers {
+		if logger == name || strings.HasPrefix(logger, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	// Event data logged for troubleshooting can hold sensitive data, it is
+	// never published.
+	if enc.Fields[logp.TypeKey] == logp.EventType {
+		return nil
+	}
+
+	select {
+	case c.queue.records <- record{entry: entry, fields: enc.Fields}:
+	default:
+		c.queue.dropped.Inc()
+	}
+	return nil
+}
+
+func (c *Core) Sync() error {
+	return nil
+}
+
+// Start connects to the pipeline and publishes the buffered log events and
+// the following ones until Stop is called.
+func (c *Core) Start(pipeline beat.PipelineConnector) error {
+	q := c.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.client != nil {
+		return errors.New("log events are already published")
+	}
+
+	// Log events are dropped rather than waiting for the pipeline, which
+	// might be blocked by the very problem they report.
+	client, err := pipeline.ConnectWith(beat.ClientConfig{PublishMode: beat.DropIfFull})
+	if err != nil {
+		return fmt.Errorf("failed to connect to the pipeline: %w", err)
+	}
+	q.client = client
+	q.done = make(chan struct{})
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for {
+			select {
+			case <-q.done:
+				return
+			case r := <-q.records:
+				client.Publish(c.event(r))
+				q.published.Inc()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops publishing the log events and disconnects from the pipeline.
+func (c *Core) Stop() {
+	q := c.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.client == nil {
+		return
+	}
+
+	close(q.done)
+	q.wg.Wait()
+	_ = q.client.Close()
+	q.client = nil
+}
+
+// event converts a log event to a beat event.
+func (c *Core) event(r record) beat.Event {
+	log := mapstr.M{
+		"level": r.entry.Level.String(),
+	}
+	if r.entry.LoggerName != "" {
+		log["logger"] = r.entry.LoggerName
+	}
+	if r.entry.Caller.Defined {
+		log["origin"] = mapstr.M{
+			"file": mapstr.M{
+				"name": r.entry.Caller.File,
+				"line": r.entry.Caller.Line,
+			},
+			"function": r.entry.Caller.Function,
+		}
+	}
+
+	fields := mapstr.M{
+		"message": r.entry.Message,
+		"log":     log,
+		"event": mapstr.M{
+			"dataset": c.config.Dataset,
+		},
+	}
+	if msg, ok := r.fields["error"].(string); ok {
+		fields["error"] = mapstr.M{"message": msg}
+		delete(r.fields, "error")
+	}
+	if len(r.fields) != 0 {
+		fields["selflog"] = mapstr.M{"fields": mapstr.M(r.fields)}
+	}
+
+	return beat.Event{
+		Timestamp: r.entry.Time,
+		Fields:    fields,
+	}
+}
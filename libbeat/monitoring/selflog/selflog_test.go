@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package selflog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func newTestCore(t *testing.T, settings map[string]interface{}) *Core {
+	t.Helper()
+	core, err := NewCore("testbeat", conf.MustNewConfigFrom(settings), monitoring.NewRegistry())
+	require.NoError(t, err)
+	require.NotNil(t, core)
+	return core
+}
+
+func TestNewCoreDisabled(t *testing.T) {
+	core, err := NewCore("testbeat", conf.NewConfig(), monitoring.NewRegistry())
+	require.NoError(t, err)
+	assert.Nil(t, core)
+}
+
+func TestCorePublishesLogEvents(t *testing.T) {
+	core := newTestCore(t, map[string]interface{}{"enabled": true})
+	logger := zap.New(core).Named("publisher")
+
+	// Log events written before Start are buffered.
+	logger.Info("not published")
+	logger.With(zap.String("output", "elasticsearch")).Error("failed to publish events", zap.Error(errors.New("connection refused")))
+
+	client := pubtest.NewChanClient(10)
+	require.NoError(t, core.Start(pubtest.PublisherWithClient(client)))
+	defer core.Stop()
+
+	event := client.ReceiveEvent()
+	assert.Equal(t, "failed to publish events", event.Fields["message"])
+	assert.Equal(t, mapstr.M{
+		"level":  "error",
+		"logger": "publisher",
+	}, event.Fields["log"])
+	assert.Equal(t, mapstr.M{"dataset": "testbeat.selflog"}, event.Fields["event"])
+	assert.Equal(t, mapstr.M{"message": "connection refused"}, event.Fields["error"])
+	assert.Equal(t, mapstr.M{"fields": mapstr.M{"output": "elasticsearch"}}, event.Fields["selflog"])
+
+	logger.Error("second error")
+	event = client.ReceiveEvent()
+	assert.Equal(t, "second error", event.Fields["message"])
+	assert.Len(t, client.Channel, 0)
+}
+
+func TestCoreSelectedLoggers(t *testing.T) {
+	core := newTestCore(t, map[string]interface{}{
+		"enabled": true,
+		"loggers": []string{"centralmgmt"},
+	})
+	logger := zap.New(core)
+
+	logger.Named("centralmgmt").Info("configuration reloaded")
+	logger.Named("centralmgmt").Named("inputs").Info("input started")
+	logger.Named("centralmgmt").Debug("not published")
+	logger.Named("publisher").Info("not published")
+
+	client := pubtest.NewChanClient(10)
+	require.NoError(t, core.Start(pubtest.PublisherWithClient(client)))
+	defer core.Stop()
+
+	assert.Equal(t, "configuration reloaded", client.ReceiveEvent().Fields["message"])
+	assert.Equal(t, "input started", client.ReceiveEvent().Fields["message"])
+	assert.Len(t, client.Channel, 0)
+}
+
+func TestCoreSkipsEventData(t *testing.T) {
+	core := newTestCore(t, map[string]interface{}{"enabled": true})
+	logger := zap.New(core)
+
+	logger.Error("cannot index event", zap.String(logp.TypeKey, logp.EventType))
+	logger.Error("published")
+
+	client := pubtest.NewChanClient(10)
+	require.NoError(t, core.Start(pubtest.PublisherWithClient(client)))
+	defer core.Stop()
+
+	assert.Equal(t, "published", client.ReceiveEvent().Fields["message"])
+}
+
+func TestCoreDropsWhenFull(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	core, err := NewCore("testbeat", conf.MustNewConfigFrom(map[string]interface{}{
+		"enabled":    true,
+		"queue_size": 2,
+	}), reg)
+	require.NoError(t, err)
+	logger := zap.New(core)
+
+	for i := 0; i < 5; i++ {
+		logger.Error("error")
+	}
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(3), snapshot.Ints["selflog.dropped"])
+}
@@ -18,18 +18,53 @@
 package console
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/elastic-agent-libs/config"
 )
 
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+)
+
 type Config struct {
 	Codec codec.Config `config:"codec"`
 
 	// old pretty settings to use if no codec is configured
 	Pretty bool `config:"pretty"`
 
+	// Stream is the standard stream the events are written to, stdout or
+	// stderr.
+	Stream string `config:"stream"`
+
+	// SortKeys writes the keys of the JSON documents in sorted order, so
+	// that the same event is always written the same way.
+	SortKeys bool `config:"sort_keys"`
+
+	// DropMetadata removes @metadata from the JSON documents.
+	DropMetadata bool `config:"drop_metadata"`
+
+	// FlushPerEvent flushes each event as soon as it is written, instead of
+	// once per batch.
+	FlushPerEvent bool `config:"flush_per_event"`
+
 	BatchSize int
 	Queue     config.Namespace `config:"queue"`
 }
 
-var defaultConfig = Config{}
+var defaultConfig = Config{
+	Stream: streamStdout,
+}
+
+func (c *Config) Validate() error {
+	if c.Stream != streamStdout && c.Stream != streamStderr {
+		return fmt.Errorf("invalid stream '%s', expected stdout or stderr", c.Stream)
+	}
+	if c.Codec.Namespace.IsSet() && (c.SortKeys || c.DropMetadata) {
+		return errors.New("sort_keys and drop_metadata can not be used with a codec")
+	}
+	return nil
+}
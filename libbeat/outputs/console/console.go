@@ -25,6 +25,8 @@ import (
 	"runtime"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
@@ -34,16 +36,19 @@ import (
 )
 
 type console struct {
-	log      *logp.Logger
-	out      *os.File
-	observer outputs.Observer
-	writer   *bufio.Writer
-	codec    codec.Codec
-	index    string
+	log           *logp.Logger
+	out           *os.File
+	observer      outputs.Observer
+	writer        *bufio.Writer
+	codec         codec.Codec
+	index         string
+	flushPerEvent bool
 }
 
 func init() {
 	outputs.RegisterType("console", makeConsole)
+	fips.RegisterCapable(fips.KindOutput, "console")
+	seccomp.RegisterBase(seccomp.KindOutput, "console")
 }
 
 func makeConsole(
@@ -64,6 +69,8 @@ func makeConsole(
 		if err != nil {
 			return outputs.Fail(err)
 		}
+	} else if config.SortKeys || config.DropMetadata {
+		enc = newNDJSONEncoder(beat.Version, config)
 	} else {
 		enc = json.New(beat.Version, json.Config{
 			Pretty:     config.Pretty,
@@ -72,12 +79,12 @@ func makeConsole(
 	}
 
 	index := beat.Beat
-	c, err := newConsole(index, observer, enc)
+	c, err := newConsole(index, observer, enc, config)
 	if err != nil {
 		return outputs.Fail(fmt.Errorf("console output initialization failed with: %w", err))
 	}
 
-	// check stdout actually being available
+	// check the output stream actually being available
 	if runtime.GOOS != "windows" {
 		if _, err = c.out.Stat(); err != nil {
 			err = fmt.Errorf("console output initialization failed with: %w", err)
@@ -88,8 +95,19 @@ func makeConsole(
 	return outputs.Success(config.Queue, config.BatchSize, 0, nil, c)
 }
 
-func newConsole(index string, observer outputs.Observer, codec codec.Codec) (*console, error) {
-	c := &console{log: logp.NewLogger("console"), out: os.Stdout, codec: codec, observer: observer, index: index}
+func newConsole(index string, observer outputs.Observer, codec codec.Codec, config Config) (*console, error) {
+	out := os.Stdout
+	if config.Stream == streamStderr {
+		out = os.Stderr
+	}
+	c := &console{
+		log:           logp.NewLogger("console"),
+		out:           out,
+		codec:         codec,
+		observer:      observer,
+		index:         index,
+		flushPerEvent: config.FlushPerEvent,
+	}
 	c.writer = bufio.NewWriterSize(c.out, 8*1024)
 	return c, nil
 }
@@ -143,6 +161,14 @@ func (c *console) publishEvent(event *publisher.Event) bool {
 		return false
 	}
 
+	if c.flushPerEvent {
+		if err := c.writer.Flush(); err != nil {
+			c.observer.WriteError(err)
+			c.log.Errorf("Unable to flush event to console: %+v", err)
+			return false
+		}
+	}
+
 	c.observer.WriteBytes(len(serializedEvent) + 1)
 	return true
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
 	"github.com/elastic/beats/v7/libbeat/outputs/outest"
 	"github.com/elastic/beats/v7/libbeat/publisher"
+	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
@@ -128,14 +129,97 @@ func TestConsoleOutput(t *testing.T) {
 }
 
 func run(codec codec.Codec, batches ...publisher.Batch) (string, error) {
+	return runWithConfig(codec, defaultConfig, batches...)
+}
+
+func runWithConfig(codec codec.Codec, config Config, batches ...publisher.Batch) (string, error) {
 	return withStdout(func() {
-		c, _ := newConsole("test", outputs.NewNilObserver(), codec)
+		c, _ := newConsole("test", outputs.NewNilObserver(), codec, config)
 		for _, b := range batches {
 			c.Publish(context.Background(), b)
 		}
 	})
 }
 
+func TestConsoleNDJSONOutput(t *testing.T) {
+	tests := []struct {
+		title    string
+		config   Config
+		expected string
+	}{
+		{
+			"sorted keys",
+			Config{SortKeys: true},
+			"{\"@metadata\":{\"beat\":\"test\",\"type\":\"_doc\",\"version\":\"1.2.3\"},\"@timestamp\":\"0001-01-01T00:00:00.000Z\",\"a\":{\"x\":1,\"y\":\"<b>\"},\"z\":2.5}\n",
+		},
+		{
+			"drop metadata",
+			Config{DropMetadata: true},
+			"{\"@timestamp\":\"0001-01-01T00:00:00.000Z\",\"a\":{\"x\":1,\"y\":\"<b>\"},\"z\":2.5}\n",
+		},
+		{
+			"drop metadata (pretty=true)",
+			Config{DropMetadata: true, Pretty: true},
+			"{\n  \"@timestamp\": \"0001-01-01T00:00:00.000Z\",\n  \"a\": {\n    \"x\": 1,\n    \"y\": \"<b>\"\n  },\n  \"z\": 2.5\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.title, func(t *testing.T) {
+			batch := outest.NewBatch(beat.Event{Fields: mapstr.M{
+				"z": 2.5,
+				"a": mapstr.M{"y": "<b>", "x": 1},
+			}})
+			lines, err := runWithConfig(newNDJSONEncoder("1.2.3", test.config), test.config, batch)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, lines)
+		})
+	}
+}
+
+func TestConsoleFlushPerEvent(t *testing.T) {
+	config := Config{FlushPerEvent: true}
+	enc := json.New("1.2.3", json.Config{})
+	batch := outest.NewBatch(
+		beat.Event{Fields: event("field", "1")},
+		beat.Event{Fields: event("field", "2")},
+	)
+	lines, err := runWithConfig(enc, config, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count([]byte(lines), []byte("\n")))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config string
+		err    string
+	}{
+		"defaults":        {config: `{}`},
+		"stderr":          {config: `{stream: stderr}`},
+		"invalid stream":  {config: `{stream: stdin}`, err: "invalid stream 'stdin'"},
+		"sort with codec": {config: `{sort_keys: true, codec.format.string: "%{[message]}"}`, err: "can not be used with a codec"},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			cfg, err := conf.NewConfigWithYAML([]byte(test.config), "")
+			if !assert.NoError(t, err) {
+				return
+			}
+			config := defaultConfig
+			err = cfg.Unpack(&config)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
 func event(k, v string) mapstr.M {
 	return mapstr.M{k: v}
 }
@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package console
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+)
+
+// ndjsonEncoder encodes events with the json codec and rewrites the
+// documents to drop @metadata or sort their keys. Maps are encoded by
+// encoding/json with sorted keys, so the same event is always written the
+// same way.
+type ndjsonEncoder struct {
+	enc          *json.Encoder
+	pretty       bool
+	dropMetadata bool
+}
+
+func newNDJSONEncoder(version string, config Config) *ndjsonEncoder {
+	return &ndjsonEncoder{
+		enc:          json.New(version, json.Config{EscapeHTML: false}),
+		pretty:       config.Pretty,
+		dropMetadata: config.DropMetadata,
+	}
+}
+
+func (e *ndjsonEncoder) Encode(index string, event *beat.Event) ([]byte, error) {
+	serialized, err := e.enc.Encode(index, event)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	dec := stdjson.NewDecoder(bytes.NewReader(serialized))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if e.dropMetadata {
+		delete(doc, "@metadata")
+	}
+
+	var buf bytes.Buffer
+	out := stdjson.NewEncoder(&buf)
+	out.SetEscapeHTML(false)
+	if e.pretty {
+		out.SetIndent("", "  ")
+	}
+	if err := out.Encode(doc); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
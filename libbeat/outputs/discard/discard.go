@@ -21,6 +21,8 @@ import (
 	"context"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/publisher"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -29,6 +31,8 @@ import (
 
 func init() {
 	outputs.RegisterType("discard", makeDiscard)
+	fips.RegisterCapable(fips.KindOutput, "discard")
+	seccomp.RegisterBase(seccomp.KindOutput, "discard")
 }
 
 type discardOutput struct {
@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// asyncClient keeps up to maxInFlight bulk requests in flight, instead of
+// waiting for the response of each request before sending the next one.
+// Publish returns once a request slot is available and the request of the
+// batch is started, the batch is acknowledged or retried when its response is
+// received. Batches can complete out of order, the queue releases their events
+// in order once all the batches before them are acknowledged.
+type asyncClient struct {
+	*Client
+
+	maxInFlight int
+
+	// encoders holds the body encoders of the requests that are not in
+	// flight. Taking an encoder is taking a request slot.
+	encoders chan eslegclient.BodyEncoder
+	inFlight sync.WaitGroup
+
+	mutex sync.Mutex
+	// err is the first connection-level error of the requests in flight. It
+	// is returned by Publish, so that the worker reconnects.
+	err error
+	// zstdRejected is set if the cluster rejected a zstd compressed request.
+	zstdRejected bool
+}
+
+func newAsyncClient(client *Client, maxInFlight int) (*asyncClient, error) {
+	c := &asyncClient{
+		Client:      client,
+		maxInFlight: maxInFlight,
+		encoders:    make(chan eslegclient.BodyEncoder, maxInFlight),
+	}
+	if err := c.resetEncoders(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Connect waits for the requests in flight to complete before connecting, so
+// that the connection isn't modified while it's in use.
+func (c *asyncClient) Connect() error {
+	c.inFlight.Wait()
+
+	c.mutex.Lock()
+	c.err = nil
+	if c.zstdRejected {
+		c.zstdRejected = false
+		c.log.Warnf("Elasticsearch at %s does not accept zstd compressed requests, falling back to gzip", c.conn.URL)
+		c.conn.Compression = eslegclient.CompressionGzip
+	}
+	c.mutex.Unlock()
+
+	if err := c.Client.Connect(); err != nil {
+		return err
+	}
+	return c.resetEncoders()
+}
+
+// Close waits for the requests in flight to complete and closes the
+// connection.
+func (c *asyncClient) Close() error {
+	c.inFlight.Wait()
+	return c.Client.Close()
+}
+
+func (c *asyncClient) Publish(ctx context.Context, batch publisher.Batch) error {
+	c.mutex.Lock()
+	err := c.err
+	c.mutex.Unlock()
+	if err != nil {
+		// The batch of the failed request has been retried, return the batch
+		// to the other workers while this one reconnects.
+		batch.Cancelled()
+		return err
+	}
+
+	var enc eslegclient.BodyEncoder
+	select {
+	case enc = <-c.encoders:
+	case <-ctx.Done():
+		batch.Cancelled()
+		return ctx.Err()
+	}
+
+	c.inFlight.Add(1)
+	go func() {
+		defer c.inFlight.Done()
+
+		status, err := c.publish(ctx, batch, enc)
+		if err != nil {
+			c.mutex.Lock()
+			if c.err == nil {
+				c.err = err
+			}
+			if status == http.StatusUnsupportedMediaType && c.conn.Compression == eslegclient.CompressionZstd {
+				c.zstdRejected = true
+			}
+			c.mutex.Unlock()
+		}
+		c.encoders <- enc
+	}()
+	return nil
+}
+
+func (c *asyncClient) String() string {
+	return "async(" + c.Client.String() + ")"
+}
+
+// resetEncoders replaces the encoders of the request slots, so that they use
+// the current compression settings of the connection. No request can be in
+// flight.
+func (c *asyncClient) resetEncoders() error {
+	for len(c.encoders) > 0 {
+		<-c.encoders
+	}
+	for i := 0; i < c.maxInFlight; i++ {
+		enc, err := c.conn.NewBulkEncoder()
+		if err != nil {
+			return fmt.Errorf("failed to create bulk request encoder: %w", err)
+		}
+		c.encoders <- enc
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestAsyncClient(t *testing.T, url string, maxInFlight int) *asyncClient {
+	t.Helper()
+	client, err := NewClient(clientSettings{
+		observer:      outputs.NewNilObserver(),
+		connection:    eslegclient.ConnectionSettings{URL: url},
+		indexSelector: outil.MakeSelector(outil.ConstSelectorExpr("test", outil.SelectorLowerCase)),
+	}, nil)
+	require.NoError(t, err)
+
+	async, err := newAsyncClient(client, maxInFlight)
+	require.NoError(t, err)
+	require.NoError(t, async.Connect())
+	return async
+}
+
+func newTestAsyncBatch(client *asyncClient) (*outest.Batch, chan outest.BatchSignal) {
+	signals := make(chan outest.BatchSignal, 1)
+	batch := outest.NewBatch(beat.Event{
+		Timestamp: time.Now(),
+		Fields:    mapstr.M{"message": "test"},
+	})
+	batch.OnSignal = func(sig outest.BatchSignal) { signals <- sig }
+	return encodeBatch(client.Client, batch), signals
+}
+
+func TestAsyncClientRequestsInFlight(t *testing.T) {
+	const maxInFlight = 3
+
+	var (
+		inFlight, maxSeen atomic.Int32
+		release           = make(chan struct{})
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "_bulk") {
+			fmt.Fprintln(w, `{ "version": { "number": "8.12.0" } }`)
+			return
+		}
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxSeen.Load()
+			if n <= seen || maxSeen.CompareAndSwap(seen, n) {
+				break
+			}
+		}
+		<-release
+		fmt.Fprintln(w, `{"items":[{"create":{"status":201}}]}`)
+	}))
+	defer ts.Close()
+
+	client := newTestAsyncClient(t, ts.URL, maxInFlight)
+
+	var signals []chan outest.BatchSignal
+	for i := 0; i < maxInFlight; i++ {
+		batch, sig := newTestAsyncBatch(client)
+		require.NoError(t, client.Publish(context.Background(), batch))
+		signals = append(signals, sig)
+	}
+
+	// All the requests are sent before any response is received.
+	require.Eventually(t, func() bool { return inFlight.Load() == maxInFlight }, 5*time.Second, 10*time.Millisecond)
+
+	// The next batch waits for a request slot.
+	published := make(chan error, 1)
+	batch, sig := newTestAsyncBatch(client)
+	go func() { published <- client.Publish(context.Background(), batch) }()
+	select {
+	case <-published:
+		t.Fatal("Publish must block while all the request slots are in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+	signals = append(signals, sig)
+
+	close(release)
+	require.NoError(t, <-published)
+	for _, sig := range signals {
+		select {
+		case s := <-sig:
+			assert.Equal(t, outest.BatchACK, s.Tag)
+		case <-time.After(5 * time.Second):
+			t.Fatal("batch not acknowledged")
+		}
+	}
+	assert.Equal(t, int32(maxInFlight), maxSeen.Load())
+	require.NoError(t, client.Close())
+}
+
+func TestAsyncClientConnectionError(t *testing.T) {
+	var (
+		mutex   sync.Mutex
+		failing = true
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "_bulk") {
+			fmt.Fprintln(w, `{ "version": { "number": "8.12.0" } }`)
+			return
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, `{"items":[{"create":{"status":201}}]}`)
+	}))
+	defer ts.Close()
+
+	client := newTestAsyncClient(t, ts.URL, 2)
+
+	batch, sig := newTestAsyncBatch(client)
+	require.NoError(t, client.Publish(context.Background(), batch))
+	assert.Equal(t, outest.BatchRetryEvents, (<-sig).Tag)
+	client.inFlight.Wait()
+
+	// The error of the request is returned by the next Publish, so that the
+	// worker reconnects.
+	batch, sig = newTestAsyncBatch(client)
+	assert.Error(t, client.Publish(context.Background(), batch))
+	assert.Equal(t, outest.BatchCancelled, (<-sig).Tag)
+
+	mutex.Lock()
+	failing = false
+	mutex.Unlock()
+
+	require.NoError(t, client.Connect())
+	batch, sig = newTestAsyncBatch(client)
+	require.NoError(t, client.Publish(context.Background(), batch))
+	assert.Equal(t, outest.BatchACK, (<-sig).Tag)
+	require.NoError(t, client.Close())
+}
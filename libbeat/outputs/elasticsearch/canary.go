@@ -0,0 +1,249 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// canaryConfig configures the canary probes written after startup to check
+// that the targets of the output can ingest and search events.
+type canaryConfig struct {
+	Enabled bool `config:"enabled"`
+	// Targets are the data streams or indices probed, the default is the
+	// index of the output when it doesn't depend on the events.
+	Targets []string `config:"targets"`
+	// Pipeline is the ingest pipeline the probes are sent through, the
+	// default is the default pipeline of the targets.
+	Pipeline string `config:"pipeline"`
+	// ExpectFields are the fields the indexed probes must have, for example
+	// the fields set by the ingest pipeline of the targets.
+	ExpectFields []string      `config:"expect_fields"`
+	Timeout      time.Duration `config:"timeout" validate:"positive"`
+	// Cleanup deletes the probes once checked.
+	Cleanup bool `config:"cleanup"`
+}
+
+func defaultCanaryConfig() canaryConfig {
+	return canaryConfig{
+		Timeout: 30 * time.Second,
+		Cleanup: true,
+	}
+}
+
+// canaryCheck is the check reporting the failed probes in the output status.
+const canaryCheck = "canary"
+
+// canaryPollInterval is the interval of the queries looking for a probe.
+var canaryPollInterval = time.Second
+
+// canary probes the targets of an output once, with the first client of the
+// output that connects.
+type canary struct {
+	config  canaryConfig
+	targets []string
+	once    sync.Once
+	log     *logp.Logger
+}
+
+func newCanary(config canaryConfig, targets []string) *canary {
+	return &canary{
+		config:  config,
+		targets: targets,
+		log:     logp.NewLogger(logSelector).Named(canaryCheck),
+	}
+}
+
+// start probes the targets in the background with a clone of client, the
+// probes don't delay publishing. Only the first call probes.
+func (c *canary) start(client *Client) {
+	c.once.Do(func() {
+		probe := client.Clone()
+		go func() {
+			defer probe.Close()
+			c.run(&probe.conn)
+		}()
+	})
+}
+
+// run probes the targets with conn and reports a degraded status for the
+// targets that failed.
+func (c *canary) run(conn *eslegclient.Connection) {
+	if err := conn.Connect(); err != nil {
+		c.log.Warnf("Canary probes not sent, failed to connect to %s: %v", conn.URL, err)
+		return
+	}
+	if caps := conn.Capabilities(); !caps.ESQL {
+		c.log.Warnf("Canary probes not sent, ES|QL is not supported by %s.", caps)
+		return
+	}
+
+	var failures []string
+	for _, target := range c.targets {
+		if err := c.probe(conn, target); err != nil {
+			c.log.Errorf("Canary probe of %s failed: %v", target, err)
+			failures = append(failures, target)
+			continue
+		}
+		c.log.Infof("Canary probe of %s succeeded.", target)
+	}
+
+	reason := ""
+	if len(failures) > 0 {
+		reason = fmt.Sprintf("Elasticsearch canary probes failed for: %s", strings.Join(failures, ", "))
+	}
+	reportDegraded(canaryCheck, reason)
+}
+
+// probe indexes a probe document into target and checks that an ES|QL query
+// finds it with the expected fields before the timeout.
+func (c *canary) probe(conn *eslegclient.Connection, target string) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+	probeID := id.String()
+
+	index, err := c.index(conn, target, probeID)
+	if err != nil {
+		return err
+	}
+	if c.config.Cleanup {
+		defer c.delete(conn, index, probeID)
+	}
+
+	deadline := time.Now().Add(c.config.Timeout)
+	query := c.query(target, probeID)
+	for {
+		found, err := c.find(conn, query)
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("probe %s not found within %v", probeID, c.config.Timeout)
+		}
+		time.Sleep(canaryPollInterval)
+	}
+}
+
+// index indexes the probe document with id into target and returns the
+// index it was written to.
+func (c *canary) index(conn *eslegclient.Connection, target, id string) (string, error) {
+	doc := map[string]interface{}{
+		"@timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		"message":    "Elasticsearch output canary probe",
+		"labels":     map[string]interface{}{"canary_id": id},
+	}
+	path := "/" + url.PathEscape(target) + "/_create/" + id
+	code, body, err := conn.Request(http.MethodPut, path, c.config.Pipeline, map[string]string{"refresh": "wait_for"}, doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to index probe (status=%d): %w", code, err)
+	}
+
+	var resp struct {
+		Index string `json:"_index"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse index response: %w", err)
+	}
+	return resp.Index, nil
+}
+
+// query returns the ES|QL query finding the probe with id in target and
+// keeping the expected fields.
+func (c *canary) query(target, id string) string {
+	keep := []string{"`labels.canary_id`"}
+	for _, f := range c.config.ExpectFields {
+		keep = append(keep, "`"+strings.ReplaceAll(f, "`", "``")+"`")
+	}
+	return fmt.Sprintf("FROM %s | WHERE `labels.canary_id` == %q | KEEP %s | LIMIT 1",
+		target, id, strings.Join(keep, ", "))
+}
+
+// find runs the ES|QL query and reports whether it returned the probe. It
+// fails if an expected field of the probe is missing.
+func (c *canary) find(conn *eslegclient.Connection, query string) (bool, error) {
+	code, body, err := conn.Request(http.MethodPost, "/_query", "", nil, map[string]string{"query": query})
+	if err != nil {
+		return false, fmt.Errorf("ES|QL query failed (status=%d): %w", code, err)
+	}
+
+	var resp struct {
+		Columns []struct {
+			Name string `json:"name"`
+		} `json:"columns"`
+		Values [][]interface{} `json:"values"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return false, fmt.Errorf("failed to parse ES|QL response: %w", err)
+	}
+	if len(resp.Values) == 0 {
+		return false, nil
+	}
+
+	var missing []string
+	row := resp.Values[0]
+	for i, col := range resp.Columns {
+		if i >= len(row) || row[i] == nil {
+			missing = append(missing, col.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return true, fmt.Errorf("probe found without the expected fields %v", missing)
+	}
+	return true, nil
+}
+
+// delete deletes the probe with id from index.
+func (c *canary) delete(conn *eslegclient.Connection, index, id string) {
+	if index == "" {
+		return
+	}
+	path := "/" + url.PathEscape(index) + "/_doc/" + id
+	code, _, err := conn.Request(http.MethodDelete, path, "", nil, nil)
+	if err != nil && code != http.StatusNotFound {
+		c.log.Warnf("Failed to delete canary probe %s from %s: %v", id, index, err)
+	}
+}
+
+// canaryTargets returns the targets configured for the canary, or the index
+// of the output when it is constant.
+func canaryTargets(config canaryConfig, index string) ([]string, error) {
+	if len(config.Targets) > 0 {
+		return config.Targets, nil
+	}
+	if index == "" || strings.Contains(index, "%{") {
+		return nil, errors.New("canary.targets must be set when the index of the output depends on the events")
+	}
+	return []string{index}, nil
+}
@@ -0,0 +1,199 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+)
+
+// canaryServer is a fake Elasticsearch answering the requests of the canary.
+type canaryServer struct {
+	version string
+
+	mu      sync.Mutex
+	docs    map[string]map[string]interface{}
+	queries []string
+	deleted []string
+	// searchableAfter is the number of queries before the probe is found.
+	searchableAfter int
+	// values are the values of the expected fields in the query response.
+	values []interface{}
+}
+
+func (s *canaryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/":
+		_, _ = w.Write([]byte(`{"version":{"number":"` + s.version + `","build_flavor":"default"}}`))
+	case r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/_create/"):
+		var doc map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&doc)
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		s.docs[id] = doc
+		_, _ = w.Write([]byte(`{"_index":".ds-logs-test-default-000001","_id":"` + id + `","result":"created"}`))
+	case r.URL.Path == "/_query":
+		var body struct {
+			Query string `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.queries = append(s.queries, body.Query)
+		if len(s.queries) <= s.searchableAfter {
+			_, _ = w.Write([]byte(`{"columns":[{"name":"labels.canary_id","type":"keyword"}],"values":[]}`))
+			return
+		}
+		columns := []map[string]string{{"name": "labels.canary_id", "type": "keyword"}}
+		row := []interface{}{"id"}
+		for i, v := range s.values {
+			columns = append(columns, map[string]string{"name": fmt.Sprintf("field%d", i), "type": "keyword"})
+			row = append(row, v)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"columns": columns,
+			"values":  [][]interface{}{row},
+		})
+	case r.Method == http.MethodDelete:
+		s.deleted = append(s.deleted, r.URL.Path)
+		_, _ = w.Write([]byte(`{"result":"deleted"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func runCanary(t *testing.T, server *canaryServer, config canaryConfig) *statusRecorder {
+	t.Helper()
+	recorder := &statusRecorder{}
+	SetStatusReporter(recorder)
+	t.Cleanup(func() { SetStatusReporter(nil) })
+
+	if server.docs == nil {
+		server.docs = map[string]map[string]interface{}{}
+	}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	conn, err := eslegclient.NewConnection(eslegclient.ConnectionSettings{URL: ts.URL})
+	require.NoError(t, err)
+	newCanary(config, []string{"logs-test-default"}).run(conn)
+	return recorder
+}
+
+func TestCanaryProbe(t *testing.T) {
+	defer func(d time.Duration) { canaryPollInterval = d }(canaryPollInterval)
+	canaryPollInterval = time.Millisecond
+
+	config := defaultCanaryConfig()
+	config.ExpectFields = []string{"event.ingested"}
+	server := &canaryServer{version: "8.15.0", searchableAfter: 2, values: []interface{}{"2024-01-01T00:00:00Z"}}
+	recorder := runCanary(t, server, config)
+
+	require.Len(t, server.docs, 1)
+	var id string
+	for k := range server.docs {
+		id = k
+	}
+	assert.Equal(t, map[string]interface{}{"canary_id": id}, server.docs[id]["labels"])
+	require.Len(t, server.queries, 3)
+	assert.Equal(t,
+		"FROM logs-test-default | WHERE `labels.canary_id` == \""+id+"\" | KEEP `labels.canary_id`, `event.ingested` | LIMIT 1",
+		server.queries[0])
+	assert.Equal(t, []string{"/.ds-logs-test-default-000001/_doc/" + id}, server.deleted)
+
+	// No failure was reported.
+	assert.Empty(t, recorder.statuses)
+}
+
+func TestCanaryProbeFailures(t *testing.T) {
+	defer func(d time.Duration) { canaryPollInterval = d }(canaryPollInterval)
+	canaryPollInterval = time.Millisecond
+
+	t.Run("missing field", func(t *testing.T) {
+		config := defaultCanaryConfig()
+		config.ExpectFields = []string{"event.ingested"}
+		server := &canaryServer{version: "8.15.0", values: []interface{}{nil}}
+		recorder := runCanary(t, server, config)
+
+		assert.Equal(t, []status.Status{status.Degraded}, recorder.statuses)
+		assert.Equal(t, []string{"Elasticsearch canary probes failed for: logs-test-default"}, recorder.msgs)
+		assert.Len(t, server.deleted, 1)
+	})
+
+	t.Run("not searchable", func(t *testing.T) {
+		config := defaultCanaryConfig()
+		config.Timeout = 10 * time.Millisecond
+		config.Cleanup = false
+		server := &canaryServer{version: "8.15.0", searchableAfter: 1 << 30}
+		recorder := runCanary(t, server, config)
+
+		assert.Equal(t, []status.Status{status.Degraded}, recorder.statuses)
+		assert.Empty(t, server.deleted)
+	})
+
+	t.Run("ES|QL not supported", func(t *testing.T) {
+		server := &canaryServer{version: "8.10.0"}
+		recorder := runCanary(t, server, defaultCanaryConfig())
+
+		assert.Empty(t, server.docs)
+		assert.Empty(t, recorder.statuses)
+	})
+}
+
+func TestCanaryTargets(t *testing.T) {
+	targets, err := canaryTargets(canaryConfig{Targets: []string{"logs-a-default"}}, "%{[fields.index]}")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs-a-default"}, targets)
+
+	targets, err = canaryTargets(canaryConfig{}, "logs-b-default")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs-b-default"}, targets)
+
+	_, err = canaryTargets(canaryConfig{}, "")
+	assert.Error(t, err)
+	_, err = canaryTargets(canaryConfig{}, "logs-%{[fields.dataset]}-default")
+	assert.Error(t, err)
+}
+
+func TestReportDegradedChecks(t *testing.T) {
+	recorder := &statusRecorder{}
+	SetStatusReporter(recorder)
+	defer SetStatusReporter(nil)
+
+	reportDegraded("pipelines", "pipelines missing")
+	reportDegraded(canaryCheck, "probe failed")
+	reportDegraded("pipelines", "")
+	reportDegraded(canaryCheck, "")
+
+	assert.Equal(t, []status.Status{status.Degraded, status.Degraded, status.Degraded, status.Running}, recorder.statuses)
+	assert.Equal(t, []string{"pipelines missing", "pipelines missing; probe failed", "probe failed", "Healthy"}, recorder.msgs)
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// XXX: like the connect callbacks, the detected clusters are a package
+// global as outputs have no access to the beat's state registry.
+var detectedClusters struct {
+	sync.Mutex
+	once     sync.Once
+	clusters map[string]eslegclient.Capabilities
+}
+
+// reportCapabilities records the capabilities detected for the cluster at
+// url, reported in state.output_clusters.
+func reportCapabilities(url string, caps eslegclient.Capabilities) {
+	detectedClusters.once.Do(func() {
+		stateRegistry := monitoring.GetNamespace("state").GetRegistry()
+		monitoring.NewFunc(stateRegistry, "output_clusters", reportDetectedClusters, monitoring.Report)
+	})
+
+	detectedClusters.Lock()
+	defer detectedClusters.Unlock()
+	if detectedClusters.clusters == nil {
+		detectedClusters.clusters = map[string]eslegclient.Capabilities{}
+	}
+	detectedClusters.clusters[url] = caps
+}
+
+func reportDetectedClusters(_ monitoring.Mode, V monitoring.Visitor) {
+	detectedClusters.Lock()
+	defer detectedClusters.Unlock()
+
+	urls := make([]string, 0, len(detectedClusters.clusters))
+	for url := range detectedClusters.clusters {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+	for i, url := range urls {
+		caps := detectedClusters.clusters[url]
+		monitoring.ReportNamespace(V, strconv.Itoa(i), func() {
+			monitoring.ReportString(V, "url", url)
+			monitoring.ReportString(V, "flavor", string(caps.Flavor))
+			if caps.Flavor != eslegclient.FlavorServerless {
+				monitoring.ReportString(V, "version", caps.Version.String())
+			}
+			monitoring.ReportString(V, "features", strings.Join(caps.Features(), ","))
+		})
+	}
+}
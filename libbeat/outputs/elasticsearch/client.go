@@ -36,13 +36,14 @@ import (
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/testing"
-	"github.com/elastic/elastic-agent-libs/version"
 )
 
 var (
 	errPayloadTooLarge = errors.New("the bulk payload is too large for the server. Consider to adjust `http.max_content_length` parameter in Elasticsearch or `bulk_max_size` in the beat. The batch has been dropped")
 
 	ErrTooOld = errors.New("Elasticsearch is too old. Please upgrade the instance. If you would like to connect to older instances set output.elasticsearch.allow_older_versions to true.")
+
+	ErrUnsupportedFlavor = errors.New("the cluster is not Elasticsearch. To connect to OpenSearch set output.elasticsearch.compatibility to opensearch. If you would like to connect to it anyway set output.elasticsearch.allow_older_versions to true.")
 )
 
 // Client is an elasticsearch client.
@@ -58,6 +59,16 @@ type Client struct {
 	// forwarded to this index. Otherwise, they will be dropped.
 	deadLetterIndex string
 
+	// pipelines are the ingest pipelines checked to exist on connect.
+	pipelines []string
+
+	// requireAlias sets the require_alias parameter of the bulk requests,
+	// when the cluster supports it.
+	requireAlias bool
+
+	// canary probes the targets of the output after the first connection.
+	canary *canary
+
 	log *logp.Logger
 }
 
@@ -75,6 +86,18 @@ type clientSettings struct {
 	// If deadLetterIndex is set, events with bulk-ingest errors will be
 	// forwarded to this index. Otherwise, they will be dropped.
 	deadLetterIndex string
+
+	// If pipelines is set, the client checks on connect that these ingest
+	// pipelines exist.
+	pipelines []string
+
+	// If requireAlias is set, bulk requests fail for targets that are not
+	// aliases.
+	requireAlias bool
+
+	// If canary is set, the targets of the output are probed once the first
+	// client connects.
+	canary *canary
 }
 
 type bulkResultStats struct {
@@ -84,6 +107,13 @@ type bulkResultStats struct {
 	nonIndexable int // number of events with permanent failures.
 	deadLetter   int // number of failed events ingested to the dead letter index.
 	tooMany      int // number of events receiving HTTP 429 Too Many Requests
+
+	// Results of events sent through an ingest pipeline, by pipeline.
+	pipelines map[string]*pipelineResultStats
+}
+
+type pipelineResultStats struct {
+	acked, fails, nonIndexable int
 }
 
 type bulkResult struct {
@@ -97,6 +127,10 @@ type bulkResult struct {
 	// had encoding errors while assembling the request.
 	events []publisher.Event
 
+	// The batch the events belong to, events dropped because of their item
+	// status are written to its dead-letter file.
+	batch publisher.Batch
+
 	// The http status returned by the bulk request.
 	status int
 
@@ -166,6 +200,9 @@ func NewClient(
 		pipelineSelector: pipeline,
 		observer:         observer,
 		deadLetterIndex:  s.deadLetterIndex,
+		pipelines:        s.pipelines,
+		requireAlias:     s.requireAlias,
+		canary:           s.canary,
 
 		log: logp.NewLogger("elasticsearch"),
 	}
@@ -183,16 +220,20 @@ func (client *Client) Clone() *Client {
 		URL:               client.conn.URL,
 		Beatname:          client.conn.Beatname,
 		Kerberos:          client.conn.Kerberos,
+		Signing:           client.conn.Signing,
 		Username:          client.conn.Username,
 		Password:          client.conn.Password,
 		APIKey:            client.conn.APIKey,
 		Parameters:        nil, // XXX: do not pass params?
 		Headers:           client.conn.Headers,
 		CompressionLevel:  client.conn.CompressionLevel,
+		Compression:       client.conn.Compression,
+		Compatibility:     client.conn.Compatibility,
 		OnConnectCallback: nil,
 		Observer:          nil,
 		EscapeHTML:        false,
 		Transport:         client.conn.Transport,
+		HTTP2:             client.conn.HTTP2,
 	}
 
 	// Without the following nil check on proxyURL, a nil Proxy field will try
@@ -206,6 +247,7 @@ func (client *Client) Clone() *Client {
 			indexSelector:    client.indexSelector,
 			pipelineSelector: client.pipelineSelector,
 			deadLetterIndex:  client.deadLetterIndex,
+			requireAlias:     client.requireAlias,
 		},
 		nil, // XXX: do not pass connection callback?
 	)
@@ -213,18 +255,27 @@ func (client *Client) Clone() *Client {
 }
 
 func (client *Client) Publish(ctx context.Context, batch publisher.Batch) error {
+	_, err := client.publish(ctx, batch, nil)
+	return err
+}
+
+// publish sends the events of batch in a bulk request and acknowledges or
+// retries them according to the response. The request body is encoded with
+// enc, or with the encoder of the connection if enc is nil. It returns the
+// HTTP status of the bulk request.
+func (client *Client) publish(ctx context.Context, batch publisher.Batch, enc eslegclient.BodyEncoder) (int, error) {
 	span, ctx := apm.StartSpan(ctx, "publishEvents", "output")
 	defer span.End()
 	span.Context.SetLabel("events_original", len(batch.Events()))
 	client.observer.NewBatch(len(batch.Events()))
 
 	// Create and send the bulk request.
-	bulkResult := client.doBulkRequest(ctx, batch)
+	bulkResult := client.doBulkRequest(ctx, batch, enc)
 	span.Context.SetLabel("events_encoded", len(bulkResult.events))
 	if bulkResult.connErr != nil {
 		// If there was a connection-level error there is no per-item response,
 		// handle it and return.
-		return client.handleBulkResultError(ctx, batch, bulkResult)
+		return bulkResult.status, client.handleBulkResultError(ctx, batch, bulkResult)
 	}
 	span.Context.SetLabel("events_published", len(bulkResult.events))
 
@@ -239,7 +290,7 @@ func (client *Client) Publish(ctx context.Context, batch publisher.Batch) error
 	} else {
 		batch.ACK()
 	}
-	return nil
+	return bulkResult.status, nil
 }
 
 // Encode a batch's events into a bulk publish request, send the request to
@@ -248,25 +299,35 @@ func (client *Client) Publish(ctx context.Context, batch publisher.Batch) error
 // The events list in the result will be shorter than the original batch if
 // some events couldn't be encoded. In this case, the removed events will
 // be reported to the Client's metrics observer via PermanentErrors.
+// If enc is set the request is encoded with it, so that several requests can
+// be sent concurrently.
 func (client *Client) doBulkRequest(
 	ctx context.Context,
 	batch publisher.Batch,
+	enc eslegclient.BodyEncoder,
 ) bulkResult {
-	var result bulkResult
+	result := bulkResult{batch: batch}
 
 	rawEvents := batch.Events()
+	client.deadLetterEncodingFailures(batch, rawEvents)
 
 	// encode events into bulk request buffer, dropping failed elements from
 	// events slice
-	resultEvents, bulkItems := client.bulkEncodePublishRequest(client.conn.GetVersion(), rawEvents)
+	caps := client.conn.Capabilities()
+	resultEvents, bulkItems := client.bulkEncodePublishRequest(caps, rawEvents)
 	result.events = resultEvents
 	client.observer.PermanentErrors(len(rawEvents) - len(resultEvents))
 
 	// If we encoded any events, send the network request.
 	if len(result.events) > 0 {
 		begin := time.Now()
-		result.status, result.response, result.connErr =
-			client.conn.Bulk(ctx, "", "", bulkRequestParams, bulkItems)
+		if enc == nil {
+			result.status, result.response, result.connErr =
+				client.conn.Bulk(ctx, "", "", client.bulkParams(caps), bulkItems)
+		} else {
+			result.status, result.response, result.connErr =
+				client.conn.BulkWithEncoder(ctx, enc, "", "", client.bulkParams(caps), bulkItems)
+		}
 		if result.connErr == nil {
 			duration := time.Since(begin)
 			client.observer.ReportLatency(duration)
@@ -313,9 +374,23 @@ func (client *Client) handleBulkResultError(
 	return bulkResult.connErr
 }
 
+// deadLetterEncodingFailures writes the events that couldn't be encoded to
+// the dead-letter file of the batch. They are dropped by
+// bulkEncodePublishRequest.
+func (client *Client) deadLetterEncodingFailures(batch publisher.Batch, data []publisher.Event) {
+	if _, ok := batch.(publisher.DeadLetterBatch); !ok {
+		return
+	}
+	for i := range data {
+		if event, ok := data[i].EncodedEvent.(*encodedEvent); ok && event.err != nil {
+			publisher.DeadLetter(batch, data[i:i+1], event.err.Error())
+		}
+	}
+}
+
 // bulkEncodePublishRequest encodes all bulk requests and returns slice of events
 // successfully added to the list of bulk items and the list of bulk items.
-func (client *Client) bulkEncodePublishRequest(version version.V, data []publisher.Event) ([]publisher.Event, []interface{}) {
+func (client *Client) bulkEncodePublishRequest(caps eslegclient.Capabilities, data []publisher.Event) ([]publisher.Event, []interface{}) {
 	okEvents := data[:0]
 	bulkItems := []interface{}{}
 	for i := range data {
@@ -330,7 +405,7 @@ func (client *Client) bulkEncodePublishRequest(version version.V, data []publish
 			client.log.Error(event.err)
 			continue
 		}
-		meta, err := client.createEventBulkMeta(version, event)
+		meta, err := client.createEventBulkMeta(caps, event)
 		if err != nil {
 			client.log.Errorf("Failed to encode event meta data: %+v", err)
 			continue
@@ -348,9 +423,9 @@ func (client *Client) bulkEncodePublishRequest(version version.V, data []publish
 	return okEvents, bulkItems
 }
 
-func (client *Client) createEventBulkMeta(version version.V, event *encodedEvent) (interface{}, error) {
+func (client *Client) createEventBulkMeta(caps eslegclient.Capabilities, event *encodedEvent) (interface{}, error) {
 	eventType := ""
-	if version.Major < 7 {
+	if caps.DocType {
 		eventType = defaultEventType
 	}
 
@@ -359,6 +434,7 @@ func (client *Client) createEventBulkMeta(version version.V, event *encodedEvent
 		DocType:  eventType,
 		Pipeline: event.pipeline,
 		ID:       event.id,
+		Routing:  event.routing,
 	}
 
 	if event.opType == events.OpTypeDelete {
@@ -368,7 +444,7 @@ func (client *Client) createEventBulkMeta(version version.V, event *encodedEvent
 			return nil, fmt.Errorf("%s %s requires _id", events.FieldMetaOpType, events.OpTypeDelete)
 		}
 	}
-	if event.id != "" || version.Major > 7 || (version.Major == 7 && version.Minor >= 5) {
+	if event.id != "" || caps.CreateWithoutID {
 		if event.opType == events.OpTypeIndex {
 			return eslegclient.BulkIndexAction{Index: meta}, nil
 		}
@@ -430,9 +506,23 @@ func (client *Client) bulkCollectPublishFails(bulkResult bulkResult) ([]publishe
 			break
 		}
 
+		// Events retried to the dead letter index are not counted for their
+		// pipeline a second time.
+		encodedEvent := events[i].EncodedEvent.(*encodedEvent)
+		pipeline := encodedEvent.pipeline
+		if encodedEvent.deadLetter {
+			pipeline = ""
+		}
+		before := stats
 		if client.applyItemStatus(events[i], itemStatus, itemMessage, &stats) {
 			eventsToRetry = append(eventsToRetry, events[i])
 			client.log.Debugf("Bulk item insert failed (i=%v, status=%v): %s", i, itemStatus, itemMessage)
+		} else if stats.nonIndexable > before.nonIndexable {
+			publisher.DeadLetter(bulkResult.batch, events[i:i+1],
+				fmt.Sprintf("cannot index event (status=%v): %s", itemStatus, itemMessage))
+		}
+		if pipeline != "" {
+			stats.addPipelineResult(pipeline, before)
 		}
 	}
 
@@ -504,8 +594,52 @@ func (client *Client) applyItemStatus(
 	return true
 }
 
+// bulkParams returns the parameters of the bulk requests sent to a cluster
+// with the capabilities.
+func (client *Client) bulkParams(caps eslegclient.Capabilities) map[string]string {
+	if !client.requireAlias || !caps.RequireAlias {
+		return bulkRequestParams
+	}
+	params := make(map[string]string, len(bulkRequestParams)+1)
+	for k, v := range bulkRequestParams {
+		params[k] = v
+	}
+	params["require_alias"] = "true"
+	return params
+}
+
 func (client *Client) Connect() error {
-	return client.conn.Connect()
+	if err := client.conn.Connect(); err != nil {
+		return err
+	}
+	caps := client.conn.Capabilities()
+	reportCapabilities(client.conn.URL, caps)
+	if client.requireAlias && !caps.RequireAlias {
+		client.log.Warnf("require_alias is not supported by %s at %s, it is ignored.", caps, client.conn.URL)
+	}
+	if len(client.pipelines) > 0 {
+		client.validatePipelines()
+	}
+	if client.canary != nil {
+		client.canary.start(client)
+	}
+	return nil
+}
+
+// validatePipelines checks that the configured ingest pipelines exist.
+// Missing pipelines don't fail the connection, as events for other pipelines
+// can still be indexed, but they are logged and reported as degraded status.
+func (client *Client) validatePipelines() {
+	missing, err := missingPipelines(&client.conn, client.pipelines)
+	if err != nil {
+		client.log.Warnf("Could not check that the configured ingest pipelines exist: %v", err)
+		return
+	}
+	if len(missing) > 0 {
+		client.log.Errorf("Ingest pipelines %v configured in the Elasticsearch output don't exist in %s, events sent to them will fail.",
+			missing, client.conn.URL)
+	}
+	reportPipelineStatus(missing)
 }
 
 func (client *Client) Close() error {
@@ -528,4 +662,24 @@ func (stats bulkResultStats) reportToObserver(ob outputs.Observer) {
 	ob.DeadLetterEvents(stats.deadLetter)
 
 	ob.ErrTooMany(stats.tooMany)
+
+	for pipeline, p := range stats.pipelines {
+		ob.PipelineEvents(pipeline, p.acked, p.fails, p.nonIndexable)
+	}
+}
+
+// addPipelineResult adds the result of an event sent through pipeline, as
+// the difference of the stats since before the event was applied.
+func (stats *bulkResultStats) addPipelineResult(pipeline string, before bulkResultStats) {
+	if stats.pipelines == nil {
+		stats.pipelines = map[string]*pipelineResultStats{}
+	}
+	p, ok := stats.pipelines[pipeline]
+	if !ok {
+		p = &pipelineResultStats{}
+		stats.pipelines[pipeline] = p
+	}
+	p.acked += stats.acked - before.acked
+	p.fails += stats.fails - before.fails
+	p.nonIndexable += stats.nonIndexable - before.nonIndexable
 }
@@ -523,14 +523,36 @@ func TestCollectPublishFailDrop(t *testing.T) {
 	event := publisher.Event{Content: beat.Event{Fields: mapstr.M{"bar": 1}}}
 	eventFail := publisher.Event{Content: beat.Event{Fields: mapstr.M{"bar": "bar1"}}}
 	events := encodeEvents(client, []publisher.Event{event, eventFail, event})
+	batch := &deadLetterBatch{}
 
 	res, stats := client.bulkCollectPublishFails(bulkResult{
 		events:   events,
+		batch:    batch,
 		status:   200,
 		response: response,
 	})
 	assert.Equal(t, 0, len(res))
 	assert.Equal(t, bulkResultStats{acked: 2, fails: 0, nonIndexable: 1}, stats)
+
+	// The dropped event is written to the dead-letter file, with its
+	// original encoding.
+	require.Len(t, batch.events, 1)
+	assert.Contains(t, string(batch.events[0].EncodedEvent.(*encodedEvent).DeadLetterEncoding()), `"bar":"bar1"`)
+	require.Len(t, batch.reasons, 1)
+	assert.Contains(t, batch.reasons[0], "status=400")
+	assert.Contains(t, batch.reasons[0], "mapper_parsing_exception")
+}
+
+// deadLetterBatch records the events written to the dead-letter file.
+type deadLetterBatch struct {
+	publisher.Batch
+	events  []publisher.Event
+	reasons []string
+}
+
+func (b *deadLetterBatch) DeadLetter(events []publisher.Event, reason string) {
+	b.events = append(b.events, events...)
+	b.reasons = append(b.reasons, reason)
 }
 
 func TestCollectPublishFailAll(t *testing.T) {
@@ -823,7 +845,7 @@ func TestBulkEncodeEvents(t *testing.T) {
 			}
 			encodeEvents(client, events)
 
-			encoded, bulkItems := client.bulkEncodePublishRequest(*libversion.MustNew(test.version), events)
+			encoded, bulkItems := client.bulkEncodePublishRequest(eslegclient.NewCapabilities(eslegclient.FlavorElasticsearch, *libversion.MustNew(test.version)), events)
 			assert.Equal(t, len(events), len(encoded), "all events should have been encoded")
 			assert.Equal(t, 2*len(events), len(bulkItems), "incomplete bulk")
 
@@ -899,7 +921,7 @@ func TestBulkEncodeEventsWithOpType(t *testing.T) {
 	}
 	encodeEvents(client, events)
 
-	encoded, bulkItems := client.bulkEncodePublishRequest(*libversion.MustNew(version.GetDefaultVersion()), events)
+	encoded, bulkItems := client.bulkEncodePublishRequest(eslegclient.NewCapabilities(eslegclient.FlavorElasticsearch, *libversion.MustNew(version.GetDefaultVersion())), events)
 	require.Equal(t, len(events)-1, len(encoded), "all events should have been encoded")
 	require.Equal(t, 9, len(bulkItems), "incomplete bulk")
 
@@ -925,6 +947,57 @@ func TestBulkEncodeEventsWithOpType(t *testing.T) {
 
 }
 
+func TestBulkEncodeEventsWithRouting(t *testing.T) {
+	cfg := c.MustNewConfigFrom(mapstr.M{})
+	info := beat.Info{
+		IndexPrefix: "test",
+		Version:     version.GetDefaultVersion(),
+	}
+
+	im, err := idxmgmt.DefaultSupport(nil, info, c.NewConfig())
+	require.NoError(t, err)
+
+	index, pipeline, err := buildSelectors(im, info, cfg)
+	require.NoError(t, err)
+
+	client, _ := NewClient(
+		clientSettings{
+			observer:         outputs.NewNilObserver(),
+			indexSelector:    index,
+			pipelineSelector: pipeline,
+		},
+		nil,
+	)
+
+	events := []publisher.Event{
+		{Content: beat.Event{
+			Meta:   mapstr.M{e.FieldMetaRouting: "tenant-a"},
+			Fields: mapstr.M{"message": "routed"},
+		}},
+		{Content: beat.Event{
+			Fields: mapstr.M{"message": "not routed"},
+		}},
+	}
+	encodeEvents(client, events)
+
+	encoded, bulkItems := client.bulkEncodePublishRequest(eslegclient.NewCapabilities(eslegclient.FlavorElasticsearch, *libversion.MustNew(version.GetDefaultVersion())), events)
+	require.Equal(t, 2, len(encoded))
+	require.Equal(t, 4, len(bulkItems))
+
+	bulkMeta := func(item interface{}) eslegclient.BulkMeta {
+		switch action := item.(type) {
+		case eslegclient.BulkCreateAction:
+			return action.Create
+		case eslegclient.BulkIndexAction:
+			return action.Index
+		}
+		require.FailNow(t, "unexpected action type")
+		return eslegclient.BulkMeta{}
+	}
+	assert.Equal(t, "tenant-a", bulkMeta(bulkItems[0]).Routing)
+	assert.Empty(t, bulkMeta(bulkItems[2]).Routing)
+}
+
 func TestClientWithAPIKey(t *testing.T) {
 	var headers http.Header
 
@@ -993,7 +1066,7 @@ func TestBulkRequestHasFilterPath(t *testing.T) {
 		client := makePublishTestClient(t, esMock.URL, nil)
 
 		batch := encodeBatch(client, &batchMock{events: []publisher.Event{event1}})
-		result := client.doBulkRequest(ctx, batch)
+		result := client.doBulkRequest(ctx, batch, nil)
 		require.NoError(t, result.connErr)
 		// Only param should be the standard filter path
 		require.Equal(t, len(reqParams), 1, "Only bulk request param should be standard filter path")
@@ -1021,7 +1094,7 @@ func TestBulkRequestHasFilterPath(t *testing.T) {
 		client := makePublishTestClient(t, esMock.URL, configParams)
 
 		batch := encodeBatch(client, &batchMock{events: []publisher.Event{event1}})
-		result := client.doBulkRequest(ctx, batch)
+		result := client.doBulkRequest(ctx, batch, nil)
 		require.NoError(t, result.connErr)
 		require.Equal(t, len(reqParams), 2, "Bulk request should include configured parameter and standard filter path")
 		require.Equal(t, filterPathValue, reqParams.Get(filterPathKey), "Bulk request should include standard filter path")
@@ -0,0 +1,462 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// clusterDef is a cluster configured in the clusters setting.
+type clusterDef struct {
+	name     string
+	esConfig elasticsearchConfig
+	hosts    []string
+}
+
+func buildClusterSelector(cfg *config.C) (outil.Selector, error) {
+	return outil.BuildSelectorFromConfig(cfg, outil.Settings{
+		Key:              "cluster",
+		MultiKey:         "cluster_rules",
+		EnableSingleOnly: true,
+		FailEmpty:        true,
+		Case:             outil.SelectorKeepCase,
+	})
+}
+
+// makeClusterClients creates the clients of an output publishing to multiple
+// clusters. One client is created per host of the cluster with the most hosts,
+// each publishing to all clusters.
+func makeClusterClients(
+	cfg *config.C,
+	beatInfo beat.Info,
+	settings clientSettings,
+	log *logp.Logger,
+) ([]outputs.NetworkClient, error) {
+	var defs struct {
+		Clusters []*config.C `config:"clusters" validate:"required"`
+	}
+	if err := cfg.Unpack(&defs); err != nil {
+		return nil, err
+	}
+
+	// Cluster settings are applied on top of the output settings, except
+	// for the hosts that must be set for every cluster.
+	base, err := config.NewConfigFrom(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []string{"clusters", "cluster", "cluster_rules", "hosts"} {
+		_, _ = base.Remove(field, -1)
+	}
+
+	clusters := make([]clusterDef, 0, len(defs.Clusters))
+	maxHosts := 0
+	for i, c := range defs.Clusters {
+		def, err := readClusterDef(base, c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster %d in %v: %w", i, cfg.PathOf("clusters"), err)
+		}
+		for _, other := range clusters {
+			if other.name == def.name {
+				return nil, fmt.Errorf("duplicate cluster name '%s' in %v", def.name, cfg.PathOf("clusters"))
+			}
+		}
+		clusters = append(clusters, def)
+		maxHosts = max(maxHosts, len(def.hosts))
+	}
+
+	clients := make([]outputs.NetworkClient, maxHosts)
+	for i := range clients {
+		router := newClusterRouter(settings.observer, log)
+		for _, def := range clusters {
+			host := def.hosts[i%len(def.hosts)]
+			client, err := makeClient(def.esConfig, host, beatInfo, settings)
+			if err != nil {
+				log.Errorf("Invalid host param set for cluster %s: %s, Error: %+v", def.name, host, err)
+				return nil, err
+			}
+			router.add(def.name, client, def.esConfig.Backoff)
+		}
+		clients[i] = router
+	}
+	return clients, nil
+}
+
+// readClusterDef reads a cluster definition. Settings of the cluster replace
+// the output settings of the same name.
+func readClusterDef(base, cluster *config.C) (clusterDef, error) {
+	var def clusterDef
+
+	var meta struct {
+		Name string `config:"name" validate:"required"`
+	}
+	if err := cluster.Unpack(&meta); err != nil {
+		return def, err
+	}
+	def.name = meta.Name
+
+	merged, err := config.NewConfigFrom(base)
+	if err != nil {
+		return def, err
+	}
+	for _, field := range cluster.GetFields() {
+		_, _ = merged.Remove(field, -1)
+	}
+	if err := merged.Merge(cluster); err != nil {
+		return def, err
+	}
+	_, _ = merged.Remove("name", -1)
+
+	def.esConfig = defaultConfig
+	if err := merged.Unpack(&def.esConfig); err != nil {
+		return def, fmt.Errorf("cluster '%s': %w", def.name, err)
+	}
+	def.hosts, err = outputs.ReadHostList(merged)
+	if err != nil {
+		return def, fmt.Errorf("cluster '%s': %w", def.name, err)
+	}
+	if len(def.hosts) == 0 {
+		return def, fmt.Errorf("cluster '%s' has no hosts configured", def.name)
+	}
+	return def, nil
+}
+
+// clusterRouter is a NetworkClient that publishes each event to the cluster
+// chosen by the cluster selector when it was encoded. Every cluster has its
+// own client and backoff, so events for an unavailable cluster are retried
+// without holding back events for the other clusters.
+type clusterRouter struct {
+	clusters map[string]*clusterClient
+	names    []string
+	observer outputs.Observer
+	log      *logp.Logger
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// clusterClient is the client of a single cluster. It is only used by one
+// goroutine at a time.
+type clusterClient struct {
+	name      string
+	client    outputs.NetworkClient
+	backoff   Backoff
+	connected bool
+	wait      time.Duration
+	retryAt   time.Time
+	log       *logp.Logger
+}
+
+func newClusterRouter(observer outputs.Observer, log *logp.Logger) *clusterRouter {
+	if observer == nil {
+		observer = outputs.NewNilObserver()
+	}
+	return &clusterRouter{
+		clusters: make(map[string]*clusterClient),
+		observer: observer,
+		log:      log,
+		done:     make(chan struct{}),
+	}
+}
+
+func (r *clusterRouter) add(name string, client outputs.NetworkClient, backoff Backoff) {
+	r.clusters[name] = &clusterClient{
+		name:    name,
+		client:  client,
+		backoff: backoff,
+		log:     r.log,
+	}
+	r.names = append(r.names, name)
+	sort.Strings(r.names)
+}
+
+// Connect connects to all clusters. It only fails if no cluster is
+// available, clusters that fail to connect are retried on publish.
+func (r *clusterRouter) Connect() error {
+	var errs []error
+	for _, name := range r.names {
+		if err := r.clusters[name].connect(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) < len(r.clusters) {
+		return nil
+	}
+	r.waitRetry()
+	return errors.Join(errs...)
+}
+
+func (r *clusterRouter) Close() error {
+	r.closeOnce.Do(func() { close(r.done) })
+	var errs []error
+	for _, name := range r.names {
+		c := r.clusters[name]
+		if !c.connected {
+			continue
+		}
+		c.connected = false
+		if err := c.client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Publish splits batch by destination cluster and publishes the parts in
+// parallel. Events that failed are retried together once all parts are
+// done. Publish only reports errors per cluster, so the output worker never
+// disconnects the healthy clusters.
+func (r *clusterRouter) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+
+	parts := make(map[string]*clusterBatch)
+	dropped := 0
+	for _, event := range events {
+		encoded, ok := event.EncodedEvent.(*encodedEvent)
+		if !ok {
+			r.log.Error("Elasticsearch output received unencoded publisher.Event")
+			dropped++
+			continue
+		}
+		if encoded.err != nil {
+			r.log.Error(encoded.err)
+			dropped++
+			continue
+		}
+		if _, exists := r.clusters[encoded.cluster]; !exists {
+			r.log.Errorf("Dropping event for unknown cluster '%s'", encoded.cluster)
+			dropped++
+			continue
+		}
+		part := r.part(parts, encoded.cluster)
+		part.events = append(part.events, event)
+	}
+	r.observer.PermanentErrors(dropped)
+
+	// If no destination cluster is available, wait for the first one to be
+	// retried instead of returning the events right away.
+	waitUntil := time.Time{}
+	for name := range parts {
+		c := r.clusters[name]
+		if c.available() {
+			waitUntil = time.Time{}
+			break
+		}
+		if waitUntil.IsZero() || c.retryAt.Before(waitUntil) {
+			waitUntil = c.retryAt
+		}
+	}
+	if !waitUntil.IsZero() {
+		r.waitUntil(waitUntil)
+	}
+
+	var wg sync.WaitGroup
+	for name, part := range parts {
+		wg.Add(1)
+		go func(c *clusterClient, part *clusterBatch) {
+			defer wg.Done()
+			c.publish(ctx, part)
+		}(r.clusters[name], part)
+	}
+	wg.Wait()
+
+	var retry []publisher.Event
+	cancelled := true
+	for _, part := range parts {
+		retry = append(retry, part.retry...)
+		if len(part.retry) > 0 && !part.cancelled {
+			cancelled = false
+		}
+	}
+	switch {
+	case len(retry) == 0:
+		batch.ACK()
+	case cancelled && len(retry) == len(events):
+		batch.Cancelled()
+	default:
+		batch.RetryEvents(retry)
+	}
+	return nil
+}
+
+func (r *clusterRouter) part(parts map[string]*clusterBatch, name string) *clusterBatch {
+	part, exists := parts[name]
+	if !exists {
+		part = &clusterBatch{}
+		parts[name] = part
+	}
+	return part
+}
+
+// waitRetry waits until the first cluster can be retried.
+func (r *clusterRouter) waitRetry() {
+	var until time.Time
+	for _, c := range r.clusters {
+		if until.IsZero() || c.retryAt.Before(until) {
+			until = c.retryAt
+		}
+	}
+	r.waitUntil(until)
+}
+
+func (r *clusterRouter) waitUntil(t time.Time) {
+	d := time.Until(t)
+	if d <= 0 {
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-r.done:
+	case <-timer.C:
+	}
+}
+
+func (r *clusterRouter) String() string {
+	return "elasticsearch(clusters: " + strings.Join(r.names, ", ") + ")"
+}
+
+func (r *clusterRouter) Test(d testing.Driver) {
+	for _, name := range r.names {
+		c := r.clusters[name]
+		d.Run("cluster: "+name, func(d testing.Driver) {
+			t, ok := c.client.(testing.Testable)
+			if !ok {
+				d.Fatal("output", errors.New("client doesn't support testing"))
+			}
+			t.Test(d)
+		})
+	}
+}
+
+// available reports whether the cluster is connected or may be reconnected.
+func (c *clusterClient) available() bool {
+	return c.connected || !time.Now().Before(c.retryAt)
+}
+
+func (c *clusterClient) connect() error {
+	if c.connected {
+		return nil
+	}
+	if err := c.client.Connect(); err != nil {
+		c.failed()
+		return fmt.Errorf("cluster %s: %w", c.name, err)
+	}
+	c.connected = true
+	c.wait = 0
+	return nil
+}
+
+// failed schedules the next connection attempt using exponential backoff.
+func (c *clusterClient) failed() {
+	if c.wait == 0 {
+		c.wait = c.backoff.Init
+	} else {
+		c.wait = min(2*c.wait, c.backoff.Max)
+	}
+	c.retryAt = time.Now().Add(c.wait)
+}
+
+func (c *clusterClient) publish(ctx context.Context, batch *clusterBatch) {
+	if !c.available() {
+		batch.Retry()
+		return
+	}
+	if err := c.connect(); err != nil {
+		c.log.Errorf("Failed to connect to %v: %v", c.client, err)
+		batch.Retry()
+		return
+	}
+
+	if err := c.client.Publish(ctx, batch); err != nil {
+		c.log.Errorf("Failed to publish events to cluster %s: %v", c.name, err)
+		c.connected = false
+		c.failed()
+		_ = c.client.Close()
+		return
+	}
+	c.wait = 0
+
+	if batch.split {
+		// The request was too large, publish both halves of the batch
+		// right away.
+		half := len(batch.events) / 2
+		first := &clusterBatch{events: batch.events[:half]}
+		second := &clusterBatch{events: batch.events[half:]}
+		c.publish(ctx, first)
+		c.publish(ctx, second)
+		batch.retry = append(first.retry, second.retry...)
+		batch.cancelled = first.cancelled && second.cancelled
+	}
+}
+
+// clusterBatch is the part of a batch published to a single cluster. It
+// records the outcome so it can be merged into the original batch.
+type clusterBatch struct {
+	events    []publisher.Event
+	retry     []publisher.Event
+	cancelled bool
+	split     bool
+}
+
+func (b *clusterBatch) Events() []publisher.Event {
+	return b.events
+}
+
+func (b *clusterBatch) ACK() {
+	b.retry = nil
+}
+
+func (b *clusterBatch) Drop() {
+	b.retry = nil
+}
+
+func (b *clusterBatch) Retry() {
+	b.retry = b.events
+}
+
+func (b *clusterBatch) RetryEvents(events []publisher.Event) {
+	b.retry = events
+}
+
+func (b *clusterBatch) SplitRetry() bool {
+	if len(b.events) < 2 {
+		return false
+	}
+	b.split = true
+	return true
+}
+
+func (b *clusterBatch) Cancelled() {
+	b.retry = b.events
+	b.cancelled = true
+}
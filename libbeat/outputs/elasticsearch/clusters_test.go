@@ -0,0 +1,225 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeClusterClient struct {
+	connectErr error
+	publish    func(publisher.Batch) error
+	published  [][]publisher.Event
+}
+
+func (c *fakeClusterClient) Connect() error { return c.connectErr }
+func (c *fakeClusterClient) Close() error   { return nil }
+func (c *fakeClusterClient) String() string { return "fake" }
+
+func (c *fakeClusterClient) Publish(_ context.Context, batch publisher.Batch) error {
+	c.published = append(c.published, batch.Events())
+	if c.publish != nil {
+		return c.publish(batch)
+	}
+	batch.ACK()
+	return nil
+}
+
+func clusterEvents(clusters ...string) []publisher.Event {
+	events := make([]publisher.Event, len(clusters))
+	for i, cluster := range clusters {
+		events[i] = publisher.Event{EncodedEvent: &encodedEvent{cluster: cluster}}
+	}
+	return events
+}
+
+func clusterBatchOf(events []publisher.Event) *outest.Batch {
+	batch := outest.NewBatch(make([]beat.Event, len(events))...)
+	copy(batch.Events(), events)
+	return batch
+}
+
+func newTestRouter(clients map[string]*fakeClusterClient) *clusterRouter {
+	router := newClusterRouter(outputs.NewNilObserver(), logp.NewLogger(logSelector))
+	for name, client := range clients {
+		router.add(name, client, Backoff{Init: time.Minute, Max: time.Hour})
+	}
+	return router
+}
+
+func TestClusterRouterPublish(t *testing.T) {
+	a, b := &fakeClusterClient{}, &fakeClusterClient{}
+	router := newTestRouter(map[string]*fakeClusterClient{"a": a, "b": b})
+	require.NoError(t, router.Connect())
+
+	events := clusterEvents("a", "b", "a", "unknown")
+	batch := clusterBatchOf(events)
+	require.NoError(t, router.Publish(context.Background(), batch))
+
+	require.Len(t, a.published, 1)
+	assert.Equal(t, []publisher.Event{events[0], events[2]}, a.published[0])
+	require.Len(t, b.published, 1)
+	assert.Equal(t, []publisher.Event{events[1]}, b.published[0])
+
+	// The event for an unknown cluster is dropped.
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestClusterRouterFailureIsolation(t *testing.T) {
+	a := &fakeClusterClient{}
+	b := &fakeClusterClient{publish: func(batch publisher.Batch) error {
+		batch.RetryEvents(batch.Events())
+		return errors.New("connection refused")
+	}}
+	router := newTestRouter(map[string]*fakeClusterClient{"a": a, "b": b})
+	require.NoError(t, router.Connect())
+
+	events := clusterEvents("a", "b")
+	batch := clusterBatchOf(events)
+	require.NoError(t, router.Publish(context.Background(), batch))
+
+	// Only the events of the failed cluster are retried.
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Equal(t, []publisher.Event{events[1]}, batch.Signals[0].Events)
+
+	// The failed cluster is backed off without holding back the other.
+	assert.False(t, router.clusters["b"].available())
+	events = clusterEvents("a", "b")
+	batch = clusterBatchOf(events)
+	require.NoError(t, router.Publish(context.Background(), batch))
+	assert.Len(t, a.published, 2)
+	assert.Len(t, b.published, 1)
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Equal(t, []publisher.Event{events[1]}, batch.Signals[0].Events)
+}
+
+func TestClusterRouterConnect(t *testing.T) {
+	a := &fakeClusterClient{}
+	b := &fakeClusterClient{connectErr: errors.New("connection refused")}
+	router := newTestRouter(map[string]*fakeClusterClient{"a": a, "b": b})
+
+	// Connect succeeds as long as one cluster is available.
+	require.NoError(t, router.Connect())
+	assert.True(t, router.clusters["a"].connected)
+	assert.False(t, router.clusters["b"].available())
+}
+
+func TestClusterRouterSplit(t *testing.T) {
+	a := &fakeClusterClient{}
+	a.publish = func(batch publisher.Batch) error {
+		if len(batch.Events()) > 1 && batch.SplitRetry() {
+			return nil
+		}
+		batch.ACK()
+		return nil
+	}
+	router := newTestRouter(map[string]*fakeClusterClient{"a": a})
+	require.NoError(t, router.Connect())
+
+	batch := clusterBatchOf(clusterEvents("a", "a", "a", "a"))
+	require.NoError(t, router.Publish(context.Background(), batch))
+
+	// 4 events, split into 2 halves, split into 4 single events.
+	assert.Len(t, a.published, 7)
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestMakeClusterClients(t *testing.T) {
+	cfg := config.MustNewConfigFrom(mapstr.M{
+		"username": "elastic",
+		"cluster":  "%{[fields.tenant]}",
+		"clusters": []mapstr.M{
+			{"name": "a", "hosts": []string{"a1:9200", "a2:9200"}},
+			{"name": "b", "hosts": []string{"b1:9200"}, "username": "tenant-b"},
+		},
+	})
+	clients, err := makeClusterClients(cfg, beat.Info{}, clientSettings{}, logp.NewLogger(logSelector))
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+
+	for i, want := range []map[string]string{
+		{"a": "http://a1:9200", "b": "http://b1:9200"},
+		{"a": "http://a2:9200", "b": "http://b1:9200"},
+	} {
+		router := clients[i].(*clusterRouter)
+		assert.Equal(t, "elasticsearch(clusters: a, b)", router.String())
+		for name, url := range want {
+			client := router.clusters[name].client.(*Client)
+			assert.Equal(t, url, client.conn.URL)
+		}
+		assert.Equal(t, "elastic", router.clusters["a"].client.(*Client).conn.Username)
+		assert.Equal(t, "tenant-b", router.clusters["b"].client.(*Client).conn.Username)
+	}
+}
+
+func TestMakeClusterClientsErrors(t *testing.T) {
+	cases := map[string][]mapstr.M{
+		"missing name":  {{"hosts": []string{"a:9200"}}},
+		"missing hosts": {{"name": "a"}},
+		"duplicate name": {
+			{"name": "a", "hosts": []string{"a:9200"}},
+			{"name": "a", "hosts": []string{"b:9200"}},
+		},
+	}
+	for name, clusters := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.MustNewConfigFrom(mapstr.M{
+				"hosts":    []string{"localhost:9200"},
+				"clusters": clusters,
+			})
+			_, err := makeClusterClients(cfg, beat.Info{}, clientSettings{}, logp.NewLogger(logSelector))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClusterSelection(t *testing.T) {
+	_, err := buildClusterSelector(config.NewConfig())
+	assert.Error(t, err, "a cluster selector is required")
+
+	selector, err := buildClusterSelector(config.MustNewConfigFrom(mapstr.M{
+		"cluster": "%{[fields.tenant]}",
+	}))
+	require.NoError(t, err)
+
+	encoder := newEventEncoder(false, nil, nil, &selector)
+	entry, _ := encoder.EncodeEntry(publisher.Event{Content: beat.Event{
+		Fields: mapstr.M{"fields": mapstr.M{"tenant": "Tenant-A"}},
+	}})
+	encoded := entry.(publisher.Event).EncodedEvent.(*encodedEvent)
+	require.NoError(t, encoded.err)
+	assert.Equal(t, "Tenant-A", encoded.cluster)
+}
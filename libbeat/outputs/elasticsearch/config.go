@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+	"github.com/elastic/beats/v7/libbeat/common/transport/signing"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 )
@@ -36,16 +38,25 @@ type elasticsearchConfig struct {
 	APIKey             string            `config:"api_key"`
 	LoadBalance        bool              `config:"loadbalance"`
 	CompressionLevel   int               `config:"compression_level" validate:"min=0, max=9"`
+	Compression        string            `config:"compression"`
+	Compatibility      string            `config:"compatibility"`
 	EscapeHTML         bool              `config:"escape_html"`
 	Kerberos           *kerberos.Config  `config:"kerberos"`
+	Signing            *signing.Config   `config:"signing"`
 	BulkMaxSize        int               `config:"bulk_max_size"`
+	BulkMaxInFlight    int               `config:"bulk_max_in_flight" validate:"min=1"`
 	MaxRetries         int               `config:"max_retries"`
 	Backoff            Backoff           `config:"backoff"`
 	NonIndexablePolicy *config.Namespace `config:"non_indexable_policy"`
 	AllowOlderVersion  bool              `config:"allow_older_versions"`
+	RequireAlias       bool              `config:"require_alias"`
 	Queue              config.Namespace  `config:"queue"`
 
+	PipelineValidation pipelineValidationConfig `config:"pipeline_validation"`
+	Canary             canaryConfig             `config:"canary"`
+
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+	HTTP2     eslegclient.HTTP2Settings        `config:"http2"`
 }
 
 type Backoff struct {
@@ -66,6 +77,7 @@ var (
 		Password:         "",
 		APIKey:           "",
 		MaxRetries:       3,
+		BulkMaxInFlight:  1,
 		CompressionLevel: 1,
 		EscapeHTML:       false,
 		Kerberos:         nil,
@@ -74,7 +86,9 @@ var (
 			Init: 1 * time.Second,
 			Max:  60 * time.Second,
 		},
+		Canary:    defaultCanaryConfig(),
 		Transport: esDefaultTransportSettings(),
+		HTTP2:     eslegclient.DefaultHTTP2Settings(),
 	}
 )
 
@@ -91,5 +105,17 @@ func (c *elasticsearchConfig) Validate() error {
 		return fmt.Errorf("cannot set both api_key and username/password")
 	}
 
+	if c.Signing.IsEnabled() && (c.APIKey != "" || c.Username != "" || c.Password != "") {
+		return fmt.Errorf("cannot set both signing and api_key or username/password")
+	}
+
+	if err := eslegclient.ValidateCompression(c.Compression); err != nil {
+		return err
+	}
+
+	if err := eslegclient.ValidateCompatibility(c.Compatibility, c.Compression); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -120,6 +120,17 @@ compression_level: 0
 	assert.Equal(t, 0, elasticsearchOutputConfig.CompressionLevel, "Explicit compression level should override defaults")
 }
 
+func TestSigningConflictsWithCredentials(t *testing.T) {
+	config := `
+signing.type: hmac
+signing.hmac: {key_id: beats, secret: secret}
+api_key: foo:bar
+`
+	c := conf.MustNewConfigFrom(config)
+	_, err := readConfig(c)
+	assert.ErrorContains(t, err, "cannot set both signing and api_key or username/password")
+}
+
 func readConfig(cfg *conf.C) (*elasticsearchConfig, error) {
 	c := defaultConfig
 	if err := cfg.Unpack(&c); err != nil {
@@ -20,6 +20,9 @@ package elasticsearch
 import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
+	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/outil"
@@ -29,6 +32,8 @@ import (
 
 func init() {
 	outputs.RegisterType("elasticsearch", makeES)
+	fips.Register(fips.KindOutput, "elasticsearch", kerberos.CheckFIPS)
+	seccomp.RegisterBase(seccomp.KindOutput, "elasticsearch")
 }
 
 const logSelector = "elasticsearch"
@@ -79,66 +84,123 @@ func makeES(
 		return outputs.Fail(err)
 	}
 
-	hosts, err := outputs.ReadHostList(cfg)
-	if err != nil {
-		return outputs.Fail(err)
-	}
-
 	if proxyURL := esConfig.Transport.Proxy.URL; proxyURL != nil && !esConfig.Transport.Proxy.Disable {
 		log.Debugf("breaking down proxy URL. Scheme: '%s', host[:port]: '%s', path: '%s'", proxyURL.Scheme, proxyURL.Host, proxyURL.Path)
 		log.Infof("Using proxy URL: %s", proxyURL)
 	}
 
-	params := esConfig.Params
-	if len(params) == 0 {
-		params = nil
+	settings := clientSettings{
+		indexSelector:    indexSelector,
+		pipelineSelector: pipelineSelector,
+		observer:         observer,
+		deadLetterIndex:  deadLetterIndex,
+		requireAlias:     esConfig.RequireAlias,
+	}
+	if esConfig.PipelineValidation.Enabled && pipelineSelector != nil {
+		// Only pipelines known from the configuration can be checked,
+		// pipelines read from event fields are only known when publishing.
+		settings.pipelines = pipelineSelector.Values()
+		if len(settings.pipelines) > 0 {
+			log.Infof("Checking that ingest pipelines %v exist on connect", settings.pipelines)
+		}
+	}
+	if esConfig.Canary.Enabled {
+		index, _ := cfg.String("index", -1)
+		targets, err := canaryTargets(esConfig.Canary, index)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+		log.Infof("Canary probes are sent to %v after connecting", targets)
+		settings.canary = newCanary(esConfig.Canary, targets)
+	}
+
+	if cfg.HasField("clusters") {
+		if esConfig.BulkMaxInFlight > 1 {
+			log.Warn("bulk_max_in_flight is not supported with clusters, bulk requests are sent one at a time")
+		}
+		clusterSelector, err := buildClusterSelector(cfg)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+		clients, err := makeClusterClients(cfg, beatInfo, settings, log)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+		encoderFactory := newEventEncoderFactory(
+			esConfig.EscapeHTML, indexSelector, pipelineSelector, &clusterSelector)
+		return outputs.SuccessNet(esConfig.Queue, esConfig.LoadBalance, esConfig.BulkMaxSize, esConfig.MaxRetries, encoderFactory, clients)
+	}
+
+	hosts, err := outputs.ReadHostList(cfg)
+	if err != nil {
+		return outputs.Fail(err)
 	}
 
 	encoderFactory := newEventEncoderFactory(
-		esConfig.EscapeHTML, indexSelector, pipelineSelector)
+		esConfig.EscapeHTML, indexSelector, pipelineSelector, nil)
 
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
-		esURL, err := common.MakeURL(esConfig.Protocol, esConfig.Path, host, 9200)
+		client, err := makeClient(esConfig, host, beatInfo, settings)
 		if err != nil {
 			log.Errorf("Invalid host param set: %s, Error: %+v", host, err)
 			return outputs.Fail(err)
 		}
 
-		var client outputs.NetworkClient
-		client, err = NewClient(clientSettings{
-			connection: eslegclient.ConnectionSettings{
-				URL:              esURL,
-				Beatname:         beatInfo.Beat,
-				Kerberos:         esConfig.Kerberos,
-				Username:         esConfig.Username,
-				Password:         esConfig.Password,
-				APIKey:           esConfig.APIKey,
-				Parameters:       params,
-				Headers:          esConfig.Headers,
-				CompressionLevel: esConfig.CompressionLevel,
-				Observer:         observer,
-				EscapeHTML:       esConfig.EscapeHTML,
-				Transport:        esConfig.Transport,
-				IdleConnTimeout:  esConfig.Transport.IdleConnTimeout,
-				UserAgentPostfix: beatInfo.UserAgentPostfix,
-			},
-			indexSelector:    indexSelector,
-			pipelineSelector: pipelineSelector,
-			observer:         observer,
-			deadLetterIndex:  deadLetterIndex,
-		}, &connectCallbackRegistry)
-		if err != nil {
-			return outputs.Fail(err)
+		var nc outputs.NetworkClient = client
+		if esConfig.BulkMaxInFlight > 1 {
+			nc, err = newAsyncClient(client, esConfig.BulkMaxInFlight)
+			if err != nil {
+				return outputs.Fail(err)
+			}
 		}
-
-		client = outputs.WithBackoff(client, esConfig.Backoff.Init, esConfig.Backoff.Max)
-		clients[i] = client
+		clients[i] = outputs.WithBackoff(nc, esConfig.Backoff.Init, esConfig.Backoff.Max)
 	}
 
 	return outputs.SuccessNet(esConfig.Queue, esConfig.LoadBalance, esConfig.BulkMaxSize, esConfig.MaxRetries, encoderFactory, clients)
 }
 
+// makeClient creates a client connecting to host. The selectors, observer and
+// dead letter index are taken from settings.
+func makeClient(
+	esConfig elasticsearchConfig,
+	host string,
+	beatInfo beat.Info,
+	settings clientSettings,
+) (*Client, error) {
+	esURL, err := common.MakeURL(esConfig.Protocol, esConfig.Path, host, 9200)
+	if err != nil {
+		return nil, err
+	}
+
+	params := esConfig.Params
+	if len(params) == 0 {
+		params = nil
+	}
+
+	settings.connection = eslegclient.ConnectionSettings{
+		URL:              esURL,
+		Beatname:         beatInfo.Beat,
+		Kerberos:         esConfig.Kerberos,
+		Signing:          esConfig.Signing,
+		Username:         esConfig.Username,
+		Password:         esConfig.Password,
+		APIKey:           esConfig.APIKey,
+		Parameters:       params,
+		Headers:          esConfig.Headers,
+		CompressionLevel: esConfig.CompressionLevel,
+		Compression:      esConfig.Compression,
+		Compatibility:    esConfig.Compatibility,
+		Observer:         settings.observer,
+		EscapeHTML:       esConfig.EscapeHTML,
+		Transport:        esConfig.Transport,
+		HTTP2:            esConfig.HTTP2,
+		IdleConnTimeout:  esConfig.Transport.IdleConnTimeout,
+		UserAgentPostfix: beatInfo.UserAgentPostfix,
+	}
+	return NewClient(settings, &connectCallbackRegistry)
+}
+
 func buildSelectors(
 	im outputs.IndexManager,
 	_ beat.Info,
@@ -37,6 +37,7 @@ type eventEncoder struct {
 	enc              eslegclient.BodyEncoder
 	pipelineSelector *outil.Selector
 	indexSelector    outputs.IndexSelector
+	clusterSelector  *outil.Selector
 }
 
 type encodedEvent struct {
@@ -58,23 +59,30 @@ type encodedEvent struct {
 	id       string
 	opType   events.OpType
 	pipeline string
+	routing  string
 	index    string
 	encoding []byte
+
+	// cluster is the name of the destination cluster when multiple
+	// clusters are configured.
+	cluster string
 }
 
 func newEventEncoderFactory(
 	escapeHTML bool,
 	indexSelector outputs.IndexSelector,
 	pipelineSelector *outil.Selector,
+	clusterSelector *outil.Selector,
 ) queue.EncoderFactory {
 	return func() queue.Encoder {
-		return newEventEncoder(escapeHTML, indexSelector, pipelineSelector)
+		return newEventEncoder(escapeHTML, indexSelector, pipelineSelector, clusterSelector)
 	}
 }
 
 func newEventEncoder(escapeHTML bool,
 	indexSelector outputs.IndexSelector,
 	pipelineSelector *outil.Selector,
+	clusterSelector *outil.Selector,
 ) queue.Encoder {
 	buf := bytes.NewBuffer(nil)
 	enc := eslegclient.NewJSONEncoder(buf, escapeHTML)
@@ -83,6 +91,7 @@ func newEventEncoder(escapeHTML bool,
 		enc:              enc,
 		pipelineSelector: pipelineSelector,
 		indexSelector:    indexSelector,
+		clusterSelector:  clusterSelector,
 	}
 }
 
@@ -95,7 +104,11 @@ func (pe *eventEncoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
 
 	encodedEvent := pe.encodeRawEvent(&e.Content)
 	e.EncodedEvent = encodedEvent
-	e.Content = beat.Event{}
+	if encodedEvent.err == nil {
+		// The content of the events that can't be encoded is kept, so they
+		// can be written to the dead-letter file.
+		e.Content = beat.Event{}
+	}
 	return e, len(encodedEvent.encoding)
 }
 
@@ -119,7 +132,16 @@ func (pe *eventEncoder) encodeRawEvent(e *beat.Event) *encodedEvent {
 		}
 	}
 
+	var cluster string
+	if pe.clusterSelector != nil {
+		cluster, err = pe.clusterSelector.Select(e)
+		if err != nil {
+			return &encodedEvent{err: fmt.Errorf("failed to select event cluster: %w", err)}
+		}
+	}
+
 	id, _ := events.GetMetaStringValue(*e, events.FieldMetaID)
+	routing, _ := events.GetMetaStringValue(*e, events.FieldMetaRouting)
 
 	err = pe.enc.Marshal(e)
 	if err != nil {
@@ -133,11 +155,18 @@ func (pe *eventEncoder) encodeRawEvent(e *beat.Event) *encodedEvent {
 		timestamp: e.Timestamp,
 		opType:    opType,
 		pipeline:  pipeline,
+		routing:   routing,
 		index:     index,
 		encoding:  bytes,
+		cluster:   cluster,
 	}
 }
 
+// DeadLetterEncoding implements deadletter.Encoded.
+func (e *encodedEvent) DeadLetterEncoding() []byte {
+	return e.encoding
+}
+
 func (e *encodedEvent) setDeadLetter(
 	deadLetterIndex string, errType int, errMsg string,
 ) {
@@ -40,7 +40,7 @@ func (testIndexSelector) Select(event *beat.Event) (string, error) {
 func TestEncodeEntry(t *testing.T) {
 	indexSelector := testIndexSelector{}
 
-	encoder := newEventEncoder(true, indexSelector, nil)
+	encoder := newEventEncoder(true, indexSelector, nil, nil)
 
 	timestamp := time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
 	pubEvent := publisher.Event{
@@ -119,6 +119,7 @@ func encodeEvents(client *Client, events []publisher.Event) []publisher.Event {
 		client.conn.EscapeHTML,
 		client.indexSelector,
 		client.pipelineSelector,
+		nil,
 	)
 	for i := range events {
 		// Skip encoding if there's already encoded data present
@@ -136,6 +137,7 @@ func encodeEvent(client *Client, event publisher.Event) publisher.Event {
 		client.conn.EscapeHTML,
 		client.indexSelector,
 		client.pipelineSelector,
+		nil,
 	)
 	encoded, _ := encoder.EncodeEntry(event)
 	return encoded.(publisher.Event)
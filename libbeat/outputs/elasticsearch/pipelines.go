@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+)
+
+// pipelineValidationConfig configures the check that the ingest pipelines
+// the output is configured with exist.
+type pipelineValidationConfig struct {
+	Enabled bool `config:"enabled"`
+}
+
+// reportPipelineStatus reports a degraded status if pipelines are missing,
+// and reports the output as running again once they exist.
+func reportPipelineStatus(missing []string) {
+	reason := ""
+	if len(missing) > 0 {
+		reason = fmt.Sprintf("Elasticsearch ingest pipelines not found: %s", strings.Join(missing, ", "))
+	}
+	reportDegraded("pipelines", reason)
+}
+
+// missingPipelines returns the pipelines that don't exist in Elasticsearch.
+func missingPipelines(conn *eslegclient.Connection, pipelines []string) ([]string, error) {
+	escaped := make([]string, len(pipelines))
+	for i, p := range pipelines {
+		escaped[i] = url.PathEscape(p)
+	}
+	code, body, err := conn.Request(http.MethodGet, "/_ingest/pipeline/"+strings.Join(escaped, ","), "", nil, nil)
+	if code == http.StatusNotFound {
+		// None of the pipelines exist.
+		return pipelines, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingest pipelines (status=%d): %w", code, err)
+	}
+
+	var found map[string]json.RawMessage
+	if err := json.Unmarshal(body, &found); err != nil {
+		return nil, fmt.Errorf("failed to parse ingest pipelines response: %w", err)
+	}
+	var missing []string
+	for _, p := range pipelines {
+		if _, ok := found[p]; !ok {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package elasticsearch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestMissingPipelines(t *testing.T) {
+	tests := map[string]struct {
+		code     int
+		body     string
+		expected []string
+	}{
+		"all found": {
+			code:     http.StatusOK,
+			body:     `{"p1": {"processors": []}, "p2": {"processors": []}}`,
+			expected: nil,
+		},
+		"some missing": {
+			code:     http.StatusOK,
+			body:     `{"p2": {"processors": []}}`,
+			expected: []string{"p1"},
+		},
+		"none found": {
+			code:     http.StatusNotFound,
+			body:     `{}`,
+			expected: []string{"p1", "p2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var path string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(test.code)
+				w.Write([]byte(test.body))
+			}))
+			defer ts.Close()
+
+			client, err := NewClient(clientSettings{
+				observer:   outputs.NewNilObserver(),
+				connection: eslegclient.ConnectionSettings{URL: ts.URL},
+			}, nil)
+			require.NoError(t, err)
+
+			missing, err := missingPipelines(&client.conn, []string{"p1", "p2"})
+			require.NoError(t, err)
+			assert.Equal(t, "/_ingest/pipeline/p1,p2", path)
+			assert.Equal(t, test.expected, missing)
+		})
+	}
+}
+
+type statusRecorder struct {
+	statuses []status.Status
+	msgs     []string
+}
+
+func (r *statusRecorder) UpdateStatus(s status.Status, msg string) {
+	r.statuses = append(r.statuses, s)
+	r.msgs = append(r.msgs, msg)
+}
+
+func TestReportPipelineStatus(t *testing.T) {
+	recorder := &statusRecorder{}
+	SetStatusReporter(recorder)
+	defer SetStatusReporter(nil)
+
+	// Nothing is reported while all pipelines exist.
+	reportPipelineStatus(nil)
+	assert.Empty(t, recorder.statuses)
+
+	reportPipelineStatus([]string{"p1", "p2"})
+	reportPipelineStatus(nil)
+	reportPipelineStatus(nil)
+	assert.Equal(t, []status.Status{status.Degraded, status.Running}, recorder.statuses)
+	assert.Equal(t, "Elasticsearch ingest pipelines not found: p1, p2", recorder.msgs[0])
+}
+
+func TestCollectPublishFailsPipelineStats(t *testing.T) {
+	client, err := NewClient(clientSettings{observer: outputs.NewNilObserver()}, nil)
+	require.NoError(t, err)
+
+	withPipeline := func(pipeline string) publisher.Event {
+		return publisher.Event{Content: beat.Event{
+			Meta:   mapstr.M{"pipeline": pipeline},
+			Fields: mapstr.M{"field": 1},
+		}}
+	}
+	events := encodeEvents(client, []publisher.Event{
+		withPipeline("p1"),
+		withPipeline("p1"),
+		withPipeline("p2"),
+		{Content: beat.Event{Fields: mapstr.M{"field": 1}}},
+	})
+
+	response := []byte(`{"items": [
+		{"create": {"status": 200}},
+		{"create": {"status": 429}},
+		{"create": {"status": 400, "error": {"type": "mapper_parsing_exception"}}},
+		{"create": {"status": 200}}
+	]}`)
+	_, stats := client.bulkCollectPublishFails(bulkResult{
+		events:   events,
+		status:   200,
+		response: response,
+	})
+
+	assert.Equal(t, map[string]*pipelineResultStats{
+		"p1": {acked: 1, fails: 1},
+		"p2": {nonIndexable: 1},
+	}, stats.pipelines)
+}
@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/management/status"
+)
+
+// XXX: like the connect callbacks, the status reporter is a package global
+// as outputs have no access to the beat's manager.
+var outputStatus struct {
+	sync.Mutex
+	reporter status.StatusReporter
+	// degraded holds the reason the output is degraded, by failed check.
+	degraded map[string]string
+}
+
+// SetStatusReporter sets the reporter used to report a degraded status when
+// the checks of the output fail, like configured ingest pipelines that don't
+// exist.
+func SetStatusReporter(reporter status.StatusReporter) {
+	outputStatus.Lock()
+	defer outputStatus.Unlock()
+	outputStatus.reporter = reporter
+	outputStatus.degraded = nil
+}
+
+// reportDegraded reports a degraded status when check failed for reason, or
+// clears the failure of check when reason is empty. The output is reported as
+// running again once no check fails.
+func reportDegraded(check, reason string) {
+	outputStatus.Lock()
+	defer outputStatus.Unlock()
+	if outputStatus.reporter == nil {
+		return
+	}
+	if reason != "" {
+		if outputStatus.degraded == nil {
+			outputStatus.degraded = map[string]string{}
+		}
+		outputStatus.degraded[check] = reason
+	} else {
+		if _, ok := outputStatus.degraded[check]; !ok {
+			return
+		}
+		delete(outputStatus.degraded, check)
+	}
+
+	if len(outputStatus.degraded) == 0 {
+		outputStatus.reporter.UpdateStatus(status.Running, "Healthy")
+		return
+	}
+	reasons := make([]string, 0, len(outputStatus.degraded))
+	for _, r := range outputStatus.degraded {
+		reasons = append(reasons, r)
+	}
+	sort.Strings(reasons)
+	outputStatus.reporter.UpdateStatus(status.Degraded, strings.Join(reasons, "; "))
+}
@@ -0,0 +1,265 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// state tracks the availability of the primary output. It is shared by all
+// the clients of the output, so they fail over and back together.
+type state struct {
+	log              *logp.Logger
+	failoverAfter    time.Duration
+	failbackInterval time.Duration
+	now              func() time.Time
+
+	mu sync.Mutex
+	// downSince is when the primary became unavailable, zero if it is
+	// available.
+	downSince  time.Time
+	failedOver bool
+	// lastCheck is when the primary was last checked while failed over.
+	lastCheck time.Time
+}
+
+// primaryUp records that the primary is available, failing back if needed.
+func (s *state) primaryUp() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failedOver {
+		s.log.Infof("Primary output available again after %v, failing back", s.now().Sub(s.downSince))
+	}
+	s.downSince = time.Time{}
+	s.failedOver = false
+}
+
+// primaryDown records that the primary is unavailable and reports whether
+// it has been for long enough to fail over.
+func (s *state) primaryDown(err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if s.downSince.IsZero() {
+		s.downSince = now
+	}
+	if !s.failedOver && now.Sub(s.downSince) >= s.failoverAfter {
+		s.log.Warnf("Primary output unavailable since %v, failing over to the secondary output: %v", s.downSince, err)
+		s.failedOver = true
+		s.lastCheck = now
+	}
+	return s.failedOver
+}
+
+// shouldCheck reports whether a client should check if the primary is
+// available again. Only one client checks it every failback interval.
+func (s *state) shouldCheck() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if !s.failedOver || now.Sub(s.lastCheck) < s.failbackInterval {
+		return false
+	}
+	s.lastCheck = now
+	return true
+}
+
+// active reports whether the output failed over, and since when the primary
+// is unavailable.
+func (s *state) active() (failedOver bool, downSince time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failedOver, s.downSince
+}
+
+// sharedClient serializes the use of a secondary client by several clients
+// of the output.
+type sharedClient struct {
+	mu        sync.Mutex
+	client    outputs.Client
+	connected bool
+	refs      int
+}
+
+func (c *sharedClient) connect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connected {
+		return nil
+	}
+	if nc, ok := c.client.(outputs.Connectable); ok {
+		if err := nc.Connect(); err != nil {
+			return err
+		}
+	}
+	c.connected = true
+	return nil
+}
+
+func (c *sharedClient) publish(ctx context.Context, batch publisher.Batch) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.client.Publish(ctx, batch)
+	if err != nil {
+		c.connected = false
+	}
+	return err
+}
+
+// close closes the client once all the clients of the output using it are
+// closed.
+func (c *sharedClient) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refs--
+	if c.refs > 0 {
+		return nil
+	}
+	c.connected = false
+	return c.client.Close()
+}
+
+// client publishes to a client of the primary output, or to a client of the
+// secondary output once failed over.
+type client struct {
+	log              *logp.Logger
+	state            *state
+	primary          outputs.Client
+	primaryType      string
+	secondary        *sharedClient
+	secondaryEncoder queue.Encoder
+	annotate         bool
+
+	primaryConnected bool
+}
+
+func (c *client) Connect() error {
+	if failedOver, _ := c.state.active(); failedOver {
+		return c.secondary.connect()
+	}
+
+	err := c.connectPrimary()
+	if err == nil {
+		return nil
+	}
+	if !c.state.primaryDown(err) {
+		return err
+	}
+	return c.secondary.connect()
+}
+
+func (c *client) connectPrimary() error {
+	return c.connect(c.primary)
+}
+
+// checkPrimary connects the primary while failed over. The backoff of the
+// primary client is skipped, so a failed check doesn't delay publishing to
+// the secondary.
+func (c *client) checkPrimary() error {
+	if b, ok := c.primary.(interface{ Client() outputs.NetworkClient }); ok {
+		return c.connect(b.Client())
+	}
+	return c.connectPrimary()
+}
+
+func (c *client) connect(primary outputs.Client) error {
+	c.primaryConnected = false
+	if nc, ok := primary.(outputs.Connectable); ok {
+		if err := nc.Connect(); err != nil {
+			return err
+		}
+	}
+	c.primaryConnected = true
+	return nil
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	failedOver, downSince := c.state.active()
+	if failedOver && c.state.shouldCheck() {
+		// Check if the primary is available again.
+		if err := c.checkPrimary(); err != nil {
+			c.log.Debugf("Primary output still unavailable: %v", err)
+		} else {
+			c.state.primaryUp()
+			failedOver = false
+		}
+	}
+
+	if failedOver {
+		var a *annotation
+		if c.annotate {
+			a = &annotation{primary: c.primaryType, since: downSince}
+		}
+		toSecondary(batch.Events(), c.secondaryEncoder, a)
+		return c.secondary.publish(ctx, batch)
+	}
+
+	// Another client may have failed back.
+	if !c.primaryConnected {
+		if err := c.connectPrimary(); err != nil {
+			c.state.primaryDown(err)
+			batch.Cancelled()
+			return err
+		}
+	}
+
+	toPrimary(batch.Events())
+	if err := c.primary.Publish(ctx, batch); err != nil {
+		c.primaryConnected = false
+		c.state.primaryDown(err)
+		return err
+	}
+	c.state.primaryUp()
+	return nil
+}
+
+func (c *client) Close() error {
+	return errors.Join(c.primary.Close(), c.secondary.close())
+}
+
+func (c *client) String() string {
+	return fmt.Sprintf("%s(%s,%s)", outputName, c.primary, c.secondary.client)
+}
+
+func (c *client) Test(d testing.Driver) {
+	for _, output := range []struct {
+		name   string
+		client outputs.Client
+	}{
+		{"primary", c.primary},
+		{"secondary", c.secondary.client},
+	} {
+		t, ok := output.client.(testing.Testable)
+		d.Run(output.name, func(d testing.Driver) {
+			if !ok {
+				d.Fatal("output", errors.New("client doesn't support testing"))
+			}
+			t.Test(d)
+		})
+	}
+}
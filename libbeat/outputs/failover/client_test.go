@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type mockClient struct {
+	name       string
+	connectErr error
+	publishErr error
+	published  []publisher.Event
+	connects   int
+	closed     bool
+}
+
+func (c *mockClient) Connect() error {
+	c.connects++
+	return c.connectErr
+}
+
+func (c *mockClient) Publish(_ context.Context, batch publisher.Batch) error {
+	if c.publishErr != nil {
+		batch.Retry()
+		return c.publishErr
+	}
+	c.published = append(c.published, batch.Events()...)
+	batch.ACK()
+	return nil
+}
+
+func (c *mockClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *mockClient) String() string { return c.name }
+
+// mockEncoder encodes the message of the events, clearing their content.
+type mockEncoder struct{}
+
+func (mockEncoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
+	event := entry.(publisher.Event)
+	msg, _ := event.Content.Fields.GetValue("message")
+	event.EncodedEvent = "encoded:" + msg.(string)
+	event.Content = beat.Event{}
+	return event, 1
+}
+
+func newTestBatch(t *testing.T, messages ...string) *outest.Batch {
+	t.Helper()
+	enc := newEncoderFactory(func() queue.Encoder { return mockEncoder{} })()
+	batch := outest.NewBatch(make([]beat.Event, len(messages))...)
+	for i, msg := range messages {
+		encoded, _ := enc.EncodeEntry(publisher.Event{Content: beat.Event{Fields: mapstr.M{"message": msg}}})
+		batch.Events()[i] = encoded.(publisher.Event)
+	}
+	return batch
+}
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestClient(clock *fakeClock, primary, secondary *mockClient) *client {
+	st := &state{
+		log:              logp.NewLogger(outputName),
+		failoverAfter:    30 * time.Second,
+		failbackInterval: 10 * time.Second,
+		now:              clock.now,
+	}
+	return &client{
+		log:         st.log,
+		state:       st,
+		primary:     primary,
+		primaryType: "elasticsearch",
+		secondary:   &sharedClient{client: secondary, refs: 1},
+		annotate:    true,
+	}
+}
+
+func TestFailoverAndFailback(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	primary := &mockClient{name: "primary"}
+	secondary := &mockClient{name: "secondary"}
+	c := newTestClient(clock, primary, secondary)
+
+	// Events are published to the primary while it is available.
+	require.NoError(t, c.Connect())
+	require.NoError(t, c.Publish(context.Background(), newTestBatch(t, "a")))
+	require.Len(t, primary.published, 1)
+	assert.Equal(t, "encoded:a", primary.published[0].EncodedEvent)
+
+	// The primary is unavailable, but not for long enough to fail over.
+	downSince := clock.t
+	primary.publishErr = errors.New("connection refused")
+	primary.connectErr = errors.New("connection refused")
+	assert.Error(t, c.Publish(context.Background(), newTestBatch(t, "b")))
+	clock.t = clock.t.Add(10 * time.Second)
+	assert.Error(t, c.Connect())
+	assert.Equal(t, 0, secondary.connects)
+
+	// The primary has been unavailable for long enough.
+	clock.t = clock.t.Add(20 * time.Second)
+	require.NoError(t, c.Connect())
+	assert.Equal(t, 1, secondary.connects)
+	require.NoError(t, c.Publish(context.Background(), newTestBatch(t, "c")))
+	require.Len(t, secondary.published, 1)
+	event := secondary.published[0]
+	assert.Nil(t, event.EncodedEvent)
+	assert.Equal(t, mapstr.M{
+		"message": "c",
+		"failover": mapstr.M{
+			"primary": "elasticsearch",
+			"since":   downSince,
+		},
+	}, event.Content.Fields)
+
+	// The primary is not checked before the failback interval.
+	primary.connectErr = nil
+	primary.publishErr = nil
+	require.NoError(t, c.Publish(context.Background(), newTestBatch(t, "d")))
+	assert.Len(t, secondary.published, 2)
+
+	// The primary is available again.
+	clock.t = clock.t.Add(10 * time.Second)
+	require.NoError(t, c.Publish(context.Background(), newTestBatch(t, "e")))
+	assert.Len(t, secondary.published, 2)
+	require.Len(t, primary.published, 2)
+	assert.Equal(t, "encoded:e", primary.published[1].EncodedEvent)
+	failedOver, _ := c.state.active()
+	assert.False(t, failedOver)
+
+	require.NoError(t, c.Close())
+	assert.True(t, primary.closed)
+	assert.True(t, secondary.closed)
+}
+
+func TestRetriedEventsKeepContent(t *testing.T) {
+	batch := newTestBatch(t, "a")
+
+	toPrimary(batch.Events())
+	assert.Equal(t, "encoded:a", batch.Events()[0].EncodedEvent)
+	assert.Nil(t, batch.Events()[0].Content.Fields)
+
+	// The batch is retried on the secondary after failing over.
+	toSecondary(batch.Events(), nil, nil)
+	assert.Nil(t, batch.Events()[0].EncodedEvent)
+	assert.Equal(t, mapstr.M{"message": "a"}, batch.Events()[0].Content.Fields)
+
+	// And on the primary again after failing back.
+	toPrimary(batch.Events())
+	assert.Equal(t, "encoded:a", batch.Events()[0].EncodedEvent)
+}
+
+func TestSecondaryEncoder(t *testing.T) {
+	batch := newTestBatch(t, "a")
+
+	toSecondary(batch.Events(), mockEncoder{}, &annotation{primary: "elasticsearch"})
+	assert.Equal(t, "encoded:a", batch.Events()[0].EncodedEvent)
+
+	// The annotation is not kept in the content used by the primary.
+	enc := unwrap(&batch.Events()[0])
+	require.NotNil(t, enc)
+	assert.Equal(t, mapstr.M{"message": "a"}, enc.content.Fields)
+}
+
+func TestSharedClient(t *testing.T) {
+	secondary := &mockClient{name: "secondary"}
+	sc := &sharedClient{client: secondary, refs: 2}
+
+	require.NoError(t, sc.connect())
+	require.NoError(t, sc.connect())
+	assert.Equal(t, 1, secondary.connects, "connected clients are not connected again")
+
+	secondary.publishErr = errors.New("disk full")
+	assert.Error(t, sc.publish(context.Background(), outest.NewBatch()))
+	require.NoError(t, sc.connect())
+	assert.Equal(t, 2, secondary.connects, "clients are connected again after a publish error")
+
+	require.NoError(t, sc.close())
+	assert.False(t, secondary.closed, "the client is used by another client")
+	require.NoError(t, sc.close())
+	assert.True(t, secondary.closed)
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+type failoverConfig struct {
+	// Primary is the output events are published to while it is available.
+	Primary config.Namespace `config:"primary"`
+	// Secondary is the output events are published to once the primary
+	// has been unavailable for FailoverAfter.
+	Secondary config.Namespace `config:"secondary"`
+	// FailoverAfter is how long the primary must be unavailable before
+	// failing over to the secondary.
+	FailoverAfter time.Duration `config:"failover_after" validate:"min=0"`
+	// FailbackInterval is how often the primary is checked while failed over.
+	FailbackInterval time.Duration `config:"failback_interval" validate:"min=1ns"`
+	// Annotate adds the failover field to the events published to the
+	// secondary, so they can be told apart when replaying them.
+	Annotate bool             `config:"annotate"`
+	Queue    config.Namespace `config:"queue"`
+}
+
+func defaultConfig() failoverConfig {
+	return failoverConfig{
+		FailoverAfter:    30 * time.Second,
+		FailbackInterval: 30 * time.Second,
+		Annotate:         true,
+	}
+}
+
+func (c *failoverConfig) Validate() error {
+	if !c.Primary.IsSet() {
+		return errors.New("primary output must be set")
+	}
+	if !c.Secondary.IsSet() {
+		return errors.New("secondary output must be set")
+	}
+	for _, ns := range []config.Namespace{c.Primary, c.Secondary} {
+		if ns.Name() == outputName {
+			return fmt.Errorf("%s output can't be nested", outputName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestConfig(t *testing.T) {
+	for name, test := range map[string]struct {
+		config  mapstr.M
+		wantErr string
+	}{
+		"valid": {
+			config: mapstr.M{
+				"primary.elasticsearch.hosts": []string{"localhost:9200"},
+				"secondary.file.path":         "/tmp/failover",
+				"failover_after":              "1m",
+			},
+		},
+		"missing primary": {
+			config: mapstr.M{
+				"secondary.file.path": "/tmp/failover",
+			},
+			wantErr: "primary output must be set",
+		},
+		"missing secondary": {
+			config: mapstr.M{
+				"primary.elasticsearch.hosts": []string{"localhost:9200"},
+			},
+			wantErr: "secondary output must be set",
+		},
+		"nested failover": {
+			config: mapstr.M{
+				"primary.elasticsearch.hosts":          []string{"localhost:9200"},
+				"secondary.failover.primary.file.path": "/tmp/failover",
+			},
+			wantErr: "failover output can't be nested",
+		},
+		"invalid failback interval": {
+			config: mapstr.M{
+				"primary.elasticsearch.hosts": []string{"localhost:9200"},
+				"secondary.file.path":         "/tmp/failover",
+				"failback_interval":           0,
+			},
+			wantErr: "failback_interval",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			foConfig := defaultConfig()
+			err := config.MustNewConfigFrom(test.config).Unpack(&foConfig)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "elasticsearch", foConfig.Primary.Name())
+			assert.Equal(t, "file", foConfig.Secondary.Name())
+			assert.Equal(t, time.Minute, foConfig.FailoverAfter)
+			assert.Equal(t, 30*time.Second, foConfig.FailbackInterval)
+			assert.True(t, foConfig.Annotate)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// cacheKey is the key of the event cache keeping the encodedEvent while the
+// EncodedEvent field holds the form of the output the event is published to.
+const cacheKey = "failover"
+
+// encodedEvent keeps the original content of an event next to its early
+// encoded form for the primary, so it can still be published to the
+// secondary.
+type encodedEvent struct {
+	primary interface{}
+	content beat.Event
+}
+
+// newEncoderFactory returns an encoder factory encoding events with the
+// encoders of the primary output, if any, and keeping their content.
+func newEncoderFactory(primary queue.EncoderFactory) queue.EncoderFactory {
+	return func() queue.Encoder {
+		e := &encoder{}
+		if primary != nil {
+			e.primary = primary()
+		}
+		return e
+	}
+}
+
+type encoder struct {
+	primary queue.Encoder
+}
+
+func (e *encoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
+	event, ok := entry.(publisher.Event)
+	if !ok {
+		return entry, 0
+	}
+
+	enc := &encodedEvent{content: event.Content}
+	size := 0
+	if e.primary != nil {
+		var encoded queue.Entry
+		encoded, size = e.primary.EncodeEntry(event)
+		if encodedEvent, ok := encoded.(publisher.Event); ok {
+			enc.primary = encodedEvent.EncodedEvent
+		}
+	}
+
+	event.Content = beat.Event{}
+	event.EncodedEvent = enc
+	return event, size
+}
+
+// unwrap returns the encodedEvent of an event, whatever the output it was
+// last published to.
+func unwrap(event *publisher.Event) *encodedEvent {
+	if enc, ok := event.EncodedEvent.(*encodedEvent); ok {
+		return enc
+	}
+	if v, err := event.Cache.GetValue(cacheKey); err == nil {
+		if enc, ok := v.(*encodedEvent); ok {
+			return enc
+		}
+	}
+	return nil
+}
+
+// toPrimary prepares the events to be published to the primary output.
+func toPrimary(events []publisher.Event) {
+	for i := range events {
+		enc := unwrap(&events[i])
+		if enc == nil {
+			continue
+		}
+		_, _ = events[i].Cache.Put(cacheKey, enc)
+		if enc.primary != nil {
+			events[i].Content = beat.Event{}
+		} else {
+			events[i].Content = enc.content
+		}
+		events[i].EncodedEvent = enc.primary
+	}
+}
+
+// annotation describes the failover in the events published to the
+// secondary output.
+type annotation struct {
+	primary string
+	since   time.Time
+}
+
+// toSecondary prepares the events to be published to the secondary output,
+// encoding them with secondary if it is not nil. If annotation is not nil it
+// is added to the events.
+func toSecondary(events []publisher.Event, secondary queue.Encoder, a *annotation) {
+	for i := range events {
+		enc := unwrap(&events[i])
+		if enc == nil {
+			continue
+		}
+		_, _ = events[i].Cache.Put(cacheKey, enc)
+
+		content := enc.content
+		if a != nil {
+			// Copy the top level of the fields so the annotation is not
+			// published to the primary on fail-back.
+			fields := make(mapstr.M, len(content.Fields)+1)
+			for k, v := range content.Fields {
+				fields[k] = v
+			}
+			fields[cacheKey] = mapstr.M{
+				"primary": a.primary,
+				"since":   a.since,
+			}
+			content.Fields = fields
+		}
+
+		events[i].Content = content
+		events[i].EncodedEvent = nil
+		if secondary != nil {
+			encoded, _ := secondary.EncodeEntry(events[i])
+			if event, ok := encoded.(publisher.Event); ok {
+				events[i].Content = event.Content
+				events[i].EncodedEvent = event.EncodedEvent
+			}
+		}
+	}
+}
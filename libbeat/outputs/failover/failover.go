@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const outputName = "failover"
+
+func init() {
+	outputs.RegisterType(outputName, makeFailover)
+	fips.Register(fips.KindOutput, outputName, checkFIPS)
+	seccomp.RegisterBase(seccomp.KindOutput, outputName)
+}
+
+// makeFailover creates the clients of the primary and secondary outputs and
+// pairs them, so each client publishes to the primary until it fails over.
+func makeFailover(
+	im outputs.IndexManager,
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *config.C,
+) (outputs.Group, error) {
+	log := logp.NewLogger(outputName)
+
+	foConfig := defaultConfig()
+	if err := cfg.Unpack(&foConfig); err != nil {
+		return outputs.Fail(err)
+	}
+
+	primary, err := outputs.Load(im, beat, observer, foConfig.Primary.Name(), foConfig.Primary.Config())
+	if err != nil {
+		return outputs.Fail(fmt.Errorf("failed to load primary %s output: %w", foConfig.Primary.Name(), err))
+	}
+	secondary, err := outputs.Load(im, beat, observer, foConfig.Secondary.Name(), foConfig.Secondary.Config())
+	if err != nil {
+		return outputs.Fail(fmt.Errorf("failed to load secondary %s output: %w", foConfig.Secondary.Name(), err))
+	}
+	if len(primary.Clients) == 0 || len(secondary.Clients) == 0 {
+		return outputs.Fail(outputs.ErrNoConnectionConfigured)
+	}
+
+	st := &state{
+		log:              log,
+		failoverAfter:    foConfig.FailoverAfter,
+		failbackInterval: foConfig.FailbackInterval,
+		now:              time.Now,
+	}
+
+	// The secondary clients are shared by the clients of the primary, as
+	// the primary usually has more of them.
+	shared := make([]*sharedClient, len(secondary.Clients))
+	for i, c := range secondary.Clients {
+		shared[i] = &sharedClient{client: c}
+	}
+
+	clients := make([]outputs.Client, len(primary.Clients))
+	for i, c := range primary.Clients {
+		sc := shared[i%len(shared)]
+		sc.refs++
+		fc := &client{
+			log:         log,
+			state:       st,
+			primary:     c,
+			primaryType: foConfig.Primary.Name(),
+			secondary:   sc,
+			annotate:    foConfig.Annotate,
+		}
+		if secondary.EncoderFactory != nil {
+			fc.secondaryEncoder = secondary.EncoderFactory()
+		}
+		clients[i] = fc
+	}
+
+	log.Infof("Publishing to the %s output, failing over to the %s output after %v",
+		foConfig.Primary.Name(), foConfig.Secondary.Name(), foConfig.FailoverAfter)
+	return outputs.Success(foConfig.Queue, primary.BatchSize, primary.Retry, newEncoderFactory(primary.EncoderFactory), clients...)
+}
+
+// checkFIPS reports the output as FIPS capable only if both the primary and
+// the secondary outputs are.
+func checkFIPS(cfg *config.C) error {
+	foConfig := defaultConfig()
+	if err := cfg.Unpack(&foConfig); err != nil {
+		return err
+	}
+	for _, ns := range []config.Namespace{foConfig.Primary, foConfig.Secondary} {
+		switch c := fips.Check(fips.KindOutput, ns.Name(), "", ns.Config()); c.Status {
+		case fips.StatusIncapable:
+			return fmt.Errorf("%s output: %s", ns.Name(), c.Reason)
+		case fips.StatusUnknown:
+			return fmt.Errorf("%s output: %w", ns.Name(), fips.ErrUnknownCapability)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package fieldtypes coerces event fields to configured types while events
+// are encoded for an output, so that sources sending the same field with
+// different types cannot cause mapping conflicts in the destination.
+package fieldtypes
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Observer receives the coercion metrics.
+type Observer interface {
+	FieldsCoerced(int)      // report number of field values converted to another type
+	FieldTypeConflicts(int) // report number of field values dropped as they could not be converted
+}
+
+// Rule forces a field to a type.
+type Rule struct {
+	Field string `config:"field" validate:"required"`
+	Type  string `config:"type" validate:"required"`
+}
+
+// Config is the `field_types` setting of an output.
+type Config struct {
+	FieldTypes []Rule `config:"field_types"`
+}
+
+type converter func(interface{}) (interface{}, bool)
+
+var converters = map[string]converter{
+	"long":    toLong,
+	"double":  toDouble,
+	"keyword": toKeyword,
+	"boolean": toBoolean,
+}
+
+// Validate checks that the type of the rule is supported.
+func (r *Rule) Validate() error {
+	if _, ok := converters[r.Type]; !ok {
+		return fmt.Errorf("unsupported type '%s' for field '%s', expected one of long, double, keyword or boolean", r.Type, r.Field)
+	}
+	return nil
+}
+
+// Coercer applies the field type rules of an output to events.
+type Coercer struct {
+	rules    []Rule
+	observer Observer
+	log      *logp.Logger
+}
+
+// New creates a Coercer from the configuration of an output. It returns nil
+// if no field types are configured.
+func New(cfg *config.C, observer Observer) (*Coercer, error) {
+	if cfg == nil || !cfg.HasField("field_types") {
+		return nil, nil
+	}
+
+	var c Config
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("invalid field_types: %w", err)
+	}
+	if len(c.FieldTypes) == 0 {
+		return nil, nil
+	}
+
+	return &Coercer{
+		rules:    c.FieldTypes,
+		observer: observer,
+		log:      logp.NewLogger("field_types"),
+	}, nil
+}
+
+// Coerce converts the configured fields in place. Values that cannot be
+// converted are removed from the event.
+func (c *Coercer) Coerce(fields mapstr.M) {
+	coerced, conflicts := 0, 0
+	for _, rule := range c.rules {
+		v, err := fields.GetValue(rule.Field)
+		if err != nil || v == nil {
+			continue
+		}
+
+		out, changed, ok := convert(v, converters[rule.Type])
+		switch {
+		case !ok:
+			conflicts++
+			_ = fields.Delete(rule.Field)
+			c.log.Debugf("Dropped field '%s', value of type %T cannot be converted to %s", rule.Field, v, rule.Type)
+		case changed:
+			coerced++
+			_, _ = fields.Put(rule.Field, out)
+		}
+	}
+
+	if c.observer != nil {
+		if coerced > 0 {
+			c.observer.FieldsCoerced(coerced)
+		}
+		if conflicts > 0 {
+			c.observer.FieldTypeConflicts(conflicts)
+		}
+	}
+}
+
+// WrapEncoderFactory returns an encoder factory that coerces the fields of
+// events before passing them to the encoders of next. If next is nil the
+// events are only coerced.
+func (c *Coercer) WrapEncoderFactory(next queue.EncoderFactory) queue.EncoderFactory {
+	return func() queue.Encoder {
+		e := &encoder{coercer: c}
+		if next != nil {
+			e.next = next()
+		}
+		return e
+	}
+}
+
+type encoder struct {
+	coercer *Coercer
+	next    queue.Encoder
+}
+
+func (e *encoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
+	if event, ok := entry.(publisher.Event); ok && event.Content.Fields != nil {
+		e.coercer.Coerce(event.Content.Fields)
+	}
+	if e.next == nil {
+		return entry, 0
+	}
+	return e.next.EncodeEntry(entry)
+}
+
+// convert applies conv to v, or to each element if v is a list. changed
+// reports if the JSON type of any value was changed.
+func convert(v interface{}, conv converter) (interface{}, bool, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		out, ok := conv(v)
+		return out, ok && jsonKind(out) != jsonKind(v), ok
+	}
+
+	changed := false
+	out := make([]interface{}, rv.Len())
+	for i := range out {
+		elem := rv.Index(i).Interface()
+		converted, ok := conv(elem)
+		if !ok {
+			return nil, false, false
+		}
+		changed = changed || jsonKind(converted) != jsonKind(elem)
+		out[i] = converted
+	}
+	return out, changed, true
+}
+
+func jsonKind(v interface{}) reflect.Kind {
+	switch k := reflect.ValueOf(v).Kind(); k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	default:
+		return k
+	}
+}
+
+func toLong(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, false
+		}
+		return int64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return floatToLong(rv.Float())
+	case reflect.String:
+		s := strings.TrimSpace(rv.String())
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return floatToLong(f)
+		}
+	}
+	return nil, false
+}
+
+func floatToLong(f float64) (interface{}, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return nil, false
+	}
+	return int64(f), true
+}
+
+func toDouble(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+func toKeyword(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Map {
+		// Objects can't be keywords, even if they implement fmt.Stringer
+		return nil, false
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), true
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), true
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), true
+	}
+	return nil, false
+}
+
+func toBoolean(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), true
+	case reflect.String:
+		if b, err := strconv.ParseBool(strings.TrimSpace(rv.String())); err == nil {
+			return b, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,185 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fieldtypes
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type testObserver struct {
+	coerced   int
+	conflicts int
+}
+
+func (o *testObserver) FieldsCoerced(n int)      { o.coerced += n }
+func (o *testObserver) FieldTypeConflicts(n int) { o.conflicts += n }
+
+func newTestCoercer(t *testing.T, rules map[string]string) (*Coercer, *testObserver) {
+	t.Helper()
+	var fieldTypes []map[string]interface{}
+	for field, typ := range rules {
+		fieldTypes = append(fieldTypes, map[string]interface{}{"field": field, "type": typ})
+	}
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"hosts":       []string{"localhost:9200"},
+		"field_types": fieldTypes,
+	})
+
+	observer := &testObserver{}
+	c, err := New(cfg, observer)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	return c, observer
+}
+
+func TestNew(t *testing.T) {
+	t.Run("no field types", func(t *testing.T) {
+		c, err := New(config.MustNewConfigFrom(map[string]interface{}{"hosts": []string{"localhost:9200"}}), nil)
+		require.NoError(t, err)
+		assert.Nil(t, c)
+
+		c, err = New(nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := New(config.MustNewConfigFrom(map[string]interface{}{
+			"field_types": []map[string]interface{}{{"field": "port", "type": "integer"}},
+		}), nil)
+		assert.ErrorContains(t, err, "unsupported type 'integer'")
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		_, err := New(config.MustNewConfigFrom(map[string]interface{}{
+			"field_types": []map[string]interface{}{{"type": "long"}},
+		}), nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCoerce(t *testing.T) {
+	tests := map[string]struct {
+		rules     map[string]string
+		fields    mapstr.M
+		expected  mapstr.M
+		coerced   int
+		conflicts int
+	}{
+		"string to long": {
+			rules:    map[string]string{"source.port": "long"},
+			fields:   mapstr.M{"source": mapstr.M{"port": " 443 "}},
+			expected: mapstr.M{"source": mapstr.M{"port": int64(443)}},
+			coerced:  1,
+		},
+		"integral float to long": {
+			rules:    map[string]string{"port": "long"},
+			fields:   mapstr.M{"port": 8080.0},
+			expected: mapstr.M{"port": int64(8080)},
+		},
+		"number to keyword": {
+			rules:    map[string]string{"process.pid": "keyword"},
+			fields:   mapstr.M{"process": mapstr.M{"pid": 1234}},
+			expected: mapstr.M{"process": mapstr.M{"pid": "1234"}},
+			coerced:  1,
+		},
+		"stringer to keyword": {
+			rules:    map[string]string{"ip": "keyword"},
+			fields:   mapstr.M{"ip": net.IPv4(10, 0, 0, 1)},
+			expected: mapstr.M{"ip": "10.0.0.1"},
+			coerced:  1,
+		},
+		"string to double": {
+			rules:    map[string]string{"ratio": "double"},
+			fields:   mapstr.M{"ratio": "0.5"},
+			expected: mapstr.M{"ratio": 0.5},
+			coerced:  1,
+		},
+		"string to boolean": {
+			rules:    map[string]string{"ok": "boolean"},
+			fields:   mapstr.M{"ok": "true"},
+			expected: mapstr.M{"ok": true},
+			coerced:  1,
+		},
+		"list elements": {
+			rules:    map[string]string{"ports": "long"},
+			fields:   mapstr.M{"ports": []string{"80", "443"}},
+			expected: mapstr.M{"ports": []interface{}{int64(80), int64(443)}},
+			coerced:  1,
+		},
+		"conflicting value is dropped": {
+			rules:     map[string]string{"port": "long", "host": "keyword"},
+			fields:    mapstr.M{"port": "http", "host": mapstr.M{"name": "a"}, "message": "m"},
+			expected:  mapstr.M{"message": "m"},
+			conflicts: 2,
+		},
+		"missing fields are ignored": {
+			rules:    map[string]string{"port": "long"},
+			fields:   mapstr.M{"message": "m"},
+			expected: mapstr.M{"message": "m"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, observer := newTestCoercer(t, test.rules)
+			c.Coerce(test.fields)
+			assert.Equal(t, test.expected, test.fields)
+			assert.Equal(t, test.coerced, observer.coerced)
+			assert.Equal(t, test.conflicts, observer.conflicts)
+		})
+	}
+}
+
+type testEncoder struct{}
+
+func (testEncoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
+	e, _ := entry.(publisher.Event)
+	e.EncodedEvent = e.Content.Fields.String()
+	e.Content = beat.Event{}
+	return e, 1
+}
+
+func TestWrapEncoderFactory(t *testing.T) {
+	c, _ := newTestCoercer(t, map[string]string{"port": "long"})
+	event := publisher.Event{Content: beat.Event{Fields: mapstr.M{"port": "80"}}}
+
+	t.Run("without output encoder", func(t *testing.T) {
+		entry, size := c.WrapEncoderFactory(nil)().EncodeEntry(event)
+		assert.Equal(t, 0, size)
+		assert.Equal(t, mapstr.M{"port": int64(80)}, entry.(publisher.Event).Content.Fields)
+	})
+
+	t.Run("with output encoder", func(t *testing.T) {
+		event := publisher.Event{Content: beat.Event{Fields: mapstr.M{"port": "80"}}}
+		factory := c.WrapEncoderFactory(func() queue.Encoder { return testEncoder{} })
+		entry, size := factory().EncodeEntry(event)
+		assert.Equal(t, 1, size)
+		assert.Equal(t, `{"port":80}`, entry.(publisher.Event).EncodedEvent)
+	})
+}
@@ -25,6 +25,8 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/beats/v7/libbeat/publisher"
@@ -35,6 +37,8 @@ import (
 
 func init() {
 	outputs.RegisterType("file", makeFileout)
+	fips.RegisterCapable(fips.KindOutput, "file")
+	seccomp.RegisterBase(seccomp.KindOutput, "file")
 }
 
 type fileOutput struct {
@@ -31,10 +31,18 @@ type hostWorkerCfg struct {
 	// the value of Worker should take precedence. To always retrieve the correct
 	// value, use the NumWorkers() method.
 	Workers int `config:"workers"`
+
+	// Autoscale replaces Worker when it is enabled.
+	Autoscale WorkerAutoscaleConfig `config:"worker_autoscale"`
 }
 
 // NumWorkers returns the number of output workers desired.
 func (hwc hostWorkerCfg) NumWorkers() int {
+	// With autoscaling, the maximum number of workers is created.
+	if hwc.Autoscale.Enabled {
+		return hwc.Autoscale.MaxWorkers
+	}
+
 	// Both Worker and Workers are set; give precedence to Worker.
 	if hwc.Worker != 0 && hwc.Workers != 0 {
 		return hwc.Worker
@@ -50,9 +58,11 @@ func (hwc hostWorkerCfg) NumWorkers() int {
 
 // ReadHostList reads a list of hosts to connect to from an configuration
 // object. If the `worker` settings is > 1, each host is duplicated in the final
-// host list by the number of `worker`.
+// host list by the number of `worker`. With `worker_autoscale`, the hosts are
+// duplicated by `max_workers` in turn, so that the workers activated first are
+// spread over all the hosts.
 func ReadHostList(cfg *config.C) ([]string, error) {
-	var config hostWorkerCfg
+	config := hostWorkerCfg{Autoscale: DefaultWorkerAutoscaleConfig()}
 	err := cfg.Unpack(&config)
 	if err != nil {
 		return nil, err
@@ -70,6 +80,12 @@ func ReadHostList(cfg *config.C) ([]string, error) {
 
 	// duplicate entries config.NumWorkers() times
 	hosts := make([]string, 0, len(lst)*config.NumWorkers())
+	if config.Autoscale.Enabled {
+		for i := 0; i < config.NumWorkers(); i++ {
+			hosts = append(hosts, lst...)
+		}
+		return hosts, nil
+	}
 	for _, entry := range lst {
 		for i := 0; i < config.NumWorkers(); i++ {
 			hosts = append(hosts, entry)
@@ -33,6 +33,10 @@ func TestHostsNumWorkers(t *testing.T) {
 		"worker_set":  {hwc: hostWorkerCfg{Worker: 17}, expectedNumWorkers: 17},
 		"workers_set": {hwc: hostWorkerCfg{Workers: 23}, expectedNumWorkers: 23},
 		"both_set":    {hwc: hostWorkerCfg{Worker: 17, Workers: 23}, expectedNumWorkers: 17},
+		"autoscale": {
+			hwc:                hostWorkerCfg{Worker: 17, Autoscale: WorkerAutoscaleConfig{Enabled: true, MaxWorkers: 5}},
+			expectedNumWorkers: 5,
+		},
 	}
 
 	for name, test := range tests {
@@ -75,6 +79,17 @@ func TestReadHostList(t *testing.T) {
 			},
 			expectedHosts: []string{"foo.bar", "foo.bar", "foo.bar"},
 		},
+		"two_hosts_worker_autoscale": {
+			cfg: map[string]interface{}{
+				"hosts":  []string{"foo.bar", "bar.baz"},
+				"worker": 3,
+				"worker_autoscale": map[string]interface{}{
+					"enabled":     true,
+					"max_workers": 2,
+				},
+			},
+			expectedHosts: []string{"foo.bar", "bar.baz", "foo.bar", "bar.baz"},
+		},
 	}
 
 	for name, test := range tests {
@@ -88,3 +103,24 @@ func TestReadHostList(t *testing.T) {
 		})
 	}
 }
+
+func TestReadWorkerAutoscale(t *testing.T) {
+	cfg := config.MustNewConfigFrom(map[string]interface{}{"hosts": []string{"foo.bar"}})
+	autoscale, err := readWorkerAutoscale(cfg)
+	require.NoError(t, err)
+	require.Nil(t, autoscale)
+
+	cfg = config.MustNewConfigFrom(map[string]interface{}{
+		"worker_autoscale": map[string]interface{}{"enabled": true, "max_workers": 8},
+	})
+	autoscale, err = readWorkerAutoscale(cfg)
+	require.NoError(t, err)
+	require.Equal(t, 1, autoscale.MinWorkers)
+	require.Equal(t, 8, autoscale.MaxWorkers)
+
+	cfg = config.MustNewConfigFrom(map[string]interface{}{
+		"worker_autoscale": map[string]interface{}{"enabled": true, "min_workers": 4, "max_workers": 2},
+	})
+	_, err = readWorkerAutoscale(cfg)
+	require.ErrorContains(t, err, "min_workers must not be greater than max_workers")
+}
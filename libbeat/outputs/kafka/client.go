@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -29,12 +30,15 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/eapache/go-resiliency/breaker"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/beats/v7/libbeat/outputs/outil"
 	"github.com/elastic/beats/v7/libbeat/publisher"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/testing"
 	"github.com/elastic/elastic-agent-libs/transport"
 )
@@ -53,6 +57,16 @@ type client struct {
 
 	producer sarama.AsyncProducer
 
+	// racks locates the partition leaders in the client rack, nil when no
+	// rack is configured. The producer then uses its own sarama client, set
+	// in libClient, so the partitioner can read the metadata.
+	racks     *rackLocator
+	libClient sarama.Client
+
+	// topics creates the missing topics, nil when topic creation is
+	// disabled.
+	topics *topicCreator
+
 	recordHeaders []sarama.RecordHeader
 
 	wg sync.WaitGroup
@@ -81,6 +95,8 @@ func newKafkaClient(
 	headers []header,
 	writer codec.Codec,
 	cfg *sarama.Config,
+	topics *topicCreator,
+	racks *rackLocator,
 ) (*client, error) {
 	c := &client{
 		log:      logp.NewLogger(logSelector),
@@ -92,6 +108,8 @@ func newKafkaClient(
 		codec:    writer,
 		config:   *cfg,
 		done:     make(chan struct{}),
+		topics:   topics,
+		racks:    racks,
 	}
 
 	if len(headers) != 0 {
@@ -120,7 +138,13 @@ func (c *client) Connect() error {
 	c.log.Debugf("connect: %v", c.hosts)
 
 	// try to connect
-	producer, err := sarama.NewAsyncProducer(c.hosts, &c.config)
+	var producer sarama.AsyncProducer
+	var err error
+	if c.racks != nil {
+		producer, err = c.connectRackAware()
+	} else {
+		producer, err = sarama.NewAsyncProducer(c.hosts, &c.config)
+	}
 	if err != nil {
 		c.log.Errorf("Kafka connect fails with: %+v", err)
 		return err
@@ -149,6 +173,20 @@ func (c *client) Close() error {
 	c.producer.AsyncClose()
 	c.wg.Wait()
 	c.producer = nil
+
+	if c.libClient != nil {
+		c.racks.setClient(nil)
+		if err := c.libClient.Close(); err != nil {
+			c.log.Warnf("Failed to close kafka client: %v", err)
+		}
+		c.libClient = nil
+	}
+
+	if c.topics != nil {
+		if err := c.topics.close(); err != nil {
+			c.log.Warnf("Failed to close kafka cluster admin: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -164,6 +202,10 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 		batch:  batch,
 	}
 
+	// topicErrs holds the topics that couldn't be created in this batch, so
+	// that the creation is attempted once per batch.
+	var topicErrs map[string]error
+
 	ch := c.producer.Input()
 	for i := range events {
 		d := &events[i]
@@ -176,6 +218,24 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 		}
 
 		msg.ref = ref
+		if c.topics != nil {
+			err, failed := topicErrs[msg.topic]
+			if !failed {
+				if err = c.topics.ensure(msg.topic); err != nil {
+					c.log.Errorf("Kafka (topic=%v): %v", msg.topic, err)
+					if topicErrs == nil {
+						topicErrs = map[string]error{}
+					}
+					topicErrs[msg.topic] = err
+				}
+			}
+			if err != nil {
+				// The event is retried with the batch.
+				ref.fail(msg, err)
+				continue
+			}
+		}
+
 		msg.initProducerMessage()
 		ch <- &msg.msg
 	}
@@ -183,6 +243,23 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 	return nil
 }
 
+// connectRackAware creates the producer from a client shared with the rack
+// locator.
+func (c *client) connectRackAware() (sarama.AsyncProducer, error) {
+	libClient, err := sarama.NewClient(c.hosts, &c.config)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := sarama.NewAsyncProducerFromClient(libClient)
+	if err != nil {
+		libClient.Close()
+		return nil, err
+	}
+	c.libClient = libClient
+	c.racks.setClient(libClient)
+	return producer, nil
+}
+
 func (c *client) String() string {
 	return "kafka(" + strings.Join(c.hosts, ",") + ")"
 }
@@ -249,19 +326,91 @@ func (c *client) getEventMessage(data *publisher.Event) (*message, error) {
 		}
 	}
 
+	msg.headers = eventHeaders(event)
+
 	return msg, nil
 }
 
+// eventHeaders returns the headers set in the @metadata of the event, sorted
+// by name.
+func eventHeaders(event *beat.Event) []sarama.RecordHeader {
+	value, err := event.Meta.GetValue(events.FieldMetaHeaders)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	switch v := value.(type) {
+	case mapstr.M:
+		fields = v
+	case map[string]interface{}:
+		fields = v
+	case map[string]string:
+		fields = make(map[string]interface{}, len(v))
+		for k, s := range v {
+			fields[k] = s
+		}
+	default:
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	headers := make([]sarama.RecordHeader, 0, len(names))
+	for _, name := range names {
+		var b []byte
+		switch v := fields[name].(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		case nil:
+		default:
+			b = []byte(fmt.Sprint(v))
+		}
+		headers = append(headers, sarama.RecordHeader{Key: []byte(name), Value: b})
+	}
+	return headers
+}
+
 func (c *client) successWorker(ch <-chan *sarama.ProducerMessage) {
 	defer c.wg.Done()
 	defer c.log.Debug("Stop kafka ack worker")
 
 	for libMsg := range ch {
 		msg := libMsg.Metadata.(*message)
+		if c.racks != nil {
+			c.observeRack(libMsg)
+		}
+		c.observer.PartitionEvents(libMsg.Topic, libMsg.Partition, 1)
 		msg.ref.done()
 	}
 }
 
+// observeRack reports the bytes of a message acknowledged by a partition
+// leader in the client rack or in another rack.
+func (c *client) observeRack(libMsg *sarama.ProducerMessage) {
+	local, ok := c.racks.isLocal(libMsg.Topic, libMsg.Partition)
+	if !ok {
+		return
+	}
+	n := libMsg.Value.Length()
+	if libMsg.Key != nil {
+		n += libMsg.Key.Length()
+	}
+	if local {
+		c.observer.LocalRackBytes(n)
+	} else {
+		c.observer.CrossRackBytes(n)
+	}
+}
+
 func (c *client) errorWorker(ch <-chan *sarama.ProducerError) {
 	breakerOpen := false
 	defer c.wg.Done()
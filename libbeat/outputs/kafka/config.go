@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net"
 	"regexp"
 	"strings"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/common/kafka"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+	"github.com/elastic/beats/v7/libbeat/common/transport/proxy"
 	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -54,6 +56,7 @@ type header struct {
 type kafkaConfig struct {
 	Hosts              []string                  `config:"hosts"               validate:"required"`
 	TLS                *tlscommon.Config         `config:"ssl"`
+	Proxy              proxy.Config              `config:",inline"`
 	Kerberos           *kerberos.Config          `config:"kerberos"`
 	Timeout            time.Duration             `config:"timeout"             validate:"min=1"`
 	Metadata           metaConfig                `config:"metadata"`
@@ -72,6 +75,7 @@ type kafkaConfig struct {
 	Headers            []header                  `config:"headers"`
 	Backoff            backoffConfig             `config:"backoff"`
 	ClientID           string                    `config:"client_id"`
+	Rack               string                    `config:"rack"`
 	ChanBufferSize     int                       `config:"channel_buffer_size" validate:"min=1"`
 	Username           string                    `config:"username"`
 	Password           string                    `config:"password"`
@@ -79,6 +83,7 @@ type kafkaConfig struct {
 	Sasl               kafka.SaslConfig          `config:"sasl"`
 	EnableFAST         bool                      `config:"enable_krb5_fast"`
 	Queue              config.Namespace          `config:"queue"`
+	TopicCreation      topicCreationConfig       `config:"topic_creation"`
 
 	// Currently only used for validation. Those values are later
 	// unpacked into temporary structs whenever they're necessary.
@@ -147,6 +152,7 @@ func defaultConfig() kafkaConfig {
 		ChanBufferSize: 256,
 		Username:       "",
 		Password:       "",
+		TopicCreation:  defaultTopicCreationConfig(),
 	}
 }
 
@@ -186,6 +192,17 @@ func (c *kafkaConfig) Validate() error {
 		return errors.New("either 'topic' or 'topics' must be defined")
 	}
 
+	if _, ok := c.Partition["rack_aware"]; ok && c.Rack == "" {
+		return errors.New("partition.rack_aware requires rack to be set")
+	}
+
+	if c.TopicCreation.Enabled {
+		// CreateTopics requests were added in Kafka 0.10.1.0.
+		if version, ok := c.Version.Get(); ok && !version.IsAtLeast(sarama.V0_10_1_0) {
+			return fmt.Errorf("topic_creation requires kafka version 0.10.1.0 or newer, got %v", c.Version)
+		}
+	}
+
 	// When running under Elastic-Agent we do not support dynamic topic
 	// selection, so `topics` is not supported and `topic` is treated as an
 	// plain string
@@ -202,8 +219,8 @@ func (c *kafkaConfig) Validate() error {
 	return nil
 }
 
-func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, error) {
-	partitioner, err := makePartitioner(log, config.Partition)
+func newSaramaConfig(log *logp.Logger, config *kafkaConfig, racks *rackLocator) (*sarama.Config, error) {
+	partitioner, err := makePartitioner(log, config.Partition, racks)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +233,15 @@ func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, err
 	k.Net.ReadTimeout = timeout
 	k.Net.WriteTimeout = timeout
 	k.Net.KeepAlive = config.KeepAlive
+	if config.Proxy.IsEnabled() {
+		forward := &net.Dialer{Timeout: timeout, KeepAlive: config.KeepAlive}
+		dialer, err := proxy.Dialer(log, &config.Proxy, forward, timeout)
+		if err != nil {
+			return nil, err
+		}
+		k.Net.Proxy.Enable = true
+		k.Net.Proxy.Dialer = dialer
+	}
 	k.Producer.Timeout = config.BrokerTimeout
 	k.Producer.CompressionLevel = config.CompressionLevel
 
@@ -302,6 +328,7 @@ func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, err
 
 	// configure client ID
 	k.ClientID = config.ClientID
+	k.RackID = config.Rack
 
 	version, ok := config.Version.Get()
 	if !ok {
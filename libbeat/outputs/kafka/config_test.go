@@ -65,6 +65,27 @@ func TestConfigAcceptValid(t *testing.T) {
 				"realm":        "ELASTIC",
 			},
 		},
+		"SOCKS5 proxy": mapstr.M{
+			"topic":     "foo",
+			"proxy_url": "socks5://proxy:1080",
+		},
+		"HTTP proxy with credentials": mapstr.M{
+			"topic":          "foo",
+			"proxy_url":      "http://proxy:3128",
+			"proxy_username": "beats",
+			"proxy_password": "changeme",
+		},
+		"topic creation": mapstr.M{
+			"topic": "foo",
+			"topic_creation": mapstr.M{
+				"enabled":            true,
+				"partitions":         3,
+				"replication_factor": 2,
+				"configs": mapstr.M{
+					"retention.ms": 86400000,
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -78,7 +99,7 @@ func TestConfigAcceptValid(t *testing.T) {
 			if err != nil {
 				t.Fatalf("Can not create test configuration: %v", err)
 			}
-			if _, err := newSaramaConfig(logp.L(), cfg); err != nil {
+			if _, err := newSaramaConfig(logp.L(), cfg, nil); err != nil {
 				t.Fatalf("Failure creating sarama config: %v", err)
 			}
 		})
@@ -97,6 +118,24 @@ func TestConfigInvalid(t *testing.T) {
 		},
 		// The default config does not set `topic` nor `topics`.
 		"No topics or topic provided": mapstr.M{},
+		"Unsupported proxy scheme": mapstr.M{
+			"topic":     "foo",
+			"proxy_url": "ftp://proxy:21",
+		},
+		"topic creation with zero partitions": mapstr.M{
+			"topic": "foo",
+			"topic_creation": mapstr.M{
+				"enabled":    true,
+				"partitions": 0,
+			},
+		},
+		"topic creation with old kafka version": mapstr.M{
+			"topic":   "foo",
+			"version": "0.10.0",
+			"topic_creation": mapstr.M{
+				"enabled": true,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -23,6 +23,10 @@ import (
 	"github.com/Shopify/sarama"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
+	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
@@ -39,6 +43,8 @@ func init() {
 	sarama.Logger = kafkaLogger{log: logp.NewLogger(logSelector)}
 
 	outputs.RegisterType("kafka", makeKafka)
+	fips.Register(fips.KindOutput, "kafka", kerberos.CheckFIPS)
+	seccomp.RegisterBase(seccomp.KindOutput, "kafka")
 }
 
 func makeKafka(
@@ -60,7 +66,12 @@ func makeKafka(
 		return outputs.Fail(err)
 	}
 
-	libCfg, err := newSaramaConfig(log, kConfig)
+	var racks *rackLocator
+	if kConfig.Rack != "" {
+		racks = newRackLocator(kConfig.Rack)
+	}
+
+	libCfg, err := newSaramaConfig(log, kConfig, racks)
 	if err != nil {
 		return outputs.Fail(err)
 	}
@@ -75,7 +86,13 @@ func makeKafka(
 		return outputs.Fail(err)
 	}
 
-	client, err := newKafkaClient(observer, hosts, beat.IndexPrefix, kConfig.Key, topic, kConfig.Headers, codec, libCfg)
+	var topics *topicCreator
+	if kConfig.TopicCreation.Enabled {
+		cfgwarn.Beta("Kafka topic creation is beta.")
+		topics = newTopicCreator(log, hosts, libCfg, kConfig.TopicCreation)
+	}
+
+	client, err := newKafkaClient(observer, hosts, beat.IndexPrefix, kConfig.Key, topic, kConfig.Headers, codec, libCfg, topics, racks)
 	if err != nil {
 		return outputs.Fail(err)
 	}
@@ -20,6 +20,9 @@ package kafka
 import (
 	"testing"
 
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -92,3 +95,31 @@ func TestBuildTopicSelector(t *testing.T) {
 		}
 	})
 }
+
+func TestEventHeaders(t *testing.T) {
+	client := &client{recordHeaders: []sarama.RecordHeader{
+		{Key: []byte("source"), Value: []byte("beats")},
+	}}
+
+	event := &beat.Event{Meta: mapstr.M{
+		"headers": mapstr.M{
+			"tenant":   "a",
+			"priority": 3,
+		},
+	}}
+	msg := &message{
+		ref:     &msgRef{client: client},
+		headers: eventHeaders(event),
+	}
+	msg.initProducerMessage()
+
+	assert.Equal(t, []sarama.RecordHeader{
+		{Key: []byte("source"), Value: []byte("beats")},
+		{Key: []byte("priority"), Value: []byte("3")},
+		{Key: []byte("tenant"), Value: []byte("a")},
+	}, msg.msg.Headers)
+	assert.Len(t, client.recordHeaders, 1, "the headers of the output must not be modified")
+
+	assert.Nil(t, eventHeaders(&beat.Event{}))
+	assert.Nil(t, eventHeaders(&beat.Event{Meta: mapstr.M{"headers": "invalid"}}))
+}
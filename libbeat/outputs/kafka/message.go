@@ -37,6 +37,10 @@ type message struct {
 	hash      uint32
 	partition int32
 
+	// headers are the headers of this event, added to the headers
+	// configured for the output.
+	headers []sarama.RecordHeader
+
 	data publisher.Event
 }
 
@@ -54,4 +58,9 @@ func (m *message) initProducerMessage() {
 	if m.ref != nil {
 		m.msg.Headers = m.ref.client.recordHeaders
 	}
+	if len(m.headers) > 0 {
+		headers := make([]sarama.RecordHeader, 0, len(m.msg.Headers)+len(m.headers))
+		headers = append(headers, m.msg.Headers...)
+		m.msg.Headers = append(headers, m.headers...)
+	}
 }
@@ -48,8 +48,9 @@ type messagePartitioner struct {
 func makePartitioner(
 	log *logp.Logger,
 	partition map[string]*config.C,
+	racks *rackLocator,
 ) (sarama.PartitionerConstructor, error) {
-	mkStrategy, reachable, err := initPartitionStrategy(log, partition)
+	mkStrategy, reachable, err := initPartitionStrategy(log, partition, racks)
 	if err != nil {
 		return nil, err
 	}
@@ -66,11 +67,13 @@ var partitioners = map[string]partitionBuilder{
 	"random":      cfgRandomPartitioner,
 	"round_robin": cfgRoundRobinPartitioner,
 	"hash":        cfgHashPartitioner,
+	"composite":   cfgCompositePartitioner,
 }
 
 func initPartitionStrategy(
 	log *logp.Logger,
 	partition map[string]*config.C,
+	racks *rackLocator,
 ) (func() partitioner, bool, error) {
 	if len(partition) == 0 {
 		// default use `hash` partitioner + all partitions (block if unreachable)
@@ -89,11 +92,21 @@ func initPartitionStrategy(
 	}
 
 	// instantiate partitioner strategy
-	mk := partitioners[name]
-	if mk == nil {
-		return nil, false, fmt.Errorf("unknown kafka partition mode %v", name)
+	var constr func() partitioner
+	var err error
+	if name == "rack_aware" {
+		// The rack aware partitioner depends on the metadata of the client.
+		if racks == nil {
+			return nil, false, errors.New("rack_aware partitioning requires rack to be set")
+		}
+		constr, err = cfgRackAwarePartitioner(log, config, racks)
+	} else {
+		mk := partitioners[name]
+		if mk == nil {
+			return nil, false, fmt.Errorf("unknown kafka partition mode %v", name)
+		}
+		constr, err = mk(log, config)
 	}
-	constr, err := mk(log, config)
 	if err != nil {
 		return nil, false, err
 	}
@@ -214,6 +227,76 @@ func cfgHashPartitioner(log *logp.Logger, config *config.C) (func() partitioner,
 	}, nil
 }
 
+// cfgCompositePartitioner partitions events by a key combined from several
+// fields. Events with none of the fields stick to a random partition for
+// group_events events, instead of being spread randomly one by one.
+func cfgCompositePartitioner(_ *logp.Logger, config *config.C) (func() partitioner, error) {
+	cfg := struct {
+		Fields      []string `config:"fields" validate:"required"`
+		GroupEvents int      `config:"group_events" validate:"min=1"`
+	}{
+		GroupEvents: 100,
+	}
+	if err := config.Unpack(&cfg); err != nil {
+		return nil, err
+	}
+
+	return func() partitioner {
+		return makeCompositePartitioner(cfg.Fields, cfg.GroupEvents)
+	}, nil
+}
+
+func makeCompositePartitioner(fields []string, groupEvents int) partitioner {
+	generator := rand.New(rand.NewSource(rand.Int63()))
+	hasher := fnv.New32a()
+	count := groupEvents
+	sticky := int32(0)
+
+	return func(msg *message, numPartitions int32) (int32, error) {
+		hash := msg.hash
+		if hash == 0 {
+			hasher.Reset()
+			found := false
+			for _, field := range fields {
+				// Missing fields still add the separator, so that the same
+				// value in another field gives another key.
+				if err := hashFieldValue(hasher, msg.data.Content.Fields, field); err == nil {
+					found = true
+				}
+				if _, err := hasher.Write([]byte{0}); err != nil {
+					return -1, err
+				}
+			}
+
+			if !found {
+				if count == groupEvents || sticky >= numPartitions {
+					count = 0
+					sticky = int32(generator.Intn(int(numPartitions)))
+				}
+				count++
+				return sticky, nil
+			}
+			msg.hash = hasher.Sum32()
+			hash = msg.hash
+		}
+
+		return jumpHash(uint64(hash), numPartitions), nil
+	}
+}
+
+// jumpHash maps a key to one of numBuckets buckets with the jump consistent
+// hash of Lamping and Veach, so that adding partitions to a topic only moves
+// the keys needed to fill the new partitions.
+func jumpHash(key uint64, numBuckets int32) int32 {
+	b, j := int64(-1), int64(0)
+	for j < int64(numBuckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int32(b)
+}
+
 func makeHashPartitioner() partitioner {
 	generator := rand.New(rand.NewSource(rand.Int63()))
 	hasher := fnv.New32a()
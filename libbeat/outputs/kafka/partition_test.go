@@ -179,6 +179,24 @@ func TestPartitioners(t *testing.T) {
 				"hash":           arr{"message"},
 			}},
 		},
+		{
+			"composite message field, non-consistent",
+			true,
+			hashScenarios,
+			obj{"partition.composite": obj{
+				"reachable_only": true,
+				"fields":         arr{"message", "host.name"},
+			}},
+		},
+		{
+			"composite message field, consistent",
+			false,
+			hashScenarios,
+			obj{"partition.composite": obj{
+				"reachable_only": false,
+				"fields":         arr{"message", "host.name"},
+			}},
+		},
 	}
 
 	for i, test := range tests {
@@ -199,7 +217,7 @@ func TestPartitioners(t *testing.T) {
 			continue
 		}
 
-		constr, err := makePartitioner(logp.L(), pcfg.Partition)
+		constr, err := makePartitioner(logp.L(), pcfg.Partition, nil)
 		if err != nil {
 			t.Error(err)
 			continue
@@ -369,3 +387,62 @@ func TestHash2Partition(t *testing.T) {
 		})
 	}
 }
+
+func TestCompositePartitioner(t *testing.T) {
+	const numPartitions = int32(12)
+
+	partition := func(part partitioner, fields mapstr.M) int32 {
+		msg := &message{partition: -1}
+		msg.data = publisher.Event{Content: beat.Event{Fields: fields}}
+		p, err := part(msg, numPartitions)
+		assert.NoError(t, err)
+		assert.True(t, 0 <= p && p < numPartitions)
+		return p
+	}
+
+	t.Run("same fields give same partition", func(t *testing.T) {
+		part := makeCompositePartitioner([]string{"host.name", "log.file.path"}, 10)
+		fields := mapstr.M{"host": mapstr.M{"name": "web-1"}, "log": mapstr.M{"file": mapstr.M{"path": "/var/log/a.log"}}}
+		p := partition(part, fields)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, p, partition(part, fields.Clone()))
+		}
+	})
+
+	t.Run("composite key spreads a dominant host", func(t *testing.T) {
+		part := makeCompositePartitioner([]string{"host.name", "log.file.path"}, 10)
+		used := map[int32]bool{}
+		for i := 0; i < 100; i++ {
+			used[partition(part, mapstr.M{
+				"host": mapstr.M{"name": "web-1"},
+				"log":  mapstr.M{"file": mapstr.M{"path": fmt.Sprintf("/var/log/%d.log", i)}},
+			})] = true
+		}
+		assert.Greater(t, len(used), 3)
+	})
+
+	t.Run("missing fields stick to a partition", func(t *testing.T) {
+		part := makeCompositePartitioner([]string{"host.name"}, 10)
+		first := partition(part, mapstr.M{"message": "a"})
+		for i := 1; i < 10; i++ {
+			assert.Equal(t, first, partition(part, mapstr.M{"message": "a"}))
+		}
+	})
+}
+
+func TestJumpHash(t *testing.T) {
+	const keys = 10000
+
+	moved := 0
+	for key := uint64(0); key < keys; key++ {
+		before := jumpHash(key, 10)
+		after := jumpHash(key, 11)
+		assert.True(t, 0 <= before && before < 10)
+		if before != after {
+			// keys only move to the new partition
+			assert.Equal(t, int32(10), after)
+			moved++
+		}
+	}
+	assert.InDelta(t, keys/11, moved, keys/50)
+}
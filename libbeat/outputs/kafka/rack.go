@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// rackRefreshInterval is how long the racks of the partition leaders of a
+// topic are cached. Leaders move between brokers when brokers restart, the
+// partitioner then follows the new leaders after this interval.
+const rackRefreshInterval = 30 * time.Second
+
+// leaderRackFunc returns the rack of the leader of a partition, or false if
+// the leader or its rack is unknown.
+type leaderRackFunc func(topic string, partition int32) (string, bool)
+
+// rackLocator tells which partitions of a topic have their leader in the
+// rack of the client. It reads the metadata of the client of the producer,
+// set on connect.
+type rackLocator struct {
+	rack string
+	now  func() time.Time
+
+	mu         sync.Mutex
+	leaderRack leaderRackFunc
+	topics     map[string]*topicRacks
+}
+
+// topicRacks are the racks of the partition leaders of a topic.
+type topicRacks struct {
+	expires time.Time
+	racks   []string // indexed by partition, empty if unknown
+	local   []int32  // partitions with their leader in the client rack
+}
+
+func newRackLocator(rack string) *rackLocator {
+	return &rackLocator{
+		rack:   rack,
+		now:    time.Now,
+		topics: map[string]*topicRacks{},
+	}
+}
+
+// setClient sets the client used to look up partition leaders, nil when the
+// client is closed.
+func (l *rackLocator) setClient(client sarama.Client) {
+	var leaderRack leaderRackFunc
+	if client != nil {
+		leaderRack = func(topic string, partition int32) (string, bool) {
+			broker, err := client.Leader(topic, partition)
+			if err != nil || broker.Rack() == "" {
+				return "", false
+			}
+			return broker.Rack(), true
+		}
+	}
+	l.setLeaderRack(leaderRack)
+}
+
+func (l *rackLocator) setLeaderRack(leaderRack leaderRackFunc) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.leaderRack = leaderRack
+	l.topics = map[string]*topicRacks{}
+}
+
+// localPartitions returns the partitions of topic with their leader in the
+// client rack.
+func (l *rackLocator) localPartitions(topic string, numPartitions int32) []int32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t := l.lookup(topic, numPartitions)
+	if t == nil {
+		return nil
+	}
+	return t.local
+}
+
+// isLocal tells whether the leader of a partition is in the client rack. ok
+// is false when the rack of the leader is unknown.
+func (l *rackLocator) isLocal(topic string, partition int32) (local, ok bool) {
+	l.mu.Lock()
+	leaderRack := l.leaderRack
+	l.mu.Unlock()
+	if leaderRack == nil {
+		return false, false
+	}
+	rack, ok := leaderRack(topic, partition)
+	if !ok {
+		return false, false
+	}
+	return rack == l.rack, true
+}
+
+func (l *rackLocator) lookup(topic string, numPartitions int32) *topicRacks {
+	if l.leaderRack == nil {
+		return nil
+	}
+
+	now := l.now()
+	t := l.topics[topic]
+	if t != nil && now.Before(t.expires) && int32(len(t.racks)) == numPartitions {
+		return t
+	}
+
+	t = &topicRacks{
+		expires: now.Add(rackRefreshInterval),
+		racks:   make([]string, numPartitions),
+	}
+	for p := int32(0); p < numPartitions; p++ {
+		rack, ok := l.leaderRack(topic, p)
+		if !ok {
+			continue
+		}
+		t.racks[p] = rack
+		if rack == l.rack {
+			t.local = append(t.local, p)
+		}
+	}
+	l.topics[topic] = t
+	return t
+}
+
+func cfgRackAwarePartitioner(_ *logp.Logger, config *config.C, racks *rackLocator) (func() partitioner, error) {
+	cfg := struct {
+		GroupEvents int `config:"group_events" validate:"min=1"`
+	}{
+		GroupEvents: 1,
+	}
+	if err := config.Unpack(&cfg); err != nil {
+		return nil, err
+	}
+
+	return func() partitioner {
+		N := cfg.GroupEvents
+		count := N
+		next := rand.Int31()
+
+		return func(msg *message, numPartitions int32) (int32, error) {
+			if N == count {
+				count = 0
+				next = (next + 1) & 0x7FFFFFFF
+			}
+			count++
+
+			// Round robin over the partitions led by a broker in the client
+			// rack, or over all partitions if there are none.
+			if local := racks.localPartitions(msg.topic, numPartitions); len(local) > 0 {
+				return local[next%int32(len(local))], nil
+			}
+			return next % numPartitions, nil
+		}
+	}, nil
+}
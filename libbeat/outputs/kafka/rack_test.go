@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestRackAwarePartitioner(t *testing.T) {
+	racks := newRackLocator("zone-a")
+	now := time.Now()
+	racks.now = func() time.Time { return now }
+
+	leaders := map[int32]string{0: "zone-a", 1: "zone-b", 2: "zone-a", 3: "zone-c", 4: ""}
+	lookups := 0
+	racks.setLeaderRack(func(topic string, partition int32) (string, bool) {
+		if topic != "test" {
+			return "", false
+		}
+		lookups++
+		rack := leaders[partition]
+		return rack, rack != ""
+	})
+
+	cfg := config.MustNewConfigFrom(map[string]interface{}{"group_events": 2})
+	mk, err := cfgRackAwarePartitioner(logp.L(), cfg, racks)
+	require.NoError(t, err)
+	part := mk()
+
+	msg := &message{topic: "test"}
+	counts := map[int32]int{}
+	for i := 0; i < 8; i++ {
+		p, err := part(msg, 5)
+		require.NoError(t, err)
+		counts[p]++
+	}
+	assert.Equal(t, map[int32]int{0: 4, 2: 4}, counts)
+	assert.Equal(t, 5, lookups, "leaders must be cached")
+
+	// The leaders are looked up again once the cache expires.
+	leaders[2] = "zone-b"
+	now = now.Add(rackRefreshInterval)
+	counts = map[int32]int{}
+	for i := 0; i < 4; i++ {
+		p, err := part(msg, 5)
+		require.NoError(t, err)
+		counts[p]++
+	}
+	assert.Equal(t, map[int32]int{0: 4}, counts)
+	assert.Equal(t, 10, lookups)
+
+	// Without a partition led in the client rack all partitions are used.
+	counts = map[int32]int{}
+	for i := 0; i < 12; i++ {
+		p, err := part(&message{topic: "other"}, 3)
+		require.NoError(t, err)
+		counts[p]++
+	}
+	assert.Len(t, counts, 3)
+
+	local, ok := racks.isLocal("test", 0)
+	assert.True(t, ok)
+	assert.True(t, local)
+	local, ok = racks.isLocal("test", 3)
+	assert.True(t, ok)
+	assert.False(t, local)
+	_, ok = racks.isLocal("test", 4)
+	assert.False(t, ok)
+
+	// Without client the partitions are used in turn.
+	racks.setClient(nil)
+	counts = map[int32]int{}
+	for i := 0; i < 10; i++ {
+		p, err := part(msg, 5)
+		require.NoError(t, err)
+		counts[p]++
+	}
+	assert.Len(t, counts, 5)
+}
+
+func TestRackAwarePartitionerRequiresRack(t *testing.T) {
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"hosts":                []string{"localhost:9092"},
+		"topic":                "test",
+		"partition.rack_aware": map[string]interface{}{},
+	})
+	_, err := readConfig(cfg)
+	assert.ErrorContains(t, err, "requires rack to be set")
+
+	_, err = makePartitioner(logp.L(), map[string]*config.C{"rack_aware": config.NewConfig()}, nil)
+	assert.Error(t, err)
+}
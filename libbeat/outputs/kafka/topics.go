@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type topicCreationConfig struct {
+	Enabled           bool          `config:"enabled"`
+	Partitions        int32         `config:"partitions"         validate:"min=1"`
+	ReplicationFactor int16         `config:"replication_factor" validate:"min=1"`
+	Configs           mapstr.M      `config:"configs"`
+	Timeout           time.Duration `config:"timeout"            validate:"min=1"`
+}
+
+func defaultTopicCreationConfig() topicCreationConfig {
+	return topicCreationConfig{
+		Enabled:           false,
+		Partitions:        1,
+		ReplicationFactor: 1,
+		Timeout:           30 * time.Second,
+	}
+}
+
+// topicAdmin is the subset of sarama.ClusterAdmin used to create topics.
+type topicAdmin interface {
+	ListTopics() (map[string]sarama.TopicDetail, error)
+	CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error
+	Close() error
+}
+
+// topicCreator creates the topics events are published to when they don't
+// exist yet, so that routing events to a new topic doesn't fail until the
+// topic is created by other means.
+type topicCreator struct {
+	log      *logp.Logger
+	config   topicCreationConfig
+	newAdmin func() (topicAdmin, error)
+
+	mu    sync.Mutex
+	admin topicAdmin
+	known map[string]bool
+}
+
+func newTopicCreator(log *logp.Logger, hosts []string, saramaConfig *sarama.Config, config topicCreationConfig) *topicCreator {
+	adminConfig := *saramaConfig
+	adminConfig.Admin.Timeout = config.Timeout
+	return &topicCreator{
+		log:    log,
+		config: config,
+		newAdmin: func() (topicAdmin, error) {
+			return sarama.NewClusterAdmin(hosts, &adminConfig)
+		},
+	}
+}
+
+// ensure creates the topic if it doesn't exist.
+func (t *topicCreator) ensure(topic string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.known[topic] {
+		return nil
+	}
+
+	if t.admin == nil {
+		admin, err := t.newAdmin()
+		if err != nil {
+			return fmt.Errorf("failed to connect kafka cluster admin: %w", err)
+		}
+		topics, err := admin.ListTopics()
+		if err != nil {
+			admin.Close()
+			return fmt.Errorf("failed to list kafka topics: %w", err)
+		}
+		t.admin = admin
+		t.known = make(map[string]bool, len(topics))
+		for name := range topics {
+			t.known[name] = true
+		}
+		if t.known[topic] {
+			return nil
+		}
+	}
+
+	detail := &sarama.TopicDetail{
+		NumPartitions:     t.config.Partitions,
+		ReplicationFactor: t.config.ReplicationFactor,
+	}
+	if len(t.config.Configs) > 0 {
+		// Topic configs have dotted names, like retention.ms, which are
+		// unpacked as nested objects.
+		configs := t.config.Configs.Flatten()
+		detail.ConfigEntries = make(map[string]*string, len(configs))
+		for k, v := range configs {
+			value := fmt.Sprint(v)
+			detail.ConfigEntries[k] = &value
+		}
+	}
+
+	err := t.admin.CreateTopic(topic, detail, false)
+	var topicErr *sarama.TopicError
+	switch {
+	case err == nil:
+		t.log.Infof("Created kafka topic %s with %d partitions and replication factor %d",
+			topic, detail.NumPartitions, detail.ReplicationFactor)
+	case errors.As(err, &topicErr) && topicErr.Err == sarama.ErrTopicAlreadyExists,
+		errors.Is(err, sarama.ErrTopicAlreadyExists):
+		// Created by another client since the topics were listed.
+	default:
+		return fmt.Errorf("failed to create kafka topic %s: %w", topic, err)
+	}
+	t.known[topic] = true
+	return nil
+}
+
+// close closes the connection of the cluster admin, if any.
+func (t *topicCreator) close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.admin == nil {
+		return nil
+	}
+	err := t.admin.Close()
+	t.admin = nil
+	return err
+}
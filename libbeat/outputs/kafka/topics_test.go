@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeTopicAdmin struct {
+	topics    map[string]sarama.TopicDetail
+	listErr   error
+	createErr error
+	lists     int
+	created   map[string]*sarama.TopicDetail
+	closed    bool
+}
+
+func (a *fakeTopicAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	a.lists++
+	return a.topics, a.listErr
+}
+
+func (a *fakeTopicAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, _ bool) error {
+	if a.createErr != nil {
+		return a.createErr
+	}
+	if a.created == nil {
+		a.created = map[string]*sarama.TopicDetail{}
+	}
+	a.created[topic] = detail
+	return nil
+}
+
+func (a *fakeTopicAdmin) Close() error {
+	a.closed = true
+	return nil
+}
+
+func newTestTopicCreator(admin *fakeTopicAdmin, config topicCreationConfig) *topicCreator {
+	return &topicCreator{
+		log:      logp.NewLogger(logSelector),
+		config:   config,
+		newAdmin: func() (topicAdmin, error) { return admin, nil },
+	}
+}
+
+func TestTopicCreatorCreatesMissingTopic(t *testing.T) {
+	config := defaultTopicCreationConfig()
+	config.Enabled = true
+	config.Partitions = 3
+	config.ReplicationFactor = 2
+	config.Configs = mapstr.M{
+		"retention": mapstr.M{"ms": 86400000},
+	}
+
+	admin := &fakeTopicAdmin{}
+	creator := newTestTopicCreator(admin, config)
+
+	require.NoError(t, creator.ensure("new-topic"))
+	require.Contains(t, admin.created, "new-topic")
+
+	detail := admin.created["new-topic"]
+	assert.Equal(t, int32(3), detail.NumPartitions)
+	assert.Equal(t, int16(2), detail.ReplicationFactor)
+	require.Contains(t, detail.ConfigEntries, "retention.ms")
+	assert.Equal(t, "86400000", *detail.ConfigEntries["retention.ms"])
+
+	// Known topics are not created again.
+	admin.created = nil
+	require.NoError(t, creator.ensure("new-topic"))
+	assert.Empty(t, admin.created)
+	assert.Equal(t, 1, admin.lists)
+
+	require.NoError(t, creator.close())
+	assert.True(t, admin.closed)
+}
+
+func TestTopicCreatorSkipsExistingTopic(t *testing.T) {
+	admin := &fakeTopicAdmin{
+		topics: map[string]sarama.TopicDetail{"existing": {}},
+	}
+	creator := newTestTopicCreator(admin, defaultTopicCreationConfig())
+
+	require.NoError(t, creator.ensure("existing"))
+	assert.Empty(t, admin.created)
+}
+
+func TestTopicCreatorTopicAlreadyExists(t *testing.T) {
+	admin := &fakeTopicAdmin{
+		createErr: &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists},
+	}
+	creator := newTestTopicCreator(admin, defaultTopicCreationConfig())
+
+	require.NoError(t, creator.ensure("racing"))
+	assert.True(t, creator.known["racing"])
+}
+
+func TestTopicCreatorErrors(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		admin := &fakeTopicAdmin{
+			createErr: &sarama.TopicError{Err: sarama.ErrPolicyViolation},
+		}
+		creator := newTestTopicCreator(admin, defaultTopicCreationConfig())
+
+		err := creator.ensure("forbidden")
+		require.Error(t, err)
+		assert.False(t, creator.known["forbidden"])
+
+		var topicErr *sarama.TopicError
+		require.ErrorAs(t, err, &topicErr)
+		assert.Equal(t, sarama.ErrPolicyViolation, topicErr.Err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		listErr := errors.New("broker unavailable")
+		admin := &fakeTopicAdmin{listErr: listErr}
+		creator := newTestTopicCreator(admin, defaultTopicCreationConfig())
+
+		require.ErrorIs(t, creator.ensure("topic"), listErr)
+		assert.True(t, admin.closed)
+		assert.Nil(t, creator.admin)
+	})
+}
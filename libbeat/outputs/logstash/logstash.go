@@ -19,6 +19,9 @@ package logstash
 
 import (
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
+	"github.com/elastic/beats/v7/libbeat/common/transport/proxy"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/transport"
@@ -33,6 +36,8 @@ const (
 
 func init() {
 	outputs.RegisterType("logstash", makeLogstash)
+	fips.RegisterCapable(fips.KindOutput, "logstash")
+	seccomp.RegisterBase(seccomp.KindOutput, "logstash")
 }
 
 func makeLogstash(
@@ -58,7 +63,6 @@ func makeLogstash(
 
 	transp := transport.Config{
 		Timeout: lsConfig.Timeout,
-		Proxy:   &lsConfig.Proxy,
 		TLS:     tls,
 		Stats:   observer,
 	}
@@ -67,7 +71,7 @@ func makeLogstash(
 	for i, host := range hosts {
 		var client outputs.NetworkClient
 
-		conn, err := transport.NewClient(transp, "tcp", host, defaultPort)
+		conn, err := proxy.NewClient(transp, &lsConfig.Proxy, "tcp", host, defaultPort)
 		if err != nil {
 			return outputs.Fail(err)
 		}
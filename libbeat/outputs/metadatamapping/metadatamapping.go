@@ -0,0 +1,225 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package metadatamapping copies keys of the event @metadata to fields,
+// message headers or the metadata interpreted by the output, so that routing
+// hints set by inputs and processors can be used by outputs without custom
+// code.
+package metadatamapping
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Rule maps a key of the event @metadata to a single destination.
+type Rule struct {
+	From     string `config:"from" validate:"required"`
+	ToField  string `config:"to_field"`
+	ToHeader string `config:"to_header"`
+	ToMeta   string `config:"to_meta"`
+}
+
+// Config is the `metadata_mapping` setting of an output.
+type Config struct {
+	MetadataMapping []Rule `config:"metadata_mapping"`
+}
+
+// metaTargets are the @metadata keys interpreted by each output that can be
+// set with to_meta.
+var metaTargets = map[string][]string{
+	"elasticsearch": {
+		events.FieldMetaPipeline,
+		events.FieldMetaRouting,
+		events.FieldMetaID,
+		events.FieldMetaOpType,
+		events.FieldMetaIndex,
+	},
+}
+
+// headerOutputs are the outputs supporting per-event message headers.
+var headerOutputs = map[string]bool{
+	"kafka": true,
+}
+
+// Validate checks that the rule has exactly one destination.
+func (r *Rule) Validate() error {
+	n := 0
+	for _, to := range []string{r.ToField, r.ToHeader, r.ToMeta} {
+		if to != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("mapping of '%s' requires exactly one of to_field, to_header or to_meta", r.From)
+	}
+	return nil
+}
+
+func (r *Rule) validateFor(output string) error {
+	switch {
+	case r.ToHeader != "" && !headerOutputs[output]:
+		return fmt.Errorf("to_header is not supported by the %s output", output)
+	case r.ToMeta != "":
+		targets := metaTargets[output]
+		for _, target := range targets {
+			if r.ToMeta == target {
+				return nil
+			}
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("to_meta is not supported by the %s output", output)
+		}
+		sorted := append([]string(nil), targets...)
+		sort.Strings(sorted)
+		return fmt.Errorf("unsupported to_meta '%s' for the %s output, expected one of %s", r.ToMeta, output, strings.Join(sorted, ", "))
+	}
+	return nil
+}
+
+// Mapper applies the metadata mapping of an output to events.
+type Mapper struct {
+	rules []Rule
+}
+
+// New creates a Mapper from the configuration of the output named output. It
+// returns nil if no mapping is configured.
+func New(cfg *config.C, output string) (*Mapper, error) {
+	if cfg == nil || !cfg.HasField("metadata_mapping") {
+		return nil, nil
+	}
+
+	var c Config
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("invalid metadata_mapping: %w", err)
+	}
+	if len(c.MetadataMapping) == 0 {
+		return nil, nil
+	}
+	for i := range c.MetadataMapping {
+		if err := c.MetadataMapping[i].validateFor(output); err != nil {
+			return nil, fmt.Errorf("invalid metadata_mapping: %w", err)
+		}
+	}
+
+	return &Mapper{rules: c.MetadataMapping}, nil
+}
+
+// Apply copies the configured @metadata keys of event to their destinations.
+// Keys missing from the event are ignored, as well as values that cannot be
+// used as a header or output metadata because they are not scalars.
+func (m *Mapper) Apply(event *beat.Event) {
+	if event.Meta == nil {
+		return
+	}
+
+	for _, rule := range m.rules {
+		v, err := event.Meta.GetValue(rule.From)
+		if err != nil || v == nil {
+			continue
+		}
+
+		switch {
+		case rule.ToField != "":
+			if event.Fields == nil {
+				event.Fields = mapstr.M{}
+			}
+			if obj, ok := v.(mapstr.M); ok {
+				v = obj.Clone()
+			}
+			_, _ = event.Fields.Put(rule.ToField, v)
+		case rule.ToHeader != "":
+			s, ok := toString(v)
+			if !ok {
+				continue
+			}
+			headers, ok := event.Meta[events.FieldMetaHeaders].(mapstr.M)
+			if !ok {
+				headers = mapstr.M{}
+				event.Meta[events.FieldMetaHeaders] = headers
+			}
+			// Header names are used as is, they can contain dots.
+			headers[rule.ToHeader] = s
+		case rule.ToMeta != "":
+			if s, ok := toString(v); ok {
+				event.Meta[rule.ToMeta] = s
+			}
+		}
+	}
+}
+
+// WrapEncoderFactory returns an encoder factory that maps the metadata of
+// events before passing them to the encoders of next. If next is nil the
+// events are only mapped.
+func (m *Mapper) WrapEncoderFactory(next queue.EncoderFactory) queue.EncoderFactory {
+	return func() queue.Encoder {
+		e := &encoder{mapper: m}
+		if next != nil {
+			e.next = next()
+		}
+		return e
+	}
+}
+
+type encoder struct {
+	mapper *Mapper
+	next   queue.Encoder
+}
+
+func (e *encoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
+	if event, ok := entry.(publisher.Event); ok {
+		e.mapper.Apply(&event.Content)
+		entry = event
+	}
+	if e.next == nil {
+		return entry, 0
+	}
+	return e.next.EncodeEntry(entry)
+}
+
+func toString(v interface{}) (string, bool) {
+	if s, ok := v.(string); ok {
+		return s, true
+	}
+	kind := reflect.ValueOf(v).Kind()
+	switch kind {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		// Objects and lists can't be headers or output metadata, even if
+		// they implement fmt.Stringer.
+		return "", false
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), true
+	}
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metadatamapping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestMapper(t *testing.T, output string, rules ...map[string]interface{}) (*Mapper, error) {
+	t.Helper()
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"hosts":            []string{"localhost:9200"},
+		"metadata_mapping": rules,
+	})
+	return New(cfg, output)
+}
+
+func TestNew(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		m, err := New(config.MustNewConfigFrom(map[string]interface{}{"hosts": []string{"localhost:9200"}}), "elasticsearch")
+		require.NoError(t, err)
+		assert.Nil(t, m)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		m, err := newTestMapper(t, "elasticsearch",
+			map[string]interface{}{"from": "tenant", "to_field": "labels.tenant"},
+			map[string]interface{}{"from": "tenant", "to_meta": "routing"},
+		)
+		require.NoError(t, err)
+		assert.NotNil(t, m)
+	})
+
+	errorCases := map[string]struct {
+		output string
+		rule   map[string]interface{}
+	}{
+		"missing from":                 {"elasticsearch", map[string]interface{}{"to_field": "labels.tenant"}},
+		"missing destination":          {"elasticsearch", map[string]interface{}{"from": "tenant"}},
+		"several destinations":         {"kafka", map[string]interface{}{"from": "tenant", "to_field": "labels.tenant", "to_header": "tenant"}},
+		"header unsupported by output": {"elasticsearch", map[string]interface{}{"from": "tenant", "to_header": "tenant"}},
+		"meta unsupported by output":   {"kafka", map[string]interface{}{"from": "tenant", "to_meta": "routing"}},
+		"unknown meta":                 {"elasticsearch", map[string]interface{}{"from": "tenant", "to_meta": "shard"}},
+	}
+	for name, tc := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := newTestMapper(t, tc.output, tc.rule)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	m, err := newTestMapper(t, "kafka",
+		map[string]interface{}{"from": "tenant", "to_field": "labels.tenant"},
+		map[string]interface{}{"from": "hints", "to_field": "routing_hints"},
+		map[string]interface{}{"from": "tenant", "to_header": "x.tenant"},
+		map[string]interface{}{"from": "hints.priority", "to_header": "priority"},
+		map[string]interface{}{"from": "hints", "to_header": "hints"},
+		map[string]interface{}{"from": "missing", "to_header": "missing"},
+	)
+	require.NoError(t, err)
+
+	hints := mapstr.M{"priority": 3}
+	event := &beat.Event{
+		Meta:   mapstr.M{"tenant": "a", "hints": hints},
+		Fields: mapstr.M{"message": "hello"},
+	}
+	m.Apply(event)
+
+	assert.Equal(t, mapstr.M{
+		"message":       "hello",
+		"labels":        mapstr.M{"tenant": "a"},
+		"routing_hints": mapstr.M{"priority": 3},
+	}, event.Fields)
+	assert.Equal(t, mapstr.M{"x.tenant": "a", "priority": "3"}, event.Meta["headers"])
+
+	// Objects are copied, so that changing the fields doesn't change the
+	// metadata.
+	event.Fields.Put("routing_hints.priority", 1)
+	assert.Equal(t, 3, hints["priority"])
+
+	// Events without metadata are left untouched.
+	event = &beat.Event{Fields: mapstr.M{"message": "hello"}}
+	m.Apply(event)
+	assert.Nil(t, event.Meta)
+	assert.Equal(t, mapstr.M{"message": "hello"}, event.Fields)
+}
+
+func TestApplyMeta(t *testing.T) {
+	m, err := newTestMapper(t, "elasticsearch",
+		map[string]interface{}{"from": "hint.pipeline", "to_meta": "pipeline"},
+		map[string]interface{}{"from": "customer_id", "to_meta": "routing"},
+	)
+	require.NoError(t, err)
+
+	event := &beat.Event{Meta: mapstr.M{
+		"hint":        mapstr.M{"pipeline": "tenant-a"},
+		"customer_id": 42,
+	}}
+	m.Apply(event)
+
+	assert.Equal(t, "tenant-a", event.Meta["pipeline"])
+	assert.Equal(t, "42", event.Meta["routing"])
+}
+
+func TestWrapEncoderFactory(t *testing.T) {
+	m, err := newTestMapper(t, "elasticsearch",
+		map[string]interface{}{"from": "tenant", "to_field": "labels.tenant"},
+	)
+	require.NoError(t, err)
+
+	enc := m.WrapEncoderFactory(nil)()
+	entry, size := enc.EncodeEntry(publisher.Event{Content: beat.Event{
+		Meta:   mapstr.M{"tenant": "a"},
+		Fields: mapstr.M{},
+	}})
+	assert.Zero(t, size)
+
+	event, ok := entry.(publisher.Event)
+	require.True(t, ok)
+	tenant, err := event.Content.Fields.GetValue("labels.tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "a", tenant)
+}
@@ -18,6 +18,8 @@
 package outputs
 
 import (
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/rcrowley/go-metrics"
@@ -63,6 +65,22 @@ type Stats struct {
 	// These events are also included in eventsFailed.
 	eventsTooMany *monitoring.Uint
 
+	// Number of field values converted to another type by `field_types`.
+	fieldsCoerced *monitoring.Uint
+
+	// Number of field values dropped because `field_types` could not
+	// convert them.
+	fieldTypeConflicts *monitoring.Uint
+
+	// Number of batches delayed by `rate_limit`, and the total time they
+	// were delayed.
+	rateLimitThrottled *monitoring.Uint
+	rateLimitWaitMs    *monitoring.Uint
+
+	// Event results per ingest pipeline, reported under `pipelines`.
+	pipelinesMu sync.Mutex
+	pipelines   map[string]*pipelineStats
+
 	// Output batch stats
 
 	// Number of times a batch was split for being too large
@@ -77,6 +95,16 @@ type Stats struct {
 	readBytes  *monitoring.Uint // total amount of bytes read
 	readErrors *monitoring.Uint // total number of errors while waiting for response on output
 
+	// Bytes acknowledged by brokers in the same rack as the output and in
+	// other racks, for outputs with a configured rack.
+	rackLocalBytes *monitoring.Uint
+	rackCrossBytes *monitoring.Uint
+
+	// Events acknowledged per topic partition, for outputs that partition
+	// events, reported under `partitions`.
+	partitionsMu sync.Mutex
+	partitions   map[string]map[int32]uint64
+
 	sendLatencyMillis metrics.Sample
 }
 
@@ -95,6 +123,12 @@ func NewStats(reg *monitoring.Registry) *Stats {
 		eventsActive:     monitoring.NewUint(reg, "events.active"),
 		eventsTooMany:    monitoring.NewUint(reg, "events.toomany"),
 
+		fieldsCoerced:      monitoring.NewUint(reg, "field_types.coerced"),
+		fieldTypeConflicts: monitoring.NewUint(reg, "field_types.conflicts"),
+
+		rateLimitThrottled: monitoring.NewUint(reg, "rate_limit.throttled"),
+		rateLimitWaitMs:    monitoring.NewUint(reg, "rate_limit.wait_ms"),
+
 		batchesSplit: monitoring.NewUint(reg, "batches.split"),
 
 		writeBytes:  monitoring.NewUint(reg, "write.bytes"),
@@ -103,12 +137,22 @@ func NewStats(reg *monitoring.Registry) *Stats {
 		readBytes:  monitoring.NewUint(reg, "read.bytes"),
 		readErrors: monitoring.NewUint(reg, "read.errors"),
 
+		rackLocalBytes: monitoring.NewUint(reg, "rack.local.bytes"),
+		rackCrossBytes: monitoring.NewUint(reg, "rack.cross.bytes"),
+
 		sendLatencyMillis: metrics.NewUniformSample(1024),
 	}
 	_ = adapter.NewGoMetrics(reg, "write.latency", adapter.Accept).Register("histogram", metrics.NewHistogram(obj.sendLatencyMillis))
+	monitoring.NewFunc(reg, "pipelines", obj.reportPipelines)
+	monitoring.NewFunc(reg, "partitions", obj.reportPartitions)
 	return obj
 }
 
+// pipelineStats holds the event results of one ingest pipeline.
+type pipelineStats struct {
+	acked, failed, dropped uint64
+}
+
 // NewBatch updates active batch and event metrics.
 func (s *Stats) NewBatch(n int) {
 	if s != nil {
@@ -178,6 +222,76 @@ func (s *Stats) ErrTooMany(n int) {
 	}
 }
 
+// FieldsCoerced updates the number of field values converted by `field_types`.
+func (s *Stats) FieldsCoerced(n int) {
+	if s != nil {
+		s.fieldsCoerced.Add(uint64(n))
+	}
+}
+
+// FieldTypeConflicts updates the number of field values dropped by `field_types`.
+func (s *Stats) FieldTypeConflicts(n int) {
+	if s != nil {
+		s.fieldTypeConflicts.Add(uint64(n))
+	}
+}
+
+// RateLimitWait updates the number of batches delayed by `rate_limit` and
+// the time they were delayed.
+func (s *Stats) RateLimitWait(d time.Duration) {
+	if s != nil {
+		s.rateLimitThrottled.Inc()
+		s.rateLimitWaitMs.Add(uint64(d.Milliseconds()))
+	}
+}
+
+// PipelineEvents updates the event results of an ingest pipeline.
+func (s *Stats) PipelineEvents(pipeline string, acked, failed, dropped int) {
+	if s == nil {
+		return
+	}
+	s.pipelinesMu.Lock()
+	defer s.pipelinesMu.Unlock()
+	if s.pipelines == nil {
+		s.pipelines = map[string]*pipelineStats{}
+	}
+	p, ok := s.pipelines[pipeline]
+	if !ok {
+		p = &pipelineStats{}
+		s.pipelines[pipeline] = p
+	}
+	p.acked += uint64(acked)
+	p.failed += uint64(failed)
+	p.dropped += uint64(dropped)
+}
+
+// reportPipelines reports the event results of each ingest pipeline, keyed by
+// pipeline name. Pipeline names are arbitrary strings, so they can't be
+// registered as metric names.
+func (s *Stats) reportPipelines(_ monitoring.Mode, V monitoring.Visitor) {
+	s.pipelinesMu.Lock()
+	defer s.pipelinesMu.Unlock()
+
+	names := make([]string, 0, len(s.pipelines))
+	for name := range s.pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+	for _, name := range names {
+		p := s.pipelines[name]
+		monitoring.ReportNamespace(V, name, func() {
+			monitoring.ReportNamespace(V, "events", func() {
+				monitoring.ReportInt(V, "acked", int64(p.acked))
+				monitoring.ReportInt(V, "failed", int64(p.failed))
+				monitoring.ReportInt(V, "dropped", int64(p.dropped))
+			})
+		})
+	}
+}
+
 // WriteError increases the write I/O error metrics.
 func (s *Stats) WriteError(err error) {
 	if s != nil {
@@ -205,3 +319,82 @@ func (s *Stats) ReadBytes(n int) {
 		s.readBytes.Add(uint64(n))
 	}
 }
+
+// LocalRackBytes updates the number of bytes acknowledged by brokers in the
+// rack of the output.
+func (s *Stats) LocalRackBytes(n int) {
+	if s != nil {
+		s.rackLocalBytes.Add(uint64(n))
+	}
+}
+
+// CrossRackBytes updates the number of bytes acknowledged by brokers in
+// another rack than the one of the output.
+func (s *Stats) CrossRackBytes(n int) {
+	if s != nil {
+		s.rackCrossBytes.Add(uint64(n))
+	}
+}
+
+// PartitionEvents updates the number of events acknowledged by a topic
+// partition.
+func (s *Stats) PartitionEvents(topic string, partition int32, n int) {
+	if s == nil {
+		return
+	}
+	s.partitionsMu.Lock()
+	defer s.partitionsMu.Unlock()
+	if s.partitions == nil {
+		s.partitions = map[string]map[int32]uint64{}
+	}
+	counts, ok := s.partitions[topic]
+	if !ok {
+		counts = map[int32]uint64{}
+		s.partitions[topic] = counts
+	}
+	counts[partition] += uint64(n)
+}
+
+// reportPartitions reports how evenly the events of each topic are spread
+// over the partitions that received events, keyed by topic. The skew is the
+// number of events of the busiest partition divided by the mean, so 1 means
+// the events are spread evenly.
+func (s *Stats) reportPartitions(_ monitoring.Mode, V monitoring.Visitor) {
+	s.partitionsMu.Lock()
+	defer s.partitionsMu.Unlock()
+
+	topics := make([]string, 0, len(s.partitions))
+	for topic := range s.partitions {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+	for _, topic := range topics {
+		var total, maxEvents, minEvents uint64
+		for _, n := range s.partitions[topic] {
+			total += n
+			if n > maxEvents {
+				maxEvents = n
+			}
+			if minEvents == 0 || n < minEvents {
+				minEvents = n
+			}
+		}
+		count := len(s.partitions[topic])
+		skew := 0.0
+		if total > 0 {
+			skew = float64(maxEvents) * float64(count) / float64(total)
+		}
+
+		monitoring.ReportNamespace(V, topic, func() {
+			monitoring.ReportInt(V, "count", int64(count))
+			monitoring.ReportNamespace(V, "events", func() {
+				monitoring.ReportInt(V, "max", int64(maxEvents))
+				monitoring.ReportInt(V, "min", int64(minEvents))
+			})
+			monitoring.ReportFloat(V, "skew", skew)
+		})
+	}
+}
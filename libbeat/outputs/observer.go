@@ -31,6 +31,13 @@ type Observer interface {
 	AckedEvents(int)      // report number of acked events
 	ErrTooMany(int)       // report too many requests response
 
+	FieldsCoerced(int)      // report number of field values converted by `field_types`
+	FieldTypeConflicts(int) // report number of field values dropped by `field_types`
+
+	RateLimitWait(time.Duration) // report the time a batch was delayed by `rate_limit`
+
+	PipelineEvents(pipeline string, acked, failed, dropped int) // report event results per ingest pipeline
+
 	BatchSplit() // report a batch was split for being too large to ingest
 
 	WriteError(error) // report an I/O error on write
@@ -38,6 +45,11 @@ type Observer interface {
 	ReadError(error)  // report an I/O error on read
 	ReadBytes(int)    // report number of bytes being read
 
+	LocalRackBytes(int) // report number of bytes acknowledged by a broker in the client rack
+	CrossRackBytes(int) // report number of bytes acknowledged by a broker in another rack
+
+	PartitionEvents(topic string, partition int32, n int) // report number of events acknowledged by a topic partition
+
 	ReportLatency(time.Duration) // report the duration a send to the output takes
 }
 
@@ -50,16 +62,23 @@ func NewNilObserver() Observer {
 	return nilObserver
 }
 
-func (*emptyObserver) NewBatch(int)                  {}
-func (*emptyObserver) ReportLatency(_ time.Duration) {}
-func (*emptyObserver) AckedEvents(int)               {}
-func (*emptyObserver) DeadLetterEvents(int)          {}
-func (*emptyObserver) DuplicateEvents(int)           {}
-func (*emptyObserver) RetryableErrors(int)           {}
-func (*emptyObserver) PermanentErrors(int)           {}
-func (*emptyObserver) BatchSplit()                   {}
-func (*emptyObserver) WriteError(error)              {}
-func (*emptyObserver) WriteBytes(int)                {}
-func (*emptyObserver) ReadError(error)               {}
-func (*emptyObserver) ReadBytes(int)                 {}
-func (*emptyObserver) ErrTooMany(int)                {}
+func (*emptyObserver) NewBatch(int)                         {}
+func (*emptyObserver) ReportLatency(_ time.Duration)        {}
+func (*emptyObserver) AckedEvents(int)                      {}
+func (*emptyObserver) DeadLetterEvents(int)                 {}
+func (*emptyObserver) DuplicateEvents(int)                  {}
+func (*emptyObserver) RetryableErrors(int)                  {}
+func (*emptyObserver) PermanentErrors(int)                  {}
+func (*emptyObserver) BatchSplit()                          {}
+func (*emptyObserver) WriteError(error)                     {}
+func (*emptyObserver) WriteBytes(int)                       {}
+func (*emptyObserver) ReadError(error)                      {}
+func (*emptyObserver) ReadBytes(int)                        {}
+func (*emptyObserver) LocalRackBytes(int)                   {}
+func (*emptyObserver) CrossRackBytes(int)                   {}
+func (*emptyObserver) ErrTooMany(int)                       {}
+func (*emptyObserver) FieldsCoerced(int)                    {}
+func (*emptyObserver) FieldTypeConflicts(int)               {}
+func (*emptyObserver) RateLimitWait(time.Duration)          {}
+func (*emptyObserver) PipelineEvents(string, int, int, int) {}
+func (*emptyObserver) PartitionEvents(string, int32, int)   {}
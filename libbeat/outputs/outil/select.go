@@ -19,6 +19,7 @@ package outil
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
@@ -102,6 +103,45 @@ func (s Selector) IsConst() bool {
 	return ok
 }
 
+// Values returns the constant strings the selector can return, sorted. Strings
+// computed from event fields are not included, as they are only known once an
+// event is selected.
+func (s Selector) Values() []string {
+	set := map[string]struct{}{}
+	collectValues(s.sel, set)
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func collectValues(e SelectorExpr, set map[string]struct{}) {
+	add := func(v string) {
+		if v != "" {
+			set[v] = struct{}{}
+		}
+	}
+	switch e := e.(type) {
+	case *constSelector:
+		add(e.s)
+	case *fmtSelector:
+		add(e.otherwise)
+	case *mapSelector:
+		add(e.otherwise)
+		for _, v := range e.to {
+			add(v)
+		}
+	case *condSelector:
+		collectValues(e.s, set)
+	case *listSelector:
+		for _, sub := range e.selectors {
+			collectValues(sub, set)
+		}
+	}
+}
+
 // BuildSelectorFromConfig creates a selector from a configuration object.
 func BuildSelectorFromConfig(
 	cfg *config.C,
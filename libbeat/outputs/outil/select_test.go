@@ -368,3 +368,58 @@ func TestSelectorInitFail(t *testing.T) {
 
 	}
 }
+
+func TestSelectorValues(t *testing.T) {
+	tests := map[string]struct {
+		config string
+		want   []string
+	}{
+		"empty": {
+			config: `test: none`,
+			want:   []string{},
+		},
+		"constant key": {
+			config: `key: Value`,
+			want:   []string{"value"},
+		},
+		"format string without default": {
+			config: `key: '%{[field]}'`,
+			want:   []string{},
+		},
+		"rules": {
+			config: `
+keys:
+  - key: '%{[field]}'
+    default: fallback
+  - key: '%{[type]}'
+    mappings: {a: mapped-a, b: mapped-b}
+    default: other
+  - key: constant
+    when.equals.type: c
+  - key: constant
+`,
+			want: []string{"constant", "fallback", "mapped-a", "mapped-b", "other"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := config.NewConfigWithYAML([]byte(test.config), "test")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sel, err := BuildSelectorFromConfig(cfg, Settings{
+				Key:              "key",
+				MultiKey:         "keys",
+				EnableSingleOnly: true,
+				Case:             SelectorLowerCase,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.want, sel.Values())
+		})
+	}
+}
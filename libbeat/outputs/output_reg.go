@@ -21,6 +21,9 @@ import (
 	"fmt"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/fieldtypes"
+	"github.com/elastic/beats/v7/libbeat/outputs/metadatamapping"
+	"github.com/elastic/beats/v7/libbeat/outputs/ratelimit"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/elastic-agent-libs/config"
 )
@@ -75,6 +78,10 @@ type Group struct {
 	//   and clear Content anyway. Metadata about the error should be saved in
 	//   EncodedEvent and reported when Publish is called.
 	EncoderFactory queue.EncoderFactory
+
+	// WorkerAutoscale is set when the number of active clients is scaled
+	// with the load. The clients are then activated in order.
+	WorkerAutoscale *WorkerAutoscaleConfig
 }
 
 // RegisterType registers a new output type.
@@ -106,5 +113,44 @@ func Load(
 	if stats == nil {
 		stats = NewNilObserver()
 	}
-	return factory(im, info, stats, config)
+	group, err := factory(im, info, stats, config)
+	if err != nil {
+		return group, err
+	}
+
+	// Field types are coerced by all outputs, before the output's own encoder
+	// runs if it supports early encoding.
+	coercer, err := fieldtypes.New(config, stats)
+	if err != nil {
+		return Fail(err)
+	}
+	if coercer != nil {
+		group.EncoderFactory = coercer.WrapEncoderFactory(group.EncoderFactory)
+	}
+
+	// The metadata is mapped first, so that the mapped fields are coerced.
+	mapper, err := metadatamapping.New(config, name)
+	if err != nil {
+		return Fail(err)
+	}
+	if mapper != nil {
+		group.EncoderFactory = mapper.WrapEncoderFactory(group.EncoderFactory)
+	}
+
+	// The rate limit is shared by all the clients of the output.
+	limiter, err := ratelimit.New(config, info, stats)
+	if err != nil {
+		return Fail(err)
+	}
+	if limiter != nil {
+		for i, client := range group.Clients {
+			group.Clients[i] = limiter.Wrap(client)
+		}
+	}
+
+	group.WorkerAutoscale, err = readWorkerAutoscale(config)
+	if err != nil {
+		return Fail(err)
+	}
+	return group, nil
 }
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// Client is the interface of the output clients, as defined by
+// outputs.Client.
+type Client interface {
+	Close() error
+	Publish(context.Context, publisher.Batch) error
+	String() string
+}
+
+// client delays the batches of an output client until they can be published
+// within the limits.
+type client struct {
+	client  Client
+	limiter *Limiter
+}
+
+// networkClient is a client of a network output, that must be reconnectable.
+type networkClient struct {
+	*client
+	connectable interface{ Connect() error }
+}
+
+// Wrap returns a client publishing the batches of c within the limits. The
+// returned client is reconnectable if c is.
+func (l *Limiter) Wrap(c Client) Client {
+	wrapped := &client{client: c, limiter: l}
+	if nc, ok := c.(interface{ Connect() error }); ok {
+		return &networkClient{client: wrapped, connectable: nc}
+	}
+	return wrapped
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	if err := c.limiter.Wait(ctx, batch.Events()); err != nil {
+		batch.Cancelled()
+		return err
+	}
+	return c.client.Publish(ctx, batch)
+}
+
+func (c *client) Close() error {
+	return c.client.Close()
+}
+
+func (c *client) String() string {
+	return "rate_limit(" + c.client.String() + ")"
+}
+
+func (c *client) Test(d testing.Driver) {
+	t, ok := c.client.(testing.Testable)
+	if !ok {
+		d.Fatal("output", errors.New("client doesn't support testing"))
+	}
+	t.Test(d)
+}
+
+func (c *networkClient) Connect() error {
+	return c.connectable.Connect()
+}
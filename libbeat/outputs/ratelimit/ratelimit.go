@@ -0,0 +1,339 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ratelimit shapes the throughput of an output, limiting the events
+// and bytes it publishes per second, with limits that can change with the
+// time of the day.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	jsoncodec "github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Observer receives the rate limiting metrics.
+type Observer interface {
+	RateLimitWait(time.Duration) // report the time a batch was delayed by `rate_limit`
+}
+
+// Limits are the maximum throughput of an output. Zero means unlimited.
+type Limits struct {
+	EventsPerSecond float64          `config:"events_per_second" validate:"min=0"`
+	BytesPerSecond  cfgtype.ByteSize `config:"bytes_per_second"`
+}
+
+// Schedule replaces the default limits of the output between two times of
+// the day. A schedule whose end is before its start ends the next day.
+type Schedule struct {
+	// Days the schedule starts on, all days when empty.
+	Days   []string `config:"days"`
+	Start  string   `config:"start" validate:"required"`
+	End    string   `config:"end" validate:"required"`
+	Limits `config:",inline"`
+}
+
+// Settings is the `rate_limit` setting of an output.
+type Settings struct {
+	Limits    `config:",inline"`
+	Timezone  string     `config:"timezone"`
+	Schedules []Schedule `config:"schedules"`
+}
+
+// Config holds the `rate_limit` setting of an output.
+type Config struct {
+	RateLimit *Settings `config:"rate_limit"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// schedule is a parsed Schedule, with its times as minutes of the day.
+type schedule struct {
+	days       [7]bool
+	start, end int
+	limits     Limits
+}
+
+// Validate checks the days and times of the schedule.
+func (s *Schedule) Validate() error {
+	_, err := parseSchedule(*s)
+	return err
+}
+
+func parseSchedule(s Schedule) (schedule, error) {
+	sched := schedule{limits: s.Limits}
+	if len(s.Days) == 0 {
+		sched.days = [7]bool{true, true, true, true, true, true, true}
+	}
+	for _, d := range s.Days {
+		wd, ok := weekdays[strings.ToLower(d)]
+		if !ok {
+			return sched, fmt.Errorf("invalid day '%s', expected one of mon, tue, wed, thu, fri, sat or sun", d)
+		}
+		sched.days[wd] = true
+	}
+	var err error
+	if sched.start, err = parseTimeOfDay(s.Start); err != nil {
+		return sched, err
+	}
+	if sched.end, err = parseTimeOfDay(s.End); err != nil {
+		return sched, err
+	}
+	if sched.start == sched.end {
+		return sched, fmt.Errorf("schedule start and end are both %s", s.Start)
+	}
+	return sched, nil
+}
+
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day '%s', expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// active reports if the schedule applies at the time t.
+func (s schedule) active(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if s.start < s.end {
+		return s.days[t.Weekday()] && minute >= s.start && minute < s.end
+	}
+	// The schedule spans midnight, it started the previous day when the
+	// time is before its end.
+	if minute >= s.start {
+		return s.days[t.Weekday()]
+	}
+	return minute < s.end && s.days[(t.Weekday()+6)%7]
+}
+
+// Limiter limits the throughput of the clients of an output. The limits are
+// shared by all the clients of the output.
+type Limiter struct {
+	defaults  Limits
+	schedules []schedule
+	location  *time.Location
+	observer  Observer
+	codec     codec.Codec
+	info      beat.Info
+	log       *logp.Logger
+	now       func() time.Time
+
+	mu      sync.Mutex
+	current Limits
+	events  *rate.Limiter
+	bytes   *rate.Limiter
+}
+
+// New creates a Limiter from the configuration of an output. It returns nil
+// if no rate limit is configured.
+func New(cfg *config.C, info beat.Info, observer Observer) (*Limiter, error) {
+	var c Config
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("invalid rate_limit settings: %w", err)
+	}
+	if c.RateLimit == nil {
+		return nil, nil
+	}
+
+	location := time.Local
+	if c.RateLimit.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(c.RateLimit.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid rate_limit.timezone: %w", err)
+		}
+	}
+	l := &Limiter{
+		defaults: c.RateLimit.Limits,
+		location: location,
+		observer: observer,
+		info:     info,
+		log:      logp.NewLogger("rate_limit"),
+		now:      time.Now,
+		events:   rate.NewLimiter(rate.Inf, 1),
+		bytes:    rate.NewLimiter(rate.Inf, 1),
+	}
+	for _, s := range c.RateLimit.Schedules {
+		sched, err := parseSchedule(s)
+		if err != nil {
+			return nil, err
+		}
+		l.schedules = append(l.schedules, sched)
+	}
+	if l.needsSize() {
+		l.codec = jsoncodec.New(info.Version, jsoncodec.Config{})
+	}
+	l.apply(l.limitsAt(l.now()))
+	return l, nil
+}
+
+// needsSize reports if the size of the events must be computed.
+func (l *Limiter) needsSize() bool {
+	if l.defaults.BytesPerSecond > 0 {
+		return true
+	}
+	for _, s := range l.schedules {
+		if s.limits.BytesPerSecond > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// limitsAt returns the limits of the first schedule active at the time t,
+// or the default ones.
+func (l *Limiter) limitsAt(t time.Time) Limits {
+	t = t.In(l.location)
+	for _, s := range l.schedules {
+		if s.active(t) {
+			return s.limits
+		}
+	}
+	return l.defaults
+}
+
+// apply updates the rate limiters when the limits changed. The burst of a
+// limiter is a second of throughput.
+func (l *Limiter) apply(limits Limits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limits == l.current {
+		return
+	}
+	l.log.Infof("Output rate limit set to %s", limits)
+	l.current = limits
+	setLimit(l.events, limits.EventsPerSecond)
+	setLimit(l.bytes, float64(limits.BytesPerSecond))
+}
+
+func setLimit(lim *rate.Limiter, perSecond float64) {
+	if perSecond <= 0 {
+		lim.SetLimit(rate.Inf)
+		return
+	}
+	lim.SetLimit(rate.Limit(perSecond))
+	lim.SetBurst(int(math.Max(1, math.Ceil(perSecond))))
+}
+
+// String describes the limits.
+func (l Limits) String() string {
+	events, bytes := "unlimited", "unlimited"
+	if l.EventsPerSecond > 0 {
+		events = fmt.Sprintf("%g", l.EventsPerSecond)
+	}
+	if l.BytesPerSecond > 0 {
+		bytes = fmt.Sprintf("%d", uint64(l.BytesPerSecond))
+	}
+	return fmt.Sprintf("%s events/s and %s bytes/s", events, bytes)
+}
+
+// Wait blocks until the events can be published within the limits. The
+// limits are checked again after every second of throughput, so that a
+// batch waiting when a schedule starts or ends is published with the new
+// limits.
+func (l *Limiter) Wait(ctx context.Context, events []publisher.Event) error {
+	size := 0
+	if l.codec != nil {
+		for i := range events {
+			size += l.size(&events[i])
+		}
+	}
+
+	start := l.now()
+	waited := false
+	for n, b := len(events), size; n > 0 || b > 0; {
+		l.apply(l.limitsAt(l.now()))
+		var err error
+		var ok bool
+		if n, ok, err = waitChunk(ctx, l.events, n); err != nil {
+			return err
+		} else if ok {
+			waited = true
+		}
+		if b, ok, err = waitChunk(ctx, l.bytes, b); err != nil {
+			return err
+		} else if ok {
+			waited = true
+		}
+	}
+	if waited {
+		l.observer.RateLimitWait(l.now().Sub(start))
+	}
+	return nil
+}
+
+// waitChunk waits for up to a burst of the n tokens, and returns the tokens
+// left. It reports if the tokens were not available right away.
+func waitChunk(ctx context.Context, lim *rate.Limiter, n int) (int, bool, error) {
+	if n <= 0 {
+		return 0, false, nil
+	}
+	if lim.Limit() == rate.Inf {
+		return 0, false, nil
+	}
+	k := n
+	if burst := lim.Burst(); k > burst {
+		k = burst
+	}
+	delayed := !lim.AllowN(time.Now(), k)
+	if delayed {
+		if err := lim.WaitN(ctx, k); err != nil {
+			return n, delayed, err
+		}
+	}
+	return n - k, delayed, nil
+}
+
+// size returns the size of the event once encoded by the output, or of its
+// JSON encoding if the output did not encode it yet.
+func (l *Limiter) size(event *publisher.Event) int {
+	if enc, ok := event.EncodedEvent.(deadletter.Encoded); ok {
+		if raw := enc.DeadLetterEncoding(); raw != nil {
+			return len(raw)
+		}
+	}
+	if event.Content.Fields == nil {
+		return 0
+	}
+	raw, err := l.codec.Encode(l.info.Beat, &event.Content)
+	if err != nil {
+		return 0
+	}
+	return len(raw)
+}
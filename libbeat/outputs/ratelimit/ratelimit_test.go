@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type testObserver struct {
+	waits []time.Duration
+}
+
+func (o *testObserver) RateLimitWait(d time.Duration) { o.waits = append(o.waits, d) }
+
+func newLimiter(t *testing.T, settings mapstr.M) (*Limiter, *testObserver) {
+	t.Helper()
+	cfg, err := config.NewConfigFrom(settings)
+	require.NoError(t, err)
+	observer := &testObserver{}
+	l, err := New(cfg, beat.Info{Beat: "test", Version: "9.9.9"}, observer)
+	require.NoError(t, err)
+	return l, observer
+}
+
+func events(n int) []publisher.Event {
+	events := make([]publisher.Event, n)
+	for i := range events {
+		events[i] = publisher.Event{Content: beat.Event{
+			Timestamp: time.Now(),
+			Fields:    mapstr.M{"message": "0123456789"},
+		}}
+	}
+	return events
+}
+
+func TestNotConfigured(t *testing.T) {
+	l, _ := newLimiter(t, mapstr.M{"hosts": []string{"localhost:9200"}})
+	assert.Nil(t, l)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for name, settings := range map[string]mapstr.M{
+		"negative rate": {"rate_limit.events_per_second": -1},
+		"invalid day":   {"rate_limit.schedules": []mapstr.M{{"days": []string{"monday"}, "start": "08:00", "end": "18:00"}}},
+		"invalid time":  {"rate_limit.schedules": []mapstr.M{{"start": "8am", "end": "18:00"}}},
+		"empty range":   {"rate_limit.schedules": []mapstr.M{{"start": "08:00", "end": "08:00"}}},
+		"missing end":   {"rate_limit.schedules": []mapstr.M{{"start": "08:00"}}},
+		"timezone":      {"rate_limit.timezone": "Mars/Olympus_Mons"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := config.NewConfigFrom(settings)
+			require.NoError(t, err)
+			_, err = New(cfg, beat.Info{}, &testObserver{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSchedules(t *testing.T) {
+	l, _ := newLimiter(t, mapstr.M{
+		"rate_limit": mapstr.M{
+			"events_per_second": 1000,
+			"timezone":          "UTC",
+			"schedules": []mapstr.M{
+				{
+					"days":              []string{"mon", "tue", "wed", "thu", "fri"},
+					"start":             "08:00",
+					"end":               "18:00",
+					"events_per_second": 100,
+					"bytes_per_second":  "1KiB",
+				},
+				{
+					"days":              []string{"fri"},
+					"start":             "22:00",
+					"end":               "02:00",
+					"events_per_second": 10,
+				},
+			},
+		},
+	})
+
+	// 2024-01-01 is a Monday.
+	for at, expected := range map[string]Limits{
+		"2024-01-01T07:59:00Z": {EventsPerSecond: 1000},
+		"2024-01-01T08:00:00Z": {EventsPerSecond: 100, BytesPerSecond: 1024},
+		"2024-01-01T17:59:00Z": {EventsPerSecond: 100, BytesPerSecond: 1024},
+		"2024-01-01T18:00:00Z": {EventsPerSecond: 1000},
+		"2024-01-06T12:00:00Z": {EventsPerSecond: 1000},
+		"2024-01-05T23:00:00Z": {EventsPerSecond: 10},
+		"2024-01-06T01:59:00Z": {EventsPerSecond: 10},
+		"2024-01-06T02:00:00Z": {EventsPerSecond: 1000},
+		"2024-01-01T01:00:00Z": {EventsPerSecond: 1000},
+	} {
+		ts, err := time.Parse(time.RFC3339, at)
+		require.NoError(t, err)
+		assert.Equal(t, expected, l.limitsAt(ts), at)
+	}
+}
+
+func TestWait(t *testing.T) {
+	l, observer := newLimiter(t, mapstr.M{"rate_limit.events_per_second": 100})
+
+	start := time.Now()
+	require.NoError(t, l.Wait(context.Background(), events(100)))
+	assert.Empty(t, observer.waits, "the first second of throughput is not delayed")
+
+	require.NoError(t, l.Wait(context.Background(), events(50)))
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	assert.Len(t, observer.waits, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, l.Wait(ctx, events(100)))
+}
+
+func TestWaitBytes(t *testing.T) {
+	l, observer := newLimiter(t, mapstr.M{"rate_limit.bytes_per_second": "1KiB"})
+	size := l.size(&events(1)[0])
+	require.Greater(t, size, 10)
+
+	start := time.Now()
+	require.NoError(t, l.Wait(context.Background(), events(1024/size+1)))
+	require.NoError(t, l.Wait(context.Background(), events(512/size)))
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	assert.NotEmpty(t, observer.waits)
+}
+
+func TestWrap(t *testing.T) {
+	l, _ := newLimiter(t, mapstr.M{"rate_limit.events_per_second": 1000})
+
+	var published int
+	inner := &mockNetworkClient{publish: func(batch publisher.Batch) { published += len(batch.Events()) }}
+	wrapped := l.Wrap(inner)
+	nc, ok := wrapped.(interface{ Connect() error })
+	require.True(t, ok, "network clients must stay reconnectable")
+	require.NoError(t, nc.Connect())
+	assert.True(t, inner.connected)
+	assert.Equal(t, "rate_limit(mock)", wrapped.String())
+
+	batch := outest.NewBatch(events(3)[0].Content, events(3)[1].Content)
+	require.NoError(t, wrapped.Publish(context.Background(), batch))
+	assert.Equal(t, 2, published)
+}
+
+type mockNetworkClient struct {
+	connected bool
+	publish   func(publisher.Batch)
+}
+
+func (c *mockNetworkClient) Connect() error { c.connected = true; return nil }
+func (c *mockNetworkClient) Close() error   { return nil }
+func (c *mockNetworkClient) String() string { return "mock" }
+func (c *mockNetworkClient) Publish(_ context.Context, batch publisher.Batch) error {
+	c.publish(batch)
+	batch.ACK()
+	return nil
+}
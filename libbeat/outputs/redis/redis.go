@@ -26,6 +26,9 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
+	"github.com/elastic/beats/v7/libbeat/common/transport/proxy"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/beats/v7/libbeat/outputs/outil"
@@ -44,6 +47,8 @@ const (
 
 func init() {
 	outputs.RegisterType("redis", makeRedis)
+	fips.RegisterCapable(fips.KindOutput, "redis")
+	seccomp.RegisterBase(seccomp.KindOutput, "redis")
 }
 
 func makeRedis(
@@ -128,7 +133,6 @@ func makeRedis(
 
 		transp := transport.Config{
 			Timeout: rConfig.Timeout,
-			Proxy:   &rConfig.Proxy,
 			TLS:     tls,
 			Stats:   observer,
 		}
@@ -144,7 +148,7 @@ func makeRedis(
 			}
 		}
 
-		conn, err := transport.NewClient(transp, "tcp", hostUrl.Host, defaultPort)
+		conn, err := proxy.NewClient(transp, &rConfig.Proxy, "tcp", hostUrl.Host, defaultPort)
 		if err != nil {
 			return outputs.Fail(err)
 		}
@@ -0,0 +1,388 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package splunk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// errAckTimeout is returned when the events sent are not acknowledged by the
+// indexers before ack.timeout.
+var errAckTimeout = errors.New("events were not acknowledged by the Splunk indexers in time")
+
+type clientSettings struct {
+	url              string
+	token            string
+	compressionLevel int
+	transport        httpcommon.HTTPTransportSettings
+	ack              ackConfig
+	beatName         string
+	hostname         string
+	selectors        selectors
+	codec            codec.Codec
+	observer         outputs.Observer
+}
+
+// client sends events to a Splunk HTTP Event Collector.
+type client struct {
+	clientSettings
+	log *logp.Logger
+
+	// channel identifies the client to the collector, it is required to
+	// query the acknowledgement of the events.
+	channel string
+	http    *http.Client
+}
+
+// hecEvent is an event in the format of the HTTP Event Collector.
+type hecEvent struct {
+	Time       json.Number     `json:"time"`
+	Host       string          `json:"host,omitempty"`
+	Index      string          `json:"index,omitempty"`
+	Source     string          `json:"source,omitempty"`
+	SourceType string          `json:"sourcetype,omitempty"`
+	Event      json.RawMessage `json:"event"`
+}
+
+// hecResponse is the response of the HTTP Event Collector.
+type hecResponse struct {
+	Text  string `json:"text"`
+	Code  int    `json:"code"`
+	AckID *int64 `json:"ackId"`
+
+	// InvalidEventNumber is the index of the event of the request that
+	// failed. The events before it were accepted.
+	InvalidEventNumber *int `json:"invalid-event-number"`
+}
+
+func newClient(settings clientSettings, log *logp.Logger) (*client, error) {
+	c := &client{
+		clientSettings: settings,
+		log:            log.With("url", settings.url),
+	}
+	if settings.ack.Enabled {
+		channel, err := uuid.NewV4()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the request channel: %w", err)
+		}
+		c.channel = channel.String()
+	}
+	return c, nil
+}
+
+func (c *client) Connect() error {
+	if c.http == nil {
+		httpClient, err := c.transport.Client(
+			httpcommon.WithLogger(c.log),
+			httpcommon.WithIOStats(c.observer),
+		)
+		if err != nil {
+			return err
+		}
+		c.http = httpClient
+	}
+
+	status, res, err := c.request(context.Background(), http.MethodGet, "/health", nil)
+	if err != nil {
+		return fmt.Errorf("failed to check the health of the HTTP Event Collector: %w", err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("HTTP Event Collector is not healthy: status %d: %s", status, res.Text)
+	}
+	c.log.Infof("Connection to the Splunk HTTP Event Collector established")
+	return nil
+}
+
+func (c *client) Close() error {
+	if c.http != nil {
+		c.http.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (c *client) String() string {
+	return "splunk(" + c.url + ")"
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	body, okEvents := c.encodeEvents(events)
+	c.observer.PermanentErrors(len(events) - len(okEvents))
+	if len(okEvents) == 0 {
+		batch.ACK()
+		return nil
+	}
+
+	begin := time.Now()
+	status, res, err := c.request(ctx, http.MethodPost, "/event", body)
+	if err != nil {
+		c.observer.RetryableErrors(len(okEvents))
+		batch.RetryEvents(okEvents)
+		return err
+	}
+	c.observer.ReportLatency(time.Since(begin))
+
+	switch {
+	case status == http.StatusOK:
+		if c.ack.Enabled {
+			if res.AckID == nil {
+				err = errors.New("indexer acknowledgement is not enabled on the HTTP Event Collector token")
+			} else {
+				err = c.waitAck(ctx, *res.AckID)
+			}
+			if err != nil {
+				c.observer.RetryableErrors(len(okEvents))
+				batch.RetryEvents(okEvents)
+				return err
+			}
+		}
+		c.observer.AckedEvents(len(okEvents))
+		batch.ACK()
+		return nil
+
+	case status == http.StatusRequestEntityTooLarge:
+		if batch.SplitRetry() {
+			c.observer.BatchSplit()
+			return nil
+		}
+		c.log.Errorf("Dropping an event larger than the maximum content length of the HTTP Event Collector")
+		c.observer.PermanentErrors(len(okEvents))
+		batch.ACK()
+		return nil
+
+	case status == http.StatusBadRequest && res.InvalidEventNumber != nil &&
+		*res.InvalidEventNumber >= 0 && *res.InvalidEventNumber < len(okEvents):
+		n := *res.InvalidEventNumber
+		c.log.Errorw(fmt.Sprintf("Dropping an event rejected by the HTTP Event Collector: %s (code %d)", res.Text, res.Code),
+			logp.TypeKey, logp.EventType, "event", okEvents[n].Content)
+		c.observer.PermanentErrors(1)
+
+		// The events before the rejected one were accepted, but they are only
+		// known to be indexed when indexer acknowledgement is enabled.
+		retry := okEvents[n+1:]
+		if c.ack.Enabled {
+			retry = append(okEvents[:n:n], retry...)
+		} else {
+			c.observer.AckedEvents(n)
+		}
+		c.observer.RetryableErrors(len(retry))
+		batch.RetryEvents(retry)
+		return nil
+
+	case status == http.StatusBadRequest:
+		c.log.Errorf("Dropping %d events rejected by the HTTP Event Collector: %s (code %d)", len(okEvents), res.Text, res.Code)
+		c.observer.PermanentErrors(len(okEvents))
+		batch.ACK()
+		return nil
+
+	default:
+		if status == http.StatusTooManyRequests {
+			c.observer.ErrTooMany(len(okEvents))
+		} else {
+			c.observer.RetryableErrors(len(okEvents))
+		}
+		batch.RetryEvents(okEvents)
+		return fmt.Errorf("failed to send events to the HTTP Event Collector: status %d: %s (code %d)", status, res.Text, res.Code)
+	}
+}
+
+// encodeEvents returns the events in the format of the HTTP Event Collector,
+// and the events that could be encoded.
+func (c *client) encodeEvents(events []publisher.Event) ([]byte, []publisher.Event) {
+	var buf bytes.Buffer
+	okEvents := events[:0]
+	for i := range events {
+		if err := c.encodeEvent(&buf, &events[i].Content); err != nil {
+			c.log.Errorf("Failed to encode event: %v", err)
+			c.log.Debugw(fmt.Sprintf("Failed event: %v", events[i].Content), logp.TypeKey, logp.EventType)
+			continue
+		}
+		okEvents = append(okEvents, events[i])
+	}
+	return buf.Bytes(), okEvents
+}
+
+func (c *client) encodeEvent(buf *bytes.Buffer, event *beat.Event) error {
+	e := hecEvent{
+		Time: json.Number(strconv.FormatFloat(float64(event.Timestamp.UnixMilli())/1000, 'f', 3, 64)),
+		Host: c.hostname,
+	}
+	if host, err := event.GetValue("host.name"); err == nil {
+		if name, ok := host.(string); ok && name != "" {
+			e.Host = name
+		}
+	}
+
+	var err error
+	if e.Index, err = c.selectors.index.Select(event); err != nil {
+		return fmt.Errorf("failed to select the index: %w", err)
+	}
+	if e.Source, err = c.selectors.source.Select(event); err != nil {
+		return fmt.Errorf("failed to select the source: %w", err)
+	}
+	if e.SourceType, err = c.selectors.sourcetype.Select(event); err != nil {
+		return fmt.Errorf("failed to select the sourcetype: %w", err)
+	}
+
+	data, err := c.codec.Encode(c.beatName, event)
+	if err != nil {
+		return err
+	}
+	data = bytes.TrimSpace(data)
+	if json.Valid(data) {
+		// The codec output is copied, as codecs reuse their buffer.
+		e.Event = append(json.RawMessage(nil), data...)
+	} else {
+		// Codecs like format produce plain text, sent as a string event.
+		if e.Event, err = json.Marshal(string(data)); err != nil {
+			return err
+		}
+	}
+
+	enc, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	buf.Write(enc)
+	buf.WriteByte('\n')
+	return nil
+}
+
+// waitAck polls the acknowledgement of the request ackID until its events
+// are indexed, or ack.timeout is reached.
+func (c *client) waitAck(ctx context.Context, ackID int64) error {
+	body, err := json.Marshal(map[string][]int64{"acks": {ackID}})
+	if err != nil {
+		return err
+	}
+
+	deadline := time.NewTimer(c.ack.Timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(c.ack.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return errAckTimeout
+		case <-ticker.C:
+		}
+
+		status, data, err := c.do(ctx, http.MethodPost, "/ack", body)
+		if err != nil {
+			return fmt.Errorf("failed to query the acknowledgement of the events: %w", err)
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("failed to query the acknowledgement of the events: status %d: %s", status, data)
+		}
+		var res struct {
+			Acks map[string]bool `json:"acks"`
+		}
+		if err := json.Unmarshal(data, &res); err != nil {
+			return fmt.Errorf("failed to read the acknowledgement of the events: %w", err)
+		}
+		if res.Acks[strconv.FormatInt(ackID, 10)] {
+			return nil
+		}
+	}
+}
+
+// request sends a request to the endpoint of the collector and reads its
+// response. The status is returned with the response, that is empty when
+// the body is not a collector response.
+func (c *client) request(ctx context.Context, method, endpoint string, body []byte) (int, hecResponse, error) {
+	var res hecResponse
+	status, data, err := c.do(ctx, method, endpoint, body)
+	if err != nil {
+		return 0, res, err
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		res.Text = string(data)
+	}
+	return status, res, nil
+}
+
+func (c *client) do(ctx context.Context, method, endpoint string, body []byte) (int, []byte, error) {
+	var reader io.Reader
+	compressed := false
+	if body != nil {
+		if c.compressionLevel > 0 {
+			var buf bytes.Buffer
+			w, err := gzip.NewWriterLevel(&buf, c.compressionLevel)
+			if err != nil {
+				return 0, nil, err
+			}
+			if _, err := w.Write(body); err != nil {
+				return 0, nil, err
+			}
+			if err := w.Close(); err != nil {
+				return 0, nil, err
+			}
+			body = buf.Bytes()
+			compressed = true
+		}
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+endpoint, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Splunk "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if c.channel != "" {
+		req.Header.Set("X-Splunk-Request-Channel", c.channel)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, data, nil
+}
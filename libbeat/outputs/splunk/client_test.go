@@ -0,0 +1,285 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package splunk
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	jsoncodec "github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// fakeCollector is a HTTP Event Collector recording the events it receives.
+type fakeCollector struct {
+	mu       sync.Mutex
+	events   []hecEvent
+	headers  http.Header
+	response func(events []hecEvent) (int, string)
+	acks     int
+}
+
+func (f *fakeCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/services/collector/health":
+		_, _ = io.WriteString(w, `{"text":"HEC is healthy","code":17}`)
+	case "/services/collector/ack":
+		f.acks++
+		// The events are acknowledged at the second poll.
+		_, _ = io.WriteString(w, `{"acks":{"7":`+map[bool]string{true: "true", false: "false"}[f.acks > 1]+`}}`)
+	case "/services/collector/event":
+		f.headers = r.Header.Clone()
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body = gz
+		}
+		var events []hecEvent
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			var e hecEvent
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			events = append(events, e)
+		}
+		f.events = append(f.events, events...)
+		status, res := http.StatusOK, `{"text":"Success","code":0}`
+		if f.response != nil {
+			status, res = f.response(events)
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, res)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, collector *fakeCollector, cfg map[string]interface{}) *client {
+	t.Helper()
+	server := httptest.NewServer(collector)
+	t.Cleanup(server.Close)
+
+	c := config.MustNewConfigFrom(cfg)
+	splunkConfig := defaultConfig
+	require.NoError(t, c.Unpack(&splunkConfig))
+	sel, err := buildSelectors(c)
+	require.NoError(t, err)
+
+	client, err := newClient(clientSettings{
+		url:              server.URL + defaultPath,
+		token:            splunkConfig.Token,
+		compressionLevel: splunkConfig.CompressionLevel,
+		transport:        splunkConfig.Transport,
+		ack:              splunkConfig.Ack,
+		beatName:         "testbeat",
+		hostname:         "beathost",
+		selectors:        sel,
+		codec:            jsoncodec.New("1.2.3", jsoncodec.Config{}),
+		observer:         outputs.NewNilObserver(),
+	}, logp.NewLogger(logSelector))
+	require.NoError(t, err)
+	require.NoError(t, client.Connect())
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func testEvents(n int) []beat.Event {
+	events := make([]beat.Event, n)
+	for i := range events {
+		events[i] = beat.Event{
+			Timestamp: time.Date(2024, 5, 6, 7, 8, 9, 123e6, time.UTC),
+			Fields: mapstr.M{
+				"message": "event " + string(rune('a'+i)),
+				"fields":  mapstr.M{"sourcetype": "access_combined"},
+			},
+		}
+	}
+	return events
+}
+
+func TestPublish(t *testing.T) {
+	collector := &fakeCollector{}
+	client := newTestClient(t, collector, map[string]interface{}{
+		"token":             "secret",
+		"index":             "Main",
+		"sourcetype":        "%{[fields.sourcetype]}",
+		"compression_level": 5,
+	})
+
+	events := testEvents(2)
+	events[1].Fields["host"] = mapstr.M{"name": "eventhost"}
+	batch := outest.NewBatch(events...)
+	require.NoError(t, client.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	assert.Equal(t, "Splunk secret", collector.headers.Get("Authorization"))
+	assert.Equal(t, "gzip", collector.headers.Get("Content-Encoding"))
+	assert.Empty(t, collector.headers.Get("X-Splunk-Request-Channel"))
+
+	require.Len(t, collector.events, 2)
+	e := collector.events[0]
+	assert.Equal(t, json.Number("1714979289.123"), e.Time)
+	assert.Equal(t, "beathost", e.Host)
+	assert.Equal(t, "main", e.Index)
+	assert.Equal(t, "access_combined", e.SourceType)
+	assert.Empty(t, e.Source)
+	var content map[string]interface{}
+	require.NoError(t, json.Unmarshal(e.Event, &content))
+	assert.Equal(t, "event a", content["message"])
+	assert.Equal(t, "eventhost", collector.events[1].Host)
+}
+
+func TestPublishInvalidEvent(t *testing.T) {
+	collector := &fakeCollector{
+		response: func(events []hecEvent) (int, string) {
+			return http.StatusBadRequest, `{"text":"Event field cannot be blank","code":13,"invalid-event-number":1}`
+		},
+	}
+
+	t.Run("without ack", func(t *testing.T) {
+		client := newTestClient(t, collector, map[string]interface{}{"token": "secret"})
+		batch := outest.NewBatch(testEvents(3)...)
+		require.NoError(t, client.Publish(context.Background(), batch))
+
+		require.Len(t, batch.Signals, 1)
+		assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+		require.Len(t, batch.Signals[0].Events, 1)
+		assert.Equal(t, "event c", batch.Signals[0].Events[0].Content.Fields["message"])
+	})
+
+	t.Run("with ack", func(t *testing.T) {
+		client := newTestClient(t, collector, map[string]interface{}{"token": "secret", "ack.enabled": true})
+		batch := outest.NewBatch(testEvents(3)...)
+		require.NoError(t, client.Publish(context.Background(), batch))
+
+		require.Len(t, batch.Signals, 1)
+		assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+		require.Len(t, batch.Signals[0].Events, 2)
+		assert.Equal(t, "event a", batch.Signals[0].Events[0].Content.Fields["message"])
+		assert.Equal(t, "event c", batch.Signals[0].Events[1].Content.Fields["message"])
+	})
+}
+
+func TestPublishAck(t *testing.T) {
+	collector := &fakeCollector{
+		response: func(events []hecEvent) (int, string) {
+			return http.StatusOK, `{"text":"Success","code":0,"ackId":7}`
+		},
+	}
+	client := newTestClient(t, collector, map[string]interface{}{
+		"token":             "secret",
+		"ack.enabled":       true,
+		"ack.poll_interval": "10ms",
+	})
+
+	batch := outest.NewBatch(testEvents(1)...)
+	require.NoError(t, client.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.Equal(t, 2, collector.acks)
+	assert.NotEmpty(t, collector.headers.Get("X-Splunk-Request-Channel"))
+}
+
+func TestPublishAckTimeout(t *testing.T) {
+	collector := &fakeCollector{
+		response: func(events []hecEvent) (int, string) {
+			return http.StatusOK, `{"text":"Success","code":0,"ackId":8}`
+		},
+	}
+	client := newTestClient(t, collector, map[string]interface{}{
+		"token":             "secret",
+		"ack.enabled":       true,
+		"ack.poll_interval": "10ms",
+		"ack.timeout":       "50ms",
+	})
+
+	batch := outest.NewBatch(testEvents(2)...)
+	assert.ErrorIs(t, client.Publish(context.Background(), batch), errAckTimeout)
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 2)
+}
+
+func TestPublishRetry(t *testing.T) {
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusForbidden} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			collector := &fakeCollector{
+				response: func(events []hecEvent) (int, string) {
+					return status, `{"text":"failed","code":9}`
+				},
+			}
+			client := newTestClient(t, collector, map[string]interface{}{"token": "secret"})
+
+			batch := outest.NewBatch(testEvents(2)...)
+			assert.Error(t, client.Publish(context.Background(), batch))
+
+			require.Len(t, batch.Signals, 1)
+			assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+			assert.Len(t, batch.Signals[0].Events, 2)
+		})
+	}
+}
+
+func TestPublishTooLarge(t *testing.T) {
+	collector := &fakeCollector{
+		response: func(events []hecEvent) (int, string) {
+			return http.StatusRequestEntityTooLarge, `{"text":"Content length too large","code":27}`
+		},
+	}
+	client := newTestClient(t, collector, map[string]interface{}{"token": "secret"})
+
+	batch := outest.NewBatch(testEvents(2)...)
+	require.NoError(t, client.Publish(context.Background(), batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchSplitRetry, batch.Signals[0].Tag)
+
+	batch = outest.NewBatch(testEvents(1)...)
+	require.NoError(t, client.Publish(context.Background(), batch))
+	require.Len(t, batch.Signals, 2)
+	assert.Equal(t, outest.BatchSplitRetry, batch.Signals[0].Tag)
+	assert.Equal(t, outest.BatchACK, batch.Signals[1].Tag)
+}
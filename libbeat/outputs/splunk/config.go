@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package splunk
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+type splunkConfig struct {
+	Protocol         string           `config:"protocol"`
+	Path             string           `config:"path"`
+	Token            string           `config:"token" validate:"required"`
+	LoadBalance      bool             `config:"loadbalance"`
+	CompressionLevel int              `config:"compression_level" validate:"min=0, max=9"`
+	BulkMaxSize      int              `config:"bulk_max_size"`
+	MaxRetries       int              `config:"max_retries"`
+	Backoff          backoff          `config:"backoff"`
+	Codec            codec.Config     `config:"codec"`
+	Ack              ackConfig        `config:"ack"`
+	Queue            config.Namespace `config:"queue"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+// ackConfig configures the indexer acknowledgement of the events.
+type ackConfig struct {
+	Enabled      bool          `config:"enabled"`
+	PollInterval time.Duration `config:"poll_interval" validate:"positive"`
+	Timeout      time.Duration `config:"timeout" validate:"positive"`
+}
+
+const (
+	defaultPort = 8088
+	defaultPath = "/services/collector"
+)
+
+var defaultConfig = splunkConfig{
+	Protocol:         "https",
+	Path:             defaultPath,
+	LoadBalance:      true,
+	CompressionLevel: 0,
+	BulkMaxSize:      500,
+	MaxRetries:       3,
+	Backoff: backoff{
+		Init: 1 * time.Second,
+		Max:  60 * time.Second,
+	},
+	Ack: ackConfig{
+		Enabled:      false,
+		PollInterval: 1 * time.Second,
+		Timeout:      5 * time.Minute,
+	},
+	Transport: httpcommon.DefaultHTTPTransportSettings(),
+}
+
+func (c *splunkConfig) Validate() error {
+	if c.Ack.Enabled && c.Ack.PollInterval > c.Ack.Timeout {
+		return errors.New("ack.poll_interval must not be greater than ack.timeout")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package splunk
+
+import (
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func init() {
+	outputs.RegisterType("splunk", makeSplunk)
+	fips.RegisterCapable(fips.KindOutput, "splunk")
+	seccomp.RegisterBase(seccomp.KindOutput, "splunk")
+}
+
+const logSelector = "splunk"
+
+// selectors select the Splunk metadata of the events.
+type selectors struct {
+	index      outil.Selector
+	source     outil.Selector
+	sourcetype outil.Selector
+}
+
+func makeSplunk(
+	_ outputs.IndexManager,
+	beatInfo beat.Info,
+	observer outputs.Observer,
+	cfg *config.C,
+) (outputs.Group, error) {
+	log := logp.NewLogger(logSelector)
+
+	splunkConfig := defaultConfig
+	if err := cfg.Unpack(&splunkConfig); err != nil {
+		return outputs.Fail(err)
+	}
+
+	sel, err := buildSelectors(cfg)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	hosts, err := outputs.ReadHostList(cfg)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	clients := make([]outputs.NetworkClient, len(hosts))
+	for i, host := range hosts {
+		url, err := common.MakeURL(splunkConfig.Protocol, splunkConfig.Path, host, defaultPort)
+		if err != nil {
+			log.Errorf("Invalid host param set: %s, Error: %+v", host, err)
+			return outputs.Fail(err)
+		}
+
+		enc, err := codec.CreateEncoder(beatInfo, splunkConfig.Codec)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+
+		client, err := newClient(clientSettings{
+			url:              url,
+			token:            splunkConfig.Token,
+			compressionLevel: splunkConfig.CompressionLevel,
+			transport:        splunkConfig.Transport,
+			ack:              splunkConfig.Ack,
+			beatName:         beatInfo.Beat,
+			hostname:         beatInfo.Hostname,
+			selectors:        sel,
+			codec:            enc,
+			observer:         observer,
+		}, log)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+		clients[i] = outputs.WithBackoff(client, splunkConfig.Backoff.Init, splunkConfig.Backoff.Max)
+	}
+
+	return outputs.SuccessNet(splunkConfig.Queue, splunkConfig.LoadBalance, splunkConfig.BulkMaxSize, splunkConfig.MaxRetries, nil, clients)
+}
+
+func buildSelectors(cfg *config.C) (selectors, error) {
+	var sel selectors
+	var err error
+	sel.index, err = outil.BuildSelectorFromConfig(cfg, outil.Settings{
+		Key:              "index",
+		MultiKey:         "indices",
+		EnableSingleOnly: true,
+		Case:             outil.SelectorLowerCase,
+	})
+	if err != nil {
+		return sel, err
+	}
+	sel.source, err = outil.BuildSelectorFromConfig(cfg, outil.Settings{
+		Key:              "source",
+		MultiKey:         "sources",
+		EnableSingleOnly: true,
+		Case:             outil.SelectorKeepCase,
+	})
+	if err != nil {
+		return sel, err
+	}
+	sel.sourcetype, err = outil.BuildSelectorFromConfig(cfg, outil.Settings{
+		Key:              "sourcetype",
+		MultiKey:         "sourcetypes",
+		EnableSingleOnly: true,
+		Case:             outil.SelectorKeepCase,
+	})
+	return sel, err
+}
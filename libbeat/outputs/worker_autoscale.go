@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// WorkerAutoscaleConfig configures the scaling of the output workers with
+// the load. When it is enabled, MaxWorkers workers are created per host,
+// replacing the `worker` setting, and the pipeline only activates as many
+// of them as needed.
+type WorkerAutoscaleConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// MinWorkers and MaxWorkers are the bounds of the number of active
+	// workers per host.
+	MinWorkers int `config:"min_workers" validate:"min=1"`
+	MaxWorkers int `config:"max_workers" validate:"min=1"`
+
+	// Interval is the time between two scaling decisions.
+	Interval time.Duration `config:"interval" validate:"positive"`
+
+	// MaxLatency is the average publish latency above which workers are
+	// removed instead of added, as more concurrent requests would only
+	// overload the output further. 0 disables the check.
+	MaxLatency time.Duration `config:"max_latency" validate:"min=0"`
+}
+
+// DefaultWorkerAutoscaleConfig returns the default worker_autoscale settings.
+func DefaultWorkerAutoscaleConfig() WorkerAutoscaleConfig {
+	return WorkerAutoscaleConfig{
+		MinWorkers: 1,
+		MaxWorkers: 4,
+		Interval:   10 * time.Second,
+	}
+}
+
+func (c *WorkerAutoscaleConfig) Validate() error {
+	if c.MinWorkers > c.MaxWorkers {
+		return errors.New("min_workers must not be greater than max_workers")
+	}
+	return nil
+}
+
+// readWorkerAutoscale reads the worker_autoscale settings of an output. It
+// returns nil when scaling is not enabled.
+func readWorkerAutoscale(cfg *config.C) (*WorkerAutoscaleConfig, error) {
+	settings := struct {
+		Autoscale WorkerAutoscaleConfig `config:"worker_autoscale"`
+	}{Autoscale: DefaultWorkerAutoscaleConfig()}
+	if err := cfg.Unpack(&settings); err != nil {
+		return nil, fmt.Errorf("invalid worker_autoscale settings: %w", err)
+	}
+	if !settings.Autoscale.Enabled {
+		return nil, nil
+	}
+	return &settings.Autoscale, nil
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/common/jsonparse"
 	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/libbeat/processors/checks"
@@ -44,6 +45,7 @@ type decodeJSONFields struct {
 	processArray  bool
 	documentID    string
 	target        *string
+	decoder       *jsonparse.Decoder
 	logger        *logp.Logger
 }
 
@@ -56,6 +58,8 @@ type config struct {
 	ProcessArray  bool     `config:"process_array"`
 	Target        *string  `config:"target"`
 	DocumentID    string   `config:"document_id"`
+
+	Decoder jsonparse.Config `config:",inline"`
 }
 
 var (
@@ -70,7 +74,8 @@ func init() {
 	processors.RegisterPlugin("decode_json_fields",
 		checks.ConfigChecked(NewDecodeJSONFields,
 			checks.RequireFields("fields"),
-			checks.AllowedFields("fields", "max_depth", "overwrite_keys", "add_error_key", "process_array", "target", "when", "document_id", "expand_keys")))
+			checks.AllowedFields("fields", "max_depth", "overwrite_keys", "add_error_key", "process_array", "target", "when", "document_id", "expand_keys",
+				"max_nesting", "max_size", "duplicate_keys")))
 
 	jsprocessor.RegisterPlugin("DecodeJSONFields", NewDecodeJSONFields)
 }
@@ -95,6 +100,7 @@ func NewDecodeJSONFields(c *cfg.C) (beat.Processor, error) {
 		processArray:  config.ProcessArray,
 		documentID:    config.DocumentID,
 		target:        config.Target,
+		decoder:       jsonparse.NewDecoder(config.Decoder),
 		logger:        logger,
 	}
 	return f, nil
@@ -118,7 +124,7 @@ func (f *decodeJSONFields) Run(event *beat.Event) (*beat.Event, error) {
 		}
 
 		var output interface{}
-		err = unmarshal(f.maxDepth, text, &output, f.processArray)
+		err = unmarshal(f.decoder, f.maxDepth, text, &output, f.processArray)
 		if err != nil {
 			f.logger.Debugf("Error trying to unmarshal %s", text)
 			errs = append(errs, err.Error())
@@ -182,8 +188,8 @@ func (f *decodeJSONFields) Run(event *beat.Event) (*beat.Event, error) {
 	return event, nil
 }
 
-func unmarshal(maxDepth int, text string, fields *interface{}, processArray bool) error {
-	if err := decodeJSON(text, fields); err != nil {
+func unmarshal(dec *jsonparse.Decoder, maxDepth int, text string, fields *interface{}, processArray bool) error {
+	if err := decodeJSON(dec, text, fields); err != nil {
 		return err
 	}
 
@@ -201,7 +207,7 @@ func unmarshal(maxDepth int, text string, fields *interface{}, processArray bool
 		}
 
 		var tmp interface{}
-		err := unmarshal(maxDepth, str, &tmp, processArray)
+		err := unmarshal(dec, maxDepth, str, &tmp, processArray)
 		if err != nil {
 			return v, errors.Is(err, errProcessingSkipped)
 		}
@@ -232,7 +238,24 @@ func unmarshal(maxDepth int, text string, fields *interface{}, processArray bool
 	return nil
 }
 
-func decodeJSON(text string, to *interface{}) error {
+// decodeJSON decodes text using the fast decoder. Invalid input, including
+// trailing data, is passed on to encoding/json so that users get the same
+// error messages as before.
+func decodeJSON(dec *jsonparse.Decoder, text string, to *interface{}) error {
+	v, n, err := dec.Decode([]byte(text))
+	if err == nil && strings.Trim(text[n:], " \t\r\n") == "" {
+		*to = v
+		return nil
+	}
+
+	var syntaxErr *jsonparse.SyntaxError
+	if err != nil && !errors.As(err, &syntaxErr) {
+		return err
+	}
+	return decodeJSONStd(text, to)
+}
+
+func decodeJSONStd(text string, to *interface{}) error {
 	dec := json.NewDecoder(strings.NewReader(text))
 	dec.UseNumber()
 	err := dec.Decode(to)
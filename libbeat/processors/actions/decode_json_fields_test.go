@@ -579,6 +579,85 @@ func TestAddErrorToEventOnUnmarshalError(t *testing.T) {
 	assert.NotNil(t, errObj["message"])
 }
 
+func TestDuplicateKeysOption(t *testing.T) {
+	input := mapstr.M{
+		"msg": `{"level": "info", "level": "debug"}`,
+	}
+
+	testConfig := conf.MustNewConfigFrom(map[string]interface{}{
+		"fields":         fields,
+		"duplicate_keys": "first",
+	})
+	actual := getActualValue(t, testConfig, input.Clone())
+	assert.Equal(t, mapstr.M{"msg": map[string]interface{}{"level": "info"}}, actual)
+
+	testConfig = conf.MustNewConfigFrom(map[string]interface{}{
+		"fields":         fields,
+		"duplicate_keys": "error",
+		"add_error_key":  true,
+	})
+	actual = getActualValue(t, testConfig, input.Clone())
+	assert.Equal(t, input["msg"], actual["msg"])
+	errObj, ok := actual["error"].(mapstr.M)
+	require.True(t, ok, "'error' field not present or of invalid type")
+	assert.Contains(t, errObj["message"], "duplicate key 'level'")
+}
+
+func TestMaxSizeAndNestingOptions(t *testing.T) {
+	input := mapstr.M{
+		"msg": `{"a": {"b": {"c": "some longer value"}}}`,
+	}
+
+	testConfig := conf.MustNewConfigFrom(map[string]interface{}{
+		"fields":   fields,
+		"max_size": "16B",
+	})
+	actual := getActualValue(t, testConfig, input.Clone())
+	assert.Equal(t, input, actual)
+
+	testConfig = conf.MustNewConfigFrom(map[string]interface{}{
+		"fields":      fields,
+		"max_nesting": 2,
+	})
+	actual = getActualValue(t, testConfig, input.Clone())
+	assert.Equal(t, input, actual)
+
+	testConfig = conf.MustNewConfigFrom(map[string]interface{}{
+		"fields":      fields,
+		"max_nesting": 3,
+	})
+	actual = getActualValue(t, testConfig, input.Clone())
+	expected := mapstr.M{
+		"msg": map[string]interface{}{
+			"a": map[string]interface{}{
+				"b": map[string]interface{}{"c": "some longer value"},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func BenchmarkDecodeJSONFields(b *testing.B) {
+	msg := `{"@timestamp":"2024-01-02T03:04:05.678Z","log":{"level":"info","logger":"http"},` +
+		`"http":{"request":{"method":"GET","bytes":1234},"response":{"status_code":200,"bytes":56789}},` +
+		`"url":{"path":"/api/v1/items","query":"q=caf\u00e9&limit=10"},"duration":0.01234,` +
+		`"message":"GET /api/v1/items returned 200 in 12.34ms"}`
+
+	p, err := NewDecodeJSONFields(conf.MustNewConfigFrom(map[string]interface{}{
+		"fields": []string{"message"},
+		"target": "json",
+	}))
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"message": msg}})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func getActualValue(t *testing.T, config *conf.C, input mapstr.M) mapstr.M {
 	log := logp.NewLogger("decode_json_fields_test")
 
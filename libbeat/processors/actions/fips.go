@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import "github.com/elastic/beats/v7/libbeat/common/fips"
+
+func init() {
+	// The actions only move, rename, decode or drop the fields of events,
+	// without relying on any cryptographic algorithm.
+	fips.RegisterCapable(fips.KindProcessor,
+		"add_fields",
+		"add_labels",
+		"add_network_direction",
+		"add_tags",
+		"append",
+		"copy_fields",
+		"decode_base64_field",
+		"decode_json_fields",
+		"decompress_gzip_field",
+		"detect_mime_type",
+		"drop_event",
+		"drop_fields",
+		"extract_field",
+		"include_fields",
+		"rename",
+		"replace",
+		"truncate_fields",
+	)
+}
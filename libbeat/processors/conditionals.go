@@ -109,17 +109,31 @@ func addCondition(
 }
 
 type ifThenElseConfig struct {
+	Cond   conditions.Config `config:"if"      validate:"required"`
+	Then   *config.C         `config:"then"    validate:"required"`
+	ElseIf []elseIfConfig    `config:"else_if"`
+	Else   *config.C         `config:"else"`
+}
+
+type elseIfConfig struct {
 	Cond conditions.Config `config:"if"   validate:"required"`
 	Then *config.C         `config:"then" validate:"required"`
-	Else *config.C         `config:"else"`
 }
 
-// IfThenElseProcessor executes one set of processors (then) if the condition is
-// true and another set of processors (else) if the condition is false.
-type IfThenElseProcessor struct {
+// conditionalBranch is a set of processors executed when its condition is
+// true.
+type conditionalBranch struct {
 	cond conditions.Condition
 	then *Processors
-	els  *Processors
+}
+
+// IfThenElseProcessor executes the processors of the first branch whose
+// condition is true: the if branch (then), followed by the else_if branches
+// in order. If no condition is true, another set of processors (else) is
+// executed.
+type IfThenElseProcessor struct {
+	branches []conditionalBranch
+	els      *Processors
 }
 
 // NewIfElseThenProcessor construct a new IfThenElseProcessor.
@@ -129,11 +143,6 @@ func NewIfElseThenProcessor(cfg *config.C) (*IfThenElseProcessor, error) {
 		return nil, err
 	}
 
-	cond, err := conditions.NewCondition(&c.Cond)
-	if err != nil {
-		return nil, err
-	}
-
 	newProcessors := func(c *config.C) (*Processors, error) {
 		if c == nil {
 			return nil, nil
@@ -149,23 +158,50 @@ func NewIfElseThenProcessor(cfg *config.C) (*IfThenElseProcessor, error) {
 		return New(pc)
 	}
 
-	var ifProcessors, elseProcessors *Processors
-	if ifProcessors, err = newProcessors(c.Then); err != nil {
+	newBranch := func(condConfig *conditions.Config, then *config.C) (conditionalBranch, error) {
+		cond, err := conditions.NewCondition(condConfig)
+		if err != nil {
+			return conditionalBranch{}, err
+		}
+		processors, err := newProcessors(then)
+		if err != nil {
+			return conditionalBranch{}, err
+		}
+		return conditionalBranch{cond: cond, then: processors}, nil
+	}
+
+	branches := make([]conditionalBranch, 0, 1+len(c.ElseIf))
+	branch, err := newBranch(&c.Cond, c.Then)
+	if err != nil {
 		return nil, err
 	}
-	if elseProcessors, err = newProcessors(c.Else); err != nil {
+	branches = append(branches, branch)
+	for i := range c.ElseIf {
+		branch, err := newBranch(&c.ElseIf[i].Cond, c.ElseIf[i].Then)
+		if err != nil {
+			return nil, fmt.Errorf("else_if branch %d: %w", i, err)
+		}
+		branches = append(branches, branch)
+	}
+
+	elseProcessors, err := newProcessors(c.Else)
+	if err != nil {
 		return nil, err
 	}
 
-	return &IfThenElseProcessor{cond, ifProcessors, elseProcessors}, nil
+	return &IfThenElseProcessor{branches: branches, els: elseProcessors}, nil
 }
 
-// Run checks the if condition and executes the processors attached to the
-// then statement or the else statement based on the condition.
+// Run checks the conditions of the branches in order and executes the
+// processors of the first branch whose condition is true, or the processors
+// attached to the else statement if none is.
 func (p *IfThenElseProcessor) Run(event *beat.Event) (*beat.Event, error) {
-	if p.cond.Check(event) {
-		return p.then.Run(event)
-	} else if p.els != nil {
+	for _, b := range p.branches {
+		if b.cond.Check(event) {
+			return b.then.Run(event)
+		}
+	}
+	if p.els != nil {
 		return p.els.Run(event)
 	}
 	return event, nil
@@ -173,10 +209,15 @@ func (p *IfThenElseProcessor) Run(event *beat.Event) (*beat.Event, error) {
 
 func (p *IfThenElseProcessor) String() string {
 	var sb strings.Builder
-	sb.WriteString("if ")
-	sb.WriteString(p.cond.String())
-	sb.WriteString(" then ")
-	sb.WriteString(p.then.String())
+	for i, b := range p.branches {
+		if i > 0 {
+			sb.WriteString(" else ")
+		}
+		sb.WriteString("if ")
+		sb.WriteString(b.cond.String())
+		sb.WriteString(" then ")
+		sb.WriteString(b.then.String())
+	}
 	if p.els != nil {
 		sb.WriteString(" else ")
 		sb.WriteString(p.els.String())
@@ -198,6 +198,24 @@ func TestIfElseThenProcessor(t *testing.T) {
       add_fields: {target: "", fields: {uid_type: "gt_500"}}
 `
 
+	const ifThenElseIfChain = `
+- if:
+    range.uid.lt: 500
+  then:
+    - add_fields: {target: "", fields: {uid_type: reserved}}
+  else_if:
+    - if:
+        equals.uid: 500
+      then:
+        add_fields: {target: "", fields: {uid_type: "eq_500"}}
+    - if:
+        range.uid.lt: 1000
+      then:
+        - add_fields: {target: "", fields: {uid_type: "lt_1000"}}
+  else:
+    add_fields: {target: "", fields: {uid_type: "gte_1000"}}
+`
+
 	testProcessors(t, map[string]testCase{
 		"if-then-true": {
 			event: mapstr.M{"uid": 411},
@@ -229,5 +247,43 @@ func TestIfElseThenProcessor(t *testing.T) {
 			want:  mapstr.M{"uid": 500, "uid_type": "eq_500"},
 			cfg:   ifThenElseIf,
 		},
+		"if-then-else-if-chain-if": {
+			event: mapstr.M{"uid": 411},
+			want:  mapstr.M{"uid": 411, "uid_type": "reserved"},
+			cfg:   ifThenElseIfChain,
+		},
+		"if-then-else-if-chain-first-else-if": {
+			event: mapstr.M{"uid": 500},
+			want:  mapstr.M{"uid": 500, "uid_type": "eq_500"},
+			cfg:   ifThenElseIfChain,
+		},
+		"if-then-else-if-chain-second-else-if": {
+			event: mapstr.M{"uid": 501},
+			want:  mapstr.M{"uid": 501, "uid_type": "lt_1000"},
+			cfg:   ifThenElseIfChain,
+		},
+		"if-then-else-if-chain-else": {
+			event: mapstr.M{"uid": 1000},
+			want:  mapstr.M{"uid": 1000, "uid_type": "gte_1000"},
+			cfg:   ifThenElseIfChain,
+		},
 	})
 }
+
+func TestIfElseThenProcessorElseIfWithoutThen(t *testing.T) {
+	c, err := conf.NewConfigWithYAML([]byte(`
+if:
+  range.uid.lt: 500
+then:
+  - add_fields: {target: "", fields: {uid_type: reserved}}
+else_if:
+  - if:
+      equals.uid: 500
+`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewIfElseThenProcessor(c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package detect_sensitive
+
+import (
+	"fmt"
+	"strings"
+)
+
+// action is the operation applied to an event when a detector matches.
+type action uint8
+
+const (
+	actionMask action = iota
+	actionHash
+	actionTag
+	actionDrop
+)
+
+var actionNames = map[string]action{
+	"mask": actionMask,
+	"hash": actionHash,
+	"tag":  actionTag,
+	"drop": actionDrop,
+}
+
+// Unpack creates the action from the given string.
+func (a *action) Unpack(str string) error {
+	v, found := actionNames[strings.ToLower(str)]
+	if !found {
+		return fmt.Errorf("invalid action '%s', must be one of mask, hash, tag or drop", str)
+	}
+	*a = v
+	return nil
+}
+
+func (a action) String() string {
+	for name, v := range actionNames {
+		if v == a {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+type detectSensitiveConfig struct {
+	Fields        []string         `config:"fields"`
+	Detectors     []detectorConfig `config:"detectors" validate:"required"`
+	IgnoreMissing bool             `config:"ignore_missing"`
+	TargetField   string           `config:"target_field"`
+	Tag           string           `config:"tag"`
+	MaskChar      string           `config:"mask_char"`
+}
+
+type detectorConfig struct {
+	Type   string `config:"type" validate:"required"`
+	Action action `config:"action"`
+}
+
+func defaultConfig() detectSensitiveConfig {
+	return detectSensitiveConfig{
+		Fields:        []string{"message"},
+		IgnoreMissing: true,
+		TargetField:   "sensitive.detected",
+		Tag:           "sensitive_data",
+		MaskChar:      "*",
+	}
+}
+
+// Validate checks that the detectors are known and the mask character is
+// a single rune.
+func (c *detectSensitiveConfig) Validate() error {
+	if len(c.Fields) == 0 {
+		return fmt.Errorf("at least one field is required")
+	}
+	for _, d := range c.Detectors {
+		if _, found := detectors[strings.ToLower(d.Type)]; !found {
+			return fmt.Errorf("unknown detector type '%s'", d.Type)
+		}
+	}
+	if len([]rune(c.MaskChar)) != 1 {
+		return fmt.Errorf("mask_char must be a single character, got '%s'", c.MaskChar)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package detect_sensitive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const procName = "detect_sensitive"
+
+func init() {
+	processors.RegisterPlugin(procName, New)
+	jsprocessor.RegisterPlugin("DetectSensitive", New)
+}
+
+type boundDetector struct {
+	*detector
+	action action
+}
+
+type detectSensitive struct {
+	config    detectSensitiveConfig
+	detectors []boundDetector
+	log       *logp.Logger
+}
+
+// New constructs a new detect_sensitive processor.
+func New(cfg *config.C) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("failed to unpack the %s configuration: %w", procName, err)
+	}
+
+	p := &detectSensitive{
+		config: c,
+		log:    logp.NewLogger(procName),
+	}
+	for _, d := range c.Detectors {
+		p.detectors = append(p.detectors, boundDetector{
+			detector: detectors[strings.ToLower(d.Type)],
+			action:   d.Action,
+		})
+	}
+	return p, nil
+}
+
+// Run scans the configured fields and applies the action of every detector
+// that finds a match.
+func (p *detectSensitive) Run(event *beat.Event) (*beat.Event, error) {
+	var (
+		found []string
+		drop  bool
+		tag   bool
+	)
+
+	for _, field := range p.config.Fields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			if p.config.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+				continue
+			}
+			return event, fmt.Errorf("could not fetch value for key: %s, error: %w", field, err)
+		}
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		out := s
+		for _, d := range p.detectors {
+			var matched bool
+			out = d.re.ReplaceAllStringFunc(out, func(m string) string {
+				if d.validate != nil && !d.validate(m) {
+					return m
+				}
+				matched = true
+				return p.replace(d.action, m)
+			})
+			if !matched {
+				continue
+			}
+			found = appendUnique(found, d.name)
+			switch d.action {
+			case actionDrop:
+				drop = true
+			case actionTag:
+				tag = true
+			}
+		}
+
+		if out != s {
+			if _, err := event.PutValue(field, out); err != nil {
+				return event, fmt.Errorf("could not put value for key: %s, error: %w", field, err)
+			}
+		}
+	}
+
+	if drop {
+		p.log.Debugw("dropping event with sensitive data", "detectors", found)
+		return nil, nil
+	}
+	if len(found) == 0 {
+		return event, nil
+	}
+	if p.config.TargetField != "" {
+		if _, err := event.PutValue(p.config.TargetField, found); err != nil {
+			return event, fmt.Errorf("could not put value for key: %s, error: %w", p.config.TargetField, err)
+		}
+	}
+	if tag && p.config.Tag != "" {
+		if err := mapstr.AddTags(event.Fields, []string{p.config.Tag}); err != nil {
+			return event, fmt.Errorf("failed to add tag: %w", err)
+		}
+	}
+	return event, nil
+}
+
+// replace returns the text that substitutes a match for the given action.
+// The tag and drop actions leave the value untouched.
+func (p *detectSensitive) replace(a action, m string) string {
+	switch a {
+	case actionMask:
+		return strings.Repeat(p.config.MaskChar, len([]rune(m)))
+	case actionHash:
+		sum := sha256.Sum256([]byte(m))
+		return hex.EncodeToString(sum[:])
+	default:
+		return m
+	}
+}
+
+func (p *detectSensitive) String() string {
+	names := make([]string, 0, len(p.detectors))
+	for _, d := range p.detectors {
+		names = append(names, d.name+":"+d.action.String())
+	}
+	return fmt.Sprintf("%s=[fields=%v, detectors=%v]", procName, p.config.Fields, names)
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
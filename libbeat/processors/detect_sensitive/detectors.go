@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package detect_sensitive
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// detector finds one kind of sensitive value inside a string. Candidates
+// matched by re are passed to validate, when set, to discard false positives.
+type detector struct {
+	name     string
+	re       *regexp.Regexp
+	validate func(string) bool
+}
+
+var detectors = map[string]*detector{}
+
+func init() {
+	for _, d := range []*detector{
+		{
+			name:     "credit_card",
+			re:       regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+			validate: validCreditCard,
+		},
+		{
+			name: "aws_access_key",
+			re:   regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[0-9A-Z]{16}\b`),
+		},
+		{
+			name: "github_token",
+			re:   regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,255}\b`),
+		},
+		{
+			name: "slack_token",
+			re:   regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+		},
+		{
+			name: "jwt",
+			re:   regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+		},
+		{
+			name: "private_key",
+			re:   regexp.MustCompile(`(?s)-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----.*?-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----`),
+		},
+		{
+			name: "email",
+			re:   regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+		},
+		{
+			name:     "ssn",
+			re:       regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+			validate: validSSN,
+		},
+	} {
+		detectors[d.name] = d
+	}
+}
+
+// validCreditCard reports whether the digits in s form a 13 to 19 digit
+// number that passes the Luhn checksum.
+func validCreditCard(s string) bool {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits = append(digits, s[i]-'0')
+		}
+	}
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	return luhn(digits)
+}
+
+func luhn(digits []byte) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i])
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// validSSN discards numbers that can never be issued as a US social
+// security number.
+func validSSN(s string) bool {
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return false
+	}
+	area, _ := strconv.Atoi(parts[0])
+	group, _ := strconv.Atoi(parts[1])
+	serial, _ := strconv.Atoi(parts[2])
+	return area != 0 && area != 666 && area < 900 && group != 0 && serial != 0
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/fips"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -38,6 +39,28 @@ const (
 func init() {
 	processors.RegisterPlugin(procName, New)
 	jsprocessor.RegisterPlugin("Fingerprint", New)
+	fips.Register(fips.KindProcessor, procName, checkFIPS)
+}
+
+// fipsApprovedHashes are the hash methods that are FIPS approved.
+var fipsApprovedHashes = map[string]bool{
+	"sha256": true,
+	"sha384": true,
+	"sha512": true,
+}
+
+// checkFIPS returns an error if the configured hash method is not FIPS approved.
+func checkFIPS(cfg *config.C) error {
+	settings := struct {
+		Method namedHashMethod `config:"method"`
+	}{Method: defaultConfig().Method}
+	if err := cfg.Unpack(&settings); err != nil {
+		return err
+	}
+	if !fipsApprovedHashes[settings.Method.Name] {
+		return fmt.Errorf("method %s is not FIPS approved", settings.Method.Name)
+	}
+	return nil
 }
 
 type fingerprint struct {
@@ -519,3 +519,27 @@ func nRandomEvents(num int) []beat.Event {
 
 	return events
 }
+
+func TestCheckFIPS(t *testing.T) {
+	tests := map[string]struct {
+		config  string
+		capable bool
+	}{
+		"default":   {config: "fields: [foo]", capable: true},
+		"sha512":    {config: "fields: [foo]\nmethod: sha512", capable: true},
+		"md5":       {config: "fields: [foo]\nmethod: md5"},
+		"sha1":      {config: "fields: [foo]\nmethod: sha1"},
+		"xxhash":    {config: "fields: [foo]\nmethod: xxhash"},
+		"uppercase": {config: "fields: [foo]\nmethod: SHA256", capable: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkFIPS(config.MustNewConfigFrom(test.config))
+			if test.capable {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
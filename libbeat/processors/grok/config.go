@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+type grokConfig struct {
+	Field              string            `config:"field"`
+	Patterns           []string          `config:"patterns" validate:"required"`
+	PatternDefinitions map[string]string `config:"pattern_definitions"`
+	PatternFiles       []string          `config:"pattern_files"`
+	TargetPrefix       string            `config:"target_prefix"`
+	IgnoreMissing      bool              `config:"ignore_missing"`
+	IgnoreFailure      bool              `config:"ignore_failure"`
+	OverwriteKeys      bool              `config:"overwrite_keys"`
+	KeepEmptyCaptures  bool              `config:"keep_empty_captures"`
+	Timeout            time.Duration     `config:"timeout" validate:"min=0"`
+}
+
+func defaultConfig() grokConfig {
+	return grokConfig{
+		Field:   "message",
+		Timeout: 30 * time.Second,
+	}
+}
+
+// Validate checks that a field and at least one pattern are configured.
+func (c *grokConfig) Validate() error {
+	if c.Field == "" {
+		return fmt.Errorf("field is required")
+	}
+	if len(c.Patterns) == 0 {
+		return fmt.Errorf("at least one pattern is required")
+	}
+	return nil
+}
+
+// definitions returns the patterns of the pattern files followed by the
+// pattern definitions, which take precedence.
+func (c *grokConfig) definitions() (map[string]string, error) {
+	defs := map[string]string{}
+	for _, f := range c.PatternFiles {
+		if err := readPatternFile(paths.Resolve(paths.Config, f), defs); err != nil {
+			return nil, err
+		}
+	}
+	for name, def := range c.PatternDefinitions {
+		defs[name] = def
+	}
+	return defs, nil
+}
+
+// readPatternFile reads a file of patterns in the Logstash format: a
+// pattern per line, its name followed by a space and its definition.
+// Blank lines and lines starting with # are ignored.
+func readPatternFile(path string, defs map[string]string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open pattern file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, def, found := strings.Cut(line, " ")
+		if !found {
+			return fmt.Errorf("invalid pattern at %s:%d, expected a name and a definition", path, n)
+		}
+		defs[name] = strings.TrimSpace(def)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read pattern file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errNoMatch = errors.New("no pattern matched")
+	errTimeout = errors.New("timed out matching patterns")
+)
+
+// grok is a set of compiled grok expressions, tried in order until one of
+// them matches.
+type grok struct {
+	expressions []*expression
+}
+
+// expression is a grok expression compiled to a regular expression. The
+// named captures of the expression are the only capturing groups of the
+// regular expression.
+type expression struct {
+	raw      string
+	re       *regexp.Regexp
+	captures []capture
+}
+
+// capture is a named capture of an expression, its field and data type.
+type capture struct {
+	field    string
+	dataType string
+}
+
+var dataTypes = map[string]bool{
+	"":        true,
+	"string":  true,
+	"int":     true,
+	"long":    true,
+	"float":   true,
+	"double":  true,
+	"boolean": true,
+}
+
+// compileGrok compiles the expressions, referencing the patterns of the
+// standard library and the given definitions, which take precedence.
+func compileGrok(expressions []string, definitions map[string]string) (*grok, error) {
+	defs := make(map[string]string, len(standardPatterns)+len(definitions))
+	for name, def := range standardPatterns {
+		defs[name] = def
+	}
+	for name, def := range definitions {
+		defs[name] = def
+	}
+
+	g := &grok{}
+	for _, raw := range expressions {
+		c := compiler{definitions: defs}
+		expanded, err := c.expand(raw, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", raw, err)
+		}
+		re, err := regexp.Compile(expanded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", raw, err)
+		}
+		g.expressions = append(g.expressions, &expression{
+			raw:      raw,
+			re:       re,
+			captures: c.captures,
+		})
+	}
+	return g, nil
+}
+
+// match returns the captures of the first expression matching s. Regular
+// expressions run in linear time, so the deadline, when set, is checked
+// between expressions.
+func (g *grok) match(s string, keepEmpty bool, deadline time.Time) (map[string]interface{}, error) {
+	for i, e := range g.expressions {
+		if i > 0 && !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, errTimeout
+		}
+		loc := e.re.FindStringSubmatchIndex(s)
+		if loc == nil {
+			continue
+		}
+		return e.fields(s, loc, keepEmpty)
+	}
+	return nil, errNoMatch
+}
+
+// fields returns the values of the captures of a match. A field captured
+// more than once keeps its first value.
+func (e *expression) fields(s string, loc []int, keepEmpty bool) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, len(e.captures))
+	for i, c := range e.captures {
+		start, end := loc[2*(i+1)], loc[2*(i+1)+1]
+		if start < 0 || (start == end && !keepEmpty) {
+			continue
+		}
+		if _, found := fields[c.field]; found {
+			continue
+		}
+		v, err := convert(s[start:end], c.dataType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert field '%s': %w", c.field, err)
+		}
+		fields[c.field] = v
+	}
+	return fields, nil
+}
+
+func convert(s, dataType string) (interface{}, error) {
+	switch dataType {
+	case "int", "long":
+		return strconv.ParseInt(s, 10, 64)
+	case "float", "double":
+		return strconv.ParseFloat(s, 64)
+	case "boolean":
+		return strconv.ParseBool(s)
+	default:
+		return s, nil
+	}
+}
+
+// compiler expands the pattern references of a grok expression into a
+// regular expression.
+type compiler struct {
+	definitions map[string]string
+	captures    []capture
+}
+
+// expand rewrites a pattern in RE2 syntax. Pattern references %{NAME},
+// %{NAME:field} and %{NAME:field:type} are replaced by the expanded pattern,
+// in a capturing group when they have a field. Oniguruma named groups
+// (?<field>...) are captures too. Other groups are made non-capturing.
+// stack holds the names of the patterns being expanded, to detect cycles.
+func (c *compiler) expand(pattern string, stack []string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			b.WriteString(pattern[i : i+2])
+			i += 2
+		case pattern[i] == '[':
+			n := classLength(pattern[i:])
+			b.WriteString(pattern[i : i+n])
+			i += n
+		case strings.HasPrefix(pattern[i:], "%{"):
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated pattern reference at offset %d", i)
+			}
+			expanded, err := c.reference(pattern[i+2:i+end], stack)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(expanded)
+			i += end + 1
+		case pattern[i] == '(':
+			if name, n := namedGroup(pattern[i:]); n > 0 {
+				group, err := c.capture(name, "")
+				if err != nil {
+					return "", err
+				}
+				b.WriteString("(?P<" + group + ">")
+				i += n
+				continue
+			}
+			if strings.HasPrefix(pattern[i:], "(?") {
+				b.WriteString("(?")
+				i += 2
+				continue
+			}
+			b.WriteString("(?:")
+			i++
+		default:
+			b.WriteByte(pattern[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// reference expands a %{NAME:field:type} pattern reference.
+func (c *compiler) reference(ref string, stack []string) (string, error) {
+	parts := strings.SplitN(ref, ":", 3)
+	name := parts[0]
+	def, found := c.definitions[name]
+	if !found {
+		return "", fmt.Errorf("pattern %%{%s} is not defined", name)
+	}
+	for _, s := range stack {
+		if s == name {
+			return "", fmt.Errorf("pattern %%{%s} references itself", name)
+		}
+	}
+
+	// The group of the capture is created before expanding the
+	// pattern so captures are numbered in the order of their groups.
+	var group string
+	if len(parts) > 1 && parts[1] != "" {
+		var dataType string
+		if len(parts) > 2 {
+			dataType = parts[2]
+		}
+		var err error
+		group, err = c.capture(parts[1], dataType)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	expanded, err := c.expand(def, append(stack, name))
+	if err != nil {
+		return "", err
+	}
+	if group == "" {
+		return "(?:" + expanded + ")", nil
+	}
+	return "(?P<" + group + ">" + expanded + ")", nil
+}
+
+// capture registers a capture and returns the name of its group.
+func (c *compiler) capture(field, dataType string) (string, error) {
+	if !dataTypes[dataType] {
+		return "", fmt.Errorf("unsupported data type '%s' for field '%s'", dataType, field)
+	}
+	field = fieldName(field)
+	if field == "" {
+		return "", errors.New("empty capture name")
+	}
+	c.captures = append(c.captures, capture{field: field, dataType: dataType})
+	return "_" + strconv.Itoa(len(c.captures)), nil
+}
+
+// fieldName converts the nested field references of Logstash, [a][b], to
+// dotted names.
+func fieldName(name string) string {
+	if !strings.HasPrefix(name, "[") || !strings.HasSuffix(name, "]") {
+		return name
+	}
+	return strings.Join(strings.Split(name[1:len(name)-1], "]["), ".")
+}
+
+// namedGroup returns the name of the (?<name> or (?P<name> group at the
+// start of s and the length of its opening, or a zero length if s does not
+// start with a named group. Lookbehinds (?<= and (?<! are not named groups.
+func namedGroup(s string) (string, int) {
+	var start int
+	switch {
+	case strings.HasPrefix(s, "(?P<"):
+		start = 4
+	case strings.HasPrefix(s, "(?<") && !strings.HasPrefix(s, "(?<=") && !strings.HasPrefix(s, "(?<!"):
+		start = 3
+	default:
+		return "", 0
+	}
+	end := strings.IndexByte(s[start:], '>')
+	if end < 0 {
+		return "", 0
+	}
+	return s[start : start+end], start + end + 1
+}
+
+// classLength returns the length of the character class at the start of
+// s, so parentheses and %{ in it are not interpreted.
+func classLength(s string) int {
+	i := 1
+	if i < len(s) && s[i] == '^' {
+		i++
+	}
+	if i < len(s) && s[i] == ']' {
+		i++
+	}
+	for i < len(s) {
+		switch {
+		case s[i] == '\\':
+			i += 2
+		case strings.HasPrefix(s[i:], "[:"):
+			end := strings.Index(s[i:], ":]")
+			if end < 0 {
+				return len(s)
+			}
+			i += end + 2
+		case s[i] == ']':
+			return i + 1
+		default:
+			i++
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,298 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrokMatch(t *testing.T) {
+	testCases := map[string]struct {
+		patterns    []string
+		definitions map[string]string
+		input       string
+		output      map[string]interface{}
+	}{
+		"named captures": {
+			patterns: []string{`%{IP:client} %{WORD:method} %{URIPATHPARAM:request}`},
+			input:    "55.3.244.1 GET /index.html?a=b",
+			output: map[string]interface{}{
+				"client":  "55.3.244.1",
+				"method":  "GET",
+				"request": "/index.html?a=b",
+			},
+		},
+		"data types": {
+			patterns: []string{`%{NUMBER:bytes:int} %{NUMBER:duration:float} %{WORD:cached:boolean}`},
+			input:    "15824 0.043 true",
+			output: map[string]interface{}{
+				"bytes":    int64(15824),
+				"duration": 0.043,
+				"cached":   true,
+			},
+		},
+		"nested fields": {
+			patterns: []string{`%{IPV4:[source][ip]} %{POSINT:destination.port:long}`},
+			input:    "10.0.0.1 443",
+			output: map[string]interface{}{
+				"source.ip":        "10.0.0.1",
+				"destination.port": int64(443),
+			},
+		},
+		"first matching pattern wins": {
+			patterns: []string{`^%{IPV4:ip}$`, `^%{WORD:word}$`, `^%{NOTSPACE:any}$`},
+			input:    "hello",
+			output:   map[string]interface{}{"word": "hello"},
+		},
+		"custom definitions": {
+			patterns:    []string{`%{QUEUE_ID:queue_id}: %{GREEDYDATA:text}`},
+			definitions: map[string]string{"QUEUE_ID": `[0-9A-F]{10,11}`},
+			input:       "BEF25A72965: message-id=<20130101142543.5828399CCAF@example.com>",
+			output: map[string]interface{}{
+				"queue_id": "BEF25A72965",
+				"text":     "message-id=<20130101142543.5828399CCAF@example.com>",
+			},
+		},
+		"definitions override the library": {
+			patterns:    []string{`%{WORD:w}`},
+			definitions: map[string]string{"WORD": `[a-z]{2}`},
+			input:       "hello",
+			output:      map[string]interface{}{"w": "he"},
+		},
+		"oniguruma named groups": {
+			patterns: []string{`(?<queue_id>[0-9A-F]{10,11}) (?<[log][level]>\w+)`},
+			input:    "BEF25A72965 info",
+			output: map[string]interface{}{
+				"queue_id":  "BEF25A72965",
+				"log.level": "info",
+			},
+		},
+		"unnamed groups and classes are not captured": {
+			patterns: []string{`(a|b)+ [(%{]+ %{WORD:w}`},
+			input:    "abba (%{ x",
+			output:   map[string]interface{}{"w": "x"},
+		},
+		"empty and optional captures are skipped": {
+			patterns: []string{`^%{WORD:a}(?: %{WORD:b})?%{DATA:c}$`},
+			input:    "x",
+			output:   map[string]interface{}{"a": "x"},
+		},
+		"captures of nested patterns": {
+			patterns: []string{`%{SYSLOGBASE}`},
+			input:    "Mar  7 00:00:01 myhost sshd[1234]:",
+			output: map[string]interface{}{
+				"timestamp": "Mar  7 00:00:01",
+				"logsource": "myhost",
+				"program":   "sshd",
+				"pid":       "1234",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g, err := compileGrok(tc.patterns, tc.definitions)
+			require.NoError(t, err)
+
+			fields, err := g.match(tc.input, false, time.Time{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.output, fields)
+		})
+	}
+}
+
+func TestGrokKeepEmptyCaptures(t *testing.T) {
+	g, err := compileGrok([]string{`^%{WORD:a}%{DATA:b}$`}, nil)
+	require.NoError(t, err)
+
+	fields, err := g.match("x", true, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "x", "b": ""}, fields)
+}
+
+func TestGrokNoMatch(t *testing.T) {
+	g, err := compileGrok([]string{`^%{IPV4:ip}$`}, nil)
+	require.NoError(t, err)
+
+	_, err = g.match("not an ip", false, time.Time{})
+	assert.ErrorIs(t, err, errNoMatch)
+}
+
+func TestGrokConversionError(t *testing.T) {
+	g, err := compileGrok([]string{`%{NOTSPACE:n:int}`}, nil)
+	require.NoError(t, err)
+
+	_, err = g.match("abc", false, time.Time{})
+	assert.ErrorContains(t, err, "failed to convert field 'n'")
+}
+
+func TestGrokTimeout(t *testing.T) {
+	g, err := compileGrok([]string{`^a$`, `^b$`}, nil)
+	require.NoError(t, err)
+
+	_, err = g.match("b", false, time.Now().Add(-time.Second))
+	assert.ErrorIs(t, err, errTimeout)
+
+	// The first pattern is always tried.
+	_, err = g.match("a", false, time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+}
+
+func TestGrokCompileErrors(t *testing.T) {
+	testCases := map[string]struct {
+		pattern     string
+		definitions map[string]string
+		err         string
+	}{
+		"undefined pattern": {
+			pattern: `%{NOPE:x}`,
+			err:     "pattern %{NOPE} is not defined",
+		},
+		"unterminated reference": {
+			pattern: `%{WORD:x`,
+			err:     "unterminated pattern reference",
+		},
+		"unsupported type": {
+			pattern: `%{WORD:x:date}`,
+			err:     "unsupported data type 'date'",
+		},
+		"recursive pattern": {
+			pattern:     `%{A}`,
+			definitions: map[string]string{"A": `a%{B}`, "B": `b%{A}`},
+			err:         "pattern %{A} references itself",
+		},
+		"lookbehind": {
+			pattern: `(?<!x)y`,
+			err:     "invalid pattern",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := compileGrok([]string{tc.pattern}, tc.definitions)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestStandardPatterns(t *testing.T) {
+	for name := range standardPatterns {
+		_, err := compileGrok([]string{"%{" + name + ":value}"}, nil)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestStandardPatternsMatch(t *testing.T) {
+	testCases := map[string]struct {
+		pattern string
+		input   string
+		output  map[string]interface{}
+	}{
+		"combined apache log": {
+			pattern: `^%{COMBINEDAPACHELOG}$`,
+			input:   `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			output: map[string]interface{}{
+				"clientip":    "127.0.0.1",
+				"ident":       "-",
+				"auth":        "frank",
+				"timestamp":   "10/Oct/2000:13:55:36 -0700",
+				"verb":        "GET",
+				"request":     "/apache_pb.gif",
+				"httpversion": "1.0",
+				"response":    "200",
+				"bytes":       "2326",
+				"referrer":    `"http://www.example.com/start.html"`,
+				"agent":       `"Mozilla/4.08"`,
+			},
+		},
+		"syslog line": {
+			pattern: `^%{SYSLOGLINE}$`,
+			input:   "Oct 11 22:14:15 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8",
+			output: map[string]interface{}{
+				"timestamp": "Oct 11 22:14:15",
+				"logsource": "mymachine",
+				"program":   "su",
+				"pid":       "230",
+				"message":   "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		"tomcat log": {
+			pattern: `^%{TOMCATLOG}$`,
+			input:   "2014-01-09 20:03:28,269 -0800 | ERROR | com.example.service.ExampleService - something unexpected happened",
+			output: map[string]interface{}{
+				"timestamp":  "2014-01-09 20:03:28,269 -0800",
+				"level":      "ERROR",
+				"class":      "com.example.service.ExampleService",
+				"logmessage": "something unexpected happened",
+			},
+		},
+		"ipv4 last octet": {
+			pattern: `%{IPV4:ip}`,
+			input:   "from 192.168.1.255 port",
+			output:  map[string]interface{}{"ip": "192.168.1.255"},
+		},
+		"ipv6": {
+			pattern: `^%{IP:ip}$`,
+			input:   "2001:db8::8a2e:370:7334",
+			output:  map[string]interface{}{"ip": "2001:db8::8a2e:370:7334"},
+		},
+		"iso8601 timestamp": {
+			pattern: `^%{TIMESTAMP_ISO8601:ts}$`,
+			input:   "2024-05-01T12:34:56.789Z",
+			output:  map[string]interface{}{"ts": "2024-05-01T12:34:56.789Z"},
+		},
+		"quoted string": {
+			pattern: `^%{QS:q}$`,
+			input:   `"say \"hi\""`,
+			output:  map[string]interface{}{"q": `"say \"hi\""`},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g, err := compileGrok([]string{tc.pattern}, nil)
+			require.NoError(t, err)
+
+			fields, err := g.match(tc.input, false, time.Time{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.output, fields)
+		})
+	}
+}
+
+func TestExpandOnlyKeepsNamedCaptures(t *testing.T) {
+	c := compiler{definitions: standardPatterns}
+	expanded, err := c.expand(`%{HTTPD_COMBINEDLOG}`, nil)
+	require.NoError(t, err)
+
+	re := regexp.MustCompile(expanded)
+	for i, name := range re.SubexpNames() {
+		if i > 0 {
+			assert.NotEmpty(t, name, "unnamed capturing group %d", i)
+		}
+	}
+	assert.Len(t, c.captures, re.NumSubexp())
+}
@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	procName = "grok"
+
+	flagParsingError = "grok_parsing_error"
+	flagTimeout      = "grok_timeout"
+)
+
+func init() {
+	processors.RegisterPlugin(procName, New)
+	jsprocessor.RegisterPlugin("Grok", New)
+}
+
+type processor struct {
+	config grokConfig
+	grok   *grok
+	log    *logp.Logger
+}
+
+// New constructs a new grok processor.
+func New(cfg *config.C) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("failed to unpack the %s configuration: %w", procName, err)
+	}
+
+	defs, err := c.definitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the %s patterns: %w", procName, err)
+	}
+	g, err := compileGrok(c.Patterns, defs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile the %s patterns: %w", procName, err)
+	}
+
+	return &processor{
+		config: c,
+		grok:   g,
+		log:    logp.NewLogger(procName),
+	}, nil
+}
+
+// Run matches the configured field against the patterns and adds the
+// captures of the first matching pattern to the event.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.config.Field)
+	if err != nil {
+		if p.config.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+			return event, nil
+		}
+		return event, fmt.Errorf("could not fetch value for key: %s, error: %w", p.config.Field, err)
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return event, fmt.Errorf("field is not a string, value: `%v`, field: `%s`", v, p.config.Field)
+	}
+
+	var deadline time.Time
+	if p.config.Timeout > 0 {
+		deadline = time.Now().Add(p.config.Timeout)
+	}
+	fields, err := p.grok.match(s, p.config.KeepEmptyCaptures, deadline)
+	if err != nil {
+		flag := flagParsingError
+		if errors.Is(err, errTimeout) {
+			flag = flagTimeout
+			p.log.Debugf("Timed out matching field %s after %v", p.config.Field, p.config.Timeout)
+		}
+		if err := mapstr.AddTagsWithKey(
+			event.Fields,
+			beat.FlagField,
+			[]string{flag},
+		); err != nil {
+			return event, fmt.Errorf("cannot add new flag the event: %w", err)
+		}
+		if p.config.IgnoreFailure {
+			return event, nil
+		}
+		return event, fmt.Errorf("failed to match field %s: %w", p.config.Field, err)
+	}
+
+	backup := event.Clone()
+	event, err = p.mapper(event, fields)
+	if err != nil {
+		return backup, err
+	}
+	return event, nil
+}
+
+func (p *processor) mapper(event *beat.Event, fields map[string]interface{}) (*beat.Event, error) {
+	prefix := ""
+	if p.config.TargetPrefix != "" {
+		prefix = p.config.TargetPrefix + "."
+	}
+	for k, v := range fields {
+		key := prefix + k
+		if _, err := event.GetValue(key); errors.Is(err, mapstr.ErrKeyNotFound) || p.config.OverwriteKeys {
+			_, _ = event.PutValue(key, v)
+		} else {
+			// When the target key exists but is a string instead of a map.
+			if err != nil {
+				return event, fmt.Errorf("cannot override existing key with `%s`: %w", key, err)
+			}
+			return event, fmt.Errorf("cannot override existing key with `%s`", key)
+		}
+	}
+	return event, nil
+}
+
+func (p *processor) String() string {
+	return procName + "=[field=" + p.config.Field +
+		", patterns=[" + strings.Join(p.config.Patterns, ", ") + "]" +
+		", target_prefix=" + p.config.TargetPrefix + "]"
+}
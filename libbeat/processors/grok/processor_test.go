@@ -0,0 +1,205 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestProcessor(t *testing.T) {
+	testCases := map[string]struct {
+		config map[string]interface{}
+		input  mapstr.M
+		output mapstr.M
+		err    bool
+	}{
+		"captures at the root": {
+			config: map[string]interface{}{
+				"patterns": []string{`%{IP:source.ip} %{WORD:http.request.method} %{NUMBER:http.response.status_code:int}`},
+			},
+			input: mapstr.M{"message": "10.0.0.1 GET 200"},
+			output: mapstr.M{
+				"message": "10.0.0.1 GET 200",
+				"source":  mapstr.M{"ip": "10.0.0.1"},
+				"http": mapstr.M{
+					"request":  mapstr.M{"method": "GET"},
+					"response": mapstr.M{"status_code": int64(200)},
+				},
+			},
+		},
+		"target prefix": {
+			config: map[string]interface{}{
+				"patterns":      []string{`%{WORD:level}`},
+				"target_prefix": "grok",
+			},
+			input: mapstr.M{"message": "error"},
+			output: mapstr.M{
+				"message": "error",
+				"grok":    mapstr.M{"level": "error"},
+			},
+		},
+		"custom field": {
+			config: map[string]interface{}{
+				"field":    "event.original",
+				"patterns": []string{`%{WORD:level}`},
+			},
+			input: mapstr.M{"event": mapstr.M{"original": "warn"}},
+			output: mapstr.M{
+				"event": mapstr.M{"original": "warn"},
+				"level": "warn",
+			},
+		},
+		"no match flags the event": {
+			config: map[string]interface{}{
+				"patterns": []string{`^%{IPV4:ip}$`},
+			},
+			input: mapstr.M{"message": "hello"},
+			output: mapstr.M{
+				"message": "hello",
+				"log":     mapstr.M{"flags": []string{flagParsingError}},
+			},
+			err: true,
+		},
+		"ignore failure": {
+			config: map[string]interface{}{
+				"patterns":       []string{`^%{IPV4:ip}$`},
+				"ignore_failure": true,
+			},
+			input: mapstr.M{"message": "hello"},
+			output: mapstr.M{
+				"message": "hello",
+				"log":     mapstr.M{"flags": []string{flagParsingError}},
+			},
+		},
+		"missing field": {
+			config: map[string]interface{}{
+				"patterns": []string{`%{WORD:w}`},
+			},
+			input:  mapstr.M{"other": "hello"},
+			output: mapstr.M{"other": "hello"},
+			err:    true,
+		},
+		"ignore missing": {
+			config: map[string]interface{}{
+				"patterns":       []string{`%{WORD:w}`},
+				"ignore_missing": true,
+			},
+			input:  mapstr.M{"other": "hello"},
+			output: mapstr.M{"other": "hello"},
+		},
+		"existing keys are kept": {
+			config: map[string]interface{}{
+				"patterns": []string{`%{WORD:a} %{WORD:b}`},
+			},
+			input:  mapstr.M{"message": "x y", "b": "old"},
+			output: mapstr.M{"message": "x y", "b": "old"},
+			err:    true,
+		},
+		"overwrite keys": {
+			config: map[string]interface{}{
+				"patterns":       []string{`%{WORD:level} %{GREEDYDATA:message}`},
+				"overwrite_keys": true,
+			},
+			input: mapstr.M{"message": "info started"},
+			output: mapstr.M{
+				"message": "started",
+				"level":   "info",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			p, err := New(config.MustNewConfigFrom(tc.config))
+			require.NoError(t, err)
+
+			event, err := p.Run(&beat.Event{Fields: tc.input.Clone()})
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.output, event.Fields)
+		})
+	}
+}
+
+func TestProcessorTimeout(t *testing.T) {
+	p, err := New(config.MustNewConfigFrom(map[string]interface{}{
+		"patterns":       []string{`^a$`, `^b$`},
+		"ignore_failure": true,
+	}))
+	require.NoError(t, err)
+	p.(*processor).config.Timeout = time.Nanosecond
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "b"}})
+	require.NoError(t, err)
+	flags, err := event.GetValue(beat.FlagField)
+	require.NoError(t, err)
+	assert.Equal(t, []string{flagTimeout}, flags)
+}
+
+func TestProcessorPatternFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "postfix")
+	require.NoError(t, os.WriteFile(path, []byte(`
+# Postfix patterns
+POSTFIX_QUEUEID [0-9A-F]{10,11}
+POSTFIX_PREFIX %{POSTFIX_QUEUEID:queue_id}:
+`), 0o600))
+
+	p, err := New(config.MustNewConfigFrom(map[string]interface{}{
+		"patterns":      []string{`%{POSTFIX_PREFIX} %{GREEDYDATA:text}`},
+		"pattern_files": []string{path},
+	}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "BEF25A72965: removed"}})
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"message":  "BEF25A72965: removed",
+		"queue_id": "BEF25A72965",
+		"text":     "removed",
+	}, event.Fields)
+}
+
+func TestProcessorInvalidConfig(t *testing.T) {
+	testCases := map[string]map[string]interface{}{
+		"no patterns":       {},
+		"undefined pattern": {"patterns": []string{`%{NOPE}`}},
+		"missing file":      {"patterns": []string{`%{WORD}`}, "pattern_files": []string{"/does/not/exist"}},
+	}
+
+	for name, cfg := range testCases {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) {
+			_, err := New(config.MustNewConfigFrom(cfg))
+			assert.Error(t, err)
+		})
+	}
+}
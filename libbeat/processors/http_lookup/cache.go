@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_lookup
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// result is the outcome of a lookup.
+type result struct {
+	fields mapstr.M
+	err    error
+}
+
+type cacheEntry struct {
+	result
+	expires time.Time
+}
+
+// lookupCache is a size bounded cache of lookup results. Results expire
+// after their TTL and the least recently used result is evicted when the
+// cache is full.
+type lookupCache struct {
+	mu         sync.Mutex
+	lru        *simplelru.LRU
+	ttl        time.Duration
+	failureTTL time.Duration
+}
+
+func newLookupCache(c cacheConfig) (*lookupCache, error) {
+	lru, err := simplelru.NewLRU(c.Size, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &lookupCache{lru: lru, ttl: c.TTL, failureTTL: c.FailureTTL}, nil
+}
+
+// get returns the cached result of the key, if it has not expired.
+func (c *lookupCache) get(now time.Time, key string) (result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, found := c.lru.Get(key)
+	if !found {
+		return result{}, false
+	}
+	entry := v.(cacheEntry)
+	if now.After(entry.expires) {
+		c.lru.Remove(key)
+		return result{}, false
+	}
+	return entry.result, true
+}
+
+// set caches the result of the key, unless caching of this kind of result is
+// disabled.
+func (c *lookupCache) set(now time.Time, key string, r result) {
+	ttl := c.ttl
+	if r.err != nil {
+		ttl = c.failureTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Add(key, cacheEntry{result: r, expires: now.Add(ttl)})
+}
@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_lookup
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestLookupCache(t *testing.T) {
+	c, err := newLookupCache(cacheConfig{Size: 2, TTL: time.Minute, FailureTTL: time.Second})
+	require.NoError(t, err)
+
+	now := time.Now()
+	c.set(now, "a", result{fields: mapstr.M{"owner": "a"}})
+	c.set(now, "failed", result{err: errors.New("lookup failed")})
+
+	r, found := c.get(now.Add(30*time.Second), "a")
+	assert.True(t, found)
+	assert.Equal(t, mapstr.M{"owner": "a"}, r.fields)
+
+	_, found = c.get(now.Add(2*time.Second), "failed")
+	assert.False(t, found, "failures expire after the failure TTL")
+
+	_, found = c.get(now.Add(2*time.Minute), "a")
+	assert.False(t, found, "results expire after the TTL")
+}
+
+func TestLookupCacheEviction(t *testing.T) {
+	c, err := newLookupCache(cacheConfig{Size: 2, TTL: time.Minute})
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		key := strconv.Itoa(i)
+		c.set(now, key, result{fields: mapstr.M{"key": key}})
+		if i == 1 {
+			// Use the first key so that the second is the least recently used.
+			_, found := c.get(now, "0")
+			assert.True(t, found)
+		}
+	}
+
+	_, found := c.get(now, "0")
+	assert.True(t, found)
+	_, found = c.get(now, "1")
+	assert.False(t, found, "least recently used result must be evicted")
+	_, found = c.get(now, "2")
+	assert.True(t, found)
+}
+
+func TestLookupCacheDisabledFailures(t *testing.T) {
+	c, err := newLookupCache(cacheConfig{Size: 2, TTL: time.Minute})
+	require.NoError(t, err)
+
+	c.set(time.Now(), "failed", result{err: errors.New("lookup failed")})
+	_, found := c.get(time.Now(), "failed")
+	assert.False(t, found)
+}
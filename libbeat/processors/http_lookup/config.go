@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_lookup
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// failurePolicy defines what happens to an event when its lookup fails.
+type failurePolicy uint8
+
+const (
+	failureTag failurePolicy = iota
+	failureIgnore
+	failureDrop
+	failureError
+)
+
+var failurePolicyNames = map[string]failurePolicy{
+	"tag":    failureTag,
+	"ignore": failureIgnore,
+	"drop":   failureDrop,
+	"error":  failureError,
+}
+
+// Unpack creates the failure policy from the given string.
+func (p *failurePolicy) Unpack(str string) error {
+	v, found := failurePolicyNames[strings.ToLower(str)]
+	if !found {
+		return fmt.Errorf("invalid on_failure policy '%s', must be one of tag, ignore, drop or error", str)
+	}
+	*p = v
+	return nil
+}
+
+func (p failurePolicy) String() string {
+	for name, v := range failurePolicyNames {
+		if v == p {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+type config struct {
+	URL            string                           `config:"url" validate:"required"`
+	Method         string                           `config:"method"`
+	Headers        map[string]string                `config:"headers"`
+	Query          map[string]string                `config:"query"`
+	Body           string                           `config:"body"`
+	Fields         mapstr.M                         `config:"fields"`
+	Target         string                           `config:"target"`
+	OverwriteKeys  bool                             `config:"overwrite_keys"`
+	IgnoreMissing  bool                             `config:"ignore_missing"`
+	OnFailure      failurePolicy                    `config:"on_failure"`
+	TagOnFailure   []string                         `config:"tag_on_failure"`
+	MaxConcurrency int                              `config:"max_concurrency" validate:"min=1"`
+	Cache          cacheConfig                      `config:"cache"`
+	Transport      httpcommon.HTTPTransportSettings `config:",inline"`
+
+	// fieldMap is the flattened mapping of response fields to event fields.
+	fieldMap map[string]string
+}
+
+// cacheConfig defines the caching of the lookup results.
+type cacheConfig struct {
+	// Size is the maximum number of results kept in the cache. When the
+	// cache is full, the least recently used result is evicted. Zero
+	// disables caching.
+	Size int `config:"size" validate:"min=0"`
+
+	// TTL is how long a successful result is cached.
+	TTL time.Duration `config:"ttl" validate:"min=0"`
+
+	// FailureTTL is how long a failed lookup is cached. Zero disables the
+	// caching of failures.
+	FailureTTL time.Duration `config:"failure_ttl" validate:"min=0"`
+}
+
+func defaultConfig() config {
+	return config{
+		Method:         http.MethodGet,
+		IgnoreMissing:  true,
+		OnFailure:      failureTag,
+		TagOnFailure:   []string{"_http_lookup_failure"},
+		MaxConcurrency: 10,
+		Cache: cacheConfig{
+			Size:       10000,
+			TTL:        10 * time.Minute,
+			FailureTTL: time.Minute,
+		},
+		Transport: httpcommon.HTTPTransportSettings{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Validate validates the data contained in the config.
+func (c *config) Validate() error {
+	c.Method = strings.ToUpper(c.Method)
+	switch c.Method {
+	case http.MethodGet:
+		if c.Body != "" {
+			return errors.New("body can only be used with the POST method")
+		}
+	case http.MethodPost:
+	default:
+		return fmt.Errorf("invalid method '%s', must be GET or POST", c.Method)
+	}
+
+	if len(c.Fields) == 0 && c.Target == "" {
+		return errors.New("one of fields or target must be set")
+	}
+	if len(c.Fields) > 0 && c.Target != "" {
+		return errors.New("fields and target cannot be used together")
+	}
+
+	// Flatten the mapping of response fields to event fields.
+	c.fieldMap = map[string]string{}
+	for k, v := range c.Fields.Flatten() {
+		target, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("target field of response field %v must be a string but got %T", k, v)
+		}
+		c.fieldMap[k] = target
+	}
+	return nil
+}
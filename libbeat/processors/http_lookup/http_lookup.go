@@ -0,0 +1,359 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_lookup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/atomic"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	procName = "http_lookup"
+	logName  = "processor." + procName
+
+	// maxResponseSize is the maximum size of a response body.
+	maxResponseSize = 1 << 20
+)
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin(procName, New)
+	jsprocessor.RegisterPlugin("HTTPLookup", New)
+}
+
+type httpLookup struct {
+	config config
+	url    *template
+	query  map[string]*template
+	body   *template
+
+	client *http.Client
+	cache  *lookupCache // nil when caching is disabled.
+	// inflight merges concurrent lookups of the same request.
+	inflight singleflight.Group
+	// slots limits the number of concurrent requests.
+	slots *semaphore.Weighted
+
+	log     *logp.Logger
+	metrics metrics
+}
+
+type metrics struct {
+	requests    *monitoring.Int // Number of requests sent.
+	failures    *monitoring.Int // Number of failed lookups.
+	cacheHits   *monitoring.Int // Number of lookups answered by the cache.
+	cacheMisses *monitoring.Int // Number of lookups not found in the cache.
+}
+
+// New constructs a new http_lookup processor.
+func New(cfg *conf.C) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("failed to unpack the %s configuration: %w", procName, err)
+	}
+
+	// Logging and metrics (each processor instance has a unique ID).
+	var (
+		id  = int(instanceID.Inc())
+		log = logp.NewLogger(logName).With("instance_id", id)
+		reg = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	p := &httpLookup{
+		config: c,
+		query:  map[string]*template{},
+		slots:  semaphore.NewWeighted(int64(c.MaxConcurrency)),
+		log:    log,
+		metrics: metrics{
+			requests:    monitoring.NewInt(reg, "requests"),
+			failures:    monitoring.NewInt(reg, "failures"),
+			cacheHits:   monitoring.NewInt(reg, "cache.hits"),
+			cacheMisses: monitoring.NewInt(reg, "cache.misses"),
+		},
+	}
+
+	var err error
+	if p.url, err = compileTemplate(c.URL, url.PathEscape); err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	for name, value := range c.Query {
+		// Query values are escaped when the query is encoded.
+		if p.query[name], err = compileTemplate(value, noEscape); err != nil {
+			return nil, fmt.Errorf("invalid query parameter %s: %w", name, err)
+		}
+	}
+	if c.Body != "" {
+		if p.body, err = compileTemplate(c.Body, jsonEscape); err != nil {
+			return nil, fmt.Errorf("invalid body: %w", err)
+		}
+	}
+
+	if p.client, err = c.Transport.Client(); err != nil {
+		return nil, fmt.Errorf("failed to create the HTTP client: %w", err)
+	}
+	if c.Cache.Size > 0 {
+		if p.cache, err = newLookupCache(c.Cache); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Run looks up the event and adds the fields of the response to it.
+func (p *httpLookup) Run(event *beat.Event) (*beat.Event, error) {
+	req, err := p.newRequest(event)
+	if err != nil {
+		if p.config.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+			return event, nil
+		}
+		return p.fail(event, err)
+	}
+
+	fields, err := p.lookup(req)
+	if err != nil {
+		return p.fail(event, err)
+	}
+	if err := p.apply(event, fields); err != nil {
+		return p.fail(event, err)
+	}
+	return event, nil
+}
+
+// request is a lookup request rendered from an event.
+type request struct {
+	method string
+	url    string
+	body   string
+}
+
+// key identifies the request in the cache.
+func (r request) key() string {
+	return r.method + " " + r.url + "\n" + r.body
+}
+
+func (p *httpLookup) newRequest(event *beat.Event) (request, error) {
+	u, err := p.url.render(event)
+	if err != nil {
+		return request{}, fmt.Errorf("failed to render url: %w", err)
+	}
+
+	if len(p.query) > 0 {
+		// The parameters are sorted for the cache key to be stable.
+		names := make([]string, 0, len(p.query))
+		for name := range p.query {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		q := url.Values{}
+		for _, name := range names {
+			v, err := p.query[name].render(event)
+			if err != nil {
+				return request{}, fmt.Errorf("failed to render query parameter %s: %w", name, err)
+			}
+			q.Set(name, v)
+		}
+		sep := "?"
+		if strings.Contains(u, "?") {
+			sep = "&"
+		}
+		u += sep + q.Encode()
+	}
+
+	req := request{method: p.config.Method, url: u}
+	if p.body != nil {
+		if req.body, err = p.body.render(event); err != nil {
+			return request{}, fmt.Errorf("failed to render body: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// lookup returns the fields of the response to the request, from the cache
+// if possible.
+func (p *httpLookup) lookup(req request) (mapstr.M, error) {
+	key := req.key()
+	if p.cache != nil {
+		if r, found := p.cache.get(time.Now(), key); found {
+			p.metrics.cacheHits.Inc()
+			return r.fields, r.err
+		}
+		p.metrics.cacheMisses.Inc()
+	}
+
+	v, err, _ := p.inflight.Do(key, func() (interface{}, error) {
+		fields, err := p.fetch(req)
+		if p.cache != nil {
+			p.cache.set(time.Now(), key, result{fields: fields, err: err})
+		}
+		return fields, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(mapstr.M), nil
+}
+
+// fetch sends the request and decodes the JSON object of the response.
+func (p *httpLookup) fetch(req request) (mapstr.M, error) {
+	// The timeout includes the time spent waiting for a free slot.
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.Transport.Timeout)
+	defer cancel()
+	if err := p.slots.Acquire(ctx, 1); err != nil {
+		return nil, fmt.Errorf("failed waiting for a free request slot: %w", err)
+	}
+	defer p.slots.Release(1)
+
+	var body io.Reader
+	if req.body != "" {
+		body = strings.NewReader(req.body)
+	}
+	r, err := http.NewRequestWithContext(ctx, req.method, req.url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	r.Header.Set("Accept", "application/json")
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range p.config.Headers {
+		r.Header.Set(name, value)
+	}
+
+	p.metrics.requests.Inc()
+	resp, err := p.client.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("lookup request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lookup response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("lookup request returned unexpected status code: %d", resp.StatusCode)
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("lookup response is larger than %d bytes", maxResponseSize)
+	}
+
+	var fields mapstr.M
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("lookup response is not a JSON object: %w", err)
+	}
+	return fields, nil
+}
+
+// apply adds the response fields to the event. Results are shared through
+// the cache, so they are cloned before being added.
+func (p *httpLookup) apply(event *beat.Event, fields mapstr.M) error {
+	if p.config.Target != "" {
+		return p.put(event, p.config.Target, fields.Clone())
+	}
+
+	for source, target := range p.config.fieldMap {
+		v, err := fields.GetValue(source)
+		if err != nil {
+			// Fields missing from the response are not an error.
+			continue
+		}
+		switch m := v.(type) {
+		case mapstr.M:
+			v = m.Clone()
+		case map[string]interface{}:
+			v = mapstr.M(m).Clone()
+		}
+		if err := p.put(event, target, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *httpLookup) put(event *beat.Event, key string, value interface{}) error {
+	if !p.config.OverwriteKeys {
+		if _, err := event.GetValue(key); err == nil {
+			return fmt.Errorf("target field %s already exists and overwrite_keys is false", key)
+		}
+	}
+	_, err := event.PutValue(key, value)
+	return err
+}
+
+// fail applies the failure policy to the event.
+func (p *httpLookup) fail(event *beat.Event, err error) (*beat.Event, error) {
+	p.metrics.failures.Inc()
+	p.log.Debugw("HTTP lookup failed", "error", err)
+
+	switch p.config.OnFailure {
+	case failureIgnore:
+		return event, nil
+	case failureDrop:
+		return nil, nil
+	case failureError:
+		return event, err
+	default:
+		if err := mapstr.AddTags(event.Fields, p.config.TagOnFailure); err != nil {
+			return event, err
+		}
+		return event, nil
+	}
+}
+
+// Close releases the idle connections of the HTTP client.
+func (p *httpLookup) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+func (p *httpLookup) String() string {
+	return fmt.Sprintf("%s=[method=%s, url=%s, target=%s, fields=%v, on_failure=%v]",
+		procName, p.config.Method, p.config.URL, p.config.Target, p.config.fieldMap, p.config.OnFailure)
+}
+
+func noEscape(s string) string { return s }
+
+// jsonEscape escapes a value to be inserted in a JSON string.
+func jsonEscape(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b[1 : len(b)-1])
+}
@@ -0,0 +1,365 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_lookup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// testServer is a CMDB like service returning the owner of a host.
+type testServer struct {
+	*httptest.Server
+	requests int32
+
+	mu   sync.Mutex
+	last *http.Request
+	body string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.last, s.body = r, string(body)
+		s.mu.Unlock()
+
+		switch r.URL.EscapedPath() {
+		case "/hosts/web-01", "/hosts/a%2Fb%20c", "/search":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"owner":{"name":"payments","email":"payments@example.com"},"criticality":"high","tags":["pci"]}`)
+		case "/hosts/unknown":
+			http.NotFound(w, r)
+		case "/hosts/invalid":
+			fmt.Fprint(w, `["not","an","object"]`)
+		default:
+			http.Error(w, "unexpected path "+r.URL.EscapedPath(), http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) requestCount() int {
+	return int(atomic.LoadInt32(&s.requests))
+}
+
+func newProcessor(t *testing.T, settings map[string]interface{}) beat.Processor {
+	t.Helper()
+	p, err := New(conf.MustNewConfigFrom(settings))
+	require.NoError(t, err)
+	t.Cleanup(func() { p.(*httpLookup).Close() })
+	return p
+}
+
+func TestHTTPLookupFields(t *testing.T) {
+	server := newTestServer(t)
+	p := newProcessor(t, map[string]interface{}{
+		"url": server.URL + "/hosts/%{[host.name]}",
+		"fields": map[string]interface{}{
+			"owner.name":  "host.owner",
+			"criticality": "host.criticality",
+			"tags":        "host.labels",
+			"missing":     "host.missing",
+		},
+		"headers": map[string]interface{}{"Authorization": "Bearer token"},
+	})
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "web-01"}}})
+	require.NoError(t, err)
+
+	assert.Equal(t, mapstr.M{
+		"host": mapstr.M{
+			"name":        "web-01",
+			"owner":       "payments",
+			"criticality": "high",
+			"labels":      []interface{}{"pci"},
+		},
+	}, event.Fields)
+	assert.Equal(t, http.MethodGet, server.last.Method)
+	assert.Equal(t, "Bearer token", server.last.Header.Get("Authorization"))
+}
+
+func TestHTTPLookupEscapesValues(t *testing.T) {
+	server := newTestServer(t)
+	p := newProcessor(t, map[string]interface{}{
+		"url":    server.URL + "/hosts/%{[host.name]}",
+		"target": "cmdb",
+	})
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "a/b c"}}})
+	require.NoError(t, err)
+	owner, err := event.GetValue("cmdb.owner.name")
+	require.NoError(t, err)
+	assert.Equal(t, "payments", owner)
+}
+
+func TestHTTPLookupQuery(t *testing.T) {
+	server := newTestServer(t)
+	p := newProcessor(t, map[string]interface{}{
+		"url": server.URL + "/search?source=beats",
+		"query": map[string]interface{}{
+			"ip":   "%{[source.ip]}",
+			"kind": "host",
+		},
+		"target": "cmdb",
+	})
+
+	_, err := p.Run(&beat.Event{Fields: mapstr.M{"source": mapstr.M{"ip": "10.0.0.1&admin=true"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "source=beats&ip=10.0.0.1%26admin%3Dtrue&kind=host", server.last.URL.RawQuery)
+}
+
+func TestHTTPLookupPost(t *testing.T) {
+	server := newTestServer(t)
+	p := newProcessor(t, map[string]interface{}{
+		"url":    server.URL + "/search",
+		"method": "post",
+		"body":   `{"host":"%{[host.name]}","port":%{[port]}}`,
+		"target": "cmdb",
+	})
+
+	_, err := p.Run(&beat.Event{Fields: mapstr.M{
+		"host": mapstr.M{"name": `web"01`},
+		"port": 443,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, server.last.Method)
+	assert.Equal(t, "application/json", server.last.Header.Get("Content-Type"))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(server.body), &body))
+	assert.Equal(t, map[string]interface{}{"host": `web"01`, "port": float64(443)}, body)
+}
+
+func TestHTTPLookupCache(t *testing.T) {
+	server := newTestServer(t)
+	p := newProcessor(t, map[string]interface{}{
+		"url":    server.URL + "/hosts/%{[host.name]}",
+		"target": "cmdb",
+	})
+
+	for i := 0; i < 3; i++ {
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "web-01"}}})
+		require.NoError(t, err)
+
+		// Events must not share the cached response.
+		_, err = event.PutValue("cmdb.owner.name", "changed")
+		require.NoError(t, err)
+	}
+	for i := 0; i < 3; i++ {
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "unknown"}}})
+		require.NoError(t, err)
+		tags, err := event.GetValue("tags")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"_http_lookup_failure"}, tags)
+	}
+	assert.Equal(t, 2, server.requestCount())
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "web-01"}}})
+	require.NoError(t, err)
+	owner, err := event.GetValue("cmdb.owner.name")
+	require.NoError(t, err)
+	assert.Equal(t, "payments", owner)
+
+	metrics := p.(*httpLookup).metrics
+	assert.Equal(t, int64(2), metrics.requests.Get())
+	assert.Equal(t, int64(5), metrics.cacheHits.Get())
+	assert.Equal(t, int64(2), metrics.cacheMisses.Get())
+}
+
+func TestHTTPLookupCacheDisabled(t *testing.T) {
+	server := newTestServer(t)
+	p := newProcessor(t, map[string]interface{}{
+		"url":        server.URL + "/hosts/%{[host.name]}",
+		"target":     "cmdb",
+		"cache.size": 0,
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "web-01"}}})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, server.requestCount())
+}
+
+func TestHTTPLookupFailurePolicies(t *testing.T) {
+	server := newTestServer(t)
+	tests := []struct {
+		policy  string
+		drop    bool
+		wantErr bool
+		tags    interface{}
+	}{
+		{policy: "tag", tags: []string{"lookup_failed"}},
+		{policy: "ignore"},
+		{policy: "drop", drop: true},
+		{policy: "error", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			p := newProcessor(t, map[string]interface{}{
+				"url":            server.URL + "/hosts/%{[host.name]}",
+				"target":         "cmdb",
+				"on_failure":     test.policy,
+				"tag_on_failure": []string{"lookup_failed"},
+			})
+
+			event, err := p.Run(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "invalid"}}})
+			if test.wantErr {
+				assert.ErrorContains(t, err, "not a JSON object")
+			} else {
+				assert.NoError(t, err)
+			}
+			if test.drop {
+				assert.Nil(t, event)
+				return
+			}
+			require.NotNil(t, event)
+			tags, _ := event.GetValue("tags")
+			assert.Equal(t, test.tags, tags)
+			assert.NotContains(t, event.Fields, "cmdb")
+		})
+	}
+}
+
+func TestHTTPLookupMissingField(t *testing.T) {
+	server := newTestServer(t)
+
+	t.Run("ignore_missing", func(t *testing.T) {
+		p := newProcessor(t, map[string]interface{}{
+			"url":    server.URL + "/hosts/%{[host.name]}",
+			"target": "cmdb",
+		})
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "hello"}})
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{"message": "hello"}, event.Fields)
+	})
+
+	t.Run("default value", func(t *testing.T) {
+		p := newProcessor(t, map[string]interface{}{
+			"url":    server.URL + "/hosts/%{[host.name]:web-01}",
+			"target": "cmdb",
+		})
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "hello"}})
+		require.NoError(t, err)
+		assert.Contains(t, event.Fields, "cmdb")
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		p := newProcessor(t, map[string]interface{}{
+			"url":            server.URL + "/hosts/%{[host.name]}",
+			"target":         "cmdb",
+			"ignore_missing": false,
+			"on_failure":     "error",
+		})
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "hello"}})
+		assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+	})
+	assert.Equal(t, 1, server.requestCount())
+}
+
+func TestHTTPLookupOverwriteKeys(t *testing.T) {
+	server := newTestServer(t)
+	settings := map[string]interface{}{
+		"url":        server.URL + "/hosts/%{[host.name]}",
+		"fields":     map[string]interface{}{"criticality": "host.criticality"},
+		"on_failure": "error",
+	}
+	newEvent := func() *beat.Event {
+		return &beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "web-01", "criticality": "low"}}}
+	}
+
+	_, err := newProcessor(t, settings).Run(newEvent())
+	assert.ErrorContains(t, err, "host.criticality already exists")
+
+	settings["overwrite_keys"] = true
+	event, err := newProcessor(t, settings).Run(newEvent())
+	require.NoError(t, err)
+	criticality, _ := event.GetValue("host.criticality")
+	assert.Equal(t, "high", criticality)
+}
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name:     "missing url",
+			settings: map[string]interface{}{"target": "cmdb"},
+			wantErr:  "missing required field",
+		},
+		{
+			name:     "no fields nor target",
+			settings: map[string]interface{}{"url": "http://localhost/%{[host.name]}"},
+			wantErr:  "one of fields or target must be set",
+		},
+		{
+			name: "fields and target",
+			settings: map[string]interface{}{
+				"url":    "http://localhost/%{[host.name]}",
+				"target": "cmdb",
+				"fields": map[string]interface{}{"owner": "host.owner"},
+			},
+			wantErr: "fields and target cannot be used together",
+		},
+		{
+			name:     "invalid method",
+			settings: map[string]interface{}{"url": "http://localhost", "target": "cmdb", "method": "PUT"},
+			wantErr:  "invalid method 'PUT'",
+		},
+		{
+			name:     "body with GET",
+			settings: map[string]interface{}{"url": "http://localhost", "target": "cmdb", "body": "{}"},
+			wantErr:  "body can only be used with the POST method",
+		},
+		{
+			name:     "invalid policy",
+			settings: map[string]interface{}{"url": "http://localhost", "target": "cmdb", "on_failure": "retry"},
+			wantErr:  "invalid on_failure policy 'retry'",
+		},
+		{
+			name:     "invalid template",
+			settings: map[string]interface{}{"url": "http://localhost/%{[host.name}", "target": "cmdb"},
+			wantErr:  "invalid url",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(test.settings))
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_lookup
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// fieldRefRegex matches the event field references of a format string, like
+// %{[host.name]} or %{[host][name]:default}.
+var fieldRefRegex = regexp.MustCompile(`%\{((?:\[[^\]]+\])+)`)
+
+// template is a format string whose event field values are escaped before
+// being inserted, so that they can't alter the structure of the URL or the
+// body of the request.
+type template struct {
+	format *fmtstr.EventFormatString
+	// fields holds a format string converting each referenced event field
+	// to a string, by path.
+	fields map[string]*fmtstr.EventFormatString
+	escape func(string) string
+}
+
+func compileTemplate(in string, escape func(string) string) (*template, error) {
+	format, err := fmtstr.CompileEvent(in)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format string '%s': %w", in, err)
+	}
+
+	t := &template{
+		format: format,
+		fields: map[string]*fmtstr.EventFormatString{},
+		escape: escape,
+	}
+	for _, match := range fieldRefRegex.FindAllStringSubmatch(in, -1) {
+		ref := match[1]
+		field, err := fmtstr.CompileEvent("%{" + ref + "}")
+		if err != nil {
+			return nil, fmt.Errorf("invalid field reference '%s': %w", ref, err)
+		}
+		fields := field.Fields()
+		if len(fields) != 1 {
+			continue
+		}
+		t.fields[fields[0]] = field
+	}
+	return t, nil
+}
+
+// render expands the template with the escaped values of the event fields.
+func (t *template) render(event *beat.Event) (string, error) {
+	if len(t.fields) == 0 {
+		return t.format.Run(event)
+	}
+
+	escaped := &beat.Event{
+		Timestamp: event.Timestamp,
+		Fields:    mapstr.M{},
+	}
+	for path, field := range t.fields {
+		v, err := field.Run(event)
+		if err != nil {
+			// Missing fields are reported by the template, unless they
+			// have a default value.
+			continue
+		}
+		if _, err := escaped.PutValue(path, t.escape(v)); err != nil {
+			return "", err
+		}
+	}
+	return t.format.Run(escaped)
+}
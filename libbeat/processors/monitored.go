@@ -0,0 +1,229 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// slowEventLogInterval is the minimum time between two slow event logs of
+// the same processor.
+const slowEventLogInterval = 10 * time.Second
+
+// MetricsConfig is the configuration of the processor metrics, read from
+// the `processor_metrics` setting.
+type MetricsConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// SlowEventThreshold is the time a processor can spend on a single event
+	// before the event is logged as slow. It is disabled when 0.
+	SlowEventThreshold time.Duration `config:"slow_event_threshold" validate:"min=0"`
+}
+
+func defaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{Enabled: true}
+}
+
+// XXX: processors are created from many places that have no access to the
+// beat's registries, so the metrics and their configuration are package
+// globals, like the registry of the processors.
+var processorMetrics = struct {
+	sync.Mutex
+	once   sync.Once
+	config MetricsConfig
+	stats  map[string]*processorStats
+}{
+	config: defaultMetricsConfig(),
+}
+
+// SetMetrics configures the metrics of the processors created afterwards.
+func SetMetrics(c *conf.C) error {
+	config := defaultMetricsConfig()
+	if c != nil {
+		if err := c.Unpack(&config); err != nil {
+			return fmt.Errorf("failed to configure processor metrics: %w", err)
+		}
+	}
+
+	processorMetrics.Lock()
+	defer processorMetrics.Unlock()
+	processorMetrics.config = config
+	return nil
+}
+
+// processorStats are the metrics of all the processors with the same name.
+type processorStats struct {
+	events     atomic.Uint64
+	errors     atomic.Uint64
+	dropped    atomic.Uint64
+	slowEvents atomic.Uint64
+	timeNanos  atomic.Int64
+
+	// lastSlowLog is the time, in Unix nanoseconds, of the last slow event
+	// log of the processors.
+	lastSlowLog atomic.Int64
+}
+
+// statsFor returns the stats of the processors named name, reported in the
+// processors namespace of the stats registry.
+func statsFor(name string) *processorStats {
+	processorMetrics.once.Do(func() {
+		monitoring.NewFunc(monitoring.Default, "processors", reportProcessorStats, monitoring.Report)
+	})
+
+	processorMetrics.Lock()
+	defer processorMetrics.Unlock()
+	if processorMetrics.stats == nil {
+		processorMetrics.stats = map[string]*processorStats{}
+	}
+	stats, ok := processorMetrics.stats[name]
+	if !ok {
+		stats = &processorStats{}
+		processorMetrics.stats[name] = stats
+	}
+	return stats
+}
+
+func reportProcessorStats(_ monitoring.Mode, V monitoring.Visitor) {
+	processorMetrics.Lock()
+	defer processorMetrics.Unlock()
+
+	names := make([]string, 0, len(processorMetrics.stats))
+	for name := range processorMetrics.stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+	for _, name := range names {
+		stats := processorMetrics.stats[name]
+		monitoring.ReportNamespace(V, name, func() {
+			monitoring.ReportInt(V, "events", int64(stats.events.Load()))
+			monitoring.ReportInt(V, "errors", int64(stats.errors.Load()))
+			monitoring.ReportInt(V, "dropped", int64(stats.dropped.Load()))
+			monitoring.ReportInt(V, "slow_events", int64(stats.slowEvents.Load()))
+			monitoring.ReportInt(V, "time.ns", stats.timeNanos.Load())
+		})
+	}
+}
+
+// monitoredProcessor records the metrics of a processor, and logs the events
+// it is slow to process.
+type monitoredProcessor struct {
+	beat.Processor
+	name          string
+	stats         *processorStats
+	slowThreshold time.Duration
+	log           *logp.Logger
+}
+
+// monitoredCloser is a monitoredProcessor whose processor must be closed.
+type monitoredCloser struct {
+	*monitoredProcessor
+}
+
+func (p monitoredCloser) Close() error {
+	return Close(p.Processor)
+}
+
+// withMetrics returns the processor wrapped to record its metrics under
+// name, or the processor itself when the metrics are disabled.
+func withMetrics(p beat.Processor, name string, log *logp.Logger) beat.Processor {
+	processorMetrics.Lock()
+	config := processorMetrics.config
+	processorMetrics.Unlock()
+	if !config.Enabled {
+		return p
+	}
+
+	mp := &monitoredProcessor{
+		Processor:     p,
+		name:          name,
+		stats:         statsFor(name),
+		slowThreshold: config.SlowEventThreshold,
+		log:           log,
+	}
+	if _, ok := p.(Closer); ok {
+		return monitoredCloser{mp}
+	}
+	return mp
+}
+
+// metricsName returns the name the metrics of a processor are reported
+// under: its action, followed by its tag when it has one.
+func metricsName(action string, config *conf.C) string {
+	if tag, err := config.String("tag", -1); err == nil && tag != "" {
+		return action + ":" + tag
+	}
+	return action
+}
+
+func (p *monitoredProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	start := time.Now()
+	out, err := p.Processor.Run(event)
+	elapsed := time.Since(start)
+
+	p.stats.events.Add(1)
+	p.stats.timeNanos.Add(int64(elapsed))
+	if err != nil {
+		p.stats.errors.Add(1)
+	} else if out == nil {
+		p.stats.dropped.Add(1)
+	}
+
+	if p.slowThreshold > 0 && elapsed >= p.slowThreshold {
+		p.stats.slowEvents.Add(1)
+		p.logSlowEvent(event, elapsed, err)
+	}
+	return out, err
+}
+
+// logSlowEvent logs a slow event, at most once every slowEventLogInterval
+// for all the processors of the same name. The content of the event is only
+// logged at the debug level, as it may hold sensitive data.
+func (p *monitoredProcessor) logSlowEvent(event *beat.Event, elapsed time.Duration, err error) {
+	now := time.Now().UnixNano()
+	last := p.stats.lastSlowLog.Load()
+	if now-last < int64(slowEventLogInterval) || !p.stats.lastSlowLog.CompareAndSwap(last, now) {
+		return
+	}
+
+	fields := []interface{}{
+		"processor", p.name,
+		"duration", elapsed,
+		"threshold", p.slowThreshold,
+		"slow_events", p.stats.slowEvents.Load(),
+	}
+	if err != nil {
+		fields = append(fields, "error", err)
+	}
+	p.log.Warnw(fmt.Sprintf("Processor %v was slow to process an event", p.Processor), fields...)
+	if event != nil && p.log.IsDebug() {
+		p.log.Debugw("Slow event", "processor", p.name, logp.TypeKey, logp.EventType, "event", event.Fields.String())
+	}
+}
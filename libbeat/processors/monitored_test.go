@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// testProcessor drops the events with a drop field, fails the events with a
+// fail field, and sleeps for the duration of their sleep field.
+type testProcessor struct {
+	closed bool
+}
+
+func (p *testProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	if d, err := event.GetValue("sleep"); err == nil {
+		time.Sleep(d.(time.Duration))
+	}
+	if _, err := event.GetValue("drop"); err == nil {
+		return nil, nil
+	}
+	if _, err := event.GetValue("fail"); err == nil {
+		return event, errors.New("failed")
+	}
+	return event, nil
+}
+
+func (p *testProcessor) String() string { return "test_metrics" }
+
+func (p *testProcessor) Close() error {
+	p.closed = true
+	return nil
+}
+
+var lastTestProcessor *testProcessor
+
+func init() {
+	processors.RegisterPlugin("test_metrics", func(_ *conf.C) (beat.Processor, error) {
+		lastTestProcessor = &testProcessor{}
+		return lastTestProcessor, nil
+	})
+}
+
+func processorSnapshot(t *testing.T, name string) map[string]int64 {
+	t.Helper()
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	stats := map[string]int64{}
+	for _, metric := range []string{"events", "errors", "dropped", "slow_events", "time.ns"} {
+		stats[metric] = snapshot.Ints["processors."+name+"."+metric]
+	}
+	return stats
+}
+
+func TestProcessorMetrics(t *testing.T) {
+	logp.DevelopmentSetup(logp.ToObserverOutput())
+	require.NoError(t, processors.SetMetrics(conf.MustNewConfigFrom(map[string]interface{}{
+		"slow_event_threshold": "10ms",
+	})))
+	defer func() {
+		require.NoError(t, processors.SetMetrics(nil))
+	}()
+
+	procs := GetProcessors(t, []map[string]interface{}{
+		{"test_metrics": map[string]interface{}{"tag": "tagged"}},
+	})
+
+	for _, fields := range []mapstr.M{
+		{},
+		{"drop": true},
+		{"fail": true},
+		{"sleep": 20 * time.Millisecond},
+	} {
+		_, _ = procs.Run(&beat.Event{Fields: fields})
+	}
+
+	stats := processorSnapshot(t, "test_metrics:tagged")
+	assert.Equal(t, int64(4), stats["events"])
+	assert.Equal(t, int64(1), stats["errors"])
+	assert.Equal(t, int64(1), stats["dropped"])
+	assert.Equal(t, int64(1), stats["slow_events"])
+	assert.GreaterOrEqual(t, stats["time.ns"], int64(20*time.Millisecond))
+
+	logs := logp.ObserverLogs().FilterMessage("Processor test_metrics was slow to process an event").TakeAll()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "test_metrics:tagged", logs[0].ContextMap()["processor"])
+
+	assert.Equal(t, "test_metrics", procs.String())
+	require.NoError(t, procs.Close())
+	assert.True(t, lastTestProcessor.closed, "the processor must be closed through its metrics wrapper")
+}
+
+func TestProcessorMetricsDisabled(t *testing.T) {
+	require.NoError(t, processors.SetMetrics(conf.MustNewConfigFrom(map[string]interface{}{
+		"enabled": false,
+	})))
+	defer func() {
+		require.NoError(t, processors.SetMetrics(nil))
+	}()
+
+	procs := GetProcessors(t, []map[string]interface{}{
+		{"test_metrics": map[string]interface{}{"tag": "disabled"}},
+	})
+	_, _ = procs.Run(&beat.Event{Fields: mapstr.M{}})
+
+	require.Len(t, procs.List, 1)
+	assert.Same(t, lastTestProcessor, procs.List[0])
+	assert.Zero(t, processorSnapshot(t, "test_metrics:disabled")["events"])
+}
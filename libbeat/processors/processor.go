@@ -63,6 +63,8 @@ func NewList(log *logp.Logger) *Processors {
 }
 
 // New creates a list of processors from a list of free user configurations.
+// Unless disabled with SetMetrics, each processor records its metrics in the
+// processors namespace of the stats registry.
 func New(config PluginConfig) (*Processors, error) {
 	procs := NewList(nil)
 
@@ -73,7 +75,7 @@ func New(config PluginConfig) (*Processors, error) {
 			if err != nil {
 				return nil, fmt.Errorf("failed to make if/then/else processor: %w", err)
 			}
-			procs.AddProcessor(p)
+			procs.AddProcessor(withMetrics(p, metricsName("if", procConfig), procs.log))
 			continue
 		}
 
@@ -107,7 +109,7 @@ func New(config PluginConfig) (*Processors, error) {
 			return nil, err
 		}
 
-		procs.AddProcessor(plugin)
+		procs.AddProcessor(withMetrics(plugin, metricsName(actionName, actionCfg), procs.log))
 	}
 
 	if len(procs.List) > 0 {
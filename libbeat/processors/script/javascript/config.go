@@ -32,6 +32,7 @@ type Config struct {
 	Timeout           time.Duration          `config:"timeout" validate:"min=0"`             // Execution timeout.
 	TagOnException    string                 `config:"tag_on_exception"`                     // Tag to add to events when an exception happens.
 	MaxCachedSessions int                    `config:"max_cached_sessions" validate:"min=0"` // Max. number of cached VM sessions.
+	ModulePaths       []string               `config:"module_paths"`                         // Directories of the modules loaded with require().
 }
 
 // Validate returns an error if one (and only one) option is not set.
@@ -81,12 +81,19 @@ func NewFromConfig(c Config, reg *monitoring.Registry) (beat.Processor, error) {
 	}
 
 	// Validate processor source code.
-	prog, err := goja.Compile(sourceFile, string(sourceCode), true)
+	prog, err := compile(sourceFile, sourceCode)
 	if err != nil {
 		return nil, err
 	}
 
-	pool, err := newSessionPool(prog, c)
+	var modules *Modules
+	if len(c.ModulePaths) > 0 {
+		if modules, err = loadModules(c.ModulePaths); err != nil {
+			return nil, annotateError(c.Tag, err)
+		}
+	}
+
+	pool, err := newSessionPool(prog, modules, c)
 	if err != nil {
 		return nil, annotateError(c.Tag, err)
 	}
@@ -27,12 +27,17 @@ import (
 
 func init() {
 	javascript.AddSessionHook("require", func(s javascript.Session) {
+		if modules := s.Modules(); modules != nil {
+			modules.Enable(s.Runtime())
+			return
+		}
 		reg := require.NewRegistryWithLoader(loadSource)
 		reg.Enable(s.Runtime())
 	})
 }
 
-// loadSource disallows loading custom modules from file.
+// loadSource disallows loading custom modules from file when no module paths
+// are configured.
 func loadSource(path string) ([]byte, error) {
 	return nil, fmt.Errorf("cannot load %v, only built-in modules are supported", path)
 }
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package require_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors/script/javascript"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+
+	_ "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/path"
+	_ "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/require"
+)
+
+func writeModules(t *testing.T, modules map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, code := range modules {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(code), 0o644))
+	}
+	return dir
+}
+
+func TestRequireModules(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"enrich.js": `
+var util = require('./lib/util');
+var path = require('path');
+exports.enrich = function(evt) {
+    evt.Put("result.upper", util.upper(evt.Get("message")));
+    evt.Put("result.base", path.basename(evt.Get("file")));
+};`,
+		"lib/util.js": `
+module.exports = {
+    upper: function(s) { return s.toUpperCase(); }
+};`,
+	})
+
+	const script = `
+var enrich = require('enrich').enrich;
+
+function process(evt) {
+    enrich(evt);
+}
+`
+	p, err := javascript.NewFromConfig(javascript.Config{Source: script, ModulePaths: []string{dir}}, nil)
+	require.NoError(t, err)
+
+	evt, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "hello", "file": "/var/log/messages"}})
+	require.NoError(t, err)
+
+	fields := evt.Fields.Flatten()
+	assert.Equal(t, "HELLO", fields["result.upper"])
+	assert.Equal(t, "messages", fields["result.base"])
+}
+
+func TestRequireModulesPrecedence(t *testing.T) {
+	first := writeModules(t, map[string]string{"value.js": `module.exports = "first";`})
+	second := writeModules(t, map[string]string{"value.js": `module.exports = "second";`})
+
+	const script = `
+var value = require('value.js');
+
+function process(evt) {
+    evt.Put("value", value);
+}
+`
+	p, err := javascript.NewFromConfig(javascript.Config{Source: script, ModulePaths: []string{first, second}}, nil)
+	require.NoError(t, err)
+
+	evt, err := p.Run(&beat.Event{Fields: mapstr.M{}})
+	require.NoError(t, err)
+	assert.Equal(t, "first", evt.Fields["value"])
+}
+
+func TestRequireModulesErrors(t *testing.T) {
+	t.Run("syntax error", func(t *testing.T) {
+		dir := writeModules(t, map[string]string{"broken.js": `exports.f = function( {`})
+		_, err := javascript.NewFromConfig(javascript.Config{Source: `function process(evt) {}`, ModulePaths: []string{dir}}, nil)
+		assert.ErrorContains(t, err, "failed to compile module")
+	})
+
+	t.Run("missing module", func(t *testing.T) {
+		dir := writeModules(t, map[string]string{"util.js": `exports.x = 1;`})
+		_, err := javascript.NewFromConfig(javascript.Config{Source: `require('missing'); function process(evt) {}`, ModulePaths: []string{dir}}, nil)
+		assert.ErrorContains(t, err, "cannot load missing")
+	})
+
+	t.Run("outside of the module paths", func(t *testing.T) {
+		dir := writeModules(t, map[string]string{"util.js": `exports.x = 1;`})
+		_, err := javascript.NewFromConfig(javascript.Config{Source: `require('../util'); function process(evt) {}`, ModulePaths: []string{dir}}, nil)
+		assert.ErrorContains(t, err, "must be relative to the module paths")
+	})
+
+	t.Run("modules disabled", func(t *testing.T) {
+		_, err := javascript.NewFromConfig(javascript.Config{Source: `require('util'); function process(evt) {}`}, nil)
+		assert.ErrorContains(t, err, "only built-in modules are supported")
+	})
+}
@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package javascript
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// maxCachedPrograms bounds the number of compiled programs and module sets
+// kept for reuse. The caches are emptied when they grow past it, which only
+// happens when the sources change across many reloads.
+const maxCachedPrograms = 128
+
+// Modules are the helper modules found in the module_paths of a processor,
+// that its script can load with require(). The modules are compiled once and
+// shared by all the sessions and processors loading the same module files.
+type Modules struct {
+	sources  map[string][]byte
+	registry *require.Registry
+}
+
+var (
+	cacheMu      sync.Mutex
+	programCache = map[[sha256.Size]byte]*goja.Program{}
+	modulesCache = map[[sha256.Size]byte]*Modules{}
+)
+
+// compile compiles the source code of a script, reusing the program of a
+// processor with the same sources.
+func compile(name string, code []byte) (*goja.Program, error) {
+	key := sha256.Sum256(append([]byte(name+"\x00"), code...))
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if prog, found := programCache[key]; found {
+		return prog, nil
+	}
+	prog, err := goja.Compile(name, string(code), true)
+	if err != nil {
+		return nil, err
+	}
+	if len(programCache) >= maxCachedPrograms {
+		programCache = map[[sha256.Size]byte]*goja.Program{}
+	}
+	programCache[key] = prog
+	return prog, nil
+}
+
+// loadModules reads the modules of the directories. A module is named after
+// its path relative to its directory, without the .js extension. When the
+// same module is found in several directories, the first one is used. All
+// the modules are compiled to report syntax errors when the processor is
+// created rather than when the module is required.
+func loadModules(dirs []string) (*Modules, error) {
+	sources := map[string][]byte{}
+	for _, dir := range dirs {
+		dir = paths.Resolve(paths.Config, dir)
+		err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || filepath.Ext(file) != ".js" {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, file)
+			if err != nil {
+				return err
+			}
+			name := strings.TrimSuffix(filepath.ToSlash(rel), ".js")
+			if _, found := sources[name]; found {
+				return nil
+			}
+			if common.IsStrictPerms() {
+				if err := common.OwnerHasExclusiveWritePerms(file); err != nil {
+					return err
+				}
+			}
+			code, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read module %v: %w", file, err)
+			}
+			if _, err := goja.Compile(file, moduleWrapper(code), false); err != nil {
+				return fmt.Errorf("failed to compile module %v: %w", file, err)
+			}
+			sources[name] = code
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the modules of %v: %w", dir, err)
+		}
+	}
+
+	key := modulesKey(sources)
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if m, found := modulesCache[key]; found {
+		return m, nil
+	}
+	m := &Modules{sources: sources}
+	m.registry = require.NewRegistryWithLoader(m.load)
+	if len(modulesCache) >= maxCachedPrograms {
+		modulesCache = map[[sha256.Size]byte]*Modules{}
+	}
+	modulesCache[key] = m
+	return m, nil
+}
+
+// moduleWrapper wraps the code of a module the way require() does before
+// compiling it.
+func moduleWrapper(code []byte) string {
+	return "(function(module, exports) {" + string(code) + "\n})"
+}
+
+func modulesKey(sources map[string][]byte) [sha256.Size]byte {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(sources[name]))
+		h.Write(sources[name])
+	}
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// Enable makes the built-in modules and the helper modules available to the
+// runtime through require().
+func (m *Modules) Enable(vm *goja.Runtime) {
+	m.registry.Enable(vm)
+}
+
+// load returns the source of a helper module. Module names are relative to
+// the module paths, with or without the leading ./ and the .js extension.
+func (m *Modules) load(name string) ([]byte, error) {
+	name = path.Clean(filepath.ToSlash(name))
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return nil, fmt.Errorf("cannot load %v, modules must be relative to the module paths", name)
+	}
+	if code, found := m.sources[strings.TrimSuffix(name, ".js")]; found {
+		return code, nil
+	}
+	return nil, fmt.Errorf("cannot load %v, it is neither a built-in module nor a module of the module paths", name)
+}
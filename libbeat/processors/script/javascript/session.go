@@ -48,6 +48,10 @@ type Session interface {
 
 	// Event returns a pointer to the current event being processed.
 	Event() Event
+
+	// Modules returns the helper modules the script can require, or nil
+	// when no module paths are configured.
+	Modules() *Modules
 }
 
 // Event is the event being processed by the processor.
@@ -80,9 +84,10 @@ type session struct {
 	processFunc    goja.Callable
 	timeout        time.Duration
 	tagOnException string
+	modules        *Modules
 }
 
-func newSession(p *goja.Program, conf Config, test bool) (*session, error) {
+func newSession(p *goja.Program, modules *Modules, conf Config, test bool) (*session, error) {
 	// Create a logger
 	logger := logp.NewLogger(logName)
 	if conf.Tag != "" {
@@ -101,6 +106,7 @@ func newSession(p *goja.Program, conf Config, test bool) (*session, error) {
 		makeEvent:      newBeatEventV0,
 		timeout:        conf.Timeout,
 		tagOnException: conf.TagOnException,
+		modules:        modules,
 	}
 
 	// Register modules.
@@ -260,6 +266,11 @@ func (s *session) Event() Event {
 	return s.evt
 }
 
+// Modules returns the helper modules the script can require.
+func (s *session) Modules() *Modules {
+	return s.modules
+}
+
 func init() {
 	// Register mapstr.M as being a simple map[string]interface{} for
 	// treatment within the JS VM.
@@ -277,15 +288,15 @@ type sessionPool struct {
 	C   chan *session
 }
 
-func newSessionPool(p *goja.Program, c Config) (*sessionPool, error) {
-	s, err := newSession(p, c, true)
+func newSessionPool(p *goja.Program, modules *Modules, c Config) (*sessionPool, error) {
+	s, err := newSession(p, modules, c, true)
 	if err != nil {
 		return nil, err
 	}
 
 	pool := sessionPool{
 		New: func() *session {
-			s, _ := newSession(p, c, false)
+			s, _ := newSession(p, modules, c, false)
 			return s
 		},
 		C: make(chan *session, c.MaxCachedSessions),
@@ -54,6 +54,8 @@ type config struct {
 	IgnoreMissing bool              `config:"ignore_missing"`
 	IgnoreFailure bool              `config:"ignore_failure"`
 	Tag           string            `config:"tag"`
+
+	StructuredData syslog.StructuredDataConfig `config:"structured_data"`
 }
 
 // processor defines a syslog processor.
@@ -89,6 +91,7 @@ func init() {
 				"ignore_missing",
 				"ignore_failure",
 				"tag",
+				"structured_data",
 				"when",
 			),
 		),
@@ -170,7 +173,7 @@ func (p *processor) run(event *beat.Event) error {
 		return fmt.Errorf("type of field %q is not a string", p.Field)
 	}
 
-	fields, ts, err := syslog.ParseMessage(data, p.Format, p.TimeZone.Location())
+	fields, ts, err := syslog.ParseMessage(data, p.Format, p.TimeZone.Location(), p.StructuredData)
 	if err != nil {
 		p.stats.Failure.Inc()
 	} else {
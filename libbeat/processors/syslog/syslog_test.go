@@ -129,6 +129,43 @@ var syslogCases = map[string]struct {
 		},
 		wantTime: mustParseTime(time.RFC3339Nano, "2003-10-11T22:14:15.003Z", nil),
 	},
+	"rfc-5424-structured-data-include-ids": {
+		cfg: conf.MustNewConfigFrom(mapstr.M{
+			"structured_data.include_ids": []string{"exampleSDID@32473"},
+		}),
+		in: mapstr.M{
+			"message": `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1024 ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"][examplePriority@32473 class="high"] this is the message`,
+		},
+		want: mapstr.M{
+			"log": mapstr.M{
+				"syslog": mapstr.M{
+					"priority": 165,
+					"facility": mapstr.M{
+						"code": 20,
+						"name": "local4",
+					},
+					"severity": mapstr.M{
+						"code": 5,
+						"name": "Notice",
+					},
+					"hostname": "mymachine.example.com",
+					"appname":  "evntslog",
+					"procid":   "1024",
+					"msgid":    "ID47",
+					"version":  "1",
+					"structured_data": map[string]interface{}{
+						"exampleSDID@32473": map[string]interface{}{
+							"eventID":     "1011",
+							"eventSource": "Application",
+							"iut":         "3",
+						},
+					},
+				},
+			},
+			"message": "this is the message",
+		},
+		wantTime: mustParseTime(time.RFC3339Nano, "2003-10-11T22:14:15.003Z", nil),
+	},
 }
 
 func TestSyslog(t *testing.T) {
@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trace_context
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	procName = "trace_context"
+	logName  = "processor." + procName
+
+	traceIDField = "trace.id"
+	spanIDField  = "span.id"
+
+	// maxTracestateLen is the length of the tracestate values kept, above
+	// which the W3C Trace Context recommends discarding the value.
+	maxTracestateLen = 512
+)
+
+// traceparentPattern matches a W3C traceparent: version, trace ID, parent
+// span ID and trace flags.
+var traceparentPattern = regexp.MustCompile(`\b([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})\b`)
+
+func init() {
+	processors.RegisterPlugin(procName, New)
+	jsprocessor.RegisterPlugin("TraceContext", New)
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+}
+
+// New constructs a new trace_context processor.
+func New(cfg *conf.C) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v processor configuration: %w", procName, err)
+	}
+
+	return newTraceContext(c)
+}
+
+func newTraceContext(c config) (*processor, error) {
+	if len(c.Fields) == 0 && !c.Synthesize {
+		return nil, errors.New("at least one field must be set unless synthesize is enabled")
+	}
+
+	log := logp.NewLogger(logName)
+	if c.ID != "" {
+		log = log.With("instance_id", c.ID)
+	}
+
+	return &processor{config: c, log: log}, nil
+}
+
+func (p *processor) String() string {
+	json, _ := json.Marshal(p.config)
+	return procName + "=" + string(json)
+}
+
+// Run sets the trace.id and span.id fields of the event from the first
+// traceparent found in the fields, or from new random IDs if synthesize is
+// enabled.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if !p.Overwrite {
+		if v, err := event.GetValue(traceIDField); err == nil && v != nil {
+			return event, nil
+		}
+	}
+
+	traceID, spanID, found := p.traceparent(event)
+	if !found {
+		if !p.Synthesize {
+			return event, nil
+		}
+		var err error
+		if traceID, err = randomID(16); err == nil {
+			spanID, err = randomID(8)
+		}
+		if err != nil {
+			if p.IgnoreFailure {
+				return event, nil
+			}
+			return event, fmt.Errorf("failed to generate trace context IDs: %w", err)
+		}
+	}
+
+	if _, err := event.PutValue(traceIDField, traceID); err != nil {
+		return p.failure(event, fmt.Errorf("failed to write %v: %w", traceIDField, err))
+	}
+	if _, err := event.PutValue(spanIDField, spanID); err != nil {
+		return p.failure(event, fmt.Errorf("failed to write %v: %w", spanIDField, err))
+	}
+
+	// The tracestate is only meaningful with the traceparent it came with.
+	if found && p.TracestateTarget != "" {
+		if tracestate := p.tracestate(event); tracestate != "" {
+			if _, err := event.PutValue(p.TracestateTarget, tracestate); err != nil {
+				return p.failure(event, fmt.Errorf("failed to write tracestate to %v: %w", p.TracestateTarget, err))
+			}
+		}
+	}
+
+	return event, nil
+}
+
+func (p *processor) failure(event *beat.Event, err error) (*beat.Event, error) {
+	if p.IgnoreFailure {
+		return event, nil
+	}
+	return event, err
+}
+
+// traceparent returns the IDs of the first valid traceparent found in the
+// fields.
+func (p *processor) traceparent(event *beat.Event) (traceID, spanID string, found bool) {
+	for _, field := range p.Fields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			continue
+		}
+		for _, s := range stringValues(v) {
+			for _, m := range traceparentPattern.FindAllStringSubmatch(s, -1) {
+				if validTraceparent(m[1], m[2], m[3]) {
+					return m[2], m[3], true
+				}
+			}
+		}
+	}
+	return "", "", false
+}
+
+// tracestate returns the tracestate held by the first set tracestate field.
+// Values of multiple headers are combined as a single list.
+func (p *processor) tracestate(event *beat.Event) string {
+	for _, field := range p.TracestateFields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			continue
+		}
+		var members []string
+		for _, s := range stringValues(v) {
+			if s = strings.TrimSpace(s); s != "" {
+				members = append(members, s)
+			}
+		}
+		tracestate := strings.Join(members, ",")
+		if tracestate == "" || len(tracestate) > maxTracestateLen {
+			continue
+		}
+		return tracestate
+	}
+	return ""
+}
+
+// validTraceparent returns whether a traceparent can be used: version ff and
+// all zero IDs are invalid.
+func validTraceparent(version, traceID, spanID string) bool {
+	return version != "ff" &&
+		strings.Trim(traceID, "0") != "" &&
+		strings.Trim(spanID, "0") != ""
+}
+
+// stringValues returns the strings held by a field value, which can be a
+// list as HTTP headers are.
+func stringValues(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// randomID returns a random non-zero ID of n bytes, hex encoded.
+func randomID(n int) (string, error) {
+	b := make([]byte, n)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		for _, c := range b {
+			if c != 0 {
+				return hex.EncodeToString(b), nil
+			}
+		}
+	}
+}
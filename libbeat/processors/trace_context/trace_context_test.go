@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trace_context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestTraceContextFromMessage(t *testing.T) {
+	p, err := newTraceContext(defaultConfig())
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		message string
+		found   bool
+	}{
+		"traceparent in message": {
+			message: "GET /api traceparent=00-" + testTraceID + "-" + testSpanID + "-01 200",
+			found:   true,
+		},
+		"invalid version is skipped": {
+			message: "ff-" + testTraceID + "-" + testSpanID + "-01 00-" + testTraceID + "-" + testSpanID + "-00",
+			found:   true,
+		},
+		"zero trace ID": {
+			message: "00-00000000000000000000000000000000-" + testSpanID + "-01",
+		},
+		"uppercase": {
+			message: "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + testSpanID + "-01",
+		},
+		"no traceparent": {
+			message: "hello world",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			event, err := p.Run(&beat.Event{Fields: mapstr.M{"message": tc.message}})
+			require.NoError(t, err)
+
+			traceID, _ := event.GetValue("trace.id")
+			spanID, _ := event.GetValue("span.id")
+			if !tc.found {
+				assert.Nil(t, traceID)
+				assert.Nil(t, spanID)
+				return
+			}
+			assert.Equal(t, testTraceID, traceID)
+			assert.Equal(t, testSpanID, spanID)
+		})
+	}
+}
+
+func TestTraceContextFromHeaders(t *testing.T) {
+	c := defaultConfig()
+	c.Fields = []string{"headers.Traceparent"}
+	c.TracestateFields = []string{"headers.Tracestate"}
+	p, err := newTraceContext(c)
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{
+		Fields: mapstr.M{
+			"headers": mapstr.M{
+				"Traceparent": []string{"00-" + testTraceID + "-" + testSpanID + "-01"},
+				"Tracestate":  []string{"congo=t61rcWkgMzE", " rojo=00f067aa0ba902b7"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	traceID, _ := event.GetValue("trace.id")
+	assert.Equal(t, testTraceID, traceID)
+	spanID, _ := event.GetValue("span.id")
+	assert.Equal(t, testSpanID, spanID)
+	tracestate, _ := event.GetValue("@metadata.tracestate")
+	assert.Equal(t, "congo=t61rcWkgMzE,rojo=00f067aa0ba902b7", tracestate)
+}
+
+func TestTraceContextOverwrite(t *testing.T) {
+	message := "00-" + testTraceID + "-" + testSpanID + "-01"
+	newEvent := func() *beat.Event {
+		return &beat.Event{Fields: mapstr.M{
+			"message": message,
+			"trace":   mapstr.M{"id": "existing"},
+		}}
+	}
+
+	p, err := newTraceContext(defaultConfig())
+	require.NoError(t, err)
+	event, err := p.Run(newEvent())
+	require.NoError(t, err)
+	traceID, _ := event.GetValue("trace.id")
+	assert.Equal(t, "existing", traceID)
+
+	c := defaultConfig()
+	c.Overwrite = true
+	p, err = newTraceContext(c)
+	require.NoError(t, err)
+	event, err = p.Run(newEvent())
+	require.NoError(t, err)
+	traceID, _ = event.GetValue("trace.id")
+	assert.Equal(t, testTraceID, traceID)
+}
+
+func TestTraceContextSynthesize(t *testing.T) {
+	c := defaultConfig()
+	c.Synthesize = true
+	c.TracestateFields = []string{"tracestate"}
+	p, err := newTraceContext(c)
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "hello", "tracestate": "congo=t61rcWkgMzE"}})
+	require.NoError(t, err)
+
+	traceID, _ := event.GetValue("trace.id")
+	assert.Regexp(t, "^[0-9a-f]{32}$", traceID)
+	spanID, _ := event.GetValue("span.id")
+	assert.Regexp(t, "^[0-9a-f]{16}$", spanID)
+	_, err = event.GetValue("@metadata.tracestate")
+	assert.Error(t, err, "the tracestate of another trace must not be kept")
+}
+
+func TestTraceContextConfig(t *testing.T) {
+	c := defaultConfig()
+	c.Fields = nil
+	_, err := newTraceContext(c)
+	assert.Error(t, err)
+}
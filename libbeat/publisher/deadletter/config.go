@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deadletter
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+)
+
+// Config holds the settings of the dead-letter file, configured via
+// `pipeline.dead_letter`.
+type Config struct {
+	Enabled bool `config:"enabled"`
+
+	// Path is the directory the dead-letter files are written to. Defaults
+	// to `deadletter` in the data path.
+	Path string `config:"path"`
+
+	// MaxSize is the size after which the file is rotated.
+	MaxSize cfgtype.ByteSize `config:"max_size"`
+
+	// MaxFiles is the number of files kept, older files are deleted when
+	// a rotation would exceed it.
+	MaxFiles int `config:"max_files"`
+}
+
+// DefaultConfig returns the default dead-letter settings.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:  false,
+		MaxSize:  100 * 1024 * 1024,
+		MaxFiles: 5,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.MaxSize < 1024 {
+		return errors.New("max_size must be at least 1KiB")
+	}
+	if c.MaxFiles < 1 {
+		return errors.New("max_files must be at least 1")
+	}
+	return nil
+}
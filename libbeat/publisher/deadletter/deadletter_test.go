@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deadletter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type encodedEvent struct {
+	raw []byte
+}
+
+func (e *encodedEvent) DeadLetterEncoding() []byte { return e.raw }
+
+func TestWriteAndRead(t *testing.T) {
+	config := testConfig(t)
+	w, err := Open(config, beat.Info{Beat: "testbeat", Version: "9.0.0"}, logp.NewLogger("deadletter"))
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	w.Write([]publisher.Event{{
+		Content: beat.Event{
+			Timestamp: ts,
+			Meta:      mapstr.M{"index": "logs-test-default"},
+			Fields:    mapstr.M{"message": "first", "count": 42},
+		},
+	}}, "retry limit reached")
+	w.Write([]publisher.Event{
+		{EncodedEvent: &encodedEvent{raw: []byte(`{"@timestamp":"2024-03-01T12:00:01Z","message":"second"}`)}},
+		// The content of this one is lost and it must be skipped.
+		{EncodedEvent: struct{}{}},
+	}, "mapping error")
+	require.NoError(t, w.Close())
+
+	// Writes after Close are discarded.
+	w.Write([]publisher.Event{{Content: beat.Event{Fields: mapstr.M{"message": "late"}}}}, "late")
+
+	files, err := Files(config.Path)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	var records []Record
+	err = Read(files[0], func(rec Record) error {
+		records = append(records, rec)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "retry limit reached", records[0].Reason)
+	assert.False(t, records[0].Timestamp.IsZero())
+	assert.Equal(t, ts, records[0].Event.Timestamp.UTC())
+	assert.Equal(t, mapstr.M{"index": "logs-test-default"}, records[0].Event.Meta)
+	assert.Equal(t, mapstr.M{"message": "first", "count": int64(42)}, records[0].Event.Fields)
+
+	assert.Equal(t, "mapping error", records[1].Reason)
+	assert.Equal(t, ts.Add(time.Second), records[1].Event.Timestamp)
+	assert.Nil(t, records[1].Event.Meta)
+	assert.Equal(t, mapstr.M{"message": "second"}, records[1].Event.Fields)
+}
+
+func TestNilWriter(t *testing.T) {
+	var w *Writer
+	w.Write([]publisher.Event{{Content: beat.Event{Fields: mapstr.M{"message": "test"}}}}, "test")
+	assert.NoError(t, w.Close())
+}
+
+func testConfig(t *testing.T) Config {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Path = t.TempDir()
+	return config
+}
@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deadletter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Record is a dropped event read from a dead-letter file.
+type Record struct {
+	// Timestamp is the time the event was dropped.
+	Timestamp time.Time
+	Reason    string
+	Event     beat.Event
+}
+
+// Files returns the dead-letter files of dir, oldest first.
+func Files(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, fileName+"*.ndjson"))
+	if err != nil {
+		return nil, err
+	}
+	modTimes := make(map[string]time.Time, len(matches))
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[path] = info.ModTime()
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := modTimes[matches[i]], modTimes[matches[j]]
+		if a.Equal(b) {
+			return matches[i] < matches[j]
+		}
+		return a.Before(b)
+	})
+	return matches, nil
+}
+
+// Read calls fn with each record of the dead-letter file at path, in order.
+// It stops at the first error returned by fn.
+func Read(path string, fn func(Record) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for n := 1; ; n++ {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			rec, parseErr := parseRecord(line)
+			if parseErr != nil {
+				return fmt.Errorf("invalid record at line %d of %s: %w", n, path, parseErr)
+			}
+			if err := fn(rec); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseRecord decodes a line written by Writer. The @timestamp and
+// @metadata fields of the event are restored, except for the metadata
+// added by the JSON codec.
+func parseRecord(line []byte) (Record, error) {
+	var raw record
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return Record{}, err
+	}
+	if len(raw.Event) == 0 {
+		return Record{}, errors.New("no event")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw.Event))
+	dec.UseNumber()
+	var fields mapstr.M
+	if err := dec.Decode(&fields); err != nil {
+		return Record{}, fmt.Errorf("invalid event: %w", err)
+	}
+	jsontransform.TransformNumbers(fields)
+
+	event := beat.Event{Fields: fields}
+	if ts, ok := fields["@timestamp"].(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return Record{}, fmt.Errorf("invalid event @timestamp: %w", err)
+		}
+		event.Timestamp = t
+		delete(fields, "@timestamp")
+	}
+	if meta, ok := fields["@metadata"].(map[string]interface{}); ok {
+		delete(fields, "@metadata")
+		for _, k := range []string{"beat", "type", "version"} {
+			delete(meta, k)
+		}
+		if len(meta) > 0 {
+			event.Meta = mapstr.M(meta)
+		}
+	}
+	return Record{Timestamp: raw.Timestamp, Reason: raw.Reason, Event: event}, nil
+}
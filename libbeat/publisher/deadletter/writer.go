@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package deadletter captures the events the publisher pipeline or the
+// outputs give up on in local files, so they can be inspected and
+// replayed later.
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	jsoncodec "github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/file"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// fileName is the base name of the dead-letter files. The rotator adds the
+// date and a counter to it.
+const fileName = "deadletter"
+
+// Encoded is implemented by the early encoded events of the outputs that
+// can return the JSON document of the original event. Events encoded by
+// the queue have no content left to capture otherwise.
+type Encoded interface {
+	DeadLetterEncoding() []byte
+}
+
+// record is a line of a dead-letter file.
+type record struct {
+	Timestamp time.Time       `json:"@timestamp"`
+	Reason    string          `json:"reason"`
+	Event     json.RawMessage `json:"event"`
+}
+
+// Writer appends dropped events to a rotated file. All methods can be
+// called on a nil Writer, they discard the events.
+type Writer struct {
+	logger *logp.Logger
+	beat   string
+
+	mu      sync.Mutex
+	closed  bool
+	rotator *file.Rotator
+	codec   *jsoncodec.Encoder
+}
+
+// Open opens the dead-letter file configured by config, events are appended
+// to the files left by a previous run.
+func Open(config Config, info beat.Info, logger *logp.Logger) (*Writer, error) {
+	dir := config.Path
+	if dir == "" {
+		dir = paths.Resolve(paths.Data, "deadletter")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create dead-letter directory %s: %w", dir, err)
+	}
+
+	rotator, err := file.NewFileRotator(
+		filepath.Join(dir, fileName),
+		file.MaxSizeBytes(uint(config.MaxSize)),
+		file.MaxBackups(uint(config.MaxFiles)),
+		file.Permissions(0o600),
+		file.RotateOnStartup(false),
+		file.WithLogger(logger.Named("rotator").With(logp.Namespace("rotator"))),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dead-letter file in %s: %w", dir, err)
+	}
+
+	logger.Infof("Dropped events are written to the dead-letter files in %s.", dir)
+	return &Writer{
+		logger:  logger,
+		beat:    info.Beat,
+		rotator: rotator,
+		codec:   jsoncodec.New(info.Version, jsoncodec.Config{}),
+	}, nil
+}
+
+// Write records the events with the reason they were dropped. Events that
+// can't be encoded are logged and skipped.
+func (w *Writer) Write(events []publisher.Event, reason string) {
+	if w == nil || len(events) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+
+	now := time.Now().UTC()
+	for i := range events {
+		raw, err := w.encode(&events[i])
+		if err != nil {
+			w.logger.Warnf("Failed to encode dropped event for the dead-letter file: %v", err)
+			continue
+		}
+		line, err := json.Marshal(record{Timestamp: now, Reason: reason, Event: raw})
+		if err != nil {
+			w.logger.Warnf("Failed to encode dropped event for the dead-letter file: %v", err)
+			continue
+		}
+		if _, err := w.rotator.Write(append(line, '\n')); err != nil {
+			w.logger.Errorf("Failed to write %d dropped events to the dead-letter file: %v", len(events)-i, err)
+			return
+		}
+	}
+	w.logger.Debugf("%d dropped events written to the dead-letter file: %s", len(events), reason)
+}
+
+func (w *Writer) encode(event *publisher.Event) ([]byte, error) {
+	if enc, ok := event.EncodedEvent.(Encoded); ok {
+		if raw := enc.DeadLetterEncoding(); raw != nil {
+			return raw, nil
+		}
+	}
+	if event.EncodedEvent != nil && event.Content.Fields == nil {
+		return nil, errors.New("the content of the event was released by the output")
+	}
+	return w.codec.Encode(w.beat, &event.Content)
+}
+
+// Close flushes and closes the dead-letter file. Events written after
+// Close are discarded.
+func (w *Writer) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.rotator.Close()
+}
@@ -19,6 +19,7 @@ package publisher
 
 import (
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
@@ -53,6 +54,25 @@ type Batch interface {
 	Cancelled()
 }
 
+// DeadLetterBatch is implemented by the batches that can capture the
+// events an output gives up on, when the dead-letter file is enabled.
+type DeadLetterBatch interface {
+	Batch
+
+	// DeadLetter records events that are dropped permanently, with the
+	// reason they were dropped. The events must still be acknowledged or
+	// dropped as part of the batch.
+	DeadLetter(events []Event, reason string)
+}
+
+// DeadLetter records events of the batch dropped permanently by the output,
+// if the batch supports it.
+func DeadLetter(batch Batch, events []Event, reason string) {
+	if b, ok := batch.(DeadLetterBatch); ok && len(events) > 0 {
+		b.DeadLetter(events, reason)
+	}
+}
+
 // Event is used by the publisher pipeline and broker to pass additional
 // meta-data to the consumers/outputs.
 type Event struct {
@@ -109,3 +129,16 @@ const (
 func (e *Event) Guaranteed() bool {
 	return (e.Flags & GuaranteedSend) == GuaranteedSend
 }
+
+// PriorityMetaKey is the metadata field holding the priority of an event in
+// the queue. Events with the value "high" are handed to the outputs before
+// the normal events waiting in the queue.
+const PriorityMetaKey = "priority"
+
+// Priority returns the queue priority of the event.
+func (e Event) Priority() queue.Priority {
+	if v, _ := e.Content.Meta[PriorityMetaKey].(string); v == "high" {
+		return queue.PriorityHigh
+	}
+	return queue.PriorityNormal
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/libbeat/publisher"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/beats/v7/libbeat/publisher/wal"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -47,6 +48,11 @@ type client struct {
 	observer       observer
 	eventListener  beat.EventListener
 	clientListener beat.ClientListener
+
+	// wal is set if events are written to the write-ahead log. walSeqs
+	// holds the sequence numbers of the events in the queue.
+	wal     *wal.Log
+	walSeqs *walSeqs
 }
 
 type clientCloseWaiter struct {
@@ -116,16 +122,36 @@ func (c *client) publish(e beat.Event) {
 		Flags:   c.eventFlags,
 	}
 
+	var seq uint64
+	if c.wal != nil {
+		var err error
+		seq, err = c.wal.Append(pubEvent)
+		if err != nil {
+			c.logger.Errorf("Failed to write event to the write-ahead log, it will not be replayed after a restart: %v", err)
+		}
+		c.walSeqs.push(seq)
+	}
+
 	var published bool
+	start := time.Now()
 	if c.canDrop {
 		_, published = c.producer.TryPublish(pubEvent)
 	} else {
 		_, published = c.producer.Publish(pubEvent)
 	}
+	c.observer.enqueueWait(time.Since(start))
 
 	if published {
 		c.onPublished()
+		if c.wal != nil {
+			// The event is persisted, it is acknowledged right away.
+			c.eventListener.ACKEvents(1)
+		}
 	} else {
+		if c.wal != nil {
+			c.walSeqs.dropLast()
+			c.wal.ACK(seq)
+		}
 		c.onDroppedOnPublish(e)
 	}
 }
@@ -20,6 +20,7 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/elastic/beats/v7/libbeat/publisher"
 
@@ -31,6 +32,12 @@ import (
 type worker struct {
 	qu   chan publisher.Batch
 	done chan struct{}
+
+	// gate pauses the worker when it is deactivated by the worker scaler.
+	// It is nil when the workers are not scaled.
+	gate *workerGate
+	// load accumulates the time spent publishing, for the worker scaler.
+	load *workerLoad
 }
 
 // clientWorker manages output client of type outputs.Client, not supporting reconnect.
@@ -49,10 +56,12 @@ type netClientWorker struct {
 	tracer *apm.Tracer
 }
 
-func makeClientWorker(qu chan publisher.Batch, client outputs.Client, logger logger, tracer *apm.Tracer) outputWorker {
+func makeClientWorker(qu chan publisher.Batch, client outputs.Client, logger logger, tracer *apm.Tracer, gate *workerGate, load *workerLoad) outputWorker {
 	w := worker{
 		qu:   qu,
 		done: make(chan struct{}),
+		gate: gate,
+		load: load,
 	}
 
 	var c interface {
@@ -79,25 +88,57 @@ func (w *worker) close() {
 	close(w.done)
 }
 
+// queue returns the channel to read batches from, which is nil while the
+// worker is paused, and a channel closed when the worker is activated or
+// paused.
+func (w *worker) queue() (chan publisher.Batch, <-chan struct{}) {
+	if w.gate == nil {
+		return w.qu, nil
+	}
+	active, changed := w.gate.state()
+	if !active {
+		return nil, changed
+	}
+	return w.qu, changed
+}
+
+// publish publishes the batch with fn, recording the time it took.
+func (w *worker) publish(batch publisher.Batch, fn func(publisher.Batch) error) error {
+	if w.load == nil {
+		return fn(batch)
+	}
+	start := time.Now()
+	err := fn(batch)
+	w.load.published(time.Since(start))
+	return err
+}
+
 func (w *clientWorker) Close() error {
 	w.worker.close()
 	return w.client.Close()
 }
 
+func (w *clientWorker) publishBatch(batch publisher.Batch) error {
+	return w.client.Publish(context.TODO(), batch)
+}
+
 func (w *clientWorker) run() {
 	for {
 		// We wait for either the worker to be closed or for there to be a batch of
 		// events to publish.
+		qu, changed := w.queue()
 		select {
 
 		case <-w.done:
 			return
 
-		case batch := <-w.qu:
+		case <-changed:
+
+		case batch := <-qu:
 			if batch == nil {
 				continue
 			}
-			if err := w.client.Publish(context.TODO(), batch); err != nil {
+			if err := w.publish(batch, w.publishBatch); err != nil {
 				return
 			}
 		}
@@ -118,12 +159,15 @@ func (w *netClientWorker) run() {
 	for {
 		// We wait for either the worker to be closed or for there to be a batch of
 		// events to publish.
+		qu, changed := w.queue()
 		select {
 
 		case <-w.done:
 			return
 
-		case batch := <-w.qu:
+		case <-changed:
+
+		case batch := <-qu:
 			if batch == nil {
 				continue
 			}
@@ -152,7 +196,7 @@ func (w *netClientWorker) run() {
 				continue
 			}
 
-			if err := w.publishBatch(batch); err != nil {
+			if err := w.publish(batch, w.publishBatch); err != nil {
 				connected = false
 			}
 		}
@@ -35,6 +35,12 @@ type Config struct {
 
 	// Event queue
 	Queue config.Namespace `config:"queue"`
+
+	// Write-ahead log
+	WAL *config.C `config:"pipeline.wal"`
+
+	// Dead-letter file of the dropped events
+	DeadLetter *config.C `config:"pipeline.dead_letter"`
 }
 
 // validateClientConfig checks a ClientConfig can be used with (*Pipeline).ConnectWith.
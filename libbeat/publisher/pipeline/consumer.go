@@ -19,8 +19,10 @@ package pipeline
 
 import (
 	"sync"
+	"time"
 
 	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -31,8 +33,18 @@ import (
 type eventConsumer struct {
 	logger *logp.Logger
 
-	// eventConsumer calls the retryObserver methods eventsRetry and eventsDropped.
-	retryObserver retryObserver
+	// eventConsumer calls the retryObserver methods eventsRetry and
+	// eventsDropped, and passes the observer to the batches it reads to
+	// report their latency.
+	observer consumerObserver
+
+	// deadLetter is passed to the batches, to write the events they drop
+	// to the dead-letter file. It is nil if the file is disabled.
+	deadLetter *deadletter.Writer
+
+	// load records the time batches wait to be sent to a free output
+	// worker, for the worker scaler. It can be nil.
+	load *workerLoad
 
 	// When the output changes, the new target is sent to the worker routine
 	// on this channel. Clients should call eventConsumer.setTarget().
@@ -73,12 +85,16 @@ type retryRequest struct {
 
 func newEventConsumer(
 	log *logp.Logger,
-	observer retryObserver,
+	observer consumerObserver,
+	deadLetter *deadletter.Writer,
+	load *workerLoad,
 ) *eventConsumer {
 	c := &eventConsumer{
-		logger:        log,
-		retryObserver: observer,
-		queueReader:   makeQueueReader(),
+		logger:      log,
+		observer:    observer,
+		deadLetter:  deadLetter,
+		load:        load,
+		queueReader: makeQueueReader(),
 
 		targetChan: make(chan consumerTarget),
 		retryChan:  make(chan retryRequest),
@@ -122,6 +138,9 @@ func (c *eventConsumer) run() {
 		// The output channel (and associated parameters) that will receive
 		// the batches we're loading.
 		target consumerTarget
+
+		// When the active batch started waiting for a free output worker.
+		waitingSince time.Time
 	)
 
 outerLoop:
@@ -136,6 +155,8 @@ outerLoop:
 				retryer:    c,
 				batchSize:  target.batchSize,
 				timeToLive: target.timeToLive,
+				deadLetter: c.deadLetter,
+				observer:   c.observer,
 			}
 		}
 
@@ -155,15 +176,22 @@ outerLoop:
 		var outputChan chan publisher.Batch
 		if active != nil {
 			outputChan = target.ch
+			if c.load != nil && outputChan != nil && waitingSince.IsZero() {
+				waitingSince = time.Now()
+			}
 		}
 
 		// Now we can block until the next state change.
 		select {
 		case outputChan <- active:
 			// Successfully sent a batch to the output workers
+			if !waitingSince.IsZero() {
+				c.load.waited(time.Since(waitingSince))
+				waitingSince = time.Time{}
+			}
 			if len(retryBatches) > 0 {
 				// This was a retry, report it to the observer
-				c.retryObserver.eventsRetry(len(active.Events()))
+				c.observer.eventsRetry(len(active.Events()))
 				retryBatches = retryBatches[1:]
 			} else {
 				// This was directly from the queue, clear the value so we can
@@ -183,7 +211,7 @@ outerLoop:
 				alive := req.batch.reduceTTL()
 
 				countDropped := countFailed - len(req.batch.Events())
-				c.retryObserver.eventsDropped(countDropped)
+				c.observer.eventsDropped(countDropped)
 
 				if !alive {
 					log.Info("Drop batch")
@@ -214,8 +242,9 @@ func (c *eventConsumer) retry(batch *ttlBatch, decreaseTTL bool) {
 	case c.retryChan <- retryRequest{batch: batch, decreaseTTL: decreaseTTL}:
 		// The batch is back in eventConsumer's retry queue
 	case <-c.done:
-		// The consumer has already shut down, drop the batch
-		batch.Drop()
+		// The consumer has already shut down, drop the batch. Its events are
+		// not dead-lettered, as they were not rejected by the output.
+		batch.drop()
 	}
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue/memqueue"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -63,6 +64,12 @@ type outputController struct {
 	workers    []outputWorker
 	workerChan chan publisher.Batch
 
+	// scaler activates the workers as needed when the output enables
+	// worker_autoscale, nil otherwise. load is shared by the consumer and
+	// the workers to measure how busy the workers are.
+	scaler *workerScaler
+	load   *workerLoad
+
 	// The InputQueueSize can be set when the Beat is started, in
 	// libbeat/cmd/instance/Settings we need to preserve that
 	// value and pass it into the queue factory.  The queue
@@ -86,16 +93,19 @@ type outputWorker interface {
 func newOutputController(
 	beat beat.Info,
 	monitors Monitors,
-	retryObserver retryObserver,
+	observer consumerObserver,
 	queueFactory queue.QueueFactory,
 	inputQueueSize int,
+	deadLetter *deadletter.Writer,
 ) (*outputController, error) {
+	load := &workerLoad{}
 	controller := &outputController{
 		beat:           beat,
 		monitors:       monitors,
 		queueFactory:   queueFactory,
 		workerChan:     make(chan publisher.Batch),
-		consumer:       newEventConsumer(monitors.Logger, retryObserver),
+		consumer:       newEventConsumer(monitors.Logger, observer, deadLetter, load),
+		load:           load,
 		inputQueueSize: inputQueueSize,
 	}
 
@@ -113,6 +123,10 @@ func (c *outputController) WaitClose(timeout time.Duration) error {
 	c.consumer.close()
 	close(c.workerChan)
 
+	if c.scaler != nil {
+		c.scaler.stop()
+	}
+
 	// Signal the output workers to close. This step is a hint, and carries
 	// no guarantees. For example, on close the Elasticsearch output workers
 	// will close idle connections, but will not change any behavior for
@@ -131,18 +145,45 @@ func (c *outputController) Set(outGrp outputs.Group) {
 	// Set consumer to empty target to pause it while we reload
 	c.consumer.setTarget(consumerTarget{})
 
-	// Close old outputWorkers, so they send their remaining events
-	// back to eventConsumer's retry channel
+	// Stop scaling the old outputWorkers, and close them, so they send
+	// their remaining events back to eventConsumer's retry channel
+	if c.scaler != nil {
+		c.scaler.stop()
+		c.scaler = nil
+	}
 	for _, w := range c.workers {
 		w.Close()
 	}
 
 	// create new output group with the shared work queue
 	clients := outGrp.Clients
+	var (
+		gates []*workerGate
+		load  *workerLoad
+	)
+	if outGrp.WorkerAutoscale != nil && len(clients) > 0 && c.load != nil {
+		gates = make([]*workerGate, len(clients))
+		for i := range gates {
+			gates[i] = newWorkerGate(false)
+		}
+		load = c.load
+	}
 	c.workers = make([]outputWorker, len(clients))
 	for i, client := range clients {
 		logger := logp.NewLogger("publisher_pipeline_output")
-		c.workers[i] = makeClientWorker(c.workerChan, client, logger, c.monitors.Tracer)
+		var gate *workerGate
+		if gates != nil {
+			gate = gates[i]
+		}
+		c.workers[i] = makeClientWorker(c.workerChan, client, logger, c.monitors.Tracer, gate, load)
+	}
+	if gates != nil {
+		var reg *monitoring.Registry
+		if c.monitors.Metrics != nil {
+			reg = c.monitors.Metrics.GetRegistry("output")
+		}
+		c.scaler = newWorkerScaler(*outGrp.WorkerAutoscale, gates, load, reg, logp.NewLogger("publisher_pipeline_output"))
+		c.scaler.run()
 	}
 
 	targetChan := c.workerChan
@@ -150,8 +150,8 @@ func TestQueueCreatedOnlyAfterOutputExists(t *testing.T) {
 			// We aren't testing the values sent to eventConsumer, we
 			// just need a placeholder here so outputController can
 			// send configuration updates without blocking.
-			targetChan:    make(chan consumerTarget, 4),
-			retryObserver: nilObserver,
+			targetChan: make(chan consumerTarget, 4),
+			observer:   nilObserver,
 		},
 	}
 	// Set to an empty output group. This should not create a queue.
@@ -173,8 +173,8 @@ func TestOutputQueueFactoryTakesPrecedence(t *testing.T) {
 			memqueue.Settings{Events: 1},
 		),
 		consumer: &eventConsumer{
-			targetChan:    make(chan consumerTarget, 4),
-			retryObserver: nilObserver,
+			targetChan: make(chan consumerTarget, 4),
+			observer:   nilObserver,
 		},
 	}
 	controller.Set(outputs.Group{
@@ -195,8 +195,8 @@ func TestFailedQueueFactoryRevertsToDefault(t *testing.T) {
 	controller := outputController{
 		queueFactory: failedFactory,
 		consumer: &eventConsumer{
-			targetChan:    make(chan consumerTarget, 4),
-			retryObserver: nilObserver,
+			targetChan: make(chan consumerTarget, 4),
+			observer:   nilObserver,
 		},
 		monitors: Monitors{
 			Logger: logp.NewLogger("tests"),
@@ -213,8 +213,8 @@ func TestQueueProducerBlocksUntilOutputIsSet(t *testing.T) {
 	controller := outputController{
 		queueFactory: memqueue.FactoryForSettings(memqueue.Settings{Events: 1}),
 		consumer: &eventConsumer{
-			targetChan:    make(chan consumerTarget, 4),
-			retryObserver: nilObserver,
+			targetChan: make(chan consumerTarget, 4),
+			observer:   nilObserver,
 		},
 	}
 	// Send producer requests from different goroutines. They should all
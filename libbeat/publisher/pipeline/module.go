@@ -19,12 +19,15 @@ package pipeline
 
 import (
 	"flag"
+	"fmt"
 
 	"go.elastic.co/apm/v2"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
+	"github.com/elastic/beats/v7/libbeat/publisher/wal"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 )
@@ -97,8 +100,41 @@ func LoadWithSettings(
 		return nil, err
 	}
 
+	if config.WAL != nil {
+		walConfig := wal.DefaultConfig()
+		if err := config.WAL.Unpack(&walConfig); err != nil {
+			return nil, fmt.Errorf("invalid pipeline.wal settings: %w", err)
+		}
+		if walConfig.Enabled {
+			settings.WAL, err = wal.Open(walConfig, log.Named("wal"))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if config.DeadLetter != nil {
+		deadLetterConfig := deadletter.DefaultConfig()
+		if err := config.DeadLetter.Unpack(&deadLetterConfig); err != nil {
+			return nil, fmt.Errorf("invalid pipeline.dead_letter settings: %w", err)
+		}
+		if deadLetterConfig.Enabled {
+			settings.DeadLetter, err = deadletter.Open(deadLetterConfig, beatInfo, log.Named("deadletter"))
+			if err != nil {
+				if settings.WAL != nil {
+					_ = settings.WAL.Close()
+				}
+				return nil, err
+			}
+		}
+	}
+
 	p, err := New(beatInfo, monitors, config.Queue, out, settings)
 	if err != nil {
+		if settings.WAL != nil {
+			_ = settings.WAL.Close()
+		}
+		_ = settings.DeadLetter.Close()
 		return nil, err
 	}
 
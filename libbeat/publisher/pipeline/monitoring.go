@@ -18,17 +18,33 @@
 package pipeline
 
 import (
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+
 	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
 )
 
+// latencySampleSize is the number of samples kept by the latency
+// histograms.
+const latencySampleSize = 1024
+
 type observer interface {
 	pipelineObserver
 	clientObserver
 	retryObserver
+	batchObserver
 
 	cleanup()
 }
 
+// consumerObserver is the observer of the eventConsumer.
+type consumerObserver interface {
+	retryObserver
+	batchObserver
+}
+
 type pipelineObserver interface {
 	// A new client connected to the pipeline via (*Pipeline).ConnectWith.
 	clientConnected()
@@ -46,6 +62,8 @@ type clientObserver interface {
 	// An event was rejected by the queue
 	failedPublishEvent()
 	eventsACKed(count int)
+	// The client waited for the queue to accept an event
+	enqueueWait(time.Duration)
 }
 
 type retryObserver interface {
@@ -55,6 +73,14 @@ type retryObserver interface {
 	eventsRetry(int)
 }
 
+type batchObserver interface {
+	// The queue reader waited for the queue to return a batch.
+	batchFilled(time.Duration)
+	// A batch was acknowledged by the output, after the given time since it
+	// was read from the queue.
+	batchACKed(time.Duration)
+}
+
 // metricsObserver is used by many component in the publisher pipeline, to report
 // internal events. The oberserver can call registered global event handlers or
 // updated shared counters/metrics for reporting.
@@ -79,6 +105,10 @@ type metricsObserverVars struct {
 	queueACKed       *monitoring.Uint
 	queueMaxEvents   *monitoring.Uint
 	percentQueueFull *monitoring.Float
+
+	// latency histograms, in milliseconds
+	enqueueWait, batchFill, ackLatency metrics.Sample
+	enqueueWaitTotal                   *monitoring.Uint
 }
 
 func newMetricsObserver(metrics *monitoring.Registry) *metricsObserver {
@@ -87,7 +117,7 @@ func newMetricsObserver(metrics *monitoring.Registry) *metricsObserver {
 		reg = metrics.NewRegistry("pipeline")
 	}
 
-	return &metricsObserver{
+	o := &metricsObserver{
 		metrics: metrics,
 		vars: metricsObserverVars{
 			// (Gauge) clients measures the number of open pipeline clients.
@@ -131,8 +161,35 @@ func newMetricsObserver(metrics *monitoring.Registry) *metricsObserver {
 			// (Gauge) queue.filled.pct.events measures the fraction (from 0 to 1)
 			// of the queue's event capacity that is currently filled.
 			percentQueueFull: monitoring.NewFloat(reg, "queue.filled.pct.events"),
+
+			// enqueue_wait_ms counts the time clients spent waiting for the
+			// queue to accept their events. When the queue is full, producers
+			// block and this grows as fast as the wall clock.
+			enqueueWaitTotal: monitoring.NewUint(reg, "enqueue_wait_ms"),
+
+			enqueueWait: metrics.NewUniformSample(latencySampleSize),
+			batchFill:   metrics.NewUniformSample(latencySampleSize),
+			ackLatency:  metrics.NewUniformSample(latencySampleSize),
 		},
 	}
+
+	// (Histogram) enqueue_wait measures the time a client waited for the
+	// queue to accept an event.
+	_ = adapter.NewGoMetrics(reg, "enqueue_wait", adapter.Accept).
+		Register("histogram", metrics.NewHistogram(o.vars.enqueueWait))
+
+	// (Histogram) batch_fill measures the time the pipeline waited for the
+	// queue to return a batch for the outputs. High values mean the inputs
+	// don't produce enough events to fill the batches.
+	_ = adapter.NewGoMetrics(reg, "batch_fill", adapter.Accept).
+		Register("histogram", metrics.NewHistogram(o.vars.batchFill))
+
+	// (Histogram) ack_latency measures the time between a batch being read
+	// from the queue and its acknowledgment by the output, retries included.
+	_ = adapter.NewGoMetrics(reg, "ack_latency", adapter.Accept).
+		Register("histogram", metrics.NewHistogram(o.vars.ackLatency))
+
+	return o
 }
 
 func (o *metricsObserver) cleanup() {
@@ -177,6 +234,12 @@ func (o *metricsObserver) eventsACKed(n int) {
 	o.vars.activeEvents.Sub(uint64(n))
 }
 
+// (client) client waited for the queue to accept an event
+func (o *metricsObserver) enqueueWait(d time.Duration) {
+	o.vars.enqueueWaitTotal.Add(uint64(d.Milliseconds()))
+	o.vars.enqueueWait.Update(d.Milliseconds())
+}
+
 // (client) client closing down or DropIfFull is set
 func (o *metricsObserver) failedPublishEvent() {
 	o.vars.eventsFailed.Inc()
@@ -197,6 +260,20 @@ func (o *metricsObserver) eventsRetry(n int) {
 	o.vars.eventsRetry.Add(uint64(n))
 }
 
+//
+// pipeline batch events
+//
+
+// (queue reader) queue returned a batch
+func (o *metricsObserver) batchFilled(d time.Duration) {
+	o.vars.batchFill.Update(d.Milliseconds())
+}
+
+// (batch) batch was acknowledged by the output
+func (o *metricsObserver) batchACKed(d time.Duration) {
+	o.vars.ackLatency.Update(d.Milliseconds())
+}
+
 type emptyObserver struct{}
 
 var nilObserver observer = (*emptyObserver)(nil)
@@ -211,3 +288,7 @@ func (*emptyObserver) failedPublishEvent() {}
 func (*emptyObserver) eventsACKed(n int)   {}
 func (*emptyObserver) eventsDropped(int)   {}
 func (*emptyObserver) eventsRetry(int)     {}
+
+func (*emptyObserver) enqueueWait(time.Duration) {}
+func (*emptyObserver) batchFilled(time.Duration) {}
+func (*emptyObserver) batchACKed(time.Duration)  {}
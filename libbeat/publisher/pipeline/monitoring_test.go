@@ -0,0 +1,50 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestMetricsObserverLatencyHistograms(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	o := newMetricsObserver(reg)
+
+	o.enqueueWait(2 * time.Millisecond)
+	o.enqueueWait(40 * time.Millisecond)
+	o.batchFilled(5 * time.Millisecond)
+	o.batchACKed(120 * time.Millisecond)
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(42), snapshot.Ints["pipeline.enqueue_wait_ms"])
+	assert.Equal(t, int64(2), snapshot.Ints["pipeline.enqueue_wait.histogram.count"])
+	assert.Equal(t, int64(40), snapshot.Ints["pipeline.enqueue_wait.histogram.max"])
+	assert.Equal(t, int64(1), snapshot.Ints["pipeline.batch_fill.histogram.count"])
+	assert.Equal(t, int64(5), snapshot.Ints["pipeline.batch_fill.histogram.max"])
+	assert.Equal(t, int64(1), snapshot.Ints["pipeline.ack_latency.histogram.count"])
+	assert.Equal(t, int64(120), snapshot.Ints["pipeline.ack_latency.histogram.max"])
+
+	o.cleanup()
+	snapshot = monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.NotContains(t, snapshot.Ints, "pipeline.ack_latency.histogram.count")
+}
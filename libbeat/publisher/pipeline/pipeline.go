@@ -21,7 +21,9 @@
 package pipeline
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -30,10 +32,13 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/hybridqueue"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue/memqueue"
+	"github.com/elastic/beats/v7/libbeat/publisher/wal"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -68,6 +73,13 @@ type Pipeline struct {
 	waitCloseTimeout time.Duration
 
 	processors processing.Supporter
+
+	// wal is the optional write-ahead log events are persisted to before
+	// they are acknowledged to the clients.
+	wal *wal.Log
+
+	// deadLetter is the optional file the dropped events are written to.
+	deadLetter *deadletter.Writer
 }
 
 // Settings is used to pass additional settings to a newly created pipeline instance.
@@ -81,6 +93,15 @@ type Settings struct {
 	Processors processing.Supporter
 
 	InputQueueSize int
+
+	// WAL is an optional write-ahead log. The pipeline takes ownership of it
+	// and closes it on Close.
+	WAL *wal.Log
+
+	// DeadLetter is an optional writer of the events dropped by the pipeline
+	// or the outputs. The pipeline takes ownership of it and closes it on
+	// Close.
+	DeadLetter *deadletter.Writer
 }
 
 // WaitCloseMode enumerates the possible behaviors of WaitClose in a pipeline.
@@ -126,6 +147,8 @@ func New(
 		observer:         nilObserver,
 		waitCloseTimeout: settings.WaitClose,
 		processors:       settings.Processors,
+		wal:              settings.WAL,
+		deadLetter:       settings.DeadLetter,
 	}
 	if settings.WaitCloseMode == WaitOnPipelineClose && settings.WaitClose > 0 {
 		p.waitCloseTimeout = settings.WaitClose
@@ -147,13 +170,17 @@ func New(
 		return nil, err
 	}
 
-	output, err := newOutputController(beat, monitors, p.observer, queueFactory, settings.InputQueueSize)
+	output, err := newOutputController(beat, monitors, p.observer, queueFactory, settings.InputQueueSize, settings.DeadLetter)
 	if err != nil {
 		return nil, err
 	}
 	p.outputController = output
 	p.outputController.Set(out)
 
+	if p.wal != nil {
+		go p.replayWAL()
+	}
+
 	return p, nil
 }
 
@@ -169,6 +196,15 @@ func (p *Pipeline) Close() error {
 	// Note: active clients are not closed / disconnected.
 	p.outputController.WaitClose(p.waitCloseTimeout)
 
+	if p.wal != nil {
+		if err := p.wal.Close(); err != nil {
+			log.Errorf("Failed to close write-ahead log: %v", err)
+		}
+	}
+	if err := p.deadLetter.Close(); err != nil {
+		log.Errorf("Failed to close dead-letter file: %v", err)
+	}
+
 	p.observer.cleanup()
 	return nil
 }
@@ -218,6 +254,10 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 		canDrop:        canDrop,
 		observer:       p.observer,
 	}
+	if p.wal != nil {
+		client.wal = p.wal
+		client.walSeqs = &walSeqs{}
+	}
 
 	ackHandler := cfg.EventListener
 
@@ -234,6 +274,12 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 	producerCfg := queue.ProducerConfig{
 		ACK: func(count int) {
 			client.observer.eventsACKed(count)
+			if client.wal != nil {
+				// The client was already acknowledged when the events were
+				// written to the write-ahead log.
+				client.wal.ACK(client.walSeqs.pop(count)...)
+				return
+			}
 			if ackHandler != nil {
 				ackHandler.ACKEvents(count)
 			}
@@ -269,6 +315,70 @@ func (p *Pipeline) OutputReloader() OutputReloader {
 	return p.outputController
 }
 
+// replayWAL publishes the events of the write-ahead log that were not
+// acknowledged by the output before the last shutdown. The events were
+// already processed, so they are published to the queue directly.
+func (p *Pipeline) replayWAL() {
+	log := p.monitors.Logger
+	seqs := &walSeqs{}
+	producer := p.outputController.queueProducer(queue.ProducerConfig{
+		ACK: func(count int) {
+			p.wal.ACK(seqs.pop(count)...)
+		},
+	})
+	if producer == nil {
+		return
+	}
+
+	count := 0
+	err := p.wal.Replay(func(seq uint64, event publisher.Event) error {
+		seqs.push(seq)
+		if _, ok := producer.Publish(event); !ok {
+			seqs.dropLast()
+			return errors.New("pipeline is shutting down")
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Failed to replay the write-ahead log after %d events: %v", count, err)
+		return
+	}
+	if count > 0 {
+		log.Infof("Replayed %d events from the write-ahead log.", count)
+	}
+}
+
+// walSeqs holds the write-ahead log sequence numbers of the events a
+// producer published to the queue, in order.
+type walSeqs struct {
+	mu   sync.Mutex
+	seqs []uint64
+}
+
+func (s *walSeqs) push(seq uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seqs = append(s.seqs, seq)
+}
+
+func (s *walSeqs) dropLast() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := len(s.seqs); n > 0 {
+		s.seqs = s.seqs[:n-1]
+	}
+}
+
+func (s *walSeqs) pop(n int) []uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n = min(n, len(s.seqs))
+	popped := append([]uint64(nil), s.seqs[:n]...)
+	s.seqs = append(s.seqs[:0], s.seqs[n:]...)
+	return popped
+}
+
 // Parses the given config and returns a QueueFactory based on it.
 // This helper exists to frontload config parsing errors: if there is an
 // error in the queue config, we want it to show up as fatal during
@@ -287,6 +397,12 @@ func queueFactoryForUserConfig(queueType string, userConfig *conf.C) (queue.Queu
 			return nil, err
 		}
 		return diskqueue.FactoryForSettings(settings), nil
+	case hybridqueue.QueueType:
+		settings, err := hybridqueue.SettingsForUserConfig(userConfig)
+		if err != nil {
+			return nil, err
+		}
+		return hybridqueue.FactoryForSettings(settings), nil
 	default:
 		return nil, fmt.Errorf("unrecognized queue type '%v'", queueType)
 	}
@@ -18,8 +18,11 @@
 package pipeline
 
 import (
+	"time"
+
 	"github.com/elastic/elastic-agent-libs/logp"
 
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 )
 
@@ -35,6 +38,8 @@ type queueReaderRequest struct {
 	retryer    retryer
 	batchSize  int
 	timeToLive int
+	deadLetter *deadletter.Writer
+	observer   batchObserver
 }
 
 func makeQueueReader() queueReader {
@@ -54,10 +59,13 @@ func (qr *queueReader) run(logger *logp.Logger) {
 			logger.Debug("pipeline event consumer queue reader: stop")
 			return
 		}
+		start := time.Now()
 		queueBatch, _ := req.queue.Get(req.batchSize)
 		var batch *ttlBatch
 		if queueBatch != nil {
-			batch = newBatch(req.retryer, queueBatch, req.timeToLive)
+			req.observer.batchFilled(time.Since(start))
+			batch = newBatch(req.retryer, queueBatch, req.timeToLive, req.deadLetter)
+			batch.observer = req.observer
 		}
 		select {
 		case qr.resp <- batch:
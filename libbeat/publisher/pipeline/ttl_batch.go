@@ -19,11 +19,18 @@ package pipeline
 
 import (
 	"sync/atomic"
+	"time"
 
 	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 )
 
+const (
+	reasonRetryLimit = "retry limit reached"
+	reasonDropped    = "dropped by the output"
+)
+
 type retryer interface {
 	retry(batch *ttlBatch, decreaseTTL bool)
 }
@@ -49,6 +56,15 @@ type ttlBatch struct {
 	// all split batches descending from the same original batch will
 	// point to the same metadata.
 	split *batchSplitData
+
+	// deadLetter is set if dropped events are written to the dead-letter
+	// file.
+	deadLetter *deadletter.Writer
+
+	// readTime is when the batch was read from the queue. The observer, if
+	// set, is reported the time between it and the acknowledgment.
+	readTime time.Time
+	observer batchObserver
 }
 
 type batchSplitData struct {
@@ -61,7 +77,7 @@ type batchSplitData struct {
 	outstandingEvents atomic.Int64
 }
 
-func newBatch(retryer retryer, original queue.Batch, ttl int) *ttlBatch {
+func newBatch(retryer retryer, original queue.Batch, ttl int, deadLetter *deadletter.Writer) *ttlBatch {
 	if original == nil {
 		panic("empty batch")
 	}
@@ -79,10 +95,12 @@ func newBatch(retryer retryer, original queue.Batch, ttl int) *ttlBatch {
 	}
 
 	b := &ttlBatch{
-		done:    original.Done,
-		retryer: retryer,
-		ttl:     ttl,
-		events:  events,
+		done:       original.Done,
+		retryer:    retryer,
+		ttl:        ttl,
+		events:     events,
+		deadLetter: deadLetter,
+		readTime:   time.Now(),
 	}
 	return b
 }
@@ -92,17 +110,33 @@ func (b *ttlBatch) Events() []publisher.Event {
 }
 
 func (b *ttlBatch) ACK() {
+	if b.observer != nil {
+		b.observer.batchACKed(time.Since(b.readTime))
+	}
 	// Help the garbage collector clean up the event data a little faster
 	b.events = nil
 	b.done()
 }
 
 func (b *ttlBatch) Drop() {
+	b.deadLetter.Write(b.events, reasonDropped)
+	b.drop()
+}
+
+// drop releases the batch without writing its events to the dead-letter
+// file, for batches that are not sent because the pipeline is shutting
+// down.
+func (b *ttlBatch) drop() {
 	// Help the garbage collector clean up the event data a little faster
 	b.events = nil
 	b.done()
 }
 
+// DeadLetter implements publisher.DeadLetterBatch.
+func (b *ttlBatch) DeadLetter(events []publisher.Event, reason string) {
+	b.deadLetter.Write(events, reason)
+}
+
 // SplitRetry is called by the output to report that the batch is
 // too large to ingest. It splits the events into two separate batches
 // and sends both of them back to the retryer. Returns false if the
@@ -125,18 +159,24 @@ func (b *ttlBatch) SplitRetry() bool {
 	events1 := b.events[:splitIndex]
 	events2 := b.events[splitIndex:]
 	b.retryer.retry(&ttlBatch{
-		events:  events1,
-		done:    splitData.doneCallback(len(events1)),
-		retryer: b.retryer,
-		ttl:     b.ttl,
-		split:   splitData,
+		events:     events1,
+		done:       splitData.doneCallback(len(events1)),
+		retryer:    b.retryer,
+		ttl:        b.ttl,
+		split:      splitData,
+		deadLetter: b.deadLetter,
+		readTime:   b.readTime,
+		observer:   b.observer,
 	}, false)
 	b.retryer.retry(&ttlBatch{
-		events:  events2,
-		done:    splitData.doneCallback(len(events2)),
-		retryer: b.retryer,
-		ttl:     b.ttl,
-		split:   splitData,
+		events:     events2,
+		done:       splitData.doneCallback(len(events2)),
+		retryer:    b.retryer,
+		ttl:        b.ttl,
+		split:      splitData,
+		deadLetter: b.deadLetter,
+		readTime:   b.readTime,
+		observer:   b.observer,
 	}, false)
 	return true
 }
@@ -179,12 +219,16 @@ func (b *ttlBatch) reduceTTL() bool {
 
 	// filter for events with guaranteed send flags
 	events := b.events[:0]
+	var dropped []publisher.Event
 	for _, event := range b.events {
 		if event.Guaranteed() {
 			events = append(events, event)
+		} else if b.deadLetter != nil {
+			dropped = append(dropped, event)
 		}
 	}
 	b.events = events
+	b.deadLetter.Write(dropped, reasonRetryLimit)
 
 	if len(b.events) > 0 {
 		b.ttl = -1 // we need infinite retry for all events left in this batch
@@ -20,12 +20,17 @@ package pipeline
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/deadletter"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 func TestBatchSplitRetry(t *testing.T) {
@@ -112,6 +117,76 @@ func TestBatchCallsDoneAndFreesEvents(t *testing.T) {
 	require.True(t, doneCalled, "Calling batch.Drop should invoke the done callback")
 }
 
+func TestBatchReportsACKLatency(t *testing.T) {
+	observer := &mockBatchObserver{}
+	retryer := &mockRetryer{}
+	batch := &ttlBatch{
+		done:     func() {},
+		retryer:  retryer,
+		events:   make([]publisher.Event, 2),
+		readTime: time.Now().Add(-time.Second),
+		observer: observer,
+	}
+
+	batch.SplitRetry()
+	require.Len(t, retryer.batches, 2)
+	for _, b := range retryer.batches {
+		b.ACK()
+	}
+
+	// Split batches report the latency since the original batch was read.
+	require.Len(t, observer.acked, 2)
+	for _, d := range observer.acked {
+		assert.GreaterOrEqual(t, d, time.Second)
+	}
+}
+
+type mockBatchObserver struct {
+	acked []time.Duration
+}
+
+func (o *mockBatchObserver) batchFilled(time.Duration)  {}
+func (o *mockBatchObserver) batchACKed(d time.Duration) { o.acked = append(o.acked, d) }
+
+func TestBatchDeadLettersDroppedEvents(t *testing.T) {
+	dir := t.TempDir()
+	config := deadletter.DefaultConfig()
+	config.Path = dir
+	w, err := deadletter.Open(config, beat.Info{Beat: "testbeat"}, logp.NewLogger("deadletter"))
+	require.NoError(t, err)
+
+	event := func(msg string, flags publisher.EventFlags) publisher.Event {
+		return publisher.Event{Content: beat.Event{Fields: mapstr.M{"message": msg}}, Flags: flags}
+	}
+	batch := &ttlBatch{
+		done:       func() {},
+		ttl:        1,
+		deadLetter: w,
+		events: []publisher.Event{
+			event("expired", 0),
+			event("guaranteed", publisher.GuaranteedSend),
+		},
+	}
+	require.True(t, batch.reduceTTL(), "the batch must be kept for the guaranteed event")
+	require.Len(t, batch.events, 1)
+
+	batch.Drop()
+	require.NoError(t, w.Close())
+
+	files, err := deadletter.Files(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	var reasons, messages []string
+	err = deadletter.Read(files[0], func(rec deadletter.Record) error {
+		reasons = append(reasons, rec.Reason)
+		messages = append(messages, rec.Event.Fields["message"].(string))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{reasonRetryLimit, reasonDropped}, reasons)
+	assert.Equal(t, []string{"expired", "guaranteed"}, messages)
+}
+
 type mockQueueBatch struct {
 	freeEntriesCalled int
 }
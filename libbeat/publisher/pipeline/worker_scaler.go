@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	// scaleUpWaiting is the fraction of the interval batches waited for a
+	// free worker above which a worker is added.
+	scaleUpWaiting = 0.5
+	// scaleDownBusy is how busy the remaining workers would be, at most,
+	// for a worker to be removed.
+	scaleDownBusy = 0.6
+)
+
+// workerGate activates or pauses an output worker. A paused worker finishes
+// the batch it is publishing, then stops reading batches.
+type workerGate struct {
+	mu     sync.Mutex
+	active bool
+	// changed is closed and replaced when active changes.
+	changed chan struct{}
+}
+
+func newWorkerGate(active bool) *workerGate {
+	return &workerGate{active: active, changed: make(chan struct{})}
+}
+
+func (g *workerGate) state() (bool, <-chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.active, g.changed
+}
+
+func (g *workerGate) set(active bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.active == active {
+		return
+	}
+	g.active = active
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// workerLoad accumulates, between two scaling decisions, the time batches
+// waited for a free worker and the time workers spent publishing.
+type workerLoad struct {
+	waiting atomic.Int64
+	busy    atomic.Int64
+	batches atomic.Int64
+}
+
+// waited records the time a batch waited to be handed to a worker.
+func (l *workerLoad) waited(d time.Duration) {
+	l.waiting.Add(int64(d))
+}
+
+// published records the time a worker spent publishing a batch.
+func (l *workerLoad) published(d time.Duration) {
+	l.busy.Add(int64(d))
+	l.batches.Add(1)
+}
+
+// reset returns the accumulated times and starts over.
+func (l *workerLoad) reset() (waiting, busy time.Duration, batches int64) {
+	return time.Duration(l.waiting.Swap(0)), time.Duration(l.busy.Swap(0)), l.batches.Swap(0)
+}
+
+// workerScaler adds and removes active output workers, one at a time,
+// depending on whether the queued batches wait for a free worker, how busy
+// the workers are, and how long publishing takes.
+type workerScaler struct {
+	config outputs.WorkerAutoscaleConfig
+	logger *logp.Logger
+
+	// gates are the gates of the workers, in activation order.
+	gates []*workerGate
+	load  *workerLoad
+	min   int
+	// active is the number of active workers, the first of gates.
+	active int
+
+	activeMetric *monitoring.Uint
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newWorkerScaler returns a scaler for the workers of the gates. The bounds
+// of the configuration are per host, the workers of each host being
+// interleaved in gates.
+func newWorkerScaler(config outputs.WorkerAutoscaleConfig, gates []*workerGate, load *workerLoad, reg *monitoring.Registry, logger *logp.Logger) *workerScaler {
+	hosts := (len(gates) + config.MaxWorkers - 1) / config.MaxWorkers
+	min := hosts * config.MinWorkers
+	if min > len(gates) {
+		min = len(gates)
+	}
+	s := &workerScaler{
+		config: config,
+		logger: logger,
+		gates:  gates,
+		load:   load,
+		min:    min,
+		done:   make(chan struct{}),
+	}
+	if reg != nil {
+		workers := reg.GetRegistry("workers")
+		if workers == nil {
+			workers = reg.NewRegistry("workers")
+		} else {
+			// The metrics of the previous output are replaced.
+			_ = workers.Clear()
+		}
+		s.activeMetric = monitoring.NewUint(workers, "active")
+		monitoring.NewUint(workers, "max").Set(uint64(len(gates)))
+	}
+	s.setActive(min)
+	return s
+}
+
+func (s *workerScaler) run() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+		s.load.reset()
+		last := time.Now()
+		for {
+			select {
+			case <-s.done:
+				return
+			case now := <-ticker.C:
+				s.scale(now.Sub(last))
+				last = now
+			}
+		}
+	}()
+}
+
+func (s *workerScaler) stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// scale adds or removes a worker depending on the load of the last elapsed
+// time.
+func (s *workerScaler) scale(elapsed time.Duration) {
+	waiting, busy, batches := s.load.reset()
+	if elapsed <= 0 {
+		return
+	}
+	var latency time.Duration
+	if batches > 0 {
+		latency = busy / time.Duration(batches)
+	}
+	overloaded := s.config.MaxLatency > 0 && latency > s.config.MaxLatency
+
+	target := s.active
+	switch {
+	case overloaded:
+		target--
+	case float64(waiting) >= scaleUpWaiting*float64(elapsed):
+		target++
+	case waiting == 0 && float64(busy) <= scaleDownBusy*float64(elapsed)*float64(s.active-1):
+		target--
+	}
+	if target < s.min {
+		target = s.min
+	}
+	if target > len(s.gates) {
+		target = len(s.gates)
+	}
+	if target == s.active {
+		return
+	}
+	s.logger.Infof("Scaling output workers from %d to %d (batches waited %v, workers busy %v, average publish latency %v over %v)",
+		s.active, target, waiting, busy, latency, elapsed)
+	s.setActive(target)
+}
+
+func (s *workerScaler) setActive(n int) {
+	for i, g := range s.gates {
+		g.set(i < n)
+	}
+	s.active = n
+	if s.activeMetric != nil {
+		s.activeMetric.Set(uint64(n))
+	}
+}
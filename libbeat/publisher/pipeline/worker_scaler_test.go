@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func newTestScaler(t *testing.T, clients int, config outputs.WorkerAutoscaleConfig) (*workerScaler, *monitoring.Registry) {
+	t.Helper()
+	gates := make([]*workerGate, clients)
+	for i := range gates {
+		gates[i] = newWorkerGate(false)
+	}
+	reg := monitoring.NewRegistry()
+	return newWorkerScaler(config, gates, &workerLoad{}, reg, logp.NewLogger("test")), reg
+}
+
+func activeGates(s *workerScaler) int {
+	n := 0
+	for _, g := range s.gates {
+		if active, _ := g.state(); active {
+			n++
+		}
+	}
+	return n
+}
+
+func TestWorkerScaler(t *testing.T) {
+	config := outputs.DefaultWorkerAutoscaleConfig()
+	config.MinWorkers = 1
+	config.MaxWorkers = 3
+	config.MaxLatency = time.Second
+
+	// Two hosts, with three workers each.
+	s, reg := newTestScaler(t, 6, config)
+	assert.Equal(t, 2, s.active, "min_workers per host")
+	assert.Equal(t, 2, activeGates(s))
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(2), snapshot.Ints["workers.active"])
+	assert.Equal(t, int64(6), snapshot.Ints["workers.max"])
+
+	const interval = 10 * time.Second
+
+	// Batches waited for a free worker most of the time.
+	for i := 0; i < 10; i++ {
+		s.load.waited(interval)
+		s.load.published(100 * time.Millisecond)
+		s.scale(interval)
+	}
+	assert.Equal(t, 6, s.active, "scaled up to max_workers per host")
+	assert.Equal(t, 6, activeGates(s))
+
+	// Publishing gets slower than max_latency.
+	s.load.waited(interval)
+	s.load.published(2 * time.Second)
+	s.scale(interval)
+	assert.Equal(t, 5, s.active)
+
+	// Steady load, waiting a bit but not enough to add workers.
+	s.load.waited(time.Second)
+	for i := 0; i < 400; i++ {
+		s.load.published(100 * time.Millisecond)
+	}
+	s.scale(interval)
+	assert.Equal(t, 5, s.active)
+
+	// Workers are mostly idle.
+	for i := 0; i < 10; i++ {
+		s.load.published(time.Second)
+		s.scale(interval)
+	}
+	assert.Equal(t, 2, s.active, "scaled down to min_workers per host")
+	assert.Equal(t, 2, activeGates(s))
+	snapshot = monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(2), snapshot.Ints["workers.active"])
+}
+
+func TestWorkerGate(t *testing.T) {
+	g := newWorkerGate(false)
+	active, changed := g.state()
+	require.False(t, active)
+
+	g.set(true)
+	select {
+	case <-changed:
+	default:
+		t.Fatal("activating the gate must be signaled")
+	}
+	active, changed = g.state()
+	require.True(t, active)
+
+	g.set(true)
+	select {
+	case <-changed:
+		t.Fatal("setting the same state must not be signaled")
+	default:
+	}
+}
+
+func TestPausedClientWorker(t *testing.T) {
+	workQueue := make(chan publisher.Batch)
+	published := make(chan struct{}, 1)
+	client := newMockNetworkClient(func(publisher.Batch) error {
+		published <- struct{}{}
+		return nil
+	})
+	gate := newWorkerGate(false)
+	load := &workerLoad{}
+	worker := makeClientWorker(workQueue, client, makeBufLogger(t), nil, gate, load)
+	defer worker.Close()
+
+	select {
+	case workQueue <- randomBatch(1, 2):
+		t.Fatal("a paused worker must not read batches")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	gate.set(true)
+	// The first batch is cancelled while the worker connects.
+	for i := 0; i < 2; i++ {
+		select {
+		case workQueue <- randomBatch(1, 2):
+		case <-time.After(5 * time.Second):
+			t.Fatal("an active worker must read batches")
+		}
+	}
+	<-published
+	assert.True(t, waitUntilTrue(5*time.Second, func() bool { return load.batches.Load() == 1 }))
+}
@@ -18,6 +18,8 @@
 package pipetool
 
 import (
+	"fmt"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/acker"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -68,6 +70,28 @@ func (p *wrapClientPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, er
 	return client, err
 }
 
+// outputProfilesPipeline connects the clients to the pipeline of the output
+// profile they select.
+type outputProfilesPipeline struct {
+	parent   beat.PipelineConnector
+	profiles map[string]beat.PipelineConnector
+}
+
+func (p *outputProfilesPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+func (p *outputProfilesPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	if cfg.OutputProfile == "" {
+		return p.parent.ConnectWith(cfg)
+	}
+	pipeline, found := p.profiles[cfg.OutputProfile]
+	if !found {
+		return nil, fmt.Errorf("output profile '%s' is not defined", cfg.OutputProfile)
+	}
+	return pipeline.ConnectWith(cfg)
+}
+
 // WithClientConfigEdit creates a pipeline connector, that allows the
 // beat.ClientConfig to be modified before connecting to the underlying
 // pipeline.
@@ -105,6 +129,13 @@ func WithClientWrapper(pipeline beat.PipelineConnector, wrap ClientWrapper) beat
 	return &wrapClientPipeline{parent: pipeline, wrapper: wrap}
 }
 
+// WithOutputProfiles creates a pipeline connector that connects the clients
+// setting beat.ClientConfig.OutputProfile to the pipeline of that profile,
+// and the other clients to pipeline.
+func WithOutputProfiles(pipeline beat.PipelineConnector, profiles map[string]beat.PipelineConnector) beat.PipelineConnector {
+	return &outputProfilesPipeline{parent: pipeline, profiles: profiles}
+}
+
 // WithDynamicFields ensures that dynamicFields from autodiscovery are setup
 // when connecting to the publisher pipeline.
 // Processing.DynamicFields will only be overwritten if not is not already set.
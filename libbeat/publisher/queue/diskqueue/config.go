@@ -67,6 +67,10 @@ type Settings struct {
 	// EncryptionKey is used to encrypt data if SchemaVersion 2 is used.
 	EncryptionKey []byte
 
+	// EncryptionKeys, if set, is used to encrypt segments with AES-GCM,
+	// taking precedence over EncryptionKey.
+	EncryptionKeys *Keyring
+
 	// UseCompression enables or disables LZ4 compression
 	UseCompression bool
 }
@@ -83,6 +87,8 @@ type userConfig struct {
 
 	RetryInterval    *time.Duration `config:"retry_interval" validate:"positive"`
 	MaxRetryInterval *time.Duration `config:"max_retry_interval" validate:"positive"`
+
+	Encryption *encryptionConfig `config:"encryption"`
 }
 
 func (c *userConfig) Validate() error {
@@ -166,6 +172,14 @@ func SettingsForUserConfig(config *config.C) (Settings, error) {
 		settings.MaxRetryInterval = *userConfig.MaxRetryInterval
 	}
 
+	if userConfig.Encryption.IsEnabled() {
+		keyring, err := userConfig.Encryption.keyring()
+		if err != nil {
+			return Settings{}, fmt.Errorf("couldn't load disk queue encryption keys: %w", err)
+		}
+		settings.EncryptionKeys = keyring
+	}
+
 	return settings, nil
 }
 
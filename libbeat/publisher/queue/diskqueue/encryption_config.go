@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const defaultKeyCommandTimeout = 10 * time.Second
+
+// encryptionConfig holds the user configuration for encrypting the disk
+// queue at rest with AES-GCM.
+type encryptionConfig struct {
+	Enabled *bool `config:"enabled"`
+
+	// Key is the base64 encoded current key, usually referenced from the
+	// keystore.
+	Key string `config:"key"`
+
+	// PreviousKeys are base64 encoded keys that are only used to decrypt
+	// segments written before the current key was rotated in.
+	PreviousKeys []string `config:"previous_keys"`
+
+	// KeyCommand is run to fetch the keys from an external KMS. It must
+	// print one base64 encoded key per line, the current key first.
+	KeyCommand        []string       `config:"key_command"`
+	KeyCommandTimeout *time.Duration `config:"key_command_timeout" validate:"positive"`
+}
+
+// IsEnabled returns true if the encryption section is present and not
+// explicitly disabled.
+func (c *encryptionConfig) IsEnabled() bool {
+	return c != nil && (c.Enabled == nil || *c.Enabled)
+}
+
+func (c *encryptionConfig) Validate() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	if (c.Key == "") == (len(c.KeyCommand) == 0) {
+		return errors.New("disk queue encryption requires exactly one of key or key_command")
+	}
+	if len(c.KeyCommand) > 0 && len(c.PreviousKeys) > 0 {
+		return errors.New("disk queue encryption previous_keys can't be used with key_command")
+	}
+	return nil
+}
+
+// keyring loads the configured keys.
+func (c *encryptionConfig) keyring() (*Keyring, error) {
+	encoded := append([]string{c.Key}, c.PreviousKeys...)
+	if len(c.KeyCommand) > 0 {
+		var err error
+		encoded, err = c.runKeyCommand()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([][]byte, 0, len(encoded))
+	for i, s := range encoded {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key %d: %w", i, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewKeyring(keys[0], keys[1:]...)
+}
+
+// runKeyCommand runs the key command and returns the non-empty lines of
+// its output.
+func (c *encryptionConfig) runKeyCommand() ([]string, error) {
+	timeout := defaultKeyCommandTimeout
+	if c.KeyCommandTimeout != nil {
+		timeout = *c.KeyCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.KeyCommand[0], c.KeyCommand[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("key_command %q failed: %w: %s", c.KeyCommand[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var keys []string
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			keys = append(keys, line)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("key_command %q returned no keys", c.KeyCommand[0])
+	}
+	return keys, nil
+}
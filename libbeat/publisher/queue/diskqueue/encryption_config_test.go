@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"encoding/base64"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestEncryptionConfig(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(testGCMKey)
+	oldKey := base64.StdEncoding.EncodeToString(testGCMOldKey)
+
+	tests := map[string]struct {
+		config  map[string]interface{}
+		enabled bool
+		wantErr string
+	}{
+		"not configured": {
+			config: map[string]interface{}{},
+		},
+		"disabled": {
+			config: map[string]interface{}{"encryption.enabled": false},
+		},
+		"key": {
+			config:  map[string]interface{}{"encryption.key": key},
+			enabled: true,
+		},
+		"key and previous keys": {
+			config: map[string]interface{}{
+				"encryption.key":           key,
+				"encryption.previous_keys": []string{oldKey},
+			},
+			enabled: true,
+		},
+		"no key": {
+			config:  map[string]interface{}{"encryption.enabled": true},
+			wantErr: "exactly one of key or key_command",
+		},
+		"key and key command": {
+			config: map[string]interface{}{
+				"encryption.key":         key,
+				"encryption.key_command": []string{"echo"},
+			},
+			wantErr: "exactly one of key or key_command",
+		},
+		"invalid base64": {
+			config:  map[string]interface{}{"encryption.key": "not base64!"},
+			wantErr: "failed to decode key 0",
+		},
+		"invalid key size": {
+			config:  map[string]interface{}{"encryption.key": base64.StdEncoding.EncodeToString([]byte("short"))},
+			wantErr: "invalid encryption key",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := config.MustNewConfigFrom(tc.config)
+			require.NoError(t, c.SetString("max_size", -1, "1GB"))
+			settings, err := SettingsForUserConfig(c)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.enabled, settings.EncryptionKeys != nil)
+		})
+	}
+}
+
+func TestEncryptionKeyCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on sh")
+	}
+	key := base64.StdEncoding.EncodeToString(testGCMKey)
+	oldKey := base64.StdEncoding.EncodeToString(testGCMOldKey)
+
+	c := &encryptionConfig{
+		KeyCommand: []string{"sh", "-c", "printf '" + key + "\\n\\n" + oldKey + "\\n'"},
+	}
+	keyring, err := c.keyring()
+	require.NoError(t, err)
+	assert.Len(t, keyring.keys, 2)
+	want, err := NewKeyring(testGCMKey)
+	require.NoError(t, err)
+	assert.Equal(t, want.currentID, keyring.currentID)
+
+	c.KeyCommand = []string{"sh", "-c", "echo boom >&2; exit 1"}
+	_, err = c.keyring()
+	assert.ErrorContains(t, err, "boom")
+
+	c.KeyCommand = []string{"true"}
+	_, err = c.keyring()
+	assert.ErrorContains(t, err, "returned no keys")
+}
@@ -0,0 +1,288 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// keyIDSize is the size of the key ID stored in segments encrypted
+	// with AES-GCM, identifying the key needed to decrypt the segment.
+	keyIDSize = 8
+
+	// gcmChunkSize is the maximum size of the plaintext sealed in a single
+	// AES-GCM chunk.
+	gcmChunkSize = 64 * 1024
+)
+
+// Keyring holds the AES-GCM keys used to encrypt and decrypt segments.
+// Segments are encrypted with the current key, and can be decrypted with
+// the current or any of the previous keys, allowing keys to be rotated
+// while older segments are still in the queue.
+type Keyring struct {
+	currentID keyID
+	keys      map[keyID]cipher.AEAD
+}
+
+type keyID [keyIDSize]byte
+
+func (id keyID) String() string { return hex.EncodeToString(id[:]) }
+
+// NewKeyring returns a Keyring encrypting with the current key. Keys must be
+// 16, 24 or 32 bytes long, to select AES-128, AES-192 or AES-256.
+func NewKeyring(current []byte, previous ...[]byte) (*Keyring, error) {
+	k := &Keyring{keys: make(map[keyID]cipher.AEAD, 1+len(previous))}
+	id, err := k.add(current)
+	if err != nil {
+		return nil, err
+	}
+	k.currentID = id
+	for _, key := range previous {
+		if _, err := k.add(key); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+func (k *Keyring) add(key []byte) (keyID, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return keyID{}, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return keyID{}, err
+	}
+	// The key ID is derived from the key, so that segments don't need to
+	// be associated with user provided names.
+	sum := sha256.Sum256(key)
+	var id keyID
+	copy(id[:], sum[:])
+	k.keys[id] = aead
+	return id, nil
+}
+
+// GCMWriter encrypts the data written to it with AES-GCM. The data is
+// sealed in chunks of up to 64KiB, when a chunk is full and on Sync.
+//
+// The stream starts with the key ID and a random base nonce. Each chunk is
+// stored as its 4-byte little endian length followed by the ciphertext. The
+// nonce of a chunk is the base nonce XORed with the chunk index, which is
+// also authenticated, so that chunks can't be reordered.
+type GCMWriter struct {
+	dst   WriteCloseSyncer
+	aead  cipher.AEAD
+	nonce []byte
+	index uint64
+	buf   []byte
+	out   []byte
+}
+
+// NewGCMWriter returns a new AES-GCM stream encryptor using the current key
+// of the keyring.
+func NewGCMWriter(w WriteCloseSyncer, keyring *Keyring) (*GCMWriter, error) {
+	aead := keyring.keys[keyring.currentID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	preamble := make([]byte, 0, keyIDSize+len(nonce))
+	preamble = append(preamble, keyring.currentID[:]...)
+	preamble = append(preamble, nonce...)
+	n, err := w.Write(preamble)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(preamble) {
+		return nil, io.ErrShortWrite
+	}
+
+	return &GCMWriter{
+		dst:   w,
+		aead:  aead,
+		nonce: nonce,
+		buf:   make([]byte, 0, gcmChunkSize),
+	}, nil
+}
+
+func (w *GCMWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+		if len(w.buf) == cap(w.buf) {
+			if err := w.seal(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// seal encrypts the buffered data as a chunk and writes it.
+func (w *GCMWriter) seal() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	nonce, ad := chunkNonce(w.nonce, w.index)
+	w.out = append(w.out[:0], 0, 0, 0, 0)
+	w.out = w.aead.Seal(w.out, nonce, w.buf, ad)
+	binary.LittleEndian.PutUint32(w.out, uint32(len(w.out)-4))
+	n, err := w.dst.Write(w.out)
+	if err != nil {
+		return err
+	}
+	if n != len(w.out) {
+		return io.ErrShortWrite
+	}
+	w.index++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+func (w *GCMWriter) Close() error {
+	if err := w.seal(); err != nil {
+		w.dst.Close()
+		return err
+	}
+	return w.dst.Close()
+}
+
+func (w *GCMWriter) Sync() error {
+	if err := w.seal(); err != nil {
+		return err
+	}
+	return w.dst.Sync()
+}
+
+// GCMReader decrypts a stream written by GCMWriter.
+type GCMReader struct {
+	src     io.ReadCloser
+	keyring *Keyring
+	aead    cipher.AEAD
+	nonce   []byte
+	index   uint64
+	chunk   []byte
+	plain   []byte
+}
+
+// NewGCMReader returns a new AES-GCM stream decrypter, using the key of the
+// keyring the stream was encrypted with.
+func NewGCMReader(r io.ReadCloser, keyring *Keyring) (*GCMReader, error) {
+	if keyring == nil {
+		return nil, errors.New("segment is encrypted but no encryption keys are configured")
+	}
+	gr := &GCMReader{src: r, keyring: keyring}
+	if err := gr.Reset(); err != nil {
+		return nil, err
+	}
+	return gr, nil
+}
+
+func (r *GCMReader) Read(buf []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(buf, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// open reads and decrypts the next chunk.
+func (r *GCMReader) open() error {
+	var header [4]byte
+	if _, err := io.ReadFull(r.src, header[:]); err != nil {
+		// EOF at a chunk boundary is the end of the stream.
+		return err
+	}
+	size := int(binary.LittleEndian.Uint32(header[:]))
+	if size < r.aead.Overhead() || size > gcmChunkSize+r.aead.Overhead() {
+		return fmt.Errorf("invalid encrypted chunk size %d", size)
+	}
+	if cap(r.chunk) < size {
+		r.chunk = make([]byte, size)
+	}
+	r.chunk = r.chunk[:size]
+	if _, err := io.ReadFull(r.src, r.chunk); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	nonce, ad := chunkNonce(r.nonce, r.index)
+	plain, err := r.aead.Open(r.chunk[:0], nonce, r.chunk, ad)
+	if err != nil {
+		return fmt.Errorf("could not decrypt chunk %d: %w", r.index, err)
+	}
+	r.index++
+	r.plain = plain
+	return nil
+}
+
+func (r *GCMReader) Close() error {
+	return r.src.Close()
+}
+
+// Reset reads the stream preamble and restarts decryption, assumes that
+// the caller has already set the src to the beginning of the stream.
+func (r *GCMReader) Reset() error {
+	var id keyID
+	if _, err := io.ReadFull(r.src, id[:]); err != nil {
+		return err
+	}
+	aead, ok := r.keyring.keys[id]
+	if !ok {
+		return fmt.Errorf("segment is encrypted with unknown key ID %v", id)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(r.src, nonce); err != nil {
+		return err
+	}
+	r.aead = aead
+	r.nonce = nonce
+	r.index = 0
+	r.plain = nil
+	return nil
+}
+
+// chunkNonce returns the nonce and additional data of the chunk with the
+// given index.
+func chunkNonce(base []byte, index uint64) (nonce, ad []byte) {
+	ad = binary.BigEndian.AppendUint64(nil, index)
+	nonce = make([]byte, len(base))
+	copy(nonce, base)
+	for i := range ad {
+		nonce[len(nonce)-len(ad)+i] ^= ad[i]
+	}
+	return nonce, ad
+}
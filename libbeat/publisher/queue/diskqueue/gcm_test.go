@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testGCMKey    = []byte("0123456789abcdef0123456789abcdef")
+	testGCMOldKey = []byte("fedcba9876543210")
+)
+
+func gcmEncrypt(t *testing.T, keyring *Keyring, plaintexts ...[]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw, err := NewGCMWriter(NopWriteCloseSyncer(nopCloser{&buf}), keyring)
+	require.NoError(t, err)
+	for _, p := range plaintexts {
+		n, err := gw.Write(p)
+		require.NoError(t, err)
+		require.Equal(t, len(p), n)
+		require.NoError(t, gw.Sync())
+	}
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestGCMRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		plaintexts [][]byte
+	}{
+		"empty":        {plaintexts: nil},
+		"single chunk": {plaintexts: [][]byte{[]byte("abc")}},
+		"synced":       {plaintexts: [][]byte{[]byte("abc"), []byte("defg")}},
+		"multi chunk":  {plaintexts: [][]byte{bytes.Repeat([]byte("x"), 3*gcmChunkSize+17)}},
+	}
+	keyring, err := NewKeyring(testGCMKey)
+	require.NoError(t, err)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ciphertext := gcmEncrypt(t, keyring, tc.plaintexts...)
+			want := bytes.Join(tc.plaintexts, nil)
+			if len(want) > 0 {
+				assert.NotContains(t, string(ciphertext), string(want[:3]))
+			}
+
+			gr, err := NewGCMReader(io.NopCloser(bytes.NewReader(ciphertext)), keyring)
+			require.NoError(t, err)
+			got, err := io.ReadAll(gr)
+			require.NoError(t, err)
+			assert.Equal(t, len(want), len(got))
+			assert.True(t, bytes.Equal(want, got))
+		})
+	}
+}
+
+func TestGCMKeyRotation(t *testing.T) {
+	old, err := NewKeyring(testGCMOldKey)
+	require.NoError(t, err)
+	ciphertext := gcmEncrypt(t, old, []byte("written before rotation"))
+
+	rotated, err := NewKeyring(testGCMKey, testGCMOldKey)
+	require.NoError(t, err)
+	gr, err := NewGCMReader(io.NopCloser(bytes.NewReader(ciphertext)), rotated)
+	require.NoError(t, err)
+	got, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, "written before rotation", string(got))
+
+	// Without the previous key the segment can't be read.
+	current, err := NewKeyring(testGCMKey)
+	require.NoError(t, err)
+	_, err = NewGCMReader(io.NopCloser(bytes.NewReader(ciphertext)), current)
+	assert.ErrorContains(t, err, "unknown key ID")
+}
+
+func TestGCMTamperDetection(t *testing.T) {
+	keyring, err := NewKeyring(testGCMKey)
+	require.NoError(t, err)
+	ciphertext := gcmEncrypt(t, keyring, []byte("abc"), []byte("defg"))
+
+	// Flip a bit in the last chunk.
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-1] ^= 1
+	gr, err := NewGCMReader(io.NopCloser(bytes.NewReader(tampered)), keyring)
+	require.NoError(t, err)
+	_, err = io.ReadAll(gr)
+	assert.ErrorContains(t, err, "could not decrypt chunk 1")
+
+	// Truncating a chunk is detected too.
+	gr, err = NewGCMReader(io.NopCloser(bytes.NewReader(ciphertext[:len(ciphertext)-1])), keyring)
+	require.NoError(t, err)
+	_, err = io.ReadAll(gr)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestNewKeyringInvalidKey(t *testing.T) {
+	_, err := NewKeyring([]byte("short"))
+	assert.Error(t, err)
+	_, err = NewKeyring(testGCMKey, []byte("short"))
+	assert.Error(t, err)
+}
+
+func TestSegmentsGCMRoundTrip(t *testing.T) {
+	keyring, err := NewKeyring(testGCMKey)
+	require.NoError(t, err)
+	plaintexts := [][]byte{[]byte("abc"), []byte("defg")}
+	for _, compress := range []bool{false, true} {
+		settings := DefaultSettings()
+		settings.Path = t.TempDir()
+		settings.EncryptionKeys = keyring
+		settings.UseCompression = compress
+
+		qs := &queueSegment{id: 0}
+		sw, err := qs.getWriter(settings)
+		require.NoError(t, err)
+		for _, p := range plaintexts {
+			_, err := sw.Write(p)
+			require.NoError(t, err)
+			require.NoError(t, sw.Sync())
+		}
+		require.NoError(t, sw.Close())
+
+		sr, err := qs.getReader(settings)
+		require.NoError(t, err)
+		n, err := sr.Seek(segmentHeaderSize+int64(len(plaintexts[0])), io.SeekStart)
+		require.NoError(t, err)
+		assert.Equal(t, segmentHeaderSize+int64(len(plaintexts[0])), n)
+		dst := make([]byte, len(plaintexts[1]))
+		_, err = io.ReadFull(sr, dst)
+		require.NoError(t, err)
+		assert.Equal(t, plaintexts[1], dst)
+		sr.Close()
+
+		// Segments encrypted with AES-GCM can't be read without keys.
+		settings.EncryptionKeys = nil
+		_, err = qs.getReader(settings)
+		assert.Error(t, err)
+	}
+}
@@ -154,8 +154,16 @@ const (
 	ENABLE_ENCRYPTION  uint32 = 1 << iota // 0x1
 	ENABLE_COMPRESSION                    // 0x2
 	ENABLE_PROTOBUF                       // 0x4
+	ENABLE_AES_GCM                        // 0x8
 )
 
+// encryptionReader is implemented by the readers decrypting segment data,
+// Reset restarts decryption from the beginning of the data region.
+type encryptionReader interface {
+	io.ReadCloser
+	Reset() error
+}
+
 // Sort order: we store loaded segments in ascending order by their id.
 type bySegmentID []*queueSegment
 
@@ -256,7 +264,14 @@ func (segment *queueSegment) getReader(queueSettings Settings) (*segmentReader,
 		sr.serializationFormat = SerializationCBOR
 	}
 
-	if (header.options & ENABLE_ENCRYPTION) == ENABLE_ENCRYPTION {
+	switch {
+	case (header.options & ENABLE_AES_GCM) == ENABLE_AES_GCM:
+		sr.er, err = NewGCMReader(sr.src, queueSettings.EncryptionKeys)
+		if err != nil {
+			sr.src.Close()
+			return nil, fmt.Errorf("couldn't create encryption reader for segment %d: %w", segment.id, err)
+		}
+	case (header.options & ENABLE_ENCRYPTION) == ENABLE_ENCRYPTION:
 		sr.er, err = NewEncryptionReader(sr.src, queueSettings.EncryptionKey)
 		if err != nil {
 			sr.src.Close()
@@ -287,7 +302,11 @@ func (segment *queueSegment) getWriter(queueSettings Settings) (*segmentWriter,
 		return nil, err
 	}
 
-	if len(queueSettings.EncryptionKey) > 0 {
+	// AES-GCM takes precedence over AES-128-CTR, which is kept to read
+	// and write segments of existing queues.
+	if queueSettings.EncryptionKeys != nil {
+		options = options | ENABLE_AES_GCM
+	} else if len(queueSettings.EncryptionKey) > 0 {
 		options = options | ENABLE_ENCRYPTION
 	}
 
@@ -302,7 +321,14 @@ func (segment *queueSegment) getWriter(queueSettings Settings) (*segmentWriter,
 		return nil, err
 	}
 
-	if (options & ENABLE_ENCRYPTION) == ENABLE_ENCRYPTION {
+	switch {
+	case (options & ENABLE_AES_GCM) == ENABLE_AES_GCM:
+		sw.ew, err = NewGCMWriter(sw.dst, queueSettings.EncryptionKeys)
+		if err != nil {
+			sw.dst.Close()
+			return nil, fmt.Errorf("couldn't create encryption writer: %w", err)
+		}
+	case (options & ENABLE_ENCRYPTION) == ENABLE_ENCRYPTION:
 		sw.ew, err = NewEncryptionWriter(sw.dst, queueSettings.EncryptionKey)
 		if err != nil {
 			sw.dst.Close()
@@ -474,7 +500,7 @@ func (segments *diskQueueSegments) sizeOnDisk() uint64 {
 // less compressable.
 type segmentReader struct {
 	src                 io.ReadSeekCloser
-	er                  *EncryptionReader
+	er                  encryptionReader
 	cr                  *CompressionReader
 	serializationFormat SerializationFormat
 }
@@ -545,7 +571,7 @@ func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
 // data less compressable.
 type segmentWriter struct {
 	dst *os.File
-	ew  *EncryptionWriter
+	ew  WriteCloseSyncer
 	cw  *CompressionWriter
 }
 
@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hybridqueue
+
+import (
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+)
+
+// memoryBatch is a batch of events taken from the memory tier.
+type memoryBatch struct {
+	queue   *hybridQueue
+	entries []entry
+}
+
+func (b *memoryBatch) Count() int {
+	return len(b.entries)
+}
+
+func (b *memoryBatch) Entry(i int) queue.Entry {
+	return b.entries[i].event
+}
+
+func (b *memoryBatch) Done() {
+	seqs := make([]uint64, len(b.entries))
+	for i, e := range b.entries {
+		seqs[i] = e.seq
+	}
+	b.queue.ack(seqs, len(seqs))
+	b.queue.observer.RemoveEvents(len(seqs), 0)
+}
+
+// diskBatch is a batch of events read back from the disk tier. Its events
+// were acknowledged to their producers when they were written.
+type diskBatch struct {
+	queue.Batch
+	queue *hybridQueue
+}
+
+func (b *diskBatch) Done() {
+	b.Batch.Done()
+	b.queue.observer.RemoveEvents(b.Count(), 0)
+}
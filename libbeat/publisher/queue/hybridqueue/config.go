@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hybridqueue
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	c "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// Settings contains the configuration fields to create a new hybrid queue.
+type Settings struct {
+	// Events is the maximum number of events held in memory, including the
+	// events that were handed to the output and not acknowledged yet.
+	// Producers block once it is reached.
+	Events int
+
+	// SpillThreshold is the number of events in memory above which the
+	// oldest events that weren't handed to the output yet are moved to disk.
+	SpillThreshold int
+
+	// Disk holds the settings of the disk queue storing the spilled events.
+	Disk diskqueue.Settings
+}
+
+type config struct {
+	Memory memoryConfig `config:"memory"`
+	Disk   *c.C         `config:"disk" validate:"required"`
+}
+
+type memoryConfig struct {
+	Events int `config:"events" validate:"min=32"`
+	// SpillThreshold is the fraction of the memory capacity above which
+	// events are spilled to disk.
+	SpillThreshold float64 `config:"spill_threshold" validate:"min=0"`
+}
+
+var defaultConfig = config{
+	Memory: memoryConfig{
+		Events:         3200,
+		SpillThreshold: 0.8,
+	},
+}
+
+func (c *config) Validate() error {
+	if c.Memory.SpillThreshold > 1 {
+		return errors.New("memory.spill_threshold must be between 0 and 1")
+	}
+	return nil
+}
+
+// SettingsForUserConfig unpacks a ucfg config from a Beats queue
+// configuration and returns the equivalent hybridqueue.Settings object.
+func SettingsForUserConfig(cfg *c.C) (Settings, error) {
+	config := defaultConfig
+	if cfg != nil {
+		if err := cfg.Unpack(&config); err != nil {
+			return Settings{}, fmt.Errorf("couldn't unpack hybrid queue config: %w", err)
+		}
+	}
+	disk, err := diskqueue.SettingsForUserConfig(config.Disk)
+	if err != nil {
+		return Settings{}, err
+	}
+	if disk.Path == "" {
+		// Keep the spilled events apart from the segments of a disk queue
+		// that may have been configured before.
+		disk.Path = paths.Resolve(paths.Data, "hybridqueue")
+	}
+
+	return Settings{
+		Events:         config.Memory.Events,
+		SpillThreshold: int(float64(config.Memory.Events) * config.Memory.SpillThreshold),
+		Disk:           disk,
+	}, nil
+}
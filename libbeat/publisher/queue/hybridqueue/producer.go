@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hybridqueue
+
+import (
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+)
+
+type producer struct {
+	queue       *hybridQueue
+	ackCallback func(count int)
+
+	// closed is protected by queue.mu.
+	closed bool
+}
+
+func (p *producer) Publish(event queue.Entry) (queue.EntryID, bool) {
+	return p.queue.publish(p, event, true)
+}
+
+func (p *producer) TryPublish(event queue.Entry) (queue.EntryID, bool) {
+	return p.queue.publish(p, event, false)
+}
+
+func (p *producer) Close() {
+	p.queue.mu.Lock()
+	defer p.queue.mu.Unlock()
+	p.closed = true
+	// Unblock a pending Publish call.
+	p.queue.cond.Broadcast()
+}
+
+// ackList tracks the acknowledgment state of every event of the queue in
+// publish order. An event is acknowledged once it was written to disk or
+// once its batch was done, but producers are only notified when all their
+// older events were acknowledged too, so they always see their events
+// acknowledged in order.
+type ackList struct {
+	// first is the sequence number of entries[0].
+	first   uint64
+	entries []ackEntry
+}
+
+type ackEntry struct {
+	producer *producer
+	acked    bool
+}
+
+// add appends an event of the given producer and returns its sequence
+// number.
+func (l *ackList) add(p *producer) uint64 {
+	l.entries = append(l.entries, ackEntry{producer: p})
+	return l.first + uint64(len(l.entries)) - 1
+}
+
+func (l *ackList) done(seq uint64) {
+	if seq >= l.first && seq-l.first < uint64(len(l.entries)) {
+		l.entries[seq-l.first].acked = true
+	}
+}
+
+// pop removes the acknowledged events at the front of the list and returns
+// the producer callbacks reporting them.
+func (l *ackList) pop() []func() {
+	var producers []*producer
+	counts := map[*producer]int{}
+	n := 0
+	for n < len(l.entries) && l.entries[n].acked {
+		p := l.entries[n].producer
+		if p.ackCallback != nil {
+			if counts[p] == 0 {
+				producers = append(producers, p)
+			}
+			counts[p]++
+		}
+		n++
+	}
+	// Clear the references so the producers can be garbage collected.
+	clear(l.entries[:n])
+	l.entries = l.entries[n:]
+	l.first += uint64(n)
+
+	callbacks := make([]func(), 0, len(producers))
+	for _, p := range producers {
+		cb, count := p.ackCallback, counts[p]
+		callbacks = append(callbacks, func() { cb(count) })
+	}
+	return callbacks
+}
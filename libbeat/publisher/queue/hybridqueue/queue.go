@@ -0,0 +1,370 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hybridqueue
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// The string used to specify this queue in beats configurations.
+const QueueType = "hybrid"
+
+// hybridQueue keeps the most recent events in memory and moves the oldest
+// events that weren't handed to the output yet to a disk queue once the
+// memory holds more than Settings.SpillThreshold events. Consumers are
+// always served from the disk first, and every event on disk is older than
+// the events waiting in memory, so the events reach the output in the order
+// they were published.
+type hybridQueue struct {
+	logger   *logp.Logger
+	observer queue.Observer
+	settings Settings
+
+	// The disk queue holding the spilled events, and the producer used to
+	// write them.
+	disk         queue.Queue
+	diskProducer queue.Producer
+
+	encoderFactory queue.EncoderFactory
+	encoder        queue.Encoder
+
+	// mu protects all the fields below, cond is signaled whenever they
+	// change in a way that can unblock a producer, a consumer or the spill
+	// loop.
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// pending holds the events in memory that weren't handed to the output
+	// yet, oldest first.
+	pending []entry
+
+	// memEvents is the number of events in memory: the pending events plus
+	// the events in memory batches that weren't acknowledged yet.
+	memEvents int
+
+	// spilling is the number of events sent to the disk queue that weren't
+	// written yet, readable is the number of events written to the disk
+	// queue that weren't read back yet.
+	spilling int
+	readable int
+
+	// spilled holds the sequence numbers of the events that are being
+	// written to disk, in the order they were sent to the disk queue.
+	spilled []uint64
+
+	acks *ackList
+
+	closed bool
+
+	// getMu serializes Get calls, so that a batch read from disk can't be
+	// overtaken by a newer batch from memory.
+	getMu sync.Mutex
+
+	// ackMu serializes the producer callbacks, so they run in the order
+	// the events were acknowledged.
+	ackMu sync.Mutex
+
+	done chan struct{}
+}
+
+// entry is an event waiting in memory and its position in the queue.
+type entry struct {
+	event queue.Entry
+	seq   uint64
+}
+
+// FactoryForSettings is a simple wrapper around NewQueue so a concrete
+// Settings object can be wrapped in a queue-agnostic interface for
+// later use by the pipeline.
+func FactoryForSettings(settings Settings) queue.QueueFactory {
+	return func(
+		logger *logp.Logger,
+		observer queue.Observer,
+		inputQueueSize int,
+		encoderFactory queue.EncoderFactory,
+	) (queue.Queue, error) {
+		return NewQueue(logger, observer, settings, encoderFactory)
+	}
+}
+
+// NewQueue returns a hybrid queue configured with the given logger and
+// settings. Events spilled to disk by a previous run are delivered before
+// any new event.
+func NewQueue(
+	logger *logp.Logger,
+	observer queue.Observer,
+	settings Settings,
+	encoderFactory queue.EncoderFactory,
+) (queue.Queue, error) {
+	logger = logger.Named("hybridqueue")
+	if observer == nil {
+		observer = queue.NewQueueObserver(nil)
+	}
+
+	q := &hybridQueue{
+		logger:         logger,
+		observer:       observer,
+		settings:       settings,
+		encoderFactory: encoderFactory,
+		acks:           &ackList{},
+		done:           make(chan struct{}),
+	}
+	q.cond = sync.NewCond(&q.mu)
+
+	disk, err := diskqueue.NewQueue(logger, &diskObserver{queue: q}, settings.Disk, encoderFactory)
+	if err != nil {
+		return nil, err
+	}
+	q.disk = disk
+	q.diskProducer = disk.Producer(queue.ProducerConfig{ACK: q.handleDiskACK})
+	if encoderFactory != nil {
+		q.encoder = encoderFactory()
+	}
+
+	observer.MaxEvents(settings.Events)
+	go q.spillLoop()
+	return q, nil
+}
+
+func (q *hybridQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.cond.Broadcast()
+	}
+	return nil
+}
+
+func (q *hybridQueue) Done() <-chan struct{} {
+	return q.done
+}
+
+func (q *hybridQueue) QueueType() string {
+	return QueueType
+}
+
+func (q *hybridQueue) BufferConfig() queue.BufferConfig {
+	// The disk tier is bounded by bytes, there is no fixed event limit.
+	return queue.BufferConfig{MaxEvents: 0}
+}
+
+func (q *hybridQueue) Producer(cfg queue.ProducerConfig) queue.Producer {
+	return &producer{queue: q, ackCallback: cfg.ACK}
+}
+
+// Get returns the oldest events of the queue: the events on disk if there
+// are any, the events in memory otherwise.
+func (q *hybridQueue) Get(eventCount int) (queue.Batch, error) {
+	q.getMu.Lock()
+	defer q.getMu.Unlock()
+
+	q.mu.Lock()
+	for {
+		switch {
+		case q.readable > 0:
+			q.mu.Unlock()
+			return q.getFromDisk(eventCount)
+		case q.spilling > 0:
+			// Older events are being written to disk, they must be read
+			// before the events in memory.
+			q.cond.Wait()
+		case len(q.pending) > 0:
+			batch := q.getFromMemory(eventCount)
+			q.mu.Unlock()
+			return batch, nil
+		case q.closed:
+			q.mu.Unlock()
+			return nil, errors.New("tried to read from a closed hybrid queue")
+		default:
+			q.cond.Wait()
+		}
+	}
+}
+
+func (q *hybridQueue) getFromDisk(eventCount int) (queue.Batch, error) {
+	batch, err := q.disk.Get(eventCount)
+	if err != nil {
+		return nil, err
+	}
+	q.mu.Lock()
+	q.readable -= batch.Count()
+	q.mu.Unlock()
+
+	q.observer.ConsumeEvents(batch.Count(), 0)
+	return &diskBatch{queue: q, Batch: batch}, nil
+}
+
+// Called with q.mu held.
+func (q *hybridQueue) getFromMemory(eventCount int) queue.Batch {
+	count := len(q.pending)
+	if eventCount > 0 && eventCount < count {
+		count = eventCount
+	}
+	entries := make([]entry, count)
+	copy(entries, q.pending)
+	q.pending = q.pending[count:]
+
+	for i := range entries {
+		if q.encoder != nil {
+			entries[i].event, _ = q.encoder.EncodeEntry(entries[i].event)
+		}
+	}
+	q.observer.ConsumeEvents(count, 0)
+	return &memoryBatch{queue: q, entries: entries}
+}
+
+// publish adds an event to the memory tier, blocking until there is room
+// for it if shouldBlock is set.
+func (q *hybridQueue) publish(p *producer, event queue.Entry, shouldBlock bool) (queue.EntryID, bool) {
+	q.mu.Lock()
+	for q.memEvents >= q.settings.Events && !q.closed && !p.closed {
+		if !shouldBlock {
+			q.mu.Unlock()
+			return 0, false
+		}
+		q.cond.Wait()
+	}
+	if q.closed || p.closed {
+		q.mu.Unlock()
+		return 0, false
+	}
+
+	seq := q.acks.add(p)
+	q.pending = append(q.pending, entry{event: event, seq: seq})
+	q.memEvents++
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	q.observer.AddEvent(0)
+	return queue.EntryID(seq), true
+}
+
+// spillLoop moves the oldest pending events to disk while the memory holds
+// more events than the spill threshold. When the queue is closed, it moves
+// all the pending events to disk so they survive a restart, then closes
+// the disk queue.
+func (q *hybridQueue) spillLoop() {
+	defer close(q.done)
+
+	for {
+		q.mu.Lock()
+		for !q.closed && (q.memEvents <= q.settings.SpillThreshold || len(q.pending) == 0) {
+			q.cond.Wait()
+		}
+		closed := q.closed
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+			break
+		}
+		e := q.pending[0]
+		q.pending = q.pending[1:]
+		q.memEvents--
+		q.spilling++
+		q.spilled = append(q.spilled, e.seq)
+		// Room was made in memory for a blocked producer.
+		q.cond.Broadcast()
+		q.mu.Unlock()
+
+		var ok bool
+		if closed {
+			// Don't let a full disk block the shutdown.
+			_, ok = q.diskProducer.TryPublish(e.event)
+		} else {
+			_, ok = q.diskProducer.Publish(e.event)
+		}
+		if !ok {
+			q.logger.Warnf("Dropping event that couldn't be spilled to disk")
+			q.mu.Lock()
+			q.spilling--
+			q.spilled = q.spilled[:len(q.spilled)-1]
+			q.cond.Broadcast()
+			q.mu.Unlock()
+			q.ack([]uint64{e.seq}, 0)
+			q.observer.RemoveEvents(1, 0)
+		}
+	}
+
+	q.diskProducer.Close()
+	_ = q.disk.Close()
+	<-q.disk.Done()
+}
+
+// handleDiskACK is called by the disk queue once spilled events have been
+// written. As with the disk queue, they are acknowledged to their producers
+// at this point.
+func (q *hybridQueue) handleDiskACK(count int) {
+	q.mu.Lock()
+	count = min(count, len(q.spilled))
+	seqs := append([]uint64(nil), q.spilled[:count]...)
+	q.spilled = q.spilled[count:]
+	q.spilling -= count
+	q.readable += count
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	q.ack(seqs, 0)
+}
+
+// ack marks the given events as acknowledged and runs the callbacks of the
+// producers whose oldest events are now all acknowledged. memCount is the
+// number of events removed from memory.
+func (q *hybridQueue) ack(seqs []uint64, memCount int) {
+	q.mu.Lock()
+	for _, seq := range seqs {
+		q.acks.done(seq)
+	}
+	callbacks := q.acks.pop()
+	if memCount > 0 {
+		q.memEvents -= memCount
+		q.cond.Broadcast()
+	}
+	// Take ackMu before releasing mu, so the callbacks run in the order they
+	// were collected.
+	q.ackMu.Lock()
+	q.mu.Unlock()
+	defer q.ackMu.Unlock()
+
+	for _, cb := range callbacks {
+		cb()
+	}
+}
+
+// diskObserver forwards the events restored from a previous run to the
+// queue observer. The hybrid queue reports the other events itself.
+type diskObserver struct {
+	queue *hybridQueue
+}
+
+func (o *diskObserver) Restore(eventCount int, byteCount int) {
+	o.queue.mu.Lock()
+	o.queue.readable += eventCount
+	o.queue.mu.Unlock()
+	o.queue.observer.Restore(eventCount, 0)
+}
+
+func (o *diskObserver) MaxEvents(int)          {}
+func (o *diskObserver) MaxBytes(int)           {}
+func (o *diskObserver) AddEvent(int)           {}
+func (o *diskObserver) ConsumeEvents(int, int) {}
+func (o *diskObserver) RemoveEvents(int, int)  {}
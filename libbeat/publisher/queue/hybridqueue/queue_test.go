@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hybridqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/queuetest"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestProduceConsumer(t *testing.T) {
+	events := 1024
+	batchSize := 100
+
+	factory := func(t *testing.T) queue.Queue {
+		return makeTestQueue(t, 64, 32)
+	}
+	t.Run("single", func(t *testing.T) {
+		queuetest.TestSingleProducerConsumer(t, events, batchSize, factory)
+	})
+	t.Run("multi", func(t *testing.T) {
+		queuetest.TestMultiProducerConsumer(t, events, batchSize, factory)
+	})
+}
+
+func TestSpilledEventsAreReadInOrder(t *testing.T) {
+	q := makeTestQueue(t, 64, 16)
+	defer q.Close()
+
+	acked := 0
+	ackChan := make(chan int, 100)
+	p := q.Producer(queue.ProducerConfig{ACK: func(count int) { ackChan <- count }})
+
+	// Without a consumer, everything above the spill threshold ends up on
+	// disk instead of blocking the producer.
+	const total = 200
+	for i := 0; i < total; i++ {
+		_, ok := p.Publish(queuetest.MakeEvent(mapstr.M{"count": i}))
+		require.True(t, ok)
+	}
+
+	// The spilled events are acknowledged once written.
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case count := <-ackChan:
+				acked += count
+			default:
+				return acked >= total-16
+			}
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.LessOrEqual(t, acked, total-16)
+
+	next := 0
+	for next < total {
+		batch, err := q.Get(50)
+		require.NoError(t, err)
+		for i := 0; i < batch.Count(); i++ {
+			event, ok := batch.Entry(i).(publisher.Event)
+			require.True(t, ok)
+			count, err := event.Content.Fields.GetValue("count")
+			require.NoError(t, err)
+			assert.EqualValues(t, next, count)
+			next++
+		}
+		batch.Done()
+	}
+
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case count := <-ackChan:
+				acked += count
+			default:
+				return acked == total
+			}
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestTryPublishFailsWhenMemoryIsFull(t *testing.T) {
+	q := makeTestQueue(t, 32, 32)
+	defer q.Close()
+
+	p := q.Producer(queue.ProducerConfig{})
+	for i := 0; i < 32; i++ {
+		_, ok := p.TryPublish(queuetest.MakeEvent(mapstr.M{"count": i}))
+		require.True(t, ok)
+	}
+	_, ok := p.TryPublish(queuetest.MakeEvent(mapstr.M{"count": 32}))
+	assert.False(t, ok, "events must not be spilled below the spill threshold")
+}
+
+func TestACKListReportsProducersInOrder(t *testing.T) {
+	var acks []int
+	p1 := &producer{ackCallback: func(count int) { acks = append(acks, count) }}
+	p2 := &producer{ackCallback: func(count int) { acks = append(acks, -count) }}
+
+	l := &ackList{}
+	seqs := []uint64{l.add(p1), l.add(p2), l.add(p1), l.add(p1)}
+
+	// Acknowledging newer events doesn't notify anybody while an older
+	// event is outstanding.
+	l.done(seqs[2])
+	l.done(seqs[3])
+	assert.Empty(t, l.pop())
+
+	l.done(seqs[0])
+	for _, cb := range l.pop() {
+		cb()
+	}
+	assert.Equal(t, []int{1}, acks)
+
+	l.done(seqs[1])
+	for _, cb := range l.pop() {
+		cb()
+	}
+	assert.Equal(t, []int{1, -1, 2}, acks)
+	assert.Empty(t, l.entries)
+}
+
+func makeTestQueue(t *testing.T, events, spillThreshold int) queue.Queue {
+	settings := Settings{
+		Events:         events,
+		SpillThreshold: spillThreshold,
+		Disk:           diskqueue.DefaultSettings(),
+	}
+	settings.Disk.Path = t.TempDir()
+	q, err := NewQueue(logp.L(), nil, settings, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		q.Close()
+		<-q.Done()
+	})
+	return q
+}
@@ -62,6 +62,10 @@ type broker struct {
 	// Producers send requests to pushChan to add events to the queue.
 	pushChan chan pushRequest
 
+	// Producers send requests for high priority events to priorityPushChan,
+	// which is still read when only the reserved capacity is left.
+	priorityPushChan chan pushRequest
+
 	// Consumers send requests to getChan to read events from the queue.
 	getChan chan getRequest
 
@@ -105,6 +109,11 @@ type Settings struct {
 	// If positive, the amount of time the queue will wait to fill up
 	// a batch if a Get request asks for more events than we have.
 	FlushTimeout time.Duration
+
+	// The number of events of the queue capacity reserved to high priority
+	// events. Normal events are blocked when only the reserved capacity is
+	// left.
+	ReservedPriorityEvents int
 }
 
 type queueEntry struct {
@@ -114,6 +123,8 @@ type queueEntry struct {
 
 	producer   *ackProducer
 	producerID producerID // The order of this entry within its producer
+
+	priority queue.Priority
 }
 
 type batch struct {
@@ -205,6 +216,11 @@ func newQueue(
 		settings.MaxGetRequest = settings.Events
 	}
 
+	// Normal events must be able to use part of the queue
+	if settings.ReservedPriorityEvents >= settings.Events {
+		settings.ReservedPriorityEvents = settings.Events - 1
+	}
+
 	if logger == nil {
 		logger = logp.NewLogger("memqueue")
 	}
@@ -218,9 +234,10 @@ func newQueue(
 		encoderFactory: encoderFactory,
 
 		// broker API channels
-		pushChan:  make(chan pushRequest, chanSize),
-		getChan:   make(chan getRequest),
-		closeChan: make(chan struct{}),
+		pushChan:         make(chan pushRequest, chanSize),
+		priorityPushChan: make(chan pushRequest, chanSize),
+		getChan:          make(chan getRequest),
+		closeChan:        make(chan struct{}),
 
 		// internal runLoop and ackLoop channels
 		consumedChan: make(chan batchList),
@@ -33,6 +33,10 @@ type config struct {
 	// since it used to control buffer size in the internal buffer chain.
 	MaxGetRequest int           `config:"flush.min_events" validate:"min=0"`
 	FlushTimeout  time.Duration `config:"flush.timeout"`
+
+	// ReservedPriorityEvents is the part of the queue capacity that only
+	// high priority events can use.
+	ReservedPriorityEvents int `config:"priority.reserved_events" validate:"min=0"`
 }
 
 var defaultConfig = config{
@@ -45,6 +49,9 @@ func (c *config) Validate() error {
 	if c.MaxGetRequest > c.Events {
 		return errors.New("flush.min_events must be less events")
 	}
+	if c.ReservedPriorityEvents >= c.Events {
+		return errors.New("priority.reserved_events must be less than events")
+	}
 	return nil
 }
 
@@ -62,5 +69,7 @@ func SettingsForUserConfig(cfg *c.C) (Settings, error) {
 		Events:        config.Events,
 		MaxGetRequest: config.MaxGetRequest,
 		FlushTimeout:  config.FlushTimeout,
+
+		ReservedPriorityEvents: config.ReservedPriorityEvents,
 	}, nil
 }
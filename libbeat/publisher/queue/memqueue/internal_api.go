@@ -36,6 +36,9 @@ type pushRequest struct {
 	// multiple acknowledgments for a producer to a single callback call.
 	producerID producerID
 	resp       chan queue.EntryID
+
+	// The priority of the event, see queue.EntryPriority.
+	priority queue.Priority
 }
 
 // consumer -> broker API
@@ -35,11 +35,12 @@ type ackProducer struct {
 }
 
 type openState struct {
-	log          *logp.Logger
-	done         chan struct{}
-	queueClosing <-chan struct{}
-	events       chan pushRequest
-	encoder      queue.Encoder
+	log            *logp.Logger
+	done           chan struct{}
+	queueClosing   <-chan struct{}
+	events         chan pushRequest
+	priorityEvents chan pushRequest
+	encoder        queue.Encoder
 }
 
 // producerID stores the order of events within a single producer, so multiple
@@ -57,11 +58,12 @@ type ackHandler func(count int)
 
 func newProducer(b *broker, cb ackHandler, encoder queue.Encoder) queue.Producer {
 	openState := openState{
-		log:          b.logger,
-		done:         make(chan struct{}),
-		queueClosing: b.closingChan,
-		events:       b.pushChan,
-		encoder:      encoder,
+		log:            b.logger,
+		done:           make(chan struct{}),
+		queueClosing:   b.closingChan,
+		events:         b.pushChan,
+		priorityEvents: b.priorityPushChan,
+		encoder:        encoder,
 	}
 
 	if cb != nil {
@@ -75,8 +77,9 @@ func newProducer(b *broker, cb ackHandler, encoder queue.Encoder) queue.Producer
 func (p *forgetfulProducer) makePushRequest(event queue.Entry) pushRequest {
 	resp := make(chan queue.EntryID, 1)
 	return pushRequest{
-		event: event,
-		resp:  resp}
+		event:    event,
+		priority: queue.EntryPriority(event),
+		resp:     resp}
 }
 
 func (p *forgetfulProducer) Publish(event queue.Entry) (queue.EntryID, bool) {
@@ -99,6 +102,7 @@ func (p *ackProducer) makePushRequest(event queue.Entry) pushRequest {
 		// We add 1 to the id so the default lastACK of 0 is a
 		// valid initial state and 1 is the first real id.
 		producerID: producerID(p.producedCount + 1),
+		priority:   queue.EntryPriority(event),
 		resp:       resp}
 }
 
@@ -133,7 +137,7 @@ func (st *openState) publish(req pushRequest) (queue.EntryID, bool) {
 		req.event, req.eventSize = st.encoder.EncodeEntry(req.event)
 	}
 	select {
-	case st.events <- req:
+	case st.requests(req) <- req:
 		// The events channel is buffered, which means we may successfully
 		// write to it even if the queue is shutting down. To avoid blocking
 		// forever during shutdown, we also have to wait on the queue's
@@ -142,14 +146,14 @@ func (st *openState) publish(req pushRequest) (queue.EntryID, bool) {
 		case resp := <-req.resp:
 			return resp, true
 		case <-st.queueClosing:
-			st.events = nil
+			st.events, st.priorityEvents = nil, nil
 			return 0, false
 		}
 	case <-st.done:
-		st.events = nil
+		st.events, st.priorityEvents = nil, nil
 		return 0, false
 	case <-st.queueClosing:
-		st.events = nil
+		st.events, st.priorityEvents = nil, nil
 		return 0, false
 	}
 }
@@ -161,7 +165,7 @@ func (st *openState) tryPublish(req pushRequest) (queue.EntryID, bool) {
 		req.event, req.eventSize = st.encoder.EncodeEntry(req.event)
 	}
 	select {
-	case st.events <- req:
+	case st.requests(req) <- req:
 		// The events channel is buffered, which means we may successfully
 		// write to it even if the queue is shutting down. To avoid blocking
 		// forever during shutdown, we also have to wait on the queue's
@@ -170,14 +174,23 @@ func (st *openState) tryPublish(req pushRequest) (queue.EntryID, bool) {
 		case resp := <-req.resp:
 			return resp, true
 		case <-st.queueClosing:
-			st.events = nil
+			st.events, st.priorityEvents = nil, nil
 			return 0, false
 		}
 	case <-st.done:
-		st.events = nil
+		st.events, st.priorityEvents = nil, nil
 		return 0, false
 	default:
 		st.log.Debugf("Dropping event, queue is blocked")
 		return 0, false
 	}
 }
+
+// requests returns the channel the request is sent to, depending on the
+// priority of its event.
+func (st *openState) requests(req pushRequest) chan pushRequest {
+	if req.priority == queue.PriorityHigh {
+		return st.priorityEvents
+	}
+	return st.events
+}
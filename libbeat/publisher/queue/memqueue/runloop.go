@@ -98,10 +98,14 @@ func (l *runLoop) run() {
 // Perform one iteration of the queue's main run loop. Broken out into a
 // standalone helper function to allow testing of loop invariants.
 func (l *runLoop) runIteration() {
-	var pushChan chan pushRequest
-	// Push requests are enabled if the queue isn't full or closing.
+	var pushChan, priorityPushChan chan pushRequest
+	// Push requests are enabled if the queue isn't full or closing. Normal
+	// events can't use the capacity reserved to high priority events.
 	if l.eventCount < len(l.broker.buf) && !l.closing {
-		pushChan = l.broker.pushChan
+		priorityPushChan = l.broker.priorityPushChan
+		if l.eventCount < len(l.broker.buf)-l.broker.settings.ReservedPriorityEvents {
+			pushChan = l.broker.pushChan
+		}
 	}
 
 	var getChan chan getRequest
@@ -138,6 +142,9 @@ func (l *runLoop) runIteration() {
 	case req := <-pushChan: // producer pushing new event
 		l.handleInsert(&req)
 
+	case req := <-priorityPushChan: // producer pushing new high priority event
+		l.handleInsert(&req)
+
 	case req := <-getChan: // consumer asking for next batch
 		l.handleGetRequest(&req)
 
@@ -248,13 +255,40 @@ func (l *runLoop) maybeUnblockGetRequest() {
 }
 
 func (l *runLoop) insert(req *pushRequest, id queue.EntryID) {
-	index := (l.bufPos + l.eventCount) % len(l.broker.buf)
+	pos := l.eventCount
+	if req.priority == queue.PriorityHigh {
+		pos = l.priorityInsertPosition(req)
+		// Shift the entries after the insert position to make room
+		for i := l.eventCount; i > pos; i-- {
+			l.broker.buf[(l.bufPos+i)%len(l.broker.buf)] = l.broker.buf[(l.bufPos+i-1)%len(l.broker.buf)]
+		}
+	}
+
+	index := (l.bufPos + pos) % len(l.broker.buf)
 	l.broker.buf[index] = queueEntry{
 		event:      req.event,
 		eventSize:  req.eventSize,
 		id:         id,
 		producer:   req.producer,
 		producerID: req.producerID,
+		priority:   req.priority,
 	}
 	l.observer.AddEvent(req.eventSize)
 }
+
+// priorityInsertPosition returns the position, relative to bufPos, of a
+// high priority event. It goes ahead of the normal events not yet consumed,
+// but never ahead of another high priority event, nor of an earlier event
+// of its producer, whose acknowledgment callbacks rely on its events being
+// consumed in order.
+func (l *runLoop) priorityInsertPosition(req *pushRequest) int {
+	pos := l.eventCount
+	for pos > l.consumedCount {
+		prev := &l.broker.buf[(l.bufPos+pos-1)%len(l.broker.buf)]
+		if prev.priority == queue.PriorityHigh || (req.producer != nil && prev.producer == req.producer) {
+			break
+		}
+		pos--
+	}
+	return pos
+}
@@ -25,9 +25,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/publisher"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
@@ -186,6 +188,103 @@ func TestObserverRemoveEvents(t *testing.T) {
 	assertRegistryUint(t, reg, "queue.removed.bytes", deleteCount*123, "Deleting from the queue should report the removed bytes")
 }
 
+func TestPriorityEventsBypassBacklog(t *testing.T) {
+	// High priority events are handed out before the normal events waiting
+	// in the queue, but after the events already consumed, the other high
+	// priority events, and the earlier events of their producer.
+	broker := newQueue(
+		logp.NewLogger("testing"),
+		nil,
+		Settings{
+			Events:        20,
+			MaxGetRequest: 20,
+			FlushTimeout:  0,
+		},
+		10, nil)
+
+	rl := broker.runLoop
+	logs := newProducer(broker, func(int) {}, nil)
+	health := newProducer(broker, func(int) {}, nil)
+	publish := func(producer queue.Producer, id string, priority string) {
+		go rl.runIteration()
+		_, ok := producer.Publish(testPriorityEvent(id, priority))
+		require.True(t, ok, "Queue publish call must succeed")
+	}
+	get := func(count int) []string {
+		resp := make(chan queue.Batch, 1)
+		go func() {
+			batch, err := broker.Get(count)
+			assert.NoError(t, err)
+			resp <- batch
+		}()
+		rl.runIteration()
+		batch := <-resp
+		var ids []string
+		for i := 0; i < batch.Count(); i++ {
+			id, _ := batch.Entry(i).(publisher.Event).Content.Fields.GetValue("id")
+			ids = append(ids, id.(string))
+		}
+		return ids
+	}
+
+	publish(logs, "log1", "")
+	publish(logs, "log2", "")
+	assert.Equal(t, []string{"log1"}, get(1))
+
+	publish(logs, "log3", "")
+	publish(health, "health1", "high")
+	publish(logs, "log4", "")
+	publish(health, "health2", "high")
+	publish(logs, "log5", "high")
+
+	assert.Equal(t, []string{"health1", "health2", "log2", "log3", "log4", "log5"}, get(20))
+}
+
+func TestReservedPriorityEvents(t *testing.T) {
+	broker := newQueue(
+		logp.NewLogger("testing"),
+		nil,
+		Settings{
+			Events:                 4,
+			MaxGetRequest:          4,
+			ReservedPriorityEvents: 1,
+		},
+		10, nil)
+
+	rl := broker.runLoop
+	producer := newProducer(broker, nil, nil)
+	for i := 0; i < 3; i++ {
+		go rl.runIteration()
+		_, ok := producer.Publish(testPriorityEvent("log", ""))
+		require.True(t, ok, "Queue publish call must succeed")
+	}
+
+	// Only the reserved capacity is left: the normal event waits while the
+	// high priority one is accepted.
+	normal := make(chan bool, 1)
+	go func() {
+		_, ok := producer.Publish(testPriorityEvent("log", ""))
+		normal <- ok
+	}()
+	priority := make(chan bool, 1)
+	go func() {
+		_, ok := producer.Publish(testPriorityEvent("health", "high"))
+		priority <- ok
+	}()
+	rl.runIteration()
+	assert.True(t, <-priority, "High priority publish call must succeed")
+	assert.Equal(t, 4, rl.eventCount)
+	assert.Len(t, normal, 0, "Normal publish call must block on the reserved capacity")
+}
+
+func testPriorityEvent(id, priority string) publisher.Event {
+	event := beat.Event{Fields: mapstr.M{"id": id}}
+	if priority != "" {
+		event.Meta = mapstr.M{publisher.PriorityMetaKey: priority}
+	}
+	return publisher.Event{Content: event}
+}
+
 func assertRegistryUint(t *testing.T, reg *monitoring.Registry, key string, expected uint64, message string) {
 	t.Helper()
 
@@ -85,6 +85,30 @@ type ProducerConfig struct {
 
 type EntryID uint64
 
+// Priority is the priority of a queue entry. Queues supporting priorities
+// hand out high priority entries before the normal ones still waiting to be
+// consumed, so that they don't wait behind a backlog.
+type Priority uint8
+
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+)
+
+// PriorityEntry is implemented by entries that have a priority. Entries not
+// implementing it have the normal priority.
+type PriorityEntry interface {
+	Priority() Priority
+}
+
+// EntryPriority returns the priority of the entry.
+func EntryPriority(entry Entry) Priority {
+	if e, ok := entry.(PriorityEntry); ok {
+		return e.Priority()
+	}
+	return PriorityNormal
+}
+
 // Producer is an interface to be used by the pipelines client to forward
 // events to a queue.
 type Producer interface {
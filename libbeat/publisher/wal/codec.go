@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Encoding / decoding routines adapted from
+// libbeat/publisher/queue/diskqueue/serialize.go.
+
+package wal
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-structform/cborl"
+	"github.com/elastic/go-structform/gotype"
+)
+
+type entry struct {
+	Timestamp int64
+	Flags     uint32
+	Meta      mapstr.M
+	Fields    mapstr.M
+}
+
+type eventEncoder struct {
+	buf    bytes.Buffer
+	folder *gotype.Iterator
+}
+
+type eventDecoder struct {
+	parser   *cborl.Parser
+	unfolder *gotype.Unfolder
+}
+
+func newEventEncoder() *eventEncoder {
+	e := &eventEncoder{}
+	e.reset()
+	return e
+}
+
+func (e *eventEncoder) reset() {
+	visitor := cborl.NewVisitor(&e.buf)
+	// NewIterator can not fail with the hard-coded options.
+	folder, _ := gotype.NewIterator(visitor,
+		gotype.Folders(
+			codec.MakeTimestampEncoder(),
+			codec.MakeBCTimestampEncoder(),
+		),
+	)
+	e.folder = folder
+}
+
+// encode returns the serialized event. The returned buffer is only valid
+// until the next call to encode.
+func (e *eventEncoder) encode(event publisher.Event) ([]byte, error) {
+	e.buf.Reset()
+
+	err := e.folder.Fold(entry{
+		Timestamp: event.Content.Timestamp.UTC().UnixNano(),
+		Flags:     uint32(event.Flags),
+		Meta:      event.Content.Meta,
+		Fields:    event.Content.Fields,
+	})
+	if err != nil {
+		e.reset()
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+func newEventDecoder() *eventDecoder {
+	d := &eventDecoder{}
+	d.reset()
+	return d
+}
+
+func (d *eventDecoder) reset() {
+	// NewUnfolder can not fail when called with nil.
+	unfolder, _ := gotype.NewUnfolder(nil)
+	d.unfolder = unfolder
+	d.parser = cborl.NewParser(unfolder)
+}
+
+func (d *eventDecoder) decode(buf []byte) (publisher.Event, error) {
+	var to entry
+
+	err := d.unfolder.SetTarget(&to)
+	if err != nil {
+		return publisher.Event{}, err
+	}
+	defer d.unfolder.Reset()
+
+	err = d.parser.Parse(buf)
+	if err != nil {
+		d.reset()
+		return publisher.Event{}, err
+	}
+
+	return publisher.Event{
+		Flags: publisher.EventFlags(to.Flags),
+		Content: beat.Event{
+			Timestamp: time.Unix(0, to.Timestamp),
+			Fields:    to.Fields,
+			Meta:      to.Meta,
+		},
+	}, nil
+}
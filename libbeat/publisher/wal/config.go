@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wal
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+)
+
+// Config holds the settings of the write-ahead log, configured via
+// `pipeline.wal`.
+type Config struct {
+	Enabled bool `config:"enabled"`
+
+	// Path is the directory the log is written to. Defaults to `wal` in the
+	// data path.
+	Path string `config:"path"`
+
+	// SegmentSize is the size after which a new segment file is started.
+	// Segments are deleted once all their events were acknowledged.
+	SegmentSize cfgtype.ByteSize `config:"segment_size"`
+
+	// Sync forces each event to be flushed to disk before it is
+	// acknowledged to the input.
+	Sync bool `config:"sync"`
+}
+
+// DefaultConfig returns the default write-ahead log settings.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:     false,
+		SegmentSize: 64 * 1024 * 1024,
+		Sync:        true,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.SegmentSize < 1024 {
+		return errors.New("segment_size must be at least 1KiB")
+	}
+	return nil
+}
@@ -0,0 +1,461 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package wal implements a write-ahead log for the publisher pipeline.
+// Events are appended to the log before they are acknowledged to the
+// producer, and are replayed after a restart until the output acknowledged
+// them.
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+const (
+	segmentSuffix  = ".wal"
+	checkpointFile = "checkpoint"
+
+	// Each record starts with the payload length, the CRC32 of sequence
+	// number and payload, and the sequence number.
+	recordHeaderSize = 4 + 4 + 8
+
+	// maxRecordSize protects against allocating huge buffers when reading
+	// the length of a corrupt record.
+	maxRecordSize = 1 << 30
+)
+
+var (
+	errClosed        = errors.New("write-ahead log is closed")
+	errCorruptRecord = errors.New("corrupt write-ahead log record")
+)
+
+// Log is a segmented write-ahead log. Each appended event is assigned a
+// sequence number. Acknowledged sequence numbers move the checkpoint forward,
+// and segments that only contain events before the checkpoint are deleted.
+type Log struct {
+	logger *logp.Logger
+	dir    string
+	config Config
+
+	mu      sync.Mutex
+	closed  bool
+	encoder *eventEncoder
+	buf     []byte
+
+	// segments holds the first sequence number of each segment file, the
+	// last one is the active segment.
+	segments        []uint64
+	active          *os.File
+	activeSize      int64
+	nextSeq         uint64
+	replayEnd       uint64
+	checkpoint      uint64
+	acked           map[uint64]struct{}
+	savedCheckpoint uint64 // last checkpoint written to disk
+}
+
+// Open opens or creates the write-ahead log configured by config.
+func Open(config Config, logger *logp.Logger) (*Log, error) {
+	dir := config.Path
+	if dir == "" {
+		dir = paths.Resolve(paths.Data, "wal")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create write-ahead log directory %s: %w", dir, err)
+	}
+
+	l := &Log{
+		logger:  logger,
+		dir:     dir,
+		config:  config,
+		encoder: newEventEncoder(),
+		acked:   map[uint64]struct{}{},
+		nextSeq: 1,
+	}
+
+	checkpoint, err := l.readCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	l.segments, err = l.listSegments()
+	if err != nil {
+		return nil, err
+	}
+	if n := len(l.segments); n > 0 {
+		last, err := l.recoverSegment(l.segments[n-1])
+		if err != nil {
+			return nil, err
+		}
+		l.nextSeq = last + 1
+		if last == 0 {
+			l.nextSeq = l.segments[n-1]
+		}
+	}
+	switch {
+	case len(l.segments) == 0:
+		// Keep sequence numbers increasing, so segment names are not reused.
+		l.nextSeq = max(checkpoint, 1)
+		checkpoint = l.nextSeq
+	case checkpoint < l.segments[0]:
+		checkpoint = l.segments[0]
+	case checkpoint > l.nextSeq:
+		checkpoint = l.nextSeq
+	}
+	l.checkpoint = checkpoint
+	l.savedCheckpoint = checkpoint
+	l.replayEnd = l.nextSeq
+	l.deleteSegments()
+
+	logger.Infof("Write-ahead log opened at %s with %d pending events.", dir, l.replayEnd-l.checkpoint)
+	return l, nil
+}
+
+// Append persists the event and returns its sequence number.
+func (l *Log) Append(event publisher.Event) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return 0, errClosed
+	}
+
+	payload, err := l.encoder.encode(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if l.active == nil || l.activeSize >= int64(l.config.SegmentSize) {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	seq := l.nextSeq
+	l.buf = appendRecord(l.buf[:0], seq, payload)
+	n, err := l.active.Write(l.buf)
+	l.activeSize += int64(n)
+	if err != nil {
+		// Start a new segment, so a partially written record is only ever
+		// at the end of a segment.
+		_ = l.active.Close()
+		l.active = nil
+		return 0, fmt.Errorf("failed to write event: %w", err)
+	}
+	if l.config.Sync {
+		if err := l.active.Sync(); err != nil {
+			return 0, fmt.Errorf("failed to sync write-ahead log: %w", err)
+		}
+	}
+
+	l.nextSeq++
+	return seq, nil
+}
+
+// ACK marks the events with the given sequence numbers as delivered.
+func (l *Log) ACK(seqs ...uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	for _, seq := range seqs {
+		if seq >= l.checkpoint {
+			l.acked[seq] = struct{}{}
+		}
+	}
+
+	advanced := false
+	for {
+		if _, ok := l.acked[l.checkpoint]; !ok {
+			break
+		}
+		delete(l.acked, l.checkpoint)
+		l.checkpoint++
+		advanced = true
+	}
+	if !advanced {
+		return
+	}
+
+	if err := l.writeCheckpoint(); err != nil {
+		l.logger.Errorf("Failed to write write-ahead log checkpoint: %v", err)
+	}
+	l.deleteSegments()
+}
+
+// Replay calls fn for each event that was written before the log was opened
+// and is not yet acknowledged, in order.
+func (l *Log) Replay(fn func(seq uint64, event publisher.Event) error) error {
+	l.mu.Lock()
+	segments := append([]uint64(nil), l.segments...)
+	from, end := l.checkpoint, l.replayEnd
+	l.mu.Unlock()
+
+	decoder := newEventDecoder()
+	for i, first := range segments {
+		if first >= end {
+			break
+		}
+		if i+1 < len(segments) && segments[i+1] <= from {
+			continue
+		}
+
+		done := false
+		err := l.readSegment(first, func(seq uint64, payload []byte) error {
+			if seq >= end {
+				done = true
+				return io.EOF
+			}
+			if seq < from {
+				return nil
+			}
+			event, err := decoder.decode(payload)
+			if err != nil {
+				l.logger.Errorf("Skipping event %d that can not be decoded: %v", seq, err)
+				l.ACK(seq)
+				return nil
+			}
+			return fn(seq, event)
+		})
+		if errors.Is(err, errCorruptRecord) {
+			l.logger.Warnf("Write-ahead log segment %d ends with a corrupt record, skipping the rest of it.", first)
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+	return nil
+}
+
+// Close writes the checkpoint and closes the active segment.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+
+	err := l.writeCheckpoint()
+	if l.active != nil {
+		if syncErr := l.active.Sync(); err == nil {
+			err = syncErr
+		}
+		if closeErr := l.active.Close(); err == nil {
+			err = closeErr
+		}
+		l.active = nil
+	}
+	return err
+}
+
+func (l *Log) rotate() error {
+	if l.active != nil {
+		if err := l.active.Sync(); err != nil {
+			return fmt.Errorf("failed to sync write-ahead log segment: %w", err)
+		}
+		if err := l.active.Close(); err != nil {
+			return fmt.Errorf("failed to close write-ahead log segment: %w", err)
+		}
+		l.active = nil
+	}
+
+	f, err := os.OpenFile(l.segmentPath(l.nextSeq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create write-ahead log segment: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat write-ahead log segment: %w", err)
+	}
+
+	l.active = f
+	l.activeSize = info.Size()
+	if n := len(l.segments); n == 0 || l.segments[n-1] != l.nextSeq {
+		l.segments = append(l.segments, l.nextSeq)
+	}
+	return nil
+}
+
+// deleteSegments removes all segments, except the active one, that only
+// contain acknowledged events.
+func (l *Log) deleteSegments() {
+	for len(l.segments) > 1 && l.segments[1] <= l.checkpoint {
+		path := l.segmentPath(l.segments[0])
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			l.logger.Errorf("Failed to delete write-ahead log segment %s: %v", path, err)
+			return
+		}
+		l.segments = l.segments[1:]
+	}
+}
+
+// recoverSegment returns the last valid sequence number in the segment,
+// truncating a partially written record at its end.
+func (l *Log) recoverSegment(first uint64) (uint64, error) {
+	var last uint64
+	var valid int64
+	err := l.readSegment(first, func(seq uint64, payload []byte) error {
+		last = seq
+		valid += int64(recordHeaderSize + len(payload))
+		return nil
+	})
+	if errors.Is(err, errCorruptRecord) {
+		l.logger.Warnf("Truncating corrupt end of write-ahead log segment %d after %d bytes.", first, valid)
+		if err := os.Truncate(l.segmentPath(first), valid); err != nil {
+			return 0, fmt.Errorf("failed to truncate write-ahead log segment: %w", err)
+		}
+		err = nil
+	}
+	return last, err
+}
+
+// readSegment calls fn for each record in the segment. errCorruptRecord is
+// returned if the segment ends with an invalid record.
+func (l *Log) readSegment(first uint64, fn func(seq uint64, payload []byte) error) error {
+	f, err := os.Open(l.segmentPath(first))
+	if err != nil {
+		return fmt.Errorf("failed to open write-ahead log segment: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header := make([]byte, recordHeaderSize)
+	var payload []byte
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errCorruptRecord
+		}
+		size := binary.LittleEndian.Uint32(header[0:4])
+		checksum := binary.LittleEndian.Uint32(header[4:8])
+		seq := binary.LittleEndian.Uint64(header[8:16])
+
+		if size > maxRecordSize {
+			return errCorruptRecord
+		}
+		if cap(payload) < int(size) {
+			payload = make([]byte, size)
+		}
+		payload = payload[:size]
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return errCorruptRecord
+		}
+		if recordChecksum(header[8:16], payload) != checksum {
+			return errCorruptRecord
+		}
+
+		if err := fn(seq, payload); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *Log) listSegments() ([]uint64, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list write-ahead log segments: %w", err)
+	}
+
+	var segments []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		first, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, first)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+func (l *Log) readCheckpoint() (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir, checkpointFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read write-ahead log checkpoint: %w", err)
+	}
+	if len(data) != 8 {
+		l.logger.Warnf("Ignoring invalid write-ahead log checkpoint, all events will be replayed.")
+		return 0, nil
+	}
+	return binary.LittleEndian.Uint64(data), nil
+}
+
+func (l *Log) writeCheckpoint() error {
+	if l.checkpoint == l.savedCheckpoint {
+		return nil
+	}
+
+	path := filepath.Join(l.dir, checkpointFile)
+	tmp := path + ".tmp"
+	data := binary.LittleEndian.AppendUint64(nil, l.checkpoint)
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	l.savedCheckpoint = l.checkpoint
+	return nil
+}
+
+func (l *Log) segmentPath(first uint64) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%020d%s", first, segmentSuffix))
+}
+
+func appendRecord(buf []byte, seq uint64, payload []byte) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(payload)))
+	seqBytes := binary.LittleEndian.AppendUint64(nil, seq)
+	buf = binary.LittleEndian.AppendUint32(buf, recordChecksum(seqBytes, payload))
+	buf = append(buf, seqBytes...)
+	return append(buf, payload...)
+}
+
+func recordChecksum(seq []byte, payload []byte) uint32 {
+	crc := crc32.ChecksumIEEE(seq)
+	return crc32.Update(crc, crc32.IEEETable, payload)
+}
@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestReplayUnacknowledged(t *testing.T) {
+	config := testConfig(t)
+
+	l, err := Open(config, logp.NewLogger("wal"))
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		seq, err := l.Append(testEvent(i))
+		require.NoError(t, err)
+		require.Equal(t, uint64(i+1), seq)
+	}
+	// 4 is acknowledged out of order and must still be replayed, as the
+	// checkpoint only moves past contiguously acknowledged events.
+	l.ACK(1, 2, 4)
+	require.NoError(t, l.Close())
+
+	l, err = Open(config, logp.NewLogger("wal"))
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, []int{2, 3, 4}, replayed(t, l))
+
+	// New events continue after the last written sequence number and are
+	// not part of the replay.
+	seq, err := l.Append(testEvent(5))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), seq)
+	assert.Equal(t, []int{2, 3, 4}, replayed(t, l))
+}
+
+func TestSegmentsDeletedWhenAcknowledged(t *testing.T) {
+	config := testConfig(t)
+	config.SegmentSize = 1024
+
+	l, err := Open(config, logp.NewLogger("wal"))
+	require.NoError(t, err)
+	defer l.Close()
+
+	var seqs []uint64
+	for i := 0; i < 100; i++ {
+		seq, err := l.Append(testEvent(i))
+		require.NoError(t, err)
+		seqs = append(seqs, seq)
+	}
+	require.Greater(t, len(segmentFiles(t, config.Path)), 1)
+
+	l.ACK(seqs...)
+	assert.Len(t, segmentFiles(t, config.Path), 1, "only the active segment must be kept")
+}
+
+func TestCorruptTailIsTruncated(t *testing.T) {
+	config := testConfig(t)
+
+	l, err := Open(config, logp.NewLogger("wal"))
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := l.Append(testEvent(i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, l.Close())
+
+	// Simulate a partially written record.
+	files := segmentFiles(t, config.Path)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{42, 0, 0, 0, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	l, err = Open(config, logp.NewLogger("wal"))
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, []int{0, 1, 2}, replayed(t, l))
+	seq, err := l.Append(testEvent(3))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), seq)
+}
+
+func testConfig(t *testing.T) Config {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Path = t.TempDir()
+	config.Sync = false
+	return config
+}
+
+func testEvent(i int) publisher.Event {
+	return publisher.Event{
+		Content: beat.Event{
+			Timestamp: time.Now(),
+			Fields:    mapstr.M{"message": "test event", "i": i},
+		},
+	}
+}
+
+func replayed(t *testing.T, l *Log) []int {
+	t.Helper()
+	var events []int
+	err := l.Replay(func(_ uint64, event publisher.Event) error {
+		v, err := event.Content.Fields.GetValue("i")
+		require.NoError(t, err)
+		// the integer type is not preserved by the encoding
+		i, err := strconv.Atoi(fmt.Sprint(v))
+		require.NoError(t, err)
+		events = append(events, i)
+		return nil
+	})
+	require.NoError(t, err)
+	return events
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentSuffix))
+	require.NoError(t, err)
+	return files
+}
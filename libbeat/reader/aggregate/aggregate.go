@@ -0,0 +1,337 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/reader"
+	"github.com/elastic/beats/v7/libbeat/reader/readfile"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/match"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+var sigAggregateTimeout = errors.New("aggregate timeout")
+
+// Parser joins messages sharing the same correlation ID into a single
+// message. An aggregation is flushed when a message matches the end
+// pattern, when it contains max_messages messages or when it is older than
+// the timeout. Messages without a correlation ID are passed through.
+//
+// The Bytes of an aggregated message is the sum of the Bytes of its parts, so
+// inputs tracking the file offset stay correct once all aggregations are
+// flushed. If persist is enabled, pending aggregations are written to the
+// data path on Close and restored when the same file is read again.
+type Parser struct {
+	reader      reader.Reader
+	logger      *logp.Logger
+	field       string
+	pattern     *regexp.Regexp
+	endPattern  *match.Matcher
+	timeout     time.Duration
+	maxMessages int
+	separator   []byte
+	persist     bool
+
+	mu      sync.Mutex
+	closed  bool
+	pending map[string]*aggregation
+	ready   []reader.Message
+	err     error
+
+	// loaded is set once persisted aggregations were looked up, stateFile
+	// is the file they are persisted to and skip the number of bytes
+	// already contained in the restored aggregations.
+	loaded    bool
+	stateFile string
+	skip      int
+}
+
+type aggregation struct {
+	ID      string    `json:"id"`
+	Ts      time.Time `json:"ts"`
+	Started time.Time `json:"started"`
+	Content []byte    `json:"content"`
+	Count   int       `json:"count"`
+	Bytes   int       `json:"bytes"`
+	Fields  mapstr.M  `json:"fields,omitempty"`
+}
+
+// New creates a new aggregate parser reading from r.
+func New(r reader.Reader, c *Config) (*Parser, error) {
+	p := &Parser{
+		logger:      logp.NewLogger("reader_aggregate"),
+		field:       c.Field,
+		endPattern:  c.EndPattern,
+		timeout:     c.Timeout,
+		maxMessages: c.MaxMessages,
+		separator:   []byte(c.Separator),
+		persist:     c.Persist,
+		pending:     map[string]*aggregation{},
+	}
+	if c.Pattern != "" {
+		var err error
+		p.pattern, err = regexp.Compile(c.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	if p.timeout > 0 {
+		r = readfile.NewTimeoutReader(r, sigAggregateTimeout, p.timeout)
+	}
+	p.reader = r
+	return p, nil
+}
+
+// Next returns the next aggregated or passed through message.
+func (p *Parser) Next() (reader.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		if len(p.ready) != 0 {
+			message := p.ready[0]
+			p.ready = p.ready[1:]
+			return message, nil
+		}
+		if p.err != nil {
+			return reader.Message{}, p.err
+		}
+		if p.closed {
+			return reader.Message{}, io.EOF
+		}
+
+		// Do not hold the lock while waiting for the next message, so
+		// Close can persist the pending aggregations.
+		p.mu.Unlock()
+		message, err := p.reader.Next()
+		p.mu.Lock()
+
+		if p.closed {
+			return reader.Message{}, io.EOF
+		}
+		if err != nil {
+			if errors.Is(err, sigAggregateTimeout) {
+				p.flushExpired(time.Now())
+				continue
+			}
+			// Pending aggregations are kept for Close if they are
+			// persisted, otherwise they are flushed before the error.
+			if !p.persist {
+				p.flush(func(*aggregation) bool { return true })
+			}
+			p.err = err
+			continue
+		}
+
+		p.flushExpired(time.Now())
+		p.add(message)
+	}
+}
+
+func (p *Parser) add(message reader.Message) {
+	if p.persist && !p.loaded {
+		p.load(message)
+	}
+
+	if p.skip > 0 {
+		// The message was read before the last shutdown and is already
+		// part of a restored aggregation.
+		p.skip -= message.Bytes + message.Offset
+		return
+	}
+
+	id, ok := p.correlationID(message)
+	if !ok {
+		p.ready = append(p.ready, message)
+		return
+	}
+
+	agg, ok := p.pending[id]
+	if !ok {
+		agg = &aggregation{
+			ID:      id,
+			Ts:      message.Ts,
+			Started: time.Now(),
+			Fields:  message.Fields.Clone(),
+		}
+		p.pending[id] = agg
+	} else {
+		agg.Content = append(agg.Content, p.separator...)
+	}
+	agg.Content = append(agg.Content, message.Content...)
+	agg.Count++
+	agg.Bytes += message.Bytes + message.Offset
+
+	if p.endPattern != nil && p.endPattern.Match(message.Content) {
+		p.emit(agg, true)
+	} else if agg.Count >= p.maxMessages {
+		p.logger.Debugf("Aggregation %s flushed because max_messages was reached.", id)
+		p.emit(agg, false)
+	}
+}
+
+func (p *Parser) correlationID(message reader.Message) (string, bool) {
+	if p.pattern != nil {
+		m := p.pattern.FindSubmatch(message.Content)
+		if len(m) < 2 || len(m[1]) == 0 {
+			return "", false
+		}
+		return string(m[1]), true
+	}
+
+	v, err := message.Fields.GetValue(p.field)
+	if err != nil || v == nil {
+		return "", false
+	}
+	id := fmt.Sprint(v)
+	return id, id != ""
+}
+
+func (p *Parser) flushExpired(now time.Time) {
+	if p.timeout <= 0 {
+		return
+	}
+	p.flush(func(agg *aggregation) bool {
+		return now.Sub(agg.Started) >= p.timeout
+	})
+}
+
+// flush emits all pending aggregations selected by fn in the order they
+// were started.
+func (p *Parser) flush(fn func(*aggregation) bool) {
+	var aggs []*aggregation
+	for _, agg := range p.pending {
+		if fn(agg) {
+			aggs = append(aggs, agg)
+		}
+	}
+	sort.Slice(aggs, func(i, j int) bool {
+		return aggs[i].Started.Before(aggs[j].Started)
+	})
+	for _, agg := range aggs {
+		p.logger.Debugf("Aggregation %s flushed before it was complete.", agg.ID)
+		p.emit(agg, false)
+	}
+}
+
+func (p *Parser) emit(agg *aggregation, complete bool) {
+	delete(p.pending, agg.ID)
+
+	fields := agg.Fields
+	if fields == nil {
+		fields = mapstr.M{}
+	}
+	_, _ = fields.Put("aggregate.id", agg.ID)
+	_, _ = fields.Put("aggregate.count", agg.Count)
+	_, _ = fields.Put("aggregate.complete", complete)
+
+	p.ready = append(p.ready, reader.Message{
+		Ts:      agg.Ts,
+		Content: agg.Content,
+		Bytes:   agg.Bytes,
+		Fields:  fields,
+	})
+}
+
+// load restores the aggregations persisted for the source of message.
+func (p *Parser) load(message reader.Message) {
+	p.loaded = true
+
+	source := "default"
+	if v, err := message.Fields.GetValue("log.file.path"); err == nil {
+		source = fmt.Sprint(v)
+	}
+	p.stateFile = filepath.Join(paths.Resolve(paths.Data, "aggregate_parser"), fmt.Sprintf("%x.json", sha256.Sum256([]byte(source))))
+
+	f, err := os.Open(p.stateFile)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			p.logger.Errorf("Failed to open persisted aggregations %s: %v", p.stateFile, err)
+		}
+		return
+	}
+	defer f.Close()
+
+	var aggs []*aggregation
+	if err := json.NewDecoder(f).Decode(&aggs); err != nil {
+		p.logger.Errorf("Failed to read persisted aggregations %s: %v", p.stateFile, err)
+		return
+	}
+	for _, agg := range aggs {
+		p.pending[agg.ID] = agg
+		p.skip += agg.Bytes
+	}
+	p.logger.Debugf("Restored %d aggregations from %s.", len(aggs), p.stateFile)
+
+	if err := os.Remove(p.stateFile); err != nil {
+		p.logger.Errorf("Failed to remove persisted aggregations %s: %v", p.stateFile, err)
+	}
+}
+
+// save writes the pending aggregations to the state file.
+func (p *Parser) save() error {
+	aggs := make([]*aggregation, 0, len(p.pending))
+	for _, agg := range p.pending {
+		aggs = append(aggs, agg)
+	}
+
+	err := os.MkdirAll(filepath.Dir(p.stateFile), 0o700)
+	if err != nil {
+		return err
+	}
+	tmp := p.stateFile + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	err = json.NewEncoder(f).Encode(aggs)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.stateFile)
+}
+
+func (p *Parser) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	if p.persist && p.loaded && len(p.pending) != 0 {
+		if err := p.save(); err != nil {
+			p.logger.Errorf("Failed to persist %d aggregations: %v", len(p.pending), err)
+		}
+	}
+	p.mu.Unlock()
+
+	return p.reader.Close()
+}
@@ -0,0 +1,196 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/reader"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+func TestParser(t *testing.T) {
+	tests := map[string]struct {
+		config   map[string]interface{}
+		input    []reader.Message
+		expected []reader.Message
+	}{
+		"aggregate by pattern with end pattern": {
+			config: map[string]interface{}{
+				"pattern":     `txn=(\w+)`,
+				"end_pattern": "^commit",
+			},
+			input: []reader.Message{
+				{Content: []byte("begin txn=a"), Bytes: 12},
+				{Content: []byte("begin txn=b"), Bytes: 12},
+				{Content: []byte("no transaction"), Bytes: 15},
+				{Content: []byte("commit txn=a"), Bytes: 13},
+				{Content: []byte("update txn=b"), Bytes: 13},
+			},
+			expected: []reader.Message{
+				{Content: []byte("no transaction"), Bytes: 15},
+				{
+					Content: []byte("begin txn=a\ncommit txn=a"),
+					Bytes:   25,
+					Fields:  mapstr.M{"aggregate": mapstr.M{"id": "a", "count": 2, "complete": true}},
+				},
+				{
+					Content: []byte("begin txn=b\nupdate txn=b"),
+					Bytes:   25,
+					Fields:  mapstr.M{"aggregate": mapstr.M{"id": "b", "count": 2, "complete": false}},
+				},
+			},
+		},
+		"aggregate by field with max messages": {
+			config: map[string]interface{}{
+				"field":        "syslog.procid",
+				"max_messages": 2,
+				"separator":    " ",
+			},
+			input: []reader.Message{
+				{Content: []byte("one"), Bytes: 4, Fields: mapstr.M{"syslog": mapstr.M{"procid": 42}}},
+				{Content: []byte("two"), Bytes: 4, Fields: mapstr.M{"syslog": mapstr.M{"procid": 42}}},
+				{Content: []byte("three"), Bytes: 6, Fields: mapstr.M{"syslog": mapstr.M{"procid": 42}}},
+			},
+			expected: []reader.Message{
+				{
+					Content: []byte("one two"),
+					Bytes:   8,
+					Fields: mapstr.M{
+						"syslog":    mapstr.M{"procid": 42},
+						"aggregate": mapstr.M{"id": "42", "count": 2, "complete": false},
+					},
+				},
+				{
+					Content: []byte("three"),
+					Bytes:   6,
+					Fields: mapstr.M{
+						"syslog":    mapstr.M{"procid": 42},
+						"aggregate": mapstr.M{"id": "42", "count": 1, "complete": false},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newTestParser(t, test.config, test.input)
+
+			var messages []reader.Message
+			msg, err := p.Next()
+			for err == nil {
+				messages = append(messages, msg)
+				msg, err = p.Next()
+			}
+			require.ErrorIs(t, err, io.EOF)
+			require.Equal(t, test.expected, messages)
+		})
+	}
+}
+
+func TestParserPersist(t *testing.T) {
+	origDataPath := paths.Paths.Data
+	t.Cleanup(func() {
+		paths.Paths.Data = origDataPath
+	})
+	paths.Paths.Data = t.TempDir()
+
+	cfg := map[string]interface{}{
+		"pattern":     `txn=(\w+)`,
+		"end_pattern": "^commit",
+		"persist":     true,
+	}
+	fields := mapstr.M{"log": mapstr.M{"file": mapstr.M{"path": "/var/log/app.log"}}}
+	first := reader.Message{Content: []byte("begin txn=a"), Bytes: 12, Fields: fields}
+	second := reader.Message{Content: []byte("commit txn=a"), Bytes: 13, Fields: fields}
+
+	// The parser is closed before the transaction is complete.
+	p := newTestParser(t, cfg, []reader.Message{first})
+	_, err := p.Next()
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, p.Close())
+
+	// The first message is read again after the restart as the offset was
+	// not advanced, it must be skipped.
+	p = newTestParser(t, cfg, []reader.Message{first, second})
+	msg, err := p.Next()
+	require.NoError(t, err)
+	require.Equal(t, "begin txn=a\ncommit txn=a", string(msg.Content))
+	require.Equal(t, 25, msg.Bytes)
+	complete, err := msg.Fields.GetValue("aggregate.complete")
+	require.NoError(t, err)
+	require.Equal(t, true, complete)
+
+	_, err = p.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, cfg := range map[string]map[string]interface{}{
+		"no correlation":        {},
+		"field and pattern":     {"field": "id", "pattern": `id=(\d+)`},
+		"pattern without group": {"pattern": `id=\d+`},
+		"invalid pattern":       {"pattern": `id=(\d+`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := DefaultConfig()
+			err := config.MustNewConfigFrom(cfg).Unpack(&c)
+			require.Error(t, err)
+		})
+	}
+}
+
+func newTestParser(t *testing.T, cfg map[string]interface{}, input []reader.Message) *Parser {
+	t.Helper()
+	c := DefaultConfig()
+	err := config.MustNewConfigFrom(cfg).Unpack(&c)
+	require.NoError(t, err)
+	p, err := New(newTestReader(input), &c)
+	require.NoError(t, err)
+	return p
+}
+
+type testReader struct {
+	msg []reader.Message
+	idx int
+}
+
+func newTestReader(input []reader.Message) reader.Reader {
+	return &testReader{
+		msg: input,
+		idx: 0,
+	}
+}
+
+func (r *testReader) Next() (reader.Message, error) {
+	if r.idx == len(r.msg) {
+		return reader.Message{}, io.EOF
+	}
+
+	m := r.msg[r.idx]
+	r.idx += 1
+	return m, nil
+}
+
+func (r *testReader) Close() error { return nil }
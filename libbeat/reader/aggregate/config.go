@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/match"
+)
+
+// Config holds the options of the aggregate parser.
+type Config struct {
+	// Field is the field of the message holding the correlation ID.
+	Field string `config:"field"`
+	// Pattern is a regular expression applied to the message content.
+	// Its first capture group is used as the correlation ID.
+	Pattern     string         `config:"pattern"`
+	EndPattern  *match.Matcher `config:"end_pattern"`
+	Timeout     time.Duration  `config:"timeout" validate:"positive"`
+	MaxMessages int            `config:"max_messages" validate:"positive"`
+	Separator   string         `config:"separator"`
+	Persist     bool           `config:"persist"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:     30 * time.Second,
+		MaxMessages: 1000,
+		Separator:   "\n",
+	}
+}
+
+// Validate validates the Config option for the aggregate parser.
+func (c *Config) Validate() error {
+	if (c.Field == "") == (c.Pattern == "") {
+		return errors.New("exactly one of field or pattern must be set")
+	}
+	if c.Pattern != "" {
+		re, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		if re.NumSubexp() < 1 {
+			return errors.New("pattern must contain a capture group for the correlation ID")
+		}
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
 	"github.com/elastic/beats/v7/libbeat/reader"
+	"github.com/elastic/beats/v7/libbeat/reader/aggregate"
 	"github.com/elastic/beats/v7/libbeat/reader/filter"
 	"github.com/elastic/beats/v7/libbeat/reader/multiline"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile"
@@ -128,6 +129,13 @@ func NewConfig(pCfg CommonConfig, parsers []config.Namespace) (*Config, error) {
 			if err != nil {
 				return nil, fmt.Errorf("error while parsing include_message parser config: %w", err)
 			}
+		case "aggregate":
+			config := aggregate.DefaultConfig()
+			cfg := ns.Config()
+			err := cfg.Unpack(&config)
+			if err != nil {
+				return nil, fmt.Errorf("error while parsing aggregate parser config: %w", err)
+			}
 		default:
 			return nil, fmt.Errorf("%s: %w", name, ErrNoSuchParser)
 		}
@@ -141,6 +149,11 @@ func NewConfig(pCfg CommonConfig, parsers []config.Namespace) (*Config, error) {
 
 }
 
+// HasParsers returns true if at least one parser is configured.
+func (c *Config) HasParsers() bool {
+	return len(c.parsers) > 0
+}
+
 func (c *Config) Create(in reader.Reader) Parser {
 	p := in
 	for _, ns := range c.parsers {
@@ -189,6 +202,18 @@ func (c *Config) Create(in reader.Reader) Parser {
 				return p
 			}
 			p = filter.NewParser(p, &config)
+		case "aggregate":
+			config := aggregate.DefaultConfig()
+			cfg := ns.Config()
+			err := cfg.Unpack(&config)
+			if err != nil {
+				return p
+			}
+			ap, err := aggregate.New(p, &config)
+			if err != nil {
+				return p
+			}
+			p = ap
 		default:
 			return p
 		}
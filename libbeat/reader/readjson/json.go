@@ -20,11 +20,13 @@ package readjson
 import (
 	"bytes"
 	gojson "encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/jsonparse"
 	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
 	"github.com/elastic/beats/v7/libbeat/reader"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -33,9 +35,10 @@ import (
 
 // JSONReader parses JSON inputs
 type JSONReader struct {
-	reader reader.Reader
-	cfg    *Config
-	logger *logp.Logger
+	reader  reader.Reader
+	cfg     *Config
+	decoder *jsonparse.Decoder
+	logger  *logp.Logger
 }
 
 type JSONParser struct {
@@ -46,18 +49,20 @@ type JSONParser struct {
 // NewJSONReader creates a new reader that can decode JSON.
 func NewJSONReader(r reader.Reader, cfg *Config) *JSONReader {
 	return &JSONReader{
-		reader: r,
-		cfg:    cfg,
-		logger: logp.NewLogger("reader_json"),
+		reader:  r,
+		cfg:     cfg,
+		decoder: jsonparse.NewDecoder(cfg.Decoder),
+		logger:  logp.NewLogger("reader_json"),
 	}
 }
 
 func NewJSONParser(r reader.Reader, cfg *ParserConfig) *JSONParser {
 	return &JSONParser{
 		JSONReader{
-			reader: r,
-			cfg:    &cfg.Config,
-			logger: logp.NewLogger("parser_json"),
+			reader:  r,
+			cfg:     &cfg.Config,
+			decoder: jsonparse.NewDecoder(cfg.Decoder),
+			logger:  logp.NewLogger("parser_json"),
 		},
 		cfg.Field,
 		cfg.Target,
@@ -69,7 +74,7 @@ func NewJSONParser(r reader.Reader, cfg *ParserConfig) *JSONParser {
 func (r *JSONReader) decode(text []byte) ([]byte, mapstr.M) {
 	var jsonFields map[string]interface{}
 
-	err := unmarshal(text, &jsonFields)
+	err := r.unmarshal(text, &jsonFields)
 	if err != nil || jsonFields == nil {
 		if !r.cfg.IgnoreDecodingError {
 			r.logger.Errorf("Error decoding JSON: %v", err)
@@ -103,6 +108,29 @@ func (r *JSONReader) decode(text []byte) ([]byte, mapstr.M) {
 	return []byte(textString), jsonFields
 }
 
+// unmarshal decodes text using the fast decoder. Invalid input and documents
+// that are not objects are passed on to encoding/json so that users get the
+// same error messages as before.
+func (r *JSONReader) unmarshal(text []byte, fields *map[string]interface{}) error {
+	v, _, err := r.decoder.Decode(text)
+	if err == nil {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			*fields = v
+			return nil
+		case nil:
+			*fields = nil
+			return nil
+		}
+	}
+
+	var syntaxErr *jsonparse.SyntaxError
+	if err != nil && !errors.As(err, &syntaxErr) {
+		return err
+	}
+	return unmarshal(text, fields)
+}
+
 // unmarshal is equivalent with json.Unmarshal but it converts numbers
 // to int64 where possible, instead of using always float64.
 func unmarshal(text []byte, fields *map[string]interface{}) error {
@@ -17,6 +17,8 @@
 
 package readjson
 
+import "github.com/elastic/beats/v7/libbeat/common/jsonparse"
+
 // Config holds the options a JSON reader.
 type Config struct {
 	MessageKey          string `config:"message_key"`
@@ -26,6 +28,8 @@ type Config struct {
 	AddErrorKey         bool   `config:"add_error_key"`
 	IgnoreDecodingError bool   `config:"ignore_decoding_error"`
 	ExpandKeys          bool   `config:"expand_keys"`
+
+	Decoder jsonparse.Config `config:",inline"`
 }
 
 type ParserConfig struct {
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/jsonparse"
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -182,12 +183,34 @@ func TestDecodeJSON(t *testing.T) {
 			ExpectedText: `{"message": "test", "value": "`,
 			ExpectedMap:  nil,
 		},
+		{
+			// Duplicate keys keep the first value if configured
+			Text:         `{"message": "test", "message": "other"}`,
+			Config:       Config{MessageKey: "message", Decoder: jsonparse.Config{DuplicateKeys: jsonparse.DuplicateKeysFirst}},
+			ExpectedText: "test",
+			ExpectedMap:  mapstr.M{"message": "test"},
+		},
+		{
+			// Duplicate keys are reported as error if configured
+			Text:         `{"message": "test", "message": "other"}`,
+			Config:       Config{MessageKey: "message", AddErrorKey: true, Decoder: jsonparse.Config{DuplicateKeys: jsonparse.DuplicateKeysError}},
+			ExpectedText: `{"message": "test", "message": "other"}`,
+			ExpectedMap:  mapstr.M{"error": mapstr.M{"message": "Error decoding JSON: duplicate key 'message' in JSON object", "type": "json"}},
+		},
+		{
+			// Documents larger than max_size are not decoded
+			Text:         `{"message": "test"}`,
+			Config:       Config{MessageKey: "message", AddErrorKey: true, Decoder: jsonparse.Config{MaxSize: 10}},
+			ExpectedText: `{"message": "test"}`,
+			ExpectedMap:  mapstr.M{"error": mapstr.M{"message": "Error decoding JSON: JSON document exceeds the maximum size", "type": "json"}},
+		},
 	}
 
 	for _, test := range tests {
 
 		var p JSONReader
 		p.cfg = &test.Config
+		p.decoder = jsonparse.NewDecoder(test.Config.Decoder)
 		p.logger = logp.NewLogger("json_test")
 		text, M := p.decode([]byte(test.Text))
 		assert.Equal(t, test.ExpectedText, string(text))
@@ -196,8 +196,9 @@ func (m *message) setRawSDValue(v string) {
 	}
 }
 
-// fields produces fields from the message.
-func (m message) fields() mapstr.M {
+// fields produces fields from the message. Structured data elements are
+// filtered with sdConfig.
+func (m message) fields(sdConfig StructuredDataConfig) mapstr.M {
 	f := mapstr.M{}
 	msg := m.msg
 
@@ -229,7 +230,9 @@ func (m message) fields() mapstr.M {
 		_, _ = f.Put("log.syslog.version", strconv.Itoa(m.version))
 	}
 	if data := parseStructuredData(m.rawSDValue); data != nil {
-		_, _ = f.Put("log.syslog.structured_data", data)
+		if data = sdConfig.filter(data); len(data) > 0 {
+			_, _ = f.Put("log.syslog.structured_data", data)
+		}
 	} else {
 		// Raw structured data value is prepended to the message field
 		// if it could not be parsed properly. The message is not altered
@@ -18,6 +18,7 @@
 package syslog
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -539,6 +540,48 @@ func TestParseStructuredData(t *testing.T) {
 				},
 			},
 		},
+		"escapes": {
+			in: `[a@1 quote="say \"hi\"" bs="C:\\temp" bracket="[x\]" other="a\nb"]`,
+			want: map[string]interface{}{
+				"a@1": map[string]interface{}{
+					"quote":   `say "hi"`,
+					"bs":      `C:\temp`,
+					"bracket": `[x]`,
+					"other":   `a\nb`,
+				},
+			},
+		},
+		"empty-value": {
+			in: `[a@1 empty="" foo="bar"]`,
+			want: map[string]interface{}{
+				"a@1": map[string]interface{}{
+					"empty": "",
+					"foo":   "bar",
+				},
+			},
+		},
+		"unescaped-bracket": {
+			in: `[a@1 foo="[bar]"]`,
+			want: map[string]interface{}{
+				"a@1": map[string]interface{}{
+					"foo": "[bar]",
+				},
+			},
+		},
+		"no-params": {
+			in: `[a@1]`,
+			want: map[string]interface{}{
+				"a@1": map[string]interface{}{},
+			},
+		},
+		"unterminated-value": {
+			in:   `[a@1 foo="bar\"]`,
+			want: nil,
+		},
+		"id-too-long": {
+			in:   `[` + strings.Repeat("a", 33) + ` foo="bar"]`,
+			want: nil,
+		},
 		"non-compliant": {
 			in:   `[action:"Drop"; flags:"278528"; ifdir:"inbound"; ifname:"bond1.3999"; loguid:"{0x60928f1d,0x8,0x40de101f,0xfcdbb197}"; origin:"127.0.0.1"; originsicname:"CN=CP,O=cp.com.9jjkfo"; sequencenum:"62"; time:"1620217629"; version:"5"; __policy_id_tag:"product=VPN-1 & FireWall-1[db_tag={F6212FB3-54CE-6344-9164-B224119E2B92};mgmt=cp-m;date=1620031791;policy_name=CP-Cluster]"; action_reason:"Dropped by multiportal infrastructure"; dst:"81.2.69.144"; product:"VPN & FireWall"; proto:"6"; s_port:"52780"; service:"80"; src:"81.2.69.144"]`,
 			want: nil,
@@ -689,7 +732,7 @@ func TestMessage_Fields(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			got := tc.in.fields()
+			got := tc.in.fields(StructuredDataConfig{})
 
 			assert.Equal(t, tc.want, got)
 		})
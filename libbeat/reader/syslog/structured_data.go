@@ -0,0 +1,172 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StructuredDataConfig configures which RFC 5424 structured data elements are
+// added to the message fields.
+type StructuredDataConfig struct {
+	// If set, only elements with these SD-IDs are kept.
+	IncludeIDs []string `config:"include_ids"`
+	// Elements with these SD-IDs are dropped.
+	ExcludeIDs []string `config:"exclude_ids"`
+}
+
+// Validate ensures the SD-IDs are valid SD-NAMEs.
+func (c *StructuredDataConfig) Validate() error {
+	for _, ids := range [][]string{c.IncludeIDs, c.ExcludeIDs} {
+		for _, id := range ids {
+			if !isSDName(id) {
+				return fmt.Errorf("invalid structured data ID %q", id)
+			}
+		}
+	}
+	return nil
+}
+
+// filter removes the elements that are not included, or are excluded, from data.
+func (c StructuredDataConfig) filter(data map[string]interface{}) map[string]interface{} {
+	if len(c.IncludeIDs) == 0 && len(c.ExcludeIDs) == 0 {
+		return data
+	}
+	for id := range data {
+		if (len(c.IncludeIDs) > 0 && !contains(c.IncludeIDs, id)) || contains(c.ExcludeIDs, id) {
+			delete(data, id)
+		}
+	}
+	return data
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStructuredData performs a best effort parsing of the raw structured data value
+// extracted from the syslog message. If the raw structured data value is an empty
+// string or the nil value ('-'), nil is returned. Otherwise, the value is parsed
+// using the format defined by RFC 5424. If the value cannot be parsed, then nil
+// is returned.
+//
+// Param values are unescaped as described in RFC 5424 section 6.3.3: a backslash
+// is removed when it precedes '"', '\' or ']', and kept as a regular character
+// otherwise. Empty param values and unescaped ']' in param values are accepted.
+func parseStructuredData(data string) map[string]interface{} {
+	if data == "" || data == "-" {
+		return nil
+	}
+
+	structuredData := map[string]interface{}{}
+	for p := 0; p < len(data); {
+		var ok bool
+		if p, ok = parseSDElement(data, p, structuredData); !ok {
+			return nil
+		}
+	}
+	if len(structuredData) == 0 {
+		return nil
+	}
+
+	return structuredData
+}
+
+// parseSDElement parses the SD-ELEMENT starting at p into structuredData. It
+// returns the position after the element and false if the element is invalid.
+func parseSDElement(data string, p int, structuredData map[string]interface{}) (int, bool) {
+	if data[p] != '[' {
+		return p, false
+	}
+	p++
+
+	tok := p
+	p = scanSDName(data, p)
+	id := data[tok:p]
+	if id == "" {
+		return p, false
+	}
+	params, ok := structuredData[id].(map[string]interface{})
+	if !ok {
+		params = map[string]interface{}{}
+		structuredData[id] = params
+	}
+
+	for p < len(data) && data[p] == ' ' {
+		p++
+		tok = p
+		p = scanSDName(data, p)
+		name := data[tok:p]
+		if name == "" || !strings.HasPrefix(data[p:], `="`) {
+			return p, false
+		}
+		p += 2
+
+		var escapes []int
+		tok = p
+		for ; p < len(data) && data[p] != '"'; p++ {
+			if data[p] == '\\' && p+1 < len(data) {
+				switch data[p+1] {
+				case '"', '\\', ']':
+					escapes = append(escapes, p)
+					p++
+				}
+			}
+		}
+		if p == len(data) {
+			return p, false
+		}
+		params[name] = removeBytes(data[tok:p], escapes, tok)
+		p++
+	}
+
+	if p == len(data) || data[p] != ']' {
+		return p, false
+	}
+	return p + 1, true
+}
+
+// scanSDName returns the position after the SD-NAME starting at p. The SD-NAME
+// is empty if it exceeds 32 characters.
+func scanSDName(data string, p int) int {
+	tok := p
+	for p < len(data) && isSDNameChar(data[p]) {
+		p++
+	}
+	if p-tok > 32 {
+		return tok
+	}
+	return p
+}
+
+// isSDName returns true if v is a valid SD-NAME.
+func isSDName(v string) bool {
+	return v != "" && scanSDName(v, 0) == len(v)
+}
+
+// isSDNameChar returns true if c is allowed in an SD-NAME: printable US-ASCII
+// except '=', ' ', ']' and '"'.
+func isSDNameChar(c byte) bool {
+	return c > ' ' && c < 0x7f && c != '=' && c != ']' && c != '"'
+}
@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructuredDataConfig_Filter(t *testing.T) {
+	tests := map[string]struct {
+		cfg  StructuredDataConfig
+		want []string
+	}{
+		"default": {
+			want: []string{"a@1", "b@1", "origin"},
+		},
+		"include": {
+			cfg:  StructuredDataConfig{IncludeIDs: []string{"a@1", "origin"}},
+			want: []string{"a@1", "origin"},
+		},
+		"exclude": {
+			cfg:  StructuredDataConfig{ExcludeIDs: []string{"origin"}},
+			want: []string{"a@1", "b@1"},
+		},
+		"include-and-exclude": {
+			cfg:  StructuredDataConfig{IncludeIDs: []string{"a@1", "origin"}, ExcludeIDs: []string{"origin"}},
+			want: []string{"a@1"},
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data := parseStructuredData(`[a@1 foo="bar"][b@1 foo="baz"][origin ip="192.0.2.1"]`)
+			got := tc.cfg.filter(data)
+
+			var ids []string
+			for id := range got {
+				ids = append(ids, id)
+			}
+			assert.ElementsMatch(t, tc.want, ids)
+		})
+	}
+}
+
+func TestStructuredDataConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		cfg     StructuredDataConfig
+		wantErr string
+	}{
+		"valid": {
+			cfg: StructuredDataConfig{IncludeIDs: []string{"exampleSDID@32473"}, ExcludeIDs: []string{"origin"}},
+		},
+		"invalid-include": {
+			cfg:     StructuredDataConfig{IncludeIDs: []string{"a b"}},
+			wantErr: `invalid structured data ID "a b"`,
+		},
+		"invalid-exclude": {
+			cfg:     StructuredDataConfig{ExcludeIDs: []string{""}},
+			wantErr: `invalid structured data ID ""`,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.cfg.Validate()
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
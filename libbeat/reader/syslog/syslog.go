@@ -118,6 +118,8 @@ type Config struct {
 	LogErrors bool `config:"log_errors"`
 	// If true, errors will be added to the message fields under the error.message field.
 	AddErrorKey bool `config:"add_error_key"`
+	// The RFC 5424 structured data elements added to the message fields.
+	StructuredData StructuredDataConfig `config:"structured_data"`
 }
 
 // DefaultConfig will return a Config with default values.
@@ -131,9 +133,10 @@ func DefaultConfig() Config {
 }
 
 // ParseMessage will parse syslog message data formatted as format into fields. loc is used to enrich
-// timestamps that lack a time zone. The error value will indicate any errors encountered during parsing.
-// Even if an error is returned, fields may still contain useful values.
-func ParseMessage(data string, format Format, loc *time.Location) (mapstr.M, time.Time, error) {
+// timestamps that lack a time zone, and sdConfig selects the structured data elements added to fields.
+// The error value will indicate any errors encountered during parsing. Even if an error is returned,
+// fields may still contain useful values.
+func ParseMessage(data string, format Format, loc *time.Location, sdConfig StructuredDataConfig) (mapstr.M, time.Time, error) {
 	var m message
 	var err error
 
@@ -150,7 +153,7 @@ func ParseMessage(data string, format Format, loc *time.Location) (mapstr.M, tim
 		m, err = parseRFC5424(data)
 	}
 
-	return m.fields(), m.timestamp, err
+	return m.fields(sdConfig), m.timestamp, err
 }
 
 // Parser is a syslog parser that implements parser.Parser.
@@ -172,7 +175,7 @@ func (p *Parser) Next() (reader.Message, error) {
 		return msg, err
 	}
 
-	fields, ts, err := ParseMessage(string(msg.Content), p.cfg.Format, p.cfg.TimeZone.Location())
+	fields, ts, err := ParseMessage(string(msg.Content), p.cfg.Format, p.cfg.TimeZone.Location(), p.cfg.StructuredData)
 	if err != nil {
 		if p.cfg.LogErrors {
 			p.logger.Errorf("Error parsing syslog message: %v", err)
@@ -143,6 +143,49 @@ func TestNewParser(t *testing.T) {
 				},
 			},
 		},
+		"structured-data-exclude-ids": {
+			config: func() Config {
+				c := DefaultConfig()
+				c.StructuredData.ExcludeIDs = []string{"examplePriority@32473"}
+				return c
+			}(),
+			in: [][]byte{
+				[]byte(`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1024 ID47 [exampleSDID@32473 path="C:\\temp\\app" note="a\nb"][examplePriority@32473 class="high"] this is the message`),
+			},
+			want: []testResult{
+				{
+					timestamp: mustParseTime(time.RFC3339Nano, "2003-10-11T22:14:15.003Z", nil),
+					content:   []byte("this is the message"),
+					fields: mapstr.M{
+						"log": mapstr.M{
+							"syslog": mapstr.M{
+								"priority": 165,
+								"facility": mapstr.M{
+									"code": 20,
+									"name": "local4",
+								},
+								"severity": mapstr.M{
+									"code": 5,
+									"name": "Notice",
+								},
+								"hostname": "mymachine.example.com",
+								"appname":  "evntslog",
+								"procid":   "1024",
+								"msgid":    "ID47",
+								"version":  "1",
+								"structured_data": map[string]interface{}{
+									"exampleSDID@32473": map[string]interface{}{
+										"path": `C:\temp\app`,
+										"note": `a\nb`,
+									},
+								},
+							},
+						},
+						"message": "this is the message",
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -52,6 +52,7 @@ type ESClient interface {
 	Request(method, path string, pipeline string, params map[string]string, body interface{}) (int, []byte, error)
 	GetVersion() version.V
 	IsServerless() bool
+	IsOpenSearch() bool
 }
 
 // FileLoader implements Loader interface for loading templates to a File.
@@ -74,6 +75,7 @@ type StatusError struct {
 type templateBuilder struct {
 	log          *logp.Logger
 	isServerless bool
+	isOpenSearch bool
 }
 
 // NewESLoader creates a new template loader for ES
@@ -81,8 +83,10 @@ func NewESLoader(client ESClient, lifecycleClient lifecycle.ClientHandler) (*ESL
 	if client == nil {
 		return nil, errors.New("can not load template without active Elasticsearch client")
 	}
+	builder := newTemplateBuilder(client.IsServerless())
+	builder.isOpenSearch = client.IsOpenSearch()
 	return &ESLoader{client: client, lifecycleClient: lifecycleClient,
-		builder: newTemplateBuilder(client.IsServerless()), log: logp.NewLogger("template_loader")}, nil
+		builder: builder, log: logp.NewLogger("template_loader")}, nil
 }
 
 // NewFileLoader creates a new template loader for the given file.
@@ -251,10 +255,17 @@ func (b *templateBuilder) template(config TemplateConfig, info beat.Info, esVers
 		b.log.Info("template config not enabled")
 		return nil, nil
 	}
+	if b.isOpenSearch {
+		// The version of OpenSearch can't be compared with the versions of
+		// Elasticsearch, the template is built for the version it was forked
+		// from, without the types it does not support.
+		esVersion = *openSearchForkVersion
+	}
 	tmpl, err := New(b.isServerless, info.Version, info.IndexPrefix, info.ElasticLicensed, esVersion, config, migration)
 	if err != nil {
 		return nil, fmt.Errorf("error creating template instance: %w", err)
 	}
+	tmpl.isOpenSearch = b.isOpenSearch
 	return tmpl, nil
 }
 
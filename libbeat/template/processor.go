@@ -37,13 +37,32 @@ var (
 	minVersionWildcard                = version.MustNew("7.9.0")
 	minVersionExplicitDynamicTemplate = version.MustNew("7.13.0")
 	minVersionMatchOnlyText           = version.MustNew("7.14.0")
+
+	// openSearchForkVersion is the version of Elasticsearch OpenSearch was
+	// forked from.
+	openSearchForkVersion = version.MustNew("7.10.2")
 )
 
+// openSearchTypes are the field types OpenSearch does not support, replaced
+// by the closest type it supports. Fields of the types replaced by "" are not
+// mapped.
+var openSearchTypes = map[string]string{
+	"match_only_text":         "text",
+	"wildcard":                "keyword",
+	"constant_keyword":        "keyword",
+	"version":                 "keyword",
+	"flattened":               "flat_object",
+	"histogram":               "",
+	"aggregate_metric_double": "",
+}
+
 // Processor struct to process fields to template
 type Processor struct {
 	EsVersion       version.V
 	Migration       bool
 	ElasticLicensed bool
+	// OpenSearch is set if the template is built for OpenSearch.
+	OpenSearch bool
 
 	// dynamicTemplatesMap records which dynamic templates have been added, to prevent duplicates.
 	dynamicTemplatesMap map[dynamicTemplateKey]mapstr.M
@@ -75,6 +94,15 @@ func (p *Processor) Process(fields mapping.Fields, state *fieldState, output, an
 			continue
 		}
 
+		if p.OpenSearch {
+			if typ, ok := openSearchTypes[field.Type]; ok {
+				if typ == "" {
+					continue
+				}
+				field.Type = typ
+			}
+		}
+
 		field.Path = state.Path
 		if field.DefaultField == nil {
 			field.DefaultField = &state.DefaultField
@@ -456,6 +484,9 @@ func (p *Processor) object(f *mapping.Field) mapstr.M {
 			dynProperties["type"] = otp.ObjectType
 			matchingType = matchType(otp.ObjectType, otp.ObjectTypeMappingType)
 		case "histogram":
+			if p.OpenSearch {
+				continue
+			}
 			dynProperties["type"] = otp.ObjectType
 			matchingType = matchType("*", otp.ObjectTypeMappingType)
 		default:
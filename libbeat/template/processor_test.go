@@ -993,3 +993,29 @@ func TestProcessNestedSupportNoSubfields(t *testing.T) {
 
 	assert.Equal(t, expectedOutput, output)
 }
+
+func TestProcessOpenSearch(t *testing.T) {
+	fields := mapping.Fields{
+		mapping.Field{Name: "message", Type: "match_only_text"},
+		mapping.Field{Name: "path", Type: "wildcard"},
+		mapping.Field{Name: "kind", Type: "constant_keyword"},
+		mapping.Field{Name: "release", Type: "version"},
+		mapping.Field{Name: "labels", Type: "flattened"},
+		mapping.Field{Name: "latency", Type: "histogram"},
+		mapping.Field{Name: "stats", Type: "aggregate_metric_double"},
+	}
+
+	output := mapstr.M{}
+	p := Processor{EsVersion: *openSearchForkVersion, ElasticLicensed: true, OpenSearch: true}
+	err := p.Process(fields, nil, output, mapstr.M{})
+	require.NoError(t, err)
+
+	expectedOutput := mapstr.M{
+		"message": mapstr.M{"type": "text", "norms": false},
+		"path":    mapstr.M{"type": "keyword", "ignore_above": 1024},
+		"kind":    mapstr.M{"type": "keyword", "ignore_above": 1024},
+		"release": mapstr.M{"type": "keyword", "ignore_above": 1024},
+		"labels":  mapstr.M{"type": "flat_object"},
+	}
+	assert.Equal(t, expectedOutput, output)
+}
@@ -54,6 +54,7 @@ type Template struct {
 	migration       bool
 	priority        int
 	isServerless    bool
+	isOpenSearch    bool
 }
 
 // New creates a new template instance
@@ -161,7 +162,7 @@ func (t *Template) load(fields mapping.Fields) (mapstr.M, error) {
 	// Start processing at the root
 	properties := mapstr.M{}
 	analyzers := mapstr.M{}
-	processor := Processor{EsVersion: t.esVersion, ElasticLicensed: t.elasticLicensed, Migration: t.migration}
+	processor := Processor{EsVersion: t.esVersion, ElasticLicensed: t.elasticLicensed, Migration: t.migration, OpenSearch: t.isOpenSearch}
 	if err := processor.Process(fields, nil, properties, analyzers); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package powershell runs PowerShell scripts that write their result as JSON
+// to standard output. It is used by metricsets that read data only exposed
+// through PowerShell modules or COM objects.
+package powershell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Executable is the PowerShell executable used to run scripts.
+var Executable = "powershell.exe"
+
+// preamble makes the scripts fail on the first error and write UTF-8.
+const preamble = "$ErrorActionPreference = 'Stop'; [Console]::OutputEncoding = [System.Text.Encoding]::UTF8; "
+
+// Run runs script and decodes the JSON it writes to standard output into v.
+// The script must serialize its result, for example with ConvertTo-Json.
+func Run(ctx context.Context, script string, v interface{}) error {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, Executable, "-NoProfile", "-NonInteractive", "-NoLogo", "-Command", preamble+script)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("powershell: %w", ctxErr)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("powershell: %w: %s", err, msg)
+		}
+		return fmt.Errorf("powershell: %w", err)
+	}
+	return Decode(stdout.Bytes(), v)
+}
+
+// Decode decodes the output of a script into v. Empty output, as written by
+// ConvertTo-Json for an empty pipeline, leaves v unchanged.
+func Decode(output []byte, v interface{}) error {
+	// Windows PowerShell prefixes UTF-8 output with a byte order mark.
+	output = bytes.TrimPrefix(output, []byte("\xef\xbb\xbf"))
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(output, v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return fmt.Errorf("powershell: invalid JSON output: %w", err)
+		}
+		return fmt.Errorf("powershell: unexpected output: %w", err)
+	}
+	return nil
+}
+
+// Quote quotes s as a single-quoted PowerShell string literal.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package powershell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	var out []struct {
+		Name  string
+		Count int
+	}
+	require.NoError(t, Decode([]byte("\xef\xbb\xbf[{\"Name\":\"a\",\"Count\":1}]\r\n"), &out))
+	assert.Len(t, out, 1)
+	assert.Equal(t, "a", out[0].Name)
+
+	out = nil
+	require.NoError(t, Decode([]byte("\r\n"), &out))
+	assert.Nil(t, out)
+
+	assert.ErrorContains(t, Decode([]byte("Get-Foo : not recognized"), &out), "invalid JSON output")
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `'dhcp01'`, Quote("dhcp01"))
+	assert.Equal(t, `'it''s'`, Quote("it's"))
+}
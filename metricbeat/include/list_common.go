@@ -68,9 +68,12 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy"
 	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy/server"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd"
+	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/alarm"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/leader"
+	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/lease"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/metrics"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/self"
+	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/status"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/store"
 	_ "github.com/elastic/beats/v7/metricbeat/module/golang"
 	_ "github.com/elastic/beats/v7/metricbeat/module/golang/expvar"
@@ -112,6 +115,8 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/node_stats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/memcached"
 	_ "github.com/elastic/beats/v7/metricbeat/module/memcached/stats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/metricbeat"
+	_ "github.com/elastic/beats/v7/metricbeat/module/metricbeat/health"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/collstats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/dbstats"
@@ -142,7 +147,10 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/activity"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/bgwriter"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/database"
+	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/replication_slot"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/statement"
+	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/subscription"
+	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/vacuum"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus/collector"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus/query"
@@ -164,10 +172,12 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/entropy"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/filesystem"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/fsstat"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/inventory"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/load"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/memory"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/network"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/network_summary"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/pressure"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/process"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/process_summary"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/raid"
@@ -185,8 +195,11 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/host"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/virtualmachine"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows"
+	_ "github.com/elastic/beats/v7/metricbeat/module/windows/defender"
+	_ "github.com/elastic/beats/v7/metricbeat/module/windows/dhcp"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows/perfmon"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows/service"
+	_ "github.com/elastic/beats/v7/metricbeat/module/windows/update"
 	_ "github.com/elastic/beats/v7/metricbeat/module/zookeeper"
 	_ "github.com/elastic/beats/v7/metricbeat/module/zookeeper/connection"
 	_ "github.com/elastic/beats/v7/metricbeat/module/zookeeper/mntr"
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Error classes reported in MetricSetHealth.ErrorClasses.
+const (
+	ErrorClassTimeout    = "timeout"
+	ErrorClassConnection = "connection"
+	ErrorClassPermission = "permission"
+	ErrorClassNotFound   = "not_found"
+	ErrorClassOther      = "other"
+)
+
+// MetricSetHealth is a snapshot of the outcome of the fetches of all running
+// instances of a module/metricset combination.
+type MetricSetHealth struct {
+	Module    string
+	MetricSet string
+	Period    time.Duration // Fetch period, zero for push metricsets.
+
+	Successes int64 // Total events reported without error.
+	Failures  int64 // Total events reported with an error.
+
+	LastSuccess time.Time
+	LastFailure time.Time
+	LastError   string
+
+	// ErrorClasses counts the reported errors by class.
+	ErrorClasses map[string]int64
+}
+
+// health tracks the fetch outcomes of a module/metricset combination.
+type health struct {
+	module    string
+	metricSet string
+
+	mu           sync.Mutex
+	period       time.Duration
+	lastSuccess  time.Time
+	lastFailure  time.Time
+	lastError    string
+	errorClasses map[string]int64
+}
+
+func newHealth(module, metricSet string) *health {
+	return &health{
+		module:       module,
+		metricSet:    metricSet,
+		errorClasses: map[string]int64{},
+	}
+}
+
+func (h *health) setPeriod(period time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.period = period
+}
+
+func (h *health) success(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastSuccess = now
+}
+
+func (h *health) failure(now time.Time, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastFailure = now
+	h.lastError = err.Error()
+	h.errorClasses[classifyError(err)]++
+}
+
+// Health returns a snapshot of the fetch health of all running metricsets,
+// sorted by module and metricset name.
+func Health() []MetricSetHealth {
+	fetchesLock.Lock()
+	snapshots := make([]MetricSetHealth, 0, len(fetches))
+	for _, s := range fetches {
+		snapshots = append(snapshots, s.snapshot())
+	}
+	fetchesLock.Unlock()
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].Module != snapshots[j].Module {
+			return snapshots[i].Module < snapshots[j].Module
+		}
+		return snapshots[i].MetricSet < snapshots[j].MetricSet
+	})
+	return snapshots
+}
+
+func (s *stats) snapshot() MetricSetHealth {
+	h := s.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	errorClasses := make(map[string]int64, len(h.errorClasses))
+	for class, count := range h.errorClasses {
+		errorClasses[class] = count
+	}
+	return MetricSetHealth{
+		Module:       h.module,
+		MetricSet:    h.metricSet,
+		Period:       h.period,
+		Successes:    s.success.Get(),
+		Failures:     s.failures.Get(),
+		LastSuccess:  h.lastSuccess,
+		LastFailure:  h.lastFailure,
+		LastError:    h.lastError,
+		ErrorClasses: errorClasses,
+	}
+}
+
+// classifyError maps a fetch error to a coarse error class.
+func classifyError(err error) string {
+	var netErr net.Error
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.Is(err, os.ErrPermission):
+		return ErrorClassPermission
+	case errors.Is(err, os.ErrNotExist):
+		return ErrorClassNotFound
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET),
+		errors.As(err, &opErr), errors.As(err, &dnsErr):
+		return ErrorClassConnection
+	default:
+		return ErrorClassOther
+	}
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	testCases := map[string]struct {
+		err   error
+		class string
+	}{
+		"deadline":           {err: fmt.Errorf("fetch: %w", context.DeadlineExceeded), class: ErrorClassTimeout},
+		"net timeout":        {err: &net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}, class: ErrorClassTimeout},
+		"connection refused": {err: fmt.Errorf("fetch: %w", syscall.ECONNREFUSED), class: ErrorClassConnection},
+		"dns":                {err: &net.DNSError{Err: "no such host", Name: "example"}, class: ErrorClassConnection},
+		"permission":         {err: &os.PathError{Op: "open", Path: "/proc/1/io", Err: os.ErrPermission}, class: ErrorClassPermission},
+		"not found":          {err: &os.PathError{Op: "open", Path: "/proc/1/io", Err: os.ErrNotExist}, class: ErrorClassNotFound},
+		"other":              {err: errors.New("unexpected response"), class: ErrorClassOther},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.class, classifyError(test.err))
+		})
+	}
+}
+
+func TestHealthFailure(t *testing.T) {
+	h := newHealth("fake", "metricset")
+	now := time.Now()
+
+	h.failure(now, syscall.ECONNREFUSED)
+	h.failure(now, errors.New("boom"))
+	h.success(now)
+
+	assert.Equal(t, now, h.lastSuccess)
+	assert.Equal(t, now, h.lastFailure)
+	assert.Equal(t, "boom", h.lastError)
+	assert.Equal(t, map[string]int64{ErrorClassConnection: 1, ErrorClassOther: 1}, h.errorClasses)
+}
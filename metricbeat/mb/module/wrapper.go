@@ -78,6 +78,7 @@ type stats struct {
 	success  *monitoring.Int // Total success events.
 	failures *monitoring.Int // Total error events.
 	events   *monitoring.Int // Total events published.
+	health   *health         // Fetch outcomes reported by Health.
 }
 
 // NewWrapper creates a new module and its associated metricsets based on the given configuration.
@@ -110,6 +111,10 @@ func createWrapper(module mb.Module, metricSets []mb.MetricSet, options ...Optio
 			module:    wrapper,
 			stats:     getMetricSetStats(wrapper.Name(), metricSet.Name()),
 		}
+		switch metricSet.(type) {
+		case mb.ReportingMetricSet, mb.ReportingMetricSetV2, mb.ReportingMetricSetV2Error, mb.ReportingMetricSetV2WithContext: //nolint:staticcheck // ReportingMetricSet is deprecated but not removed
+			wrapper.metricSets[i].stats.health.setPeriod(module.Config().Period)
+		}
 	}
 	return wrapper, nil
 }
@@ -376,8 +381,10 @@ func (r reporterV2) Event(event mb.Event) bool {
 
 	if event.Error == nil {
 		r.msw.stats.success.Add(1)
+		r.msw.stats.health.success(time.Now())
 	} else {
 		r.msw.stats.failures.Add(1)
+		r.msw.stats.health.failure(time.Now(), event.Error)
 	}
 
 	if event.Namespace == "" {
@@ -421,6 +428,7 @@ func getMetricSetStats(module, name string) *stats {
 		success:  monitoring.NewInt(reg, successesKey),
 		failures: monitoring.NewInt(reg, failuresKey),
 		events:   monitoring.NewInt(reg, eventsKey),
+		health:   newHealth(module, name),
 	}
 
 	fetches[key] = s
@@ -231,3 +231,38 @@ func TestNewWrapperForMetricSet(t *testing.T) {
 		assert.Fail(t, "received unexpected event")
 	}
 }
+
+func TestHealth(t *testing.T) {
+	hosts := []string{"alpha"}
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      hosts,
+		"period":     "1m",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	output := m.Start(done)
+	<-output
+
+	var found bool
+	for _, h := range module.Health() {
+		if h.Module != moduleName || h.MetricSet != reportingFetcherName {
+			continue
+		}
+		found = true
+		assert.Equal(t, time.Minute, h.Period)
+		assert.EqualValues(t, 1, h.Successes)
+		assert.Zero(t, h.Failures)
+		assert.False(t, h.LastSuccess.IsZero())
+		assert.Empty(t, h.ErrorClasses)
+	}
+	assert.True(t, found, "health of %s/%s not reported", moduleName, reportingFetcherName)
+
+	close(done)
+	for range output {
+	}
+}
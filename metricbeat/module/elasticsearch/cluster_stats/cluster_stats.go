@@ -57,9 +57,9 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.FetchContent()
 	if err != nil {
-		return err
+		return m.ReportUnavailableAPI(r, err)
 	}
 
 	info, err := elasticsearch.GetInfo(m.HTTP, m.HostData().SanitizedURI+clusterStatsPath)
@@ -67,5 +67,5 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return err
 	}
 
-	return eventMapping(r, m.HTTP, info, content, m.XPackEnabled)
+	return m.ReportUnavailableAPI(r, eventMapping(r, m.HTTP, info, content, m.XPackEnabled))
 }
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync"
@@ -89,7 +91,32 @@ type Info struct {
 
 // Version contains the semver formatted version of ES
 type Version struct {
-	Number *version.V `json:"number"`
+	Number      *version.V `json:"number"`
+	BuildFlavor string     `json:"build_flavor"`
+}
+
+// IsServerless returns true if the Elasticsearch endpoint is a serverless project.
+func (i Info) IsServerless() bool {
+	return i.Version.BuildFlavor == "serverless"
+}
+
+// APIUnavailableError is returned when an Elasticsearch API exists but isn't
+// available on the monitored cluster, as it happens for the node level APIs of
+// serverless projects.
+type APIUnavailableError struct {
+	Path   string
+	Reason string
+}
+
+func (e *APIUnavailableError) Error() string {
+	return fmt.Sprintf("Elasticsearch API %s is not available: %s", e.Path, e.Reason)
+}
+
+// IsAPIUnavailable returns true if the error reports an Elasticsearch API that
+// isn't available on the monitored cluster.
+func IsAPIUnavailable(err error) bool {
+	var apiErr *APIUnavailableError
+	return errors.As(err, &apiErr)
 }
 
 // NodeInfo struct cotains data about the node.
@@ -225,7 +252,58 @@ func fetchPath(http *helper.HTTP, uri, path string, query string) ([]byte, error
 
 	// Http helper includes the HostData with username and password
 	http.SetURI(u.String())
-	return http.FetchContent()
+	return fetchContent(http, path)
+}
+
+// fetchContent works like helper.HTTP.FetchContent but keeps the error returned
+// by Elasticsearch, so unavailable APIs and missing privileges can be told apart
+// from other failures.
+func fetchContent(http *helper.HTTP, name string) ([]byte, error) {
+	resp, err := http.FetchResponse()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, responseError(http.GetURI(), name, resp, content)
+	}
+
+	return content, nil
+}
+
+func responseError(uri, name string, resp *http.Response, content []byte) error {
+	var response struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	// The body is only used to enrich the error, it doesn't matter if it can't be parsed.
+	_ = json.Unmarshal(content, &response)
+
+	if resp.StatusCode == http.StatusGone || response.Error.Type == "api_not_available_exception" {
+		path := uri
+		if u, err := url.Parse(uri); err == nil {
+			path = u.Path
+		}
+		reason := response.Error.Reason
+		if reason == "" {
+			reason = resp.Status
+		}
+		return &APIUnavailableError{Path: path, Reason: reason}
+	}
+
+	if resp.StatusCode == http.StatusForbidden && response.Error.Reason != "" {
+		return fmt.Errorf("HTTP error %d in %s: %s; make sure the credentials used have the privileges required by the metricset", resp.StatusCode, name, response.Error.Reason)
+	}
+
+	return fmt.Errorf("HTTP error %d in %s: %s", resp.StatusCode, name, resp.Status)
 }
 
 // GetNodeInfo returns the node information.
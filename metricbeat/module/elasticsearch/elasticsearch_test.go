@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseError(t *testing.T) {
+	tests := map[string]struct {
+		status      int
+		body        string
+		unavailable bool
+		expected    string
+	}{
+		"serverless unavailable API": {
+			status:      http.StatusGone,
+			body:        `{"error":{"type":"api_not_available_exception","reason":"Request for uri [/_nodes/_local/nodes] with method [GET] exists but is not available when running in serverless mode"},"status":410}`,
+			unavailable: true,
+			expected:    "Elasticsearch API /_nodes/_local/nodes is not available: Request for uri [/_nodes/_local/nodes] with method [GET] exists but is not available when running in serverless mode",
+		},
+		"gone without body": {
+			status:      http.StatusGone,
+			unavailable: true,
+			expected:    "Elasticsearch API /_nodes/_local/nodes is not available: 410 Gone",
+		},
+		"missing privileges": {
+			status:   http.StatusForbidden,
+			body:     `{"error":{"type":"security_exception","reason":"action [cluster:monitor/nodes/info] is unauthorized for API key id [abc]"},"status":403}`,
+			expected: "HTTP error 403 in node: action [cluster:monitor/nodes/info] is unauthorized for API key id [abc]; make sure the credentials used have the privileges required by the metricset",
+		},
+		"other error": {
+			status:   http.StatusInternalServerError,
+			body:     "not json",
+			expected: "HTTP error 500 in node: 500 Internal Server Error",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: test.status,
+				Status:     fmt.Sprintf("%d %s", test.status, http.StatusText(test.status)),
+			}
+
+			err := responseError("http://localhost:9200/_nodes/_local/nodes?filter_path=nodes", "node", resp, []byte(test.body))
+			require.Error(t, err)
+			assert.Equal(t, test.unavailable, IsAPIUnavailable(err))
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestIsServerless(t *testing.T) {
+	assert.True(t, Info{Version: Version{BuildFlavor: "serverless"}}.IsServerless())
+	assert.False(t, Info{Version: Version{BuildFlavor: "default"}}.IsServerless())
+}
@@ -69,7 +69,7 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 
 	enrichUnavailableMessage, err := m.checkEnrichAvailability(info.Version.Number)
 	if err != nil {
-		return fmt.Errorf("error determining if Enrich is available: %w", err)
+		return m.ReportUnavailableAPI(r, fmt.Errorf("error determining if Enrich is available: %w", err))
 	}
 
 	if enrichUnavailableMessage != "" {
@@ -80,9 +80,9 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.FetchContent()
 	if err != nil {
-		return err
+		return m.ReportUnavailableAPI(r, err)
 	}
 
 	return eventsMapping(r, info, content, m.XPackEnabled)
@@ -32,5 +32,5 @@ func init() {
 // AssetElasticsearch returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/elasticsearch.
 func AssetElasticsearch() string {
-	return "eJzsfV2P3biR9r1/BeGrCWDrRW6NYPIC2WTXC4wxyEz2ZrFQ2BLPObQlUSapdvf++oVIfZASPyVKfey0b5Lpbj31VPGrWCwW34Mv6PkDQBVkHBcMQVrc3gDAMa/QB/D2r+rP374BoESsoLjlmDQfwM9vAABA+xtQk7Kr0BsAKKoQZOgDuMI3ADDEOW6u7AP477eMVW/fgbc3ztu3/9P/7kYozwvSXPD1A7jAivXfXzCqSvZBiHgPGlijD6CoOsYRzUe0bPhBViMOS8hhVmLWVvA57/9efAoAf27Rh17Nb4SWGhxuSvSUU1SQR0SftT+/UtK1w09UJurn7AZpyTLGIeU5xzXKcZPXuKowm/52xIMVhupPW8hvC7Nngk420lFws5rZhZP2ENkDrEM0JxxWB8iecUfhk2AOiy8545Cz6MaCbZ1dSNeUmyiO/UzIzgSPbI04ynpq+98XBc1QAx8qlE6mHXktGz5CXPV/dIB0HXuUXeECNQzFDyQOebet7+g0BwLZAnCU08MmlDLBaQMjWvuW4hpO008cMSExWyKodt2msMTVv9dmzR0DXZmaZ9CGlNuY9h9meD0O1LbYonvT1Q+ImlefTRMQbkpcoHUvV781fa8xIF3Dtd/YFLMpp/fkgZOcco0S1bn+AMEDvK6X2icS2Cs5ecErW7fFKPXzY22UtmRuY69i1fAp71rrIutXJ0alz491NgtUl/4VLVRnNwTbvGOo7Jk9PHN0MDFUE/ospGa91MwscsWwV+h0gjV8UviZJpD4VVJIym+Q3dIs6BxlBshR2iOiDJMmmagl3tzBhUk2z/8mWSZMbU3Muw4ncsq49DYWkMk9GwVo8mZwjRiHdfvGBi1h3/7/6S/fGrujwtyGYaaG9e0UIx0tkGr28M69uUFs67/mZUQDTl9Pczp5kOt+1v+/SHvVVf/V0lwz5IVQVEDG2fDf6oIVJcEOpG95N3swmuMX6OKZja97v3jnQs84oShj+H+Rba6PW/ClGhO3zIc/8ihJYfIM9oq3wE7ao2uNGn6EZAf0KJ2iC0Xs5osH7OcSLGj2Aeh19G7z4zpHoBhtGOHmmsxHlGPa5OW6dQvVbyScuQQtyKR3WH2s3BKnRfNGCecVOpWhT+hEbmHZ+Hnwa4foc17A4oYGfzR5vxcksyhBIzvBXIRpD+UWIWaew752iPEzLBcp6pS5TDKLnMcOnvdHa0XO+cd4ApJMqBfwnczwUql7mt3NjO5lZl+wCxE4e2jDwdqax5b+IGffY/rD8COXiAWNY+2t8wkxtfRYkxmbI1qzfJiok0aSdDUHRztM3LR9IrjhJ7ILlOcKWCdkY4ZXNmP5I6w6dKJ9ImTOcb1T+1eYOG05LXM5Rs4jGSd29i+fUJk/YJ4zxM8jGydWnVbyR1RwQk+eXYKlLmLBeQ3b85jGCNXdk28Uc0TPYxolVTmfOIfdUpAWDiwKmsOOk/xCqop82xgYlGelObnkF4irftxKNNuRZ1D4u6CZwiyTyJmObDs4XPKhqCYc5dr5Sz5sxJLScwrysmVdUSDGLl11hAUH9DATyj9CNJssCMv9FpssMZkLlgoBtVdu64niPDnferZT0GzSe5UFALStr9rMO8xhhJnSaRAsEc2351v0MiRIpoMsW3mnjMloZimDHteKPMAqL26o+CLcyL062QEXkmv4lDP0NW/IXpEGpJUt0+k52dWv6SQ9ga6TWIe2/UfjfIDK3dZ1oo0ySccZh02Jm2vq+UiBXk5KNgZivT+IgsC2cJByH7rLpV80WkQh792k5VYojoUKmk2gIQxs0bAd8nvIRXaHoZu3bd8Ku9IU9L5uBlxJHnOP04m2Iq5kC2iUULQNUDuRkINNhF52ZPn2cpXUXjHKDMnFisRheURPqDhEuoJvTHOevbHEs82M7JpszvS8VLnT8GdC9v5ONgMGiu1nA44SdG8H5ihWzDP7FDVA6L1Zzm6Ju7BcI9z9NvUapXRc1xI19NzU0oeuq0rWtg0NKdHGfcNlTS/m2NWeYbol4Dwl17uiA9bUpSGlqG+suob0ec7Vt2RMuiMNek9OQkgGKGLJzN27XqTLJ7K2ldGLmDoBm312xkQbS/sN7T6uMiG6UIFx6TD+WYjNRm0HoylLlu0IA3hDdGq8AJZ5epZiNU1MVU7a6bnKWXsH2ZT3KMTxQrKO7Uo2sGkTbMnppsSUe+BJfHf8yRlUEmdCGsR7kiGPSr7wnXIHsjckN5guWoStFO68kI2MxHDdcnAbn4kRy1BDjjPbKgctWf8IyBaL0HWZvLZ5/VYy9O5U1XUO4WZltaS6O1XXlPi33Qs+JKEo0Xhdpexsn+HcmU6b+Gya3w7LLrIRCVbNmQoTZuSoRJlYYjP41j4PdqR4xLLV8FMQjkxJiOWrwqegm55hAlKhGVKx1ARuCoLBWXixDCVwCoqxiVyxTDX8FIQj86Ni+arwqegexTMJwbgkrliaCvoWstOdSO3qfPzG/lokXJirSnaPY2JWVekMftiwffgGFUzHw8t/nvb+/Fhn1yKbbZKRqsxmfGc0BwSEnyy0vR5pKv5GBzWU/Ej8mXSruIOOce+tKjT4rtt1pcGmllXcoWQTSkg1igB1x8IWixoTYXqt+Pjqd8QQclTliGXUIlqgLfuiNaG2CN0WaeeIWg5hSOEBNybZF7Au2i5ZP6wILHP4iCi8LkMlbmAXuCrgj8sxM/7ztB1hWdF22cDvmllxfKO2MLHf4Qm0HSwcvWiPrToGryhvYEM2nrT0RhMEsoFmJiAz68lNyEBcd7c02hYXln/tCId5jQuaROWsuLBMYGbdFpWBtkeC7hOpFMv3nO6NKtgOkx0m5Y5VUDdI/7NsgZ10HZ81EIVl8jEqb3Not2mwwE6qgXA/Jmjn8NtOXuFuHYw+4npY5pgxSRpOSZW7+nawAYatXwhm6KCscI25y0XZQlCAWn2VGHpyAk9MT07hsfSmaBQlBWL343BsduYGRcSwinfj+E2kXbSE7Kup8dBVX5LZ4muHOpPX5bGEokvW88kEzq6gP0WfUcGNk3YsmRFq86nKFZmzBF7IwlfE78bAPZfd9l1e73lxC8ubivdi47EA9U4rpz+T3Wvm+eDzLuw8/Ha3ocVx1z3ZWSa33YuZJZtoKy8S7DZmUuew2rfcmgoC2pFsaMCfEeYGdQGvwK0JCiBoY2K/yz2m8trKnjl2RZHpa4czNeWBHJM/b5yH3ZAuWBCcjgIS2XBd+CagnQNTeA7k6Csa/QOMQ1OBKsMFdg0iegody+s2piqq6jsm8p/hSZbxn/40y4ArpGRWqYaQdgKZuLRLlFWU08oVmEapxkrTMQsUXi7PNrIewv2/T6RE4OO/GeUsmj+FJL3lVWGUVJYVd7s0gWkWJyt0G+U9EFIh2MTJ+8gAvyHRtuL/SHzx3382E6hI8UV3VfZTGEHB8DgLIM1E68/r7l+sK0cs+6FD5l8oYez9OL4oaitciKsVYHltR3+Jafzn6uLW+hbA2S2cdyrnTyuyWAOmUJ+nqEAAhKEAyPwVbji6KvrYfRGxjiZzSJZ3nb3arD62XFWOAlpdGHV+bekXsMzRU4Fa090gidKIxrN8vroKCvZ5etN91EN8CsNtZZMIo9XByvIbgabMV3H79cfXU6mD8eMpaxwWavGLdEPDfw3M3QyWIiDx6ik1qJJpd+BgcJbH8lrAhBhTFCxYwGIKvENTmJd4UUcpWT8wBbaBx3UFfl+u//cJ1giQy8DYImn2Zw1Vlxy2iWLyC3zCdVcD1neZpkDDAXxPbhqlo6s5sF2+fqazdVWocpA2NuhYa+N7atKRs6dRjSXgnBaKYzO1Yt9wQhj4hvkNy5Z0c3NWS0nPcBYneaES/DRuOVD5h96zJoL1ZFqpz4WSOrxfiuAVw02B8mErsMFvDtLsd1yjdwA3oGbvgJCos+/FgwvixQ2tlEg9rKKI/7uQAWYZQNyt6oe/bvr7n6qC+doqfQWQnkPC1qJdESj28lsRIO5qSE4oU+DMn+bhiiEM0YP9EQOzSfyhguUTpXY1bAzAIra3eiLPTwasIlPL9+jiMHz9w/e98Y26OIjl25n615cK9kuGZZu+erTOJ1brYWqf6hj46UoRat6BZ9SP9neAovIP5pigibK9K2gyP/WfColYxICzoH4zuTDMWSrHOqaV732Ff7wY5lnAMZ1o+v/e01fmWmHKfnkbZwqLVGMYNlzs7B9IoPeowlf8UKFgAoZCDFvE9zDBMtfPs7r3Svadkrqk2t9M9ai0TPL0X+7YAOe8muENqq5L7oDwsWmIlEu4zePVWwrHO9okgoz09n1HXCbfA2g+dwztyCZfajmkhzYI6N/yTeWoLu5l85vw5u0N5l+aRdUedwkwp9sU4SZF6LW2s7Sew8z+TJUjaA1PvPvpTYtSWB2ZPV30FwEM+rkFXAhVRBpnkeHZ/J3O3nTE/tRi+tyPXle+Q8BMYPB13N7ONObH5//DPlQkFunyiWFbZxfSNXZP0XyYOSM8tbD4Ih8LGJ2YBFjDCWgw0tSsDcXF+oHriD3NXwVCgi0NekJF1+8a85ZUuEhXcdJwsA+CHXsO2bL3uNm4GDmSGlRYj6NhoeQP2U2dWNzU3QVRwKZAla33+vqvMqdDihqe9yqtT5HjKBmOkFUA+90Y900yZR6h3HW72XM6oZxPdE2Dm2vWQGNMyImmJdEKn8w4TAwsfBkk84osM3rGAxMwxEelRMfIFRkE/afI7HikZ8ZvkA8z2HgJh1AGbvARTZyGaKPIVBFN2LWWjBx5apT8zLzoKDXfxtnrs/xFIqsxv/ksd9BnMpbdrdrgJMZGs+20wtrJdlYRvVgtAeJXqRsuy1Xw3z7bOTcJ2zvVDsd+gxc9fmqIkIVFHCOiW8dk433s292Rjpc+Cf4OkqK9B+XRKcyexCl7Tcg0igfWh4ywAHrEhavsciDMDXNnbZhAmBozthVHb9PvqQ3uyHj+x6yjgAwBr5241irPMd+XqELmS09BJ3vGgpwgSedK0Q28pdYiwEKLzEVAxtYFjGQbUcEvAjm0IGQEZHAJxwjMqLqqEbiRNUUjkOOKBEYAx9Zt9UDPa/yFInZT3sUJeIk8CBE9cUSbHjQZdI3oddyLpph87+I+VWBn3evUqYjh9eUdoK6dy3Yve+t6uOlMoCRFJ9Y+oKaM2TfW2xfbbfSkpGiaYU7PhdAa8uGuyQHajPr0PMYcPUG810II9WkQ6W0drZBPHYCboupETOQBFl/6/xXH6hdCQQspx7CqnkHd921UjseRdhsc4/dHe/1BAWz3risIwr1pCDuvd28YAhaE5ZlfikVmxnRZ6XU77oK5ox1lah/ku3LADt0rJnGX/csACF2+1gtyrZzXPzzLrPPBIo5FDRy7/zxi37VjrG02rlxMp3roL7IvPGBbfGB4IPGWEJwSeThsg5x2T/+vG/V/3RQnMqSh7CFIYsQw3yNCdfh4TQ2XtGWEvt4XNaPQtvLT+l9GUUEekVZX9gVOsNMWJ7zgdSETN6ILVUW2lzEFAVlBqoMrrG4tlxzYESjqHbkEnWnH6rJO9wFJTD2ZKNGQlrZK5Vz4XsJ2YG3KJlgP/XXal0ce46Td+iWkfNunluSM+IwrmX0+zlZaVSfgTsjdkaKxENojuXI1zOmhmyoJ/U47BPAi59ssm/H1Iw47dP77qK3AtViZwoZVZNmE22ds+7wamBG7PfdoWJqbXPTycIw585VeEc+ESVpCeQ7Lkq6rMQQMbQmUsrTY7wJSJrBZR4wUeyPMXH95t+AeGQxGAT8VpKtK8IDAx1+nHxIq/qjnY7kXOJBMm4ClktTTsMzjjHS0QAkaegBK2dC/CUh3Qw9i0za0KjhFQw8k0za0StKeb/eIKL70G+6Urqi4pJ+HvfMdME1FQeiO/lC+8mX9/PQ5ja+nrSlPW7dUi/LHqxOcTG44aL1bXRKfsgY+/5yum/nC3CtiQY+BHt0ee89Azgsupqg3Zq+3HQR1VuzYySzNQ5khL0Y5joKUFdhpTA/Y4RFVpaRMgu7jnD8jcKz335K38eaWWaLBx2tSLJcVHXCvuWmH03v1ll69pUh9X72lH9Nb8j9g6Yrl6mDaKewm72ut46sj9+rIRen7XTpyd+B6zcPuihjPW9yiCjf+x0KmUNgD0qqZaRPu37umtyOoEae4YIA0gxwwyhkLjmiVMFwBNWug9BuuymJdU1MvngZrBP4fwOW88mpsEjikmrxfZr17aQLJboF3ysoPq0qpphBWKsw/d4Qv9Z8MTu3AZ1wWMTPbTSXiKVm+jQkqB1zAyUgqnJKYDGXpw51VdqXjIDo4a1HDRy7CY5us1q+O3fWmsxuamuFH4QQSfkN0+iUDBaxMZtNUYKi67NZgG3f0FMd9DoYPHTrZ9s5w+g0C8lJ0I/Qj87lF0neVY9NCVBecc2h382KFw6aUcwS8Ck9fmnzkMe7UpLsL3v6p/+bnD3/i8PrzWytJQktEjec7IK6b3JDEWrKCbYsgnfaR05RWogtusLG+0elTlLcpX2CO8nevF52kVvSmdP8q+0wevL6BY9NVVyYfIjxtLuER8j8a/LVDoK7AZ/JgP0S21mreJPQ/yYOENEu7EIoKyPjwEGlMCaepjUiJZKZpsll2vGNkLhsYkgEgIi9iyKc7CMXNI6xwKash7ijHMM0ZOUUFoeUWrEW7/zpNQyJygx7XkRDVMpmqinETtDWJrJ+558iG1I+JBxcAwmLhhuKG1RBkQtLooJ/a5X+L6l4ys6EhHDwg0ELKUGlIHVnNFkGPDjoUWHx/fH32sBf+BlRzc67rLAduFQxTxX/9Aj42FxLr/G8txB4ULOtJGQ0AVvOFLMLc+wEvFtr7DwRb0DPQonm9Dv5AXmhh6lN0qOHTdhUa0rx8U3wizfsEzTHq8pItMqkS3iqLtSY74CXOy+Rm9tjiZGA0mnFm3v+Qxm9TZWwAH0jHAYLFbUjJawA0P4q7z/0T27N0u0dnEu0L11BKeRvHXv4yAsS6T3JgKEH9qGL6vpY7LBg93oNyqpki9Lsn7LtOkfyO6Fruz6SluzEHYQkzXrL7XvgqRykyeLCzcMKp2RPoqV9M1LDE/WZO3NFx+/d20D4mPkScqe9NQInmBqtqfHQCMoavjYzjiejY6N+cm3cSb97pzQyZVGLTI2WqyVQ255Cl2dN7vSYE3l58Xg5HwlSJfNdMD5LmcWgZEocXPTqjK5xVwOkMXY6vi5GiUyslNnY/E93Pgflyn5tOazm77yV5ZH2mueiOb/H3sB1Eeus0zUuBUufmZNlqVZmTRYtiRCfLlMWkThaqlUg6WbZa6OgFRJ8tU6m+dLBk5RCjlyRi38lCNgdFbEd4S12Q/VPoWaO4cK8hJelszzzNYkxVBZaN2hJSpQvEkeoYq7ufAk1i+eCqd7/Ap5A6gS2CX+6G868Ifgklnd+TsQXxOszingdezyX+D3kQ5AwKPJPuoF3lPTXh63h5HS9pxgvr6CN+NOTJvQ6Zl+f8OmTOJm4bMqqLdy2yglSV3B2ldPNGWFdq0Us/O7QjZCmW5h9MR2MnuaTrFcflT+wpN7jEml54TlFaeFVTKEzngAH+N1whwJ4ZR7VDTLDxTgv0UbTrvl2MrKklTzkEIkdHjp38U2QVBN6sBHEXDSmCZXYMtIjZJsCeDJBuriva7pCeUBFY5vDxmv1xmTisy7jB6pJfKgLX5pgo2ngkWLjaDhYFzzoGryjbW87ZbEs/Ux9bTcaFZV87wmFmvLIQyBgsboJ4kVzUQ+irAlEFW4bKvEUUk9I/GAL1AYskQ6UUwFEiFAnWvhMIrx+bHt+NSMMpqXJfu/pS63XUCteOVOxtmHJobsdcpigXbZetg9KOYHRIEJrf+tUjbwlJV+LnsFxQ+dh+Ap+dos+o4J7hFeB9XggtUC5eM/rXUPiK7Bl0P5Si7ju6P5Sqx2ah3Jeuwp/9cVVd5svlLUWMdTT1zdNjXFp5JyIrSP2AG1TmBSG0xA3kvSawKfOhFP1puQVi2z4Kle2y/emU7ZJPVVc1+gvorIk/VXGK2goX8AV0HiWf3M53MsrmYX9+y4+yX0jlkxt+FAur04KRsq+dKFBs5A56/nKyo7w4mbWoKftBwyFT9zvmldARcP6nCfCfYqcLccMABMMvQP8LFUk9ddpyeZMhynNRSMboIMSXN/goIMEact7MYkIxN79TEy/vVxPc5NiKRxeMknY84pCBvxEK0BOs26pXqOPva9i2y9sJWrQFN7n0D0MfL/LXkcC1uPQiYFc9VORZ7+mSAmDoPLv62GHPEokrM5jJMkf+J4rk9axUxteKeAgm7teRUhas6YULyBDZFFWkkEu7uGLepH2+5jY8aSJKsoou8w2yUSgqwYWSOoxY0jeHgmiBjxzcoOxA6AkWHDBYIyCS8wG/wcZoPHETqyB1Czl+wBXmz6DtaEuYLQVATkL5onQK2LUJM7Siz2RKWLJb2Xr98f8FAAD//02PqlQ="
+	return "eNrtXVuP5baRfvevEOZlbWBGi7wOAmeDbLI7AWwMbGdfFoHClnjOoUcSZVHq6d5fv7zoQkkkRUol9Zlx+yGxu1tVXxWLxWKxWHwXfcLP7yOcI9aQlGFUp7dvoqghTY7fR2/+qv/8Df9Fhllak6ohtHwffc9/EEWTv4kKmrU55r+ocY4R40SuiP8Xw01Dyit7H/3vG8byN2+jN7emqd78U/zuRusmSWl5Idf30QXlTHx/ITjP2HvJ4l1UooKTSvOWNbhOempx94O4wA3KUIPijLAqR8+J+Hv5KZflueKfcjE/0zqbkCNlhp+SGqf0EdfPkz+/1rStup/oSPTP2Q3VGYtZgzj8hhQ4IWVSkDwnbPjbnh7KCdJ/WqHmNlN7LOHEPRyNblwwO3NaHcK7I+tg3dAG5QfwHun2zAfGDUo/Jfx/GxY8WKgq4gtty2wTxN7OJO9Y4oiXFHteT5X4fZrWMS7RQ47heNopL3mjR0Ry8UcHcJ/S7nnnJMUlw+ETifNoGQDMDkA8I9jzEWQBuQzkJhMjWPqqJgUa3E8YMMkxnlPQ9Yp30J1+P/GaOya65ppHoiXNtiEVH8YkMzupHbKXbfGAa/Pqs8kBceG53SytXP/W9P0EAXc3zeQ3NsH8LLnDpFyukaPu6w9g3JGfyqXbBIC+wMFLXPFyLHquvz4WRm5z5Db0Oq0CPSVtZV1k18UJEYkDj0eG+tK/gIWL+IZRlbQMZwLZw3ODDwaGC8qjA8E1FlxjM8sFQiHQ6QA5Uw2fyYGEr5KSU3JD7AazoDc4NpDsufFQjPEgH4zVnN5o4FIlm/2/iZeJ5mRNTNqWZGDsDCTBIxuN0BDN8DnKf1xU39hIK7Jv/mP4yzdGc9SQ22iYoZHpdorRtk6xrnZ/4948ILb1fxJlBBMcvh58On1Q634s/i1QX0UuvpqrayR5oXzzwz9g3X/rC1YQBzuh6ZZ3cwQzCfw8Qzyz8qfRL9m50PP9as0nIvk/bPP1YQu+EmPAFq/R73FkNDVFBnvZW8gO0uNrgcvmCM4O0j33Gl9qzG5r+YD9WLwZjTFAfe2j2+Q44/BkM5lGpLyCxYhqTpui3G0Bzly+HnDsYjQDAx+wrqFycxwWzVtNmybHpyJcYzqAm2k23A/+1uL6OUlResNdPApu9xJkHMSoRyeRyzTtodgC2Iw+jAvEmjM0F8jqFF+mkAX6sYP9fq+tQJ9/TCSgwPhGAV+Ih1dC3ZN3NyO6F88+Q+fDcIzQuoM1GHtQ3vcYe+h+5GIxg3Gsvqd4fFStIlYwZfOdbsGSzlGDZpKmYnaBth+7YftECf/oPHSe/FwJa0A0ZvLaZix5RDlfbc/TTwDPMa93qn35sZssp1mi5sh5IMPYjvHlE//ogTSiQOA8sGFsdbeSPOKUS3qyd/HmOssFJ4VIp5+FNITpNDz5XBOR1jwNaRBX7XziHHRzRtMUcFonqG1ocqF5Tj9vTAyqs9KEXpILIrmYt4qa7cjTK/0tqgxGZLGiHE8p2w4O53hqroMGJ5Pzl6TbiIHCczJaRcvalAvFLm1+hAY76n4qVH+E63jQIMr2a2zQxKAulGkAdKvcZonyPDnZerbDEQ5yL6oApltffZh3qMNIZiin4drhhrG93kLwUETiKZH5KO/kMSjNzKWT45rTB76HT284/STDyL0y2QnOOIsjYIZ/S0q6l6WB0kKXcHIOel2XdOAOIOvA1iGt+Kj3B9w97NWuk1rPk7YN9wrcbZVXaH+kkZ47JRsCud4fBEHStmBQfB/ay0UsGhWukSjGTeZboTAUOtF4IOqDwJYN28FfkJxVdxjMvKrEKOwqU5jaupnggnNfewzH2kpxwVuSxoCsbQQnJxJqssnUy44qX8FXK+2Vs6xgLo7d8oifcHoId42+scx5jMaAvc1I2eVszoy8Jk6un/5M8t5vZCNBT7bCGzS4hOS8oNmzlX5mn6AGElNrVt4N2ITVGuG2W+g1SjNc1xLVWS409850dc7fzAvkNu4bLvvKT+wVplsSzkNxvSs7YC1d6kqKxGAVoip8rNWPt2QappYMAkglKELBjOZdzMrlgbQd35Wq45fWM6GTuQRwjuI8rjJRdFE1Lx3GP/PRWS9tpzRtybIdYayn6PR8AV/D4VHK1RQYqnLa8FiV194BFvIehTxeADNsV7HBtkSupsnhpsRQe7BS+O74kzOgAFdCGtivFEMeVXyxdsrtid5Q3GC6aOG3UrjrQjYiktN1y8FteCVGKMIJ5TC1LWrQ4K7orFeLBcg6L17bvH5rFXp3KuqyhnCzsJOiujsV11T4tz0KPqSgCGi+Lkp2tns4d6XTJjyb/Nth1UU2IN6iOUth/JQcVCgTCmwkvtXm95R4hKKd0IcAHFiSEIpXJw8BFx4hACjfCqlQaJIuBEDvKrxQhIowBMTQQq5QpBP6EIAD66NC8erkoeAehRMEYFgRVyhMjfoWsOar8+Eb+2sKuDDnuTKPY3JWeeZMfthor9E3iGA6Hg4cb3G//ZrGo05ijj8e6cdr9NfSTxbYqxEpFP6C7QDfA3/mSrh+0aMqJfiix3UhwaaR1cIhMIfi043CQ9y+scWsx0T4guDVvyMEkKMrRyiiCtcp3rIvWgKq0iZwEZLniJMaQp/GA26adGcjm6oFs8OcoixBfMFGV3zI0vaHYtuhAeW72KqNO3zX2EpnbdamJvQ7IoGqRanDivboqmV8FJISlZRtV5oEEHcwY0kytp7c+EzEpbnBSJteWPJbSxvEHX9ag4gcc5qxpBm3bOfZlyB/+PI9lntzAavO2RGa7VgFpwoRP4tntEHX8VEC2Vgm6bPyGagEM9qgEsjwYyDtnH7bwWvYy63R5TQtc8ycpGVT0zxx2ba3Arqtnw9N30mZk4I0rhBlC0BJNHZR9IWnHDgwPOXCQ+GNLXWoqNK6m4BjczDXCSKnVXgYx6efKLuoKN3XU+OhzT9BHrS0OFwTmiyxwBNLOruS/jX+le+QjE47FExPavOpyhU396RhDuduFCyw7Nbv/HrPi2tY3VS8Fx33Dah3ahn+THavmseDz7vQc/fb3YqWx133pGdV3HYvalZogrU8K7DbWEmdoHzfcmtqCAhQwEWMmdn94bO7BMtvY2K/y92X8tranjl2RYHla4cjNdWBHFM/b/TDMKPtLkeB0uGy8Y3HOHuW8ByIca1p9FcwD00NqgwX2OfPqYS50L69rrGLqv6OifrH8CRL/8/0aZaOruQSW7kaUtoAPElm56i6KMPylTSNXI2dpkMWKJIZPdUS7Apg8c+PHEz04T+NfGbDD8FpOvKTyIPmlhV3OzdJ08xOdeg28nvgIQxGZRi/D4xv97EcW/kvir787z+ZAeQ0/TQNVfZD6IlG3eMsES0HWH9atrwu7S+0zO3Rwftn3PR88KOoxYjoJSpwU4u5wP/r840y3P8gSlH5b030gPvDWA7yAaeoZZ3eaElkIc8wcTOKmfgEP1W0+6M/f/wg/v85QjWWV1uiS02Lt1FOPuFxGHKOJhd/KwExXD/imtsDE+kpEZlOLMM13wZZAM2TExaopMg9+ZmmhHAFYUz4eSMuVBE4RB/50tMj0gxj5tW4Ni3TFyNGSyOcBj81YVh+ksQ4zYrW0kCeZzAutB4MoaTCnLiStIt0S0+f1ntM/C81Zexdb5EcV05SeYsomt9Qmz465mNd1lYu7gF1Xh8eP83pLNzx7Z/hQcLQ62b8ipQNvmry2MNuGTKCxd7za/2r0iw+ttzKDyK0uBvt/No2ozIOIsWV6RqcolLKwXNdSIDbxI5Xrw8Jnw0X8721vtT8RkLT++Nfv5xay5evT1h75q7r8wJ6/3Kl7sg9DJZ+N+Hiae3WwKQ7cDI4O8FtspSQ/nfB49S5wDtUhXmJly3DYLOYVtnNQadHsKeHwwqxhZP+IpkxXFk1Ew8kP6AnUrQF3y5wkylT3NWaCHDDLO13Ox3a+UN/s5NFRzO20AHt28p8SUPaY14ZVGO3Q7hh/XEYRTFwkln0mTQ3okbSjc3ZGAge4chO4eIboG/7LQfOvhORNZWoB9UqecQe2N8uZZ6W7y1TnHRbgQ1xs5dkv3BObznqqGBvI8lxil6wjy644VNkIQT0tAoC/l+SRzTyiOQ1QjH9p6q/f1fljdfW1C5klbT3pwugYu80tyWwMjb+cpIy5YjZrhxClz3YnzEwq2Q9VTB/jXff2mF8DdJvAXE/vRhGY80+1r43PscYRmL+TOz060uOxJKB7WPSbs576zbVsujba41x+TZ6xmK2v+WmlX1nTteZINtNYcJTZNaZ5EhkRi72spuxz5mzK5R1Tmvfr/W4WqVh9gIOdzKR/xcBX/O1UpViees9xTcuY9/MdowPFKF3OCdXIjK0vgAMPUe2sBdkvHkuXyJ275XsO6Xle8S2i1XOdXVaz7x+j2kDOectpNWk6rK7VMDcNBwKKXKb5+tq16fV2aYoqEyvsB3ZN2EPQfMRu68hm2Kp+ZTuxsDDvtXz4UEmvormZxnN2wdsfWmWDarc3e6cYVNAmBS2E5jpWWnPoeb1oqwjYClez+vwbM8Khlq2B8ofJOFI+BZ5+jWyNKd+uP2WbG+wN1STPFWkfhaz11Xa4+EJDLGOO9oZ5jz/m4APNY4pXOk8qor4wqeGPVI0n9uPFJ4qjke9i2E4ed9Kqzvs96Y0DGtZk/S2Z0/zV0kBYEsjDttasWtMKsptF665qqGGxT+wbxD7BJjstdbvBAQaFkjrKbvBiOWl9F0kUlSmfIdhsd41+9V8Oqpx2SRCpMSpk3VIhiNkn7FauzSp+ZG6cV3kXzmd0M4n2rIUBR3ixl8otUm9uIzJvvFEsVaNMq7Iqr6iPzCJuvyo4uiYubKCQHyKzYEHPLLmhprOg/X3zWjNoht6xAOmLtsoq4HkELZV7KrBAD8zT9u6xoeEUn9RlPWc33iW28kzKCve2BIZJJtth+U3TraziuDFak4gfJW6kSzDpXfFnnOTcFQba2dgvyGKHooF24Cy043ZrWMKTz+IcXdUnsLf97iD+v/Vg/Lgav2Vwil7+1MYwT1boQZoAD+S1NVh3JPMjTQJQOGCqPRMdtYn2Dvu3usY3JHy1t9tDyJkSHjtpGttaB7yfcYXvcaxV1w52TP2noUxLggzWO0qGEDMt59iAMnQFpiBaAOaVQZQ9u19GkDSu1tpAM2gFsIBdAPb5wZQDuuHGUA4tEWx9xp/qTG7aU9AOSOHAIr4iSu3FETBSBe4vvZ7UQjnexdXBz2NdW9QN/Er3k8peOwhYG/Zbl0PN50JcGatXPsivWQsPmCx3QZPcQqG6Rf0XPgSgJrurskB0vTyCBx9jZ4ELqSQTGPYaOtogdbE4f+S5q3MiTyg9JP4f3msLk52KlQ3BOX5c1QI2+aD2tcEnxz3B0f9Xgls967Li4R70+B3Xu/eMPhcEZmd+UEsMiNNl5Zet+NfyI4SOgb5ogKwQ/eKIOHy+jLgvXwtF+RCO69/eFZV551GHIvawfvPI/ZdO+baZuWqxXRo/f8i+8IDtsUHpgeAt4TnZB4O2yDD7ul/v1n/100xkCINHT5hlOgXewSIjh6v0ORAR0bKu/p4bBC1rfgm9hfXOKXcoT2/7Ak2bB/OC8nxMQGevWPv2mHwPMCVWsfZ3lvFIpADMKYdq8uy3AdG1YOKgKa00hVUcLH26LtH1jGommA59ZdlX2ttURpabf0S1c22T0kGVHGlqs97bzVpYDbRz7Igd0eJxoypoOSq1XiGa5r1S93iiMxqvmPb6FwBZf6pl1bStWi5RiXL6fWETuieFbHba4+6pblMpJWHFyDxr0QraqkS0RcqQVlWL7sxeExtRQiyi94vkqQqYLPOGMX2RllzDGNBOeqUEn3L9+W5aK0Wffg4/JDW8o8Enu+cIGELsHSQ0zIs8zyjLTdSgIHuCEEO9M+SpHugO7awA60zhhjoDiTsQOsg7fV23OmRi9hwQ4ai8pJ+4vekvYebCiIxDfS7Tq0vG+fD1zS+nrZCnrbGm04e1/LVACeTGw5a71YW4FNWz5fO4cxsLc29AOb17u3R47H3DOS85CJEvzF7a/m7yh2f8Casz+NojqOg6V0un0dTXyajqrWUATAfp/8MyZPY7r+Bj/HmkTGkfkFpubTos718rU17jZZeo6XXaOk1WjowWlp/q3Xt8rjlFHZT9PUayL0Gcr/HQO4OQq9x2l1FoWpFKpyTcv0dkiEV9oAn3cwmDvcnPpxcj8NLGrTs+EQ9n77hyKQThiuhZk2UfiZ5liJ3plT2wv33iGTjyjtBAxCQTvj9MMotuElKdg281VZ+8XbM2E0hDk3C7l7qfzQEtR2eflkkzKy3SWGCu2X5NiSi85KkyxXag/KHJJ2han24s8uuChykgbNK9IDosMiIbdCaWB3b622KrhtqRh5lEEi5ddTDL8XbPLlJbRMRGM4vuyXYhh0/hWGfPUdN4fpmG9tRBbbN/kXMTP6Bil3V3LQAnTJOGnQFY47KTPkIdO1e1SFsxNHv1FS4G735o/jm+/d/5H/9/Rt71906w3Xi7i3uZSacs6Q1R4WqiodZwz5ycGkZvpCSGPsbne6iVofyBXzUunm9qJNawBvfSot/pQ97TtSKfF/ZHOAR8j9KwiN5jijiMtkPka29mjcx/TtnpV45tDwsUOMUsaZ7czekhdMwRjTDqtIUzMv2d4zMbQN9KgBk5kVOecjHfB9RTjLVDXFHO4bBZySi7qnOkv2u6ePghmTmRr3GF1s1E+uiGDdBW4vIhOceMxtKPiYfXIgwkQs36p+2E0iwUrooW0Dqv2V3L1XZoN53Eyko0Vd3WTqy7X1NhwCz74/vz+73mGVH1Tycyz7LmzsG//1/fog+lBcaGvxvbcTulSwToIwKWPoL1YRZxAEvltr7b44gEggm2Twhw3oiz7cx9SkycADbRShp+fJD8SMt3wEMRy/LS47IIIr/qMzWmviAR2cvQ5gpaMuTgV5pRs+8/yGNn4fO2BF6oG0TYZTeupK8ki8gxvefd96aENuzkxr4vXAPJcjbOPb2lwFErPskj6A0tJn+2sgdlozu70Ednvrdk/ZdfbX1nuFa7s/Awt1Yg2Cyhi8Jr3aUopIHOxsnnFo9gZ/EYqKnJe63cuKOjtu/tIP2vvAh4Ex9bwFKMDZx+tI9OoH45vhaqjyezI718c25dSfh6h3ezFBFJTY5IEtNhrY5B/YaiQ9sNHLmdXqwUolkl6eHreM4t//tGaZwVgOnM2Q5vi8GhFFrLTZ2PxMtfGAy3+fCSa28+16QR/ZnGpvuxMfb2XQp0PrcnMxb7ypzMmvZjOhknqqZ1MlMJy2STuatNzp6AdZn89S6Lx3MWTvEEJxk7hvuEPGYjO3wLAZz7AT3uNCzZnHqXkMy2tqeeRrZmLoKzAe1ojSHS8TR/Bitu58CPbel4A/oyadPYIXRp7vB/JGD8QWd3JOyJfDCT+MrD7yeC/wf6iDImRR45qHi9XW+vM6X1/niNV9YWz+SR0Od3OuUeZ0yr1PGFOJd0zilea52R5BhXk/WVVr00s8O7UhZyqX5K5PRXGLKvoD6iT3tBpeHuN0LzxCthRc9hfxk9pjgfyM5jtgza3DhYOOtvNMSfTXGZ/EaRvKUQyB6dOb48KoCz5uVXkrTj0RQFh9DWuZsAWgPCgB8QbhqD7GEnKIsEXc3/1A47eGG8kty4X/d2CHacAAsXFWL0rSJWyZaZO5t52zWJaztpxcW/9byaRa3QBdshTdYpeSC7gNfZ4hzVIlrCXw6EJqtTwZPeSZ+iBRYawVwFAuNQ8n2kZ8em55wC79sapona+O63gl7MulJ4SjF3kZTTc3tNOclynySxsuktCMZ7ZOE5nbAV49E5KDvvxb0txa3GKKwosa/8k3FyvTyeZaKcs0m8jWj34fAV9z8PgR139H9qkQ9tgrlvmSV8ezXK+q8Xi6pRNPjtoa+eXpMSKvuRHC5iwdSYnHtky+JpESiBjlBJV+lVCv602oL5La9Z6rGZfvTKds5nyqurvQXkHnC/lTBa1zlJEUvIHPP+eRxvpNZNk7780e+5/1CIp888D1blOfn2tqJDOVG7qDnLwc9qouTcYVLUamfNIh92nNt818mgv+SO11EShahqPtFJH6hU4p3Xt5kuG4S2UgGqL3BB0kyWpLU2u9THoM9A/H7aCI3fckB+hGHOPobrSP8hIoqFwK1zbsCVRWxdc/tbo2q+ND38aL1PhKifwwpVdi5sFBZZ73HJCWBzngAnls44FkieWWGMNXmaP2JInU965AmHhKJ+3UkyIY1grkk6cObDxhN1dIur5gDP19z6540kS1Zpcl8RqxnKjou1bTwAwb65pAXrOhDE92QMiA+m9MmYqIZoSzOF11WSqPy5E0sHjtV/OcPJOeuh3uAuqLMVgKgnFAya52ybxNmGMX19OSQlmxJtvrx/wOjm4Fo"
 }
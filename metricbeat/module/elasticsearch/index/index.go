@@ -80,9 +80,9 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return err
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.FetchContent()
 	if err != nil {
-		return err
+		return m.ReportUnavailableAPI(r, err)
 	}
 
 	return eventsMapping(r, m.HTTP, info, content, m.XPackEnabled)
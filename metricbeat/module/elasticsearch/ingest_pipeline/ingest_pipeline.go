@@ -105,9 +105,9 @@ func (m *IngestMetricSet) Fetch(report mb.ReporterV2) error {
 	}
 	m.HTTP.SetURI(uri.String())
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.FetchContent()
 	if err != nil {
-		return err
+		return m.ReportUnavailableAPI(report, err)
 	}
 
 	info, err := elasticsearch.GetInfo(m.HTTP, m.HostData().SanitizedURI)
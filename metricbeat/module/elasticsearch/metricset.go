@@ -22,11 +22,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/elastic/beats/v7/libbeat/common/productorigin"
 	"github.com/elastic/beats/v7/metricbeat/helper"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 const (
@@ -81,6 +83,9 @@ type MetricSet struct {
 	*helper.HTTP
 	Scope        Scope
 	XPackEnabled bool
+
+	scopeFallbackOnce   sync.Once
+	unavailableAPIsOnce sync.Once
 }
 
 // NewMetricSet creates an metric set that can be used to build other metric
@@ -115,15 +120,21 @@ func NewMetricSet(base mb.BaseMetricSet, servicePath string) (*MetricSet, error)
 	}
 
 	ms := &MetricSet{
-		base,
-		servicePath,
-		http,
-		config.Scope,
-		config.XPackEnabled,
+		BaseMetricSet: base,
+		servicePath:   servicePath,
+		HTTP:          http,
+		Scope:         config.Scope,
+		XPackEnabled:  config.XPackEnabled,
 	}
 
 	ms.SetServiceURI(servicePath)
 
+	if config.ApiKey != "" {
+		if err := ms.checkPrivileges(); err != nil {
+			return nil, err
+		}
+	}
+
 	return ms, nil
 }
 
@@ -143,6 +154,14 @@ func (m *MetricSet) ShouldSkipFetch() (bool, error) {
 	// we don't collect the same stats from every node and end up duplicating them.
 	if m.Scope == ScopeNode {
 		isMaster, err := isMaster(m.HTTP, m.GetServiceURI())
+		if IsAPIUnavailable(err) {
+			// Clusters that don't expose the nodes APIs, like serverless projects, are
+			// always reached through an endpoint fronting the whole cluster.
+			m.scopeFallbackOnce.Do(func() {
+				m.Logger().Infof("cannot determine if the connected Elasticsearch node is master, collecting %v stats with cluster scope: %v", m.Name(), err)
+			})
+			return false, nil
+		}
 		if err != nil {
 			return false, fmt.Errorf("error determining if connected Elasticsearch node is master: %w", err)
 		}
@@ -157,6 +176,44 @@ func (m *MetricSet) ShouldSkipFetch() (bool, error) {
 	return false, nil
 }
 
+// FetchContent fetches the service URI of the metricset. Unlike the helper.HTTP
+// method it shadows, it returns an APIUnavailableError when the cluster doesn't
+// expose the API.
+func (m *MetricSet) FetchContent() ([]byte, error) {
+	return fetchContent(m.HTTP, m.Name())
+}
+
+// ReportUnavailableAPI marks the metrics of the metricset as missing when err
+// is an APIUnavailableError, so dashboards can tell them apart from a failing
+// cluster. It returns nil in that case and err unchanged otherwise.
+func (m *MetricSet) ReportUnavailableAPI(r mb.ReporterV2, err error) error {
+	var apiErr *APIUnavailableError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	m.unavailableAPIsOnce.Do(func() {
+		m.Logger().Warnf("%v metrics are not collected from this cluster: %v", m.Name(), apiErr)
+	})
+
+	// The stack monitoring indices have a fixed mapping, the warning above is all
+	// we can report there.
+	if m.XPackEnabled {
+		return nil
+	}
+
+	r.Event(mb.Event{
+		ModuleFields: mapstr.M{
+			"unavailable": mapstr.M{
+				"metricset": m.Name(),
+				"api":       apiErr.Path,
+				"reason":    apiErr.Reason,
+			},
+		},
+	})
+	return nil
+}
+
 // GetMasterNodeID returns the ID of the Elasticsearch cluster's master node
 func (m *MetricSet) GetMasterNodeID() (string, error) {
 	http := m.HTTP
@@ -105,6 +105,16 @@ func TestFetch(t *testing.T) {
 				w.Header().Set("Content-Type", "application/json")
 				w.Write([]byte("{}"))
 
+			case "/_security/user/_has_privileges":
+				apiKey := r.Header.Get("Authorization")
+				if apiKey != expectedHeader {
+					t.Errorf("expected api key to be %s but got %s", expectedHeader, apiKey)
+				}
+
+				w.WriteHeader(200)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"has_all_requested": true, "cluster": {"monitor": true}, "index": {}}`))
+
 			default:
 				t.FailNow()
 			}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+)
+
+const hasPrivilegesPath = "/_security/user/_has_privileges"
+
+// privileges are the minimal privileges the credentials used by a metricset
+// need on the monitored cluster.
+type privileges struct {
+	Cluster []string
+	Index   []string
+}
+
+// requiredPrivileges returns the privileges needed by the given metricset.
+func requiredPrivileges(metricSet string) privileges {
+	switch metricSet {
+	case "ml_job":
+		return privileges{Cluster: []string{"monitor", "monitor_ml"}}
+	case "index", "index_recovery", "index_summary":
+		return privileges{Cluster: []string{"monitor"}, Index: []string{"monitor"}}
+	default:
+		return privileges{Cluster: []string{"monitor"}}
+	}
+}
+
+type hasPrivilegesRequest struct {
+	Cluster []string                 `json:"cluster"`
+	Index   []hasPrivilegesIndexRule `json:"index,omitempty"`
+}
+
+type hasPrivilegesIndexRule struct {
+	Names      []string `json:"names"`
+	Privileges []string `json:"privileges"`
+}
+
+type hasPrivilegesResponse struct {
+	HasAllRequested bool                       `json:"has_all_requested"`
+	Cluster         map[string]bool            `json:"cluster"`
+	Index           map[string]map[string]bool `json:"index"`
+}
+
+// missing lists the privileges the response reports as not granted.
+func (r hasPrivilegesResponse) missing() []string {
+	var missing []string
+	for name, granted := range r.Cluster {
+		if !granted {
+			missing = append(missing, "cluster:"+name)
+		}
+	}
+	for index, privs := range r.Index {
+		for name, granted := range privs {
+			if !granted {
+				missing = append(missing, "index["+index+"]:"+name)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// checkPrivileges validates that the API key configured for the metricset has
+// the privileges the metricset needs. Errors talking to the cluster are only
+// logged, as the cluster might not be reachable yet or have security disabled.
+func (m *MetricSet) checkPrivileges() error {
+	required := requiredPrivileges(m.Name())
+
+	resp, err := hasPrivileges(m.HTTP, m.GetServiceURI(), required)
+	if err != nil {
+		m.Logger().Warnf("could not validate the privileges of the API key used by the %v metricset: %v", m.Name(), err)
+		return nil
+	}
+
+	if missing := resp.missing(); !resp.HasAllRequested && len(missing) > 0 {
+		return fmt.Errorf("the API key used by the elasticsearch %v metricset is missing the privileges %v; "+
+			"grant them in the role descriptors of the API key or disable the metricset",
+			m.Name(), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func hasPrivileges(http *helper.HTTP, resetURI string, required privileges) (hasPrivilegesResponse, error) {
+	request := hasPrivilegesRequest{Cluster: required.Cluster}
+	if len(required.Index) > 0 {
+		request.Index = []hasPrivilegesIndexRule{{Names: []string{"*"}, Privileges: required.Index}}
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return hasPrivilegesResponse{}, err
+	}
+
+	http.SetMethod("POST")
+	http.SetBody(body)
+	http.SetHeader("Content-Type", "application/json")
+	defer func() {
+		http.SetMethod("GET")
+		http.SetBody(nil)
+	}()
+
+	content, err := fetchPath(http, resetURI, hasPrivilegesPath, "")
+	if err != nil {
+		return hasPrivilegesResponse{}, err
+	}
+
+	var resp hasPrivilegesResponse
+	if err := json.Unmarshal(content, &resp); err != nil {
+		return hasPrivilegesResponse{}, fmt.Errorf("failure parsing Elasticsearch Has Privileges API response: %w", err)
+	}
+	return resp, nil
+}
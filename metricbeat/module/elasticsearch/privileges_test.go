@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredPrivileges(t *testing.T) {
+	assert.Equal(t, privileges{Cluster: []string{"monitor"}}, requiredPrivileges("node_stats"))
+	assert.Equal(t, privileges{Cluster: []string{"monitor", "monitor_ml"}}, requiredPrivileges("ml_job"))
+	assert.Equal(t, privileges{Cluster: []string{"monitor"}, Index: []string{"monitor"}}, requiredPrivileges("index"))
+}
+
+func TestHasPrivilegesResponseMissing(t *testing.T) {
+	var resp hasPrivilegesResponse
+	err := json.Unmarshal([]byte(`{
+		"username": "elastic",
+		"has_all_requested": false,
+		"cluster": {"monitor": true, "monitor_ml": false},
+		"index": {"*": {"monitor": false}}
+	}`), &resp)
+	require.NoError(t, err)
+
+	assert.False(t, resp.HasAllRequested)
+	assert.Equal(t, []string{"cluster:monitor_ml", "index[*]:monitor"}, resp.missing())
+}
+
+func TestHasPrivilegesResponseAllGranted(t *testing.T) {
+	var resp hasPrivilegesResponse
+	err := json.Unmarshal([]byte(`{"has_all_requested": true, "cluster": {"monitor": true}, "index": {}}`), &resp)
+	require.NoError(t, err)
+
+	assert.Empty(t, resp.missing())
+}
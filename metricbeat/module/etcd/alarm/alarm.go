@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package alarm
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/etcd"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const apiVersion = "3"
+
+func init() {
+	mb.Registry.MustAddMetricSet("etcd", "alarm", New,
+		mb.WithHostParser(etcd.V3HostParser),
+	)
+}
+
+// MetricSet for etcd.alarm
+type MetricSet struct {
+	mb.BaseMetricSet
+	client *etcd.V3Client
+}
+
+// New etcd.alarm metricset object
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The etcd alarm metricset is beta.")
+
+	client, err := etcd.NewV3Client(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{BaseMetricSet: base, client: client}, nil
+}
+
+// Fetch reports the alarms active in the cluster. An event is reported
+// even when there is no alarm, so that the end of an alarm is visible.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var alarms alarmResponse
+	if err := m.client.Call("/v3/maintenance/alarm", alarmRequest{Action: "GET"}, &alarms); err != nil {
+		return err
+	}
+
+	reporter.Event(mb.Event{
+		MetricSetFields: eventMapping(alarms),
+		ModuleFields:    mapstr.M{"api_version": apiVersion},
+	})
+	return nil
+}
@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package alarm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetchEventContent(t *testing.T) {
+	response, err := os.ReadFile("../_meta/test/v3_alarm.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/maintenance/alarm" || string(body) != `{"action":"GET"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "etcd",
+		"metricsets": []string{"alarm"},
+		"hosts":      []string{server.URL},
+	}
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	assert.Equal(t, mapstr.M{
+		"count":      2,
+		"nospace":    true,
+		"corrupt":    false,
+		"types":      []string{"nospace"},
+		"member_ids": []string{"8211f1d0f64f3269", "8e9e05c52164694d"},
+	}, events[0].MetricSetFields)
+	assert.Equal(t, mapstr.M{"api_version": "3"}, events[0].ModuleFields)
+}
+
+func TestEventMappingNoAlarm(t *testing.T) {
+	var alarms alarmResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"header":{"cluster_id":"1"}}`), &alarms))
+
+	assert.Equal(t, mapstr.M{
+		"count":   0,
+		"nospace": false,
+		"corrupt": false,
+	}, eventMapping(alarms))
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package alarm
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/module/etcd"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// alarmRequest is the request of the Maintenance/Alarm method.
+type alarmRequest struct {
+	Action string `json:"action"`
+}
+
+// alarmResponse is the response of the Maintenance/Alarm method.
+type alarmResponse struct {
+	Header etcd.ResponseHeader `json:"header"`
+	Alarms []struct {
+		MemberID etcd.Uint64 `json:"memberID"`
+		Alarm    string      `json:"alarm"`
+	} `json:"alarms"`
+}
+
+func eventMapping(alarms alarmResponse) mapstr.M {
+	types := map[string]struct{}{}
+	members := map[string]struct{}{}
+	for _, alarm := range alarms.Alarms {
+		types[strings.ToLower(alarm.Alarm)] = struct{}{}
+		members[alarm.MemberID.ID()] = struct{}{}
+	}
+
+	_, nospace := types["nospace"]
+	_, corrupt := types["corrupt"]
+	event := mapstr.M{
+		"count":   len(alarms.Alarms),
+		"nospace": nospace,
+		"corrupt": corrupt,
+	}
+	if len(alarms.Alarms) > 0 {
+		event["types"] = sortedKeys(types)
+		event["member_ids"] = sortedKeys(members)
+	}
+	return event
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
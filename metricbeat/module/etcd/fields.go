@@ -32,5 +32,5 @@ func init() {
 // AssetEtcd returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/etcd.
 func AssetEtcd() string {
-	return "eJzMmU+P27gOwO/5FMRcXt/Da97h3eawwO60WBTYKYq2aA+LhctItK2NLLkinTT99Av5T8ZJ7EwyzqT1YQaILfInkqZI+iUsaXMLJErPAMSIpVu4eS1K38wANLEKphTj3S38MgOA+km497qyNAMIZAmZbiHDGQCTiHEZ38KfN8z25r9wk4uUN3/NAFJDVvNtLeMlOCxoqzVesimjlOCrsv1lQHe8vsRFX0B5J2gcAwuKYTGKQXIUWFMgCIQa0uALeP2gok/Qp8DSJCsKbLzb3uuAlrRZ+6B7v49gxSvqgl/fvYFWGqQ+QEESIlyI/2mFdjY7ILCEmsKB8r41HlF915mjdk8jr2eaee/hXZd1175x+nipt9avdwDHIR8B3YHtBI+QjnH12Yw+uDXuu0G+N6/Ap2N77PkIhZzajGobssMJtohXKxrEA6HKoSQKYBxITqBsxTKANW6ZPnTBg7c7ZlZoSSep9SijG+dKKWJOfEkB4zaGZDbyjBPKBmF3rNBKTCsL7zEVeP/uDgJ9rYjlUPY2CNFE1stRNALPIDh4S3fVnhVuqKRCuy9ya3IKq2n54EMtYZt86lQYw6lOUZ/+D//r7pDTpTdOhhLEguTUFJEjJ4P2acgXG6HzcsTnnCSnANglM/pmWHjstRiEalYmKkeXEc+Vr9x+mDd41rvsPLy3VbGgED3Z4rVKgIkcoJwOWQZfekbLifJFYURIPxOp8o7JccUPOqHRSfpUxpKcNi67ImGr8VTA5q2+Il+j8DheFkqVsGB4PucyOYFsMI+NI+Xo9PNZK5AisyL9GFaHpA0vp+S8V4aX18t4xUqpRC8S8YI2YfOd5jHL7R8fo2ZMfShQmtS4v+gRE38w36n2ufhAGjQKxpRz/+nu7rjT12iTlDdOJbpqTtG54/miUkuS+X8Gyf3ib1L7sdH8mDw1RP5o651YHq+DiS0DYB5LduszjmXQTiicsZVLBvLnYIT6XIfCzyDjqngurq587KsYJFugWpLT7UHz88VAy7c9S08KhCObumQ0/Nax1WpODoYjdJeLiH22owGxzV9U+LCZkm/vawnXy7iZTwoqYqvICVrr1dUSbrvTWikK6UYGIMcsfE/Fh8gEv/txazuStQ+Tjre3jYjr2VtZQ06SpnghJ1czd10v1AVNY+f6iD2eBfqsXd1xXd5ttTPE/NDY2XRKDOyOeaK0iw15DgYpx7ra4waJQVk587UiMJqcmNRQqDN9vFNQLBBP6dyMS/38SFf5FDYwOr60dXtWhRCDrO3g9rhGcOoqXkyx385OIIosUSKsc4rdrWFwXhOssZ5uhn57NkJVlZdFwiIeb7WhIlgkbK2UI8Mi9rh7fhkkjH8vaSfDrZf+xbuyB7UHUqs5lrGBbJuPIxXB8NDoESK37XUaNdsmp+fFaLAyeEXMI36sORfo9NpoyQMejEoeHdadjNnkppICMCnvdI/TcJvCYiX2YjuZ9c5u/j2OXS6zZwXeGvTpzIPwTE4/b2wMbaL+QLEbGvGYGyf8GaKCmwEMvGgzQG3dOXyMz6zQVvVDldOUGkcavAM2LrNdou+mYHuT/Z1t/vAouvwehzf7Yw+PMaYRyz+Jh0w9tu3sGA4/rDwo9oGmFEMfc2qE9L8BGqdsFZ3qmoLOeAe48FUzkX34ejD0LnbjsEl1VEYSe7v6C8fJ2WRncYrGnrRy5IWaoJ+n69dkSejJBO3yaQxVqXECQ7t8GoMKNIWhXT6RwRclBkKneY3l01l2xVyKaWKkHAg6n2tQLn0rTaAzyoFBKWsUlVM4vq1/AgAA//8+et5y"
+	return "eNrNml+P2zYMwN/7KYR7WTdcvYe93cOA7loMB7TFoS3ah2HwFJtJtLMlT5STZp9+pPwndmznnNjJmof2YsfkTxRNkZReiSfY3QlwUfxCCKdcAnfi5i19vaHvMWBkVeaU0XfiV7og/C/FexPnCdB3CwlIpEdWkr4hOKf0Cu/EHzeIyc2tuFk7l938SfeWCpIY77yMV0LLFGqt/HG7jKVYk2fllR7d/PmLH/pLREY7qTQKdNIpdCpC4dbSiS1YICwZi6U1qXi7V9EkaFLITIUbsEiK6nsVEBlna2zcuD6AxR/WJV4/PohSmlgaK1JwluEs/w8bmbx40SVIpE07upvGeEbzaxZAOqRCiIXSZAoQUZKjA3tbGIKveMAvv4j3ZDkHWuoImDdoyKondAFONq4fGq+JH5lcu9adagiJ0auDG0dGwZ8PeboAK8xSyMipDRS2wVthNIgM2J7+B1LHxS2vqRdLG8xkBL1gC2NonPo0tq9rICuS7grCSi1M7pjWqwrE573diTjZ0SgiyBx6j0RPHZOFHaAgm6nET0sxDoUiVkh/QRwMmNnaPHPzj4cZYumkt3o1NsIpFULci8OKsRem+9KMgPnM4pjAW6Q5973aC8pQxTMiPLypAQrxKLbKrWnWWkC3/IKt4ZuMIVIpvdCHbDQTMdgp7/N9Fd18tC3kNSJd7wu7Gvu6Lk2SmG0LcBhyhNlq2ErwAOkQV5NNxZ1bx6e0w/fwhudwYIyNOZIUAKPdoLY+O4ywBX9K0cIZATJaU9Ti16kVk3ueG7JMy+mx93bFjJFMIA6XiZFucOCYU0RCDA0FU8nDwEEb8CKx6oVtWaGUuMwT8VEunfj4eE9e+U8O6HAQYykVs85HUQg8gaDzlk5wNwoPuUwORTY0IUwJB+9YQB2bhtd1/7vvd0VPimGsaNl0nTSlYzUEu5kWRT95CXUG1jHXz9Ud0HFmVGvE51htLTHs9apyjd45ODfhKJcA+EZhFYcs92LYzcNoLfUKMLjYzDJeqYSmDmjJdOMhM2sygzLBMDJpqhy5x4VIqW5A0JjjXqcodDbSnGcYM/IXKnOuSFhqHAtYxMIr8hUKj+OtbBaFlBnYy00ucTmx6o3+w0jks/HlrGUhAsoc4+ewKiQqAZ6mxLw39Pz1Il66iaIwXoTOOJmEqP6FgKMcjjUjFcipdEVoxNNM/ImU+Tl3xpJ5ff1CIef9l/v745O+JdIl7jSB50XuEWgMFnn0BC74qZfcLP6G6NA3iovhuS7yrswSuUewtYr7JkKuuW+RmBVy8thyhROGMqcjfyUyaHJ1hZ9Ahnl6Ka4q6W6q6CVbSJpoHZcLzffnAyVfvZaOcoQjg5rTG36r2Lya0c5whG4+jzhkO+oQjS6Csbsp8fa9l3C9iLsyIUFzgY2hpBo3ulrALUfqlUrO370MIX1dQjc/MZP43QxbW4OjkmrS8vahEHE9e0eJoqQiLJIX+utq5vb5gk9oCjv7JfZ4FGiyVnnHdXnrbKePeV/YJcv5mmMsbbbWWKf9dH43kZ0y14ryPZJK06JIr/WRft9fHFO5Kb00wZGq8qxOp4rrZkJuLTtZWcEdcA3g+CzeqRTmI2IWlii2a+DqVqHQJqav0m/x2KEudIMqz+ZFkikvb95QDMaEpZWo0KcYQpwH89K/DSHntRNZppilH7Atu1c7vZGbQGZcQJbFx5GMoL/V9gyR3u/YeDV1kdOYRTYYVYvcJhyYR8+5oFJsq2K3pgSh32ZHWpyjMYvYxHtJCFTMxg1OhWUI40zsZd3P5p2cH4exs6fVRYFrg57P3AtPq0t8Wd/oG4TfpW27Bi9zw4Tfg1dg0YARL8sI4K3L2310byOT3P8o1zEslablz2iB9POkCvRVFwyD4WH+7140/xj7B/v/Lh5DTG5GHlC+bVvZ0Xa3o5qKc5zU5PYS2vuWx3f8eRP6vtyjfv34gFM3CwqdwYy5U7mrsh/Q4JZrD8e86+0HktZmOWqD4xsAUzfp91vzjWSkvSv0PJ3Vl8fzGxas6Jb+1Ea/2hjf3BiT9pYDuZA/1ZtnIxyqMPCFQFoJ9yic7hmliTA+OFRnlZ73cQsbNQhwesPmvqw8KrEVAQ3lVbHc+NZuLwpYa+yMJz/eenmEkhlbtDZawSdRT53DKL1c8SK4ahP8cb1DRblB0uzLYNkZ5xFU3URujy/au9AtaqXDHK/fvO9DLBJECiKJWZVjo5cjH2KHpZWrgP9JgbeVrzcGPvE1OIgcCzcqTnvF7JZ4WwxNltDM6xuhYmvyhOumKJEqHTnMLHKnporVYCkBjECfmEg+Uhp1fMrGjqs/uMilCygwp3NHFz4P0pHb0qoopf02k9oHllWHeIlO1BvaBQox2d0wC1VjiaLpuyzTnkSUCnmbYWzwrUF3YSJXF9hf7hhMASf0robdgRsmq3elg1pSSB4fUskzc23lzxodHiNg8EaFRSVSVPSOMl7oDKXqS3DRegx/5QxXoa9sewr7kWTzUidNqoOZo7NeDMpUFC9x6sULHoLZF3ntV+nkGo9PFXshzdPuNDlJzpW7LuI6B1y54OPIzLI/WNfXcKnOPExqlq/A8QaeP/w3umXUepiPjYx6cqBrMkE/TtdfLO1nE5SPT2PIM1qDz2coH5/GEFmYwlA+PpHBpJkkQTrGrczOZ2mLmYtpoqd0BJ3O1V9FfcuUhRN6vgNHPWhJGAqvlYT/AGQH9is="
 }
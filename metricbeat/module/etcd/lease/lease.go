@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lease
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/etcd"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const apiVersion = "3"
+
+func init() {
+	mb.Registry.MustAddMetricSet("etcd", "lease", New,
+		mb.WithHostParser(etcd.V3HostParser),
+	)
+}
+
+// MetricSet for etcd.lease
+type MetricSet struct {
+	mb.BaseMetricSet
+	client *etcd.V3Client
+}
+
+// leasesResponse is the response of the Lease/LeaseLeases method.
+type leasesResponse struct {
+	Header etcd.ResponseHeader `json:"header"`
+	Leases []struct {
+		ID etcd.Uint64 `json:"ID"`
+	} `json:"leases"`
+}
+
+// New etcd.lease metricset object
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The etcd lease metricset is beta.")
+
+	client, err := etcd.NewV3Client(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{BaseMetricSet: base, client: client}, nil
+}
+
+// Fetch reports the number of leases granted in the cluster.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var leases leasesResponse
+	if err := m.client.Call("/v3/lease/leases", struct{}{}, &leases); err != nil {
+		return err
+	}
+
+	reporter.Event(mb.Event{
+		MetricSetFields: mapstr.M{
+			"count": len(leases.Leases),
+		},
+		ModuleFields: mapstr.M{"api_version": apiVersion},
+	})
+	return nil
+}
@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/module/etcd"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// statusResponse is the response of the Maintenance/Status method.
+type statusResponse struct {
+	Header           etcd.ResponseHeader `json:"header"`
+	Version          string              `json:"version"`
+	DBSize           etcd.Uint64         `json:"dbSize"`
+	DBSizeInUse      etcd.Uint64         `json:"dbSizeInUse"`
+	Leader           etcd.Uint64         `json:"leader"`
+	RaftIndex        etcd.Uint64         `json:"raftIndex"`
+	RaftTerm         etcd.Uint64         `json:"raftTerm"`
+	RaftAppliedIndex etcd.Uint64         `json:"raftAppliedIndex"`
+	Errors           []string            `json:"errors"`
+	IsLearner        bool                `json:"isLearner"`
+}
+
+// memberListResponse is the response of the Cluster/MemberList method.
+type memberListResponse struct {
+	Members []struct {
+		ID        etcd.Uint64 `json:"ID"`
+		Name      string      `json:"name"`
+		IsLearner bool        `json:"isLearner"`
+	} `json:"members"`
+}
+
+func eventMapping(status statusResponse, members memberListResponse) mapstr.M {
+	memberID := status.Header.MemberID
+	event := mapstr.M{
+		"member": mapstr.M{
+			"id":         memberID.ID(),
+			"is_leader":  memberID != 0 && memberID == status.Leader,
+			"is_learner": status.IsLearner,
+		},
+		"cluster": mapstr.M{
+			"id": status.Header.ClusterID.ID(),
+		},
+		"version":  status.Version,
+		"revision": uint64(status.Header.Revision),
+		"leader": mapstr.M{
+			"id": status.Leader.ID(),
+		},
+		"db": mapstr.M{
+			"size": mapstr.M{
+				"bytes": uint64(status.DBSize),
+			},
+			"in_use": mapstr.M{
+				"bytes": uint64(status.DBSizeInUse),
+			},
+		},
+		"raft": mapstr.M{
+			"term":          uint64(status.RaftTerm),
+			"index":         uint64(status.RaftIndex),
+			"applied_index": uint64(status.RaftAppliedIndex),
+			"apply_lag":     lag(status.RaftIndex, status.RaftAppliedIndex),
+		},
+	}
+
+	// The space of the deleted keys is only reclaimed by a defragmentation.
+	if status.DBSizeInUse > 0 && status.DBSize >= status.DBSizeInUse {
+		fragmented := uint64(status.DBSize - status.DBSizeInUse)
+		event.Put("defrag.fragmented.bytes", fragmented)
+		event.Put("defrag.fragmented.pct", float64(fragmented)/float64(status.DBSize))
+	}
+
+	if len(status.Errors) > 0 {
+		event.Put("errors", status.Errors)
+	}
+
+	learners := 0
+	for _, member := range members.Members {
+		if member.IsLearner {
+			learners++
+		}
+		if member.ID == memberID && member.Name != "" {
+			event.Put("member.name", member.Name)
+		}
+	}
+	event.Put("members.count", len(members.Members))
+	event.Put("members.learners", learners)
+
+	return event
+}
+
+func lag(index, applied etcd.Uint64) uint64 {
+	if applied > index {
+		return 0
+	}
+	return uint64(index - applied)
+}
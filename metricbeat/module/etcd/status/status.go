@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/etcd"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const apiVersion = "3"
+
+func init() {
+	mb.Registry.MustAddMetricSet("etcd", "status", New,
+		mb.WithHostParser(etcd.V3HostParser),
+	)
+}
+
+// MetricSet for etcd.status
+type MetricSet struct {
+	mb.BaseMetricSet
+	client *etcd.V3Client
+
+	// previous holds the raft indexes of the last fetch, to compute the
+	// rates of the raft proposals.
+	previous *sample
+}
+
+// sample is the state of the raft log at a point in time.
+type sample struct {
+	time         time.Time
+	index        uint64
+	appliedIndex uint64
+}
+
+// New etcd.status metricset object
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The etcd status metricset is beta.")
+
+	client, err := etcd.NewV3Client(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{BaseMetricSet: base, client: client}, nil
+}
+
+// Fetch reports the status of the member from the Maintenance API, with
+// the members of the cluster from the Cluster API.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var status statusResponse
+	if err := m.client.Call("/v3/maintenance/status", struct{}{}, &status); err != nil {
+		return err
+	}
+	var members memberListResponse
+	if err := m.client.Call("/v3/cluster/member/list", struct{}{}, &members); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	fields := eventMapping(status, members)
+	if rates := m.rates(now, status); rates != nil {
+		fields.DeepUpdate(rates)
+	}
+
+	reporter.Event(mb.Event{
+		MetricSetFields: fields,
+		ModuleFields:    mapstr.M{"api_version": apiVersion},
+	})
+	return nil
+}
+
+// rates returns the number of raft proposals committed and applied per
+// second since the previous fetch. The raft index grows by one with each
+// committed proposal.
+func (m *MetricSet) rates(now time.Time, status statusResponse) mapstr.M {
+	current := &sample{
+		time:         now,
+		index:        uint64(status.RaftIndex),
+		appliedIndex: uint64(status.RaftAppliedIndex),
+	}
+	previous := m.previous
+	m.previous = current
+	if previous == nil || current.index < previous.index || current.appliedIndex < previous.appliedIndex {
+		// First fetch, or the member was replaced.
+		return nil
+	}
+	elapsed := now.Sub(previous.time).Seconds()
+	if elapsed <= 0 {
+		return nil
+	}
+	return mapstr.M{
+		"raft": mapstr.M{
+			"proposals": mapstr.M{
+				"committed_per_sec": float64(current.index-previous.index) / elapsed,
+				"applied_per_sec":   float64(current.appliedIndex-previous.appliedIndex) / elapsed,
+			},
+		},
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetchEventContent(t *testing.T) {
+	status, err := os.ReadFile("../_meta/test/v3_status.json")
+	require.NoError(t, err)
+	members, err := os.ReadFile("../_meta/test/v3_member_list.json")
+	require.NoError(t, err)
+
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/maintenance/status":
+			calls.Add(1)
+			w.Write(status)
+		case "/v3/cluster/member/list":
+			w.Write(members)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "etcd",
+		"metricsets": []string{"status"},
+		"hosts":      []string{server.URL},
+	}
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 1, calls.Load())
+
+	fields := events[0].MetricSetFields
+	for field, expected := range map[string]interface{}{
+		"member.id":               "8e9e05c52164694d",
+		"member.name":             "etcd-0",
+		"member.is_leader":        true,
+		"member.is_learner":       false,
+		"cluster.id":              "cdf818194e3a8c32",
+		"leader.id":               "8e9e05c52164694d",
+		"version":                 "3.5.9",
+		"revision":                uint64(1204),
+		"db.size.bytes":           uint64(2097152),
+		"db.in_use.bytes":         uint64(1572864),
+		"defrag.fragmented.bytes": uint64(524288),
+		"defrag.fragmented.pct":   0.25,
+		"raft.term":               uint64(3),
+		"raft.index":              uint64(5821),
+		"raft.applied_index":      uint64(5819),
+		"raft.apply_lag":          uint64(2),
+		"members.count":           3,
+		"members.learners":        1,
+	} {
+		v, err := fields.GetValue(field)
+		if assert.NoError(t, err, field) {
+			assert.Equal(t, expected, v, field)
+		}
+	}
+	_, err = fields.GetValue("raft.proposals")
+	assert.Error(t, err, "rates are only reported from the second fetch")
+}
+
+func TestRates(t *testing.T) {
+	m := &MetricSet{}
+	now := time.Now()
+	assert.Nil(t, m.rates(now, statusResponse{RaftIndex: 100, RaftAppliedIndex: 90}))
+
+	rates := m.rates(now.Add(10*time.Second), statusResponse{RaftIndex: 150, RaftAppliedIndex: 150})
+	assert.Equal(t, 5.0, rates["raft"].(mapstr.M)["proposals"].(mapstr.M)["committed_per_sec"])
+	assert.Equal(t, 6.0, rates["raft"].(mapstr.M)["proposals"].(mapstr.M)["applied_per_sec"])
+
+	// A lower index means that the member was replaced.
+	assert.Nil(t, m.rates(now.Add(20*time.Second), statusResponse{RaftIndex: 10, RaftAppliedIndex: 10}))
+}
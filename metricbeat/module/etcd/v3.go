@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+// V3HostParser parses the hosts of the metricsets using the v3 API. The
+// path of the URL is the prefix of the API, and is empty by default.
+var V3HostParser = parse.URLHostParserBuilder{DefaultScheme: "http"}.Build()
+
+// V3Client calls the etcd v3 gRPC services, like Maintenance, Cluster and
+// Lease, through the gRPC gateway that etcd serves as JSON over HTTP on its
+// client URLs. TLS client certificates are configured with the `ssl` settings
+// of the module.
+type V3Client struct {
+	http *helper.HTTP
+	base string
+}
+
+// NewV3Client returns a client of the v3 API of the host of the metricset.
+func NewV3Client(base mb.BaseMetricSet) (*V3Client, error) {
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	http.SetMethod("POST")
+	http.SetHeader("Content-Type", "application/json")
+	return &V3Client{
+		http: http,
+		base: strings.TrimSuffix(base.HostData().SanitizedURI, "/"),
+	}, nil
+}
+
+// Call sends the request to the gRPC method at path, for example
+// /v3/maintenance/status, and decodes its response in response.
+func (c *V3Client) Call(path string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	c.http.SetURI(c.base + path)
+	c.http.SetBody(body)
+	content, err := c.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error calling %s: %w", path, err)
+	}
+	if err := json.Unmarshal(content, response); err != nil {
+		return fmt.Errorf("error decoding response of %s: %w", path, err)
+	}
+	return nil
+}
+
+// ResponseHeader is the header of the responses of the v3 API.
+type ResponseHeader struct {
+	ClusterID Uint64 `json:"cluster_id"`
+	MemberID  Uint64 `json:"member_id"`
+	Revision  Uint64 `json:"revision"`
+	RaftTerm  Uint64 `json:"raft_term"`
+}
+
+// Uint64 is a 64 bits integer of the v3 API. The gateway encodes them as
+// strings, to not lose precision in JSON.
+type Uint64 uint64
+
+func (u *Uint64) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*u = 0
+		return nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+	*u = Uint64(v)
+	return nil
+}
+
+// ID returns the integer formatted like member and cluster IDs by etcdctl.
+func (u Uint64) ID() string {
+	return strconv.FormatUint(uint64(u), 16)
+}
@@ -18,6 +18,11 @@
 package json
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -27,6 +32,102 @@ import (
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
+// decode returns the JSON documents of the response body. The body holds a
+// single document unless json.multi_document is set, in which case it can
+// hold any number of concatenated or newline delimited documents.
+func (m *MetricSet) decode(content []byte) ([]interface{}, error) {
+	if !m.multiDocument {
+		var doc interface{}
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return nil, err
+		}
+		return []interface{}{doc}, nil
+	}
+
+	var docs []interface{}
+	dec := json.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc interface{}
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode document %d of the response: %w", len(docs)+1, err)
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// extract returns the objects of a document that are published as events,
+// after splitting the document and applying the json.fields rules.
+func (m *MetricSet) extract(doc interface{}) ([]mapstr.M, error) {
+	items := []interface{}{doc}
+	switch {
+	case m.jsonIsArray:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a JSON array, got %T", doc)
+		}
+		items = arr
+	case m.split != nil:
+		items = nil
+		for _, v := range m.split.find(doc) {
+			// A path selecting a single array splits its elements, a
+			// wildcard path splits the selected values.
+			if arr, ok := v.([]interface{}); ok && !m.split.hasWildcard() {
+				items = append(items, arr...)
+			} else {
+				items = append(items, v)
+			}
+		}
+		if len(items) == 0 {
+			m.Logger().Debugf("json.split path '%s' didn't select any value", m.split)
+		}
+	}
+
+	objs := make([]mapstr.M, 0, len(items))
+	for _, item := range items {
+		if len(m.fields) > 0 {
+			objs = append(objs, m.extractFields(item))
+			continue
+		}
+		switch v := item.(type) {
+		case map[string]interface{}:
+			objs = append(objs, v)
+		default:
+			if m.split == nil && !m.jsonIsArray {
+				return nil, fmt.Errorf("expected a JSON object, got %T", item)
+			}
+			// Values of split arrays that are not objects are kept under
+			// the value key.
+			objs = append(objs, mapstr.M{"value": v})
+		}
+	}
+	return objs, nil
+}
+
+// extractFields builds an object from the values selected by the
+// json.fields rules. A rule whose path has a wildcard sets the list of the
+// selected values, other rules set the selected value.
+func (m *MetricSet) extractFields(item interface{}) mapstr.M {
+	obj := mapstr.M{}
+	for _, rule := range m.fields {
+		values := rule.Path.find(item)
+		if len(values) == 0 {
+			continue
+		}
+		var value interface{} = values[0]
+		if rule.Path.hasWildcard() {
+			value = values
+		}
+		if _, err := obj.Put(rule.Target, value); err != nil {
+			m.Logger().Debugf("failed to set field '%s': %v", rule.Target, err)
+		}
+	}
+	return obj
+}
+
 func (m *MetricSet) processBody(response *http.Response, jsonBody interface{}) mb.Event {
 	var event mapstr.M
 
@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package json
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const statusDoc = `{
+  "cluster": {"name": "prod", "health": "green"},
+  "nodes": [
+    {"name": "a", "load": 0.5, "tags": ["x", "y"]},
+    {"name": "b", "load": 1.5, "tags": []}
+  ]
+}`
+
+func TestJSONPath(t *testing.T) {
+	m := &MetricSet{}
+	docs, err := m.decode([]byte(statusDoc))
+	require.NoError(t, err)
+	doc := docs[0]
+
+	tests := []struct {
+		path string
+		want []interface{}
+	}{
+		{"$", []interface{}{doc}},
+		{"cluster.health", []interface{}{"green"}},
+		{"$.cluster['name']", []interface{}{"prod"}},
+		{"$.nodes[1].name", []interface{}{"b"}},
+		{"$.nodes[-1].load", []interface{}{1.5}},
+		{"$.nodes[*].name", []interface{}{"a", "b"}},
+		{"$.cluster.*", []interface{}{"green", "prod"}},
+		{"$.nodes[*].tags[*]", []interface{}{"x", "y"}},
+		{"$.nodes[2].name", nil},
+		{"$.missing", nil},
+	}
+	for _, test := range tests {
+		p, err := compileJSONPath(test.path)
+		require.NoError(t, err, test.path)
+		assert.Equal(t, test.want, p.find(doc), test.path)
+	}
+
+	for _, invalid := range []string{"$.a[", "$.a[x]", "$..a", "$.a.[0]"} {
+		_, err := compileJSONPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	m := &MetricSet{}
+	docs, err := m.decode([]byte(statusDoc))
+	require.NoError(t, err)
+
+	t.Run("split", func(t *testing.T) {
+		m := &MetricSet{split: mustCompile(t, "$.nodes")}
+		objs, err := m.extract(docs[0])
+		require.NoError(t, err)
+		require.Len(t, objs, 2)
+		assert.Equal(t, "a", objs[0]["name"])
+		assert.Equal(t, "b", objs[1]["name"])
+	})
+
+	t.Run("split with fields", func(t *testing.T) {
+		m := &MetricSet{
+			split: mustCompile(t, "$.nodes[*]"),
+			fields: []fieldRule{
+				{Path: mustCompile(t, "name"), Target: "node.name"},
+				{Path: mustCompile(t, "tags[*]"), Target: "node.tags"},
+				{Path: mustCompile(t, "missing"), Target: "missing"},
+			},
+		}
+		objs, err := m.extract(docs[0])
+		require.NoError(t, err)
+		assert.Equal(t, []mapstr.M{
+			{"node": mapstr.M{"name": "a", "tags": []interface{}{"x", "y"}}},
+			{"node": mapstr.M{"name": "b"}},
+		}, objs)
+	})
+
+	t.Run("split scalar values", func(t *testing.T) {
+		m := &MetricSet{split: mustCompile(t, "$.nodes[0].tags")}
+		objs, err := m.extract(docs[0])
+		require.NoError(t, err)
+		assert.Equal(t, []mapstr.M{{"value": "x"}, {"value": "y"}}, objs)
+	})
+
+	t.Run("object expected", func(t *testing.T) {
+		m := &MetricSet{}
+		_, err := m.extract([]interface{}{1.0})
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeMultiDocument(t *testing.T) {
+	m := &MetricSet{multiDocument: true}
+	docs, err := m.decode([]byte("{\"a\": 1}\n{\"a\": 2}{\"a\": 3}\n"))
+	require.NoError(t, err)
+	assert.Len(t, docs, 3)
+
+	_, err = m.decode([]byte("{\"a\": 1}\n{\"a\": "))
+	assert.Error(t, err)
+}
+
+func TestRequestTemplate(t *testing.T) {
+	tmpl, err := newRequestTemplate(
+		`{"from": "{{formatDate .last "RFC3339"}}", "to": {{unix .now}}, "env": "{{.vars.env}}"}`,
+		map[string]string{"X-Env": "{{.vars.env}}"},
+		map[string]interface{}{"env": "prod"},
+		time.Minute,
+	)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	body, headers, err := tmpl.render(now)
+	require.NoError(t, err)
+	assert.Equal(t, `{"from": "2024-05-01T11:59:00Z", "to": 1714564800, "env": "prod"}`, body)
+	assert.Equal(t, map[string]string{"X-Env": "prod"}, headers)
+
+	// The next request starts where the previous one ended.
+	body, _, err = tmpl.render(now.Add(10 * time.Second))
+	require.NoError(t, err)
+	assert.Contains(t, body, `"from": "2024-05-01T12:00:00Z"`)
+
+	_, err = newRequestTemplate(`{{.vars.env`, nil, nil, time.Minute)
+	assert.Error(t, err)
+
+	tmpl, err = newRequestTemplate(`{{.vars.missing}}`, nil, map[string]interface{}{}, time.Minute)
+	require.NoError(t, err)
+	_, _, err = tmpl.render(now)
+	assert.Error(t, err)
+}
+
+func mustCompile(t *testing.T, expr string) *jsonPath {
+	t.Helper()
+	p, err := compileJSONPath(expr)
+	require.NoError(t, err)
+	return p
+}
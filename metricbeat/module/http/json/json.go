@@ -18,9 +18,10 @@
 package json
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/elastic/beats/v7/metricbeat/helper"
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -66,6 +67,16 @@ type MetricSet struct {
 	responseEnabled bool
 	jsonIsArray     bool
 	deDotEnabled    bool
+	multiDocument   bool
+	split           *jsonPath
+	fields          []fieldRule
+	template        *requestTemplate
+}
+
+// fieldRule copies the values selected by a path to a field of the event.
+type fieldRule struct {
+	Path   *jsonPath `config:"path" validate:"required"`
+	Target string    `config:"target" validate:"required"`
 }
 
 // New create a new instance of the MetricSet
@@ -74,13 +85,18 @@ type MetricSet struct {
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 
 	config := struct {
-		Namespace       string `config:"namespace" validate:"required"`
-		Method          string `config:"method"`
-		Body            string `config:"body"`
-		RequestEnabled  bool   `config:"request.enabled"`
-		ResponseEnabled bool   `config:"response.enabled"`
-		JSONIsArray     bool   `config:"json.is_array"`
-		DeDotEnabled    bool   `config:"dedot.enabled"`
+		Namespace         string                 `config:"namespace" validate:"required"`
+		Method            string                 `config:"method"`
+		Body              string                 `config:"body"`
+		RequestEnabled    bool                   `config:"request.enabled"`
+		RequestHeaders    map[string]string      `config:"request.headers"`
+		RequestVars       map[string]interface{} `config:"request.vars"`
+		ResponseEnabled   bool                   `config:"response.enabled"`
+		JSONIsArray       bool                   `config:"json.is_array"`
+		JSONMultiDocument bool                   `config:"json.multi_document"`
+		JSONSplit         *jsonPath              `config:"json.split"`
+		JSONFields        []fieldRule            `config:"json.fields"`
+		DeDotEnabled      bool                   `config:"dedot.enabled"`
 	}{
 		Method:          "GET",
 		Body:            "",
@@ -93,6 +109,14 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	if err := base.Module().UnpackConfig(&config); err != nil {
 		return nil, err
 	}
+	if config.JSONIsArray && config.JSONSplit != nil {
+		return nil, errors.New("json.is_array and json.split can't be used together")
+	}
+
+	tmpl, err := newRequestTemplate(config.Body, config.RequestHeaders, config.RequestVars, base.Module().Config().Period)
+	if err != nil {
+		return nil, err
+	}
 
 	http, err := helper.NewHTTP(base)
 	if err != nil {
@@ -111,6 +135,10 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		responseEnabled: config.ResponseEnabled,
 		jsonIsArray:     config.JSONIsArray,
 		deDotEnabled:    config.DeDotEnabled,
+		multiDocument:   config.JSONMultiDocument,
+		split:           config.JSONSplit,
+		fields:          config.JSONFields,
+		template:        tmpl,
 	}, nil
 }
 
@@ -118,6 +146,16 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 // format. It publishes the event which is then forwarded to the output. In case
 // of an error set the Error field of mb.Event or simply call report.Error().
 func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	body, headers, err := m.template.render(time.Now())
+	if err != nil {
+		return err
+	}
+	m.body = body
+	m.http.SetBody([]byte(body))
+	for k, v := range headers {
+		m.http.SetHeader(k, v)
+	}
+
 	response, err := m.http.FetchResponse()
 	if err != nil {
 		return err
@@ -128,18 +166,23 @@ func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
 		}
 	}()
 
-	body, err := ioutil.ReadAll(response.Body)
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	docs, err := m.decode(content)
 	if err != nil {
 		return err
 	}
 
-	if m.jsonIsArray {
-		var jsonBodyArr []mapstr.M
-		if err = json.Unmarshal(body, &jsonBodyArr); err != nil {
+	for _, doc := range docs {
+		objs, err := m.extract(doc)
+		if err != nil {
 			return err
 		}
 
-		for _, obj := range jsonBodyArr {
+		for _, obj := range objs {
 			event := m.processBody(response, obj)
 
 			if reported := reporter.Event(event); !reported {
@@ -147,18 +190,6 @@ func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
 				return nil
 			}
 		}
-	} else {
-		var jsonBody mapstr.M
-		if err = json.Unmarshal(body, &jsonBody); err != nil {
-			return err
-		}
-
-		event := m.processBody(response, jsonBody)
-
-		if reported := reporter.Event(event); !reported {
-			m.Logger().Debug(fmt.Errorf("error reporting event: %#v", event))
-			return nil
-		}
 	}
 
 	return nil
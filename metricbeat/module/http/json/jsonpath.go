@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package json
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// jsonPath is a compiled path selecting values in a decoded JSON document.
+// It supports a subset of JSONPath: the root `$`, child names as `.name` or
+// `['name']`, array indexes as `[n]` (negative indexes count from the end),
+// and the wildcards `.*` and `[*]` selecting all the children of an object
+// or array.
+type jsonPath struct {
+	expr  string
+	steps []pathStep
+}
+
+type pathStep struct {
+	name     string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// compileJSONPath parses a path. The leading `$` is optional, so plain
+// dotted field names like `status.health` are valid paths.
+func compileJSONPath(expr string) (*jsonPath, error) {
+	p := &jsonPath{expr: expr}
+	s := strings.TrimSpace(expr)
+	s = strings.TrimPrefix(s, "$")
+	if s != "" && s[0] != '.' && s[0] != '[' {
+		s = "." + s
+	}
+
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			name := s[:end]
+			s = s[end:]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("invalid path '%s': empty field name", expr)
+			case "*":
+				p.steps = append(p.steps, pathStep{wildcard: true})
+			default:
+				p.steps = append(p.steps, pathStep{name: name})
+			}
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path '%s': missing ']'", expr)
+			}
+			sel := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+			switch {
+			case sel == "*":
+				p.steps = append(p.steps, pathStep{wildcard: true})
+			case len(sel) >= 2 && (sel[0] == '\'' || sel[0] == '"') && sel[len(sel)-1] == sel[0]:
+				p.steps = append(p.steps, pathStep{name: sel[1 : len(sel)-1]})
+			default:
+				index, err := strconv.Atoi(sel)
+				if err != nil {
+					return nil, fmt.Errorf("invalid path '%s': unsupported selector '[%s]'", expr, sel)
+				}
+				p.steps = append(p.steps, pathStep{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("invalid path '%s'", expr)
+		}
+	}
+	return p, nil
+}
+
+// Unpack implements the config unpacker interface, so paths are validated
+// when the configuration is loaded.
+func (p *jsonPath) Unpack(expr string) error {
+	compiled, err := compileJSONPath(expr)
+	if err != nil {
+		return err
+	}
+	*p = *compiled
+	return nil
+}
+
+func (p *jsonPath) String() string {
+	return p.expr
+}
+
+// hasWildcard returns whether the path can select more than one value.
+func (p *jsonPath) hasWildcard() bool {
+	for _, step := range p.steps {
+		if step.wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// find returns all the values selected by the path in doc.
+func (p *jsonPath) find(doc interface{}) []interface{} {
+	values := []interface{}{doc}
+	for _, step := range p.steps {
+		var next []interface{}
+		for _, v := range values {
+			next = append(next, step.apply(v)...)
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		values = next
+	}
+	return values
+}
+
+func (s pathStep) apply(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case mapstr.M:
+		return s.apply(map[string]interface{}(v))
+	case map[string]interface{}:
+		if s.wildcard {
+			// Sort the keys so the values are always selected in the same
+			// order.
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			values := make([]interface{}, 0, len(v))
+			for _, k := range keys {
+				values = append(values, v[k])
+			}
+			return values
+		}
+		if child, ok := v[s.name]; ok && !s.isIndex {
+			return []interface{}{child}
+		}
+	case []interface{}:
+		if s.wildcard {
+			return v
+		}
+		if s.isIndex {
+			index := s.index
+			if index < 0 {
+				index += len(v)
+			}
+			if index >= 0 && index < len(v) {
+				return []interface{}{v[index]}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package json
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// requestTemplate renders the body and headers of the request sent every
+// period. Templates can use the following data:
+//
+//   - .vars: the variables set in request.vars.
+//   - .now: the time of the current request.
+//   - .last: the time of the previous request, or .now minus the period for
+//     the first request.
+type requestTemplate struct {
+	body    *template.Template
+	headers map[string]*template.Template
+	vars    map[string]interface{}
+	period  time.Duration
+	last    time.Time
+}
+
+var templateFuncs = template.FuncMap{
+	"formatDate": formatDate,
+	"unix":       func(t time.Time) int64 { return t.Unix() },
+	"unixMilli":  func(t time.Time) int64 { return t.UnixMilli() },
+}
+
+var dateLayouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+}
+
+// formatDate formats t in UTC with a Go layout or the name of a layout of
+// the time package.
+func formatDate(t time.Time, layout string) string {
+	if named, ok := dateLayouts[layout]; ok {
+		layout = named
+	}
+	return t.UTC().Format(layout)
+}
+
+func newRequestTemplate(body string, headers map[string]string, vars map[string]interface{}, period time.Duration) (*requestTemplate, error) {
+	t := &requestTemplate{
+		headers: make(map[string]*template.Template, len(headers)),
+		vars:    vars,
+		period:  period,
+	}
+
+	var err error
+	if t.body, err = parseTemplate("body", body); err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		if t.headers[k], err = parseTemplate("request.headers."+k, v); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// render returns the body and headers of the request sent at now.
+func (t *requestTemplate) render(now time.Time) (string, map[string]string, error) {
+	last := t.last
+	if last.IsZero() {
+		last = now.Add(-t.period)
+	}
+	data := map[string]interface{}{
+		"vars": t.vars,
+		"now":  now,
+		"last": last,
+	}
+
+	body, err := execute(t.body, data)
+	if err != nil {
+		return "", nil, err
+	}
+	headers := make(map[string]string, len(t.headers))
+	for k, tmpl := range t.headers {
+		if headers[k], err = execute(tmpl, data); err != nil {
+			return "", nil, err
+		}
+	}
+	t.last = now
+	return body, headers, nil
+}
+
+func execute(tmpl *template.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
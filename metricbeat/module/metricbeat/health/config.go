@@ -0,0 +1,40 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"time"
+)
+
+type config struct {
+	// StalePeriods is the number of fetch periods without a successful event
+	// after which a metricset is considered stale.
+	StalePeriods int `config:"health.stale_periods" validate:"min=1"`
+}
+
+func defaultConfig() config {
+	return config{
+		StalePeriods: 3,
+	}
+}
+
+// staleAfter returns after how long without a successful event a metricset
+// with the given fetch period is considered stale.
+func (c config) staleAfter(period time.Duration) time.Duration {
+	return time.Duration(c.StalePeriods) * period
+}
@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	moduleName    = "metricbeat"
+	metricSetName = "health"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet(moduleName, metricSetName, New, mb.DefaultMetricSet())
+}
+
+// MetricSet reports a health score summarizing the fetch outcomes of all
+// other metricsets running in this Metricbeat.
+type MetricSet struct {
+	mb.BaseMetricSet
+	scorer *scorer
+
+	// health returns the current fetch health snapshots. It is replaced in
+	// tests.
+	health func() []module.MetricSetHealth
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The metricbeat health metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		scorer:        newScorer(config),
+		health:        module.Health,
+	}, nil
+}
+
+// Fetch reports the health score of the host and of each metricset.
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	now := time.Now()
+
+	var snapshots []module.MetricSetHealth
+	for _, h := range m.health() {
+		// The health metricset does not score itself.
+		if h.Module == moduleName && h.MetricSet == metricSetName {
+			continue
+		}
+		snapshots = append(snapshots, h)
+	}
+
+	report.Event(mb.Event{
+		Timestamp:       now.UTC(),
+		MetricSetFields: eventMapping(now, m.scorer.update(now, snapshots)),
+	})
+	return nil
+}
+
+func eventMapping(now time.Time, host hostScore) mapstr.M {
+	counts := map[string]int{statusHealthy: 0, statusDegraded: 0, statusUnhealthy: 0}
+	var stale int
+	var successes, failures int64
+	var unhealthy []string
+	details := make([]mapstr.M, 0, len(host.metricSets))
+	for _, ms := range host.metricSets {
+		counts[ms.status]++
+		if ms.stale {
+			stale++
+		}
+		successes += ms.successes
+		failures += ms.failures
+		if ms.status != statusHealthy {
+			unhealthy = append(unhealthy, ms.module+"/"+ms.metricSet)
+		}
+		details = append(details, detailMapping(now, ms))
+	}
+
+	fields := mapstr.M{
+		"score":  host.score,
+		"status": host.status,
+		"metricsets": mapstr.M{
+			"total":     len(host.metricSets),
+			"healthy":   counts[statusHealthy],
+			"degraded":  counts[statusDegraded],
+			"unhealthy": counts[statusUnhealthy],
+			"stale":     stale,
+		},
+		"events": mapstr.M{
+			"success":  successes,
+			"failures": failures,
+		},
+		"details": details,
+	}
+	if len(unhealthy) > 0 {
+		fields["unhealthy"] = unhealthy
+	}
+	return fields
+}
+
+func detailMapping(now time.Time, ms metricSetScore) mapstr.M {
+	detail := mapstr.M{
+		"module":    ms.module,
+		"metricset": ms.metricSet,
+		"score":     ms.score,
+		"status":    ms.status,
+		"stale":     ms.stale,
+		"events": mapstr.M{
+			"success":  ms.successes,
+			"failures": ms.failures,
+		},
+	}
+	if ms.successRate != nil {
+		detail["success_rate"] = *ms.successRate
+	}
+	if len(ms.errorClasses) > 0 {
+		errs := mapstr.M{}
+		for class, count := range ms.errorClasses {
+			errs[class] = count
+		}
+		detail["errors"] = errs
+	}
+	if !ms.lastSuccess.IsZero() {
+		detail["last_success"] = ms.lastSuccess.UTC()
+		detail["staleness"] = mapstr.M{"sec": int64(now.Sub(ms.lastSuccess).Seconds())}
+	}
+	if ms.lastError != "" {
+		detail["last_error"] = ms.lastError
+	}
+	return detail
+}
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func getConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{metricSetName},
+	}
+}
+
+func fakeHealth() []module.MetricSetHealth {
+	return []module.MetricSetHealth{
+		{
+			Module:       "system",
+			MetricSet:    "cpu",
+			Period:       10 * time.Second,
+			Successes:    10,
+			LastSuccess:  time.Now(),
+			ErrorClasses: map[string]int64{},
+		},
+		{
+			Module:       "mysql",
+			MetricSet:    "status",
+			Period:       10 * time.Second,
+			Failures:     3,
+			LastFailure:  time.Now(),
+			LastError:    "dial tcp 127.0.0.1:3306: connect: connection refused",
+			ErrorClasses: map[string]int64{module.ErrorClassConnection: 3},
+		},
+		{
+			Module:    moduleName,
+			MetricSet: metricSetName,
+			Successes: 1,
+		},
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	f.(*MetricSet).health = fakeHealth
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	fields := events[0].MetricSetFields
+	assert.Equal(t, 50.0, fields["score"])
+	assert.Equal(t, statusDegraded, fields["status"])
+	assert.Equal(t, []string{"mysql/status"}, fields["unhealthy"])
+
+	total, _ := fields.GetValue("metricsets.total")
+	assert.Equal(t, 2, total)
+	failures, _ := fields.GetValue("events.failures")
+	assert.EqualValues(t, 3, failures)
+
+	details := fields["details"].([]mapstr.M)
+	require.Len(t, details, 2)
+	assert.Equal(t, "status", details[1]["metricset"])
+	assert.Equal(t, map[string]interface{}{"connection": int64(3)}, map[string]interface{}(details[1]["errors"].(mapstr.M)))
+	assert.Equal(t, "dial tcp 127.0.0.1:3306: connect: connection refused", details[1]["last_error"])
+}
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	f.(*MetricSet).health = fakeHealth
+
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
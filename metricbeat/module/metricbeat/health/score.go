@@ -0,0 +1,175 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+)
+
+// Status values of a metricset and of the host.
+const (
+	statusHealthy   = "healthy"
+	statusDegraded  = "degraded"
+	statusUnhealthy = "unhealthy"
+)
+
+// Score thresholds, on a scale from 0 to 100.
+const (
+	healthyScore  = 90
+	degradedScore = 50
+)
+
+// metricSetScore is the health of a single module/metricset over the window
+// since the previous fetch.
+type metricSetScore struct {
+	module    string
+	metricSet string
+
+	score       float64
+	status      string
+	stale       bool
+	successRate *float64 // nil if no events were reported in the window.
+
+	successes    int64
+	failures     int64
+	errorClasses map[string]int64
+
+	lastSuccess time.Time
+	lastError   string
+}
+
+// hostScore aggregates the scores of all metricsets.
+type hostScore struct {
+	score      float64
+	status     string
+	metricSets []metricSetScore
+}
+
+// tracked holds the state of a metricset as of the previous fetch.
+type tracked struct {
+	firstSeen time.Time
+	previous  module.MetricSetHealth
+}
+
+// scorer computes health scores from successive health snapshots.
+type scorer struct {
+	config  config
+	tracked map[string]*tracked
+}
+
+func newScorer(config config) *scorer {
+	return &scorer{config: config, tracked: map[string]*tracked{}}
+}
+
+// update scores the given snapshots against the previous ones. Metricsets
+// that are no longer running are forgotten.
+func (s *scorer) update(now time.Time, snapshots []module.MetricSetHealth) hostScore {
+	var host hostScore
+	seen := make(map[string]struct{}, len(snapshots))
+	for _, h := range snapshots {
+		key := h.Module + "/" + h.MetricSet
+		seen[key] = struct{}{}
+
+		t, found := s.tracked[key]
+		if !found {
+			t = &tracked{firstSeen: now}
+			s.tracked[key] = t
+		}
+		host.metricSets = append(host.metricSets, s.score(now, t, h))
+		t.previous = h
+	}
+	for key := range s.tracked {
+		if _, found := seen[key]; !found {
+			delete(s.tracked, key)
+		}
+	}
+
+	host.score = 100
+	if len(host.metricSets) > 0 {
+		var total float64
+		for _, ms := range host.metricSets {
+			total += ms.score
+		}
+		host.score = total / float64(len(host.metricSets))
+	}
+	host.status = statusForScore(host.score)
+	return host
+}
+
+func (s *scorer) score(now time.Time, t *tracked, h module.MetricSetHealth) metricSetScore {
+	ms := metricSetScore{
+		module:       h.Module,
+		metricSet:    h.MetricSet,
+		successes:    h.Successes - t.previous.Successes,
+		failures:     h.Failures - t.previous.Failures,
+		errorClasses: map[string]int64{},
+		lastSuccess:  h.LastSuccess,
+		lastError:    h.LastError,
+	}
+	for class, count := range h.ErrorClasses {
+		if delta := count - t.previous.ErrorClasses[class]; delta > 0 {
+			ms.errorClasses[class] = delta
+		}
+	}
+
+	// Counters are shared by all instances of a metricset and start from
+	// zero again when all of them are restarted.
+	if ms.successes < 0 || ms.failures < 0 {
+		ms.successes, ms.failures = h.Successes, h.Failures
+	}
+
+	// Only periodic metricsets are expected to report on every period.
+	// Metricsets that never succeeded get a grace period from when they
+	// were first seen.
+	if h.Period > 0 {
+		since := h.LastSuccess
+		if since.IsZero() {
+			since = t.firstSeen
+		}
+		ms.stale = now.Sub(since) > s.config.staleAfter(h.Period)
+	}
+
+	if total := ms.successes + ms.failures; total > 0 {
+		rate := float64(ms.successes) / float64(total)
+		ms.successRate = &rate
+	}
+
+	switch {
+	case ms.stale:
+		ms.score = 0
+	case ms.successRate != nil:
+		ms.score = 100 * *ms.successRate
+	default:
+		ms.score = 100
+	}
+	ms.status = statusForScore(ms.score)
+	return ms
+}
+
+func statusForScore(score float64) string {
+	switch {
+	case score >= healthyScore:
+		return statusHealthy
+	case score >= degradedScore:
+		return statusDegraded
+	default:
+		return statusUnhealthy
+	}
+}
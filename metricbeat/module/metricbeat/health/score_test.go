@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+)
+
+func TestScorer(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newScorer(defaultConfig())
+
+	snapshot := func(now time.Time, successes, failures int64, lastSuccess time.Time, classes map[string]int64) []module.MetricSetHealth {
+		return []module.MetricSetHealth{
+			{
+				Module:       "system",
+				MetricSet:    "cpu",
+				Period:       10 * time.Second,
+				Successes:    successes,
+				Failures:     failures,
+				LastSuccess:  lastSuccess,
+				ErrorClasses: classes,
+			},
+			{
+				Module:    "system",
+				MetricSet: "socket",
+			},
+		}
+	}
+
+	// First fetch: everything since start is considered.
+	host := s.update(start, snapshot(start, 9, 1, start, map[string]int64{module.ErrorClassTimeout: 1}))
+	require.Len(t, host.metricSets, 2)
+	cpu := host.metricSets[0]
+	assert.InDelta(t, 90, cpu.score, 0.001)
+	assert.Equal(t, statusHealthy, cpu.status)
+	assert.InDelta(t, 0.9, *cpu.successRate, 0.001)
+	assert.Equal(t, map[string]int64{module.ErrorClassTimeout: 1}, cpu.errorClasses)
+
+	// A push metricset without events is healthy.
+	assert.Equal(t, 100.0, host.metricSets[1].score)
+	assert.Nil(t, host.metricSets[1].successRate)
+	assert.InDelta(t, 95, host.score, 0.001)
+
+	// Second fetch: only the window since the previous fetch counts.
+	now := start.Add(10 * time.Second)
+	host = s.update(now, snapshot(now, 10, 4, now, map[string]int64{module.ErrorClassTimeout: 4}))
+	cpu = host.metricSets[0]
+	assert.EqualValues(t, 1, cpu.successes)
+	assert.EqualValues(t, 3, cpu.failures)
+	assert.InDelta(t, 25, cpu.score, 0.001)
+	assert.Equal(t, statusUnhealthy, cpu.status)
+	assert.Equal(t, map[string]int64{module.ErrorClassTimeout: 3}, cpu.errorClasses)
+
+	// No success for more than three periods: stale.
+	lastSuccess := now
+	now = now.Add(31 * time.Second)
+	host = s.update(now, snapshot(now, 10, 4, lastSuccess, map[string]int64{module.ErrorClassTimeout: 4}))
+	cpu = host.metricSets[0]
+	assert.True(t, cpu.stale)
+	assert.Equal(t, 0.0, cpu.score)
+	assert.Equal(t, statusDegraded, host.status)
+}
+
+func TestScorerNeverSucceeded(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newScorer(defaultConfig())
+	snapshot := []module.MetricSetHealth{{Module: "mysql", MetricSet: "status", Period: 10 * time.Second, Failures: 1}}
+
+	host := s.update(start, snapshot)
+	assert.False(t, host.metricSets[0].stale, "metricsets get a grace period when first seen")
+	assert.Equal(t, 0.0, host.metricSets[0].score)
+
+	host = s.update(start.Add(time.Minute), snapshot)
+	assert.True(t, host.metricSets[0].stale)
+}
+
+func TestScorerCounterReset(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newScorer(defaultConfig())
+
+	s.update(start, []module.MetricSetHealth{{Module: "system", MetricSet: "cpu", Successes: 100}})
+	host := s.update(start, []module.MetricSetHealth{{Module: "system", MetricSet: "cpu", Successes: 2, Failures: 2}})
+	assert.EqualValues(t, 2, host.metricSets[0].successes)
+	assert.EqualValues(t, 2, host.metricSets[0].failures)
+}
+
+func TestScorerForgetsStoppedMetricSets(t *testing.T) {
+	s := newScorer(defaultConfig())
+	s.update(time.Now(), []module.MetricSetHealth{{Module: "system", MetricSet: "cpu"}})
+	host := s.update(time.Now(), nil)
+
+	assert.Empty(t, s.tracked)
+	assert.Equal(t, 100.0, host.score)
+	assert.Equal(t, statusHealthy, host.status)
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetPostgresql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/postgresql.
 func AssetPostgresql() string {
-	return "eJzUWk+P47oNv8+nIN7l7RbZoL3OoUCxr0AX6O7bh92ix4CR6ViILHklOZn00xeU5D+xncwkY8+2Oc0kFvkTSZE/Uv4Aezo9QmWc31lyP9QDgJde0SP88jV++e2Pf/7yAJCRE1ZWXhr9CH99AAD4TN5K4UAYpUh4yiC3poRuHTiyB7Ju/QDgCmP9Rhidy90j5KgcPQBYUoSOHmGHDwC5JJW5xyD8A2gsaQCNP/5U8fPW1FX6ZgIaf3o4yoh0nX7r6+nrQuHlQfpT+8OUtisa+fO7JsiMqEvSHiqyyQZQWSPIuRUb4ij1DqTOjS2RZbAZkO3nDfiCQNTWkvZnchtsYHLwBfqewFoUgA6cR0+AOmvWw4+a7GkNH1v/bE9nMsPvjKXabXj1plGy7j127qLmMzRh34wZetyio7WR2dkDjTmV0bvBD1csGqz66be4cWqlgy+kgy2KPekMJIeh1nGb3qyvA+N/J5Ht6XQ0doj6GXBfsKQZ0FWzWetrDA1ojNYhmdZcO7LrJXzFgkGZ3Y4ykDpE94uwTPjnBh/coRWrSkkRDuPmdcp7kuI5Hfj+BWCEkqT9GrPMknO3Qfn0FdK6BlCUdieGwjh/uz3+YZwPcloMrfIod8X5ylJlbMxKgGCJKwXBb1++gTJmX1e8OD6+4S1dxcmSZgrf7x+/AosDXZdbstGJPUNKB7XjpJkbC8KUZa0bfx+lL4JtR0KTrVdgLHz4C8gcEP6l5RM4I/aUhNIFX6TFG97RjXs5VZ0PUlFo3M710cmtomApB2gJsPbmgKKuS1BYa1GQXfW/PBq7J7sa6VFmJwUqdmkb/J2AqV+TJKjQolKk2i8YHpdbPUxHAEcrPT+SHNHaVBQk9pWROvzqPFpfVys4orIkSB742yMTDp2RDQXyiCoKW4+U/P3Jk3bSaAclnsDSTjpPNuFz0ceYZZK3gapN8cGI1/0XkE1GaYb+Vs/KkuBYkI5nOZEBOEYewMdqBXJN61Xz0GQiGInl5yJhmd6Kt6gdswSj32A7v7ZB29Pb3+M0yEBrFoPXniR1iqSMosJz2xvLhzxSMulAmyGUxOio5yCFzo9lTe8xSN6IAvVumsm8dpMROuMIsKKmC2COKL0c5dmIY2uMItQ3QrE1sf1GJKq1fFIJRgOCMmJ/xUy36f6YQs4ciFNTMsSQR3XZ84CqjumzY8MjoQB/Sv5+hO8F9fdETyTqsBdMhH1ytczUeG1jBS5FCJqO3SEvSxzW9r4okLp/qEaSJdu198AKtrW/FMn86Vxzw4YGKOAdbgMleM94pOvOj5OlVGiZu6R1kyDOANOToMqD0W0JDOK4MQv742/6ygVyCZ6UixrIWjNRLngnOTpfoS8gr3UjSqmrjuYlH87WTIvOpMOtomxoj5Y78SGxKPZN6ybJ8e/NurjP58he8NKNJ5Se/PBQ/OqgZObHVbfrPj/10mDKl2FR6CBHcrk7doMs2xmuEamBT6bxBffXLNytQPpu8ZigdKk18DnOa1HstZy2ocOwDX/WMP9G6SGsiwxYxtC7lsSeA3AP8SsirWMXRTCcIo6FFMUQzghES1t2kSO9ZhryzaOXzkvhALem9q3ySPESpWvrvWvHHP2pRaTbQ7c2M4sG5jjX3D656JikWztRUFarUUa4t6/4EtsJk0Mrua8vxmWBB4ItkYaKbG5seSk8+0gt/ajJ+QWQtpJnQuplSW4d/LUuh11ShJsrgzceue/GowIsTa1jTmIik0C6iNFVoXWLSZ9TJ2dp7i9bcCOpKSY59I4FWYJcqtQm8QY88yTDiXa/YsGlVEo6EkZnF9qAsSHcSYv/Zzsw/sIaLf8Txwo3GGNb5zlZt+4ZZfboTTpad2W15V30/XAd2wRxnR/V9jSdFF+AbZPXSs0OMMTmhUTtvKkqygAhAGBzOoEathTYE8hx/BSY9Q6MgRL1qd3G1T2mIrV8XEhLgutxGERdZUoDaJucj8BCHmihBBN60xAWkN6BOWoIygPXhHfa2BKVGrI4uODHAnWmgpeNo8AQus6v0ZoZ5pJR1/Qs5v1VG6FSRuASZalxYKvhcvPnNpYczToHYPrYECkXm4pEcY6cHgPVDErHlKq5HXgNpfpdE1hzDHdLjbzuVqn55sNRZn1s57dA7c3PJKOaQHk7lfp5dz9h+PLnQHhdgZYy9oaprbg0n/vJt0EroLLyp1sAh5OwMfkmiVygdibBvW5lfIPQ3qc9Nyt0a2HKclQcZsiUPR1tr9uz+hlRjRguposzvNYoxTb4uYgZBR9YvDTf2ioj9twB4PxZltlcUgCsYIT2KqRiCW+HutjHFTJuHiobKgZ5ashtrBEgUBScHqdG3ejDvRPzEwyDLdDEVBftCd6FnRqtWKBQdUYOCtkNjrqXC0aCzzWzWF5gKrIYhhju5DyVv7pApNN/8en3Vy3Kuw+evtQyZKbeqnsqWuwKWHRTQiK2ZOTtqUsHwxhYTY3iXkD/e1u62g6+ck8s+632ZM2RT6KvrV6iFTdHPoZRelPCw7TvRacygMvJi2IpbEn4ndCkdmQXGWEwtkb6neDqKluExwZsSfid0DJStBi0JPx2aMLoXEmxQE/f4BCoBXFtzGpiPtJqjBe0loQ5kD01eEcSn6EuVFbGoj2tw7Bj/kLWyE/DFGHp2RiAv416fRgJQksgTK3DHaSlHVpu88K7IMciDhrOl3DpG0lt4Lyj9W7NxdPGuzbuG10h9e79KlyjnysIV5hmt2EFmym7ATjylwfendG3Jz+b0YdDsVAJegO8oQXHLrgcO+ySO1zwjBcDG0kuWMLOGWEWiuD8jLGRDBn52Cq8LF/873Tqo5a9vTN8bc9+9lJovIriv8LLatO9/MQbomdSpT6Y9DpN81JokNu9EiqqGmqHu/haqA9HIXCuG94J7S5NX3e38pYvGXZDrGAVi5G0T9xGv/k7q/23RWytYXjze3YnO5+9OiytP6/p9fR04+XDH/FydbjuXKxApRaopu3AOpq2ve2w9VXjMseZtcro62z9Qugli/OxDCd09huhDla/iWm6vlrr7mr9OYCl1DPC+yy1LOtyVoD4NCdAfJodIOFFE94ed58J9ZzonM8yOsyH76upahVLlPOoM7QZZHSQXdXqzR/6SF94jRihl1Qae1rH4emMk6fh8UnT2TBCiBObOBNKV3jPmvgc54xDuxcADXOu+4Bm0no5W4/5AqxJ4Z1wE7l/O7iDm9yXwlVGoFowWoP8VwdrRLlgrI5h3hOqEeaykTpGemegRrDLxukY7J1hym3mkv5n+a92fwC5rEFHOKft+d8AAAD//5VSM/M="
+	return "eNrVXN2P2zYSf+9fQfSlyWFjXHFAD8jDAUF6hyvQtGmTXh8NWqJtIpKoktR63b/+ZoakRH3asqXdu31JbIvD38yQ88Wh3rAv4vyWlcrYgxbmj+wrxqy0mXjLvv7ovvz0y49fw7epMImWpZWqeMv+AV8w9kFYLRPDEpVlIrEiZXutctaMY0boR6HNBh43R6XtNlHFXh7esj3PjIBvtcgENzDbgcOnvRRZat4S8Tes4LnoQMM/ey7xea2q0n8zAA3/Ihy5Q7rxv8XzxHPxxMpHac/1D0OzTcyIfz8XgqUqqXJRWFYK7WXASq0SYcwDCuIkiwOTxV7pnCMNFANH+VnF7FGwpNIahrfoBmxM7eEZbiOCVXJk3DBjgQbjRRrGsz8qoc8b9r7Wz+7cokm/I5bysMXR2zDJJnqsraLw1xVhLMaUW76DIRsl09YDQZyZKg6dHyYkSlL94XvHuKipwwdp2I4nXwSwLHEZFoVj06rNNDD8OIgMdsNJ6XQeuJ+A2gLoysWk9dEtDRaE1iAZnrmCNbpZQ1dIGCgcDsC2LGh1X4VlQD8zdHDDrLwsM5nQZtzeN3lEye3Tju6vAJNkEnbvhqcp2D0zD8oPH5kfFwA5ajdiOIINnS+Pf8MoolNjqCd3dB/QXmlRgksgq8Q4fEJPIdj3P30C3akvVYmD3eNbZGkSJ1JaaPl+fv+RITlWVPkOFhIpMRIkSK4yaDTBfANjeV4VQd8naY8k2x5RL+sHBmPefMvkHjj+rZBPzCj4wRMVI7rwg7fI0UxeYEStA+8UgtrRPxq5ywRJyjCuYfFWVj3ypKpylvGqSI5CP8Rfgm38Al/15oHNBjLIUKX14m8IDP3qKbGSaw7eKau/QHjobou0N8tJS4uPeEXUMoVZki+lgq2Ov4Ij07YqH9iJZ1okQj7itycMOIoU9IkOEj45YpveJP98sqIwANGwnJ8B80EaeNDjM07HsB4lsgFMBRNPQpzWHyEbXKXgNeZqVsLuOh1F4fayDwaAMeMEIEAuciM2D+GhQUPQI4vPuYBlmBWreWEwSgA7uT4739SLNpo35nEYJIU1q8Grd1J2dkGZcBO2ZQ/7HDa5C8mAoULZHlE3OFJQxo3t0xrmkShvkyMvDmIVJh10xEGw3EwjYE4cdkPPzjocO6UgfixmQtGVQPn1gqha8n5KBuuBg4FJvkyIad7c7/2SU7AJwDR5QXTjqMZ6PvKscuaziYZ7RBn7i9f3W/YZpBvxJJ5EUhEv3Afsg6NlmvXHBimgK+KsEKdmk+c57/r2mBQERvGm6lGWKNfoATDMlR1byfjXqGYGQx0U7BXfUUjwGvFI0+wfI3OZcY2xix83CKIFWDwlogQ9FrULJHKYmBF/+E08ecLRBQ/S5QUTWis9zMkeNkjJwS3sweN5Ulk2qWgc8qY1Zph0Kg2HRZZ25VHHTrhJNMwRUjcJSxF+D+Mcn5eCPdLSzB0qnmx3U3wDLhMjP/S6Tfb5Q2QGvb2kQZRB9uhidmw6VrYRXCAJixN2poLHtCMOabC0zeB+gNKYVorn0K45slM2bQsxaWHnCeZ3GMdonIuApVt6U0bsEoBbAr+jC+tQRQ4MmojTUSbHLpweiDpsObgY6Z5qyCdQDsRPWCWCrV3ZenIX4vmQrvb3pi5zxFULF2531RpqFgFm39bMr1w0kaTZGPiQVplYKi3+yaUToJCacjyfW5dHDttjJ8AHl0JjfWhsecZItQDWIUhdHmlNeSGkFkIMsyF9bXIzCHefKT5zy31WFgJxnsOKcjYJAxkP0jiMpqTUzRl9NJ1opTG/rMH1qPo1iUsPgiJIjvYy82kSMmAxTlJoaL88IGHwT5k0AqLr1FwrCHMukv9nOSD+o1aF/NOVFWYIY1ft91gZjoSy+Or1c9TqSiuNXMR6mMY2ELguj2p3HjaKV2Db7qssWxwgrc0RQ22sKktQNmcEAMVpEoiOdoKiJ3DCPcJHnkYbRkFWXZxrNiZ59E5q/XUhIWJBf0yFqMlIqQNtu8ctsJIGaigkQthePmABIRumTgWjySnWZK8KPEvIsvNgRt/XI2RzaUZaVqA1jBCazC/MmiqMJd1cw7WY15MyAjgq4Wu4paDAeobx5M9stTBi0ToAho8hkDIuqfAhzgnNI4WaNGk/pAqnA/ceMGl1orOlQK85VQrfvDnJNMbWPgWqT34GI6oBlPNDqZc7+6Hiy18p4DVHcFMpakNVOhmrz73waRBkqnlpz3MA007Yqv3Wk1zBd3rCUbbSP0Goz9Mu1QrBX6k8l3Z5SxnNUee6kdRbgarDMGouWng17BaUwcsiRhS4YflYfWuHpS/MAPjyVhajOT8Bwwl6aCchHdfQNvnFGBdZ3D15Nqz28/QcglvnI8A7QrgH5nGo1A2i5o4zLAcUCjJ2gaEu12f2ijhVRYYEk6wCjOwom8JR01zQP+tpzYxkcYACe82piGHOkFHl3xgKpP0n9/TrSYki96TpsZQhVdUuu8WjuawASQcX4rB5IYO/qM1Bdw08DJXirgj/I5Ym08E7eULaz8UTOGXcibbSxRqpOJa2AvXgwqnad9WuJHB7YbH0sA42T/xGaLIwQtu1sAXqN4KrypSvhs0TvxFaCoHZatA88fnQsM0rk8kKOX3AAUlnItA3ppXAeKSe0R3QQkKH5zfngLdH8ULoAhGZ0uAHNlTsWN6RBfq+mJKA7b0kaPaul+uzHiGsyiRY/8EzSC0OXGOaR70gp6MrNLSHoOvrUQ1wXonNYYPOU7uzNswbzRH++/qBjtHbE9ARpjpscYLtkNwYg6RovODdCH13tosJvVsUI08QFfC6EuyrYHztoEpuUMEFLVI04lWwhpxT8PPkBJePGANl+J91qcJ19uJ/J1PvpexR08rWZK3Dz/t7Q+OOGCQedYfauKETVf77ux/xjAtCAA7urC3Hwdwe8/kuejN4PrIDmlem9evlyl1ZjDRJZtVBFssh+LmyZWU9WYTC63Ylp5AmNy+PZ1P/MBIDDpzU3QEubt9yaGoMSgecm5fswe0J7OUbb6+EVNvcNbpWnLroIF77grnLArH8ExSKhyqhSTR4XDmC1h2crwu1qfTsBJqP8YZEh2a7XsNyI5367HdClU+5XOqY5ucMHui0m1GJXIe2BJJWIURqOhU+5U3zSEQMz6HTXhcr3+/BDdQSC6UFmto0naxtHkbjm0s8gevEFrVtZhY0yO/a3cvKMYnODzw3hFsI2/k531hswJ3q2EqOpyQST6m3+6wyx3VAVyXKrGmziOG1jVKDh7km1X7/nFPEWHNIRrX6yizIxCOXGd/JrL5l4oKOtsQRPKkeu0RT7LKy2FcL/6uK8G2PNHkrYzdhDqx7SUjg4js63/5tJDbke7EFfudlBe5uDVjHgUHXF8FxMP4HxeDsNJ11Nme3AuYRLSsKUUSKrZK6L4Q9O7i4/HZhuO0Ii/g5BfJJ/iniBRFAxEviAYPIE9asMXKNLEOIX78aaRgkinSM12uCG926/PBivAcu/e7umpSBjb4M/1jbWKnFVvQN00Alpbdc+048Wr7fDXMiCx86DFG4K9WrtyB2ImDbnZ9pepf9fQzmI89k6tImiMyMWtBX/Er0GsCY7dcTQsR1LeBWi3Pe7lO8PzN1vZL4P7pNNXzYPHCFsUUV2PILu761SHSbO4tJWcEE/ODTXKrV0KHAjEuLTVfvfc1/z3kLrumyIKlACEdfDrRLP/ulyvg6g65Ac8VE0/By8opC/6DPqXktxB3zJvjFdf92x7XJYjONWbGjyom2bscD+U4xiUnHomXQYvo46TwpcdyWtEMXb1lsYMWnbOFYsiqKpvf7EkBIrxaE90EWMq/yRQHypyUB8qfFAUIoMYZw/rr7ANSWRGdsmorH5fB9VGWV+aKjBR/EdQpDHmXjtaID8hjplX2uDnoucgX/uO6eBVsjutvHtw/RGbdrKXBNCz5QvyjiNs4Fu0quAEqNGLcBTaW2UjwjVj/hjXB99vh8cDutxtfCxawkW3G1Ev27F6tDueJa7cO8Zak6mOuu1D7SGxeqA7vuOu2DvXGZYiV/Tf0j/bvVTyDXFWgP57Q86/yx2tXTLJpCjl/7d1WSeGLq2S07RbP4ASpG4AWy4IQdpTltzTG5e49B18jBxvA9x+Hr5blFQRdKVzqCijWNV2XdZCO1b6f5NU+fwssocllffIIoVoXlK01YhpMIQSap0Ksek7kp6KUz59bmqt+rEf+0Gav5D9aavptkbr2TbjeBeyfP+aHLCdgJ6wDXl88mSqceLBWn8KFViieBevs+HF0mGoQ6qI2mwk+vLEmXPaD6kZt2pbmeZxgFtsZsc+yFPkDO1b8EfWut9hOVvGRjeKgHx09UY3LvkaMritUOEqzjmLBaMMPohaD+iuRKuyhaKwAvXi5bXbn+9QC+2Hg9MOR2ybp8LLiFIKKuF6xAUX5Pxm8Y6Cl+VVUX7I2t4niCRef2SHZpZuIjKnznkW8hdKt5YCFfWclAzCWQQ6/3ciePfsvhy0jwZsVZ2GtWUuDBvcZq2RY+H4/+593733770G7gK7XCV1eaOacZYczWYb03UF0z+AhHGEEE+J0Tw0v3pOFnJz/qr8gmIoRnbk2bgWzdoGUuiPXE00PyALuCZ3LvCxfUCgv5bM5dHC7De4iGbK8zelwnR4av19nAhs3OdJ8yI1cN4aN7bUxQCrVFOWPZvCmof1jv7j66fjC6Mm7oWHzbkLmUtpTH9pXaOyUYXlMVvZ6BZghSndqIzRv91knn3Nw+TaJAuOi/RXC0E3z0GtAdrQFxk1zfXE2/ai2ttNsCqwUd16G70k8fBS834X4dlohWKjfhPOE2XScRugwMXxqxxi25GJSf4zKYYIBWRRMmmYATAJfJcLoFv2fUbNRfbyHGgogkmf3Kqn9pv/a8Gq8WoixS8RQCFbrzsrgIE5WX7hIaTebFyJJzko3djqcHxbqL38+BIadn/7Y+I4fU2/AVVmDAGS5H16vwFrh4vWdrqxIln/OnVa4PMUe/1W1prKITLddsCd/1lwIbuKbl+lY3kMiXGU9cLEEsSCty4sDlMWhWZ3K/zlqP+W/eqd/JTfu8bwZKF4McE+o53EbD1mEWJ2AyBYuF0Z6+ku1+dEZiuHlJOzZfIqethXCnwu/jvN4JL8f9gtv9giz+CxPUqGs="
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -33,11 +34,29 @@ type MetricSet struct {
 	mb.BaseMetricSet
 
 	db *sql.DB
+
+	// dbs holds the connection pools to the other databases of the server,
+	// by name.
+	dbs map[string]*sql.DB
+
+	discoverDatabases bool
 }
 
 // NewMetricSet creates a PostgreSQL metricset with a pool of connections
 func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
-	return &MetricSet{BaseMetricSet: base}, nil
+	config := struct {
+		DiscoverDatabases bool `config:"discover_databases"`
+	}{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	return &MetricSet{BaseMetricSet: base, discoverDatabases: config.DiscoverDatabases}, nil
+}
+
+// DiscoverDatabases returns whether per-database metrics must be collected
+// from all the databases of the server.
+func (ms *MetricSet) DiscoverDatabases() bool {
+	return ms.discoverDatabases
 }
 
 // DB creates a database connection, it must be freed after use with `Close()`
@@ -52,6 +71,39 @@ func (ms *MetricSet) DB(ctx context.Context) (*sql.Conn, error) {
 	return ms.db.Conn(ctx)
 }
 
+// DatabaseDB creates a connection to the given database of the server, it
+// must be freed after use with `Close()`
+func (ms *MetricSet) DatabaseDB(ctx context.Context, name string) (*sql.Conn, error) {
+	db, found := ms.dbs[name]
+	if !found {
+		var err error
+		// Later keys of a connection string override the earlier ones.
+		db, err = sql.Open("postgres", ms.HostData().URI+" dbname="+quoteConnValue(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open connection to database %s: %w", name, err)
+		}
+		if ms.dbs == nil {
+			ms.dbs = make(map[string]*sql.DB)
+		}
+		ms.dbs[name] = db
+	}
+	return db.Conn(ctx)
+}
+
+// Databases returns the names of the databases of the server accepting
+// connections, templates excluded.
+func (ms *MetricSet) Databases(ctx context.Context) ([]string, error) {
+	results, err := ms.QueryStats(ctx, "SELECT datname FROM pg_database WHERE datallowconn AND NOT datistemplate")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result["datname"].(string))
+	}
+	return names, nil
+}
+
 // QueryStats makes the database call for a given metric
 func (ms *MetricSet) QueryStats(ctx context.Context, query string) ([]map[string]interface{}, error) {
 	db, err := ms.DB(ctx)
@@ -60,6 +112,22 @@ func (ms *MetricSet) QueryStats(ctx context.Context, query string) ([]map[string
 	}
 	defer db.Close()
 
+	return queryStats(ctx, db, query)
+}
+
+// QueryDatabaseStats makes the database call for a given metric in the given
+// database of the server
+func (ms *MetricSet) QueryDatabaseStats(ctx context.Context, database, query string) ([]map[string]interface{}, error) {
+	db, err := ms.DatabaseDB(ctx, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain a connection with the database %s: %w", database, err)
+	}
+	defer db.Close()
+
+	return queryStats(ctx, db, query)
+}
+
+func queryStats(ctx context.Context, db *sql.Conn, query string) ([]map[string]interface{}, error) {
 	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query database: %w", err)
@@ -103,5 +171,15 @@ func (ms *MetricSet) Close() error {
 	if err := ms.db.Close(); err != nil {
 		return fmt.Errorf("failed to close connection: %w", err)
 	}
+	for name, db := range ms.dbs {
+		if err := db.Close(); err != nil {
+			return fmt.Errorf("failed to close connection to database %s: %w", name, err)
+		}
+	}
 	return nil
 }
+
+// quoteConnValue quotes a value of a key/value connection string.
+func quoteConnValue(v string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
@@ -105,3 +105,9 @@ func TestParseUrl(t *testing.T) {
 		assert.Equal(t, test.Expected, hostData.URI, test.Name)
 	}
 }
+
+func TestQuoteConnValue(t *testing.T) {
+	assert.Equal(t, `'postgres'`, quoteConnValue("postgres"))
+	assert.Equal(t, `'it\'s'`, quoteConnValue("it's"))
+	assert.Equal(t, `'a\\b'`, quoteConnValue(`a\b`))
+}
@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication_slot
+
+import (
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+)
+
+// Based on: https://www.postgresql.org/docs/17/view-pg-replication-slots.html
+// Columns added by later versions are optional.
+var schema = s.Schema{
+	"name":   c.Str("slot_name"),
+	"plugin": c.Str("plugin"),
+	"type":   c.Str("slot_type"),
+	"database": s.Object{
+		"oid":  c.Int("datoid", s.Optional),
+		"name": c.Str("database"),
+	},
+	"temporary":           c.Bool("temporary", s.Optional),
+	"active":              c.Bool("active"),
+	"active_pid":          c.Int("active_pid", s.Optional),
+	"xmin":                c.Int("xmin", s.Optional),
+	"catalog_xmin":        c.Int("catalog_xmin", s.Optional),
+	"restart_lsn":         c.Str("restart_lsn"),
+	"confirmed_flush_lsn": c.Str("confirmed_flush_lsn"),
+	"wal_status":          c.Str("wal_status", s.Optional),
+	"safe_wal": s.Object{
+		"bytes": c.Int("safe_wal_size", s.Optional),
+	},
+	"retained_wal": s.Object{
+		"bytes": c.Int("retained_wal_bytes", s.Optional),
+	},
+	"confirmed_flush_lag": s.Object{
+		"bytes": c.Int("confirmed_flush_lag_bytes", s.Optional),
+	},
+	"conflicting":         c.Bool("conflicting", s.Optional),
+	"inactive_since":      c.Time(time.RFC3339Nano, "inactive_since", s.Optional),
+	"invalidation_reason": c.Str("invalidation_reason", s.Optional),
+}
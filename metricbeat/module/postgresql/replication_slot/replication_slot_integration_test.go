@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build integration
+
+package replication_slot
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/tests/compose"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/postgresql"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	service := compose.EnsureUp(t, "postgresql")
+	createSlot(t, service.Host())
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+	require.NotEmpty(t, events)
+
+	var event mapstr.M
+	for _, e := range events {
+		if e.MetricSetFields["name"] == "metricbeat_test" {
+			event = e.MetricSetFields
+		}
+	}
+	require.NotNil(t, event, "Expected an event for the test slot")
+
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), event)
+
+	assert.Equal(t, "physical", event["type"])
+	assert.Equal(t, false, event["active"])
+	assert.Contains(t, event, "restart_lsn")
+	retained, err := event.GetValue("retained_wal.bytes")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, retained.(int64), int64(0))
+}
+
+func TestData(t *testing.T) {
+	service := compose.EnsureUp(t, "postgresql")
+	createSlot(t, service.Host())
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+// createSlot creates a physical replication slot reserving WAL right away,
+// dropped at the end of the test.
+func createSlot(t *testing.T, host string) {
+	t.Helper()
+
+	mod := mbtest.NewTestModule(t, getConfig(host))
+	hostData, err := postgresql.ParseURL(mod, postgresql.GetDSN(host))
+	require.NoError(t, err)
+	db, err := sql.Open("postgres", hostData.URI)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("SELECT pg_create_physical_replication_slot('metricbeat_test', true)")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := db.Exec("SELECT pg_drop_replication_slot('metricbeat_test')")
+		assert.NoError(t, err)
+	})
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "postgresql",
+		"metricsets": []string{"replication_slot"},
+		"hosts":      []string{postgresql.GetDSN(host)},
+		"username":   postgresql.GetEnvUsername(),
+		"password":   postgresql.GetEnvPassword(),
+	}
+}
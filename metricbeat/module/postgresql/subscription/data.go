@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package subscription
+
+import (
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+)
+
+// Based on: https://www.postgresql.org/docs/17/monitoring-stats.html#MONITORING-PG-STAT-SUBSCRIPTION
+var schema = s.Schema{
+	"id":      c.Int("subid"),
+	"name":    c.Str("subname"),
+	"enabled": c.Bool("enabled", s.Optional),
+	"worker": s.Object{
+		"pid":        c.Int("pid", s.Optional),
+		"leader_pid": c.Int("leader_pid", s.Optional),
+		"type":       c.Str("worker_type", s.Optional),
+		"relation": s.Object{
+			"oid": c.Int("relid", s.Optional),
+		},
+	},
+	"received_lsn": c.Str("received_lsn", s.Optional),
+	"last_message": s.Object{
+		"sent":     c.Time(time.RFC3339Nano, "last_msg_send_time", s.Optional),
+		"received": c.Time(time.RFC3339Nano, "last_msg_receipt_time", s.Optional),
+	},
+	"latest_end": s.Object{
+		"lsn":  c.Str("latest_end_lsn", s.Optional),
+		"time": c.Time(time.RFC3339Nano, "latest_end_time", s.Optional),
+		"age": s.Object{
+			"ms": c.Float("latest_end_age_ms", s.Optional),
+		},
+	},
+	"lag": s.Object{
+		"transport": s.Object{
+			"ms": c.Float("transport_lag_ms", s.Optional),
+		},
+		"pending": s.Object{
+			"bytes": c.Int("pending_bytes", s.Optional),
+		},
+	},
+}
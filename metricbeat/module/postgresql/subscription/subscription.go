@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package subscription
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/postgresql"
+)
+
+const query = `SELECT s.*,
+	sub.subenabled AS enabled,
+	EXTRACT(EPOCH FROM s.last_msg_receipt_time - s.last_msg_send_time) * 1000 AS transport_lag_ms,
+	EXTRACT(EPOCH FROM clock_timestamp() - s.latest_end_time) * 1000 AS latest_end_age_ms,
+	pg_wal_lsn_diff(s.received_lsn, s.latest_end_lsn) AS pending_bytes
+FROM pg_stat_subscription s JOIN pg_subscription sub ON sub.oid = s.subid`
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("postgresql", "subscription", New,
+		mb.WithHostParser(postgresql.ParseURL),
+	)
+}
+
+// MetricSet type defines all fields of the Postgresql MetricSet
+type MetricSet struct {
+	*postgresql.MetricSet
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The postgresql subscription metricset is beta.")
+
+	ms, err := postgresql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes one event per logical replication worker, or per
+// subscription without running worker.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	ctx := context.Background()
+	results, err := m.QueryStats(ctx, query)
+	if err != nil {
+		return fmt.Errorf("error in QueryStats: %w", err)
+	}
+
+	for _, result := range results {
+		data, _ := schema.Apply(result)
+		if _, ok := result["worker_type"]; !ok && result["pid"] != "" {
+			// Before PostgreSQL 17 the type of worker is only known by the
+			// relation it synchronizes, if any.
+			workerType := "apply"
+			if result["relid"] != "" {
+				workerType = "table synchronization"
+			}
+			_, _ = data.Put("worker.type", workerType)
+		}
+		reporter.Event(mb.Event{
+			MetricSetFields: data,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vacuum
+
+import (
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+)
+
+// Based on: https://www.postgresql.org/docs/17/progress-reporting.html#VACUUM-PROGRESS-REPORTING
+// The dead tuple counters were replaced by dead item counters in PostgreSQL 17.
+var schema = s.Schema{
+	"pid": c.Int("pid"),
+	"database": s.Object{
+		"oid":  c.Int("datid"),
+		"name": c.Str("datname"),
+	},
+	"relation": s.Object{
+		"oid":  c.Int("relid"),
+		"name": c.Str("relname", s.Optional),
+	},
+	"phase":      c.Str("phase"),
+	"autovacuum": c.Bool("autovacuum", s.Optional),
+	"started":    c.Time(time.RFC3339Nano, "xact_start", s.Optional),
+	"duration": s.Object{
+		"ms": c.Float("duration_ms", s.Optional),
+	},
+	"heap": s.Object{
+		"blocks": s.Object{
+			"total":    c.Int("heap_blks_total"),
+			"scanned":  c.Int("heap_blks_scanned"),
+			"vacuumed": c.Int("heap_blks_vacuumed"),
+		},
+	},
+	"index_vacuum": s.Object{
+		"count": c.Int("index_vacuum_count"),
+	},
+	"indexes": s.Object{
+		"total":     c.Int("indexes_total", s.Optional),
+		"processed": c.Int("indexes_processed", s.Optional),
+	},
+	"dead_tuples": s.Object{
+		"max":   c.Int("max_dead_tuples", s.Optional),
+		"count": c.Int("num_dead_tuples", s.Optional),
+	},
+	"dead_items": s.Object{
+		"count": c.Int("num_dead_item_ids", s.Optional),
+		"bytes": c.Int("dead_tuple_bytes", s.Optional),
+		"max": s.Object{
+			"bytes": c.Int("max_dead_tuple_bytes", s.Optional),
+		},
+	},
+}
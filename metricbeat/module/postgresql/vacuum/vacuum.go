@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vacuum
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/postgresql"
+)
+
+// Relation names can only be resolved in the database of the relation, they
+// are resolved here for the database of the connection.
+const query = `SELECT v.*,
+	a.query LIKE 'autovacuum:%' AS autovacuum,
+	a.xact_start,
+	EXTRACT(EPOCH FROM clock_timestamp() - a.xact_start) * 1000 AS duration_ms,
+	CASE WHEN v.datname = current_database() THEN v.relid::regclass::text END AS relname
+FROM pg_stat_progress_vacuum v LEFT JOIN pg_stat_activity a ON a.pid = v.pid`
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("postgresql", "vacuum", New,
+		mb.WithHostParser(postgresql.ParseURL),
+	)
+}
+
+// MetricSet type defines all fields of the Postgresql MetricSet
+type MetricSet struct {
+	*postgresql.MetricSet
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The postgresql vacuum metricset is beta.")
+
+	ms, err := postgresql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes one event per running VACUUM.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	ctx := context.Background()
+	results, err := m.QueryStats(ctx, query)
+	if err != nil {
+		return fmt.Errorf("error in QueryStats: %w", err)
+	}
+
+	if m.DiscoverDatabases() {
+		m.resolveRelations(ctx, results)
+	}
+
+	for _, result := range results {
+		data, _ := schema.Apply(result)
+		if pct, ok := scannedPct(result); ok {
+			_, _ = data.Put("heap.scanned.pct", pct)
+		}
+		reporter.Event(mb.Event{
+			MetricSetFields: data,
+		})
+	}
+
+	return nil
+}
+
+// resolveRelations resolves the names of the relations vacuumed in other
+// databases than the one of the connection, connecting to each of them.
+func (m *MetricSet) resolveRelations(ctx context.Context, results []map[string]interface{}) {
+	for _, result := range results {
+		if result["relname"] != "" {
+			continue
+		}
+		database, _ := result["datname"].(string)
+		oid, _ := result["relid"].(string)
+		relid, err := strconv.ParseUint(oid, 10, 32)
+		if database == "" || err != nil {
+			continue
+		}
+		names, err := m.QueryDatabaseStats(ctx, database, fmt.Sprintf("SELECT %d::regclass::text AS relname", relid))
+		if err != nil {
+			m.Logger().Debugw("Failed to resolve vacuumed relation", "database", database, "relid", relid, "error", err)
+			continue
+		}
+		if len(names) == 1 {
+			result["relname"] = names[0]["relname"]
+		}
+	}
+}
+
+// scannedPct returns the fraction of the heap blocks scanned.
+func scannedPct(result map[string]interface{}) (float64, bool) {
+	v, _ := result["heap_blks_total"].(string)
+	total, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || total <= 0 {
+		return 0, false
+	}
+	v, _ = result["heap_blks_scanned"].(string)
+	scanned, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return float64(scanned) / float64(total), true
+}
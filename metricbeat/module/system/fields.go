@@ -32,5 +32,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/system.
 func AssetSystem() string {
-	return "eJzsfXtvIzmS5//9KYg6DNq1J8uP7umd9R8HVJe374yrGhvlqtkFFgsVlRmSOGaS2SRTsvrTL/jIN/MlpWS5YWPQ021LwV8Eg8FgRDB4jp5ge4PkViqIfkBIEUXhBr17NL949wNCIchAkFgRzm7Q//kBIYTsH5FUWCUSRaAECeQEUfIE6OPDN4RZiCKIuNiiROIlTJBaYYWwABRwSiFQEKKF4BFSK0A8BoEVYUuHYvoDQnLFhZoFnC3I8gYpkcAPCAmggCXcoCX+AaEFARrKGwPoHDEcwQ2KBQ9ASvM7hNQ21h8WPIndbzy86J8H+7WUk6n7Q3GE4iiab8h+m47zBNsNF2Hh9w2j6Z+vK0jBWnJT9BsXCJ5xFBv5i4QxwpbvprXRgziZxoGqjS8DTCGcLSjHxT8uuIiwukExiACYGgDPfgEvAfGFmVZFIkAyBqbQfGumLmOBsADMbyiWCsEamJpWKBKJ1pgmgIhETIOi5A8IU0osieYg0pECLkAaNSIKCcyWIEvUjO5cIsXRlV9AUmGhZhpwTU5hefI6pGB43qyAlfjdYDNtQkFYH99q/gvMkVtyRaA8CJKYQIgIQxHW/7CfOfvy4fP7aWntZCYADVk63+3XvqOAM4UJk4jyAFNHre+K0vNdE1Zx9A5ZOBTnmk4BilYlh0DLGGGtqEsKZjwtMYyihCpivlewPulP2eBks1VhosgICUu/TlmhnC0rf2jhRv9o6B81KrswclSlT/4v9JBpgPQCUlxhWtFF1KWPqFUne6D/qkdFOFBkDR6zUZpuL+xEgjg+6i6rR5gBhmSMA2iYkhIHigRPchyN0OBwxBOm9gTm1PwUhfsEggEdwsWIAu6U8AB0jARwehLmDFG+OY8F4YKobbpJgOzDzdEkvStKEtITlLlB1QP48RS5ByC+wUSdoCwZ0sDQGWcoJPLpfT8+jmkjhuETv5+ekCWINQn0aUy73yvMQqr/Y4VFuNEHOMIUCJHEqnM9it+PJ/rRUEu+UK9pXjTe3Th86bnZAbmCl/Ble5glwtacJkxhsbUmwDm6ayJUgqn5xmZFqD0jr7axFonkojaYOVgW5MXVCkS6BXIxrX3hwxoTiucUEGd0qzfPb4w89xLkMe3iKxNQxEOgM3v08kqoHuzpISRzHNWU00MduteQsEWoAaJPhCXPvbDhUmhjPGQ4gh1xrf7wAvItyB5wzJE8SITQGhRQHjztBkvTmTUcy3eWVbzaShJgalHe3WpoLqoQkeVKuX/CMwSJAhtkiI1yC8ChnKAVsABQpL+gVpjVBuEM0qDGVJOdkvA7CjBDcoWFXSQSR5XPOFa/7yaolKfRhWXEkknMCqsVXiGQ90MVxZCgUBAntbiUBiQVLu41u8R5TIByRNvpC3/GAqQ7EBmjyKWa2g9zdp5HUGv08s1Kog2hFK3wGhBGEX4mURK5KCxfoO9Xl5d/Qf9ih/tuaNeIFSK1RbqYakXeIoWftDbmsV2mOMJBYHYCu92v60Q9WDSUnYNcryFahO5ZPdgoJzWyW56YhW4mrSjyLIWyFIAVCGM4rNyKuYMJIgv0U42si6gLQFihXy7/oqFNtF5Z5crsSJxMU2l+t9ozB3T1t8bJ+XNFlf5ccZvXGxH5swQgXtFB8u2o7OHw7cA5znnqhbJQPQRpku8SWbbNjnoXUjCKc3f/H9oKNTklf889o17+ifakTlIEQzNHJ8vI0I3+NBnZa7c/TZb6b/knin+Hff80ORl9839VbO7qAZwmk6/VDTg1afbxAiZpIET6StbM4drDe8Vj+FoLuL+WYpFTLrN4HYUJJ5jfP+m8+EtnJ3ffEV8a+a6b3Fs6sCgTraeE/1AVxZD0gyZRyD/o/0R391lBas9K+PRneI5iYIowqz2XIb4aPt2GPZM97FA2EASPn1tNIfwo3QhZjtVUnkd4ixhXaG5Ko9cktNs4pjQXeo2mi9F3MCQAh1OT8Bhx8RhPqeBhmLQhCrieIa0yMgm0hi8SSrcd+DaCKDg4QDPKjgiNBOdb1T+jlrqCvi/tAN6QMTDKsNG9S03aFBepDoUqfqCEQHHhKLmkL3GaxhCWMom0ZMynkCR/GD/0r1fXvWbw5QWkcShg48goJdZTTDWq3WIzalW5AtIqtB0EExGqzwQBZ6HMa9y1WTErttfEvhhEu2Y7ncVDA/RjDLneB+8u7mV5E28CyeMxnZcqRo1DOy6x4EsBUtYdBmBK8Hi7j8eQ+ybu9kyd5nAvAKdEZ3OiRvXvMrSasBZSHW6huuTFz/s5XodzoqWJ7RUVbm9NcU4zu/zz5b/9UpvlBaFQuiiFdnINczK1ApX8T2PUqWRMH2njMF6gOboX5K24tusJiwVZEwpLCG0AgjA7zNQLPYQ1CWDkQreyp1q+c/n9IoT1hf7r1XcvIj3uAaBoGlUo8Kx+/j5FdwxJHgEKsARzg+0/CAv5RqL7R3teMuUzaZnG94RlQv+OsETY7NNWu/XezOzsEs7sJUyltwG+gRCdwfMUwbMCwTA1sOR7/7SYU90s5qTBndxZFoawtvmGdm1u/FNiVstYRq1i8wnjIci0/MquyYk+lgarTORYe7xzwqxQ+cICmqAFpyEIOUFyG1HCnuTEHNKtTjcoPDfI/NzsLFVHtFpMVrAyRu5+RAsBL2o42g2ERjcbVQFKjqomn+oAyaXmOZpXAtxH9dELcmuXViLhuIcsPeBAeC/vJFRAt8X0MzWUep/eyx1wKmUpFTyC4ip1USO8XApY4ixspP14s4IrhaD5V/eudN01cPD3fCnl60aiBU9Y2LJ89ljSR7Tg6O/cGFO3Ibfxox3KAUvLrz516YI0SpCLFoU8kNWIp29qUbuJb5V3F/oazvqqQ9lea0xsZaFVAeoV+WIAjTnoAOiz+cdDaG3rmQEa00Qamb6vH08px+E+NurjwzdDA+E1CLyEPa3Ku6t3Qy29/hNhy9kCB4qLG3R1eTnM2n8qwDfOdNavJCIsUeBfw+/+ekpI/+qwNhicd1cnhfbKA9eP29QEvJROeHQBhSRLEvTL9dfZeamp8CvMGBy9mHY1aNWePJkP+Tk67C2emnW2XXn28iEtiVo4yfX7GSGUdLSzjdnXXBOl9hk86pnmm95ie8E64rHZHgLzVLvxqNycZweuYvOtkION/BAW0CTMPhxwZtMu823qTgY4WNkuXLWh58liAUKiMwnZgdiJBgcqwXRacUP8Rws9QO+GSXur1EczXMowZ7WT/MkfTHtpn52A3Q4VdSQfDLW8mx+E5hikp7frqNHq0nuXLTqC2+wYKsizsFDuFBLgLLY09jokWtPNbd05qA24+3Ju3bHQ/FcesXIz5L1KqX+qn0QhxMDC7Ix6/2gDn+ZmcAgKEyonKDa2GgUrCJ6yaEFhoX1vUAn08gc9J26/XbpTiEgUYBok1IQ05lhPS0EW5eRyOdz9GaI8Y2aCIRex4MFFBBFhCz6py0L/cFEc0HytCM6coXLLl1k6sihTzwLoKYL6AVH/3DN0//ifiBhGMZJJVLXSqQ4R5tqlpSp0nwUXJu778Ht9YbtZ5JlauK/3VYsG84b6mDjUaeZQz6NsPVlXW6VdZUUbXLVszTYvFrAgzzfo3X8Ztv676gOWg0pa8wyV3LfS7hSRigTS5hAhTFOIGkep92k5+jQ0PPPCwYWcmb6q9FJm3Xhnw/C+lEXMs/zDxXt6KzVpFHxWhwhqw8XTXqcbR6NwvHG/KVZUllp8pn83VbCLSgTveMWUoFYDD7zmIFkF36eykifqqAmeanmYLDX99UIk7EURCgiArIs9exsFGePgCUatl8nBONo9BXY4JCJD0lMwhE1BCC4OIxZL2tV9W0SELXvM1bEwSWBhNyLCpqHgcdz/ZDsIEWEBj0yZhJs70xdkA/q8YIftIbFDAuSJWvJ2gJVu4Jhu8La+m15q1+kWiw1hxkv+9fEWzSHAiQTnEGsHXEDMhcpjgM019JX9aCaTKMI9om7ZZjEHhfvtV5/djmQLzNhSe4dLyueYZqbdePtEbXvuPySe/ot3uvj8n1BzCjom7O7BpnVBNDSNDsYc7evHjuGScMzhvt12DzejRMHIY34iCtoHJkE06ix+/OzhNCu4LD2FgHbyuhyNgtcV5y8l4BArPCn2WJ8UH36odH5H43pdmBJctRgxVquM76nnqxFZCmwZdS9K1Eesvu2A9i/BGvjOQxFN3NCLrZv9+jf7cB/vMSAW2p3Zcdzl7uPWv9pnRJZEM9eNzzuw9ryXtQtKHbOdV7w40mnhWH0xFqLgUUgJG1nnFgmlKOBRhFl4rsnbc5ri9tmKIqiJSwWYbcoTtsRimUQmHiohxgK7vdZbFUGWjAuY4Tlfww26vvz5b34LLEHssLRtG5Xd1nWw2VXB9G5N2HIWEmFupGx3GB3Yur/Zt7+c7akBwNZEcKZnDq2xIHhOQTZrge0sqE267woPLtyaRL8JgF8fbyc2MmuN/v0j+k+/CSs3cUTjxeU+Pnw7lzEEZEGCYkAuzi+ADo22NV7DR11xoZ6hE8+d2NIzOm3386tgbTMF40QfCG3WnFGDtcFM+9CP0R5nL5pk3d38Cb1w5GrQk0ZJHJrd+04VDi6SRIRi4WK/3mH/okfJBFkcICQypnibn1wUj1OTnd5Lrl9B9Qu3oaXGq5Kw59GonPKYj0dlTOePSJWaj1ZF3NIDAx3ZLvh7Y1QBW504JF6b/myd3hZ5+t7mKqML6074EHT93+xCbUU4qH/i/LP57tD9qGu/69qvPJXF6BjJoUwD0n4NnpfHVlgWc5g2gVtJrn/kUUQU+rjCYgnoTHkKWjLK2Lor6ekSM7wEoUcxNpMok801CQB3pEqRvM9aE7sosC0mI7JbU4WUL5bQ0kL+ApKEemk9gkKP5A+YVqzF4BffumYkbbjunF4kQRktmzRc36hK6/T2oN0exfPxZ1qvv5AmmLFDHzOJbCoGLrUvQf1t2a1pVHJxn0YxJwibRbPBIkxLJmwtxH893N3+9wXh01JbFVexwVmx8YOTRodBCzALgGotMXfTZzstvkr2vp4GKi7+fMiJLQ0xJyTGlfYL5pC2KWjResDhgXAuQJmKtaw/jFTcFFlrt9HT26bUVuBAmNK2DWm5Qz9ERkjBCtcb+OyLx7TaMALS/3Z2/d44fqljLrfa6NAWo2VldRBopuxXn7UdCLPx6PFKOSSvqOrNDIbDCdJNQlM8T8tU+kpkVASGZAeErCrDH6fZoXTxN0Ih+wwX7gSbxqXtoULvb2aTsPkaIguhjoWn35Q1bVrFXBRkbJeOx1B/DWR3TVyUZSAN/d6eOiURUVPJF82b+n7nCE3ajpKWSXVAz858XpKlWFaBdoCZtuXBSh8Gw+o5FCuE2dacGrpEscK1UNxYotCkDyWKAm0tCtP5bA5I4LSdpeC84Qp44Ft4Oy/JNC+qF5DBI/PNzY5kzIW5oGlOA1g+2crJCMrP4KY/7lvZ9VgBeUa4dgTUpwVLSK5IrN0/XCPIODvX4nCUjQAllAbghedrCh7PUGenlrdAHXH/HgJGTpvubs2xiJuNGlPquJEIS8kDYuzwhqiV9YW0mP2RlzsTsxKAiGQ/KntZVlO9u7WhZNdJKKVuqBm+0ypdL1U8byl9KYooxmp1OCFp6qkj4/So2hHG/VomcxsF+lHa29a2R8UgkZnRjiE0R3e2BiEJ3383cXTMtSoHOVti6cWsaoKrGVwtHYBaDUoNUfN0BnGSTxSS2nVOKNhXwF0nFAMXy6csMecWuZfmhyA9IJjNgzMluD4oGLO74Vk6LBtKyAn6+NujsW5fvvqJ6r9LhVlowaT98ugWLTAROSlnBGPBtaQJZ5jSamzKScfcgXMBlTQil95VSCcsK6zfAFmu1BR9+VqA4aUrAFMX3quAkqBk4Q0nb/DSe8RHec/c8gQYIbsrSChMhGnmiZZkDUwf5wkPG9Yas7kJFoLIeP3H9aRImmibL5apwYv0Xu9C8c6oN0eHgzjBQaBMIx4chkRPxEQjOs9lUtwZlpyZQNM/mnrTOdoNOwPq2h1QD+OHqivm7jblt6rtrQAabO9OEPyLVv887GKDG6n5bHMrk+ZeZCuXTUapxqOFub5updk286iUicRLmNaaAdXhNRjxFoQSra/dWYgwxDDjrvFeT1D+EF8ZVVOMrxesvsJpznmNA6aQZyrj6gEQxAEnT290JkOctq8fNonet/Lq4HYVWwldT1EdcSr98Dpxpm/fHGxSnduyy5Q2vr1XB7erAAvoeovqiJPqg9dq94OFnDrHImm3/y3T2XeLM+OYPdhNbkQCkc6ucQNXfIMELBOKhT5aNpKy3P9Y7Oyn/R8BkiciAInkiic0NId7wJTyAKvGgnCfTH5PuMKHF8nXSpavUTDW4cTUf+3RQErdeVz0JUXCUj9Su2R2qtEZliiEBbGxk2YpF5Wj6RK7T3omS3No2X1gaXGiS2ya3KjLPIN2zDMHyj4WXHDMG4mWmnWmkZeSWKeFkqB0sNB58c2SjBMnFBvDihJperBfI630ZLkqhnRaxSvUCa/XbF02y7dhvZoM7NCFKtRUJMzEK09BGKaCh7MlSGVOyYQlPJFuzTUSJqwS5ysvYvtmsV9qQ3x7pzWHFlNe+etMjbm5t8ZUGqNTWjB6UZRNTLNx00vbiAIojmVvDbGsq5XgSlEIjy4ErSuyaVbntomCw4bODJPE8xRy+lNsVKu4se3pPSC1gq0T0PMKJ6YlnnnUYdFqlwrmTmt1aYZsUJ0IZPbCvua/KvHDb6FZsYxhIUxsETI6qyzR94V9NJ+QDg/DN1GmDKB428q5YuvrQYJp9KCPIZiCVz2aXPyRqfwaDkiZeOtG0PAYw4Ojhs5SY2gsLjD95/f1Lk/Fn76hB8lb/IQ+iGuoH1M3JN1CnFenEAUslW1xbedAf1AqbMKsnGUxwJTblhG72CuyeHXZcTBBvQ8nqFflUvHHP6F8DQJdXaKuM1+RjV9OlI1fhrHx0+WJ8vHT5TBGmpp11NnoiBHsyYW1fo96VaXmp9/JfZG0HLRGXfqUmkQrCWnPlY8kiRKqMAOeyIZMiBPcmyE4FTbeDEEXGz0MgWdp/5ZQ2rC0a8QKiVcctFTn9kq+ulRq+lKD545v+vOW4sp+XjjF5W4IHSekV3xUt+Bsl9pLauNfTMJ3+NyD2Gw2H71q0Afwm3FaUjzz6kqlAl2bEM1z+o1h89YeRT8driaVe1n2JlbClKu0JBItEhakpQzmpTfXMzp1D+w1IqzPrlo3TGGDew10WMynPf82TOMziRRVOFNfk0mKeNh3Ugv4xp7U7NZYakzql0knvoks3+UaLuZDKOg4vDSr5BAuuzJ/o6vTmb3l9364XnUmAnebja9Ns1G8jjiybvVLIr4sP/vql6btLYdD7Q0C0p9So4D9d/BMNWuRskxXOUOAg5X5aGUnb4lhE9m9lbdewkTDvFR7GTMtMLY9ad4c1QM5qsMd0giiqS3habqihPqY1a6bdQMYL7acd6VR821jreJZehWyb24gZzjCz6fD9AqyEs5ie++xObc3J06R67z+wLpx5fbUmtu0L86C1F7QzH9Mt+j37opyvbjYRLYK6atE9t3UtfQWmNDk8EUF5VtDLn1XuXNtL5CcVeb0PdrUGujkPwJM0/0h6sI3R1aWlD2+MW2b5GrFqd+SFnGuyHL1MkD1yEOQHt/oZJf0n304OwGbTiAjVeXWlbpQyi+QEiSOIczCzdb4U1hDU/yubxqN8k1LYG94qWCunk1zXhxd68iowxeUrs/4EX4edfhclfqMznk06ujcXFEbMPrsifTIpAyFoIlqne2NRG8eo6LQBPUuVkPQe3HLzan5fitIn2VIm1+UHEHzIITpb26f2nM+UiO9MX2nTFin6jfWOpGYy/c1YZWE0nJQ3FdYJ+9qpuVyTuGqQmu//nAQB1NL7XV4XHLzenwuuXl1XpfcnNJZo2p1zcpupHhWW/7mVFI/fTTz/uZzehh58znffM5X4HP6YDydapTR5RgOFmx8Ov1oY1UEnUHHRqrDJXPyLiJfVOTT5vY1Et7JHXw6zYBjdd7GjDhq2jMVxCdpKko2Qh8dvn58yB8krPVjHMLoqZqGok2ocuyxEY0097GeRkyvwU44YVXlVDMYXVLa+fyYSes0jUZ1IpvvWXkPC62s2yqF6mMuZYZbk/cllu1Lr3mDz7v7li4SFQg9lHREID0Q2XdMZ5hx/2tvZWzHWUAfGGfbiCcyD7aYtJ0pNbfvrpq7KOcCAmCKbs+NCTr79OVbs9ZQIlXp9YwoXkh0JlcRRO99HXP7C29B6LF3o98IhfM5Dp5Kb2Q74Xz68i1jdweujKyPzM+D3jXNwGPP0YqAwCJYkQDTmRXV7LT2i2IFTBZ0TGE7lzJ706lgPO2G0HwTcxRxyc1pSiuPOfWWWyPJsjx3k1v6IvTrsaTZG9ZFc1Faec0hvOqK3ElSL2A2myXlN6heGe2gHRGOYwhPi+NH8keh5/q5hYjc/2mkstkUj2tzYryE2QIntCOme4BL79pG4KzlaPnIrgRZLkGY4G/clusx0Afqwz+5mL0Cvg3QDsbRu8/6U+/sf0q00irE8oauLkJiX4mnW9PYVVXb3ec/pkm4St+3Mp39QlJsedpTo+SsMRZ1gEYSekDzT9NNgqfva2fXKXD6+OMOfPCkvaT6UIzwpHBy3ZeVtuc5erFyjG3RVRHqFSIwk/aZRLRKlmDk8n6CGG/ObI3ruAopZ3rkk5Ha3yst8/kC4UyQXnkNuw+xwfHJ8PqYZfh3nL2EwZoECs9PaMf/XIhRB5gxrmzvsYBiEkHYi9OUyzl9qj1ZgoZV/v9KefCE7u7fCv4PVfC/Q72/vch4KhprQ+u1J2G0rVmAEDYEqm24cRMwpWhulCrUi69xcNSSwRokJsKPc1E3F8DdxX36aDpnpm2XlrbrwkDpHoybvkqQvzfhWuaYt7k4JUHteaCWt4vQMEPgAPzjWhuD7B0jATHFgR7f2Jo363Ac6+BjYdz4eQzi3Oqpnm/3VGnvAPrOKGqERo3F78lUpyk5NJLX1/rqeP1v3lpfvXW8eet40wfXzs2sjtfHLrsF/baE35bwvnz8ORZl7gPYZ8hkEkW4dK9fEUVBc2jjxY/1D3gXaIvv6khkD7JU3iYCmb0M7B5YW3FZ8lIF6E1ZD1rsA+pbmm0SbpRoh9tdPZflsA1cImt4Cx2hLWMjIcmjcpWnlHthIWHtrvT+QEyjwCEoJAWIDyGSlPAwNIrHMfhfttwLjKU7CMsfPJqT8WfIkh2EJAQ8vkg00SYU6E79KNEaxBYljJInoC50SZR9eg3HMWCB5olpB2Occ9MYGlMkiUpciIQoFOGtS0r5WdvgJ/DU4+/PXkr4XB94GsWN7hndogVPmHFEOA1BuLdL/79NnblH3RoeYdWjHAT6YWHHWDwdYJVZsmNBN+9MuxfLQChMzMtn9m2xBr4S9sT4ppp6HIGxjJdCL+yVPdub53tpyH50EX0lCKy1XysQkSkiP1y1EoBrPkplw//q/1CjV17uosUTpky6qNAmzg1rPW/XP6pCpOtJTv8+2ipsP7bCdWK6zbZRh7A57GlCzTUN3nH8VB6OKJpv0d3F/bTmowlcCmgO9rsq3x/uRel/evXAH8bsUm+cty+0QaumPRmrWpf+PcY1ZT1EbZ2d6IHAd+VvDwBfV+ZJ39DSLQPwS2DLghk2LRnHQ/HRXaLXxJElPkHEYvny4e4WYSHw1j58EiYsxEwhv3dA5FNaDzuS5SuYPleEZQdpGf+QHr4ZoTBJps8akcawtWEySfHxRWLIht0isRfyxh/fXfTrHN+sr3ruoCWoXWqUt5NWowibp6kF3hgUdouUXpTG3o6rOYU6CUO8qDQrTkNTV4euLq9/Pp9vFaQQ2uDp9XkAX8nhcydsB9HWhgkTM9PjdqDN7BOIiu3y703ZjjMHhfvtWcUYQfpgsB1NHmHTMvdYCttUndHinW4czoy27TOapoJKG1PbmHsPl+6FA4ZM5vtzKZP5+TAmZ5KwWmDWjhnWwdQGNKWPCkdxOiA1SR3rPq8wW8LUvQeeQjHVbnbvwSxMPcOJPUno/ymJkrjcJrqIGp4hmAU83EtOj3f/9+P/+3SLNJ38AX6H8EeJIn0oqT7pjUoHEqJs6fH+c1acL0233myvPuoaWMjFLBYgoWrsB40egqkwHYIie4Zst6OLszbZq9G19/j9Wtt9dgniZNrybPCAA8TDt37vA5d7tnZcoe0/funaa+06QHVwk3mZmvLRvUYt3Cu0uZzeE1PYE0BtuHhqxNGrdMURyV8+aeoK1KtU5dgXR2w5VUOZdAFVjIMn2L1IfTAuN14XMp6ofaF5h22qty6Ou9dEeSahOGbdt+Ia2D7H/q8fHxwVmTt4dmvbL6kSEgHNh1JMSe32f4zVKls406bvR2Rpi9xukBJJQzfyvI1JRGrPDPVFoD+3z+CUB5hOSdVU2OFrv4ZnHMUUbtDVv11PL6fX0yvEBbq+vLy6ubz99W83H37999ubv/31p19ubq6GufWfNA5094BwGAqQ0hXsB5ihufZf0N3D+mc92N3D+pfsQ314i7nw79seFc/4u66+NNgLvh6qA5OAiCs4AYF/MUBGlrjj7igidwz0l/mKyyEOXAbsX385v766Or+6+tfzn36Zss3U/WUa8FrUtwPzw9cvSEDARejd9EU6J1N0p7SLzucKmyek1wQjAWsQsr493z0gyvlTY0lnRQygaDiLaSJnnA1xpzN57My+9oJhsYDA1XXG5zZ8GHJzCjiDr59u36eesZOFnjR7XZYzQBGv1/hRPAc6Rb9xkSKbGAKa2v++MsfudwvOp3MspktOMVtOuVhO32n5viv+ohYr/5pd4+IChaBARMQF1y15FPAIpCvfZgiiOYQhhCjg8TYLimJVe+rTfGGlVHxzcREnc0oCmSwW5Nng6K3LMxCidr1tj8DTv2ty7kPzlE379m02J0YDnboh14qjA3FalxHXapm79rjmbw7a4lIyAY8izHYF4QnC7IYiCikZtPA6ps280OZ4QyXSrTjg2Y+hWxLwDEFi7gbtIw/zos9glfB/a/jAjSG1jqEXCaWzAapQ9oGba5Mezd+R5+/7libxBeIxsMx/JnlBkgsQ7OVB44bCg87gRF2RPxg9Zsx61NVJ6IxJtB7LCVOwBN9d4B43FDQwI8NmdAWnk0gFrZndvbFkQxjnxx82U4Hfw9xxXvQJbPe56Xhpp1kgXWfvHgL7XG79VzxKpgGfCZpjaeto89AMpvr4oN0ze6nY3LW1AbXYXEogf8AUfeRCgIzNw4OKp+8ASTBFPRfaYl7IrbxgoC5IvP75QgXxLILI1XDkb8NzZis4ps13FusrDQ2L9jQrVPvsop4BIC7iFW6/tN480z3RGsR2rbtJcsNCqFU+ndpm+bZy0GRDxmYgtSfdcu9nVw6AT0NrszNVeCC1R0DkyltSMjLAPAdYGHaQNAPKJcw2uLHT80HQVhBqGzHLkcy8ybAybkWi04CdAemDWm7ZTDZXyh8NdIqjL2YBwfoUMGscfTAvCDNzUg0FHR10BmQI6mr858VQX/dBTbFUMxz4MjBHBZ3i6INZ25qj7CDdJo+wpQ9xdkgLR3Vfv93+SdxXzcgLuq9JeIrua/vsop7u67GdvybULf+SrY64ci1xcJTguyXxvdya0fVmYMtUVeynXCxhz1RbYgMk08hfzeBJDaTLJ/1q5c+ExYmapR+KCKXEXz7Qo5j1/jHllbASqXqpWCJByE7Z71Ao9okvlxCeZ09Pg5SEs2oAuU3GDeG0nUt8854RDox3VAm1m4Z7jPuBFVMjlC+JtlzVIVraU+zJ8+2viXRVnIZ6Hwl4krB7otBfz2qECtrQMAG+WpF95iBTvr6lKeX0hBfJnHMKtfhAJxL9NXP1IrCWCaeZoVaJ7FMq5p+R9MW2StFfC4aAj60VhdmwBjr0jJKX/OOwtlntXE++AiQ4V+ihn02wczQbmHLt3EI/lNKCLiedP3VWAZT/y/8EAAD//yjo1H4="
+	return "eJzsff1vG7nR/+/6K4gUxdmFvHnp3aHf/PAF0hiHR3iS2oiTtkBR2NQupWW9S+6RXCm6v/7BcMl95b5JK1m+ugmud7Y0/MxwZjgczpBX6JHs3iO5k4rEM4QUVRF5j17d6R+8miEUEOkLmijK2Xv0/2cIIZT9EkmFVSpRTJSgvpyjiD4S9PH2G8IsQDGJudihVOI1mSMVYoWwIMjnUUR8RQK0EjxGKiSIJ0RgRdnaoPBmCMmQC3Xvc7ai6/dIiZTMEBIkIliS92iNZwitKIkC+V4DukIMx+Q9SgT3iZT6ZwipXQIfFjxNzE8cvMDf2+xrlhPP/KI8QnkU4JvkP7XjPJLdloug9POW0eDv15BYsFqMxEO/cIHIdxwnWv4iZYyy9SuvMbqfpF7iqxK5bHzp44gE96uI4/IvV1zEWL1HCRE+YWoEvOwLeE0QX+lpVTQmSCaEKbTcIVVmgTKf6J9EWCpENoSpAjn8+RpSiTY4SgmiEjEAFdHfSGApsTReEmFH8rkgUqsRVUhgtiZ2Tg1ToDtvkOLorVtAUmGh7gFw6XuZnILq5PVIAUigbUhYhd8t1tMmFAma42ea/wRzZEyuDJT7fppQEiDKUIzhH9lnLr58+HzpVWwndwGjTOch+9oD8jlTmDKJIu7jyFAbalEw3w1hlUfvkYVBcQV0SlBAlQwCtOICYVDUdQReSGiJYRSnkaL6ewZyMZ91h4OQm4kyI7Rs/wUrEWfr2i86uIG/AP0joMoMo0BV+eQf0G2uAdIJSHGFo5ou9upjt04OQP8VRkXYV3RDHG6jMt1O2Kkk4vSo+7weZRoYkgn2iTeAA0X9R+nkYbRGwIqBY54ydSAwo+bnKNxHIhiJxnAxoYB7JTwCHaM+OT/15QxFfHuVCMoFVTu7SBA5hJuTSXpflDSIzlDmGlX+tXbgp1PkAYD4FlN1hrJkCIChC85QQOXj5TA+TifasfjEr+cnZEnEhvqwG4PwO8QsiOA/QiyCLWzgKFNEiDRRvfYofj2dVk+GWvKVek7zAnj34/Cp52YP5Irg6PxmhjJE2YZHKVNY7DIXYALdDRUqxZH+xjakUbZHDncJiERy0Rhsi2VFXlyFRNglkAuv8YUPG0wjvIwI4izaIc7QN0a/DxLkyRTg+Qko5gGJ7rOtl1NCzWTPACGBWDRlu6lDNwAJZwhhBtEnytLv+Re7sOGYHAUZjsmeuMLfnIBcBjkADuwRkZ8KASbmR9x/3A8W0LmnwbSySsKdpJDmAOpocQ3QTFYhputQmX+S78RPFcmSDIlWbkFwIOcoJJAwi+HbKsSsMQhnxCY1PCDr0eAB+ZghGYKzBy8icVz7jGH1YT9BWZ4mFxbAKiS2uO6Fl09dkh6UFPKTtJGXgtmDhKs8LM8DPE3pO13pz0QQaTZEMN8hl8rTqxHj7KrIoDboFYuVRFsaRSjEG4IwivF3GqexycLyFXp4++bNH9GftN7KB027QayUqS3TxREo8g4p/AjaWOR2meII+75eCbJQbFP3U8iFBaC0OOX+JNdzyBahG9ZMNsp5g+yOp9rQQXAl+rI4QlkLghUR8AOWya18djBHdIX+3CCr51ifwGCFfn7zR4AGxzImsZ37kST1rDQfMu1ZEvT2L62TY6fAfP+ZZ5V+X3mb55sR+b0kIH7XG/z/gq3yy4Zzmg3nE51CDRAkxIJEooxtvaIugohoxVnc/AO8UE62Qv8P6G9FZDQoPoFI6tyDlPz7TjbMGn+2jIxd6M+TkYNW+zOdm8FL/pni32PdP09OJl/8nxWb+0YA58nkcw0Dzk2aQ6KAuS1Zk66SNb25dvCe/wv8/QP62ki4P5dikVMeFYxdxU+G7aCF+XQSHLzWng7SHsvnycBNviI+NfJ9F7mT4T7rdcvKBOpLKD/o+AFIlM4f4D/R4iYvSB1YCb//GcXII8K89lwG+O346dbswZAFaCcqSQTF05+tWgg/aH2gODLLM5xqUIlivEOMK7TUpdEbGmTLOI6iQugNmiZH38MQHIR4+sDDyc1+xqMjpVKEAYNI5HPI8IPKyNSHioBVGkW7HnxbQRU5OkA9yp4IgTlvuVNEDgVoQ0HXl/YAr8loGFXYcGajT+SzIy5aHwrV4kBJfMWFoWQOfanRNIawlGkMc6c/hST9TcehP719N2gGn15AMMeKsGlkZIkNFFODar/YYBa8WgtIp9D2EExMI9gT+JwF0ixvxq3A6H0LL8iAPB1EPXwfRsqPDdCNMeCwoi9e35QAdoHkiTwiRsABcWwi+FoQWcJkIRCmBE92h0QMRWxiumeaNMdHAabMIiL3S6qmFFFOGAFhEFITbgHj6ff7BV6Dcw51HzhrUeHakyecR7lf/vHN//t5VmdjRSNSaZTaa6IfCjKNApXiV1PUqeRMO4U//cIBIVi2dS/JG0pCGEpZIuiGRmRNguzMgbJsGM8JPSAb6pOJC91yjEC21nP58Dogm9fw27cPTkQw7hGgANk6FPJd/fjgoQVDkscE+VgSmBr0D8oCvpXo5k4rbFbKY8s0HlKWC/0BYQmFONAEqLhZm1kWN1HOsiZMBcsA35IAXZDvHiLfFREMR3qTLi89pxB0BfZ9wilT08pCEwafr2k35sY9Jdpahup2H4iaz6eMB3A0mFXIZDY5h22pH+YixxDxLinLhMpXGaA5WvEoIELOkdzFEWWPcq436ZlOtyg818jktFI1ROvFZCUvo+XuRrQShAwV7jEcR7eDAHT3kypAJVAF8lYHaCE1owhuSDpsGIrmQInpscpy65ZWKslpN1kw4Eh4Tx8k1EAXWJv/YpGvJKzTszrmMeGAUamMUikiKFupyRrh9VqQNc7TRpDB0BZcKwQtvnpgBLF/4uBvhSkVdiPRiqcs8JxjaZU+wKRP6MHR37g+qjcLchc/EFC2aG27PdbVpyldIrUSFKJFAS867bumtsfFd8q7D32Pqts/2UzB4HVDqwMEi3wygDB4H0CXzz8dQg0OXWigSZRKLdNS6GZRUgZ3cnCxm/VpWsfQC0sEjMvWpOdHJfNG/lpbDGVS4SgCfNh/hFJmp0NaEjXUJYVYhjOXsPeKku7+58PVu59+1lQtX7m0PLQAM492yA/1NSTFXSD5Z+yvPCdYP0mdWHsNHE5NShH7WMPW7TyN33ZLyomh1BfUOtiGsICLKUbLKLWP5FqOeq2tZVmK+JqanpCWyctuVNlv/j7r7x4yhcf3LNbL6W26ubemFU7Mg7S5MndLZID9wd9F7iPM/TRmLEjR4NIB2d3nvy5g6wt4pYe+kF9TCn0NgnOF8vSC9FxyaRF2mUO4ukY59XiILjd4/YSXJLIuRUZcaR4y1mADXnhGyrxOYEvMHo+CCgjvjcoR2AxS0SFq2oB9Z9JyBdhucI5kzd4iM6YM34PAMM9LoOvrLz/2yCghJOjE0SGkKgjTMqUpViUBmenPX1/LbigxZukK+yoVjYPX/SVTojlmdrIz2qlQ3JVPfMfASOCWMOdR9N5YbrFQA5BYBLBBlrOh7rQ6km2pXEJ3bL4zmQ3zex1VAkP4riD536xkC0hZnjM0Xuuwk4Qnn4HI0BGniVH+rqkMHbNVzUeN6VTv3qHdznm62KHskPvACK50ENshiyXnEcGsb9R/hMQUadpRYSXFpRF0+qYDCon5xnEiMhGSnLw3c43O97T1m/rO6oCwkgatrA9UyAaaxXUWo3F5ZTZ01XUyXaZMpV4rpCmcUANU95YFqhcOHfKXtFw/VB53jijzozQAOGAgGyKkY6oKOOYDhyJqCMHQreNJsPJDFJENiXoxNS8EmAwWXJ3QOnxWCHzo0GZtMmrZN9r9MqVRMNGYmpZl1Zu5RrUpkf28wqIztTLEF8SY4TURhzJ8m7FhyRkV0z+TaEsEKfZxc323BV+hIHlczxFOHhEXSCSx1wpyyj0/7RVZZ55ptGTc+11XzklyuE4WRVQqJ1AtjOxSXKNSJegWcsRxMOvTow6AkIIBGghviKhLZ/zhwau3r2Yu4XUc6MCvKFvfw06Fi/dwwcFslHw/leDrRSi/ljimLFUtC/Orn84J6U8Gq2wB+/as0L51wHXjhiYa76l0ooI5A4wCmtcCD2vpabLz0zmwk8/AFBy9PQuW3k7Fk/7Qq9nAtWPCy3pmdSiNdPJo//yQkWhUjZm06QQVY66kc6uwujaMfYKEcRo5Zyekk5YufJMkGAbrhNUxelJKHTUwtAFZ1FWU79gPOMkKvLKwP/+wz1lWhL7c2VDcx36YXbbfGHqZrlZESHQhiT1k9oxoIOeHI6922uiUkx4gOIWktJQ+6uEsw5xZ4PnHnSDPqf5kkPZlE+CEW/cnA5B80NSs0EAYoBD6MNmrc1xzHrVf10TqmsbO+e/TgQHMlBgqybNkKAuFBDEeGy7WgkJM0HR9Kd+SqC0xZ7zG7lig/6soTDMz5LwxDf7WP4kCkhAW5KUoN3dZfWMMV4EFRGEayTlKdHiN/JD4j3lRUMnQHrx+oT9RPYcRt9svLRTk6Hwc+WmkK5eWGKalJItqD0m1qvUziYvCeNhBotfQ3/c6JjEkweZNWcAfLsoD6q+VwelSicLz5Z6OrqrU8zpZi6BuDdmfG4Zu7v6JqGYUI5nGdS9tdYgy8yqCVaGbvIZobr5Pfm0atplFnquF+fpQtWhxb4NcXL+bG6gkTXeHG1bawovlQ25xMtjnJYKs6Pf36NW/tOf+96tZB2S9eGoqRWwF4RSVCp4P0a0CJLCdAoDDTq1+4shqs5me2kiugKsv6DqF2ZoaooKZoarUNuaxAevobBzep/KIuc8aB/dMLTVtFbwFzojacvE467PMjuEfDI3S9sb8pNw4XXnJx/5et+mvaoV643c/joOIrnSfOd94j4gKR254v4YO8LUyKydEnqqTbYaqu11NAMnK215OiJQ9KUJBfEI35ae5nChBkJAEJpO2xRVgDO2BAjseEpEjGSgYyjwiBBfHEUtG2lzvkCGibN0DCebqVJgkYUE/Isq8QPAkIcFREFHm8xhO6+zc6et/9VGKGXaAxI4JkKdqzbsB1h79w9EW7+rzh9AbCJ2usdhSpkPxv95doyXxcSqJCYghABckyc5HTA6wfurqzeoCMM71XqZxjAdk3doqkTuk8tmsSDo4BCyKo3XElzjKXbuO9qnaDVx/aOL9yTldfPkf4qtxE7a4zY7OiJDOwZQ/5WhfP/YMlwZTDvftun+4+wj666cd8xNVpHtg6sdTMrr4+NnBqR0MshgyPez1xFtDAxqOo6hcWoIubu8Wl/aEEpLasJzohxN9HdehzTsdqwkeZSN6AyyqywD8Ou52jgbIDlyWAQoMJO2MLgk4ROiVzVp7JFH5cUQ1md/Ow7RFNotrK3e/IdnqWAlWB59T32KVn0QbidW743WRdfUjISUCCz8sbQcm631on6yxcwEt1K3ycWFp4LnTr27wVX5hYNZDhhUCDVdZk7bC8jG7Yt+e3HMGnNThdkEuw377pmUfWIBv2wQO2wg22MwdAd8Qgd6+gTtY4GYTrxPnz0+M8+eBOP/85omB/vnNQKRt6aDO6M0JwCR4QD21UuYKHFNf8FYwg4rkDrKcKNLPmNAgajccJGmcRgozwlNZvkXqxY5e7Ogp7AjMYbAdWRCVWwS7racy5OKmI17xZsPs4ByWwMXNi+W+WO7TWu5zXAEXNy8L4MsC+PwXwEbVW7cBuTovfx8L4efayVk/6hcrfrHi6a34OS6GxhG8LIgvC+KzXBAtAHM1/azPejoS2w+GRqlKwvwEbgnDAVZ4rtMnpmAiu3rQFCuZnznT9IdXSeCI4uqhCNLZ6Zxvz/HVmK6hORCEqkRKnCNCJRaZDc9vDzgXsDLTpGvXTb4SKWOUrV95TjQJDfZkv/nNIdwnBwyI4QHtfcdd7z/ueq8RWRrfm0eya9/OGIZKmWa/Ys9sF91/hrS9z9GAdSLxY3hOgzhR7K1z2l34PI4xC66AvK6mgwMWqeDeiBKoWsdugxwW6zTW9cuSJFhgczbuvKyMrhk8DY6XfEPeo3dvfvyLk2W4lHcP04av7WvX/jYYOZoRjwen69BEFVChL4rf7TE6YZvhx7TZ2e39gRpA2IYKzmDm0AYLChWCsl0LPP0lcOmum/WLMnPO0C+CkL/eXc+zSurM6d/coX+6XdiYo7lRdbQfb79dyYT4dEX9cgFtUrzL4s2GhWy9r2P1rto9E9LxVE1pDrqfzaqD1RGGp4tejoQ2fzMdwGbFx5JC54L2IXmHv1vWdaDnV2laey1ouavOheY0bxJMk0Cv3gtVKjSSNKYRFubc2jnsH2GUXJDlAQIqkwjvikojxRPrsu1zQabmqFe4LS/dPSsJk02lfLH8v2p5l3kg7zfXA3lFnyR4JaqQ0JcpOqnqXo03MHn6bftWEZu6r3PwC+4n6+qAM4M7Jl49Qvf0tsPT3uPe8dZHgS5oBuFj0AGm/PpMK8Qt1iE41PZ5Mxeuw9OHY9ejvvWub71quRevc4a7SqDHyFhrgH1Gzez5yuIOsSz3HGUNV7VmuI88jqlCH0Ms1gRdlBrhcnvIKWNzqaHZXZr7P0Ksn4KK4YmfwBTsmy2VRXJpPYdpEzPVVlS2zUohXyGls778VEL+QiQNwLTuiNLXYHk1b+GQO/f9NIG3oCBDgOEf2Wcuvnz4fNk7I34qYBuHTNCrq9NAy+ZFl12ntM5vDRotolb+JKQUn0gT9NiBi5m08i7oFPUA13Ar/eL1ja06nsMLHysutljADRalzsh/3S6u//2acq/UhMRthyVcZuyNdGg+Zj6BTOy9fjLqfi/jqzBTrSXX9CrGXww5h4pTXQ0e6IZ2xaEp1Lwe5rVChn3+kXCuiPLD8rONUnF9hQuEjR1XFx9TdvY1NcXHINJC8kMsJsdTXNsL/3bx7lJvR21gLnfgdKIOpwX8kONAg3YciC4tCPBw+om9Ss9HHRCwcd98Y2w8HN8uEkDxyraVDpXIpAg0yR4IdvhVsJ/XavrOX6DHxn6GC7ODtXnprGcE1jcdZegfwu6iSHWsHJd4Zq4NVMxkQaYO6XhC2MGCLzRxVZWBhEsE2eBIPaIxVR6883sQpI5lja9UNopta+6Bnu/5nCQtQ3XaPmbgy7Pr84Ma+3CgjtlO7xr6RAEvEBxJFED6WKIo0QZRwMoM8hDYvjIPFffezMX3tG0Sto8JDEjzKovFzVT6g7vQdQ4gAijY1UaJYgLCaM6P+ZY1YKiYyDu4GltA2C1khGRIEwhpywdR2R84HQZxGMpagDJ3G/q0WMuvkhLVbsEbaeq0XZXcWd8R2rS41tsiMCoOb68ZbiQ8esp9qv3wlqowi4VAzE3Rwp8FHGUQeONbsh8UdONkVBfXWSp5uatQ19Q03/ZWDSdVvOxoVZ264aRdSEDdBjItrSjmxzJdZlmgHyTSW9Ps6bhRItOjnUJohu69ubyxVX5D/NXX4kpZcEQWcm5itnPJWFhOrh1ckrYCcjmUEdPpJ2kxUUhC6AzPSOg0lXmgUMPVVR+UdXY8wd8Pvt0ggLM0zWeRcbtbnh+H5UPBM3wff7nTEeiXr26i8HupMFzRA2DsM9bRDq0wFQUp4wQTwUHSlDMcRfXclJGOvrPOJFRsRs7eLWQnLL8IZ0voOlQe+vK1BMNJVxBsag3qoCS0CWMUm3cCnMlLrLqWpeKlO2NgIGRzZRgKUgGpB4zWdEMY5AMoDzwnuYXue0IpC4jIef37u3mZNDzKTsTaOrwY1nqTijdO3Ular0t+kmLfV/p9TBwEFIxiDoiuCpmUV4Y1XDlLGfp725PR3StD7+owxPmN6O/rBNDie/eC4DZa+HO7jw9upebyzZ1MQp5WdnLZ5pQaPGboN+86aXbNfBmX3qt4rI1MrxPvQCihlVbTBxtkmNkaooGg3Cm+4TVYvbAG4ug485oGTOmcqYprAEAijjh5sNDpE2JbCTZuEok4qtgq6IbBOeVUuuH14jSHgMebVBO27DOlBtsxBVhCNxTOKSfVBW/WhdFfSc8EFqmc7TmdQ5c4PU65FrlcuamzgiHfIkHWaYQFbC1bSWXc/1B+cBviH0EkT4UPOceQp1EA8SqEb/qZsdYLXFwy+TXlCh9fJF9rp3ytgsmrtltJ5eF8flMExJIiZTaOhJAsm2p0gSUKyIpmuZNWkhXlaLt01iU9fUpzbNl9YLY40Rxs6gt7zMkzgcA8D6CkLYK3gXkr0SKbYYKuhli9UkmQHSwwUXwrWT9JjVD0a7coTqUC5XwHZy0hXYfllE6neIU6Y3s1IuoITNvslco9DFUoT8AT9jE5C2FA6A4DEal0oThlKU+lsblWwpTV8nxVIw7xhrR5uYFi0nG40Zpji6mo/DWuBkxUbHAktdOpGAwYRdXFtJLVpq1FQSKcyMEakrGuQsGVikhwciGArsi2WV1CYiLHBmUfGG7fmrfShdNYWMy22bWo4NvtvV0qJLuMKvke4lS/VA25Nb7q9EsldwcmXpkhyO6EhAqk18LLPSV+dMMsinBA2LDYmYuUaiZ6WVpHiwlpJds+UboMIE/8LXdmTuXm3SjBsKcUTCmqnkwu3sz1Bcu+bSidJseQNyNdWGeoPS5h8OtLI+sDUw8tjavDETdQ79/IanOAltuOEfvYG9yWN25zMqxyqVU0be16g9jo7Np7QjaKbr5BbHQ39T0hH6Vmv0GMdPX8DfZyE3ChcaA7aL7N+9k7o/+ettsjmH5vG27d8ms3VLw4ghdH8F/iCCq4NOXsOVC3ac/aMJmjrFkbkjazrgxvjlIhbQOpFXP05c3GWeHLEdcpj7hMh5Cc7amHA7n8Wm0JqQbb9mw3r33II+7O7NGYuezqMhtcgz6C35zTiuLBHgnXKtDBo+OokM24eevOop8PV/NyPn65My/WpUyZSkuocUmZb0sZEGb5G482PMjaiDCkl0A39AOD0F3y8fbbuJxP9/nbOI3PJVJW4Vx9YSR4dp+Mxzf1pOZdY2bOHM2kc9dEVnu58pGGsnEUBZ2Gl3aVzDEM4NIeZ51MnS6yLr/L8XploE49G1/bZqPcjjixblmpc3G+/ByqX0A7STtZc14Q4LwoYKgCDvD9jUxZrqucIYL9UM9obSVvJavLG3vDss4mzJFRqrnCyRQYQzXeS6B6tEB1fEAak9jTBTWtvZWD3Krd3XURGch4+YlYUxq13LXWKl7YVsjL0QzH+Pv5MB2SvIQzZ50Ek3OuzfAsuS7qD/S6a4RgeUQXxb04cHjdSlK/7nhpWpTtSl6SGmS2SsdXqRy6qIPerDCN0uMXFVS7hszxXa3nWk8kuqjN6SXaNi7QKf4IWC4GH1tq0nx7YmWx7PEtHHYRGYY8CnpxQvXC0wCFkccgPb3TMUBj/N2FsxewvgmkG21bHNCA0lTqUim/QEpQeEEqP2jSbhpFZEPa8ndd8UKZkYhvWz8zQOYNRgr1bJvz8uigI5MOX1K6IePH+PukwxeqNGR0zuNJR+c8Hjf6/SONoskhAFEiRiCBxWNSFECQBA4EzX9x4YlJLLfnFvtBq2j2jLK9/MK4L40ze8BZv6yqt4U2RmqlN2XsJLdnHjcWEZSRGWy2msKqBJSthA8X1tmHmrZczihcXWjd7Q9HCTDl9rlEXHL7fGIuuX12UZfcntNeo+51tWW3UrxomL/elTR3H+28v8ScLzHnS8z5PGNOF4zHc80ymjOGoyUbS4yfa9RYF0Fv0rGV6njJnH2IyFc1+XSFfa2E9woHH88z4fh4xIwj0L6H56nP0VUYMWhoQF2/fL1MVysipOM+xjGMnqtryFkmQYNjh49opXmI99T68Bz8hBFWXU4Nh9Enpb33j7m0ztNp1Ceyvc/KuVnoZD0ruKg/vj7i8L7CckBUllkzTCxuOm6RqEEYoKQTAhmACPtQ2niPGWdnY0AfGGe7GNoS82SLPrbTTSYab/bA9pUgUL4S7a50WHLx6cu3dq2JqFSV1zPiZCXRhQxjEl+6bswdLjw4cDyx8OC6xKsl9h+L2S+E8+nLt5zdPbjSsj4xP7ewauqBp54j+xg99XF0n1ns/XmtF+UKmLxHN39DP/OZ+ZtOJeeZLQjtnZiTiEtuz1NaRc5psNxaSVbluZ/cKHtunpQyh7uoWF4r2YZF5p8cI6kncJvtknI7VKeM9tCOGEMS8bw4hivqi8D0KoOIzP8BUtnuiluJ7iWdBK/J/QqnkdpbLvs2vYNvxXanYiJwG2krQddrInTyN+k669HQR+rDf7i4fwZ8x/g/XPQwjl59hk+9yv4THpNI4N7i/EJXkyHBvkp1MwFc7Kr4zEkxu8oQDq3N+1b6Zr+Alq88HSBfkKy8p+xkYtUD6n/CrRGKG6sq2ingZtEUrpnYgw+eqtMy8n/sXV9v47gRf/enIO6lPfSixLnt3jVv2c21NbrtBpvdvvpokXGISKRASs76Pn0x/CPJEkVLluwYRYBgsUhszm9myOFwOJyxjIiidnIdy0qoPcepTV/ntmizCMEySMyVaZOInoo1BYmoHyGJvEsXqNNaHrZnSKWWQPlspFZNEj0Y/AeXgvTKaxC/oIaz4fWhvOE/UHsFpxsW59Bl49xcZ238Y8yhUouuPRYnmKWU9OLUcblKnlstS/bfvu4A/ZCI+BktPr8l/B8r4d9f/zrIi340czYz1oTWWy1hwNY8UgmuWS50Ro52E+CF40pPKgKLr5M4CtxgDRITEwcL6UABQKcfkVFTJUeX7QJp2yoMSTKCcXhBpWjVb8KWzIHdLBMJiz1t1Tt7Fw00BBbAf6/RotbHSNIswTHQ17bmzTqcxjr4WJg2fg4Vuc08BX3bVqW9A+gHo2gN1MPKnYypiInXReIKVHRQCK/m7oocRyx9dbr6N2+lr95KX72VvuoofTVNMavT1bFLkrcl/LaEJ1rC/x+L0kGwJ4NIFWmKd9715yxPKMhefwA9tD/gXaAB39UO4c7+5f1ddTpxnYFtg7UnoXa8VElhUwaieBZemiEJd0o0AN13Lqtga7hMtfBWOCxjEyGponJOYEOwMJLQyYHAoINQqITS7BgicQMPQ5MLuAObHowZdxCWP0S6YtNryAw7CAmheHqRwKBdKNAi/5NCGwoZhjxhzzSxoUuWm9ZrcFOJJVoVuhwMeBBQ7CZmOEGK5YUNkbAcpXhrL6X8rL3gZ+rJxx/Pnhv4Ag48neJGn6FF4aMouA7jiAT6Y+kmhehf5urMNnVT3fCPAv24sDMsn4+wysywU0HXfaZtxzIqIZUEUkpMb7EOvgr+zMULn56xkpdaLWx4D6s5jaGVCLQw1BH9XDK6Ab9WwoWdReSHC6/PcMtHaWz4X/0f6vTKd5j6CCmwCliolYmzZEEv2DWhmfVzs8P7aFDYfmy158TJttxGLcKok74ONVMyDX0nDzsoRKgXl5+jWZOoxIyM8bsa3x/uRcG/Mx+7/jBmAAr8/AdX5QtN0CryUoXlXKjp6Oq0HpZvrZ3ogcD35G8EAEgSB12Yp4S7IvDSV1seLwG24NOh+Ggf0cPgyAz+E2IGy5fbxR3CUuIt2BBJScEJ5jnyooMMDZcPO+u5GPZgq5k+m4RliAToH9PD18RrSoJ9QDGlDVsIk74UnwhTTSR6WGJpBMhDsj8l09O3D/320tfrq313EAhq7xTKO2hWQxIbYJT4RaMwW6TyotT2dtqZUwnJDF6fNPAmUufVofnV9bsLuIFwEELwYH1Scix8gtchah8bYmfgVG95vAetQ6qobNgu/95U7jgrmuNZL9D1GIFtHmepqRNsWmCpeW2bajNa0UkEJks928ZQg1GsW9eD5mhybi8cQLJYjedSFauL/hThg0vFeOynSdpgWgR16mOO08wRTPSlDoyM4ifM1zRCix0osPHZvQeSDKxnCA2LTSYcyxUqst0y0XXU9DuNl7Ego+T0sPjHx39+ghbxhFYN+C1CaHEOhxJ70PGiKDjLTerxeJ3V9QXjtovttaluKCeQ7SqpovkY6oTqDNMhKFw0X3npNm1Ti6q1Ns4Atfvxl4pojOIzRnVc0FYs0Da407S3EEJx1179gR1lm80ZfkLbn77NlS8h+Ed0xHUp7kinj46iWrll9i6nt2IqLJzmL0I+e0h1T442EDNIOc86qwJ1TYg6InbqhyOaWleVpxqqDN5F5KfDZentQyaKfCw0L9mufOs63RAvw6jaZNoazVmToIKYQD7bN0MDLhQ8OTajuOR/aTd+Gvmcs97hAMIk7T6U4oS1Xv9DeeRy4URd30/Z2iS53aBcFtWK8oJ4xClLtgciAKRjiEPf3iRiTVMBw96g1q/pd5xmcJc2/9t1dBVdR3MI0l1fXc1vru4+/Hpz++G3u5tf//rz+5ubeeOrAfXCzyfAgRb3CBMCN5E2YR8a7K4odHBY3G/eAbHF/eZ9+aFymABv0EXQy51nipf8XV8fAh9IVRPSi0nSVOT0DAT+RQOZWOKWu5OI3DLQX+ZwXeFF5XfgSmC/vL+4ns8v5vNfLn5+H/GXyP4likUaDcN8//ULZKwLSbybvnQ6idAC+pQisYKgPSVow6DXMsT1m6sdgQoTIZ6LrJ8YaJ6QJTxAXQpOD5HHwezDuYk+PoLF1Xmd2YUJHxKhTwF/pl8/3f3oPGMrC1CaKYwJzbVT0c7xS/CKJhH6u5AOIhxxKILR/jIHtwL98ChEtMIyWosE83Uk5Dr6AeT7Q/0XTWaM166fcQmJCM2pTJkNrpvhUSzgPZo+1mCOaLqihFCCYpFtHR/wDqw5sP7CU55nN5eXWbFKWKyKx0f2XeMoPxxSIohlSaUUcoAG90zO32A4q8KVY9P0vi11omegnW7IluKo5OZFbA93UcaIF2v3Htf9zUFbnBsmFmmK+aEgPEGYw1CkJGGcTqc23aHN8oZ2hg7ioN/pgZKAuECh3waNkQfUwY8GTwn/t4YT7gyp7SENabvLAVPBETXea3du0oP+O/L8fWxqEnQFgwetzn+2zybAgNhw5CgPGnckHjQR95jIt3oecw77g2gFFnwg6kDCx3Lokb6mvrfAe0A5YFqG3egqHFC6hAZvdkdjKUlo50fNfDDyOJtSL3ACO1w3ezrtdAtk39m7h8D+vVv6r36UdAGfn9AKw58Fr92Y4QSORuCe6ceg+p2WDajBL5Bif9AIfRRSUpVBzAoeqtg+QIrqpJ5LsJiXaqsuOc0vWbZ5d5nHGZRxsTkcVW94wU0GR4Q6hdheaWGt9pTPfu2GNFwHKGT2hJsn4b6a7okWfm7NY3SrJEsWHtPFmVNtt3yDHHTZkKkZcPZkv9z72ZUj4ANoITvThEcVeARMPbUu+o4AsLoDrJEdJM04EYouXzDLT4m2gRBsxLJCskS+G45d3HBbcxawSyB9UKstXyrKXx20w9EXs6Tx5hwwA44+mB8Z1zpphoJODroEMgR1M/7zaqiv+6CG69cljp9fG7TD0Qcz2JqT7CBhyBaGD7FDWpBs1tfR2YMJHJxvdzsoZv2cmzN0X7/dvar7WpBzdF+/3U3hvp7a+etCHfiPg2qyNmZNfE0xBhD9bob4fbc0o63NwNduqphP2VhCNCpQQAoTIIlS1fdqwC0f99XGnxnPinzpPpSyJGH+9IE9moEw7+cHxyvjO0NFsyYjEAdSe2V/QKLYJ7FeU3JRtp6mSjHBmwHkkIwZmS6sCFKpakZYMF6qiuJ8Orq3vH41kog146RNIlCeYiTPdx8KZVM7dcyxjwQ8l7AjUcDXHeX6bPCS9+eKjEBw68j1Tk1xUMytTWNAg2QlREIxH4oEvqY79MfGMmFLIywRjys0UiOuY9tO+lYQQyymnhU1bRgDTTxUHP2EYkJlX1vbg7oUIkf3/WyC0dFy4JXrHhAwHerXgvZOunx92wQ0QwghhBCa/W8AWeLRxw=="
 }
@@ -0,0 +1,40 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inventory
+
+// config holds the configuration of the inventory metricset.
+type config struct {
+	// ChangesOnly only reports the inventory when it differs from the
+	// previously reported one.
+	ChangesOnly bool           `config:"inventory.changes_only"`
+	Packages    packagesConfig `config:"inventory.packages"`
+}
+
+type packagesConfig struct {
+	// Enabled reports the number of installed packages and a hash of the
+	// package list.
+	Enabled bool `config:"enabled"`
+}
+
+func defaultConfig() config {
+	return config{
+		Packages: packagesConfig{
+			Enabled: true,
+		},
+	}
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package inventory
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// readCPU reads the CPU model and the number of logical CPUs from
+// /proc/cpuinfo.
+func readCPU(mod resolve.Resolver) (mapstr.M, error) {
+	f, err := os.Open(mod.ResolveHostFS("/proc/cpuinfo"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fields := mapstr.M{}
+	count := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "processor":
+			count++
+		case "model name", "Model":
+			if _, ok := fields["model"]; !ok && value != "" {
+				fields["model"] = value
+			}
+		case "vendor_id":
+			if _, ok := fields["vendor"]; !ok && value != "" {
+				fields["vendor"] = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	fields["count"] = count
+	return fields, nil
+}
+
+// readMemory reads the total memory from /proc/meminfo and the installed
+// memory modules from the SMBIOS tables. Reading the SMBIOS tables requires
+// root privileges, if they can't be read the total memory is still returned
+// together with the error.
+func readMemory(mod resolve.Resolver) (mapstr.M, error) {
+	meminfo, err := readKeyValueFile(mod.ResolveHostFS("/proc/meminfo"), ":")
+	if err != nil {
+		return nil, err
+	}
+	kb, err := strconv.ParseUint(strings.TrimSuffix(meminfo["MemTotal"], " kB"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing MemTotal: %w", err)
+	}
+	fields := mapstr.M{"total": kb * 1024}
+
+	modules, err := readMemoryModules(mod.ResolveHostFS("/sys/firmware/dmi/entries"))
+	if err != nil {
+		return fields, fmt.Errorf("error reading memory modules: %w", err)
+	}
+	if len(modules) > 0 {
+		fields["modules"] = modules
+	}
+	return fields, nil
+}
+
+// readMemoryModules reads the installed memory devices, SMBIOS structures of
+// type 17, from the SMBIOS entries exported in sysfs.
+func readMemoryModules(dir string) ([]mapstr.M, error) {
+	entries, err := filepath.Glob(filepath.Join(dir, "17-*", "raw"))
+	if err != nil {
+		return nil, err
+	}
+	var modules []mapstr.M
+	for _, path := range entries {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		device, err := parseMemoryDevice(raw)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		if device == nil {
+			// Empty slot.
+			continue
+		}
+		modules = append(modules, device)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return fmt.Sprint(modules[i]["locator"]) < fmt.Sprint(modules[j]["locator"])
+	})
+	return modules, nil
+}
+
+// readDisks reads the physical block devices from /sys/block. Virtual
+// devices like loop, device mapper and RAID devices have no device link and
+// are skipped.
+func readDisks(mod resolve.Resolver) ([]mapstr.M, error) {
+	root := mod.ResolveHostFS("/sys/block")
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []mapstr.M
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, "device")); err != nil {
+			continue
+		}
+
+		disk := mapstr.M{"name": entry.Name()}
+		if sectors, err := readUint(filepath.Join(dir, "size")); err == nil {
+			// The size is always in 512 bytes sectors.
+			disk["size"] = sectors * 512
+		}
+		for file, field := range map[string]string{
+			"device/model":  "model",
+			"device/vendor": "vendor",
+		} {
+			if v, err := readTrimmed(filepath.Join(dir, file)); err == nil && v != "" {
+				disk[field] = v
+			}
+		}
+		if serial := readDiskSerial(dir); serial != "" {
+			disk["serial"] = serial
+		}
+		if v, err := readUint(filepath.Join(dir, "queue/rotational")); err == nil {
+			disk["rotational"] = v == 1
+		}
+		if v, err := readUint(filepath.Join(dir, "removable")); err == nil {
+			disk["removable"] = v == 1
+		}
+		disks = append(disks, disk)
+	}
+	return disks, nil
+}
+
+// readDiskSerial returns the serial number of the disk. NVMe and virtio
+// devices expose it in a file, SCSI devices in the unit serial number VPD
+// page.
+func readDiskSerial(dir string) string {
+	if serial, err := readTrimmed(filepath.Join(dir, "device/serial")); err == nil && serial != "" {
+		return serial
+	}
+	if serial, err := readTrimmed(filepath.Join(dir, "serial")); err == nil && serial != "" {
+		return serial
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "device/vpd_pg80"))
+	if err != nil || len(page) < 4 {
+		return ""
+	}
+	// The page starts with a 4 bytes header, the last byte of which is the
+	// length of the serial number.
+	n := int(page[3])
+	if n > len(page)-4 {
+		n = len(page) - 4
+	}
+	return strings.TrimSpace(strings.TrimRight(string(page[4:4+n]), "\x00"))
+}
+
+func readUint(path string) (uint64, error) {
+	s, err := readTrimmed(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package inventory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("system", "inventory", New)
+}
+
+// MetricSet reports an inventory of the host hardware, operating system and
+// installed packages.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod    resolve.Resolver
+	config config
+	logger *logp.Logger
+
+	// lastHash is the hash of the last reported inventory, used in
+	// changes only mode.
+	lastHash string
+}
+
+// New creates a new instance of the inventory metricset.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The system inventory metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		mod:           base.Module().(resolve.Resolver),
+		config:        config,
+		logger:        base.Logger(),
+	}, nil
+}
+
+// Fetch reports the host inventory. In changes only mode nothing is reported
+// if the inventory didn't change since the last report.
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	fields, err := m.inventory()
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashInventory(fields)
+	if err != nil {
+		return fmt.Errorf("error hashing inventory: %w", err)
+	}
+	if m.config.ChangesOnly && hash == m.lastHash {
+		m.logger.Debugf("Inventory unchanged, hash %s", hash)
+		return nil
+	}
+	fields["hash"] = hash
+
+	if report.Event(mb.Event{MetricSetFields: fields}) {
+		m.lastHash = hash
+	}
+	return nil
+}
+
+// inventory collects the inventory. Only the OS is required, the other parts
+// are left out if they can't be read, for example because of missing
+// privileges.
+func (m *MetricSet) inventory() (mapstr.M, error) {
+	osFields, err := readOS(m.mod)
+	if err != nil {
+		return nil, fmt.Errorf("error reading operating system information: %w", err)
+	}
+	fields := mapstr.M{"os": osFields}
+
+	if cpu, err := readCPU(m.mod); err != nil {
+		m.logger.Debugf("Error reading CPU information: %v", err)
+	} else {
+		fields["cpu"] = cpu
+	}
+
+	memory, err := readMemory(m.mod)
+	if err != nil {
+		m.logger.Debugf("Error reading memory information: %v", err)
+	}
+	if len(memory) > 0 {
+		fields["memory"] = memory
+	}
+
+	if disks, err := readDisks(m.mod); err != nil {
+		m.logger.Debugf("Error reading disks: %v", err)
+	} else if len(disks) > 0 {
+		fields["disks"] = disks
+	}
+
+	if m.config.Packages.Enabled {
+		if packages, err := readPackages(m.mod); err != nil {
+			m.logger.Debugf("Error reading installed packages: %v", err)
+		} else if packages != nil {
+			fields["packages"] = packages
+		}
+	}
+
+	return fields, nil
+}
+
+// hashInventory returns the SHA-256 of the inventory. Map keys are sorted
+// when marshalling, so the hash only changes when the inventory does.
+func hashInventory(fields mapstr.M) (string, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,192 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("./_meta/testdata", nil))
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("./_meta/testdata", nil))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	fields := events[0].MetricSetFields
+
+	assertValue(t, fields, "os.id", "ubuntu")
+	assertValue(t, fields, "os.version", "22.04.4 LTS (Jammy Jellyfish)")
+	assertValue(t, fields, "os.kernel", "5.15.0-105-generic")
+
+	assertValue(t, fields, "cpu.model", "Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz")
+	assertValue(t, fields, "cpu.vendor", "GenuineIntel")
+	assertValue(t, fields, "cpu.count", 2)
+
+	assertValue(t, fields, "memory.total", uint64(32827332*1024))
+	assertValue(t, fields, "memory.modules", []mapstr.M{{
+		"locator":      "DIMM_A1",
+		"bank":         "BANK 0",
+		"manufacturer": "Samsung",
+		"serial":       "40A1B2C3",
+		"part_number":  "M393A2K43DB3-CWE",
+		"size":         uint64(16 << 30),
+		"type":         "DDR4",
+		"speed":        uint16(3200),
+	}})
+
+	assertValue(t, fields, "disks", []mapstr.M{
+		{
+			"name":       "nvme0n1",
+			"model":      "Samsung SSD 980 PRO 500GB",
+			"serial":     "S5GYNX0T123456A",
+			"size":       uint64(1000215216 * 512),
+			"rotational": false,
+			"removable":  false,
+		},
+		{
+			"name":       "sda",
+			"model":      "ST1000DM010-2EP1",
+			"vendor":     "ATA",
+			"serial":     "Z9A1B2C3",
+			"size":       uint64(1953525168 * 512),
+			"rotational": true,
+			"removable":  false,
+		},
+	})
+
+	assertValue(t, fields, "packages.manager", "dpkg")
+	assertValue(t, fields, "packages.count", 2)
+	assert.Equal(t, hashPackages([]pkg{
+		{name: "bash", version: "5.1-6ubuntu1.1"},
+		{name: "adduser", version: "3.118ubuntu5"},
+	}), mustValue(t, fields, "packages.hash"))
+
+	assert.Len(t, mustValue(t, fields, "hash"), 64)
+}
+
+func TestFetchChangesOnly(t *testing.T) {
+	hostfs := copyTestdata(t)
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(hostfs, map[string]interface{}{
+		"inventory.changes_only": true,
+	}))
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	first := mustValue(t, events[0].MetricSetFields, "hash")
+
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Empty(t, events)
+
+	// Uninstalling a package changes the inventory.
+	status := filepath.Join(hostfs, "var/lib/dpkg/status")
+	require.NoError(t, os.WriteFile(status, []byte("Package: bash\nStatus: install ok installed\nVersion: 5.1-6ubuntu1.1\n"), 0o644))
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assertValue(t, events[0].MetricSetFields, "packages.count", 1)
+	assert.NotEqual(t, first, mustValue(t, events[0].MetricSetFields, "hash"))
+}
+
+func TestFetchPackagesDisabled(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("./_meta/testdata", map[string]interface{}{
+		"inventory.packages.enabled": false,
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.NotContains(t, events[0].MetricSetFields, "packages")
+}
+
+func TestFetchNoOSRelease(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(t.TempDir(), nil))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	assert.NotEmpty(t, errs)
+}
+
+// copyTestdata copies the testdata to a temporary directory that tests can
+// modify.
+func copyTestdata(t *testing.T) string {
+	t.Helper()
+	dst := t.TempDir()
+	err := filepath.Walk("./_meta/testdata", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel("./_meta/testdata", path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0o755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), data, 0o644)
+	})
+	require.NoError(t, err)
+	return dst
+}
+
+func mustValue(t *testing.T, fields mapstr.M, key string) interface{} {
+	t.Helper()
+	v, err := fields.GetValue(key)
+	require.NoError(t, err, key)
+	return v
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	v, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, v, key)
+	}
+}
+
+func getConfig(hostfs string, extra map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"module":     "system",
+		"metricsets": []string{"inventory"},
+		"hostfs":     hostfs,
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+	return config
+}
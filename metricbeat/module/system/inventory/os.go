@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package inventory
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// readOS reads the operating system release from os-release and the kernel
+// release and build from /proc.
+func readOS(mod resolve.Resolver) (mapstr.M, error) {
+	release, err := readKeyValueFile(mod.ResolveHostFS("/etc/os-release"), "=")
+	if errors.Is(err, fs.ErrNotExist) {
+		release, err = readKeyValueFile(mod.ResolveHostFS("/usr/lib/os-release"), "=")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := mapstr.M{}
+	for key, field := range map[string]string{
+		"ID":          "id",
+		"NAME":        "name",
+		"VERSION":     "version",
+		"VERSION_ID":  "version_id",
+		"PRETTY_NAME": "full",
+	} {
+		if v := unquote(release[key]); v != "" {
+			fields[field] = v
+		}
+	}
+
+	if kernel, err := readTrimmed(mod.ResolveHostFS("/proc/sys/kernel/osrelease")); err == nil {
+		fields["kernel"] = kernel
+	}
+	if build, err := readTrimmed(mod.ResolveHostFS("/proc/sys/kernel/version")); err == nil {
+		fields["kernel_build"] = build
+	}
+	return fields, nil
+}
+
+// readKeyValueFile reads a file of key, value lines split by sep.
+func readKeyValueFile(path, sep string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), sep)
+		if !found {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values, scanner.Err()
+}
+
+// unquote removes the shell quoting of os-release values.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		if v, err := strconv.Unquote(`"` + s[1:len(s)-1] + `"`); err == nil {
+			return v
+		}
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func readTrimmed(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
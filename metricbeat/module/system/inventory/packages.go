@@ -0,0 +1,196 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package inventory
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+const rpmTimeout = 30 * time.Second
+
+// pkg is an installed package.
+type pkg struct {
+	name, version string
+}
+
+// readPackages returns the number of installed packages and a hash of the
+// package list, so that changes of installed packages can be detected
+// without reporting the whole list. It returns nil if no supported package
+// manager is found.
+func readPackages(mod resolve.Resolver) (mapstr.M, error) {
+	for _, manager := range []struct {
+		name string
+		list func(resolve.Resolver) ([]pkg, error)
+	}{
+		{"dpkg", listDpkg},
+		{"apk", listApk},
+		{"rpm", listRpm},
+	} {
+		packages, err := manager.list(mod)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return mapstr.M{
+			"manager": manager.name,
+			"count":   len(packages),
+			"hash":    hashPackages(packages),
+		}, nil
+	}
+	return nil, nil
+}
+
+// hashPackages returns the SHA-256 of the sorted package list.
+func hashPackages(packages []pkg) string {
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].name != packages[j].name {
+			return packages[i].name < packages[j].name
+		}
+		return packages[i].version < packages[j].version
+	})
+	h := sha256.New()
+	for _, p := range packages {
+		io.WriteString(h, p.name+" "+p.version+"\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// listDpkg reads the installed packages from the dpkg status database.
+func listDpkg(mod resolve.Resolver) ([]pkg, error) {
+	f, err := os.Open(mod.ResolveHostFS("/var/lib/dpkg/status"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var packages []pkg
+	var current pkg
+	installed := false
+	flush := func() {
+		if installed && current.name != "" {
+			packages = append(packages, current)
+		}
+		current, installed = pkg{}, false
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.HasPrefix(line, " ") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Package":
+			current.name = value
+		case "Version":
+			current.version = value
+		case "Status":
+			installed = strings.HasSuffix(value, " installed")
+		}
+	}
+	flush()
+	return packages, scanner.Err()
+}
+
+// listApk reads the installed packages from the apk database.
+func listApk(mod resolve.Resolver) ([]pkg, error) {
+	f, err := os.Open(mod.ResolveHostFS("/lib/apk/db/installed"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var packages []pkg
+	var current pkg
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if current.name != "" {
+				packages = append(packages, current)
+			}
+			current = pkg{}
+		case strings.HasPrefix(line, "P:"):
+			current.name = line[2:]
+		case strings.HasPrefix(line, "V:"):
+			current.version = line[2:]
+		}
+	}
+	if current.name != "" {
+		packages = append(packages, current)
+	}
+	return packages, scanner.Err()
+}
+
+// listRpm lists the installed packages with the rpm command, as the rpm
+// database can't be read directly.
+func listRpm(mod resolve.Resolver) ([]pkg, error) {
+	root := mod.ResolveHostFS("/")
+	if _, err := os.Stat(mod.ResolveHostFS("/var/lib/rpm")); err != nil {
+		return nil, err
+	}
+	path, err := exec.LookPath("rpm")
+	if err != nil {
+		return nil, fs.ErrNotExist
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rpmTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--root", root, "-qa",
+		"--queryformat", "%{NAME} %{EPOCH}:%{VERSION}-%{RELEASE}.%{ARCH}\n").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []pkg
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		name, version, _ := strings.Cut(scanner.Text(), " ")
+		if name != "" {
+			packages = append(packages, pkg{name: name, version: strings.TrimPrefix(version, "(none):")})
+		}
+	}
+	return packages, scanner.Err()
+}
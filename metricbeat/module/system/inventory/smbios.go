@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package inventory
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// smbiosMemoryDevice is the SMBIOS structure type of memory devices.
+const smbiosMemoryDevice = 17
+
+// memoryTypes maps the SMBIOS memory type to its name.
+var memoryTypes = map[byte]string{
+	0x12: "DDR",
+	0x13: "DDR2",
+	0x18: "DDR3",
+	0x1A: "DDR4",
+	0x1B: "LPDDR",
+	0x1C: "LPDDR2",
+	0x1D: "LPDDR3",
+	0x1E: "LPDDR4",
+	0x20: "HBM",
+	0x21: "HBM2",
+	0x22: "DDR5",
+	0x23: "LPDDR5",
+}
+
+// placeholders are the strings firmwares use for unset fields.
+var placeholders = map[string]bool{
+	"":              true,
+	"Not Specified": true,
+	"Unknown":       true,
+	"NO DIMM":       true,
+}
+
+// parseMemoryDevice parses a raw SMBIOS memory device structure, as defined
+// by the SMBIOS specification. It returns nil for empty slots.
+func parseMemoryDevice(raw []byte) (mapstr.M, error) {
+	if len(raw) < 0x15 || raw[0] != smbiosMemoryDevice {
+		return nil, errors.New("not a memory device structure")
+	}
+	length := int(raw[1])
+	if length < 0x15 || length > len(raw) {
+		return nil, errors.New("invalid structure length")
+	}
+	formatted, strs := raw[:length], smbiosStrings(raw[length:])
+	str := func(offset int) string {
+		if offset >= length {
+			return ""
+		}
+		i := int(formatted[offset])
+		if i == 0 || i > len(strs) {
+			return ""
+		}
+		return strs[i-1]
+	}
+
+	size := uint64(binary.LittleEndian.Uint16(formatted[0x0C:]))
+	switch {
+	case size == 0 || size == 0xFFFF:
+		// No module installed, or unknown size.
+		return nil, nil
+	case size == 0x7FFF && length >= 0x20:
+		size = uint64(binary.LittleEndian.Uint32(formatted[0x1C:])&0x7FFFFFFF) << 20
+	case size&0x8000 != 0:
+		size = (size & 0x7FFF) << 10
+	default:
+		size <<= 20
+	}
+
+	device := mapstr.M{"size": size}
+	for field, offset := range map[string]int{
+		"locator":      0x10,
+		"bank":         0x11,
+		"manufacturer": 0x17,
+		"serial":       0x18,
+		"part_number":  0x1A,
+	} {
+		if v := str(offset); !placeholders[v] {
+			device[field] = v
+		}
+	}
+	if t, ok := memoryTypes[formatted[0x12]]; ok {
+		device["type"] = t
+	}
+	if length >= 0x17 {
+		if speed := binary.LittleEndian.Uint16(formatted[0x15:]); speed != 0 && speed != 0xFFFF {
+			device["speed"] = speed
+		}
+	}
+	return device, nil
+}
+
+// smbiosStrings returns the strings set following the formatted section of
+// a structure, which is terminated by an empty string.
+func smbiosStrings(b []byte) []string {
+	var strs []string
+	for len(b) > 0 && b[0] != 0 {
+		i := bytes.IndexByte(b, 0)
+		if i < 0 {
+			i = len(b)
+		}
+		strs = append(strs, strings.TrimSpace(string(b[:i])))
+		if i == len(b) {
+			break
+		}
+		b = b[i+1:]
+	}
+	return strs
+}
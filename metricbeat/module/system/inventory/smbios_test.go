@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package inventory
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// memoryDevice builds a raw SMBIOS memory device structure.
+func memoryDevice(size uint16, extendedSize uint32, strs ...string) []byte {
+	raw := make([]byte, 0x28)
+	raw[0], raw[1] = smbiosMemoryDevice, 0x28
+	binary.LittleEndian.PutUint16(raw[0x0C:], size)
+	binary.LittleEndian.PutUint32(raw[0x1C:], extendedSize)
+	raw[0x10], raw[0x12] = 1, 0x22
+	for _, s := range strs {
+		raw = append(raw, s...)
+		raw = append(raw, 0)
+	}
+	return append(raw, 0)
+}
+
+func TestParseMemoryDevice(t *testing.T) {
+	tests := map[string]struct {
+		raw      []byte
+		expected mapstr.M
+	}{
+		"megabytes": {
+			raw:      memoryDevice(8192, 0, "DIMM 0"),
+			expected: mapstr.M{"locator": "DIMM 0", "size": uint64(8 << 30), "type": "DDR5"},
+		},
+		"kilobytes": {
+			raw:      memoryDevice(0x8000|512, 0, "DIMM 0"),
+			expected: mapstr.M{"locator": "DIMM 0", "size": uint64(512 << 10), "type": "DDR5"},
+		},
+		"extended size": {
+			raw:      memoryDevice(0x7FFF, 64<<10, "DIMM 0"),
+			expected: mapstr.M{"locator": "DIMM 0", "size": uint64(64 << 30), "type": "DDR5"},
+		},
+		"placeholder strings": {
+			raw:      memoryDevice(1024, 0, "Not Specified"),
+			expected: mapstr.M{"size": uint64(1 << 30), "type": "DDR5"},
+		},
+		"empty slot": {
+			raw: memoryDevice(0, 0, "DIMM 1"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			device, err := parseMemoryDevice(tc.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, device)
+		})
+	}
+}
+
+func TestParseMemoryDeviceInvalid(t *testing.T) {
+	_, err := parseMemoryDevice([]byte{17, 4, 0, 0})
+	assert.Error(t, err)
+
+	raw := memoryDevice(1024, 0)
+	raw[0] = 16
+	_, err = parseMemoryDevice(raw)
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pressure
+
+// config holds the configuration of the pressure metricset.
+type config struct {
+	Cgroups cgroupsConfig `config:"pressure.cgroups"`
+}
+
+type cgroupsConfig struct {
+	// Enabled reports pressure of each cgroup v2 control group.
+	Enabled bool `config:"enabled"`
+	// MaxDepth limits how deep the cgroup hierarchy is walked, 0 means no
+	// limit.
+	MaxDepth int `config:"max_depth" validate:"min=0"`
+}
+
+func defaultConfig() config {
+	return config{
+		Cgroups: cgroupsConfig{
+			Enabled: true,
+		},
+	}
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package pressure
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// containerIDRegexp matches the 64 character container IDs used by Docker,
+// containerd and CRI-O in cgroup names.
+var containerIDRegexp = regexp.MustCompile(`[0-9a-f]{64}`)
+
+func init() {
+	mb.Registry.MustAddMetricSet("system", "pressure", New)
+}
+
+// MetricSet reads pressure stall information from /proc/pressure and from
+// the cgroup v2 hierarchy.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod    resolve.Resolver
+	config config
+	logger *logp.Logger
+}
+
+// New creates a new instance of the pressure metricset.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The system pressure metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		mod:           base.Module().(resolve.Resolver),
+		config:        config,
+		logger:        base.Logger(),
+	}, nil
+}
+
+// Fetch reports one event for the host and, if enabled, one event for each
+// cgroup.
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	host, err := readPressureDir(m.mod.ResolveHostFS("/proc/pressure"), "%s")
+	if err != nil {
+		return fmt.Errorf("error reading pressure stall information, PSI requires Linux 4.20 or newer with CONFIG_PSI enabled: %w", err)
+	}
+	if !report.Event(mb.Event{MetricSetFields: host}) {
+		return nil
+	}
+
+	if !m.config.Cgroups.Enabled {
+		return nil
+	}
+	return m.fetchCgroups(report)
+}
+
+func (m *MetricSet) fetchCgroups(report mb.ReporterV2) error {
+	root := m.mod.ResolveHostFS("/sys/fs/cgroup")
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err != nil {
+		m.logger.Debugf("Skipping cgroup pressure, %s is not a cgroup v2 hierarchy: %v", root, err)
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// cgroups come and go, don't fail the whole fetch for them.
+			if path != root && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() || path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		depth := strings.Count(rel, string(filepath.Separator)) + 1
+		if m.config.Cgroups.MaxDepth > 0 && depth > m.config.Cgroups.MaxDepth {
+			return filepath.SkipDir
+		}
+
+		fields, err := readPressureDir(path, "%s.pressure")
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				m.logger.Debugf("Error reading pressure of cgroup %s: %v", path, err)
+			}
+			return nil
+		}
+		fields["cgroup"] = mapstr.M{
+			"id":   d.Name(),
+			"path": "/" + filepath.ToSlash(rel),
+		}
+
+		event := mb.Event{MetricSetFields: fields}
+		if id := containerIDRegexp.FindString(d.Name()); id != "" {
+			event.RootFields = mapstr.M{"container": mapstr.M{"id": id}}
+		}
+		if !report.Event(event) {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+}
+
+// readPressureDir reads the pressure files of all resources in dir. name is
+// the format of the file names, with the resource as argument. Resources
+// without pressure file are skipped.
+func readPressureDir(dir, name string) (mapstr.M, error) {
+	fields := mapstr.M{}
+	for _, resource := range resources {
+		stats, err := readPressureFile(filepath.Join(dir, fmt.Sprintf(name, resource)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields[resource] = stats
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no pressure files found in %s: %w", dir, fs.ErrNotExist)
+	}
+	return fields, nil
+}
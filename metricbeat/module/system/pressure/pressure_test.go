@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package pressure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const containerID = "2d4d8f1f9bd9a2e64cb5a7a6b5e5a5b3c1b4f6e2d1a0c9b8a7f6e5d4c3b2a190"
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(nil))
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(nil))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	host := events[0].MetricSetFields
+	assert.NotContains(t, host, "cgroup")
+	assertValue(t, host, "cpu.some.10.pct", 1.53)
+	assertValue(t, host, "cpu.some.total", uint64(129745321))
+	assertValue(t, host, "io.full.300.pct", 0.02)
+	assertValue(t, host, "memory.full.total", uint64(1193204))
+
+	slice := findCgroup(t, events, "/system.slice")
+	assertValue(t, slice.MetricSetFields, "cgroup.id", "system.slice")
+	assertValue(t, slice.MetricSetFields, "cpu.full.10.pct", 0.31)
+	assert.Nil(t, slice.RootFields)
+
+	container := findCgroup(t, events, "/system.slice/docker-"+containerID+".scope")
+	assertValue(t, container.MetricSetFields, "io.some.total", uint64(812934))
+	assertValue(t, container.RootFields, "container.id", containerID)
+}
+
+func TestFetchCgroupsMaxDepth(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(map[string]interface{}{
+		"pressure.cgroups.max_depth": 1,
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+	findCgroup(t, events, "/system.slice")
+}
+
+func TestFetchCgroupsDisabled(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(map[string]interface{}{
+		"pressure.cgroups.enabled": false,
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.NotContains(t, events[0].MetricSetFields, "cgroup")
+}
+
+func TestFetchNoPSI(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, map[string]interface{}{
+		"module":     "system",
+		"metricsets": []string{"pressure"},
+		"hostfs":     t.TempDir(),
+	})
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	assert.NotEmpty(t, errs)
+}
+
+func findCgroup(t *testing.T, events []mb.Event, path string) mb.Event {
+	t.Helper()
+	for _, event := range events {
+		if p, _ := event.MetricSetFields.GetValue("cgroup.path"); p == path {
+			return event
+		}
+	}
+	t.Fatalf("no event for cgroup %s", path)
+	return mb.Event{}
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	v, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, v, key)
+	}
+}
+
+func getConfig(extra map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"module":     "system",
+		"metricsets": []string{"pressure"},
+		"hostfs":     "./_meta/testdata",
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+	return config
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pressure
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// resources lists the resources for which the kernel reports pressure.
+var resources = []string{"cpu", "io", "memory"}
+
+// readPressureFile parses a PSI file like /proc/pressure/cpu:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func readPressureFile(path string) (mapstr.M, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := parsePressure(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return stats, nil
+}
+
+func parsePressure(raw []byte) (mapstr.M, error) {
+	result := mapstr.M{}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		kind := fields[0]
+		if kind != "some" && kind != "full" {
+			return nil, fmt.Errorf("unexpected pressure line type '%s'", kind)
+		}
+
+		stats := mapstr.M{}
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				return nil, fmt.Errorf("malformed pressure value '%s'", field)
+			}
+
+			switch key {
+			case "avg10", "avg60", "avg300":
+				pct, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing %s: %w", key, err)
+				}
+				stats[strings.TrimPrefix(key, "avg")] = mapstr.M{"pct": pct}
+			case "total":
+				total, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing total: %w", err)
+				}
+				stats["total"] = total
+			}
+		}
+		result[kind] = stats
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no pressure data found")
+	}
+	return result, nil
+}
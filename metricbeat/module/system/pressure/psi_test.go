@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pressure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestParsePressure(t *testing.T) {
+	raw := []byte("some avg10=1.53 avg60=0.87 avg300=0.40 total=129745321\n" +
+		"full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+
+	stats, err := parsePressure(raw)
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"some": mapstr.M{
+			"10":    mapstr.M{"pct": 1.53},
+			"60":    mapstr.M{"pct": 0.87},
+			"300":   mapstr.M{"pct": 0.40},
+			"total": uint64(129745321),
+		},
+		"full": mapstr.M{
+			"10":    mapstr.M{"pct": 0.0},
+			"60":    mapstr.M{"pct": 0.0},
+			"300":   mapstr.M{"pct": 0.0},
+			"total": uint64(0),
+		},
+	}, stats)
+}
+
+func TestParsePressureErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":        "",
+		"unknown type": "partial avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+		"no value":     "some avg10\n",
+		"bad float":    "some avg10=abc avg60=0.00 avg300=0.00 total=0\n",
+		"bad total":    "some avg10=0.00 avg60=0.00 avg300=0.00 total=-1\n",
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parsePressure([]byte(raw))
+			assert.Error(t, err)
+		})
+	}
+}
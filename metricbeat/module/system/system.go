@@ -20,6 +20,7 @@ package system
 import (
 	"sync"
 
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/metricbeat/internal/sysinit"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 )
@@ -31,4 +32,5 @@ func init() {
 	if err := mb.Registry.AddModule("system", sysinit.InitSystemModule); err != nil {
 		panic(err)
 	}
+	seccomp.RegisterBase(seccomp.KindModule, "system")
 }
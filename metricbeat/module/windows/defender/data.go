@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package defender
+
+import (
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// computerStatus holds the properties read from MSFT_MpComputerStatus.
+type computerStatus struct {
+	AMServiceEnabled              bool
+	AntivirusEnabled              bool
+	AntispywareEnabled            bool
+	RealTimeProtectionEnabled     bool
+	BehaviorMonitorEnabled        bool
+	IoavProtectionEnabled         bool
+	OnAccessProtectionEnabled     bool
+	IsTamperProtected             bool
+	AMRunningMode                 string
+	AMEngineVersion               string
+	AMProductVersion              string
+	AntivirusSignatureVersion     string
+	AntivirusSignatureAge         uint32
+	AntivirusSignatureLastUpdated time.Time
+	QuickScanAge                  uint32
+	FullScanAge                   uint32
+}
+
+// threat holds the properties read from MSFT_MpThreat.
+type threat struct {
+	ThreatID         int64
+	SeverityID       uint8
+	IsActive         bool
+	DidThreatExecute bool
+}
+
+// severities maps MSFT_MpThreat SeverityID values to their names.
+var severities = map[uint8]string{
+	0: "unknown",
+	1: "low",
+	2: "moderate",
+	4: "high",
+	5: "severe",
+}
+
+// ageUnknown is reported by Defender when a scan never ran.
+const ageUnknown = 65535
+
+func toEvent(s computerStatus, threats []threat) mapstr.M {
+	event := mapstr.M{
+		"antimalware": mapstr.M{"enabled": s.AMServiceEnabled},
+		"antivirus":   mapstr.M{"enabled": s.AntivirusEnabled},
+		"antispyware": mapstr.M{"enabled": s.AntispywareEnabled},
+		"protection": mapstr.M{
+			"real_time":        s.RealTimeProtectionEnabled,
+			"behavior_monitor": s.BehaviorMonitorEnabled,
+			"ioav":             s.IoavProtectionEnabled,
+			"on_access":        s.OnAccessProtectionEnabled,
+			"tamper":           s.IsTamperProtected,
+		},
+		"running_mode": s.AMRunningMode,
+		"engine":       mapstr.M{"version": s.AMEngineVersion},
+		"product":      mapstr.M{"version": s.AMProductVersion},
+		"signature": mapstr.M{
+			"version": s.AntivirusSignatureVersion,
+			"age":     mapstr.M{"days": s.AntivirusSignatureAge},
+		},
+	}
+	if !s.AntivirusSignatureLastUpdated.IsZero() {
+		_, _ = event.Put("signature.last_updated", s.AntivirusSignatureLastUpdated.UTC())
+	}
+	if s.QuickScanAge != ageUnknown {
+		_, _ = event.Put("quick_scan.age.days", s.QuickScanAge)
+	}
+	if s.FullScanAge != ageUnknown {
+		_, _ = event.Put("full_scan.age.days", s.FullScanAge)
+	}
+
+	var active, executed int
+	bySeverity := mapstr.M{}
+	for _, t := range threats {
+		if t.IsActive {
+			active++
+		}
+		if t.DidThreatExecute {
+			executed++
+		}
+		name, ok := severities[t.SeverityID]
+		if !ok {
+			name = "unknown"
+		}
+		count, _ := bySeverity[name].(int)
+		bySeverity[name] = count + 1
+	}
+	event["threats"] = mapstr.M{
+		"total":       len(threats),
+		"active":      active,
+		"executed":    executed,
+		"by_severity": bySeverity,
+	}
+	return event
+}
@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package defender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestToEvent(t *testing.T) {
+	updated := time.Date(2024, 5, 2, 7, 30, 0, 0, time.UTC)
+	status := computerStatus{
+		AMServiceEnabled:              true,
+		AntivirusEnabled:              true,
+		RealTimeProtectionEnabled:     true,
+		IsTamperProtected:             true,
+		AMRunningMode:                 "Normal",
+		AntivirusSignatureVersion:     "1.411.125.0",
+		AntivirusSignatureAge:         1,
+		AntivirusSignatureLastUpdated: updated,
+		QuickScanAge:                  0,
+		FullScanAge:                   ageUnknown,
+	}
+	threats := []threat{
+		{ThreatID: 1, SeverityID: 5, IsActive: true, DidThreatExecute: true},
+		{ThreatID: 2, SeverityID: 5},
+		{ThreatID: 3, SeverityID: 1},
+	}
+
+	event := toEvent(status, threats)
+
+	assert.Equal(t, true, get(t, event, "antivirus.enabled"))
+	assert.Equal(t, true, get(t, event, "protection.real_time"))
+	assert.Equal(t, true, get(t, event, "protection.tamper"))
+	assert.Equal(t, "Normal", get(t, event, "running_mode"))
+	assert.Equal(t, updated, get(t, event, "signature.last_updated"))
+	assert.Equal(t, uint32(0), get(t, event, "quick_scan.age.days"))
+	_, err := event.GetValue("full_scan.age.days")
+	assert.Error(t, err, "unknown scan age must not be reported")
+
+	assert.Equal(t, 3, get(t, event, "threats.total"))
+	assert.Equal(t, 1, get(t, event, "threats.active"))
+	assert.Equal(t, 1, get(t, event, "threats.executed"))
+	assert.Equal(t, mapstr.M{"severe": 2, "low": 1}, get(t, event, "threats.by_severity"))
+}
+
+func TestToEventNoThreats(t *testing.T) {
+	event := toEvent(computerStatus{}, nil)
+	assert.Equal(t, 0, get(t, event, "threats.total"))
+	assert.Equal(t, mapstr.M{}, get(t, event, "threats.by_severity"))
+}
+
+func get(t *testing.T, m mapstr.M, key string) interface{} {
+	t.Helper()
+	v, err := m.GetValue(key)
+	if err != nil {
+		t.Fatalf("missing %s: %v", key, err)
+	}
+	return v
+}
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build windows
+
+package defender
+
+import (
+	"fmt"
+
+	"github.com/StackExchange/wmi"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// namespace is the WMI namespace of the Defender provider.
+const namespace = `root\Microsoft\Windows\Defender`
+
+func init() {
+	mb.Registry.MustAddMetricSet("windows", "defender", New)
+}
+
+// MetricSet reads MSFT_MpComputerStatus and MSFT_MpThreat.
+type MetricSet struct {
+	mb.BaseMetricSet
+}
+
+// New creates a new instance of the defender MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The windows defender metricset is beta.")
+	return &MetricSet{BaseMetricSet: base}, nil
+}
+
+// Fetch reports the Defender status with the number of known threats.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var status []computerStatus
+	if err := wmi.QueryNamespace(wmi.CreateQuery(&status, ""), &status, namespace); err != nil {
+		return fmt.Errorf("failed to query MSFT_MpComputerStatus: %w", err)
+	}
+	if len(status) == 0 {
+		return fmt.Errorf("MSFT_MpComputerStatus returned no instances, is Microsoft Defender installed?")
+	}
+
+	var threats []threat
+	if err := wmi.QueryNamespace(wmi.CreateQuery(&threats, ""), &threats, namespace); err != nil {
+		return fmt.Errorf("failed to query MSFT_MpThreat: %w", err)
+	}
+
+	reporter.Event(mb.Event{
+		MetricSetFields: toEvent(status[0], threats),
+	})
+	return nil
+}
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dhcp
+
+import (
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// scopeStatistics is one scope in the output of the statistics script.
+type scopeStatistics struct {
+	ScopeID         string `json:"ScopeId"`
+	Name            string
+	State           string
+	SubnetMask      string
+	StartRange      string
+	EndRange        string
+	Free            uint64
+	InUse           uint64
+	Reserved        uint64
+	Pending         uint64
+	PercentageInUse float64
+}
+
+func toEvent(s scopeStatistics) mapstr.M {
+	event := mapstr.M{
+		"scope": mapstr.M{
+			"id": s.ScopeID,
+		},
+		"addresses": mapstr.M{
+			"free":     s.Free,
+			"in_use":   s.InUse,
+			"reserved": s.Reserved,
+			"pending":  s.Pending,
+			"total":    s.Free + s.InUse,
+		},
+		// PercentageInUse is a percentage, Beats report ratios.
+		"utilization": mapstr.M{"pct": s.PercentageInUse / 100},
+	}
+	scope := event["scope"].(mapstr.M)
+	if s.Name != "" {
+		scope["name"] = s.Name
+	}
+	if s.State != "" {
+		scope["state"] = strings.ToLower(s.State)
+	}
+	if s.SubnetMask != "" {
+		scope["subnet_mask"] = s.SubnetMask
+	}
+	if s.StartRange != "" {
+		scope["range"] = mapstr.M{
+			"start": s.StartRange,
+			"end":   s.EndRange,
+		}
+	}
+	return event
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dhcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/windows/powershell"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestToEvent(t *testing.T) {
+	output := `[{"ScopeId":"10.0.0.0","Name":"Office","State":"Active","SubnetMask":"255.255.255.0",` +
+		`"StartRange":"10.0.0.10","EndRange":"10.0.0.209","Free":150,"InUse":50,"Reserved":5,"Pending":1,` +
+		`"PercentageInUse":25}]`
+
+	var scopes []scopeStatistics
+	require.NoError(t, powershell.Decode([]byte(output), &scopes))
+	require.Len(t, scopes, 1)
+
+	assert.Equal(t, mapstr.M{
+		"scope": mapstr.M{
+			"id":          "10.0.0.0",
+			"name":        "Office",
+			"state":       "active",
+			"subnet_mask": "255.255.255.0",
+			"range": mapstr.M{
+				"start": "10.0.0.10",
+				"end":   "10.0.0.209",
+			},
+		},
+		"addresses": mapstr.M{
+			"free":     uint64(150),
+			"in_use":   uint64(50),
+			"reserved": uint64(5),
+			"pending":  uint64(1),
+			"total":    uint64(200),
+		},
+		"utilization": mapstr.M{"pct": 0.25},
+	}, toEvent(scopes[0]))
+}
+
+func TestToEventNoScopes(t *testing.T) {
+	var scopes []scopeStatistics
+	require.NoError(t, powershell.Decode([]byte("[]"), &scopes))
+	assert.Empty(t, scopes)
+}
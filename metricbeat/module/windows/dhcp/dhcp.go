@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build windows
+
+package dhcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper/windows/powershell"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// script joins the scope statistics with the scope settings. It requires the
+// DhcpServer PowerShell module that is installed with the DHCP server role
+// or the Remote Server Administration Tools.
+const script = `
+$scopes = @{}
+Get-DhcpServerv4Scope %[1]s | ForEach-Object { $scopes[$_.ScopeId.ToString()] = $_ }
+ConvertTo-Json -Compress -InputObject @(Get-DhcpServerv4ScopeStatistics %[1]s | ForEach-Object {
+	$scope = $scopes[$_.ScopeId.ToString()]
+	[pscustomobject]@{
+		ScopeId = $_.ScopeId.ToString()
+		Name = $scope.Name
+		State = [string]$scope.State
+		SubnetMask = [string]$scope.SubnetMask
+		StartRange = [string]$scope.StartRange
+		EndRange = [string]$scope.EndRange
+		Free = $_.Free
+		InUse = $_.InUse
+		Reserved = $_.Reserved
+		Pending = $_.Pending
+		PercentageInUse = $_.PercentageInUse
+	}
+})
+`
+
+func init() {
+	mb.Registry.MustAddMetricSet("windows", "dhcp", New)
+}
+
+type config struct {
+	// Server is the DHCP server to query, the local server is queried
+	// when it is empty.
+	Server string `config:"dhcp.server"`
+}
+
+// MetricSet reports one event per DHCP scope.
+type MetricSet struct {
+	mb.BaseMetricSet
+	script string
+}
+
+// New creates a new instance of the dhcp MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The windows dhcp metricset is beta.")
+
+	var c config
+	if err := base.Module().UnpackConfig(&c); err != nil {
+		return nil, err
+	}
+	var args string
+	if server := strings.TrimSpace(c.Server); server != "" {
+		args = "-ComputerName " + powershell.Quote(server)
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		script:        fmt.Sprintf(script, args),
+	}, nil
+}
+
+// Fetch reports the utilization of every scope.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.Module().Config().Timeout)
+	defer cancel()
+
+	var scopes []scopeStatistics
+	if err := powershell.Run(ctx, m.script, &scopes); err != nil {
+		return fmt.Errorf("failed to read DHCP scope statistics: %w", err)
+	}
+
+	for _, s := range scopes {
+		if !reporter.Event(mb.Event{MetricSetFields: toEvent(s)}) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetWindows returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/windows.
 func AssetWindows() string {
-	return "eJysVl1v2zYUffevuMhLgSIx1vZpfiiQxcvmYUmLJoUxIIDFkFfWXShS5b20Y2A/fqAkO5Y/YscoHwKFpM49555D0RfwhIsBzMkZP+cegJBYHMDZuJk56wEYZB2oEvJuAJ97AAA33kSLkPsA49WrXPggE+1dTtMB5Moy9gACWlSMA5iqHkBOaA0PapALcKrE9eJpyKJKm4OPVTuzo34XaB2swpCX3q3mdwGm0aW1HDtLNWMbeJPCCwlyLMpp7AA0PJ5wMffBdFY6Vf/rLAGMWiyYKRuxv6NaiRJIc/99//2Ogv7xX9TSWWimJs16br3auzwpVVWRm7Z7z96f7Sf+eYP4TU2roc0QUGJwaF4EbFnHGGbUadpu616pmrUYGWjvRJFjkAKBRUnk9bgui3H/YCa2bV4z2myI3m8yAD6rskqHqxh/uv3rWn+4rDZ2vNpPgEuIjn5EhNGw1lJLa3T0YSRADAoKxQX4vF4slS7I4TuGP76PhqCcSdNbuC1GrWmHP+uC099TJI9R80y/Te79i7xjqBniyqrF5GSKbTJ+n6ETuPLWohYf3s65JVLTWjqxtOl1CSwqNAfvDQKO6WLCjVUNs8mo3lB5Znq0uDytKiBkl1F8qYR0dr6Fmv3mvWTnkA2J1aNFk55vlIvKZud10LK7BQuW2VGST/Xs9h4uv9//+eXb6P6fh7+9VvZu6yNyRI8utfbRSeNYdAYDzAvSBahVAEN0fEBKpaQ4WcnV4GE8uh1+Gd89cN24Tx8feKYLz9LHZ4SLJ1jXBxdv/HZcR2sX8CMqSzmhqcmC+DoKOVkEKZQAJTIlOuH1jGzbT07baMhNQYVprF847LP85FQrLVHZBvn4VF95J+QiuemuWH9Vkeul5rHJ9bfoXDt5l+K6evb15bgKfPofzaHE4zOlX0nmJ3bj2oeX8qurDaQgTpdCagyG4AOkso3Tq1xj5YPwFuS8QNcczuSyeOBWbWotMczJWnjEGnuKDtNVv3G3bmGucYjOIndCBlXwMzLJpuXUBVeoKSe99uahM7jnRrbeTfcdvQ+//PrxhH4vU7G734rZa1KSjlrwOon9OhoeYB8roRL75aYdezXkPpRKBmBiUInsxjK5Kspkuakka4lRe2c2Cxx/E7/jliW05qABch3sfu//AAAA//8t/Vii"
+	return "eNrNWllv20YQfs+vWOSlbRATvV7qhwKu3bQuateIExgFAoir5VDcmtpl9pCioj++M8ulTInUaSqtAcMSj5lvjp35dtZn7BEW52wuVabn9gVjTroSztnLh/rKS7yUgRVGVk5qdc5+xAuM3ejMl8BybdjD8lVbaONGQqtcTs5ZzksLeNVACdyiyAnHb7mEMrPnQcgZU3wKbeX04xYVPWy0r+KVHv2rgtrCMshBZWCWN/okbpRa/6SNkJShNY5LZZkrgFnHnbeMqwy/GuAOb3vlLNM5u5HCaKtzx666CBi7UE7OpPE2aV1eumYMjreur9vWto+joCkv59xAAoqPS8hWnmvMHWuNwtXavS1G089DAWinCca2FDELZiYFMGlZ1JlsBFdbeTJoSxWsMtqBoOf3wmWrxWmd1lJyCLanRxNMqXKEXofhwZHoMxJ9JLQxFHwmtRlNtZJOm+ERNhpY1CDV5BCAUvPZ8KCs4EoRElzhWKNUqXkGGS7QEuo6wJ3jopgCVYED0Go14kKAtcND1uqsFn1kpB2fVnCC+NZyDwFlfPA9plzWvyKwdc21yQ7D8w5rW5TMSDKFtinZr0NHg08IFbtbeqsNVsD0NUvvuLVyBinrZD52i5+v3rKfSi0eqS1C2m8MqIlUkMzAWAQ2rDlRKFmyXrmj2k1Bz7xwnx1To7cXlJUThV3WfD5X1d1kqdfuwsUnkGR80b90S60mh6NCkdsQMakYadyFrOTWjXyVcbehv9Gdw9GFtrER2xwwpKSZRc39KD96KR5HVE9P4UDlp2OqfXnwE8JTooYcgAXdoZYn7FY7bIYVMlWs4/MCFFO6I/LpBWbwdwEbkjX3Zfkf2USqt5gU7nfE7rao5rU2cdrx8iTGRA2U02RMJNKFtNjzF9tBcewbMzgpKlcgmEB5nUQH1xq3o4JPIPymFTcorrDUGnXbQY0XIwtY7KRbJK96kenxX9iH127VF0fDYh8vWIOFfZmWek4dlVqvwXJBnws5KehveArSjljsyalXjwoJWPpV0t3yFaJ63nYPBaxt9XiWGeJQHtNA/s1d7BmcXf16eRf2Q2jj9d3se1xTuoLn7upqITIbttldXzVdJch/zSRGQ4FDaY+NhckWPPR5WEQkcQXTNvW03R5YfxC55pS0XuRpSDOp4retwPwYvTiacvs4MLwgmJHgvb2E5XwSfGVcLxhZHY4jlwZbTLMG2khYrW4nHqTTg6EJ/e5QMPF5pHO5gdP0DBL8pKdpaFsC9oRJqpG3p0EVik92JC78Q6XtNL2sEX4ktgpzSnYQDAPtCZHOc+xBGXOaiVKGfX3ovZnMkFYR0froAfMRYU87QjfSqicrTkes+r1aoyfeN4aYG/0QW50uqUR/LUH+iLv1UV5qvv5ATntlh5tKMAK9dkTNMVws2+wWU4ic4VVcPl0mgMrz6cqWsZ8MrM6k9wDZFdzt5g0IpBCOI1d/sV9jWNH6z5prrqMsNuOlX1khjbYpOCOFTV6t0byNJG+F4nUj2bqNDa6qaABTP/vy1csXe8f0JsCqYVv0N+4VVTvzOqGLI+Zn8bgoo39q3zqraJT1D+Mn+5K2g+haHCqds+Lhu9vf3ohvLg7shBfMK4m1h1gd2RJMq+1I2LWjaRpnBbdF0yKnXBRSwReW/fIe36lPLaC7J4yzfbKpJz5tgw/kg0uTH0DYmTiCDu0PLZO2KvlidDTEmBk/z7B4sUtdlrgKOsO+PTBHIKtMN4ZpuwmBwoW1NjjnNc5XQcw6ovBApa2V4xKa1UoVNr3wTmNBlyJ93Z14/qS1ow3blbRhfkufb7jyYVhKiZbeL6yDabqXycfG7PYdu3j/7tc/3l6/+/PD7xp7032niOyzsEQ4xasj5mkMzOaFFAUupyYBjVd2hykVd8XRllyef3i4vr364+H+gw2O++7bD7hiCm0djRfY2SNr28fODqwdb3xZLthHjI/EgpYFsMRwXOD6ZeysksBMI+VZ5kg3/FKJ0hMVw0yZ+PDC7jgPvZNDsoDmrG3odmb1JTYGqTxi70vrO+5tuFV/rPP6bX1WQB/vKV2Xn3VojsuEp+/4xg5PwCdJR+RDHmi8oX1ro37Z2tAhMhxJkWPAGHyI1NaRXuZ1mBrajsgwRLTRWkoUG60l16LMOc3GxvU0cgIKqNWv9daOzBYGr0rayrVCRmcCM5lRmJpLZ7YCgekqWm/uWoNy743Lcul98/UP3x7h7yYr+v3NrdVC0jCcDAuHcXfXVzvQ+4oG7cl07xlyQ7kzbwJtf7G+TCvvRs1DUwyZtIDMKLNHd2LkEjVKFoODBiIXb8vukvL6WOBZxK4W0cPrwtJvMu99eOq5o7i4zUxCUzjNRq2qSimocUbftMbOtMUMG4hy81ltBPh0Hn0SlFFNFyIvDfBs0ToP347TwBjpwog2ztJ8TrDhsKPWimWlhkHFLPp3O+r/wwB93arxgt3cv71sT9NRGHI04l3ddob9HKs7V2593k4j+DhUX67j9mB95RB+j+g9799mmshI20Srnr5oKtCubjIxZMs5fPRIP+RwDGp9+CeIxAI3ohj6NDQSjjCZjJpyj2wkKAvbs/0RRts+E8SNnvwX2pDxyQ=="
 }
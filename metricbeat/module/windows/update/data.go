@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package update
+
+import (
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// status is the output of the update search script.
+type status struct {
+	Updates                     []pendingUpdate
+	RebootRequired              bool
+	LastSearchSuccessDate       string
+	LastInstallationSuccessDate string
+}
+
+type pendingUpdate struct {
+	Title          string
+	MsrcSeverity   string
+	IsDownloaded   bool
+	RebootRequired bool
+}
+
+func toEvent(s status) mapstr.M {
+	var downloaded, rebootRequired int
+	bySeverity := mapstr.M{}
+	for _, u := range s.Updates {
+		if u.IsDownloaded {
+			downloaded++
+		}
+		if u.RebootRequired {
+			rebootRequired++
+		}
+		severity := strings.ToLower(u.MsrcSeverity)
+		if severity == "" {
+			severity = "unspecified"
+		}
+		count, _ := bySeverity[severity].(int)
+		bySeverity[severity] = count + 1
+	}
+
+	event := mapstr.M{
+		"pending": mapstr.M{
+			"count":           len(s.Updates),
+			"downloaded":      downloaded,
+			"reboot_required": rebootRequired,
+			"by_severity":     bySeverity,
+		},
+		"reboot_required": s.RebootRequired,
+	}
+	if ts, ok := parseDate(s.LastSearchSuccessDate); ok {
+		_, _ = event.Put("last_success.search", ts)
+	}
+	if ts, ok := parseDate(s.LastInstallationSuccessDate); ok {
+		_, _ = event.Put("last_success.install", ts)
+	}
+	return event
+}
+
+// parseDate parses a round-trip formatted date. The Windows Update Agent
+// reports dates before 1900 when a search or installation never succeeded.
+func parseDate(s string) (time.Time, bool) {
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || ts.Year() < 1900 {
+		return time.Time{}, false
+	}
+	return ts.UTC(), true
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/windows/powershell"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestToEvent(t *testing.T) {
+	output := `{"Updates":[` +
+		`{"Title":"2024-05 Cumulative Update","MsrcSeverity":"Critical","IsDownloaded":true,"RebootRequired":true},` +
+		`{"Title":"Security Intelligence Update","MsrcSeverity":"","IsDownloaded":false,"RebootRequired":false},` +
+		`{"Title":".NET Framework Update","MsrcSeverity":"Important","IsDownloaded":false,"RebootRequired":true}],` +
+		`"RebootRequired":false,` +
+		`"LastSearchSuccessDate":"2024-05-02T07:30:00.0000000Z",` +
+		`"LastInstallationSuccessDate":"1601-01-01T00:00:00.0000000Z"}`
+
+	var s status
+	require.NoError(t, powershell.Decode([]byte(output), &s))
+	event := toEvent(s)
+
+	assert.Equal(t, mapstr.M{
+		"pending": mapstr.M{
+			"count":           3,
+			"downloaded":      1,
+			"reboot_required": 2,
+			"by_severity": mapstr.M{
+				"critical":    1,
+				"important":   1,
+				"unspecified": 1,
+			},
+		},
+		"reboot_required": false,
+		"last_success": mapstr.M{
+			"search": time.Date(2024, 5, 2, 7, 30, 0, 0, time.UTC),
+		},
+	}, event)
+}
+
+func TestToEventNoUpdates(t *testing.T) {
+	var s status
+	require.NoError(t, powershell.Decode([]byte(`{"Updates":[],"RebootRequired":true}`), &s))
+	event := toEvent(s)
+
+	count, _ := event.GetValue("pending.count")
+	assert.Equal(t, 0, count)
+	reboot, _ := event.GetValue("reboot_required")
+	assert.Equal(t, true, reboot)
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build windows
+
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper/windows/powershell"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// script searches for updates that are not installed through the Windows
+// Update Agent COM API. A search can take minutes when the update service
+// has to contact Microsoft Update.
+const script = `
+$searcher = (New-Object -ComObject Microsoft.Update.Session).CreateUpdateSearcher()
+$updates = @($searcher.Search("IsInstalled=0 and IsHidden=0").Updates)
+$results = (New-Object -ComObject Microsoft.Update.AutoUpdate).Results
+ConvertTo-Json -Compress -InputObject ([pscustomobject]@{
+	Updates = @($updates | ForEach-Object { [pscustomobject]@{
+		Title = $_.Title
+		MsrcSeverity = [string]$_.MsrcSeverity
+		IsDownloaded = $_.IsDownloaded
+		RebootRequired = $_.RebootBehavior -ne 0
+	} })
+	RebootRequired = (New-Object -ComObject Microsoft.Update.SystemInfo).RebootRequired
+	LastSearchSuccessDate = $results.LastSearchSuccessDate.ToUniversalTime().ToString('o')
+	LastInstallationSuccessDate = $results.LastInstallationSuccessDate.ToUniversalTime().ToString('o')
+})
+`
+
+func init() {
+	mb.Registry.MustAddMetricSet("windows", "update", New)
+}
+
+// MetricSet reports the pending Windows updates.
+type MetricSet struct {
+	mb.BaseMetricSet
+}
+
+// New creates a new instance of the update MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The windows update metricset is beta.")
+	return &MetricSet{BaseMetricSet: base}, nil
+}
+
+// Fetch searches for pending updates and reports their counts.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.Module().Config().Timeout)
+	defer cancel()
+
+	var s status
+	if err := powershell.Run(ctx, script, &s); err != nil {
+		return fmt.Errorf("failed to search for pending updates: %w", err)
+	}
+
+	reporter.Event(mb.Event{
+		MetricSetFields: toEvent(s),
+	})
+	return nil
+}
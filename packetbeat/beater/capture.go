@@ -0,0 +1,368 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/mitchellh/hashstructure"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+)
+
+// errCaptureNotFound is returned when a capture update does not match any
+// interface or enabled protocol.
+var errCaptureNotFound = errors.New("not found")
+
+// capture holds the state needed to change the capture of a single interface
+// at runtime.
+type capture struct {
+	device    string
+	file      bool
+	protocols *protos.ProtocolsStruct
+
+	// autoFilter is set if the BPF filter is generated from the protocol
+	// ports and must be regenerated when they change.
+	autoFilter bool
+	withVlans  bool
+	withICMP   bool
+}
+
+// interfaceState is the capture state of an interface reported by the
+// capture API.
+type interfaceState struct {
+	Device    string           `json:"device"`
+	BpfFilter string           `json:"bpf_filter"`
+	Generated bool             `json:"generated"`
+	Protocols map[string][]int `json:"protocols"`
+}
+
+// state returns the current capture state of all live interfaces.
+func (p *processor) state() []interfaceState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	filters := p.sniffer.Filters()
+	states := make([]interfaceState, 0, len(p.captures))
+	for _, c := range p.captures {
+		if c.file {
+			continue
+		}
+		protocols := make(map[string][]int)
+		for proto := range c.protocols.GetAllTCP() {
+			protocols[proto.String()] = c.protocols.GetPorts(proto)
+		}
+		for proto := range c.protocols.GetAllUDP() {
+			protocols[proto.String()] = c.protocols.GetPorts(proto)
+		}
+		states = append(states, interfaceState{
+			Device:    c.device,
+			BpfFilter: filters[c.device],
+			Generated: c.autoFilter,
+			Protocols: protocols,
+		})
+	}
+	return states
+}
+
+// SetFilter replaces the BPF filter of the capture on device without
+// restarting it. An empty filter restores the filter generated from the
+// protocol ports.
+func (p *processor) SetFilter(device, filter string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.setFilter(device, filter)
+}
+
+func (p *processor) setFilter(device, filter string) error {
+	for i := range p.captures {
+		c := &p.captures[i]
+		if c.device != device || c.file {
+			continue
+		}
+
+		auto := filter == "" && !p.config.Flows.IsEnabled()
+		if auto {
+			filter = c.protocols.BpfFilter(c.withVlans, c.withICMP)
+		}
+		if err := p.sniffer.SetFilter(device, filter); err != nil {
+			return err
+		}
+		c.autoFilter = auto
+		return nil
+	}
+	return fmt.Errorf("interface %q: %w", device, errCaptureNotFound)
+}
+
+// SetPorts changes the ports the named protocol is analyzed on for all
+// interfaces it is enabled on. Generated BPF filters are updated to match.
+// If the change fails for any interface, all interfaces are left unchanged.
+func (p *processor) SetPorts(name string, ports []int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.setPorts(name, ports)
+}
+
+func (p *processor) setPorts(name string, ports []int) error {
+	proto := protos.Lookup(name)
+	if proto == protos.UnknownProtocol {
+		return fmt.Errorf("protocol %q: %w", name, errCaptureNotFound)
+	}
+
+	type change struct {
+		capture *capture
+		ports   []int
+	}
+	var changed []change
+	rollback := func() {
+		for _, c := range changed {
+			_ = c.capture.protocols.SetPorts(proto, c.ports)
+		}
+	}
+
+	for i := range p.captures {
+		c := &p.captures[i]
+		old := c.protocols.GetPorts(proto)
+		err := c.protocols.SetPorts(proto, ports)
+		if errors.Is(err, protos.ErrNotEnabled) {
+			continue
+		}
+		if err != nil {
+			rollback()
+			return fmt.Errorf("failed to set %s ports on %q: %w", name, c.device, err)
+		}
+		changed = append(changed, change{capture: c, ports: old})
+	}
+	if len(changed) == 0 {
+		return fmt.Errorf("protocol %q: %w", name, errCaptureNotFound)
+	}
+
+	for _, c := range changed {
+		if !c.capture.autoFilter || c.capture.file {
+			continue
+		}
+		filter := c.capture.protocols.BpfFilter(c.capture.withVlans, c.capture.withICMP)
+		if err := p.sniffer.SetFilter(c.capture.device, filter); err != nil {
+			rollback()
+			return fmt.Errorf("failed to update BPF filter on %q: %w", c.capture.device, err)
+		}
+	}
+	return nil
+}
+
+// Update applies cfg without restarting capture if it differs from the
+// running configuration only in interface BPF filters and protocol ports.
+// It implements cfgfile.UpdatableRunner, so any other change makes the
+// runner list restart the processor.
+func (p *processor) Update(cfg *conf.C) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.configurator == nil || p.raw == nil {
+		return errors.New("runtime updates are not supported")
+	}
+	next, err := p.configurator(cfg)
+	if err != nil {
+		return err
+	}
+	filters, ports, err := captureChanges(p.raw, cfg, p.config, next)
+	if err != nil {
+		return err
+	}
+
+	// Ports are applied first so that generated filters include them.
+	names := make([]string, 0, len(ports))
+	for name := range ports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := p.setPorts(name, ports[name]); err != nil {
+			return err
+		}
+	}
+	for device, filter := range filters {
+		if err := p.setFilter(device, filter); err != nil {
+			return err
+		}
+	}
+
+	p.config = next
+	p.raw = cfg
+	return nil
+}
+
+// captureChanges returns the BPF filters by device and the ports by protocol
+// name that differ between the current and next configuration. It returns an
+// error if any other setting differs.
+func captureChanges(currentRaw, nextRaw *conf.C, current, next config.Config) (map[string]string, map[string][]int, error) {
+	currentHash, err := captureIndependentHash(currentRaw)
+	if err != nil {
+		return nil, nil, err
+	}
+	nextHash, err := captureIndependentHash(nextRaw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if currentHash != nextHash || len(current.Interfaces) != len(next.Interfaces) {
+		return nil, nil, errors.New("configuration changes require a restart")
+	}
+
+	filters := make(map[string]string)
+	for i, iface := range next.Interfaces {
+		if current.Interfaces[i].Device != iface.Device {
+			return nil, nil, errors.New("interface changes require a restart")
+		}
+		if current.Interfaces[i].BpfFilter != iface.BpfFilter {
+			filters[iface.Device] = iface.BpfFilter
+		}
+	}
+
+	currentPorts, err := protocolPorts(current)
+	if err != nil {
+		return nil, nil, err
+	}
+	nextPorts, err := protocolPorts(next)
+	if err != nil {
+		return nil, nil, err
+	}
+	ports := make(map[string][]int)
+	for name, p := range currentPorts {
+		if slices.Equal(p, nextPorts[name]) {
+			continue
+		}
+		if nextPorts[name] == nil {
+			return nil, nil, fmt.Errorf("resetting the %s ports to their defaults requires a restart", name)
+		}
+		ports[name] = nextPorts[name]
+	}
+	for name, p := range nextPorts {
+		if _, exists := currentPorts[name]; !exists {
+			ports[name] = p
+		}
+	}
+	return filters, ports, nil
+}
+
+// protocolPorts returns the configured ports by protocol name.
+func protocolPorts(cfg config.Config) (map[string][]int, error) {
+	var settings struct {
+		Type  string `config:"type"`
+		Ports []int  `config:"ports"`
+	}
+	ports := make(map[string][]int)
+	for name, c := range cfg.Protocols {
+		settings.Type, settings.Ports = "", nil
+		if err := c.Unpack(&settings); err != nil {
+			return nil, err
+		}
+		if settings.Type != "" {
+			name = settings.Type
+		}
+		if settings.Ports != nil {
+			ports[name] = settings.Ports
+		}
+	}
+	for _, c := range cfg.ProtocolsList {
+		settings.Type, settings.Ports = "", nil
+		if err := c.Unpack(&settings); err != nil {
+			return nil, err
+		}
+		if settings.Ports != nil {
+			ports[settings.Type] = settings.Ports
+		}
+	}
+	return ports, nil
+}
+
+// captureIndependentHash hashes cfg ignoring BPF filters and protocol ports.
+func captureIndependentHash(cfg *conf.C) (uint64, error) {
+	var m map[string]interface{}
+	if err := cfg.Unpack(&m); err != nil {
+		return 0, err
+	}
+	return hashstructure.Hash(withoutCaptureSettings(m), nil)
+}
+
+func withoutCaptureSettings(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if k == "bpf_filter" || k == "ports" {
+				continue
+			}
+			m[k] = withoutCaptureSettings(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = withoutCaptureSettings(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// captureRegistry tracks the running processors so that the capture API can
+// update them.
+type captureRegistry struct {
+	mu         sync.Mutex
+	processors map[*processor]struct{}
+}
+
+func newCaptureRegistry() *captureRegistry {
+	return &captureRegistry{processors: make(map[*processor]struct{})}
+}
+
+func (r *captureRegistry) add(p *processor) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processors[p] = struct{}{}
+}
+
+func (r *captureRegistry) remove(p *processor) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.processors, p)
+}
+
+func (r *captureRegistry) list() []*processor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	processors := make([]*processor, 0, len(r.processors))
+	for p := range r.processors {
+		processors = append(processors, p)
+	}
+	return processors
+}
@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	captureRoute    = "/capture"
+	contentType     = "Content-Type"
+	applicationJSON = "application/json; charset=utf-8"
+)
+
+// captureHandler serves the capture API used to inspect and change the BPF
+// filters and protocol ports of the running sniffers.
+type captureHandler struct {
+	registry *captureRegistry
+}
+
+// attachCaptureHandler attaches the capture API to the given mux.Router to
+// handle requests to /capture.
+func attachCaptureHandler(r *mux.Router, registry *captureRegistry) error {
+	h := &captureHandler{registry: registry}
+	r = r.PathPrefix(captureRoute).Subrouter()
+	if err := r.HandleFunc("", h.getCapture).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+	if err := r.HandleFunc("/interfaces/{device}", h.putFilter).Methods(http.MethodPut).GetError(); err != nil {
+		return err
+	}
+	return r.HandleFunc("/protocols/{protocol}", h.putPorts).Methods(http.MethodPut).GetError()
+}
+
+func (h *captureHandler) getCapture(w http.ResponseWriter, _ *http.Request) {
+	interfaces := []interfaceState{}
+	for _, p := range h.registry.list() {
+		interfaces = append(interfaces, p.state()...)
+	}
+	serveJSON(w, http.StatusOK, map[string]interface{}{"interfaces": interfaces})
+}
+
+func (h *captureHandler) putFilter(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		BpfFilter *string `json:"bpf_filter"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body.BpfFilter == nil {
+		serveError(w, http.StatusBadRequest, errors.New(`request body must be {"bpf_filter": "<filter>"}`))
+		return
+	}
+
+	device := mux.Vars(req)["device"]
+	h.apply(w, func(p *processor) error {
+		return p.SetFilter(device, *body.BpfFilter)
+	})
+}
+
+func (h *captureHandler) putPorts(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Ports []int `json:"ports"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body.Ports == nil {
+		serveError(w, http.StatusBadRequest, errors.New(`request body must be {"ports": [<port>, ...]}`))
+		return
+	}
+
+	protocol := mux.Vars(req)["protocol"]
+	h.apply(w, func(p *processor) error {
+		return p.SetPorts(protocol, body.Ports)
+	})
+}
+
+// apply calls update on all running processors. It responds with the new
+// capture state if at least one processor was updated.
+func (h *captureHandler) apply(w http.ResponseWriter, update func(*processor) error) {
+	updated := false
+	notFound := errCaptureNotFound
+	for _, p := range h.registry.list() {
+		err := update(p)
+		if errors.Is(err, errCaptureNotFound) {
+			notFound = err
+			continue
+		}
+		if err != nil {
+			serveError(w, http.StatusBadRequest, err)
+			return
+		}
+		updated = true
+	}
+	if !updated {
+		serveError(w, http.StatusNotFound, notFound)
+		return
+	}
+	h.getCapture(w, nil)
+}
+
+func serveError(w http.ResponseWriter, status int, err error) {
+	serveJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func serveJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set(contentType, applicationJSON)
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(value)
+}
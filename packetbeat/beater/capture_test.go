@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+const baseCaptureConfig = `
+interfaces:
+  - device: eth0
+    bpf_filter: "tcp port 80"
+  - device: eth1
+protocols:
+  - type: http
+    ports: [80, 8080]
+  - type: dns
+`
+
+var captureChangesTests = []struct {
+	name        string
+	cfg         string
+	wantFilters map[string]string
+	wantPorts   map[string][]int
+	wantErr     bool
+}{
+	{
+		name:        "unchanged",
+		cfg:         baseCaptureConfig,
+		wantFilters: map[string]string{},
+		wantPorts:   map[string][]int{},
+	},
+	{
+		name: "filter_and_ports",
+		cfg: `
+interfaces:
+  - device: eth0
+    bpf_filter: "tcp port 8000"
+  - device: eth1
+protocols:
+  - type: http
+    ports: [80, 8000]
+  - type: dns
+    ports: [5353]
+`,
+		wantFilters: map[string]string{"eth0": "tcp port 8000"},
+		wantPorts:   map[string][]int{"http": {80, 8000}, "dns": {5353}},
+	},
+	{
+		name: "generated_filter",
+		cfg: `
+interfaces:
+  - device: eth0
+  - device: eth1
+protocols:
+  - type: http
+    ports: [80, 8080]
+  - type: dns
+`,
+		wantFilters: map[string]string{"eth0": ""},
+		wantPorts:   map[string][]int{},
+	},
+	{
+		name: "reset_ports",
+		cfg: `
+interfaces:
+  - device: eth0
+    bpf_filter: "tcp port 80"
+  - device: eth1
+protocols:
+  - type: http
+  - type: dns
+`,
+		wantErr: true,
+	},
+	{
+		name: "other_setting",
+		cfg: `
+interfaces:
+  - device: eth0
+    bpf_filter: "tcp port 80"
+    snaplen: 1514
+  - device: eth1
+protocols:
+  - type: http
+    ports: [80, 8080]
+  - type: dns
+`,
+		wantErr: true,
+	},
+	{
+		name: "removed_protocol",
+		cfg: `
+interfaces:
+  - device: eth0
+    bpf_filter: "tcp port 80"
+  - device: eth1
+protocols:
+  - type: http
+    ports: [80, 8080]
+`,
+		wantErr: true,
+	},
+}
+
+func TestCaptureChanges(t *testing.T) {
+	currentRaw, current := captureConfig(t, baseCaptureConfig)
+	for _, test := range captureChangesTests {
+		t.Run(test.name, func(t *testing.T) {
+			nextRaw, next := captureConfig(t, test.cfg)
+			filters, ports, err := captureChanges(currentRaw, nextRaw, current, next)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantFilters, filters)
+			assert.Equal(t, test.wantPorts, ports)
+		})
+	}
+}
+
+func captureConfig(t *testing.T, yaml string) (*conf.C, config.Config) {
+	t.Helper()
+	raw, err := conf.NewConfigWithYAML([]byte(yaml), "")
+	require.NoError(t, err)
+	cfg, err := config.Config{}.FromStatic(raw)
+	require.NoError(t, err)
+	return raw, cfg
+}
+
+func TestCaptureAPINotFound(t *testing.T) {
+	r := mux.NewRouter()
+	require.NoError(t, attachCaptureHandler(r, newCaptureRegistry()))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/capture", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var state struct {
+		Interfaces []interfaceState `json:"interfaces"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+	assert.Empty(t, state.Interfaces)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/capture/interfaces/eth0",
+		strings.NewReader(`{"bpf_filter": "tcp port 80"}`)))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/capture/protocols/http",
+		strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
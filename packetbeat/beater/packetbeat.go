@@ -147,6 +147,10 @@ func (pb *packetbeat) Run(b *beat.Beat) error {
 		if err != nil {
 			return fmt.Errorf("failed attach inputs api to monitoring endpoint server: %w", err)
 		}
+		err = attachCaptureHandler(b.API.Router(), pb.factory.registry)
+		if err != nil {
+			return fmt.Errorf("failed attach capture api to monitoring endpoint server: %w", err)
+		}
 	}
 
 	if b.Manager != nil {
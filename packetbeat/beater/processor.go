@@ -47,6 +47,15 @@ type processor struct {
 	sniffer         *sniffer.Sniffer
 	shutdownTimeout time.Duration
 	err             chan error
+
+	// mu serializes runtime changes to the capture filters and
+	// protocol ports.
+	mu           sync.Mutex
+	captures     []capture
+	config       config.Config
+	raw          *conf.C
+	configurator func(*conf.C) (config.Config, error)
+	registry     *captureRegistry
 }
 
 func newProcessor(shutdownTimeout time.Duration, publisher *publish.TransactionPublisher, flows *flows.Flows, sniffer *sniffer.Sniffer, err chan error) *processor {
@@ -64,6 +73,7 @@ func (p *processor) String() string {
 }
 
 func (p *processor) Start() {
+	p.registry.add(p)
 	if p.flows != nil {
 		p.flows.Start()
 	}
@@ -81,6 +91,7 @@ func (p *processor) Start() {
 }
 
 func (p *processor) Stop() {
+	p.registry.remove(p)
 	p.sniffer.Stop()
 	if p.flows != nil {
 		p.flows.Stop()
@@ -100,6 +111,7 @@ type processorFactory struct {
 	err          chan error
 	beat         *beat.Beat
 	configurator func(*conf.C) (config.Config, error)
+	registry     *captureRegistry
 }
 
 func newProcessorFactory(name string, err chan error, beat *beat.Beat, configurator func(*conf.C) (config.Config, error)) *processorFactory {
@@ -108,6 +120,7 @@ func newProcessorFactory(name string, err chan error, beat *beat.Beat, configura
 		err:          err,
 		beat:         beat,
 		configurator: configurator,
+		registry:     newCaptureRegistry(),
 	}
 }
 
@@ -169,12 +182,18 @@ func (p *processorFactory) Create(pipeline beat.PipelineConnector, cfg *conf.C)
 	if err != nil {
 		return nil, err
 	}
-	sniffer, err := setupSniffer(id, config, publisher, &watch, flows)
+	sniffer, captures, err := setupSniffer(id, config, publisher, &watch, flows)
 	if err != nil {
 		return nil, err
 	}
 
-	return newProcessor(config.ShutdownTimeout, publisher, flows, sniffer, p.err), nil
+	proc := newProcessor(config.ShutdownTimeout, publisher, flows, sniffer, p.err)
+	proc.captures = captures
+	proc.config = config
+	proc.raw = cfg
+	proc.configurator = p.configurator
+	proc.registry = p.registry
+	return proc, nil
 }
 
 // setupFlows returns a *flows.Flows that will publish to the provided pipeline,
@@ -208,10 +227,10 @@ func setupFlows(pipeline beat.Pipeline, watch *procs.ProcessesWatcher, cfg confi
 	return flows.NewFlows(client.PublishAll, watch, cfg.Flows)
 }
 
-func setupSniffer(id string, cfg config.Config, pub *publish.TransactionPublisher, watch *procs.ProcessesWatcher, flows *flows.Flows) (*sniffer.Sniffer, error) {
+func setupSniffer(id string, cfg config.Config, pub *publish.TransactionPublisher, watch *procs.ProcessesWatcher, flows *flows.Flows) (*sniffer.Sniffer, []capture, error) {
 	icmp, err := cfg.ICMP()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Ensure interfaces are uniquely represented so we don't listen on the
@@ -222,7 +241,7 @@ func setupSniffer(id string, cfg config.Config, pub *publish.TransactionPublishe
 		// Currently we hash on all fields in the config. We can revise this in future.
 		h, err := hashstructure.Hash(iface, nil)
 		if err != nil {
-			return nil, fmt.Errorf("could not deduplicate interface configurations: %w", err)
+			return nil, nil, fmt.Errorf("could not deduplicate interface configurations: %w", err)
 		}
 		if seen[h] {
 			continue
@@ -233,20 +252,33 @@ func setupSniffer(id string, cfg config.Config, pub *publish.TransactionPublishe
 
 	logp.Debug("main", "Initializing protocol plugins")
 	decoders := make(map[string]sniffer.Decoders)
+	captures := make([]capture, 0, len(interfaces))
 	for i, iface := range interfaces {
 		protocols := protos.NewProtocols()
 		err = protocols.InitFiltered(false, iface.Device, pub, watch, cfg.Protocols, cfg.ProtocolsList)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize protocol analyzers for %s: %w", iface.Device, err)
+			return nil, nil, fmt.Errorf("failed to initialize protocol analyzers for %s: %w", iface.Device, err)
 		}
 		decoders[iface.Device] = sniffer.DecodersFor(id, pub, protocols, watch, flows, cfg)
-		if iface.BpfFilter != "" || cfg.Flows.IsEnabled() {
-			continue
+		c := capture{
+			device:    iface.Device,
+			file:      iface.File != "",
+			protocols: protocols,
+			withVlans: iface.WithVlans,
+			withICMP:  icmp.Enabled(),
 		}
-		interfaces[i].BpfFilter = protocols.BpfFilter(iface.WithVlans, icmp.Enabled())
+		if iface.BpfFilter == "" && !cfg.Flows.IsEnabled() {
+			c.autoFilter = true
+			interfaces[i].BpfFilter = protocols.BpfFilter(iface.WithVlans, icmp.Enabled())
+		}
+		captures = append(captures, c)
 	}
 
-	return sniffer.New(id, false, "", decoders, interfaces)
+	s, err := sniffer.New(id, false, "", decoders, interfaces)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, captures, nil
 }
 
 // CheckConfig performs a dry-run creation of a Packetbeat pipeline based
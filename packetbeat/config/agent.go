@@ -102,8 +102,14 @@ func mergeProcsConfig(one, two procs.ProcsConfig) procs.ProcsConfig {
 		refreshPidsFreq = two.RefreshPidsFreq
 	}
 
+	mode := one.Mode
+	if two.Mode == procs.ModeEBPF {
+		mode = two.Mode
+	}
+
 	return procs.ProcsConfig{
 		Enabled:         true,
+		Mode:            mode,
 		MaxProcReadFreq: maxProcReadFreq,
 		RefreshPidsFreq: refreshPidsFreq,
 		Monitored:       append(one.Monitored, two.Monitored...),
@@ -146,6 +146,18 @@ type Flows struct {
 	Index string `config:"index"`
 	// DeltaFlowReports when enabled will report flow network stats(bytes, packets) as delta values
 	EnableDeltaFlowReports bool `config:"enable_delta_flow_reports"`
+	// Export configures sending flow records to IPFIX or NetFlow v9 collectors
+	Export *FlowsExport `config:"export"`
+}
+
+// FlowsExport configures the export of flow records to external collectors,
+// in addition to publishing flow events.
+type FlowsExport struct {
+	Enabled             *bool         `config:"enabled"`
+	Hosts               []string      `config:"hosts" validate:"required"`
+	Protocol            string        `config:"protocol"`
+	ObservationDomainID uint32        `config:"observation_domain_id"`
+	TemplateRefresh     time.Duration `config:"template_refresh"`
 }
 
 type ProtocolCommon struct {
@@ -159,6 +171,22 @@ func (f *Flows) IsEnabled() bool {
 	return f != nil && (f.Enabled == nil || *f.Enabled)
 }
 
+func (e *FlowsExport) IsEnabled() bool {
+	return e != nil && (e.Enabled == nil || *e.Enabled)
+}
+
+func (e *FlowsExport) Validate() error {
+	switch strings.ToLower(e.Protocol) {
+	case "", "ipfix", "netflow9":
+	default:
+		return fmt.Errorf("invalid flows export protocol %q, must be one of ipfix or netflow9", e.Protocol)
+	}
+	if e.TemplateRefresh < 0 {
+		return errors.New("flows export template_refresh must not be negative")
+	}
+	return nil
+}
+
 func (i InterfaceConfig) Validate() error {
 	if i.Type != "af_packet" && i.FanoutGroup != nil {
 		return errFanoutGroupAFPacketOnly
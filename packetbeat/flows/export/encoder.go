@@ -0,0 +1,301 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package export
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+const (
+	versionNetFlow9 = 9
+	versionIPFIX    = 10
+
+	// maxMessageSize is the maximum size of the messages, keeping the
+	// datagrams below the usual path MTU.
+	maxMessageSize = 1400
+
+	ipv4TemplateID = 256
+	ipv6TemplateID = 257
+)
+
+// Information elements, numbered as in the IANA IPFIX registry and the
+// NetFlow v9 field types.
+const (
+	fieldOctetDeltaCount          = 1
+	fieldPacketDeltaCount         = 2
+	fieldProtocolIdentifier       = 4
+	fieldSourceTransportPort      = 7
+	fieldSourceIPv4Address        = 8
+	fieldDestinationTransportPort = 11
+	fieldDestinationIPv4Address   = 12
+	fieldLastSwitched             = 21
+	fieldFirstSwitched            = 22
+	fieldSourceIPv6Address        = 27
+	fieldDestinationIPv6Address   = 28
+	fieldVlanID                   = 58
+	fieldFlowEndReason            = 136
+	fieldFlowStartMilliseconds    = 152
+	fieldFlowEndMilliseconds      = 153
+)
+
+// Values of the flowEndReason information element.
+const (
+	endReasonIdleTimeout   = 0x01
+	endReasonActiveTimeout = 0x02
+)
+
+type field struct {
+	id, length uint16
+}
+
+type template struct {
+	id     uint16
+	fields []field
+	size   int // Size of a data record.
+}
+
+func newTemplate(id uint16, fields ...field) template {
+	t := template{id: id, fields: fields}
+	for _, f := range fields {
+		t.size += int(f.length)
+	}
+	return t
+}
+
+// encoder encodes flow records as IPFIX or NetFlow v9 messages.
+type encoder struct {
+	version uint16
+	domain  uint32
+	boot    time.Time // Reference of the NetFlow v9 system uptime.
+	ipv4    template
+	ipv6    template
+
+	// seq is the IPFIX count of exported data records or the NetFlow v9
+	// count of exported packets.
+	seq uint32
+}
+
+func newIPFIXEncoder(domain uint32) *encoder {
+	common := []field{
+		{fieldSourceTransportPort, 2},
+		{fieldDestinationTransportPort, 2},
+		{fieldProtocolIdentifier, 1},
+		{fieldVlanID, 2},
+		{fieldOctetDeltaCount, 8},
+		{fieldPacketDeltaCount, 8},
+		{fieldFlowStartMilliseconds, 8},
+		{fieldFlowEndMilliseconds, 8},
+		{fieldFlowEndReason, 1},
+	}
+	return &encoder{
+		version: versionIPFIX,
+		domain:  domain,
+		ipv4: newTemplate(ipv4TemplateID, append([]field{
+			{fieldSourceIPv4Address, 4},
+			{fieldDestinationIPv4Address, 4},
+		}, common...)...),
+		ipv6: newTemplate(ipv6TemplateID, append([]field{
+			{fieldSourceIPv6Address, 16},
+			{fieldDestinationIPv6Address, 16},
+		}, common...)...),
+	}
+}
+
+func newNetFlow9Encoder(domain uint32, boot time.Time) *encoder {
+	common := []field{
+		{fieldSourceTransportPort, 2},
+		{fieldDestinationTransportPort, 2},
+		{fieldProtocolIdentifier, 1},
+		{fieldVlanID, 2},
+		{fieldOctetDeltaCount, 8},
+		{fieldPacketDeltaCount, 8},
+		{fieldFirstSwitched, 4},
+		{fieldLastSwitched, 4},
+	}
+	return &encoder{
+		version: versionNetFlow9,
+		domain:  domain,
+		boot:    boot,
+		ipv4: newTemplate(ipv4TemplateID, append([]field{
+			{fieldSourceIPv4Address, 4},
+			{fieldDestinationIPv4Address, 4},
+		}, common...)...),
+		ipv6: newTemplate(ipv6TemplateID, append([]field{
+			{fieldSourceIPv6Address, 16},
+			{fieldDestinationIPv6Address, 16},
+		}, common...)...),
+	}
+}
+
+// encode returns the messages holding the records. The templates are added
+// to the first message if withTemplates is true.
+func (e *encoder) encode(now time.Time, records []record, withTemplates bool) [][]byte {
+	var ipv4, ipv6 []record
+	for _, r := range records {
+		if r.isIPv4() {
+			ipv4 = append(ipv4, r)
+		} else {
+			ipv6 = append(ipv6, r)
+		}
+	}
+
+	var msgs [][]byte
+	for _, group := range []struct {
+		template *template
+		records  []record
+	}{
+		{&e.ipv4, ipv4},
+		{&e.ipv6, ipv6},
+	} {
+		records := group.records
+		for len(records) > 0 || withTemplates {
+			n := e.fit(group.template, len(records), withTemplates)
+			msgs = append(msgs, e.message(now, group.template, records[:n], withTemplates))
+			records = records[n:]
+			withTemplates = false
+		}
+	}
+	return msgs
+}
+
+// fit returns the number of data records fitting in a message.
+func (e *encoder) fit(t *template, n int, withTemplates bool) int {
+	free := maxMessageSize - e.headerSize() - 4 - 3 // Set header and padding.
+	if withTemplates {
+		free -= e.templateSetSize()
+	}
+	return min(n, free/t.size)
+}
+
+func (e *encoder) headerSize() int {
+	if e.version == versionNetFlow9 {
+		return 20
+	}
+	return 16
+}
+
+func (e *encoder) templateSetSize() int {
+	return 4 + 4 + 4*len(e.ipv4.fields) + 4 + 4*len(e.ipv6.fields)
+}
+
+func (e *encoder) message(now time.Time, t *template, records []record, withTemplates bool) []byte {
+	b := make([]byte, e.headerSize(), maxMessageSize)
+	count := len(records)
+
+	if withTemplates {
+		setID := uint16(2)
+		if e.version == versionNetFlow9 {
+			setID = 0
+		}
+		b = binary.BigEndian.AppendUint16(b, setID)
+		b = binary.BigEndian.AppendUint16(b, uint16(e.templateSetSize()))
+		for _, t := range []*template{&e.ipv4, &e.ipv6} {
+			b = binary.BigEndian.AppendUint16(b, t.id)
+			b = binary.BigEndian.AppendUint16(b, uint16(len(t.fields)))
+			for _, f := range t.fields {
+				b = binary.BigEndian.AppendUint16(b, f.id)
+				b = binary.BigEndian.AppendUint16(b, f.length)
+			}
+		}
+		count += 2
+	}
+
+	if len(records) > 0 {
+		start := len(b)
+		b = binary.BigEndian.AppendUint16(b, t.id)
+		b = binary.BigEndian.AppendUint16(b, 0) // Set length, written below.
+		for i := range records {
+			b = e.appendRecord(b, t, &records[i])
+		}
+		if e.version == versionNetFlow9 {
+			// NetFlow v9 flowsets are padded to a 32 bit boundary.
+			for (len(b)-start)%4 != 0 {
+				b = append(b, 0)
+			}
+		}
+		binary.BigEndian.PutUint16(b[start+2:], uint16(len(b)-start))
+	}
+
+	binary.BigEndian.PutUint16(b[0:], e.version)
+	if e.version == versionNetFlow9 {
+		binary.BigEndian.PutUint16(b[2:], uint16(count))
+		binary.BigEndian.PutUint32(b[4:], e.uptime(now))
+		binary.BigEndian.PutUint32(b[8:], uint32(now.Unix()))
+		binary.BigEndian.PutUint32(b[12:], e.seq)
+		binary.BigEndian.PutUint32(b[16:], e.domain)
+		e.seq++
+	} else {
+		binary.BigEndian.PutUint16(b[2:], uint16(len(b)))
+		binary.BigEndian.PutUint32(b[4:], uint32(now.Unix()))
+		binary.BigEndian.PutUint32(b[8:], e.seq)
+		binary.BigEndian.PutUint32(b[12:], e.domain)
+		e.seq += uint32(len(records))
+	}
+	return b
+}
+
+func (e *encoder) appendRecord(b []byte, t *template, r *record) []byte {
+	for _, f := range t.fields {
+		switch f.id {
+		case fieldSourceIPv4Address:
+			b = append(b, r.srcIP.To4()...)
+		case fieldDestinationIPv4Address:
+			b = append(b, r.dstIP.To4()...)
+		case fieldSourceIPv6Address:
+			b = append(b, r.srcIP.To16()...)
+		case fieldDestinationIPv6Address:
+			b = append(b, r.dstIP.To16()...)
+		case fieldSourceTransportPort:
+			b = binary.BigEndian.AppendUint16(b, r.srcPort)
+		case fieldDestinationTransportPort:
+			b = binary.BigEndian.AppendUint16(b, r.dstPort)
+		case fieldProtocolIdentifier:
+			b = append(b, r.protocol)
+		case fieldVlanID:
+			b = binary.BigEndian.AppendUint16(b, r.vlan)
+		case fieldOctetDeltaCount:
+			b = binary.BigEndian.AppendUint64(b, r.bytes)
+		case fieldPacketDeltaCount:
+			b = binary.BigEndian.AppendUint64(b, r.packets)
+		case fieldFlowStartMilliseconds:
+			b = binary.BigEndian.AppendUint64(b, uint64(r.start.UnixMilli()))
+		case fieldFlowEndMilliseconds:
+			b = binary.BigEndian.AppendUint64(b, uint64(r.end.UnixMilli()))
+		case fieldFirstSwitched:
+			b = binary.BigEndian.AppendUint32(b, e.uptime(r.start))
+		case fieldLastSwitched:
+			b = binary.BigEndian.AppendUint32(b, e.uptime(r.end))
+		case fieldFlowEndReason:
+			reason := uint8(endReasonActiveTimeout)
+			if r.final {
+				reason = endReasonIdleTimeout
+			}
+			b = append(b, reason)
+		}
+	}
+	return b
+}
+
+// uptime returns the NetFlow v9 system uptime in milliseconds at ts.
+func (e *encoder) uptime(ts time.Time) uint32 {
+	if ts.Before(e.boot) {
+		return 0
+	}
+	return uint32(ts.Sub(e.boot).Milliseconds())
+}
@@ -0,0 +1,145 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package export
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecord(src, dst string) record {
+	start := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	return record{
+		srcIP:    net.ParseIP(src),
+		dstIP:    net.ParseIP(dst),
+		srcPort:  40000,
+		dstPort:  443,
+		protocol: 6,
+		vlan:     10,
+		bytes:    1500,
+		packets:  3,
+		start:    start,
+		end:      start.Add(2 * time.Second),
+		final:    true,
+	}
+}
+
+func TestIPFIXEncoder(t *testing.T) {
+	enc := newIPFIXEncoder(42)
+	now := time.Date(2023, 6, 1, 10, 0, 5, 0, time.UTC)
+
+	msgs := enc.encode(now, []record{testRecord("10.0.0.1", "10.0.0.2")}, true)
+	require.Len(t, msgs, 1)
+	msg := msgs[0]
+
+	// Message header.
+	assert.Equal(t, uint16(10), binary.BigEndian.Uint16(msg[0:]))
+	assert.Equal(t, uint16(len(msg)), binary.BigEndian.Uint16(msg[2:]))
+	assert.Equal(t, uint32(now.Unix()), binary.BigEndian.Uint32(msg[4:]))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:]))
+	assert.Equal(t, uint32(42), binary.BigEndian.Uint32(msg[12:]))
+
+	// Template set with the IPv4 and IPv6 templates.
+	set := msg[16:]
+	assert.Equal(t, uint16(2), binary.BigEndian.Uint16(set[0:]))
+	setLen := int(binary.BigEndian.Uint16(set[2:]))
+	assert.Equal(t, uint16(ipv4TemplateID), binary.BigEndian.Uint16(set[4:]))
+	assert.Equal(t, uint16(len(enc.ipv4.fields)), binary.BigEndian.Uint16(set[6:]))
+	assert.Equal(t, uint16(fieldSourceIPv4Address), binary.BigEndian.Uint16(set[8:]))
+
+	// Data set.
+	data := set[setLen:]
+	assert.Equal(t, uint16(ipv4TemplateID), binary.BigEndian.Uint16(data[0:]))
+	require.Equal(t, 4+enc.ipv4.size, int(binary.BigEndian.Uint16(data[2:])))
+	rec := data[4:]
+	assert.Equal(t, net.IP{10, 0, 0, 1}, net.IP(rec[0:4]))
+	assert.Equal(t, net.IP{10, 0, 0, 2}, net.IP(rec[4:8]))
+	assert.Equal(t, uint16(40000), binary.BigEndian.Uint16(rec[8:]))
+	assert.Equal(t, uint16(443), binary.BigEndian.Uint16(rec[10:]))
+	assert.Equal(t, uint8(6), rec[12])
+	assert.Equal(t, uint16(10), binary.BigEndian.Uint16(rec[13:]))
+	assert.Equal(t, uint64(1500), binary.BigEndian.Uint64(rec[15:]))
+	assert.Equal(t, uint64(3), binary.BigEndian.Uint64(rec[23:]))
+	assert.Equal(t, uint64(1685613600000), binary.BigEndian.Uint64(rec[31:]))
+	assert.Equal(t, uint64(1685613602000), binary.BigEndian.Uint64(rec[39:]))
+	assert.Equal(t, uint8(endReasonIdleTimeout), rec[47])
+
+	// The sequence number counts the exported data records.
+	msgs = enc.encode(now, []record{testRecord("10.0.0.1", "10.0.0.2")}, false)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msgs[0][8:]))
+	assert.Equal(t, uint16(ipv4TemplateID), binary.BigEndian.Uint16(msgs[0][16:]))
+}
+
+func TestNetFlow9Encoder(t *testing.T) {
+	boot := time.Date(2023, 6, 1, 9, 0, 0, 0, time.UTC)
+	enc := newNetFlow9Encoder(7, boot)
+	now := boot.Add(time.Hour + 5*time.Second)
+
+	records := []record{
+		testRecord("10.0.0.1", "10.0.0.2"),
+		testRecord("2001:db8::1", "2001:db8::2"),
+	}
+	msgs := enc.encode(now, records, true)
+	require.Len(t, msgs, 2)
+
+	msg := msgs[0]
+	assert.Equal(t, uint16(9), binary.BigEndian.Uint16(msg[0:]))
+	assert.Equal(t, uint16(3), binary.BigEndian.Uint16(msg[2:]), "two templates and a data record")
+	assert.Equal(t, uint32(3605000), binary.BigEndian.Uint32(msg[4:]))
+	assert.Equal(t, uint32(now.Unix()), binary.BigEndian.Uint32(msg[8:]))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[12:]))
+	assert.Equal(t, uint32(7), binary.BigEndian.Uint32(msg[16:]))
+
+	set := msg[20:]
+	assert.Equal(t, uint16(0), binary.BigEndian.Uint16(set[0:]))
+	data := set[binary.BigEndian.Uint16(set[2:]):]
+	dataLen := int(binary.BigEndian.Uint16(data[2:]))
+	assert.Zero(t, dataLen%4, "flowsets are padded")
+	assert.Equal(t, len(data), dataLen)
+	rec := data[4:]
+	assert.Equal(t, uint32(3600000), binary.BigEndian.Uint32(rec[31:]))
+	assert.Equal(t, uint32(3602000), binary.BigEndian.Uint32(rec[35:]))
+
+	msg = msgs[1]
+	assert.Equal(t, uint16(1), binary.BigEndian.Uint16(msg[2:]))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msg[12:]), "the sequence number counts packets")
+	data = msg[20:]
+	assert.Equal(t, uint16(ipv6TemplateID), binary.BigEndian.Uint16(data[0:]))
+	assert.Equal(t, net.ParseIP("2001:db8::1"), net.IP(data[4:20]))
+}
+
+func TestEncoderSplitsMessages(t *testing.T) {
+	enc := newIPFIXEncoder(0)
+	records := make([]record, 100)
+	for i := range records {
+		records[i] = testRecord("10.0.0.1", "10.0.0.2")
+	}
+
+	msgs := enc.encode(time.Now(), records, true)
+	require.Greater(t, len(msgs), 1)
+	for _, msg := range msgs {
+		assert.LessOrEqual(t, len(msg), maxMessageSize)
+	}
+	assert.Equal(t, uint32(len(records)), enc.seq)
+}
@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package export sends packetbeat flow records to IPFIX and NetFlow v9
+// collectors.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const defaultTemplateRefresh = time.Minute
+
+// Exporter sends the flows reported in flow events to collectors as IPFIX or
+// NetFlow v9 datagrams.
+type Exporter struct {
+	log     *logp.Logger
+	encoder *encoder
+	conns   []net.Conn
+
+	// deltaReports is set when the flow events counters are relative to
+	// the previous report.
+	deltaReports bool
+	// flows holds the counters of the last report of each flow.
+	flows map[string]counters
+
+	templateRefresh time.Duration
+	templatesSent   time.Time
+}
+
+// New returns an Exporter sending to the collectors in cfg. deltaReports must
+// be set when flow events report delta counters.
+func New(cfg *config.FlowsExport, deltaReports bool) (*Exporter, error) {
+	now := time.Now()
+
+	var enc *encoder
+	switch strings.ToLower(cfg.Protocol) {
+	case "", "ipfix":
+		enc = newIPFIXEncoder(cfg.ObservationDomainID)
+	case "netflow9":
+		enc = newNetFlow9Encoder(cfg.ObservationDomainID, now)
+	default:
+		return nil, fmt.Errorf("unsupported flows export protocol: %v", cfg.Protocol)
+	}
+
+	if len(cfg.Hosts) == 0 {
+		return nil, errors.New("no flows export hosts configured")
+	}
+	conns := make([]net.Conn, 0, len(cfg.Hosts))
+	for _, host := range cfg.Hosts {
+		conn, err := net.Dial("udp", host)
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			return nil, fmt.Errorf("failed to connect flows export host %v: %w", host, err)
+		}
+		conns = append(conns, conn)
+	}
+
+	refresh := cfg.TemplateRefresh
+	if refresh == 0 {
+		refresh = defaultTemplateRefresh
+	}
+
+	return &Exporter{
+		log:             logp.NewLogger("flows.export"),
+		encoder:         enc,
+		conns:           conns,
+		deltaReports:    deltaReports,
+		flows:           make(map[string]counters),
+		templateRefresh: refresh,
+	}, nil
+}
+
+// Export sends the flows reported by events. Export is not safe for
+// concurrent use.
+func (e *Exporter) Export(events []beat.Event) {
+	var records []record
+	for _, event := range events {
+		flow, ok := parseFlowReport(event)
+		if !ok {
+			continue
+		}
+
+		// Flow events report cumulative counters unless delta reports are
+		// enabled, while the exported records hold the counters since the
+		// previous record.
+		var prev counters
+		if !e.deltaReports {
+			prev = e.flows[flow.id]
+			if flow.record.final {
+				delete(e.flows, flow.id)
+			} else {
+				e.flows[flow.id] = flow.counters
+			}
+		}
+		records = append(records, flow.records(prev)...)
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	now := time.Now()
+	// Collectors receiving datagrams rely on the templates being sent
+	// periodically.
+	withTemplates := now.Sub(e.templatesSent) >= e.templateRefresh
+	if withTemplates {
+		e.templatesSent = now
+	}
+
+	for _, msg := range e.encoder.encode(now, records, withTemplates) {
+		for _, conn := range e.conns {
+			if _, err := conn.Write(msg); err != nil {
+				e.log.Errorf("Failed to send flow records to %v: %v", conn.RemoteAddr(), err)
+			}
+		}
+	}
+}
+
+// Close closes the connections to the collectors.
+func (e *Exporter) Close() error {
+	var errs []error
+	for _, conn := range e.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
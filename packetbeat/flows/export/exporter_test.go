@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package export
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func flowEvent(id string, final bool, srcBytes, srcPackets, dstBytes, dstPackets uint64) beat.Event {
+	start := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	return beat.Event{
+		Timestamp: start.Add(10 * time.Second),
+		Fields: mapstr.M{
+			"event": mapstr.M{
+				"start": common.Time(start),
+				"end":   common.Time(start.Add(5 * time.Second)),
+			},
+			"flow": mapstr.M{
+				"id":    common.NetString(id),
+				"final": final,
+			},
+			"network": mapstr.M{
+				"transport": "udp",
+			},
+			"source": mapstr.M{
+				"ip":      "192.168.1.10",
+				"port":    uint16(5353),
+				"bytes":   srcBytes,
+				"packets": srcPackets,
+			},
+			"destination": mapstr.M{
+				"ip":      "192.168.1.1",
+				"port":    uint16(53),
+				"bytes":   dstBytes,
+				"packets": dstPackets,
+			},
+		},
+	}
+}
+
+// dataRecords returns the source address, destination port, octet and packet
+// counts of the IPv4 data records of an IPFIX message.
+func dataRecords(t *testing.T, msg []byte) [][4]uint64 {
+	t.Helper()
+	var records [][4]uint64
+	for sets := msg[16:]; len(sets) > 0; {
+		id := binary.BigEndian.Uint16(sets[0:])
+		length := binary.BigEndian.Uint16(sets[2:])
+		if id == ipv4TemplateID {
+			for rec := sets[4:length]; len(rec) >= 48; rec = rec[48:] {
+				records = append(records, [4]uint64{
+					uint64(binary.BigEndian.Uint32(rec[0:])),
+					uint64(binary.BigEndian.Uint16(rec[10:])),
+					binary.BigEndian.Uint64(rec[15:]),
+					binary.BigEndian.Uint64(rec[23:]),
+				})
+			}
+		}
+		sets = sets[length:]
+	}
+	return records
+}
+
+func TestExporter(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	exporter, err := New(&config.FlowsExport{
+		Hosts: []string{collector.LocalAddr().String()},
+	}, false)
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	receive := func() [][4]uint64 {
+		t.Helper()
+		buf := make([]byte, 65535)
+		require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := collector.ReadFrom(buf)
+		require.NoError(t, err)
+		return dataRecords(t, buf[:n])
+	}
+
+	const (
+		client = 0xc0a8010a // 192.168.1.10
+		server = 0xc0a80101 // 192.168.1.1
+	)
+
+	exporter.Export([]beat.Event{flowEvent("a", false, 100, 1, 0, 0)})
+	assert.Equal(t, [][4]uint64{{client, 53, 100, 1}}, receive())
+
+	// Cumulative counters are exported as deltas.
+	exporter.Export([]beat.Event{flowEvent("a", false, 300, 3, 500, 1)})
+	assert.Equal(t, [][4]uint64{
+		{client, 53, 200, 2},
+		{server, 5353, 500, 1},
+	}, receive())
+
+	exporter.Export([]beat.Event{flowEvent("a", true, 400, 4, 500, 1)})
+	assert.Equal(t, [][4]uint64{{client, 53, 100, 1}}, receive())
+	assert.Empty(t, exporter.flows)
+
+	// Events without IP addresses are ignored.
+	exporter.Export([]beat.Event{{Fields: mapstr.M{"flow": mapstr.M{"id": "b"}}}})
+	exporter.Export([]beat.Event{flowEvent("c", true, 10, 1, 0, 0)})
+	assert.Equal(t, [][4]uint64{{client, 53, 10, 1}}, receive())
+}
+
+func TestExporterDeltaReports(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	exporter, err := New(&config.FlowsExport{
+		Hosts:    []string{collector.LocalAddr().String()},
+		Protocol: "ipfix",
+	}, true)
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	exporter.Export([]beat.Event{flowEvent("a", false, 100, 1, 0, 0)})
+	exporter.Export([]beat.Event{flowEvent("a", false, 100, 1, 0, 0)})
+	assert.Empty(t, exporter.flows)
+
+	buf := make([]byte, 65535)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := collector.ReadFrom(buf)
+		require.NoError(t, err)
+		records := dataRecords(t, buf[:n])
+		require.Len(t, records, 1)
+		assert.Equal(t, uint64(100), records[0][2])
+	}
+}
+
+func TestNewExporterInvalidProtocol(t *testing.T) {
+	_, err := New(&config.FlowsExport{
+		Hosts:    []string{"127.0.0.1:2055"},
+		Protocol: "sflow",
+	}, false)
+	assert.Error(t, err)
+}
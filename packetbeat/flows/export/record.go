@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package export
+
+import (
+	"net"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// record is a unidirectional flow record.
+type record struct {
+	srcIP, dstIP     net.IP
+	srcPort, dstPort uint16
+	protocol         uint8
+	vlan             uint16
+	bytes, packets   uint64
+	start, end       time.Time
+	final            bool
+}
+
+// isIPv4 returns whether the record addresses are IPv4 addresses.
+func (r *record) isIPv4() bool {
+	return r.srcIP.To4() != nil && r.dstIP.To4() != nil
+}
+
+// counters holds the cumulative counters of both directions of a flow.
+type counters struct {
+	srcBytes, srcPackets uint64
+	dstBytes, dstPackets uint64
+}
+
+// flowReport is a flow reported by a flow event.
+type flowReport struct {
+	id       string
+	counters counters
+	// record is the forward record of the flow, without counters.
+	record record
+}
+
+// parseFlowReport returns the flow reported by event. ok is false if the
+// event isn't an IP flow event.
+func parseFlowReport(event beat.Event) (report flowReport, ok bool) {
+	fields := event.Fields
+	source, _ := fields["source"].(mapstr.M)
+	dest, _ := fields["destination"].(mapstr.M)
+	network, _ := fields["network"].(mapstr.M)
+	flow, _ := fields["flow"].(mapstr.M)
+	if source == nil || dest == nil || network == nil || flow == nil {
+		return flowReport{}, false
+	}
+
+	srcIP, dstIP := firstIP(source["ip"]), firstIP(dest["ip"])
+	if srcIP == nil || dstIP == nil {
+		return flowReport{}, false
+	}
+
+	switch v := flow["id"].(type) {
+	case common.NetString:
+		report.id = string(v)
+	case string:
+		report.id = v
+	}
+
+	report.counters = counters{
+		srcBytes:   toUint64(source["bytes"]),
+		srcPackets: toUint64(source["packets"]),
+		dstBytes:   toUint64(dest["bytes"]),
+		dstPackets: toUint64(dest["packets"]),
+	}
+
+	r := record{
+		srcIP:    srcIP,
+		dstIP:    dstIP,
+		srcPort:  uint16(toUint64(source["port"])),
+		dstPort:  uint16(toUint64(dest["port"])),
+		protocol: protocolNumber(network["transport"]),
+		vlan:     uint16(firstUint64(flow["vlan"])),
+		final:    flow["final"] == true,
+	}
+	if ev, ok := fields["event"].(mapstr.M); ok {
+		r.start = toTime(ev["start"])
+		r.end = toTime(ev["end"])
+	}
+	if r.end.IsZero() {
+		r.end = event.Timestamp
+	}
+	if r.start.IsZero() {
+		r.start = r.end
+	}
+	report.record = r
+	return report, true
+}
+
+// records returns the records of both directions of the flow, with the
+// counters relative to prev. The records of directions without new packets
+// are omitted.
+func (f *flowReport) records(prev counters) []record {
+	var records []record
+	cur := f.counters
+	if packets := cur.srcPackets - min(prev.srcPackets, cur.srcPackets); packets > 0 {
+		forward := f.record
+		forward.packets = packets
+		forward.bytes = cur.srcBytes - min(prev.srcBytes, cur.srcBytes)
+		records = append(records, forward)
+	}
+	if packets := cur.dstPackets - min(prev.dstPackets, cur.dstPackets); packets > 0 {
+		reverse := f.record
+		reverse.srcIP, reverse.dstIP = f.record.dstIP, f.record.srcIP
+		reverse.srcPort, reverse.dstPort = f.record.dstPort, f.record.srcPort
+		reverse.packets = packets
+		reverse.bytes = cur.dstBytes - min(prev.dstBytes, cur.dstBytes)
+		records = append(records, reverse)
+	}
+	return records
+}
+
+// protocolNumber returns the IANA protocol number of the network.transport
+// value.
+func protocolNumber(v interface{}) uint8 {
+	switch v {
+	case "icmp":
+		return 1
+	case "tcp":
+		return 6
+	case "udp":
+		return 17
+	case "ipv6-icmp":
+		return 58
+	default:
+		return 0
+	}
+}
+
+// firstIP returns the first address of an IP field, which holds the outer
+// address of tunnelled flows.
+func firstIP(v interface{}) net.IP {
+	switch v := v.(type) {
+	case string:
+		return net.ParseIP(v)
+	case []string:
+		if len(v) > 0 {
+			return net.ParseIP(v[0])
+		}
+	}
+	return nil
+}
+
+func firstUint64(v interface{}) uint64 {
+	if v, ok := v.([]uint64); ok {
+		if len(v) > 0 {
+			return v[0]
+		}
+		return 0
+	}
+	return toUint64(v)
+}
+
+func toUint64(v interface{}) uint64 {
+	switch v := v.(type) {
+	case uint64:
+		return v
+	case uint32:
+		return uint64(v)
+	case uint16:
+		return uint64(v)
+	case uint8:
+		return uint64(v)
+	case int:
+		if v > 0 {
+			return uint64(v)
+		}
+	case int64:
+		if v > 0 {
+			return uint64(v)
+		}
+	}
+	return 0
+}
+
+func toTime(v interface{}) time.Time {
+	switch v := v.(type) {
+	case common.Time:
+		return time.Time(v)
+	case time.Time:
+		return v
+	}
+	return time.Time{}
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/flows/export"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -41,6 +42,7 @@ type Flows struct {
 	worker     *worker
 	table      *flowMetaTable
 	counterReg *counterReg
+	exporter   *export.Exporter
 }
 
 // NewFlows returns a Flows publishing to pub after enrichment by the given
@@ -71,9 +73,28 @@ func NewFlows(pub Reporter, watcher *procs.ProcessesWatcher, config *config.Flow
 
 	counter := &counterReg{}
 
+	var exporter *export.Exporter
+	if config.Export.IsEnabled() {
+		exporter, err = export.New(config.Export, config.EnableDeltaFlowReports)
+		if err != nil {
+			logp.Err("failed to configure flows export: %v", err)
+			return nil, err
+		}
+		// Flows are exported before publishing, as the events may be
+		// modified by the pipeline.
+		publish := pub
+		pub = func(events []beat.Event) {
+			exporter.Export(events)
+			publish(events)
+		}
+	}
+
 	worker, err := newFlowsWorker(pub, watcher, table, counter, timeout, period, config.EnableDeltaFlowReports)
 	if err != nil {
 		logp.Err("failed to configure flows processing intervals: %v", err)
+		if exporter != nil {
+			exporter.Close()
+		}
 		return nil, err
 	}
 
@@ -81,6 +102,7 @@ func NewFlows(pub Reporter, watcher *procs.ProcessesWatcher, config *config.Flow
 		table:      table,
 		worker:     worker,
 		counterReg: counter,
+		exporter:   exporter,
 	}, nil
 }
 
@@ -109,6 +131,11 @@ func (f *Flows) Start() {
 
 func (f *Flows) Stop() {
 	f.worker.stop()
+	if f.exporter != nil {
+		if err := f.exporter.Close(); err != nil {
+			logp.Err("failed to close flows export: %v", err)
+		}
+	}
 }
 
 func (f *Flows) NewInt(name string) (*Int, error) {
@@ -17,15 +17,38 @@
 
 package procs
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ModeProc resolves socket owners by scanning the /proc file system, or the
+	// IP Helper API on Windows.
+	ModeProc = "proc"
+
+	// ModeEBPF resolves socket owners from kernel events collected with eBPF
+	// probes. It's only available on Linux.
+	ModeEBPF = "ebpf"
+)
 
 type ProcsConfig struct {
 	Enabled         bool          `config:"enabled"`
+	Mode            string        `config:"mode"`
 	MaxProcReadFreq time.Duration `config:"max_proc_read_freq"`
 	Monitored       []ProcConfig  `config:"monitored"`
 	RefreshPidsFreq time.Duration `config:"refresh_pids_freq"`
 }
 
+func (c *ProcsConfig) Validate() error {
+	switch c.Mode {
+	case "", ModeProc, ModeEBPF:
+		return nil
+	default:
+		return fmt.Errorf("invalid procs mode %q, must be one of %q or %q", c.Mode, ModeProc, ModeEBPF)
+	}
+}
+
 type ProcConfig struct {
 	Process     string `config:"process"`
 	CmdlineGrep string `config:"cmdline_grep"`
@@ -48,6 +48,7 @@ type ProcessesWatcher struct {
 
 	enabled   bool         // enabled specifier whether the ProcessWatcher will be active.
 	monitored []ProcConfig // monitored is the set of processes that are monitored by the ProcessWatcher.
+	ebpf      bool         // ebpf is set when socket owners are tracked from eBPF events.
 
 	// watcher is the OS-dependent engine for the ProcessWatcher.
 	watcher processWatcher
@@ -123,6 +124,15 @@ func (proc *ProcessesWatcher) init(config ProcsConfig, watcher processWatcher) e
 
 	proc.monitored = config.Monitored
 
+	if proc.enabled && config.Mode == ModeEBPF {
+		if err := proc.startEBPF(); err != nil {
+			logp.Warn("Process watcher could not start eBPF socket tracking, falling back to scanning /proc: %v", err)
+		} else {
+			proc.ebpf = true
+			logp.Info("Process watcher tracking sockets with eBPF")
+		}
+	}
+
 	return nil
 }
 
@@ -191,14 +201,14 @@ func (proc *ProcessesWatcher) findProc(address net.IP, port uint16, transport ap
 		return nil
 	}
 
-	p, exists := lookupMapping(address, port, procMap)
+	p, exists := proc.lookupMapping(address, port, procMap)
 	if exists {
 		return p.proc
 	}
 
 	proc.updateMap(transport)
 
-	p, exists = lookupMapping(address, port, procMap)
+	p, exists = proc.lookupMapping(address, port, procMap)
 	if exists {
 		return p.proc
 	}
@@ -206,6 +216,14 @@ func (proc *ProcessesWatcher) findProc(address net.IP, port uint16, transport ap
 	return nil
 }
 
+// lookupMapping holds proc.mu while looking up procMap, which is updated
+// concurrently when sockets are tracked with eBPF.
+func (proc *ProcessesWatcher) lookupMapping(address net.IP, port uint16, procMap map[endpoint]portProcMapping) (p portProcMapping, found bool) {
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	return lookupMapping(address, port, procMap)
+}
+
 func lookupMapping(address net.IP, port uint16, procMap map[endpoint]portProcMapping) (p portProcMapping, found bool) {
 	// Precedence when one socket is bound to a specific IP:port and another one
 	// to INADDR_ANY and same port is not clear. Seems that the last one to bind
@@ -237,7 +255,9 @@ func (proc *ProcessesWatcher) updateMap(transport applayer.Transport) {
 		logp.Err("unable to list local ports: %v", err)
 	}
 
+	proc.mu.Lock()
 	proc.expireProcessCache()
+	proc.mu.Unlock()
 
 	for e, pid := range endpoints {
 		proc.updateMappingEntry(transport, e, pid)
@@ -267,6 +287,12 @@ func (proc *ProcessesWatcher) updateMappingEntry(transport applayer.Transport, e
 		return
 	}
 
+	proc.storeMappingLocked(transport, e, pid, p)
+}
+
+// storeMappingLocked associates the endpoint with the process. proc.mu must
+// be held.
+func (proc *ProcessesWatcher) storeMappingLocked(transport applayer.Transport, e endpoint, pid int, p *process) {
 	// Simply overwrite old entries for now.
 	// We never expire entries from this map. Since there are 65k possible
 	// ports, the size of the dict can be max 1.5 MB, which we consider
@@ -295,7 +321,12 @@ func (proc *ProcessesWatcher) getProcessInfo(pid int) *process {
 	if p == nil {
 		return nil
 	}
+	proc.cacheProcess(pid, p)
+	return p
+}
 
+// cacheProcess adds the process with the given PID to proc.processCache.
+func (proc *ProcessesWatcher) cacheProcess(pid int, p *process) {
 	// The packetbeat.procs.monitored*.cmdline_grep allows you to overwrite
 	// the process name with an alias.
 	for _, match := range proc.monitored {
@@ -305,7 +336,6 @@ func (proc *ProcessesWatcher) getProcessInfo(pid int) *process {
 		}
 	}
 	proc.processCache[pid] = p
-	return p
 }
 
 // GetProcess returns the process metadata.
@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package procs
+
+import (
+	"fmt"
+	"net/netip"
+	"path/filepath"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/ebpf"
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+	"github.com/elastic/ebpfevents"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/go-sysinfo"
+)
+
+const ebpfClientName = "packetbeat-procs"
+
+// ebpfEventMask selects the events needed to attribute sockets. Execs are
+// tracked so short-lived processes keep their executable and arguments once
+// they are gone from /proc.
+const ebpfEventMask = ebpf.EventMask(ebpfevents.EventTypeProcessExec |
+	ebpfevents.EventTypeNetworkConnectionAccepted |
+	ebpfevents.EventTypeNetworkConnectionAttempted)
+
+// startEBPF subscribes to the eBPF watcher and maps the local endpoint of
+// every accepted or attempted TCP connection to the process owning it. UDP
+// sockets and connections opened before the subscription are still resolved
+// by scanning /proc.
+func (proc *ProcessesWatcher) startEBPF() error {
+	w, err := ebpf.GetWatcher()
+	if err != nil {
+		return err
+	}
+
+	host, err := sysinfo.Host()
+	if err != nil {
+		return fmt.Errorf("failed to get the boot time: %w", err)
+	}
+
+	records := w.Subscribe(ebpfClientName, ebpfEventMask)
+	go proc.ebpfLoop(records, host.Info().BootTime)
+	return nil
+}
+
+func (proc *ProcessesWatcher) ebpfLoop(records <-chan ebpfevents.Record, bootTime time.Time) {
+	expire := time.NewTicker(processCacheExpiration)
+	defer expire.Stop()
+
+	for {
+		select {
+		case <-expire.C:
+			proc.mu.Lock()
+			proc.expireProcessCache()
+			proc.mu.Unlock()
+
+		case r, ok := <-records:
+			if !ok {
+				return
+			}
+			if r.Error != nil {
+				logp.Debug("procs", "Error received from the eBPF watcher: %v", r.Error)
+				continue
+			}
+			if r.Event == nil {
+				continue
+			}
+
+			switch body := r.Event.Body.(type) {
+			case *ebpfevents.ProcessExec:
+				proc.handleExec(body, bootTime)
+			case *ebpfevents.NetConnAccepted:
+				proc.handleConnection(body.Pids, body.Net, body.Comm, bootTime)
+			case *ebpfevents.NetConnAttempted:
+				proc.handleConnection(body.Pids, body.Net, body.Comm, bootTime)
+			}
+		}
+	}
+}
+
+func (proc *ProcessesWatcher) handleExec(body *ebpfevents.ProcessExec, bootTime time.Time) {
+	pid := int(body.Pids.Tgid)
+	p := &process{
+		pid:       pid,
+		ppid:      int(body.Pids.Ppid),
+		name:      filepath.Base(body.Filename),
+		exe:       body.Filename,
+		cwd:       body.Cwd,
+		args:      body.Argv,
+		startTime: bootTime.Add(time.Duration(body.Pids.StartTimeNs)),
+		expires:   time.Now().Add(processCacheExpiration),
+	}
+
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	proc.cacheProcess(pid, p)
+}
+
+func (proc *ProcessesWatcher) handleConnection(pids ebpfevents.PidInfo, info ebpfevents.NetInfo, comm string, bootTime time.Time) {
+	// The source of the connection events is always the local socket.
+	if info.SourcePort == 0 {
+		return
+	}
+	e := endpoint{address: addrString(info.SourceAddress), port: info.SourcePort}
+	pid := int(pids.Tgid)
+
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+
+	if prev, ok := proc.portProcMap[applayer.TransportTCP][e]; ok && prev.pid == pid {
+		return
+	}
+
+	p := proc.getProcessInfo(pid)
+	if p == nil {
+		// The process is already gone and its exec wasn't seen, keep what the
+		// event tells about it.
+		p = &process{
+			pid:       pid,
+			ppid:      int(pids.Ppid),
+			name:      comm,
+			startTime: bootTime.Add(time.Duration(pids.StartTimeNs)),
+			expires:   time.Now().Add(processCacheExpiration),
+		}
+		proc.cacheProcess(pid, p)
+	}
+	proc.storeMappingLocked(applayer.TransportTCP, e, pid, p)
+}
+
+// addrString formats the address like the net.IP addresses looked up during
+// enrichment, which never are IPv4-mapped IPv6 addresses.
+func addrString(addr netip.Addr) string {
+	return addr.Unmap().String()
+}
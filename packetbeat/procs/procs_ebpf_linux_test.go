@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package procs
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+	"github.com/elastic/ebpfevents"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestEBPFShortLivedProcess(t *testing.T) {
+	logp.TestingSetup()
+
+	// The watcher knows nothing about the process, as if it was already gone
+	// from /proc when the packets are enriched.
+	var procs ProcessesWatcher
+	err := procs.init(ProcsConfig{Enabled: true}, newMockWatcher([]net.IP{net.ParseIP("10.1.1.1")}, nil))
+	require.NoError(t, err)
+
+	bootTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pids := ebpfevents.PidInfo{Tgid: 4242, Ppid: 1, StartTimeNs: uint64(time.Second)}
+
+	procs.handleExec(&ebpfevents.ProcessExec{
+		Pids:     pids,
+		Filename: "/usr/bin/curl",
+		Argv:     []string{"curl", "http://10.1.1.2/"},
+		Cwd:      "/tmp",
+	}, bootTime)
+	procs.handleConnection(pids, ebpfevents.NetInfo{
+		SourceAddress: netip.MustParseAddr("::ffff:10.1.1.1"),
+		SourcePort:    40000,
+	}, "curl", bootTime)
+
+	p := procs.findProc(net.ParseIP("10.1.1.1"), 40000, applayer.TransportTCP)
+	require.NotNil(t, p)
+	assert.Equal(t, 4242, p.pid)
+	assert.Equal(t, 1, p.ppid)
+	assert.Equal(t, "curl", p.name)
+	assert.Equal(t, "/usr/bin/curl", p.exe)
+	assert.Equal(t, []string{"curl", "http://10.1.1.2/"}, p.args)
+	assert.Equal(t, bootTime.Add(time.Second), p.startTime)
+
+	// Without a prior exec the connection event is enough to attribute the socket.
+	procs.handleConnection(ebpfevents.PidInfo{Tgid: 4343, Ppid: 1}, ebpfevents.NetInfo{
+		SourceAddress: netip.MustParseAddr("10.1.1.1"),
+		SourcePort:    40001,
+	}, "wget", bootTime)
+
+	p = procs.findProc(net.ParseIP("10.1.1.1"), 40001, applayer.TransportTCP)
+	require.NotNil(t, p)
+	assert.Equal(t, 4343, p.pid)
+	assert.Equal(t, "wget", p.name)
+}
@@ -20,8 +20,11 @@ package protos
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -50,6 +53,10 @@ type Packet struct {
 
 var ErrInvalidPort = errors.New("port number out of range")
 
+// ErrNotEnabled is returned when changing the ports of a protocol that is not
+// enabled.
+var ErrNotEnabled = errors.New("protocol is not enabled")
+
 // Protocol Plugin Port configuration with validation on init
 type PortsConfig struct {
 	Ports []int
@@ -83,6 +90,11 @@ type Protocols interface {
 	GetAllTCP() map[Protocol]TCPPlugin
 	GetAllUDP() map[Protocol]UDPPlugin
 
+	// PortsVersion is incremented each time the ports of a protocol are
+	// changed at runtime. Port maps built from GetAllTCP or GetAllUDP must
+	// be rebuilt if it changes.
+	PortsVersion() uint64
+
 	// Register(proto Protocol, plugin ProtocolPlugin)
 }
 
@@ -91,13 +103,23 @@ type ProtocolsStruct struct {
 	all map[Protocol]protocolInstance
 	tcp map[Protocol]TCPPlugin
 	udp map[Protocol]UDPPlugin
+
+	// ports holds the ports of protocols changed at runtime with SetPorts.
+	ports *portOverrides
+}
+
+type portOverrides struct {
+	mu      sync.RWMutex
+	ports   map[Protocol][]int
+	version atomic.Uint64
 }
 
 func NewProtocols() *ProtocolsStruct {
 	return &ProtocolsStruct{
-		all: map[Protocol]protocolInstance{},
-		tcp: map[Protocol]TCPPlugin{},
-		udp: map[Protocol]UDPPlugin{},
+		all:   map[Protocol]protocolInstance{},
+		tcp:   map[Protocol]TCPPlugin{},
+		udp:   map[Protocol]UDPPlugin{},
+		ports: &portOverrides{ports: map[Protocol][]int{}},
 	}
 }
 
@@ -258,12 +280,144 @@ func (s ProtocolsStruct) GetUDP(proto Protocol) UDPPlugin {
 	return plugin
 }
 
+// GetAllTCP returns all TCP plugins. Plugins whose ports were changed with
+// SetPorts report the changed ports.
 func (s ProtocolsStruct) GetAllTCP() map[Protocol]TCPPlugin {
-	return s.tcp
+	overrides := s.overriddenPorts()
+	if len(overrides) == 0 {
+		return s.tcp
+	}
+
+	plugins := make(map[Protocol]TCPPlugin, len(s.tcp))
+	for proto, plugin := range s.tcp {
+		if ports, exists := overrides[proto]; exists {
+			plugin = tcpPortsOverride{TCPPlugin: plugin, ports: ports}
+		}
+		plugins[proto] = plugin
+	}
+	return plugins
 }
 
+// GetAllUDP returns all UDP plugins. Plugins whose ports were changed with
+// SetPorts report the changed ports.
 func (s ProtocolsStruct) GetAllUDP() map[Protocol]UDPPlugin {
-	return s.udp
+	overrides := s.overriddenPorts()
+	if len(overrides) == 0 {
+		return s.udp
+	}
+
+	plugins := make(map[Protocol]UDPPlugin, len(s.udp))
+	for proto, plugin := range s.udp {
+		if ports, exists := overrides[proto]; exists {
+			plugin = udpPortsOverride{UDPPlugin: plugin, ports: ports}
+		}
+		plugins[proto] = plugin
+	}
+	return plugins
+}
+
+type tcpPortsOverride struct {
+	TCPPlugin
+	ports []int
+}
+
+func (p tcpPortsOverride) GetPorts() []int { return p.ports }
+
+type udpPortsOverride struct {
+	UDPPlugin
+	ports []int
+}
+
+func (p udpPortsOverride) GetPorts() []int { return p.ports }
+
+// GetPorts returns the ports the protocol is analyzed on. Ports changed with
+// SetPorts take precedence over the configured ports.
+func (s ProtocolsStruct) GetPorts(proto Protocol) []int {
+	if s.ports != nil {
+		s.ports.mu.RLock()
+		defer s.ports.mu.RUnlock()
+	}
+	return s.portsLocked(proto)
+}
+
+func (s ProtocolsStruct) portsLocked(proto Protocol) []int {
+	if s.ports != nil {
+		if ports, exists := s.ports.ports[proto]; exists {
+			return ports
+		}
+	}
+	inst, exists := s.all[proto]
+	if !exists {
+		return nil
+	}
+	return inst.plugin.GetPorts()
+}
+
+func (s ProtocolsStruct) overriddenPorts() map[Protocol][]int {
+	if s.ports == nil {
+		return nil
+	}
+	s.ports.mu.RLock()
+	defer s.ports.mu.RUnlock()
+	if len(s.ports.ports) == 0 {
+		return nil
+	}
+	overrides := make(map[Protocol][]int, len(s.ports.ports))
+	for proto, ports := range s.ports.ports {
+		overrides[proto] = ports
+	}
+	return overrides
+}
+
+// PortsVersion returns a counter that is incremented each time SetPorts
+// changes the ports of a protocol.
+func (s ProtocolsStruct) PortsVersion() uint64 {
+	if s.ports == nil {
+		return 0
+	}
+	return s.ports.version.Load()
+}
+
+// SetPorts changes the ports the protocol is analyzed on without restarting
+// the analyzer. It fails if the protocol is not enabled or if a port is
+// already used by another protocol on the same transport.
+func (s ProtocolsStruct) SetPorts(proto Protocol, ports []int) error {
+	if s.ports == nil {
+		return errors.New("changing protocol ports is not supported")
+	}
+	if _, exists := s.all[proto]; !exists {
+		return fmt.Errorf("%w: %s", ErrNotEnabled, proto)
+	}
+	for _, port := range ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("%w: %d", ErrInvalidPort, port)
+		}
+	}
+
+	s.ports.mu.Lock()
+	defer s.ports.mu.Unlock()
+
+	_, isTCP := s.tcp[proto]
+	_, isUDP := s.udp[proto]
+	for other := range s.all {
+		if other == proto {
+			continue
+		}
+		_, otherTCP := s.tcp[other]
+		_, otherUDP := s.udp[other]
+		if !(isTCP && otherTCP) && !(isUDP && otherUDP) {
+			continue
+		}
+		for _, port := range s.portsLocked(other) {
+			if slices.Contains(ports, port) {
+				return fmt.Errorf("port %d is already used by protocol '%s'", port, other)
+			}
+		}
+	}
+
+	s.ports.ports[proto] = slices.Clone(ports)
+	s.ports.version.Add(1)
+	return nil
 }
 
 // BpfFilter returns a Berkeley Packer Filter (BFP) expression that
@@ -281,8 +435,7 @@ func (s ProtocolsStruct) BpfFilter(withVlans bool, withICMP bool) string {
 	var expressions []string
 	for _, key := range protos {
 		proto := Protocol(key)
-		plugin := s.all[proto].plugin
-		for _, port := range plugin.GetPorts() {
+		for _, port := range s.GetPorts(proto) {
 			hasTCP := false
 			hasUDP := false
 
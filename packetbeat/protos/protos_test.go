@@ -107,11 +107,12 @@ func TestProtocolNames(t *testing.T) {
 	assert.Equal(t, "impossible", Protocol(100).String())
 }
 
-func newProtocols() Protocols {
+func newProtocols() ProtocolsStruct {
 	p := ProtocolsStruct{}
 	p.all = make(map[Protocol]protocolInstance)
 	p.tcp = make(map[Protocol]TCPPlugin)
 	p.udp = make(map[Protocol]UDPPlugin)
+	p.ports = &portOverrides{ports: make(map[Protocol][]int)}
 
 	tcp := &TCPProtocol{Ports: []int{80}}
 	udp := &UDPProtocol{Ports: []int{5060}}
@@ -175,6 +176,42 @@ func TestGetAllUDP(t *testing.T) {
 	assert.NotNil(t, udp[3])
 }
 
+func TestSetPorts(t *testing.T) {
+	p := newProtocols()
+	assert.Equal(t, uint64(0), p.PortsVersion())
+
+	err := p.SetPorts(1, []int{8080, 8081})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(1), p.PortsVersion())
+	assert.Equal(t, []int{8080, 8081}, p.GetPorts(1))
+	assert.Equal(t, []int{8080, 8081}, p.GetAllTCP()[1].GetPorts())
+	assert.Equal(t, []int{53}, p.GetAllTCP()[3].GetPorts())
+	assert.Equal(t, "tcp port 8080 or tcp port 8081 or udp port 5060 or port 53",
+		p.BpfFilter(false, false))
+}
+
+func TestSetPortsErrors(t *testing.T) {
+	p := newProtocols()
+
+	// Protocol not enabled.
+	assert.ErrorIs(t, p.SetPorts(4, []int{8080}), ErrNotEnabled)
+
+	// Port out of range.
+	assert.ErrorIs(t, p.SetPorts(1, []int{0}), ErrInvalidPort)
+	assert.ErrorIs(t, p.SetPorts(1, []int{65536}), ErrInvalidPort)
+
+	// Port used by another TCP protocol.
+	assert.Error(t, p.SetPorts(1, []int{53}))
+
+	// Different transports may share a port.
+	assert.NoError(t, p.SetPorts(1, []int{5060}))
+
+	assert.Equal(t, uint64(1), p.PortsVersion())
+	assert.Equal(t, []int{5060}, p.GetPorts(1))
+}
+
 func TestGetTCP(t *testing.T) {
 	p := newProtocols()
 	tcp := p.GetTCP(1)
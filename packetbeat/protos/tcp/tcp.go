@@ -53,6 +53,7 @@ type TCP struct {
 	id           uint32
 	streams      *common.Cache
 	portMap      map[uint16]protos.Protocol
+	portsVersion uint64
 	protocols    protos.Protocols
 	expiredConns expirationQueue
 
@@ -63,15 +64,17 @@ type TCP struct {
 func NewTCP(p protos.Protocols, id, device string, idx int) (*TCP, error) {
 	isDebug = logp.IsDebug("tcp")
 
+	portsVersion := p.PortsVersion()
 	portMap, err := buildPortsMap(p.GetAllTCP())
 	if err != nil {
 		return nil, err
 	}
 
 	tcp := &TCP{
-		protocols: p,
-		portMap:   portMap,
-		metrics:   newInputMetrics(fmt.Sprintf("%s_%d", id, idx), device, portMap),
+		protocols:    p,
+		portMap:      portMap,
+		portsVersion: portsVersion,
+		metrics:      newInputMetrics(fmt.Sprintf("%s_%d", id, idx), device, portMap),
 	}
 	tcp.streams = common.NewCacheWithRemovalListener(
 		protos.DefaultTransactionExpiration,
@@ -249,6 +252,10 @@ func (tcp *TCP) getID() uint32 {
 }
 
 func (tcp *TCP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
+	if version := tcp.protocols.PortsVersion(); version != tcp.portsVersion {
+		tcp.updatePortMap(version)
+	}
+
 	protocol, exists := tcp.portMap[tuple.SrcPort]
 	if exists {
 		return protocol
@@ -262,6 +269,19 @@ func (tcp *TCP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
 	return protos.UnknownProtocol
 }
 
+// updatePortMap rebuilds the port map after protocol ports were changed at
+// runtime.
+func (tcp *TCP) updatePortMap(version uint64) {
+	tcp.portsVersion = version
+	portMap, err := buildPortsMap(tcp.protocols.GetAllTCP())
+	if err != nil {
+		logp.Err("Failed to update TCP port map: %v", err)
+		return
+	}
+	tcp.portMap = portMap
+	logp.Debug("tcp", "Port map: %v", portMap)
+}
+
 func (tcp *TCP) findStream(k common.HashableIPPortTuple) *TCPConnection {
 	v := tcp.streams.Get(k)
 	if v != nil {
@@ -41,23 +41,26 @@ type Processor interface {
 }
 
 type UDP struct {
-	protocols protos.Protocols
-	portMap   map[uint16]protos.Protocol
+	protocols    protos.Protocols
+	portMap      map[uint16]protos.Protocol
+	portsVersion uint64
 
 	metrics *inputMetrics
 }
 
 // NewUDP creates and returns a new UDP.
 func NewUDP(p protos.Protocols, id, device string, idx int) (*UDP, error) {
+	portsVersion := p.PortsVersion()
 	portMap, err := buildPortsMap(p.GetAllUDP())
 	if err != nil {
 		return nil, err
 	}
 
 	udp := &UDP{
-		protocols: p,
-		portMap:   portMap,
-		metrics:   newInputMetrics(fmt.Sprintf("%s_%d", id, idx), device, portMap),
+		protocols:    p,
+		portMap:      portMap,
+		portsVersion: portsVersion,
+		metrics:      newInputMetrics(fmt.Sprintf("%s_%d", id, idx), device, portMap),
 	}
 	logp.Debug("udp", "Port map: %v", portMap)
 
@@ -116,6 +119,10 @@ func (udp *UDP) Process(id *flows.FlowID, pkt *protos.Packet) {
 // ports. If the protocol cannot be determined then protos.UnknownProtocol
 // is returned.
 func (udp *UDP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
+	if version := udp.protocols.PortsVersion(); version != udp.portsVersion {
+		udp.updatePortMap(version)
+	}
+
 	protocol, exists := udp.portMap[tuple.SrcPort]
 	if exists {
 		return protocol
@@ -129,6 +136,19 @@ func (udp *UDP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
 	return protos.UnknownProtocol
 }
 
+// updatePortMap rebuilds the port map after protocol ports were changed at
+// runtime.
+func (udp *UDP) updatePortMap(version uint64) {
+	udp.portsVersion = version
+	portMap, err := buildPortsMap(udp.protocols.GetAllUDP())
+	if err != nil {
+		logp.Err("Failed to update UDP port map: %v", err)
+		return
+	}
+	udp.portMap = portMap
+	logp.Debug("udp", "Port map: %v", portMap)
+}
+
 func (udp *UDP) Close() {
 	if udp.metrics == nil {
 		return
@@ -49,7 +49,8 @@ var (
 )
 
 type TestProtocols struct {
-	udp map[protos.Protocol]protos.UDPPlugin
+	udp          map[protos.Protocol]protos.UDPPlugin
+	portsVersion uint64
 }
 
 func (p TestProtocols) BpfFilter(withVlans bool, withICMP bool) string {
@@ -76,6 +77,10 @@ func (p TestProtocols) GetAllUDP() map[protos.Protocol]protos.UDPPlugin {
 	return p.udp
 }
 
+func (p TestProtocols) PortsVersion() uint64 {
+	return p.portsVersion
+}
+
 func (p TestProtocols) Register(proto protos.Protocol, plugin protos.Plugin) {}
 
 type TestProtocol struct {
@@ -234,6 +239,20 @@ func TestProcess_unknownProtocol(t *testing.T) {
 	assert.Equal(t, protos.UnknownProtocol, test.udp.decideProtocol(&tuple))
 }
 
+// Verify that decideProtocol picks up protocol ports changed at runtime.
+func Test_decideProtocol_portsChanged(t *testing.T) {
+	test := testSetup(t)
+	defer test.udp.metrics.close()
+	tuple := common.NewIPPortTuple(4,
+		net.ParseIP("10.0.0.1"), 34898,
+		net.ParseIP("192.168.0.1"), PORT+1)
+	assert.Equal(t, protos.UnknownProtocol, test.udp.decideProtocol(&tuple))
+
+	test.plugin.Ports = []int{PORT + 1}
+	test.protocols.portsVersion++
+	assert.Equal(t, PROTO, test.udp.decideProtocol(&tuple))
+}
+
 // Verify that Process ignores empty packets.
 func TestProcess_emptyPayload(t *testing.T) {
 	test := testSetup(t)
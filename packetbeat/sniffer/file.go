@@ -108,6 +108,11 @@ func (h *fileHandler) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
 	return data, ci, nil
 }
 
+// SetBPFFilter is a no-op since packet filters are not applied to pcap files.
+func (h *fileHandler) SetBPFFilter(_ string) error {
+	return nil
+}
+
 func (h *fileHandler) LinkType() layers.LinkType {
 	return h.pcapHandle.LinkType()
 }
@@ -20,11 +20,13 @@ package sniffer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/gopacket"
@@ -46,6 +48,10 @@ type Sniffer struct {
 	sniffers []sniffer
 	cancel   func()
 	log      *logp.Logger
+
+	// mu protects filters and serializes filter updates.
+	mu      sync.Mutex
+	filters []string
 }
 
 type sniffer struct {
@@ -62,8 +68,14 @@ type sniffer struct {
 	followDefault bool
 
 	// filter is the bpf filter program used by the sniffer.
+	// It is only accessed by the sniffing goroutine once Run
+	// has been called.
 	filter string
 
+	// filterUpdates passes filters set with SetFilter to the
+	// sniffing goroutine. It holds at most the latest filter.
+	filterUpdates chan string
+
 	// id and idx identify the sniffer for metric collection.
 	id  string
 	idx int
@@ -76,6 +88,7 @@ type sniffer struct {
 type snifferHandle interface {
 	gopacket.PacketDataSource
 	LinkType() layers.LinkType
+	SetBPFFilter(expr string) error
 	Close()
 }
 
@@ -94,6 +107,7 @@ func New(id string, testMode bool, _ string, decoders map[string]Decoders, inter
 	s := &Sniffer{
 		sniffers: make([]sniffer, len(interfaces)),
 		log:      logp.NewLogger("sniffer"),
+		filters:  make([]string, len(interfaces)),
 	}
 
 	for i, iface := range interfaces {
@@ -108,6 +122,7 @@ func New(id string, testMode bool, _ string, decoders map[string]Decoders, inter
 			id:            id,
 			idx:           i,
 			decoders:      dec,
+			filterUpdates: make(chan string, 1),
 			log:           s.log,
 		}
 
@@ -165,11 +180,70 @@ func New(id string, testMode bool, _ string, decoders map[string]Decoders, inter
 		child.config = iface
 		child.filter = iface.BpfFilter
 		s.sniffers[i] = child
+		s.filters[i] = iface.BpfFilter
 	}
 
 	return s, nil
 }
 
+// SetFilter replaces the BPF filter of the sniffers capturing on device. The
+// device is matched against the configured device name and the resolved
+// device name. Active capture handles are updated in place, so capture is not
+// interrupted. The filter is also used for devices opened later, e.g. after a
+// default route change.
+func (s *Sniffer) SetFilter(device, filter string) error {
+	if err := validatePcapFilter(filter); err != nil {
+		return fmt.Errorf("invalid BPF filter %q: %w", filter, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for i := range s.sniffers {
+		c := &s.sniffers[i]
+		if c.config.Device != device && c.device != device {
+			continue
+		}
+		if c.config.File != "" {
+			return errors.New("packet filters are not applied to pcap files")
+		}
+		found = true
+
+		// Replace any update the sniffer has not picked up yet. The
+		// channel is only written while holding s.mu, so the send
+		// never blocks.
+		select {
+		case <-c.filterUpdates:
+		default:
+		}
+		c.filterUpdates <- filter
+		s.filters[i] = filter
+	}
+	if !found {
+		return fmt.Errorf("no sniffer capturing on device %q", device)
+	}
+	return nil
+}
+
+// Filters returns the BPF filter of each sniffer keyed by its configured
+// device name.
+func (s *Sniffer) Filters() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filters := make(map[string]string, len(s.sniffers))
+	for i := range s.sniffers {
+		c := &s.sniffers[i]
+		device := c.config.Device
+		if device == "" {
+			device = c.device
+		}
+		filters[device] = s.filters[i]
+	}
+	return filters
+}
+
 func validateConfig(filter string, cfg *config.InterfaceConfig) error {
 	if cfg.File == "" {
 		if err := validatePcapFilter(filter); err != nil {
@@ -391,6 +465,8 @@ func (s *sniffer) sniffHandle(ctx context.Context, handle snifferHandle, dec *de
 			// termination and any error that caused that will already
 			// have been captured by the errgroup.
 			return nil
+		case filter := <-s.filterUpdates:
+			s.setFilter(handle, filter)
 		default:
 		}
 
@@ -462,6 +538,20 @@ func (s *sniffer) sniffHandle(ctx context.Context, handle snifferHandle, dec *de
 	return nil
 }
 
+// setFilter attaches filter to the open handle. The previous filter stays
+// attached if the new one can not be applied.
+func (s *sniffer) setFilter(handle snifferHandle, filter string) {
+	if filter == s.filter {
+		return
+	}
+	if err := handle.SetBPFFilter(filter); err != nil {
+		s.log.Errorf("failed to update BPF filter on %q: %v", s.config.Device, err)
+		return
+	}
+	s.log.Infof("updated BPF filter on %q: '%s'", s.config.Device, filter)
+	s.filter = filter
+}
+
 func (s *sniffer) open(device string) (snifferHandle, error) {
 	if s.config.File != "" {
 		return newFileHandler(s.config.File, s.config.TopSpeed, s.config.Loop)
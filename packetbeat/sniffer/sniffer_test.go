@@ -20,9 +20,16 @@
 package sniffer
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
 )
 
 func TestSniffer_afpacketComputeSize(t *testing.T) {
@@ -92,3 +99,65 @@ func Test_deviceNameFromIndex(t *testing.T) {
 	_, err = deviceNameFromIndex(3, devs)
 	assert.Error(t, err)
 }
+
+type filterHandle struct {
+	filter string
+	err    error
+}
+
+func (h *filterHandle) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	return nil, gopacket.CaptureInfo{}, nil
+}
+
+func (h *filterHandle) LinkType() layers.LinkType { return layers.LinkTypeEthernet }
+
+func (h *filterHandle) SetBPFFilter(expr string) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.filter = expr
+	return nil
+}
+
+func (h *filterHandle) Close() {}
+
+func TestSniffer_SetFilter(t *testing.T) {
+	s := &Sniffer{
+		sniffers: []sniffer{
+			{
+				config:        config.InterfaceConfig{Device: "default_route"},
+				device:        "eth0",
+				filter:        "tcp port 80",
+				filterUpdates: make(chan string, 1),
+				log:           logp.NewLogger("sniffer"),
+			},
+			{
+				config:        config.InterfaceConfig{File: "test.pcap"},
+				filterUpdates: make(chan string, 1),
+				log:           logp.NewLogger("sniffer"),
+			},
+		},
+		filters: []string{"tcp port 80", ""},
+		log:     logp.NewLogger("sniffer"),
+	}
+	c := &s.sniffers[0]
+
+	assert.Error(t, s.SetFilter("eth1", "tcp port 8080"))
+	assert.Error(t, s.SetFilter("eth0", "not a filter"))
+
+	// Only the latest pending update is kept.
+	assert.NoError(t, s.SetFilter("eth0", "tcp port 8080"))
+	assert.NoError(t, s.SetFilter("default_route", "tcp port 9090"))
+	assert.Len(t, c.filterUpdates, 1)
+	assert.Equal(t, "tcp port 9090", s.Filters()["default_route"])
+
+	handle := &filterHandle{}
+	c.setFilter(handle, <-c.filterUpdates)
+	assert.Equal(t, "tcp port 9090", handle.filter)
+	assert.Equal(t, "tcp port 9090", c.filter)
+
+	// A failing update keeps the previous filter.
+	handle.err = errors.New("failed")
+	c.setFilter(handle, "tcp port 7070")
+	assert.Equal(t, "tcp port 9090", c.filter)
+}
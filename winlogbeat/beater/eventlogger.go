@@ -38,8 +38,10 @@ import (
 
 type eventLogger struct {
 	source     eventlog.EventLog
+	parent     string // ID of the sharded event log the source is a shard of.
 	eventMeta  mapstr.EventMetadata
 	processors beat.ProcessorList
+	filter     *eventFilter
 	keepNull   bool
 	log        *logp.Logger
 }
@@ -49,6 +51,7 @@ type eventLoggerConfig struct {
 
 	Processors processors.PluginConfig  `config:"processors"`
 	Index      fmtstr.EventFormatString `config:"index"`
+	Filter     filterConfig             `config:"filter"`
 
 	// KeepNull determines whether published events will keep null values or omit them.
 	KeepNull bool `config:"keep_null"`
@@ -70,10 +73,16 @@ func newEventLogger(
 		return nil, err
 	}
 
+	filter, err := newEventFilter(config.Filter)
+	if err != nil {
+		return nil, err
+	}
+
 	return &eventLogger{
 		source:     source,
 		eventMeta:  config.EventMetadata,
 		processors: processors,
+		filter:     filter,
 		log:        log.With("id", source.Name()),
 	}, nil
 }
@@ -105,8 +114,10 @@ func (e *eventLogger) run(
 	// Initialize per event log metrics.
 	initMetrics(api.Name())
 
+	checkpoints := &filteredCheckpoint{persist: eventACKer.checkpoint.PersistState}
 	pipeline = pipetool.WithACKer(pipeline, acker.EventPrivateReporter(func(_ int, private []interface{}) {
 		eventACKer.ACKEvents(private)
+		checkpoints.ack(len(private))
 	}))
 
 	client, err := e.connect(pipeline)
@@ -209,9 +220,16 @@ runLoop:
 				continue
 			}
 
-			eventACKer.Add(len(records))
 			for _, lr := range records {
-				client.Publish(lr.ToEvent())
+				event := lr.ToEvent()
+				if !e.filter.keep(event) {
+					addFiltered(api.Name(), 1)
+					checkpoints.drop(lr.Offset)
+					continue
+				}
+				checkpoints.publish()
+				eventACKer.Add(1)
+				client.Publish(event)
 			}
 		}
 	}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+
+	"github.com/elastic/beats/v7/winlogbeat/checkpoint"
+)
+
+// filterConfig selects the events published by an event log with conditions
+// evaluated on the rendered events, including their event data. Events must
+// match include, if set, and must not match exclude.
+type filterConfig struct {
+	Include *conditions.Config `config:"include"`
+	Exclude *conditions.Config `config:"exclude"`
+}
+
+// eventFilter drops the events not selected by a filterConfig. A nil
+// eventFilter keeps all the events.
+type eventFilter struct {
+	include conditions.Condition
+	exclude conditions.Condition
+}
+
+func newEventFilter(config filterConfig) (*eventFilter, error) {
+	if config.Include == nil && config.Exclude == nil {
+		return nil, nil
+	}
+
+	var f eventFilter
+	var err error
+	if config.Include != nil {
+		if f.include, err = conditions.NewCondition(config.Include); err != nil {
+			return nil, fmt.Errorf("invalid filter.include condition: %w", err)
+		}
+	}
+	if config.Exclude != nil {
+		if f.exclude, err = conditions.NewCondition(config.Exclude); err != nil {
+			return nil, fmt.Errorf("invalid filter.exclude condition: %w", err)
+		}
+	}
+	return &f, nil
+}
+
+// keep returns whether the event must be published.
+func (f *eventFilter) keep(event beat.Event) bool {
+	if f == nil {
+		return true
+	}
+	if f.include != nil && !f.include.Check(event.Fields) {
+		return false
+	}
+	return f.exclude == nil || !f.exclude.Check(event.Fields)
+}
+
+// filteredCheckpoint persists the position of the records dropped by the
+// filter once the events published before them are acknowledged, so the
+// checkpoint of an event log advances when its last records are dropped.
+type filteredCheckpoint struct {
+	persist func(checkpoint.EventLogState)
+
+	mu        sync.Mutex
+	published int                       // Published events not acknowledged yet.
+	dropped   *checkpoint.EventLogState // Last record dropped after them.
+}
+
+// publish must be called before publishing an event.
+func (c *filteredCheckpoint) publish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.published++
+	// The acknowledgement of the event persists a later position.
+	c.dropped = nil
+}
+
+// drop must be called when a record is dropped by the filter.
+func (c *filteredCheckpoint) drop(state checkpoint.EventLogState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.published == 0 {
+		c.persist(state)
+		return
+	}
+	c.dropped = &state
+}
+
+// ack must be called when published events are acknowledged.
+func (c *filteredCheckpoint) ack(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.published -= n
+	if c.published <= 0 && c.dropped != nil {
+		c.persist(*c.dropped)
+		c.dropped = nil
+	}
+}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+
+	"github.com/elastic/beats/v7/winlogbeat/checkpoint"
+)
+
+func TestEventFilter(t *testing.T) {
+	config, err := eventLoggerConfigFromString(`
+filter:
+  include:
+    equals.winlog.event_id: 4688
+  exclude:
+    equals.winlog.event_data.NewProcessName: 'C:\Windows\System32\conhost.exe'
+`)
+	require.NoError(t, err)
+	filter, err := newEventFilter(config.Filter)
+	require.NoError(t, err)
+
+	event := func(id int, process string) beat.Event {
+		return beat.Event{Fields: mapstr.M{
+			"winlog": mapstr.M{
+				"event_id":   id,
+				"event_data": mapstr.M{"NewProcessName": process},
+			},
+		}}
+	}
+	assert.True(t, filter.keep(event(4688, `C:\Windows\System32\cmd.exe`)))
+	assert.False(t, filter.keep(event(4688, `C:\Windows\System32\conhost.exe`)))
+	assert.False(t, filter.keep(event(4624, `C:\Windows\System32\cmd.exe`)))
+}
+
+func TestEventFilterUnset(t *testing.T) {
+	filter, err := newEventFilter(filterConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+	assert.True(t, filter.keep(beat.Event{Fields: mapstr.M{}}))
+}
+
+func TestEventFilterInvalid(t *testing.T) {
+	config, err := eventLoggerConfigFromString("filter.exclude.range.winlog.event_id.above: 1")
+	require.NoError(t, err)
+	_, err = newEventFilter(config.Filter)
+	assert.ErrorContains(t, err, "filter.exclude")
+}
+
+func TestFilteredCheckpoint(t *testing.T) {
+	var persisted []uint64
+	c := &filteredCheckpoint{persist: func(st checkpoint.EventLogState) {
+		persisted = append(persisted, st.RecordNumber)
+	}}
+
+	// Records dropped with no event in flight are persisted right away.
+	c.drop(checkpoint.EventLogState{RecordNumber: 1})
+	assert.Equal(t, []uint64{1}, persisted)
+
+	// Records dropped after published events wait for their ACK.
+	c.publish()
+	c.publish()
+	c.drop(checkpoint.EventLogState{RecordNumber: 4})
+	c.drop(checkpoint.EventLogState{RecordNumber: 5})
+	c.ack(1)
+	assert.Equal(t, []uint64{1}, persisted)
+	c.ack(1)
+	assert.Equal(t, []uint64{1, 5}, persisted)
+
+	// Records dropped before a published event are covered by its ACK.
+	c.drop(checkpoint.EventLogState{RecordNumber: 6})
+	c.publish()
+	c.drop(checkpoint.EventLogState{RecordNumber: 8})
+	c.publish()
+	c.ack(2)
+	assert.Equal(t, []uint64{1, 5, 6}, persisted)
+}
@@ -23,11 +23,13 @@ import "expvar"
 // enable through configuration in order for the web service to be started.
 var (
 	publishedEvents = expvar.NewMap("published_events")
+	filteredEvents  = expvar.NewMap("filtered_events")
 )
 
 func initMetrics(namespace string) {
 	// Initialize metrics.
 	publishedEvents.Add(namespace, 0)
+	filteredEvents.Add(namespace, 0)
 }
 
 func addPublished(namespace string, n int) {
@@ -35,3 +37,10 @@ func addPublished(namespace string, n int) {
 	publishedEvents.Add("total", numEvents)
 	publishedEvents.Add(namespace, numEvents)
 }
+
+// addFiltered counts the events dropped by the filter of an event log.
+func addFiltered(namespace string, n int) {
+	numEvents := int64(n)
+	filteredEvents.Add("total", numEvents)
+	filteredEvents.Add(namespace, numEvents)
+}
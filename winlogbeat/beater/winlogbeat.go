@@ -92,18 +92,25 @@ func (eb *Winlogbeat) init(b *beat.Beat) error {
 		// configuration.
 		eb.eventLogs = make([]*eventLogger, 0, len(config.EventLogs))
 		for _, config := range config.EventLogs {
-			eventLog, err := eventlog.New(config)
+			shards, err := eventlog.Shards(config)
 			if err != nil {
 				return fmt.Errorf("failed to create new event log: %w", err)
 			}
-			eb.log.Debugf("initialized WinEventLog[%s]", eventLog.Name())
+			for _, shard := range shards {
+				eventLog, err := eventlog.New(shard.Config)
+				if err != nil {
+					return fmt.Errorf("failed to create new event log: %w", err)
+				}
+				eb.log.Debugf("initialized WinEventLog[%s]", eventLog.Name())
 
-			logger, err := newEventLogger(b.Info, eventLog, config, eb.log)
-			if err != nil {
-				return fmt.Errorf("failed to create new event log: %w", err)
-			}
+				logger, err := newEventLogger(b.Info, eventLog, shard.Config, eb.log)
+				if err != nil {
+					return fmt.Errorf("failed to create new event log: %w", err)
+				}
+				logger.parent = shard.Parent
 
-			eb.eventLogs = append(eb.eventLogs, logger)
+				eb.eventLogs = append(eb.eventLogs, logger)
+			}
 		}
 	}
 	b.OverwritePipelinesCallback = func(esConfig *conf.C) error {
@@ -178,7 +185,12 @@ func (eb *Winlogbeat) Run(b *beat.Beat) error {
 
 	var wg sync.WaitGroup
 	for _, log := range eb.eventLogs {
-		state := persistedState[log.source.Name()]
+		state, found := persistedState[log.source.Name()]
+		if !found && log.parent != "" {
+			// A new shard resumes from the position of the event log
+			// it is part of, instead of reading the whole channel again.
+			state = persistedState[log.parent]
+		}
 
 		// Start a goroutine for each event log.
 		wg.Add(1)
@@ -0,0 +1,170 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eventlog
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// Sharding methods of an event log.
+const (
+	shardByRecordNumber = "record_number"
+	shardByProvider     = "provider"
+)
+
+// shardsConfig splits the reading of a channel across parallel readers.
+type shardsConfig struct {
+	By        string     `config:"by" validate:"required"`
+	Count     int        `config:"count"`     // Number of shards when sharding by record number.
+	Providers [][]string `config:"providers"` // Providers of each shard when sharding by provider.
+}
+
+// shardConfig identifies the shard of the channel read by a reader. It is set
+// by Shards on the configuration of each reader.
+type shardConfig struct {
+	By    string `config:"by"`
+	Index int    `config:"index"`
+	Count int    `config:"count"`
+}
+
+// String returns the shard as index/count, or an empty string for readers
+// that are not sharded.
+func (s shardConfig) String() string {
+	if s.Count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s %d/%d", s.By, s.Index, s.Count)
+}
+
+// Shard is the configuration of one of the readers of an event log.
+type Shard struct {
+	Config *conf.C
+
+	// Parent is the ID of the sharded event log. A shard without a state
+	// of its own resumes from the state of its parent. It is empty for
+	// event logs that are not sharded.
+	Parent string
+}
+
+// Shards returns the configurations of the readers of an event log. An event
+// log with a shards option is read by one reader per shard, each with its
+// own ID, query and state. Other event logs are read by a single reader.
+func Shards(options *conf.C) ([]Shard, error) {
+	var config struct {
+		ConfigCommon    `config:",inline"`
+		Provider        []string      `config:"provider"`
+		ExcludeProvider []string      `config:"exclude_provider"`
+		Shards          *shardsConfig `config:"shards"`
+	}
+	if err := options.Unpack(&config); err != nil {
+		return nil, fmt.Errorf("failed unpacking config. %w", err)
+	}
+	if config.Shards == nil {
+		return []Shard{{Config: options}}, nil
+	}
+
+	id := config.ID
+	if id == "" {
+		id = config.Name
+	}
+	switch {
+	case config.XMLQuery != "":
+		return nil, errors.New("shards cannot be used with 'xml_query'")
+	case config.Name == "":
+		return nil, errors.New("event log is missing a 'name'")
+	case filepath.IsAbs(config.Name):
+		return nil, errors.New("shards can only be used to read channels, not files")
+	}
+
+	// overrides are the settings of each shard, applied on top of the event
+	// log settings.
+	var overrides []map[string]interface{}
+	switch strings.ToLower(config.Shards.By) {
+	case shardByRecordNumber:
+		n := config.Shards.Count
+		if n < 2 || n&(n-1) != 0 {
+			return nil, fmt.Errorf("shards.count must be a power of two greater than 1, got %d", n)
+		}
+		for i := 0; i < n; i++ {
+			overrides = append(overrides, map[string]interface{}{
+				"shard": shardConfig{By: shardByRecordNumber, Index: i, Count: n},
+			})
+		}
+	case shardByProvider:
+		if len(config.Shards.Providers) == 0 {
+			return nil, errors.New("shards.providers must not be empty when sharding by provider")
+		}
+		if len(config.Provider) != 0 {
+			return nil, errors.New("shards by provider cannot be used with 'provider'")
+		}
+		n := len(config.Shards.Providers) + 1
+		seen := map[string]bool{}
+		for i, providers := range config.Shards.Providers {
+			if len(providers) == 0 {
+				return nil, fmt.Errorf("shards.providers[%d] is empty", i)
+			}
+			for _, p := range providers {
+				if seen[p] {
+					return nil, fmt.Errorf("provider '%s' is assigned to more than one shard", p)
+				}
+				seen[p] = true
+			}
+			overrides = append(overrides, map[string]interface{}{
+				"provider": providers,
+				"shard":    shardConfig{By: shardByProvider, Index: i, Count: n},
+			})
+		}
+		// The last shard reads the providers not assigned to another shard.
+		var others []string
+		others = append(others, config.ExcludeProvider...)
+		for _, providers := range config.Shards.Providers {
+			others = append(others, providers...)
+		}
+		overrides = append(overrides, map[string]interface{}{
+			"exclude_provider": others,
+			"shard":            shardConfig{By: shardByProvider, Index: n - 1, Count: n},
+		})
+	default:
+		return nil, fmt.Errorf("invalid shards.by '%s', must be %s or %s", config.Shards.By, shardByRecordNumber, shardByProvider)
+	}
+
+	shards := make([]Shard, 0, len(overrides))
+	for i, o := range overrides {
+		shard, err := conf.NewConfigFrom(options)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range []string{"shards", "shard", "id", "provider", "exclude_provider"} {
+			_, _ = shard.Remove(field, -1)
+		}
+		o["id"] = fmt.Sprintf("%s-shard-%d", id, i)
+		if _, ok := o["exclude_provider"]; !ok && len(config.ExcludeProvider) != 0 {
+			o["exclude_provider"] = config.ExcludeProvider
+		}
+		if err := shard.Merge(o); err != nil {
+			return nil, err
+		}
+		shards = append(shards, Shard{Config: shard, Parent: id})
+	}
+	return shards, nil
+}
@@ -0,0 +1,166 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package eventlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// shardSettings are the settings of a shard read by a reader.
+type shardSettings struct {
+	ID              string      `config:"id"`
+	Name            string      `config:"name"`
+	Provider        []string    `config:"provider"`
+	ExcludeProvider []string    `config:"exclude_provider"`
+	Shard           shardConfig `config:"shard"`
+}
+
+func unpackShards(t *testing.T, shards []Shard) []shardSettings {
+	t.Helper()
+	settings := make([]shardSettings, 0, len(shards))
+	for _, s := range shards {
+		var set shardSettings
+		require.NoError(t, s.Config.Unpack(&set))
+		settings = append(settings, set)
+	}
+	return settings
+}
+
+func TestShardsNotSharded(t *testing.T) {
+	options := conf.MustNewConfigFrom(map[string]interface{}{
+		"name": "Application",
+	})
+
+	shards, err := Shards(options)
+	require.NoError(t, err)
+	require.Len(t, shards, 1)
+	assert.Same(t, options, shards[0].Config)
+	assert.Empty(t, shards[0].Parent)
+}
+
+func TestShardsByRecordNumber(t *testing.T) {
+	shards, err := Shards(conf.MustNewConfigFrom(map[string]interface{}{
+		"name":             "ForwardedEvents",
+		"id":               "wef",
+		"exclude_provider": []string{"VSS"},
+		"shards": map[string]interface{}{
+			"by":    "record_number",
+			"count": 2,
+		},
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []shardSettings{
+		{ID: "wef-shard-0", Name: "ForwardedEvents", ExcludeProvider: []string{"VSS"}, Shard: shardConfig{By: "record_number", Index: 0, Count: 2}},
+		{ID: "wef-shard-1", Name: "ForwardedEvents", ExcludeProvider: []string{"VSS"}, Shard: shardConfig{By: "record_number", Index: 1, Count: 2}},
+	}, unpackShards(t, shards))
+	for _, s := range shards {
+		assert.Equal(t, "wef", s.Parent)
+	}
+}
+
+func TestShardsByProvider(t *testing.T) {
+	shards, err := Shards(conf.MustNewConfigFrom(map[string]interface{}{
+		"name":             "ForwardedEvents",
+		"exclude_provider": []string{"VSS"},
+		"shards": map[string]interface{}{
+			"by": "provider",
+			"providers": [][]string{
+				{"Microsoft-Windows-Security-Auditing"},
+				{"Microsoft-Windows-Sysmon", "Microsoft-Windows-PowerShell"},
+			},
+		},
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []shardSettings{
+		{
+			ID:              "ForwardedEvents-shard-0",
+			Name:            "ForwardedEvents",
+			Provider:        []string{"Microsoft-Windows-Security-Auditing"},
+			ExcludeProvider: []string{"VSS"},
+			Shard:           shardConfig{By: "provider", Index: 0, Count: 3},
+		},
+		{
+			ID:              "ForwardedEvents-shard-1",
+			Name:            "ForwardedEvents",
+			Provider:        []string{"Microsoft-Windows-Sysmon", "Microsoft-Windows-PowerShell"},
+			ExcludeProvider: []string{"VSS"},
+			Shard:           shardConfig{By: "provider", Index: 1, Count: 3},
+		},
+		{
+			ID:              "ForwardedEvents-shard-2",
+			Name:            "ForwardedEvents",
+			ExcludeProvider: []string{"VSS", "Microsoft-Windows-Security-Auditing", "Microsoft-Windows-Sysmon", "Microsoft-Windows-PowerShell"},
+			Shard:           shardConfig{By: "provider", Index: 2, Count: 3},
+		},
+	}, unpackShards(t, shards))
+}
+
+func TestShardsInvalid(t *testing.T) {
+	for name, options := range map[string]map[string]interface{}{
+		"xml_query": {
+			"id":        "custom",
+			"xml_query": "<QueryList/>",
+			"shards":    map[string]interface{}{"by": "record_number", "count": 2},
+		},
+		"file": {
+			"name":   filepath.Join(os.TempDir(), "forwarded.evtx"),
+			"shards": map[string]interface{}{"by": "record_number", "count": 2},
+		},
+		"count not a power of two": {
+			"name":   "ForwardedEvents",
+			"shards": map[string]interface{}{"by": "record_number", "count": 3},
+		},
+		"single shard": {
+			"name":   "ForwardedEvents",
+			"shards": map[string]interface{}{"by": "record_number", "count": 1},
+		},
+		"no providers": {
+			"name":   "ForwardedEvents",
+			"shards": map[string]interface{}{"by": "provider"},
+		},
+		"provider filter": {
+			"name":     "ForwardedEvents",
+			"provider": []string{"VSS"},
+			"shards":   map[string]interface{}{"by": "provider", "providers": [][]string{{"MsiInstaller"}}},
+		},
+		"duplicate provider": {
+			"name":   "ForwardedEvents",
+			"shards": map[string]interface{}{"by": "provider", "providers": [][]string{{"VSS"}, {"VSS"}}},
+		},
+		"unknown method": {
+			"name":   "ForwardedEvents",
+			"shards": map[string]interface{}{"by": "computer"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Shards(conf.MustNewConfigFrom(options))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -98,10 +98,50 @@ type winEventLogConfig struct {
 // query contains parameters used to customize the event log data that is
 // queried from the log.
 type query struct {
-	IgnoreOlder time.Duration `config:"ignore_older"` // Ignore records older than this period of time.
-	EventID     string        `config:"event_id"`     // White-list and black-list of events.
-	Level       string        `config:"level"`        // Severity level.
-	Provider    []string      `config:"provider"`     // Provider (source name).
+	IgnoreOlder     time.Duration   `config:"ignore_older"`     // Ignore records older than this period of time.
+	EventID         string          `config:"event_id"`         // White-list and black-list of events.
+	Level           string          `config:"level"`            // Severity level.
+	Provider        []string        `config:"provider"`         // Provider (source name).
+	ExcludeProvider []string        `config:"exclude_provider"` // Provider (source name) to exclude.
+	Keywords        []string        `config:"keywords"`         // Keywords, any of them must match.
+	ProviderLevels  []providerLevel `config:"provider_levels"`  // Severity levels of specific providers.
+	Shard           shardConfig     `config:"shard"`            // Shard read when the log is sharded.
+}
+
+// providerLevel restricts the severity levels of the records of a provider.
+type providerLevel struct {
+	Provider string `config:"provider" validate:"required"`
+	Level    string `config:"level" validate:"required"`
+}
+
+// build returns the XML query for the given log.
+func (q query) build(log string) (string, error) {
+	providerLevels := make([]win.ProviderLevel, 0, len(q.ProviderLevels))
+	for _, pl := range q.ProviderLevels {
+		providerLevels = append(providerLevels, win.ProviderLevel{Provider: pl.Provider, Level: pl.Level})
+	}
+
+	return win.Query{
+		Log:             log,
+		IgnoreOlder:     q.IgnoreOlder,
+		Level:           q.Level,
+		EventID:         q.EventID,
+		Provider:        q.Provider,
+		ExcludeProvider: q.ExcludeProvider,
+		Keywords:        q.Keywords,
+		ProviderLevels:  providerLevels,
+		Shard:           q.recordShard(),
+	}.Build()
+}
+
+// recordShard returns the shard of the records to query when the log is
+// sharded by record number. Logs sharded by provider use the provider
+// filters instead.
+func (q query) recordShard() win.RecordShard {
+	if q.Shard.By != shardByRecordNumber {
+		return win.RecordShard{}
+	}
+	return win.RecordShard{Index: q.Shard.Index, Count: q.Shard.Count}
 }
 
 // NoMoreEventsAction defines what action for the reader to take when
@@ -166,6 +206,14 @@ func (c *winEventLogConfig) Validate() error {
 			errs = append(errs, fmt.Errorf("xml_query cannot be used with 'event_id'"))
 		case len(c.SimpleQuery.Provider) != 0:
 			errs = append(errs, fmt.Errorf("xml_query cannot be used with 'provider'"))
+		case len(c.SimpleQuery.ExcludeProvider) != 0:
+			errs = append(errs, fmt.Errorf("xml_query cannot be used with 'exclude_provider'"))
+		case len(c.SimpleQuery.Keywords) != 0:
+			errs = append(errs, fmt.Errorf("xml_query cannot be used with 'keywords'"))
+		case len(c.SimpleQuery.ProviderLevels) != 0:
+			errs = append(errs, fmt.Errorf("xml_query cannot be used with 'provider_levels'"))
+		case c.SimpleQuery.Shard.Count != 0:
+			errs = append(errs, fmt.Errorf("xml_query cannot be used with 'shards'"))
 		}
 	} else if c.Name == "" {
 		errs = append(errs, fmt.Errorf("event log is missing a 'name'"))
@@ -226,13 +274,7 @@ func newWinEventLog(options *conf.C) (EventLog, error) {
 	if c.XMLQuery != "" {
 		xmlQuery = c.XMLQuery
 	} else {
-		xmlQuery, err = win.Query{
-			Log:         c.Name,
-			IgnoreOlder: c.SimpleQuery.IgnoreOlder,
-			Level:       c.SimpleQuery.Level,
-			EventID:     c.SimpleQuery.EventID,
-			Provider:    c.SimpleQuery.Provider,
-		}.Build()
+		xmlQuery, err = c.SimpleQuery.build(c.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -270,7 +312,7 @@ func newWinEventLog(options *conf.C) (EventLog, error) {
 		cache:        newMessageFilesCache(id, eventMetadataHandle, freeHandle),
 		winMetaCache: newWinMetaCache(metaTTL),
 		logPrefix:    fmt.Sprintf("WinEventLog[%s]", id),
-		metrics:      newInputMetrics(c.Name, id),
+		metrics:      newInputMetrics(c.Name, id, c.SimpleQuery.Shard),
 	}
 
 	// Forwarded events should be rendered using RenderEventXML. It is more
@@ -661,33 +703,38 @@ type inputMetrics struct {
 
 	lastBatch time.Time
 
-	name        *monitoring.String // name of the provider being read
-	events      *monitoring.Uint   // total number of events received
-	dropped     *monitoring.Uint   // total number of discarded events
-	errors      *monitoring.Uint   // total number of errors
-	batchSize   metrics.Sample     // histogram of the number of events in each non-zero batch
-	sourceLag   metrics.Sample     // histogram of the difference between timestamped event's creation and reading
-	batchPeriod metrics.Sample     // histogram of the elapsed time between non-zero batch reads
+	name          *monitoring.String // name of the provider being read
+	shard         *monitoring.String // shard of the channel being read, if sharded
+	events        *monitoring.Uint   // total number of events received
+	dropped       *monitoring.Uint   // total number of discarded events
+	errors        *monitoring.Uint   // total number of errors
+	lastSourceLag *monitoring.Int    // difference between the newest event's creation and reading in the last non-zero batch
+	batchSize     metrics.Sample     // histogram of the number of events in each non-zero batch
+	sourceLag     metrics.Sample     // histogram of the difference between timestamped event's creation and reading
+	batchPeriod   metrics.Sample     // histogram of the elapsed time between non-zero batch reads
 }
 
 // newInputMetrics returns an input metric for windows event logs. If id is empty
 // a nil inputMetric is returned.
-func newInputMetrics(name, id string) *inputMetrics {
+func newInputMetrics(name, id string, shard shardConfig) *inputMetrics {
 	if id == "" {
 		return nil
 	}
 	reg, unreg := inputmon.NewInputRegistry("winlog", id, nil)
 	out := &inputMetrics{
-		unregister:  unreg,
-		name:        monitoring.NewString(reg, "provider"),
-		events:      monitoring.NewUint(reg, "received_events_total"),
-		dropped:     monitoring.NewUint(reg, "discarded_events_total"),
-		errors:      monitoring.NewUint(reg, "errors_total"),
-		batchSize:   metrics.NewUniformSample(1024),
-		sourceLag:   metrics.NewUniformSample(1024),
-		batchPeriod: metrics.NewUniformSample(1024),
+		unregister:    unreg,
+		name:          monitoring.NewString(reg, "provider"),
+		shard:         monitoring.NewString(reg, "shard"),
+		events:        monitoring.NewUint(reg, "received_events_total"),
+		dropped:       monitoring.NewUint(reg, "discarded_events_total"),
+		errors:        monitoring.NewUint(reg, "errors_total"),
+		lastSourceLag: monitoring.NewInt(reg, "last_source_lag_time"),
+		batchSize:     metrics.NewUniformSample(1024),
+		sourceLag:     metrics.NewUniformSample(1024),
+		batchPeriod:   metrics.NewUniformSample(1024),
 	}
 	out.name.Set(name)
+	out.shard.Set(shard.String())
 	_ = adapter.NewGoMetrics(reg, "received_events_count", adapter.Accept).
 		Register("histogram", metrics.NewHistogram(out.batchSize))
 	_ = adapter.NewGoMetrics(reg, "source_lag_time", adapter.Accept).
@@ -715,9 +762,14 @@ func (m *inputMetrics) log(batch []Record) {
 
 	m.events.Add(uint64(len(batch)))
 	m.batchSize.Update(int64(len(batch)))
+	var newest time.Time
 	for _, r := range batch {
 		m.sourceLag.Update(now.Sub(r.TimeCreated.SystemTime).Nanoseconds())
+		if r.TimeCreated.SystemTime.After(newest) {
+			newest = r.TimeCreated.SystemTime
+		}
 	}
+	m.lastSourceLag.Set(now.Sub(newest).Nanoseconds())
 }
 
 // logError logs error metrics. Nil errors do not increment the error
@@ -101,13 +101,7 @@ func newWinEventLogExp(options *conf.C) (EventLog, error) {
 			queryLog = "file://" + path
 		}
 
-		xmlQuery, err = win.Query{
-			Log:         queryLog,
-			IgnoreOlder: c.SimpleQuery.IgnoreOlder,
-			Level:       c.SimpleQuery.Level,
-			EventID:     c.SimpleQuery.EventID,
-			Provider:    c.SimpleQuery.Provider,
-		}.Build()
+		xmlQuery, err = c.SimpleQuery.build(queryLog)
 		if err != nil {
 			return nil, err
 		}
@@ -129,7 +123,7 @@ func newWinEventLogExp(options *conf.C) (EventLog, error) {
 		maxRead:     c.BatchReadSize,
 		renderer:    renderer,
 		log:         log,
-		metrics:     newInputMetrics(c.Name, id),
+		metrics:     newInputMetrics(c.Name, id, c.SimpleQuery.Shard),
 	}
 
 	return l, nil
@@ -144,6 +144,39 @@ func TestWinEventLogConfig_Validate(t *testing.T) {
 			WantErr: true,
 			Desc:    "xml query: conflicting keys (xml query and provider)",
 		},
+		{
+			In: winEventLogConfig{
+				ConfigCommon: ConfigCommon{
+					ID:       "test",
+					XMLQuery: customXMLQuery,
+				},
+				SimpleQuery: query{ExcludeProvider: []string{providerName}},
+			},
+			WantErr: true,
+			Desc:    "xml query: conflicting keys (xml query and exclude_provider)",
+		},
+		{
+			In: winEventLogConfig{
+				ConfigCommon: ConfigCommon{
+					ID:       "test",
+					XMLQuery: customXMLQuery,
+				},
+				SimpleQuery: query{Keywords: []string{"audit_failure"}},
+			},
+			WantErr: true,
+			Desc:    "xml query: conflicting keys (xml query and keywords)",
+		},
+		{
+			In: winEventLogConfig{
+				ConfigCommon: ConfigCommon{
+					ID:       "test",
+					XMLQuery: customXMLQuery,
+				},
+				SimpleQuery: query{ProviderLevels: []providerLevel{{Provider: providerName, Level: "error"}}},
+			},
+			WantErr: true,
+			Desc:    "xml query: conflicting keys (xml query and provider_levels)",
+		},
 		{
 			In: winEventLogConfig{
 				ConfigCommon: ConfigCommon{},
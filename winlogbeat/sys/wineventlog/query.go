@@ -44,6 +44,20 @@ var (
 	incEventIDRegex      = regexp.MustCompile(`^\d+$`)
 	incEventIDRangeRegex = regexp.MustCompile(`^(\d+)\s*-\s*(\d+)$`)
 	excEventIDRegex      = regexp.MustCompile(`^-(\d+)$`)
+	excEventIDRangeRegex = regexp.MustCompile(`^-(\d+)\s*-\s*(\d+)$`)
+
+	// keywordMasks maps the standard keywords defined in winmeta.xml to their
+	// bit masks.
+	keywordMasks = map[string]uint64{
+		"response_time":    0x1000000000000,
+		"wdi_context":      0x2000000000000,
+		"wdi_diagnostic":   0x4000000000000,
+		"sqm":              0x8000000000000,
+		"audit_failure":    0x10000000000000,
+		"audit_success":    0x20000000000000,
+		"correlation_hint": 0x40000000000000,
+		"classic":          0x80000000000000,
+	}
 )
 
 // Query that identifies the source of the events and one or more selectors or
@@ -57,8 +71,9 @@ type Query struct {
 
 	// Whitelist and blacklist of event IDs. The value is a comma-separated
 	// list. The accepted values are single event IDs to include (e.g. 4634), a
-	// range of event IDs to include (e.g. 4400-4500), and single event IDs to
-	// exclude (e.g. -4410).
+	// range of event IDs to include (e.g. 4400-4500), single event IDs to
+	// exclude (e.g. -4410), and ranges of event IDs to exclude (e.g.
+	// -4420-4430).
 	EventID string
 
 	// Level or levels to include. The value is a comma-separated list of levels
@@ -68,6 +83,40 @@ type Query struct {
 
 	// Providers (sources) to include records from.
 	Provider []string
+
+	// Providers (sources) to exclude records from.
+	ExcludeProvider []string
+
+	// Keywords to include. Records matching any of them are included. The
+	// accepted values are the names of the standard keywords (e.g.
+	// audit_failure) and numeric keyword masks (e.g. 0x10000000000000).
+	Keywords []string
+
+	// Levels to include for specific providers. Records from these providers
+	// with other levels are excluded, records from other providers are not
+	// affected.
+	ProviderLevels []ProviderLevel
+
+	// Shard of the records to include, by record number. Unused when its
+	// Count is 0 or 1.
+	Shard RecordShard
+}
+
+// RecordShard selects one of Count shards of the records of a log. Records
+// are assigned to the shard of the low bits of their record number, so Count
+// must be a power of two.
+type RecordShard struct {
+	Index int // Index of the shard, from 0 to Count-1.
+	Count int // Number of shards.
+}
+
+// ProviderLevel restricts the levels of the records of a provider.
+type ProviderLevel struct {
+	// Provider (source) name.
+	Provider string
+
+	// Level or levels to include, using the same format as Query.Level.
+	Level string
 }
 
 // Build builds a query from the given parameters. The query is returned as a
@@ -84,6 +133,10 @@ func (q Query) Build() (string, error) {
 		qp.eventIDSelect,
 		qp.levelSelect,
 		qp.providerSelect,
+		qp.excludeProviderSuppress,
+		qp.keywordsSelect,
+		qp.providerLevelsSuppress,
+		qp.recordShardFilter,
 	}
 	for _, build := range builders {
 		if err := build(q); err != nil {
@@ -133,13 +186,18 @@ func (qp *queryParams) eventIDSelect(q Query) error {
 			excludes = append(excludes, fmt.Sprintf("EventID=%s", m[1]))
 		case incEventIDRangeRegex.MatchString(c):
 			m := incEventIDRangeRegex.FindStringSubmatch(c)
-			r1, _ := strconv.Atoi(m[1])
-			r2, _ := strconv.Atoi(m[2])
-			if r1 >= r2 {
-				return fmt.Errorf("event ID range '%s' is invalid", c)
+			r, err := eventIDRange(c, m[1], m[2])
+			if err != nil {
+				return err
 			}
-			includes = append(includes,
-				fmt.Sprintf("(EventID &gt;= %d and EventID &lt;= %d)", r1, r2))
+			includes = append(includes, r)
+		case excEventIDRangeRegex.MatchString(c):
+			m := excEventIDRangeRegex.FindStringSubmatch(c)
+			r, err := eventIDRange(c, m[1], m[2])
+			if err != nil {
+				return err
+			}
+			excludes = append(excludes, r)
 		default:
 			return fmt.Errorf("invalid event ID query component ('%s')", c)
 		}
@@ -159,6 +217,16 @@ func (qp *queryParams) eventIDSelect(q Query) error {
 	return nil
 }
 
+// eventIDRange returns a xpath selector for the event IDs between from and to.
+func eventIDRange(component, from, to string) (string, error) {
+	r1, _ := strconv.Atoi(from)
+	r2, _ := strconv.Atoi(to)
+	if r1 >= r2 {
+		return "", fmt.Errorf("event ID range '%s' is invalid", component)
+	}
+	return fmt.Sprintf("(EventID &gt;= %d and EventID &lt;= %d)", r1, r2), nil
+}
+
 // levelSelect returns a xpath selector for the event Level. The returned
 // selector will select events with levels less than or equal to the specified
 // level. Note that level 0 is used as a catch-all/unknown level.
@@ -175,34 +243,46 @@ func (qp *queryParams) levelSelect(q Query) error {
 		return nil
 	}
 
-	l := func(level int) string { return fmt.Sprintf("Level = %d", level) }
+	levels, err := parseLevels(q.Level)
+	if err != nil {
+		return err
+	}
+
+	levelSelect := make([]string, 0, len(levels))
+	for _, level := range levels {
+		levelSelect = append(levelSelect, fmt.Sprintf("Level = %d", level))
+	}
+
+	if len(levelSelect) > 0 {
+		qp.Select = append(qp.Select, "("+strings.Join(levelSelect, " or ")+")")
+	}
+
+	return nil
+}
 
-	var levelSelect []string
-	for _, expr := range strings.Split(q.Level, ",") {
+// parseLevels returns the level values of a comma-separated list of levels.
+func parseLevels(value string) ([]int, error) {
+	var levels []int
+	for _, expr := range strings.Split(value, ",") {
 		expr = strings.TrimSpace(expr)
 		switch strings.ToLower(expr) {
 		default:
-			return fmt.Errorf("invalid level ('%s') for query", q.Level)
+			return nil, fmt.Errorf("invalid level ('%s') for query", value)
 		case "verbose", "5":
-			levelSelect = append(levelSelect, l(5))
+			levels = append(levels, 5)
 		case "information", "info", "4":
-			levelSelect = append(levelSelect, l(0), l(4))
+			levels = append(levels, 0, 4)
 		case "warning", "warn", "3":
-			levelSelect = append(levelSelect, l(3))
+			levels = append(levels, 3)
 		case "error", "err", "2":
-			levelSelect = append(levelSelect, l(2))
+			levels = append(levels, 2)
 		case "critical", "crit", "1":
-			levelSelect = append(levelSelect, l(1))
+			levels = append(levels, 1)
 		case "0":
-			levelSelect = append(levelSelect, l(0))
+			levels = append(levels, 0)
 		}
 	}
-
-	if len(levelSelect) > 0 {
-		qp.Select = append(qp.Select, "("+strings.Join(levelSelect, " or ")+")")
-	}
-
-	return nil
+	return levels, nil
 }
 
 func (qp *queryParams) providerSelect(q Query) error {
@@ -220,6 +300,105 @@ func (qp *queryParams) providerSelect(q Query) error {
 	return nil
 }
 
+func (qp *queryParams) excludeProviderSuppress(q Query) error {
+	if len(q.ExcludeProvider) == 0 {
+		return nil
+	}
+
+	suppress := make([]string, 0, len(q.ExcludeProvider))
+	for _, p := range q.ExcludeProvider {
+		suppress = append(suppress, fmt.Sprintf("@Name='%s'", p))
+	}
+
+	qp.Suppress = append(qp.Suppress,
+		fmt.Sprintf("Provider[%s]", strings.Join(suppress, " or ")))
+	return nil
+}
+
+// keywordsSelect returns a xpath selector for records with any of the given
+// keywords. Keywords are matched by their bit masks, so the selector does not
+// depend on the localized keyword names.
+func (qp *queryParams) keywordsSelect(q Query) error {
+	if len(q.Keywords) == 0 {
+		return nil
+	}
+
+	var mask uint64
+	for _, k := range q.Keywords {
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(k)), " ", "_")
+		if m, found := keywordMasks[name]; found {
+			mask |= m
+			continue
+		}
+		m, err := strconv.ParseUint(name, 0, 64)
+		if err != nil || m == 0 {
+			return fmt.Errorf("invalid keyword ('%s') for query", k)
+		}
+		mask |= m
+	}
+
+	qp.Select = append(qp.Select, fmt.Sprintf("band(Keywords,%d)", mask))
+	return nil
+}
+
+// providerLevelsSuppress returns a xpath suppressor for each provider with
+// level filters. It suppresses the records of the provider with a level that
+// is not included.
+func (qp *queryParams) providerLevelsSuppress(q Query) error {
+	for _, pl := range q.ProviderLevels {
+		if pl.Provider == "" {
+			return fmt.Errorf("provider level filter is missing a provider")
+		}
+		if pl.Level == "" {
+			return fmt.Errorf("provider level filter for '%s' is missing a level", pl.Provider)
+		}
+
+		levels, err := parseLevels(pl.Level)
+		if err != nil {
+			return fmt.Errorf("provider level filter for '%s': %w", pl.Provider, err)
+		}
+
+		conditions := []string{fmt.Sprintf("Provider[@Name='%s']", pl.Provider)}
+		for _, level := range levels {
+			conditions = append(conditions, fmt.Sprintf("Level != %d", level))
+		}
+		qp.Suppress = append(qp.Suppress, "("+strings.Join(conditions, " and ")+")")
+	}
+	return nil
+}
+
+// recordShardFilter returns xpath selectors and suppressors for the records
+// of a shard. The XPath subset supported by the event log has no modulo, so
+// the low bits of the record number are tested one by one: bits set in the
+// shard index are selected, and records with any other bit set are
+// suppressed.
+func (qp *queryParams) recordShardFilter(q Query) error {
+	if q.Shard.Count <= 1 {
+		return nil
+	}
+	if q.Shard.Count&(q.Shard.Count-1) != 0 {
+		return fmt.Errorf("shard count %d is not a power of two", q.Shard.Count)
+	}
+	if q.Shard.Index < 0 || q.Shard.Index >= q.Shard.Count {
+		return fmt.Errorf("shard index %d is out of range for %d shards", q.Shard.Index, q.Shard.Count)
+	}
+
+	var suppress []string
+	for bit := 1; bit < q.Shard.Count; bit <<= 1 {
+		if q.Shard.Index&bit != 0 {
+			qp.Select = append(qp.Select, fmt.Sprintf("band(EventRecordID,%d)", bit))
+		} else {
+			suppress = append(suppress, fmt.Sprintf("band(EventRecordID,%d)", bit))
+		}
+	}
+	if len(suppress) == 1 {
+		qp.Suppress = append(qp.Suppress, suppress...)
+	} else if len(suppress) > 1 {
+		qp.Suppress = append(qp.Suppress, "("+strings.Join(suppress, " or ")+")")
+	}
+	return nil
+}
+
 // executeTemplate populates a template with the given data and returns the
 // value as a string.
 func executeTemplate(t *template.Template, data interface{}) (string, error) {
@@ -128,3 +128,128 @@ func TestQueryNoParams(t *testing.T) {
 		t.Log(q)
 	}
 }
+
+func TestEventIDExcludeRangeQuery(t *testing.T) {
+	const expected = `<QueryList>
+  <Query Id="0">
+    <Select Path="Security">*[System[(EventID &gt;= 4600 and EventID &lt;= 4800)]]</Select>
+    <Suppress Path="Security">*[System[(((EventID &gt;= 4660 and EventID &lt;= 4670) or EventID=4735))]]</Suppress>
+  </Query>
+</QueryList>`
+
+	q, err := Query{Log: "Security", EventID: "4600-4800, -4660-4670, -4735"}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, q)
+		t.Log(q)
+	}
+
+	_, err = Query{Log: "Security", EventID: "-4670-4660"}.Build()
+	assert.Error(t, err)
+}
+
+func TestExcludeProviderQuery(t *testing.T) {
+	const expected = `<QueryList>
+  <Query Id="0">
+    <Select Path="Application">*</Select>
+    <Suppress Path="Application">*[System[(Provider[@Name='ESENT' or @Name='Outlook'])]]</Suppress>
+  </Query>
+</QueryList>`
+
+	q, err := Query{Log: "Application", ExcludeProvider: []string{"ESENT", "Outlook"}}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, q)
+		t.Log(q)
+	}
+}
+
+func TestKeywordsQuery(t *testing.T) {
+	const expected = `<QueryList>
+  <Query Id="0">
+    <Select Path="Security">*[System[band(Keywords,%d)]]</Select>
+  </Query>
+</QueryList>`
+
+	q, err := Query{Log: "Security", Keywords: []string{"audit_failure", "Audit Success"}}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, fmt.Sprintf(expected, 0x30000000000000), q)
+		t.Log(q)
+	}
+
+	q, err = Query{Log: "Security", Keywords: []string{"0x80000000000000"}}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, fmt.Sprintf(expected, 0x80000000000000), q)
+	}
+
+	_, err = Query{Log: "Security", Keywords: []string{"audit"}}.Build()
+	assert.Error(t, err)
+}
+
+func TestProviderLevelsQuery(t *testing.T) {
+	const expected = `<QueryList>
+  <Query Id="0">
+    <Select Path="Application">*</Select>
+    <Suppress Path="Application">*[System[((Provider[@Name='MsiInstaller'] and Level != 1 and Level != 2) or (Provider[@Name='VSS'] and Level != 3))]]</Suppress>
+  </Query>
+</QueryList>`
+
+	q, err := Query{Log: "Application", ProviderLevels: []ProviderLevel{
+		{Provider: "MsiInstaller", Level: "critical, error"},
+		{Provider: "VSS", Level: "warning"},
+	}}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, q)
+		t.Log(q)
+	}
+
+	_, err = Query{Log: "Application", ProviderLevels: []ProviderLevel{{Provider: "VSS"}}}.Build()
+	assert.Error(t, err)
+
+	_, err = Query{Log: "Application", ProviderLevels: []ProviderLevel{{Provider: "VSS", Level: "loud"}}}.Build()
+	assert.Error(t, err)
+}
+
+func TestRecordShardQuery(t *testing.T) {
+	const expectedFirst = `<QueryList>
+  <Query Id="0">
+    <Select Path="ForwardedEvents">*</Select>
+    <Suppress Path="ForwardedEvents">*[System[((band(EventRecordID,1) or band(EventRecordID,2)))]]</Suppress>
+  </Query>
+</QueryList>`
+
+	q, err := Query{Log: "ForwardedEvents", Shard: RecordShard{Index: 0, Count: 4}}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, expectedFirst, q)
+		t.Log(q)
+	}
+
+	const expectedSecond = `<QueryList>
+  <Query Id="0">
+    <Select Path="ForwardedEvents">*[System[band(EventRecordID,1)]]</Select>
+    <Suppress Path="ForwardedEvents">*[System[(band(EventRecordID,2))]]</Suppress>
+  </Query>
+</QueryList>`
+
+	q, err = Query{Log: "ForwardedEvents", Shard: RecordShard{Index: 1, Count: 4}}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, expectedSecond, q)
+		t.Log(q)
+	}
+
+	const expectedLast = `<QueryList>
+  <Query Id="0">
+    <Select Path="ForwardedEvents">*[System[band(EventRecordID,1) and band(EventRecordID,2)]]</Select>
+  </Query>
+</QueryList>`
+
+	q, err = Query{Log: "ForwardedEvents", Shard: RecordShard{Index: 3, Count: 4}}.Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, expectedLast, q)
+		t.Log(q)
+	}
+
+	_, err = Query{Log: "ForwardedEvents", Shard: RecordShard{Index: 0, Count: 3}}.Build()
+	assert.Error(t, err)
+
+	_, err = Query{Log: "ForwardedEvents", Shard: RecordShard{Index: 4, Count: 4}}.Build()
+	assert.Error(t, err)
+}
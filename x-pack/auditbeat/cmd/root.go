@@ -18,12 +18,14 @@ import (
 	"github.com/elastic/beats/v7/libbeat/cmd"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/processors"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/include"
 	"github.com/elastic/beats/v7/x-pack/libbeat/management"
 
 	// Register base auditbeat includes.
 	_ "github.com/elastic/beats/v7/auditbeat/include"
 
+	// Register x-pack auditbeat modules.
+	_ "github.com/elastic/beats/v7/x-pack/auditbeat/include"
+
 	// Register libbeat x-pack modules.
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/include"
 )
@@ -66,7 +68,6 @@ func init() {
 	}
 	settings := auditbeatcmd.AuditbeatSettings(globalProcs)
 	settings.ElasticLicensed = true
-	settings.Initialize = append(settings.Initialize, include.InitializeModule)
 	RootCmd = auditbeatcmd.Initialize(settings)
 	RootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
 		management.ConfigTransform.SetTransform(auditbeatCfg)
@@ -7,9 +7,6 @@
 package include
 
 import (
-	// Import packages to perform 'func InitializeModule()' when in-use.
-	m0 "github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd"
-
 	// Import packages that perform 'func init()'.
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/host"
@@ -19,8 +16,3 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/socket"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/user"
 )
-
-// InitializeModules initialize all of the modules.
-func InitializeModule() {
-	m0.InitializeModule()
-}
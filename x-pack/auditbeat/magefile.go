@@ -78,9 +78,7 @@ func AssembleDarwinUniversal() error {
 // for the packages that match the paths (or globs) in importDirs (optional)
 // and moduleDirs (optional).
 func GenerateModuleIncludeListGo() error {
-	opts := devtools.DefaultIncludeListOptions()
-	opts.ImportDirs = []string{"processors/*"}
-	return devtools.GenerateIncludeListGo(opts)
+	return devtools.GenerateIncludeListGo(devtools.DefaultIncludeListOptions())
 }
 
 // Package packages the Beat for distribution.
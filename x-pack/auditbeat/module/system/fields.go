@@ -19,5 +19,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/system.
 func AssetSystem() string {
-	return "eJy8Wm1v27oV/u5fcRAMaILrKotvExT+MMBt7pZg7W0wp0C3uzubEo8lLhSpkVQSFfvxA6kXSzZlW4k7A0VjmXyec57z4kPJb+EBiynoQhtMRwCGGY5TOJm7CycjAIo6UiwzTIop/GkEAHCfoEYgCsEkCCuGnGqIUaAiBimEhbteYkIqac4xGAEo5Eg0TiFEQ0ZQbZyORgBvQZAUp4CPKIzjMEWGU4iVzDP3vl5s/65XS8ViJtylesMDFk9S0eqax3b7+uL2gVw5Ox1nAPcJ0xARASECgRXjCBkxCZxiEAewPH8k6pzL2P4LLpZn4wZNKgdjTaohK9cjmWZSoDBgEmJA51nGGVK3hBJDamyBhjPxsDwL2lrkGtXBUqAwzBQLRoercXsNuWD/yZEXwKgFWhVMxM5KawNIAQQSqU0AtwasSjLNchtpooHA/Gb2dnJ5BQnRyVqUUgi7C26vxyWQ/YMIWr6xdgcdHwyqlAnCh7twX+2saS1BR8tMyQi1PlhOkygkNIhIRkLGmWGoA1ytMDLsEStajo/Ip4DPBgXFXcKzWEiFCxLKR5zCxR8n73zuuARkukwgNNaXNr91qqmtB1QCORgJGaqVVKn9P2VaMykaVaIEowcNqypBK5+qj/GZpJkt9Te/nXyc3S0+3P35ZAzuz/nf54vZ9efbX09+f1OtzogxqMQU/nVqV/w2e/uPxe8//fef9KezPzS+rEjOzcLJOYUV4Rr3auqsNqZR70dpSoCzlBmb1jrPUFl9a12auHbltiXbSLnWD1JSANE6r7P3/yVlR8tWrW2mc2+R3BCdoG663jNGuSEhR9v6bEYV2rV0wmOpmElSR6VdwdoNj4Tn6JZ0VEnwGVBEkiIFymLUploZjKp17fpaexBy8oCTcDG5vFrjeeK84c6HT7O//jIJm4bjcWfUw/Tz+3cvYfr5/buhTJcXk5cwXV5MDmXSCZlMBrkzv5lNJgd7ohMyUK75zWyAUhZ/MdwDt2cYx7D0KjkOzy3H8QKlFkO1GphSjmNYPl1eTF4QkcuLyfmwmDiewVFxPIfH5fk5uRrkyrdvVzudaBxwk11Acsr8c6qn+XYbYKuJS73+hvE18h68+rW0AEuIpDCEiXoC5+XQxYQdC4jdF7R2bc7g9WvTxtYYmhmWYoe4tJRLEXcul4RToLlyvJ0Pmchys6iXCCKkxkgKqjurZG7ay4i+JoV3RaYwYtqJctH5fIde9vXVeQNMtE0IPG6HUpoexykxOITzg5QGLJaPp4oeKvYdqYcslJIjEUP45miArao0sLNPw+EzwBr2XQoM7FuPAZtlc4ABv7aOQjV8+0QwBnfu+TC/32mQXK00mkBjdEj27bHpfm2HRbUZsCP61srj6XFTofmYmC/oL+SA22sfBVFRwgxGJldHdKgDW51kn99fLa7enfmMSIkvii/g/jz7CIRShVqjN3Ys8xBtXNzDcXu3m0JqD8Vm597DspS61btb7RpIKHPjikVmaFupPbWU3zvdfrvVs9ttheJWAu9Sfa8mX+YN6Ni2FyKKKuraKDRRchZ4Lck4Mda3o1pSg1YWRCiM1GPIw1yYfAxPTFD5pHssOrou7tZPaclnEtkr33qoVyRlvDgqeQlZ0SukCTFjoBgyIsawUoihpvsUeUSlN7+wX2tXheknLO9fHI/v3lMsb3R9m2SnKZb1qI7b3XCqEeGXj3OQOrAXWsI3hUGiBxLjqybACmNnIyECmNCGcI4UpAKFqXxEWvO/bjrcvO+4T8Cd8u26E1lbu/cWZDVobEWmuRVZIUHZyYholPDlSW/PeKGLdy1yH4+vEl9JtcOrKt7HZKsg++aQY1K1BxAfH2cRiuN6V0F6x46yxo5yZqjpKszew4Nm3w86mR1EZsG8JHmaElW8ALDc6MPMFT9mWL7+7dN2f22en7QphjRXC7B3RLOLdPmIZHtGO7yf/qjpBOBr92HLlkpsE/H1bN1jyJorPi7XX2wse8koU8d27I2GRKZooTEyspva7ZtcyI842wDcKRkrkoKRoHIBxACXMeuZZ2xCLlq5elTFqztM7gFe+w4TfBHwiYn8eQym9RgrxkjqMtt7MmLrzFRbKMN/Y2SGGbh0cHuGoaIk1evnm0xDRpR74HYaYiGrBx55GfFMMdvFyl0b87O/kmF3Ne+LwkGRgCb/t0sbdpbcmp4JgzFuVslA+r7yy4jWHuf6jsr7Y1sD7g5vE7VqNZwKaaoBsrrCjEa+GhxJzzkBjhXJ2ZbZFjaAO6k1C3n74RssdUKofFo0evRgnnacdpOxLUxRPl92GO5XDmfjtbYLyjQJOdLluAd1KeSa2XKUxU6JiFHJXLt5XBRSoPstBZcxMHHmxuw+xEgVmWmDPiUouiFzsbG2n6OJzt1lChox1T2gRtZZYo8/KByHO/OUiFvRb02NRJtFlFiH+ktna5wrXwcF+979+qPo9Jja0SeinQFQGRCM/hcAAP//VUidAg=="
+	return "eJy8Wn9v47gR/d+fYrAosAnOq218SbDwHwWym+sl6O4lqLPAtterTYljiw1FqiSVRIt++GJoypYT+odyagEfbk1R772Z4YyHZN7BPdZjsLV1WAwAnHASx/Bm4gfeDAA42syI0gmtxvCnAQDAXY4WgRkElyPMBUpuYYEKDXPIIa39+BITCs0rickAwKBEZnEMKTo2gPDieDAAeAeKFTgGfEDlPIerSxzDwuiq9N+byQDr2dqIhVD+cfPCPdaP2vAwFtFOnxv/Hui51+k5E7jLhYWMKUgRGMyFRCiZy+EIk0UCs/cPzLyXekH/JSez4+EKTRsPQ5IayGB6potSK1QOXM4c2KospUDup3PmWIOt0Emh7mfHSdsXlUVzsCtQOeHqqeDdvXF9CZUS/65Q1iA4Ac1roRZeJWkArYBBrq1L4NoBeUkXZUWRZhYYTK4u3o3OziFnNl+BBkfQW3B9OVwC0T+Y4ssvZGSyYYNDUwjFZHcT7sKbjf+JYMOXpdEZWnuwO11ukPEkYyVLhRROoE1wPsfMiQcMtBIfUI4BnxwqjnyHarFQ2uCUpfoBx3Dyx9FpzBy/AIX1frPoyJY2P3ltlVv3aBRKcBpKNHNtCvp/IawVuskHgCzH7N7CPCzQYFN4jE+sKCnV3/765tPF7fTj7Z/fDMH/c/K3yfTi8sv1L29+extml8w5NGoM/zyiGb9evPv79Lcf/vMP/sPxH8IUjnNWSTf1iTqGOZMW9/rUq3YO+f/YpwykKISjZW2rEg35t/HLKq6b7qaUDZDQ8h8UrAZmbVXg/9eVG75s5drz5bw1Sa6YzdE2KYJPmFWOpRKp9CF51/qSzuRCG+HywlNZn7D0wgOTFfopK0QazvEJUGWaIwcuFmhdmOnz73l+rS1IJbvHUTodnZ2HJ/E4PzPn4+eLv/w0SlcFJ2LOYAvTjx9OX8P044fTrkxnJ6PXMJ2djA5lsjkbjU67kEyuLkajgy2xOevorsnVRQdPEf60uwU/Tjva0HV5kRXTDmvLc7zCU9Ouvuq4pLwd3dbT2cnoFRE5Oxm97xYTz9M5Kp7n8Lg8PeXnnUz59u18jxENdKaLgik+lULh9EUd3iTZUonbHVPDN6+kbLCBsIetNmvu0IDFzKDbrMA2M1WaUiemOKQ41wZB+B7NmUpl1I4ncKNkDQZLbQjsMcd1izALjVHStipBRXbzGeFYdLscsKIJkEtXp1pLZGqPF9BRA+NMhV5UXMwMHlnLGnB6j/iCPU0lqoXLZ5DWLvwQNeKXnXnCKi7i+4yI2M0fsDUWNberwZdIO42nz4wAZpBp5ZhQzQ5K+lYbhKK2jlECNH0GwMs9FEDsR7atsiqdKNYrZq1UarXYGF4SjoFXxvNuPBSqrNy0maKY0hYzrbjdmKUr157G7CWrozNKg5mgbnUMJxvPd/iLPl+9NSBUW0ISMTvV2m0xnDOHXTg/au2AsGI8IXpoxHfkEbLNVDiIb4IOxDwsA9phrThiAkjYd60woa8bWPGyd4CAX1pb2Qa++U6qhuD3rR8ndzsF6fmcqofF7JDVt0fT3VoHodIK2BF9UtmfP64CWoxJ8P444PoyRsFMlguHmasM9kjWhg0nEU8fzqfnp8cxEQXL+uH+cvEJGOcGrcVo7EQZIRJlF47r290UerMmxSv3HpaZtq3a3SrXwFJd0ZEPgi7pSMzvOpe/OxsYL2v2WiFtqF4s4F1e3+uTm8kKdEjlhak6RN06gy7Lj5OoklIyR7b1qqQBDQoyVE7bIVRppVw1hEehuH60WxT17hcCDEq+sAxuJvBtC/WcFULWvZIvIQO9QZ4zNwSOqWBqCHODmFq+zyMPaDZOfvrQFTDjhMvzp/747iLJ8tYGmt1SyMr+hNxM/NtwZBHhp08T0DahgZbjG+KSZfdsgYN9dWQH4yxg7CwkTIFQ1jEpkYM2YLDQD8gb/t/XHT4/N97nwJ3u23WS3Kjde4QcGo1n0LBsh+gEOSD5KCzPk5uRyDrZWjNeaeJtizzGEyT0SbXDqhDvPtkCZIyNGoY+qdoNSIxPigxVv9YFyBhbyLFe9gwNXcDcunmw4jv+7t64ISOwKElVFMzUrwBcvhjDrIzsMyxf//o5GbzgaO6/Xltc6RZob4tGk+zyiiucDbRADq+nOyrNLu/s8Q99vm5elj2nqwTvne36Ms616JfrZ7qC20rGhemTjAx7ayHXBQIXBjOnTR1ntjnKHnsbgFujF4YVdNplKgXMgdQLoeLstCCnrbXap5CfwwkTcbTzgc4I4bNQ1dMQXOsacoGZtssL0rjY5+m4VqjTf2Hmugmcebg9zVC9vLq1q+ylU8qSGX9hepRircOFFT17a6E0gqrY8q1n/XM8k/dk874oHBSJ9fp/mdq7U25NL5TDBZrI8w7029KvZNZGjItX4QM4Zw3g7vCuohZmw5Giw7cc1yPCWZTzzpGM7BN6i+TFC9kEm8Cttlaksn15CjObM64fp83U2RbMow2j/VUBJabyzlhi+L9SOR6ufTvlwi6P7oeDKCjMlF4zw1GT7JypBRpdWd+Pq5oO2uhvYaRegFDHvs3ehpiZunRtUH+Yv6HeZxlpf48ue++HOVjEwg4iiBQV3awSYIrul4nD73mWiMfJ1jhLZt00y8mgWDy3tHMdgk17Vc7qjRrTGEp3FSQAspypBfJk8N8BACv5Ld8="
 }
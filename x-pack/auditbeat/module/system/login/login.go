@@ -9,6 +9,7 @@ package login
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
 	"github.com/elastic/beats/v7/auditbeat/ab"
@@ -81,12 +82,15 @@ func init() {
 	)
 }
 
-// MetricSet collects login records from /var/log/wtmp.
+// MetricSet collects login records from /var/log/wtmp, wtmpdb and
+// systemd-logind.
 type MetricSet struct {
 	mb.BaseMetricSet
-	config     config
-	log        *logp.Logger
-	utmpReader *UtmpFileReader
+	config        config
+	log           *logp.Logger
+	utmpReader    *UtmpFileReader
+	wtmpdbReader  *WtmpdbReader
+	logindWatcher *LogindWatcher
 }
 
 // New constructs a new MetricSet.
@@ -114,19 +118,65 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		return nil, err
 	}
 
+	ms.wtmpdbReader, err = NewWtmpdbReader(ms.log, bucket, config)
+	if err != nil {
+		ms.utmpReader.Close()
+		return nil, err
+	}
+
+	if config.LogindEnabled {
+		ms.logindWatcher, err = NewLogindWatcher(ms.log)
+		if err != nil {
+			ms.utmpReader.Close()
+			return nil, fmt.Errorf("failed to watch systemd-logind sessions: %w", err)
+		}
+	} else if !anyFileExists(config.WtmpFilePattern, config.BtmpFilePattern, config.WtmpdbFilePattern) {
+		ms.log.Warnf("No wtmp, btmp or wtmpdb file found, logins won't be reported. " +
+			"Set login.logind.enabled to track the logins with systemd-logind.")
+	}
+
 	return ms, nil
 }
 
+// anyFileExists returns whether any file matches one of the patterns.
+func anyFileExists(patterns ...string) bool {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		if matches, err := filepath.Glob(pattern); err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // Close cleans up the MetricSet when it finishes.
 func (ms *MetricSet) Close() error {
+	if ms.logindWatcher != nil {
+		if err := ms.logindWatcher.Close(); err != nil {
+			ms.log.Debugf("Error closing the systemd-logind connection: %v", err)
+		}
+	}
 	return ms.utmpReader.Close()
 }
 
-// Fetch collects any new login records from /var/log/wtmp. It is invoked periodically.
+// Fetch collects any new login records from /var/log/wtmp, wtmpdb and
+// systemd-logind. It is invoked periodically.
 func (ms *MetricSet) Fetch(report mb.ReporterV2) {
 	count := ms.readAndEmit(report)
 
-	ms.log.Debugf("%d new login records.", count)
+	wtmpdbCount := ms.readAndEmitWtmpdb(report)
+
+	var logindCount int
+	if ms.logindWatcher != nil {
+		for _, loginRecord := range ms.logindWatcher.ReadNew() {
+			report.Event(ms.loginEvent(&loginRecord))
+			logindCount++
+		}
+	}
+
+	ms.log.Debugf("%d new login records.", count+wtmpdbCount+logindCount)
 
 	// Save new state to disk
 	if count > 0 {
@@ -136,6 +186,26 @@ func (ms *MetricSet) Fetch(report mb.ReporterV2) {
 			report.Error(err)
 		}
 	}
+	if wtmpdbCount > 0 {
+		err := ms.wtmpdbReader.saveStateToDisk()
+		if err != nil {
+			ms.log.Error(err)
+			report.Error(err)
+		}
+	}
+}
+
+// readAndEmitWtmpdb reads and emits the login events of the wtmpdb
+// databases and returns the number of events.
+func (ms *MetricSet) readAndEmitWtmpdb(report mb.ReporterV2) int {
+	loginRecords, err := ms.wtmpdbReader.ReadNew()
+	if err != nil {
+		ms.log.Error(err)
+	}
+	for _, loginRecord := range loginRecords {
+		report.Event(ms.loginEvent(&loginRecord))
+	}
+	return len(loginRecords)
 }
 
 // readAndEmit reads and emits login events and returns the number of events.
@@ -196,7 +266,7 @@ func (ms *MetricSet) loginEvent(loginRecord *LoginRecord) mb.Event {
 		event.RootFields.Put("related.ip", []string{loginRecord.IP.String()})
 	}
 
-	if loginRecord.Hostname != "" && loginRecord.Hostname != loginRecord.IP.String() {
+	if loginRecord.Hostname != "" && (loginRecord.IP == nil || loginRecord.Hostname != loginRecord.IP.String()) {
 		event.RootFields.Put("source.domain", loginRecord.Hostname)
 	}
 
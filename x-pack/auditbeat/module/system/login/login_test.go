@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/godbus/dbus/v5"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/elastic/beats/v7/auditbeat/ab"
@@ -256,6 +257,117 @@ func TestBtmp(t *testing.T) {
 		"Timestamp is not equal: %+v", events[3].Timestamp)
 }
 
+func TestWtmpdb(t *testing.T) {
+	defer abtest.SetupDataDir(t)()
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+
+	dbPath := filepath.Join(dir, "wtmp.db")
+
+	config := getBaseConfig()
+	config["login.wtmp_file_pattern"] = ""
+	config["login.btmp_file_pattern"] = ""
+	config["login.wtmpdb_file_pattern"] = dbPath
+	f := mbtest.NewReportingMetricSetV2WithRegistry(t, config, ab.Registry)
+	defer f.(*MetricSet).utmpReader.bucket.DeleteBucket()
+
+	events, errs := mbtest.ReportingFetchV2(f)
+	if len(errs) > 0 {
+		t.Fatalf("received error: %+v", errs[0])
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	checkFieldValue(t, events[0].RootFields, "event.action", "boot")
+	checkFieldValue(t, events[0].RootFields, "event.origin", dbPath)
+	assert.True(t, events[0].Timestamp.Equal(time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)),
+		"Timestamp is not equal: %+v", events[0].Timestamp)
+
+	checkFieldValue(t, events[1].RootFields, "event.action", "user_login")
+	checkFieldValue(t, events[1].RootFields, "event.outcome", "success")
+	checkFieldValue(t, events[1].RootFields, "source.ip", "10.0.2.2")
+	checkFieldValue(t, events[1].RootFields, "user.name", "vagrant")
+	checkFieldValue(t, events[1].RootFields, "user.terminal", "pts/0")
+	assert.True(t, events[1].Timestamp.Equal(time.Date(2024, 5, 1, 8, 1, 0, 123456000, time.UTC)),
+		"Timestamp is not equal: %+v", events[1].Timestamp)
+
+	// Nothing changed.
+	events, errs = mbtest.ReportingFetchV2(f)
+	if len(errs) > 0 {
+		t.Fatalf("received error: %+v", errs[0])
+	}
+	assert.Empty(t, events)
+
+	// The user logs out, which updates the row of the login, and root logs
+	// in. The file is overwritten in place to keep its inode.
+	updated, err := os.ReadFile(filepath.Join(dir, "wtmp-logout.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(dbPath, updated, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the modification time changed.
+	later := time.Now().Add(time.Second)
+	if err = os.Chtimes(dbPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	events, errs = mbtest.ReportingFetchV2(f)
+	if len(errs) > 0 {
+		t.Fatalf("received error: %+v", errs[0])
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	checkFieldValue(t, events[0].RootFields, "event.action", "user_logout")
+	checkFieldValue(t, events[0].RootFields, "event.type", []string{"end"})
+	checkFieldValue(t, events[0].RootFields, "user.name", "vagrant")
+	assert.True(t, events[0].Timestamp.Equal(time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)),
+		"Timestamp is not equal: %+v", events[0].Timestamp)
+
+	checkFieldValue(t, events[1].RootFields, "event.action", "user_login")
+	checkFieldValue(t, events[1].RootFields, "user.name", "root")
+	checkFieldValue(t, events[1].RootFields, "user.terminal", "tty1")
+	contains, err := events[1].RootFields.HasKey("source.ip")
+	if assert.NoError(t, err) {
+		assert.False(t, contains)
+	}
+}
+
+func TestSessionLoginRecord(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Id":         dbus.MakeVariant("42"),
+		"Name":       dbus.MakeVariant("alice"),
+		"User":       dbus.MakeVariant([]interface{}{uint32(1000), dbus.ObjectPath("/org/freedesktop/login1/user/_1000")}),
+		"TTY":        dbus.MakeVariant(""),
+		"VTNr":       dbus.MakeVariant(uint32(0)),
+		"RemoteHost": dbus.MakeVariant("192.168.1.10"),
+		"Leader":     dbus.MakeVariant(uint32(4321)),
+		"Timestamp":  dbus.MakeVariant(uint64(1714550400000000)),
+		"Class":      dbus.MakeVariant("user"),
+	}
+
+	record, ok := sessionLoginRecord(props)
+	if assert.True(t, ok) {
+		assert.Equal(t, userLoginRecord, record.Type)
+		assert.Equal(t, "alice", record.Username)
+		assert.Equal(t, 1000, record.UID)
+		assert.Equal(t, 4321, record.PID)
+		assert.Equal(t, "192.168.1.10", record.IP.String())
+		assert.Equal(t, logindOrigin, record.Origin)
+		assert.True(t, record.Timestamp.Equal(time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)))
+	}
+
+	// The sessions of the service managers of users are not logins.
+	props["Class"] = dbus.MakeVariant("manager")
+	_, ok = sessionLoginRecord(props)
+	assert.False(t, ok)
+}
+
 func checkFieldValue(t *testing.T, mapstr mapstr.M, fieldName string, fieldValue interface{}) {
 	value, err := mapstr.GetValue(fieldName)
 	if assert.NoError(t, err) {
@@ -272,6 +384,8 @@ func getBaseConfig() map[string]interface{} {
 	return map[string]interface{}{
 		"module":   system.ModuleName,
 		"datasets": []string{"login"},
+		// Don't read the wtmpdb database of the host.
+		"login.wtmpdb_file_pattern": "",
 	}
 }
 
@@ -0,0 +1,267 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package login
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	logindService   = "org.freedesktop.login1"
+	logindPath      = dbus.ObjectPath("/org/freedesktop/login1")
+	logindManager   = "org.freedesktop.login1.Manager"
+	logindSession   = "org.freedesktop.login1.Session"
+	logindOrigin    = "logind"
+	logindMaxBuffer = 10000
+)
+
+// LogindWatcher subscribes to the session signals of systemd-logind over
+// D-Bus, and buffers the login records until they are read by the next
+// Fetch.
+type LogindWatcher struct {
+	log     *logp.Logger
+	conn    *dbus.Conn
+	signals chan *dbus.Signal
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex
+	records []LoginRecord
+	// sessions holds the login records of the open sessions, the session
+	// properties are not available anymore when a session is removed.
+	sessions map[dbus.ObjectPath]LoginRecord
+}
+
+// NewLogindWatcher connects to the system bus and starts watching the
+// sessions. The sessions that are already open are not reported.
+func NewLogindWatcher(log *logp.Logger) (*LogindWatcher, error) {
+	conn, err := dbus.SystemBusPrivate()
+	if err != nil {
+		return nil, fmt.Errorf("error getting connection to system bus: %w", err)
+	}
+	if err = conn.Auth([]dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error authenticating: %w", err)
+	}
+	if err = conn.Hello(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error in Hello: %w", err)
+	}
+
+	rule := fmt.Sprintf("type='signal',sender='%s',interface='%s',path='%s'", logindService, logindManager, logindPath)
+	if err = conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, rule).Err; err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error subscribing to logind signals: %w", err)
+	}
+
+	w := &LogindWatcher{
+		log:      log,
+		conn:     conn,
+		signals:  make(chan *dbus.Signal, 64),
+		done:     make(chan struct{}),
+		sessions: make(map[dbus.ObjectPath]LoginRecord),
+	}
+	conn.Signal(w.signals)
+
+	if err = w.loadSessions(); err != nil {
+		w.log.Warnf("Failed to list the open logind sessions, their logouts won't be reported: %v", err)
+	}
+
+	w.wg.Add(1)
+	go w.run()
+	return w, nil
+}
+
+// loadSessions caches the open sessions, so their logout can be reported.
+func (w *LogindWatcher) loadSessions() error {
+	var sessions [][]dbus.Variant
+	err := w.conn.Object(logindService, logindPath).Call(logindManager+".ListSessions", 0).Store(&sessions)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if len(session) < 5 {
+			continue
+		}
+		path, ok := session[4].Value().(dbus.ObjectPath)
+		if !ok {
+			continue
+		}
+		if record, ok := w.sessionRecord(path); ok {
+			w.sessions[path] = record
+		}
+	}
+	return nil
+}
+
+func (w *LogindWatcher) run() {
+	defer w.wg.Done()
+	defer logp.Recover("A panic occurred while watching logind sessions")
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case sig, ok := <-w.signals:
+			if !ok {
+				return
+			}
+			w.handleSignal(sig)
+		}
+	}
+}
+
+func (w *LogindWatcher) handleSignal(sig *dbus.Signal) {
+	switch sig.Name {
+	case logindManager + ".SessionNew":
+		path, ok := signalPath(sig)
+		if !ok {
+			return
+		}
+		record, ok := w.sessionRecord(path)
+		if !ok {
+			return
+		}
+		w.mu.Lock()
+		w.sessions[path] = record
+		w.mu.Unlock()
+		w.add(record)
+	case logindManager + ".SessionRemoved":
+		path, ok := signalPath(sig)
+		if !ok {
+			return
+		}
+		w.mu.Lock()
+		record, found := w.sessions[path]
+		delete(w.sessions, path)
+		w.mu.Unlock()
+		if !found {
+			return
+		}
+		record.Type = userLogoutRecord
+		record.Timestamp = time.Now().UTC()
+		w.add(record)
+	case logindManager + ".PrepareForShutdown":
+		if len(sig.Body) == 0 {
+			return
+		}
+		// The signal is sent with false when a shutdown is cancelled.
+		if start, _ := sig.Body[0].(bool); start {
+			w.add(LoginRecord{
+				Type:      shutdownRecord,
+				Timestamp: time.Now().UTC(),
+				UID:       -1,
+				PID:       -1,
+				Origin:    logindOrigin,
+			})
+		}
+	}
+}
+
+// signalPath returns the session object path of a SessionNew or
+// SessionRemoved signal.
+func signalPath(sig *dbus.Signal) (dbus.ObjectPath, bool) {
+	if len(sig.Body) < 2 {
+		return "", false
+	}
+	path, ok := sig.Body[1].(dbus.ObjectPath)
+	return path, ok
+}
+
+func (w *LogindWatcher) sessionRecord(path dbus.ObjectPath) (LoginRecord, bool) {
+	var props map[string]dbus.Variant
+	err := w.conn.Object(logindService, path).Call("org.freedesktop.DBus.Properties.GetAll", 0, logindSession).Store(&props)
+	if err != nil {
+		w.log.Debugf("Failed to get the properties of logind session %v: %v", path, err)
+		return LoginRecord{}, false
+	}
+	return sessionLoginRecord(props)
+}
+
+// sessionLoginRecord returns the login record of a session from its
+// properties. Only user sessions are reported, not the sessions of the
+// display managers or of the service managers of the users.
+func sessionLoginRecord(props map[string]dbus.Variant) (LoginRecord, bool) {
+	str := func(name string) string {
+		s, _ := props[name].Value().(string)
+		return s
+	}
+
+	if class := str("Class"); class != "" && !strings.HasPrefix(class, "user") {
+		return LoginRecord{}, false
+	}
+
+	record := LoginRecord{
+		Type:      userLoginRecord,
+		Username:  str("Name"),
+		TTY:       str("TTY"),
+		Hostname:  str("RemoteHost"),
+		UID:       -1,
+		PID:       -1,
+		Timestamp: time.Now().UTC(),
+		Origin:    logindOrigin,
+	}
+	if user, ok := props["User"].Value().([]interface{}); ok && len(user) > 0 {
+		if uid, ok := user[0].(uint32); ok {
+			record.UID = int(uid)
+		}
+	}
+	if leader, ok := props["Leader"].Value().(uint32); ok && leader != 0 {
+		record.PID = int(leader)
+	}
+	if usec, ok := props["Timestamp"].Value().(uint64); ok && usec != 0 {
+		record.Timestamp = time.UnixMicro(int64(usec)).UTC()
+	}
+	if ip := net.ParseIP(record.Hostname); ip != nil {
+		record.IP = &ip
+	}
+	if record.TTY == "" {
+		// Graphical sessions have a seat and a virtual terminal instead.
+		if vt, ok := props["VTNr"].Value().(uint32); ok && vt != 0 {
+			record.TTY = "tty" + strconv.Itoa(int(vt))
+		}
+	}
+	return record, true
+}
+
+func (w *LogindWatcher) add(record LoginRecord) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.records) >= logindMaxBuffer {
+		w.log.Warnf("Dropping logind login record, more than %d records are waiting to be reported", logindMaxBuffer)
+		return
+	}
+	w.records = append(w.records, record)
+}
+
+// ReadNew returns the login records received since the last call.
+func (w *LogindWatcher) ReadNew() []LoginRecord {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	records := w.records
+	w.records = nil
+	return records
+}
+
+// Close stops watching the sessions.
+func (w *LogindWatcher) Close() error {
+	close(w.done)
+	w.conn.RemoveSignal(w.signals)
+	err := w.conn.Close()
+	w.wg.Wait()
+	return err
+}
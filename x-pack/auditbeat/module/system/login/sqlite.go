@@ -0,0 +1,453 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package login
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// sqliteDB is a minimal read-only reader of SQLite 3 database files, enough
+// to read the rows of the tables of the wtmpdb database without depending
+// on a SQLite library. It supports rollback journal and WAL databases with
+// UTF-8 text. The rows committed to the WAL file are read too, so the
+// database doesn't have to be checkpointed.
+type sqliteDB struct {
+	f        *os.File
+	pageSize int
+	usable   int
+
+	// walPages maps page numbers to the offset of their most recent
+	// committed frame in the WAL file.
+	wal      *os.File
+	walPages map[uint32]int64
+}
+
+// sqliteRow is a row of a table, indexed by column name.
+type sqliteRow map[string]interface{}
+
+var errNotSQLite = errors.New("not a SQLite 3 database")
+
+func openSQLite(path string) (*sqliteDB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 100)
+	if _, err := io.ReadFull(f, header); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read header of %v: %w", path, err)
+	}
+	if string(header[:16]) != "SQLite format 3\x00" {
+		f.Close()
+		return nil, errNotSQLite
+	}
+	if enc := binary.BigEndian.Uint32(header[56:]); enc > 1 {
+		f.Close()
+		return nil, fmt.Errorf("unsupported text encoding %d of %v", enc, path)
+	}
+
+	pageSize := int(binary.BigEndian.Uint16(header[16:]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	db := &sqliteDB{
+		f:        f,
+		pageSize: pageSize,
+		usable:   pageSize - int(header[20]),
+	}
+
+	// Read versions of 2 mean the database is in WAL mode.
+	if header[19] == 2 {
+		if err := db.openWAL(path + "-wal"); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// openWAL indexes the frames of the WAL file that belong to committed
+// transactions.
+func (db *sqliteDB) openWAL(path string) error {
+	wal, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, 32)
+	if _, err := io.ReadFull(wal, header); err != nil {
+		// No frames were written to the WAL file yet.
+		wal.Close()
+		return nil //nolint:nilerr // an empty WAL file is valid
+	}
+	if magic := binary.BigEndian.Uint32(header); magic != 0x377f0682 && magic != 0x377f0683 {
+		wal.Close()
+		return fmt.Errorf("invalid WAL file %v", path)
+	}
+	if int(binary.BigEndian.Uint32(header[8:])) != db.pageSize {
+		wal.Close()
+		return fmt.Errorf("page size of WAL file %v doesn't match the database", path)
+	}
+	salt := header[16:24]
+
+	db.wal = wal
+	db.walPages = map[uint32]int64{}
+	pending := map[uint32]int64{}
+	frameHeader := make([]byte, 24)
+	for offset := int64(32); ; offset += int64(24 + db.pageSize) {
+		if _, err := wal.ReadAt(frameHeader, offset); err != nil {
+			break
+		}
+		if !bytes.Equal(frameHeader[8:16], salt) {
+			// Frames left over from before the last checkpoint.
+			break
+		}
+		pending[binary.BigEndian.Uint32(frameHeader)] = offset + 24
+		if binary.BigEndian.Uint32(frameHeader[4:]) != 0 {
+			// A commit frame, the frames of the transaction are valid.
+			for page, off := range pending {
+				db.walPages[page] = off
+			}
+			pending = map[uint32]int64{}
+		}
+	}
+	return nil
+}
+
+func (db *sqliteDB) Close() error {
+	if db.wal != nil {
+		db.wal.Close()
+	}
+	return db.f.Close()
+}
+
+func (db *sqliteDB) page(n uint32) ([]byte, error) {
+	if n == 0 {
+		return nil, errors.New("invalid page number 0")
+	}
+	buf := make([]byte, db.pageSize)
+	if off, ok := db.walPages[n]; ok {
+		if _, err := db.wal.ReadAt(buf, off); err != nil {
+			return nil, fmt.Errorf("failed to read page %d from WAL: %w", n, err)
+		}
+		return buf, nil
+	}
+	if _, err := db.f.ReadAt(buf, int64(n-1)*int64(db.pageSize)); err != nil {
+		return nil, fmt.Errorf("failed to read page %d: %w", n, err)
+	}
+	return buf, nil
+}
+
+// readTable returns the rows of a table. The rowid is set in the column
+// declared as INTEGER PRIMARY KEY, if any.
+func (db *sqliteDB) readTable(name string) ([]sqliteRow, error) {
+	var root uint32
+	var columns []string
+	rowidColumn := -1
+	err := db.scan(1, func(rowid int64, values []interface{}) error {
+		if len(values) < 5 || values[0] != "table" {
+			return nil
+		}
+		if tblName, _ := values[1].(string); !strings.EqualFold(tblName, name) {
+			return nil
+		}
+		page, _ := values[3].(int64)
+		sql, _ := values[4].(string)
+		root = uint32(page)
+		columns, rowidColumn = parseColumns(sql)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if root == 0 {
+		return nil, fmt.Errorf("table %v not found", name)
+	}
+
+	var rows []sqliteRow
+	err = db.scan(root, func(rowid int64, values []interface{}) error {
+		row := make(sqliteRow, len(columns))
+		for i, col := range columns {
+			switch {
+			case i == rowidColumn:
+				row[col] = rowid
+			case i < len(values):
+				row[col] = values[i]
+			default:
+				// Columns added after the row was written.
+				row[col] = nil
+			}
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
+
+// scan calls fn with the rowid and values of every row of the table b-tree
+// with the given root page.
+func (db *sqliteDB) scan(root uint32, fn func(rowid int64, values []interface{}) error) error {
+	return db.scanPage(root, 0, fn)
+}
+
+func (db *sqliteDB) scanPage(n uint32, depth int, fn func(int64, []interface{}) error) error {
+	if depth > 64 {
+		return errors.New("b-tree is too deep, the database may be corrupt")
+	}
+	page, err := db.page(n)
+	if err != nil {
+		return err
+	}
+	hdr := 0
+	if n == 1 {
+		hdr = 100
+	}
+	if hdr+8 > len(page) {
+		return fmt.Errorf("page %d is too short", n)
+	}
+
+	cells := int(binary.BigEndian.Uint16(page[hdr+3:]))
+	switch page[hdr] {
+	case 0x05: // interior table page
+		ptrs := page[hdr+12:]
+		for i := 0; i < cells; i++ {
+			if 2*i+2 > len(ptrs) {
+				return fmt.Errorf("invalid cell pointer on page %d", n)
+			}
+			off := int(binary.BigEndian.Uint16(ptrs[2*i:]))
+			if off+4 > len(page) {
+				return fmt.Errorf("invalid cell offset on page %d", n)
+			}
+			if err := db.scanPage(binary.BigEndian.Uint32(page[off:]), depth+1, fn); err != nil {
+				return err
+			}
+		}
+		return db.scanPage(binary.BigEndian.Uint32(page[hdr+8:]), depth+1, fn)
+	case 0x0d: // leaf table page
+		ptrs := page[hdr+8:]
+		for i := 0; i < cells; i++ {
+			if 2*i+2 > len(ptrs) {
+				return fmt.Errorf("invalid cell pointer on page %d", n)
+			}
+			rowid, payload, err := db.leafCell(page, int(binary.BigEndian.Uint16(ptrs[2*i:])))
+			if err != nil {
+				return fmt.Errorf("invalid cell on page %d: %w", n, err)
+			}
+			values, err := parseRecord(payload)
+			if err != nil {
+				return fmt.Errorf("invalid record %d on page %d: %w", rowid, n, err)
+			}
+			if err := fn(rowid, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("page %d is not a table b-tree page (type %d)", n, page[hdr])
+	}
+}
+
+// leafCell returns the rowid and the full payload of a leaf table cell,
+// following the overflow pages.
+func (db *sqliteDB) leafCell(page []byte, off int) (int64, []byte, error) {
+	if off >= len(page) {
+		return 0, nil, errors.New("offset out of range")
+	}
+	size, n := readVarint(page[off:])
+	off += n
+	rowid, n := readVarint(page[off:])
+	off += n
+
+	total := int(size)
+	u := db.usable
+	maxLocal := u - 35
+	local := total
+	if total > maxLocal {
+		minLocal := (u-12)*32/255 - 23
+		local = minLocal + (total-minLocal)%(u-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if off+local > len(page) {
+		return 0, nil, errors.New("payload out of range")
+	}
+	payload := make([]byte, 0, total)
+	payload = append(payload, page[off:off+local]...)
+	if local == total {
+		return int64(rowid), payload, nil
+	}
+
+	if off+local+4 > len(page) {
+		return 0, nil, errors.New("overflow pointer out of range")
+	}
+	next := binary.BigEndian.Uint32(page[off+local:])
+	for pages := 0; len(payload) < total; pages++ {
+		if next == 0 || pages > total/(u-4)+1 {
+			return 0, nil, errors.New("truncated overflow chain")
+		}
+		overflow, err := db.page(next)
+		if err != nil {
+			return 0, nil, err
+		}
+		next = binary.BigEndian.Uint32(overflow)
+		chunk := overflow[4:u]
+		if rest := total - len(payload); len(chunk) > rest {
+			chunk = chunk[:rest]
+		}
+		payload = append(payload, chunk...)
+	}
+	return int64(rowid), payload, nil
+}
+
+// parseRecord decodes the values of a record in the SQLite record format.
+// Integers are returned as int64, floats as float64, text as string and
+// blobs as []byte.
+func parseRecord(payload []byte) ([]interface{}, error) {
+	headerSize, n := readVarint(payload)
+	if n == 0 || int(headerSize) > len(payload) || int(headerSize) < n {
+		return nil, errors.New("invalid record header")
+	}
+	header := payload[n:headerSize]
+	body := payload[headerSize:]
+
+	var values []interface{}
+	for len(header) > 0 {
+		serialType, n := readVarint(header)
+		if n == 0 {
+			return nil, errors.New("invalid serial type")
+		}
+		header = header[n:]
+
+		var size int
+		switch {
+		case serialType >= 12 && serialType%2 == 0:
+			size = int(serialType-12) / 2
+		case serialType >= 13:
+			size = int(serialType-13) / 2
+		case serialType <= 4:
+			size = int(serialType)
+		case serialType == 5:
+			size = 6
+		case serialType == 6, serialType == 7:
+			size = 8
+		}
+		if size > len(body) {
+			return nil, errors.New("value out of range")
+		}
+		data := body[:size]
+		body = body[size:]
+
+		switch {
+		case serialType == 0:
+			values = append(values, nil)
+		case serialType <= 6:
+			values = append(values, readInt(data))
+		case serialType == 7:
+			values = append(values, math.Float64frombits(binary.BigEndian.Uint64(data)))
+		case serialType == 8:
+			values = append(values, int64(0))
+		case serialType == 9:
+			values = append(values, int64(1))
+		case serialType >= 12 && serialType%2 == 0:
+			values = append(values, append([]byte(nil), data...))
+		case serialType >= 13:
+			values = append(values, string(data))
+		default:
+			return nil, fmt.Errorf("reserved serial type %d", serialType)
+		}
+	}
+	return values, nil
+}
+
+// readInt decodes a big-endian two's complement integer of 1 to 8 bytes.
+func readInt(b []byte) int64 {
+	var v int64
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, c := range b {
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+// readVarint decodes a SQLite varint and returns it with its length, or a
+// length of 0 if b is too short.
+func readVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < 9; i++ {
+		if i >= len(b) {
+			return 0, 0
+		}
+		if i == 8 {
+			return v<<8 | uint64(b[i]), 9
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return v, 9
+}
+
+// parseColumns returns the column names of a CREATE TABLE statement and the
+// index of the column that is an alias of the rowid, or -1.
+func parseColumns(sql string) ([]string, int) {
+	start, end := strings.IndexByte(sql, '('), strings.LastIndexByte(sql, ')')
+	if start < 0 || end < start {
+		return nil, -1
+	}
+
+	var defs []string
+	depth, last := 0, start+1
+	for i := start + 1; i < end; i++ {
+		switch sql[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				defs = append(defs, sql[last:i])
+				last = i + 1
+			}
+		}
+	}
+	defs = append(defs, sql[last:end])
+
+	var columns []string
+	rowidColumn := -1
+	for _, def := range defs {
+		fields := strings.Fields(def)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "CONSTRAINT":
+			// Table constraints.
+			continue
+		}
+		upper := strings.ToUpper(strings.Join(fields[1:], " "))
+		if strings.HasPrefix(upper, "INTEGER PRIMARY KEY") && !strings.Contains(upper, "DESC") {
+			rowidColumn = len(columns)
+		}
+		columns = append(columns, strings.Trim(fields[0], "\"`[]'"))
+	}
+	return columns, rowidColumn
+}
@@ -0,0 +1,302 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package login
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/elastic/beats/v7/auditbeat/datastore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const bucketKeyWtmpdbFiles = "wtmpdb_files"
+
+// Entry types of the wtmpdb database, see wtmpdb.h.
+const (
+	wtmpdbBootTime    = 1
+	wtmpdbUserProcess = 3
+)
+
+// WtmpdbFile is the state of a wtmpdb database. Unlike utmp files, wtmpdb
+// stores a login and its logout in the same row, which is updated when the
+// user logs out.
+type WtmpdbFile struct {
+	Inode Inode
+	Path  string
+	// LastID is the ID of the most recent row that was read.
+	LastID int64
+	// OpenIDs are the rows read without a logout time.
+	OpenIDs map[int64]struct{}
+}
+
+// WtmpdbReader reads the login records of wtmpdb databases, the Y2038-safe
+// replacement of the wtmp file (usually /var/lib/wtmpdb/wtmp.db).
+type WtmpdbReader struct {
+	log     *logp.Logger
+	bucket  datastore.Bucket
+	pattern string
+	files   map[Inode]WtmpdbFile
+
+	// modTimes holds the modification times of the databases and their WAL
+	// files when they were last read, to skip unchanged databases.
+	modTimes map[Inode][2]time.Time
+}
+
+// NewWtmpdbReader creates a wtmpdb reader and restores its state from the
+// bucket.
+func NewWtmpdbReader(log *logp.Logger, bucket datastore.Bucket, config config) (*WtmpdbReader, error) {
+	r := &WtmpdbReader{
+		log:      log,
+		bucket:   bucket,
+		pattern:  config.WtmpdbFilePattern,
+		files:    make(map[Inode]WtmpdbFile),
+		modTimes: make(map[Inode][2]time.Time),
+	}
+
+	if err := r.restoreStateFromDisk(); err != nil {
+		return nil, fmt.Errorf("failed to restore wtmpdb state from disk: %w", err)
+	}
+	return r, nil
+}
+
+// ReadNew returns the logins, logouts, boots and shutdowns recorded since
+// the last call.
+func (r *WtmpdbReader) ReadNew() ([]LoginRecord, error) {
+	if r.pattern == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(r.pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand file pattern %v: %w", r.pattern, err)
+	}
+	sort.Strings(paths)
+
+	var records []LoginRecord
+	var errs []error
+	existing := make(map[Inode]struct{}, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("unexpected error when looking up file %v: %w", path, err))
+			}
+			continue
+		}
+		inode := Inode(info.Sys().(*syscall.Stat_t).Ino)
+		existing[inode] = struct{}{}
+
+		modTimes := [2]time.Time{info.ModTime()}
+		if walInfo, err := os.Stat(path + "-wal"); err == nil {
+			modTimes[1] = walInfo.ModTime()
+		}
+		if saved, ok := r.modTimes[inode]; ok && saved == modTimes {
+			continue
+		}
+
+		fileRecords, err := r.readFile(path, inode)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading wtmpdb file %v: %w", path, err))
+			continue
+		}
+		r.modTimes[inode] = modTimes
+		records = append(records, fileRecords...)
+	}
+
+	for inode := range r.files {
+		if _, found := existing[inode]; !found {
+			r.log.Debugf("Deleting wtmpdb file record for old inode %d.", inode)
+			delete(r.files, inode)
+			delete(r.modTimes, inode)
+		}
+	}
+
+	return records, errors.Join(errs...)
+}
+
+func (r *WtmpdbReader) readFile(path string, inode Inode) ([]LoginRecord, error) {
+	db, err := openSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.readTable("wtmp")
+	if err != nil {
+		return nil, err
+	}
+
+	file, known := r.files[inode]
+	if !known {
+		r.log.Debugf("Found new wtmpdb file: %v", path)
+		file = WtmpdbFile{Inode: inode}
+	}
+	file.Path = path
+	if file.OpenIDs == nil {
+		file.OpenIDs = make(map[int64]struct{})
+	}
+
+	var records []LoginRecord
+	present := make(map[int64]struct{}, len(file.OpenIDs))
+	for _, row := range rows {
+		entry := newWtmpdbEntry(row)
+		if entry.Type != wtmpdbBootTime && entry.Type != wtmpdbUserProcess {
+			continue
+		}
+
+		_, open := file.OpenIDs[entry.ID]
+		if open {
+			present[entry.ID] = struct{}{}
+		}
+		switch {
+		case entry.ID > file.LastID:
+			records = append(records, entry.loginRecord(path))
+			if entry.Logout.IsZero() {
+				file.OpenIDs[entry.ID] = struct{}{}
+				present[entry.ID] = struct{}{}
+			} else {
+				records = append(records, entry.logoutRecord(path))
+			}
+			file.LastID = entry.ID
+		case open && !entry.Logout.IsZero():
+			records = append(records, entry.logoutRecord(path))
+			delete(file.OpenIDs, entry.ID)
+		}
+	}
+
+	// Rows can be removed by "wtmpdb rotate".
+	for id := range file.OpenIDs {
+		if _, found := present[id]; !found {
+			delete(file.OpenIDs, id)
+		}
+	}
+
+	r.files[inode] = file
+	return records, nil
+}
+
+// wtmpdbEntry is a row of the wtmp table.
+type wtmpdbEntry struct {
+	ID         int64
+	Type       int64
+	User       string
+	Login      time.Time
+	Logout     time.Time
+	TTY        string
+	RemoteHost string
+}
+
+func newWtmpdbEntry(row sqliteRow) wtmpdbEntry {
+	var e wtmpdbEntry
+	e.ID, _ = row["ID"].(int64)
+	e.Type, _ = row["Type"].(int64)
+	e.User, _ = row["User"].(string)
+	e.TTY, _ = row["TTY"].(string)
+	e.RemoteHost, _ = row["RemoteHost"].(string)
+	// Times are stored in microseconds since the epoch.
+	if usec, ok := row["Login"].(int64); ok && usec > 0 {
+		e.Login = time.UnixMicro(usec).UTC()
+	}
+	if usec, ok := row["Logout"].(int64); ok && usec > 0 {
+		e.Logout = time.UnixMicro(usec).UTC()
+	}
+	return e
+}
+
+func (e wtmpdbEntry) loginRecord(origin string) LoginRecord {
+	record := LoginRecord{
+		Timestamp: e.Login,
+		UID:       -1,
+		PID:       -1,
+		Origin:    origin,
+	}
+	if e.TTY != "~" {
+		record.TTY = e.TTY
+	}
+	switch e.Type {
+	case wtmpdbBootTime:
+		record.Type = bootRecord
+	case wtmpdbUserProcess:
+		record.Type = userLoginRecord
+		record.Username = e.User
+		record.UID = lookupUsername(e.User)
+		record.Hostname = e.RemoteHost
+		if ip := net.ParseIP(e.RemoteHost); ip != nil {
+			record.IP = &ip
+		}
+	}
+	return record
+}
+
+func (e wtmpdbEntry) logoutRecord(origin string) LoginRecord {
+	record := e.loginRecord(origin)
+	record.Timestamp = e.Logout
+	switch record.Type {
+	case bootRecord:
+		record.Type = shutdownRecord
+	case userLoginRecord:
+		record.Type = userLogoutRecord
+	}
+	return record
+}
+
+func (r *WtmpdbReader) saveStateToDisk() error {
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+
+	for _, file := range r.files {
+		if err := encoder.Encode(file); err != nil {
+			return fmt.Errorf("error encoding wtmpdb file record: %w", err)
+		}
+	}
+
+	if err := r.bucket.Store(bucketKeyWtmpdbFiles, buf.Bytes()); err != nil {
+		return fmt.Errorf("error writing wtmpdb file records to disk: %w", err)
+	}
+
+	r.log.Debugf("Wrote %d wtmpdb file records to disk", len(r.files))
+	return nil
+}
+
+func (r *WtmpdbReader) restoreStateFromDisk() error {
+	var decoder *gob.Decoder
+	err := r.bucket.Load(bucketKeyWtmpdbFiles, func(blob []byte) error {
+		if len(blob) > 0 {
+			decoder = gob.NewDecoder(bytes.NewBuffer(blob))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if decoder != nil {
+		for {
+			var file WtmpdbFile
+			err = decoder.Decode(&file)
+			if err == nil {
+				r.files[file.Inode] = file
+			} else if errors.Is(err, io.EOF) {
+				break
+			} else {
+				return fmt.Errorf("error decoding wtmpdb file record: %w", err)
+			}
+		}
+	}
+	r.log.Debugf("Restored %d wtmpdb file records from disk", len(r.files))
+
+	return nil
+}
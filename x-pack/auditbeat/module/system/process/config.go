@@ -16,11 +16,16 @@ type Config struct {
 	ProcessStatePeriod time.Duration `config:"process.state.period"`
 
 	HasherConfig hasher.Config `config:"process.hash"`
+
+	CommandLine CommandLineConfig `config:"process.command_line"`
 }
 
 // Validate validates the config.
 func (c *Config) Validate() error {
-	return c.HasherConfig.Validate()
+	if err := c.HasherConfig.Validate(); err != nil {
+		return err
+	}
+	return c.CommandLine.Validate()
 }
 
 func (c *Config) effectiveStatePeriod() time.Duration {
@@ -40,4 +45,6 @@ var defaultConfig = Config{
 		ScanRatePerSec:      "50 MiB",
 		ScanRateBytesPerSec: 50 * 1024 * 1024,
 	},
+
+	CommandLine: defaultCommandLineConfig,
 }
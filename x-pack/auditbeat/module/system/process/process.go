@@ -97,6 +97,9 @@ type MetricSet struct {
 	lastState time.Time
 	hasher    *hasher.FileHasher
 
+	// commandLine is nil unless process.command_line.enabled is set.
+	commandLine *commandLineBuilder
+
 	suppressPermissionWarnings bool
 }
 
@@ -172,6 +175,12 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		hasher:          hasher,
 	}
 
+	if config.CommandLine.Enabled {
+		if ms.commandLine, err = newCommandLineBuilder(config.CommandLine); err != nil {
+			return nil, err
+		}
+	}
+
 	// Load from disk: Time when state was last sent
 	err = bucket.Load(bucketKeyStateTimestamp, func(blob []byte) error {
 		if len(blob) > 0 {
@@ -393,6 +402,16 @@ func (ms *MetricSet) processEvent(process *Process, eventType string, action eve
 		}
 	}
 
+	if ms.commandLine != nil && len(process.Info.Args) > 0 {
+		cl := ms.commandLine.build(process.Info.Args)
+		event.RootFields.Put("process.args", cl.args)
+		event.RootFields.Put("process.command_line", cl.value)
+		event.RootFields.Put("process.command_line_hash", cl.hash)
+		if cl.truncated {
+			event.RootFields.Put("process.command_line_truncated", true)
+		}
+	}
+
 	if process.Error != nil {
 		event.RootFields.Put("error.message", process.Error.Error())
 	}
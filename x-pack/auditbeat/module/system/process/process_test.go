@@ -106,6 +106,30 @@ func TestProcessEvent(t *testing.T) {
 	}
 }
 
+func TestProcessEventCommandLine(t *testing.T) {
+	config := getConfig()
+	config["process.command_line.enabled"] = true
+	config["process.command_line.max_length"] = 20
+	ms := mbtest.NewReportingMetricSetV2WithRegistry(t, config, ab.Registry).(*MetricSet)
+
+	p := testProcess()
+	p.Info.Args = []string{"zsh", "-c", "curl -u admin --password hunter2 http://localhost"}
+	event := ms.processEvent(p, eventTypeEvent, eventActionProcessStarted)
+
+	expectedRootFields := map[string]interface{}{
+		"process.args":                   []string{"zsh", "-c", "curl -u admin --password [REDACTED] http://localhost"},
+		"process.command_line":           "zsh -c curl -u admin",
+		"process.command_line_hash":      "371d457d455d9ee09bc14df9f0749279a8c9b64316f02d6a286421294111e174",
+		"process.command_line_truncated": true,
+	}
+	for expFieldName, expFieldValue := range expectedRootFields {
+		value, err := event.RootFields.GetValue(expFieldName)
+		if assert.NoErrorf(t, err, "error for field %v (value: %v)", expFieldName, expFieldValue) {
+			assert.Equalf(t, expFieldValue, value, "Unexpected value for field %v.", expFieldName)
+		}
+	}
+}
+
 func testProcess() *Process {
 	return &Process{
 		Info: types.ProcessInfo{
@@ -65,6 +65,13 @@ type Config struct {
 	// EnableIPv6 allows to control IPv6 support. When unset (default) IPv6
 	// will be automatically detected on runtime.
 	EnableIPv6 *bool `config:"socket.enable_ipv6"`
+
+	// ListeningEnabled enables periodic scanning of /proc/net to report
+	// listening sockets that are opened or closed.
+	ListeningEnabled bool `config:"socket.listening.enabled"`
+
+	// ListeningPeriod determines how often listening sockets are scanned.
+	ListeningPeriod time.Duration `config:"socket.listening.period,positive"`
 }
 
 // Validate validates the socket metricset config.
@@ -99,4 +106,5 @@ var defaultConfig = Config{
 	ClockMaxDrift:          100 * time.Millisecond,
 	ClockSyncPeriod:        10 * time.Second,
 	GuessTimeout:           15 * time.Second,
+	ListeningPeriod:        10 * time.Second,
 }
@@ -0,0 +1,353 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build (linux && 386) || (linux && amd64)
+
+package socket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/cache"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	// TCP_LISTEN in the st column of /proc/net/tcp{,6}.
+	procNetStateListen = 0x0A
+	// TCP_CLOSE in the st column of /proc/net/udp{,6}. Bound but unconnected
+	// UDP sockets are reported in this state.
+	procNetStateClose = 0x07
+)
+
+type listeningAction uint8
+
+const (
+	listeningSocketOpened listeningAction = iota
+	listeningSocketClosed
+)
+
+func (a listeningAction) String() string {
+	if a == listeningSocketOpened {
+		return "listening_socket_opened"
+	}
+	return "listening_socket_closed"
+}
+
+func (a listeningAction) Type() string {
+	if a == listeningSocketOpened {
+		return "start"
+	}
+	return "end"
+}
+
+// listeningSocket is a TCP or UDP socket waiting for incoming traffic, as
+// reported by /proc/net.
+type listeningSocket struct {
+	proto    flowProto
+	inetType inetType
+	ip       net.IP
+	port     uint16
+	uid      uint32
+	inode    uint64
+
+	// Owner process, resolved when the socket is first seen.
+	pid        int
+	name       string
+	executable string
+}
+
+// Hash implements cache.Cacheable.
+func (s *listeningSocket) Hash() uint64 {
+	h := xxhash.New()
+	var buf [8]byte
+	buf[0], buf[1] = byte(s.proto), byte(s.inetType)
+	binary.BigEndian.PutUint16(buf[2:], s.port)
+	binary.BigEndian.PutUint32(buf[4:], s.uid)
+	h.Write(buf[:])
+	h.Write(s.ip)
+	binary.BigEndian.PutUint64(buf[:], s.inode)
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+func (s *listeningSocket) toEvent(action listeningAction) mb.Event {
+	root := mapstr.M{
+		"event": mapstr.M{
+			"kind":     "event",
+			"action":   action.String(),
+			"category": []string{"network"},
+			"type":     []string{action.Type()},
+		},
+		"server": mapstr.M{
+			"ip":   s.ip.String(),
+			"port": int(s.port),
+		},
+		"network": mapstr.M{
+			"type":      s.inetType.String(),
+			"transport": s.proto.String(),
+		},
+		"related": mapstr.M{
+			"ip": []string{s.ip.String()},
+		},
+		"user": mapstr.M{
+			"id": strconv.Itoa(int(s.uid)),
+		},
+	}
+	if name := userCache.LookupID(strconv.Itoa(int(s.uid))); name != "" {
+		root.Put("user.name", name)
+		root.Put("related.user", []string{name})
+	}
+	if s.pid != 0 {
+		process := mapstr.M{
+			"pid": s.pid,
+		}
+		if s.name != "" {
+			process["name"] = s.name
+		}
+		if s.executable != "" {
+			process["executable"] = s.executable
+		}
+		root["process"] = process
+	}
+	return mb.Event{
+		RootFields: root,
+		MetricSetFields: mapstr.M{
+			"inode": s.inode,
+		},
+	}
+}
+
+// listeningScanner periodically snapshots the listening sockets of the host
+// and reports the ones that appeared or disappeared since the last snapshot.
+type listeningScanner struct {
+	procPath string
+	cache    *cache.Cache
+	log      *logp.Logger
+	primed   bool
+}
+
+func newListeningScanner(procPath string, log *logp.Logger) *listeningScanner {
+	return &listeningScanner{
+		procPath: procPath,
+		cache:    cache.New(),
+		log:      log,
+	}
+}
+
+// run scans every period until the reporter is closed.
+func (l *listeningScanner) run(period time.Duration, r mb.PushReporterV2) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if err := l.scan(r); err != nil {
+			l.log.Errorf("Failed to scan listening sockets: %v", err)
+		}
+		select {
+		case <-r.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan takes a snapshot of the listening sockets and reports the differences
+// with the previous one. The first snapshot only initializes the cache.
+func (l *listeningScanner) scan(r mb.PushReporterV2) error {
+	sockets, err := l.listeningSockets()
+	if err != nil {
+		return err
+	}
+	current := make([]cache.Cacheable, 0, len(sockets))
+	for _, s := range sockets {
+		current = append(current, s)
+	}
+	opened, closed := l.cache.DiffAndUpdateCache(current)
+	if !l.primed {
+		l.primed = true
+		l.log.Debugf("Found %d listening sockets", len(opened))
+		return nil
+	}
+
+	if len(opened) > 0 {
+		inodes := make(map[uint64]*listeningSocket, len(opened))
+		for _, v := range opened {
+			s := v.(*listeningSocket)
+			inodes[s.inode] = s
+		}
+		l.resolveOwners(inodes)
+	}
+	for _, v := range opened {
+		r.Event(v.(*listeningSocket).toEvent(listeningSocketOpened))
+	}
+	for _, v := range closed {
+		r.Event(v.(*listeningSocket).toEvent(listeningSocketClosed))
+	}
+	return nil
+}
+
+func (l *listeningScanner) listeningSockets() ([]*listeningSocket, error) {
+	sources := []struct {
+		file     string
+		proto    flowProto
+		inetType inetType
+	}{
+		{"tcp", protoTCP, inetTypeIPv4},
+		{"tcp6", protoTCP, inetTypeIPv6},
+		{"udp", protoUDP, inetTypeIPv4},
+		{"udp6", protoUDP, inetTypeIPv6},
+	}
+	var sockets []*listeningSocket
+	for _, src := range sources {
+		f, err := os.Open(filepath.Join(l.procPath, "net", src.file))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// IPv6 disabled.
+				continue
+			}
+			return nil, err
+		}
+		found, err := parseProcNet(f, src.proto, src.inetType)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing /proc/net/%s: %w", src.file, err)
+		}
+		sockets = append(sockets, found...)
+	}
+	return sockets, nil
+}
+
+// resolveOwners fills in the owner process of the given sockets, indexed by
+// inode, by looking for the socket in the file descriptors of every process.
+func (l *listeningScanner) resolveOwners(inodes map[uint64]*listeningSocket) {
+	pids, err := os.ReadDir(l.procPath)
+	if err != nil {
+		l.log.Debugf("Unable to list processes: %v", err)
+		return
+	}
+	pending := len(inodes)
+	for _, entry := range pids {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		fdPath := filepath.Join(l.procPath, entry.Name(), "fd")
+		fds, err := os.ReadDir(fdPath)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdPath, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(link[len("socket:["):], "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			s, found := inodes[inode]
+			if !found || s.pid != 0 {
+				continue
+			}
+			s.pid = pid
+			if comm, err := os.ReadFile(filepath.Join(l.procPath, entry.Name(), "comm")); err == nil {
+				s.name = strings.TrimSpace(string(comm))
+			}
+			if exe, err := os.Readlink(filepath.Join(l.procPath, entry.Name(), "exe")); err == nil {
+				s.executable = exe
+			}
+			if pending--; pending == 0 {
+				return
+			}
+		}
+	}
+}
+
+// parseProcNet parses the contents of /proc/net/{tcp,tcp6,udp,udp6} and
+// returns the sockets that are listening for incoming traffic.
+func parseProcNet(r io.Reader, proto flowProto, inet inetType) ([]*listeningSocket, error) {
+	var sockets []*listeningSocket
+	scanner := bufio.NewScanner(r)
+	// Skip header.
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		state, err := strconv.ParseUint(fields[3], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket state %q: %w", fields[3], err)
+		}
+		local, localPort, err := parseProcNetAddr(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		_, remotePort, err := parseProcNetAddr(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case proto == protoTCP && state == procNetStateListen:
+		case proto == protoUDP && state == procNetStateClose && remotePort == 0:
+		default:
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket uid %q: %w", fields[7], err)
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket inode %q: %w", fields[9], err)
+		}
+		sockets = append(sockets, &listeningSocket{
+			proto:    proto,
+			inetType: inet,
+			ip:       local,
+			port:     localPort,
+			uid:      uint32(uid),
+			inode:    inode,
+		})
+	}
+	return sockets, scanner.Err()
+}
+
+// parseProcNetAddr parses an address in /proc/net format. The IP is printed
+// as a sequence of 32-bit words in host byte order (little-endian for the
+// supported architectures) and the port in hex.
+func parseProcNetAddr(s string) (net.IP, uint16, error) {
+	ipPart, portPart, found := strings.Cut(s, ":")
+	if !found {
+		return nil, 0, fmt.Errorf("invalid address %q", s)
+	}
+	raw, err := hex.DecodeString(ipPart)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, 0, fmt.Errorf("invalid address %q", s)
+	}
+	port, err := strconv.ParseUint(portPart, 16, 16)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid port in address %q: %w", s, err)
+	}
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+	return ip, uint16(port), nil
+}
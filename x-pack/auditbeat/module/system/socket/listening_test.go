@@ -0,0 +1,130 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build (linux && 386) || (linux && amd64)
+
+package socket
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	procNetTCPHeader = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"
+	procNetSSH       = "   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 21034 1 0000000000000000 100 0 0 10 0\n"
+	procNetHTTP      = "   1: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 43110 1 0000000000000000 100 0 0 10 0\n"
+	procNetEstab     = "   2: 0A00020F:0016 0102000A:D431 01 00000000:00000000 02:0009A3E1 00000000     0        0 44221 4 0000000000000000 20 4 31 10 21\n"
+	procNetTCP6SSH   = "   0: 00000000000000000000000000000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 21036 1 0000000000000000 100 0 0 10 0\n"
+	procNetUDPHeader = "   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops\n"
+	procNetDNS       = "  512: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 20190 2 0000000000000000 0\n"
+	procNetUDPConn   = "  600: 0A00020F:C2B1 08080808:0035 01 00000000:00000000 00:00000000 00000000  1000        0 51000 2 0000000000000000 0\n"
+)
+
+type listeningReporter struct {
+	events []mb.Event
+}
+
+func (r *listeningReporter) Event(event mb.Event) bool {
+	r.events = append(r.events, event)
+	return true
+}
+
+func (r *listeningReporter) Error(error) bool {
+	return true
+}
+
+func (r *listeningReporter) Done() <-chan struct{} {
+	return nil
+}
+
+func TestParseProcNet(t *testing.T) {
+	sockets, err := parseProcNet(strings.NewReader(procNetTCPHeader+procNetSSH+procNetHTTP+procNetEstab), protoTCP, inetTypeIPv4)
+	require.NoError(t, err)
+	require.Len(t, sockets, 2)
+	assert.Equal(t, &listeningSocket{proto: protoTCP, inetType: inetTypeIPv4, ip: net.IPv4zero.To4(), port: 22, inode: 21034}, sockets[0])
+	assert.Equal(t, &listeningSocket{proto: protoTCP, inetType: inetTypeIPv4, ip: net.IPv4(127, 0, 0, 1).To4(), port: 8080, uid: 1000, inode: 43110}, sockets[1])
+
+	sockets, err = parseProcNet(strings.NewReader(procNetTCPHeader+procNetTCP6SSH), protoTCP, inetTypeIPv6)
+	require.NoError(t, err)
+	require.Len(t, sockets, 1)
+	assert.Equal(t, "::", sockets[0].ip.String())
+
+	sockets, err = parseProcNet(strings.NewReader(procNetUDPHeader+procNetDNS+procNetUDPConn), protoUDP, inetTypeIPv4)
+	require.NoError(t, err)
+	require.Len(t, sockets, 1)
+	assert.Equal(t, "127.0.0.53", sockets[0].ip.String())
+	assert.EqualValues(t, 53, sockets[0].port)
+	assert.EqualValues(t, 101, sockets[0].uid)
+
+	_, err = parseProcNet(strings.NewReader(procNetTCPHeader+"   0: 0000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 21034 1\n"), protoTCP, inetTypeIPv4)
+	assert.Error(t, err)
+}
+
+func TestListeningScanner(t *testing.T) {
+	procPath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(procPath, "net"), 0o755))
+	writeProcNet := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(procPath, "net", name), []byte(content), 0o644))
+	}
+
+	writeProcNet("tcp", procNetTCPHeader+procNetSSH+procNetEstab)
+	writeProcNet("udp", procNetUDPHeader+procNetDNS)
+
+	scanner := newListeningScanner(procPath, logp.NewLogger("test"))
+	r := &listeningReporter{}
+
+	// The first scan only records the existing sockets.
+	require.NoError(t, scanner.scan(r))
+	assert.Empty(t, r.events)
+
+	writeProcNet("tcp", procNetTCPHeader+procNetSSH+procNetHTTP)
+	writeProcNet("udp", procNetUDPHeader)
+	require.NoError(t, scanner.scan(r))
+	require.Len(t, r.events, 2)
+
+	byAction := map[string]mb.Event{}
+	for _, ev := range r.events {
+		action, err := ev.RootFields.GetValue("event.action")
+		require.NoError(t, err)
+		byAction[action.(string)] = ev
+	}
+
+	opened := byAction["listening_socket_opened"].RootFields
+	assert.Equal(t, "127.0.0.1", get(t, opened, "server.ip"))
+	assert.Equal(t, 8080, get(t, opened, "server.port"))
+	assert.Equal(t, "tcp", get(t, opened, "network.transport"))
+	assert.Equal(t, "ipv4", get(t, opened, "network.type"))
+	assert.Equal(t, "1000", get(t, opened, "user.id"))
+	assert.Equal(t, []string{"start"}, get(t, opened, "event.type"))
+
+	closed := byAction["listening_socket_closed"].RootFields
+	assert.Equal(t, "127.0.0.53", get(t, closed, "server.ip"))
+	assert.Equal(t, 53, get(t, closed, "server.port"))
+	assert.Equal(t, "udp", get(t, closed, "network.transport"))
+	assert.Equal(t, []string{"end"}, get(t, closed, "event.type"))
+	assert.EqualValues(t, 20190, byAction["listening_socket_closed"].MetricSetFields["inode"])
+
+	// No changes, no events.
+	r.events = nil
+	require.NoError(t, scanner.scan(r))
+	assert.Empty(t, r.events)
+}
+
+func get(t *testing.T, m mapstr.M, key string) interface{} {
+	t.Helper()
+	v, err := m.GetValue(key)
+	require.NoError(t, err, key)
+	return v
+}
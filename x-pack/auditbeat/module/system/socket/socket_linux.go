@@ -183,6 +183,11 @@ func (m *MetricSet) Run(r mb.PushReporterV2) {
 	// Launch the clock-synchronization ticker.
 	go m.clockSyncLoop(m.config.ClockSyncPeriod, r.Done())
 
+	if m.config.ListeningEnabled {
+		scanner := newListeningScanner("/proc", m.log.Named("listening"))
+		go scanner.run(m.config.ListeningPeriod, r)
+	}
+
 	if procs, err := sysinfo.Processes(); err != nil {
 		m.log.Error("Failed to bootstrap process table using /proc", err)
 	} else {
@@ -0,0 +1,182 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const backfillStatePrefix = "filebeat::aws-cloudwatch::backfill::"
+
+// nextTokenTTL is how long the tokens of the pages returned by
+// FilterLogEvents can be used.
+const nextTokenTTL = 24 * time.Hour
+
+// backfillState is the position of the backfill of a log group, saved in the
+// registry once the events before it are acknowledged.
+type backfillState struct {
+	// StartTime and EndTime are the settings the backfill was started with,
+	// the backfill starts over when they are changed.
+	StartTime string    `json:"start_time" struct:"start_time"`
+	EndTime   string    `json:"end_time" struct:"end_time"`
+	End       time.Time `json:"end" struct:"end"`
+
+	// Since is the start of the window being collected, and NextToken the
+	// token of its next page, empty at the start of the window.
+	Since     time.Time `json:"since" struct:"since"`
+	NextToken string    `json:"next_token" struct:"next_token"`
+	Updated   time.Time `json:"updated" struct:"updated"`
+	Done      bool      `json:"done" struct:"done"`
+}
+
+// backfiller collects the log events of the log groups stored between the
+// backfill start and end times, window by window.
+type backfiller struct {
+	config    backfillConfig
+	inputID   string
+	region    string
+	apiSleep  time.Duration
+	client    cloudwatchlogs.FilterLogEventsAPIClient
+	store     *statestore.Store
+	processor *logProcessor
+	metrics   *inputMetrics
+	logger    *logp.Logger
+
+	// filterInput builds the FilterLogEvents request of a window, with the
+	// log streams filters of the input.
+	filterInput func(startTime, endTime time.Time, logGroup string) *cloudwatchlogs.FilterLogEventsInput
+	now         func() time.Time
+}
+
+// run backfills the log groups one after the other. The log groups that
+// failed are resumed from their saved position when the input is started
+// again.
+func (b *backfiller) run(ctx context.Context, logGroups []string) {
+	for _, logGroup := range logGroups {
+		if err := b.backfillLogGroup(ctx, logGroup); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.logger.Errorw("failed to backfill log group, it is resumed from its last position when the input is restarted",
+				"log_group", logGroup, "error", err)
+		}
+	}
+}
+
+func (b *backfiller) backfillLogGroup(ctx context.Context, logGroup string) error {
+	key := backfillStatePrefix + b.inputID + "::" + logGroup
+	st, err := b.loadState(key)
+	if err != nil {
+		return err
+	}
+	if st.Done {
+		b.logger.Debugw("log group is already backfilled", "log_group", logGroup)
+		return nil
+	}
+
+	log := b.logger.With("log_group", logGroup)
+	log.Infow("backfilling log group", "since", st.Since, "end", st.End)
+	for st.Since.Before(st.End) {
+		windowEnd := st.Since.Add(b.config.Window)
+		if windowEnd.After(st.End) {
+			windowEnd = st.End
+		}
+		input := b.filterInput(st.Since, windowEnd, logGroup)
+		if st.NextToken != "" && b.now().Sub(st.Updated) < nextTokenTTL {
+			input.NextToken = &st.NextToken
+		}
+
+		for {
+			out, err := b.client.FilterLogEvents(ctx, input)
+			if err != nil {
+				return fmt.Errorf("error FilterLogEvents: %w", err)
+			}
+			b.metrics.apiCallsTotal.Inc()
+			b.metrics.logEventsReceivedTotal.Add(uint64(len(out.Events)))
+
+			ack := awscommon.NewEventACKTracker(ctx)
+			b.processor.processLogEvents(out.Events, logGroup, b.region, ack)
+			ack.Wait()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if out.NextToken == nil {
+				break
+			}
+			st.NextToken, st.Updated = *out.NextToken, b.now()
+			if err := b.store.Set(key, st); err != nil {
+				return fmt.Errorf("failed to save backfill position: %w", err)
+			}
+			input.NextToken = out.NextToken
+			if !b.sleep(ctx) {
+				return ctx.Err()
+			}
+		}
+
+		st.Since, st.NextToken, st.Updated = windowEnd, "", b.now()
+		if err := b.store.Set(key, st); err != nil {
+			return fmt.Errorf("failed to save backfill position: %w", err)
+		}
+		log.Debugw("backfilled window", "until", windowEnd)
+		if !b.sleep(ctx) {
+			return ctx.Err()
+		}
+	}
+
+	st.Done = true
+	if err := b.store.Set(key, st); err != nil {
+		return fmt.Errorf("failed to save backfill position: %w", err)
+	}
+	log.Info("log group backfilled")
+	return nil
+}
+
+// loadState returns the saved position of the backfill of a log group, or
+// its start if the backfill settings were changed.
+func (b *backfiller) loadState(key string) (backfillState, error) {
+	var st backfillState
+	has, err := b.store.Has(key)
+	if err != nil {
+		return st, fmt.Errorf("failed to read backfill position: %w", err)
+	}
+	if has {
+		if err := b.store.Get(key, &st); err != nil {
+			b.logger.Warnw("invalid backfill position, starting over", "key", key, "error", err)
+		} else if st.StartTime == b.config.StartTime && st.EndTime == b.config.EndTime {
+			return st, nil
+		}
+	}
+
+	end := b.config.end
+	if end.IsZero() {
+		end = b.now()
+	}
+	return backfillState{
+		StartTime: b.config.StartTime,
+		EndTime:   b.config.EndTime,
+		End:       end,
+		Since:     b.config.start,
+	}, nil
+}
+
+// sleep waits between the API calls, to stay under the FilterLogEvents rate
+// limit shared with the poller. It returns false if the context is cancelled.
+func (b *backfiller) sleep(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(b.apiSleep):
+		return true
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/storetest"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// fakeFilterLogEvents returns the pages of log events of the windows,
+// indexed by the start time of the window and the page token.
+type fakeFilterLogEvents struct {
+	pages map[string]*cloudwatchlogs.FilterLogEventsOutput
+	fail  map[string]bool
+	calls []string
+}
+
+func (f *fakeFilterLogEvents) FilterLogEvents(_ context.Context, params *cloudwatchlogs.FilterLogEventsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	key := time.UnixMilli(*params.StartTime).UTC().Format(time.RFC3339) + "/" + awssdk.ToString(params.NextToken)
+	f.calls = append(f.calls, key)
+	if f.fail[key] {
+		return nil, errors.New("throttled")
+	}
+	if out, ok := f.pages[key]; ok {
+		return out, nil
+	}
+	return &cloudwatchlogs.FilterLogEventsOutput{}, nil
+}
+
+func logEvents(ids ...string) []types.FilteredLogEvent {
+	var events []types.FilteredLogEvent
+	for _, id := range ids {
+		events = append(events, types.FilteredLogEvent{
+			EventId:       awssdk.String(id),
+			LogStreamName: awssdk.String("stream"),
+			Message:       awssdk.String("message " + id),
+			Timestamp:     awssdk.Int64(1700000000000),
+			IngestionTime: awssdk.Int64(1700000000000),
+		})
+	}
+	return events
+}
+
+func TestBackfill(t *testing.T) {
+	registry := statestore.NewRegistry(storetest.NewMemoryStoreBackend())
+	defer func() { _ = registry.Close() }()
+	store, err := registry.Get("filebeat")
+	require.NoError(t, err)
+	defer store.Close()
+
+	cfg := defaultConfig()
+	cfg.Backfill.StartTime = "2024-01-01T00:00:00Z"
+	cfg.Backfill.EndTime = "2024-01-01T02:00:00Z"
+	require.NoError(t, cfg.Backfill.Validate())
+
+	api := &fakeFilterLogEvents{
+		pages: map[string]*cloudwatchlogs.FilterLogEventsOutput{
+			"2024-01-01T00:00:00Z/":      {Events: logEvents("1", "2"), NextToken: awssdk.String("page2")},
+			"2024-01-01T00:00:00Z/page2": {Events: logEvents("3")},
+			"2024-01-01T01:00:00Z/":      {Events: logEvents("4")},
+		},
+		fail: map[string]bool{"2024-01-01T01:00:00Z/": true},
+	}
+
+	client, events := ackingClient()
+	newBackfiller := func() *backfiller {
+		metrics := newInputMetrics("", nil)
+		poller := newCloudwatchPoller(logp.NewLogger(inputName), metrics, "us-east-1", cfg)
+		return &backfiller{
+			config:      cfg.Backfill,
+			inputID:     "test",
+			region:      "us-east-1",
+			apiSleep:    time.Millisecond,
+			client:      api,
+			store:       store,
+			processor:   newLogProcessor(logp.NewLogger(inputName), metrics, client, context.Background()),
+			metrics:     metrics,
+			logger:      logp.NewLogger(inputName),
+			filterInput: poller.constructFilterLogEventsInput,
+			now:         time.Now,
+		}
+	}
+
+	// The second window fails, the backfill stops after the first one.
+	newBackfiller().run(context.Background(), []string{"group"})
+	assert.Equal(t, []string{"2024-01-01T00:00:00Z/", "2024-01-01T00:00:00Z/page2", "2024-01-01T01:00:00Z/"}, api.calls)
+	require.Len(t, *events, 3)
+
+	var st backfillState
+	require.NoError(t, store.Get(backfillStatePrefix+"test::group", &st))
+	assert.False(t, st.Done)
+	assert.Equal(t, "2024-01-01T01:00:00Z", st.Since.UTC().Format(time.RFC3339))
+
+	// The backfill resumes from the second window.
+	api.fail = nil
+	api.calls = nil
+	newBackfiller().run(context.Background(), []string{"group"})
+	assert.Equal(t, []string{"2024-01-01T01:00:00Z/"}, api.calls)
+	require.Len(t, *events, 4)
+	assert.Equal(t, "4", (*events)[3].Meta["_id"])
+
+	require.NoError(t, store.Get(backfillStatePrefix+"test::group", &st))
+	assert.True(t, st.Done)
+
+	// A completed backfill is not run again.
+	api.calls = nil
+	newBackfiller().run(context.Background(), []string{"group"})
+	assert.Empty(t, api.calls)
+}
+
+func TestBackfillConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		config backfillConfig
+		err    bool
+	}{
+		"disabled":      {config: backfillConfig{}},
+		"open end":      {config: backfillConfig{StartTime: "2024-01-01T00:00:00Z"}},
+		"range":         {config: backfillConfig{StartTime: "2024-01-01T00:00:00Z", EndTime: "2024-01-02T00:00:00Z"}},
+		"end before":    {config: backfillConfig{StartTime: "2024-01-02T00:00:00Z", EndTime: "2024-01-01T00:00:00Z"}, err: true},
+		"invalid start": {config: backfillConfig{StartTime: "yesterday"}, err: true},
+		"end only":      {config: backfillConfig{EndTime: "2024-01-01T00:00:00Z"}, err: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -6,10 +6,23 @@ package awscloudwatch
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/elastic/beats/v7/filebeat/harvester"
 	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+const (
+	modePoll         = "poll"
+	modeSubscription = "subscription"
+
+	sourceFirehose = "firehose"
+	sourceKinesis  = "kinesis"
+
+	startPositionLatest      = "latest"
+	startPositionTrimHorizon = "trim_horizon"
 )
 
 type config struct {
@@ -27,6 +40,88 @@ type config struct {
 	Latency                   time.Duration       `config:"latency"`
 	NumberOfWorkers           int                 `config:"number_of_workers"`
 	AWSConfig                 awscommon.ConfigAWS `config:",inline"`
+
+	// Mode is how the live log events are collected, poll with the
+	// FilterLogEvents API or subscription to receive the records delivered
+	// by a subscription filter.
+	Mode         string             `config:"mode"`
+	Subscription subscriptionConfig `config:"subscription"`
+	Backfill     backfillConfig     `config:"backfill"`
+}
+
+// subscriptionConfig configures the service delivering the records of the
+// subscription filters of the log groups.
+type subscriptionConfig struct {
+	// Source is the service delivering the records, firehose or kinesis.
+	Source   string         `config:"source"`
+	Firehose firehoseConfig `config:"firehose"`
+	Kinesis  kinesisConfig  `config:"kinesis"`
+}
+
+// firehoseConfig configures the HTTP endpoint receiving the records from a
+// Kinesis Data Firehose delivery stream.
+type firehoseConfig struct {
+	Host string `config:"host"`
+	Port int    `config:"port"`
+	// AccessKey must match the access key configured in the HTTP endpoint
+	// destination of the delivery stream, when set.
+	AccessKey   string                  `config:"access_key"`
+	MaxBodySize int64                   `config:"max_body_size" validate:"min=1"`
+	TLS         *tlscommon.ServerConfig `config:"ssl"`
+}
+
+// kinesisConfig configures the consumption of the records from a Kinesis
+// data stream.
+type kinesisConfig struct {
+	StreamName    string        `config:"stream_name"`
+	StartPosition string        `config:"start_position"`
+	PollInterval  time.Duration `config:"poll_interval" validate:"min=0"`
+	// ShardRefreshInterval is how often the shards of the stream are listed,
+	// to consume the shards created by resharding.
+	ShardRefreshInterval time.Duration `config:"shard_refresh_interval" validate:"min=0"`
+	MaxRecords           int32         `config:"max_records" validate:"min=1,max=10000"`
+}
+
+// backfillConfig configures the collection of the log events stored between
+// two timestamps, before the input was started.
+type backfillConfig struct {
+	StartTime string `config:"start_time"`
+	// EndTime defaults to the time the input is started.
+	EndTime string `config:"end_time"`
+	// Window is the time range of the log events requested at once. The
+	// position in the current window is saved in the registry, so that the
+	// backfill resumes from it after a restart.
+	Window time.Duration `config:"window" validate:"min=0,nonzero"`
+
+	start, end time.Time
+}
+
+func (c *backfillConfig) enabled() bool {
+	return c.StartTime != ""
+}
+
+func (c *backfillConfig) Validate() error {
+	if !c.enabled() {
+		if c.EndTime != "" {
+			return errors.New("backfill.end_time requires backfill.start_time")
+		}
+		return nil
+	}
+	var err error
+	c.start, err = time.Parse(time.RFC3339, c.StartTime)
+	if err != nil {
+		return fmt.Errorf("invalid backfill.start_time: %w", err)
+	}
+	if c.EndTime != "" {
+		c.end, err = time.Parse(time.RFC3339, c.EndTime)
+		if err != nil {
+			return fmt.Errorf("invalid backfill.end_time: %w", err)
+		}
+		if !c.end.After(c.start) {
+			return errors.New("backfill.end_time must be after backfill.start_time")
+		}
+	}
+	return nil
 }
 
 func defaultConfig() config {
@@ -39,6 +134,24 @@ func defaultConfig() config {
 		APITimeout:      120 * time.Second,
 		APISleep:        200 * time.Millisecond, // FilterLogEvents has a limit of 5 transactions per second (TPS)/account/Region: 1s / 5 = 200 ms
 		NumberOfWorkers: 1,
+		Mode:            modePoll,
+		Subscription: subscriptionConfig{
+			Source: sourceFirehose,
+			Firehose: firehoseConfig{
+				Host:        "localhost",
+				Port:        8080,
+				MaxBodySize: 64 * 1024 * 1024,
+			},
+			Kinesis: kinesisConfig{
+				StartPosition:        startPositionLatest,
+				PollInterval:         time.Second,
+				ShardRefreshInterval: time.Minute,
+				MaxRecords:           1000,
+			},
+		},
+		Backfill: backfillConfig{
+			Window: time.Hour,
+		},
 	}
 }
 
@@ -48,6 +161,21 @@ func (c *config) Validate() error {
 			"either 'beginning' or 'end'")
 	}
 
+	switch c.Mode {
+	case modePoll:
+	case modeSubscription:
+		if err := c.Subscription.validate(); err != nil {
+			return err
+		}
+		if !c.Backfill.enabled() {
+			// The log groups are only needed to backfill them, the
+			// records of the subscription filters hold their log group.
+			return nil
+		}
+	default:
+		return fmt.Errorf("invalid mode %q, must be poll or subscription", c.Mode)
+	}
+
 	if c.LogGroupARN == "" && c.LogGroupName == "" && c.LogGroupNamePrefix == "" {
 		return errors.New("log_group_arn, log_group_name and log_group_name_prefix config parameter " +
 			"cannot all be empty")
@@ -63,3 +191,21 @@ func (c *config) Validate() error {
 	}
 	return nil
 }
+
+func (c *subscriptionConfig) validate() error {
+	switch c.Source {
+	case sourceFirehose:
+	case sourceKinesis:
+		if c.Kinesis.StreamName == "" {
+			return errors.New("subscription.kinesis.stream_name is required when subscription.source is kinesis")
+		}
+		switch c.Kinesis.StartPosition {
+		case startPositionLatest, startPositionTrimHorizon:
+		default:
+			return fmt.Errorf("invalid subscription.kinesis.start_position %q, must be latest or trim_horizon", c.Kinesis.StartPosition)
+		}
+	default:
+		return fmt.Errorf("invalid subscription.source %q, must be firehose or kinesis", c.Source)
+	}
+	return nil
+}
@@ -19,5 +19,5 @@ func init() {
 // AssetAwscloudwatch returns asset data.
 // This is the base64 encoded zlib format compressed contents of input/awscloudwatch.
 func AssetAwscloudwatch() string {
-	return "eJy8k81q4zAUhfd+ikOWAzHZjhYDQ0tfoC1ZBtW6tkVkyUjXNXn7ItskctpAWptqqZ+j74NztzjSSUD2YVsY16leclFnAGs2JLD5v3/GQzzYx4NNBigKhdcta2cF/mUA8KTJqIDSuwbzBzCuCnkGlMMVMVzfwsqGhk9nf8alqPVUSCYl8Dff5bvzfik7w4chR4B9R9MJn1oSqLzr2vPdT4R3Uo7rMWF4DRQ58wto/gfaBiappgepWqpnXHVIsS6wRzr1zqtk/wZyXC81DYFwJbimGDvagh36Whc1uNYB9E6W8UbG2VTmDqEz6JXYXCWwJ9ms7zLmriozRd6y0baiEPEOrBtabhRTBp2Rupdh+oIUtL3q2rdk5qQzoWSI8i+m6LemZfXyL6z78sYu7eg6LftBrz4CAAD//yvmwZQ="
+	return "eNq9k0FOwzAQRfc9xahLRKNuyQIJgbgAoC4rN540Fo4d2ZNGvT3jJKROaaVCIryyPOM/71vfK/jEYwqi8atM21o2grJiAUCKNKawfNq8wXMobEJhyRWJPnOqImVNCo98APCqUEsPubMljC+AtnufcFPetqRt+wqMKLEdOpoZlsTKYSYIZQoPyTpZD+e5qDVtW50UyNXYV+hYsdbe2boaen8Q3kjZrZeI4cNj4ExOoMkdKOMJhewvxNZieyy6jbFOsPzkjXUyOr+CHNZ7ga0g2ByI9yzbuQWy0BSK8alQHvCAhmCH2prYzA2GBtAzY2MrnhyKcn4vne6sZnrJa26U2aMPeFtSJU53FFRaOx11I3w/AiVvzrL2KzNj0pGh6BMlF37Rf/2W2cM/Me7TEzs1o/OkbEKuvgF8vRsEOQma0Pm/YMQ60OswliBu1eqAjnEGynt+ITSgCPiFONrIDbJLkbiklCy+AEds/qk="
 }
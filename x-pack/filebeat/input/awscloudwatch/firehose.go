@@ -0,0 +1,168 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// accessKeyHeader is the header holding the access key configured in the
+// HTTP endpoint destination of a delivery stream.
+const accessKeyHeader = "X-Amz-Firehose-Access-Key"
+
+// firehoseRequest is the body of the requests sent by Kinesis Data Firehose
+// to an HTTP endpoint destination.
+type firehoseRequest struct {
+	RequestID string `json:"requestId"`
+	Timestamp int64  `json:"timestamp"`
+	Records   []struct {
+		// Data is decoded from base64 by encoding/json.
+		Data []byte `json:"data"`
+	} `json:"records"`
+}
+
+// firehoseResponse is the body of the responses expected by Kinesis Data
+// Firehose. Requests answered with an error are retried by the delivery
+// stream.
+type firehoseResponse struct {
+	RequestID    string `json:"requestId"`
+	Timestamp    int64  `json:"timestamp"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// firehoseSource is an HTTP endpoint receiving the records of subscription
+// filters from a Kinesis Data Firehose delivery stream.
+type firehoseSource struct {
+	config firehoseConfig
+	logger *logp.Logger
+}
+
+func newFirehoseSource(config firehoseConfig, logger *logp.Logger) *firehoseSource {
+	return &firehoseSource{config: config, logger: logger}
+}
+
+func (s *firehoseSource) run(ctx context.Context, handler recordsHandler) error {
+	server := &http.Server{
+		Addr:              net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port)),
+		Handler:           &firehoseHandler{config: s.config, handler: handler, logger: s.logger},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if s.config.TLS.IsEnabled() {
+		tlsConfig, err := tlscommon.LoadTLSServerConfig(s.config.TLS)
+		if err != nil {
+			return fmt.Errorf("failed to load ssl settings: %w", err)
+		}
+		server.TLSConfig = tlsConfig.BuildServerConfig(s.config.Host)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		case <-done:
+		}
+	}()
+
+	s.logger.Infow("starting firehose endpoint", "address", server.Addr)
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// firehoseHandler handles the requests of a delivery stream. Requests are
+// answered once their events are acknowledged, so that the delivery stream
+// retries them if the events could not be published.
+type firehoseHandler struct {
+	config  firehoseConfig
+	handler recordsHandler
+	logger  *logp.Logger
+}
+
+func (h *firehoseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := r.Header.Get("X-Amz-Firehose-Request-Id")
+	if r.Method != http.MethodPost {
+		h.respond(w, http.StatusMethodNotAllowed, requestID, "only POST requests are supported")
+		return
+	}
+	if h.config.AccessKey != "" {
+		key := r.Header.Get(accessKeyHeader)
+		if subtle.ConstantTimeCompare([]byte(key), []byte(h.config.AccessKey)) != 1 {
+			h.respond(w, http.StatusUnauthorized, requestID, "invalid access key")
+			return
+		}
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, h.config.MaxBodySize)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			h.respond(w, http.StatusBadRequest, requestID, fmt.Sprintf("failed to decompress body: %v", err))
+			return
+		}
+		defer gz.Close()
+		body = io.LimitReader(gz, h.config.MaxBodySize)
+	}
+
+	var req firehoseRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.respond(w, http.StatusRequestEntityTooLarge, requestID, "request body is too large")
+			return
+		}
+		h.respond(w, http.StatusBadRequest, requestID, fmt.Sprintf("failed to decode body: %v", err))
+		return
+	}
+	if req.RequestID != "" {
+		requestID = req.RequestID
+	}
+
+	data := make([][]byte, 0, len(req.Records))
+	for _, rec := range req.Records {
+		data = append(data, rec.Data)
+	}
+	if !h.handler(data) {
+		h.respond(w, http.StatusServiceUnavailable, requestID, "input is stopping")
+		return
+	}
+	h.respond(w, http.StatusOK, requestID, "")
+}
+
+func (h *firehoseHandler) respond(w http.ResponseWriter, status int, requestID, message string) {
+	if message != "" {
+		h.logger.Debugw("rejecting firehose request", "request_id", requestID, "status", status, "error", message)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(firehoseResponse{
+		RequestID:    requestID,
+		Timestamp:    time.Now().UnixMilli(),
+		ErrorMessage: message,
+	})
+}
@@ -8,18 +8,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 
+	"github.com/elastic/beats/v7/filebeat/beater"
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
 	"github.com/elastic/beats/v7/libbeat/feature"
 	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/go-concert/unison"
 )
 
@@ -27,17 +30,18 @@ const (
 	inputName = "aws-cloudwatch"
 )
 
-func Plugin() v2.Plugin {
+func Plugin(store beater.StateStore) v2.Plugin {
 	return v2.Plugin{
 		Name:       inputName,
 		Stability:  feature.Stable,
 		Deprecated: false,
 		Info:       "Collect logs from cloudwatch",
-		Manager:    &cloudwatchInputManager{},
+		Manager:    &cloudwatchInputManager{store: store},
 	}
 }
 
 type cloudwatchInputManager struct {
+	store beater.StateStore
 }
 
 func (im *cloudwatchInputManager) Init(grp unison.Group) error {
@@ -50,17 +54,19 @@ func (im *cloudwatchInputManager) Create(cfg *conf.C) (v2.Input, error) {
 		return nil, err
 	}
 
-	return newInput(config)
+	return newInput(config, im.store)
 }
 
-// cloudwatchInput is an input for reading logs from CloudWatch periodically.
+// cloudwatchInput is an input for reading logs from CloudWatch periodically,
+// or receiving them from subscription filters.
 type cloudwatchInput struct {
 	config    config
 	awsConfig awssdk.Config
+	store     beater.StateStore
 	metrics   *inputMetrics
 }
 
-func newInput(config config) (*cloudwatchInput, error) {
+func newInput(config config, store beater.StateStore) (*cloudwatchInput, error) {
 	cfgwarn.Beta("aws-cloudwatch input type is used")
 	awsConfig, err := awscommon.InitializeAWSConfig(config.AWSConfig)
 	if err != nil {
@@ -84,6 +90,7 @@ func newInput(config config) (*cloudwatchInput, error) {
 	return &cloudwatchInput{
 		config:    config,
 		awsConfig: awsConfig,
+		store:     store,
 	}, nil
 }
 
@@ -97,7 +104,9 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 	ctx := v2.GoContextFromCanceler(inputContext.Cancelation)
 
 	// Create client for publishing events and receive notification of their ACKs.
-	client, err := pipeline.ConnectWith(beat.ClientConfig{})
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		EventListener: awscommon.NewEventACKHandler(),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create pipeline client: %w", err)
 	}
@@ -109,9 +118,12 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 		}
 	})
 
-	logGroupNames, err := getLogGroupNames(svc, in.config.LogGroupNamePrefix, in.config.LogGroupName)
-	if err != nil {
-		return fmt.Errorf("failed to get log group names: %w", err)
+	var logGroupNames []string
+	if in.config.Mode == modePoll || in.config.Backfill.enabled() {
+		logGroupNames, err = getLogGroupNames(svc, in.config.LogGroupNamePrefix, in.config.LogGroupName)
+		if err != nil {
+			return fmt.Errorf("failed to get log group names: %w", err)
+		}
 	}
 
 	log := inputContext.Logger
@@ -124,11 +136,71 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 		in.config)
 	logProcessor := newLogProcessor(log.Named("log_processor"), in.metrics, client, ctx)
 	cwPoller.metrics.logGroupsTotal.Add(uint64(len(logGroupNames)))
+
+	if in.config.Backfill.enabled() {
+		store, err := in.store.Access()
+		if err != nil {
+			return fmt.Errorf("can't access persistent store: %w", err)
+		}
+		defer store.Close()
+
+		b := &backfiller{
+			config:      in.config.Backfill,
+			inputID:     inputContext.ID,
+			region:      in.awsConfig.Region,
+			apiSleep:    in.config.APISleep,
+			client:      svc,
+			store:       store,
+			processor:   logProcessor,
+			metrics:     in.metrics,
+			logger:      log.Named("backfill"),
+			filterInput: cwPoller.constructFilterLogEventsInput,
+			now:         time.Now,
+		}
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.run(ctx, logGroupNames)
+		}()
+	}
+
+	if in.config.Mode == modeSubscription {
+		return in.runSubscription(ctx, logProcessor, log)
+	}
 	cwPoller.startWorkers(ctx, svc, logProcessor)
 	cwPoller.receive(ctx, logGroupNames, time.Now)
 	return nil
 }
 
+// runSubscription publishes the log events of the records of the
+// subscription filters, until the input is stopped.
+func (in *cloudwatchInput) runSubscription(ctx context.Context, logProcessor *logProcessor, log *logp.Logger) error {
+	handler := &subscriptionHandler{
+		region:    in.awsConfig.Region,
+		processor: logProcessor,
+		metrics:   in.metrics,
+		logger:    log.Named("subscription"),
+	}
+
+	var source subscriptionSource
+	switch in.config.Subscription.Source {
+	case sourceKinesis:
+		source = newKinesisSource(in.config.Subscription.Kinesis, newKinesisClient(in.awsConfig, in.config.AWSConfig), log.Named("kinesis"))
+	default:
+		source = newFirehoseSource(in.config.Subscription.Firehose, log.Named("firehose"))
+	}
+
+	err := source.run(ctx, func(records [][]byte) bool {
+		return handler.handle(ctx, records)
+	})
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to receive subscription filter records: %w", err)
+	}
+	return nil
+}
+
 func parseARN(logGroupARN string) (string, string, error) {
 	arnParsed, err := arn.Parse(logGroupARN)
 	if err != nil {
@@ -16,6 +16,9 @@ type inputMetrics struct {
 	logGroupsTotal               *monitoring.Uint // Logs collected from number of CloudWatch log groups.
 	cloudwatchEventsCreatedTotal *monitoring.Uint // Number of events created from processing logs from CloudWatch.
 	apiCallsTotal                *monitoring.Uint // Number of API calls made total.
+
+	subscriptionRecordsReceivedTotal *monitoring.Uint // Number of subscription filter records received.
+	subscriptionRecordsInvalidTotal  *monitoring.Uint // Number of subscription filter records dropped because they could not be decoded.
 }
 
 // Close removes the metrics from the registry.
@@ -32,6 +35,9 @@ func newInputMetrics(id string, optionalParent *monitoring.Registry) *inputMetri
 		logGroupsTotal:               monitoring.NewUint(reg, "log_groups_total"),
 		cloudwatchEventsCreatedTotal: monitoring.NewUint(reg, "cloudwatch_events_created_total"),
 		apiCallsTotal:                monitoring.NewUint(reg, "api_calls_total"),
+
+		subscriptionRecordsReceivedTotal: monitoring.NewUint(reg, "subscription_records_received_total"),
+		subscriptionRecordsInvalidTotal:  monitoring.NewUint(reg, "subscription_records_invalid_total"),
 	}
 	return out
 }
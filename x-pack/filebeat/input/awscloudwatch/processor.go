@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -32,14 +33,23 @@ func newLogProcessor(log *logp.Logger, metrics *inputMetrics, publisher beat.Cli
 	}
 }
 
-func (p *logProcessor) processLogEvents(logEvents []types.FilteredLogEvent, logGroup string, regionName string) {
+// processLogEvents publishes the log events. When ack is set, the events
+// are added to the acknowledgements it tracks.
+func (p *logProcessor) processLogEvents(logEvents []types.FilteredLogEvent, logGroup string, regionName string, ack *awscommon.EventACKTracker) {
 	for _, logEvent := range logEvents {
-		event := createEvent(logEvent, logGroup, regionName)
-		p.metrics.cloudwatchEventsCreatedTotal.Inc()
-		p.publisher.Publish(event)
+		p.publish(createEvent(logEvent, logGroup, regionName), ack)
 	}
 }
 
+func (p *logProcessor) publish(event beat.Event, ack *awscommon.EventACKTracker) {
+	if ack != nil {
+		ack.Add()
+		event.Private = ack
+	}
+	p.metrics.cloudwatchEventsCreatedTotal.Inc()
+	p.publisher.Publish(event)
+}
+
 func createEvent(logEvent types.FilteredLogEvent, logGroup string, regionName string) beat.Event {
 	event := beat.Event{
 		Timestamp: time.Unix(*logEvent.Timestamp/1000, 0).UTC(),
@@ -51,14 +61,12 @@ func createEvent(logEvent types.FilteredLogEvent, logGroup string, regionName st
 				"ingested": time.Now(),
 			},
 			"awscloudwatch": mapstr.M{
-				"log_group":      logGroup,
-				"log_stream":     *logEvent.LogStreamName,
-				"ingestion_time": time.Unix(*logEvent.IngestionTime/1000, 0),
+				"log_group":  logGroup,
+				"log_stream": *logEvent.LogStreamName,
 			},
 			"aws.cloudwatch": mapstr.M{
-				"log_group":      logGroup,
-				"log_stream":     *logEvent.LogStreamName,
-				"ingestion_time": time.Unix(*logEvent.IngestionTime/1000, 0),
+				"log_group":  logGroup,
+				"log_stream": *logEvent.LogStreamName,
 			},
 			"cloud": mapstr.M{
 				"provider": "aws",
@@ -66,6 +74,12 @@ func createEvent(logEvent types.FilteredLogEvent, logGroup string, regionName st
 			},
 		},
 	}
+	// The records of subscription filters don't hold the ingestion time.
+	if logEvent.IngestionTime != nil {
+		ingestionTime := time.Unix(*logEvent.IngestionTime/1000, 0)
+		event.Fields["awscloudwatch"].(mapstr.M)["ingestion_time"] = ingestionTime
+		event.Fields["aws.cloudwatch"].(mapstr.M)["ingestion_time"] = ingestionTime
+	}
 	event.SetID(*logEvent.EventId)
 
 	return event
@@ -0,0 +1,139 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Message types of the records delivered by subscription filters. Control
+// messages are sent to check that the destination is reachable and hold
+// no log events.
+const (
+	messageTypeData    = "DATA_MESSAGE"
+	messageTypeControl = "CONTROL_MESSAGE"
+)
+
+// subscriptionRecord is the payload of the records delivered by the
+// subscription filter of a log group.
+type subscriptionRecord struct {
+	MessageType         string   `json:"messageType"`
+	Owner               string   `json:"owner"`
+	LogGroup            string   `json:"logGroup"`
+	LogStream           string   `json:"logStream"`
+	SubscriptionFilters []string `json:"subscriptionFilters"`
+	LogEvents           []struct {
+		ID        string `json:"id"`
+		Timestamp int64  `json:"timestamp"`
+		Message   string `json:"message"`
+	} `json:"logEvents"`
+}
+
+// subscriptionSource delivers the records of the subscription filters to
+// the handler until the context is cancelled.
+type subscriptionSource interface {
+	run(ctx context.Context, handler recordsHandler) error
+}
+
+// recordsHandler publishes the log events of the records delivered by the
+// subscription filters, and returns once they are acknowledged. It returns
+// false when the input is stopping, and the records must be delivered again.
+type recordsHandler func(records [][]byte) bool
+
+// decodeSubscriptionRecords decodes the payloads of a record. The records
+// are gzip compressed by CloudWatch Logs, unless they are decompressed by
+// the Firehose delivery stream, which may concatenate several payloads in a
+// record.
+func decodeSubscriptionRecords(data []byte) ([]subscriptionRecord, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress record: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var records []subscriptionRecord
+	dec := json.NewDecoder(r)
+	for {
+		var rec subscriptionRecord
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, fmt.Errorf("failed to decode record: %w", err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// subscriptionHandler publishes the log events of the records of the
+// subscription filters.
+type subscriptionHandler struct {
+	region    string
+	processor *logProcessor
+	metrics   *inputMetrics
+	logger    *logp.Logger
+}
+
+func (h *subscriptionHandler) handle(ctx context.Context, data [][]byte) bool {
+	ack := awscommon.NewEventACKTracker(ctx)
+	for _, d := range data {
+		h.metrics.subscriptionRecordsReceivedTotal.Inc()
+		records, err := decodeSubscriptionRecords(d)
+		if err != nil {
+			// Invalid records would fail again if they were delivered
+			// again, so they are dropped and the valid ones kept.
+			h.metrics.subscriptionRecordsInvalidTotal.Inc()
+			h.logger.Warnw("dropping invalid subscription filter record", "error", err)
+		}
+		for _, rec := range records {
+			if rec.MessageType != messageTypeData {
+				if rec.MessageType != messageTypeControl {
+					h.logger.Debugw("ignoring subscription filter record", "message_type", rec.MessageType)
+				}
+				continue
+			}
+			h.publish(rec, ack)
+		}
+	}
+	ack.Wait()
+	return ctx.Err() == nil
+}
+
+func (h *subscriptionHandler) publish(rec subscriptionRecord, ack *awscommon.EventACKTracker) {
+	h.metrics.logEventsReceivedTotal.Add(uint64(len(rec.LogEvents)))
+	for _, e := range rec.LogEvents {
+		event := createEvent(types.FilteredLogEvent{
+			EventId:       awssdk.String(e.ID),
+			LogStreamName: awssdk.String(rec.LogStream),
+			Message:       awssdk.String(e.Message),
+			Timestamp:     awssdk.Int64(e.Timestamp),
+		}, rec.LogGroup, h.region)
+		if rec.Owner != "" {
+			_, _ = event.Fields.Put("cloud.account.id", rec.Owner)
+		}
+		if len(rec.SubscriptionFilters) > 0 {
+			event.Fields["aws.cloudwatch"].(mapstr.M)["subscription_filters"] = rec.SubscriptionFilters
+		}
+		h.processor.publish(event, ack)
+	}
+}
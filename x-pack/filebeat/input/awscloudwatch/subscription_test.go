@@ -0,0 +1,166 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const dataMessage = `{"messageType":"DATA_MESSAGE","owner":"123456789012","logGroup":"/aws/lambda/test",` +
+	`"logStream":"2024/01/01/[$LATEST]abc","subscriptionFilters":["to-firehose"],` +
+	`"logEvents":[{"id":"id-1","timestamp":1700000000000,"message":"first"},{"id":"id-2","timestamp":1700000001000,"message":"second"}]}`
+
+const controlMessage = `{"messageType":"CONTROL_MESSAGE","owner":"CloudwatchLogs","logGroup":"","logStream":"",` +
+	`"subscriptionFilters":[],"logEvents":[{"id":"","timestamp":1700000000000,"message":"CWL CONTROL MESSAGE"}]}`
+
+func gzipData(t *testing.T, data string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// ackingClient returns a client acknowledging the published events, and the
+// events it received.
+func ackingClient() (*pubtest.ChanClient, *[]beat.Event) {
+	var events []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		events = append(events, event)
+		if ack, ok := event.Private.(*awscommon.EventACKTracker); ok {
+			ack.ACK()
+		}
+	})
+	return client, &events
+}
+
+func TestDecodeSubscriptionRecords(t *testing.T) {
+	t.Run("gzip", func(t *testing.T) {
+		records, err := decodeSubscriptionRecords(gzipData(t, dataMessage))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, messageTypeData, records[0].MessageType)
+		assert.Equal(t, "/aws/lambda/test", records[0].LogGroup)
+		assert.Len(t, records[0].LogEvents, 2)
+	})
+
+	t.Run("decompressed by firehose", func(t *testing.T) {
+		records, err := decodeSubscriptionRecords([]byte(controlMessage + dataMessage))
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, messageTypeControl, records[0].MessageType)
+		assert.Equal(t, messageTypeData, records[1].MessageType)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		records, err := decodeSubscriptionRecords([]byte(dataMessage + "{"))
+		assert.Error(t, err)
+		assert.Len(t, records, 1)
+	})
+}
+
+func TestSubscriptionHandler(t *testing.T) {
+	client, events := ackingClient()
+	metrics := newInputMetrics("", nil)
+	h := &subscriptionHandler{
+		region:    "us-east-1",
+		processor: newLogProcessor(logp.NewLogger(inputName), metrics, client, context.Background()),
+		metrics:   metrics,
+		logger:    logp.NewLogger(inputName),
+	}
+
+	ok := h.handle(context.Background(), [][]byte{
+		gzipData(t, controlMessage),
+		[]byte("not a record"),
+		gzipData(t, dataMessage),
+	})
+	require.True(t, ok)
+	require.Len(t, *events, 2)
+
+	event := (*events)[0]
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), event.Timestamp)
+	assert.Equal(t, "id-1", event.Meta["_id"])
+	for field, value := range map[string]interface{}{
+		"message":          "first",
+		"cloud.account.id": "123456789012",
+		"cloud.region":     "us-east-1",
+		"log.file.path":    "/aws/lambda/test/2024/01/01/[$LATEST]abc",
+	} {
+		v, err := event.Fields.GetValue(field)
+		require.NoError(t, err, field)
+		assert.Equal(t, value, v, field)
+	}
+	cw, ok := event.Fields["aws.cloudwatch"].(mapstr.M)
+	require.True(t, ok)
+	assert.Equal(t, []string{"to-firehose"}, cw["subscription_filters"])
+	assert.NotContains(t, cw, "ingestion_time")
+
+	assert.EqualValues(t, 3, metrics.subscriptionRecordsReceivedTotal.Get())
+	assert.EqualValues(t, 1, metrics.subscriptionRecordsInvalidTotal.Get())
+}
+
+func TestFirehoseHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		accessKey string
+		header    string
+		stopping  bool
+		status    int
+		records   int
+	}{
+		{name: "valid", status: http.StatusOK, records: 1},
+		{name: "access key", accessKey: "secret", header: "secret", status: http.StatusOK, records: 1},
+		{name: "invalid access key", accessKey: "secret", header: "other", status: http.StatusUnauthorized},
+		{name: "stopping", stopping: true, status: http.StatusServiceUnavailable, records: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var received [][]byte
+			h := &firehoseHandler{
+				config: firehoseConfig{AccessKey: tc.accessKey, MaxBodySize: 1024 * 1024},
+				handler: func(records [][]byte) bool {
+					received = append(received, records...)
+					return !tc.stopping
+				},
+				logger: logp.NewLogger(inputName),
+			}
+
+			body, err := json.Marshal(map[string]interface{}{
+				"requestId": "req-1",
+				"timestamp": 1700000000000,
+				"records":   []map[string]interface{}{{"data": gzipData(t, dataMessage)}},
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+			req.Header.Set("X-Amz-Firehose-Request-Id", "req-1")
+			req.Header.Set(accessKeyHeader, tc.header)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Len(t, received, tc.records)
+			var res firehoseResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			assert.Equal(t, "req-1", res.RequestID)
+		})
+	}
+}
@@ -7,6 +7,7 @@ package awss3
 import (
 	"errors"
 	"fmt"
+	"path"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
@@ -43,6 +44,7 @@ type config struct {
 	PathStyle           bool                 `config:"path_style"`
 	ProviderOverride    string               `config:"provider"`
 	BackupConfig        backupConfig         `config:",inline"`
+	Manifest            *manifestConfig      `config:"manifest"`
 }
 
 func defaultConfig() config {
@@ -103,6 +105,10 @@ func (c *config) Validate() error {
 			c.APITimeout, c.SQSWaitTime)
 	}
 
+	if c.Manifest != nil && c.QueueURL != "" {
+		return errors.New("manifest can only be used with bucket_arn or non_aws_bucket_name")
+	}
+
 	if c.AWSConfig.FIPSEnabled && c.NonAWSBucketName != "" {
 		return errors.New("fips_enabled cannot be used with a non-AWS S3 bucket")
 	}
@@ -136,6 +142,40 @@ func (c *config) Validate() error {
 	return nil
 }
 
+const (
+	manifestFormatInventory = "inventory"
+	manifestFormatCSV       = "csv"
+)
+
+// manifestConfig configures a one-time backfill of the objects listed in a
+// manifest stored in the configured bucket.
+type manifestConfig struct {
+	Key    string `config:"key" validate:"required"`
+	Format string `config:"format"` // Either "inventory" or "csv". Detected from the key when empty.
+}
+
+func (c *manifestConfig) Validate() error {
+	switch c.Format {
+	case "", manifestFormatInventory, manifestFormatCSV:
+		return nil
+	default:
+		return fmt.Errorf("manifest.format <%v> must be one of %q or %q",
+			c.Format, manifestFormatInventory, manifestFormatCSV)
+	}
+}
+
+// getFormat returns the configured manifest format. S3 Inventory manifests are
+// always named manifest.json, any other key is read as a CSV manifest.
+func (c *manifestConfig) getFormat() string {
+	if c.Format != "" {
+		return c.Format
+	}
+	if path.Base(c.Key) == "manifest.json" {
+		return manifestFormatInventory
+	}
+	return manifestFormatCSV
+}
+
 type backupConfig struct {
 	BackupToBucketArn        string `config:"backup_to_bucket_arn"`
 	NonAWSBackupToBucketName string `config:"non_aws_backup_to_bucket_name"`
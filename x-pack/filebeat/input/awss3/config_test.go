@@ -557,6 +557,63 @@ func TestConfig(t *testing.T) {
 			expectedErr: "backup_to_bucket_prefix cannot be the same as bucket_list_prefix, this will create an infinite loop",
 			expectedCfg: nil,
 		},
+		{
+			name:           "input with manifest",
+			queueURL:       "",
+			s3Bucket:       s3Bucket,
+			nonAWSS3Bucket: "",
+			config: mapstr.M{
+				"bucket_arn":        s3Bucket,
+				"number_of_workers": 5,
+				"manifest.key":      "inventory/aBucket/all/2024-01-01T00-00Z/manifest.json",
+			},
+			expectedErr: "",
+			expectedCfg: func(queueURL, s3Bucket, nonAWSS3Bucket string) config {
+				c := makeConfig("", s3Bucket, "")
+				c.NumberOfWorkers = 5
+				c.Manifest = &manifestConfig{Key: "inventory/aBucket/all/2024-01-01T00-00Z/manifest.json"}
+				return c
+			},
+		},
+		{
+			name:           "error on manifest with queueURL",
+			queueURL:       queueURL,
+			s3Bucket:       "",
+			nonAWSS3Bucket: "",
+			config: mapstr.M{
+				"queue_url":    queueURL,
+				"manifest.key": "manifest.csv",
+			},
+			expectedErr: "manifest can only be used with bucket_arn or non_aws_bucket_name",
+			expectedCfg: nil,
+		},
+		{
+			name:           "error on manifest without key",
+			queueURL:       "",
+			s3Bucket:       s3Bucket,
+			nonAWSS3Bucket: "",
+			config: mapstr.M{
+				"bucket_arn":        s3Bucket,
+				"number_of_workers": 5,
+				"manifest.format":   "csv",
+			},
+			expectedErr: "missing required field accessing 'manifest.key'",
+			expectedCfg: nil,
+		},
+		{
+			name:           "error on invalid manifest format",
+			queueURL:       "",
+			s3Bucket:       s3Bucket,
+			nonAWSS3Bucket: "",
+			config: mapstr.M{
+				"bucket_arn":        s3Bucket,
+				"number_of_workers": 5,
+				"manifest.key":      "manifest.csv",
+				"manifest.format":   "parquet",
+			},
+			expectedErr: "manifest.format <parquet> must be one of",
+			expectedCfg: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -101,6 +101,10 @@ func (in *s3PollerInput) Run(
 		in.config.getFileSelectors(),
 		in.config.BackupConfig)
 
+	if in.config.Manifest != nil {
+		return in.runManifest(ctx)
+	}
+
 	in.run(ctx)
 
 	return nil
@@ -116,6 +120,37 @@ func (in *s3PollerInput) run(ctx context.Context) {
 }
 
 func (in *s3PollerInput) runPoll(ctx context.Context) {
+	in.runWorkers(ctx, func(workChan chan<- *s3FetchTask) error {
+		in.readerLoop(ctx, workChan)
+		return nil
+	})
+}
+
+// runManifest processes every object listed in the configured manifest once.
+// Objects already processed by a previous run are skipped, so an interrupted
+// backfill resumes where it stopped.
+func (in *s3PollerInput) runManifest(ctx context.Context) error {
+	manifest := in.config.Manifest
+	bucketName := in.config.getBucketName()
+
+	in.log.Infow("Starting manifest backfill.", "bucket", bucketName, "manifest", manifest.Key)
+	err := in.runWorkers(ctx, func(workChan chan<- *s3FetchTask) error {
+		return in.manifestReaderLoop(ctx, workChan)
+	})
+	if ctx.Err() != nil {
+		in.log.Infow("Manifest backfill stopped, it will resume on the next start.", "manifest", manifest.Key)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to backfill objects from manifest %q: %w", manifest.Key, err)
+	}
+	in.log.Infow("Manifest backfill completed.", "manifest", manifest.Key)
+	return nil
+}
+
+// runWorkers starts the configured number of workers, feeds them the tasks
+// sent by the reader and waits for all of them to be processed.
+func (in *s3PollerInput) runWorkers(ctx context.Context, reader func(workChan chan<- *s3FetchTask) error) error {
 	var workerWg sync.WaitGroup
 	workChan := make(chan *s3FetchTask)
 
@@ -129,8 +164,9 @@ func (in *s3PollerInput) runPoll(ctx context.Context) {
 	}
 
 	// Start reading data and wait for its processing to be done
-	in.readerLoop(ctx, workChan)
+	err := reader(workChan)
 	workerWg.Wait()
+	return err
 }
 
 func (in *s3PollerInput) workerLoop(ctx context.Context, workChan <-chan *s3FetchTask) {
@@ -232,15 +268,57 @@ func (in *s3PollerInput) readerLoop(ctx context.Context, workChan chan<- *s3Fetc
 	}
 }
 
+func (in *s3PollerInput) manifestReaderLoop(ctx context.Context, workChan chan<- *s3FetchTask) error {
+	defer close(workChan)
+
+	reader := &manifestReader{s3: in.s3}
+	manifest := in.config.Manifest
+	return reader.forEachObject(ctx, in.config.getBucketName(), manifest.Key, manifest.getFormat(), func(state state) error {
+		in.metrics.s3ObjectsListedTotal.Inc()
+		if in.states.IsProcessed(state) {
+			in.log.Debugw("skipping state.", "state", state)
+			return nil
+		}
+
+		s3Processor := in.createS3ObjectProcessor(ctx, state)
+		if s3Processor == nil {
+			in.log.Debugw("empty s3 processor.", "state", state)
+			return nil
+		}
+
+		select {
+		case workChan <- &s3FetchTask{s3ObjectHandler: s3Processor, objectState: state}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		in.metrics.s3ObjectsProcessedTotal.Inc()
+		return nil
+	})
+}
+
 func (in *s3PollerInput) createS3ObjectProcessor(ctx context.Context, state state) s3ObjectHandler {
 	event := s3EventV2{}
 	event.AWSRegion = in.awsConfig.Region
 	event.Provider = in.provider
 	event.S3.Bucket.Name = state.Bucket
-	event.S3.Bucket.ARN = in.config.getBucketARN()
+	event.S3.Bucket.ARN = in.bucketARN(state.Bucket)
 	event.S3.Object.Key = state.Key
 
 	acker := awscommon.NewEventACKTracker(ctx)
 
 	return in.s3ObjectHandler.Create(ctx, in.log, in.client, acker, event)
 }
+
+// bucketARN returns the ARN reported for objects of the given bucket. Objects
+// listed in a manifest can live in a different bucket than the configured one.
+func (in *s3PollerInput) bucketARN(bucket string) string {
+	switch {
+	case bucket == in.config.getBucketName():
+		return in.config.getBucketARN()
+	case in.config.NonAWSBucketName != "":
+		return bucket
+	default:
+		return "arn:aws:s3:::" + bucket
+	}
+}
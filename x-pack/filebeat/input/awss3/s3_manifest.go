@@ -0,0 +1,227 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// inventoryManifest is the manifest.json file written by S3 Inventory. See
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/storage-inventory-location.html.
+type inventoryManifest struct {
+	SourceBucket      string `json:"sourceBucket"`
+	DestinationBucket string `json:"destinationBucket"`
+	FileFormat        string `json:"fileFormat"`
+	FileSchema        string `json:"fileSchema"`
+	Files             []struct {
+		Key string `json:"key"`
+	} `json:"files"`
+}
+
+// inventoryColumns holds the position of the S3 Inventory columns used by
+// the input. Optional columns that are not part of the schema are -1.
+type inventoryColumns struct {
+	bucket         int
+	key            int
+	etag           int
+	lastModified   int
+	isLatest       int
+	isDeleteMarker int
+}
+
+func parseInventorySchema(schema string) (inventoryColumns, error) {
+	cols := inventoryColumns{-1, -1, -1, -1, -1, -1}
+	for i, name := range strings.Split(schema, ",") {
+		switch strings.TrimSpace(name) {
+		case "Bucket":
+			cols.bucket = i
+		case "Key":
+			cols.key = i
+		case "ETag":
+			cols.etag = i
+		case "LastModifiedDate":
+			cols.lastModified = i
+		case "IsLatest":
+			cols.isLatest = i
+		case "IsDeleteMarker":
+			cols.isDeleteMarker = i
+		}
+	}
+	if cols.bucket < 0 || cols.key < 0 {
+		return cols, fmt.Errorf("inventory schema %q has no Bucket and Key columns", schema)
+	}
+	return cols, nil
+}
+
+// manifestReader lists the objects referenced by an S3 Inventory manifest or
+// by a CSV manifest in the S3 Batch Operations format.
+type manifestReader struct {
+	s3 s3Getter
+}
+
+// forEachObject calls fn with the state of every object listed in the
+// manifest. Reading stops at the first error returned by fn.
+func (r *manifestReader) forEachObject(ctx context.Context, bucket, key, format string, fn func(state) error) error {
+	switch format {
+	case manifestFormatInventory:
+		return r.readInventory(ctx, bucket, key, fn)
+	case manifestFormatCSV:
+		body, err := r.open(ctx, bucket, key)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		return readCSVManifest(body, fn)
+	default:
+		return fmt.Errorf("unsupported manifest format %q", format)
+	}
+}
+
+func (r *manifestReader) readInventory(ctx context.Context, bucket, key string, fn func(state) error) error {
+	body, err := r.open(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+	var manifest inventoryManifest
+	err = json.NewDecoder(body).Decode(&manifest)
+	body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to decode inventory manifest %q: %w", key, err)
+	}
+
+	if !strings.EqualFold(manifest.FileFormat, "CSV") {
+		return fmt.Errorf("unsupported inventory file format %q, only CSV is supported", manifest.FileFormat)
+	}
+	cols, err := parseInventorySchema(manifest.FileSchema)
+	if err != nil {
+		return err
+	}
+
+	// The inventory files are stored next to the manifest unless the
+	// manifest says otherwise.
+	filesBucket := bucket
+	if manifest.DestinationBucket != "" {
+		filesBucket = getBucketNameFromARN(manifest.DestinationBucket)
+	}
+
+	for _, file := range manifest.Files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		body, err := r.open(ctx, filesBucket, file.Key)
+		if err != nil {
+			return err
+		}
+		err = readInventoryFile(body, cols, fn)
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read inventory file %q: %w", file.Key, err)
+		}
+	}
+	return nil
+}
+
+// open downloads the given object, decompressing it if it is gzipped.
+func (r *manifestReader) open(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	out, err := r.s3.GetObject(ctx, bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest object %q from bucket %q: %w", key, bucket, err)
+	}
+
+	bufReader := bufio.NewReader(out.Body)
+	gzipped, err := isStreamGzipped(bufReader)
+	if err != nil {
+		out.Body.Close()
+		return nil, fmt.Errorf("failed checking for gzip content: %w", err)
+	}
+	if !gzipped {
+		return readCloser{Reader: bufReader, Closer: out.Body}, nil
+	}
+	gzipReader, err := gzip.NewReader(bufReader)
+	if err != nil {
+		out.Body.Close()
+		return nil, fmt.Errorf("failed to create gzip reader for %q: %w", key, err)
+	}
+	return readCloser{Reader: gzipReader, Closer: out.Body}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// readInventoryFile reads an S3 Inventory CSV file. Delete markers and
+// noncurrent object versions are skipped as only the current version of an
+// object can be collected.
+func readInventoryFile(r io.Reader, cols inventoryColumns, fn func(state) error) error {
+	return readCSV(r, func(record []string) error {
+		if field(record, cols.isDeleteMarker) == "true" || field(record, cols.isLatest) == "false" {
+			return nil
+		}
+
+		key, err := url.QueryUnescape(field(record, cols.key))
+		if err != nil {
+			return fmt.Errorf("invalid object key %q: %w", field(record, cols.key), err)
+		}
+		var lastModified time.Time
+		if v := field(record, cols.lastModified); v != "" {
+			lastModified, err = time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return fmt.Errorf("invalid last modified date for object key %q: %w", key, err)
+			}
+		}
+		return fn(newState(field(record, cols.bucket), key, field(record, cols.etag), lastModified))
+	})
+}
+
+// readCSVManifest reads a manifest in the S3 Batch Operations CSV format,
+// one object per line as bucket,key with an optional version ID column.
+func readCSVManifest(r io.Reader, fn func(state) error) error {
+	return readCSV(r, func(record []string) error {
+		if len(record) < 2 {
+			return fmt.Errorf("manifest line %q must contain a bucket and a key", strings.Join(record, ","))
+		}
+		key, err := url.QueryUnescape(record[1])
+		if err != nil {
+			return fmt.Errorf("invalid object key %q: %w", record[1], err)
+		}
+		return fn(newState(record[0], key, "", time.Time{}))
+	})
+}
+
+func readCSV(r io.Reader, fn func([]string) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
@@ -0,0 +1,135 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3Getter serves objects from memory, keyed by bucket/key.
+type fakeS3Getter map[string][]byte
+
+func (f fakeS3Getter) GetObject(_ context.Context, bucket, key string) (*s3.GetObjectOutput, error) {
+	data, found := f[bucket+"/"+key]
+	if !found {
+		return nil, errors.New("no such key")
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func gzipBytes(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func collectManifest(t *testing.T, getter fakeS3Getter, bucket, key, format string) ([]state, error) {
+	t.Helper()
+	var states []state
+	reader := &manifestReader{s3: getter}
+	err := reader.forEachObject(context.Background(), bucket, key, format, func(st state) error {
+		states = append(states, st)
+		return nil
+	})
+	return states, err
+}
+
+func TestManifestReaderInventory(t *testing.T) {
+	const manifest = `{
+  "sourceBucket": "source",
+  "destinationBucket": "arn:aws:s3:::inventory",
+  "fileFormat": "CSV",
+  "fileSchema": "Bucket, Key, IsLatest, IsDeleteMarker, Size, LastModifiedDate, ETag",
+  "files": [
+    {"key": "source/all/data/file1.csv.gz"},
+    {"key": "source/all/data/file2.csv"}
+  ]
+}`
+	getter := fakeS3Getter{
+		"inventory/source/all/manifest.json": []byte(manifest),
+		"inventory/source/all/data/file1.csv.gz": gzipBytes(t, ""+
+			"\"source\",\"logs/a+b%3Dc.log\",\"true\",\"false\",\"10\",\"2024-01-02T03:04:05.000Z\",\"etag1\"\n"+
+			"\"source\",\"logs/old.log\",\"false\",\"false\",\"10\",\"2024-01-02T03:04:05.000Z\",\"etag2\"\n"),
+		"inventory/source/all/data/file2.csv": []byte("" +
+			"\"source\",\"logs/deleted.log\",\"true\",\"true\",\"\",\"2024-01-02T03:04:05.000Z\",\"\"\n" +
+			"\"source\",\"logs/c.log\",\"true\",\"false\",\"10\",\"2024-01-03T00:00:00.000Z\",\"etag3\"\n"),
+	}
+
+	states, err := collectManifest(t, getter, "inventory", "source/all/manifest.json", manifestFormatInventory)
+	require.NoError(t, err)
+	assert.Equal(t, []state{
+		newState("source", "logs/a b=c.log", "etag1", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		newState("source", "logs/c.log", "etag3", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+	}, states)
+}
+
+func TestManifestReaderInventoryErrors(t *testing.T) {
+	testCases := map[string]string{
+		"unsupported file format": `{"fileFormat": "Parquet", "fileSchema": "Bucket, Key"}`,
+		"missing key column":      `{"fileFormat": "CSV", "fileSchema": "Bucket, Size"}`,
+		"missing data file":       `{"fileFormat": "CSV", "fileSchema": "Bucket, Key", "files": [{"key": "missing.csv"}]}`,
+		"invalid json":            `{"fileFormat":`,
+	}
+	for name, manifest := range testCases {
+		t.Run(name, func(t *testing.T) {
+			getter := fakeS3Getter{"inventory/manifest.json": []byte(manifest)}
+			_, err := collectManifest(t, getter, "inventory", "manifest.json", manifestFormatInventory)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestManifestReaderCSV(t *testing.T) {
+	getter := fakeS3Getter{
+		"bucket/backfill.csv": []byte("source,logs/a.log\nsource,logs/b%20c.log,version1\n"),
+	}
+
+	states, err := collectManifest(t, getter, "bucket", "backfill.csv", manifestFormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, []state{
+		newState("source", "logs/a.log", "", time.Time{}),
+		newState("source", "logs/b c.log", "", time.Time{}),
+	}, states)
+
+	getter["bucket/invalid.csv"] = []byte("source\n")
+	_, err = collectManifest(t, getter, "bucket", "invalid.csv", manifestFormatCSV)
+	assert.Error(t, err)
+}
+
+func TestManifestReaderStopsOnError(t *testing.T) {
+	getter := fakeS3Getter{
+		"bucket/backfill.csv": []byte("source,a.log\nsource,b.log\n"),
+	}
+	errStop := errors.New("stop")
+
+	var calls int
+	reader := &manifestReader{s3: getter}
+	err := reader.forEachObject(context.Background(), "bucket", "backfill.csv", manifestFormatCSV, func(state) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestManifestConfigFormat(t *testing.T) {
+	assert.Equal(t, manifestFormatInventory, (&manifestConfig{Key: "inv/src/all/2024-01-01T00-00Z/manifest.json"}).getFormat())
+	assert.Equal(t, manifestFormatCSV, (&manifestConfig{Key: "backfill.csv"}).getFormat())
+	assert.Equal(t, manifestFormatCSV, (&manifestConfig{Key: "manifest.json", Format: manifestFormatCSV}).getFormat())
+}
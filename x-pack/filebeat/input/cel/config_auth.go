@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 
 	"golang.org/x/oauth2"
@@ -21,6 +22,8 @@ import (
 	"golang.org/x/oauth2/google"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/oauth2cache"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
 type authConfig struct {
@@ -150,8 +153,9 @@ func (o *oAuth2Config) isEnabled() bool {
 }
 
 // clientCredentialsGrant creates http client from token_url and client credentials
-// held by the receiver.
-func (o *oAuth2Config) clientCredentialsGrant(ctx context.Context, _ *http.Client) *http.Client {
+// held by the receiver. The token is shared with all inputs in the process that
+// use the same credentials, scopes and endpoint parameters.
+func (o *oAuth2Config) clientCredentialsGrant(ctx context.Context, _ *http.Client, reg *monitoring.Registry) *http.Client {
 	creds := clientcredentials.Config{
 		ClientID:       o.ClientID,
 		ClientSecret:   maybeString(o.ClientSecret),
@@ -159,11 +163,20 @@ func (o *oAuth2Config) clientCredentialsGrant(ctx context.Context, _ *http.Clien
 		Scopes:         o.Scopes,
 		EndpointParams: o.getEndpointParams(),
 	}
-	return creds.Client(ctx)
+	scopes := append([]string(nil), creds.Scopes...)
+	sort.Strings(scopes)
+	key := oauth2cache.NewKey("client_credentials", creds.TokenURL, creds.ClientID, creds.ClientSecret,
+		strings.Join(scopes, " "), creds.EndpointParams.Encode())
+	src := oauth2cache.Default.TokenSource(key, func() oauth2.TokenSource {
+		// The token outlives the input that created it, keep the HTTP
+		// client held by ctx but not its cancellation.
+		return creds.TokenSource(context.WithoutCancel(ctx))
+	}, reg)
+	return oauth2.NewClient(ctx, src)
 }
 
 // Client wraps the given http.Client and returns a new one that will use the oauth authentication.
-func (o *oAuth2Config) client(ctx context.Context, client *http.Client) (*http.Client, error) {
+func (o *oAuth2Config) client(ctx context.Context, client *http.Client, reg *monitoring.Registry) (*http.Client, error) {
 	ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
 
 	switch o.getProvider() {
@@ -183,10 +196,10 @@ func (o *oAuth2Config) client(ctx context.Context, client *http.Client) (*http.C
 			}
 			return conf.Client(ctx, token), nil
 		} else {
-			return o.clientCredentialsGrant(ctx, client), nil
+			return o.clientCredentialsGrant(ctx, client, reg), nil
 		}
 	case oAuth2ProviderAzure:
-		return o.clientCredentialsGrant(ctx, client), nil
+		return o.clientCredentialsGrant(ctx, client, reg), nil
 	case oAuth2ProviderGoogle:
 		if len(o.GoogleJWTJSON) != 0 {
 			cfg, err := google.JWTConfigFromJSON(o.GoogleJWTJSON, o.Scopes...)
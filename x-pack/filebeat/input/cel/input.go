@@ -40,6 +40,7 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	inputcursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
@@ -71,6 +72,10 @@ const (
 // is not given a user-agent string, this user agent is added to the request.
 var userAgent = useragent.UserAgent("Filebeat", version.GetDefaultVersion(), version.Commit(), version.BuildTime().String())
 
+func init() {
+	seccomp.RegisterBase(seccomp.KindInput, inputName)
+}
+
 func Plugin(log *logp.Logger, store inputcursor.StateStore) v2.Plugin {
 	return v2.Plugin{
 		Name:      inputName,
@@ -81,6 +86,9 @@ func Plugin(log *logp.Logger, store inputcursor.StateStore) v2.Plugin {
 
 type input struct {
 	time func() time.Time
+
+	// dryRun is set when the input is executing a dry run.
+	dryRun *v2.DryRunContext
 }
 
 // now is time.Now with a modifiable time source.
@@ -123,6 +131,12 @@ func (input) Run(env v2.Context, src inputcursor.Source, crsr inputcursor.Cursor
 	return nil
 }
 
+// DryRun executes a single interval of the program, reporting the program
+// states and the resulting events without publishing them.
+func (input) DryRun(ctx v2.DryRunContext, src inputcursor.Source) error {
+	return input{dryRun: &ctx}.run(ctx.Context, src.(*source), nil, inputcursor.DryRunPublisher(ctx.Reporter))
+}
+
 // sanitizeFileName returns name with ":" and "/" replaced with "_", removing repeated instances.
 // The request.tracer.filename may have ":" when a cel input has cursor config and
 // the macOS Finder will treat this as path-separator and causes to show up strange filepaths.
@@ -150,6 +164,9 @@ func (i input) run(env v2.Context, src *source, cursor map[string]interface{}, p
 	if err != nil {
 		return err
 	}
+	if i.dryRun != nil && client != nil {
+		client.Transport = i.dryRun.Transport(client.Transport)
+	}
 
 	limiter := newRateLimiterFromConfig(cfg.Resource)
 
@@ -217,7 +234,7 @@ func (i input) run(env v2.Context, src *source, cursor map[string]interface{}, p
 	// In addition to this and the functions and globals available
 	// from mito/lib, a global, useragent, is available to use
 	// in requests.
-	err = periodically(ctx, cfg.Interval, func() error {
+	iterate := func() error {
 		log.Info("process repeated request")
 		var (
 			budget    = *cfg.MaxExecutions
@@ -248,10 +265,16 @@ func (i input) run(env v2.Context, src *source, cursor map[string]interface{}, p
 				log.Debugw("previous transaction", "transaction.id", trace.TxID())
 			}
 			log.Debugw("request state", logp.Namespace("cel"), "state", redactor{state: state, cfg: cfg.Redact})
+			if i.dryRun != nil {
+				i.dryRun.Reporter.State("request state", redactor{state: state, cfg: cfg.Redact})
+			}
 			metrics.executions.Add(1)
 			start := i.now().In(time.UTC)
 			state, err = evalWith(ctx, prg, ast, state, start)
 			log.Debugw("response state", logp.Namespace("cel"), "state", redactor{state: state, cfg: cfg.Redact})
+			if i.dryRun != nil {
+				i.dryRun.Reporter.State("response state", redactor{state: state, cfg: cfg.Redact})
+			}
 			if err != nil {
 				switch {
 				case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
@@ -507,7 +530,12 @@ func (i input) run(env v2.Context, src *source, cursor map[string]interface{}, p
 				return nil
 			}
 		}
-	})
+	}
+	if i.dryRun != nil {
+		err = iterate()
+	} else {
+		err = periodically(ctx, cfg.Interval, iterate)
+	}
 	switch {
 	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
 		log.Infof("input stopped because context was cancelled with: %v", err)
@@ -807,7 +835,7 @@ func newClient(ctx context.Context, cfg config, log *logp.Logger, reg *monitorin
 	}
 
 	if cfg.Auth.OAuth2.isEnabled() {
-		authClient, err := cfg.Auth.OAuth2.client(ctx, c)
+		authClient, err := cfg.Auth.OAuth2.client(ctx, c, reg)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1735,6 +1735,92 @@ func TestInput(t *testing.T) {
 	}
 }
 
+func TestDryRun(t *testing.T) {
+	logp.TestingSetup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"items":[{"id":1},{"id":2}]}`)
+	}))
+	defer server.Close()
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"interval":     1,
+		"resource.url": server.URL,
+		"program": `
+	bytes(get(state.url).Body).decode_json().as(body, {
+		"events": body.items,
+		"cursor": {"last": body.items[size(body.items)-1].id},
+	})
+	`,
+	})
+	config := defaultConfig()
+	config.Redact = &redact{}
+	if err := cfg.Unpack(&config); err != nil {
+		t.Fatalf("unexpected error unpacking config: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var (
+		reporter dryRunReporter
+		requests int
+	)
+	err := input{}.DryRun(v2.DryRunContext{
+		Context: v2.Context{
+			Logger:      logp.NewLogger("cel_test"),
+			ID:          "test_id:dry_run",
+			Cancelation: ctx,
+		},
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				requests++
+				return rt.RoundTrip(r)
+			})
+		},
+		Reporter: &reporter,
+	}, &source{config})
+	if err != nil {
+		t.Fatalf("unexpected error from dry run: %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("unexpected number of requests: got:%d want:1", requests)
+	}
+	wantStates := []string{"request state", "response state"}
+	if !reflect.DeepEqual(reporter.states, wantStates) {
+		t.Errorf("unexpected reported states: got:%v want:%v", reporter.states, wantStates)
+	}
+	wantEvents := []mapstr.M{{"id": 1.0}, {"id": 2.0}}
+	if !reflect.DeepEqual(reporter.events, wantEvents) {
+		t.Errorf("unexpected reported events: got:- want:+\n%s", cmp.Diff(reporter.events, wantEvents))
+	}
+	wantCursors := []interface{}{nil, map[string]interface{}{"last": 2.0}}
+	if !reflect.DeepEqual(reporter.cursors, wantCursors) {
+		t.Errorf("unexpected reported cursors: got:- want:+\n%s", cmp.Diff(reporter.cursors, wantCursors))
+	}
+}
+
+type dryRunReporter struct {
+	states  []string
+	events  []mapstr.M
+	cursors []interface{}
+}
+
+func (r *dryRunReporter) State(name string, _ interface{}) {
+	r.states = append(r.states, name)
+}
+
+func (r *dryRunReporter) Event(event beat.Event, private interface{}) {
+	r.events = append(r.events, event.Fields)
+	r.cursors = append(r.cursors, private)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
 var _ inputcursor.Publisher = (*publisher)(nil)
 
 type publisher struct {
@@ -0,0 +1,128 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+const (
+	// maxWindow is the longest time range accepted by a Logpull request.
+	maxWindow = time.Hour
+	// maxRetention is how long Cloudflare keeps the logs available to
+	// Logpull.
+	maxRetention = 7 * 24 * time.Hour
+	// minDelay is how long Cloudflare needs before the logs of a time
+	// range can be pulled.
+	minDelay = time.Minute
+)
+
+type config struct {
+	// ZoneID is the identifier of the Cloudflare zone whose HTTP request
+	// logs are pulled.
+	ZoneID string `config:"zone_id" validate:"required"`
+
+	// APIToken is the API token used to authenticate the requests. When it
+	// is not set, AuthEmail and AuthKey are used instead.
+	APIToken  string `config:"api_token"`
+	AuthEmail string `config:"auth_email"`
+	AuthKey   string `config:"auth_key"`
+
+	// APIEndpoint is the base URL of the Cloudflare API.
+	APIEndpoint string `config:"api_endpoint"`
+
+	// Fields is the list of log fields to pull. When empty, the default
+	// fields of the zone are pulled.
+	Fields []string `config:"fields"`
+
+	// TimestampField is the log field holding the time used by Logpull to
+	// select the records of a time range.
+	TimestampField string `config:"timestamp_field"`
+
+	// Interval is the time between two checks for ranges to pull.
+	Interval time.Duration `config:"interval" validate:"positive"`
+
+	// Window is the time range pulled by a single request.
+	Window time.Duration `config:"window" validate:"positive"`
+
+	// Delay is how far behind real time the input pulls, leaving time to
+	// Cloudflare to make the logs available.
+	Delay time.Duration `config:"delay" validate:"positive"`
+
+	// InitialInterval is how far back the first pull goes.
+	InitialInterval time.Duration `config:"initial_interval" validate:"positive"`
+
+	// Retention is how long ranges that failed to be pulled are retried.
+	Retention time.Duration `config:"retention" validate:"positive"`
+
+	// MaxRecords limits the number of records returned by a request. A
+	// window returning that many records is split and pulled again.
+	MaxRecords int `config:"max_records" validate:"min=0"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+func defaultConfig() config {
+	return config{
+		APIEndpoint:     "https://api.cloudflare.com/client/v4",
+		TimestampField:  "EdgeEndTimestamp",
+		Interval:        time.Minute,
+		Window:          5 * time.Minute,
+		Delay:           5 * time.Minute,
+		InitialInterval: time.Hour,
+		Retention:       maxRetention,
+		Transport: httpcommon.HTTPTransportSettings{
+			Timeout: 2 * time.Minute,
+		},
+	}
+}
+
+func (c *config) Validate() error {
+	if c.APIToken == "" && (c.AuthEmail == "" || c.AuthKey == "") {
+		return errors.New("either api_token or both auth_email and auth_key must be set")
+	}
+	if _, err := url.Parse(c.APIEndpoint); err != nil {
+		return fmt.Errorf("invalid api_endpoint: %w", err)
+	}
+	if c.TimestampField == "" {
+		return errors.New("timestamp_field must not be empty")
+	}
+	if c.Window > maxWindow {
+		return fmt.Errorf("window must not be greater than %v", maxWindow)
+	}
+	if c.Window < time.Second {
+		return errors.New("window must not be less than 1s")
+	}
+	if c.Delay < minDelay {
+		return fmt.Errorf("delay must not be less than %v", minDelay)
+	}
+	if c.Retention > maxRetention {
+		return fmt.Errorf("retention must not be greater than %v", maxRetention)
+	}
+	if c.InitialInterval > c.Retention {
+		return errors.New("initial_interval must not be greater than retention")
+	}
+	return nil
+}
+
+// fields returns the value of the fields query parameter, making sure the
+// timestamp field is pulled so that records can be checked against the
+// range of their window.
+func (c *config) fields() []string {
+	if len(c.Fields) == 0 {
+		return nil
+	}
+	for _, f := range c.Fields {
+		if f == c.TimestampField {
+			return c.Fields
+		}
+	}
+	return append(append([]string(nil), c.Fields...), c.TimestampField)
+}
@@ -0,0 +1,78 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudflare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "api token",
+			config: map[string]interface{}{"zone_id": "zone", "api_token": "token"},
+		},
+		{
+			name:   "api key",
+			config: map[string]interface{}{"zone_id": "zone", "auth_email": "user@example.com", "auth_key": "key"},
+		},
+		{
+			name:    "missing credentials",
+			config:  map[string]interface{}{"zone_id": "zone", "auth_email": "user@example.com"},
+			wantErr: "either api_token or both auth_email and auth_key must be set",
+		},
+		{
+			name:    "window too long",
+			config:  map[string]interface{}{"zone_id": "zone", "api_token": "token", "window": "2h"},
+			wantErr: "window must not be greater than 1h0m0s",
+		},
+		{
+			name:    "delay too short",
+			config:  map[string]interface{}{"zone_id": "zone", "api_token": "token", "delay": "30s"},
+			wantErr: "delay must not be less than 1m0s",
+		},
+		{
+			name:    "retention too long",
+			config:  map[string]interface{}{"zone_id": "zone", "api_token": "token", "retention": "240h"},
+			wantErr: "retention must not be greater than",
+		},
+		{
+			name:    "initial interval beyond retention",
+			config:  map[string]interface{}{"zone_id": "zone", "api_token": "token", "retention": "1h", "initial_interval": "2h"},
+			wantErr: "initial_interval must not be greater than retention",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := defaultConfig()
+			err := conf.MustNewConfigFrom(test.config).Unpack(&c)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigFields(t *testing.T) {
+	c := defaultConfig()
+	assert.Nil(t, c.fields())
+
+	c.Fields = []string{"RayID", "EdgeEndTimestamp"}
+	assert.Equal(t, []string{"RayID", "EdgeEndTimestamp"}, c.fields())
+
+	c.Fields = []string{"RayID", "ClientIP"}
+	assert.Equal(t, []string{"RayID", "ClientIP", "EdgeEndTimestamp"}, c.fields())
+	assert.Equal(t, []string{"RayID", "ClientIP"}, c.Fields)
+}
@@ -0,0 +1,214 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const pluginName = "cloudflare"
+
+// Plugin creates the cloudflare input plugin.
+func Plugin(log *logp.Logger, store cursor.StateStore) v2.Plugin {
+	return v2.Plugin{
+		Name:      pluginName,
+		Stability: feature.Experimental,
+		Info:      "Cloudflare",
+		Doc:       "Collect Cloudflare HTTP request logs with Logpull",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       pluginName,
+			Configure:  configure,
+		},
+	}
+}
+
+// zone is the source of the HTTP request logs of a Cloudflare zone.
+type zone struct {
+	id string
+}
+
+func (z *zone) Name() string {
+	return z.id
+}
+
+type logpullInput struct {
+	config config
+}
+
+func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+	return []cursor.Source{&zone{id: config.ZoneID}}, &logpullInput{config: config}, nil
+}
+
+func (inp *logpullInput) Name() string { return pluginName }
+
+// FIPSCapable returns nil, as the input only relies on TLS, whose settings
+// are checked with the ones of the other inputs.
+func (inp *logpullInput) FIPSCapable() error { return nil }
+
+func (inp *logpullInput) Test(src cursor.Source, ctx v2.TestContext) error {
+	client, err := inp.newClient(ctx.Logger)
+	if err != nil {
+		return err
+	}
+	end := time.Now().Add(-inp.config.Delay).Truncate(time.Second)
+	_, err = client.get(ctxtool.FromCanceller(ctx.Cancelation), client.receivedURL(timeRange{Start: end.Add(-time.Second), End: end}))
+	if err != nil {
+		return fmt.Errorf("unable to pull logs of zone %s: %w", inp.config.ZoneID, err)
+	}
+	return nil
+}
+
+func (inp *logpullInput) Run(ctx v2.Context, src cursor.Source, crsr cursor.Cursor, publisher cursor.Publisher) error {
+	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
+	log := ctx.Logger.With("zone_id", inp.config.ZoneID)
+
+	client, err := inp.newClient(log)
+	if err != nil {
+		return err
+	}
+	p := &puller{config: inp.config, client: client, logger: log}
+
+	var st state
+	if crsr.IsNew() {
+		st.Since = time.Now().Add(-inp.config.InitialInterval).Truncate(time.Second)
+	} else if err := crsr.Unpack(&st); err != nil {
+		return fmt.Errorf("unable to read cursor of zone %s: %w", inp.config.ZoneID, err)
+	}
+
+	err = timed.Periodic(stdCtx, inp.config.Interval, func() error {
+		if err := p.pullGaps(stdCtx, &st, time.Now(), publisher.Publish); err != nil {
+			if stdCtx.Err() != nil {
+				return err
+			}
+			log.Errorw("failed to pull logs, the remaining ranges are pulled at the next interval", "error", err)
+		}
+		return nil
+	})
+	if stdCtx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (inp *logpullInput) newClient(log *logp.Logger) (*logpullClient, error) {
+	client, err := inp.config.Transport.Client()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP client: %w", err)
+	}
+	return &logpullClient{
+		endpoint:       strings.TrimSuffix(inp.config.APIEndpoint, "/"),
+		zoneID:         inp.config.ZoneID,
+		fields:         inp.config.fields(),
+		timestampField: inp.config.TimestampField,
+		maxRecords:     inp.config.MaxRecords,
+		apiToken:       inp.config.APIToken,
+		authEmail:      inp.config.AuthEmail,
+		authKey:        inp.config.AuthKey,
+		client:         client,
+		logger:         log,
+	}, nil
+}
+
+// publishFunc publishes an event, with the cursor update to store once the
+// event is acknowledged.
+type publishFunc func(event beat.Event, cursor interface{}) error
+
+// puller pulls the ranges missing from the state of a zone.
+type puller struct {
+	config config
+	client *logpullClient
+	logger *logp.Logger
+}
+
+// pullGaps pulls, oldest first, the ranges of the state not yet pulled.
+// Ranges that can no longer be pulled are given up and reported. It stops
+// at the first window that fails, leaving it and the following ones for the
+// next call.
+func (p *puller) pullGaps(ctx context.Context, st *state, now time.Time, publish publishFunc) error {
+	for _, r := range st.expire(now.Add(-p.config.Retention).Truncate(time.Second)) {
+		p.logger.Warnw("logs were not pulled before the end of their retention and are lost",
+			"start", r.Start, "end", r.End)
+	}
+
+	until := now.Add(-p.config.Delay).Truncate(time.Second)
+	gaps := st.gaps(until)
+	if len(gaps) > 1 || (len(gaps) == 1 && until.Sub(gaps[0].Start) > p.config.Window+p.config.Interval) {
+		p.logger.Infow("backfilling ranges not pulled", "gaps", len(gaps), "since", gaps[0].Start)
+	}
+	for _, w := range split(gaps, p.config.Window) {
+		if err := p.pullWindow(ctx, st, w, publish); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pullWindow pulls and publishes the records of the window, and records it
+// as pulled. The new state is attached to the last event of the window, so
+// that the window is pulled again if the input stops before the events
+// are acknowledged. When the window holds more than max_records records,
+// it is split in halves that are pulled separately.
+func (p *puller) pullWindow(ctx context.Context, st *state, w timeRange, publish publishFunc) error {
+	records, err := p.client.pull(ctx, w)
+	if err != nil {
+		return err
+	}
+	if limit := p.config.MaxRecords; limit > 0 && len(records) >= limit {
+		half := w.End.Sub(w.Start) / 2
+		if half < time.Second {
+			return &validationError{window: w, reason: fmt.Sprintf("more than %d records in a single second", limit)}
+		}
+		mid := w.Start.Add(half).Truncate(time.Second)
+		p.logger.Debugw("window reached max_records, splitting it", "start", w.Start, "end", w.End)
+		if err := p.pullWindow(ctx, st, timeRange{Start: w.Start, End: mid}, publish); err != nil {
+			return err
+		}
+		return p.pullWindow(ctx, st, timeRange{Start: mid, End: w.End}, publish)
+	}
+
+	p.logger.Debugw("window pulled", "start", w.Start, "end", w.End, "records", len(records))
+	st.add(w)
+	for i, rec := range records {
+		var update interface{}
+		if i == len(records)-1 {
+			update = st.clone()
+		}
+		if err := publish(p.event(rec), update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *puller) event(rec record) beat.Event {
+	return beat.Event{
+		Timestamp: rec.timestamp,
+		Fields: mapstr.M{
+			"message": string(rec.raw),
+			"cloudflare": mapstr.M{
+				"zone_id": p.config.ZoneID,
+			},
+		},
+	}
+}
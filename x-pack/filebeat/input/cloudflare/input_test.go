@@ -0,0 +1,232 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type published struct {
+	events  []beat.Event
+	cursors []interface{}
+}
+
+func (p *published) publish(event beat.Event, cursor interface{}) error {
+	p.events = append(p.events, event)
+	p.cursors = append(p.cursors, cursor)
+	return nil
+}
+
+// logServer serves a record for every minute of the requested window.
+type logServer struct {
+	mu       sync.Mutex
+	requests []timeRange
+	// fail returns the status code to reply for a window, or 0.
+	fail func(w timeRange) int
+}
+
+func (s *logServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/zones/zone-1/logs/received" || r.Header.Get("Authorization") != "Bearer token" {
+		http.NotFound(w, r)
+		return
+	}
+	var window timeRange
+	var err error
+	window.Start, err = time.Parse(time.RFC3339, r.URL.Query().Get("start"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	window.End, _ = time.Parse(time.RFC3339, r.URL.Query().Get("end"))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, window)
+	fail := s.fail
+	s.mu.Unlock()
+	if fail != nil {
+		if code := fail(window); code != 0 {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, `{"success":false}`, code)
+			return
+		}
+	}
+	ts := window.Start.Truncate(time.Minute)
+	if ts.Before(window.Start) {
+		ts = ts.Add(time.Minute)
+	}
+	for ; ts.Before(window.End); ts = ts.Add(time.Minute) {
+		fmt.Fprintf(w, "{\"RayID\":%q,\"EdgeEndTimestamp\":%q}\n", ts.Format("1504"), ts.Format(time.RFC3339))
+	}
+}
+
+func newTestPuller(t *testing.T, handler http.Handler, maxRecords int) *puller {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := defaultConfig()
+	c.ZoneID = "zone-1"
+	c.APIToken = "token"
+	c.APIEndpoint = server.URL
+	c.Window = 10 * time.Minute
+	c.MaxRecords = maxRecords
+	log := logp.NewLogger("cloudflare_test")
+	return &puller{
+		config: c,
+		client: &logpullClient{
+			endpoint:       server.URL,
+			zoneID:         c.ZoneID,
+			timestampField: c.TimestampField,
+			maxRecords:     maxRecords,
+			apiToken:       c.APIToken,
+			client:         server.Client(),
+			logger:         log,
+		},
+		logger: log,
+	}
+}
+
+func TestPullGaps(t *testing.T) {
+	server := &logServer{}
+	p := newTestPuller(t, server, 0)
+
+	st := state{Since: epoch}
+	var pub published
+	now := epoch.Add(25*time.Minute + p.config.Delay)
+	require.NoError(t, p.pullGaps(context.Background(), &st, now, pub.publish))
+
+	assert.Equal(t, []timeRange{at(0, 10), at(10, 20), at(20, 25)}, server.requests)
+	assert.Len(t, pub.events, 25)
+	assert.Equal(t, []timeRange{at(0, 25)}, st.Pulled)
+	assert.Equal(t, state{Since: epoch, Pulled: []timeRange{at(0, 10)}}, pub.cursors[9])
+	assert.Nil(t, pub.cursors[10])
+	assert.Equal(t, state{Since: epoch, Pulled: []timeRange{at(0, 25)}}, pub.cursors[24])
+	assert.True(t, pub.events[3].Timestamp.Equal(epoch.Add(3*time.Minute)))
+
+	zone, err := pub.events[0].Fields.GetValue("cloudflare.zone_id")
+	require.NoError(t, err)
+	assert.Equal(t, "zone-1", zone)
+}
+
+func TestPullGapsBackfillsFailedWindows(t *testing.T) {
+	server := &logServer{
+		fail: func(w timeRange) int {
+			if w.Start.Equal(epoch.Add(10 * time.Minute)) {
+				return http.StatusTooManyRequests
+			}
+			return 0
+		},
+	}
+	p := newTestPuller(t, server, 0)
+
+	st := state{Since: epoch}
+	var pub published
+	now := epoch.Add(30*time.Minute + p.config.Delay)
+	err := p.pullGaps(context.Background(), &st, now, pub.publish)
+	var statusErr *statusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.code)
+	assert.Equal(t, []timeRange{at(0, 10)}, st.Pulled)
+	assert.Len(t, server.requests, 1+1+maxThrottleRetries)
+
+	// The rate limited window is pulled once the limit is lifted, before
+	// the newer ones.
+	server.requests = nil
+	server.fail = nil
+	require.NoError(t, p.pullGaps(context.Background(), &st, now.Add(5*time.Minute), pub.publish))
+	assert.Equal(t, []timeRange{at(10, 20), at(20, 30), at(30, 35)}, server.requests)
+	assert.Equal(t, []timeRange{at(0, 35)}, st.Pulled)
+	assert.Len(t, pub.events, 35)
+}
+
+func TestPullGapsExpiresLostRanges(t *testing.T) {
+	server := &logServer{}
+	p := newTestPuller(t, server, 0)
+	p.config.Retention = time.Hour
+
+	st := state{Since: epoch, Pulled: []timeRange{at(0, 10)}}
+	var pub published
+	now := epoch.Add(2*time.Hour + p.config.Delay)
+	require.NoError(t, p.pullGaps(context.Background(), &st, now, pub.publish))
+
+	assert.Equal(t, epoch.Add(time.Hour+p.config.Delay), st.Since)
+	assert.Equal(t, split([]timeRange{{Start: st.Since, End: epoch.Add(2 * time.Hour)}}, p.config.Window), server.requests)
+	assert.Equal(t, []timeRange{{Start: st.Since, End: epoch.Add(2 * time.Hour)}}, st.Pulled)
+}
+
+func TestPullWindowSplitsOnMaxRecords(t *testing.T) {
+	server := &logServer{}
+	p := newTestPuller(t, server, 4)
+
+	st := state{Since: epoch}
+	var pub published
+	now := epoch.Add(10*time.Minute + p.config.Delay)
+	require.NoError(t, p.pullGaps(context.Background(), &st, now, pub.publish))
+
+	// Windows are split at the second.
+	first := timeRange{Start: epoch, End: epoch.Add(150 * time.Second)}
+	second := timeRange{Start: first.End, End: epoch.Add(5 * time.Minute)}
+	third := timeRange{Start: epoch.Add(5 * time.Minute), End: epoch.Add(450 * time.Second)}
+	fourth := timeRange{Start: third.End, End: epoch.Add(10 * time.Minute)}
+	assert.Equal(t, []timeRange{at(0, 10), at(0, 5), first, second, at(5, 10), third, fourth}, server.requests)
+	assert.Len(t, pub.events, 10)
+	assert.Equal(t, []timeRange{at(0, 10)}, st.Pulled)
+}
+
+func TestPullValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "truncated",
+			body: `{"RayID":"1","EdgeEndTimestamp":"2024-05-01T00:00:00Z"}` + "\n" + `{"RayID":"2","Edge`,
+			want: "response is truncated",
+		},
+		{
+			name: "outside of window",
+			body: `{"RayID":"1","EdgeEndTimestamp":"2024-05-01T00:10:00Z"}` + "\n",
+			want: "EdgeEndTimestamp of record 0 is outside of the window",
+		},
+		{
+			name: "missing timestamp",
+			body: `{"RayID":"1"}` + "\n",
+			want: "record 0 has no EdgeEndTimestamp field",
+		},
+		{
+			name: "invalid record",
+			body: `{"RayID":"1"` + "\n",
+			want: "record 0 is not valid JSON",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newTestPuller(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, test.body)
+			}), 0)
+
+			st := state{Since: epoch}
+			var pub published
+			err := p.pullWindow(context.Background(), &st, at(0, 10), pub.publish)
+			var validationErr *validationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.ErrorContains(t, err, test.want)
+			assert.Empty(t, pub.events)
+			assert.Empty(t, st.Pulled)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// maxThrottleRetries is the number of times a throttled request is retried
+// before giving up. The window is then pulled again at the next interval.
+const maxThrottleRetries = 3
+
+// defaultRetryAfter is the time to wait after a throttled request that has
+// no Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+// statusError is returned for responses with an unexpected status code.
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d body: %s", e.code, e.body)
+}
+
+// validationError is returned when the response of a window is incomplete
+// or holds records outside of the window.
+type validationError struct {
+	window timeRange
+	reason string
+}
+
+func (e *validationError) Error() string {
+	return fmt.Sprintf("invalid response for window %s - %s: %s",
+		e.window.Start.Format(time.RFC3339), e.window.End.Format(time.RFC3339), e.reason)
+}
+
+// record is a log record pulled from Logpull.
+type record struct {
+	raw       []byte
+	timestamp time.Time
+}
+
+// logpullClient pulls the HTTP request logs of a zone.
+type logpullClient struct {
+	endpoint       string
+	zoneID         string
+	fields         []string
+	timestampField string
+	maxRecords     int
+
+	apiToken  string
+	authEmail string
+	authKey   string
+
+	client *http.Client
+	logger *logp.Logger
+}
+
+// pull returns the records of the window. The whole response is read and
+// validated before any record is returned, so that a window is either
+// published in full or pulled again later.
+func (c *logpullClient) pull(ctx context.Context, window timeRange) ([]record, error) {
+	data, err := c.get(ctx, c.receivedURL(window))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[len(data)-1] != '\n' {
+		return nil, &validationError{window: window, reason: "response is truncated"}
+	}
+
+	var records []record
+	for _, line := range bytes.Split(data[:len(data)-1], []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(line, &fields); err != nil {
+			return nil, &validationError{window: window, reason: fmt.Sprintf("record %d is not valid JSON: %v", len(records), err)}
+		}
+		v, ok := fields[c.timestampField]
+		if !ok {
+			return nil, &validationError{window: window, reason: fmt.Sprintf("record %d has no %s field", len(records), c.timestampField)}
+		}
+		var ts time.Time
+		if err := json.Unmarshal(v, &ts); err != nil {
+			return nil, &validationError{window: window, reason: fmt.Sprintf("invalid %s of record %d: %v", c.timestampField, len(records), err)}
+		}
+		if ts.Before(window.Start) || !ts.Before(window.End) {
+			return nil, &validationError{window: window, reason: fmt.Sprintf("%s of record %d is outside of the window: %s", c.timestampField, len(records), ts.Format(time.RFC3339Nano))}
+		}
+		records = append(records, record{raw: line, timestamp: ts})
+	}
+	return records, nil
+}
+
+// receivedURL returns the URL of the logs received during the window.
+func (c *logpullClient) receivedURL(window timeRange) string {
+	q := url.Values{
+		"start":      []string{window.Start.UTC().Format(time.RFC3339)},
+		"end":        []string{window.End.UTC().Format(time.RFC3339)},
+		"timestamps": []string{"rfc3339"},
+	}
+	if len(c.fields) != 0 {
+		q.Set("fields", strings.Join(c.fields, ","))
+	}
+	if c.maxRecords > 0 {
+		q.Set("count", strconv.Itoa(c.maxRecords))
+	}
+	return c.endpoint + "/zones/" + url.PathEscape(c.zoneID) + "/logs/received?" + q.Encode()
+}
+
+// get sends an authenticated GET request and returns the response body.
+// Throttled requests are retried after the time requested by the server.
+func (c *logpullClient) get(ctx context.Context, url string) ([]byte, error) {
+	for retries := 0; ; retries++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create request: %w", err)
+		}
+		if c.apiToken != "" {
+			req.Header.Set("Authorization", "Bearer "+c.apiToken)
+		} else {
+			req.Header.Set("X-Auth-Email", c.authEmail)
+			req.Header.Set("X-Auth-Key", c.authKey)
+		}
+
+		res, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+		data, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read response body: %w", err)
+		}
+
+		switch {
+		case res.StatusCode == http.StatusTooManyRequests && retries < maxThrottleRetries:
+			wait := retryAfter(res.Header.Get("Retry-After"))
+			c.logger.Debugw("request throttled", "url", url, "retry_after", wait)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		case res.StatusCode < 200 || res.StatusCode > 299:
+			return nil, &statusError{code: res.StatusCode, body: string(data)}
+		}
+		if res.ContentLength >= 0 && int64(len(data)) != res.ContentLength {
+			return nil, fmt.Errorf("read %d bytes of a %d bytes response", len(data), res.ContentLength)
+		}
+		return data, nil
+	}
+}
+
+// retryAfter returns the wait time of a Retry-After header, in seconds.
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,110 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudflare
+
+import (
+	"sort"
+	"time"
+)
+
+// timeRange is the time range [Start, End).
+type timeRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// state is the cursor of a zone. Instead of a single position, it keeps
+// track of the ranges successfully pulled, so that ranges that failed,
+// were rate limited or were missed while the input was stopped are
+// detected and pulled later.
+type state struct {
+	// Since is the start of the time range collected by the input. It
+	// moves forward as older logs are no longer available.
+	Since time.Time `json:"since"`
+	// Pulled is the sorted list of disjoint ranges pulled since Since.
+	Pulled []timeRange `json:"pulled,omitempty"`
+}
+
+// add records the range as pulled, merging it with adjacent ranges.
+func (s *state) add(r timeRange) {
+	ranges := append(s.Pulled, r)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start.Before(ranges[j].Start) })
+
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && !r.Start.After(merged[n-1].End) {
+			if r.End.After(merged[n-1].End) {
+				merged[n-1].End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	s.Pulled = merged
+}
+
+// gaps returns the ranges between Since and until that were not pulled.
+func (s *state) gaps(until time.Time) []timeRange {
+	var gaps []timeRange
+	start := s.Since
+	for _, r := range s.Pulled {
+		if !r.Start.Before(until) {
+			break
+		}
+		if r.Start.After(start) {
+			gaps = append(gaps, timeRange{Start: start, End: r.Start})
+		}
+		if r.End.After(start) {
+			start = r.End
+		}
+	}
+	if start.Before(until) {
+		gaps = append(gaps, timeRange{Start: start, End: until})
+	}
+	return gaps
+}
+
+// expire moves Since to horizon and returns the ranges before horizon that
+// were never pulled and are lost.
+func (s *state) expire(horizon time.Time) []timeRange {
+	if !s.Since.Before(horizon) {
+		return nil
+	}
+	lost := s.gaps(horizon)
+	s.Since = horizon
+
+	var kept []timeRange
+	for _, r := range s.Pulled {
+		if !r.End.After(horizon) {
+			continue
+		}
+		if r.Start.Before(horizon) {
+			r.Start = horizon
+		}
+		kept = append(kept, r)
+	}
+	s.Pulled = kept
+	return lost
+}
+
+// clone returns a copy of the state that is not modified by later updates.
+func (s *state) clone() state {
+	return state{Since: s.Since, Pulled: append([]timeRange(nil), s.Pulled...)}
+}
+
+// split splits the ranges in windows of at most size.
+func split(ranges []timeRange, size time.Duration) []timeRange {
+	var windows []timeRange
+	for _, r := range ranges {
+		for start := r.Start; start.Before(r.End); start = start.Add(size) {
+			end := start.Add(size)
+			if end.After(r.End) {
+				end = r.End
+			}
+			windows = append(windows, timeRange{Start: start, End: end})
+		}
+	}
+	return windows
+}
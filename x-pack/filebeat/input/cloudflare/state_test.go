@@ -0,0 +1,69 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudflare
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+// at returns the range between the given minutes after epoch.
+func at(start, end int) timeRange {
+	return timeRange{
+		Start: epoch.Add(time.Duration(start) * time.Minute),
+		End:   epoch.Add(time.Duration(end) * time.Minute),
+	}
+}
+
+func TestStateAdd(t *testing.T) {
+	st := state{Since: epoch}
+	st.add(at(20, 30))
+	st.add(at(0, 10))
+	st.add(at(40, 50))
+	assert.Equal(t, []timeRange{at(0, 10), at(20, 30), at(40, 50)}, st.Pulled)
+
+	st.add(at(10, 20))
+	assert.Equal(t, []timeRange{at(0, 30), at(40, 50)}, st.Pulled)
+
+	st.add(at(35, 45))
+	assert.Equal(t, []timeRange{at(0, 30), at(35, 50)}, st.Pulled)
+}
+
+func TestStateGaps(t *testing.T) {
+	st := state{Since: epoch}
+	assert.Equal(t, []timeRange{at(0, 60)}, st.gaps(epoch.Add(time.Hour)))
+
+	st.add(at(10, 20))
+	st.add(at(30, 40))
+	assert.Equal(t, []timeRange{at(0, 10), at(20, 30), at(40, 60)}, st.gaps(epoch.Add(time.Hour)))
+	assert.Equal(t, []timeRange{at(0, 10), at(20, 25)}, st.gaps(epoch.Add(25*time.Minute)))
+
+	st.add(at(0, 10))
+	st.add(at(40, 60))
+	assert.Equal(t, []timeRange{at(20, 30)}, st.gaps(epoch.Add(time.Hour)))
+}
+
+func TestStateExpire(t *testing.T) {
+	st := state{Since: epoch}
+	st.add(at(10, 20))
+	st.add(at(30, 40))
+
+	lost := st.expire(epoch.Add(35 * time.Minute))
+	assert.Equal(t, []timeRange{at(0, 10), at(20, 30)}, lost)
+	assert.Equal(t, epoch.Add(35*time.Minute), st.Since)
+	assert.Equal(t, []timeRange{at(35, 40)}, st.Pulled)
+
+	assert.Nil(t, st.expire(epoch))
+	assert.Equal(t, []timeRange{at(35, 40)}, st.Pulled)
+}
+
+func TestSplit(t *testing.T) {
+	windows := split([]timeRange{at(0, 12), at(20, 25)}, 5*time.Minute)
+	assert.Equal(t, []timeRange{at(0, 5), at(5, 10), at(10, 12), at(20, 25)}, windows)
+}
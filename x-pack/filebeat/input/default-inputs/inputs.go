@@ -10,6 +10,9 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/elastic-agent-libs/logp"
+
+	// Register the structured file formats of the filestream input.
+	_ "github.com/elastic/beats/v7/x-pack/filebeat/input/filestreamformat"
 )
 
 func Init(info beat.Info, log *logp.Logger, store beater.StateStore) []v2.Plugin {
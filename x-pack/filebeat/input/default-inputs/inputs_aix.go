@@ -9,10 +9,13 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/awss3"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/cloudflare"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/entityanalytics"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/github"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/http_endpoint"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/httpjson"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/lumberjack"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/msgraph"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -23,6 +26,9 @@ func xpackInputs(info beat.Info, log *logp.Logger, store beater.StateStore) []v2
 		http_endpoint.Plugin(),
 		httpjson.Plugin(log, store),
 		o365audit.Plugin(log, store),
+		msgraph.Plugin(log, store),
+		github.Plugin(log, store),
+		cloudflare.Plugin(log, store),
 		awss3.Plugin(store),
 		lumberjack.Plugin(),
 	}
@@ -15,15 +15,19 @@ import (
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/azureblobstorage"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/benchmark"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/cel"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/cloudflare"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/cloudfoundry"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/entityanalytics"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/gcs"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/github"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/http_endpoint"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/httpjson"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/lumberjack"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/msgraph"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/salesforce"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/sql"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/websocket"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -38,8 +42,12 @@ func xpackInputs(info beat.Info, log *logp.Logger, store beater.StateStore) []v2
 		http_endpoint.Plugin(),
 		httpjson.Plugin(log, store),
 		o365audit.Plugin(log, store),
+		msgraph.Plugin(log, store),
+		github.Plugin(log, store),
+		cloudflare.Plugin(log, store),
+		sql.Plugin(log, store),
 		awss3.Plugin(store),
-		awscloudwatch.Plugin(),
+		awscloudwatch.Plugin(store),
 		lumberjack.Plugin(),
 		salesforce.Plugin(log, store),
 		websocket.Plugin(log, store),
@@ -52,9 +52,58 @@ type config struct {
 	// Session is the name of an existing session to read from.
 	// Run 'logman query -ets' to list existing sessions.
 	Session string `config:"session"`
+	// Providers are enabled on the existing session, each one with its own
+	// level and keywords. Events from other providers of the session are
+	// discarded.
+	Providers []providerConfig `config:"providers"`
+	// Manifests is a list of instrumentation manifests (.man or .xml) or
+	// binaries embedding them, used to decode events of providers that are
+	// not registered in the system.
+	Manifests []string `config:"manifests"`
+	// TMFSearchPath is the directory containing the TMF files used to
+	// decode WPP events.
+	TMFSearchPath string `config:"tmf_search_path"`
+}
+
+// providerConfig defines a provider to enable on an existing session.
+type providerConfig struct {
+	GUID            string `config:"guid"`
+	Name            string `config:"name"`
+	TraceLevel      string `config:"trace_level"`
+	MatchAnyKeyword uint64 `config:"match_any_keyword"`
+	MatchAllKeyword uint64 `config:"match_all_keyword"`
+}
+
+func (p *providerConfig) InitDefaults() {
+	p.TraceLevel = "verbose"
+	p.MatchAnyKeyword = 0xffffffffffffffff
+}
+
+func (p *providerConfig) Validate() error {
+	if p.GUID == "" && p.Name == "" {
+		return fmt.Errorf("provider name or GUID must be set")
+	}
+	if p.GUID != "" && p.Name != "" {
+		return fmt.Errorf("configuration constraint error: provider GUID and provider name cannot be defined together")
+	}
+	if !validTraceLevel[p.TraceLevel] {
+		return fmt.Errorf("invalid Trace Level value '%s'", p.TraceLevel)
+	}
+	return nil
 }
 
 func convertConfig(cfg config) etw.Config {
+	providers := make([]etw.ProviderConfig, 0, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		providers = append(providers, etw.ProviderConfig{
+			GUID:            p.GUID,
+			Name:            p.Name,
+			TraceLevel:      p.TraceLevel,
+			MatchAnyKeyword: p.MatchAnyKeyword,
+			MatchAllKeyword: p.MatchAllKeyword,
+		})
+	}
+
 	return etw.Config{
 		Logfile:         cfg.Logfile,
 		ProviderGUID:    cfg.ProviderGUID,
@@ -64,6 +113,9 @@ func convertConfig(cfg config) etw.Config {
 		MatchAnyKeyword: cfg.MatchAnyKeyword,
 		MatchAllKeyword: cfg.MatchAllKeyword,
 		Session:         cfg.Session,
+		Providers:       providers,
+		Manifests:       cfg.Manifests,
+		TMFSearchPath:   cfg.TMFSearchPath,
 	}
 }
 
@@ -110,5 +162,9 @@ func (c *config) Validate() error {
 		}
 	}
 
+	if len(c.Providers) != 0 && c.Session == "" {
+		return fmt.Errorf("configuration constraint error: providers can only be defined with an existing session")
+	}
+
 	return nil
 }
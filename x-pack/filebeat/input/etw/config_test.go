@@ -115,6 +115,55 @@ func Test_validateConfig(t *testing.T) {
 			},
 			wantError: "configuration constraint error: file and existing session cannot be defined together",
 		},
+		{
+			name: "session with providers",
+			config: config{
+				Session: "EventLog-Application",
+				Providers: []providerConfig{
+					{Name: "Microsoft-Windows-DNSServer", TraceLevel: "warning"},
+					{GUID: "{eb79061a-a566-4698-1234-3ed2807033a0}", TraceLevel: "verbose", MatchAllKeyword: 0x8000000000000000},
+				},
+				Manifests:       []string{"C:\\Manifests\\provider.man"},
+				TraceLevel:      "verbose",
+				MatchAnyKeyword: 0xffffffffffffffff,
+			},
+		},
+		{
+			name: "providers without session",
+			config: config{
+				ProviderName: "Microsoft-Windows-DNSServer",
+				Providers: []providerConfig{
+					{Name: "Microsoft-Windows-Kernel-Process", TraceLevel: "verbose"},
+				},
+				TraceLevel:      "verbose",
+				MatchAnyKeyword: 0xffffffffffffffff,
+			},
+			wantError: "configuration constraint error: providers can only be defined with an existing session",
+		},
+		{
+			name: "provider without name or GUID",
+			config: config{
+				Session: "EventLog-Application",
+				Providers: []providerConfig{
+					{TraceLevel: "error"},
+				},
+				TraceLevel:      "verbose",
+				MatchAnyKeyword: 0xffffffffffffffff,
+			},
+			wantError: "provider name or GUID must be set",
+		},
+		{
+			name: "provider with invalid trace level",
+			config: config{
+				Session: "EventLog-Application",
+				Providers: []providerConfig{
+					{Name: "Microsoft-Windows-DNSServer", TraceLevel: "failed"},
+				},
+				TraceLevel:      "verbose",
+				MatchAnyKeyword: 0xffffffffffffffff,
+			},
+			wantError: "invalid Trace Level value 'failed'",
+		},
 	}
 
 	for _, tc := range testCases {
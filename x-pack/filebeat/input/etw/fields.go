@@ -19,5 +19,5 @@ func init() {
 // AssetEtw returns asset data.
 // This is the base64 encoded zlib format compressed contents of input/etw.
 func AssetEtw() string {
-	return "eJzUVk2P2zYQvftXDHLp7sFGtsDm4EOBIkjQAu2pLnJcjMiRNF1qqJCUVP/7gh/ySmsFaNG4aHwzRb55b+bNkHt4pvMRJhZjmx1A4GDoCG8+sWg7efhw+vRmB6DJK8d9YCtH+GEHAPCRyWgPtbMdhJbiTmDphwB3H0aSACeHiqWB2joocPeHHUCdDh53CWUPgh0tCMRfOPd0hMbZoS8rG/Hj70djChz4nhTXrCDYROciYMUFHYGmmoU0tOToUKBWnJa8UAUeOZyfWF++zQSf6TxZt1x39HlgR/oINRpPiy9fEJBEQGNshcacYRD+PBCwJglcMzkILYaX/z5JU4NzUdTM7QCnllaQFEX7fDhK7ofKsG9Jw8ShhdCyXwZJW9AFsHUK4LFb410iXSdItShC5lbJ+d2TjiUlwcpQrjKaLBB6ZxV5z9Ic4H3mASOagTxUZOwED++SNkee3Eg6WXHwBNUZfmXlrLd12EAPjjB0MUJ1vnjbDRK4o6tIMYZowMqOBBMbAxUBN2Id6Y3zcJfQo6qSaUA/ZxHe3icwhRJRGh5JImG3n03rqUMJrPxGKVJSnjQGvKqGrf4gFRbLeeHpq9bq1FLmsL80YyST7AnKSogZTRZjXwgArzp3bTo5xwqPrKNFRWfsDd21wcbfyoAfI3jupEIGWGrrOoy7Y9mHkEqZLekH1caCxpXII+r10aNWgANM6MHYpsmmjqK4XpyOSUIWD/RnINGkcwKvJRdxN1MdKzbHSC00lD5k0awwEKBkzt956KiryPmWe2ABBE+pzEUSBmqsY9pyrKHxdqPjlwie8z+SS3MyL5XGfQuhdXZoWnhc3QvL4fDqyLvLkYfH1WR5te/hZeP3j49zOy8CvJR8dvhGdmyvrKZbljhHuBBjeSF2gBP652TRsivqDeeeVb6qsiFWkOVKyfKMVblFCir2veGylB4NU7yAF5mY0K/Qcp9s5KWM/RteyT+vL9wSME+BhoTcZYB/aSbNZX1qhv/Ry+EyTtPHnOAV4t+SJOvnwVd3pbeDUzQ/RrI5jG3AkbJOw91rN1kHsQtZUdK1YaIElI/fbygrA/pWmt5bqbkZXLq+81UQbHyLTOh0ehuU91pqiznNPm5SVvzQkdsanvNYuxXtn4YOZe8IdXocmatxek0poH++pTMi/rc2rUIbU/jfDasc75/MqpHcpv2NlebfkvstPe5yjkuc6CGEDoVr8mFfYaxN4fZXAAAA///9F1n8"
+	return "eJzUVk1v3DYTvu+vGOTy2oddxC/gHPZQIAgStEB7qoscjRE5kqamSIUf2u6/L4aiZMleA60bGS2wl6XImeeZeeZjDw90PsKJrXHNDiByNHSEd1/ZancK8Pnu67sdgKagPPeRnT3CDzsAgC9MRgeovesgtiQ3gW2fIlx9HshGuPOo2DZQOw/F3PVhB1Dnh8ddtrIHix0tAMhhPPd0hMa71JeTC/7l99GYYg5CT4prVhBdhjMTWGFBT6CpZksaWvJ0KKZWmJa4UEUeOJ7vWc/fJoAPdD45vzz39C2xJ32EGk2gxZcXCMjvIzTGVWjMGZLlb4mANdnINZOH2GJ8/B8yNZW8F1ITtgPctUtfACSk5TLGTLlPleHQkoYTxxZiy2HpJF9BH8HV2UHAbm1v9vQ8QKpFa8lsFZzfAmlJKVmsDI1ZRjMShN47RSGwbQ7wacQBA5pEASoy7gQ3HzJ9T4H8QDpLMQWC6gy/sPIuuDpesB49YewkxNV51rZPNnJHzzyJD6sBKzcQnNgYqAi4sc6TvvAerrJ10nOkAcMURXh/nY0ptGKl4YEspEB+P4k2UIc2sgovp+LertP3XfNx11Ku2EkqE3AMc12xzdR67wbW5KFDyzWFeAFxTuO9xogLJyNeV/1OKi6Ox4P7784mY9jP7UPA5IIC5WwUDWSmHAoi4FWvWZlDe36kLXnMti/wrg02YasUfRHjY+0XMMC2dr5DuS1CTTFnKKODkFQrEpQTCa5kNkhVOQsc4YQBjGsaEazL4uR68VqChGwD0B+RrCY9BvA55UJuM9aSsclHLvpUOgdbzQojAdqxbfwvQEddRT603ANbQAiU01woYaTGeaZLNVZc5BrblEt2MFVZsRteWWaGhu069M9iXIAGGsjncTQelf74HmLrXWpauF2N32UPfvLkw/zk5nbVwJ/cu3m8+P/b26lrLhw86nQK04XouF45TVuFR3Q5eniauQzsAHcYHnJdlVvCN557VuNGMKp4ZbJM7nG0GKfGui5Wse8Nl6O8m51kz1lU7AmXuoVS3C/G5U2nyRSCV6m8bAMbbmo/rfew4nBstQ1Z8vNcf6nxTzzum/QvWijn6GYmoyBWFv8Spa2FElzyapZKhiPiBU/KeQ1XT9XvPEjXYEWZ9MreNNFaKs+vLzArU3ArTp+crblJsiZO8zY6WVFP6HVeOcsan8t4CnOQMaycDakjf2lCTW14K9g/pg7t3hPqvDObZ+3/OaSI4WErOKIMsf9f666C+U17qzh8ZWeNraT77Rrr6O/v9NWB/MVSNc42/xTcr3nbH/VQ/EgTwjli+woDaaCBbDzs/hwAl2kmIg=="
 }
@@ -180,11 +180,11 @@ var (
 )
 
 // buildEvent builds the final beat.Event emitted by this input.
-func buildEvent(data map[string]any, h etw.EventHeader, session *etw.Session, cfg config) beat.Event {
+func buildEvent(rendered *etw.RenderedEvent, h etw.EventHeader, session *etw.Session, cfg config) beat.Event {
 	winlog := map[string]any{
 		"activity_id":   h.ActivityId.String(),
 		"channel":       strconv.FormatUint(uint64(h.EventDescriptor.Channel), 10),
-		"event_data":    data,
+		"event_data":    rendered.Properties,
 		"flags":         strconv.FormatUint(uint64(h.Flags), 10),
 		"keywords":      strconv.FormatUint(h.EventDescriptor.Keyword, 10),
 		"opcode":        strconv.FormatUint(uint64(h.EventDescriptor.Opcode), 10),
@@ -199,6 +199,21 @@ func buildEvent(data map[string]any, h etw.EventHeader, session *etw.Session, cf
 	if h.ProviderId == zeroGUID {
 		winlog["provider_guid"] = session.GUID.String()
 	}
+	// Add the names defined by the provider manifest when available.
+	for k, v := range map[string]string{
+		"provider_name": rendered.ProviderName,
+		"level":         rendered.Level,
+		"channel_name":  rendered.Channel,
+		"task_name":     rendered.Task,
+		"opcode_name":   rendered.Opcode,
+	} {
+		if v != "" {
+			winlog[k] = v
+		}
+	}
+	if len(rendered.Keywords) != 0 {
+		winlog["keyword_names"] = rendered.Keywords
+	}
 
 	event := mapstr.M{
 		"code":     strconv.FormatUint(uint64(h.EventDescriptor.Id), 10),
@@ -208,6 +223,8 @@ func buildEvent(data map[string]any, h etw.EventHeader, session *etw.Session, cf
 	}
 	if cfg.ProviderName != "" {
 		event["provider"] = cfg.ProviderName
+	} else if rendered.ProviderName != "" {
+		event["provider"] = rendered.ProviderName
 	}
 
 	fields := mapstr.M{
@@ -220,6 +237,9 @@ func buildEvent(data map[string]any, h etw.EventHeader, session *etw.Session, cf
 	if cfg.Logfile != "" {
 		fields.Put("log.file.path", cfg.Logfile)
 	}
+	if rendered.Message != "" {
+		fields["message"] = rendered.Message
+	}
 
 	return beat.Event{
 		Timestamp: convertFileTimeToGoTime(uint64(h.TimeStamp)),
@@ -251,13 +271,20 @@ func (e *etwInput) consumeEvent(record *etw.EventRecord) uintptr {
 		return 1
 	}
 
+	// Discard the events of a shared session not matching the configured
+	// providers.
+	if !e.etwSession.Match(&record.EventHeader) {
+		e.metrics.filtered.Inc()
+		return 0
+	}
+
 	start := time.Now()
 	defer func() {
 		elapsed := time.Since(start)
 		e.metrics.processingTime.Update(elapsed.Nanoseconds())
 	}()
 
-	data, err := etw.GetEventProperties(record)
+	rendered, err := e.etwSession.RenderEvent(record)
 	if err != nil {
 		e.log.Errorw("failed to read event properties", "error", err)
 		e.metrics.errors.Inc()
@@ -265,7 +292,7 @@ func (e *etwInput) consumeEvent(record *etw.EventRecord) uintptr {
 		return 1
 	}
 
-	evt := buildEvent(data, record.EventHeader, e.etwSession, e.config)
+	evt := buildEvent(rendered, record.EventHeader, e.etwSession, e.config)
 	e.publisher.Publish(evt)
 
 	e.metrics.events.Inc()
@@ -297,6 +324,7 @@ type inputMetrics struct {
 	name           *monitoring.String // name of the etw session being read
 	events         *monitoring.Uint   // total number of events received
 	dropped        *monitoring.Uint   // total number of discarded events
+	filtered       *monitoring.Uint   // total number of events not matching the configured providers
 	errors         *monitoring.Uint   // total number of errors
 	sourceLag      metrics.Sample     // histogram of the difference between timestamped event's creation and reading
 	arrivalPeriod  metrics.Sample     // histogram of the elapsed time between callbacks.
@@ -312,6 +340,7 @@ func newInputMetrics(session, id string) *inputMetrics {
 		name:           monitoring.NewString(reg, "session"),
 		events:         monitoring.NewUint(reg, "received_events_total"),
 		dropped:        monitoring.NewUint(reg, "discarded_events_total"),
+		filtered:       monitoring.NewUint(reg, "filtered_events_total"),
 		errors:         monitoring.NewUint(reg, "errors_total"),
 		sourceLag:      metrics.NewUniformSample(1024),
 		arrivalPeriod:  metrics.NewUniformSample(1024),
@@ -310,7 +310,7 @@ func Test_RunEtwInput_Success(t *testing.T) {
 func Test_buildEvent(t *testing.T) {
 	tests := []struct {
 		name     string
-		data     map[string]any
+		rendered *etw.RenderedEvent
 		header   etw.EventHeader
 		session  *etw.Session
 		cfg      config
@@ -318,8 +318,10 @@ func Test_buildEvent(t *testing.T) {
 	}{
 		{
 			name: "TestStandardData",
-			data: map[string]any{
-				"key": "value",
+			rendered: &etw.RenderedEvent{
+				Properties: map[string]any{
+					"key": "value",
+				},
 			},
 			header: etw.EventHeader{
 				Size:          0,
@@ -391,8 +393,10 @@ func Test_buildEvent(t *testing.T) {
 		{
 			// This case tests an unmapped severity, empty provider GUID and including logfile
 			name: "TestAlternativeMetadata",
-			data: map[string]any{
-				"key": "value",
+			rendered: &etw.RenderedEvent{
+				Properties: map[string]any{
+					"key": "value",
+				},
 			},
 			header: etw.EventHeader{
 				Size:          0,
@@ -456,11 +460,84 @@ func Test_buildEvent(t *testing.T) {
 				"log.file.path":  "C:\\TestFile",
 			},
 		},
+		{
+			// This case tests the names and message rendered from a provider manifest
+			name: "TestManifestRendering",
+			rendered: &etw.RenderedEvent{
+				Properties: map[string]any{
+					"User": "alice",
+				},
+				ProviderName: "Microsoft-Windows-TestProvider",
+				Level:        "Information",
+				Channel:      "Microsoft-Windows-TestProvider/Operational",
+				Task:         "Logon",
+				Opcode:       "Info",
+				Keywords:     []string{"Audit Success"},
+				Message:      "User alice logged on.",
+			},
+			header: etw.EventHeader{
+				Flags:     30,
+				ThreadId:  80,
+				ProcessId: 60,
+				TimeStamp: 133516441890350000,
+				ProviderId: windows.GUID{
+					Data1: 0x12345678,
+					Data2: 0x1234,
+					Data3: 0x1234,
+					Data4: [8]byte{0x12, 0x34, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc},
+				},
+				EventDescriptor: etw.EventDescriptor{
+					Id:      20,
+					Version: 90,
+					Channel: 10,
+					Level:   4, // Information
+					Opcode:  50,
+					Task:    70,
+					Keyword: 40,
+				},
+			},
+			session: &etw.Session{
+				Name: "EventLog-Application",
+			},
+			cfg: config{
+				Session: "EventLog-Application",
+			},
+
+			expected: mapstr.M{
+				"winlog": map[string]any{
+					"activity_id": "{00000000-0000-0000-0000-000000000000}",
+					"channel":     "10",
+					"event_data": map[string]any{
+						"User": "alice",
+					},
+					"flags":         "30",
+					"keywords":      "40",
+					"opcode":        "50",
+					"process_id":    "60",
+					"provider_guid": "{12345678-1234-1234-1234-123456789ABC}",
+					"session":       "EventLog-Application",
+					"task":          "70",
+					"thread_id":     "80",
+					"version":       "90",
+					"provider_name": "Microsoft-Windows-TestProvider",
+					"level":         "Information",
+					"channel_name":  "Microsoft-Windows-TestProvider/Operational",
+					"task_name":     "Logon",
+					"opcode_name":   "Info",
+					"keyword_names": []string{"Audit Success"},
+				},
+				"event.code":     "20",
+				"event.provider": "Microsoft-Windows-TestProvider",
+				"event.severity": uint8(4),
+				"log.level":      "information",
+				"message":        "User alice logged on.",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			evt := buildEvent(tt.data, tt.header, tt.session, tt.cfg)
+			evt := buildEvent(tt.rendered, tt.header, tt.session, tt.cfg)
 			assert.Equal(t, tt.expected["winlog"].(map[string]any)["activity_id"], evt.Fields["winlog"].(map[string]any)["activity_id"])
 			assert.Equal(t, tt.expected["winlog"].(map[string]any)["channel"], evt.Fields["winlog"].(map[string]any)["channel"])
 			assert.Equal(t, tt.expected["winlog"].(map[string]any)["event_data"], evt.Fields["winlog"].(map[string]any)["event_data"])
@@ -472,6 +549,9 @@ func Test_buildEvent(t *testing.T) {
 			assert.Equal(t, tt.expected["winlog"].(map[string]any)["session"], evt.Fields["winlog"].(map[string]any)["session"])
 			assert.Equal(t, tt.expected["winlog"].(map[string]any)["task"], evt.Fields["winlog"].(map[string]any)["task"])
 			assert.Equal(t, tt.expected["winlog"].(map[string]any)["thread_id"], evt.Fields["winlog"].(map[string]any)["thread_id"])
+			for _, k := range []string{"provider_name", "level", "channel_name", "task_name", "opcode_name", "keyword_names"} {
+				assert.Equal(t, tt.expected["winlog"].(map[string]any)[k], evt.Fields["winlog"].(map[string]any)[k], k)
+			}
 			mapEv := evt.Fields.Flatten()
 
 			assert.Equal(t, tt.expected["winlog"].(map[string]any)["version"], strconv.Itoa(int(mapEv["winlog.version"].(uint8))))
@@ -480,6 +560,7 @@ func Test_buildEvent(t *testing.T) {
 			assert.Equal(t, tt.expected["event.severity"], mapEv["event.severity"])
 			assert.Equal(t, tt.expected["log.file.path"], mapEv["log.file.path"])
 			assert.Equal(t, tt.expected["log.level"], mapEv["log.level"])
+			assert.Equal(t, tt.expected["message"], mapEv["message"])
 		})
 	}
 }
@@ -0,0 +1,81 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package filestreamformat registers the structured file formats that the
+// filestream input can read with the `format` setting.
+package filestreamformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/beats/v7/filebeat/input/filestream"
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+	"github.com/elastic/beats/v7/x-pack/libbeat/reader/parquet"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	filestream.RegisterFormat("parquet", newParquetReader)
+}
+
+// parquetReader reads the rows of a Parquet file one row group batch at a
+// time, so the memory used doesn't depend on the size of the file.
+type parquetReader struct {
+	reader *parquet.BufferedReader
+
+	rows    []mapstr.M // decoded rows of the current batch not returned yet
+	rowSize int        // average size of the rows of the current batch
+}
+
+func newParquetReader(f *os.File, cfg *conf.C) (filestream.FormatReader, error) {
+	config := parquet.Config{BatchSize: 1000}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	r, err := parquet.NewReaderAt(f, &config)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetReader{reader: r}, nil
+}
+
+func (r *parquetReader) Next() (mapstr.M, int, error) {
+	for len(r.rows) == 0 {
+		if !r.reader.Next() {
+			return nil, 0, io.EOF
+		}
+		data, err := r.reader.Record()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var rows []mapstr.M
+		if err := dec.Decode(&rows); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode parquet rows: %w", err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		r.rows = rows
+		r.rowSize = len(data) / len(rows)
+	}
+
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	// Keep int64 columns exact instead of converting them to float64.
+	jsontransform.TransformNumbers(row)
+	return row, r.rowSize, nil
+}
+
+func (r *parquetReader) Close() error {
+	return r.reader.Close()
+}
@@ -0,0 +1,73 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package filestreamformat
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+const testDataPath = "../../../libbeat/reader/parquet/testdata/"
+
+func TestParquetReader(t *testing.T) {
+	for _, batchSize := range []int{1, 7, 1000} {
+		f, err := os.Open(testDataPath + "route53.parquet")
+		require.NoError(t, err)
+		defer f.Close()
+
+		cfg := conf.MustNewConfigFrom(map[string]interface{}{"batch_size": batchSize})
+		r, err := newParquetReader(f, cfg)
+		require.NoError(t, err)
+
+		var expected []map[string]interface{}
+		data, err := os.ReadFile(testDataPath + "route53.json")
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &expected))
+
+		rows := 0
+		for {
+			row, size, err := r.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			assert.Positive(t, size)
+
+			// Compare through JSON, as the numbers of the rows keep their integer types.
+			got, err := json.Marshal(row)
+			require.NoError(t, err)
+			want, err := json.Marshal(expected[rows])
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+			rows++
+		}
+		assert.Equal(t, len(expected), rows, "batch size %d", batchSize)
+		assert.NoError(t, r.Close())
+	}
+}
+
+func TestParquetReaderColumns(t *testing.T) {
+	f, err := os.Open(testDataPath + "route53.parquet")
+	require.NoError(t, err)
+	defer f.Close()
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{"columns": []string{"activity_name"}})
+	r, err := newParquetReader(f, cfg)
+	require.NoError(t, err)
+	defer r.Close()
+
+	row, _, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "Response", row["activity_name"])
+	assert.Len(t, row, 1)
+}
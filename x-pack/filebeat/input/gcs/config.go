@@ -10,8 +10,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/match"
 )
 
-// MaxWorkers, Poll, PollInterval, BucketTimeOut, ParseJSON, FileSelectors, TimeStampEpoch & ExpandEventListFromField
-// can be configured at a global level, which applies to all buckets, as well as at the bucket level.
+// MaxWorkers, Poll, PollInterval, BucketTimeOut, ParseJSON, FileSelectors, TimeStampEpoch, ExpandEventListFromField,
+// ObjectVersions & Decoding can be configured at a global level, which applies to all buckets, as well as at the bucket level.
 // Bucket level configurations will always override global level values.
 type config struct {
 	// ProjectId - Defines the project id of the concerned gcs bucket in Google Cloud.
@@ -37,6 +37,11 @@ type config struct {
 	TimeStampEpoch *int64 `config:"timestamp_epoch"`
 	// ExpandEventListFromField - Defines the field name that will be used to expand the event into separate events.
 	ExpandEventListFromField string `config:"expand_event_list_from_field"`
+	// ObjectVersions - Defines if all the generations of the objects of a versioned bucket are listed and processed,
+	// instead of only the live ones.
+	ObjectVersions *bool `config:"object_versions,omitempty"`
+	// Decoding - Defines the codec used to decode the objects, instead of reading them as JSON.
+	Decoding *decoderConfig `config:"decoding"`
 	// This field is only used for system test purposes, to override the HTTP endpoint.
 	AlternativeHost string `config:"alternative_host,omitempty"`
 }
@@ -52,6 +57,8 @@ type bucket struct {
 	FileSelectors            []fileSelectorConfig `config:"file_selectors"`
 	TimeStampEpoch           *int64               `config:"timestamp_epoch"`
 	ExpandEventListFromField string               `config:"expand_event_list_from_field"`
+	ObjectVersions           *bool                `config:"object_versions,omitempty"`
+	Decoding                 *decoderConfig       `config:"decoding"`
 }
 
 // fileSelectorConfig helps filter out gcs objects based on a regex pattern
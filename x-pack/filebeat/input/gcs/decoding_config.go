@@ -0,0 +1,37 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcs
+
+import "github.com/elastic/beats/v7/x-pack/libbeat/reader/parquet"
+
+// decoderConfig contains the configuration options for instantiating a decoder.
+type decoderConfig struct {
+	Codec *codecConfig `config:"codec"`
+}
+
+// codecConfig contains the configuration options for different codecs used by a decoder.
+type codecConfig struct {
+	Parquet *parquetCodecConfig `config:"parquet"`
+}
+
+// parquetCodecConfig contains the configuration options for the parquet codec.
+type parquetCodecConfig struct {
+	Enabled         bool     `config:"enabled"`
+	ProcessParallel bool     `config:"process_parallel"`
+	BatchSize       int      `config:"batch_size" default:"1"`
+	Columns         []string `config:"columns"`
+}
+
+// parquetConfig returns the parquet codec config, or nil if the parquet codec is not enabled.
+func (c *decoderConfig) parquetConfig() *parquet.Config {
+	if c == nil || c.Codec == nil || c.Codec.Parquet == nil || !c.Codec.Parquet.Enabled {
+		return nil
+	}
+	return &parquet.Config{
+		ProcessParallel: c.Codec.Parquet.ProcessParallel,
+		BatchSize:       c.Codec.Parquet.BatchSize,
+		Columns:         c.Codec.Parquet.Columns,
+	}
+}
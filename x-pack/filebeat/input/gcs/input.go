@@ -71,6 +71,8 @@ func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
 			TimeStampEpoch:           bucket.TimeStampEpoch,
 			ExpandEventListFromField: bucket.ExpandEventListFromField,
 			FileSelectors:            bucket.FileSelectors,
+			ObjectVersions:           *bucket.ObjectVersions,
+			Decoding:                 bucket.Decoding,
 		})
 	}
 
@@ -125,6 +127,16 @@ func tryOverrideOrDefault(cfg config, b bucket) bucket {
 	if len(b.FileSelectors) == 0 && len(cfg.FileSelectors) != 0 {
 		b.FileSelectors = cfg.FileSelectors
 	}
+	if b.ObjectVersions == nil {
+		var versions bool
+		if cfg.ObjectVersions != nil {
+			versions = *cfg.ObjectVersions
+		}
+		b.ObjectVersions = &versions
+	}
+	if b.Decoding == nil {
+		b.Decoding = cfg.Decoding
+	}
 
 	return b
 }
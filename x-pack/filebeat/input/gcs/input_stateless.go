@@ -62,6 +62,8 @@ func (in *statelessInput) Run(inputCtx v2.Context, publisher stateless.Publisher
 			TimeStampEpoch:           bucket.TimeStampEpoch,
 			ExpandEventListFromField: bucket.ExpandEventListFromField,
 			FileSelectors:            bucket.FileSelectors,
+			ObjectVersions:           *bucket.ObjectVersions,
+			Decoding:                 bucket.Decoding,
 		}
 
 		st := newState()
@@ -15,6 +15,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 	"unicode"
 
@@ -22,6 +24,7 @@ import (
 
 	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/libbeat/reader/parquet"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -68,24 +71,52 @@ func newJob(bucket *storage.BucketHandle, object *storage.ObjectAttrs, objectURI
 	}
 }
 
-// gcsObjectHash returns a short sha256 hash of the bucket name + object name.
+// gcsObjectHash returns a short sha256 hash of the bucket name + object name. The generation
+// of the object is part of the hash when object versions are processed.
 func gcsObjectHash(src *Source, object *storage.ObjectAttrs) string {
 	h := sha256.New()
 	h.Write([]byte(src.BucketName))
 	h.Write([]byte((object.Name)))
+	if src.ObjectVersions {
+		h.Write([]byte(strconv.FormatInt(object.Generation, 10)))
+	}
 	return hex.EncodeToString(h.Sum(nil)[:5])
 }
 
+// versionedName returns the name of an object generation, in the format used by gs:// URIs.
+func versionedName(name string, generation int64) string {
+	return name + "#" + strconv.FormatInt(generation, 10)
+}
+
+// parseVersionedName returns the object name and the generation of a versioned name.
+func parseVersionedName(name string) (string, int64, error) {
+	i := strings.LastIndexByte(name, '#')
+	if i < 0 {
+		return "", 0, fmt.Errorf("no generation in object name %s", name)
+	}
+	generation, err := strconv.ParseInt(name[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid generation in object name %s: %w", name, err)
+	}
+	return name[:i], generation, nil
+}
+
 func (j *job) do(ctx context.Context, id string) {
 	var fields mapstr.M
 
-	if allowedContentTypes[j.object.ContentType] {
+	if cfg := j.src.Decoding.parquetConfig(); cfg != nil {
+		err := j.processAndPublishParquet(ctx, cfg, id)
+		if err != nil {
+			j.state.updateFailedJobs(j.stateName())
+			j.log.Errorw("job encountered an error while publishing data and has been added to a failed jobs list", "gcs.jobId", id, "error", err)
+		}
+	} else if allowedContentTypes[j.object.ContentType] {
 		if j.object.ContentType == gzType || j.object.ContentEncoding == encodingGzip {
 			j.isCompressed = true
 		}
 		err := j.processAndPublishData(ctx, id)
 		if err != nil {
-			j.state.updateFailedJobs(j.object.Name)
+			j.state.updateFailedJobs(j.stateName())
 			j.log.Errorw("job encountered an error while publishing data and has been added to a failed jobs list", "gcs.jobId", id, "error", err)
 			return
 		}
@@ -101,7 +132,7 @@ func (j *job) do(ctx context.Context, id string) {
 		}
 		event.SetID(objectID(j.hash, 0))
 		// locks while data is being saved and published to avoid concurrent map read/writes
-		cp, done := j.state.saveForTx(j.object.Name, j.object.Updated)
+		cp, done := j.state.saveForTx(j.stateName(), j.object.Generation, j.object.Updated)
 		if err := j.publisher.Publish(event, cp); err != nil {
 			j.log.Errorw("job encountered an error while publishing event", "gcs.jobId", id, "error", err)
 		}
@@ -114,6 +145,25 @@ func (j *job) Name() string {
 	return j.object.Name
 }
 
+// stateName returns the name of the job in the state. When object versions are
+// processed, every generation of an object is tracked on its own.
+func (j *job) stateName() string {
+	if j.src.ObjectVersions {
+		return versionedName(j.object.Name, j.object.Generation)
+	}
+	return j.object.Name
+}
+
+// objectHandle returns the handle of the object to read. When object versions are
+// processed, it reads the generation of the job rather than the live object.
+func (j *job) objectHandle() *storage.ObjectHandle {
+	obj := j.bucket.Object(j.object.Name)
+	if j.src.ObjectVersions {
+		obj = obj.Generation(j.object.Generation)
+	}
+	return obj
+}
+
 func (j *job) Source() *Source {
 	return j.src
 }
@@ -125,7 +175,7 @@ func (j *job) Timestamp() time.Time {
 func (j *job) processAndPublishData(ctx context.Context, id string) error {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, j.src.BucketTimeOut)
 	defer cancel()
-	obj := j.bucket.Object(j.object.Name)
+	obj := j.objectHandle()
 	reader, err := obj.NewReader(ctxWithTimeout)
 	if err != nil {
 		return fmt.Errorf("failed to open reader for object: %s, with error: %w", j.object.Name, err)
@@ -145,6 +195,103 @@ func (j *job) processAndPublishData(ctx context.Context, id string) error {
 	return err
 }
 
+// processAndPublishParquet decodes a parquet object and publishes an event for each of its rows.
+// Uncompressed objects are read with range requests, one row group at a time, fetching only the
+// column chunks of the selected columns. Gzipped objects have to be downloaded and decoded in memory.
+func (j *job) processAndPublishParquet(ctx context.Context, cfg *parquet.Config, id string) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, j.src.BucketTimeOut)
+	defer cancel()
+	obj := j.objectHandle()
+
+	var dec *parquet.BufferedReader
+	if j.object.ContentType == gzType || j.object.ContentEncoding == encodingGzip {
+		reader, err := obj.NewReader(ctxWithTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to open reader for object: %s, with error: %w", j.object.Name, err)
+		}
+		defer func() {
+			err = reader.Close()
+			if err != nil {
+				j.log.Errorw("failed to close reader for object", "objectName", j.object.Name, "error", err)
+			}
+		}()
+		r, err := j.addGzipDecoderIfNeeded(bufio.NewReader(reader))
+		if err != nil {
+			return fmt.Errorf("failed to add gzip decoder to object: %s, with error: %w", j.object.Name, err)
+		}
+		dec, err = parquet.NewBufferedReader(r, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create parquet decoder for object: %s, with error: %w", j.object.Name, err)
+		}
+	} else {
+		var err error
+		dec, err = parquet.NewReaderAt(&objectReaderAt{ctx: ctxWithTimeout, obj: obj, size: j.object.Size}, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create parquet decoder for object: %s, with error: %w", j.object.Name, err)
+		}
+	}
+	defer func() {
+		err := dec.Close()
+		if err != nil {
+			j.log.Errorw("failed to close parquet decoder for object", "objectName", j.object.Name, "error", err)
+		}
+	}()
+
+	return j.readParquetAndPublish(ctx, dec, id)
+}
+
+// readParquetAndPublish publishes an event for each row decoded from a parquet object. The row number
+// is used as the offset of the event. Events are published one row behind the decoder, so that the
+// state is saved with the last row of the object.
+func (j *job) readParquetAndPublish(ctx context.Context, dec *parquet.BufferedReader, id string) error {
+	var (
+		row     int64
+		pending *beat.Event
+	)
+	for dec.Next() && ctx.Err() == nil {
+		data, err := dec.Record()
+		if err != nil {
+			return fmt.Errorf("failed to decode parquet record of object: %s, with error: %w", j.object.Name, err)
+		}
+		var items []json.RawMessage
+		if err = json.Unmarshal(data, &items); err != nil {
+			return fmt.Errorf("failed to decode parquet record of object: %s, with error: %w", j.object.Name, err)
+		}
+
+		for _, item := range items {
+			if pending != nil {
+				// since we don't update the cursor checkpoint, lack of a lock here is not a problem
+				if err := j.publisher.Publish(*pending, nil); err != nil {
+					j.log.Errorw("job encountered an error while publishing event", "gcs.jobId", id, "error", err)
+				}
+			}
+			var parsedData []mapstr.M
+			if j.src.ParseJSON {
+				parsedData, err = decodeJSON(bytes.NewReader(item))
+				if err != nil {
+					j.log.Errorw("job encountered an error", "gcs.jobId", id, "error", err)
+				}
+			}
+			evt := j.createEvent(item, parsedData, row)
+			pending = &evt
+			row++
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if pending != nil {
+		// this is the last row, so perform a complete state save
+		cp, done := j.state.saveForTx(j.stateName(), j.object.Generation, j.object.Updated)
+		if err := j.publisher.Publish(*pending, cp); err != nil {
+			j.log.Errorw("job encountered an error while publishing event", "gcs.jobId", id, "error", err)
+		}
+		done()
+	}
+	return nil
+}
+
 func (j *job) readJsonAndPublish(ctx context.Context, r io.Reader, id string) error {
 	r, err := j.addGzipDecoderIfNeeded(bufio.NewReader(r))
 	if err != nil {
@@ -192,7 +339,7 @@ func (j *job) readJsonAndPublish(ctx context.Context, r io.Reader, id string) er
 		evt := j.createEvent(item, parsedData, offset)
 		if !dec.More() {
 			// if this is the last object, then perform a complete state save
-			cp, done := j.state.saveForTx(j.object.Name, j.object.Updated)
+			cp, done := j.state.saveForTx(j.stateName(), j.object.Generation, j.object.Updated)
 			if err := j.publisher.Publish(evt, cp); err != nil {
 				j.log.Errorw("job encountered an error while publishing event", "gcs.jobId", id, "error", err)
 			}
@@ -248,7 +395,7 @@ func (j *job) splitEventList(key string, raw json.RawMessage, offset int64, objH
 
 		if !dec.More() {
 			// if this is the last object, then perform a complete state save
-			cp, done := j.state.saveForTx(j.object.Name, j.object.Updated)
+			cp, done := j.state.saveForTx(j.stateName(), j.object.Generation, j.object.Updated)
 			if err := j.publisher.Publish(evt, cp); err != nil {
 				j.log.Errorw("job encountered an error while publishing event", "gcs.jobId", id, "error", err)
 			}
@@ -343,6 +490,7 @@ func (j *job) createEvent(message []byte, data []mapstr.M, offset int64) beat.Ev
 					},
 					"object": mapstr.M{
 						"name":         j.object.Name,
+						"generation":   j.object.Generation,
 						"content_type": j.object.ContentType,
 						"json_data":    data, // objectified data, if parseJSON == true, else its empty array
 					},
@@ -364,3 +512,49 @@ func (j *job) createEvent(message []byte, data []mapstr.M, offset int64) beat.Ev
 func objectID(objectHash string, offset int64) string {
 	return fmt.Sprintf("%s-%012d", objectHash, offset)
 }
+
+// objectReaderAt reads an object with range requests. It allows the parquet decoder
+// to fetch the footer and the column chunks it needs instead of the whole object.
+type objectReaderAt struct {
+	ctx    context.Context
+	obj    *storage.ObjectHandle
+	size   int64
+	offset int64
+}
+
+func (r *objectReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	length := int64(len(p))
+	if off+length > r.size {
+		length = r.size - off
+	}
+	reader, err := r.obj.NewRangeReader(r.ctx, off, length)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	n, err := io.ReadFull(reader, p[:length])
+	if err == nil && length < int64(len(p)) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *objectReaderAt) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, fmt.Errorf("invalid whence: %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.offset = offset
+	return offset, nil
+}
@@ -101,7 +101,11 @@ func (s *scheduler) scheduleOnce(ctx context.Context) error {
 
 		// If previous checkpoint was saved then look up starting point for new jobs
 		if !s.state.checkpoint().LatestEntryTime.IsZero() {
-			jobs = s.moveToLastSeenJob(jobs)
+			if s.src.ObjectVersions {
+				jobs = s.moveToNewGenerations(jobs)
+			} else {
+				jobs = s.moveToLastSeenJob(jobs)
+			}
 			if len(s.state.checkpoint().FailedJobs) > 0 {
 				jobs = s.addFailedJobs(ctx, jobs)
 			}
@@ -161,8 +165,7 @@ func (s *scheduler) createJobs(objects []*storage.ObjectAttrs, log *logp.Logger)
 			continue
 		}
 
-		objectURI := "gs://" + s.src.BucketName + "/" + obj.Name
-		job := newJob(s.bucket, obj, objectURI, s.state, s.src, s.publisher, log, false)
+		job := newJob(s.bucket, obj, s.objectURI(obj), s.state, s.src, s.publisher, log, false)
 		jobs = append(jobs, job)
 	}
 
@@ -172,7 +175,7 @@ func (s *scheduler) createJobs(objects []*storage.ObjectAttrs, log *logp.Logger)
 // fetchObjectPager fetches the page handler for objects, given a batch size.
 // [NOTE] : There are no api's / sdk functions that list blobs via timestamp/latest entry, it's always lexicographical order
 func (s *scheduler) fetchObjectPager(ctx context.Context, pageSize int) *iterator.Pager {
-	bktIt := s.bucket.Objects(ctx, &storage.Query{})
+	bktIt := s.bucket.Objects(ctx, &storage.Query{Versions: s.src.ObjectVersions})
 	pager := iterator.NewPager(bktIt, pageSize, "")
 
 	return pager
@@ -218,11 +221,34 @@ func (s *scheduler) moveToLastSeenJob(jobs []*job) []*job {
 	return jobsToReturn
 }
 
+// objectURI returns the gs:// URI of an object. The generation is part of the URI
+// when object versions are processed.
+func (s *scheduler) objectURI(obj *storage.ObjectAttrs) string {
+	if s.src.ObjectVersions {
+		return "gs://" + s.src.BucketName + "/" + versionedName(obj.Name, obj.Generation)
+	}
+	return "gs://" + s.src.BucketName + "/" + obj.Name
+}
+
+// moveToNewGenerations keeps the jobs of the object generations created after the latest
+// generation processed. Generation numbers grow over time across the whole bucket, so they
+// identify new objects as well as new versions of existing objects, whatever their names.
+func (s *scheduler) moveToNewGenerations(jobs []*job) []*job {
+	latest := s.state.checkpoint().LatestGeneration
+	jobsToReturn := make([]*job, 0, len(jobs))
+	for _, job := range jobs {
+		if job.object.Generation > latest {
+			jobsToReturn = append(jobsToReturn, job)
+		}
+	}
+	return jobsToReturn
+}
+
 func (s *scheduler) addFailedJobs(ctx context.Context, jobs []*job) []*job {
 	jobMap := make(map[string]bool)
 
 	for _, j := range jobs {
-		jobMap[j.Name()] = true
+		jobMap[j.stateName()] = true
 	}
 
 	failedJobs := s.state.checkpoint().FailedJobs
@@ -230,14 +256,13 @@ func (s *scheduler) addFailedJobs(ctx context.Context, jobs []*job) []*job {
 	fj := 0
 	for name := range failedJobs {
 		if !jobMap[name] {
-			obj, err := s.bucket.Object(name).Attrs(ctx)
+			obj, err := s.failedObjectAttrs(ctx, name)
 			if err != nil {
 				s.log.Errorf("adding failed job %s to job list caused an error: %w", err)
 				continue
 			}
 
-			objectURI := "gs://" + s.src.BucketName + "/" + obj.Name
-			job := newJob(s.bucket, obj, objectURI, s.state, s.src, s.publisher, s.log, true)
+			job := newJob(s.bucket, obj, s.objectURI(obj), s.state, s.src, s.publisher, s.log, true)
 			jobs = append(jobs, job)
 			s.log.Debugf("scheduler: adding failed job number %d with name %s to job current list", fj, job.Name())
 			fj++
@@ -246,6 +271,19 @@ func (s *scheduler) addFailedJobs(ctx context.Context, jobs []*job) []*job {
 	return jobs
 }
 
+// failedObjectAttrs returns the attributes of the object of a failed job. When object
+// versions are processed, the job name holds the generation of the object to fetch.
+func (s *scheduler) failedObjectAttrs(ctx context.Context, name string) (*storage.ObjectAttrs, error) {
+	if !s.src.ObjectVersions {
+		return s.bucket.Object(name).Attrs(ctx)
+	}
+	objectName, generation, err := parseVersionedName(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.bucket.Object(objectName).Generation(generation).Attrs(ctx)
+}
+
 func (s *scheduler) isFileSelected(name string) bool {
 	for _, sel := range s.src.FileSelectors {
 		if sel.Regex.MatchString(name) {
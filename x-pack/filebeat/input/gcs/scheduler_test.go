@@ -0,0 +1,50 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcs
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func Test_moveToNewGenerations(t *testing.T) {
+	src := &Source{BucketName: "bucket", ObjectVersions: true}
+	st := newState()
+	s := newScheduler(nil, nil, src, &config{}, st, logp.NewLogger("gcs_test"))
+
+	now := time.Now()
+	cp, done := st.saveForTx(versionedName("b.json", 20), 20, now)
+	assert.Equal(t, int64(20), cp.LatestGeneration)
+	done()
+
+	objects := []*storage.ObjectAttrs{
+		{Name: "a.json", Generation: 10, Updated: now},
+		{Name: "a.json", Generation: 30, Updated: now},
+		{Name: "b.json", Generation: 20, Updated: now},
+		{Name: "c.json", Generation: 25, Updated: now},
+	}
+	var names []string
+	for _, j := range s.moveToNewGenerations(s.createJobs(objects, s.log)) {
+		names = append(names, j.stateName())
+		assert.Equal(t, "gs://bucket/"+j.stateName(), j.objectURI)
+	}
+	assert.Equal(t, []string{"a.json#30", "c.json#25"}, names)
+}
+
+func Test_parseVersionedName(t *testing.T) {
+	name, generation, err := parseVersionedName(versionedName("dir/a#b.json", 1661343636712270))
+	require.NoError(t, err)
+	assert.Equal(t, "dir/a#b.json", name)
+	assert.Equal(t, int64(1661343636712270), generation)
+
+	_, _, err = parseVersionedName("a.json")
+	assert.Error(t, err)
+}
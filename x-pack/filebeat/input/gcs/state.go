@@ -28,6 +28,8 @@ type Checkpoint struct {
 	ObjectName string
 	// timestamp to denote which is the latest blob
 	LatestEntryTime time.Time
+	// generation of the latest blob, used to only process new generations when object versions are listed
+	LatestGeneration int64
 	// list of failed jobs due to unexpected errors/download errors
 	FailedJobs map[string]int
 }
@@ -44,7 +46,7 @@ func newState() *state {
 // and returns an unlock function, done. The caller must call done when
 // s and cp are no longer needed in a locked state. done may not be called
 // more than once.
-func (s *state) saveForTx(name string, lastModifiedOn time.Time) (cp *Checkpoint, done func()) {
+func (s *state) saveForTx(name string, generation int64, lastModifiedOn time.Time) (cp *Checkpoint, done func()) {
 	s.mu.Lock()
 	if _, ok := s.cp.FailedJobs[name]; !ok {
 		if len(s.cp.ObjectName) == 0 {
@@ -58,6 +60,10 @@ func (s *state) saveForTx(name string, lastModifiedOn time.Time) (cp *Checkpoint
 		} else if lastModifiedOn.After(s.cp.LatestEntryTime) {
 			s.cp.LatestEntryTime = lastModifiedOn
 		}
+
+		if generation > s.cp.LatestGeneration {
+			s.cp.LatestGeneration = generation
+		}
 	} else {
 		// clear entry if this is a failed job
 		delete(s.cp.FailedJobs, name)
@@ -21,6 +21,8 @@ type Source struct {
 	TimeStampEpoch           *int64
 	FileSelectors            []fileSelectorConfig
 	ExpandEventListFromField string
+	ObjectVersions           bool
+	Decoding                 *decoderConfig
 }
 
 func (s *Source) Name() string {
@@ -0,0 +1,229 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// maxRateLimitRetries is the number of times a rate limited request is
+// retried before giving up.
+const maxRateLimitRetries = 3
+
+// maxRateLimitWait is the longest time to wait for the rate limit to be
+// reset before retrying a request.
+const maxRateLimitWait = 5 * time.Minute
+
+// auditLogState is the cursor of an audit log.
+type auditLogState struct {
+	// Timestamp is the time of the newest event published, and IDs the
+	// document IDs of the events published with that time.
+	Timestamp time.Time `json:"timestamp"`
+	IDs       []string  `json:"ids,omitempty"`
+}
+
+// auditLogFetcher fetches the new events of an audit log.
+type auditLogFetcher struct {
+	owner   string
+	include string
+	client  *githubClient
+}
+
+// fetch publishes the events created since st and returns the new state. The
+// events are requested in ascending order, so the state is attached to every
+// event published.
+func (f *auditLogFetcher) fetch(ctx context.Context, st auditLogState, publish publishFunc) (auditLogState, error) {
+	seen := make(map[string]bool, len(st.IDs))
+	for _, id := range st.IDs {
+		seen[id] = true
+	}
+
+	link := f.queryURL(st.Timestamp)
+	for link != "" {
+		events, next, err := f.client.getPage(ctx, link)
+		if err != nil {
+			return st, err
+		}
+
+		for _, raw := range events {
+			event, ts, id, err := f.event(raw)
+			if err != nil {
+				return st, err
+			}
+			if ts.Before(st.Timestamp) || (ts.Equal(st.Timestamp) && seen[id]) {
+				continue
+			}
+
+			if ts.After(st.Timestamp) {
+				st = auditLogState{Timestamp: ts}
+				seen = make(map[string]bool)
+			}
+			// Copy the IDs, the states published must not share them.
+			st.IDs = append(st.IDs[:len(st.IDs):len(st.IDs)], id)
+			seen[id] = true
+
+			if err := publish(event, st); err != nil {
+				return st, err
+			}
+		}
+		link = next
+	}
+	return st, nil
+}
+
+// queryURL returns the URL of the events created since the given time. The
+// search phrase has a precision of a second, so the events of the first
+// second are deduplicated with the state.
+func (f *auditLogFetcher) queryURL(since time.Time) string {
+	query := url.Values{
+		"phrase":   []string{"created:>=" + since.UTC().Truncate(time.Second).Format(time.RFC3339)},
+		"include":  []string{f.include},
+		"order":    []string{"asc"},
+		"per_page": []string{"100"},
+	}
+	return f.client.endpoint + "/" + f.owner + "/audit-log?" + query.Encode()
+}
+
+// event creates the event of an audit log entry, and returns its creation
+// time and document ID.
+func (f *auditLogFetcher) event(raw json.RawMessage) (beat.Event, time.Time, string, error) {
+	var entry struct {
+		Timestamp  int64  `json:"@timestamp"`
+		DocumentID string `json:"_document_id"`
+	}
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return beat.Event{}, time.Time{}, "", fmt.Errorf("unable to decode audit log event of %s: %w", f.owner, err)
+	}
+	ts := time.UnixMilli(entry.Timestamp).UTC()
+
+	event := beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"message": string(raw),
+			"github": mapstr.M{
+				"source":      "audit_log",
+				"document_id": entry.DocumentID,
+			},
+		},
+	}
+	if entry.Timestamp == 0 {
+		event.Timestamp = time.Now()
+	}
+	return event, ts, entry.DocumentID, nil
+}
+
+// statusError is returned for responses with an unexpected status code.
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d body: %s", e.code, e.body)
+}
+
+// githubClient sends authenticated requests to the GitHub REST API.
+type githubClient struct {
+	endpoint string
+	token    string
+	client   *http.Client
+	logger   *logp.Logger
+}
+
+// getPage fetches the page of events at the given URL, and returns the URL
+// of the next page, if any. Rate limited requests are retried once the
+// limit is reset.
+func (c *githubClient) getPage(ctx context.Context, url string) ([]json.RawMessage, string, error) {
+	for retries := 0; ; retries++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+		res, err := c.client.Do(req)
+		if err != nil {
+			return nil, "", fmt.Errorf("request failed: %w", err)
+		}
+		data, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to read response body: %w", err)
+		}
+
+		if wait, limited := rateLimitWait(res, time.Now()); limited && retries < maxRateLimitRetries {
+			c.logger.Debugw("request rate limited", "url", url, "retry_after", wait)
+			select {
+			case <-ctx.Done():
+				return nil, "", ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return nil, "", &statusError{code: res.StatusCode, body: string(data)}
+		}
+
+		var events []json.RawMessage
+		if err := json.Unmarshal(data, &events); err != nil {
+			return nil, "", fmt.Errorf("unable to decode response body: %w", err)
+		}
+		return events, nextLink(res.Header.Get("Link")), nil
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying a request that
+// was rate limited. GitHub answers 429, or 403 with no remaining requests,
+// when a rate limit is exceeded.
+func rateLimitWait(res *http.Response, now time.Time) (time.Duration, bool) {
+	exhausted := res.Header.Get("X-RateLimit-Remaining") == "0"
+	if res.StatusCode != http.StatusTooManyRequests && !(res.StatusCode == http.StatusForbidden && exhausted) {
+		return 0, false
+	}
+
+	wait := time.Minute
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		wait = time.Duration(seconds) * time.Second
+	} else if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && exhausted {
+		wait = time.Unix(reset, 0).Sub(now)
+	}
+	if wait <= 0 {
+		wait = time.Second
+	}
+	if wait > maxRateLimitWait {
+		wait = maxRateLimitWait
+	}
+	return wait, true
+}
+
+// nextLink returns the URL of the next page from a Link header.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+	return ""
+}
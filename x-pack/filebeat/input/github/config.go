@@ -0,0 +1,136 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	// AuditLog configures the collection of the audit log. It is disabled
+	// when neither an enterprise nor an organization is set.
+	AuditLog auditLogConfig `config:"audit_log"`
+
+	// Webhook configures the webhook receiver. It is disabled when no
+	// secret is set.
+	Webhook webhookConfig `config:"webhook"`
+}
+
+type auditLogConfig struct {
+	// Enterprise or Organization is the owner of the audit log. Only one
+	// of them can be set.
+	Enterprise   string `config:"enterprise"`
+	Organization string `config:"organization"`
+
+	// Token is the personal access token or GitHub App token used to
+	// authenticate. It needs the read:audit_log scope.
+	Token string `config:"token"`
+
+	// APIEndpoint is the base URL of the GitHub REST API.
+	APIEndpoint string `config:"api_endpoint"`
+
+	// Include selects the events of the audit log, one of web, git or all.
+	Include string `config:"include"`
+
+	// Interval is the time between two fetches of the audit log.
+	Interval time.Duration `config:"interval" validate:"positive"`
+
+	// InitialInterval is how far back the first fetch goes.
+	InitialInterval time.Duration `config:"initial_interval" validate:"positive"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+type webhookConfig struct {
+	// ListenAddress and ListenPort are the local address of the receiver,
+	// and Path the path the deliveries are sent to.
+	ListenAddress string `config:"listen_address"`
+	ListenPort    string `config:"listen_port"`
+	Path          string `config:"path"`
+
+	// Secret is the secret of the webhook, used to validate the signature
+	// of the deliveries.
+	Secret string `config:"secret"`
+
+	TLS *tlscommon.ServerConfig `config:"ssl"`
+
+	// ReplayWindow is how long the IDs of the deliveries received are kept
+	// to drop redeliveries, and MaxDeliveries how many of them are kept.
+	ReplayWindow  time.Duration `config:"replay_window" validate:"positive"`
+	MaxDeliveries int           `config:"max_deliveries" validate:"min=1"`
+}
+
+func defaultConfig() config {
+	return config{
+		AuditLog: auditLogConfig{
+			APIEndpoint:     "https://api.github.com",
+			Include:         "all",
+			Interval:        time.Minute,
+			InitialInterval: 24 * time.Hour,
+			Transport: httpcommon.HTTPTransportSettings{
+				Timeout: 30 * time.Second,
+			},
+		},
+		Webhook: webhookConfig{
+			ListenAddress: "127.0.0.1",
+			ListenPort:    "8080",
+			Path:          "/",
+			ReplayWindow:  72 * time.Hour,
+			MaxDeliveries: 10000,
+		},
+	}
+}
+
+// enabled returns whether the audit log is collected.
+func (c auditLogConfig) enabled() bool {
+	return c.Enterprise != "" || c.Organization != ""
+}
+
+// owner returns the path of the owner of the audit log, relative to the API
+// endpoint.
+func (c auditLogConfig) owner() string {
+	if c.Enterprise != "" {
+		return "enterprises/" + url.PathEscape(c.Enterprise)
+	}
+	return "orgs/" + url.PathEscape(c.Organization)
+}
+
+// enabled returns whether the webhook receiver is started.
+func (c webhookConfig) enabled() bool {
+	return c.Secret != ""
+}
+
+func (c *config) Validate() error {
+	if !c.AuditLog.enabled() && !c.Webhook.enabled() {
+		return errors.New("either audit_log or webhook must be configured")
+	}
+	if a := c.AuditLog; a.enabled() {
+		if a.Enterprise != "" && a.Organization != "" {
+			return errors.New("audit_log.enterprise and audit_log.organization are mutually exclusive")
+		}
+		if a.Token == "" {
+			return errors.New("audit_log.token is required")
+		}
+		if _, err := url.Parse(a.APIEndpoint); err != nil {
+			return fmt.Errorf("invalid audit_log.api_endpoint: %w", err)
+		}
+		switch a.Include {
+		case "web", "git", "all":
+		default:
+			return fmt.Errorf("invalid audit_log.include %q, must be one of web, git or all", a.Include)
+		}
+	}
+	if w := c.Webhook; w.enabled() && !strings.HasPrefix(w.Path, "/") {
+		return fmt.Errorf("webhook.path %q must start with /", w.Path)
+	}
+	return nil
+}
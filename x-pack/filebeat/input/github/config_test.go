@@ -0,0 +1,93 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "nothing configured",
+			config:  map[string]interface{}{},
+			wantErr: "either audit_log or webhook must be configured",
+		},
+		{
+			name:   "enterprise audit log",
+			config: map[string]interface{}{"audit_log.enterprise": "acme", "audit_log.token": "t"},
+		},
+		{
+			name: "enterprise and organization",
+			config: map[string]interface{}{
+				"audit_log.enterprise":   "acme",
+				"audit_log.organization": "acme-org",
+				"audit_log.token":        "t",
+			},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "audit log without token",
+			config:  map[string]interface{}{"audit_log.organization": "acme-org"},
+			wantErr: "audit_log.token is required",
+		},
+		{
+			name: "invalid include",
+			config: map[string]interface{}{
+				"audit_log.organization": "acme-org",
+				"audit_log.token":        "t",
+				"audit_log.include":      "api",
+			},
+			wantErr: `invalid audit_log.include "api"`,
+		},
+		{
+			name:   "webhook",
+			config: map[string]interface{}{"webhook.secret": "s3cr3t"},
+		},
+		{
+			name:    "webhook with relative path",
+			config:  map[string]interface{}{"webhook.secret": "s3cr3t", "webhook.path": "hook"},
+			wantErr: "must start with /",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := conf.MustNewConfigFrom(test.config)
+			c := defaultConfig()
+			err := cfg.Unpack(&c)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigureSources(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"audit_log.enterprise":   "acme",
+		"audit_log.token":        "t",
+		"webhook.secret":         "s3cr3t",
+		"webhook.listen_address": "0.0.0.0",
+		"webhook.path":           "/github",
+	})
+	sources, _, err := configure(cfg)
+	assert.NoError(t, err)
+
+	var names []string
+	for _, src := range sources {
+		names = append(names, src.Name())
+	}
+	assert.Equal(t, []string{"audit_log::enterprises/acme", "webhook::0.0.0.0:8080/github"}, names)
+}
@@ -0,0 +1,216 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+	"github.com/elastic/go-concert/ctxtool"
+)
+
+const pluginName = "github"
+
+// Plugin creates the github input plugin.
+func Plugin(log *logp.Logger, store cursor.StateStore) v2.Plugin {
+	return v2.Plugin{
+		Name:      pluginName,
+		Stability: feature.Experimental,
+		Info:      "GitHub",
+		Doc:       "Collect GitHub audit log and webhook events",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       pluginName,
+			Configure:  configure,
+		},
+	}
+}
+
+// publishFunc publishes an event, with the cursor update to store once the
+// event is acknowledged.
+type publishFunc func(event beat.Event, cursor interface{}) error
+
+// auditLogSource is the audit log of an enterprise or organization.
+type auditLogSource struct {
+	owner string
+}
+
+func (s *auditLogSource) Name() string { return "audit_log::" + s.owner }
+
+// webhookSource is the webhook receiver, identified by its address.
+type webhookSource struct {
+	addr string
+	path string
+}
+
+func (s *webhookSource) Name() string { return "webhook::" + s.addr + s.path }
+
+type githubInput struct {
+	config config
+}
+
+func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var sources []cursor.Source
+	if config.AuditLog.enabled() {
+		sources = append(sources, &auditLogSource{owner: config.AuditLog.owner()})
+	}
+	if w := config.Webhook; w.enabled() {
+		sources = append(sources, &webhookSource{
+			addr: net.JoinHostPort(w.ListenAddress, w.ListenPort),
+			path: w.Path,
+		})
+	}
+	return sources, &githubInput{config: config}, nil
+}
+
+func (inp *githubInput) Name() string { return pluginName }
+
+func (inp *githubInput) Test(src cursor.Source, ctx v2.TestContext) error {
+	switch s := src.(type) {
+	case *auditLogSource:
+		client, err := inp.newClient(ctx.Logger)
+		if err != nil {
+			return err
+		}
+		_, _, err = client.getPage(ctxtool.FromCanceller(ctx.Cancelation), client.endpoint+"/"+s.owner+"/audit-log?per_page=1")
+		if err != nil {
+			return fmt.Errorf("unable to read the audit log of %s: %w", s.owner, err)
+		}
+		return nil
+	case *webhookSource:
+		l, err := net.Listen("tcp", s.addr)
+		if err != nil {
+			return err
+		}
+		return l.Close()
+	}
+	return fmt.Errorf("unknown source %s", src.Name())
+}
+
+func (inp *githubInput) Run(ctx v2.Context, src cursor.Source, crsr cursor.Cursor, publisher cursor.Publisher) error {
+	switch s := src.(type) {
+	case *auditLogSource:
+		return inp.runAuditLog(ctx, s, crsr, publisher.Publish)
+	case *webhookSource:
+		return inp.runWebhook(ctx, s, crsr, publisher.Publish)
+	}
+	return fmt.Errorf("unknown source %s", src.Name())
+}
+
+func (inp *githubInput) runAuditLog(ctx v2.Context, s *auditLogSource, crsr cursor.Cursor, publish publishFunc) error {
+	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
+	log := ctx.Logger.With("audit_log", s.owner)
+
+	client, err := inp.newClient(log)
+	if err != nil {
+		return err
+	}
+	f := &auditLogFetcher{owner: s.owner, include: inp.config.AuditLog.Include, client: client}
+
+	var st auditLogState
+	if crsr.IsNew() {
+		st.Timestamp = time.Now().Add(-inp.config.AuditLog.InitialInterval)
+	} else if err := crsr.Unpack(&st); err != nil {
+		return fmt.Errorf("unable to read cursor of audit log %s: %w", s.owner, err)
+	}
+
+	for {
+		st, err = f.fetch(stdCtx, st, publish)
+		if err != nil {
+			if stdCtx.Err() != nil {
+				return nil
+			}
+			log.Errorw("failed to fetch audit log events", "error", err)
+		}
+
+		timer := time.NewTimer(inp.config.AuditLog.Interval)
+		select {
+		case <-stdCtx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+func (inp *githubInput) runWebhook(ctx v2.Context, s *webhookSource, crsr cursor.Cursor, publish publishFunc) error {
+	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
+	log := ctx.Logger.With("webhook", s.addr+s.path)
+
+	var st webhookState
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&st); err != nil {
+			return fmt.Errorf("unable to read cursor of webhook %s: %w", s.addr, err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(s.path, newWebhookHandler(inp.config.Webhook, st, publish, log))
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	tlsConfig, err := tlscommon.LoadTLSServerConfig(inp.config.Webhook.TLS)
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen for webhook deliveries: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			srv.TLSConfig = tlsConfig.BuildServerConfig(s.addr)
+			done <- srv.ServeTLS(ln, "", "")
+		} else {
+			done <- srv.Serve(ln)
+		}
+	}()
+	log.Infow("listening for webhook deliveries", "address", ln.Addr().String(), "path", s.path)
+
+	select {
+	case <-stdCtx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+		return nil
+	case err := <-done:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("webhook listener failed: %w", err)
+	}
+}
+
+func (inp *githubInput) newClient(log *logp.Logger) (*githubClient, error) {
+	client, err := inp.config.AuditLog.Transport.Client()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP client: %w", err)
+	}
+	return &githubClient{
+		endpoint: strings.TrimSuffix(inp.config.AuditLog.APIEndpoint, "/"),
+		token:    inp.config.AuditLog.Token,
+		client:   client,
+		logger:   log,
+	}, nil
+}
@@ -0,0 +1,259 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package github
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type published struct {
+	events  []beat.Event
+	cursors []interface{}
+}
+
+func (p *published) publish(event beat.Event, cursor interface{}) error {
+	p.events = append(p.events, event)
+	p.cursors = append(p.cursors, cursor)
+	return nil
+}
+
+func (p *published) field(t *testing.T, name string) []interface{} {
+	var values []interface{}
+	for _, e := range p.events {
+		v, err := e.Fields.GetValue(name)
+		require.NoError(t, err)
+		values = append(values, v)
+	}
+	return values
+}
+
+func newTestFetcher(t *testing.T, handler http.HandlerFunc) *auditLogFetcher {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &auditLogFetcher{
+		owner:   "enterprises/acme",
+		include: "all",
+		client: &githubClient{
+			endpoint: server.URL,
+			token:    "test-token",
+			client:   server.Client(),
+			logger:   logp.NewLogger("github_test"),
+		},
+	}
+}
+
+func auditEvent(id string, ts time.Time) string {
+	return fmt.Sprintf(`{"@timestamp":%d,"_document_id":%q,"action":"repo.create"}`, ts.UnixMilli(), id)
+}
+
+func TestFetchAuditLog(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 500000000, time.UTC)
+	later := start.Add(2 * time.Minute)
+
+	var query url.Values
+	var f *auditLogFetcher
+	f = newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/enterprises/acme/audit-log", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("after") {
+		case "":
+			query = r.URL.Query()
+			w.Header().Set("Link", fmt.Sprintf(`<%s/enterprises/acme/audit-log?after=MS4y>; rel="next", <%s/enterprises/acme/audit-log?before=MS4x>; rel="prev"`, f.client.endpoint, f.client.endpoint))
+			fmt.Fprintf(w, `[%s,%s,%s]`,
+				auditEvent("old", start.Add(-time.Minute)),
+				auditEvent("seen", start),
+				auditEvent("a", start))
+		case "MS4y":
+			fmt.Fprintf(w, `[%s,%s]`, auditEvent("b", later), auditEvent("c", later))
+		}
+	})
+
+	st := auditLogState{Timestamp: start, IDs: []string{"seen"}}
+	var pub published
+	next, err := f.fetch(context.Background(), st, pub.publish)
+	require.NoError(t, err)
+
+	assert.Equal(t, "created:>=2024-05-01T10:00:00Z", query.Get("phrase"))
+	assert.Equal(t, "asc", query.Get("order"))
+	assert.Equal(t, "all", query.Get("include"))
+	assert.Equal(t, []interface{}{"a", "b", "c"}, pub.field(t, "github.document_id"))
+
+	assert.Equal(t, auditLogState{Timestamp: later, IDs: []string{"b", "c"}}, next)
+	assert.Equal(t, []interface{}{
+		auditLogState{Timestamp: start, IDs: []string{"seen", "a"}},
+		auditLogState{Timestamp: later, IDs: []string{"b"}},
+		auditLogState{Timestamp: later, IDs: []string{"b", "c"}},
+	}, pub.cursors)
+	assert.True(t, pub.events[1].Timestamp.Equal(later))
+}
+
+func TestFetchAuditLogError(t *testing.T) {
+	f := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	})
+
+	st := auditLogState{Timestamp: time.Now()}
+	var pub published
+	next, err := f.fetch(context.Background(), st, pub.publish)
+	var statusErr *statusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.code)
+	assert.Equal(t, st, next)
+	assert.Empty(t, pub.events)
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name     string
+		status   int
+		header   map[string]string
+		wantWait time.Duration
+		limited  bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "forbidden", status: http.StatusForbidden, header: map[string]string{"X-RateLimit-Remaining": "10"}},
+		{
+			name:     "primary rate limit",
+			status:   http.StatusForbidden,
+			header:   map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000030"},
+			wantWait: 30 * time.Second,
+			limited:  true,
+		},
+		{
+			name:     "secondary rate limit",
+			status:   http.StatusTooManyRequests,
+			header:   map[string]string{"Retry-After": "5"},
+			wantWait: 5 * time.Second,
+			limited:  true,
+		},
+		{
+			name:     "reset too far",
+			status:   http.StatusForbidden,
+			header:   map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700003600"},
+			wantWait: maxRateLimitWait,
+			limited:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &http.Response{StatusCode: test.status, Header: http.Header{}}
+			for k, v := range test.header {
+				res.Header.Set(k, v)
+			}
+			wait, limited := rateLimitWait(res, now)
+			assert.Equal(t, test.limited, limited)
+			assert.Equal(t, test.wantWait, wait)
+		})
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/x?after=b", nextLink(`<https://api.github.com/x?before=a>; rel="prev", <https://api.github.com/x?after=b>; rel="next"`))
+	assert.Equal(t, "", nextLink(`<https://api.github.com/x?before=a>; rel="prev"`))
+	assert.Equal(t, "", nextLink(""))
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookHandler(t *testing.T) {
+	config := defaultConfig().Webhook
+	config.Secret = "s3cr3t"
+	config.ReplayWindow = time.Hour
+
+	var pub published
+	h := newWebhookHandler(config, webhookState{}, pub.publish, logp.NewLogger("github_test"))
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+
+	deliver := func(delivery, event, body, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Hub-Signature-256", signature)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	body := `{"action":"opened"}`
+	assert.Equal(t, http.StatusUnauthorized, deliver("1", "push", body, sign("wrong", body)))
+	assert.Equal(t, http.StatusUnauthorized, deliver("1", "push", body, ""))
+	assert.Equal(t, http.StatusOK, deliver("0", "ping", body, sign("s3cr3t", body)))
+	assert.Empty(t, pub.events)
+
+	assert.Equal(t, http.StatusOK, deliver("1", "pull_request", body, sign("s3cr3t", body)))
+	assert.Equal(t, http.StatusOK, deliver("1", "pull_request", body, sign("s3cr3t", body)))
+	assert.Equal(t, []interface{}{"1"}, pub.field(t, "github.delivery_id"))
+	assert.Equal(t, []interface{}{"pull_request"}, pub.field(t, "github.event"))
+	assert.Equal(t, []interface{}{body}, pub.field(t, "message"))
+	assert.Equal(t, webhookState{Deliveries: map[string]time.Time{"1": now}}, pub.cursors[0])
+
+	// Redeliveries after the replay window are published again.
+	now = now.Add(2 * time.Hour)
+	assert.Equal(t, http.StatusOK, deliver("1", "pull_request", body, sign("s3cr3t", body)))
+	assert.Len(t, pub.events, 2)
+}
+
+func TestWebhookHandlerRestoresState(t *testing.T) {
+	config := defaultConfig().Webhook
+	config.Secret = "s3cr3t"
+	config.MaxDeliveries = 2
+
+	now := time.Now()
+	st := webhookState{Deliveries: map[string]time.Time{"1": now.Add(-time.Minute)}}
+	var pub published
+	h := newWebhookHandler(config, st, pub.publish, logp.NewLogger("github_test"))
+
+	payload := `{"action":"created"}`
+	form := url.Values{"payload": []string{payload}}.Encode()
+	deliver := func(delivery string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		req.Header.Set("X-GitHub-Event", "star")
+		req.Header.Set("X-Hub-Signature-256", sign("s3cr3t", form))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, deliver("1"))
+	assert.Empty(t, pub.events)
+
+	assert.Equal(t, http.StatusOK, deliver("2"))
+	assert.Equal(t, http.StatusOK, deliver("3"))
+	assert.Equal(t, []interface{}{payload, payload}, pub.field(t, "message"))
+
+	// Only the newest deliveries are kept.
+	last, err := json.Marshal(pub.cursors[1])
+	require.NoError(t, err)
+	var got webhookState
+	require.NoError(t, json.Unmarshal(last, &got))
+	assert.Len(t, got.Deliveries, 2)
+	assert.NotContains(t, got.Deliveries, "1")
+}
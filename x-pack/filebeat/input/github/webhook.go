@@ -0,0 +1,195 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// maxPayloadSize is the maximum size of a webhook delivery, GitHub caps the
+// payloads at 25 MB.
+const maxPayloadSize = 25 << 20
+
+// webhookState is the cursor of the webhook receiver.
+type webhookState struct {
+	// Deliveries holds the reception time of the deliveries received in
+	// the replay window, by delivery ID.
+	Deliveries map[string]time.Time `json:"deliveries,omitempty"`
+}
+
+// prune removes the deliveries received before the replay window, and the
+// oldest ones when more than limit deliveries are kept.
+func (st *webhookState) prune(now time.Time, window time.Duration, limit int) {
+	for id, received := range st.Deliveries {
+		if now.Sub(received) > window {
+			delete(st.Deliveries, id)
+		}
+	}
+	if len(st.Deliveries) <= limit {
+		return
+	}
+
+	ids := make([]string, 0, len(st.Deliveries))
+	for id := range st.Deliveries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return st.Deliveries[ids[i]].Before(st.Deliveries[ids[j]])
+	})
+	for _, id := range ids[:len(ids)-limit] {
+		delete(st.Deliveries, id)
+	}
+}
+
+// webhookHandler validates the webhook deliveries and publishes them,
+// dropping the deliveries already received.
+type webhookHandler struct {
+	secret        []byte
+	window        time.Duration
+	maxDeliveries int
+	publish       publishFunc
+	logger        *logp.Logger
+	now           func() time.Time
+
+	mu    sync.Mutex
+	state webhookState
+}
+
+func newWebhookHandler(config webhookConfig, st webhookState, publish publishFunc, logger *logp.Logger) *webhookHandler {
+	if st.Deliveries == nil {
+		st.Deliveries = make(map[string]time.Time)
+	}
+	return &webhookHandler{
+		secret:        []byte(config.Secret),
+		window:        config.ReplayWindow,
+		maxDeliveries: config.MaxDeliveries,
+		publish:       publish,
+		logger:        logger,
+		now:           time.Now,
+		state:         st,
+	}
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxPayloadSize {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !h.validSignature(r.Header.Get("X-Hub-Signature-256"), body) {
+		h.logger.Warnw("dropping webhook delivery with invalid signature", "remote_addr", r.RemoteAddr)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	delivery := r.Header.Get("X-GitHub-Delivery")
+	if delivery == "" {
+		http.Error(w, "missing X-GitHub-Delivery header", http.StatusBadRequest)
+		return
+	}
+	eventType := r.Header.Get("X-GitHub-Event")
+	if eventType == "ping" {
+		// Sent when the webhook is created, it carries no event.
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	payload, err := webhookPayload(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	h.state.prune(now, h.window, h.maxDeliveries)
+	if _, ok := h.state.Deliveries[delivery]; ok {
+		h.logger.Debugw("dropping webhook delivery already received", "delivery_id", delivery)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	h.state.Deliveries[delivery] = now
+	h.state.prune(now, h.window, h.maxDeliveries)
+
+	event := beat.Event{
+		Timestamp: now,
+		Fields: mapstr.M{
+			"message": payload,
+			"github": mapstr.M{
+				"source":      "webhook",
+				"event":       eventType,
+				"delivery_id": delivery,
+				"hook_id":     r.Header.Get("X-GitHub-Hook-ID"),
+			},
+		},
+	}
+	if err := h.publish(event, h.state); err != nil {
+		// The input is stopping, let GitHub redeliver the event.
+		delete(h.state.Deliveries, delivery)
+		http.Error(w, "unable to publish event", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// validSignature returns whether the X-Hub-Signature-256 header is the
+// HMAC-SHA256 of the body with the webhook secret.
+func (h *webhookHandler) validSignature(header string, body []byte) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// webhookPayload returns the JSON payload of a delivery. Webhooks can be
+// configured to send it form encoded in the payload parameter.
+func webhookPayload(contentType string, body []byte) (string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "application/x-www-form-urlencoded" {
+		return string(body), nil
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return "", err
+	}
+	payload := form.Get("payload")
+	if payload == "" {
+		return "", errors.New("missing payload parameter")
+	}
+	return payload, nil
+}
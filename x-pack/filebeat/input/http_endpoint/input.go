@@ -29,6 +29,7 @@ import (
 
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -50,6 +51,10 @@ type httpEndpoint struct {
 	tlsConfig *tls.Config
 }
 
+func init() {
+	seccomp.RegisterRequirements(seccomp.KindInput, "http_endpoint", seccomp.Requirements{Listen: true})
+}
+
 func Plugin() v2.Plugin {
 	return v2.Plugin{
 		Name:       inputName,
@@ -28,6 +28,7 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	inputcursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/seccomp"
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
 	"github.com/elastic/beats/v7/libbeat/version"
@@ -82,6 +83,10 @@ func (log *retryLogger) Warn(msg string, keysAndValues ...interface{}) {
 	log.log.Warnw(msg, keysAndValues...)
 }
 
+func init() {
+	seccomp.RegisterBase(seccomp.KindInput, inputName)
+}
+
 func Plugin(log *logp.Logger, store inputcursor.StateStore) v2.Plugin {
 	return v2.Plugin{
 		Name:       inputName,
@@ -114,10 +119,18 @@ func test(url *url.URL) error {
 func runWithMetrics(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcursor.Cursor) error {
 	reg, unreg := inputmon.NewInputRegistry("httpjson", ctx.ID, nil)
 	defer unreg()
-	return run(ctx, cfg, pub, crsr, reg)
+	return run(ctx, cfg, pub, crsr, reg, nil)
+}
+
+// dryRun executes a single interval of requests, reporting the cursor and
+// the resulting events without publishing them.
+func dryRun(ctx v2.DryRunContext, cfg config) error {
+	return run(ctx.Context, cfg, inputcursor.DryRunPublisher(ctx.Reporter), nil, nil, &ctx)
 }
 
-func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcursor.Cursor, reg *monitoring.Registry) error {
+// run executes the requests on each interval. If dryRunCtx is not nil, only
+// the first interval is executed.
+func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcursor.Cursor, reg *monitoring.Registry, dryRunCtx *v2.DryRunContext) error {
 	log := ctx.Logger.With("input_url", cfg.Request.URL)
 
 	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
@@ -157,6 +170,14 @@ func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcurso
 			return err
 		}
 	}
+	if dryRunCtx != nil {
+		client.client.Transport = dryRunCtx.Transport(client.client.Transport)
+		for _, rf := range requestFactory {
+			if rf.chainClient != nil {
+				rf.chainClient.client.Transport = dryRunCtx.Transport(rf.chainClient.client.Transport)
+			}
+		}
+	}
 	pagination := newPagination(cfg, client, log)
 	responseProcessor := newResponseProcessor(cfg, pagination, xmlDetails, metrics, log)
 	requester := newRequester(client, requestFactory, responseProcessor, log)
@@ -183,6 +204,13 @@ func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcurso
 
 		metrics.updateIntervalMetrics(err, startTime)
 
+		if dryRunCtx != nil {
+			dryRunCtx.Reporter.State("cursor", trCtx.cursorMap())
+			if err != nil {
+				return err
+			}
+		}
+
 		if err := stdCtx.Err(); err != nil {
 			return err
 		}
@@ -190,6 +218,10 @@ func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcurso
 		return nil
 	}
 
+	if dryRunCtx != nil {
+		return doFunc()
+	}
+
 	// we trigger the first call immediately,
 	// then we schedule it on the given interval using timed.Periodic
 	if err = doFunc(); err == nil {
@@ -232,7 +264,7 @@ func newHTTPClient(ctx context.Context, config config, log *logp.Logger, reg *mo
 	limiter := newRateLimiterFromConfig(config.Request.RateLimit, log)
 
 	if config.Auth.OAuth2.isEnabled() {
-		authClient, err := config.Auth.OAuth2.client(ctx, client)
+		authClient, err := config.Auth.OAuth2.client(ctx, client, reg)
 		if err != nil {
 			return nil, err
 		}
@@ -332,7 +364,7 @@ func newChainHTTPClient(ctx context.Context, authCfg *authConfig, requestCfg *re
 	limiter := newRateLimiterFromConfig(requestCfg.RateLimit, log)
 
 	if authCfg != nil && authCfg.OAuth2.isEnabled() {
-		authClient, err := authCfg.OAuth2.client(ctx, client)
+		authClient, err := authCfg.OAuth2.client(ctx, client, reg)
 		if err != nil {
 			return nil, err
 		}
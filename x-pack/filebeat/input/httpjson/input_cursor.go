@@ -43,6 +43,12 @@ func (in *cursorInput) Test(src inputcursor.Source, _ v2.TestContext) error {
 	return test((src.(*source)).config.Request.URL.URL)
 }
 
+// DryRun executes a single interval of requests without publishing events
+// or updating the cursor.
+func (in *cursorInput) DryRun(ctx v2.DryRunContext, src inputcursor.Source) error {
+	return dryRun(ctx, src.(*source).config)
+}
+
 // Run starts the input and blocks until it ends the execution.
 // It will return on context cancellation, any other error will be retried.
 func (in *cursorInput) Run(ctx v2.Context, src inputcursor.Source, crsr inputcursor.Cursor, pub inputcursor.Publisher) error {
@@ -44,6 +44,11 @@ func (pub statelessPublisher) Publish(event beat.Event, _ interface{}) error {
 	return nil
 }
 
+// DryRun executes a single interval of requests without publishing events.
+func (in *statelessInput) DryRun(ctx v2.DryRunContext) error {
+	return dryRun(ctx, in.config)
+}
+
 // Run starts the input and blocks until it ends the execution.
 // It will return on context cancellation, any other error will be retried.
 func (in *statelessInput) Run(ctx v2.Context, publisher stateless.Publisher) error {
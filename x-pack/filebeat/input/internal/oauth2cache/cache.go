@@ -0,0 +1,208 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package oauth2cache provides a process-wide cache of OAuth2 tokens so that
+// inputs authenticating with the same credentials against the same token
+// endpoint share a token instead of each fetching their own.
+package oauth2cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	// minRetryInterval and maxRetryInterval bound the time a failed token
+	// request is reported to all users of the token before it is retried.
+	minRetryInterval = time.Second
+	maxRetryInterval = time.Minute
+)
+
+// Key identifies a token. It is a hash of everything that influences the
+// token issued by the provider, including the client secret.
+type Key string
+
+// NewKey returns the key for the given parts. The parts are length
+// prefixed so that different splits of the same string do not collide.
+func NewKey(parts ...string) Key {
+	h := sha256.New()
+	var n [8]byte
+	for _, p := range parts {
+		binary.BigEndian.PutUint64(n[:], uint64(len(p)))
+		h.Write(n[:])
+		h.Write([]byte(p))
+	}
+	return Key(hex.EncodeToString(h.Sum(nil)))
+}
+
+// Default is the process-wide token cache.
+var Default = New()
+
+// Cache holds one token per key. Entries are kept for the lifetime of the
+// process, there is one entry per distinct set of credentials.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[Key]*entry
+
+	now func() time.Time
+}
+
+// New returns an empty Cache.
+func New() *Cache {
+	return &Cache{entries: map[Key]*entry{}, now: time.Now}
+}
+
+// TokenSource returns a token source backed by the shared token for key.
+// newSource is called once for each key to create the source that fetches
+// tokens from the provider. It must not depend on the lifetime of a single
+// input, see context.WithoutCancel. Cache hits, token requests and failures
+// are counted in reg if it is not nil.
+func (c *Cache) TokenSource(key Key, newSource func() oauth2.TokenSource, reg *monitoring.Registry) oauth2.TokenSource {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &entry{src: newSource(), now: c.now}
+		c.entries[key] = e
+	}
+	c.mu.Unlock()
+
+	return &tokenSource{entry: e, metrics: newMetrics(reg)}
+}
+
+// entry is a shared token. Only one request for a new token is in flight at
+// a time, concurrent callers wait for its result.
+type entry struct {
+	mu  sync.Mutex
+	src oauth2.TokenSource
+	now func() time.Time
+
+	token *oauth2.Token
+
+	// err is the last token request failure. It is returned without a new
+	// request until retryAt to avoid refresh storms against a provider
+	// that is failing or throttling.
+	err      error
+	retryAt  time.Time
+	failures int
+}
+
+type result int
+
+const (
+	hit result = iota
+	refreshed
+	failed
+	throttled
+)
+
+func (e *entry) get() (*oauth2.Token, result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.token.Valid() {
+		return e.token, hit, nil
+	}
+	now := e.now()
+	if e.err != nil && now.Before(e.retryAt) {
+		return nil, throttled, e.err
+	}
+
+	token, err := e.src.Token()
+	if err != nil {
+		e.failures++
+		e.err = err
+		e.retryAt = now.Add(retryInterval(err, e.failures))
+		return nil, failed, err
+	}
+	e.token = token
+	e.err = nil
+	e.failures = 0
+	return token, refreshed, nil
+}
+
+// retryInterval returns how long to wait before requesting a token again.
+// A Retry-After header sent by the provider takes precedence over the
+// exponential backoff.
+func retryInterval(err error, failures int) time.Duration {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.Response != nil {
+		switch retrieveErr.Response.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			if secs, err := strconv.Atoi(retrieveErr.Response.Header.Get("Retry-After")); err == nil && secs > 0 {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+	d := minRetryInterval
+	for i := 1; i < failures && d < maxRetryInterval; i++ {
+		d *= 2
+	}
+	if d > maxRetryInterval {
+		d = maxRetryInterval
+	}
+	return d
+}
+
+// tokenSource is the view of a shared token held by one input.
+type tokenSource struct {
+	entry   *entry
+	metrics *metrics
+}
+
+// Token implements oauth2.TokenSource.
+func (s *tokenSource) Token() (*oauth2.Token, error) {
+	token, res, err := s.entry.get()
+	if s.metrics != nil {
+		switch res {
+		case hit:
+			s.metrics.hits.Add(1)
+		case refreshed:
+			s.metrics.refreshes.Add(1)
+		case failed:
+			s.metrics.refreshes.Add(1)
+			s.metrics.refreshErrors.Add(1)
+		case throttled:
+			s.metrics.throttled.Add(1)
+		}
+	}
+	return token, err
+}
+
+type metrics struct {
+	hits          *monitoring.Uint // tokens served from the shared cache
+	refreshes     *monitoring.Uint // token requests made by this input
+	refreshErrors *monitoring.Uint // failed token requests made by this input
+	throttled     *monitoring.Uint // calls answered with a recent failure without a request
+}
+
+func newMetrics(reg *monitoring.Registry) *metrics {
+	if reg == nil {
+		return nil
+	}
+	return &metrics{
+		hits:          getOrNewUint(reg, "oauth2_token_cache_hits_total"),
+		refreshes:     getOrNewUint(reg, "oauth2_token_refreshes_total"),
+		refreshErrors: getOrNewUint(reg, "oauth2_token_refresh_errors_total"),
+		throttled:     getOrNewUint(reg, "oauth2_token_refresh_throttled_total"),
+	}
+}
+
+// getOrNewUint returns the named metric of reg, inputs can create several
+// clients sharing a registry.
+func getOrNewUint(reg *monitoring.Registry, name string) *monitoring.Uint {
+	if v, ok := reg.Get(name).(*monitoring.Uint); ok {
+		return v
+	}
+	return monitoring.NewUint(reg, name)
+}
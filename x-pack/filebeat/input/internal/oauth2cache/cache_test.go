@@ -0,0 +1,159 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package oauth2cache
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type countingSource struct {
+	mu     sync.Mutex
+	calls  int
+	expiry time.Time
+	err    error
+}
+
+func (s *countingSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &oauth2.Token{AccessToken: "token", Expiry: s.expiry}, nil
+}
+
+func TestSharedToken(t *testing.T) {
+	src := &countingSource{expiry: time.Now().Add(time.Hour)}
+	newSource := func() oauth2.TokenSource { return src }
+
+	c := New()
+	key := NewKey("client_credentials", "https://idp.example.com/token", "client", "secret")
+	regA := monitoring.NewRegistry()
+	regB := monitoring.NewRegistry()
+	a := c.TokenSource(key, newSource, regA)
+	b := c.TokenSource(key, func() oauth2.TokenSource {
+		t.Fatal("source must be created once per key")
+		return nil
+	}, regB)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); _, _ = a.Token() }()
+		go func() { defer wg.Done(); _, _ = b.Token() }()
+	}
+	wg.Wait()
+
+	if src.calls != 1 {
+		t.Errorf("unexpected number of token requests: got %d want 1", src.calls)
+	}
+	refreshes := regA.Get("oauth2_token_refreshes_total").(*monitoring.Uint).Get() +
+		regB.Get("oauth2_token_refreshes_total").(*monitoring.Uint).Get()
+	hits := regA.Get("oauth2_token_cache_hits_total").(*monitoring.Uint).Get() +
+		regB.Get("oauth2_token_cache_hits_total").(*monitoring.Uint).Get()
+	if refreshes != 1 || hits != 19 {
+		t.Errorf("unexpected metrics: refreshes=%d hits=%d", refreshes, hits)
+	}
+
+	other := c.TokenSource(NewKey("client_credentials", "https://idp.example.com/token", "client", "other"), func() oauth2.TokenSource {
+		return &countingSource{expiry: time.Now().Add(time.Hour)}
+	}, nil)
+	if _, err := other.Token(); err != nil {
+		t.Fatal(err)
+	}
+	if src.calls != 1 {
+		t.Error("different credentials must not share a token")
+	}
+}
+
+func TestExpiredTokenIsRefreshed(t *testing.T) {
+	src := &countingSource{expiry: time.Now().Add(-time.Minute)}
+	ts := New().TokenSource(NewKey("k"), func() oauth2.TokenSource { return src }, nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := ts.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if src.calls != 3 {
+		t.Errorf("unexpected number of token requests: got %d want 3", src.calls)
+	}
+}
+
+func TestFailuresAreThrottled(t *testing.T) {
+	now := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+	c := New()
+	c.now = func() time.Time { return now }
+
+	src := &countingSource{err: errors.New("unavailable")}
+	reg := monitoring.NewRegistry()
+	ts := c.TokenSource(NewKey("k"), func() oauth2.TokenSource { return src }, reg)
+
+	for i := 0; i < 5; i++ {
+		if _, err := ts.Token(); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if src.calls != 1 {
+		t.Errorf("failed requests must not be retried before the retry interval: got %d calls", src.calls)
+	}
+	if got := reg.Get("oauth2_token_refresh_throttled_total").(*monitoring.Uint).Get(); got != 4 {
+		t.Errorf("unexpected throttled count: %d", got)
+	}
+
+	now = now.Add(minRetryInterval)
+	src.err = nil
+	src.expiry = now.Add(time.Hour)
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("unexpected error after retry interval: %v", err)
+	}
+	if src.calls != 2 {
+		t.Errorf("unexpected number of token requests: got %d want 2", src.calls)
+	}
+}
+
+func TestRetryInterval(t *testing.T) {
+	tooMany := &oauth2.RetrieveError{Response: &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+	}}
+
+	tests := []struct {
+		name     string
+		err      error
+		failures int
+		want     time.Duration
+	}{
+		{name: "first failure", err: errors.New("x"), failures: 1, want: time.Second},
+		{name: "backoff", err: errors.New("x"), failures: 4, want: 8 * time.Second},
+		{name: "capped", err: errors.New("x"), failures: 20, want: maxRetryInterval},
+		{name: "retry after", err: tooMany, failures: 1, want: 30 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := retryInterval(test.err, test.failures); got != test.want {
+				t.Errorf("unexpected interval: got %v want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	if NewKey("ab", "c") == NewKey("a", "bc") {
+		t.Error("keys of different parts must differ")
+	}
+	if NewKey("a", "b") != NewKey("a", "b") {
+		t.Error("keys of the same parts must be equal")
+	}
+}
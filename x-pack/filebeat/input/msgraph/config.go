@@ -0,0 +1,132 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// resource is a Microsoft Graph collection that can be collected by the input.
+type resource struct {
+	// path of the collection, relative to the API endpoint. It is also the
+	// resource of the change notification subscriptions.
+	path string
+	// timestampField is the field of the records holding their creation
+	// time, used to filter and order the records when the collection does
+	// not return delta links.
+	timestampField string
+}
+
+var resources = map[string]resource{
+	"directory_audits": {path: "auditLogs/directoryAudits", timestampField: "activityDateTime"},
+	"sign_ins":         {path: "auditLogs/signIns", timestampField: "createdDateTime"},
+}
+
+type config struct {
+	// TenantID, ClientID and Secret are the credentials of the Azure
+	// application. They are consumed by the OAuth2 authenticator together
+	// with login_endpoint and login_scopes.
+	TenantID string `config:"tenant_id" validate:"required"`
+	ClientID string `config:"client_id" validate:"required"`
+	Secret   string `config:"secret" validate:"required"`
+
+	// APIEndpoint is the base URL of the Microsoft Graph API.
+	APIEndpoint string `config:"api_endpoint"`
+
+	// Resources is the list of collections to fetch.
+	Resources []string `config:"resources"`
+
+	// Interval is the time between two fetches of a resource when no change
+	// notification is received.
+	Interval time.Duration `config:"interval" validate:"positive"`
+
+	// InitialInterval is how far back the first fetch of a resource goes.
+	InitialInterval time.Duration `config:"initial_interval" validate:"positive"`
+
+	// Notifications configures the change notification subscriptions. When
+	// no URL is set, the input only polls.
+	Notifications notificationsConfig `config:"notifications"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+type notificationsConfig struct {
+	// URL is the public HTTPS address Microsoft Graph sends the
+	// notifications to. Its path is the path served by the listener.
+	URL string `config:"url"`
+
+	// ListenAddress and ListenPort are the local address of the listener.
+	ListenAddress string `config:"listen_address"`
+	ListenPort    string `config:"listen_port"`
+
+	// ClientState is the secret sent by Microsoft Graph with every
+	// notification. Notifications with a different value are dropped.
+	ClientState string `config:"client_state"`
+
+	// Lifetime is the lifetime of the subscriptions. Subscriptions are
+	// renewed before they expire.
+	Lifetime time.Duration `config:"subscription_lifetime" validate:"positive"`
+}
+
+func defaultConfig() config {
+	return config{
+		APIEndpoint:     "https://graph.microsoft.com/v1.0",
+		Resources:       []string{"directory_audits", "sign_ins"},
+		Interval:        5 * time.Minute,
+		InitialInterval: 24 * time.Hour,
+		Notifications: notificationsConfig{
+			ListenAddress: "127.0.0.1",
+			ListenPort:    "8000",
+			Lifetime:      time.Hour,
+		},
+		Transport: httpcommon.HTTPTransportSettings{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// enabled returns whether change notifications are configured.
+func (c notificationsConfig) enabled() bool {
+	return c.URL != ""
+}
+
+// maxLifetime is the longest subscription lifetime accepted by Microsoft
+// Graph for the audit log resources.
+const maxLifetime = 3 * 24 * time.Hour
+
+func (c *config) Validate() error {
+	if len(c.Resources) == 0 {
+		return errors.New("at least one resource must be configured")
+	}
+	for _, name := range c.Resources {
+		if _, ok := resources[name]; !ok {
+			return fmt.Errorf("unknown resource %q", name)
+		}
+	}
+	if _, err := url.Parse(c.APIEndpoint); err != nil {
+		return fmt.Errorf("invalid api_endpoint: %w", err)
+	}
+	if n := c.Notifications; n.enabled() {
+		u, err := url.Parse(n.URL)
+		if err != nil {
+			return fmt.Errorf("invalid notifications.url: %w", err)
+		}
+		if u.Scheme != "https" {
+			return errors.New("notifications.url must use https")
+		}
+		if n.ClientState == "" {
+			return errors.New("notifications.client_state is required when notifications.url is set")
+		}
+		if n.Lifetime > maxLifetime {
+			return fmt.Errorf("notifications.subscription_lifetime must not be greater than %v", maxLifetime)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfig(t *testing.T) {
+	base := map[string]interface{}{
+		"tenant_id": "tenant",
+		"client_id": "client",
+		"secret":    "secret",
+	}
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{name: "defaults"},
+		{
+			name:    "unknown resource",
+			config:  map[string]interface{}{"resources": []string{"sign_ins", "users"}},
+			wantErr: `unknown resource "users"`,
+		},
+		{
+			name: "notifications",
+			config: map[string]interface{}{
+				"notifications.url":          "https://example.com/notify",
+				"notifications.client_state": "s3cr3t",
+			},
+		},
+		{
+			name:    "notifications without https",
+			config:  map[string]interface{}{"notifications.url": "http://example.com/notify", "notifications.client_state": "s3cr3t"},
+			wantErr: "notifications.url must use https",
+		},
+		{
+			name:    "notifications without client state",
+			config:  map[string]interface{}{"notifications.url": "https://example.com/notify"},
+			wantErr: "notifications.client_state is required",
+		},
+		{
+			name: "subscription lifetime too long",
+			config: map[string]interface{}{
+				"notifications.url":                   "https://example.com/notify",
+				"notifications.client_state":          "s3cr3t",
+				"notifications.subscription_lifetime": "96h",
+			},
+			wantErr: "must not be greater than",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := conf.MustNewConfigFrom(base)
+			if test.config != nil {
+				assert.NoError(t, cfg.Merge(test.config))
+			}
+			c := defaultConfig()
+			err := cfg.Unpack(&c)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, time.Hour, c.Notifications.Lifetime)
+		})
+	}
+}
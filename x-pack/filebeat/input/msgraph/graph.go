@@ -0,0 +1,160 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/entityanalytics/provider/azuread/authenticator"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// maxThrottleRetries is the number of times a throttled request is retried
+// before giving up.
+const maxThrottleRetries = 3
+
+// defaultRetryAfter is the time to wait after a throttled request that has
+// no Retry-After header.
+const defaultRetryAfter = 10 * time.Second
+
+// statusError is returned for responses with an unexpected status code.
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d body: %s", e.code, e.body)
+}
+
+// page is a page of a Microsoft Graph collection.
+type page struct {
+	Value     []json.RawMessage `json:"value"`
+	NextLink  string            `json:"@odata.nextLink"`
+	DeltaLink string            `json:"@odata.deltaLink"`
+}
+
+// subscription is a Microsoft Graph change notification subscription.
+type subscription struct {
+	ID                 string    `json:"id,omitempty"`
+	ChangeType         string    `json:"changeType,omitempty"`
+	NotificationURL    string    `json:"notificationUrl,omitempty"`
+	Resource           string    `json:"resource,omitempty"`
+	ExpirationDateTime time.Time `json:"expirationDateTime"`
+	ClientState        string    `json:"clientState,omitempty"`
+}
+
+// graphClient sends authenticated requests to the Microsoft Graph API.
+type graphClient struct {
+	endpoint string
+	client   *http.Client
+	auth     authenticator.Authenticator
+	logger   *logp.Logger
+}
+
+// getPage fetches the page at the given URL.
+func (c *graphClient) getPage(ctx context.Context, url string) (*page, error) {
+	var p page
+	if err := c.do(ctx, http.MethodGet, url, nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// createSubscription creates the subscription and returns it as stored by
+// Microsoft Graph.
+func (c *graphClient) createSubscription(ctx context.Context, sub subscription) (subscription, error) {
+	var created subscription
+	err := c.do(ctx, http.MethodPost, c.endpoint+"/subscriptions", sub, &created)
+	return created, err
+}
+
+// renewSubscription extends the expiration time of the subscription.
+func (c *graphClient) renewSubscription(ctx context.Context, id string, expiration time.Time) error {
+	return c.do(ctx, http.MethodPatch, c.endpoint+"/subscriptions/"+id, subscription{ExpirationDateTime: expiration}, nil)
+}
+
+// deleteSubscription deletes the subscription.
+func (c *graphClient) deleteSubscription(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, c.endpoint+"/subscriptions/"+id, nil, nil)
+}
+
+// do sends a request with the JSON encoding of body, if any, and decodes the
+// JSON response into out, if not nil. Throttled requests are retried after
+// the time requested by the server.
+func (c *graphClient) do(ctx context.Context, method, url string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("unable to encode request body: %w", err)
+		}
+	}
+
+	for retries := 0; ; retries++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("unable to create request: %w", err)
+		}
+		bearer, err := c.auth.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		req.Header.Set("Accept", "application/json")
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		res, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		data, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return fmt.Errorf("unable to read response body: %w", err)
+		}
+
+		switch {
+		case res.StatusCode == http.StatusTooManyRequests && retries < maxThrottleRetries:
+			wait := retryAfter(res.Header.Get("Retry-After"))
+			c.logger.Debugw("request throttled", "url", url, "retry_after", wait)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		case res.StatusCode < 200 || res.StatusCode > 299:
+			return &statusError{code: res.StatusCode, body: string(data)}
+		}
+
+		if out == nil || len(data) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("unable to decode response body: %w", err)
+		}
+		return nil
+	}
+}
+
+// retryAfter returns the wait time of a Retry-After header, in seconds.
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,189 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/entityanalytics/provider/azuread/authenticator/oauth2"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/go-concert/ctxtool"
+)
+
+const pluginName = "msgraph"
+
+// Plugin creates the msgraph input plugin.
+func Plugin(log *logp.Logger, store cursor.StateStore) v2.Plugin {
+	return v2.Plugin{
+		Name:      pluginName,
+		Stability: feature.Experimental,
+		Info:      "Microsoft Graph",
+		Doc:       "Collect Entra ID audit and sign-in logs from Microsoft Graph",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       pluginName,
+			Configure:  configure,
+		},
+	}
+}
+
+// stream is a resource of a tenant.
+type stream struct {
+	tenantID string
+	name     string
+	resource resource
+}
+
+func (s *stream) Name() string {
+	return s.tenantID + "::" + s.name
+}
+
+type graphInput struct {
+	config config
+	// auth is the raw configuration of the OAuth2 authenticator.
+	auth     *conf.C
+	listener *listener
+}
+
+func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	sources := make([]cursor.Source, 0, len(config.Resources))
+	for _, name := range config.Resources {
+		sources = append(sources, &stream{
+			tenantID: config.TenantID,
+			name:     name,
+			resource: resources[name],
+		})
+	}
+
+	return sources, &graphInput{
+		config:   config,
+		auth:     cfg,
+		listener: &listener{config: config.Notifications},
+	}, nil
+}
+
+func (inp *graphInput) Name() string { return pluginName }
+
+func (inp *graphInput) Test(src cursor.Source, ctx v2.TestContext) error {
+	client, err := inp.newClient(ctx.Logger)
+	if err != nil {
+		return err
+	}
+	if _, err := client.auth.Token(ctxtool.FromCanceller(ctx.Cancelation)); err != nil {
+		return fmt.Errorf("unable to acquire authentication token for tenant %s: %w", inp.config.TenantID, err)
+	}
+	return nil
+}
+
+func (inp *graphInput) Run(ctx v2.Context, src cursor.Source, crsr cursor.Cursor, publisher cursor.Publisher) error {
+	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
+	s := src.(*stream)
+	log := ctx.Logger.With("resource", s.name)
+
+	client, err := inp.newClient(log)
+	if err != nil {
+		return err
+	}
+	f := &fetcher{name: s.name, resource: s.resource, client: client}
+
+	var st state
+	if crsr.IsNew() {
+		st.Timestamp = time.Now().Add(-inp.config.InitialInterval)
+	} else if err := crsr.Unpack(&st); err != nil {
+		return fmt.Errorf("unable to read cursor of resource %s: %w", s.name, err)
+	}
+
+	// trigger is signalled by change notifications to fetch before the
+	// end of the interval.
+	trigger := make(chan struct{}, 1)
+	var sub *subscriber
+	if inp.config.Notifications.enabled() {
+		notifier, err := inp.listener.acquire(log)
+		if err != nil {
+			return err
+		}
+		defer inp.listener.release()
+
+		sub = &subscriber{
+			client:        client,
+			notifier:      notifier,
+			config:        inp.config.Notifications,
+			resource:      s.resource,
+			trigger:       trigger,
+			logger:        log,
+			retryInterval: inp.config.Interval,
+		}
+		sub.subscribe(stdCtx)
+		defer sub.close()
+	}
+
+	for {
+		st, err = f.fetch(stdCtx, st, publisher.Publish)
+		if err != nil {
+			if stdCtx.Err() != nil {
+				return nil
+			}
+			log.Errorw("failed to fetch records", "error", err)
+		}
+
+		if !inp.wait(stdCtx, sub, trigger) {
+			return nil
+		}
+	}
+}
+
+// wait waits for the next fetch, renewing the subscription when needed. It
+// returns false when the input is stopped.
+func (inp *graphInput) wait(ctx context.Context, sub *subscriber, trigger <-chan struct{}) bool {
+	timer := time.NewTimer(inp.config.Interval)
+	defer timer.Stop()
+
+	var renew <-chan time.Time
+	if sub != nil {
+		renew = sub.C()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-trigger:
+			return true
+		case <-timer.C:
+			return true
+		case <-renew:
+			sub.renew(ctx)
+		}
+	}
+}
+
+func (inp *graphInput) newClient(log *logp.Logger) (*graphClient, error) {
+	auth, err := oauth2.New(inp.auth, log)
+	if err != nil {
+		return nil, err
+	}
+	client, err := inp.config.Transport.Client()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP client: %w", err)
+	}
+	return &graphClient{
+		endpoint: strings.TrimSuffix(inp.config.APIEndpoint, "/"),
+		client:   client,
+		auth:     auth,
+		logger:   log,
+	}, nil
+}
@@ -0,0 +1,313 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type testAuth struct{}
+
+func (testAuth) Token(context.Context) (string, error) { return "test-token", nil }
+func (testAuth) SetLogger(*logp.Logger)                {}
+
+type published struct {
+	events  []beat.Event
+	cursors []interface{}
+}
+
+func (p *published) publish(event beat.Event, cursor interface{}) error {
+	p.events = append(p.events, event)
+	p.cursors = append(p.cursors, cursor)
+	return nil
+}
+
+func (p *published) ids(t *testing.T) []string {
+	var ids []string
+	for _, e := range p.events {
+		msg, err := e.Fields.GetValue("message")
+		require.NoError(t, err)
+		var rec struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(msg.(string)), &rec))
+		ids = append(ids, rec.ID)
+	}
+	return ids
+}
+
+func newTestFetcher(t *testing.T, handler http.HandlerFunc) *fetcher {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &fetcher{
+		name:     "directory_audits",
+		resource: resources["directory_audits"],
+		client: &graphClient{
+			endpoint: server.URL,
+			client:   server.Client(),
+			auth:     testAuth{},
+			logger:   logp.NewLogger("msgraph_test"),
+		},
+	}
+}
+
+func audit(id, ts string) string {
+	return fmt.Sprintf(`{"id":%q,"activityDateTime":%q,"activityDisplayName":"Add user"}`, id, ts)
+}
+
+func TestFetchFollowsPagesAndDeduplicates(t *testing.T) {
+	var filter string
+	var f *fetcher
+	f = newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("$skiptoken") {
+		case "":
+			filter = r.URL.Query().Get("$filter")
+			fmt.Fprintf(w, `{"value":[%s,%s],"@odata.nextLink":%q}`,
+				audit("seen", "2024-05-01T10:00:00.5Z"),
+				audit("b", "2024-05-01T10:02:00Z"),
+				f.client.endpoint+"/auditLogs/directoryAudits?$skiptoken=2")
+		case "2":
+			fmt.Fprintf(w, `{"value":[%s,%s,%s]}`,
+				audit("old", "2024-05-01T09:59:00Z"),
+				audit("a", "2024-05-01T10:00:00.5Z"),
+				audit("c", "2024-05-01T10:02:00Z"))
+		}
+	})
+
+	st := state{
+		Timestamp: time.Date(2024, 5, 1, 10, 0, 0, 500000000, time.UTC),
+		IDs:       []string{"seen"},
+	}
+	var pub published
+	next, err := f.fetch(context.Background(), st, pub.publish)
+	require.NoError(t, err)
+
+	assert.Equal(t, "activityDateTime ge 2024-05-01T10:00:00Z", filter)
+	assert.Equal(t, []string{"b", "a", "c"}, pub.ids(t))
+
+	want := state{
+		Timestamp: time.Date(2024, 5, 1, 10, 2, 0, 0, time.UTC),
+		IDs:       []string{"b", "c"},
+	}
+	assert.Equal(t, want, next)
+	assert.Equal(t, []interface{}{nil, nil, want}, pub.cursors)
+
+	ts := pub.events[0].Timestamp
+	assert.True(t, ts.Equal(time.Date(2024, 5, 1, 10, 2, 0, 0, time.UTC)))
+	resource, err := pub.events[0].Fields.GetValue("msgraph.resource")
+	require.NoError(t, err)
+	assert.Equal(t, "directory_audits", resource)
+}
+
+func TestFetchDeltaLink(t *testing.T) {
+	var f *fetcher
+	f = newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("$deltatoken") {
+		case "":
+			fmt.Fprintf(w, `{"value":[%s],"@odata.deltaLink":%q}`,
+				audit("a", "2024-05-01T10:00:00Z"),
+				f.client.endpoint+"/auditLogs/directoryAudits?$deltatoken=1")
+		case "1":
+			// Changes are returned whatever their creation time.
+			fmt.Fprintf(w, `{"value":[%s],"@odata.deltaLink":%q}`,
+				audit("b", "2024-04-01T10:00:00Z"),
+				f.client.endpoint+"/auditLogs/directoryAudits?$deltatoken=2")
+		}
+	})
+
+	var pub published
+	st, err := f.fetch(context.Background(), state{Timestamp: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}, pub.publish)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(st.DeltaLink, "$deltatoken=1"))
+
+	st, err = f.fetch(context.Background(), st, pub.publish)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(st.DeltaLink, "$deltatoken=2"))
+	assert.Equal(t, []string{"a", "b"}, pub.ids(t))
+}
+
+func TestFetchExpiredDeltaLink(t *testing.T) {
+	f := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$deltatoken") != "" {
+			http.Error(w, `{"error":{"code":"SyncStateNotFound"}}`, http.StatusGone)
+			return
+		}
+		fmt.Fprintf(w, `{"value":[%s]}`, audit("a", "2024-05-01T10:00:00Z"))
+	})
+
+	st := state{
+		DeltaLink: f.client.endpoint + "/auditLogs/directoryAudits?$deltatoken=expired",
+		Timestamp: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	var pub published
+	next, err := f.fetch(context.Background(), st, pub.publish)
+	require.NoError(t, err)
+	assert.Empty(t, next.DeltaLink)
+	assert.Equal(t, []string{"a"}, pub.ids(t))
+}
+
+func TestFetchError(t *testing.T) {
+	f := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"code":"Authorization_RequestDenied"}}`, http.StatusForbidden)
+	})
+
+	st := state{Timestamp: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	var pub published
+	next, err := f.fetch(context.Background(), st, pub.publish)
+	var statusErr *statusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusForbidden, statusErr.code)
+	assert.Equal(t, st, next)
+	assert.Empty(t, pub.events)
+}
+
+func TestThrottledRequestIsRetried(t *testing.T) {
+	var requests int32
+	f := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprintf(w, `{"value":[%s]}`, audit("a", "2024-05-01T10:00:00Z"))
+	})
+
+	var pub published
+	_, err := f.fetch(context.Background(), state{}, pub.publish)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, []string{"a"}, pub.ids(t))
+}
+
+func TestNotifier(t *testing.T) {
+	n := newNotifier("s3cr3t", logp.NewLogger("msgraph_test"))
+	trigger := make(chan struct{}, 1)
+	n.register("sub-1", trigger)
+
+	t.Run("validation", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		n.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notify?validationToken="+url.QueryEscape("Validation: token 1"), nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "Validation: token 1", rec.Body.String())
+	})
+
+	notify := func(subscriptionID, clientState string) int {
+		body := fmt.Sprintf(`{"value":[{"subscriptionId":%q,"clientState":%q,"changeType":"created"}]}`, subscriptionID, clientState)
+		rec := httptest.NewRecorder()
+		n.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(body)))
+		return rec.Code
+	}
+	triggered := func() bool {
+		select {
+		case <-trigger:
+			return true
+		default:
+			return false
+		}
+	}
+
+	t.Run("invalid client state", func(t *testing.T) {
+		assert.Equal(t, http.StatusAccepted, notify("sub-1", "wrong"))
+		assert.False(t, triggered())
+	})
+	t.Run("unknown subscription", func(t *testing.T) {
+		assert.Equal(t, http.StatusAccepted, notify("sub-2", "s3cr3t"))
+		assert.False(t, triggered())
+	})
+	t.Run("notification", func(t *testing.T) {
+		assert.Equal(t, http.StatusAccepted, notify("sub-1", "s3cr3t"))
+		assert.Equal(t, http.StatusAccepted, notify("sub-1", "s3cr3t"))
+		assert.True(t, triggered())
+		assert.False(t, triggered())
+	})
+	t.Run("unregistered", func(t *testing.T) {
+		n.unregister("sub-1")
+		assert.Equal(t, http.StatusAccepted, notify("sub-1", "s3cr3t"))
+		assert.False(t, triggered())
+	})
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		n.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader("{")))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestSubscriber(t *testing.T) {
+	var created, renewed, deleted int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/subscriptions":
+			var sub subscription
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sub))
+			assert.Equal(t, "created", sub.ChangeType)
+			assert.Equal(t, "auditLogs/signIns", sub.Resource)
+			assert.Equal(t, "https://example.com/notify", sub.NotificationURL)
+			assert.Equal(t, "s3cr3t", sub.ClientState)
+			atomic.AddInt32(&created, 1)
+			sub.ID = "sub-1"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(sub)
+		case r.Method == http.MethodPatch && r.URL.Path == "/subscriptions/sub-1":
+			atomic.AddInt32(&renewed, 1)
+			w.Write([]byte(`{"id":"sub-1"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/subscriptions/sub-1":
+			atomic.AddInt32(&deleted, 1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	log := logp.NewLogger("msgraph_test")
+	n := newNotifier("s3cr3t", log)
+	trigger := make(chan struct{}, 1)
+	sub := &subscriber{
+		client: &graphClient{
+			endpoint: server.URL,
+			client:   server.Client(),
+			auth:     testAuth{},
+			logger:   log,
+		},
+		notifier: n,
+		config: notificationsConfig{
+			URL:         "https://example.com/notify",
+			ClientState: "s3cr3t",
+			Lifetime:    time.Hour,
+		},
+		resource:      resources["sign_ins"],
+		trigger:       trigger,
+		logger:        log,
+		retryInterval: time.Minute,
+	}
+
+	sub.subscribe(context.Background())
+	assert.Equal(t, "sub-1", sub.id)
+	assert.Contains(t, n.triggers, "sub-1")
+
+	sub.renew(context.Background())
+	sub.close()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&created))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&renewed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&deleted))
+	assert.NotContains(t, n.triggers, "sub-1")
+}
@@ -0,0 +1,172 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// maxNotificationSize is the maximum size of a notification request body.
+const maxNotificationSize = 1 << 20
+
+// notification is a change notification sent by Microsoft Graph.
+type notification struct {
+	SubscriptionID string `json:"subscriptionId"`
+	ClientState    string `json:"clientState"`
+	ChangeType     string `json:"changeType"`
+	Resource       string `json:"resource"`
+}
+
+// notifier receives the change notifications of the subscriptions of all the
+// resources of the input and triggers a fetch of the resource they belong to.
+type notifier struct {
+	clientState string
+	logger      *logp.Logger
+
+	mu       sync.Mutex
+	triggers map[string]chan<- struct{} // by subscription ID
+}
+
+func newNotifier(clientState string, logger *logp.Logger) *notifier {
+	return &notifier{
+		clientState: clientState,
+		logger:      logger,
+		triggers:    make(map[string]chan<- struct{}),
+	}
+}
+
+// register triggers a fetch on the channel for every notification of the
+// subscription.
+func (n *notifier) register(subscriptionID string, trigger chan<- struct{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.triggers[subscriptionID] = trigger
+}
+
+func (n *notifier) unregister(subscriptionID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.triggers, subscriptionID)
+}
+
+func (n *notifier) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Microsoft Graph validates the notification URL when a subscription
+	// is created by sending a token that must be echoed back.
+	if token := r.URL.Query().Get("validationToken"); token != "" {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, html.EscapeString(token))
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Value []notification `json:"value"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxNotificationSize)).Decode(&body); err != nil {
+		http.Error(w, "invalid notification body", http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	for _, notif := range body.Value {
+		if subtle.ConstantTimeCompare([]byte(notif.ClientState), []byte(n.clientState)) != 1 {
+			n.logger.Warnw("dropping change notification with invalid client state", "subscription_id", notif.SubscriptionID)
+			continue
+		}
+		trigger, ok := n.triggers[notif.SubscriptionID]
+		if !ok {
+			n.logger.Debugw("dropping change notification of unknown subscription", "subscription_id", notif.SubscriptionID)
+			continue
+		}
+		select {
+		case trigger <- struct{}{}:
+		default:
+			// A fetch is already pending.
+		}
+	}
+	n.mu.Unlock()
+
+	// Notifications must be acknowledged quickly, the records are fetched
+	// asynchronously.
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// listener serves the notifier on the configured address while at least one
+// resource of the input is running.
+type listener struct {
+	config notificationsConfig
+
+	mu       sync.Mutex
+	refs     int
+	server   *http.Server
+	notifier *notifier
+}
+
+// acquire starts the server if it is not running and returns its notifier.
+func (l *listener) acquire(logger *logp.Logger) (*notifier, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.refs == 0 {
+		u, err := url.Parse(l.config.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notifications url: %w", err)
+		}
+		path := u.Path
+		if path == "" {
+			path = "/"
+		}
+
+		ln, err := net.Listen("tcp", net.JoinHostPort(l.config.ListenAddress, l.config.ListenPort))
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen for change notifications: %w", err)
+		}
+
+		l.notifier = newNotifier(l.config.ClientState, logger)
+		mux := http.NewServeMux()
+		mux.Handle(path, l.notifier)
+		l.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func(srv *http.Server) {
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Errorw("change notification listener failed", "error", err)
+			}
+		}(l.server)
+		logger.Infow("listening for change notifications", "address", ln.Addr().String(), "path", path)
+	}
+	l.refs++
+	return l.notifier, nil
+}
+
+// release stops the server once it is released by all the resources.
+func (l *listener) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refs--
+	if l.refs > 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = l.server.Shutdown(ctx)
+	l.server, l.notifier = nil, nil
+}
@@ -0,0 +1,163 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// state is the cursor of a resource.
+type state struct {
+	// DeltaLink is the link returned by collections supporting delta
+	// queries. When set, it is used for the next fetch.
+	DeltaLink string `json:"delta_link,omitempty"`
+
+	// Timestamp is the creation time of the newest record published, and
+	// IDs the identifiers of the records published with that time. They
+	// are used to fetch collections without delta links.
+	Timestamp time.Time `json:"timestamp"`
+	IDs       []string  `json:"ids,omitempty"`
+}
+
+// publishFunc publishes an event, with the cursor update to store once the
+// event is acknowledged.
+type publishFunc func(event beat.Event, cursor interface{}) error
+
+// fetcher fetches the new records of a resource.
+type fetcher struct {
+	name     string
+	resource resource
+	client   *graphClient
+}
+
+// fetch publishes the records created after st and returns the new state.
+// The records of a response are not ordered, so the new state is only
+// attached to the last event published: if the input stops before it is
+// acknowledged, the whole fetch is repeated.
+func (f *fetcher) fetch(ctx context.Context, st state, publish publishFunc) (state, error) {
+	next, err := f.fetchPages(ctx, st, publish)
+	var statusErr *statusError
+	if st.DeltaLink != "" && errors.As(err, &statusErr) && statusErr.code == http.StatusGone {
+		// The delta token expired, restart from the last record published.
+		f.client.logger.Warnw("delta link expired, falling back to a time filtered query", "resource", f.name)
+		st.DeltaLink = ""
+		return f.fetchPages(ctx, st, publish)
+	}
+	return next, err
+}
+
+func (f *fetcher) fetchPages(ctx context.Context, st state, publish publishFunc) (state, error) {
+	// Delta queries only return the changes since the previous query, the
+	// records are only deduplicated for time filtered queries.
+	link, dedupe := st.DeltaLink, st.DeltaLink == ""
+	if dedupe {
+		link = f.queryURL(st.Timestamp)
+	}
+
+	seen := make(map[string]bool, len(st.IDs))
+	for _, id := range st.IDs {
+		seen[id] = true
+	}
+	next := state{Timestamp: st.Timestamp, IDs: st.IDs}
+
+	// pending holds the last event read, so that the new state can be
+	// attached to it once all the pages are read.
+	var pending *beat.Event
+	for link != "" {
+		p, err := f.client.getPage(ctx, link)
+		if err != nil {
+			return st, err
+		}
+
+		for _, raw := range p.Value {
+			event, ts, id, err := f.event(raw)
+			if err != nil {
+				return st, err
+			}
+			if dedupe && (ts.Before(st.Timestamp) || (ts.Equal(st.Timestamp) && seen[id])) {
+				continue
+			}
+
+			switch {
+			case ts.After(next.Timestamp):
+				next.Timestamp = ts
+				next.IDs = []string{id}
+			case ts.Equal(next.Timestamp):
+				next.IDs = append(next.IDs, id)
+			}
+
+			if pending != nil {
+				if err := publish(*pending, nil); err != nil {
+					return st, err
+				}
+			}
+			pending = &event
+		}
+
+		link = p.NextLink
+		if p.DeltaLink != "" {
+			next.DeltaLink = p.DeltaLink
+		}
+	}
+
+	if pending != nil {
+		if err := publish(*pending, next); err != nil {
+			return st, err
+		}
+	}
+	return next, nil
+}
+
+// queryURL returns the URL of the records created since the given time.
+// Microsoft Graph filters with a precision of a second, so the records of
+// the first second are deduplicated with the state.
+func (f *fetcher) queryURL(since time.Time) string {
+	filter := f.resource.timestampField + " ge " + since.UTC().Truncate(time.Second).Format(time.RFC3339)
+	return f.client.endpoint + "/" + f.resource.path + "?" + url.Values{"$filter": []string{filter}}.Encode()
+}
+
+// event creates the event of a record, and returns its creation time and
+// identifier.
+func (f *fetcher) event(raw json.RawMessage) (beat.Event, time.Time, string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return beat.Event{}, time.Time{}, "", fmt.Errorf("unable to decode %s record: %w", f.name, err)
+	}
+	var id string
+	if v, ok := fields["id"]; ok {
+		if err := json.Unmarshal(v, &id); err != nil {
+			return beat.Event{}, time.Time{}, "", fmt.Errorf("invalid id of %s record: %w", f.name, err)
+		}
+	}
+	var ts time.Time
+	if v, ok := fields[f.resource.timestampField]; ok {
+		if err := json.Unmarshal(v, &ts); err != nil {
+			return beat.Event{}, time.Time{}, "", fmt.Errorf("invalid %s of %s record %s: %w", f.resource.timestampField, f.name, id, err)
+		}
+	}
+
+	event := beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"message": string(raw),
+			"msgraph": mapstr.M{
+				"resource": f.name,
+			},
+		},
+	}
+	if ts.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	return event, ts, id, nil
+}
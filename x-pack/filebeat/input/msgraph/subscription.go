@@ -0,0 +1,103 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package msgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// subscriber manages the change notification subscription of a resource.
+type subscriber struct {
+	client   *graphClient
+	notifier *notifier
+	config   notificationsConfig
+	resource resource
+	trigger  chan<- struct{}
+	logger   *logp.Logger
+
+	// retryInterval is the time to wait before retrying to subscribe after
+	// a failure.
+	retryInterval time.Duration
+
+	id    string
+	timer *time.Timer
+}
+
+// C returns the channel signalling that the subscription must be renewed.
+func (s *subscriber) C() <-chan time.Time {
+	return s.timer.C
+}
+
+// subscribe creates the subscription. On failure, the input keeps polling
+// and the subscription is retried later.
+func (s *subscriber) subscribe(ctx context.Context) {
+	sub, err := s.client.createSubscription(ctx, subscription{
+		ChangeType:         "created",
+		NotificationURL:    s.config.URL,
+		Resource:           s.resource.path,
+		ExpirationDateTime: time.Now().Add(s.config.Lifetime).UTC(),
+		ClientState:        s.config.ClientState,
+	})
+	if err != nil {
+		s.logger.Warnw("unable to subscribe to change notifications, relying on polling", "error", err, "retry_in", s.retryInterval)
+		s.schedule(s.retryInterval)
+		return
+	}
+	s.id = sub.ID
+	s.notifier.register(s.id, s.trigger)
+	s.logger.Infow("subscribed to change notifications", "subscription_id", s.id, "expiration", sub.ExpirationDateTime)
+	s.schedule(s.renewAfter())
+}
+
+// renew extends the lifetime of the subscription, or creates a new one if
+// it does not exist.
+func (s *subscriber) renew(ctx context.Context) {
+	if s.id == "" {
+		s.subscribe(ctx)
+		return
+	}
+	err := s.client.renewSubscription(ctx, s.id, time.Now().Add(s.config.Lifetime).UTC())
+	if err != nil {
+		s.logger.Warnw("unable to renew change notification subscription, creating a new one", "subscription_id", s.id, "error", err)
+		s.notifier.unregister(s.id)
+		s.id = ""
+		s.subscribe(ctx)
+		return
+	}
+	s.logger.Debugw("renewed change notification subscription", "subscription_id", s.id)
+	s.schedule(s.renewAfter())
+}
+
+// close deletes the subscription.
+func (s *subscriber) close() {
+	s.timer.Stop()
+	if s.id == "" {
+		return
+	}
+	s.notifier.unregister(s.id)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.client.deleteSubscription(ctx, s.id); err != nil {
+		s.logger.Warnw("unable to delete change notification subscription", "subscription_id", s.id, "error", err)
+	}
+	s.id = ""
+}
+
+// renewAfter returns the time after which the subscription is renewed,
+// leaving a quarter of its lifetime to retry on failures.
+func (s *subscriber) renewAfter() time.Duration {
+	return s.config.Lifetime * 3 / 4
+}
+
+func (s *subscriber) schedule(d time.Duration) {
+	if s.timer == nil {
+		s.timer = time.NewTimer(d)
+		return
+	}
+	s.timer.Reset(d)
+}
@@ -29,6 +29,8 @@ func toBeatEvent(flow record.Record, internalNetworks []string) (event beat.Even
 		return flowToBeatEvent(flow, internalNetworks)
 	case record.Options:
 		return optionsToBeatEvent(flow)
+	case record.Counters:
+		return toBeatEventCommon(flow)
 	default:
 		return toBeatEventCommon(flow)
 	}
@@ -36,13 +38,18 @@ func toBeatEvent(flow record.Record, internalNetworks []string) (event beat.Even
 
 func toBeatEventCommon(flow record.Record) beat.Event {
 	const (
-		flowType    = "netflow_flow"
-		optionsType = "netflow_options"
-		unknownType = "netflow_unknown"
+		flowType     = "netflow_flow"
+		optionsType  = "netflow_options"
+		countersType = "netflow_counters"
+		unknownType  = "netflow_unknown"
 	)
 
 	// replace net.HardwareAddress with its String() representation
 	fixMacAddresses(flow.Fields)
+	// Nest the sFlow specific fields, converted like the others
+	if sflow, ok := flow.Fields["sflow"].(record.Map); ok {
+		flow.Fields["sflow"] = nestedToSnakeCase(sflow)
+	}
 	// Nest Exporter into netflow fields
 	flow.Fields["exporter"] = fieldNameConverter.ToSnakeCase(flow.Exporter)
 
@@ -52,6 +59,8 @@ func toBeatEventCommon(flow record.Record) beat.Event {
 		flow.Fields["type"] = flowType
 	case record.Options:
 		flow.Fields["type"] = optionsType
+	case record.Counters:
+		flow.Fields["type"] = countersType
 	default:
 		flow.Fields["type"] = unknownType
 	}
@@ -63,8 +72,11 @@ func toBeatEventCommon(flow record.Record) beat.Event {
 		"category": []string{"network"},
 		"action":   flow.Fields["type"],
 	}
-	if ecsEvent["action"] == flowType {
+	switch ecsEvent["action"] {
+	case flowType:
 		ecsEvent["type"] = []string{"connection"}
+	case countersType:
+		ecsEvent["kind"] = "metric"
 	}
 	// ECS Fields -- device
 	ecsDevice := mapstr.M{}
@@ -82,6 +94,17 @@ func toBeatEventCommon(flow record.Record) beat.Event {
 	}
 }
 
+// nestedToSnakeCase converts the names of the fields of m and of its submaps
+// to snake-case.
+func nestedToSnakeCase(m record.Map) mapstr.M {
+	for key, value := range m {
+		if sub, ok := value.(record.Map); ok {
+			m[key] = nestedToSnakeCase(sub)
+		}
+	}
+	return fieldNameConverter.ToSnakeCase(m)
+}
+
 func extractIPFromIPPort(address string) string {
 	// address can be "n.n.n.n:port" or "[hhhh:hhhh::hhhh]:port"
 	if lastColon := strings.LastIndexByte(address, ':'); lastColon > -1 {
@@ -20,7 +20,8 @@ import (
 )
 
 // Decoder is a NetFlow decoder that accepts network packets from an Exporter
-// and returns the NetFlow records contained in them.
+// and returns the NetFlow records contained in them. sFlow datagrams are
+// decoded too, when the sflow protocol is enabled.
 type Decoder struct {
 	mutex   sync.Mutex
 	protos  map[uint16]protocol.Protocol
@@ -87,6 +88,10 @@ func (p *Decoder) Read(buf *bytes.Buffer, source net.Addr) (records []record.Rec
 
 	handler, exists := p.protos[version]
 	if !exists {
+		if version == 0 {
+			// sFlow datagrams start with a 32-bit version.
+			return nil, errors.New("sflow protocol not enabled")
+		}
 		return nil, fmt.Errorf("netflow protocol version %d not supported", version)
 	}
 	return handler.OnPacket(buf, source)
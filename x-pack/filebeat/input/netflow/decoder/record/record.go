@@ -19,6 +19,10 @@ const (
 	// Options enumeration value identifies exported options records, as defined
 	// in NetFlowV9 and IPFIX.
 	Options
+
+	// Counters enumeration value identifies interface and device counters,
+	// as exported in sFlow counter samples.
+	Counters
 )
 
 // Map type is a regular map with string keys and interface{} values. The valid
@@ -58,6 +62,7 @@ type Record struct {
 	// in each flow.
 	// For NetFlow 9 and IPFIX options records, this map contains two submaps,
 	// one for scope and one for options.
+	// For sFlow records, the fields specific to sFlow are in the sflow submap.
 	Fields Map
 
 	// Exporter contains metadata from the exporter process and NetFlow session.
@@ -96,8 +101,17 @@ type Record struct {
 	// +--------------+-----------+------------------------------------------------------------------+
 	// | sourceId     |   uint64  | Exporter observation domain ID.                                  |
 	// +--------------+-----------+------------------------------------------------------------------+
+	//
+	// sFlow only:
+	// +----------------+-----------+----------------------------------------------------------------+
+	// | agentAddress   |   string  | IP address of the sFlow agent.                                 |
+	// +----------------+-----------+----------------------------------------------------------------+
+	// | subAgentId     |   uint64  | ID of the sub-agent that sent the datagram.                    |
+	// +----------------+-----------+----------------------------------------------------------------+
+	// | sequenceNumber |   uint64  | Sequence number of the datagram.                               |
+	// +----------------+-----------+----------------------------------------------------------------+
 	Exporter Map
 
-	// Type is the type of this record, either Flow or Options.
+	// Type is the type of this record, either Flow, Options or Counters.
 	Type Type
 }
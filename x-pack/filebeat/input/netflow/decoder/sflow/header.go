@@ -0,0 +1,128 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sflow
+
+import (
+	"encoding/binary"
+	"net"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow/decoder/record"
+)
+
+// Protocols of the raw packet headers.
+const (
+	headerEthernet = 1
+	headerIPv4     = 11
+	headerIPv6     = 12
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+	etherTypeVLAN = 0x8100
+	etherTypeQinQ = 0x88a8
+
+	ipProtoTCP = 6
+	ipProtoUDP = 17
+)
+
+// decodeRawPacketHeader decodes the headers of the sampled packet. The
+// fields found are stored with their NetFlow v9 names. Headers cut short by
+// the exporter are decoded as far as possible.
+func decodeRawPacketHeader(r *reader, fields, sflow record.Map) error {
+	proto := r.uint32()
+	frameLength := r.uint32()
+	stripped := r.uint32()
+	header := r.opaque(int(r.uint32()))
+	if r.err != nil {
+		return r.err
+	}
+
+	sflow["headerProtocol"] = uint64(proto)
+	sflow["strippedBytes"] = uint64(stripped)
+	fields["octetDeltaCount"] = uint64(frameLength)
+
+	switch proto {
+	case headerEthernet:
+		decodeEthernetHeader(header, fields)
+	case headerIPv4:
+		decodeIPv4Header(header, fields)
+	case headerIPv6:
+		decodeIPv6Header(header, fields)
+	}
+	return nil
+}
+
+func decodeEthernetHeader(b []byte, fields record.Map) {
+	if len(b) < 14 {
+		return
+	}
+	fields["destinationMacAddress"] = net.HardwareAddr(b[0:6])
+	fields["sourceMacAddress"] = net.HardwareAddr(b[6:12])
+	etherType := binary.BigEndian.Uint16(b[12:14])
+	b = b[14:]
+	for (etherType == etherTypeVLAN || etherType == etherTypeQinQ) && len(b) >= 4 {
+		if _, found := fields["vlanId"]; !found {
+			fields["vlanId"] = uint64(binary.BigEndian.Uint16(b[0:2]) & 0x0fff)
+		}
+		etherType = binary.BigEndian.Uint16(b[2:4])
+		b = b[4:]
+	}
+	switch etherType {
+	case etherTypeIPv4:
+		decodeIPv4Header(b, fields)
+	case etherTypeIPv6:
+		decodeIPv6Header(b, fields)
+	}
+}
+
+func decodeIPv4Header(b []byte, fields record.Map) {
+	if len(b) < 20 || b[0]>>4 != 4 {
+		return
+	}
+	ihl := int(b[0]&0x0f) * 4
+	proto := b[9]
+	fields["ipVersion"] = uint64(4)
+	fields["ipClassOfService"] = uint64(b[1])
+	fields["protocolIdentifier"] = uint64(proto)
+	fields["sourceIPv4Address"] = net.IP(b[12:16])
+	fields["destinationIPv4Address"] = net.IP(b[16:20])
+	// Only the first fragment holds the transport header.
+	if binary.BigEndian.Uint16(b[6:8])&0x1fff != 0 || ihl < 20 || len(b) < ihl {
+		return
+	}
+	decodeTransportHeader(b[ihl:], proto, fields)
+}
+
+func decodeIPv6Header(b []byte, fields record.Map) {
+	if len(b) < 40 || b[0]>>4 != 6 {
+		return
+	}
+	proto := b[6]
+	fields["ipVersion"] = uint64(6)
+	fields["ipClassOfService"] = uint64(binary.BigEndian.Uint16(b[0:2]) >> 4 & 0xff)
+	fields["protocolIdentifier"] = uint64(proto)
+	fields["sourceIPv6Address"] = net.IP(b[8:24])
+	fields["destinationIPv6Address"] = net.IP(b[24:40])
+	decodeTransportHeader(b[40:], proto, fields)
+}
+
+func decodeTransportHeader(b []byte, proto uint8, fields record.Map) {
+	switch proto {
+	case ipProtoTCP:
+		if len(b) < 14 {
+			return
+		}
+		fields["tcpControlBits"] = uint64(binary.BigEndian.Uint16(b[12:14]) & 0x01ff)
+	case ipProtoUDP:
+		if len(b) < 4 {
+			return
+		}
+	default:
+		return
+	}
+	fields["sourceTransportPort"] = uint64(binary.BigEndian.Uint16(b[0:2]))
+	fields["destinationTransportPort"] = uint64(binary.BigEndian.Uint16(b[2:4]))
+}
@@ -0,0 +1,500 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sflow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow/decoder/config"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow/decoder/protocol"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow/decoder/record"
+)
+
+const (
+	ProtocolName = "sflow"
+	LogPrefix    = "[sflow] "
+
+	// ProtocolID is the first 16 bits of the 32-bit version of sFlow
+	// datagrams. It is zero for all the sFlow versions, which no NetFlow
+	// version uses, so the decoder can tell both apart.
+	ProtocolID uint16 = 0
+
+	// Version is the sFlow version supported.
+	Version uint32 = 5
+)
+
+// Sample formats of the standard enterprise.
+const (
+	formatFlowSample             = 1
+	formatCountersSample         = 2
+	formatFlowSampleExpanded     = 3
+	formatCountersSampleExpanded = 4
+)
+
+// Flow record formats of the standard enterprise.
+const (
+	flowRawPacketHeader = 1
+	flowEthernetFrame   = 2
+	flowIPv4            = 3
+	flowIPv6            = 4
+	flowExtendedSwitch  = 1001
+	flowExtendedRouter  = 1002
+)
+
+// Counter record formats of the standard enterprise.
+const (
+	countersGenericInterface  = 1
+	countersEthernetInterface = 2
+	countersProcessor         = 1001
+)
+
+// Interface formats of the input and output of flow samples.
+const (
+	interfaceSingle = 0
+)
+
+var errTruncated = errors.New("truncated sFlow datagram")
+
+// timeNow is the clock used to timestamp the records, as sFlow datagrams
+// carry no export time.
+var timeNow = time.Now
+
+func init() {
+	if err := protocol.Registry.Register(ProtocolName, New); err != nil {
+		panic(err)
+	}
+}
+
+// SFlowProtocol decodes sFlow v5 datagrams. Flow samples are decoded as flow
+// records, with the fields of the sampled packet mapped to their NetFlow v9
+// equivalent. Counter samples are decoded as counter records.
+type SFlowProtocol struct {
+	logger *log.Logger
+}
+
+// New returns a new sFlow protocol decoder.
+func New(config config.Config) protocol.Protocol {
+	return &SFlowProtocol{
+		logger: log.New(config.LogOutput(), LogPrefix, 0),
+	}
+}
+
+func (p *SFlowProtocol) Version() uint16 {
+	return ProtocolID
+}
+
+func (p *SFlowProtocol) Start() error {
+	return nil
+}
+
+func (p *SFlowProtocol) Stop() error {
+	return nil
+}
+
+func (p *SFlowProtocol) OnPacket(buf *bytes.Buffer, source net.Addr) ([]record.Record, error) {
+	r := &reader{data: buf.Bytes()}
+	buf.Reset()
+
+	if version := r.uint32(); version != Version {
+		return nil, fmt.Errorf("sflow version %d not supported", version)
+	}
+	agent := r.address()
+	subAgentID := r.uint32()
+	sequence := r.uint32()
+	uptime := r.uint32()
+	numSamples := r.uint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	now := timeNow().UTC()
+	exporter := record.Map{
+		"version":        uint64(Version),
+		"timestamp":      now,
+		"uptimeMillis":   uint64(uptime),
+		"address":        source.String(),
+		"agentAddress":   agent.String(),
+		"subAgentId":     uint64(subAgentID),
+		"sequenceNumber": uint64(sequence),
+	}
+
+	var records []record.Record
+	for i := uint32(0); i < numSamples; i++ {
+		enterprise, format, sample := r.structure()
+		if r.err != nil {
+			return records, fmt.Errorf("unable to read sample %d of %d: %w", i+1, numSamples, r.err)
+		}
+		if enterprise != 0 {
+			p.logger.Printf("Skipping sample of enterprise %d and format %d", enterprise, format)
+			continue
+		}
+
+		var (
+			rec record.Record
+			err error
+		)
+		switch format {
+		case formatFlowSample, formatFlowSampleExpanded:
+			rec.Type = record.Flow
+			rec.Fields, err = p.decodeFlowSample(sample, format == formatFlowSampleExpanded)
+		case formatCountersSample, formatCountersSampleExpanded:
+			rec.Type = record.Counters
+			rec.Fields, err = p.decodeCountersSample(sample, format == formatCountersSampleExpanded)
+		default:
+			p.logger.Printf("Skipping sample of unknown format %d", format)
+			continue
+		}
+		if err != nil {
+			return records, fmt.Errorf("unable to decode sample %d of %d: %w", i+1, numSamples, err)
+		}
+		rec.Timestamp = now
+		rec.Exporter = copyMap(exporter)
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// decodeFlowSample decodes a flow sample, in its compact or expanded format.
+func (p *SFlowProtocol) decodeFlowSample(r *reader, expanded bool) (record.Map, error) {
+	fields := record.Map{}
+	sflow := record.Map{}
+
+	sflow["sequenceNumber"] = uint64(r.uint32())
+	if expanded {
+		sflow["sourceIdType"] = uint64(r.uint32())
+		sflow["sourceIdIndex"] = uint64(r.uint32())
+	} else {
+		sourceID := r.uint32()
+		sflow["sourceIdType"] = uint64(sourceID >> 24)
+		sflow["sourceIdIndex"] = uint64(sourceID & 0xffffff)
+	}
+	samplingRate := r.uint32()
+	fields["samplingInterval"] = uint64(samplingRate)
+	sflow["samplePool"] = uint64(r.uint32())
+	sflow["drops"] = uint64(r.uint32())
+
+	var inFormat, inValue, outFormat, outValue uint32
+	if expanded {
+		inFormat, inValue = r.uint32(), r.uint32()
+		outFormat, outValue = r.uint32(), r.uint32()
+	} else {
+		in, out := r.uint32(), r.uint32()
+		inFormat, inValue = in>>30, in&0x3fffffff
+		outFormat, outValue = out>>30, out&0x3fffffff
+	}
+	if inFormat == interfaceSingle {
+		fields["ingressInterface"] = uint64(inValue)
+	}
+	if outFormat == interfaceSingle {
+		fields["egressInterface"] = uint64(outValue)
+	}
+	fields["packetDeltaCount"] = uint64(1)
+
+	numRecords := r.uint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	for i := uint32(0); i < numRecords; i++ {
+		enterprise, format, data := r.structure()
+		if r.err != nil {
+			return nil, r.err
+		}
+		if enterprise != 0 {
+			continue
+		}
+		var err error
+		switch format {
+		case flowRawPacketHeader:
+			err = decodeRawPacketHeader(data, fields, sflow)
+		case flowEthernetFrame:
+			err = decodeEthernetFrame(data, fields)
+		case flowIPv4:
+			err = decodeSampledIP(data, fields, false)
+		case flowIPv6:
+			err = decodeSampledIP(data, fields, true)
+		case flowExtendedSwitch:
+			err = decodeExtendedSwitch(data, fields)
+		case flowExtendedRouter:
+			err = decodeExtendedRouter(data, fields)
+		default:
+			p.logger.Printf("Skipping flow record of unknown format %d", format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("flow record of format %d: %w", format, err)
+		}
+	}
+	fields["sflow"] = sflow
+	return fields, nil
+}
+
+// decodeCountersSample decodes a counters sample, in its compact or expanded
+// format.
+func (p *SFlowProtocol) decodeCountersSample(r *reader, expanded bool) (record.Map, error) {
+	sflow := record.Map{}
+
+	sflow["sequenceNumber"] = uint64(r.uint32())
+	if expanded {
+		sflow["sourceIdType"] = uint64(r.uint32())
+		sflow["sourceIdIndex"] = uint64(r.uint32())
+	} else {
+		sourceID := r.uint32()
+		sflow["sourceIdType"] = uint64(sourceID >> 24)
+		sflow["sourceIdIndex"] = uint64(sourceID & 0xffffff)
+	}
+
+	numRecords := r.uint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	for i := uint32(0); i < numRecords; i++ {
+		enterprise, format, data := r.structure()
+		if r.err != nil {
+			return nil, r.err
+		}
+		if enterprise != 0 {
+			continue
+		}
+		var err error
+		switch format {
+		case countersGenericInterface:
+			sflow["interface"], err = decodeGenericInterface(data)
+		case countersEthernetInterface:
+			sflow["ethernet"], err = decodeEthernetInterface(data)
+		case countersProcessor:
+			sflow["processor"], err = decodeProcessor(data)
+		default:
+			p.logger.Printf("Skipping counter record of unknown format %d", format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("counter record of format %d: %w", format, err)
+		}
+	}
+	return record.Map{"sflow": sflow}, nil
+}
+
+func decodeEthernetFrame(r *reader, fields record.Map) error {
+	r.uint32() // length
+	src := r.opaque(6)
+	dst := r.opaque(6)
+	r.uint32() // type
+	if r.err != nil {
+		return r.err
+	}
+	fields["sourceMacAddress"] = net.HardwareAddr(src)
+	fields["destinationMacAddress"] = net.HardwareAddr(dst)
+	return nil
+}
+
+func decodeSampledIP(r *reader, fields record.Map, v6 bool) error {
+	length := r.uint32()
+	proto := r.uint32()
+	size := net.IPv4len
+	if v6 {
+		size = net.IPv6len
+	}
+	src := r.opaque(size)
+	dst := r.opaque(size)
+	srcPort := r.uint32()
+	dstPort := r.uint32()
+	tcpFlags := r.uint32()
+	tos := r.uint32()
+	if r.err != nil {
+		return r.err
+	}
+	if v6 {
+		fields["sourceIPv6Address"] = net.IP(src)
+		fields["destinationIPv6Address"] = net.IP(dst)
+	} else {
+		fields["sourceIPv4Address"] = net.IP(src)
+		fields["destinationIPv4Address"] = net.IP(dst)
+	}
+	fields["octetDeltaCount"] = uint64(length)
+	fields["protocolIdentifier"] = uint64(proto)
+	fields["sourceTransportPort"] = uint64(srcPort)
+	fields["destinationTransportPort"] = uint64(dstPort)
+	if proto == ipProtoTCP {
+		fields["tcpControlBits"] = uint64(tcpFlags)
+	}
+	fields["ipClassOfService"] = uint64(tos)
+	return nil
+}
+
+func decodeExtendedSwitch(r *reader, fields record.Map) error {
+	srcVLAN := r.uint32()
+	r.uint32() // source priority
+	dstVLAN := r.uint32()
+	r.uint32() // destination priority
+	if r.err != nil {
+		return r.err
+	}
+	fields["vlanId"] = uint64(srcVLAN)
+	fields["postVlanId"] = uint64(dstVLAN)
+	return nil
+}
+
+func decodeExtendedRouter(r *reader, fields record.Map) error {
+	nextHop := r.address()
+	srcMask := r.uint32()
+	dstMask := r.uint32()
+	if r.err != nil {
+		return r.err
+	}
+	if nextHop.To4() != nil {
+		fields["ipNextHopIPv4Address"] = nextHop
+		fields["sourceIPv4PrefixLength"] = uint64(srcMask)
+		fields["destinationIPv4PrefixLength"] = uint64(dstMask)
+	} else {
+		fields["ipNextHopIPv6Address"] = nextHop
+		fields["sourceIPv6PrefixLength"] = uint64(srcMask)
+		fields["destinationIPv6PrefixLength"] = uint64(dstMask)
+	}
+	return nil
+}
+
+func decodeGenericInterface(r *reader) (record.Map, error) {
+	m := record.Map{
+		"index":            uint64(r.uint32()),
+		"type":             uint64(r.uint32()),
+		"speed":            r.uint64(),
+		"direction":        uint64(r.uint32()),
+		"status":           uint64(r.uint32()),
+		"inOctets":         r.uint64(),
+		"inUcastPkts":      uint64(r.uint32()),
+		"inMulticastPkts":  uint64(r.uint32()),
+		"inBroadcastPkts":  uint64(r.uint32()),
+		"inDiscards":       uint64(r.uint32()),
+		"inErrors":         uint64(r.uint32()),
+		"inUnknownProtos":  uint64(r.uint32()),
+		"outOctets":        r.uint64(),
+		"outUcastPkts":     uint64(r.uint32()),
+		"outMulticastPkts": uint64(r.uint32()),
+		"outBroadcastPkts": uint64(r.uint32()),
+		"outDiscards":      uint64(r.uint32()),
+		"outErrors":        uint64(r.uint32()),
+		"promiscuousMode":  r.uint32() == 1,
+	}
+	return m, r.err
+}
+
+var ethernetCounters = []string{
+	"alignmentErrors",
+	"fcsErrors",
+	"singleCollisionFrames",
+	"multipleCollisionFrames",
+	"sqeTestErrors",
+	"deferredTransmissions",
+	"lateCollisions",
+	"excessiveCollisions",
+	"internalMacTransmitErrors",
+	"carrierSenseErrors",
+	"frameTooLongs",
+	"internalMacReceiveErrors",
+	"symbolErrors",
+}
+
+func decodeEthernetInterface(r *reader) (record.Map, error) {
+	m := make(record.Map, len(ethernetCounters))
+	for _, name := range ethernetCounters {
+		m[name] = uint64(r.uint32())
+	}
+	return m, r.err
+}
+
+func decodeProcessor(r *reader) (record.Map, error) {
+	// The CPU load is reported in hundredths of a percent, and stored as a
+	// fraction.
+	m := record.Map{
+		"cpu_5s_pct":  float64(r.uint32()) / 10000,
+		"cpu_1m_pct":  float64(r.uint32()) / 10000,
+		"cpu_5m_pct":  float64(r.uint32()) / 10000,
+		"totalMemory": r.uint64(),
+		"freeMemory":  r.uint64(),
+	}
+	return m, r.err
+}
+
+func copyMap(m record.Map) record.Map {
+	c := make(record.Map, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// reader reads the XDR encoded values of sFlow datagrams. The first error
+// is kept and all the following reads return zero values.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.data) {
+		r.err = errTruncated
+		r.data = nil
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// opaque returns a copy of the next n bytes, skipping the padding to the
+// next 4-byte boundary.
+func (r *reader) opaque(n int) []byte {
+	b := r.next(n)
+	if b == nil {
+		return nil
+	}
+	r.next((4 - n%4) % 4)
+	return append([]byte(nil), b...)
+}
+
+// address reads an address, preceded by its type.
+func (r *reader) address() net.IP {
+	switch typ := r.uint32(); typ {
+	case 1:
+		return net.IP(r.opaque(net.IPv4len))
+	case 2:
+		return net.IP(r.opaque(net.IPv6len))
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("unknown address type %d", typ)
+		}
+		return nil
+	}
+}
+
+// structure reads the header of a sample or record and returns a reader for
+// its data.
+func (r *reader) structure() (enterprise, format uint32, data *reader) {
+	dataFormat := r.uint32()
+	length := r.uint32()
+	b := r.next(int(length))
+	return dataFormat >> 12, dataFormat & 0xfff, &reader{data: b}
+}
@@ -0,0 +1,205 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sflow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow/decoder/config"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow/decoder/record"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow/decoder/test"
+)
+
+type datagram []byte
+
+func (d datagram) u32(values ...uint32) datagram {
+	for _, v := range values {
+		d = binary.BigEndian.AppendUint32(d, v)
+	}
+	return d
+}
+
+func (d datagram) u64(v uint64) datagram {
+	return binary.BigEndian.AppendUint64(d, v)
+}
+
+func (d datagram) opaque(b []byte) datagram {
+	d = append(d, b...)
+	for n := len(b); n%4 != 0; n++ {
+		d = append(d, 0)
+	}
+	return d
+}
+
+func (d datagram) structure(format uint32, data datagram) datagram {
+	return append(d.u32(format, uint32(len(data))), data...)
+}
+
+func TestSFlowProtocol_New(t *testing.T) {
+	proto := New(config.Defaults())
+
+	assert.Nil(t, proto.Start())
+	assert.Equal(t, ProtocolID, proto.Version())
+	assert.Nil(t, proto.Stop())
+}
+
+func TestSFlowProtocol_OnPacket(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	// Ethernet, 802.1Q tag of VLAN 10, IPv4 and TCP headers.
+	header := []byte{
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0x81, 0x00,
+		0x00, 0x0a, 0x08, 0x00,
+		0x45, 0x10, 0x00, 0x3c, 0x00, 0x00, 0x40, 0x00, 0x40, 0x06, 0x00, 0x00,
+		10, 0, 0, 1, 192, 0, 2, 10,
+		0xc3, 0x50, 0x01, 0xbb, 0, 0, 0, 0, 0, 0, 0, 0, 0x50, 0x12, 0x00, 0x00,
+	}
+	rawHeader := datagram{}.u32(headerEthernet, 1518, 4, uint32(len(header))).opaque(header)
+	extSwitch := datagram{}.u32(10, 0, 20, 0)
+	flowSample := datagram{}.u32(
+		7,         // sequence number
+		0<<24|3,   // source ID
+		512,       // sampling rate
+		1024,      // sample pool
+		2,         // drops
+		3,         // input
+		1<<30|258, // output, discarded
+		2,         // number of records
+	).structure(flowRawPacketHeader, rawHeader).structure(flowExtendedSwitch, extSwitch)
+
+	ifCounters := datagram{}.u32(3, 6).u64(10000000000).u32(1, 3).u64(123456).
+		u32(10, 11, 12, 13, 14, 15).u64(654321).u32(20, 21, 22, 23, 24, 1)
+	processor := datagram{}.u32(1250, 2500, 5000).u64(8 << 30).u64(2 << 30)
+	countersSample := datagram{}.u32(
+		8, // sequence number
+		0, // source ID type
+		3, // source ID index
+		3, // number of records
+	).structure(countersGenericInterface, ifCounters).
+		structure(countersProcessor, processor).
+		structure(1<<12|1, datagram{}.u32(42))
+
+	packet := datagram{}.u32(Version, 1).opaque(net.IPv4(192, 0, 2, 1).To4()).u32(0, 99, 360000, 3).
+		structure(formatFlowSample, flowSample).
+		structure(formatCountersSampleExpanded, countersSample).
+		structure(1<<12|1, datagram{}.u32(42))
+
+	proto := New(config.Defaults())
+	records, err := proto.OnPacket(bytes.NewBuffer(packet), test.MakeAddress(t, "127.0.0.1:6343"))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	exporter := record.Map{
+		"version":        uint64(5),
+		"timestamp":      now,
+		"uptimeMillis":   uint64(360000),
+		"address":        "127.0.0.1:6343",
+		"agentAddress":   "192.0.2.1",
+		"subAgentId":     uint64(0),
+		"sequenceNumber": uint64(99),
+	}
+	test.AssertRecordsEqual(t, record.Record{
+		Type:      record.Flow,
+		Timestamp: now,
+		Fields: record.Map{
+			"samplingInterval":         uint64(512),
+			"ingressInterface":         uint64(3),
+			"packetDeltaCount":         uint64(1),
+			"octetDeltaCount":          uint64(1518),
+			"destinationMacAddress":    net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+			"sourceMacAddress":         net.HardwareAddr{0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb},
+			"vlanId":                   uint64(10),
+			"postVlanId":               uint64(20),
+			"ipVersion":                uint64(4),
+			"ipClassOfService":         uint64(0x10),
+			"protocolIdentifier":       uint64(6),
+			"sourceIPv4Address":        net.IP{10, 0, 0, 1},
+			"destinationIPv4Address":   net.IP{192, 0, 2, 10},
+			"sourceTransportPort":      uint64(50000),
+			"destinationTransportPort": uint64(443),
+			"tcpControlBits":           uint64(0x12),
+			"sflow": record.Map{
+				"sequenceNumber": uint64(7),
+				"sourceIdType":   uint64(0),
+				"sourceIdIndex":  uint64(3),
+				"samplePool":     uint64(1024),
+				"drops":          uint64(2),
+				"headerProtocol": uint64(headerEthernet),
+				"strippedBytes":  uint64(4),
+			},
+		},
+		Exporter: exporter,
+	}, records[0])
+
+	test.AssertRecordsEqual(t, record.Record{
+		Type:      record.Counters,
+		Timestamp: now,
+		Fields: record.Map{
+			"sflow": record.Map{
+				"sequenceNumber": uint64(8),
+				"sourceIdType":   uint64(0),
+				"sourceIdIndex":  uint64(3),
+				"interface": record.Map{
+					"index":            uint64(3),
+					"type":             uint64(6),
+					"speed":            uint64(10000000000),
+					"direction":        uint64(1),
+					"status":           uint64(3),
+					"inOctets":         uint64(123456),
+					"inUcastPkts":      uint64(10),
+					"inMulticastPkts":  uint64(11),
+					"inBroadcastPkts":  uint64(12),
+					"inDiscards":       uint64(13),
+					"inErrors":         uint64(14),
+					"inUnknownProtos":  uint64(15),
+					"outOctets":        uint64(654321),
+					"outUcastPkts":     uint64(20),
+					"outMulticastPkts": uint64(21),
+					"outBroadcastPkts": uint64(22),
+					"outDiscards":      uint64(23),
+					"outErrors":        uint64(24),
+					"promiscuousMode":  true,
+				},
+				"processor": record.Map{
+					"cpu_5s_pct":  0.125,
+					"cpu_1m_pct":  0.25,
+					"cpu_5m_pct":  0.5,
+					"totalMemory": uint64(8 << 30),
+					"freeMemory":  uint64(2 << 30),
+				},
+			},
+		},
+		Exporter: exporter,
+	}, records[1])
+}
+
+func TestSFlowProtocol_OnPacketErrors(t *testing.T) {
+	proto := New(config.Defaults())
+	source := test.MakeAddress(t, "127.0.0.1:6343")
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := proto.OnPacket(bytes.NewBuffer(datagram{}.u32(4)), source)
+		assert.ErrorContains(t, err, "sflow version 4 not supported")
+	})
+	t.Run("truncated header", func(t *testing.T) {
+		_, err := proto.OnPacket(bytes.NewBuffer(datagram{}.u32(Version, 1, 0)), source)
+		assert.ErrorIs(t, err, errTruncated)
+	})
+	t.Run("truncated sample", func(t *testing.T) {
+		packet := datagram{}.u32(Version, 1).opaque([]byte{192, 0, 2, 1}).u32(0, 1, 1, 1).
+			u32(formatFlowSample, 64, 1)
+		_, err := proto.OnPacket(bytes.NewBuffer(packet), source)
+		assert.ErrorIs(t, err, errTruncated)
+	})
+}
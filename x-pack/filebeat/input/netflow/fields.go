@@ -19,5 +19,5 @@ func init() {
 // AssetNetflow returns asset data.
 // This is the base64 encoded zlib format compressed contents of input/netflow.
 func AssetNetflow() string {
-	return "eJysXc2S2ziSvvspFDOHvWx32K6y2+7DnmYndg67O4c57A0BkSkKXSTAAkCp1E+/AfBHpAhQyAR96Imx9X34YSIBZCYy/5r459NfD/86C3M4iRoOwhwqkKC5hfLXw9/UQSp7aFQpTrdfPyUz/nJ4g9vvBwn2VKvrp8PBClvD74e//A/Yv9fq+pdPh0MJptCitULJ3w//8elwOBz+LqAuzeGkVXMYfnngsjz8459//8f/HRyV+fXT4XDyP/vdQ345SN7AvCn3x95a+P1QadW1w98EWnva4q/Dz+btzdt0rUx/OTb6Brer0uXs7yNNuz//OoOHHdRpal5DoXQ5oI5QHo63g3XfBy4g7a+fVt2Aj1ZpC3rVlfn4n3Tkv8Hyklt+0FC7T3+w6mDPMHEfSriIAg72zO1dQPp+9R3+dcb3OGHz3vKy1GDM4t/ic/ek2+7Pfw5d/DfjhOCq9NvYxkHIwz/++bv758NJ6YbPZ2/eJ6M6XQATjy33vaqVrHBd+t+jAX3h7p8PpWq468ff3JRez6I4z2ftcARHbyIds6IBY3nTBjtWcgu4jv1LNODl20Gd0PXfN9J617r2WSPqWoQ/GH5q/ktdPWopXa1WhftgZ24ORwB50J2UQlb/7j5h3z4USpaxebqANkLJYB+FtFAtVkdCN8fFOBAfOgNlYOnxo1F1Z4GB1mq9AEvVHWsIwHr5ZK1SNTuL6szsWYM5q7pccfgp3mao1TWDQFvW8LYVssrtyoxpty61oFlnQBP7dipYq5VVhapXkFEwNlHM/VVUya+hUslbI/70a5+dal4ZRLsLsIXiLMV7t249TtC2tSh6+LEzQoIxv2io4cJlseaJzNmMpOAWKqVv2FmYUcwWGo3A72IZHThb27JOC2Yst8JYUaw/iTkrbVNoDOhfeAXSUijEWmwTUPSRm+64xxe0mp9OovilqLlZT11EiLRlRS1AWjZsyqzfS/iHaLoml0XIHVgMgaHlxRtY1CyoTlrmDlVMg2mVNICHS7iyQkkJhfsieDy95QnJzsJYVWnesGPnJuHLjlxfd+R62ZHrdUeubztyfd+R6zcCl9VcmkYYQ5JGj+ZoUc5UJJkaJEN1jHNP7fkDHt31JR7Xd3eRAZ0790EW9DhCLKTR5H6QMA11PPmfB78rWWV5nT0PQRb0NIRYcLPQM8zUSt6A1kTEMa2IEMMyBppjDSU7aV417vDilWcqvCvFgAgYhiKYC2heAXPXC821Fhc3BBE4Nobxx6p1p30rZH9u5IbJrjkmt+/wEj4s4+UfvHAjJjOcVctEe3lla2vTcIVqn6O/o9Gthkte7wdLFAV64bUohb35e876m0VuGkdxcnf1Uuj+bJmOkyVhih3Krwp/yXf/Sb/d+vtH6BIVnpP+57hrj8eI03jzAVkJCYh7W6HqGhaK4266WnF4812MQmlWgLZ9X5K/5h2M/jQLKE7071Dyp1VNoyRrtWrdoAHzmZU8iRJkAayGC6zNPDEjnLtPUaZJyoxhjlc4txOwstPDRT0iIdERjyzzDQYzY9JqXrylQ0puOTveLKA2IY+qhXxze5jb/XAqZgUXfyJsYY/ooLNmG92b6A3TUAt+FLWwtxXDUakauAwwQG0588oVNWezDRQtnCtwq+EkPnKwrAZZ2XPyN1uy4PTICkzv/vf87je8iPY+uoHMCahaohQVGMvO3Jzdph4wAEdER7i2i/GWrU5sOSnR0dDoNmSTSBiXllTC0ZGXP9aJaYdh3rnQI5SG8c6elRaWW3FJPgg4YIE7+zhIYEOIi6k0rPlg8FGcuayQDTUffoGChpBjYrNNaUrkqc6jPljvicXMYGs1pa13AkRjNygH6kDfposzZmBaozdjaZhRnMFHK3SyRhpAuPvziNJw0mDWuvsZyur1Nr2NMaAFX58cN0ENQTCM4poC0xfsFjKitHCqYz0fT5GW6woILV5BVGckzlrM3NsPy9zxDDGHyn55Z0VnrGpAsxIE4hj3iM09ICz5oh8otgyX8GFn2aMnl5oH7xDxL+fxhMmkjdmAvgi3i0pjuXyM1tkUmjB+p25YXuEY8BOtVdtCyWp+A/2VqcKCZf31AnWzCNH0dkoKTW43ctvvbd4ZHRgICD0wdvSJMyFLWF9QwjiofHjLUSteFtxYeg8GJm+oPfHkyI5HWPjIsYltzzcjCl5T2+6k2GXoF31KXv8gC31rLZR9SI2qVbVe9FE1ibYBDoDg3MYg9gxagmVn4CVo5KV1Qrf8VitexuBRBTMR9J+DDkedYPuTJNG/0UcO4qWwh03BXeu4we2Z7uGmsC0zVgNvUIp8gM/sArHQtM323ZzV6jqYpyhraKRpwBheQQ4FVY2PoZ8UK/eERRvI5kicbWpCUq06PYEPaeyDXdMPMPBhQUtejx1mGni9vk1FZuskNFx5XTMfP45AGcv8YZ5JJRk0rb1Nm+7oLUqOpOvpVkQ4k25PMkCPXMqAfyyqx30sJuOyZIY3be1kIHX+/WrjhRWX3jutOsR378HWanHsbCAAaxuI9sv1qNGx0IhCK5x34ZFgw4G1QQCyHI5m1C44hk1s2H02w6JdbxNWckltVgM3yK/lYMTWzM2wrsU46T0UJ/mirKly/wY3d0R22j0QHr/RZs2PUHstjUH5le1UrHe++sPBJdmuE2AwLS+EXN/vtgighmLaovG3kyUJ9ZK1ZCFflWY0SjNeV+7CfF7vPNtCYCzXNlMb9BxUfTCiaRqhRxN1Qg+mA2kL3PJjDexUd+bcb/v4z95TtMDfcFilr1yXbgkZy22XfkIYrwHhRxPPUKIEaafIjdTOjmh1OhmMtfN0ZceaF2+q8982WZR9FPdJVJ27+GB8ew5pDTO6YKZK/hbX4eOHAyNSQIit4srgw0YOmVsTOaEYrzEjm2AlmAJxDryyM69PTLUgcZI9B55FleqYWOB06JoTxTX8gxlARVmfrkx3NcLBcLoy0zUN1zfWviH1xJX9qSSwlgvMaXqOCjpDwriqVsfZHSjrzVqlwZ1NEn/tPfGDV151tg0cg6Imb4/tzwJh92N0aXikkMIK7rYtjdIXPbidTEIRBZcGDt7SNqD9HDHNZeWDcOnYwHxtYKcDD6nlR3R629a2Tq9bp4yCNrDo4vPQ0QoTM0Zto/sLB2vPmgd8wM+w750PNVFmLRtp0AbsWa2XXRo44mTcBvfHClaoEmE6mF7vsfDrvWiTomha35Y3NqUeSxao9RVmC+V+sdFgdIRr8LrdBDB6lCMqfZTVgEoHSOXOS7OYPILBcWQZ3F9DUGAuDdX8OdLk4sl+FCH7jAg+zLkGfwr2E4z6MiESynvfEBHGsh7EB11zKIrg6QQ1iH4vOUKVvJvEWUCmHm9CHAYaLjGPn0MknRSBZ0Ybc7qby3Okwnp8VjiM03MEN11tM/2WIxXZgToSZHtQRyKMC3U4hjJbtLj7eQ+0SveaLvUWc4fhnrbdvzJRDY1w7Mq3oBsoBbeAd+94NNm9I1rWv3RRpzE4BQEtxamH9aeGVol0F5Foaf7qO270AuFcHCLnkZfIeeM1uxNFhhz5wnccecithgLKYLhsHGSgYKZdR2hFu7kZARBHBQL44p1COt39JxZnRAuXV6ZazOtyD9Gqs6BZwJYUFoXLdwYfFqTx2UG8QCe3N9tSkof1IbjbSK8m7LpMgSG1moP2eb+wDQ4oSnvSMC7NFePanYBeEQZ8rM+RPpSagBuvIx+YK6UHm/6BKGGGjGWFsNiMLnesOyvoW/gCnQ4ntl5zK2wXaPlUKx4VJwdUsqIhNVQ+lxFtvANa4tFD3BkrRHtGi/MIfoMbOiBrQYAzSHmoN5aclUFfgCZwp9d7TgqMYqBxaNEYwUx37FMmYue6/o3xtkUc2GYgwgzV3IIsUo3PHqLhQvYFTwRkN7BnMLogKiyHpCosj6UrrDmc2DpJYXkgSWE5JF1hzdCE4dqzBm4jdsfQGWgJCtgbn4BwuqnmuoJRijHyuzD54cU/02KYGbYfgJuu8be9947r5JRemQbLADynGwaqmJFvC7e8PYWfAUXOpUECrN8/SIILYqyFBFZwXSLGriqm2uSBqitoVghWi0asxxZLh9Bw/ZbYn4Z/sKM4MpBWi+Qv71ADYsrqiYEOsdeoSBoHzIkmfMCj44cWeHwE0QJOgA7hLW6+pQ/J0TDkFUo9fTgaUqzuLHSBICf3oxwCJZquYTQbxogetFMGA8IU0gCXQyR/elSIBw1fBAXrfcxN+Y0VZyjeQpmoov3ssaZQ6a6hBixoUrR7A1YrBpciBIkeDu4ojAOrEUdW8NZ2esw7hvWSeAYlrQ9Hwj4VmoNx5zWH9A4udHitQzaq7GqsecYB1fEPKIg+vhl+DNYLbAAbMzWAyb02N2kDISEpUB96w44h59fzDvdgXC62FbziXSDHQiJ4nQL9mfZeMcQzaoQO+St8f240VofCqVOnUOFW1gLrV7cVxVvyhvLI0EkjKgnJakwcfQLmDVmPASXxsCOG7YQcLL1iwB94lgyEI8+SAA3OOQQM6IxDwMCAOQSoo6jBG7AQmqkHNUaYEqOBlRRW+V15fBtxP9hipzrANZMaLFtbm+HNh7G8eGMltOmz/gjGfbNHNNI1F8N/+ZzLsE6KjSRYZ8JGEiRHgsUI1jmvkQTJUWUxgnV2ayTBj1yCnygCkn95gaR5mD2FVe0wAvhItjc8INEe+TUe55R/wJPyvj1w0BXBnQOzBzwgMRcYB720svdesz73WNUJE/I4RTlsMR3vMLeXFh0O1EPGaCIfb2jgvfPZO1EJb3ui8dI9WhORJgrPoaEAcQHNrHqD1CC9e0CYhnuhjBMv0u9hkscey0TmzgGENKIEZi4FJjpyQCLT5jiU0qLy6Y5kRYtO8iSdpfbalzdC9dgjcFdEB3vvlOUMPgqAEsrIh4m36g76kcvNZrPTuxV0ixglIcGagrtTZsHHSijoxwYjiWacc2+8Rc7yHY92m66xOIdtAC9kwfWQyAuleZZcFprW13bJGVAnhc0d1b3ojzuEd+30hH8XMsT70ztRwYszMA2l0KPUzVIXF0pjlFEiKykh8oy8J9Q2eSneobOhnbmQPsIQ42HboErfk4MklInotPaPQEUB0oBjM12TbPy4E5VHVtSWEk2y4HBHhSM39HVWHlmtKhGryZYwjO2KcE+/R3lkGt7xu8QjAUXxD2DT4q4Va3jkSTcOnjcHHT6Q8Y73liWaBPSv/Hr90FfIKIYaAWVYPSZ0J8bZG1L2YNUgS9C7dXKg26N/pPeSDyT9k1qyNIxvEccUy3+GN0wMU6HUm8jrzEnpKzsdaQI6EdQZBLjnoAEC7KPQAIUuLlmT4PA5c9Anp0ZEEgY4Or02AaDwhHDIAM1FIHLlBvAfQ04PKJ1oZVCZXNk2ubJtWK0iGUIww8gTTpMpnIYZHwCXqWnmNGtTczJPf6ggjiV+pIibBSYKd5t1F6bBm93ywMEmYRgjDTpQYUVBLNka57Ggm6maBOUKtkmIyta55CzOXEqfHSa1CmgU78vK4I9jS5bU+qFR/C69SK08GsXv0ovUmqVR/C69SK12GsXn9KI/rGbdOWc8Yu0KCfkhgtiad7II2eJTF1pP4+2n2qqIQYFAdhWyVFekxTxIl6VHPIXvUAk1T41KjJL8IWx6jE2UZXhzfK9zmznjw9fDG4IeKT6yF0VviN+hLzarL3fbFL5CxZJqeGnYV/BgUpH6k3mPdBQ0o4JDejVFl/76t7uuwbwrWrEM1WizWLy2Yw04PS4MIlHkkmes8Nu1JbcQt16k9OmBa8N0kcJGXTlj6eIMCRso9tkK5mQ7bAU9XaYen5Fk6PEZy356fEaaJwF5+vceUJ+xIEaSqqO4FeYMGgZFnuz/D/MYIPuY5jTDCvfJdpiB9Tt+Atnw8IJEleMjHf0uNGu/381aXgHZy3ZnIIE/Zo4jigesMeLu3AvaOZ7OgVadrzUsiJI1aEhrCYaeCUsw8pibqVU1JgWkyM7AgK1vtCJw02Ysb9bXn2djyPTCdvJNqqv8+ts6hDIZirdLTFC8MWGC4i0AExR/bZ+g+Lv2BE1Nrx6AriMrk6HrmMpk6DqaMhX6gy5NP+jS9IMuTT/o0vSDLk0/6NL0gy5NP+jS9IMuTT/o0vSTLk0/6dL0ky5NP+nS9JMuTT/p0vSTLk0/6dL0ky5NP8nS9PKZLE0vn8nS9PKZLE0vn8nS9PKZLE0vn8nS9PKZLE0vn8nS9PKZLE0vn+nSFHh6kgylS9MXujR9oUvTF7o0faFL05e1NCUczCc0XaC+0AXqy1qgEH3+upapBNvJhKaL1Ve6WH1dixWmz4jk2wH0WrgwaETu7gCaLl9f1/KFaXgtYgj0S5aIvaxFDIOmS9nLWsoQ6+plLWUYNF2FvdA3xBe6fL3Q9dcLfUN8pW+Ir3TN9UqXqVf6hvhK3xBf6dL0SpemV7o0vWZpq9esDfHbWqYw6LVYYdBryUKM+xtduL7RhesbXbi+0YXrG124vic/n15jA6abZCzdPPBCv7y+0m9lr/Rb2Sv9pPIaOGukTvFrYLdPx9I/7St95b1+T5/k6zy+Ap9wsE+P3idox9R1WRX3RTWqLDN99T91JaQnnPCZaQ4nnmwCcn0GdTSgL32M5hCYkuxDCWBxHsg5gS8GQGu7h2Kcl3O0z9VDSF8T4EAnsFlx4FPYrCgo8LGCLEF+iIlGszOMUpdMdk5R1fL3McdUcijC8LS9ZvRq8kEKXPKKicJ9bMMK1bQ12OR3lg/woWwKFd5qMOmv0CdwlsIy7CRkBZq1OlR6JK6osKmolcG/eW+/tsxCcZaqVhWiPAA50zZ502h56RN84tbAkDIGM7A+xwxIq1WbHFqwTGqDrE3XqloUN/auhvoOU5FfdhaguS7OqeFmM6b3DnxevFCJsDRwqVWbqqAesbh2NWIT9r+OlwvbmOQJJ7uGuf+LeJMzQ/v4SiISWmQQYtuHzho7vmlpeBFVv3Gh9izKfnlnRWesakCzS82DWuxJVzwJDZtRx2nE5xRzGjnwZYY8MjPJ+ZqDoAIdR+OT8uzRmwBTVp926MwOvaDvTCsOaj/Iicba/j1Pu1zyPtbMqxH3H+Si82xGdbqAXKJlr9BnyRgL7jg5sQxjonfjTkDoQZasZ0l5nnznSfYwZfQ9CL9xaHHpo5Qt6FYLg8yi5p+8as54izkUDyCfjiW2Uz1Fj/kfCm6hUsH08E854rXJtocLH0PBxVAc/sZk92h/LT/CmV8E5jX8CK8qQ5jsjJwZC4qTqAGXBGYBr7msOlRY8hKOfny/gKNzMjygaRXLgyT4q+SSxbRKGkA/fl+woNJLLJGExBIjQTBT8bboCFmoZuN2mojGlYJdwc2Zt+5/URe3GEnsldzTLzcVeyXpHreAhhSrwSQtwR35Efsdj41kmt6eNNXZSpE/+4SmffYJnvPZVyTkz95q1YIOPMZ4ut7eFdzFb9y5VjSRkmJBklEEKSTTjOT0ZCKh9kRzguLS0CgLxMVzBxNWj5aBmrLPJGZ4Q+Qawxk65wSko5khnVDuJXu2Sh48pSEm1BzhfmcbjxlsVsIeRdXn+cNXKWkNdKViV6F9xkSrVc18I4/4iNqZwfMukzOi9NPxDBQ8YUZFVgM3yYlG+x8zC5iSuRpqbsUFGGgdOHTHapr156wSkNXn7zDc3qPBHQuB8aNRdWfxvR3gUslbM2SjiyQW2PgWIRLvxBDvHeqjDkSz9EHDZQ1bSzNERSohFCKqtOqw+YRDPJhiUyF8fg9Md8ye4Qto/2jXJ5PUWlx4jXn+OvIcq3ahgbjBGRbmPD6ehJd/8MLdXbOZSFX/Yyw429acpdVw2WdUg9koh+LCa1EKe/P3yWTD/cjQux3SkwEscTRBffDkoSunTTyqrsfUz08jPrYncqBSmhWgbd83ysiUPAnvQGE1XGB9tn2m/mfBW6Zrpjze0SiSZ6PKy4098vhaDcebxb3oX6BrId+GKrqx0hpPZ3dFhPIXxliwx50Zi4ZC6dIwDbXgR1EjHtxPPN5Y7c2KpLnNOSlGSfoyKntwIEuxRNhomnpFkj+s7/sNi+QpCBFRXVcTl6jAWHbm5twXu8OKoC87Uwwhn0ydHiYtOso82g1ZzySOSxuWePKj7c+44/BTvH1POJfxEyWsjcTPVsYjw14LZcmLTYsSoclx9kUo0e6/JQ95yvNmZDAzEQoMPeHZuVuWIyoOLLnoH0artoUyNx5lkw7vrH6k26tbe/WH7MOPEGX0yFi3t55OokDFCo54qHzFrKNWvMwLnXlg9Pf7Ew9Yd3Hw8Mk3iaM934zwHqW8vnRS7Do1F31CayCQhb61FkpStO+dhXiJHYDBb/EMas+gJUwPdmiH0onlSfXJpwpvItosnougIV3MxurJhW2ZsRp4Q9LeU1R/lj2gZyFVwh8pTkLDldc1rl7gDG0sW73Oot2ce7KB4silxPh4JpKaV4ZxWTLDm9anS0RPibst88L7BKxoQHWEa09PYq0Wxy6UyjiNoN+nMgxPPYvQuHKjS/RoJNp8NZXSjTtR3nhAltPM5HWpr1C9B0f+iLYegyVSRJxlKV95SEaa07q5GYbLtbqgEGWdu97uVX4pHfAKwylTb+0dK4/nM5mWF6GaZClEUENhx/d69FPrkiz3UL5kyz5Sz+iUZryu3NXsjEjwvSTySbj3UQ9j0fl9WPJURM+SqSR6knwC+krvyz35uCdMvb8Jr3lfjhlVGnqFHsMvIiGjiX1Qp5MBgq6qNLA3SH1ZNqK8BXWwpqrOtgEt+WT4nqHXJP4pJb7nnmEosS0MaJJFtydppwtBZBpxJMEjZwJFP5PDM7GGY2/hAY7AvCZwTOo0qyePLOi+iKJp+xdeor2s03Y8EbAFOjm5wwLtfrnRgacSuiZBPAddk5BnYUTjZ6Ea0HigVBrKuesww+Ixsg32wMGXuRddrjlvFnzHoAavjf24SRMXIssJIQoRUowEQZ6gqZBERYuuCDH12sYXR9jhQ8afGOO5DDRcWlGgjwohsk4KzMvlO9XuptqRkmofXeEpxtqRpOlqu5OddaTMNgCPRLtZgEdCigl4ODAxW7S0c+uQe0OhY00f4bSY07uUZKrFkYaqeSzoBkrBLdANnhlv4WcUOc/hZzRZlvU7fjS7UiOfxB7xj2KP8MfZiR71kjCA32FS8AkLZmADBTNt6nu2GXDTv/Ecbdcmq+edvUTeHD5FXl6ZOBNavLwy5fUIWhk5qFadBc1M4O3LpnBdvvvHRdKXYeoXD7r92YaHHXbNdQWUFHZ3gtlpnG5x2+lQv1PoQYCGlKoryLfT6DIyiD3wGahiF4IU/FKr+UsPXrUFqajWrCAZzQNYCwms4LokzI66gmaFYLUI1eJ7Fhjd8I/JtUuyjjqCPVxKDzxkm/GCh241XtDQKbL8wg3/EE3XZG6NI8uwDHdgIuy0Q+U11pTfWHGG4s10az/H0+U7sphC4a0dDVjQWSEDDVitGFwK0rumO5piq2mEzFynQu7k21kx0dfqkiljtS6JyCR7LLeBZYflNjBRlpuSwio9PQe/8PquV6nTE+CcSQCVta3N4L42lhdvrIQWP1OPJLT5fmQh36BiTIEaQmSudbZtMhWi1sYzKkThjWdU69zcZCpEYaFnVOvc6mSqdV55MhWi6MKCKsvksGDINTrQM9hFGMjmnDUPzaLzwJP1wOmBaw/1dGej7C8PDJQDjaO4tLI3aQzPZ6pOmDMl+vFuitdwf/x94oUN5ArYFmvJLS0m1AGH/M0+NHUQGqaB1+vDbgJZq3zCDEL/PZJmbHZwyvfMLpFwJ6KVSpjwOSUT7iT7lD4Y+bJrCISIkLUE4hSU742rLZDeo9wX56haA0gqIk2mmXA3+2C2EO9lEdyuLfB8DeRXGdikou33mVUHIjTY6gMRGlwVghVJTjWCiYxalWBGQNSZhCoFI5ZarWDC5wYJZzvw0fUIHoDYugSPcFJ9gokElah/gSIk7A/gCYn7AyzIBP5BBnQi/zvLHgn9l2w7PbLOSfC/4NghuGGPhP+PXHQ/+h4FAOJcGdpkt4IAzxh36eOOnduxV/mbwg6FA5Zc2eaXfQsJrFkzCwo8EGYWFnjGRjtBZhca2CDK6NEua2iX1bPPutlnxeyRq4RWoGBCZxYquPOQc7NOFHk5WkM0+6xPSs7WEDhoIXn6hcgZVkc4MdPqI5wW/apPxcu3b5/ZH8K6i3GGfWfFRLbuPDDRbTva3gMmKeYvhzfw3vmchJFl91Q+Nl7qP2l+RDaqRHd9xNIu0yNac1mqhvpaeHooHH/1mjIKx9C/oc7sRh88QHqvPHHk9mHYl3aicYPBPh64c6i2qylPRO8MWh1jqRuf6b2JhPC2cZe37Otn7OhueKWUm9NjDCocjJNlbB94rmgyQx3755V9wtRB56G30z0euk8c9NbF+DiwN4yOhYF3ohvfoWbTtW92x855tty+EfcLMD7onBSzZxpe1zl+vNyb1BxPSHO6hhMDAHIvcnN81jgyM7XucWvayRJgdJGVh85YniFXlmdOguWy5Lr09drGwhx5+ekDlLmpy8zNWGi8EyvbsWuLlvHiLePePjBIda2hrIa3wJQbsyMar7lH0tNYxzC/4dJE2L+rFDJzSrLe4jkC2nsmh2zNObPz2uRYchzDs1tcAkOvkOjf0Nxyv2Gnq3wGtx68u4Nyl3UcVyFLd48qeE2wlswZSI6ueaw1MYvGfVPJtXrOtqdcq6XtpIQ6yw3dlXvoG8cyvsGgbgmOI2vBdNI7FqhPyru2zXkv5Wth0U7CPmWQD3T0uRG9JzZcNvPJEC5C266Pvr9v/P59OeVdSpyNOMwHvn1Yuo40Nqpt/aLZidbxq2uyOHO/ZPGGPQ83hjLYq22JZ8p8Q2++gTfbsEs16OYYcvEGXIrhlmawzTPU0g20WYbZHIMs1RCbaYDNMbzSDa5ZhlaKgTXLsEo3qOYZUrMNqFTDaY7BNMdQijeQ7mQY3ckguo8hdB8DKNHwORbJwKIIZlIj6jfG2yFqByElVLMq1ZxKNKNmm0+pZlOiuTTbTJpjHs00iwbhJnJLirMQjarG1L4iADOgBa9jB7H44Ce8qCS3ncYsvRE7VXyUyjJ+CplBkCRHOClaV3BpiUbcUFDB50RFtipa1MM7j6kFSFpfVdPqQePh7sAOrY5/QBF5tbTZ4QEYzt27iWy7Yy0K9ga3jV05lSGiEzbhQ+XyyGeKL6oBh9yMKK4NsktjR1cG3oUREzOU46LkNnDKpjsssh0VdAdFnmOC7pAgOyLwDgi644HucKA7GsgOBrpjge5QyHEk0B0IZMeBhaat3X6JMRHak3VCXgNOp3pY0OoTh4gGjOXNOhY/Mvvj7+/F8u9JW35BXtkznCnjARBjhst3vOzgcMlwtOQ5WHIcK2SHCtGRQnSgEBwnDoJDZLtYLqK1UHOfmCLZhILyx0Qb3scLk+d9SfK6xEaA9bVcmivXgfKJ3FrEtjGwrBN7k2gsSN5nwbcRpRc4+YaxgdeuaVjcKl4SjG85kj/aAj2ztxA6P7N+0NGkwV8+nOwN6fG0CpwdYsP3yxz76gXvHLxybtwhtRF/8sEq7JOhprZIdCoSnIlkJ+KHP0jNt8Ax/41oWaI8rTlw4tDjB0miNp8kiP8fAAD//4qByOE="
+	return "eNrNfc+T5LaR7l1/RcX6sBdLMf2rNKPDRthr6z1FrG1t2Lvv3RgsElUFNUlwALC6S3/9ZgIki6wCWcwEW14dNFJPfx8SYCIBZCYSv/vdsn+++d3mH0dpNntZiA38eRCV0KkV+XebP6lNpeymVLncn7/75ndLGb/dvIrzD5tK2H2h3r7ZbKy0hfhh8y9/FfZH+Mm/wI9yYTItaytV9cPm3+AHm82PUhQ5CKJVuWl/c5NW+eann3/86f9vkMp8B7+4d7/2g4N8u6nSUgybwn/suYafHbRq6vYngdbutvhd+2vD9oZtYiv9D7tGoeNvSueDn080jf/84ygcbKP2ffNaZIBvUTuRb3bnjcXvI06ist99cyOGeK+VtkLfiDLs/x1B/iJsmqc2hdYL/PQbq6BR0XMD9iQzEPaY2ouCeLm8wN8N+K4HbChtmudaGDP6u+mxuyM2/vPnVsR/NagEgH/t2tjICj7kD/jXm73SZTocvaFMRjU6E4nMg1IVqjrQRPrbzgh9SvGvN7kqU5TjTzikb0eZHYejttkJpDcTgllZCmPTsg4KBh9M0AT7B/A5/UYoKp3/vhOtNzW2n5SyKKRZaWj+L2g4osbaVWuV4Qc7pgZGRFQb3VSVrA6/x0/o24fhqvKpcToJbaDBoIyysuIwmh0LxOwmY0u8aQyYw3DbKUwHm8yptaxpjf/0c6/B8IlwoIw3TNjSd+3/qKo4T6mz+NqIChS6asrdTc+5X+7vLevGs45FQ9tx0Gm5RLpml/gxW22+weQaSQNNfOua8PbK+P8S96TsJRysJCxr2q4qphaZ3MsMZ34rGEzlQpiltvK3/ZBOtnsWMrla9GIE+Ue78nWfpm3lSqDNJzTeYLXcTNb7NBO/3zz4n23++z/+8Fdn0B7bH9THs5FZWmzgm0t7vtsdWeXifS01RK77HZoQyf1lUitVrDW8ysI4XD5ynWavwho/JzLVFDnY25PwBtc3D1u+oZ7OTeNcq3qtReGvNzIie417H5GlYHvxr9JNAX+J/wWW0Y2rWS7tUaS50AmsM1Zlq43wzy3d+APnbS/aRr9rlfXP8BsadijLhTYW2oZRSHZnK9Yfa8cKg1mqE8jstsCBTuwECA/nAvgovj+bN1ik25WboC/95A3249q4LujI/8FdKBjXnhh1Gv/brZre3rY/CdndKds7lvnWOMyO/QKxbwxFL//VwM2cNNaSY2iAB8YVvm8u9rICJYDtF/7lT3/46x/kHn/927/89McZQWHJE/kHSPp35A2ICvLtUDVr/MhuizgjXC5h021v94lrCPinBhQs8E3b1aupXiv1VnUL174pim9zB/l9u3Qd02Lf/+jJ/Qg6h0vbs/sf1di5cbepbcxHDLwjDvTrj9JC36RxP0/zEoT1QjiZ4aNAV/GvVI1nRWgB1iL/CzPdkFWiMgsLwAf05G+OGM9dQqLBU9Xi6QdiNVlqbFK/foho/1VJpO9XP6aMZVNY+ZFy/qVrIFbSnVZp/pGS/rFrIFbSXJos1bn5kFVgB8tTftnz+KZEPi+R0Frp30KeN2mPG9/anbnhjZvfXf0WkuFusOps6saZV9PUfkey6fZ4MzKDLf1wO+MOnQRdQ5l+UyPDEfCfYGE4Yv4TzAtHzA+0LX9rLN24oEwfZl1uJFpmXmA2lyB6oxqTlCqf3gXv4OAs0oon3P87Cjydjb8ebm5gVzOQAAMhU+d30R7v1jredMfFjzzfpIU8VCW64j7ss/+ooaXB6uu+OhjvvmmvAjMasM/Mby0eHIbhx5vsKLLX3gl3V1Ajq0MhElh6ColO48SxfKDUzuake1SAtG1907c+I6iz4vU/S9QS3Qn2CDqgqmXymq8iscJ8oJb+/T//vMEW7tsjOBjD74g8sTqtDBgGlP0DB+7tqAwM16Ax53/pxOgdZGi5SpHLpnR/v2vMeaYXGOO7fP2PEP8/MMB0aWFGFvGOoR+YfR8rUDueGM52yjjwLPYSLBPYGWQ4ziZlmnVq8PEWNCR45xPHs/Vf/vDvGPco0jPMsXumCrYCWgqdAKERHyf6v/tmNq6Z+3PLGaHEKpW4mOhvYO6dKxw/v/tvmEHpuyxhCnn7b+SvYqketJS/iRr04q+hCuZc7lTxgabV8Yc//mCThzNQ6bU2Tz93hOvvmbK6SV5MUmd2crBgp13ACrEvVBr6JZ+K8AP6LDOY0Myp9fN/bRorC/mrTzNQp3b7WuCp5GUTjpdfd+Sh/F/ekVJWjRV3evFS/q//HL4fc5/DYrQuKQVsj87Uadh1IRQnWhoKcMFC37x3q5/n5d1rIf554v4Irc9J259wdkYVMPLevt3E9HMFZlIEYD79wkVjk6M8HBN7hB8cVZF/E+zkPAOYnQgCbWGFqWvY38eKMmBaTSSYNgmsQZop2z4LBWXHmTuzqAR/NJmFdwutVHUu22kKNiEd7TPutTsCW5EdKwmHQwJBXRcy83DYossKhvNbLQpxSqtMLB2zAQn8IQ4wCaijMKAYzDYegVufIwQ4WlsnjZYJhoeksTK7/SSgU9ouoQFN9Kk/HAqZc1D8nmMq1ApfEM4g+73Mvs1gsTFLlQimcFZI9P20WZOJT/bzO+BYFlmtwGIYDK13kYLD/WDiTsRgu2o4+wk6vBJv8F9V5ePLdDy/5R4JBthYheltYFpwEB5W5HpcketpRa7nFbleVuTarsj1PYNryju1DO/QKVmVIw1JpAWJMB3d2HMlv8KTRR/jabJjpjnsviLHPshC7keIhdWb2A8SpuH2J/7z0FclfxyMHYcgC3kYQiyGwTAwK3EduiVi9umGiNAtY0S5c44FnR5cEM0Zz6XwJpctInBzZwIDmgR728Q5+tC1esIuyHLpwWF3qHG3b2Xl942puU0sv4evxDtedvglzdytBy7DUdWJrE/PgXsT/Z2Ju+gtGV1rcYqTvs0c50Dha0n46Gd3zhFLTxo7ibcRklDu4h1clTOGGFFuVrhDPv5r+enWnT9Ch6jwmPhfpx17HAbvVHgNFtUBztCEcxsGeMTIcFzuFt16h/AzTVEonWRCWy+LILYPYPKnGUG3TCj706qyVC7Dq8ZOC8pnVtVe5u4aSyFOoljshMPzFGeYqiqim90RDleCJG90e1Cf0JDJHncswwWGMmIVALPX5RC8beKuCZAWIYcqZPXq4//YTYqJuYHLXwm+sGt08DbtPNrfoTTwZyHTnSzAuH4zlRd0yyAKoHDGlTRmgwWUrJw3YFiQ9vI9BguTqjrY4+JvNmbZxoi/jRB/Gy8+hl2npJ9cQIYEXCuRywNmoxxTc8RFvVm6/8rRuVll3Slb7ZPxoEz2hkc3o5tMwm00YXfxrl6PaYVuXrjIPaxMkjYWlFWi8/q0eCOAwIy290FIYEGYVlP4/fI9Ee/ZMa0OxIYAiBNUaBEKTMy2WZk8ofesek/8VXnKCNZWc9r6yoBo6gKFoEboc39wpnRMa/JiDCijUvjcNRwVCOqLINr5uUOBfmC0j4yy+kzEGKFlWtBAJUMxAKY5MH2iLiEdSks0HWc60qb6IBgtvgl5OBJx1lLG3sIJHbdnhDFU9uFrkjXGqlJoWHUkYRt3jY3dIIz5Jj/Q1DQcw9uVZQ1JTkVa0ey/wzMGk9dn9HRKXEUrYzGaTTi8hPEriWHTA42BPtD+snriEu4e/c2ixB8vSCeLEI33U3JoYsWIbd/7vCMEaAkYEhjbxcRv6jvM4cTBpbcMbg9xJWiZQnfeKbDwlmMW29W/4LbdVHKVrp/0fvH8hy2mPtcWk+oxpUYV6nBebibJPsAWEBzbKUh7GSdp60jQDq09uk7PBejWFHzSwPQE/nPw4aQdrN9JMuMbvkAEXQs9rE/uui3sND/SHm4yWyfGagGbQIohb+EDv8BUatps+z7Z9K11T3HmUEdTwlTCiE8EBdeMd7W5OF7uHkt2kA2RWx6S69XxBC6l0WePL9/AiPf2JkCXIQmaV5RLR2sPs+wtLYrEFfgjoPBGFG7mkwpDIWVtz/2i20WLDI3uhojm0vUkLXSXVlUgPjZpx10uZpJWeeIz8/Xy8XezDb3sJx+dVg3hu3uwtVruGisMEUiOy3lUF1goZaYVLbpwTTATwJohEDDOfmvGFQEZZrHh8NkASw699dgqrbjNwtQ0xK+FMGZr5gxbqpoSpHdQmubLvODqPcxG3CKjdVea0maR7kThrDQF5WY2mlgXfHWbg9Niv06AwYCtkdWBRCAwFNot0fTTyZiEe8gas7CPSgMapZO0OOCB+VgSlQAOytpGWgPPwbUHHZpnETyaaRM8mA/kTXCb7mDO7ovGHP2yT//snqIW6SsNq/RbqnOcQjdFo+ZNYXcMCF+auIfCFADbZ24sFbZDq/3eULyd+7dkh+UCsaQFfNvFquyyuPfy0ODBhxLbQ6RFD2uWmINdDvIfP5wYsQREWCoA924nNplzA9mjwMBYRmPuhglhH/iWYC20RNWiomn2EIi3kTg4HTrmTOLK9B3Ui5RlDSjdFII0HqYpy1SfsZoNdUR+VRUYiVRqkqJcUMFgSBh3KNRucAaKurN20AL3Jgt/20Xi26g8zPg6sA2adHk7rN8LhMOPk1PDIWUlrUxx2dIke+HBde8SmjBwy8DBU9oM1I8R6Hp1cEm4fKysKNh+w8Nq+Rq9vG28GQZ23aIxmi3aH4Z2XpgpZ9Q82h84kvqoUyPI2K+NSzVRxjKhpbBHlTPBE0HGebDfVsCA5wTXQX97Lwnf3ptsUmZl7dpyzqal25IRaktC4W/MNDjZw1vwlgMm97JDLe/loUUtB1QK90uDnDyGw7FjacNfXTGQSBqu+7OjicWz4yiy8tfkXZpzIdwu2A0w6cuESDj3fUNEFM96EB8MzZEoaKkaIQa/luzEYfFqMs0iqjyCw4gyrSiXn0MkDWxHDGVMVwt5dlTUiM8NjhL07MCDupSx8rMDqB1BdAS1I6KEUNttaGKzmnY+90D0Ht0URL3fHsJoV9suX5lphjo4deYDDsuj4S1vcninL/TECu/APt3fdMFEU5+cQoDmcu9hftdQK7k8RARwVrz6guuiQLQQh4y55CVj7ngNzkQTXZ74whccu8s1luXKg+my0yBoJjG1XC7mbAbANMoWBKGIQXf3ieWxIP2+qim3yx1Ew9ETU+qyhaoAuoOB2coVuPQKvbi9wZKyuFvvMsWF9M2EQ5dLYESrhlD/MBu1wRbFaQ8z3ivzRgnt9kBnCIVhIF0qNQPXHUfeLRVs/AVRxggBMsN7nlws7hX0OXyAXg5ntl7AhtI2gZZ9/bQZIMwkHlLD7htrGfH626IrOrrNO4OPVR/J6tyBMYJKTcgaEdAcUg7qnCXoE+J85K7cEgvGcdAgWpZGYrkj/6YldayL75O0rgkbtgGIMUJYC6jKzpTG8F45NxbcE7DDwI4BQ088g4VIrsFyWL7BGsKZrbMMlgOyDBYi+QZrgGZ0F6MoqZ3wO9Z3QVsyiGabCvQWd1pM0d+Ry4+u/pEew8i0/QAc747jae9rk+rFJb0iHZYBeIwYRhymnHxzuPHpKXwNaGJfGiSgxv2DJLQkxgKTw/GtDELf1QEONIs7qt4wrVYmhSylXVwOoUz160J5MCy9kzvYYlgtF395RLWIvqonBdrmXpMyaRAYk014hSfnD43w9AyiEZwBbdNbcLwrl5KDwUJXV+hMGHlWru4gdYGhJ5etHAGFV0wTng+jQ7fWKYKB4AopRVq1mfzLs0IcqP0iJJiPMZf5S+LeHQlVopqU02NNppaHhkphhWZluwNSq0ScshBkcnNwQVECWKXECqm1bXRXd4waJXEMGPvHdCTqVaEhmLZfQ6QLcJHTaxFZqrwpBKNJtfsFFj2ec32A75L1AgvAzEi1YLbU5lzZQErIEqhLvUl2oeDXfYE9mFaL7QZ+SJuD4IJv36i/Z71vGGqSo/wG7/eN+OJwdWAPoaLNrBHWzW6Y2K+GO4hNZeShEjkBjwWYZ3R9ClgxNzuyXU7YydI3DPQNz5iBseUZE5DBMZuAFh2xCWgZKJsAtZNgitGBRbBMHgQYk1MssKokLBS4Knd3Iy4bW+pQB7gGWkNlqwvT3vkAkuwVVph6+ahfg2nf7BpNDM1N4R8+xTI8xhI8xRI8xxK8xBJsYwm+jyX4HEvwhUTAii+PkLwIs6Owqm57AAsQE0mOyN/itzF4Vt23Kw6+IbhwUNaAKyTlAIPQU1356HXia48dGmmOhL11jWk9XfIJYY9Vk9OBPKTLJnL5ht37l7SCt56oO3R33kSii8JxtK+dafgAr2Jpkt4lIUyLy0MZ+zRbfg6rUku7kY0ACXvQHE6pp4ySHdkiiWVzEAUL/MGVO4JlnpWd5Egay5XaPW9EktghaEdEhH1tYLeX+Kf6RD7xYaZbxY3+xOFmttn+3gq5RYqRqIR1j5QloKjdSyjkywYdiU7SNHXOW+IoX/DksOktlhawDeBllaW6LeRFsjxjLivAAmPWX0yHMKs2tleXR39wE97U/RX+VcgI908vRFmaHfHRm1zqTusGpYszpQWnr/OsrILIA3JPqK2lQwddO6ZwisUMQ0qEbYZq+ZocJOEMRKO1uwQqM/eGK7CZphR0onwHg2o52SQjDtwq7FIjYjgKdZBTb7It6Mb8i3B3vwcwaPGVvkpcE3AMfws2Ne1YcQufuNJNg8eNQUNPZLzgnWeJpwH+lp+3D/6FjKx9IyAPm8cF4kxxekfKGqwwiTHRei0hW7o15GPdl7wi8Vdq2drQ3UXsSiz/mlrmlqhnypR6lXHCYAWFZL/jKWhPUEQQ0K6DBgiol0IDFDo7RQ0C4mPGwBen1lF9aHQRh2ekQwZoTjKNwr+3NT2wqI2KGRETq9smVrcNbAKy2FluIpXTRConLKAuAS7S0gxpHvk8flPB7Mv0lmLaLdBT4GkWD0xtNLtOAxubBd3oaMiJCjcUzCdbp3nwVln/mgTnCDZLSKrWOebEkv+Vqw7zwJJpgE+un+nmsDzG4leR4ikWv4oUz7H4VaR4icXHSOE3q1FnzgGPrBfFIYLYIm2q7EhJvQjSOP+ptmrCocAge5NVjlF2ksc8SBdlRxyFEygXRXqO+NyO5BdpLcOTd8XS3jm+vHNrV+gfx6d0TfEePSm8I34FWWyULBffFP2FijFVe9PQv+CRVIolT+Q5Eil4TgVEOjPF1/7i+4utodwrumFpX6ONYnHWDo5/aMelKbkftXvht6kxXWTae7FEpiuuGdfFEjbuzOmeLo7QsJZinaVgSLbCUuDpIu34gCTCjg9Y1rPjA9I4DYizv5eE+ogJ0ZEcGk5YYciAl5+dITdxPHAO5caYhjTtDHfFdjB5Yw2y9uIFiyomRtrFXXjefrea1Zizz42yXRhY4PdB4IgTAcPrvH1wL+jnuDsGGhrGt4YlU7NaC2ktw9HTYxlOHnM2eN+rvXTB0Z2Wgfq+0Q0BDhssl2VN7kNkFLapXiv1Vj1+/4kPfeBDH/nQJz70mQ994UO3fOj3fOhnPvQLG/qZr02f+dr0ma9Nn/na9JmvTZ/52vSZr02f+dr0ma9Nn/na9IWvTV/42vSFr01f+Nr0ha9NX/ja9IWvTV/42vSFr01f2Nr09OkTH/rAhz7yoU986DMf+sKHbvnQ7/nQz3woX5se+Nr0wNemB742PfC16YGvTQ98bXrYcjbmPZqvUA98hXr4EiPz4yeO76RH89Xqka9Wj09RMj9HoV+i0NsoNF+/Hj9HNfwlBv0UpWJPD1FovpY9PcXMq6fnKDTfhD3xF8Qnvn498e3XE39BfOYviM98y/XM16ln/oL4zF8Qn/na9MzXpme+Nj1HWavnqAXx5VMU+iEK/RjT7xe+cr3wleuFr1wvfOV64SvX9onuOe2wnyOwfPfAE//w+sw/lT3zT2XP/J3K8+MX9hA/Pz1GYPmf9pk/8563ywf5bZhfQS846Muj+wLtlHddbh73JTWq8Ma6e/0P3x4klyfs8ZFlDnueaAL2+wxqh+Ejn6PZJqYsjqEEsLQI5JDAPQbAa9tDKcHLIdrV6mGUrwlwkAvY3HDQS9jcUHDg3QuyDP1hFhqNrjDKnTLRNUVVnX7takwtTkVor7YXCf81+SDFlkeBHxsfNcOnye3ie5ZX8PbZFC68BrGX30LvwVEGyyR7WR3wLRAtKfdcFLUUNQDId97rR7zhnR0rVagD4XkAdqVt9qJRg9JhkQbaHGhLxlA65mvMYEVPVZ+J7bS3T4lv09WqkNk5+ara9x36R36ToxQ61dlxabrZgAnshauLF3oibBk4hwEwTCytXU1YhN1vTz8XNjPIPa4CE4z/a1hol1/JRIqamIRY+9RZrHnjNaJMs0nzO63UjkXZh69J1hirSnxrtUgr0gt0AxIeNuIdpw4f85hTx0F/ZsghI4uc33IwTCBylK4ozxrSBJiiZFpBmBWk4K9MNxxcOdiFxmp/n6ceT3mXa+bMCP6LOOkcm1GNxncB44jGUpH3klMsWx5L2ye+GBcChgRRuh6l5XH6HafZ7ZDx1yD6wqHlyWcpw+ED/scQq6i5K68ar4hSNsUtyJVjmVqp7qK7+g+wlRMHFSwPf5dj+m2y+e7is23uwcVQHv7MYHu0O5bvxDE9Scpt+A5+OBjGYEfUzBhR7LFuLKkIzAgOCnpoSGnJYzj58v0ITq7JcIXmvVgeJKEfJccssNBgdjz18vuIhVReYoxkFJboCIKViudVR1aZKmdOpwvRtKdgb+DmmNb4J+ngNkUydUvu7pfrH3tl2R6cQG2J1WCRluCKfI3d0rETlabnB0019qDYn71H8z57D4/57Dck7M8Of9ZC2zN9vn1V4qJ+3cq19EmxIEmnghySfkRiJOlJuJLolGG4tCiVFczJcwEzZo+uMrrGtHeIsDGao3NIwNqaGdYO5fJkz9yTB3dpmAU1O7hb2bptRjJ4wp5E5ev80V8pqY1ocpW8Se0qJloNLK6RhWZnAI87TA6IJKd1Wk0HfFVqcaFR/8sJvnmz/Jtogc8wnuCko3Vg0z31ppnfZ2Egm7QEXWC0tUcL3BaKJN0ZVWA5bqq0LbxS1blsq9FNFBaY+RYhEhfEkLB1ZRANyge1hzXqW5ohKtYTQiGig1ZNvYJAlMemQvh4CbCIdOwIwx/u0q4rJqnRPVBQrr92PLtDPbJA+PArxbEw5HH5JGn+S5rh2TWaCcvqkg3iFMuWzVLji7qr9Kp1G8VQwFeWOb6wi+fJxY77jsGHHZYXAxjjeIp6Fckjv5zW86ii6Eo/3834mB/IlkrpBHYS1svG6Zmq9tIFUJICflSQzf8geQuzIbo63gm3V3G1sTse91YDVvwh+UVH6EJWr+0rulNPa9wd3RsiUrxwioW63RmwYA0MnWONkUKmO1kQLtz3PM5Z7dyKrLGN2SlOkvhnVNbgID7FMsG2XaNb2xW6tV2vW6xIQYiIG7rqueQBnZrH1Bz9Y3dUFXTPzmRtyidGsMeDNtnLONoZXY8k3q5G3MfR1mdcsftLon13OMf5E7mQ5JlxzbDWRBnzUsuiTNDEBPsmKMnhvzEPe8jjRqR1MzEeGLrDs7JYNj1wvxD/w2hV1yKPzUeZpaMHq6/p1hJrLXnYMfwJogiJYFGEtXUPZwFSrmCHFwf3YtZOqzSPS525YnTn+32aiUh4eOe7iKM+no10EaU4WZpKrjo0J70nWyA4relzbUFlONm+FxbmIbYFUq5L9FB7FLoS/YUd3qa0Z7nz+uRdg9cTzT6eS6BhHcy615Mzi+XltUhLlvXus/qj/AGexQUyqQXmO4q91OItLQrae4EDNOZ0Xd/O4p2cPVlLscNi3Zo+JM6lnaRYWjHF+w6aMSR4WkZfxsk/7a0axrHHk1ir5a4JlTJeRuDXqQjHk2eBb0x6bnSM7pxEs7emlohxIYrrDxbO7EYmTiT/QvUaHPE9mrsMtpBiIli25Cu3xUhjWjdnWHFrTkjAUci8iJ1vl1d+OQI4g4HG1Hl7u5fH45kMmLTQm2RLiAS6jbv7evxd65gsdlM+ZoveUg/olE7SAlM27bFk6oAvwr2OeegenV+HJc5EeJZII+FJ4gn4M90/9+Tynijv/fV4nfrnmElPQ9+gu/SLjPKM6Q2L2u+NYNgqOEwksJEhNus8qK03FUxk3ZB3a47BWxJ3lZIuuWNon9iWmA7D8eh6kro/EEwMI40kuOVcQOFHsr0mVqbv8Ryy4nD05jRKkmsWsiwyK2t/wwt9wFQFG6G3LDT+5owAdzX0lmQbQ8IehQ5NH4VDi6YDK6Xh0w9ChxEej46t9Qe2scy16GLdeYPkuwRU3llj12/WwIXIYlKIQoQcJ0GQJ+gqZFHxsitCTN7auMcRVviQ01eM6VxGlCms9JlZQynwLXXDGfvVXbUdJdc/eoPnOGs7krIprFy3X9EO4I5oNQ9wR8hxAbcbpsRmNW/f2tbeUORc02s4L+f0oiWRZrGj4VoefGJS5FiFhO/wjLgLP6CIuQ4/oInyrF/wnduVm/kk18h/lGukPw529KSbhAH8CoNCL1gwAEPDiaklvQOz8Y37aFswhJ26c3gXCcoijwULp5wdIRsjhGo4cmGiREZULtBKvFxUuWeY/OQhtz9Y8KjdLlJ9EJwSdheCwW6c73FbaVO/UupBgIZVqivIt1LvIiqIXfEZcZg6ECzBj62aO/TQTVuQiuvNCpLxIoAFhqqzFA6u9NFRbxhNlcAReovvXmI0PivWhXZZ3lEkWCOkdMXD9hmPePhe4xENnyIqLgwEsoSZF7c0diztNFyBibHSti+vJWX+kmRHkb2CRaFP347FZIru7SgFrJxRKQPAoFUiThnrXtMFzfHVlLKKnKeyWim2c8PEn6tjpojZOiZik6wx3VqWFaZby8SZbgpOvkr318HxxlpvV7nDE+AcaACXFStX+fA1kGWvsA+q6SN1TcIb72sW9glqiunh03pcj+tRPa1H9bwe1ct6VNv1qL5fj+rzelRfmFRRLocRQ6zTgV/BboKB7c655dmuwRN1wemKaw3zdGHjrC9XDJwNDVKc6sq7NNrrM4dGmiMn+/Hiitficvl7n2JmDVGtsVAfKycUgW39Zpea2ioNJqcVJYesVq5gBkN+h+Q5mxHO+Z7RTyRciHhPJfT4mCcTLiTrPH3Q8UW/IRAiIr4lME3B+d60twWWSxR745z01gCRikkT6SZczT8YrcRreQTn3xa4PwfiXxmYpdrGUfFeHZigob4+MEFDe4XghiTmNYKejPsqwYCAaTMZrxR0WO5rBT0+Nkk4OoBPfo/gCkh9l+AaznqfoCchFeofoRgF+wN4RuH+AAuxgH+QgVzI/8KyRkH/MdtKl6xjCvyPOFZIblij4P81Fz+OvsYDANNcEdZktQcB7jGuIuOKwq0oVfyisMLDAWOuaPfLug8J3LJGPihwRWjXqfCzzgMDN2zchwZmiCIkWmUOrTJ71pk368yYNWqV8B4o6NGRDxVceNi1WXuKuBqtIZp15ienZmsIzLszz66w2sGZlVav4bzsV73Pnl5ePiW/SIsH4wj/zg0T27tzxcT37Wh7SZjkuL8Qb/DdAiyDMzHt7urHzE39O813yBK2qFws7zDdoWEdzlXJvS3cXxSevvW6pBfI4O9QR4rhkwdY95V7jlgZ2nVpJRrsjGBzqLopOFdELwxa7aZKN96zez0J427jKnfZb6+xk8VwRim2pkeXVNg6J/OpdeC+oYlMdfTXK33B1NbmkZfTNS669xz81mV3OdA7RruHgVei6+6hRtPVr3ZF4RxbrGzM9QL2bE5xODl7psQKPRFxvNiT1BDPKHN6C2cmAMQe5Ib4qH5sV+lH1KlpJU+A0VlUHToDZ1C+XmGRjqhBsLD/woRxfK+te5gjrj59gDK2dJk5GytKF8SKDuziJUJMe+Gf21uGSr0VIj+0d4E5J2Yk6o65O9bVWGQYnnB5KuzuVcoqckii7uIhAe8+EyJrc4wUHiunxTHcO8UtYPAGif8NzTn2Gzb6EM+A88GFOzhnWeR4AzuK56gsLUQcAyvQNcy1ZlbRuCwqsV7PwfIU67W0DRwfiqgwdJOvYW+QpbuDwV0SkCNqwjSVCyxwr5Q3dR1zX8q9hcXbCbuSQS7R0dVGdJHY8LOZd7pwkto2Pvv+svC7++WceynTbMxuXvGtw9I0rL5xfesnnex5gr9hk9kxdVOW7thzcDgxMTr7ZmvmnjLe0Rvv4I127HIdujGOXLoDl+O45Tls4xy1fAdtlGM2xiHLdcRGOmBjHK98h2uUo5XjYI1yrPIdqnGO1GgHKtdxGuMwjXGU0h2kKzlGV3KIruMIXccBynR8do9kUFEMN6mRxSu+duizdghawnWrct2pTDdqtPuU6zZlukuj3aQx7tFIt2gQbiZOSdMsTKeqMYV7EQATL7Fi2MRGbLrzPV4e4IjbaMHA9i8+4u2fdG9ZAoxIdmKveKLQyhJ1uPZBBVcTldiqrI/k/hYSXUYcWVWJNxIM/QyMaLX7BQxzeJc/K3ALDNfunUXWsJEClYbfmVmVlzJM2IRZePty+cRnmp5ULY64GHFCG+yQxoqhDHoIY0rNSIGLHB8xueHgByyiAxX8AEVcYIIfkGAHIugBCH7ggR9w4Aca2AEGfmCBH1CICSTwAwjswAFM8brA9ZLiIrR7i0oOWkqyqQ4W9PpMQ8DwgEUr66Wj3/1+ku6MKvBa/aVoy7fEI3tEMKXbAFLccPGBlxUCLhGBlrgAS0xghR1QYQZSmAEURuAEITREdIjlJGsritQVpljsQiHFYyYbXicKExd9WRR1meoBNdZyKt9SHXg+ER8PI7PcFvZm0Viw6r4Kvp0wevVS7JaLpc3iMUF3l2PxRxuhB/4WhvAD7wcfzer86R11ry2PB3vexauOn+bUWy/04OBbmhrcpJby17T1CrtiqEtbZAYVGcFEdhDx3W2khktgV/8Gqywv06dbDpo6eHyrSdzmFyni/wBr+3Xy"
 }
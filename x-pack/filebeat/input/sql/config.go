@@ -0,0 +1,135 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// numericTracking tracks rows with a numeric column, such as an
+	// auto-incremented ID.
+	numericTracking = "numeric"
+	// timestampTracking tracks rows with a date and time column.
+	timestampTracking = "timestamp"
+)
+
+type config struct {
+	// ID identifies the cursor of the input. When it is not set, the
+	// cursor is identified by the driver, DSN, query and tracking column.
+	ID string `config:"id"`
+
+	// Driver is the name of the database driver: postgresql, mysql, mssql
+	// or oracle.
+	Driver string `config:"driver" validate:"required"`
+
+	// DSN is the data source name used to connect to the database, in the
+	// format of the driver.
+	DSN string `config:"dsn" validate:"required"`
+
+	// Query is the query run at every interval. It can refer to the last
+	// value of the tracking column with :sql_last_value, and to the page
+	// size with :page_size.
+	Query string `config:"query" validate:"required"`
+
+	// TrackingColumn is the column whose value of the last published row
+	// is stored in the cursor. When it is empty, nothing is stored and the
+	// whole result of the query is published at every interval.
+	TrackingColumn string `config:"tracking_column"`
+
+	// TrackingColumnType is the type of the tracking column, numeric or
+	// timestamp.
+	TrackingColumnType string `config:"tracking_column_type"`
+
+	// InitialValue is the value of :sql_last_value before the first row
+	// is published.
+	InitialValue string `config:"initial_value"`
+
+	// PageSize is the number of rows requested at once. When a page is
+	// full, the next one is requested right away.
+	PageSize int `config:"page_size" validate:"min=0"`
+
+	// Interval is the time between two runs of the query.
+	Interval time.Duration `config:"interval" validate:"positive"`
+
+	// QueryTimeout limits the time taken by a single run of the query.
+	QueryTimeout time.Duration `config:"query_timeout" validate:"positive"`
+
+	// Target is the field the columns of a row are stored under.
+	Target string `config:"target"`
+
+	// TimestampColumn is the column used as timestamp of the events.
+	TimestampColumn string `config:"timestamp_column"`
+
+	// LowercaseColumnNames lowercases the names of the columns.
+	LowercaseColumnNames bool `config:"lowercase_column_names"`
+}
+
+func defaultConfig() config {
+	return config{
+		TrackingColumnType:   numericTracking,
+		Interval:             time.Minute,
+		QueryTimeout:         time.Minute,
+		Target:               "sql.row",
+		LowercaseColumnNames: true,
+	}
+}
+
+func (c *config) Validate() error {
+	if _, ok := driverNames[c.Driver]; !ok {
+		return fmt.Errorf("unsupported driver %q", c.Driver)
+	}
+	switch c.TrackingColumnType {
+	case numericTracking, timestampTracking:
+	default:
+		return fmt.Errorf("invalid tracking_column_type %q, it must be numeric or timestamp", c.TrackingColumnType)
+	}
+	hasLastValue := strings.Contains(c.Query, lastValueParam)
+	if c.TrackingColumn == "" {
+		if hasLastValue {
+			return fmt.Errorf("query refers to %s but tracking_column is not set", lastValueParam)
+		}
+		if c.PageSize > 0 {
+			return errors.New("page_size requires tracking_column to be set")
+		}
+	} else {
+		if !hasLastValue {
+			return fmt.Errorf("query must refer to %s when tracking_column is set", lastValueParam)
+		}
+		if _, err := parseTrackingValue(c.TrackingColumnType, c.initialValue()); err != nil {
+			return fmt.Errorf("invalid initial_value: %w", err)
+		}
+	}
+	if c.PageSize > 0 && !strings.Contains(c.Query, pageSizeParam) {
+		return fmt.Errorf("query must refer to %s when page_size is set", pageSizeParam)
+	}
+	if c.PageSize == 0 && strings.Contains(c.Query, pageSizeParam) {
+		return fmt.Errorf("query refers to %s but page_size is not set", pageSizeParam)
+	}
+	return nil
+}
+
+// initialValue returns the value of :sql_last_value before the first row is
+// published.
+func (c *config) initialValue() string {
+	if c.InitialValue != "" {
+		return c.InitialValue
+	}
+	if c.TrackingColumnType == timestampTracking {
+		return "1970-01-01T00:00:00Z"
+	}
+	return "0"
+}
+
+// column returns the name of a column as stored in the events.
+func (c *config) column(name string) string {
+	if c.LowercaseColumnNames {
+		return strings.ToLower(name)
+	}
+	return name
+}
@@ -0,0 +1,93 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "snapshot",
+			config: map[string]interface{}{"driver": "mysql", "dsn": "user:pass@tcp(localhost:3306)/db", "query": "SELECT * FROM t"},
+		},
+		{
+			name: "tracking with pages",
+			config: map[string]interface{}{
+				"driver": "postgresql", "dsn": "postgres://localhost/db", "tracking_column": "id", "page_size": 100,
+				"query": "SELECT * FROM t WHERE id > :sql_last_value ORDER BY id LIMIT :page_size",
+			},
+		},
+		{
+			name:    "unknown driver",
+			config:  map[string]interface{}{"driver": "db2", "dsn": "dsn", "query": "SELECT 1"},
+			wantErr: `unsupported driver "db2"`,
+		},
+		{
+			name:    "tracking without last value",
+			config:  map[string]interface{}{"driver": "mysql", "dsn": "dsn", "query": "SELECT 1", "tracking_column": "id"},
+			wantErr: "query must refer to :sql_last_value when tracking_column is set",
+		},
+		{
+			name:    "last value without tracking",
+			config:  map[string]interface{}{"driver": "mysql", "dsn": "dsn", "query": "SELECT * FROM t WHERE id > :sql_last_value"},
+			wantErr: "tracking_column is not set",
+		},
+		{
+			name:    "pages without tracking",
+			config:  map[string]interface{}{"driver": "mysql", "dsn": "dsn", "query": "SELECT * FROM t LIMIT :page_size", "page_size": 10},
+			wantErr: "page_size requires tracking_column to be set",
+		},
+		{
+			name: "pages without page size parameter",
+			config: map[string]interface{}{
+				"driver": "mysql", "dsn": "dsn", "tracking_column": "id", "page_size": 10,
+				"query": "SELECT * FROM t WHERE id > :sql_last_value",
+			},
+			wantErr: "query must refer to :page_size when page_size is set",
+		},
+		{
+			name: "invalid initial timestamp",
+			config: map[string]interface{}{
+				"driver": "oracle", "dsn": "dsn", "tracking_column": "created", "tracking_column_type": "timestamp",
+				"initial_value": "yesterday", "query": "SELECT * FROM t WHERE created > :sql_last_value",
+			},
+			wantErr: "invalid initial_value",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := defaultConfig()
+			err := conf.MustNewConfigFrom(test.config).Unpack(&c)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSourceName(t *testing.T) {
+	c := config{Driver: "mysql", DSN: "user:pass@tcp(db:3306)/audit", Query: "SELECT 1"}
+	name := sourceName(c)
+	assert.Len(t, name, 32)
+	assert.NotContains(t, name, "pass")
+
+	c.DSN = "user:pass@tcp(other:3306)/audit"
+	assert.NotEqual(t, name, sourceName(c))
+
+	c.ID = "audit-table"
+	assert.Equal(t, "audit-table", sourceName(c))
+}
@@ -0,0 +1,148 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const pluginName = "sql"
+
+// Plugin creates the sql input plugin.
+func Plugin(log *logp.Logger, store cursor.StateStore) v2.Plugin {
+	return v2.Plugin{
+		Name:      pluginName,
+		Stability: feature.Experimental,
+		Info:      "SQL",
+		Doc:       "Poll rows from SQL databases",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       pluginName,
+			Configure:  configure,
+		},
+	}
+}
+
+// table is the source of the rows returned by the query of an input.
+type table struct {
+	name string
+}
+
+func (t *table) Name() string {
+	return t.name
+}
+
+type sqlInput struct {
+	config config
+}
+
+func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+	return []cursor.Source{&table{name: sourceName(config)}}, &sqlInput{config: config}, nil
+}
+
+// sourceName returns the identifier of the cursor of the input. The DSN is
+// hashed so that the credentials it may hold are not written to the
+// registry.
+func sourceName(c config) string {
+	if c.ID != "" {
+		return c.ID
+	}
+	h := sha256.New()
+	for _, s := range []string{c.Driver, c.DSN, c.Query, c.TrackingColumn} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+func (inp *sqlInput) Name() string { return pluginName }
+
+// FIPSCapable returns nil, as the input relies on the TLS settings of the
+// database drivers.
+func (inp *sqlInput) FIPSCapable() error { return nil }
+
+func (inp *sqlInput) Test(src cursor.Source, ctx v2.TestContext) error {
+	db, err := inp.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	stdCtx, cancel := context.WithTimeout(ctxtool.FromCanceller(ctx.Cancelation), inp.config.QueryTimeout)
+	defer cancel()
+	if err := db.PingContext(stdCtx); err != nil {
+		return fmt.Errorf("unable to connect to the database: %w", err)
+	}
+	return nil
+}
+
+func (inp *sqlInput) Run(ctx v2.Context, src cursor.Source, crsr cursor.Cursor, publisher cursor.Publisher) error {
+	stdCtx := ctxtool.FromCanceller(ctx.Cancelation)
+	log := ctx.Logger.With("driver", inp.config.Driver)
+
+	db, err := inp.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	st := state{LastValue: inp.config.initialValue()}
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&st); err != nil {
+			return fmt.Errorf("unable to read cursor: %w", err)
+		}
+	}
+	p := newPoller(inp.config, db, log)
+
+	poll := func() error {
+		if err := p.poll(stdCtx, &st, publisher.Publish); err != nil {
+			if stdCtx.Err() != nil {
+				return err
+			}
+			log.Errorw("failed to poll rows, retrying at the next interval", "error", err)
+		}
+		return nil
+	}
+	if err = poll(); err == nil {
+		err = timed.Periodic(stdCtx, inp.config.Interval, poll)
+	}
+	if stdCtx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// open returns a handle to the database. Connections are opened lazily.
+func (inp *sqlInput) open() (*sql.DB, error) {
+	db, err := sql.Open(driverNames[inp.config.Driver].name, inp.config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the database: %w", err)
+	}
+	// Queries are run one at a time, there is no need for more.
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// publishFunc publishes an event, with the cursor update to store once the
+// event is acknowledged.
+type publishFunc func(event beat.Event, cursor interface{}) error
@@ -0,0 +1,177 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// poller runs the query of an input and publishes the rows it returns.
+type poller struct {
+	config config
+	db     *sql.DB
+	query  boundQuery
+	logger *logp.Logger
+}
+
+func newPoller(c config, db *sql.DB, log *logp.Logger) *poller {
+	return &poller{
+		config: c,
+		db:     db,
+		query:  bindQuery(c.Query, driverNames[c.Driver].placeholder),
+		logger: log,
+	}
+}
+
+// poll runs the query and publishes its rows. When page_size is set, pages
+// are requested until one is not full.
+func (p *poller) poll(ctx context.Context, st *state, publish publishFunc) error {
+	for {
+		last := st.LastValue
+		n, err := p.pollPage(ctx, st, publish)
+		if err != nil {
+			return err
+		}
+		p.logger.Debugw("query run", "rows", n, "last_value", st.LastValue)
+		if p.config.PageSize == 0 || n < p.config.PageSize {
+			return nil
+		}
+		if st.LastValue == last {
+			// The next page would return the same rows.
+			return fmt.Errorf("page of %d rows did not move the tracking column past %s, page_size must be increased", n, last)
+		}
+	}
+}
+
+// pollPage runs the query once and publishes its rows, each with the value
+// of its tracking column as cursor update. It returns the number of rows
+// published.
+func (p *poller) pollPage(ctx context.Context, st *state, publish publishFunc) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.config.QueryTimeout)
+	defer cancel()
+
+	var lastValue interface{}
+	if p.config.TrackingColumn != "" {
+		var err error
+		lastValue, err = parseTrackingValue(p.config.TrackingColumnType, st.LastValue)
+		if err != nil {
+			return 0, fmt.Errorf("invalid tracking value in cursor: %w", err)
+		}
+	}
+	rows, err := p.db.QueryContext(ctx, p.query.query, p.query.args(lastValue, p.config.PageSize)...)
+	if err != nil {
+		return 0, fmt.Errorf("running query: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, fmt.Errorf("reading columns: %w", err)
+	}
+	tracking, timestamp := -1, -1
+	for i, c := range columns {
+		if p.config.TrackingColumn != "" && strings.EqualFold(c.Name(), p.config.TrackingColumn) {
+			tracking = i
+		}
+		if p.config.TimestampColumn != "" && strings.EqualFold(c.Name(), p.config.TimestampColumn) {
+			timestamp = i
+		}
+	}
+	if p.config.TrackingColumn != "" && tracking < 0 {
+		return 0, fmt.Errorf("tracking column %q is not returned by the query", p.config.TrackingColumn)
+	}
+
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	var n int
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return n, fmt.Errorf("reading row: %w", err)
+		}
+		var update interface{}
+		if tracking >= 0 {
+			v, err := formatTrackingValue(p.config.TrackingColumnType, values[tracking])
+			if err != nil {
+				return n, fmt.Errorf("reading tracking column: %w", err)
+			}
+			st.LastValue = v
+			update = *st
+		}
+		if err := publish(p.event(columns, values, timestamp), update); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// event returns the event of a row. NULL values are left out.
+func (p *poller) event(columns []*sql.ColumnType, values []interface{}, timestamp int) beat.Event {
+	row := make(mapstr.M, len(columns))
+	for i, c := range columns {
+		if v := columnValue(c.DatabaseTypeName(), values[i]); v != nil {
+			row[p.config.column(c.Name())] = v
+		}
+	}
+	event := beat.Event{Timestamp: time.Now(), Fields: mapstr.M{}}
+	if timestamp >= 0 {
+		if ts, ok := toTime(values[timestamp]); ok {
+			event.Timestamp = ts
+		} else if values[timestamp] != nil {
+			p.logger.Debugw("timestamp column is not a timestamp, using the current time", "value", values[timestamp])
+		}
+	}
+	_, _ = event.Fields.Put(p.config.Target, row)
+	return event
+}
+
+// columnValue converts the value of a column read by a driver.
+func columnValue(typ string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case []byte:
+		s := string(v)
+		switch strings.ToUpper(typ) {
+		case "DECIMAL", "NUMERIC", "NUMBER", "NEWDECIMAL":
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return i
+			}
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f
+			}
+		}
+		return s
+	case nil, bool, int64, float64, string, time.Time:
+		return v
+	case fmt.Stringer:
+		return columnValue(typ, []byte(v.String()))
+	default:
+		return v
+	}
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case []byte:
+		return toTime(string(v))
+	case string:
+		t, err := parseTimestamp(v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,168 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// auditTable is a database/sql connector serving the rows of an audit table
+// whose ID is greater than the first argument, at most the second argument
+// of them.
+type auditTable struct {
+	rows    [][]driver.Value
+	queries []string
+}
+
+var auditColumns = []string{"ID", "Created", "Action", "Note"}
+
+func (a *auditTable) Connect(context.Context) (driver.Conn, error) { return a, nil }
+func (a *auditTable) Driver() driver.Driver                        { return nil }
+func (a *auditTable) Prepare(query string) (driver.Stmt, error)    { return &auditStmt{a, query}, nil }
+func (a *auditTable) Close() error                                 { return nil }
+func (a *auditTable) Begin() (driver.Tx, error)                    { return nil, errors.New("not supported") }
+
+type auditStmt struct {
+	table *auditTable
+	query string
+}
+
+func (s *auditStmt) Close() error  { return nil }
+func (s *auditStmt) NumInput() int { return -1 }
+func (s *auditStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *auditStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.table.queries = append(s.table.queries, s.query)
+	last, limit := args[0].(int64), int64(len(s.table.rows))
+	if len(args) > 1 {
+		limit = args[1].(int64)
+	}
+	var rows [][]driver.Value
+	for _, r := range s.table.rows {
+		if r[0].(int64) > last && int64(len(rows)) < limit {
+			rows = append(rows, r)
+		}
+	}
+	return &auditRows{rows: rows}, nil
+}
+
+type auditRows struct {
+	rows [][]driver.Value
+}
+
+func (r *auditRows) Columns() []string { return auditColumns }
+func (r *auditRows) Close() error      { return nil }
+func (r *auditRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func (r *auditRows) ColumnTypeDatabaseTypeName(i int) string {
+	return []string{"INT", "TIMESTAMP", "VARCHAR", "NUMERIC"}[i]
+}
+
+type published struct {
+	events  []beat.Event
+	cursors []interface{}
+}
+
+func (p *published) publish(event beat.Event, cursor interface{}) error {
+	p.events = append(p.events, event)
+	p.cursors = append(p.cursors, cursor)
+	return nil
+}
+
+func newTestPoller(t *testing.T, c config, rows int) (*poller, *auditTable) {
+	table := &auditTable{}
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= rows; i++ {
+		var note driver.Value
+		if i%2 == 0 {
+			note = []byte("1.5")
+		}
+		table.rows = append(table.rows, []driver.Value{int64(i), start.Add(time.Duration(i) * time.Minute), []byte("login"), note})
+	}
+	db := sql.OpenDB(table)
+	t.Cleanup(func() { db.Close() })
+
+	cfg := defaultConfig()
+	cfg.Driver = "postgresql"
+	cfg.TrackingColumn = "id"
+	cfg.TimestampColumn = "created"
+	cfg.Query = c.Query
+	cfg.PageSize = c.PageSize
+	return newPoller(cfg, db, logp.NewLogger(pluginName)), table
+}
+
+func TestPoll(t *testing.T) {
+	p, table := newTestPoller(t, config{Query: "SELECT * FROM audit WHERE id > :sql_last_value ORDER BY id"}, 3)
+
+	var pub published
+	st := state{LastValue: "1"}
+	require.NoError(t, p.poll(context.Background(), &st, pub.publish))
+
+	assert.Equal(t, []string{"SELECT * FROM audit WHERE id > $1 ORDER BY id"}, table.queries)
+	require.Len(t, pub.events, 2)
+	assert.Equal(t, mapstr.M{"sql": mapstr.M{"row": mapstr.M{
+		"id":      int64(2),
+		"created": time.Date(2024, 5, 6, 0, 2, 0, 0, time.UTC),
+		"action":  "login",
+		"note":    1.5,
+	}}}, pub.events[0].Fields)
+	assert.Equal(t, time.Date(2024, 5, 6, 0, 2, 0, 0, time.UTC), pub.events[0].Timestamp)
+	row, _ := pub.events[1].Fields.GetValue("sql.row")
+	assert.NotContains(t, row, "note", "NULL values are left out")
+	assert.Equal(t, []interface{}{state{LastValue: "2"}, state{LastValue: "3"}}, pub.cursors)
+	assert.Equal(t, "3", st.LastValue)
+
+	// Nothing new.
+	pub = published{}
+	require.NoError(t, p.poll(context.Background(), &st, pub.publish))
+	assert.Empty(t, pub.events)
+}
+
+func TestPollPages(t *testing.T) {
+	p, table := newTestPoller(t, config{
+		Query:    "SELECT * FROM audit WHERE id > :sql_last_value ORDER BY id LIMIT :page_size",
+		PageSize: 2,
+	}, 5)
+
+	var pub published
+	st := state{LastValue: "0"}
+	require.NoError(t, p.poll(context.Background(), &st, pub.publish))
+	assert.Len(t, pub.events, 5)
+	assert.Len(t, table.queries, 3, "two full pages and a last one")
+	assert.Equal(t, "5", st.LastValue)
+}
+
+func TestPollMissingTrackingColumn(t *testing.T) {
+	p, _ := newTestPoller(t, config{Query: "SELECT * FROM audit WHERE id > :sql_last_value"}, 1)
+	p.config.TrackingColumn = "seq"
+
+	var pub published
+	st := state{LastValue: "0"}
+	err := p.poll(context.Background(), &st, pub.publish)
+	assert.ErrorContains(t, err, `tracking column "seq" is not returned by the query`)
+	assert.Empty(t, pub.events)
+}
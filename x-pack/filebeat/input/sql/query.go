@@ -0,0 +1,211 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	lastValueParam = ":sql_last_value"
+	pageSizeParam  = ":page_size"
+)
+
+// placeholderStyle is the syntax of the bind parameters of a driver.
+type placeholderStyle int
+
+const (
+	questionMark placeholderStyle = iota // ?
+	dollar                               // $1
+	atP                                  // @p1
+	colon                                // :1
+)
+
+// driverInfo is the registered name and the bind parameter syntax of a
+// driver.
+type driverInfo struct {
+	name        string
+	placeholder placeholderStyle
+}
+
+// driverNames maps the driver names accepted in the configuration to the
+// registered database/sql drivers.
+var driverNames = map[string]driverInfo{
+	"postgresql":  {name: "postgres", placeholder: dollar},
+	"postgres":    {name: "postgres", placeholder: dollar},
+	"cockroachdb": {name: "postgres", placeholder: dollar},
+	"mysql":       {name: "mysql", placeholder: questionMark},
+	"mssql":       {name: "sqlserver", placeholder: atP},
+	"sqlserver":   {name: "sqlserver", placeholder: atP},
+	"oracle":      {name: "godror", placeholder: colon},
+}
+
+// boundQuery is a query whose named parameters were replaced with the bind
+// parameters of the driver.
+type boundQuery struct {
+	query string
+	// params lists the named parameters in the order of their bind
+	// parameters.
+	params []string
+}
+
+// bindQuery replaces the :sql_last_value and :page_size parameters of the
+// query with bind parameters of the given style. Parameters inside quoted
+// strings and identifiers are left untouched.
+func bindQuery(query string, style placeholderStyle) boundQuery {
+	var (
+		b      strings.Builder
+		params []string
+		quote  byte
+	)
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			b.WriteByte(c)
+			continue
+		}
+		if c == '\'' || c == '"' {
+			quote = c
+			b.WriteByte(c)
+			continue
+		}
+		param := matchParam(query[i:])
+		if param == "" {
+			b.WriteByte(c)
+			continue
+		}
+		params = append(params, param)
+		switch style {
+		case questionMark:
+			b.WriteByte('?')
+		case dollar:
+			fmt.Fprintf(&b, "$%d", len(params))
+		case atP:
+			fmt.Fprintf(&b, "@p%d", len(params))
+		case colon:
+			fmt.Fprintf(&b, ":%d", len(params))
+		}
+		i += len(param) - 1
+	}
+	return boundQuery{query: b.String(), params: params}
+}
+
+// matchParam returns the named parameter at the start of s, or an empty
+// string.
+func matchParam(s string) string {
+	for _, p := range []string{lastValueParam, pageSizeParam} {
+		if !strings.HasPrefix(s, p) {
+			continue
+		}
+		if len(s) > len(p) && isIdentChar(s[len(p)]) {
+			return ""
+		}
+		return p
+	}
+	return ""
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// args returns the values of the bind parameters.
+func (q boundQuery) args(lastValue interface{}, pageSize int) []interface{} {
+	args := make([]interface{}, len(q.params))
+	for i, p := range q.params {
+		switch p {
+		case lastValueParam:
+			args[i] = lastValue
+		case pageSizeParam:
+			args[i] = pageSize
+		}
+	}
+	return args
+}
+
+// timestampLayouts are the layouts used to parse timestamps read as text.
+// Timestamps without time zone are interpreted as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse %q as a timestamp", s)
+}
+
+// parseTrackingValue returns the value bound to :sql_last_value for a value
+// stored in the cursor.
+func parseTrackingValue(typ, s string) (interface{}, error) {
+	if typ == timestampTracking {
+		return parseTimestamp(s)
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %q as a number", s)
+	}
+	return f, nil
+}
+
+// formatTrackingValue returns the value stored in the cursor for a value of
+// the tracking column.
+func formatTrackingValue(typ string, v interface{}) (string, error) {
+	if v == nil {
+		return "", errors.New("tracking column is NULL")
+	}
+	if typ == timestampTracking {
+		var t time.Time
+		switch v := v.(type) {
+		case time.Time:
+			t = v
+		case []byte:
+			return formatTrackingValue(typ, string(v))
+		case string:
+			var err error
+			if t, err = parseTimestamp(v); err != nil {
+				return "", err
+			}
+		default:
+			return "", fmt.Errorf("tracking column of type %T is not a timestamp", v)
+		}
+		return t.UTC().Format(time.RFC3339Nano), nil
+	}
+
+	var s string
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []byte:
+		s = string(v)
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if _, err := parseTrackingValue(typ, s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
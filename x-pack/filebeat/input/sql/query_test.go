@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindQuery(t *testing.T) {
+	const query = "SELECT * FROM audit WHERE id > :sql_last_value AND note <> ':sql_last_value' ORDER BY id LIMIT :page_size"
+	tests := []struct {
+		style placeholderStyle
+		want  string
+	}{
+		{style: questionMark, want: "SELECT * FROM audit WHERE id > ? AND note <> ':sql_last_value' ORDER BY id LIMIT ?"},
+		{style: dollar, want: "SELECT * FROM audit WHERE id > $1 AND note <> ':sql_last_value' ORDER BY id LIMIT $2"},
+		{style: atP, want: "SELECT * FROM audit WHERE id > @p1 AND note <> ':sql_last_value' ORDER BY id LIMIT @p2"},
+		{style: colon, want: "SELECT * FROM audit WHERE id > :1 AND note <> ':sql_last_value' ORDER BY id LIMIT :2"},
+	}
+	for _, test := range tests {
+		q := bindQuery(query, test.style)
+		assert.Equal(t, test.want, q.query)
+		assert.Equal(t, []string{lastValueParam, pageSizeParam}, q.params)
+		assert.Equal(t, []interface{}{int64(5), 10}, q.args(int64(5), 10))
+	}
+
+	q := bindQuery("SELECT :sql_last_values, :page_size", dollar)
+	assert.Equal(t, "SELECT :sql_last_values, $1", q.query)
+	assert.Equal(t, []string{pageSizeParam}, q.params)
+}
+
+func TestTrackingValue(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123000000, time.UTC)
+	tests := []struct {
+		typ     string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{typ: numericTracking, value: int64(42), want: "42"},
+		{typ: numericTracking, value: 4.5, want: "4.5"},
+		{typ: numericTracking, value: []byte("1234567890123"), want: "1234567890123"},
+		{typ: numericTracking, value: []byte("abc"), wantErr: true},
+		{typ: numericTracking, value: nil, wantErr: true},
+		{typ: timestampTracking, value: ts.In(time.FixedZone("CEST", 7200)), want: "2024-05-06T07:08:09.123Z"},
+		{typ: timestampTracking, value: []byte("2024-05-06 07:08:09.123"), want: "2024-05-06T07:08:09.123Z"},
+		{typ: timestampTracking, value: int64(1), wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := formatTrackingValue(test.typ, test.value)
+		if test.wantErr {
+			assert.Error(t, err, "value %v", test.value)
+			continue
+		}
+		require.NoError(t, err, "value %v", test.value)
+		assert.Equal(t, test.want, got)
+	}
+
+	v, err := parseTrackingValue(numericTracking, "42")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+	v, err = parseTrackingValue(numericTracking, "4.5")
+	require.NoError(t, err)
+	assert.Equal(t, 4.5, v)
+	v, err = parseTrackingValue(timestampTracking, "2024-05-06T07:08:09.123Z")
+	require.NoError(t, err)
+	assert.Equal(t, ts, v)
+}
@@ -0,0 +1,12 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+// state is the cursor of an input.
+type state struct {
+	// LastValue is the value of the tracking column of the last row
+	// published, formatted as text.
+	LastValue string `json:"last_value"`
+}
@@ -224,6 +224,9 @@ func (p *Provider) publish(event bus.Event) {
 			event["config"] = config
 		}
 	}
+	if matches := p.templates.Matches(event); len(matches) > 0 {
+		event["templates"] = matches
+	}
 
 	// Call all appenders to append any extra configuration
 	p.appenders.Append(event)
@@ -0,0 +1,146 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package awsssm registers the aws_ssm config provider which resolves
+// references to AWS Systems Manager Parameter Store parameters.
+package awsssm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgprovider"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+const (
+	name        = "aws_ssm"
+	serviceName = "ssm"
+)
+
+func init() {
+	cfgprovider.Register(name, New)
+}
+
+type ssmConfig struct {
+	AWSConfig      awscommon.ConfigAWS `config:",inline"`
+	WithDecryption bool                `config:"with_decryption"`
+}
+
+// provider fetches parameters with the GetParameter API. Requests are signed
+// directly so the provider does not need the full SSM client.
+type provider struct {
+	awsConfig      awssdk.Config
+	endpoint       string
+	withDecryption bool
+	signer         *v4.Signer
+}
+
+// New creates the aws_ssm config provider.
+func New(cfg *config.C) (cfgprovider.Provider, error) {
+	c := ssmConfig{WithDecryption: true}
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	awsConfig, err := awscommon.InitializeAWSConfig(c.AWSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initializing AWS config: %w", err)
+	}
+
+	endpoint := c.AWSConfig.Endpoint
+	if endpoint == "" {
+		host := serviceName
+		if c.AWSConfig.FIPSEnabled {
+			host += "-fips"
+		}
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", host, awsConfig.Region)
+		if strings.HasPrefix(awsConfig.Region, "cn-") {
+			endpoint += ".cn"
+		}
+	}
+
+	return &provider{
+		awsConfig:      awsConfig,
+		endpoint:       strings.TrimSuffix(endpoint, "/") + "/",
+		withDecryption: c.WithDecryption,
+		signer:         v4.NewSigner(),
+	}, nil
+}
+
+// Fetch returns the value of the parameter with the given name. Versions and
+// labels can be selected with the name:version and name:label syntax.
+func (p *provider) Fetch(ctx context.Context, ref string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"Name":           ref,
+		"WithDecryption": p.withDecryption,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AmazonSSM.GetParameter")
+
+	if p.awsConfig.Credentials == nil {
+		return "", fmt.Errorf("no AWS credentials available")
+	}
+	creds, err := p.awsConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("retrieving AWS credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	err = p.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), serviceName, p.awsConfig.Region, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("signing request: %w", err)
+	}
+
+	resp, err := p.awsConfig.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		if apiErr.Type == "" {
+			return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		errType := apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:]
+		return "", fmt.Errorf("%s: %s", errType, apiErr.Message)
+	}
+
+	var result struct {
+		Parameter struct {
+			Value string `json:"Value"`
+		} `json:"Parameter"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("error decoding GetParameter response: %w", err)
+	}
+	return result.Parameter.Value, nil
+}
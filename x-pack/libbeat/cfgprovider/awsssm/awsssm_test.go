@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsssm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AmazonSSM.GetParameter", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/ssm/aws4_request")
+
+		var req struct {
+			Name           string
+			WithDecryption bool
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.WithDecryption)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch req.Name {
+		case "/prod/db/password":
+			_, _ = w.Write([]byte(`{"Parameter":{"Name":"/prod/db/password","Type":"SecureString","Value":"s3cret","Version":3}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.ssm#ParameterNotFound","message":"Parameter not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	p, err := New(config.MustNewConfigFrom(map[string]interface{}{
+		"access_key_id":     "AKID",
+		"secret_access_key": "secret",
+		"default_region":    "eu-west-1",
+		"endpoint":          srv.URL,
+	}))
+	require.NoError(t, err)
+
+	got, err := p.Fetch(context.Background(), "/prod/db/password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", got)
+
+	_, err = p.Fetch(context.Background(), "/prod/db/missing")
+	assert.EqualError(t, err, "ParameterNotFound: Parameter not found")
+}
+
+func TestDefaultEndpoint(t *testing.T) {
+	for region, want := range map[string]string{
+		"us-east-1":  "https://ssm.us-east-1.amazonaws.com/",
+		"cn-north-1": "https://ssm.cn-north-1.amazonaws.com.cn/",
+	} {
+		p, err := New(config.MustNewConfigFrom(map[string]interface{}{
+			"access_key_id":     "AKID",
+			"secret_access_key": "secret",
+			"default_region":    region,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, want, p.(*provider).endpoint)
+	}
+}
@@ -11,9 +11,13 @@ import (
 	// register processors
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/processors/add_cloudfoundry_metadata"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/processors/add_nomad_metadata"
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd"
 
 	// register autodiscover providers
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/ec2"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/elb"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/nomad"
+
+	// register config providers
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/cfgprovider/awsssm"
 )
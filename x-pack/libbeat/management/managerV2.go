@@ -21,6 +21,7 @@ import (
 	gproto "google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
 
+	"github.com/elastic/beats/v7/libbeat/audit"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
@@ -32,6 +33,7 @@ import (
 	"github.com/elastic/elastic-agent-client/v7/pkg/proto"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 // diagnosticHandler is a wrapper type that's a bit of a hack, the compiler won't let us send the raw unit struct,
@@ -725,6 +727,12 @@ func (cm *BeatV2Manager) reloadOutput(unit *agentUnit) (bool, error) {
 	if unit == nil {
 		// output is being stopped
 		err := output.Reload(nil)
+		audit.Record(audit.Event{
+			Action:    audit.ActionOutputStop,
+			Source:    audit.SourceManagement,
+			Component: "output",
+			Err:       err,
+		})
 		if err != nil {
 			return false, fmt.Errorf("failed to reload output: %w", err)
 		}
@@ -766,6 +774,18 @@ func (cm *BeatV2Manager) reloadOutput(unit *agentUnit) (bool, error) {
 	cm.lastBeatOutputCfg = reloadConfig
 
 	err = output.Reload(reloadConfig)
+	audit.Record(audit.Event{
+		Action:    audit.ActionOutputReload,
+		Source:    audit.SourceManagement,
+		Component: "output",
+		Details: mapstr.M{
+			"id":       expected.Config.GetId(),
+			"type":     expected.Config.GetType(),
+			"revision": expected.Config.GetRevision(),
+			"unit_id":  unit.ID(),
+		},
+		Err: err,
+	})
 	if err != nil {
 		return false, fmt.Errorf("failed to reload output: %w", err)
 	}
@@ -14,11 +14,11 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/processors"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/processdb"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/procfs"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/provider"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/provider/ebpf_provider"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/provider/procfs_provider"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/processdb"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/procfs"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/provider"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/provider/ebpf_provider"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/provider/procfs_provider"
 	cfg "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -29,8 +29,7 @@ const (
 	logName       = "processor." + processorName
 )
 
-// InitializeModule initializes this module.
-func InitializeModule() {
+func init() {
 	processors.RegisterPlugin(processorName, New)
 }
 
@@ -154,12 +153,14 @@ func (p *addSessionMetadata) enrich(ev *beat.Event) (*beat.Event, error) {
 
 	processMap := fullProcess.ToMap()
 
-	if b, err := ev.Fields.HasKey("process"); !b || err != nil {
-		return nil, fmt.Errorf("no process field in event")
-	}
-	m, ok := tryToMapStr(ev.Fields["process"])
-	if !ok {
-		return nil, fmt.Errorf("process field type not supported")
+	// Events that are not process events, such as logs, may only carry the
+	// PID, in which case the process field is created.
+	m := mapstr.M{}
+	if v, found := ev.Fields["process"]; found {
+		var ok bool
+		if m, ok = tryToMapStr(v); !ok {
+			return nil, fmt.Errorf("process field type not supported")
+		}
 	}
 
 	result := ev.Clone()
@@ -13,9 +13,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/processdb"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/procfs"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/types"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/processdb"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/procfs"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/types"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -261,6 +261,70 @@ var (
 			},
 			expect_error: false,
 		},
+		{
+			testName: "enrich event without process field",
+			config: config{
+				PIDField: "log.syslog.procid",
+			},
+			mockProcesses: []types.ProcessExecEvent{
+				{
+					PIDs: types.PIDInfo{
+						Tid:  uint32(100),
+						Tgid: uint32(100),
+						Ppid: uint32(50),
+						Pgid: uint32(100),
+						Sid:  uint32(40),
+					},
+					CWD:      "/",
+					Filename: "/usr/sbin/sshd",
+				},
+				{
+					PIDs: types.PIDInfo{
+						Tid:  uint32(50),
+						Tgid: uint32(50),
+						Ppid: uint32(40),
+						Sid:  uint32(40),
+					},
+				},
+				{
+					PIDs: types.PIDInfo{
+						Tid:  uint32(40),
+						Tgid: uint32(40),
+						Ppid: uint32(1),
+						Sid:  uint32(1),
+					},
+				},
+			},
+			input: beat.Event{
+				Fields: mapstr.M{
+					"log": mapstr.M{
+						"syslog": mapstr.M{
+							"procid": "100",
+						},
+					},
+				},
+			},
+			expected: beat.Event{
+				Fields: mapstr.M{
+					"log": mapstr.M{
+						"syslog": mapstr.M{
+							"procid": "100",
+						},
+					},
+					"process": mapstr.M{
+						"executable":        "/usr/sbin/sshd",
+						"working_directory": "/",
+						"parent": mapstr.M{
+							"pid": uint32(50),
+						},
+						"session_leader": mapstr.M{
+							"pid": uint32(40),
+						},
+					},
+				},
+			},
+			expect_error: false,
+		},
 	}
 
 	filterTests = []struct {
@@ -21,9 +21,9 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common/capabilities"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/procfs"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/timeutils"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/types"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/procfs"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/timeutils"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/types"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -12,8 +12,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/procfs"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/types"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/procfs"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/types"
 )
 
 const (
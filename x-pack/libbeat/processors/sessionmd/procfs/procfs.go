@@ -14,8 +14,8 @@ import (
 	"github.com/prometheus/procfs"
 	"golang.org/x/sys/unix"
 
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/timeutils"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/types"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/timeutils"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/types"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -13,9 +13,9 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/ebpf"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/processdb"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/provider"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/types"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/processdb"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/provider"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/types"
 	"github.com/elastic/ebpfevents"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -11,10 +11,10 @@ import (
 	"fmt"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/processdb"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/procfs"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/provider"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/types"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/processdb"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/procfs"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/provider"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/types"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -44,21 +44,17 @@ func NewProvider(ctx context.Context, logger *logp.Logger, db *processdb.DB, rea
 func (s prvdr) SyncDB(ev *beat.Event, pid uint32) error {
 	syscall, err := ev.GetValue(syscallField)
 	if err != nil {
-		return fmt.Errorf("event not supported, no syscall data")
+		// Events without syscall data, such as logs, don't describe process
+		// changes, so the process is polled from procfs if it isn't known yet.
+		return s.syncFromProcfs(pid)
 	}
 
 	switch syscall {
 	case "execveat", "execve":
-		pe := types.ProcessExecEvent{}
+		var pe types.ProcessExecEvent
 		proc_info, err := s.reader.GetProcess(pid)
 		if err == nil {
-			pe.PIDs = proc_info.PIDs
-			pe.Creds = proc_info.Creds
-			pe.CTTY = proc_info.CTTY
-			pe.CWD = proc_info.Cwd
-			pe.Argv = proc_info.Argv
-			pe.Env = proc_info.Env
-			pe.Filename = proc_info.Filename
+			pe = execEventFromProcessInfo(proc_info)
 		} else {
 			s.logger.Warnf("couldn't get process info from proc for pid %v: %w", pid, err)
 			// If process info couldn't be taken from procfs, populate with as much info as
@@ -122,3 +118,42 @@ func (s prvdr) SyncDB(ev *beat.Event, pid uint32) error {
 	}
 	return nil
 }
+
+// syncFromProcfs inserts the process and any of its ancestors that are not in
+// the DB yet, reading them from procfs. Ancestors are inserted first so the
+// process can be linked to them.
+func (s prvdr) syncFromProcfs(pid uint32) error {
+	var missing []procfs.ProcessInfo
+	for p := pid; p != 0 && !s.db.HasProcess(p); {
+		procInfo, err := s.reader.GetProcess(p)
+		if err != nil {
+			if p == pid {
+				return fmt.Errorf("get process %d from procfs: %w", pid, err)
+			}
+			// The ancestor exited, the process is inserted without it.
+			break
+		}
+		missing = append(missing, procInfo)
+		if procInfo.PIDs.Ppid == p {
+			break
+		}
+		p = procInfo.PIDs.Ppid
+	}
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		s.db.InsertExec(execEventFromProcessInfo(missing[i]))
+	}
+	return nil
+}
+
+func execEventFromProcessInfo(procInfo procfs.ProcessInfo) types.ProcessExecEvent {
+	return types.ProcessExecEvent{
+		PIDs:     procInfo.PIDs,
+		Creds:    procInfo.Creds,
+		CTTY:     procInfo.CTTY,
+		CWD:      procInfo.Cwd,
+		Argv:     procInfo.Argv,
+		Env:      procInfo.Env,
+		Filename: procInfo.Filename,
+	}
+}
@@ -14,9 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/processdb"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/procfs"
-	"github.com/elastic/beats/v7/x-pack/auditbeat/processors/sessionmd/types"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/processdb"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/procfs"
+	"github.com/elastic/beats/v7/x-pack/libbeat/processors/sessionmd/types"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -480,3 +480,75 @@ func TestSetSidSessionLeaderNotScraped(t *testing.T) {
 
 	require.Equal(t, expected.PIDs.Sid, actual.SessionLeader.PID)
 }
+
+func TestEventWithoutSyscall(t *testing.T) {
+	var pid uint32 = 100
+	event := beat.Event{
+		Timestamp: timestamp,
+		Fields: mapstr.M{
+			"log": mapstr.M{
+				"syslog": mapstr.M{
+					"procid": "100",
+				},
+			},
+		},
+	}
+	procinfo := []procfs.ProcessInfo{
+		{
+			PIDs: types.PIDInfo{
+				Tid:  60,
+				Tgid: 60,
+				Ppid: 1,
+				Pgid: 60,
+				Sid:  60,
+			},
+		},
+		{
+			PIDs: types.PIDInfo{
+				Tid:  80,
+				Tgid: 80,
+				Ppid: 60,
+				Pgid: 80,
+				Sid:  60,
+			},
+		},
+		{
+			PIDs: types.PIDInfo{
+				Tid:  100,
+				Tgid: 100,
+				Ppid: 80,
+				Pgid: 80,
+				Sid:  60,
+			},
+			Filename: "/usr/sbin/sshd",
+		},
+	}
+
+	reader := procfs.NewMockReader()
+	db, err := processdb.NewDB(reader, logger)
+	require.Nil(t, err)
+	db.ScrapeProcfs()
+
+	// The processes are started after the DB was populated.
+	for _, entry := range procinfo {
+		reader.AddEntry(entry.PIDs.Tgid, entry)
+	}
+
+	provider, err := NewProvider(context.TODO(), &logger, db, reader, "log.syslog.procid")
+	require.Nil(t, err, "error creating provider")
+
+	err = provider.SyncDB(&event, pid)
+	require.Nil(t, err)
+
+	actual, err := db.GetProcess(pid)
+	require.Nil(t, err, "pid not found in db")
+
+	require.Equal(t, pid, actual.PID)
+	require.Equal(t, "/usr/sbin/sshd", actual.Executable)
+	require.Equal(t, uint32(80), actual.Parent.PID)
+	require.Equal(t, uint32(80), actual.GroupLeader.PID)
+	require.Equal(t, uint32(60), actual.SessionLeader.PID)
+
+	err = provider.SyncDB(&event, 200)
+	require.Error(t, err, "unknown process should not be synced")
+}
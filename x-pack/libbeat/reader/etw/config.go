@@ -5,12 +5,23 @@
 package etw
 
 type Config struct {
-	Logfile         string // Path to the logfile
-	ProviderGUID    string // GUID of the ETW provider
-	ProviderName    string // Name of the ETW provider
-	SessionName     string // Name for new ETW session
+	Logfile         string           // Path to the logfile
+	ProviderGUID    string           // GUID of the ETW provider
+	ProviderName    string           // Name of the ETW provider
+	SessionName     string           // Name for new ETW session
+	TraceLevel      string           // Level of tracing (e.g., "verbose")
+	MatchAnyKeyword uint64           // Filter for any matching keywords (bitmask)
+	MatchAllKeyword uint64           // Filter for all matching keywords (bitmask)
+	Session         string           // Existing session to attach
+	Providers       []ProviderConfig // Providers to enable on an existing session
+	Manifests       []string         // Manifests or binaries with embedded manifests used to decode events
+	TMFSearchPath   string           // Directory with TMF files used to decode WPP events
+}
+
+type ProviderConfig struct {
+	GUID            string // GUID of the ETW provider
+	Name            string // Name of the ETW provider
 	TraceLevel      string // Level of tracing (e.g., "verbose")
 	MatchAnyKeyword uint64 // Filter for any matching keywords (bitmask)
 	MatchAllKeyword uint64 // Filter for all matching keywords (bitmask)
-	Session         string // Existing session to attach
 }
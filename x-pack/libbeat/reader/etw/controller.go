@@ -28,7 +28,7 @@ func (s *Session) AttachToExistingSession() error {
 		// Get the session handler from the properties struct.
 		s.handler = uintptr(s.properties.Wnode.Union1)
 
-		return nil
+		return s.enableProviders()
 
 	// Handle specific errors related to the query operation.
 	case errors.Is(err, ERROR_BAD_LENGTH):
@@ -75,6 +75,48 @@ func (s *Session) CreateRealtimeSession() error {
 
 	// Enable the trace session with extended options.
 	err = s.enableTrace(s.handler, &s.GUID, EVENT_CONTROL_CODE_ENABLE_PROVIDER, s.traceLevel, s.matchAnyKeyword, s.matchAllKeyword, timeout, &params)
+	return enableTraceError(err)
+}
+
+// enableProviders enables the configured providers on an existing session,
+// each one with its own level and keywords.
+func (s *Session) enableProviders() error {
+	params := EnableTraceParameters{
+		Version: 2, // ENABLE_TRACE_PARAMETERS_VERSION_2
+	}
+	const timeout = 0
+
+	for i := range s.providers {
+		p := &s.providers[i]
+		err := s.enableTrace(s.handler, &p.guid, EVENT_CONTROL_CODE_ENABLE_PROVIDER, p.traceLevel, p.matchAnyKeyword, p.matchAllKeyword, timeout, &params)
+		if err = enableTraceError(err); err != nil {
+			return fmt.Errorf("provider %s: %w", p.guid, err)
+		}
+		p.enabled = true
+	}
+	return nil
+}
+
+// disableProviders disables the providers enabled by enableProviders.
+func (s *Session) disableProviders() error {
+	var errs []error
+	for i := range s.providers {
+		p := &s.providers[i]
+		if !p.enabled {
+			continue
+		}
+		err := s.enableTrace(s.handler, &p.guid, EVENT_CONTROL_CODE_DISABLE_PROVIDER, 0, 0, 0, 0, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to disable provider %s: %w", p.guid, err))
+			continue
+		}
+		p.enabled = false
+	}
+	return errors.Join(errs...)
+}
+
+// enableTraceError translates the errors returned by EnableTraceEx2.
+func enableTraceError(err error) error {
 	switch {
 	case err == nil:
 		return nil
@@ -113,7 +155,8 @@ func (s *Session) StopSession() error {
 		)
 	}
 
-	return nil
+	// The session is shared, only disable the providers we enabled on it.
+	return s.disableProviders()
 }
 
 func isValidHandler(handler uint64) bool {
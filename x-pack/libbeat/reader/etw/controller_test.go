@@ -57,6 +57,99 @@ func TestAttachToExistingSession_Success(t *testing.T) {
 	assert.Equal(t, uintptr(12345), session.handler, "Handler should be set to the mock value")
 }
 
+func TestAttachToExistingSession_EnableProviders(t *testing.T) {
+	controlTrace := func(traceHandle uintptr,
+		instanceName *uint16,
+		properties *EventTraceProperties,
+		controlCode uint32) error {
+		properties.Wnode.Union1 = 12345
+		return nil
+	}
+
+	type call struct {
+		guid    windows.GUID
+		control uint32
+		level   uint8
+		anyKw   uint64
+		allKw   uint64
+	}
+	var calls []call
+	enableTrace := func(traceHandle uintptr,
+		providerId *windows.GUID,
+		isEnabled uint32,
+		level uint8,
+		matchAnyKeyword uint64,
+		matchAllKeyword uint64,
+		enableProperty uint32,
+		enableParameters *EnableTraceParameters) error {
+		assert.Equal(t, uintptr(12345), traceHandle)
+		calls = append(calls, call{*providerId, isEnabled, level, matchAnyKeyword, matchAllKeyword})
+		return nil
+	}
+
+	guid1 := windows.GUID{Data1: 1}
+	guid2 := windows.GUID{Data1: 2}
+	session := &Session{
+		Name:         "TestSession",
+		Realtime:     true,
+		properties:   &EventTraceProperties{},
+		controlTrace: controlTrace,
+		enableTrace:  enableTrace,
+		providers: []providerFilter{
+			{guid: guid1, traceLevel: TRACE_LEVEL_ERROR, matchAnyKeyword: 0x10},
+			{guid: guid2, traceLevel: TRACE_LEVEL_VERBOSE, matchAllKeyword: 0x3},
+		},
+	}
+
+	err := session.AttachToExistingSession()
+	assert.NoError(t, err)
+	assert.Equal(t, []call{
+		{guid1, EVENT_CONTROL_CODE_ENABLE_PROVIDER, TRACE_LEVEL_ERROR, 0x10, 0},
+		{guid2, EVENT_CONTROL_CODE_ENABLE_PROVIDER, TRACE_LEVEL_VERBOSE, 0, 0x3},
+	}, calls)
+
+	// Stopping a shared session only disables the providers enabled on it.
+	calls = nil
+	err = session.StopSession()
+	assert.NoError(t, err)
+	assert.Equal(t, []call{
+		{guid1, EVENT_CONTROL_CODE_DISABLE_PROVIDER, 0, 0, 0},
+		{guid2, EVENT_CONTROL_CODE_DISABLE_PROVIDER, 0, 0, 0},
+	}, calls)
+}
+
+func TestAttachToExistingSession_EnableProvidersError(t *testing.T) {
+	controlTrace := func(traceHandle uintptr,
+		instanceName *uint16,
+		properties *EventTraceProperties,
+		controlCode uint32) error {
+		return nil
+	}
+
+	enableTrace := func(traceHandle uintptr,
+		providerId *windows.GUID,
+		isEnabled uint32,
+		level uint8,
+		matchAnyKeyword uint64,
+		matchAllKeyword uint64,
+		enableProperty uint32,
+		enableParameters *EnableTraceParameters) error {
+		return ERROR_NO_SYSTEM_RESOURCES
+	}
+
+	session := &Session{
+		Name:         "TestSession",
+		properties:   &EventTraceProperties{},
+		controlTrace: controlTrace,
+		enableTrace:  enableTrace,
+		providers:    []providerFilter{{guid: windows.GUID{Data1: 1}}},
+	}
+
+	err := session.AttachToExistingSession()
+	assert.ErrorContains(t, err, "exceeded the number of trace sessions that can enable the provider")
+	assert.False(t, session.providers[0].enabled)
+}
+
 func TestCreateRealtimeSession_StartTraceError(t *testing.T) {
 	// Mock implementation of startTrace
 	startTrace := func(traceHandle *uintptr,
@@ -9,6 +9,8 @@ package etw
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -18,28 +20,62 @@ import (
 type propertyParser struct {
 	r       *EventRecord
 	info    *TraceEventInfo
+	ctx     []TdhContext
 	data    []byte
 	ptrSize uint32
 }
 
+// RenderedEvent holds the decoded properties of an event along with the
+// names the provider manifest defines for its metadata. Names are empty
+// when the decoding source does not define them.
+type RenderedEvent struct {
+	Properties   map[string]interface{}
+	ProviderName string
+	Level        string
+	Channel      string
+	Task         string
+	Opcode       string
+	Keywords     []string
+	// Message is the event message of the manifest with the property
+	// values inserted.
+	Message string
+}
+
 // GetEventProperties extracts and returns properties from an ETW event record.
 func GetEventProperties(r *EventRecord) (map[string]interface{}, error) {
+	evt, err := renderEvent(r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return evt.Properties, nil
+}
+
+// RenderEvent decodes an ETW event record using the manifests and TMF files
+// configured for the session.
+func (s *Session) RenderEvent(r *EventRecord) (*RenderedEvent, error) {
+	return renderEvent(r, s.tdhContext)
+}
+
+func renderEvent(r *EventRecord, ctx []TdhContext) (*RenderedEvent, error) {
 	// Handle the case where the event only contains a string.
 	if r.EventHeader.Flags == EVENT_HEADER_FLAG_STRING_ONLY {
 		userDataPtr := (*uint16)(unsafe.Pointer(r.UserData))
-		return map[string]interface{}{
-			"_": utf16AtOffsetToString(uintptr(unsafe.Pointer(userDataPtr)), 0), // Convert the user data from UTF16 to string.
+		return &RenderedEvent{
+			Properties: map[string]interface{}{
+				"_": utf16AtOffsetToString(uintptr(unsafe.Pointer(userDataPtr)), 0), // Convert the user data from UTF16 to string.
+			},
 		}, nil
 	}
 
 	// Initialize a new property parser for the event record.
-	p, err := newPropertyParser(r)
+	p, err := newPropertyParser(r, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse event properties: %w", err)
 	}
 
 	// Iterate through each property of the event and format it
 	properties := make(map[string]interface{}, int(p.info.TopLevelPropertyCount))
+	values := make([]interface{}, 0, int(p.info.TopLevelPropertyCount))
 	for i := 0; i < int(p.info.TopLevelPropertyCount); i++ {
 		name := p.getPropertyName(i)
 		value, err := p.getPropertyValue(i)
@@ -47,14 +83,107 @@ func GetEventProperties(r *EventRecord) (map[string]interface{}, error) {
 			return nil, fmt.Errorf("failed to parse %q value: %w", name, err)
 		}
 		properties[name] = value
+		values = append(values, value)
+	}
+
+	return &RenderedEvent{
+		Properties:   properties,
+		ProviderName: p.info.stringAt(p.info.ProviderNameOffset),
+		Level:        p.info.stringAt(p.info.LevelNameOffset),
+		Channel:      p.info.stringAt(p.info.ChannelNameOffset),
+		Task:         p.info.stringAt(p.info.TaskNameOffset),
+		Opcode:       p.info.stringAt(p.info.OpcodeNameOffset),
+		Keywords:     p.info.stringsAt(p.info.KeywordsNameOffset),
+		Message:      formatEventMessage(p.info.stringAt(p.info.EventMessageOffset), values),
+	}, nil
+}
+
+// stringAt returns the trimmed UTF-16 string at the given offset of the
+// event information, or an empty string if the offset is not set.
+func (info *TraceEventInfo) stringAt(offset uint32) string {
+	if offset == 0 {
+		return ""
 	}
+	s := windows.UTF16PtrToString((*uint16)(unsafe.Add(unsafe.Pointer(info), offset)))
+	return strings.TrimSpace(s)
+}
 
-	return properties, nil
+// stringsAt returns the list of UTF-16 strings at the given offset of the
+// event information. The list is terminated by an empty string.
+func (info *TraceEventInfo) stringsAt(offset uint32) []string {
+	if offset == 0 {
+		return nil
+	}
+	var out []string
+	for {
+		ptr := (*uint16)(unsafe.Add(unsafe.Pointer(info), offset))
+		s := windows.UTF16PtrToString(ptr)
+		if s == "" {
+			return out
+		}
+		out = append(out, strings.TrimSpace(s))
+		offset += uint32(len(windows.StringToUTF16(s)) * 2)
+	}
+}
+
+// formatEventMessage inserts the property values into a manifest message.
+// Insertion sequences are %1 to %99, optionally followed by a !format!
+// specifier that is ignored. %n, %t, %r and %% are also expanded.
+func formatEventMessage(template string, values []interface{}) string {
+	if template == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if c != '%' || i+1 == len(template) {
+			b.WriteByte(c)
+			continue
+		}
+
+		switch next := template[i+1]; {
+		case next == 'n':
+			b.WriteByte('\n')
+			i++
+		case next == 't':
+			b.WriteByte('\t')
+			i++
+		case next == 'r':
+			b.WriteByte('\r')
+			i++
+		case next == '%':
+			b.WriteByte('%')
+			i++
+		case next >= '1' && next <= '9':
+			end := i + 2
+			if end < len(template) && template[end] >= '0' && template[end] <= '9' {
+				end++
+			}
+			n, _ := strconv.Atoi(template[i+1 : end])
+			// Skip the printf-like format specifier.
+			if end < len(template) && template[end] == '!' {
+				if j := strings.IndexByte(template[end+1:], '!'); j >= 0 {
+					end += j + 2
+				}
+			}
+			if n <= len(values) {
+				fmt.Fprint(&b, values[n-1])
+			} else {
+				b.WriteString(template[i:end])
+			}
+			i = end - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return strings.TrimSpace(b.String())
 }
 
 // newPropertyParser initializes a new property parser for a given event record.
-func newPropertyParser(r *EventRecord) (*propertyParser, error) {
-	info, err := getEventInformation(r)
+func newPropertyParser(r *EventRecord, ctx []TdhContext) (*propertyParser, error) {
+	info, err := getEventInformation(r, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get event information: %w", err)
 	}
@@ -63,11 +192,20 @@ func newPropertyParser(r *EventRecord) (*propertyParser, error) {
 	return &propertyParser{
 		r:       r,
 		info:    info,
+		ctx:     ctx,
 		ptrSize: ptrSize,
 		data:    unsafe.Slice((*uint8)(unsafe.Pointer(r.UserData)), r.UserDataLength),
 	}, nil
 }
 
+// tdhContextArgs returns the count and pointer arguments TDH expects for ctx.
+func tdhContextArgs(ctx []TdhContext) (uint32, *TdhContext) {
+	if len(ctx) == 0 {
+		return 0, nil
+	}
+	return uint32(len(ctx)), &ctx[0]
+}
+
 // getEventPropertyInfoAtIndex looks for the EventPropertyInfo object at a specified index.
 func (info *TraceEventInfo) getEventPropertyInfoAtIndex(i uint32) *EventPropertyInfo {
 	if i < info.PropertyCount {
@@ -82,15 +220,16 @@ func (info *TraceEventInfo) getEventPropertyInfoAtIndex(i uint32) *EventProperty
 }
 
 // getEventInformation retrieves detailed metadata about an event record.
-func getEventInformation(r *EventRecord) (info *TraceEventInfo, err error) {
+func getEventInformation(r *EventRecord, ctx []TdhContext) (info *TraceEventInfo, err error) {
+	ctxCount, ctxPtr := tdhContextArgs(ctx)
 	// Initially call TdhGetEventInformation to get the required buffer size.
 	var bufSize uint32
-	if err = _TdhGetEventInformation(r, 0, nil, nil, &bufSize); errors.Is(err, ERROR_INSUFFICIENT_BUFFER) {
+	if err = _TdhGetEventInformation(r, ctxCount, ctxPtr, nil, &bufSize); errors.Is(err, ERROR_INSUFFICIENT_BUFFER) {
 		// Allocate enough memory for TRACE_EVENT_INFO based on the required size.
 		buff := make([]byte, bufSize)
 		info = ((*TraceEventInfo)(unsafe.Pointer(&buff[0])))
 		// Retrieve the event information into the allocated buffer.
-		err = _TdhGetEventInformation(r, 0, nil, info, &bufSize)
+		err = _TdhGetEventInformation(r, ctxCount, ctxPtr, info, &bufSize)
 	}
 
 	// Check for errors in retrieving the event information.
@@ -160,7 +299,7 @@ func (p *propertyParser) getArraySize(propertyInfo EventPropertyInfo) (uint32, e
 		dataDescriptor.PropertyName = readPropertyName(p, int(propertyInfo.count()))
 		dataDescriptor.ArrayIndex = 0xFFFFFFFF
 		// Retrieve the length of the array from the specified property.
-		return getLengthFromProperty(p.r, &dataDescriptor)
+		return getLengthFromProperty(p.r, p.ctx, &dataDescriptor)
 	} else {
 		// If the array size is directly specified, return it.
 		return uint32(propertyInfo.count()), nil
@@ -168,13 +307,14 @@ func (p *propertyParser) getArraySize(propertyInfo EventPropertyInfo) (uint32, e
 }
 
 // getLengthFromProperty retrieves the length of a property from an event record.
-func getLengthFromProperty(r *EventRecord, dataDescriptor *PropertyDataDescriptor) (uint32, error) {
+func getLengthFromProperty(r *EventRecord, ctx []TdhContext, dataDescriptor *PropertyDataDescriptor) (uint32, error) {
 	var length uint32
+	ctxCount, ctxPtr := tdhContextArgs(ctx)
 	// Call TdhGetProperty to get the length of the property specified by the dataDescriptor.
 	err := _TdhGetProperty(
 		r,
-		0,
-		nil,
+		ctxCount,
+		ctxPtr,
 		1,
 		dataDescriptor,
 		uint32(unsafe.Sizeof(length)),
@@ -322,7 +462,7 @@ func (p *propertyParser) getPropertyLength(propertyInfo EventPropertyInfo) (uint
 		dataDescriptor.PropertyName = readPropertyName(p, int(propertyInfo.length()))
 		dataDescriptor.ArrayIndex = 0xFFFFFFFF
 		// Retrieve the length from the specified property.
-		return getLengthFromProperty(p.r, &dataDescriptor)
+		return getLengthFromProperty(p.r, p.ctx, &dataDescriptor)
 	}
 
 	inType := propertyInfo.inType()
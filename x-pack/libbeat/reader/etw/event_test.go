@@ -0,0 +1,37 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build windows
+
+package etw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatEventMessage(t *testing.T) {
+	values := []interface{}{"alice", "host", []interface{}{"1", "2"}}
+
+	testCases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"insertions", "User %1 logged on to %2.", "User alice logged on to host."},
+		{"format specifier", "User %1!s! logged on.", "User alice logged on."},
+		{"special sequences", "100%%%tdone%n", "100%\tdone"},
+		{"array value", "Values: %3", "Values: [1 2]"},
+		{"missing value", "Value: %4 %12", "Value: %4 %12"},
+		{"unknown sequence", "%x %", "%x %"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, formatEventMessage(tc.template, values))
+		})
+	}
+}
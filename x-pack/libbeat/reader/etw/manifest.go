@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build windows
+
+package etw
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// Manifests are loaded into the TDH cache of the process, so inputs sharing
+// a manifest keep a reference count to unload it only when none uses it.
+var (
+	manifestsMu  sync.Mutex
+	manifestRefs = map[string]int{}
+
+	// For testing purposes these variables point to mock functions.
+	loadManifestFunc   = loadManifest
+	unloadManifestFunc = unloadManifest
+)
+
+// isManifestFile reports whether path is an instrumentation manifest rather
+// than a binary with the manifest embedded as a resource.
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".man", ".xml":
+		return true
+	}
+	return false
+}
+
+// loadManifest loads a manifest file or the manifest embedded in a binary.
+func loadManifest(path string) error {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return fmt.Errorf("failed to convert manifest path: %w", err)
+	}
+	if isManifestFile(path) {
+		return _TdhLoadManifest(pathPtr)
+	}
+	return _TdhLoadManifestFromBinary(pathPtr)
+}
+
+// unloadManifest unloads a manifest file. Manifests loaded from binaries
+// have no unload counterpart and stay cached by TDH.
+func unloadManifest(path string) error {
+	if !isManifestFile(path) {
+		return nil
+	}
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return fmt.Errorf("failed to convert manifest path: %w", err)
+	}
+	return _TdhUnloadManifest(pathPtr)
+}
+
+// acquireManifests loads the manifests not loaded yet and returns a function
+// that releases them. On error, the manifests already acquired are released.
+func acquireManifests(paths []string) (release func(), err error) {
+	manifestsMu.Lock()
+	defer manifestsMu.Unlock()
+
+	acquired := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if manifestRefs[path] == 0 {
+			if err := loadManifestFunc(path); err != nil {
+				releaseManifestsLocked(acquired)
+				return nil, fmt.Errorf("failed to load %q: %w", path, err)
+			}
+		}
+		manifestRefs[path]++
+		acquired = append(acquired, path)
+	}
+
+	return func() {
+		manifestsMu.Lock()
+		defer manifestsMu.Unlock()
+		releaseManifestsLocked(acquired)
+	}, nil
+}
+
+// releaseManifestsLocked drops a reference to each manifest, unloading the
+// ones no longer in use. manifestsMu must be held.
+func releaseManifestsLocked(paths []string) {
+	for _, path := range paths {
+		manifestRefs[path]--
+		if manifestRefs[path] > 0 {
+			continue
+		}
+		delete(manifestRefs, path)
+		_ = unloadManifestFunc(path)
+	}
+}
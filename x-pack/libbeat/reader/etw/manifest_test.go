@@ -0,0 +1,57 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build windows
+
+package etw
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireManifests(t *testing.T) {
+	t.Cleanup(func() {
+		loadManifestFunc = loadManifest
+		unloadManifestFunc = unloadManifest
+	})
+
+	var loaded, unloaded []string
+	loadManifestFunc = func(path string) error {
+		if path == "broken.man" {
+			return errors.New("mock error")
+		}
+		loaded = append(loaded, path)
+		return nil
+	}
+	unloadManifestFunc = func(path string) error {
+		unloaded = append(unloaded, path)
+		return nil
+	}
+
+	release1, err := acquireManifests([]string{"a.man", "b.dll"})
+	assert.NoError(t, err)
+	release2, err := acquireManifests([]string{"a.man"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.man", "b.dll"}, loaded, "shared manifests are loaded once")
+
+	release1()
+	assert.Equal(t, []string{"b.dll"}, unloaded, "manifests in use are kept loaded")
+	release2()
+	assert.Equal(t, []string{"b.dll", "a.man"}, unloaded)
+	assert.Empty(t, manifestRefs)
+
+	_, err = acquireManifests([]string{"c.man", "broken.man"})
+	assert.EqualError(t, err, `failed to load "broken.man": mock error`)
+	assert.Equal(t, []string{"b.dll", "a.man", "c.man"}, unloaded, "acquired manifests are released on error")
+	assert.Empty(t, manifestRefs)
+}
+
+func TestIsManifestFile(t *testing.T) {
+	assert.True(t, isManifestFile(`C:\manifests\provider.man`))
+	assert.True(t, isManifestFile(`C:\manifests\provider.XML`))
+	assert.False(t, isManifestFile(`C:\Windows\System32\provider.dll`))
+}
@@ -56,6 +56,18 @@ type Session struct {
 	Callback func(*EventRecord) uintptr
 	// BufferCallback is the pointer to BufferCallback which processes retrieved metadata about the ETW buffers (optional).
 	BufferCallback func(*EventTraceLogfile) uintptr
+	// providers are enabled on an existing session when attaching to it.
+	// As the session can be shared with other consumers, they are also used
+	// to discard the events not matching their level and keywords.
+	providers []providerFilter
+	// manifests are loaded while consuming so TDH can decode the events of
+	// providers that are not registered in the system.
+	manifests []string
+	// tmfSearchPath is the UTF-16 directory where TDH looks for TMF files to
+	// decode WPP events. It must be kept alive while tdhContext is in use.
+	tmfSearchPath []uint16
+	// tdhContext is passed to TDH when decoding events.
+	tdhContext []TdhContext
 
 	// Pointers to functions that make calls to the Windows API.
 	// In tests, these pointers can be replaced with mock functions to simulate API behavior without making actual calls to the Windows API.
@@ -67,6 +79,32 @@ type Session struct {
 	processTrace func(handleArray *uint64, handleCount uint32, startTime *FileTime, endTime *FileTime) error
 }
 
+// providerFilter holds the provider settings used to enable it on a session
+// and to filter its events.
+type providerFilter struct {
+	guid            windows.GUID
+	traceLevel      uint8
+	matchAnyKeyword uint64
+	matchAllKeyword uint64
+	// enabled is set once the provider has been enabled on the session.
+	enabled bool
+}
+
+// match reports whether the event level and keywords meet the provider
+// settings. Events with no level or keyword set always match, as ETW does.
+func (p *providerFilter) match(d EventDescriptor) bool {
+	if d.Level != 0 && d.Level > p.traceLevel {
+		return false
+	}
+	if d.Keyword == 0 {
+		return true
+	}
+	if p.matchAnyKeyword != 0 && d.Keyword&p.matchAnyKeyword == 0 {
+		return false
+	}
+	return d.Keyword&p.matchAllKeyword == p.matchAllKeyword
+}
+
 // setSessionName determines the session name based on the provided configuration.
 func setSessionName(conf Config) string {
 	// Iterate through potential session name values, returning the first non-empty one.
@@ -105,6 +143,38 @@ func setSessionGUID(conf Config) (windows.GUID, error) {
 	return guid, nil
 }
 
+// newProviderFilters resolves the GUIDs of the configured providers.
+func newProviderFilters(providers []ProviderConfig) ([]providerFilter, error) {
+	filters := make([]providerFilter, 0, len(providers))
+	for _, p := range providers {
+		guid, err := setSessionGUIDFunc(Config{ProviderGUID: p.GUID, ProviderName: p.Name})
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, providerFilter{
+			guid:            guid,
+			traceLevel:      getTraceLevel(p.TraceLevel),
+			matchAnyKeyword: p.MatchAnyKeyword,
+			matchAllKeyword: p.MatchAllKeyword,
+		})
+	}
+	return filters, nil
+}
+
+// Match reports whether an event passes the provider filters of the session.
+// Sessions without configured providers accept every event.
+func (s *Session) Match(h *EventHeader) bool {
+	if len(s.providers) == 0 {
+		return true
+	}
+	for i := range s.providers {
+		if s.providers[i].guid == h.ProviderId {
+			return s.providers[i].match(h.EventDescriptor)
+		}
+	}
+	return false
+}
+
 // getTraceLevel converts a string representation of a trace level
 // to its corresponding uint8 constant value
 func getTraceLevel(level string) uint8 {
@@ -169,9 +239,26 @@ func NewSession(conf Config) (*Session, error) {
 
 	session.Name = setSessionName(conf)
 	session.Realtime = true
+	session.manifests = conf.Manifests
+	if conf.TMFSearchPath != "" {
+		path, err := syscall.UTF16FromString(conf.TMFSearchPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert TMF search path: %w", err)
+		}
+		session.tmfSearchPath = path
+		session.tdhContext = []TdhContext{{
+			ParameterValue: uint64(uintptr(unsafe.Pointer(&path[0]))),
+			ParameterType:  TDH_CONTEXT_WPP_TMFSEARCHPATH,
+		}}
+	}
 
 	// If a current session is configured, set up the session properties and return.
 	if conf.Session != "" {
+		var err error
+		session.providers, err = newProviderFilters(conf.Providers)
+		if err != nil {
+			return nil, fmt.Errorf("error when initializing session '%s': %w", session.Name, err)
+		}
 		session.properties = newSessionProperties(session.Name)
 		return session, nil
 	} else if conf.Logfile != "" {
@@ -226,6 +313,14 @@ func (s *Session) StartConsumer() error {
 	elf.Callback = syscall.NewCallback(s.Callback)
 	elf.Context = 0
 
+	// Load the manifests before consuming so TDH can decode the events.
+	// They are released once the trace processing ends.
+	release, err := acquireManifests(s.manifests)
+	if err != nil {
+		return fmt.Errorf("failed to load manifests: %w", err)
+	}
+	defer release()
+
 	// Open an ETW trace processing handle for consuming events
 	// from an ETW real-time trace session or an ETW log file.
 	s.traceHandler, err = s.openTrace(&elf)
@@ -232,6 +232,86 @@ func TestNewSession_AttachSession(t *testing.T) {
 	assert.NotNil(t, session.properties)
 }
 
+func TestNewSession_AttachSessionProviders(t *testing.T) {
+	conf := Config{
+		Session: "Session1",
+		Providers: []ProviderConfig{
+			{
+				GUID:            "{12345678-1234-5678-1234-567812345678}",
+				TraceLevel:      "error",
+				MatchAnyKeyword: 0x10,
+			},
+			{
+				GUID:            "{87654321-1234-5678-1234-567812345678}",
+				TraceLevel:      "verbose",
+				MatchAnyKeyword: 0xffffffffffffffff,
+				MatchAllKeyword: 0x3,
+			},
+		},
+		TMFSearchPath: "C:\\TMF",
+	}
+	session, err := NewSession(conf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, false, session.NewSession)
+	if assert.Len(t, session.providers, 2) {
+		assert.Equal(t, "{12345678-1234-5678-1234-567812345678}", session.providers[0].guid.String())
+		assert.Equal(t, uint8(TRACE_LEVEL_ERROR), session.providers[0].traceLevel)
+		assert.Equal(t, uint64(0x10), session.providers[0].matchAnyKeyword)
+		assert.Equal(t, uint64(0x3), session.providers[1].matchAllKeyword)
+	}
+	if assert.Len(t, session.tdhContext, 1) {
+		assert.Equal(t, int32(TDH_CONTEXT_WPP_TMFSEARCHPATH), session.tdhContext[0].ParameterType)
+		assert.Equal(t, "C:\\TMF", windows.UTF16ToString(session.tmfSearchPath))
+	}
+}
+
+func TestNewSession_AttachSessionProviderError(t *testing.T) {
+	conf := Config{
+		Session:   "Session1",
+		Providers: []ProviderConfig{{GUID: "invalid"}},
+	}
+	session, err := NewSession(conf)
+
+	assert.ErrorContains(t, err, "error when initializing session 'Session1'")
+	assert.Nil(t, session)
+}
+
+func TestSessionMatch(t *testing.T) {
+	guid1 := windows.GUID{Data1: 1}
+	guid2 := windows.GUID{Data1: 2}
+	session := &Session{
+		providers: []providerFilter{
+			{guid: guid1, traceLevel: TRACE_LEVEL_WARNING, matchAnyKeyword: 0x0f},
+			{guid: guid2, traceLevel: TRACE_LEVEL_VERBOSE, matchAnyKeyword: 0xffffffffffffffff, matchAllKeyword: 0x3},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		header   EventHeader
+		expected bool
+	}{
+		{"matching level and keyword", EventHeader{ProviderId: guid1, EventDescriptor: EventDescriptor{Level: 2, Keyword: 0x1}}, true},
+		{"level above trace level", EventHeader{ProviderId: guid1, EventDescriptor: EventDescriptor{Level: 4, Keyword: 0x1}}, false},
+		{"no level", EventHeader{ProviderId: guid1, EventDescriptor: EventDescriptor{Keyword: 0x1}}, true},
+		{"no matching any keyword", EventHeader{ProviderId: guid1, EventDescriptor: EventDescriptor{Level: 1, Keyword: 0x10}}, false},
+		{"no keyword", EventHeader{ProviderId: guid1, EventDescriptor: EventDescriptor{Level: 1}}, true},
+		{"matching all keywords", EventHeader{ProviderId: guid2, EventDescriptor: EventDescriptor{Level: 5, Keyword: 0x7}}, true},
+		{"missing all keywords", EventHeader{ProviderId: guid2, EventDescriptor: EventDescriptor{Level: 5, Keyword: 0x1}}, false},
+		{"unknown provider", EventHeader{ProviderId: windows.GUID{Data1: 3}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, session.Match(&tc.header))
+		})
+	}
+
+	// Sessions without providers accept every event.
+	assert.True(t, (&Session{}).Match(&EventHeader{ProviderId: guid1}))
+}
+
 func TestNewSession_Logfile(t *testing.T) {
 	// Test case
 	conf := Config{
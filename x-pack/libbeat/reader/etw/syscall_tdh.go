@@ -20,6 +20,9 @@ var (
 	tdhGetEventMapInformation = tdh.NewProc("TdhGetEventMapInformation")
 	tdhFormatProperty         = tdh.NewProc("TdhFormatProperty")
 	tdhGetProperty            = tdh.NewProc("TdhGetProperty")
+	tdhLoadManifest           = tdh.NewProc("TdhLoadManifest")
+	tdhLoadManifestFromBinary = tdh.NewProc("TdhLoadManifestFromBinary")
+	tdhUnloadManifest         = tdh.NewProc("TdhUnloadManifest")
 )
 
 const anysizeArray = 1
@@ -107,11 +110,17 @@ type EventHeaderExtendedDataItem struct {
 
 // https://learn.microsoft.com/en-us/windows/win32/api/tdh/ns-tdh-tdh_context
 type TdhContext struct {
-	ParameterValue uint32
+	ParameterValue uint64
 	ParameterType  int32
 	ParameterSize  uint32
 }
 
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/ne-tdh-tdh_context_type
+const (
+	TDH_CONTEXT_WPP_TMFFILE       = 0
+	TDH_CONTEXT_WPP_TMFSEARCHPATH = 1
+)
+
 // https://learn.microsoft.com/en-us/windows/win32/api/tdh/ns-tdh-trace_event_info
 type TraceEventInfo struct {
 	ProviderGUID                windows.GUID
@@ -321,3 +330,33 @@ func _TdhGetEventMapInformation(pEvent *EventRecord,
 	}
 	return syscall.Errno(r0)
 }
+
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/nf-tdh-tdhloadmanifest
+func _TdhLoadManifest(manifest *uint16) error {
+	r0, _, _ := tdhLoadManifest.Call(
+		uintptr(unsafe.Pointer(manifest)))
+	if r0 == 0 {
+		return nil
+	}
+	return syscall.Errno(r0)
+}
+
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/nf-tdh-tdhloadmanifestfrombinary
+func _TdhLoadManifestFromBinary(binaryPath *uint16) error {
+	r0, _, _ := tdhLoadManifestFromBinary.Call(
+		uintptr(unsafe.Pointer(binaryPath)))
+	if r0 == 0 {
+		return nil
+	}
+	return syscall.Errno(r0)
+}
+
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/nf-tdh-tdhunloadmanifest
+func _TdhUnloadManifest(manifest *uint16) error {
+	r0, _, _ := tdhUnloadManifest.Call(
+		uintptr(unsafe.Pointer(manifest)))
+	if r0 == 0 {
+		return nil
+	}
+	return syscall.Errno(r0)
+}
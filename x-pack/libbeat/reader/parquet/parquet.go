@@ -9,13 +9,23 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/apache/arrow/go/v14/arrow/memory"
 	"github.com/apache/arrow/go/v14/parquet"
 	"github.com/apache/arrow/go/v14/parquet/file"
 	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/apache/arrow/go/v14/parquet/schema"
 )
 
+// ReaderAtSeeker is a source of parquet data that supports random access, such
+// as a file or a remote object read with range requests.
+type ReaderAtSeeker interface {
+	io.ReaderAt
+	io.Seeker
+}
+
 // BufferedReader parses parquet inputs from io streams.
 type BufferedReader struct {
 	cfg          *Config
@@ -26,39 +36,60 @@ type BufferedReader struct {
 // NewBufferedReader creates a new reader that can decode parquet data from an io.Reader.
 // It will return an error if the parquet data stream cannot be read.
 // Note: As io.ReadAll is used, the entire data stream would be read into memory, so very large data streams
-// may cause memory bottleneck issues.
+// may cause memory bottleneck issues. Use NewReaderAt for sources that support random access.
 func NewBufferedReader(r io.Reader, cfg *Config) (*BufferedReader, error) {
-	batchSize := 1
-	if cfg.BatchSize > 1 {
-		batchSize = cfg.BatchSize
-	}
-
 	// reads the contents of the reader object into a byte slice
 	data, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read data from stream reader: %w", err)
 	}
+	return newReader(bytes.NewReader(data), cfg)
+}
+
+// NewReaderAt creates a new reader that can decode parquet data from a source
+// that supports random access. Only the footer and the column chunks of the
+// selected columns are read, one row group at a time, so the memory used
+// depends on the size of the row groups rather than on the size of the data.
+// It will return an error if the parquet metadata cannot be read.
+func NewReaderAt(r ReaderAtSeeker, cfg *Config) (*BufferedReader, error) {
+	return newReader(r, cfg)
+}
+
+func newReader(r ReaderAtSeeker, cfg *Config) (*BufferedReader, error) {
+	batchSize := 1
+	if cfg.BatchSize > 1 {
+		batchSize = cfg.BatchSize
+	}
 
 	// defines a memory allocator for allocating memory for Arrow objects
 	pool := memory.NewCheckedAllocator(&memory.GoAllocator{})
 
-	pf, err := file.NewParquetReader(bytes.NewReader(data), file.WithReadProps(parquet.NewReaderProperties(pool)))
+	pf, err := file.NewParquetReader(r, file.WithReadProps(parquet.NewReaderProperties(pool)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create parquet reader: %w", err)
 	}
 
+	columns, err := columnIndices(pf.MetaData().Schema, cfg.Columns)
+	if err != nil {
+		pf.Close()
+		return nil, err
+	}
+
 	// constructs a reader for converting to Arrow objects from an existing parquet file reader object
 	reader, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{
 		Parallel:  cfg.ProcessParallel,
 		BatchSize: int64(batchSize),
 	}, pool)
 	if err != nil {
+		pf.Close()
 		return nil, fmt.Errorf("failed to create pqarrow parquet reader: %w", err)
 	}
 
-	// constructs a record reader that is capable of reding entire sets of arrow records
-	rr, err := reader.GetRecordReader(context.Background(), nil, nil)
+	// constructs a record reader that is capable of reding entire sets of arrow records,
+	// reading the row groups one after the other
+	rr, err := reader.GetRecordReader(context.Background(), columns, nil)
 	if err != nil {
+		pf.Close()
 		return nil, fmt.Errorf("failed to create parquet record reader: %w", err)
 	}
 
@@ -69,6 +100,38 @@ func NewBufferedReader(r io.Reader, cfg *Config) (*BufferedReader, error) {
 	}, nil
 }
 
+// columnIndices returns the indices of the leaf columns selected by names. A
+// name selects the leaf column with this path, or all the leaf columns of the
+// nested column with this path. It returns nil to select all the columns when
+// names is empty.
+func columnIndices(sc *schema.Schema, names []string) ([]int, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	selected := make(map[int]bool)
+	for _, name := range names {
+		found := false
+		for i := 0; i < sc.NumColumns(); i++ {
+			path := sc.Column(i).Path()
+			if path == name || strings.HasPrefix(path, name+".") {
+				selected[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("column %q not found in parquet schema", name)
+		}
+	}
+
+	indices := make([]int, 0, len(selected))
+	for i := range selected {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices, nil
+}
+
 // Next advances the pointer to point to the next record and returns true if the next record exists.
 // It will return false if there are no more records to read.
 func (sr *BufferedReader) Next() bool {
@@ -11,4 +11,7 @@ type Config struct {
 	ProcessParallel bool `config:"process_parallel"`
 	// BatchSize is the number of rows to read at a time from the file.
 	BatchSize int `config:"batch_size" default:"1"`
+	// Columns is the list of columns to read. A nested column selects all of its
+	// fields, and a dotted path selects a single field. All columns are read when empty.
+	Columns []string `config:"columns"`
 }
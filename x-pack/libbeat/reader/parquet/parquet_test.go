@@ -288,3 +288,83 @@ func readAndCompareParquetJSON(t *testing.T, r io.Reader, data map[int]string, r
 	}
 	return rowIdx
 }
+
+func TestParquetColumnProjection(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "projection.parquet")
+	createRandomParquet(t, fName, 5, 20)
+
+	file, err := os.Open(fName)
+	if err != nil {
+		t.Fatalf("Failed to open parquet test file: %v", err)
+	}
+	defer file.Close()
+
+	sReader, err := NewBufferedReader(file, &Config{BatchSize: 1, Columns: []string{"col3", "col1"}})
+	if err != nil {
+		t.Fatalf("failed to init stream reader: %v", err)
+	}
+	defer sReader.Close()
+
+	rowCount := 0
+	for sReader.Next() {
+		val, err := sReader.Record()
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		var rows []map[string]interface{}
+		if err := json.Unmarshal(val, &rows); err != nil {
+			t.Fatalf("failed to decode json: %v", err)
+		}
+		for _, row := range rows {
+			assert.Len(t, row, 2)
+			assert.Contains(t, row, "col1")
+			assert.Contains(t, row, "col3")
+			rowCount++
+		}
+	}
+	assert.Equal(t, 20, rowCount)
+}
+
+func TestParquetUnknownColumn(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "unknown.parquet")
+	createRandomParquet(t, fName, 2, 1)
+
+	file, err := os.Open(fName)
+	if err != nil {
+		t.Fatalf("Failed to open parquet test file: %v", err)
+	}
+	defer file.Close()
+
+	_, err = NewReaderAt(file, &Config{Columns: []string{"missing"}})
+	assert.ErrorContains(t, err, `column "missing" not found`)
+}
+
+func TestParquetReaderAt(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "reader_at.parquet")
+	data := createRandomParquet(t, fName, 10, 100)
+
+	file, err := os.Open(fName)
+	if err != nil {
+		t.Fatalf("Failed to open parquet test file: %v", err)
+	}
+	defer file.Close()
+
+	sReader, err := NewReaderAt(file, &Config{BatchSize: 1})
+	if err != nil {
+		t.Fatalf("failed to init reader: %v", err)
+	}
+	defer sReader.Close()
+
+	rowCount := 0
+	for sReader.Next() {
+		val, err := sReader.Record()
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		if !data[string(val)] {
+			t.Fatalf("failed to find record in parquet file: %s", val)
+		}
+		rowCount++
+	}
+	assert.Equal(t, 100, rowCount)
+}
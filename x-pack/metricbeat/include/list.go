@@ -14,6 +14,7 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/awshealth"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/billing"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/cloudwatch"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/metric_stream"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/awsfargate"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/awsfargate/task_stats"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure"
@@ -51,14 +52,28 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql/performance"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql/transaction_log"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad/allocation"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad/client"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad/job"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad/server"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/asm"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/performance"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/rac"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/sysmetric"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/tablespace"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/top_sql"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/wait_class"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/otlp"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/otlp/metrics"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/collector"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/remote_write"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/redisenterprise"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/snmp"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/snmp/get"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/snmp/walk"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/sql"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/sql/query"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/stan"
@@ -19,5 +19,5 @@ func init() {
 // AssetAws returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/aws.
 func AssetAws() string {
-	return "eJzsvVtz4zbyN3yfT4Ham8xs2U4ySbbeysVbZcvOxs96PI5lJ3muuBDZkrAmAQ4A2qPUfvin0ABI8KQjKTv/+s/NbiwJ+PUBjUaj0X1KnmD1E6Ev6itCNNMp/ET+dv779G9fEZKAiiXLNRP8J/L/f0UIIf+mL+rfJBNJkQKJRZpCrBU5/31KMsGZFpLxBclASxYrMpciw88mqSiSF6rj5dlXhEhIgSr4iSzoV4TMGaSJ+glHPyWcZuDRmH96lZsvSlHk7i8doOqDhANpulBnfy//7McTs/9ArIM/2z9E9tMnWL0ImXR/HGU0zxlfuO/+7e9/C77Xic3+e6ALMzB5pmkBJKdMOv7QF0UkKFHIGNRZiwL1/dmsiJ9An5n/blHSxroGwy3NgIg5oWT6PXGjtiZMWAZcMcHfCOM+ojKFsFqQv/77mVO5s7+f/f3rHVEnopilMAZoRfSSaiJBF5JDYuVdrQVyfndNPhcgV22SUsafIIloHIuC6xZF4YIgHfofDsWS2p/7NWcDTebf9SUpFCREC8IS4JrNVw4qcVDPOjE0dPdAFFaPJaEpo2p7QIF1WQJN9XIjW0tLNQNNt5O8MXe/4OjeDG4pJjqfQ6whiQwVmoGKhF6CVJ1sm6eC6t2Y9rAEwotsBhJNgJutMj2GWKotK/USCDwD1+RlKRQQpakuFIkp50KTGZBnkGzOIOmWdpuUHHjC+OJotOC6y+iKSPhcMAmExmasbeGagdJn6F44o+FNBDHsLSHz1VrYwJNIs551lVDd/KCOkXSCND8jlCfEDExelsADXQCeQHJGpiJzf1HIYoN5SZ/N78xXcIwexOZHEZV8OFNgQBecfS7Ar38GksyFrHCf4ZcsCef3t2RJFX44FzKjmlDJf6Iv6idrEn7C751KWJgJ7X99M726/+16cvXN1W9Xtw/Rw/+9u4omny5r/3138ziNri/X0a1ikUMUi2RAS/iwZIrkVNIMNEiicogNB5QRnbEeSKgzR5YDTBFK8mKWspicZ/RPwcnvMCNTkM8s9mwytpV7A3rqRo09O8/TVLxAYl0ZRagEcvd4cXM9OSHnk8mnx9uHaHp3Nbn+2fxFSHL76fbqjKzhDO60MdWwEHI1rG5YeswgxE9AjAjOyJ1Qis1SCMlgShVw4gm/FUadYmrGR0JUvATj+CaTJeWLtUpuSRpY1htVHYe3+u60m6FzHjkNji6vppP767uH60+33ehTqnRU5GYNH2JdOsFnQhnrFhugaw3Ni9nVLYZulHZ5Dsva9mJQ5B7nwUmN4a5MSicqZX82Nqxq/5itQiv3s5AEvtAsT+GEXE0+GI29v5z2YNVU6qEFvFaoM1jQnp3MuhcjLJdQ45wPUxNjpxEQOfATEqdCQXKC5BR5LDLGF+sWfICmkwYNX/ZwGhLQlKWQhN/dQhNbHk0nJColbZrbTf4/t3bFwKAzUWi76WumVzW1pKEf3AC6HeIz+qL88Sca8gjTt9bdXN5PQ48sFlxTxq2/UNJn6d2S82f221Eh02FpeLy/8ZpwGDJU/WGxXV8eCO1Y2xAaKhQ1riiryBu3nzbev7YF61WNQ1z17gPGGgfGKoj12qwg2t76Ty13Hb/p/XX6ok7dOnaffOO+wJJ2HGDG0rR+KO2OAqwR07/dGP+umwpQmmV4mI6XVC5AIZUrUUi0i97UMN6IjPp/fXGHdfGDKz/nxE7ZKbhUtA7hNeo+0i8sK7IeAhz2NXGeSSEl8HhvJ/6qNW/sRjSa0zOpM+G3B8SYvGuFnp7XyKyPGd0wzjMhNfsTkolQza2+W7H6RBqOSrNGALA+ZCt42UleCY3Exp70jOmnNJzunbCbmZtmbA3p57pIMarw9ljmgB2NYbX5etl1a6xgavj6qOgCzrtwvTLjKoikMBiPwbyeOfv5+Mhnb1XxSmhHU73GjP1MM6z9taC4o74xpqHUPztsR2FafcZeptmDdoenuv3eZEawZ2uzM0nQksEz4wvcj43IVPe5lCcHzXvlYrm7zYoqECUwZ5z1Hob30ZMnaOrcJmpaFBnXUmm8onYXc7kEhYFsipezhlJaRlGTTpzB5fIq71LMoSAZFwQDl2LeAaTO79mqdllL+q8+yeZLW7LDHWiLoPYxxphYAl/yVEiQFi+ZrarLcNVyzOPSKT7IN6+GabjnWXg7+wISiIolzf0NbZmx8Dve0r4sWbysBujIc1AY31qRhM3nIM1/GDpUTuO6r1hPfPD/1jn15TjDnirLYQNdx3Ad3kYH/Cc0Zx0pAitOM5HMDpKOH+RIsjE/vMQpLy8OPWq1L3b7WdA3VjjetIhjUGpepPfwuQClb6g2Z54z+tw8rZEdN8a2/InTAfoM0mxhqZ3LWBlV4sBLSFDGComSbcQcAW3krPzTVEugWZMVDkjh7FqoZhhxyUEy0QiubMWQjH4ZjSH+uPcWGfKJp4zDNU/gyx3IGLimC7iTYiFBqVHVJC+nMwyJRZangOFftBeUcHghi1TMaEoUxIInVK4IM0AJU2QGhmCaJDavgBJNZyn003knxTNTTHBIfpdMw4TmNGZ69ciZHpfO6no+rzCQFwOCxA4FennKeQlICd6U9tC/FZX3QJPXJlICTQancSK4KrJjE+iNWkVoF3Gxw0bEs7si71yOJ53TKEFWoiAx5URLGj+RpXghWREvzWwY4gt5q5dSFItlXuBVbKGaIeXtWKaKrJdlHSG9HRimiuwvyqUj24e2ZnXahr8e00bXrb8Sn+4hT13GxzF9MEhprjzlM9AvYPZW7m+hCNOQEZrnQNGBYPZevfQ5FPocxmZ3ziQ4mHOlIcxadHsnhB52e2TKMe+w/IWbzNn/Dft3B/+O4bL9j+Hfg6Rc2aS/ieDzlMV6NAU8d8on4T/2itbQcprCMwTeblIAJoRWuGhqFi9CUyWvY8HtRU1XXI1UwwnLDEUzwOnUbqwYyVYJTdO3yoZze93W5zJqlrI/cb0dxVDVTwObnMgC0VWJSp0p4uuJrW9Yb4bazj1tZ3KnK6Uhu5JSyDH34R2PrtawLYCDbEeP7T/KyS8PD3fkx2+/LXPDRQIHHHAngifMrqvJEuKnnzHtyZ3+x2RO5c/NbaYV1Rqy3HIrBzkXMjPr2qOzol+zYO9sqnuwE05QC45BAu5GdtNzYqQSELEGbghqb2Wdo84KbX+Oed1caLICTWbGxAWDHegp0ORhKYXWKVxhMvlIHLrv0n4kDr7EgP4h9FuyziEHOiJ78sdW8505EHjMKcuY7o5mCU5o+YSBvFPG/6aqxhJuWfC+nwdo39+mHtRt/JiK4La9j/SLWRVqrct8mKnwDvP6+IjNfaSczMC+rpqtgvcAbTqIf4mA2kISAQqNBs3zdGXNzmkCGTrNhkvKsKmbSessa8WmBzPKjXHR3jDDKo2wpHYfZRsxUzEPOY053S3m6YrVMc1VmKvU43bSxDsBDvAW6or0FGqNCe+Sx+92dzymQDp9sbctEQt5VJG8aUG8vi35SL8EpwzU375z1ZgBjMPOU0u2WEIrf8n+a43V0P0Ner4L43rPaK/DuaYadjMt/MmaNbon18ocnFnoO+1+SQ4zdcT78auL6WHpCkNfjP8m0iLDhXmxMtbs8EO/D3op9icqDtB4adeHyM15lwkenmJdFBpdxFwbl/cZISlzTKTx0l9r3jItxemMGgPHuNKUx3BCXpZGPjqIKDTSe/yfO4Lgmw7MljW49EbljV0Gf0nmGL35lA/BGWNwNEYJG35gyReFod8WRHyswbI1O3Ygx/GwNoR4INhfCyjgBvhCLwfC2+Cq2dybelcGsV4o06iBwrgULiEBNesAkh7KE2+VXjEQbfWN6vqbT6EccpBuSyHvrj/dTd+TBFL2DBIS/87HytJ8WNvl5vZ87WJ4VxdTt/jOyKNZZy9ML8M8AzvAdHpZrlHB09UmtoQ30qOoqMvTXiN4Rd7xKrtbC/Lhx3/8q+EYva+uE9drwTC8uSik0hc0NXZsAG5UmP6JMdeU3BUyFwoQ0rtF/uH9CakUlHzKNcuQG79cXpJ3Sn/33l5ITUTq/xZ/975OjKU3AbP0m084daeWxhIS43S+M5pmQBB8FlPCqH2u9HcIASeWkFHGg4u2mWFYq+BQt8rhZQwGB43A1oWC9jeHdsUpoyfW+aFp2rLn7gHoMObFALChriNT1VpNQ5J1naTHIGgtRpuHxoWTn2xTbJ3kYpYxXXvRWfro8YfDfPT4wzF99MmHw3z0OC/OkNNneSsx3BKvYppCEu1Z56ZuSWiaihjv4K8mH1DvCg1haIBKcG/8dGoOVaRQ4O9HvbPY/d7OEGKNUISPfjpp2fTgcVOtnsndY2npyoUVYsON2HyrCA6+m/DO7OYxCmKgWGssBG4ZzSvMS4rv3WUBCVHM/IXZqhspLTg67mjTqex9Am2IUYXM00JFRyDKTVWnCC+n8FKqMnmcFBwjR8FZw5oI87PJ3eMER3C7t6vGxxT5E6TYllIV2Xeg3UUJDiYVaekk2KwVLjTJKUtIIl64Ibktb+sNWLOil4UxoHFhK/ok5TWmJaGnWhvoFyGfzhg/y6nZtPd7TNxNadPKuxnwkTt7NqrHcedyIAjjGuScxqBaS49xX4LSODNdh8J+iqIcZKQgHsECtmkL3Hy05cbr2prM9RSJQh9RSLuj30NIAUn/U6TE+Nlspbd/lO+rHXT9aA/x4TBHW2E421EkZ+kK5LY7iZtV8fUFd7RV94qSG2rFJUw9MXFmTgPHkxxKzS8y6tx8Q0UpD6WFhCo++kxZijcLrtjmHnJrETqS3C4qsgJx7U3hWmLw7PYqYgvTmo4it4DUUQXnCQtktyeNm9WwWat4reC2Ek4VqGiGZ469xGwhsnWS2p3GSS91Q6y0XWI7nco5pjjbcanjLrxxxdmi7vDVt480bWruWbyE+Cmy6a0DkXoPuZC6Xma2Fl3IqcJkD6GX9Q99urDB5J5RAFGYCF3/zMWOU6o0yRgv+ooKdxAZ2fGOTOsYhPh5XoGUboltS0y5acRCrrMkxr1bQPPdze4hOiFdjbLNO1b5KcvoAs6Gri1Z1T7E8cvy9Da0tgu+KhKM9QUHrAFxzRMWY5K414Sy0GgQfmaKADe2qMeglkBzyZ6phrOEq2jYSv8YULajk8vbaa0Sb+uEsCVK1sxCcZrY/PMO0K7vnn8gNEkkKEWoUiJmGPPGW729sGKB7rEYaqt/N/m5pVY6aANy0TPO4bgyxoXF5Pqu/OSdYfB7MhMFL/sT7MpSXEJnvRU69zZEOG6Thyc2E/67f5zOmCYFV2zBMSKNk2yFdHi5dyIl71xvBvJfIgvO7f9Ty0JrxhenGGX+L9EgM8ZRp/9rPBYsCOT/LyTvN1Ckl8bBtQcdY6rH2grcPOhu+W2h48IvPaxyDaTHLFpzddNdr+bVkvIuaPwEPJkIzq3XPdADtroo43L4kK1c6KAoS7oioDSdpUwtjbPpXmGigyJoQtyNlCz9TAkLpjRm13jdXJMj/MvDw91EJBA5iqMPf/wxMJX4iu7DH38QCSoXXIF9R+cf32HS6oGgvx8H9Pejgv5hHNA/jAr6x3FA/zgK6KubizG5HKcMW8YY04CgVR11a41uCXlEHiuQzyAHgezemg3z8LOZIOnyIKtYCsKtrGVG+17ioqv0TNM1L5JzlqbiGeRw0Nt5s/4dXmnVy6f3M4hpoWxWsCokFtgEe0FvzP0aHcFq4KtfhGf6oe9e6ky3xcZX1QILVx06+Vh1ZEvtmBrKwiTaIcD2svkdKnhq0HKQ75va8u5hEn5a5hl4r1CKwqfb0hYf+ml85COLpODDCmW4ci+VNDA/zdUmOSGM+4y2E+sWYnavbUDQdFjQAdTV233L/g5TTwquWdoK2EjXyE1B6fm4DWQJNAG5ZocoS7Cf31ycx5o9Q+XpWUEOw6KqqnrN6XO5YMSoZainFKFYxtnNRfmTYNvXK9lb/8h8n8oF6C3J9+nPN5PHodKeu6iug2y8+Xp3M3l8H76cO8/LwgLkxvzyYqNuhzTdwsvx5MnhpSXI0GM/njTvpDCHBhjsIVEfye5i20+3vdDKetjVVw89qNaHOuKZNSD3zR1fu23aGJ7OG7BmExz74WZ6CwuhGS2P62O4pg830xqRWAE89J7docA2u2QJnuZLc0AoUaAUlhb1YdM6wa4IE8WJ0E1ff2iIfmZfIInu3dYXjUHz3ExxWu6utBWxqKIVG8DeQ8IkxHoUmNINPgjAR5lGNyxjOrrCyhmQHBFzLIo04V/r+uOv8ODweH/jr6lKuWASulEt6/6YA0Vq1g72+eLk//vXlsfP7//4YxRag5CKJdpgtWdQpFpItsD4a48x2P7APx78nmP/kPh/HBN/TwxgUPzffjsi/m+/HRH4hzGBfxgR+PdjAv9+ROA/jAn8hyGBX989/6PhYI/hT3W41m0nAV+LG0Dr4Y4YoTPDV+GXMiN5twhixzFtDJa++gHtranND0jQev25d+HKMQS06QKsM1RaJ2WJ1Z5s/QWGPdqbhXqCoV83hl0JZSf+FylcPdO0sMl1Q4Mr0s3qsmDPYMvf2fCcxM7HtmCFI4ZyshTFmiU+QnRpr5jSLlHSkYO6zlwEL0MFVyzBiKcL975iyHkdujIc3Q7ouESVQ4M51TBHDOTc2knfaBDn51S8DBnCXBPAmafiRZF39cuT9+39cdN+1wAePUzuxgdvdvjRCLiZHoGAm+loBDxeHkECj5fDSeCvuG8cIQ7Z5L7RmSXliVrSJ3/EcSWe3eU4r7BUTQN8CMO4ITbS6C9H1zrrlSkay03vUZ+13rrbsFw0bKtC3CEtuLhHO3b0r+mhaXojh4wTwnicFnit/jC5++b6bvNtbB36aALpgB+q/ro2DSiPv8TKDily69tq0xrqJneRtV3RPSgYMjjfTthQoMm7++nD+/pze/sArLw8EVvCvrq5eBXM++ZMGcxWmV6d1Za9ltWW7f97IhryRPTEOCh2WGVUN8axzkK2zN6/7KSdZ6FX7B/6T9D3EAuZqGio9IZdGqH5h+/YIRqCcKBjl+uvdEIyoKqQG5t+9St0QOi1NlZGyPMFfGRpylxq1bikL8rnE/gATiIWfJmZpgE4EtM0dYmYdGF0SxM6HDfMv/MFZkWaX/muwzHUMuD90QOHsgWBgLewO3Ia2LEWVPAkXtsu1UCzrWRzjNZpNnGOPrmn7QEB5bPbYRXO/e9R+rhIR0rHmlJLKpNhKXPddo9CWdBRt0tk7qX0UPbimsciY3wxvlVslWwJH6nkhfarqG4ENhFmK+Hb7cIdHrAOipkBNeKucDzEFV7+V8jRzdzxmn0c/njdHpNDzrjhs+MhOFV+/Qj7a+tQ1suaQvkU/4q4qmDyvmumovUVzHgHIQOYgYokb+qO1zE7MHi2c5g9VgzuGlUa/Yo+4E66qoZU1uM4HZ7uPq0d1vkIiNtGbw/boptn5E4L6Yr2U03MoUe7dnK4XlHPIYHkBFkypn67EtFju2PtqIHbuIxXjdVYOlU5pD6hmo7CgmlpVY7plga2zDPjlfng20S+hmvuEjFcVjK+reM0xUhkIeHVWRN0F3x97mgLxrdIPjZb7oEmYdeisoq9T2Ae0bBWzGkFCLQXUVm5Zjunt5fOaTEzmGbwIKbmnBjdUw2j0xg44IqALbNuow0Ub3qURYWj+PaquExUmMVk9pVUAk1WZhhsqoRvJGq/diFlbJzsej5IIiRh2Pc97OQYXMMir4MKXDRNxUvJdBao4A6cHXtHrpjq11RYtKkJp86lF6p6qyRsTyJ249zCmRxqeTSK1A4e8eimzwYPL2DJeGJcSLU+meQwYocI1bVED4aOfSJ23Qx5ne3iuEI/3uINLCI8g1x5GTupMWWrMeFVd7hkz8g1fiq4Wb6hTUVT+XWfheznBLYfef1NcAsPYbfNsDsCFA63c/jHsyyl2SyhB11T2SGOmLF3gxO+rWy9a/7s3l8Nn7BkVEHZXKR5wauHU7jyvkBcaPv23ideBGcY+7F9j8mT8D9RMBJUkbqTXjn0hmeHrhTS0ESyioH7Y7sEmtyA1iAHQ/mzkISqFY+XUnCBnSY80JOGF2blZLWz1mMfSxSUBhGDYwnQ5DRFqK4AyKxwHuM68pRmHOe+tD3UVj+7o9hbprQEvRWNhfNTh1GwqvuaK0NBdddKUjnwpMwOMovIE7Emm8MdbcZcC41SMhTbSboT1Zpbj+rieCC9sPJ0ffAzirXxynXqq8HbzUxZZem/SDZ/2sDaSZlWelVarMG5XGpAVV2lKg1TKQLYjv29WB+5BHxTmYyE+mfXBvH3KbmHRcdqtAgr8DMwuGuZbp5W961E8K9dcyEPvkrljdek2wTOVSe1w+be7CQhInite+Te9MRbN3jehiKUHnkGic/+zX+kjLo1YlszifkmtpKEPbOkSjdrNpbsIbvqTLYrA0JvZtinR9v4MgNKsiz29foUGQ1OqKxLyIaQ0rRXhEy5hnEdj26oXlANL3R1kPteDdPjwqNtR2f9BZ11c5KRNH4i2JLOsOD2/IG4MYwrTm25f7tbvLlMMoz2XPOfpcgCf2pgpWgEety6DflUhgEC/6hfuwPQU2Tr6+D1SeqMW4X/7W6yAfOnQj+IsflcNtZxvVtb4F20aHtWI+wROe2Kn61FuxOzq4e659YdH/e9buX0YwZgDyXbwL2q4rZjPzEO31zsjBgPlHdC6vPUV1oZZSNpKgMWg8EyQn43J9Q74rmQa5xo37pWFCMrg/PKtKRcYe/FMMrpA3hYmNs3/EhS95c1+7lNWr+UIh8Dvc+JTyRW9+6weBuhjb2HtLpGHryL1ICPYt22xryTcXO4R95LWg0gh9hNQuijcnzwHaW7iNwYdVyDR6TOWjTrdWy01h60TA57ciGToz23MMf5y+lhUWzsOu+7Eu/T7Nq3lnMJfV+tkdzaVtj27r+ntb+FeWqb49ca75etoLqbKxzUx/sg0hrdrm25Pntcl5QvXP2gb83C+q4f+/+27n6l1t0J1XRGFUSBERmFHD9Royhk/cBeQzYrG16dUck7Qe3V/cQ937p3x25ySzN4d35/+x5VAGi8NMZxM6g4paqbV3vBmoS2lAeteHyffMoTkkEm5Kp6io8Y/BcvLza1ZwzQswS4ZnPW6rEyBAnUiFWeqiLPUwZJJfxqVndVW/3Bv2AqOPtcgAFg9b38hhl2JxJtr7LhyJu6K2dVy9MIGum4VDVDaQ86pp4ivMSKEsj1shPbwe10RaExhGY20+tPiryTQJNvbBtCf0vynrxQVtZyx1tQ935MPXVj9930PqeRLYwY0QVwHf1HzMaxGO4V9/TXGzK1lRjPzYTETBi2NNjYfm4uAczWGdnVc9QezlXouWrmKClPROa57kD1Io+UFpIujtgJtwe2w0FU3ttfy1UXiwoFSYSnXFuuNWLJkDrii5gFM5DrS2suzJY4A8CNJTmzxaftO4s7ofRCwvTXm27wIjXnlEhCWa46UqnQUUoXZ9lsQPgpXSww+4D9WRp5N2v5GTrUQuGtvgaZoZH//fzG5sL6Q+NO9GHnWibybknsaXXajz+MBbFXncZ/7Wx72ocPWYD83qEPq9f0xF2HH6LsNh8LM4TJvZNIsOUY6RjtWjLfqtR6EOGuFErk42r6680J+Uglo5cXJzbbqJRSbZoef0O90Nx6xa+0/A0Au+JtqR/BW65GM8UNI3Cl1TA+VWXCu6kMLUUqFipyZSPa0jxk2aFiBqSYA0BgQMzEO60n2zv4SAvK7t47rqjPBUi2vdLshc7NUV3gbQKVAE1SET+NC6ucxedRlC7oJny2+TJuYa+15txGW6utdV5IIWvWCJvI4GTrCDlbb/aHpyO4wGFp6tuZNzS3LGNTKA3SQT0xW4DAbjVUkx9PrU9XNqpaT+aG1TgmnXZt4jJtkFlGEw8nE13BVMQ0fWWH0Gtn3cRryHIhqVwRbf5mkyiNSd2kpalYMB75d1Kj2gR3oMAZq2u5TfZAl6nRZ7HIMtYdXhvM2ts5drHyAcAEUuhpDT3cdoRzlHZ/F3RJOi60y8uboJzwDsCykYExrkBqdUKKPKEalHUFLSd3QmoHOgbYfQTsqtIOCq+0O77FczUfmQm9bDwXweauxqtzryS0KO9yZisb3Cu9eecZuJ0VnXWzvzprXRmuPVgQOVRDsoK5Ch3k3b0d/H3FE0nncxZ3eOdhtjuyKy6UFhnIyiHyPzas87HRy2n5Z/RCjIkPrmjMV4Nz8tZc8ZIZki2i0AuBbHlwo/91+GJcozEWczOXuyxO0HZSNmJUkELPndJgJsfOsY/JsQZ1XHR2jn3QoWc4LjhroYLHfijiTRhTVx9jR49myFiLg4BLqOX0oPHNwlJua8nYxbMYiwYMzCUwx0ZngpOU8kVhZPXu8vLmfemX7ErZDq7JWJSt9V52pGdHB2ZckvyS3pGGnaz2ABQMZdQ9/h0t+lgyqBv9HWWwo90fi4b61rAjDbvtDm9QkXY8bo5meWsn0i2FgFexLrLOMOz8SvGUICwt4rjImQ36zRincoUhFO++ZtScS9o3DDbCJtdeJATkNi+4hr3c6oiyBxMSMyGZsxR2i7UH8JuXBaPDP+iSIPixOrM5e6PGuMrqEcG8/jEzXxhNotyfeKusDH8i3ujahtTMUhE/QfdOOBQ5NTKakfzqGZ9FsvnqIUgOSWaRO+hHY6TC7Jnc4iPFrrpBTNPU2jh3AK1uAdw3NxMqRevh5AF0XV4QM6AiKXsC8vv99cPVPRGS3F+dX17dnwwJHPiCcYjMB8Phv6LxsnalKwvueG/nO7GUNa9ug2tbLA+g424CKNIZuS0lCu60h1wnzQtrWd1Vew2SBeduxTveY2am3TBikeVUsxlLmV6tudVeKytH6iIVM5pGyazcWCCJ0LWJmNhtT91A+nVovP6J05JLZwyaL30770srgNVzgFyyzGy01aPh7lsbW17BWpf697fkjjFbNgA2B3lkvlQKIyERZhezx1UPR4YcsW5GgyEHkR56HJhNMxTl/sH3VqSndGFfkZZw+MIfadfpw5YOpaPaDX42Ip0uZeQw+mq3yPtQF2X0y3AUhmlddZLC2lhN8NYWG5Pevh737kIjor8fqYwPTCrjb4HUGY2f8IVyFC8pX0DkCjKdxRLscpV9p+xDszvLqYmduqwFhVP7Il9z9gwut1OhO4G5EJt2pl6ylBZyWI811kW9d1IfWbVkju0JeGE8ES9ndp5Bzzmdxedcs5uKCju/vVar6G1+vi0VaV/s71Bt8i9CqV4H03jhKqNp6lv9ryN5juUobG9XW13MT9STqmfzIlziEI2fijySoI1/L3jk6pMNue0/dNS3sPOWORrlDSZqnxZEFXkupGVSLhjXp4yfohMpARcHmQPVhQT0FusXpJXSfq38RCWBaxWhxhrFaa6WQr8aL1ypUFyNNE09eR6XtTO048iCifUsAWykvBMDYhovIVoyHaErejYrzOobkPZ6mfl2KSRXuca9ebLTW1TbAbYlxiIFQy7f3UDfIwQFeh1ud2YsclynO2QR737qKo1N7TUWpp67sxduwmv3X5moSIvIeRy5PWOqz2m0Zy70jiHURQBwB9fx/nIanodL+rUgAovScpFAGa7ZuNEVuc9oi2zGYGRfMr6WfTDL375OXYnCxpdsImO4I2wZz3CSdTmlKcz1SMRJyCjDA3/wYAPDmL6AZjMJsaym2s7PK+3291FCWbry8vmqiXWXB8PNwRqvh/GzUhhjviWefn/oU+L4CfSZYn++Vgomnt1LfbVOrY1POGyduK23FIl5JGb/gVgPv7aCJ2h2hg5sdhWlaSlqfMLYo31uTzhU79wwgcb5Vv1vWc/8hmgfdo8orF8eHu6q7deWqRHoAdnY7fR7J7sTImFBZZKCe3S6ynv24RL7YlCPoYH5n1cPDdxGubzuMd5Fwwa8eTEi3rvHwfGuuYIdBPLl1c3Vw9XQqJd9GRSDYP7l6vxyK33epAtCjakMn6ZNbdgL5ZpsjkNxVkimVzdXkwfyCYWO77yNoRtYKywlkYop50d+fNPMp/ObrMNi7062Zsch1EvQhXwr5Hswx6A/ZWOutvrp0szlaisgdKR4vfeUiBeeCpq8jmSsWCoMuNi227Jtly77xljlguN9vyuMT8lMJD1vz4v8tcn1CKzMnNuFt53WeTPYT3a3nGALnv/wpVmfaUB1++HLF9+jHacjthaFLXe6jdzsiqNV4VtgeLT+lgjZV0jHEfbjmIT9+OWLjcvIIxLm883mDIs4rTTscBtzeNZZDvLU6xyGfsqISCyyHHPPSpXEgtJhTbcuFmhRNXUpFyWW6cGcohmUhnc9P9CR96ebo7IEUporm3HTwxqUFS7kih3uYh0LduAnyte+X7d2y/MgP6ximeLHrFg2ve2uWPaK9XzvbHOZKftziGL3Rg18BYsMlKILCPrX9FfLm36cuo4791QPBUS6GjxBQ4/px6nHRRLbA4I1H6GGuG7R0H2af3S03JWkDFuGsM0rswLsG2+3Bm6nRIucxVugvRUa060wwcW1uhgPcq2PWOJns6ulmwJ76WTbLmEFep7gvdOupNnejmPRhQYgwO5eCmvhidwVLUu14cynYuhqrHXIaLPK5kmzVb3z2xxRkFykLN5K9ftoOL3mzzRlybnWks0KDUNXhj+AqrBrYDnO14SWUDGCzywB5NTWePtCzb59Uvtt+Qvyf6afbm05+VhICbG2qYwZ1WsbBGzk4q1wtuUvw0fb+IKLgJ070n8PiWTPwB/EZfp5VGoRKl6/ZcI5Gx3Ng/YyOw/CkTE+FVjEmn9tPMk96Zh+nH4UXC8fxCXVMM2B68fp5SCg4yWVC9vCwbK7XnvStmmjUpeVC10yekxT4AnFp7J66R7/2Pp0wS7ddQXw+UCX7/NRXb5fDyxS6yqQOX5EdLHTFfYAz2vyXIovLMPS6VVXIguLcMFPbbg5KR0rd8fboZKVE+uEm0BKV8MlX/UsohBQlUng5sY0pnZ5KgkUdZFlGSSMakh7QiIlLVzo6Jkp1vZOhzlq122C3cDIPGWLZU9Mo0R2FFRN9mnJ4Jmm1eFvS30wqjQuUq+vOyHz59VxoZWx1dnKd4AWLtEFp3e+ArGvXzZAVu26zUPLPEn8ZrSGh5DleuWLX4xTFrTBnvO767KBNtUkYXaFW+4S6gnoSUwDXpnbo1/ot07P2/HYfjTss5jpr1NnM2vj1t59sUGaKNWH2ruRkhvmL9dM6SjdiBrM6fcVfQef8Tr3lIZ3a0xl640jNdzYFdjw7Ko1ptgPVdn85SKl8dNSpGM1zyi7wFSnxRXJzCI17hWZ+emJFK16zGtg34p7/P4RQfudAsETugkwLpWR8bpbvsHRjqUUa/BuoRLlRaM6NLUQR9h7K1EiAzy/vdm9Y0LTdIzWTlUPdeNF1UsOGt/GZu5hYJfGMQLoxejbKYyBs97vvRRT+ca1CdJlxPqv2RPgnPHK6CcsA65st2+lRMzQecCryUp52qr6nPODFPU553ur6W93t2/fy3koOId0qoe72QnaKwDROPwZvoc0H7DYsEWdkG8J4wk+7VXk8tPvt3jS/y744+Od/dXFP+/cT8JPr6YP5xc319Nfri7xl98SpqoCbzRNXWI7glkTArXkX1JNN7gv29Pf8PDC/k5GIxxHtkC0yW/ZFVKrjVYI5/8FAAD//1iMAS8="
+	return "eNrtXVtz4zayft9fwdqXzKRsZTKTbJ3Kw6nyLYnPemzH8iR7nhiKhCXuUKRCkPYotT/+9AUAwZtEUZBmUnXykMQUCXzdaDQaje7GqfdRrH/wghf5N88r4iIRP3h/P/tt+nf4MxIyzONVEWfpD95/wwPP+x1e/N1bZlGZCC/MkkSEhfTgfXiWxkWWx+ncW4oij0PpPeXZkn67SLIyegmKcDGBVnKRiEBCP/MA/nqKRRLJH6j1Uy8NlkKjwX+K9QpfzLNypZ50gKo3YjdUBHM5+do81u1ls38DbusxP/D5V2DIS5ZH3T/7y2C1AiLVu3//+u/We53Y+J/HYI4Ne89BUgpvFcS54g/QChyRWZmHQk5aFMh3k1kZfhTFBP9uUdLGugHDLbTgZU9e4E3fearVVodRvBSphK+/EMa9J2GyYbUgf/X1RInc5OvJ11/tiDrKylkiDgFaesUiKGB0izJPRcTjXc0F7+z+2vujFPm6TVISpx9F5AdhmJVp0aLInhBd8m83FUe1x/2Ss4Um/Of60islUFJk0KxIi/hpraB6CuqkE0NDdvdEwXKce0ESB3I7IEurLESQFIu/NdloNNJMFEG34kM19jN9rdVbn+56egLpgbFDNEUspJ8VC5HXFdpTkgXF3zYS+7gQXlouZyKnKatarVQFgg4KJh068MQzdOi9LDIpPFkERSm9MEjTrACivGeRxwA2mmyBuhJpBOLuHCvNg2Wwhkd/lHEuYHCwjW1wsIHkWUSHwRNlHrLHQErXnbCAJX4RG/lVSgNY34nBq4HA16BhGCRoAAZHpNZYQcMwIt40W6onkliEmBbBM36Hr1AbDUT4sh/k6d/6p9IGzpRpDFpHz5cYmPQEk8ngmtBLDPHs4RawSPoRXloC16DbH2Am/cBT6Qd67zQXc+yI//pmevXw6/XF1TdXv17dPvqP/3t/5V/cXdb+vr/5MPWvL7vokmEGijfMIjGGvFjC4ppDcwXQJVciRAolsh5nIRGipjFTCO8H3gpWAFhjzpbBn1nq/SZm3lTkz3Go2YC6JtUK5VS1Gmp2nSVJ9gLiRUs7NAeydP/h/Ob64sQ7u7i4+wA0T++vLq5/xCfQ1O3d7dXE66CcVpwQhnue5etxY8t48SNPN+QhKyfefSZlDAudDTOWshQnmrDbDMUBPoN2CagMFwINvehiEaTzTiFkyCPHaqsoUnMsj0r6YjI2fSVh/uXV9OLh+v7x+u62ji4JZOGXK5w7u8zeGrhlJlE7hAhk40R+wVWI+6qj4GkxjjVtYZTeA7VHjaNiq6ZsrVfJr7vqttKfs7WtJX6EkRKfguUqARG6uniLEvNwOW1gKYK8GDsAG5k+E/MgbXUGy94e4miPuFpDa2zunESgrWC6hAksvNEJwS1XYbaEZbRrwli91zAW4tOARS0C+ySGCWm/s0ESWitqrcsgz4P15j6vU5532E0wy8qCFyVobF0Ti8C2jxSQzUgmsIZo89aPI3dzRLWp139a6cMsBb6lvI4Z3EzHFo5N+C2/zJNxGD883OgRGtczidq4vsFcH9e1a/VJE5iGgiSVBahXbbbxfNkzu3fodjHRvKELIw8gr/bMyLaV9kPLTKM3tZ0GP5yqeaJ++Ua9EEfVfmkWJwlvBuq7pS63kHr39/pUExIGnjYp4SLI58BWpGINRjjpCz1V47TPQ6T2Y137rCvd9gU3XWN0kplNTA3t++BTvCyXPcAUJmsfqzu7KPNcpOFgo+yq1X6oWsCRbTSuVNdtsBws3HppJktAS8ayj7h6d2fLLC/iP4FxMCd63Wx9HrVg2emQaDlRanBNlyAeMA8bbeimkTO7OrgaLZsmdJvnCe2yjkeq6tA5obV2W2Te4qxPkA8fZDAXZ3a/Bya46torsW+XRPe03ab/Qzo79kCbLp0PdaPlNrHIil/KgDT2kYgl7v+h+nRKbL3lFrG8gbAsju26Eb/gvQNqxhy9deIZzyVQvyNLZcu/s0v7V8onM6x1GhKw+p9ioKxp9A8ZL0CyK0/RNJAFHcUoB/QqF5IcTAEdQiDywHhHooY72RyeQF8Ou8YlihwTYHhVHdb5NFubQ4cO1/2Gw4YhPvse8xBVBmxkV0mWg5lFOHBLYw5rpDGIQmOkDLKJqtcbZtHSPh14EWBpwsfBSp8QmBOz3+iU4GURw79NAx3nbJL2w2svisEQzfEPxCtXgTpR2n7gZt4fZ12bzy2Zou06nW5YfPOCVVwZmNEa/ptFs0Hc1C8fiZf44SV1eXk+1CStDgZ2m+LTMoSRkk9l8iCAY7K4AfUCtuIkeJ7vqKgbh46483sWOarYhNvE2SdNf+T8hg4lniJoctGRo3bW5tG0yEWwlLXWo1LNc3vYaae3EnmcRZPhhILpujeh2vz9Egi9S2E3JK5hEf90L3LcdcIQ3OfZHJShdDKsK9MsEgq70lUiyE1D8y7wUvHizZNsFiQg0iDwUQATMUZA6EOdCSQoiCI+Pwq8IoDe23QA5OcYT11F9FseF+IigFkO6/QHmDdu6KiOaVZVX94LduaFqjeyCqRatQgpeeR76NtIxYMIomMRAYIUOaPhIktluTwUAVpJVIR0gQ8VBi97VkcpndPhpNa8zHDDj2eRXpEH4Udvkb14yxLUMPRCrgCbZ8UC9OV8sSrJ1Y9Hu7uwAh63WGG5AgYwAlr4i1B/oPnYloTOufjlM8OZLHzJ9D+IVaJO7A5hM4gkWElNERg+LwLXllR7bT1gzBJMupUIaIGM+XzGrKWS1lLUebUegDrcFyBw1oTsWyULrt1ikFL8hPlCdaL0Zs+61cEXlybGX5Yvj3mQSg5ygKnzBB8WewvMmRKWXPybjxgQ62kinoVldUWloECVqn8AGSoI0vAQnrCjtKh1YZrJmEiJbk/sRg4jcU9dkBVB8qWQd8bu6T6TpoiT+E+Se6eKoG5tbjNySkJRHRi3QsP6iakr8qNT06nrB5MzXUsYtKs8z3IX68+OWxZWFHORApFFPdoOVNPPj4/33vdv3pjYsCwSIzY2MKWimOX7YiHCjz/ScbTazbkgurI7nvikOyiApyvmAqDC8y2cRxoFD1nHRLnnUDZrJbigUXMJkbQyK33Ffjw2REQFxipkHSq91tqsLPgzivfCwK+1wMgG+NhqZOQKGESPYEEURSKuKLhsT8ofuqSRwItPoSB7RfRrhlpTe26FNFmuxG5nyizLLImXcSEbyzjQokMNvVcS7btA1khNmbTXbdpI/33ecavrQJcDp9T9++ATSqfsNMl2m4raINu8b+UYEOD6THC0MEaspN0RyxRBSKMKywvMapyUYHcla57Op5FYklGG1Eskv5t4ITeR/4hf36AJ8QUwohpJJqW+ZWn4jjCctuIcxYC1mFJULIT+pX023TB7gkgvcgrgALEi/AB+GH9/49XhEAzutBW+LA4zxIOw+Itg7PHnMFBuWaskZ332t4sN53529yKeL4Ss7zVabTRkc4s8DmFIrw1/WI40xaabGfYnHXNlR26YM+SZHHSIBe8d8fzq6nw67Phv7MHVr1lSLmkCnK9RG+y+SdPOAwlDhcwVAdBHcpmtcB+DJxrWLkV51ch0WRVoaj0TBInbhADD1fk44zYu8ux0FqCiAEYVQRqKEzwayQVv4YzPonY8rR93OPX6NkTMAhJ5pzxgcfxLMQHl4G41hgM4sQvysjTsF0O/JJdXCwoFccJ+deO4uMPUGJSRoH4pRQnWSTovFiNxNbiEi1lTXozz4CWAnTJKToZLpzooJIkYAf3R7HyqY82RNNQV+fU3dzZfV5i8Q6rXe3V9dz99Dd8nMQikiHTcLo8N/lhbBZ54f6V8JKAB1eSYeB9wHrzExcI+D+QGptNLM4eyNFn3kW+fPDkRLRXPtmEgpfcqraLgYBDffv+PfzYW/tfVccTmUd2PB+dlLovzIEE9MoLqqu+fyEeVePdlvsJ0Rez61Xz19vWJVwmYdwffLYnqny/hd1l8+5od4hdZop+F376ug2a6oF/gRDN1oeiUuhBkCo2nVyg5CMKjcFoDo/Y7gCAI1HEOZmScWg7+GTKoN7FazSAcd3LO4IB0be13V0s8UySOOy/+QZK09KdKhNhv+mPH7KI4MPrWLHAB/zpKDgF8IxaOx0gzNR55mzI2+srZEh1+Vvi9CN8OsynDt8e0KS/eDrMpw1U5IY5NVmE9JFeGQSIif2Aeb33GgmxkIZ2xYcYZykNZCHuLiA5YdcaSoNGOm0V9rqKNoEkLKE9yn4KMByUS9OUaX9x/MBrDCLTdNy1Q+FZpbYz68MxY2e6FSARUe8AGxoxKK0yYPAJ7mryEF2WMT2LOakyCMiWDknRfkLdSdRCsBDWeYHaOO9CqyTpicpKTc7xSFTC+Ke30LRuXpxx+BkRfUAtq1VLVN2Lp/SnybBsl8F/Kn4j2I4WwdhKEsoq+iVUQR6B/XlIkqT1evOrxNC0WJSockHDah0bmGISh1klKRfGS5R8ncToBcwEWJTmCkqbWUy1RMhVYZBH5u0Fjq84AXCHyJww9bYl+nOqSMbgo25uJfsQ+vOaDpt1Dg7SxW+Yl6Ta0DgaT0Y0YmjgAk3dHtwOTLch/FS6DXMxwl93HYp39Zr+0A9vps4NLNvXilOOM2+L37iT0i8jhGX5wKT8Cx/eV8CiWH+NsglaiO44Tt7VQB8r8Q3SGjxJMPVH5j56DOCEPqCoqswO/WwTsye/zCrbF5tEUdIIlW/wg7LaP7w/Kb4sEJwzXwC2ej6ShXzzCVnbigFWSmFttAJvb22OJNhcs6OL0dhouetG7kPAhe+JO4XExHO39+nEE381wtNDvL/27jAaHZE1CDKjyOexpR1IexCrLi3qZo9rubhVIOjTNikX9Rx0Ohn2rcFN4SIFs9d+UjwsrQ3jLOC2L7UT43M6BaHEJVLd/QKjdHN8G1ijTEKSuY6aieTEX+c4uCmhNmsIB3RrbPI2X6OUfW6OlqkFC7ZhyfexaGNJ/5ZGiOiAjchSv0whjAK06YKaQjuXuiqUnUpzLUQ+QVR4/YzG2KJV+ukO1hoZDi1vxLm+ntUpOLYtxC4p4VZeE1fCur++fv0PnAWajeSCiWRiTT4288zthocJp+zKEq681+bFFKlTXI7igCVf9XOHkg/6v780vr5BBr0FblqmprziUJSSik1almsETlb5v8uCEIwi//cfpLMZAHhnPU/J4UaMbkYwfl04k3itVI9L7j5eXacr/JxdlgaeMp+TF+o8HLAJtRjL1H1wxKSFb/6+IXvcgLhZoGLEhiypqX1Wn2qPlXKs9y8GeDMs8hveO6WC/OT9s0MY5umfS6CJLU7ayRgas11kdmuZstqB3s0rCTdZYmwY0bCzRp6yzImgBzMAgVp7j3NghWK4IFvjccu52xGRhaPsFzDdfUea//de/9qSGouWhGYzjXQFJguPldXA9BRWNBPfODbh3BwH3nRtw3x0E3PduwH3vFBxMV5dcC5OYSsPilCRwso6uNVe2QHPIMywrCY/2gaZi0cclYjQDX1R8S7UHJViVFloGfRkutIQ/B0lHBs8qThIMfBoPsR3HpOPvjVY0qWQzEQZ4PknwypwK4Qg+oEJ12TG2VM1t/XOmmbhr/G2diVwcbl0Jui39ZAxSVuqWUZ0icjuoaQyoXra9IgFMEBUI2+vmKL96vLB/Nedq2kqBBVGHPwUtetu0fEgds7hM3TB5fHpvxV2KR1C5qye49VQRDCdstlBUFRdqbC7IZKAUVU4as7NDVQK9RZy0Nry5KlQuhVnZlQIG7kQi79CwpoTe2c35GQz2s6gsFh6YcayoquPVjBcVM+ChONnyFVDXzCBWzlLvCNo2i2Fj/Sd8H09niy1k6vCym4sP0iF1dTCNmPFX0NlrO6L+bGUS5rwb/PK8VyZt7Lfi5XDjg8VMmgNjW5KHH537PEMjVowOdO4jTR0k6ea3D4Kp41a9MnQjU//kiHsai5yDb2+6dYWLlfwzaokLavPxZnor5lkRB2bb5sKUgmZrxFClOtuqU0YpX5oQR7SrM9MPvdwgulR6SLuP6oSp5PiAOiLzsdto9X+MP4nIf1BLg++Ctids8tSsNkFrh1rtTntAPYgozrG2nBurlRvbC8iHPPFvMObJv6IMTuDZ4bCFWZlE6VdFPXjcNlyxwLVyYxs+U5AeigQv72jQJijbVI879f7rn1u2LbC7dUKTtVVm4hAT712IOlBZc/JT9UzK7Rs/dzB7tn8ucH7vEmfPXtAJzjdvHOJ88+YAAN+6BPj2AADfuQT47gAAv3MJ8DsXAK/vn//RMPBc2Asdpl17caQsKwTQDcuhxwSbq7bVJkJsmOemw7x3waLPZth/7uH+joB3j/uDcg+5YPg2B32nK6oOeUFZ+pxXGNMdVc2Ebavp4/j8KiYP4meJpTSCpOTgjn1BlMn2YZ7D1OIyIOwuyekmG06sVKDBDFpkZd5f024PL8Covf8QL5Rj55ianlbmBtAeR+RRUm6zI7joNqEw7rtq460OgoduuqvXj7jhvuVOj7TZ/jHJXvZx+WzYaD9B0yCodafv6/a60KfnGwB9WGzcg8QVzDnQm+kBgMIG3DXQD5cH4Cg0ujfQL1mPHsCf0+QmjjXstSO5CD5q01iVVFOHYmnVd1XEUm9BcTllj40+XOk0Cqup78oc7Bn2jVahUuDKC7GxgJ2NmSaZMzO2f265wv6ZjFY8KgqTko7TQNV9c33ff2pTh+iMwR0wbdHsKgNKfP0iZ5iNXM0zloIOKi7ufdYV6BYV+zgh2wes0J736mH6+LqebsYB6Mbpm22Bh5v6Y2DbNdYAsbEQHJ11zC5mHbPx/y3pLkv6Y5wKGQ+rnKTePZYNzeVD/smdHvr+j59E8SDCLI+kP/Z4cUghd50IRjcWCcttoshUdalPgI5AlvnW4uaTTYRcFzhbs/xsLt7j3YAq1MANaXMTlkqB8zn1SRkXSWKBADstSVQgUDDHMQep3J9aKno9pygdfFvfuhOKWkSjNmWpCU7gFmkLsyKjgZly760UsoJvVQK4G3nusgQ8B4LAwsapYBZAkybjRlDUf53W5c0V1A5Zh8U6j9wgV7fZOEVu3VjTxXKVmbTvPL1O+RpTd9qmldJrB/1iGR0lxfXJtw04V3JktaqMUMqjxR5oRO9LxSOaYeYvm2P91GvJc0u/lr1DckApD0oH2ocT5jX/cJLQT3opdWhmBb4qYLarTFe0HFANdgDdYxpWkLUqcX8zlKVQuFI6m6XOlvxK0o5gu+wkS9KFMLldVDVdfVIl3YqV3ChXuy1RzT1Qp4ZRxSXBFkdjuVDl72m+kBxirMoJkXwI+VOl1VyZE+1dn1Lsgq+sDrpFzaYuCorAKYn2BXruzabadXlM12eiU18rcUjTUB18qug0ygnAQkrowSlz8dlIt243OB71BXeqrxY6FtlYB9GuHm2qLurANgeKqyK+tTEsNKtNJvQwo6wjA2KGGGbiMZvi/sJ/gEXDGQ2WASg9wWUC7WseJfe+4lKXfF0KibG0T/VRLyeYDrnGZqiINcWs1r5WrjW6gEjVFM0x4yKm+8nsGyOsYxvipVUxAWv0vRimxpYIDeCcqxWrYpqWeTtJv9ltnQu4++7LWtxOAt3ascEYGiu+jeJUzna03fjZaXMuFnEaoQkkCwfEuHCVtIZOIN5dPCbdBB9W3R5n0NxPHkvjAJ/ztR4jxfVYcvY+HW3ZU2biXdOvWJS3rrNIFX3Vp4F6bsQ53iIxYIXcbbHo3sHbzQ3evmuWJMFyFgWD3Ob86hEjT26ow8NGnVynzyq+fP+DfRwqyWf2T2VaBYiT5H8SYVlwrpw+ALVsZP6Z8z9g0Kw/ibFgQ5aJ2imYpnvSH1QK/r7ExBVjdsdwCbbBjYCJmo9Gg5fVBHKdhiDdaUaVSjWgk4bVwHxnaand8UYpgkbBkLMiAmSnCUFTCbJ4cRpZOF1kyALDf6HPS64Zv/5RmfRfEkUG5EZaynzs7YfkbjDV5VVaJ0hEh6RjhdrInK7TxeAKbP/day5ltZEiHdD1FMoy7/DeVgdLI8eTx0Hdz7YMqDaJmS+6SiIrd8mD3H/ghI96WHVhwpmujGbYm2tm5Kps4irluRpAwTfHdZz44tl1/kzBxS7Q/aiuWwDN/yDmHbOCkVQg+eb5WiSHpkm9FWWYbkTFnjXYKjQsXG+83r2Tqv3OtHfiOFb/tG+j2Bl3uPXCpE3IaTQ8UHmUjod/JHGgZJhLX6PnbjO7wEjHCxRMmEXzoooe8qrK7EMJtVfx/UKyh6zhDkbGFIc4PnKUvCjI6xxnF0CS9A5JLFUB/Mp0ZJPLZ+3WsiCbQQ5dFmWthR7Dsr7fsWzJ92wGqxtrJ3wBnPoIJxQVVzSKldwQbARi/St8BDRKXSCyktHfQ+ziBbv4XbfH8UpdZue4ili3VmkyZc2rDYBNuUqqsMi3AseDYg4T5CVYDwscN6/3cLliLlGuLqAOP3p0jQFivT179FQbOLYBlx7lle/gUS3kAblOfwSUlk2254RpOEHU+Nt0mi22ZWNNNoGbEjuOg0sHfoKA06T/9f6iB9tdWTxmrvlmilSr+21aIJXnZDvrCJ5DzqkCIxtRDWJelTR1xia429ypyrCnqKIexJtgXVW+RNdpXXac8WBktNm7z/LiLNFZ204WteYgUiI5X8WpLAWsHMBGOFZR7bD01XU9ILAHSYADZZlKuj/D9sxppxQVP9RFfQEJP+mwFTgA9DLPVi5Q6njSKKdKiR2apBeCa13buuljtLatAXSiNQZjG6Q0FD7HOrd1icc+WteG6JSDzjRvd+EVF7XArMQgNTubOcO92k6Dy6NhYcbw3hGdpQ+XAy9opZvl9M1KQy7U0tcWqGCgwdds8Tlpz/V8DOOUL7irXZ5nyqBPxt8BthPkxg1aXHqGt7ig1ecq9/8NCvC3/3/dl7PrvvDYHy+U9a3Jtxdc3WCjAFF1+7jpeWaKtE+AxN33byqk/0Ftf2g/9+rs4fY1DRHfOxzJ/k7DJJBy924vbF1il7fWd9fh0cFSLLN8XaUVUl/6xcvzvis1LHRxBMKHh0/5HhADZHt+Kkss14VHX3pwqtbVcVb1QEfBl2n8B0w+6JDlzbyBzQ4igevi7w5/qo7dZO0s2SpercJVkJL2TRI+HSj4kVgVi1rfO18JBCs8uWlQ2V/fSe8VOpO/4asltIf7tX0db0AnSSqHQH6sY9M3KPyR+FxsxwddlRb+v7PZfjNOZahNf7nxplzF5wwb9rBhu1xq75UET7kQqOp9llqn90NVbsbqYg3Q5xGmVTG3VKctRD66jvCegkPDUf2Aum/VgFeVMXzMBPZpd8GltPw4GjNmutCG1RLepqDuQpR8FSL2NeECexzbe5/JYp4LGN86uCxB+9LPhSnF58skK/wkmE+WsxHw4MM5nYiqa8u5ABi1bn4jAwoAkUdP5EtSar+d3XD8mDbeB+Gn23fibCXHzNJ2QDHOOD7u6b16utk/kUb82nDXjJawSB3d7SJkHFtBUXF4tQhx0lKdyFUcdQzJYf8or2S2drU5+X4NTDzx3gd5HFye8y2SFXdr3TTWPfkSrNg6OtB0wg54BnHavLqgtUZJIwyFPAdmFuKaXamwOnp75iXZXPoqdbQajV3EnATFgooGnjUhsYNB8sv3FTkWYF5dBkowLEF5LMZoonbvqq3qkKKvUzy7T7Lwo5tuTWv6iMCYKH3980VNpJIPJctqQajVfzgrc3hqCzQVXKbGuwBOutXbeHyWgzVOEn3lWENSTKp3iWWcFZQTVHUZVWyGheb7U7YBTNH0bvg90u0CP8s4iXsDvvFOjIdPpgO6I5MDGxBaSuoqDH3J8Bzj6vAZBxKhSumTFvgtTn0do+5kTimDkFqu3Nt986kw4XcT2MEs48KNNuO2hmgxC0AksBqpI3VKbRm9NqT3KHHT9eXljVXabEDHS0cdg2oSOd4vXa4iDPFlE4E5MQgJN+ASzC4DoCprjerezEt9rVPVLl+SVz/CpgtpcNVXkaygebRPlK7sDhe1i/9QK6kVgIwwXAeUlqom9g4k+grNGFJjle3qvXrgRl5XNOfB0xNYV23ry454JHaEAD7DZACz4OqPkTXat3I5NY9pNUQVZ7lAA7qZ2Ow/tlKtOT6GbNiNzzMi+1G18uXTjUv0PpOpGe9nEgzbi2cvBikwtMLNlOa2dpnSrIjc9M5t7dI7WRpuOm/dAkxD0IchUTmmW1bUffaWqgsSzdZiS0ppaZfp6IS3acVzhY0cA5F4oiL0uL8K0nmJvH0Fy+Rrs04ORbw8POKNq+hAnFsWUrdQ9ZQYiG2jVhqBbF9lpXFt0VSueFZXYgN5tkWPucJWV3EDsW3Wcp9xQLeY8840TM3S38I0OiJQnrCY3EkH2h9abqYsDMtVzM4E6BR3h7gl1ObKMkA7su3p4x1+3unQs8hoOoDHOX87vGJWwx427D3FmCcwxDdmwWs675zBG+W0sz6SE449cLLnNhmPVvs6gQgMRowzS/UOojqF0zuMXlPGRjtDP9nOF4l3w63BbHreqtB+7rHfFWgd9sH/qw2Rv8/R5cjDSO0xUhl7mMHMukEZ9JX3Tr3ZT0ieJSOCiaFl/FB6SfxReL89XD9ePeCh+8PV2eXVw4kLYCKdx6kYeS3zFe5o7aOHvEwV77jdE0bePGKwjhcoJa4I6wADwu8rVepbZypj5LR5cJJXZyZ6hPUVxRXvKFKEFSYewYO+msUJHrr3n6508lqRMk+yWZD40cwoVhH5tMT6cbZ5rei9rNya/D9R896lmmzN7JpO/38FpArPW+XxEheSKlGn23vKqYI8e+vvb6Ee1QFv2J+AU4ehuxrwXEQZanHeLuhuc5tiXiYbBI8izV4x6bR0V8p0ktQg0qAHzuww3YL4qq3FpvHcYsgoqlSjEwd0qKPD/fDXTkd2Qe8vg0+7U2Afq9ch2/UImuBYl6FKbB/r6OWw4eHbjZQ4HUkK7JI/IymzIPxIGSd+uMCgO18lz2M8G0+XvLkL2jVaxXThcRcmP5+60IUTnjAmlA96JC2XdAbXp7l7YXP20ZiVKCzKer3qPti1Q8LtAF9gMcpeJtz+KDu4s+CGKlBcoeR+zL3rip7m79tQJk0fxq6jrTMHgmITDLTy5BJMI32d3CaSniglku9j4YoMuqNGyAOfz6mDYmigXIFcFGg3ghyrWg5jlrXHjhxKbt+cCZoTAZIOzBgvV3iEyTM4i9PiNE5PycjJBQmp9wTSX8J/0ZqpHzhUQvWV1B0ZQjoHska6TIOVXMC28NC0qvJCfONpkmj4un+ex0GHyUuBdjHmSBYDCQzx8iJ/ERc+mUiTWYmzYgRt9dKK7bR1la2sYoC5G+59MyAu0+BLURwc1AN1hfXLO3CpPUO5ovmxIcppuFVuJnMt+phC15Rtbt9E3Qi5iqRfZL5aMVe8t8CIyIGxWANdQ3MLwA6mC576WPsdQx/M34wKTaV0J52anb2KHvSMnoAcqeFzxPyh5x9OL85WwMpetH/nQBFbY27Zb6qRUTE2iXgq9gSfi2UQ04bNCrgkN44uutMM9jAVlKp4CaPX3vkRNLLW/B2UANL8qJENQr8ZZh4yN2T6bmhqCGZjTDAK81CuSdxjGbmpUrQD3Xc9u5pWcz978rMZllAaL8NWaDW31NE3SyssH3pIKHS+IQVKJw4df/W6NfL6GrcvYby1gufEGgfMpZs0zbLBaa0Zrbzsc5q+U7zGyKp5kEeJUMkJ0OmkG9tc7GcIEqafrh4buHCwtSzEaRfGHjyr0gGe+w/O8HQcdYyCdHl1c/V45QrVonnSNwrTz1dnl4PkqW+sMulisO6mj/ug6Dhd3BVH1dMUhuni0bujQaF8G5z4jkaNkfoyDNLUcZBrM55BLwaqL/alDiZzDFVg2Jf5scjSnR2SLrz4aX/prlv52KbKKSNoRFH36gx7oxQvTjoMR5mdVR8k1MOWFq4cXrtE3hRLBFKyqJHzU64OTYbugXmslnHrTlfEdDJcswguqvfdp0/7Dz80Ur8qnnPnuHTQEH6zhAdVUSgR05blDR5CfdsJ/HsXwKGR+t3xhwSu4wqeYkqehkHd4GXdPboA9t2nWhZoq2t2iHiuRDEGRlSo6Jldq6CLNKDYFL41k4DScOmMeiaMguqmkww7bb06JVUkwUryyW4PycRjmjgVmfq+7kD/InX9w665Yuz1dFimPrx3xEz96e300PWS7rmQ7hQ3cuPKBOpMvCUY6LAXsmrzdhQQfj9V1YKxVvnIDnOVY2sVRYV2df8YaYP1N2PRUYnwlhTD3dN7hfXeQN2vrEWbdpQ4zslRMnc7hUcrGKJ+VLdZQcfvdKCqyoi6g1arLR7p1llKu5GyU5hLPlO1wTQiv/BQEvg+BVf4aYJZGFUGCUa5MjFDUcVJgZTflYVTaI2b7df16u5P1Ku3ypI43CiafVhPr1NYfuLorICJPivHXsXqBL1d4d+08xWm8Sho5NmLGTBQSLUOPgW4Pp3UvjVfeP8zvbvl0oFhloMyLzgEZYmpQdEIbt1mao5/sfziYqNpZrFtIJ0PIsrRUfqYXSZ/OKWKIJGbfJmpxbSjEPJO0/8xU3Ddo6XiXli2dSZ2xAvrxXtYgxeACRaTKdZQ+TC9HAUuXOAZIJXTZPbVa5xwyXawpkwFDhWEh6VoMCQEbQAwLjnol+s4WKuZ7VL8Y6CJ8sdRTZRfBhYTUpUAFF1YV8JxQvtqlWef4iWVeKsqJ3O3MM3SU3Z/RcZQUGcklohUxpRiPnwSrHc/nO8RWrvD6qRM9UHH4O10cwyvpQtylksRxUBcsu7BCt/6WJB1luzn4qvPMVbk3lMSzxdFT89Oe22ST3dp4g1J2ujfMl44xG6QaHkZ1LPef7jp2viEZmt9a1CmDlKpG7W2eRwd2wNJVvWs9h2TKNLKtYMHmLq71smC+5WJaZB3dn9tLk3CGt0xzxDmDoBRABuBBViwRqsZ5wdUrd3OZt7wo3HhsKBala6g72vx1vFORX3rn4wu7Kua+ezFfQ9SNbdBXH8FWneVZ41i2dq3KWV5oAKWQwHsT36tAORuvZtip+cJ/L3IEuG66mllpa+9JU4CXJa9me7OAxHtL8Zq4N1mD/TeAcFpTUgg0TvcDYxE1jEu5W13hsrVYG7AtWEojWNfDg3VoDdHq1CZLQXZzUfTmRewfrooEVzdM4Wrcr1kBq6hHCFBjiTYAVGHHTcvMtEu8NTvvjLsNLkXTTAq0ke/xpb3U5xWSjCKlyKVfLOSlFkY0+JGrv9qkCuReV6lgwQG3hstLr/e3x5/lX0EDopkWoz30FplEGEKUnMTiuPHH+IQyZIn3hsYo4hSRqR3effbLe2AvrUefrjnr85/ulef2L9eTR/Pzm+upz9fXdKXb9B9ZAohYHQOB8QRmA7XCpOJCRE9y+p2OhuWhF0PGEdOUb6h5771dGjXrTLKdrf/BzdptVU="
 }
@@ -0,0 +1,87 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_stream
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+const (
+	sourceFirehose = "firehose"
+	sourceKinesis  = "kinesis"
+
+	startPositionLatest      = "latest"
+	startPositionTrimHorizon = "trim_horizon"
+)
+
+type config struct {
+	// Source is the service delivering the metric stream, firehose or kinesis.
+	Source   string         `config:"source"`
+	Firehose firehoseConfig `config:"firehose"`
+	Kinesis  kinesisConfig  `config:"kinesis"`
+}
+
+// firehoseConfig configures the HTTP endpoint receiving the metric stream
+// from a Kinesis Data Firehose delivery stream.
+type firehoseConfig struct {
+	Host string `config:"host"`
+	Port int    `config:"port"`
+	// AccessKey must match the access key configured in the HTTP endpoint
+	// destination of the delivery stream, when set.
+	AccessKey   string                  `config:"access_key"`
+	MaxBodySize int64                   `config:"max_body_size" validate:"min=1"`
+	TLS         *tlscommon.ServerConfig `config:"ssl"`
+}
+
+// kinesisConfig configures the consumption of the metric stream from a
+// Kinesis data stream.
+type kinesisConfig struct {
+	StreamName    string        `config:"stream_name"`
+	StartPosition string        `config:"start_position"`
+	PollInterval  time.Duration `config:"poll_interval" validate:"min=0"`
+	// ShardRefreshInterval is how often the shards of the stream are listed,
+	// to consume the shards created by resharding.
+	ShardRefreshInterval time.Duration `config:"shard_refresh_interval" validate:"min=0"`
+	MaxRecords           int32         `config:"max_records" validate:"min=1,max=10000"`
+}
+
+func defaultConfig() config {
+	return config{
+		Source: sourceFirehose,
+		Firehose: firehoseConfig{
+			Host:        "localhost",
+			Port:        8080,
+			MaxBodySize: 64 * 1024 * 1024,
+		},
+		Kinesis: kinesisConfig{
+			StartPosition:        startPositionLatest,
+			PollInterval:         time.Second,
+			ShardRefreshInterval: time.Minute,
+			MaxRecords:           1000,
+		},
+	}
+}
+
+func (c *config) Validate() error {
+	switch c.Source {
+	case sourceFirehose:
+	case sourceKinesis:
+		if c.Kinesis.StreamName == "" {
+			return errors.New("kinesis.stream_name is required when source is kinesis")
+		}
+		switch c.Kinesis.StartPosition {
+		case startPositionLatest, startPositionTrimHorizon:
+		default:
+			return fmt.Errorf("invalid kinesis.start_position %q, must be latest or trim_horizon", c.Kinesis.StartPosition)
+		}
+	default:
+		return fmt.Errorf("invalid source %q, must be firehose or kinesis", c.Source)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// kinesisAPI is the part of the Kinesis API used to consume a data stream.
+type kinesisAPI interface {
+	ListShards(ctx context.Context, params *kinesis.ListShardsInput, optFns ...func(*kinesis.Options)) (*kinesis.ListShardsOutput, error)
+	GetShardIterator(ctx context.Context, params *kinesis.GetShardIteratorInput, optFns ...func(*kinesis.Options)) (*kinesis.GetShardIteratorOutput, error)
+	GetRecords(ctx context.Context, params *kinesis.GetRecordsInput, optFns ...func(*kinesis.Options)) (*kinesis.GetRecordsOutput, error)
+}
+
+func newKinesisClient(awsConfig awssdk.Config, config awscommon.ConfigAWS) kinesisAPI {
+	return kinesis.NewFromConfig(awsConfig, func(o *kinesis.Options) {
+		if config.FIPSEnabled {
+			o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+		}
+	})
+}
+
+// kinesisSource consumes a metric stream from the shards of a Kinesis data
+// stream. The shards created by resharding are consumed from their start
+// once they are found.
+type kinesisSource struct {
+	config kinesisConfig
+	client kinesisAPI
+	logger *logp.Logger
+}
+
+func newKinesisSource(config kinesisConfig, client kinesisAPI, logger *logp.Logger) *kinesisSource {
+	return &kinesisSource{config: config, client: client, logger: logger}
+}
+
+func (s *kinesisSource) run(ctx context.Context, handler recordsHandler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// consumed holds the shards being read or fully read. Shards that
+	// failed are moved to failed, to be read again at the next refresh from
+	// the same start position.
+	var mu sync.Mutex
+	consumed := map[string]bool{}
+	failed := map[string]types.ShardIteratorType{}
+	start := s.startIteratorType()
+	refresh := time.NewTicker(s.config.ShardRefreshInterval)
+	defer refresh.Stop()
+	for {
+		shards, err := s.listShards(ctx)
+		if err != nil {
+			mu.Lock()
+			empty := len(consumed) == 0
+			mu.Unlock()
+			if empty {
+				return err
+			}
+			s.logger.Warnw("failed to list shards, retrying at the next refresh", "error", err)
+		}
+		mu.Lock()
+		for _, shard := range shards {
+			id := awssdk.ToString(shard.ShardId)
+			if consumed[id] {
+				continue
+			}
+			consumed[id] = true
+			iteratorType := start
+			if t, ok := failed[id]; ok {
+				iteratorType = t
+				delete(failed, id)
+			}
+			wg.Add(1)
+			go func(id string, iteratorType types.ShardIteratorType) {
+				defer wg.Done()
+				err := s.consumeShard(ctx, id, iteratorType, handler)
+				if errors.Is(err, errReporterClosed) {
+					cancel()
+					return
+				}
+				if err != nil && ctx.Err() == nil {
+					s.logger.Errorw("stopped consuming shard, retrying at the next refresh", "shard_id", id, "error", err)
+					mu.Lock()
+					delete(consumed, id)
+					failed[id] = iteratorType
+					mu.Unlock()
+				}
+			}(id, iteratorType)
+		}
+		mu.Unlock()
+		// Shards found after the first listing are new, their records
+		// must all be read.
+		start = types.ShardIteratorTypeTrimHorizon
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-refresh.C:
+		}
+	}
+}
+
+// errReporterClosed is returned when the events can no longer be reported.
+var errReporterClosed = errors.New("reporter is closed")
+
+func (s *kinesisSource) startIteratorType() types.ShardIteratorType {
+	if s.config.StartPosition == startPositionTrimHorizon {
+		return types.ShardIteratorTypeTrimHorizon
+	}
+	return types.ShardIteratorTypeLatest
+}
+
+// listShards returns the open shards of the stream.
+func (s *kinesisSource) listShards(ctx context.Context) ([]types.Shard, error) {
+	var shards []types.Shard
+	input := &kinesis.ListShardsInput{StreamName: awssdk.String(s.config.StreamName)}
+	for {
+		out, err := s.client.ListShards(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list shards of stream %s: %w", s.config.StreamName, err)
+		}
+		for _, shard := range out.Shards {
+			if shard.SequenceNumberRange == nil || shard.SequenceNumberRange.EndingSequenceNumber == nil {
+				shards = append(shards, shard)
+			}
+		}
+		if out.NextToken == nil {
+			return shards, nil
+		}
+		input = &kinesis.ListShardsInput{NextToken: out.NextToken}
+	}
+}
+
+// consumeShard reads the records of a shard until it is closed or the
+// context is cancelled. After a failure, reading resumes after the last
+// record handled.
+func (s *kinesisSource) consumeShard(ctx context.Context, shardID string, iteratorType types.ShardIteratorType, handler recordsHandler) error {
+	log := s.logger.With("shard_id", shardID)
+	var lastSequenceNumber string
+	iterator, err := s.shardIterator(ctx, shardID, iteratorType, lastSequenceNumber)
+	if err != nil {
+		return err
+	}
+
+	poll := time.NewTicker(s.config.PollInterval)
+	defer poll.Stop()
+	for iterator != nil {
+		out, err := s.client.GetRecords(ctx, &kinesis.GetRecordsInput{
+			ShardIterator: iterator,
+			Limit:         awssdk.Int32(s.config.MaxRecords),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Warnw("failed to get records, retrying", "error", err)
+			iteratorType = types.ShardIteratorTypeAfterSequenceNumber
+			if lastSequenceNumber == "" {
+				iteratorType = s.startIteratorType()
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-poll.C:
+			}
+			iterator, err = s.shardIterator(ctx, shardID, iteratorType, lastSequenceNumber)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, rec := range out.Records {
+			ok, err := handler(rec.Data)
+			if !ok {
+				return errReporterClosed
+			}
+			if err != nil {
+				log.Warnw("dropping invalid metric stream records", "sequence_number", awssdk.ToString(rec.SequenceNumber), "error", err)
+			}
+			lastSequenceNumber = awssdk.ToString(rec.SequenceNumber)
+		}
+		iterator = out.NextShardIterator
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-poll.C:
+		}
+	}
+	log.Debug("shard is closed and all its records were read")
+	return nil
+}
+
+func (s *kinesisSource) shardIterator(ctx context.Context, shardID string, iteratorType types.ShardIteratorType, sequenceNumber string) (*string, error) {
+	input := &kinesis.GetShardIteratorInput{
+		StreamName:        awssdk.String(s.config.StreamName),
+		ShardId:           awssdk.String(shardID),
+		ShardIteratorType: iteratorType,
+	}
+	if iteratorType == types.ShardIteratorTypeAfterSequenceNumber {
+		input.StartingSequenceNumber = awssdk.String(sequenceNumber)
+	}
+	out, err := s.client.GetShardIterator(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get iterator of shard %s: %w", shardID, err)
+	}
+	return out.ShardIterator, nil
+}
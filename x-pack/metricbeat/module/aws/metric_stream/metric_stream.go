@@ -0,0 +1,112 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const metricsetName = "metric_stream"
+
+func init() {
+	mb.Registry.MustAddMetricSet(aws.ModuleName, metricsetName, New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+// moduleConfig holds the settings of the aws module used by the metricset.
+// The period of the module is not used, as the metrics are pushed by
+// CloudWatch as soon as they are available.
+type moduleConfig struct {
+	Regions   []string            `config:"regions"`
+	AWSConfig awscommon.ConfigAWS `config:",inline"`
+}
+
+// MetricSet receives the metrics of CloudWatch Metric Streams, delivered by
+// a Kinesis Data Firehose HTTP endpoint destination or a Kinesis data
+// stream, instead of polling them with the CloudWatch API.
+type MetricSet struct {
+	mb.BaseMetricSet
+	config config
+	source source
+	logger *logp.Logger
+}
+
+// source delivers the records of a metric stream to the handler until the
+// context is cancelled.
+type source interface {
+	run(ctx context.Context, handler recordsHandler) error
+}
+
+// recordsHandler processes the data of a metric stream. It returns false
+// when the records can no longer be reported.
+type recordsHandler func(data []byte) (bool, error)
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The aws metric_stream metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	logger := base.Logger()
+	m := &MetricSet{
+		BaseMetricSet: base,
+		config:        config,
+		logger:        logger,
+	}
+
+	switch config.Source {
+	case sourceFirehose:
+		m.source = newFirehoseSource(config.Firehose, logger)
+	case sourceKinesis:
+		var modConfig moduleConfig
+		if err := base.Module().UnpackConfig(&modConfig); err != nil {
+			return nil, err
+		}
+		awsConfig, err := awscommon.InitializeAWSConfig(modConfig.AWSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get aws credentials, please check AWS credential in config: %w", err)
+		}
+		if len(modConfig.Regions) > 0 {
+			awsConfig.Region = modConfig.Regions[0]
+		}
+		m.source = newKinesisSource(config.Kinesis, newKinesisClient(awsConfig, modConfig.AWSConfig), logger)
+	}
+	return m, nil
+}
+
+// Run receives the metric stream until the context is cancelled.
+func (m *MetricSet) Run(ctx context.Context, r mb.ReporterV2) {
+	err := m.source.run(ctx, func(data []byte) (bool, error) {
+		return m.handle(data, r)
+	})
+	if err != nil && ctx.Err() == nil {
+		m.logger.Errorw("metric stream stopped", "error", err)
+		r.Error(err)
+	}
+}
+
+// handle reports the events of the metrics of a metric stream. The records
+// decoded before an invalid one are still reported.
+func (m *MetricSet) handle(data []byte, r mb.ReporterV2) (bool, error) {
+	records, decodeErr := decodeRecords(data)
+	for _, event := range createEvents(records) {
+		if !r.Event(event) {
+			return false, nil
+		}
+	}
+	return true, decodeErr
+}
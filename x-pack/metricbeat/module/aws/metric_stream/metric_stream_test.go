@@ -0,0 +1,168 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_stream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const testRecords = `{"metric_stream_name":"all","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-0123"},"timestamp":1611929698000,"value":{"max":10,"min":2,"sum":24,"count":4},"unit":"Percent"}
+{"metric_stream_name":"all","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"NetworkIn","dimensions":{"InstanceId":"i-0123"},"timestamp":1611929698000,"value":{"max":100,"min":0,"sum":200,"count":4},"unit":"Bytes"}
+{"metric_stream_name":"all","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-4567"},"timestamp":1611929698000,"value":{"max":1,"min":1,"sum":1,"count":1},"unit":"Percent"}
+`
+
+func TestCreateEvents(t *testing.T) {
+	records, err := decodeRecords([]byte(testRecords))
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	events := createEvents(records)
+	require.Len(t, events, 2)
+
+	event := events[0]
+	assert.Equal(t, time.UnixMilli(1611929698000).UTC(), event.Timestamp)
+	assert.Equal(t, mapstr.M{"name": "all"}, event.MetricSetFields)
+	for field, want := range map[string]interface{}{
+		"cloud.provider":                       "aws",
+		"cloud.region":                         "us-east-1",
+		"cloud.account.id":                     "123456789012",
+		"aws.cloudwatch.namespace":             "AWS/EC2",
+		"aws.cloudwatch.period":                60,
+		"aws.dimensions.InstanceId":            "i-0123",
+		"aws.ec2.metrics.CPUUtilization.max":   10.0,
+		"aws.ec2.metrics.CPUUtilization.avg":   6.0,
+		"aws.ec2.metrics.NetworkIn.sum":        200.0,
+		"aws.ec2.metrics.NetworkIn.count":      4.0,
+		"aws.ec2.metrics.CPUUtilization.min":   2.0,
+		"aws.ec2.metrics.NetworkIn.avg":        50.0,
+		"aws.ec2.metrics.CPUUtilization.sum":   24.0,
+		"aws.ec2.metrics.CPUUtilization.count": 4.0,
+	} {
+		got, err := event.RootFields.GetValue(field)
+		if assert.NoError(t, err, field) {
+			assert.Equal(t, want, got, field)
+		}
+	}
+
+	got, err := events[1].RootFields.GetValue("aws.dimensions.InstanceId")
+	require.NoError(t, err)
+	assert.Equal(t, "i-4567", got)
+}
+
+func TestDecodeRecords(t *testing.T) {
+	t.Run("gzip", func(t *testing.T) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte(testRecords))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+
+		records, err := decodeRecords(buf.Bytes())
+		require.NoError(t, err)
+		assert.Len(t, records, 3)
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		data := strings.SplitAfter(testRecords, "\n")[0] + `{"some":"log"}` + "\n"
+		records, err := decodeRecords([]byte(data))
+		assert.Error(t, err)
+		assert.Len(t, records, 1)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := decodeRecords([]byte("not json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestFirehoseHandler(t *testing.T) {
+	newRequest := func(t *testing.T, records ...string) *http.Request {
+		t.Helper()
+		body := map[string]interface{}{
+			"requestId": "ed4acda5-034f-9f42-bba1-f29aea6d7d8f",
+			"timestamp": 1578090901599,
+		}
+		var data []map[string]string
+		for _, rec := range records {
+			data = append(data, map[string]string{"data": base64.StdEncoding.EncodeToString([]byte(rec))})
+		}
+		body["records"] = data
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b))
+		req.Header.Set("X-Amz-Firehose-Request-Id", "ed4acda5-034f-9f42-bba1-f29aea6d7d8f")
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		accessKey  string
+		header     string
+		open       bool
+		wantStatus int
+		wantData   int
+	}{
+		{name: "accepted", open: true, wantStatus: http.StatusOK, wantData: 1},
+		{name: "access key", accessKey: "secret", header: "secret", open: true, wantStatus: http.StatusOK, wantData: 1},
+		{name: "wrong access key", accessKey: "secret", header: "other", open: true, wantStatus: http.StatusUnauthorized},
+		{name: "closed", open: false, wantStatus: http.StatusServiceUnavailable, wantData: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var data [][]byte
+			h := &firehoseHandler{
+				config: firehoseConfig{AccessKey: tc.accessKey, MaxBodySize: 1024 * 1024},
+				handler: func(b []byte) (bool, error) {
+					data = append(data, b)
+					return tc.open, nil
+				},
+				logger: logp.NewLogger("test"),
+			}
+			req := newRequest(t, testRecords)
+			if tc.header != "" {
+				req.Header.Set(accessKeyHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Len(t, data, tc.wantData)
+			var res firehoseResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, "ed4acda5-034f-9f42-bba1-f29aea6d7d8f", res.RequestID)
+			if tc.wantStatus == http.StatusOK {
+				assert.Equal(t, []byte(testRecords), data[0])
+				assert.Empty(t, res.ErrorMessage)
+			} else {
+				assert.NotEmpty(t, res.ErrorMessage)
+			}
+		})
+	}
+
+	t.Run("too large", func(t *testing.T) {
+		h := &firehoseHandler{
+			config:  firehoseConfig{MaxBodySize: 10},
+			handler: func([]byte) (bool, error) { return true, nil },
+			logger:  logp.NewLogger("test"),
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest(t, testRecords))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_stream
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/aws"
+)
+
+// metricStreamPeriod is the granularity of the metrics delivered by
+// CloudWatch Metric Streams, in seconds.
+const metricStreamPeriod = "60"
+
+// record is a metric delivered by a metric stream in the JSON output format.
+type record struct {
+	MetricStreamName string             `json:"metric_stream_name"`
+	AccountID        string             `json:"account_id"`
+	Region           string             `json:"region"`
+	Namespace        string             `json:"namespace"`
+	MetricName       string             `json:"metric_name"`
+	Dimensions       map[string]string  `json:"dimensions"`
+	Timestamp        int64              `json:"timestamp"`
+	Value            map[string]float64 `json:"value"`
+	Unit             string             `json:"unit"`
+}
+
+// decodeRecords decodes the newline delimited records of a metric stream.
+// Gzip compressed data is decompressed first.
+func decodeRecords(data []byte) ([]record, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress records: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var records []record
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var rec record
+		err := dec.Decode(&rec)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, fmt.Errorf("failed to decode record %d: %w", len(records), err)
+		}
+		if rec.Namespace == "" || rec.MetricName == "" {
+			return records, fmt.Errorf("record %d is not a metric stream record in the JSON output format", len(records))
+		}
+		records = append(records, rec)
+	}
+}
+
+// createEvents groups the metrics of the records sharing the same account,
+// region, namespace, dimensions and timestamp into events, using the same
+// fields as the cloudwatch metricset.
+func createEvents(records []record) []mb.Event {
+	var keys []string
+	events := map[string]mb.Event{}
+	for _, rec := range records {
+		key := eventKey(rec)
+		event, ok := events[key]
+		if !ok {
+			event = aws.InitEvent(rec.Region, "", rec.AccountID, time.UnixMilli(rec.Timestamp).UTC(), metricStreamPeriod)
+			_, _ = event.RootFields.Put("aws.cloudwatch.namespace", rec.Namespace)
+			for name, value := range rec.Dimensions {
+				_, _ = event.RootFields.Put("aws.dimensions."+name, value)
+			}
+			if rec.MetricStreamName != "" {
+				event.MetricSetFields["name"] = rec.MetricStreamName
+			}
+			events[key] = event
+			keys = append(keys, key)
+		}
+
+		prefix := "aws." + stripNamespace(rec.Namespace) + ".metrics." + common.DeDot(rec.MetricName) + "."
+		// The statistics max, min, sum and count have the same names as in
+		// the cloudwatch metricset. Additional statistics, like percentiles,
+		// keep their names.
+		for stat, value := range rec.Value {
+			_, _ = event.RootFields.Put(prefix+common.DeDot(stat), value)
+		}
+		if count := rec.Value["count"]; count > 0 {
+			if sum, ok := rec.Value["sum"]; ok {
+				_, _ = event.RootFields.Put(prefix+"avg", sum/count)
+			}
+		}
+	}
+
+	result := make([]mb.Event, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, events[key])
+	}
+	return result
+}
+
+// eventKey returns the key of the event a record belongs to.
+func eventKey(rec record) string {
+	names := make([]string, 0, len(rec.Dimensions))
+	for name := range rec.Dimensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s|%s|%s|%d", rec.MetricStreamName, rec.AccountID, rec.Region, rec.Namespace, rec.Timestamp)
+	for _, name := range names {
+		fmt.Fprintf(&b, "|%s=%s", name, rec.Dimensions[name])
+	}
+	return b.String()
+}
+
+// stripNamespace converts a CloudWatch namespace into the root field used
+// for its metrics, for example AWS/EC2 -> ec2.
+func stripNamespace(namespace string) string {
+	parts := strings.Split(namespace, "/")
+	return strings.ToLower(parts[len(parts)-1])
+}
@@ -399,6 +399,11 @@ func TestFindIdentifierFromARN(t *testing.T) {
 			"lqyipneb7c",
 			"/apis/lqyipneb7c",
 		},
+		{
+			"arn:aws:s3:::test-s3-bucket",
+			"test-s3-bucket",
+			"test-s3-bucket",
+		},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,75 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package allocation
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+)
+
+var hostParser = parse.URLHostParserBuilder{
+	DefaultScheme: "http",
+	DefaultPath:   nomad.MetricsPath,
+}.Build()
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("nomad", "allocation", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	mb.BaseMetricSet
+	http *helper.HTTP
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The nomad allocation metricset is beta.")
+
+	config, err := nomad.UnpackConfig(base)
+	if err != nil {
+		return nil, err
+	}
+
+	http, err := nomad.NewHTTP(base, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+	}, nil
+}
+
+// Fetch reports the resource usage of the tasks of every allocation running
+// in the Nomad client node.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error in http fetch: %w", err)
+	}
+
+	events, err := eventsMapping(content)
+	if err != nil {
+		return err
+	}
+
+	for _, fields := range events {
+		reporter.Event(mb.Event{
+			MetricSetFields: fields,
+		})
+	}
+	return nil
+}
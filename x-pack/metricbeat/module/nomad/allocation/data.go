@@ -0,0 +1,57 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package allocation
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// percent converts the CPU usage, reported in percent, to a fraction.
+const percent = 0.01
+
+var errNoAllocationMetrics = errors.New("no allocation metrics found, the Nomad agent must run in client mode with telemetry.publish_allocation_metrics enabled")
+
+var (
+	gaugeMappings = map[string]nomad.GaugeMapping{
+		"nomad.client.allocs.cpu.total_percent":       {Field: "cpu.total.pct", Scale: percent, Float: true},
+		"nomad.client.allocs.cpu.system":              {Field: "cpu.system.pct", Scale: percent, Float: true},
+		"nomad.client.allocs.cpu.user":                {Field: "cpu.user.pct", Scale: percent, Float: true},
+		"nomad.client.allocs.cpu.total_ticks":         {Field: "cpu.total_ticks", Float: true},
+		"nomad.client.allocs.cpu.throttled_time":      {Field: "cpu.throttled.ns"},
+		"nomad.client.allocs.cpu.throttled_periods":   {Field: "cpu.throttled.periods"},
+		"nomad.client.allocs.memory.rss":              {Field: "memory.rss.bytes"},
+		"nomad.client.allocs.memory.cache":            {Field: "memory.cache.bytes"},
+		"nomad.client.allocs.memory.swap":             {Field: "memory.swap.bytes"},
+		"nomad.client.allocs.memory.usage":            {Field: "memory.usage.bytes"},
+		"nomad.client.allocs.memory.max_usage":        {Field: "memory.max_usage.bytes"},
+		"nomad.client.allocs.memory.kernel_usage":     {Field: "memory.kernel_usage.bytes"},
+		"nomad.client.allocs.memory.kernel_max_usage": {Field: "memory.kernel_max_usage.bytes"},
+		"nomad.client.allocs.memory.allocated":        {Field: "memory.allocated.bytes"},
+	}
+
+	labelFields = map[string]string{
+		"alloc_id":   "id",
+		"task":       "task",
+		"task_group": "task_group",
+		"job":        "job",
+		"namespace":  "namespace",
+	}
+)
+
+func eventsMapping(content []byte) ([]mapstr.M, error) {
+	metrics, err := nomad.ParseMetrics(content)
+	if err != nil {
+		return nil, err
+	}
+
+	events := nomad.GroupGauges(metrics.Gauges, gaugeMappings, []string{"alloc_id", "task"}, labelFields)
+	if len(events) == 0 {
+		return nil, errNoAllocationMetrics
+	}
+	return events, nil
+}
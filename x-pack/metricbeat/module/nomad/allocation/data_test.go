@@ -0,0 +1,80 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package allocation
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+)
+
+func TestData(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"allocation"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"allocation"}, server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+}
+
+func TestEventsMapping(t *testing.T) {
+	content, err := os.ReadFile("../_meta/testdata/metrics.json")
+	require.NoError(t, err)
+
+	events, err := eventsMapping(content)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	redis := events[0]
+	assert.Equal(t, "5b2a2c1d-3f8c-4a64-9b87-77a0b7a4c1e2", redis["id"])
+	assert.Equal(t, "redis", redis["task"])
+	assert.Equal(t, "cache", redis["task_group"])
+	assert.Equal(t, "example", redis["job"])
+	assert.Equal(t, "default", redis["namespace"])
+
+	pct, err := redis.GetValue("cpu.total.pct")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.125, pct, 1e-9)
+
+	rss, err := redis.GetValue("memory.rss.bytes")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10407936), rss)
+
+	allocated, err := redis.GetValue("memory.allocated.bytes")
+	require.NoError(t, err)
+	assert.Equal(t, int64(268435456), allocated)
+
+	web := events[1]
+	assert.Equal(t, "web", web["task"])
+	assert.Equal(t, "webapp", web["job"])
+
+	usage, err := web.GetValue("memory.usage.bytes")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*13004800), usage)
+}
+
+func TestEventsMappingNoAllocationMetrics(t *testing.T) {
+	_, err := eventsMapping([]byte(`{"Gauges": [{"Name": "nomad.client.allocations.running", "Value": 1}]}`))
+	assert.ErrorIs(t, err, errNoAllocationMetrics)
+}
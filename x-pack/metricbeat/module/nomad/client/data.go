@@ -0,0 +1,54 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package client
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// mebibyte converts the memory and disk resources, reported in MiB, to bytes.
+const mebibyte = 1024 * 1024
+
+var errNoClientMetrics = errors.New("no client metrics found, the Nomad agent must run in client mode with telemetry.publish_node_metrics enabled")
+
+var (
+	gaugeMappings = map[string]nomad.GaugeMapping{
+		"nomad.client.allocations.running":   {Field: "allocations.running"},
+		"nomad.client.allocations.pending":   {Field: "allocations.pending"},
+		"nomad.client.allocations.blocked":   {Field: "allocations.blocked"},
+		"nomad.client.allocations.migrating": {Field: "allocations.migrating"},
+		"nomad.client.allocations.terminal":  {Field: "allocations.terminal"},
+		"nomad.client.allocated.cpu":         {Field: "allocated.cpu.mhz"},
+		"nomad.client.allocated.memory":      {Field: "allocated.memory.bytes", Scale: mebibyte},
+		"nomad.client.allocated.disk":        {Field: "allocated.disk.bytes", Scale: mebibyte},
+		"nomad.client.unallocated.cpu":       {Field: "unallocated.cpu.mhz"},
+		"nomad.client.unallocated.memory":    {Field: "unallocated.memory.bytes", Scale: mebibyte},
+		"nomad.client.unallocated.disk":      {Field: "unallocated.disk.bytes", Scale: mebibyte},
+	}
+
+	labelFields = map[string]string{
+		"node_id":     "node.id",
+		"node_class":  "node.class",
+		"node_status": "node.status",
+		"node_pool":   "node.pool",
+		"datacenter":  "datacenter",
+	}
+)
+
+func eventsMapping(content []byte) ([]mapstr.M, error) {
+	metrics, err := nomad.ParseMetrics(content)
+	if err != nil {
+		return nil, err
+	}
+
+	events := nomad.GroupGauges(metrics.Gauges, gaugeMappings, []string{"node_id"}, labelFields)
+	if len(events) == 0 {
+		return nil, errNoClientMetrics
+	}
+	return events, nil
+}
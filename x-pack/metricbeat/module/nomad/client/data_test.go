@@ -0,0 +1,81 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package client
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"client"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"client"}, server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+}
+
+func TestEventsMapping(t *testing.T) {
+	content, err := os.ReadFile("../_meta/testdata/metrics.json")
+	require.NoError(t, err)
+
+	events, err := eventsMapping(content)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	expected := mapstr.M{
+		"node": mapstr.M{
+			"id":     "f7476465-4d6e-c0de-26d0-e383c49be941",
+			"status": "ready",
+			"pool":   "default",
+		},
+		"datacenter": "dc1",
+		"allocations": mapstr.M{
+			"running":   int64(3),
+			"pending":   int64(1),
+			"blocked":   int64(0),
+			"migrating": int64(0),
+			"terminal":  int64(5),
+		},
+		"allocated": mapstr.M{
+			"cpu":    mapstr.M{"mhz": int64(1500)},
+			"memory": mapstr.M{"bytes": int64(768 * mebibyte)},
+			"disk":   mapstr.M{"bytes": int64(900 * mebibyte)},
+		},
+		"unallocated": mapstr.M{
+			"cpu":    mapstr.M{"mhz": int64(8100)},
+			"memory": mapstr.M{"bytes": int64(14890 * mebibyte)},
+			"disk":   mapstr.M{"bytes": int64(35000 * mebibyte)},
+		},
+	}
+	assert.Equal(t, expected, events[0])
+}
+
+func TestEventsMappingNoClientMetrics(t *testing.T) {
+	_, err := eventsMapping([]byte(`{"Gauges": [{"Name": "nomad.runtime.num_goroutines", "Value": 12}]}`))
+	assert.ErrorIs(t, err, errNoClientMetrics)
+}
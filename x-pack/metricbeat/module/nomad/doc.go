@@ -2,11 +2,5 @@
 // or more contributor license agreements. Licensed under the Elastic License;
 // you may not use this file except in compliance with the Elastic License.
 
-//go:build !linux
-
-package sessionmd
-
-// InitializeModule initializes this module.
-func InitializeModule() {
-	// does nothing
-}
+// Package nomad is a Metricbeat module that contains MetricSets for HashiCorp Nomad.
+package nomad
@@ -0,0 +1,117 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// jobStub is the subset of a job of the /v1/jobs response used by this
+// metricset.
+type jobStub struct {
+	ID         string      `json:"ID"`
+	ParentID   string      `json:"ParentID"`
+	Name       string      `json:"Name"`
+	Namespace  string      `json:"Namespace"`
+	Type       string      `json:"Type"`
+	Priority   int         `json:"Priority"`
+	Periodic   bool        `json:"Periodic"`
+	Stop       bool        `json:"Stop"`
+	Status     string      `json:"Status"`
+	JobSummary *jobSummary `json:"JobSummary"`
+}
+
+type jobSummary struct {
+	Summary  map[string]taskGroupSummary `json:"Summary"`
+	Children *childrenSummary            `json:"Children"`
+}
+
+type taskGroupSummary struct {
+	Queued   int64 `json:"Queued"`
+	Starting int64 `json:"Starting"`
+	Running  int64 `json:"Running"`
+	Complete int64 `json:"Complete"`
+	Failed   int64 `json:"Failed"`
+	Lost     int64 `json:"Lost"`
+	Unknown  int64 `json:"Unknown"`
+}
+
+type childrenSummary struct {
+	Pending int64 `json:"Pending"`
+	Running int64 `json:"Running"`
+	Dead    int64 `json:"Dead"`
+}
+
+// eventsMapping creates one event per task group of every job. Jobs without
+// task groups, like the parents of periodic and parameterized jobs, are
+// reported in a single event with the summary of their children.
+func eventsMapping(content []byte) ([]mapstr.M, error) {
+	var jobs []jobStub
+	if err := json.Unmarshal(content, &jobs); err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON of Nomad jobs response: %w", err)
+	}
+
+	var events []mapstr.M
+	for _, job := range jobs {
+		if job.JobSummary == nil || len(job.JobSummary.Summary) == 0 {
+			events = append(events, jobFields(job))
+			continue
+		}
+
+		names := make([]string, 0, len(job.JobSummary.Summary))
+		for name := range job.JobSummary.Summary {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			summary := job.JobSummary.Summary[name]
+			fields := jobFields(job)
+			fields["task_group"] = mapstr.M{
+				"name":     name,
+				"queued":   summary.Queued,
+				"starting": summary.Starting,
+				"running":  summary.Running,
+				"complete": summary.Complete,
+				"failed":   summary.Failed,
+				"lost":     summary.Lost,
+				"unknown":  summary.Unknown,
+			}
+			events = append(events, fields)
+		}
+	}
+	return events, nil
+}
+
+func jobFields(job jobStub) mapstr.M {
+	fields := mapstr.M{
+		"id":        job.ID,
+		"name":      job.Name,
+		"namespace": job.Namespace,
+		"type":      job.Type,
+		"priority":  job.Priority,
+		"periodic":  job.Periodic,
+		"stop":      job.Stop,
+		"status":    job.Status,
+	}
+	if job.ParentID != "" {
+		fields["parent_id"] = job.ParentID
+	}
+	if job.JobSummary != nil && job.JobSummary.Children != nil {
+		children := job.JobSummary.Children
+		if children.Pending+children.Running+children.Dead > 0 {
+			fields["children"] = mapstr.M{
+				"pending": children.Pending,
+				"running": children.Running,
+				"dead":    children.Dead,
+			}
+		}
+	}
+	return fields
+}
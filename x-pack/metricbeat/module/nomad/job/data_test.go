@@ -0,0 +1,100 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package job
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"job"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"job"}, server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+	require.Len(t, events, 4)
+
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+}
+
+func TestFetchNamespaceAndToken(t *testing.T) {
+	var namespace, token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace = r.URL.Query().Get("namespace")
+		token = r.Header.Get("X-Nomad-Token")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	config := nomad.GetConfig([]string{"job"}, server.URL)
+	config["namespace"] = "payments"
+	config["secret_id"] = "8f31a6b3-4a4c-4c2c-8c5e-6f9b4d2e1a77"
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	_, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+
+	assert.Equal(t, "payments", namespace)
+	assert.Equal(t, "8f31a6b3-4a4c-4c2c-8c5e-6f9b4d2e1a77", token)
+}
+
+func TestEventsMapping(t *testing.T) {
+	content, err := os.ReadFile("../_meta/testdata/jobs.json")
+	require.NoError(t, err)
+
+	events, err := eventsMapping(content)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	expected := []struct {
+		job, taskGroup string
+		running        int64
+	}{
+		{"example", "cache", 1},
+		{"webapp", "frontend", 2},
+		{"webapp", "proxy", 1},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.job, events[i]["id"])
+		name, err := events[i].GetValue("task_group.name")
+		require.NoError(t, err)
+		assert.Equal(t, e.taskGroup, name)
+		running, err := events[i].GetValue("task_group.running")
+		require.NoError(t, err)
+		assert.Equal(t, e.running, running)
+	}
+
+	failed, err := events[1].GetValue("task_group.failed")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), failed)
+	assert.Equal(t, 70, events[1]["priority"])
+
+	backup := events[3]
+	assert.Equal(t, "backup", backup["id"])
+	assert.Equal(t, true, backup["periodic"])
+	assert.NotContains(t, backup, "task_group")
+	assert.Equal(t, mapstr.M{"pending": int64(0), "running": int64(1), "dead": int64(6)}, backup["children"])
+}
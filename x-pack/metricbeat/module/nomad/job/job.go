@@ -0,0 +1,85 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package job
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+)
+
+var hostParser = parse.URLHostParserBuilder{
+	DefaultScheme: "http",
+	DefaultPath:   "/v1/jobs",
+}.Build()
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("nomad", "job", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	mb.BaseMetricSet
+	http *helper.HTTP
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The nomad job metricset is beta.")
+
+	config, err := nomad.UnpackConfig(base)
+	if err != nil {
+		return nil, err
+	}
+
+	http, err := nomad.NewHTTP(base, config)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(http.GetURI())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing jobs URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("namespace", config.Namespace)
+	u.RawQuery = q.Encode()
+	http.SetURI(u.String())
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+	}, nil
+}
+
+// Fetch reports the summary of every task group of the jobs registered in
+// the Nomad cluster.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error in http fetch: %w", err)
+	}
+
+	events, err := eventsMapping(content)
+	if err != nil {
+		return err
+	}
+
+	for _, fields := range events {
+		reporter.Event(mb.Event{
+			MetricSetFields: fields,
+		})
+	}
+	return nil
+}
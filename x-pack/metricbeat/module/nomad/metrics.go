@@ -0,0 +1,104 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// MetricsPath is the path of the Nomad agent metrics endpoint.
+const MetricsPath = "/v1/metrics"
+
+// Metrics is the JSON document returned by the metrics endpoint. Only gauges
+// are used by the metricsets of this module.
+type Metrics struct {
+	Timestamp string  `json:"Timestamp"`
+	Gauges    []Gauge `json:"Gauges"`
+}
+
+// Gauge is a single gauge reported by a Nomad agent.
+type Gauge struct {
+	Name   string            `json:"Name"`
+	Value  float64           `json:"Value"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// ParseMetrics decodes the content of the metrics endpoint.
+func ParseMetrics(content []byte) (*Metrics, error) {
+	var metrics Metrics
+	if err := json.Unmarshal(content, &metrics); err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON of Nomad metrics response: %w", err)
+	}
+	return &metrics, nil
+}
+
+// GaugeMapping describes how the value of a gauge is stored in an event.
+type GaugeMapping struct {
+	// Field is the name of the field the value is stored in.
+	Field string
+	// Scale is applied to the value before storing it, 0 leaves it as is.
+	Scale float64
+	// Float stores the value as a float instead of truncating it to a long.
+	Float bool
+}
+
+func (g GaugeMapping) value(v float64) interface{} {
+	if g.Scale != 0 {
+		v *= g.Scale
+	}
+	if g.Float {
+		return v
+	}
+	return int64(v)
+}
+
+// GroupGauges maps the gauges with a known mapping into one set of fields
+// per distinct combination of the values of keyLabels. The labels present in
+// labelFields are copied into the fields of each group under the name they
+// are mapped to. Groups are returned ordered by their key.
+func GroupGauges(gauges []Gauge, mappings map[string]GaugeMapping, keyLabels []string, labelFields map[string]string) []mapstr.M {
+	groups := map[string]mapstr.M{}
+	for _, gauge := range gauges {
+		mapping, found := mappings[gauge.Name]
+		if !found {
+			continue
+		}
+
+		keyParts := make([]string, len(keyLabels))
+		for i, label := range keyLabels {
+			keyParts[i] = gauge.Labels[label]
+		}
+		key := strings.Join(keyParts, "/")
+
+		fields, found := groups[key]
+		if !found {
+			fields = mapstr.M{}
+			for label, field := range labelFields {
+				if value := gauge.Labels[label]; value != "" {
+					fields.Put(field, value)
+				}
+			}
+			groups[key] = fields
+		}
+		fields.Put(mapping.Field, mapping.value(gauge.Value))
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]mapstr.M, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, groups[key])
+	}
+	return result
+}
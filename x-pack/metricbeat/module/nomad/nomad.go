@@ -0,0 +1,51 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package nomad
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// tokenHeader is the header used by the Nomad HTTP API to receive ACL tokens.
+const tokenHeader = "X-Nomad-Token"
+
+// Config contains the settings shared by all the Nomad metricsets.
+type Config struct {
+	// SecretID is the ACL token used to authenticate against the Nomad API.
+	SecretID string `config:"secret_id"`
+	// Namespace restricts the jobs that are queried, `*` queries all of them.
+	Namespace string `config:"namespace"`
+}
+
+// DefaultConfig returns the default settings of the Nomad module.
+func DefaultConfig() Config {
+	return Config{
+		Namespace: "*",
+	}
+}
+
+// UnpackConfig reads the Nomad module settings of the given metricset.
+func UnpackConfig(base mb.BaseMetricSet) (Config, error) {
+	config := DefaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// NewHTTP creates the HTTP helper used to query the Nomad API, sending the
+// configured ACL token unless an explicit header for it has been set.
+func NewHTTP(base mb.BaseMetricSet, config Config) (*helper.HTTP, error) {
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.SecretID != "" {
+		http.SetHeaderDefault(tokenHeader, config.SecretID)
+	}
+	return http, nil
+}
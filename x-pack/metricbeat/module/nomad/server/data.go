@@ -0,0 +1,100 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var errNotServer = errors.New("the Nomad agent is not running in server mode")
+
+// agentSelf is the subset of the /v1/agent/self response used by this
+// metricset. All the stats are reported by Nomad as strings.
+type agentSelf struct {
+	Config struct {
+		Region     string `json:"Region"`
+		Datacenter string `json:"Datacenter"`
+		NodeName   string `json:"NodeName"`
+	} `json:"config"`
+	Stats map[string]map[string]interface{} `json:"stats"`
+}
+
+var (
+	serverSchema = s.Schema{
+		"leader":         c.Bool("leader"),
+		"leader_address": c.Str("leader_addr", s.Optional),
+		"bootstrap":      c.Bool("bootstrap", s.Optional),
+		"known_regions":  c.Int("known_regions", s.Optional),
+	}
+
+	raftSchema = s.Schema{
+		"state":               c.Str("state"),
+		"term":                c.Int("term"),
+		"last_log_index":      c.Int("last_log_index"),
+		"last_log_term":       c.Int("last_log_term", s.Optional),
+		"commit_index":        c.Int("commit_index"),
+		"applied_index":       c.Int("applied_index"),
+		"fsm_pending":         c.Int("fsm_pending", s.Optional),
+		"last_snapshot_index": c.Int("last_snapshot_index", s.Optional),
+		"last_snapshot_term":  c.Int("last_snapshot_term", s.Optional),
+		"num_peers":           c.Int("num_peers", s.Optional),
+		"protocol_version":    c.Int("protocol_version", s.Optional),
+	}
+)
+
+func eventMapping(content []byte) (mapstr.M, error) {
+	var self agentSelf
+	if err := json.Unmarshal(content, &self); err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON of Nomad agent response: %w", err)
+	}
+
+	nomadStats := self.Stats["nomad"]
+	if nomadStats == nil || nomadStats["server"] != "true" {
+		return nil, errNotServer
+	}
+
+	fields, err := serverSchema.Apply(nomadStats)
+	if err != nil {
+		return nil, fmt.Errorf("failure applying server schema: %w", err)
+	}
+
+	raftStats := self.Stats["raft"]
+	raft, err := raftSchema.Apply(raftStats)
+	if err != nil {
+		return nil, fmt.Errorf("failure applying raft schema: %w", err)
+	}
+	if lastContact, ok := lastContactMillis(raftStats["last_contact"]); ok {
+		raft.Put("last_contact.ms", lastContact)
+	}
+
+	fields.Put("raft", raft)
+	fields.Put("name", self.Config.NodeName)
+	fields.Put("region", self.Config.Region)
+	fields.Put("datacenter", self.Config.Datacenter)
+	return fields, nil
+}
+
+// lastContactMillis converts the time since the last contact with the leader
+// to milliseconds. Nomad reports it as a duration, "0" on the leader, or
+// "never" when no leader has been contacted yet.
+func lastContactMillis(value interface{}) (int64, bool) {
+	str, ok := value.(string)
+	if !ok || str == "" || str == "never" {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, false
+	}
+	return d.Milliseconds(), true
+}
@@ -0,0 +1,94 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package server
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/nomad"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"server"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(nomad.CreateTestMuxer())
+	defer server.Close()
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"server"}, server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+}
+
+func TestEventMapping(t *testing.T) {
+	content, err := os.ReadFile("../_meta/testdata/agent_self.json")
+	require.NoError(t, err)
+
+	fields, err := eventMapping(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "nomad-server-1", fields["name"])
+	assert.Equal(t, "global", fields["region"])
+	assert.Equal(t, "dc1", fields["datacenter"])
+	assert.Equal(t, true, fields["leader"])
+	assert.Equal(t, "10.0.0.11:4647", fields["leader_address"])
+	assert.Equal(t, int64(1), fields["known_regions"])
+
+	raft, ok := fields["raft"].(mapstr.M)
+	require.True(t, ok)
+	assert.Equal(t, "Leader", raft["state"])
+	assert.Equal(t, int64(3), raft["term"])
+	assert.Equal(t, int64(1542), raft["commit_index"])
+	assert.Equal(t, int64(1542), raft["applied_index"])
+	assert.Equal(t, int64(2), raft["num_peers"])
+}
+
+func TestEventMappingClientAgent(t *testing.T) {
+	content, err := os.ReadFile("../_meta/testdata/agent_self_client.json")
+	require.NoError(t, err)
+
+	_, err = eventMapping(content)
+	assert.ErrorIs(t, err, errNotServer)
+}
+
+func TestLastContactMillis(t *testing.T) {
+	cases := map[string]struct {
+		value    interface{}
+		expected int64
+		ok       bool
+	}{
+		"leader":   {value: "0", expected: 0, ok: true},
+		"follower": {value: "37.5ms", expected: 37, ok: true},
+		"seconds":  {value: "1.2s", expected: 1200, ok: true},
+		"never":    {value: "never", ok: false},
+		"missing":  {value: nil, ok: false},
+		"invalid":  {value: "soon", ok: false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ms, ok := lastContactMillis(c.value)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.expected, ms)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// CreateTestMuxer returns a muxer that serves the Nomad API test data.
+func CreateTestMuxer() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.Handle("/v1/agent/self", testFileHandler("../_meta/testdata/agent_self.json"))
+	mux.Handle("/v1/jobs", testFileHandler("../_meta/testdata/jobs.json"))
+	mux.Handle(MetricsPath, testFileHandler("../_meta/testdata/metrics.json"))
+
+	return mux
+}
+
+func testFileHandler(path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := os.ReadFile(path)
+		_, err := w.Write(input)
+		if err != nil {
+			fmt.Println("error writing response on mock server")
+		}
+	})
+}
+
+// GetConfig returns a module configuration for the given metricsets and host.
+func GetConfig(metricsets []string, host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "nomad",
+		"metricsets": metricsets,
+		"hosts":      []string{host},
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package asm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const mb2bytes = 1024 * 1024
+
+// extractAndTransform is called by the Fetch method. It generates an event per ASM disk group.
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	diskGroups, err := m.extractor.diskGroupsData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ASM disk groups: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(diskGroups))
+	for i := range diskGroups {
+		events = append(events, mb.Event{MetricSetFields: m.diskGroupFields(&diskGroups[i])})
+	}
+
+	return events, nil
+}
+
+// diskGroupFields transforms a disk group row into a Kibana/Elasticsearch friendly JSON. Sizes are reported by
+// Oracle in megabytes and converted to bytes.
+func (m *MetricSet) diskGroupFields(d *diskGroup) mapstr.M {
+	out := mapstr.M{}
+
+	oracle.SetSqlValue(m.Logger(), out, "name", &oracle.StringValue{NullString: d.Name})
+	oracle.SetSqlValue(m.Logger(), out, "state", &oracle.StringValue{NullString: d.State})
+	oracle.SetSqlValue(m.Logger(), out, "redundancy", &oracle.StringValue{NullString: d.Type})
+	oracle.SetSqlValue(m.Logger(), out, "space.total.bytes", &oracle.Int64Value{NullInt64: toBytes(d.TotalMB)})
+	oracle.SetSqlValue(m.Logger(), out, "space.free.bytes", &oracle.Int64Value{NullInt64: toBytes(d.FreeMB)})
+	oracle.SetSqlValue(m.Logger(), out, "space.usable.bytes", &oracle.Int64Value{NullInt64: toBytes(d.UsableFileMB)})
+	oracle.SetSqlValue(m.Logger(), out, "space.required_mirror_free.bytes", &oracle.Int64Value{NullInt64: toBytes(d.RequiredMirrorFreeMB)})
+	oracle.SetSqlValue(m.Logger(), out, "disks.offline", &oracle.Int64Value{NullInt64: d.OfflineDisks})
+
+	if d.TotalMB.Valid && d.FreeMB.Valid && d.TotalMB.Int64 > 0 {
+		_, _ = out.Put("space.used.pct", float64(d.TotalMB.Int64-d.FreeMB.Int64)/float64(d.TotalMB.Int64))
+	}
+
+	return out
+}
+
+func toBytes(mb sql.NullInt64) sql.NullInt64 {
+	return sql.NullInt64{Int64: mb.Int64 * mb2bytes, Valid: mb.Valid}
+}
@@ -0,0 +1,53 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package asm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type happyMockExtractor struct{}
+
+func (happyMockExtractor) diskGroupsData(_ context.Context) ([]diskGroup, error) {
+	return []diskGroup{
+		{Name: sql.NullString{String: "DATA", Valid: true}, State: sql.NullString{String: "MOUNTED", Valid: true}, Type: sql.NullString{String: "NORMAL", Valid: true}, TotalMB: sql.NullInt64{Int64: 200, Valid: true}, FreeMB: sql.NullInt64{Int64: 50, Valid: true}, UsableFileMB: sql.NullInt64{Int64: 20, Valid: true}, RequiredMirrorFreeMB: sql.NullInt64{Int64: 10, Valid: true}, OfflineDisks: sql.NullInt64{Int64: 0, Valid: true}},
+		{Name: sql.NullString{String: "FRA", Valid: true}, State: sql.NullString{String: "DISMOUNTED", Valid: true}, Type: sql.NullString{String: "EXTERN", Valid: true}, TotalMB: sql.NullInt64{Int64: 0, Valid: true}, FreeMB: sql.NullInt64{Int64: 0, Valid: true}, UsableFileMB: sql.NullInt64{Int64: 0, Valid: true}, RequiredMirrorFreeMB: sql.NullInt64{Int64: 0, Valid: true}, OfflineDisks: sql.NullInt64{Int64: 0, Valid: true}},
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) diskGroupsData(_ context.Context) ([]diskGroup, error) {
+	return nil, errors.New("disk groups error")
+}
+
+var expectedResults = []string{
+	`{"disks":{"offline":0},"name":"DATA","redundancy":"NORMAL","space":{"free":{"bytes":52428800},"required_mirror_free":{"bytes":10485760},"total":{"bytes":209715200},"usable":{"bytes":20971520},"used":{"pct":0.75}},"state":"MOUNTED"}`,
+	`{"disks":{"offline":0},"name":"FRA","redundancy":"EXTERN","space":{"free":{"bytes":0},"required_mirror_free":{"bytes":0},"total":{"bytes":0},"usable":{"bytes":0}},"state":"DISMOUNTED"}`,
+}
+
+func TestEventMapping(t *testing.T) {
+	m := MetricSet{extractor: &happyMockExtractor{}}
+
+	events, err := m.extractAndTransform(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, events, len(expectedResults))
+
+	for i, event := range events {
+		assert.Equal(t, expectedResults[i], event.MetricSetFields.String())
+	}
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: &errorMockExtractor{}}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package asm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// asmExtractMethods contains the methods needed to extract the information about ASM disk groups
+type asmExtractMethods interface {
+	diskGroupsData(context.Context) ([]diskGroup, error)
+}
+
+// asmExtractor is the implementor of asmExtractMethods
+type asmExtractor struct {
+	db *sql.DB
+}
+
+type diskGroup struct {
+	Name                 sql.NullString
+	State                sql.NullString
+	Type                 sql.NullString
+	TotalMB              sql.NullInt64
+	FreeMB               sql.NullInt64
+	UsableFileMB         sql.NullInt64
+	RequiredMirrorFreeMB sql.NullInt64
+	OfflineDisks         sql.NullInt64
+}
+
+/*
+ * The following function executes a query that produces the following result
+ *
+ *	NAME	STATE		TYPE	TOTAL_MB	FREE_MB	USABLE_FILE_MB	REQUIRED_MIRROR_FREE_MB	OFFLINE_DISKS
+ *	DATA	MOUNTED		NORMAL	204800		102400	46080			10240					0
+ *
+ * Which is parsed into diskGroup instances. V$ASM_DISKGROUP is empty when the
+ * database doesn't use ASM.
+ */
+func (e *asmExtractor) diskGroupsData(ctx context.Context) ([]diskGroup, error) {
+	rows, err := e.db.QueryContext(ctx, "SELECT NAME, STATE, TYPE, TOTAL_MB, FREE_MB, USABLE_FILE_MB, REQUIRED_MIRROR_FREE_MB, OFFLINE_DISKS FROM V$ASM_DISKGROUP")
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]diskGroup, 0)
+
+	for rows.Next() {
+		dest := diskGroup{}
+		if err = rows.Scan(&dest.Name, &dest.State, &dest.Type, &dest.TotalMB, &dest.FreeMB, &dest.UsableFileMB, &dest.RequiredMirrorFreeMB, &dest.OfflineDisks); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
@@ -6,6 +6,7 @@ package oracle
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/godror/godror"
@@ -17,11 +18,25 @@ import (
 // ConnectionDetails contains all possible data that can be used to create a connection with
 // an Oracle db
 type ConnectionDetails struct {
-	Username string        `config:"username"`
-	Password string        `config:"password"`
-	Patterns []interface{} `config:"patterns"`
+	Username       string        `config:"username"`
+	Password       string        `config:"password"`
+	Patterns       []interface{} `config:"patterns"`
+	WalletLocation string        `config:"wallet_location"`
 }
 
+// Validate checks that credentials and a wallet are not configured together,
+// as the wallet provides the credentials of the connection.
+func (c *ConnectionDetails) Validate() error {
+	if c.WalletLocation != "" && (c.Username != "" || c.Password != "") {
+		return errors.New("username and password can not be used together with wallet_location")
+	}
+	return nil
+}
+
+// externalAuthParam enables external authentication on a logfmt-encoded DSN,
+// so the credentials are read from the wallet.
+const externalAuthParam = " externalAuth=1"
+
 // HostParser parses host and extracts connection information and returns it to HostData
 // HostData can then be used to make connection to SQL
 func HostParser(mod mb.Module, rawURL string) (mb.HostData, error) {
@@ -35,6 +50,22 @@ func HostParser(mod mb.Module, rawURL string) (mb.HostData, error) {
 		return mb.HostData{}, fmt.Errorf("error parsing config file: %w", err)
 	}
 
+	if config.WalletLocation != "" {
+		if params.Username != "" || params.Password.Secret() != "" {
+			return mb.HostData{}, errors.New("connection string in field 'hosts' can not contain credentials when wallet_location is set")
+		}
+
+		// The wallet location is used as TNS_ADMIN, where sqlnet.ora points
+		// to the wallet and tnsnames.ora resolves the connect string.
+		params.ConfigDir = config.WalletLocation
+
+		return mb.HostData{
+			URI:          params.StringWithPassword() + externalAuthParam,
+			SanitizedURI: params.ConnectString,
+			Host:         params.String(),
+		}, nil
+	}
+
 	if params.Username == "" {
 		params.Username = config.Username
 	}
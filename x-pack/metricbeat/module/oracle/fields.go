@@ -19,5 +19,5 @@ func init() {
 // AssetOracle returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/oracle.
 func AssetOracle() string {
-	return "eJzEWd1u2zoSvu9TDAos0gKpz70vDpCkyZ4AaZKN0yz2ShhLI4sbidQhKTvu0x8MRcmyRfkvbuubppI48803P5whv8ArLcegNMY5fQCwwuY0ho8P7sHHDwAJmViL0golx1A/hgQtTtEQFCqp3DqTKW2jWMlUzMaQYm74qaac0NAYZvgBIBWUJ2b8AQDgC0gsqKOYf3ZZ8rdaVaV/ElLe6uRfV2ZXbkk6VbpAGVP7LqSgp+RxtZDho6UECrJaxAaUBAQj5KxLgZDGbqhZN7v5bULtwi0wzoSktXcN3FdaLpRONt6tM1OSRivkDMzSWCoaeU56UOG0SlPSUalUfqzSeywIVAo2Iy8OWBwI6R4FeFmprwzpHrZDDK5DISim0SFUpClXmJiglkRV03wTwJqSp3o1/AGPQhpghtUILvxzEAZQLuHx9p5ZQAlq+n+KLdgMLb+UyjoiUqGNdZ/5oKSEgygm99YvylAmOcECDcSaOOrOAWUCi0zEGWj6uxKaDLBidnNnZapVAYkwr6MaJupasKyKKWnnIFGQAXQQWAFLI8OBnSrtxZjakwwbCzIlDjguV/Fr5AWEec2VnG1j9WJOGmdt5DhWYUp2QSThbEY2E9acOeP5f+YMpsQmn/27eRW0rqH/zHS5TFUlE47Iggqll12pYY7YvkNIGgVZKoXcTtLu4OvQhHnOEtn4P8o2Ek3Dy6OQfw3zkucN0wVZ5KoFpaCYTPM4F1ONetmE04I0hWlj1UO0nSK0YoyzcEHYLNg9siYWrTCWazROVWWd2Z2aZADnKHKc5uSADdancJWGXuEcZcKOytj2Ptvq4B7u54xqsyET1qeCd4spKRapoKRrx2gQVpktjYgxjzT1Cx5sS84eqEcvCsKiGo0zGrY+5LCensvOnsE5eQamdWNg7ZBjuphiJY0wlmQI2k4WegivWnFsrVnh2woimUZTLiMhIw7H8LXpM2qZMFPWkgwnUKWN0uGCszOFrurFIKRrf/jhgZmB85BBh6RDU/O8IaBKkpwAy3ajd5Uo3Cx1m6m3dwL5hm/vBWGVxc3W6lAYzyyjAdDgORRIvfx9qfqwDmFbHuyVppXWp8rRmqPVjuRlO7MHMmIdzJCjTgKlG0Grnq/ZephIbQPOaas6ahOKkgNc98giOi7rJnjdqqo4rjQlTd/eEGjImH4RgD1drOlEpH58ojVSy9qeFXJNybgB6ylf7akGvtQrIFaVtPDJefvzx98UER4K5rmBTxnqxGWwUan9zIMF/+G/4eEC4oziVzd1tsMF5rwrLxtnmQzZdbyHnoNVMCct0mXNDr8vSRfCUeOmV35UyYR0vuTWsZ095uSmlThDOaNkuMvwNI8cvxHz+65O474lxrmqsSngS2aHW6SCUJqVeZP/3LnIpoIjNhGJM8PZY3moAE2Ozi0mtaacoJ17ahq44XB005oNF4reIYZZmvroYecRRnviMCWLQ0cbf67hnzTCN485RnueWXgjI5dZR42Cf/YInXjirlhmeLbCukmIMLZiTpFHceyY1YfQNCEXTn6DyITB+GIZKRPxaH4yEFe+CD9M4E5hMjBlrvX8UUk6MrS5Jx+PoZ0Dnlg8PJKGCcVKDqDhpiSyGqVhzyh5cjzfDWl4XinYicilWWR56otMjLKmyL5t7mnHQ6rr+7ObKyeswWHqYNzhuIUWln6i5/7r5O9JlJAJvf1Uom5ZwyFEZcrYKC6rqLIiFz+wjqtenT4e11/KWLh6/A7fVwrg078+h+FIsgulXznK01TE0VzlVUEnd999rYbpYTXw4tTsl4Ba5fkU49ftQXV4YXbJ99QI34nF+cxwmT41Oc5ZLHknhmYOj+q25vRp9vUSLt154VWtYCeitYId1Vk3Xf7UCsC122ff5XKfWqDJlEoaiqzwoX3KOvDkpcOz8BEdrAFtYeLKunliuMclTnDd4bcy77mheG4hrIsJhypajFLRY3PPs5v2jIhFvOcIRwyfE+zTz3dsrqT4uyIQCUkrUkF6UGeAZNhBdE/xjchJdq6j3DF3gM9O8yp+vHOwnogftDZM1yfP9Y1PcFbYZ24u8G3kSsLAeFmrG8O2bw6bUb/hmyiqwoF2tPAcNiy+PQf/pSAd2d65DuheGFNN9IvZ7Je85veceXq7ZqxfS/AO7mJ35L7G2FaY9xcVQlamvlGZY165w4LKUMIzr7FK+886Y52799ky1lu01fAov2cShk0/u3Fx5TSM4eLl4vbu4vLuGpSG2/uXi7vbr/Cp+WNjundW+CnZ36cKyZaeO7boDYsyp3PAJiSCALCzG9SyF2gg0aosKfl8NkiKkrmQFJ2Gmzs0Fl6lWkgv1zPSK1gjeJDO25P/TR5ubs753+frb+fwcHNzd3t/fQ4P9/wv8/d0ffXwcv002r639C/c9t5XOvXcV3XXeB2/v2xNyF3JeNQ+VB+1sF5vQptx520NGU4Mzqpfi9flsftzH3zOut9BKNekLsB/AgAA//+tbOzU"
+	return "eNrVXFtv2zoSfu+vIAos0gI+bs9in/JQwHXc1tjETu2kPX0SaImyuZFEVaTi+Pz6nSGpi23KkS0ni81D40jWzMe5czjqH+SBbS6JyKgfsTeEKK4idkneTvWFt3AlYNLPeKq4SC6JuUwCquiCSkZiEeT6ObkSmfJ8kYR8eUlCGkm8mrGIwdcuyZLCXyFnUSAv4RMhf5CExqzGGH/UJsXvZiJP7RUX85In/tRp1ulSGZfXXITxp0S3YIrWrm8x/VS7QcggVyKmivtkrgD6kpEbmsCvmCWKvBvMb96TgMsHw4rETGXcl/0aiV28dcz479aNAjioaC2yYOfeFswJPEtESNSK1RD0nXykoupkRnN8eJ8TChPk8siIEvoWT4BN4rMeCUVG2BONU9DdzfR+cje66pGr8dx+BhMgn2fTf48mbrQZC/IkAFKbUyHPSgr6GYeYyDTRl0d/3Y1mkx6ZTGc3g+se+Tb++q1HvlyP/kKUeHdyNbpqkGpKfbdUd+1uD+BVJUZNBYQHQkM7E0l/50GXAdVhKKFo1F9sFJN73yHEkAWLb7hvAEciWTpubmG+Qz7Ep4CXq82+THuwCMOm34g1zBh7Baj3SS5Z0BFrLukieg20c20BakUVIAZYjEgasmhD9CLQmUIOnuRnTNtHjyj6wJMliXmWiQw/8QR8kPq+yBPVZmkZ+51zcDPPkPBeSS1moQVzjBsZkxBVGQnzKKr5PaGhYpnWWUh5lGelC0c0W8Iz5eUjtMmCfuqrAwtMWeZDVG9covRpBDILI0HVc0v9ApkLPzniZmmVABpQuWMLfl/2RRhGPHHHGIfAt/NDHi9AhsBf03IA0RZHtWw1m/5eTgWJaOEkW3HOHeO2mN9WD5o8Aeq2uZGAUCiRYLb1sqJIHq5kvaQt02lM/VWTuFpkjSksFzwM/ElupGJxQW8/TRcMF3kYssxLhYjOkcYNOYLk0DjqSfVNg01nXUoIW145yRQ8uPBAEYIG0sklEPkiYodzsX6afCC3sBiCEhZ9MrDXCZeEJhtyO56gFCD8icV/mK+MccLNRCgTB3gGbo9fs0YJFgVGpAMnKx5a0SSABa2pNNGSBT0gGZD1ivurIvBIgoxRzbUnw0zE2jf6Bia6Bd5OSi9SPIZHqYaADJAaRCIboQ0Z62UIG4S3Xx8UQo2E/+BZAvIk7x48Ml2MWsvRUsWqds1YQi6WDEAoeaEXj3/BxwXDJV98LW45V1eI/0LWZRlCXgnQImMWi2xTp+qWEa7vGCG5Y2DKk8NCet74amKikGGAIi7+Q1paoizkAlr/1iwXeNZKGqIYxagFtJjPyrAa8UVGQTTWnNYMk5pLbMj64gVNy4eYdWJRioU+lwpjNF2IXOll12ISXH6EtItVkQbWGJ+er1kN1T4ooyEnH1DwfmEKOPSyCdCzrmDVIlPmcwAT1NfRXCSkq43kkOXB7PYD3nG1zq0lRdykCo7gRY1sXArb4/O5ljPQJy/0Zs+o0VXrNChmy4REIoGAuxR6Vgp7CIclOVytrPAdBBEsvAWGEXkWDFdFnWFokqVQAMjtQHkmRSZPc6Ghebi+nTvSM+jjsqM7FDHPLoSIlCXoAJsy0etI5C6W6sXUU0cgN/SpKwi9t+0aHvS+1QIo8BwLxDzezVWn2xAO+UErN82z7Fw+amRUZSRLWy+7wSPaKeosUOoWVNV8RepBQWbKoZwyqtNMsm6qu0USNZXVHdyUqsIHoCwo6vZCgPCU3A8CbVUMueM8Qn07Y1tCTc16KuQA/bIAa0Ve5VQJgPQTRHcYyDut7fdv/0cWYaFAdSLJuxXNAu3BUoTqPW4s8IP9Dm4uCCwCqlHcdZabCxphVt4UypJABFSHObSHXQkIoDzcGOngfdhxxFyLRu9e8RJUdiyLNlg6lnuPR6Z3Kz5UzksWNFcZVsx9LV8P5dup0qg2+lpVxZocukTpYIkUM5rIannz79emQ6x72gEP9DL0ehRuKsAMtTgPLKlcyhnKuVlRwDWbo96tKXeg2GtiwCb3JQ4GsFrOy/q/CEb6AiXa3QZpGkEJqGPEMMqhBIIw9m42GL4v+x5tDwp887hXPOesThZgv6DZQ/XJzxVs/2xnreq9SBKjX5voZVnhoUvDvqyh5m/b+t5t+XTpffOgk+uMCwjjK7ONFmvTPBBRBD6NGzCwtGazN8Z3HgjWkMuebymejC0h52RO+Rw8SnquF3SwF1Wwb+a4ElKdkyPSK1turVFArG5IsEcBKa3S0jsQvbHayFMPN+3NkW7/vG0/rQEBsl6x7Uaftj9b0RwEAcGn66LdIaw8G5vfDWb66K48w5vejiZ4LqZ/34y/zgZ3owOnDCLqbJL1gFUFDN2pwgb7Y+0q4IKrPLHXJYN9bIA9meeNyDziuY5Jj0b8PefwJzMZtVG2xXnj9/vxaD4cT74ifPPH7nHjVlG1wsKlU8CZsUBYOrVdWbOzlRvyImU5be+o0+TK5kqihVwGw7vxj1GPzO/nt/roFeUynoApToYjMhsNpz9Gs1/7JxVyI835wouk+oL47llG2/RtKxlPl88n9Xs/7al1bqujIdJ0q4yaToBXmLd54tRe6j6EotMwMB5nEcn+mwPbVU9ID/vvZwMxtDut6ZxcA92GVvJWY8+Dgh6k4Z8NQ9nsmyF5PP0CYWD46Tee23iQ0RNpDgnPjuceGJC7isGziHQt7Sls7XrSh+c0JPWUnA2S2cTd6ebxHDloTDWMzyhunXHFXlBzPzX9loLisPt7elFBjZHDMYLC6snz09zLFY/439TYla/OhusblmfD23tyXzEg7/7x3g0nYQoywQNaeRhy33sUUR6zs6tvYtigeJAN+aHZtHNAKE+iBfUfDhvV8YFZO9+sIP4sFq0ziWH63MLRykLKz2Iomu2e6V2c382uPpPP+lBwaBg8i2grYHvG6/SEx8vGbut9nzdtYkHGZArpjumtwNnjwMxSN/uExhhQBiaMrLvHgi0mNZzPHT960WUM4a6EsE2msQz1cB6qY/cBSXQ5p+nYeaitOU/47xyq7wCqGDyrzF50j/8F1p3UR0fxLNshz1rxyv/u2D2fA4Wtjrk5XjZjHc49YpvmeEyfGmfW2sytHd+IvqFPPM5jM44nzaIOkC8Pu18VpBa2Va4G2grjwQnAlwH6qeFLRB/oy91lbM8eYAbXttvX34aomEPc3nso5kkuzdjEI41y3TrQHTYliJ08xK/VtnV6uOOlGy/upV980XalOVySwY/B+Hrw+XpkdsA/BtfjK/Ku+LDTwtersB1EOzRlpgu3hrF7hBYm4QRAa9nA0MZmVJCJNGXB+4vmw9EExwe988jmmkKd+ZCIdWLpWonsBayqT/VrPv3ypYe/70Y3PQJ/XI8nI/gwwd8oP9s4ONABypVgT4olki/O27Uq8erh3pJPUML/NZojxMm0fzjzdRg3r2Ubm3N0WXh69nulgeEabnPag3ztEsp40Hr691Xx6iijP7bB92oz/LsCxYjZBmBlt91KAXfk+5qJtVqRFVTgmRBx4er1aGRG3rfdtPItuTUC3/+/qSiac6BO4ztCwAAC+yF/1SMyj+NihDW2ZUmR/ZqFhOfkasUamZZTC7U8iiP6/YNiK9T2yrIzbxNELFRkwUKR7YlLiwpNQ8ma/RbiOmT1ztW5T7bbvUDQ+iWC86+S4gxEuPM6gr3r2EaK1JO/oxfpotfHDCRZc/B4fe7Hlyt8pQNqsFSXZrDf7ZMfWK6ZOWzw6jzOTYVWjf9UEwtYpGBLuZq+qQ10tG3R8+DkEw1YV7V5KydPC3gN/Y2IJt6KypWn69JT3/Bg5lAiBScFVrSu4sKX2RPzc30DebaEp8CKjpAHXyZgmR5s6x7hO39+/Oe/3hzcguIo/58fP37EWRm0TByMsMBQmsjdjatcjOz4TowZud62I1AHmDNLLBfW0PaxdtrPT8Mwqpm5qRLs8VsJREemmPuZMGeY8iAO3Xgq5XIqquIop+6EGM9q5rNrOS1h+ml+KihsYHYW05pydWLVjKxliqaxy1uLBikTP6JSHju2QqvJoH7eraKrDxlpQCiwZ0SzM+xtIoW/6QplWJE6DYqZPOoMww4wnQJBHwxw0RWCPgIYf5i2xrDzQhnOyXcMcPZ9Bz1x3y7m49tPzhcfTnnf0Lz20JIzVP/SSzPhMylZ0JE7EiMlsT3P3S95UEeeduOXqHrMlA+wMFiqmNFrqGt2x5pNg2ts3sUqHi6aPJD6xe6k0Gv8hwNNoa8uU9l3jQAfq05NqS1bfRBjhN2PTzPjHX1tc7VeHEX8oBdjmY6mkwSnJZ6fxZorQsUIydGpplkTp04Zr49A11I1RzYxtlXkgOHQ038BfJXzcw=="
 }
@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package rac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// extractAndTransform is called by the Fetch method. It generates an event per database instance of the cluster.
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	instances, err := m.extractor.instancesData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cluster instances: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(instances))
+	for i := range instances {
+		events = append(events, mb.Event{MetricSetFields: m.instanceFields(&instances[i])})
+	}
+
+	return events, nil
+}
+
+// instanceFields transforms an instance row into a Kibana/Elasticsearch friendly JSON.
+func (m *MetricSet) instanceFields(i *instance) mapstr.M {
+	out := mapstr.M{}
+
+	oracle.SetSqlValue(m.Logger(), out, "instance.id", &oracle.Int64Value{NullInt64: i.ID})
+	oracle.SetSqlValue(m.Logger(), out, "instance.number", &oracle.Int64Value{NullInt64: i.Number})
+	oracle.SetSqlValue(m.Logger(), out, "instance.name", &oracle.StringValue{NullString: i.Name})
+	oracle.SetSqlValue(m.Logger(), out, "instance.host", &oracle.StringValue{NullString: i.HostName})
+	oracle.SetSqlValue(m.Logger(), out, "instance.version", &oracle.StringValue{NullString: i.Version})
+	oracle.SetSqlValue(m.Logger(), out, "instance.startup_time", &oracle.TimeValue{NullTime: i.StartupTime})
+	oracle.SetSqlValue(m.Logger(), out, "instance.status", &oracle.StringValue{NullString: i.Status})
+	oracle.SetSqlValue(m.Logger(), out, "instance.role", &oracle.StringValue{NullString: i.Role})
+	oracle.SetSqlValue(m.Logger(), out, "instance.active_state", &oracle.StringValue{NullString: i.ActiveState})
+	oracle.SetSqlValue(m.Logger(), out, "instance.thread", &oracle.Int64Value{NullInt64: i.Thread})
+	oracle.SetSqlValue(m.Logger(), out, "database.status", &oracle.StringValue{NullString: i.DatabaseStatus})
+
+	if i.Parallel.Valid {
+		_, _ = out.Put("cluster_database", i.Parallel.String == "YES")
+	}
+
+	return out
+}
@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package rac
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type happyMockExtractor struct{}
+
+func (happyMockExtractor) instancesData(_ context.Context) ([]instance, error) {
+	startup := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	node := func(id int64, name, host, status, activeState string) instance {
+		return instance{
+			ID:             sql.NullInt64{Int64: id, Valid: true},
+			Number:         sql.NullInt64{Int64: id, Valid: true},
+			Name:           sql.NullString{String: name, Valid: true},
+			HostName:       sql.NullString{String: host, Valid: true},
+			Version:        sql.NullString{String: "19.0.0.0.0", Valid: true},
+			StartupTime:    sql.NullTime{Time: startup, Valid: true},
+			Status:         sql.NullString{String: status, Valid: true},
+			Parallel:       sql.NullString{String: "YES", Valid: true},
+			Thread:         sql.NullInt64{Int64: id, Valid: true},
+			DatabaseStatus: sql.NullString{String: "ACTIVE", Valid: true},
+			Role:           sql.NullString{String: "PRIMARY_INSTANCE", Valid: true},
+			ActiveState:    sql.NullString{String: activeState, Valid: true},
+		}
+	}
+
+	return []instance{
+		node(1, "ORCL1", "node1", "OPEN", "NORMAL"),
+		node(2, "ORCL2", "node2", "MOUNTED", "QUIESCING"),
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) instancesData(_ context.Context) ([]instance, error) {
+	return nil, errors.New("instances error")
+}
+
+var expectedResults = []string{
+	`{"cluster_database":true,"database":{"status":"ACTIVE"},"instance":{"active_state":"NORMAL","host":"node1","id":1,"name":"ORCL1","number":1,"role":"PRIMARY_INSTANCE","startup_time":"2024-01-02T03:04:05Z","status":"OPEN","thread":1,"version":"19.0.0.0.0"}}`,
+	`{"cluster_database":true,"database":{"status":"ACTIVE"},"instance":{"active_state":"QUIESCING","host":"node2","id":2,"name":"ORCL2","number":2,"role":"PRIMARY_INSTANCE","startup_time":"2024-01-02T03:04:05Z","status":"MOUNTED","thread":2,"version":"19.0.0.0.0"}}`,
+}
+
+func TestEventMapping(t *testing.T) {
+	m := MetricSet{extractor: &happyMockExtractor{}}
+
+	events, err := m.extractAndTransform(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, events, len(expectedResults))
+
+	for i, event := range events {
+		assert.Equal(t, expectedResults[i], event.MetricSetFields.String())
+	}
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: &errorMockExtractor{}}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package rac
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// racExtractMethods contains the methods needed to extract the status of the cluster instances
+type racExtractMethods interface {
+	instancesData(context.Context) ([]instance, error)
+}
+
+// racExtractor is the implementor of racExtractMethods
+type racExtractor struct {
+	db *sql.DB
+}
+
+type instance struct {
+	ID             sql.NullInt64
+	Number         sql.NullInt64
+	Name           sql.NullString
+	HostName       sql.NullString
+	Version        sql.NullString
+	StartupTime    sql.NullTime
+	Status         sql.NullString
+	Parallel       sql.NullString
+	Thread         sql.NullInt64
+	DatabaseStatus sql.NullString
+	Role           sql.NullString
+	ActiveState    sql.NullString
+}
+
+/*
+ * The following function executes a query that produces the following result
+ *
+ *	INST_ID	INSTANCE_NUMBER	INSTANCE_NAME	HOST_NAME	VERSION		STARTUP_TIME	STATUS	PARALLEL	THREAD#	DATABASE_STATUS	INSTANCE_ROLE		ACTIVE_STATE
+ *	1		1				ORCL1			node1		19.0.0.0.0	2024-01-01		OPEN	YES			1		ACTIVE			PRIMARY_INSTANCE	NORMAL
+ *
+ * Which is parsed into instance instances. GV$INSTANCE returns a single row on databases that don't run on RAC.
+ */
+func (e *racExtractor) instancesData(ctx context.Context) ([]instance, error) {
+	rows, err := e.db.QueryContext(ctx, "SELECT INST_ID, INSTANCE_NUMBER, INSTANCE_NAME, HOST_NAME, VERSION, STARTUP_TIME, STATUS, PARALLEL, THREAD#, DATABASE_STATUS, INSTANCE_ROLE, ACTIVE_STATE FROM GV$INSTANCE")
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]instance, 0)
+
+	for rows.Next() {
+		dest := instance{}
+		if err = rows.Scan(&dest.ID, &dest.Number, &dest.Name, &dest.HostName, &dest.Version, &dest.StartupTime, &dest.Status, &dest.Parallel, &dest.Thread, &dest.DatabaseStatus, &dest.Role, &dest.ActiveState); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
@@ -7,6 +7,7 @@ package oracle
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -88,3 +89,15 @@ func (s *StringValue) isValid() bool {
 func (s *StringValue) Value() interface{} {
 	return s.String
 }
+
+type TimeValue struct {
+	sql.NullTime
+}
+
+func (t *TimeValue) isValid() bool {
+	return t.Valid
+}
+
+func (t *TimeValue) Value() interface{} {
+	return t.Time.UTC().Format(time.RFC3339)
+}
@@ -47,6 +47,7 @@ func (m *MetricSet) transform(in *extractedData) map[string]mapstr.M {
 
 	m.addUsedAndFreeSpaceData(in.freeSpace, out)
 	m.addTempFreeSpaceData(in.tempFreeSpace, out)
+	m.addAutoextendData(in.dataFiles, out)
 
 	return out
 }
@@ -109,6 +110,46 @@ func (m *MetricSet) addUsedAndFreeSpaceData(freeSpaces []usedAndFreeSpace, out m
 	}
 }
 
+// addAutoextendData calculates how much a Tablespace can still grow when its data files autoextend, and how much
+// headroom that leaves over the used space. Tablespaces without autoextend information are left untouched.
+func (m *MetricSet) addAutoextendData(dataFiles []dataFile, out map[string]mapstr.M) {
+	maxBytes := make(map[string]int64)
+	for i := range dataFiles {
+		d := &dataFiles[i]
+		if !d.Autoextensible.Valid {
+			continue
+		}
+		maxBytes[d.eventKey()] += d.maxSizeBytes()
+	}
+
+	for _, cm := range out {
+		val, err := cm.GetValue("name")
+		if err != nil {
+			m.Logger().Debug("error getting tablespace name")
+			continue
+		}
+
+		maxSize, found := maxBytes[val.(string)]
+		if !found {
+			continue
+		}
+		_, _ = cm.Put("space.autoextend.max.bytes", maxSize)
+
+		used, err := cm.GetValue("space.used.bytes")
+		if err != nil {
+			continue
+		}
+		headroom := maxSize - used.(int64)
+		if headroom < 0 {
+			headroom = 0
+		}
+		_, _ = cm.Put("space.autoextend.headroom.bytes", headroom)
+		if maxSize > 0 {
+			_, _ = cm.Put("space.autoextend.headroom.pct", float64(headroom)/float64(maxSize))
+		}
+	}
+}
+
 // addDataFileData is a specific data file which generates a JSON output.
 func (m *MetricSet) addDataFileData(d *dataFile, output map[string]mapstr.M) {
 	if _, found := output[d.hash()]; !found {
@@ -125,5 +166,6 @@ func (m *MetricSet) addDataFileData(d *dataFile, output map[string]mapstr.M) {
 	oracle.SetSqlValueWithParentKey(m.Logger(), output, d.hash(), "data_file.size.bytes", &oracle.Int64Value{NullInt64: d.FileSizeBytes})
 	oracle.SetSqlValueWithParentKey(m.Logger(), output, d.hash(), "data_file.size.max.bytes", &oracle.Int64Value{NullInt64: d.MaxFileSizeBytes})
 	oracle.SetSqlValueWithParentKey(m.Logger(), output, d.hash(), "data_file.size.free.bytes", &oracle.Int64Value{NullInt64: d.AvailableForUserBytes})
+	oracle.SetSqlValueWithParentKey(m.Logger(), output, d.hash(), "data_file.autoextensible", &oracle.StringValue{NullString: d.Autoextensible})
 
 }
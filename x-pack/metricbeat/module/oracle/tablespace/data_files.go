@@ -19,6 +19,7 @@ type dataFile struct {
 	MaxFileSizeBytes      sql.NullInt64
 	AvailableForUserBytes sql.NullInt64
 	OnlineStatus          sql.NullString
+	Autoextensible        sql.NullString
 }
 
 func (d *dataFile) hash() string {
@@ -29,8 +30,17 @@ func (d *dataFile) eventKey() string {
 	return d.TablespaceName.String
 }
 
+// maxSizeBytes returns the size the data file can grow to. Files that can't
+// autoextend are capped at their current size.
+func (d *dataFile) maxSizeBytes() int64 {
+	if d.Autoextensible.String == "YES" && d.MaxFileSizeBytes.Int64 > d.FileSizeBytes.Int64 {
+		return d.MaxFileSizeBytes.Int64
+	}
+	return d.FileSizeBytes.Int64
+}
+
 func (e *tablespaceExtractor) dataFilesData(ctx context.Context) ([]dataFile, error) {
-	rows, err := e.db.QueryContext(ctx, "SELECT FILE_NAME, FILE_ID, TABLESPACE_NAME, BYTES, STATUS, MAXBYTES, USER_BYTES, ONLINE_STATUS FROM SYS.DBA_DATA_FILES UNION SELECT FILE_NAME, FILE_ID, TABLESPACE_NAME, BYTES, STATUS, MAXBYTES, USER_BYTES, STATUS AS ONLINE_STATUS FROM SYS.DBA_TEMP_FILES")
+	rows, err := e.db.QueryContext(ctx, "SELECT FILE_NAME, FILE_ID, TABLESPACE_NAME, BYTES, STATUS, MAXBYTES, USER_BYTES, ONLINE_STATUS, AUTOEXTENSIBLE FROM SYS.DBA_DATA_FILES UNION SELECT FILE_NAME, FILE_ID, TABLESPACE_NAME, BYTES, STATUS, MAXBYTES, USER_BYTES, STATUS AS ONLINE_STATUS, AUTOEXTENSIBLE FROM SYS.DBA_TEMP_FILES")
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %w", err)
 	}
@@ -39,7 +49,7 @@ func (e *tablespaceExtractor) dataFilesData(ctx context.Context) ([]dataFile, er
 
 	for rows.Next() {
 		dest := dataFile{}
-		if err = rows.Scan(&dest.FileName, &dest.FileID, &dest.TablespaceName, &dest.FileSizeBytes, &dest.Status, &dest.MaxFileSizeBytes, &dest.AvailableForUserBytes, &dest.OnlineStatus); err != nil {
+		if err = rows.Scan(&dest.FileName, &dest.FileID, &dest.TablespaceName, &dest.FileSizeBytes, &dest.Status, &dest.MaxFileSizeBytes, &dest.AvailableForUserBytes, &dest.OnlineStatus, &dest.Autoextensible); err != nil {
 			return nil, err
 		}
 		results = append(results, dest)
@@ -6,19 +6,20 @@ package tablespace
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
-var expectedResults = []string{`{"data_file":{"id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/sysaux01.dbf","online_status":"ONLINE","size":{"bytes":9999990,"free":{"bytes":99999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"SYSAUX","space":{"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
-	`{"data_file":{"id":181,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/sysaux02.dbf","online_status":"ONLINE","size":{"bytes":9999991,"free":{"bytes":99999995},"max":{"bytes":9999995}},"status":"AVAILABLE"},"name":"SYSAUX","space":{"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
-	`{"data_file":{"id":182,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/sysaux03.dbf","online_status":"ONLINE","size":{"bytes":9999992,"free":{"bytes":99999996},"max":{"bytes":9999996}},"status":"AVAILABLE"},"name":"SYSAUX","space":{"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
-	`{"data_file":{"id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/system01.dbf","online_status":"ONLINE","size":{"bytes":999990,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"SYSTEM","space":{"free":{"bytes":9990},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
-	`{"data_file":{"id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/temp012017-03-02_07-54-38-075-AM.dbf","online_status":"ONLINE","size":{"bytes":999991,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"TEMP","space":{"free":{"bytes":99999},"total":{"bytes":99999},"used":{"bytes":99999}}}`,
-	`{"data_file":{"id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/undotbs01.dbf","online_status":"ONLINE","size":{"bytes":999992,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"UNDOTBS1","space":{"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
-	`{"data_file":{"id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/users01.dbf","online_status":"ONLINE","size":{"bytes":999993,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"USERS","space":{"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`}
+var expectedResults = []string{`{"data_file":{"autoextensible":"NO","id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/sysaux01.dbf","online_status":"ONLINE","size":{"bytes":9999990,"free":{"bytes":99999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"SYSAUX","space":{"autoextend":{"headroom":{"bytes":29989982,"pct":0.9996669663669364},"max":{"bytes":29999973}},"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
+	`{"data_file":{"autoextensible":"NO","id":181,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/sysaux02.dbf","online_status":"ONLINE","size":{"bytes":9999991,"free":{"bytes":99999995},"max":{"bytes":9999995}},"status":"AVAILABLE"},"name":"SYSAUX","space":{"autoextend":{"headroom":{"bytes":29989982,"pct":0.9996669663669364},"max":{"bytes":29999973}},"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
+	`{"data_file":{"autoextensible":"NO","id":182,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/sysaux03.dbf","online_status":"ONLINE","size":{"bytes":9999992,"free":{"bytes":99999996},"max":{"bytes":9999996}},"status":"AVAILABLE"},"name":"SYSAUX","space":{"autoextend":{"headroom":{"bytes":29989982,"pct":0.9996669663669364},"max":{"bytes":29999973}},"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
+	`{"data_file":{"autoextensible":"NO","id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/system01.dbf","online_status":"ONLINE","size":{"bytes":999990,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"SYSTEM","space":{"autoextend":{"headroom":{"bytes":989999,"pct":0.9900089000890009},"max":{"bytes":999990}},"free":{"bytes":9990},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
+	`{"data_file":{"autoextensible":"NO","id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/temp012017-03-02_07-54-38-075-AM.dbf","online_status":"ONLINE","size":{"bytes":999991,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"TEMP","space":{"autoextend":{"headroom":{"bytes":899992,"pct":0.9000001000009},"max":{"bytes":999991}},"free":{"bytes":99999},"total":{"bytes":99999},"used":{"bytes":99999}}}`,
+	`{"data_file":{"autoextensible":"NO","id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/undotbs01.dbf","online_status":"ONLINE","size":{"bytes":999992,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"UNDOTBS1","space":{"autoextend":{"headroom":{"bytes":990001,"pct":0.9900089200713605},"max":{"bytes":999992}},"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`,
+	`{"data_file":{"autoextensible":"NO","id":18,"name":"/u02/app/oracle/oradata/ORCLCDB/orclpdb1/users01.dbf","online_status":"ONLINE","size":{"bytes":999993,"free":{"bytes":9999994},"max":{"bytes":9999994}},"status":"AVAILABLE"},"name":"USERS","space":{"autoextend":{"headroom":{"bytes":990002,"pct":0.9900089300625105},"max":{"bytes":999993}},"free":{"bytes":9999},"total":{"bytes":99999},"used":{"bytes":9991}}}`}
 
 var notExpectedEvents = []string{`{}`, `{"foo":"bar"}`}
 
@@ -82,6 +83,63 @@ func TestEventMapping(t *testing.T) {
 	})
 }
 
+func TestAutoextendHeadroom(t *testing.T) {
+	file := func(id int64, tablespace string, size, maxSize int64, autoextensible string) dataFile {
+		return dataFile{
+			FileName:              sql.NullString{String: "/u02/app/oracle/oradata/file.dbf", Valid: true},
+			FileID:                sql.NullInt64{Int64: id, Valid: true},
+			TablespaceName:        sql.NullString{String: tablespace, Valid: true},
+			FileSizeBytes:         sql.NullInt64{Int64: size, Valid: true},
+			Status:                sql.NullString{String: "AVAILABLE", Valid: true},
+			MaxFileSizeBytes:      sql.NullInt64{Int64: maxSize, Valid: true},
+			AvailableForUserBytes: sql.NullInt64{Int64: size, Valid: true},
+			OnlineStatus:          sql.NullString{String: "ONLINE", Valid: true},
+			Autoextensible:        sql.NullString{String: autoextensible, Valid: true},
+		}
+	}
+	space := func(tablespace string, used, total int64) usedAndFreeSpace {
+		return usedAndFreeSpace{
+			TablespaceName:  tablespace,
+			TotalFreeBytes:  sql.NullInt64{Int64: total - used, Valid: true},
+			TotalUsedBytes:  sql.NullInt64{Int64: used, Valid: true},
+			TotalSpaceBytes: sql.NullInt64{Int64: total, Valid: true},
+		}
+	}
+
+	m := MetricSet{}
+	in := &extractedData{
+		dataFiles: []dataFile{
+			file(1, "USERS", 400, 1000, "YES"),
+			file(2, "USERS", 300, 0, "NO"),
+			file(3, "FULL", 100, 0, "NO"),
+		},
+		freeSpace: []usedAndFreeSpace{
+			space("USERS", 800, 700),
+			space("FULL", 150, 100),
+		},
+	}
+
+	out := m.transform(in)
+	for _, key := range []string{"USERS1", "USERS2"} {
+		cm := out[key]
+		maxSize, err := cm.GetValue("space.autoextend.max.bytes")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1300), maxSize)
+
+		headroom, err := cm.GetValue("space.autoextend.headroom.bytes")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(500), headroom)
+
+		pct, err := cm.GetValue("space.autoextend.headroom.pct")
+		assert.NoError(t, err)
+		assert.InDelta(t, 500.0/1300.0, pct, 1e-9)
+	}
+
+	headroom, err := out["FULL3"].GetValue("space.autoextend.headroom.bytes")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), headroom)
+}
+
 func TestPeriod(t *testing.T) {
 	t.Run("Check lower period", func(t *testing.T) {
 		var printWarning = CheckCollectionPeriod(time.Second * 59)
@@ -0,0 +1,55 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package top_sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// extractAndTransform is called by the Fetch method. It generates an event per SQL statement.
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	statements, err := m.extractor.statementsData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting top SQL statements: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(statements))
+	for i := range statements {
+		events = append(events, mb.Event{MetricSetFields: m.statementFields(&statements[i])})
+	}
+
+	return events, nil
+}
+
+// statementFields transforms a statement row into a Kibana/Elasticsearch friendly JSON. The time spent waiting is
+// split by the wait classes that Oracle accounts per statement.
+func (m *MetricSet) statementFields(s *statement) mapstr.M {
+	out := mapstr.M{}
+
+	oracle.SetSqlValue(m.Logger(), out, "id", &oracle.StringValue{NullString: s.SQLID})
+	oracle.SetSqlValue(m.Logger(), out, "plan_hash_value", &oracle.Int64Value{NullInt64: s.PlanHashValue})
+	oracle.SetSqlValue(m.Logger(), out, "text", &oracle.StringValue{NullString: s.SQLText})
+	oracle.SetSqlValue(m.Logger(), out, "executions", &oracle.Int64Value{NullInt64: s.Executions})
+	oracle.SetSqlValue(m.Logger(), out, "elapsed.us", &oracle.Int64Value{NullInt64: s.ElapsedTime})
+	oracle.SetSqlValue(m.Logger(), out, "cpu.us", &oracle.Int64Value{NullInt64: s.CPUTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait.application.us", &oracle.Int64Value{NullInt64: s.ApplicationWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait.concurrency.us", &oracle.Int64Value{NullInt64: s.ConcurrencyWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait.cluster.us", &oracle.Int64Value{NullInt64: s.ClusterWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait.user_io.us", &oracle.Int64Value{NullInt64: s.UserIOWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "buffer_gets", &oracle.Int64Value{NullInt64: s.BufferGets})
+	oracle.SetSqlValue(m.Logger(), out, "disk_reads", &oracle.Int64Value{NullInt64: s.DiskReads})
+	oracle.SetSqlValue(m.Logger(), out, "rows_processed", &oracle.Int64Value{NullInt64: s.RowsProcessed})
+
+	if s.Executions.Valid && s.ElapsedTime.Valid && s.Executions.Int64 > 0 {
+		_, _ = out.Put("elapsed_per_execution.us", s.ElapsedTime.Int64/s.Executions.Int64)
+	}
+
+	return out
+}
@@ -0,0 +1,89 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package top_sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type happyMockExtractor struct{}
+
+func (happyMockExtractor) statementsData(_ context.Context) ([]statement, error) {
+	return []statement{
+		{
+			SQLID:               sql.NullString{String: "6v7n0y2bq89n8", Valid: true},
+			PlanHashValue:       sql.NullInt64{Int64: 3724264953, Valid: true},
+			SQLText:             sql.NullString{String: "SELECT * FROM foo", Valid: true},
+			Executions:          sql.NullInt64{Int64: 12, Valid: true},
+			ElapsedTime:         sql.NullInt64{Int64: 1523480, Valid: true},
+			CPUTime:             sql.NullInt64{Int64: 1201120, Valid: true},
+			ApplicationWaitTime: sql.NullInt64{Int64: 0, Valid: true},
+			ConcurrencyWaitTime: sql.NullInt64{Int64: 1250, Valid: true},
+			ClusterWaitTime:     sql.NullInt64{Int64: 0, Valid: true},
+			UserIOWaitTime:      sql.NullInt64{Int64: 320110, Valid: true},
+			BufferGets:          sql.NullInt64{Int64: 5201, Valid: true},
+			DiskReads:           sql.NullInt64{Int64: 101, Valid: true},
+			RowsProcessed:       sql.NullInt64{Int64: 12, Valid: true},
+		},
+		{
+			SQLID:               sql.NullString{String: "0w2qpuc6u2zsp", Valid: true},
+			PlanHashValue:       sql.NullInt64{Int64: 0, Valid: true},
+			SQLText:             sql.NullString{String: "BEGIN dbms_stats.gather_database_stats_job_proc; END;", Valid: true},
+			Executions:          sql.NullInt64{Int64: 0, Valid: true},
+			ElapsedTime:         sql.NullInt64{Int64: 1000, Valid: true},
+			CPUTime:             sql.NullInt64{Int64: 900, Valid: true},
+			ApplicationWaitTime: sql.NullInt64{Int64: 0, Valid: true},
+			ConcurrencyWaitTime: sql.NullInt64{Int64: 0, Valid: true},
+			ClusterWaitTime:     sql.NullInt64{Int64: 0, Valid: true},
+			UserIOWaitTime:      sql.NullInt64{Int64: 100, Valid: true},
+			BufferGets:          sql.NullInt64{Int64: 10, Valid: true},
+			DiskReads:           sql.NullInt64{Int64: 1, Valid: true},
+			RowsProcessed:       sql.NullInt64{Int64: 0, Valid: true},
+		},
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) statementsData(_ context.Context) ([]statement, error) {
+	return nil, errors.New("statements error")
+}
+
+var expectedResults = []string{
+	`{"buffer_gets":5201,"cpu":{"us":1201120},"disk_reads":101,"elapsed":{"us":1523480},"elapsed_per_execution":{"us":126956},"executions":12,"id":"6v7n0y2bq89n8","plan_hash_value":3724264953,"rows_processed":12,"text":"SELECT * FROM foo","wait":{"application":{"us":0},"cluster":{"us":0},"concurrency":{"us":1250},"user_io":{"us":320110}}}`,
+	`{"buffer_gets":10,"cpu":{"us":900},"disk_reads":1,"elapsed":{"us":1000},"executions":0,"id":"0w2qpuc6u2zsp","plan_hash_value":0,"rows_processed":0,"text":"BEGIN dbms_stats.gather_database_stats_job_proc; END;","wait":{"application":{"us":0},"cluster":{"us":0},"concurrency":{"us":0},"user_io":{"us":100}}}`,
+}
+
+func TestEventMapping(t *testing.T) {
+	m := MetricSet{extractor: &happyMockExtractor{}}
+
+	events, err := m.extractAndTransform(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, events, len(expectedResults))
+
+	for i, event := range events {
+		assert.Equal(t, expectedResults[i], event.MetricSetFields.String())
+	}
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: &errorMockExtractor{}}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	c := defaultConfig()
+	assert.NoError(t, c.Validate())
+
+	c.TopSQL.Limit = 0
+	assert.Error(t, c.Validate())
+}
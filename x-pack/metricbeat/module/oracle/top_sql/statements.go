@@ -0,0 +1,67 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package top_sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// topSQLExtractMethods contains the methods needed to extract the most expensive SQL statements
+type topSQLExtractMethods interface {
+	statementsData(context.Context) ([]statement, error)
+}
+
+// topSQLExtractor is the implementor of topSQLExtractMethods
+type topSQLExtractor struct {
+	db    *sql.DB
+	limit int
+}
+
+type statement struct {
+	SQLID               sql.NullString
+	PlanHashValue       sql.NullInt64
+	SQLText             sql.NullString
+	Executions          sql.NullInt64
+	ElapsedTime         sql.NullInt64
+	CPUTime             sql.NullInt64
+	ApplicationWaitTime sql.NullInt64
+	ConcurrencyWaitTime sql.NullInt64
+	ClusterWaitTime     sql.NullInt64
+	UserIOWaitTime      sql.NullInt64
+	BufferGets          sql.NullInt64
+	DiskReads           sql.NullInt64
+	RowsProcessed       sql.NullInt64
+}
+
+/*
+ * The following function executes a query that produces the following result
+ *
+ *	SQL_ID			PLAN_HASH_VALUE	SQL_TEXT			EXECUTIONS	ELAPSED_TIME	CPU_TIME	APPLICATION_WAIT_TIME	CONCURRENCY_WAIT_TIME	CLUSTER_WAIT_TIME	USER_IO_WAIT_TIME	BUFFER_GETS	DISK_READS	ROWS_PROCESSED
+ *	6v7n0y2bq89n8	3724264953		SELECT * FROM foo	12			1523480			1201120		0						1250					0					320110				5201		101			12
+ *
+ * Which is parsed into statement instances. Times are reported by Oracle in microseconds, accumulated since the
+ * statement was loaded in the shared pool.
+ */
+func (e *topSQLExtractor) statementsData(ctx context.Context) ([]statement, error) {
+	rows, err := e.db.QueryContext(ctx, "SELECT SQL_ID, PLAN_HASH_VALUE, SQL_TEXT, EXECUTIONS, ELAPSED_TIME, CPU_TIME, APPLICATION_WAIT_TIME, CONCURRENCY_WAIT_TIME, CLUSTER_WAIT_TIME, USER_IO_WAIT_TIME, BUFFER_GETS, DISK_READS, ROWS_PROCESSED FROM V$SQLSTATS ORDER BY ELAPSED_TIME DESC FETCH FIRST :limit ROWS ONLY", e.limit)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]statement, 0, e.limit)
+
+	for rows.Next() {
+		dest := statement{}
+		if err = rows.Scan(&dest.SQLID, &dest.PlanHashValue, &dest.SQLText, &dest.Executions, &dest.ElapsedTime, &dest.CPUTime, &dest.ApplicationWaitTime, &dest.ConcurrencyWaitTime, &dest.ClusterWaitTime, &dest.UserIOWaitTime, &dest.BufferGets, &dest.DiskReads, &dest.RowsProcessed); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,48 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package wait_class
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// extractAndTransform is called by the Fetch method. It generates an event per wait class.
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	waitClasses, err := m.extractor.waitClassesData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting wait classes: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(waitClasses))
+	for i := range waitClasses {
+		events = append(events, mb.Event{MetricSetFields: m.waitClassFields(&waitClasses[i])})
+	}
+
+	return events, nil
+}
+
+// waitClassFields transforms a wait class row into a Kibana/Elasticsearch friendly JSON. Times are converted from
+// hundredths of a second to milliseconds.
+func (m *MetricSet) waitClassFields(w *waitClass) mapstr.M {
+	out := mapstr.M{}
+
+	oracle.SetSqlValue(m.Logger(), out, "name", &oracle.StringValue{NullString: w.Name})
+	oracle.SetSqlValue(m.Logger(), out, "waits.total", &oracle.Int64Value{NullInt64: w.TotalWaits})
+	oracle.SetSqlValue(m.Logger(), out, "time_waited.ms", &oracle.Int64Value{NullInt64: toMillis(w.TimeWaited)})
+	oracle.SetSqlValue(m.Logger(), out, "foreground.waits.total", &oracle.Int64Value{NullInt64: w.ForegroundTotalWaits})
+	oracle.SetSqlValue(m.Logger(), out, "foreground.time_waited.ms", &oracle.Int64Value{NullInt64: toMillis(w.ForegroundTimeWaited)})
+
+	return out
+}
+
+func toMillis(centiseconds sql.NullInt64) sql.NullInt64 {
+	return sql.NullInt64{Int64: centiseconds.Int64 * 10, Valid: centiseconds.Valid}
+}
@@ -0,0 +1,53 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package wait_class
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type happyMockExtractor struct{}
+
+func (happyMockExtractor) waitClassesData(_ context.Context) ([]waitClass, error) {
+	return []waitClass{
+		{Name: sql.NullString{String: "User I/O", Valid: true}, TotalWaits: sql.NullInt64{Int64: 120045, Valid: true}, TimeWaited: sql.NullInt64{Int64: 3512, Valid: true}, ForegroundTotalWaits: sql.NullInt64{Int64: 98012, Valid: true}, ForegroundTimeWaited: sql.NullInt64{Int64: 3011, Valid: true}},
+		{Name: sql.NullString{String: "Concurrency", Valid: true}, TotalWaits: sql.NullInt64{Int64: 51, Valid: true}, TimeWaited: sql.NullInt64{Int64: 2, Valid: true}, ForegroundTotalWaits: sql.NullInt64{Int64: 12, Valid: true}, ForegroundTimeWaited: sql.NullInt64{Int64: 0, Valid: true}},
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) waitClassesData(_ context.Context) ([]waitClass, error) {
+	return nil, errors.New("wait classes error")
+}
+
+var expectedResults = []string{
+	`{"foreground":{"time_waited":{"ms":30110},"waits":{"total":98012}},"name":"User I/O","time_waited":{"ms":35120},"waits":{"total":120045}}`,
+	`{"foreground":{"time_waited":{"ms":0},"waits":{"total":12}},"name":"Concurrency","time_waited":{"ms":20},"waits":{"total":51}}`,
+}
+
+func TestEventMapping(t *testing.T) {
+	m := MetricSet{extractor: &happyMockExtractor{}}
+
+	events, err := m.extractAndTransform(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, events, len(expectedResults))
+
+	for i, event := range events {
+		assert.Equal(t, expectedResults[i], event.MetricSetFields.String())
+	}
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: &errorMockExtractor{}}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package wait_class
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// waitClassExtractMethods contains the methods needed to extract the time waited by wait class
+type waitClassExtractMethods interface {
+	waitClassesData(context.Context) ([]waitClass, error)
+}
+
+// waitClassExtractor is the implementor of waitClassExtractMethods
+type waitClassExtractor struct {
+	db *sql.DB
+}
+
+type waitClass struct {
+	Name                 sql.NullString
+	TotalWaits           sql.NullInt64
+	TimeWaited           sql.NullInt64
+	ForegroundTotalWaits sql.NullInt64
+	ForegroundTimeWaited sql.NullInt64
+}
+
+/*
+ * The following function executes a query that produces the following result
+ *
+ *	WAIT_CLASS		TOTAL_WAITS	TIME_WAITED	TOTAL_WAITS_FG	TIME_WAITED_FG
+ *	User I/O		120045		3512		98012			3011
+ *
+ * Which is parsed into waitClass instances. TIME_WAITED is reported by Oracle in hundredths of a second, accumulated
+ * since the instance started. The Idle wait class is not reported.
+ */
+func (e *waitClassExtractor) waitClassesData(ctx context.Context) ([]waitClass, error) {
+	rows, err := e.db.QueryContext(ctx, "SELECT WAIT_CLASS, TOTAL_WAITS, TIME_WAITED, TOTAL_WAITS_FG, TIME_WAITED_FG FROM V$SYSTEM_WAIT_CLASS WHERE WAIT_CLASS <> 'Idle'")
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]waitClass, 0)
+
+	for rows.Next() {
+		dest := waitClass{}
+		if err = rows.Scan(&dest.Name, &dest.TotalWaits, &dest.TimeWaited, &dest.ForegroundTotalWaits, &dest.ForegroundTimeWaited); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
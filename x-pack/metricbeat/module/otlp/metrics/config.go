@@ -0,0 +1,47 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	Host string         `config:"host"`
+	GRPC listenerConfig `config:"grpc"`
+	HTTP listenerConfig `config:"http"`
+	// MaxMessageSize limits the size of the requests, after
+	// decompression for the HTTP endpoint.
+	MaxMessageSize cfgtype.ByteSize        `config:"max_message_size" validate:"nonzero,positive"`
+	TLS            *tlscommon.ServerConfig `config:"ssl"`
+}
+
+// listenerConfig configures the endpoint of one of the OTLP transports.
+type listenerConfig struct {
+	Enabled bool `config:"enabled"`
+	Port    int  `config:"port" validate:"min=0,max=65535"`
+}
+
+func defaultConfig() config {
+	return config{
+		Host:           "localhost",
+		GRPC:           listenerConfig{Enabled: true, Port: 4317},
+		HTTP:           listenerConfig{Enabled: true, Port: 4318},
+		MaxMessageSize: 4 * 1024 * 1024,
+	}
+}
+
+func (c *config) Validate() error {
+	if !c.GRPC.Enabled && !c.HTTP.Enabled {
+		return errors.New("at least one of grpc or http must be enabled")
+	}
+	if c.GRPC.Enabled && c.HTTP.Enabled && c.GRPC.Port == c.HTTP.Port && c.GRPC.Port != 0 {
+		return errors.New("grpc.port and http.port must be different")
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// eventsMapping converts the metrics of a request to events. The data
+// points of a resource and scope that share their attributes and timestamp
+// are reported in the same event, under otlp.metrics. Data points without a
+// timestamp are reported at now.
+func eventsMapping(req *exportRequest, now time.Time) []mb.Event {
+	g := eventGroups{now: now, events: map[string]mb.Event{}}
+	for ri, rm := range req.resourceMetrics {
+		rootFields, resourceAttrs := resourceFields(rm.resource)
+		for si, sm := range rm.scopeMetrics {
+			src := source{
+				id:           strconv.Itoa(ri) + "/" + strconv.Itoa(si),
+				rootFields:   rootFields,
+				moduleFields: mapstr.M{},
+			}
+			if len(resourceAttrs) > 0 {
+				src.moduleFields["resource"] = mapstr.M{"attributes": resourceAttrs}
+			}
+			scopeFields := mapstr.M{}
+			if sm.scope.name != "" {
+				scopeFields["name"] = sm.scope.name
+			}
+			if sm.scope.version != "" {
+				scopeFields["version"] = sm.scope.version
+			}
+			if len(scopeFields) > 0 {
+				src.moduleFields["scope"] = scopeFields
+			}
+
+			for _, m := range sm.metrics {
+				g.addMetric(&src, m)
+			}
+		}
+	}
+
+	events := make([]mb.Event, 0, len(g.keys))
+	for _, key := range g.keys {
+		events = append(events, g.events[key])
+	}
+	return events
+}
+
+// source holds the fields of the resource and scope of a group of metrics.
+type source struct {
+	id           string
+	rootFields   mapstr.M
+	moduleFields mapstr.M
+}
+
+// eventGroups holds the events of a request, keyed by the source,
+// timestamp and attributes of their data points, in order of creation.
+type eventGroups struct {
+	now    time.Time
+	keys   []string
+	events map[string]mb.Event
+}
+
+func (g *eventGroups) addMetric(src *source, m metric) {
+	name := fieldName(m.name)
+	if name == "" {
+		return
+	}
+	for _, p := range m.numberPoints {
+		g.add(src, p.point, name, numberFields(m, p))
+	}
+	for _, p := range m.histogramPoints {
+		g.add(src, p.point, name, histogramFields(p.count, p.sum, p.hasSum, explicitHistogramToES(p.explicitBounds, p.bucketCounts)))
+	}
+	for _, p := range m.exponentialHistogramPoints {
+		g.add(src, p.point, name, histogramFields(p.count, p.sum, p.hasSum, exponentialHistogramToES(p)))
+	}
+	for _, p := range m.summaryPoints {
+		g.add(src, p.point, name, summaryFields(p))
+	}
+}
+
+// add adds the fields of a data point of the metric with the given field
+// name to the event of its group.
+func (g *eventGroups) add(src *source, p point, name string, fields mapstr.M) {
+	if p.flags&flagNoRecordedValue != 0 || len(fields) == 0 {
+		return
+	}
+
+	attrs, attrsKey := attributeFields(p.attributes)
+	key := src.id + "/" + strconv.FormatUint(p.timeUnixNano, 10) + "/" + attrsKey
+	event, found := g.events[key]
+	if !found {
+		event = mb.Event{
+			Timestamp:       g.now,
+			RootFields:      src.rootFields.Clone(),
+			ModuleFields:    src.moduleFields.Clone(),
+			MetricSetFields: mapstr.M{},
+		}
+		if p.timeUnixNano != 0 {
+			event.Timestamp = time.Unix(0, int64(p.timeUnixNano)).UTC()
+		}
+		if len(attrs) > 0 {
+			event.ModuleFields["attributes"] = attrs
+		}
+		g.events[key] = event
+		g.keys = append(g.keys, key)
+	}
+	for k, v := range fields {
+		_, _ = event.MetricSetFields.Put(name+"."+k, v)
+	}
+}
+
+// numberFields returns the fields of a data point of a gauge or a sum. Delta
+// sums are reported as delta, cumulative monotonic sums as counter, and
+// gauges and cumulative non-monotonic sums as value.
+func numberFields(m metric, p numberPoint) mapstr.M {
+	if p.value == nil {
+		return nil
+	}
+	if v, ok := p.value.(float64); ok && !isFinite(v) {
+		return nil
+	}
+	switch {
+	case m.typ == metricTypeSum && m.temporality == temporalityDelta:
+		return mapstr.M{"delta": p.value}
+	case m.typ == metricTypeSum && m.monotonic:
+		return mapstr.M{"counter": p.value}
+	default:
+		return mapstr.M{"value": p.value}
+	}
+}
+
+func histogramFields(count uint64, sum float64, hasSum bool, histogram mapstr.M) mapstr.M {
+	fields := mapstr.M{"count": count}
+	if hasSum && isFinite(sum) {
+		fields["sum"] = sum
+	}
+	if histogram != nil {
+		fields["histogram"] = histogram
+	}
+	return fields
+}
+
+// summaryFields returns the fields of a data point of a summary. Quantiles
+// are named after their percentile, as p99 or p99_9.
+func summaryFields(p summaryPoint) mapstr.M {
+	fields := mapstr.M{"count": p.count}
+	if isFinite(p.sum) {
+		fields["sum"] = p.sum
+	}
+	quantiles := mapstr.M{}
+	for _, q := range p.quantiles {
+		if !isFinite(q.value) {
+			continue
+		}
+		percentile := strconv.FormatFloat(math.Round(q.quantile*1e6)/1e4, 'f', -1, 64)
+		quantiles["p"+strings.ReplaceAll(percentile, ".", "_")] = q.value
+	}
+	if len(quantiles) > 0 {
+		fields["quantiles"] = quantiles
+	}
+	return fields
+}
+
+// explicitHistogramToES converts the buckets of a histogram to an ES
+// histogram. Centroids are calculated as in the histograms of the
+// prometheus collector:
+//
+//   - the last bucket, with no upper bound, uses the last bound
+//   - the first bucket uses its bound if it is negative, half of it otherwise
+//   - other buckets use the midpoint between their bounds
+//
+// Empty buckets are left out.
+func explicitHistogramToES(bounds []float64, counts []uint64) mapstr.M {
+	if len(bounds) == 0 || len(counts) != len(bounds)+1 {
+		return nil
+	}
+
+	var values []float64
+	var outCounts []uint64
+	var lastUpper float64
+	for i, count := range counts {
+		var value float64
+		switch {
+		case i == len(bounds):
+			value = lastUpper
+		case i == 0 && bounds[0] < 0:
+			value = bounds[0]
+		default:
+			value = lastUpper + (bounds[i]-lastUpper)/2
+		}
+		if i < len(bounds) {
+			lastUpper = bounds[i]
+		}
+		if count == 0 {
+			continue
+		}
+		values = append(values, value)
+		outCounts = append(outCounts, count)
+	}
+	return esHistogram(values, outCounts)
+}
+
+// exponentialHistogramToES converts the buckets of an exponential histogram
+// to an ES histogram, using the midpoint of the bounds of each bucket as its
+// centroid. The bucket of index i holds the values in (base^i, base^(i+1)],
+// with base = 2^(2^-scale). Empty buckets are left out.
+func exponentialHistogramToES(p exponentialHistogramPoint) mapstr.M {
+	base := math.Pow(2, math.Pow(2, -float64(p.scale)))
+	centroid := func(index int) float64 {
+		lower := math.Pow(base, float64(index))
+		return (lower + lower*base) / 2
+	}
+
+	var values []float64
+	var counts []uint64
+	for i := len(p.negative.counts) - 1; i >= 0; i-- {
+		value := -centroid(int(p.negative.offset) + i)
+		if p.negative.counts[i] == 0 || !isFinite(value) {
+			continue
+		}
+		values = append(values, value)
+		counts = append(counts, p.negative.counts[i])
+	}
+	if p.zeroCount > 0 {
+		values = append(values, 0)
+		counts = append(counts, p.zeroCount)
+	}
+	for i, count := range p.positive.counts {
+		value := centroid(int(p.positive.offset) + i)
+		if count == 0 || !isFinite(value) {
+			continue
+		}
+		values = append(values, value)
+		counts = append(counts, count)
+	}
+	return esHistogram(values, counts)
+}
+
+func esHistogram(values []float64, counts []uint64) mapstr.M {
+	if len(values) == 0 {
+		return nil
+	}
+	return mapstr.M{
+		"values": values,
+		"counts": counts,
+	}
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// fieldName returns the field name of a metric name or an attribute key.
+// Dots separate namespaces, as in the OpenTelemetry naming conventions, and
+// become objects in the events. Empty namespaces are removed and characters
+// other than letters, digits, '_', '-' and '/' are replaced with '_'.
+func fieldName(name string) string {
+	parts := strings.Split(name, ".")
+	kept := parts[:0]
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		kept = append(kept, strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '/' {
+				return r
+			}
+			return '_'
+		}, part))
+	}
+	return strings.Join(kept, ".")
+}
+
+// attributeFields returns the attributes of a data point as keywords, and
+// a key identifying them regardless of their order.
+func attributeFields(attrs []attribute) (mapstr.M, string) {
+	if len(attrs) == 0 {
+		return nil, ""
+	}
+	fields := mapstr.M{}
+	pairs := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		key := fieldName(attr.key)
+		if key == "" {
+			continue
+		}
+		value := attributeString(attr.value)
+		_, _ = fields.Put(key, value)
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return fields, strings.Join(pairs, "\x00")
+}
+
+// attributeString returns the value of an attribute as a string. Arrays and
+// lists of key-value pairs are encoded as JSON, and bytes as base64.
+func attributeString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	}
+	b, err := json.Marshal(jsonAttributeValue(value))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func jsonAttributeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, av := range v {
+			values = append(values, jsonAttributeValue(av))
+		}
+		return values
+	case []attribute:
+		values := make(map[string]interface{}, len(v))
+		for _, attr := range v {
+			values[attr.key] = jsonAttributeValue(attr.value)
+		}
+		return values
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	}
+	return value
+}
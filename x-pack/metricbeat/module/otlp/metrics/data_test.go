@@ -0,0 +1,243 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventsMapping(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := uint64(time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC).UnixNano())
+	req := &exportRequest{resourceMetrics: []resourceMetrics{{
+		resource: []attribute{
+			{key: "service.name", value: "checkout"},
+			{key: "os.type", value: "darwin"},
+			{key: "process.pid", value: int64(1234)},
+			{key: "telemetry.sdk.language", value: "go"},
+		},
+		scopeMetrics: []scopeMetrics{{
+			scope: scope{name: "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp", version: "0.46.0"},
+			metrics: []metric{
+				{
+					name: "http.server.active_requests",
+					typ:  metricTypeSum,
+					numberPoints: []numberPoint{
+						{point: point{timeUnixNano: ts, attributes: []attribute{{key: "http.method", value: "GET"}}}, value: int64(3)},
+						{point: point{timeUnixNano: ts, attributes: []attribute{{key: "http.method", value: "POST"}}}, value: int64(1)},
+					},
+				},
+				{
+					name:        "http.server.request_count",
+					typ:         metricTypeSum,
+					temporality: temporalityCumulative,
+					monotonic:   true,
+					numberPoints: []numberPoint{
+						{point: point{timeUnixNano: ts, attributes: []attribute{{key: "http.method", value: "GET"}}}, value: int64(120)},
+						{point: point{timeUnixNano: ts, flags: flagNoRecordedValue}},
+						{point: point{timeUnixNano: ts}, value: math.NaN()},
+					},
+				},
+				{
+					name:        "http.server.duration",
+					typ:         metricTypeHistogram,
+					temporality: temporalityDelta,
+					histogramPoints: []histogramPoint{{
+						point:          point{timeUnixNano: ts, attributes: []attribute{{key: "http.method", value: "GET"}}},
+						count:          6,
+						sum:            3.5,
+						hasSum:         true,
+						bucketCounts:   []uint64{1, 0, 3, 2},
+						explicitBounds: []float64{0.1, 0.5, 1},
+					}},
+				},
+			},
+		}},
+	}, {
+		scopeMetrics: []scopeMetrics{{
+			metrics: []metric{
+				{
+					name:        "..requests...delta",
+					typ:         metricTypeSum,
+					temporality: temporalityDelta,
+					numberPoints: []numberPoint{{
+						point: point{attributes: []attribute{{key: "a b", value: true}}},
+						value: 2.5,
+					}},
+				},
+				{
+					name: "rpc.latency",
+					typ:  metricTypeSummary,
+					summaryPoints: []summaryPoint{{
+						point:     point{attributes: []attribute{{key: "a b", value: true}}},
+						count:     10,
+						sum:       25,
+						quantiles: []quantileValue{{quantile: 0.5, value: 2}, {quantile: 0.999, value: 9}, {quantile: 1, value: math.Inf(1)}},
+					}},
+				},
+			},
+		}},
+	}}}
+
+	events := eventsMapping(req, now)
+	require.Len(t, events, 3)
+
+	root := mapstr.M{
+		"service": mapstr.M{"name": "checkout"},
+		"host":    mapstr.M{"os": mapstr.M{"type": "macos"}},
+		"process": mapstr.M{"pid": int64(1234)},
+	}
+	module := mapstr.M{
+		"resource": mapstr.M{"attributes": mapstr.M{"telemetry": mapstr.M{"sdk": mapstr.M{"language": "go"}}}},
+		"scope":    mapstr.M{"name": "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp", "version": "0.46.0"},
+	}
+	withAttributes := func(m mapstr.M, attrs mapstr.M) mapstr.M {
+		m = m.Clone()
+		m["attributes"] = attrs
+		return m
+	}
+
+	assert.Equal(t, mb.Event{
+		Timestamp:    time.Unix(0, int64(ts)).UTC(),
+		RootFields:   root,
+		ModuleFields: withAttributes(module, mapstr.M{"http": mapstr.M{"method": "GET"}}),
+		MetricSetFields: mapstr.M{
+			"http": mapstr.M{"server": mapstr.M{
+				"active_requests": mapstr.M{"value": int64(3)},
+				"request_count":   mapstr.M{"counter": int64(120)},
+				"duration": mapstr.M{
+					"count": uint64(6),
+					"sum":   3.5,
+					"histogram": mapstr.M{
+						"values": []float64{0.05, 0.75, 1},
+						"counts": []uint64{1, 3, 2},
+					},
+				},
+			}},
+		},
+	}, events[0])
+
+	assert.Equal(t, mb.Event{
+		Timestamp:    time.Unix(0, int64(ts)).UTC(),
+		RootFields:   root,
+		ModuleFields: withAttributes(module, mapstr.M{"http": mapstr.M{"method": "POST"}}),
+		MetricSetFields: mapstr.M{
+			"http": mapstr.M{"server": mapstr.M{
+				"active_requests": mapstr.M{"value": int64(1)},
+			}},
+		},
+	}, events[1])
+
+	assert.Equal(t, mb.Event{
+		Timestamp:    now,
+		RootFields:   mapstr.M{},
+		ModuleFields: mapstr.M{"attributes": mapstr.M{"a_b": "true"}},
+		MetricSetFields: mapstr.M{
+			"requests": mapstr.M{"delta": mapstr.M{"delta": 2.5}},
+			"rpc": mapstr.M{"latency": mapstr.M{
+				"count":     uint64(10),
+				"sum":       float64(25),
+				"quantiles": mapstr.M{"p50": float64(2), "p99_9": float64(9)},
+			}},
+		},
+	}, events[2])
+}
+
+func TestExplicitHistogramToES(t *testing.T) {
+	tests := map[string]struct {
+		bounds   []float64
+		counts   []uint64
+		expected mapstr.M
+	}{
+		"negative first bound": {
+			bounds:   []float64{-1, 0, 1},
+			counts:   []uint64{1, 2, 3, 4},
+			expected: mapstr.M{"values": []float64{-1, -0.5, 0.5, 1}, "counts": []uint64{1, 2, 3, 4}},
+		},
+		"positive bounds": {
+			bounds:   []float64{10, 20},
+			counts:   []uint64{5, 0, 1},
+			expected: mapstr.M{"values": []float64{5, 20}, "counts": []uint64{5, 1}},
+		},
+		"no bounds": {
+			counts: []uint64{5},
+		},
+		"mismatched counts": {
+			bounds: []float64{10, 20},
+			counts: []uint64{5},
+		},
+		"empty buckets": {
+			bounds: []float64{10},
+			counts: []uint64{0, 0},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, explicitHistogramToES(tc.bounds, tc.counts))
+		})
+	}
+}
+
+func TestExponentialHistogramToES(t *testing.T) {
+	// With scale 0 the base is 2, bucket i holds the values in (2^i, 2^(i+1)].
+	h := exponentialHistogramToES(exponentialHistogramPoint{
+		zeroCount: 2,
+		positive:  buckets{offset: 1, counts: []uint64{3, 0, 4}},
+		negative:  buckets{offset: 0, counts: []uint64{1, 5}},
+	})
+	assert.Equal(t, mapstr.M{
+		"values": []float64{-3, -1.5, 0, 3, 12},
+		"counts": []uint64{5, 1, 2, 3, 4},
+	}, h)
+
+	// With scale 1 the base is sqrt(2).
+	h = exponentialHistogramToES(exponentialHistogramPoint{
+		scale:    1,
+		positive: buckets{offset: 2, counts: []uint64{1}},
+	})
+	require.NotNil(t, h)
+	assert.InDelta(t, (2+2*math.Sqrt2)/2, h["values"].([]float64)[0], 1e-9)
+
+	assert.Nil(t, exponentialHistogramToES(exponentialHistogramPoint{}))
+}
+
+func TestFieldName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"http.server.duration":           "http.server.duration",
+		"process.runtime.go.gc.pause_ns": "process.runtime.go.gc.pause_ns",
+		"kafka/consumer-lag":             "kafka/consumer-lag",
+		".a..b.":                         "a.b",
+		"my metric{x}":                   "my_metric_x_",
+		"...":                            "",
+	} {
+		assert.Equal(t, expected, fieldName(name), name)
+	}
+}
+
+func TestAttributeString(t *testing.T) {
+	for _, tc := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{"a", "a"},
+		{true, "true"},
+		{int64(-3), "-3"},
+		{1.25, "1.25"},
+		{[]byte{1, 2}, "AQI="},
+		{nil, ""},
+		{[]interface{}{"a", int64(1)}, `["a",1]`},
+		{[]attribute{{key: "k", value: []byte{1}}}, `{"k":"AQ=="}`},
+	} {
+		assert.Equal(t, tc.expected, attributeString(tc.value))
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The helpers below encode OTLP messages for the tests.
+
+func appendMessage(b []byte, number protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, number, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendString(b []byte, number protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, number, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendVarint(b []byte, number protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, number, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendFixed64(b []byte, number protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, number, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, v)
+}
+
+func appendDouble(b []byte, number protowire.Number, v float64) []byte {
+	return appendFixed64(b, number, math.Float64bits(v))
+}
+
+func keyValue(number protowire.Number, key string, anyValue []byte) []byte {
+	return appendMessage(nil, number, appendMessage(appendString(nil, 1, key), 2, anyValue))
+}
+
+func stringValue(s string) []byte {
+	return appendString(nil, 1, s)
+}
+
+func TestDecodeProtoRequest(t *testing.T) {
+	gaugePointMsg := keyValue(7, "state", stringValue("idle"))
+	gaugePointMsg = appendFixed64(gaugePointMsg, 3, 1700000000000000000)
+	gaugePointMsg = appendDouble(gaugePointMsg, 4, 0.5)
+	gauge := appendString(nil, 1, "system.cpu.utilization")
+	gauge = appendString(gauge, 3, "1")
+	gauge = appendMessage(gauge, 5, appendMessage(nil, 1, gaugePointMsg))
+
+	sumPointMsg := appendFixed64(nil, 3, 1700000000000000000)
+	sumPointMsg = appendFixed64(sumPointMsg, 6, uint64(42))
+	sumPointMsg = appendVarint(sumPointMsg, 8, 0)
+	sumData := appendMessage(nil, 1, sumPointMsg)
+	sumData = appendVarint(sumData, 2, uint64(temporalityCumulative))
+	sumData = appendVarint(sumData, 3, 1)
+	sum := appendString(nil, 1, "http.server.requests")
+	sum = appendMessage(sum, 7, sumData)
+
+	// Bucket counts are packed, and explicit bounds are not.
+	var packed []byte
+	for _, c := range []uint64{1, 2, 3} {
+		packed = protowire.AppendFixed64(packed, c)
+	}
+	histogramPointMsg := keyValue(9, "route", stringValue("/"))
+	histogramPointMsg = appendFixed64(histogramPointMsg, 4, 6)
+	histogramPointMsg = appendDouble(histogramPointMsg, 5, 12.5)
+	histogramPointMsg = appendMessage(histogramPointMsg, 6, packed)
+	histogramPointMsg = appendDouble(histogramPointMsg, 7, 1)
+	histogramPointMsg = appendDouble(histogramPointMsg, 7, 5)
+	histogramPointMsg = appendVarint(histogramPointMsg, 10, flagNoRecordedValue)
+	histogramData := appendMessage(nil, 1, histogramPointMsg)
+	histogramData = appendVarint(histogramData, 2, uint64(temporalityDelta))
+	histogramMsg := appendString(nil, 1, "http.server.duration")
+	histogramMsg = appendMessage(histogramMsg, 9, histogramData)
+
+	positive := appendVarint(nil, 1, protowire.EncodeZigZag(-1))
+	positive = appendMessage(positive, 2, protowire.AppendVarint(protowire.AppendVarint(nil, 4), 5))
+	expPoint := appendFixed64(nil, 4, 10)
+	expPoint = appendVarint(expPoint, 6, protowire.EncodeZigZag(-2))
+	expPoint = appendFixed64(expPoint, 7, 1)
+	expPoint = appendMessage(expPoint, 8, positive)
+	expPoint = appendMessage(expPoint, 1, appendMessage(appendString(nil, 1, "le"), 2, appendVarint(nil, 3, uint64(7))))
+	expHistogram := appendString(nil, 1, "queue.latency")
+	expHistogram = appendMessage(expHistogram, 10, appendMessage(nil, 1, expPoint))
+
+	quantile := appendDouble(appendDouble(nil, 1, 0.99), 2, 3.5)
+	summaryPointMsg := appendFixed64(nil, 4, 100)
+	summaryPointMsg = appendDouble(summaryPointMsg, 5, 250)
+	summaryPointMsg = appendMessage(summaryPointMsg, 6, quantile)
+	summary := appendString(nil, 1, "rpc.latency")
+	summary = appendMessage(summary, 11, appendMessage(nil, 1, summaryPointMsg))
+
+	scopeMsg := appendString(appendString(nil, 1, "otelcol/hostmetrics"), 2, "0.90.0")
+	sm := appendMessage(nil, 1, scopeMsg)
+	for _, m := range [][]byte{gauge, sum, histogramMsg, expHistogram, summary} {
+		sm = appendMessage(sm, 2, m)
+	}
+
+	list := appendMessage(nil, 1, appendMessage(appendString(nil, 1, "a"), 2, appendVarint(nil, 2, 1)))
+	resource := keyValue(1, "service.name", stringValue("checkout"))
+	resource = append(resource, keyValue(1, "tags", appendMessage(nil, 5, appendMessage(nil, 1, appendDouble(nil, 4, 1.5))))...)
+	resource = append(resource, keyValue(1, "labels", appendMessage(nil, 6, list))...)
+	resource = append(resource, keyValue(1, "raw", appendMessage(nil, 7, []byte{1, 2}))...)
+	rm := appendMessage(nil, 1, resource)
+	rm = appendMessage(rm, 2, sm)
+	// Unknown fields are skipped.
+	rm = appendString(rm, 3, "https://opentelemetry.io/schemas/1.21.0")
+	body := appendMessage(nil, 1, rm)
+
+	req, err := decodeProtoRequest(body)
+	require.NoError(t, err)
+
+	expected := &exportRequest{resourceMetrics: []resourceMetrics{{
+		resource: []attribute{
+			{key: "service.name", value: "checkout"},
+			{key: "tags", value: []interface{}{1.5}},
+			{key: "labels", value: []attribute{{key: "a", value: true}}},
+			{key: "raw", value: []byte{1, 2}},
+		},
+		scopeMetrics: []scopeMetrics{{
+			scope: scope{name: "otelcol/hostmetrics", version: "0.90.0"},
+			metrics: []metric{
+				{
+					name: "system.cpu.utilization",
+					unit: "1",
+					typ:  metricTypeGauge,
+					numberPoints: []numberPoint{{
+						point: point{
+							attributes:   []attribute{{key: "state", value: "idle"}},
+							timeUnixNano: 1700000000000000000,
+						},
+						value: 0.5,
+					}},
+				},
+				{
+					name:        "http.server.requests",
+					typ:         metricTypeSum,
+					temporality: temporalityCumulative,
+					monotonic:   true,
+					numberPoints: []numberPoint{{
+						point: point{timeUnixNano: 1700000000000000000},
+						value: int64(42),
+					}},
+				},
+				{
+					name:        "http.server.duration",
+					typ:         metricTypeHistogram,
+					temporality: temporalityDelta,
+					histogramPoints: []histogramPoint{{
+						point: point{
+							attributes: []attribute{{key: "route", value: "/"}},
+							flags:      flagNoRecordedValue,
+						},
+						count:          6,
+						sum:            12.5,
+						hasSum:         true,
+						bucketCounts:   []uint64{1, 2, 3},
+						explicitBounds: []float64{1, 5},
+					}},
+				},
+				{
+					name: "queue.latency",
+					typ:  metricTypeExponentialHistogram,
+					exponentialHistogramPoints: []exponentialHistogramPoint{{
+						point: point{
+							attributes: []attribute{{key: "le", value: int64(7)}},
+						},
+						count:     10,
+						scale:     -2,
+						zeroCount: 1,
+						positive:  buckets{offset: -1, counts: []uint64{4, 5}},
+					}},
+				},
+				{
+					name: "rpc.latency",
+					typ:  metricTypeSummary,
+					summaryPoints: []summaryPoint{{
+						count:     100,
+						sum:       250,
+						quantiles: []quantileValue{{quantile: 0.99, value: 3.5}},
+					}},
+				},
+			},
+		}},
+	}}}
+	assert.Equal(t, expected, req)
+}
+
+func TestDecodeProtoRequestErrors(t *testing.T) {
+	for name, body := range map[string][]byte{
+		"truncated":          appendMessage(nil, 1, []byte{0x0a, 0x05, 0x01}),
+		"invalid tag":        {0xff},
+		"unexpected type":    appendMessage(nil, 1, appendVarint(nil, 2, 1)),
+		"invalid data point": appendMessage(nil, 1, appendMessage(nil, 2, appendMessage(nil, 2, appendMessage(appendString(nil, 1, "m"), 5, appendMessage(nil, 1, appendVarint(nil, 3, 1)))))),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeProtoRequest(body)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeJSONRequest(t *testing.T) {
+	body := `{
+  "resourceMetrics": [{
+    "resource": {"attributes": [
+      {"key": "service.name", "value": {"stringValue": "checkout"}},
+      {"key": "process.pid", "value": {"intValue": "1234"}},
+      {"key": "tags", "value": {"arrayValue": {"values": [{"boolValue": true}, {"doubleValue": 2.5}]}}},
+      {"key": "labels", "value": {"kvlistValue": {"values": [{"key": "a", "value": {"bytesValue": "AQI="}}]}}}
+    ]},
+    "scopeMetrics": [{
+      "scope": {"name": "io.opentelemetry.jvm", "version": "1.0"},
+      "metrics": [
+        {"name": "jvm.threads", "gauge": {"dataPoints": [
+          {"timeUnixNano": "1700000000000000000", "asInt": "12", "attributes": [{"key": "daemon", "value": {"boolValue": false}}]}
+        ]}},
+        {"name": "requests", "sum": {"aggregationTemporality": 1, "isMonotonic": true, "dataPoints": [
+          {"timeUnixNano": 1700000000000000000, "asDouble": "NaN"}
+        ]}},
+        {"name": "duration", "histogram": {"aggregationTemporality": "AGGREGATION_TEMPORALITY_CUMULATIVE", "dataPoints": [
+          {"count": "3", "sum": 1.5, "bucketCounts": ["1", 2], "explicitBounds": [0.5]}
+        ]}},
+        {"name": "size", "exponentialHistogram": {"dataPoints": [
+          {"count": 3, "scale": 1, "zeroCount": "1", "negative": {"offset": -2, "bucketCounts": [2]}, "flags": 1}
+        ]}},
+        {"name": "latency", "summary": {"dataPoints": [
+          {"count": "2", "sum": 3, "quantileValues": [{"quantile": 0.5, "value": "Infinity"}]}
+        ]}}
+      ]
+    }]
+  }]
+}`
+
+	req, err := decodeJSONRequest([]byte(body))
+	require.NoError(t, err)
+	require.Len(t, req.resourceMetrics, 1)
+
+	rm := req.resourceMetrics[0]
+	assert.Equal(t, []attribute{
+		{key: "service.name", value: "checkout"},
+		{key: "process.pid", value: int64(1234)},
+		{key: "tags", value: []interface{}{true, 2.5}},
+		{key: "labels", value: []attribute{{key: "a", value: []byte{1, 2}}}},
+	}, rm.resource)
+	require.Len(t, rm.scopeMetrics, 1)
+	assert.Equal(t, scope{name: "io.opentelemetry.jvm", version: "1.0"}, rm.scopeMetrics[0].scope)
+
+	metrics := rm.scopeMetrics[0].metrics
+	require.Len(t, metrics, 5)
+
+	assert.Equal(t, metric{
+		name: "jvm.threads",
+		typ:  metricTypeGauge,
+		numberPoints: []numberPoint{{
+			point: point{
+				attributes:   []attribute{{key: "daemon", value: false}},
+				timeUnixNano: 1700000000000000000,
+			},
+			value: int64(12),
+		}},
+	}, metrics[0])
+
+	assert.Equal(t, metricTypeSum, metrics[1].typ)
+	assert.Equal(t, temporalityDelta, metrics[1].temporality)
+	assert.True(t, metrics[1].monotonic)
+	require.Len(t, metrics[1].numberPoints, 1)
+	assert.True(t, math.IsNaN(metrics[1].numberPoints[0].value.(float64)))
+
+	assert.Equal(t, metric{
+		name:        "duration",
+		typ:         metricTypeHistogram,
+		temporality: temporalityCumulative,
+		histogramPoints: []histogramPoint{{
+			point:          point{attributes: []attribute{}},
+			count:          3,
+			sum:            1.5,
+			hasSum:         true,
+			bucketCounts:   []uint64{1, 2},
+			explicitBounds: []float64{0.5},
+		}},
+	}, metrics[2])
+
+	assert.Equal(t, metric{
+		name: "size",
+		typ:  metricTypeExponentialHistogram,
+		exponentialHistogramPoints: []exponentialHistogramPoint{{
+			point:     point{attributes: []attribute{}, flags: flagNoRecordedValue},
+			count:     3,
+			scale:     1,
+			zeroCount: 1,
+			positive:  buckets{counts: []uint64{}},
+			negative:  buckets{offset: -2, counts: []uint64{2}},
+		}},
+	}, metrics[3])
+
+	require.Len(t, metrics[4].summaryPoints, 1)
+	assert.Equal(t, uint64(2), metrics[4].summaryPoints[0].count)
+	assert.True(t, math.IsInf(metrics[4].summaryPoints[0].quantiles[0].value, 1))
+}
+
+func TestDecodeJSONRequestErrors(t *testing.T) {
+	for name, body := range map[string]string{
+		"invalid json":        `{"resourceMetrics": [`,
+		"invalid integer":     `{"resourceMetrics": [{"scopeMetrics": [{"metrics": [{"gauge": {"dataPoints": [{"asInt": "1.5"}]}}]}]}]}`,
+		"invalid temporality": `{"resourceMetrics": [{"scopeMetrics": [{"metrics": [{"sum": {"aggregationTemporality": "DELTA"}}]}]}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeJSONRequest([]byte(body))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" // Register the gzip compressor used by OTLP exporters.
+	"google.golang.org/grpc/status"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const metricsServiceName = "opentelemetry.proto.collector.metrics.v1.MetricsService"
+
+// metricsServiceDesc describes the MetricsService of OTLP. Requests are
+// received as raw bytes by rawCodec and decoded by decodeProtoRequest.
+var metricsServiceDesc = grpc.ServiceDesc{
+	ServiceName: metricsServiceName,
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    grpcExportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/metrics/v1/metrics_service.proto",
+}
+
+func grpcExportHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	var body []byte
+	if err := dec(&body); err != nil {
+		return nil, err
+	}
+	s := srv.(*grpcServer)
+	if interceptor == nil {
+		return s.export(body)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + metricsServiceName + "/Export",
+	}
+	return interceptor(ctx, body, info, func(_ context.Context, req interface{}) (interface{}, error) {
+		return s.export(req.([]byte))
+	})
+}
+
+// rawCodec passes the messages of the service as protobuf encoded bytes.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	// The buffer may be reused once this returns.
+	*b = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// grpcServer is the OTLP/gRPC endpoint.
+type grpcServer struct {
+	addr           string
+	maxMessageSize int
+	tlsConfig      *tls.Config
+	handler        requestHandler
+	logger         *logp.Logger
+}
+
+func (s *grpcServer) run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.MaxRecvMsgSize(s.maxMessageSize),
+	}
+	if s.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+	server := grpc.NewServer(opts...)
+	server.RegisterService(&metricsServiceDesc, s)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(5 * time.Second):
+				server.Stop()
+			}
+		case <-done:
+		}
+	}()
+
+	s.logger.Infow("starting OTLP/gRPC endpoint", "address", s.addr)
+	err = server.Serve(listener)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return err
+}
+
+// export handles an ExportMetricsServiceRequest and returns an empty
+// ExportMetricsServiceResponse.
+func (s *grpcServer) export(body []byte) (interface{}, error) {
+	req, err := decodeProtoRequest(body)
+	if err != nil {
+		s.logger.Debugw("rejecting OTLP/gRPC request", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode request: %v", err)
+	}
+	if !s.handler(req) {
+		return nil, status.Error(codes.Unavailable, "metricset is stopping")
+	}
+	return []byte{}, nil
+}
@@ -0,0 +1,148 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	metricsPath = "/v1/metrics"
+
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// httpServer is the OTLP/HTTP endpoint.
+type httpServer struct {
+	addr      string
+	tlsConfig *tls.Config
+	handler   http.Handler
+	logger    *logp.Logger
+}
+
+func (s *httpServer) run(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.handler,
+		TLSConfig:         s.tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		case <-done:
+		}
+	}()
+
+	s.logger.Infow("starting OTLP/HTTP endpoint", "address", s.addr)
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// httpHandler handles the requests sent to /v1/metrics, encoded in
+// protobuf or JSON and optionally compressed with gzip.
+type httpHandler struct {
+	maxMessageSize int64
+	handler        requestHandler
+	logger         *logp.Logger
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != metricsPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.reject(w, http.StatusMethodNotAllowed, "only POST requests are supported")
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var decode func([]byte) (*exportRequest, error)
+	switch contentType {
+	case contentTypeProtobuf:
+		decode = decodeProtoRequest
+	case contentTypeJSON:
+		decode = decodeJSONRequest
+	default:
+		h.reject(w, http.StatusUnsupportedMediaType, fmt.Sprintf("unsupported content type %q", contentType))
+		return
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, h.maxMessageSize)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			h.reject(w, http.StatusBadRequest, fmt.Sprintf("failed to decompress body: %v", err))
+			return
+		}
+		defer gz.Close()
+		// One more byte than the limit is read to detect bodies
+		// exceeding it.
+		body = io.LimitReader(gz, h.maxMessageSize+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.reject(w, http.StatusRequestEntityTooLarge, "request body is too large")
+			return
+		}
+		h.reject(w, http.StatusBadRequest, fmt.Sprintf("failed to read body: %v", err))
+		return
+	}
+	if int64(len(data)) > h.maxMessageSize {
+		h.reject(w, http.StatusRequestEntityTooLarge, "request body is too large")
+		return
+	}
+
+	req, err := decode(data)
+	if err != nil {
+		h.reject(w, http.StatusBadRequest, fmt.Sprintf("failed to decode request: %v", err))
+		return
+	}
+	if !h.handler(req) {
+		h.reject(w, http.StatusServiceUnavailable, "metricset is stopping")
+		return
+	}
+
+	// The response is an empty ExportMetricsServiceResponse.
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if contentType == contentTypeJSON {
+		_, _ = io.WriteString(w, "{}")
+	}
+}
+
+func (h *httpHandler) reject(w http.ResponseWriter, status int, message string) {
+	h.logger.Debugw("rejecting OTLP/HTTP request", "status", status, "error", message)
+	http.Error(w, message, status)
+}
@@ -0,0 +1,153 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	jsonBody := `{"resourceMetrics": [{"scopeMetrics": [{"metrics": [{"name": "up", "gauge": {"dataPoints": [{"asInt": "1"}]}}]}]}]}`
+	protoBody := appendMessage(nil, 1, appendMessage(nil, 2, appendMessage(nil, 2, appendString(nil, 1, "up"))))
+
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, _ = gz.Write([]byte(jsonBody))
+	_ = gz.Close()
+
+	tests := map[string]struct {
+		method          string
+		path            string
+		contentType     string
+		contentEncoding string
+		body            []byte
+		stopping        bool
+
+		status       int
+		responseBody string
+		requests     int
+	}{
+		"json": {
+			contentType:  "application/json",
+			body:         []byte(jsonBody),
+			status:       http.StatusOK,
+			responseBody: "{}",
+			requests:     1,
+		},
+		"protobuf": {
+			contentType: "application/x-protobuf",
+			body:        protoBody,
+			status:      http.StatusOK,
+			requests:    1,
+		},
+		"gzip": {
+			contentType:     "application/json; charset=utf-8",
+			contentEncoding: "gzip",
+			body:            gzipped.Bytes(),
+			status:          http.StatusOK,
+			responseBody:    "{}",
+			requests:        1,
+		},
+		"wrong path": {
+			path:        "/v1/traces",
+			contentType: "application/json",
+			body:        []byte(jsonBody),
+			status:      http.StatusNotFound,
+		},
+		"wrong method": {
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+		"unsupported content type": {
+			contentType: "text/plain",
+			body:        []byte(jsonBody),
+			status:      http.StatusUnsupportedMediaType,
+		},
+		"invalid body": {
+			contentType: "application/x-protobuf",
+			body:        []byte{0xff},
+			status:      http.StatusBadRequest,
+		},
+		"invalid gzip": {
+			contentType:     "application/json",
+			contentEncoding: "gzip",
+			body:            []byte(jsonBody),
+			status:          http.StatusBadRequest,
+		},
+		"too large": {
+			contentType: "application/json",
+			body:        []byte(jsonBody + strings.Repeat(" ", 1024)),
+			status:      http.StatusRequestEntityTooLarge,
+		},
+		"too large decompressed": {
+			contentType:     "application/json",
+			contentEncoding: "gzip",
+			body:            gzipBytes(t, jsonBody+strings.Repeat(" ", 1024)),
+			status:          http.StatusRequestEntityTooLarge,
+		},
+		"stopping": {
+			contentType: "application/json",
+			body:        []byte(jsonBody),
+			stopping:    true,
+			status:      http.StatusServiceUnavailable,
+			requests:    1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []*exportRequest
+			h := &httpHandler{
+				maxMessageSize: 512,
+				handler: func(req *exportRequest) bool {
+					requests = append(requests, req)
+					return !tc.stopping
+				},
+				logger: logp.NewLogger("test"),
+			}
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			path := tc.path
+			if path == "" {
+				path = metricsPath
+			}
+			r := httptest.NewRequest(method, path, bytes.NewReader(tc.body))
+			r.Header.Set("Content-Type", tc.contentType)
+			if tc.contentEncoding != "" {
+				r.Header.Set("Content-Encoding", tc.contentEncoding)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.status, w.Code)
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.responseBody, w.Body.String())
+			}
+			assert.Len(t, requests, tc.requests)
+		})
+	}
+}
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(s))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+	return buf.Bytes()
+}
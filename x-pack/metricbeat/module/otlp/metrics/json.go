@@ -0,0 +1,365 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// The JSON encoding of OTLP is the JSON mapping of the protobuf messages,
+// with lowerCamelCase keys. 64 bit integers may be encoded as strings, and
+// enums as integers.
+
+type jsonExportRequest struct {
+	ResourceMetrics []struct {
+		Resource struct {
+			Attributes []jsonKeyValue `json:"attributes"`
+		} `json:"resource"`
+		ScopeMetrics []struct {
+			Scope struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"scope"`
+			Metrics []jsonMetric `json:"metrics"`
+		} `json:"scopeMetrics"`
+	} `json:"resourceMetrics"`
+}
+
+type jsonMetric struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Unit        string `json:"unit"`
+
+	Gauge *struct {
+		DataPoints []jsonNumberPoint `json:"dataPoints"`
+	} `json:"gauge"`
+	Sum *struct {
+		DataPoints             []jsonNumberPoint `json:"dataPoints"`
+		AggregationTemporality jsonTemporality   `json:"aggregationTemporality"`
+		IsMonotonic            bool              `json:"isMonotonic"`
+	} `json:"sum"`
+	Histogram *struct {
+		DataPoints             []jsonHistogramPoint `json:"dataPoints"`
+		AggregationTemporality jsonTemporality      `json:"aggregationTemporality"`
+	} `json:"histogram"`
+	ExponentialHistogram *struct {
+		DataPoints             []jsonExponentialHistogramPoint `json:"dataPoints"`
+		AggregationTemporality jsonTemporality                 `json:"aggregationTemporality"`
+	} `json:"exponentialHistogram"`
+	Summary *struct {
+		DataPoints []jsonSummaryPoint `json:"dataPoints"`
+	} `json:"summary"`
+}
+
+type jsonPoint struct {
+	Attributes   []jsonKeyValue `json:"attributes"`
+	TimeUnixNano jsonUint64     `json:"timeUnixNano"`
+	Flags        uint32         `json:"flags"`
+}
+
+type jsonNumberPoint struct {
+	jsonPoint
+	AsDouble *jsonDouble `json:"asDouble"`
+	AsInt    *jsonInt64  `json:"asInt"`
+}
+
+type jsonHistogramPoint struct {
+	jsonPoint
+	Count          jsonUint64   `json:"count"`
+	Sum            *jsonDouble  `json:"sum"`
+	BucketCounts   []jsonUint64 `json:"bucketCounts"`
+	ExplicitBounds []jsonDouble `json:"explicitBounds"`
+}
+
+type jsonExponentialHistogramPoint struct {
+	jsonPoint
+	Count     jsonUint64  `json:"count"`
+	Sum       *jsonDouble `json:"sum"`
+	Scale     int32       `json:"scale"`
+	ZeroCount jsonUint64  `json:"zeroCount"`
+	Positive  jsonBuckets `json:"positive"`
+	Negative  jsonBuckets `json:"negative"`
+}
+
+type jsonBuckets struct {
+	Offset       int32        `json:"offset"`
+	BucketCounts []jsonUint64 `json:"bucketCounts"`
+}
+
+type jsonSummaryPoint struct {
+	jsonPoint
+	Count          jsonUint64 `json:"count"`
+	Sum            jsonDouble `json:"sum"`
+	QuantileValues []struct {
+		Quantile jsonDouble `json:"quantile"`
+		Value    jsonDouble `json:"value"`
+	} `json:"quantileValues"`
+}
+
+type jsonKeyValue struct {
+	Key   string       `json:"key"`
+	Value jsonAnyValue `json:"value"`
+}
+
+type jsonAnyValue struct {
+	StringValue *string     `json:"stringValue"`
+	BoolValue   *bool       `json:"boolValue"`
+	IntValue    *jsonInt64  `json:"intValue"`
+	DoubleValue *jsonDouble `json:"doubleValue"`
+	ArrayValue  *struct {
+		Values []jsonAnyValue `json:"values"`
+	} `json:"arrayValue"`
+	KvlistValue *struct {
+		Values []jsonKeyValue `json:"values"`
+	} `json:"kvlistValue"`
+	// BytesValue is decoded from base64 by encoding/json.
+	BytesValue []byte `json:"bytesValue"`
+}
+
+// unquote returns the content of a JSON string, or the data itself for
+// other values.
+func unquote(data []byte) (string, error) {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	}
+	return string(data), nil
+}
+
+// jsonUint64 is an unsigned 64 bit integer encoded as a number or a string.
+type jsonUint64 uint64
+
+func (v *jsonUint64) UnmarshalJSON(data []byte) error {
+	s, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid unsigned integer %s", data)
+	}
+	*v = jsonUint64(n)
+	return nil
+}
+
+// jsonInt64 is a signed 64 bit integer encoded as a number or a string.
+type jsonInt64 int64
+
+func (v *jsonInt64) UnmarshalJSON(data []byte) error {
+	s, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", data)
+	}
+	*v = jsonInt64(n)
+	return nil
+}
+
+// jsonDouble is a double encoded as a number, or as a string for the
+// special values NaN, Infinity and -Infinity.
+type jsonDouble float64
+
+func (v *jsonDouble) UnmarshalJSON(data []byte) error {
+	s, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	switch s {
+	case "NaN":
+		*v = jsonDouble(math.NaN())
+	case "Infinity":
+		*v = jsonDouble(math.Inf(1))
+	case "-Infinity":
+		*v = jsonDouble(math.Inf(-1))
+	default:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid double %s", data)
+		}
+		*v = jsonDouble(f)
+	}
+	return nil
+}
+
+// jsonTemporality is an AggregationTemporality encoded as an integer or by
+// its name.
+type jsonTemporality temporality
+
+func (v *jsonTemporality) UnmarshalJSON(data []byte) error {
+	s, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	switch s {
+	case "AGGREGATION_TEMPORALITY_UNSPECIFIED":
+		*v = jsonTemporality(temporalityUnspecified)
+	case "AGGREGATION_TEMPORALITY_DELTA":
+		*v = jsonTemporality(temporalityDelta)
+	case "AGGREGATION_TEMPORALITY_CUMULATIVE":
+		*v = jsonTemporality(temporalityCumulative)
+	default:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid aggregation temporality %s", data)
+		}
+		*v = jsonTemporality(n)
+	}
+	return nil
+}
+
+// decodeJSONRequest decodes an ExportMetricsServiceRequest in the JSON
+// encoding of OTLP.
+func decodeJSONRequest(b []byte) (*exportRequest, error) {
+	var in jsonExportRequest
+	if err := json.Unmarshal(b, &in); err != nil {
+		return nil, err
+	}
+
+	var req exportRequest
+	for _, jrm := range in.ResourceMetrics {
+		rm := resourceMetrics{resource: jsonAttributes(jrm.Resource.Attributes)}
+		for _, jsm := range jrm.ScopeMetrics {
+			sm := scopeMetrics{scope: scope{name: jsm.Scope.Name, version: jsm.Scope.Version}}
+			for _, jm := range jsm.Metrics {
+				sm.metrics = append(sm.metrics, jm.toMetric())
+			}
+			rm.scopeMetrics = append(rm.scopeMetrics, sm)
+		}
+		req.resourceMetrics = append(req.resourceMetrics, rm)
+	}
+	return &req, nil
+}
+
+func (jm jsonMetric) toMetric() metric {
+	m := metric{name: jm.Name, description: jm.Description, unit: jm.Unit}
+	switch {
+	case jm.Gauge != nil:
+		m.typ = metricTypeGauge
+		m.numberPoints = jsonNumberPoints(jm.Gauge.DataPoints)
+	case jm.Sum != nil:
+		m.typ = metricTypeSum
+		m.temporality = temporality(jm.Sum.AggregationTemporality)
+		m.monotonic = jm.Sum.IsMonotonic
+		m.numberPoints = jsonNumberPoints(jm.Sum.DataPoints)
+	case jm.Histogram != nil:
+		m.typ = metricTypeHistogram
+		m.temporality = temporality(jm.Histogram.AggregationTemporality)
+		for _, jp := range jm.Histogram.DataPoints {
+			p := histogramPoint{
+				point:        jp.toPoint(),
+				count:        uint64(jp.Count),
+				bucketCounts: jsonUint64s(jp.BucketCounts),
+			}
+			if jp.Sum != nil {
+				p.sum, p.hasSum = float64(*jp.Sum), true
+			}
+			for _, b := range jp.ExplicitBounds {
+				p.explicitBounds = append(p.explicitBounds, float64(b))
+			}
+			m.histogramPoints = append(m.histogramPoints, p)
+		}
+	case jm.ExponentialHistogram != nil:
+		m.typ = metricTypeExponentialHistogram
+		m.temporality = temporality(jm.ExponentialHistogram.AggregationTemporality)
+		for _, jp := range jm.ExponentialHistogram.DataPoints {
+			p := exponentialHistogramPoint{
+				point:     jp.toPoint(),
+				count:     uint64(jp.Count),
+				scale:     jp.Scale,
+				zeroCount: uint64(jp.ZeroCount),
+				positive:  buckets{offset: jp.Positive.Offset, counts: jsonUint64s(jp.Positive.BucketCounts)},
+				negative:  buckets{offset: jp.Negative.Offset, counts: jsonUint64s(jp.Negative.BucketCounts)},
+			}
+			if jp.Sum != nil {
+				p.sum, p.hasSum = float64(*jp.Sum), true
+			}
+			m.exponentialHistogramPoints = append(m.exponentialHistogramPoints, p)
+		}
+	case jm.Summary != nil:
+		m.typ = metricTypeSummary
+		for _, jp := range jm.Summary.DataPoints {
+			p := summaryPoint{
+				point: jp.toPoint(),
+				count: uint64(jp.Count),
+				sum:   float64(jp.Sum),
+			}
+			for _, q := range jp.QuantileValues {
+				p.quantiles = append(p.quantiles, quantileValue{quantile: float64(q.Quantile), value: float64(q.Value)})
+			}
+			m.summaryPoints = append(m.summaryPoints, p)
+		}
+	}
+	return m
+}
+
+func (jp jsonPoint) toPoint() point {
+	return point{
+		attributes:   jsonAttributes(jp.Attributes),
+		timeUnixNano: uint64(jp.TimeUnixNano),
+		flags:        jp.Flags,
+	}
+}
+
+func jsonNumberPoints(jps []jsonNumberPoint) []numberPoint {
+	points := make([]numberPoint, 0, len(jps))
+	for _, jp := range jps {
+		p := numberPoint{point: jp.toPoint()}
+		switch {
+		case jp.AsDouble != nil:
+			p.value = float64(*jp.AsDouble)
+		case jp.AsInt != nil:
+			p.value = int64(*jp.AsInt)
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+func jsonUint64s(values []jsonUint64) []uint64 {
+	out := make([]uint64, 0, len(values))
+	for _, v := range values {
+		out = append(out, uint64(v))
+	}
+	return out
+}
+
+func jsonAttributes(kvs []jsonKeyValue) []attribute {
+	attrs := make([]attribute, 0, len(kvs))
+	for _, kv := range kvs {
+		attrs = append(attrs, attribute{key: kv.Key, value: kv.Value.toValue()})
+	}
+	return attrs
+}
+
+func (v jsonAnyValue) toValue() interface{} {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		return int64(*v.IntValue)
+	case v.DoubleValue != nil:
+		return float64(*v.DoubleValue)
+	case v.ArrayValue != nil:
+		values := make([]interface{}, 0, len(v.ArrayValue.Values))
+		for _, av := range v.ArrayValue.Values {
+			values = append(values, av.toValue())
+		}
+		return values
+	case v.KvlistValue != nil:
+		return jsonAttributes(v.KvlistValue.Values)
+	case v.BytesValue != nil:
+		return v.BytesValue
+	}
+	return nil
+}
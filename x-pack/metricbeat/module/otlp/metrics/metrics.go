@@ -0,0 +1,116 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("otlp", "metrics", New,
+		mb.WithHostParser(parse.EmptyHostParser),
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet receives the metrics sent by OpenTelemetry SDKs and collectors
+// with OTLP, over gRPC and HTTP, and reports them as events. The period of
+// the module is not used, as the metrics are reported when they are
+// received.
+type MetricSet struct {
+	mb.BaseMetricSet
+	config config
+	logger *logp.Logger
+}
+
+// requestHandler reports the metrics of a request. It returns false when
+// they can no longer be reported.
+type requestHandler func(req *exportRequest) bool
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The otlp metrics metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		config:        config,
+		logger:        base.Logger(),
+	}, nil
+}
+
+// Run serves the enabled OTLP endpoints until the context is cancelled.
+func (m *MetricSet) Run(ctx context.Context, r mb.ReporterV2) {
+	var tlsConfig *tls.Config
+	if m.config.TLS.IsEnabled() {
+		tlsCommon, err := tlscommon.LoadTLSServerConfig(m.config.TLS)
+		if err != nil {
+			r.Error(fmt.Errorf("failed to load ssl settings: %w", err))
+			return
+		}
+		tlsConfig = tlsCommon.BuildServerConfig(m.config.Host)
+	}
+
+	handler := func(req *exportRequest) bool {
+		return m.report(req, r)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	if m.config.GRPC.Enabled {
+		s := &grpcServer{
+			addr:           net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.GRPC.Port)),
+			maxMessageSize: int(m.config.MaxMessageSize),
+			tlsConfig:      tlsConfig,
+			handler:        handler,
+			logger:         m.logger,
+		}
+		g.Go(func() error { return s.run(ctx) })
+	}
+	if m.config.HTTP.Enabled {
+		s := &httpServer{
+			addr:      net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.HTTP.Port)),
+			tlsConfig: tlsConfig,
+			handler: &httpHandler{
+				maxMessageSize: int64(m.config.MaxMessageSize),
+				handler:        handler,
+				logger:         m.logger,
+			},
+			logger: m.logger,
+		}
+		g.Go(func() error { return s.run(ctx) })
+	}
+
+	if err := g.Wait(); err != nil {
+		m.logger.Errorw("OTLP endpoint stopped", "error", err)
+		r.Error(err)
+	}
+}
+
+// report reports the events of the metrics of a request.
+func (m *MetricSet) report(req *exportRequest, r mb.ReporterV2) bool {
+	for _, event := range eventsMapping(req, time.Now()) {
+		if !r.Event(event) {
+			return false
+		}
+	}
+	return true
+}
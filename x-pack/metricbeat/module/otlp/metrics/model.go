@@ -0,0 +1,130 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+// exportRequest is the content of an ExportMetricsServiceRequest of the
+// OTLP metrics service, decoded from protobuf or JSON.
+type exportRequest struct {
+	resourceMetrics []resourceMetrics
+}
+
+// resourceMetrics holds the metrics of the scopes of a resource.
+type resourceMetrics struct {
+	resource     []attribute
+	scopeMetrics []scopeMetrics
+}
+
+// scopeMetrics holds the metrics of an instrumentation scope.
+type scopeMetrics struct {
+	scope   scope
+	metrics []metric
+}
+
+// scope is the instrumentation scope that produced a group of metrics.
+type scope struct {
+	name    string
+	version string
+}
+
+// attribute is a key-value pair of a resource or a data point. Values are
+// strings, booleans, int64, float64, []byte, []interface{} for arrays and
+// []attribute for lists of key-value pairs.
+type attribute struct {
+	key   string
+	value interface{}
+}
+
+type metricType int
+
+const (
+	metricTypeUnknown metricType = iota
+	metricTypeGauge
+	metricTypeSum
+	metricTypeHistogram
+	metricTypeExponentialHistogram
+	metricTypeSummary
+)
+
+// temporality is the AggregationTemporality of sums and histograms.
+type temporality int
+
+const (
+	temporalityUnspecified temporality = 0
+	temporalityDelta       temporality = 1
+	temporalityCumulative  temporality = 2
+)
+
+// flagNoRecordedValue is set in the flags of the data points that mark the
+// absence of a value, as when a target disappears.
+const flagNoRecordedValue = 1
+
+// metric is a metric of any type. Only the data points of its type are set.
+type metric struct {
+	name        string
+	description string
+	unit        string
+
+	typ         metricType
+	temporality temporality
+	monotonic   bool
+
+	numberPoints               []numberPoint
+	histogramPoints            []histogramPoint
+	exponentialHistogramPoints []exponentialHistogramPoint
+	summaryPoints              []summaryPoint
+}
+
+// point holds the fields common to the data points of all the metric types.
+type point struct {
+	attributes   []attribute
+	timeUnixNano uint64
+	flags        uint32
+}
+
+// numberPoint is a data point of a gauge or a sum. Its value is a float64
+// or an int64, or nil if the point has no value.
+type numberPoint struct {
+	point
+	value interface{}
+}
+
+type histogramPoint struct {
+	point
+	count          uint64
+	sum            float64
+	hasSum         bool
+	bucketCounts   []uint64
+	explicitBounds []float64
+}
+
+type exponentialHistogramPoint struct {
+	point
+	count     uint64
+	sum       float64
+	hasSum    bool
+	scale     int32
+	zeroCount uint64
+	positive  buckets
+	negative  buckets
+}
+
+// buckets are the buckets of one sign of an exponential histogram. The
+// first count is the one of the bucket at the offset index.
+type buckets struct {
+	offset int32
+	counts []uint64
+}
+
+type summaryPoint struct {
+	point
+	count     uint64
+	sum       float64
+	quantiles []quantileValue
+}
+
+type quantileValue struct {
+	quantile float64
+	value    float64
+}
@@ -0,0 +1,554 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of the OTLP metrics service are decoded from the protobuf
+// wire format without generated code. The field numbers are the ones of the
+// opentelemetry/proto/metrics/v1 and opentelemetry/proto/common/v1 packages.
+// Unknown fields, like exemplars, are skipped.
+
+// field is a field of a protobuf message. Varint and fixed size values are
+// held in num, length-delimited ones in bytes.
+type field struct {
+	number protowire.Number
+	typ    protowire.Type
+	num    uint64
+	bytes  []byte
+}
+
+// forEachField calls fn with every field of the message in b.
+func forEachField(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		number, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f := field{number: number, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			f.num, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			f.num = uint64(v)
+		case protowire.Fixed64Type:
+			f.num, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(number, typ, b)
+		}
+		if n < 0 {
+			return fmt.Errorf("field %d: %w", number, protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f field) expect(typ protowire.Type) error {
+	if f.typ != typ {
+		return fmt.Errorf("field %d has wire type %d, expected %d", f.number, f.typ, typ)
+	}
+	return nil
+}
+
+func (f field) message() ([]byte, error) {
+	return f.bytes, f.expect(protowire.BytesType)
+}
+
+func (f field) string() (string, error) {
+	return string(f.bytes), f.expect(protowire.BytesType)
+}
+
+func (f field) varint() (uint64, error) {
+	return f.num, f.expect(protowire.VarintType)
+}
+
+func (f field) fixed64() (uint64, error) {
+	return f.num, f.expect(protowire.Fixed64Type)
+}
+
+func (f field) double() (float64, error) {
+	return math.Float64frombits(f.num), f.expect(protowire.Fixed64Type)
+}
+
+// fixed64s returns the values of a repeated fixed64 or double field, packed
+// or not.
+func (f field) fixed64s() ([]uint64, error) {
+	if f.typ == protowire.Fixed64Type {
+		return []uint64{f.num}, nil
+	}
+	if err := f.expect(protowire.BytesType); err != nil {
+		return nil, err
+	}
+	values := make([]uint64, 0, len(f.bytes)/8)
+	for b := f.bytes; len(b) > 0; {
+		v, n := protowire.ConsumeFixed64(b)
+		if n < 0 {
+			return nil, fmt.Errorf("field %d: %w", f.number, protowire.ParseError(n))
+		}
+		values = append(values, v)
+		b = b[n:]
+	}
+	return values, nil
+}
+
+// varints returns the values of a repeated varint field, packed or not.
+func (f field) varints() ([]uint64, error) {
+	if f.typ == protowire.VarintType {
+		return []uint64{f.num}, nil
+	}
+	if err := f.expect(protowire.BytesType); err != nil {
+		return nil, err
+	}
+	var values []uint64
+	for b := f.bytes; len(b) > 0; {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, fmt.Errorf("field %d: %w", f.number, protowire.ParseError(n))
+		}
+		values = append(values, v)
+		b = b[n:]
+	}
+	return values, nil
+}
+
+// decodeProtoRequest decodes an ExportMetricsServiceRequest in the protobuf
+// wire format.
+func decodeProtoRequest(b []byte) (*exportRequest, error) {
+	var req exportRequest
+	err := forEachField(b, func(f field) error {
+		if f.number != 1 {
+			return nil
+		}
+		msg, err := f.message()
+		if err != nil {
+			return err
+		}
+		rm, err := decodeResourceMetrics(msg)
+		if err != nil {
+			return fmt.Errorf("invalid resource metrics: %w", err)
+		}
+		req.resourceMetrics = append(req.resourceMetrics, rm)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func decodeResourceMetrics(b []byte) (resourceMetrics, error) {
+	var rm resourceMetrics
+	err := forEachField(b, func(f field) error {
+		switch f.number {
+		case 1:
+			msg, err := f.message()
+			if err != nil {
+				return err
+			}
+			// Resource, only its attributes are used.
+			return forEachField(msg, func(f field) error {
+				if f.number != 1 {
+					return nil
+				}
+				attr, err := decodeKeyValue(f)
+				if err != nil {
+					return err
+				}
+				rm.resource = append(rm.resource, attr)
+				return nil
+			})
+		case 2:
+			msg, err := f.message()
+			if err != nil {
+				return err
+			}
+			sm, err := decodeScopeMetrics(msg)
+			if err != nil {
+				return fmt.Errorf("invalid scope metrics: %w", err)
+			}
+			rm.scopeMetrics = append(rm.scopeMetrics, sm)
+		}
+		return nil
+	})
+	return rm, err
+}
+
+func decodeScopeMetrics(b []byte) (scopeMetrics, error) {
+	var sm scopeMetrics
+	err := forEachField(b, func(f field) error {
+		switch f.number {
+		case 1:
+			msg, err := f.message()
+			if err != nil {
+				return err
+			}
+			return forEachField(msg, func(f field) error {
+				var err error
+				switch f.number {
+				case 1:
+					sm.scope.name, err = f.string()
+				case 2:
+					sm.scope.version, err = f.string()
+				}
+				return err
+			})
+		case 2:
+			msg, err := f.message()
+			if err != nil {
+				return err
+			}
+			m, err := decodeMetric(msg)
+			if err != nil {
+				return fmt.Errorf("invalid metric %q: %w", m.name, err)
+			}
+			sm.metrics = append(sm.metrics, m)
+		}
+		return nil
+	})
+	return sm, err
+}
+
+func decodeMetric(b []byte) (metric, error) {
+	var m metric
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 1:
+			m.name, err = f.string()
+		case 2:
+			m.description, err = f.string()
+		case 3:
+			m.unit, err = f.string()
+		case 5:
+			m.typ = metricTypeGauge
+			err = decodeMetricData(f, &m)
+		case 7:
+			m.typ = metricTypeSum
+			err = decodeMetricData(f, &m)
+		case 9:
+			m.typ = metricTypeHistogram
+			err = decodeMetricData(f, &m)
+		case 10:
+			m.typ = metricTypeExponentialHistogram
+			err = decodeMetricData(f, &m)
+		case 11:
+			m.typ = metricTypeSummary
+			err = decodeMetricData(f, &m)
+		}
+		return err
+	})
+	return m, err
+}
+
+// decodeMetricData decodes the Gauge, Sum, Histogram, ExponentialHistogram
+// or Summary message of a metric, according to its type.
+func decodeMetricData(f field, m *metric) error {
+	msg, err := f.message()
+	if err != nil {
+		return err
+	}
+	return forEachField(msg, func(f field) error {
+		switch f.number {
+		case 1:
+			msg, err := f.message()
+			if err != nil {
+				return err
+			}
+			return decodeDataPoint(msg, m)
+		case 2:
+			v, err := f.varint()
+			m.temporality = temporality(v)
+			return err
+		case 3:
+			v, err := f.varint()
+			m.monotonic = v != 0
+			return err
+		}
+		return nil
+	})
+}
+
+func decodeDataPoint(b []byte, m *metric) error {
+	switch m.typ {
+	case metricTypeGauge, metricTypeSum:
+		p, err := decodeNumberPoint(b)
+		m.numberPoints = append(m.numberPoints, p)
+		return err
+	case metricTypeHistogram:
+		p, err := decodeHistogramPoint(b)
+		m.histogramPoints = append(m.histogramPoints, p)
+		return err
+	case metricTypeExponentialHistogram:
+		p, err := decodeExponentialHistogramPoint(b)
+		m.exponentialHistogramPoints = append(m.exponentialHistogramPoints, p)
+		return err
+	case metricTypeSummary:
+		p, err := decodeSummaryPoint(b)
+		m.summaryPoints = append(m.summaryPoints, p)
+		return err
+	}
+	return nil
+}
+
+// decodePointField decodes the fields common to all the data points. The
+// time is field 3 in all of them, the numbers of the attributes and flags
+// depend on the type of the data point. Other fields are ignored.
+func decodePointField(f field, attributesNumber, flagsNumber protowire.Number, p *point) error {
+	var err error
+	switch f.number {
+	case attributesNumber:
+		var attr attribute
+		attr, err = decodeKeyValue(f)
+		p.attributes = append(p.attributes, attr)
+	case 3:
+		p.timeUnixNano, err = f.fixed64()
+	case flagsNumber:
+		var v uint64
+		v, err = f.varint()
+		p.flags = uint32(v)
+	}
+	return err
+}
+
+func decodeNumberPoint(b []byte) (numberPoint, error) {
+	var p numberPoint
+	err := forEachField(b, func(f field) error {
+		switch f.number {
+		case 4:
+			v, err := f.double()
+			p.value = v
+			return err
+		case 6:
+			v, err := f.fixed64()
+			p.value = int64(v)
+			return err
+		}
+		return decodePointField(f, 7, 8, &p.point)
+	})
+	return p, err
+}
+
+func decodeHistogramPoint(b []byte) (histogramPoint, error) {
+	var p histogramPoint
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 4:
+			p.count, err = f.fixed64()
+		case 5:
+			p.sum, err = f.double()
+			p.hasSum = true
+		case 6:
+			var counts []uint64
+			counts, err = f.fixed64s()
+			p.bucketCounts = append(p.bucketCounts, counts...)
+		case 7:
+			var bounds []uint64
+			bounds, err = f.fixed64s()
+			for _, v := range bounds {
+				p.explicitBounds = append(p.explicitBounds, math.Float64frombits(v))
+			}
+		default:
+			err = decodePointField(f, 9, 10, &p.point)
+		}
+		return err
+	})
+	return p, err
+}
+
+func decodeExponentialHistogramPoint(b []byte) (exponentialHistogramPoint, error) {
+	var p exponentialHistogramPoint
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 4:
+			p.count, err = f.fixed64()
+		case 5:
+			p.sum, err = f.double()
+			p.hasSum = true
+		case 6:
+			var v uint64
+			v, err = f.varint()
+			p.scale = int32(protowire.DecodeZigZag(v))
+		case 7:
+			p.zeroCount, err = f.fixed64()
+		case 8:
+			p.positive, err = decodeBuckets(f)
+		case 9:
+			p.negative, err = decodeBuckets(f)
+		default:
+			err = decodePointField(f, 1, 10, &p.point)
+		}
+		return err
+	})
+	return p, err
+}
+
+func decodeBuckets(f field) (buckets, error) {
+	var bk buckets
+	msg, err := f.message()
+	if err != nil {
+		return bk, err
+	}
+	err = forEachField(msg, func(f field) error {
+		switch f.number {
+		case 1:
+			v, err := f.varint()
+			bk.offset = int32(protowire.DecodeZigZag(v))
+			return err
+		case 2:
+			counts, err := f.varints()
+			bk.counts = append(bk.counts, counts...)
+			return err
+		}
+		return nil
+	})
+	return bk, err
+}
+
+func decodeSummaryPoint(b []byte) (summaryPoint, error) {
+	var p summaryPoint
+	err := forEachField(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 4:
+			p.count, err = f.fixed64()
+		case 5:
+			p.sum, err = f.double()
+		case 6:
+			var msg []byte
+			msg, err = f.message()
+			if err != nil {
+				return err
+			}
+			var q quantileValue
+			err = forEachField(msg, func(f field) error {
+				var err error
+				switch f.number {
+				case 1:
+					q.quantile, err = f.double()
+				case 2:
+					q.value, err = f.double()
+				}
+				return err
+			})
+			p.quantiles = append(p.quantiles, q)
+		default:
+			err = decodePointField(f, 7, 8, &p.point)
+		}
+		return err
+	})
+	return p, err
+}
+
+// decodeKeyValue decodes a KeyValue message.
+func decodeKeyValue(f field) (attribute, error) {
+	var attr attribute
+	msg, err := f.message()
+	if err != nil {
+		return attr, err
+	}
+	err = forEachField(msg, func(f field) error {
+		var err error
+		switch f.number {
+		case 1:
+			attr.key, err = f.string()
+		case 2:
+			attr.value, err = decodeAnyValue(f)
+		}
+		return err
+	})
+	return attr, err
+}
+
+// decodeAnyValue decodes an AnyValue message.
+func decodeAnyValue(f field) (interface{}, error) {
+	msg, err := f.message()
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = forEachField(msg, func(f field) error {
+		var err error
+		switch f.number {
+		case 1:
+			value, err = f.string()
+		case 2:
+			var v uint64
+			v, err = f.varint()
+			value = v != 0
+		case 3:
+			var v uint64
+			v, err = f.varint()
+			value = int64(v)
+		case 4:
+			value, err = f.double()
+		case 5:
+			var values []interface{}
+			values, err = decodeArrayValue(f)
+			value = values
+		case 6:
+			var attrs []attribute
+			attrs, err = decodeKeyValueList(f)
+			value = attrs
+		case 7:
+			var v []byte
+			v, err = f.message()
+			value = append([]byte(nil), v...)
+		}
+		return err
+	})
+	return value, err
+}
+
+func decodeArrayValue(f field) ([]interface{}, error) {
+	msg, err := f.message()
+	if err != nil {
+		return nil, err
+	}
+	values := []interface{}{}
+	err = forEachField(msg, func(f field) error {
+		if f.number != 1 {
+			return nil
+		}
+		v, err := decodeAnyValue(f)
+		values = append(values, v)
+		return err
+	})
+	return values, err
+}
+
+func decodeKeyValueList(f field) ([]attribute, error) {
+	msg, err := f.message()
+	if err != nil {
+		return nil, err
+	}
+	attrs := []attribute{}
+	err = forEachField(msg, func(f field) error {
+		if f.number != 1 {
+			return nil
+		}
+		attr, err := decodeKeyValue(f)
+		attrs = append(attrs, attr)
+		return err
+	})
+	return attrs, err
+}
@@ -0,0 +1,89 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// ecsFields are the ECS fields of the resource attributes of the
+// OpenTelemetry semantic conventions. Other resource attributes are reported
+// under otlp.resource.attributes.
+var ecsFields = map[string]string{
+	"service.name":                "service.name",
+	"service.version":             "service.version",
+	"service.instance.id":         "service.node.name",
+	"deployment.environment":      "service.environment",
+	"deployment.environment.name": "service.environment",
+
+	"host.name": "host.name",
+	"host.id":   "host.id",
+	"host.arch": "host.architecture",
+	"host.type": "cloud.machine.type",
+
+	"os.type":        "host.os.type",
+	"os.name":        "host.os.name",
+	"os.version":     "host.os.version",
+	"os.description": "host.os.full",
+
+	"cloud.provider":          "cloud.provider",
+	"cloud.platform":          "cloud.service.name",
+	"cloud.region":            "cloud.region",
+	"cloud.availability_zone": "cloud.availability_zone",
+	"cloud.account.id":        "cloud.account.id",
+
+	"container.id":         "container.id",
+	"container.name":       "container.name",
+	"container.runtime":    "container.runtime",
+	"container.image.name": "container.image.name",
+	"container.image.tag":  "container.image.tag",
+
+	"k8s.namespace.name":   "kubernetes.namespace",
+	"k8s.node.name":        "kubernetes.node.name",
+	"k8s.pod.name":         "kubernetes.pod.name",
+	"k8s.pod.uid":          "kubernetes.pod.uid",
+	"k8s.container.name":   "kubernetes.container.name",
+	"k8s.deployment.name":  "kubernetes.deployment.name",
+	"k8s.statefulset.name": "kubernetes.statefulset.name",
+	"k8s.daemonset.name":   "kubernetes.daemonset.name",
+	"k8s.replicaset.name":  "kubernetes.replicaset.name",
+
+	"process.pid":             "process.pid",
+	"process.executable.name": "process.name",
+	"process.executable.path": "process.executable",
+	"process.command_line":    "process.command_line",
+}
+
+// resourceFields returns the root fields of the resource attributes with an
+// ECS field, and the other resource attributes as keywords.
+func resourceFields(attrs []attribute) (root, others mapstr.M) {
+	root, others = mapstr.M{}, mapstr.M{}
+	for _, attr := range attrs {
+		if ecsField, found := ecsFields[attr.key]; found {
+			_, _ = root.Put(ecsField, ecsValue(attr))
+			continue
+		}
+		if key := fieldName(attr.key); key != "" {
+			_, _ = others.Put(key, attributeString(attr.value))
+		}
+	}
+	return root, others
+}
+
+// ecsValue returns the value of the ECS field of a resource attribute.
+// Scalar values are kept, other values are reported as strings.
+func ecsValue(attr attribute) interface{} {
+	switch v := attr.value.(type) {
+	case string:
+		// ECS uses macos for the OS type that OpenTelemetry names darwin.
+		if attr.key == "os.type" && v == "darwin" {
+			return "macos"
+		}
+		return v
+	case bool, int64, float64:
+		return v
+	}
+	return attributeString(attr.value)
+}
@@ -0,0 +1,242 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BER tags of the SNMP types.
+const (
+	tagInteger     byte = 0x02
+	tagOctetString byte = 0x04
+	tagNull        byte = 0x05
+	tagOID         byte = 0x06
+	tagSequence    byte = 0x30
+
+	tagIPAddress byte = 0x40
+	tagCounter32 byte = 0x41
+	tagGauge32   byte = 0x42
+	tagTimeTicks byte = 0x43
+	tagOpaque    byte = 0x44
+	tagCounter64 byte = 0x46
+
+	tagNoSuchObject   byte = 0x80
+	tagNoSuchInstance byte = 0x81
+	tagEndOfMIBView   byte = 0x82
+)
+
+var errTruncated = errors.New("truncated BER data")
+
+// appendTLV appends the encoding of a value with the given tag and content.
+func appendTLV(b []byte, tag byte, content []byte) []byte {
+	b = append(b, tag)
+	b = appendLength(b, len(content))
+	return append(b, content...)
+}
+
+func appendLength(b []byte, n int) []byte {
+	if n < 0x80 {
+		return append(b, byte(n))
+	}
+	var tmp [8]byte
+	i := len(tmp)
+	for ; n > 0; n >>= 8 {
+		i--
+		tmp[i] = byte(n)
+	}
+	b = append(b, 0x80|byte(len(tmp)-i))
+	return append(b, tmp[i:]...)
+}
+
+// sequence encodes the concatenation of the encoded parts with the tag.
+func sequence(tag byte, parts ...[]byte) []byte {
+	var content []byte
+	for _, p := range parts {
+		content = append(content, p...)
+	}
+	return appendTLV(nil, tag, content)
+}
+
+func encodeInteger(v int64) []byte {
+	var content []byte
+	for {
+		content = append([]byte{byte(v)}, content...)
+		if (v >= -0x80 && v < 0x80) || len(content) == 8 {
+			break
+		}
+		v >>= 8
+	}
+	return appendTLV(nil, tagInteger, content)
+}
+
+func encodeOctetString(s []byte) []byte {
+	return appendTLV(nil, tagOctetString, s)
+}
+
+func encodeNull() []byte {
+	return []byte{tagNull, 0}
+}
+
+func encodeOID(oid OID) ([]byte, error) {
+	if len(oid) < 2 || oid[0] > 2 || (oid[0] < 2 && oid[1] >= 40) {
+		return nil, fmt.Errorf("invalid OID %v", oid)
+	}
+	content := appendBase128(nil, oid[0]*40+oid[1])
+	for _, n := range oid[2:] {
+		content = appendBase128(content, n)
+	}
+	return appendTLV(nil, tagOID, content), nil
+}
+
+func appendBase128(b []byte, n uint32) []byte {
+	var tmp [5]byte
+	i := len(tmp) - 1
+	tmp[i] = byte(n & 0x7f)
+	for n >>= 7; n > 0; n >>= 7 {
+		i--
+		tmp[i] = byte(n&0x7f) | 0x80
+	}
+	return append(b, tmp[i:]...)
+}
+
+// berReader reads BER encoded values.
+type berReader struct {
+	data []byte
+}
+
+func (r *berReader) empty() bool {
+	return len(r.data) == 0
+}
+
+// read returns the tag and content of the next value.
+func (r *berReader) read() (byte, []byte, error) {
+	if len(r.data) < 2 {
+		return 0, nil, errTruncated
+	}
+	tag := r.data[0]
+	n := int(r.data[1])
+	off := 2
+	if n&0x80 != 0 {
+		size := n & 0x7f
+		if size == 0 || size > 4 || len(r.data) < off+size {
+			return 0, nil, errTruncated
+		}
+		n = 0
+		for _, c := range r.data[off : off+size] {
+			n = n<<8 | int(c)
+		}
+		off += size
+	}
+	if n < 0 || len(r.data)-off < n {
+		return 0, nil, errTruncated
+	}
+	content := r.data[off : off+n]
+	r.data = r.data[off+n:]
+	return tag, content, nil
+}
+
+// expect reads the next value and checks its tag.
+func (r *berReader) expect(tag byte) ([]byte, error) {
+	t, content, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	if t != tag {
+		return nil, fmt.Errorf("unexpected BER tag 0x%02x, expected 0x%02x", t, tag)
+	}
+	return content, nil
+}
+
+// sequence reads the next value, checks its tag and returns a reader of
+// its content.
+func (r *berReader) sequence(tag byte) (*berReader, error) {
+	content, err := r.expect(tag)
+	if err != nil {
+		return nil, err
+	}
+	return &berReader{data: content}, nil
+}
+
+func (r *berReader) integer() (int64, error) {
+	content, err := r.expect(tagInteger)
+	if err != nil {
+		return 0, err
+	}
+	return decodeInteger(content)
+}
+
+func (r *berReader) octetString() ([]byte, error) {
+	return r.expect(tagOctetString)
+}
+
+func (r *berReader) oid() (OID, error) {
+	content, err := r.expect(tagOID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeOID(content)
+}
+
+func decodeInteger(b []byte) (int64, error) {
+	if len(b) == 0 || len(b) > 8 {
+		return 0, fmt.Errorf("invalid integer length %d", len(b))
+	}
+	v := int64(int8(b[0]))
+	for _, c := range b[1:] {
+		v = v<<8 | int64(c)
+	}
+	return v, nil
+}
+
+// decodeUnsigned decodes the unsigned integer types, whose encoding can
+// have a leading zero byte.
+func decodeUnsigned(b []byte) (uint64, error) {
+	if len(b) == 0 || len(b) > 9 || (len(b) == 9 && b[0] != 0) {
+		return 0, fmt.Errorf("invalid unsigned integer length %d", len(b))
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+func decodeOID(b []byte) (OID, error) {
+	if len(b) == 0 {
+		return nil, errors.New("empty OID")
+	}
+	var (
+		oid OID
+		n   uint64
+	)
+	for i, c := range b {
+		n = n<<7 | uint64(c&0x7f)
+		if n > 0xffffffff {
+			return nil, errors.New("OID sub-identifier overflow")
+		}
+		if c&0x80 != 0 {
+			if i == len(b)-1 {
+				return nil, errTruncated
+			}
+			continue
+		}
+		if oid == nil {
+			switch {
+			case n < 40:
+				oid = OID{0, uint32(n)}
+			case n < 80:
+				oid = OID{1, uint32(n - 40)}
+			default:
+				oid = OID{2, uint32(n - 80)}
+			}
+		} else {
+			oid = append(oid, uint32(n))
+		}
+		n = 0
+	}
+	return oid, nil
+}
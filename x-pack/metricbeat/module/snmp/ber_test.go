@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOID(t *testing.T) {
+	oid, err := ParseOID(".1.3.6.1.2.1.1.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, OID{1, 3, 6, 1, 2, 1, 1, 1, 0}, oid)
+	assert.Equal(t, "1.3.6.1.2.1.1.1.0", oid.String())
+	assert.True(t, oid.HasPrefix(OID{1, 3, 6, 1}))
+	assert.False(t, OID{1, 3}.HasPrefix(oid))
+	assert.Equal(t, -1, OID{1, 3, 6}.Compare(OID{1, 3, 6, 1}))
+	assert.Equal(t, 1, OID{1, 3, 7}.Compare(OID{1, 3, 6, 1}))
+	assert.Equal(t, 0, oid.Compare(oid.Append()))
+
+	_, err = ParseOID("1.3.x")
+	assert.Error(t, err)
+}
+
+func TestBERIntegers(t *testing.T) {
+	for _, v := range []int64{0, 1, 127, 128, 255, 256, -1, -128, -129, 1<<31 - 1, -1 << 31, 1<<63 - 1} {
+		r := &berReader{data: encodeInteger(v)}
+		got, err := r.integer()
+		require.NoError(t, err)
+		assert.Equal(t, v, got)
+	}
+	assert.Equal(t, []byte{0x02, 0x02, 0x00, 0x80}, encodeInteger(128))
+}
+
+func TestBEROIDs(t *testing.T) {
+	for _, oid := range []OID{{1, 3}, {1, 3, 6, 1, 4, 1, 2636, 3, 1}, {2, 999, 4294967295}, {0, 39}} {
+		b, err := encodeOID(oid)
+		require.NoError(t, err)
+		got, err := (&berReader{data: b}).oid()
+		require.NoError(t, err)
+		assert.Equal(t, oid, got)
+	}
+
+	_, err := encodeOID(OID{1})
+	assert.Error(t, err)
+	_, err = decodeOID([]byte{0x2b, 0x86})
+	assert.ErrorIs(t, err, errTruncated)
+}
+
+func TestBERLongLength(t *testing.T) {
+	content := make([]byte, 300)
+	b := encodeOctetString(content)
+	assert.Equal(t, []byte{0x04, 0x82, 0x01, 0x2c}, b[:4])
+	got, err := (&berReader{data: b}).octetString()
+	require.NoError(t, err)
+	assert.Len(t, got, 300)
+
+	_, err = (&berReader{data: b[:100]}).octetString()
+	assert.ErrorIs(t, err, errTruncated)
+}
+
+func TestCommunityMessage(t *testing.T) {
+	p := &pdu{
+		typ:       pduResponse,
+		requestID: 1234,
+		varbinds: []Varbind{
+			{OID: OID{1, 3, 6, 1, 2, 1, 1, 1, 0}, Type: tagOctetString, Value: []byte("router")},
+			{OID: OID{1, 3, 6, 1, 2, 1, 1, 2, 0}, Type: tagOID, Value: OID{1, 3, 6, 1, 4, 1, 9}},
+			{OID: OID{1, 3, 6, 1, 2, 1, 1, 3, 0}, Type: tagTimeTicks, Value: uint64(4294967295)},
+			{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 5, 1}, Type: tagGauge32, Value: uint64(1000000000)},
+			{OID: OID{1, 3, 6, 1, 2, 1, 31, 1, 1, 1, 6, 1}, Type: tagCounter64, Value: uint64(1 << 63)},
+			{OID: OID{1, 3, 6, 1, 2, 1, 4, 20, 1, 1, 10, 0, 0, 1}, Type: tagIPAddress, Value: net.IPv4(10, 0, 0, 1).To4()},
+			{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 7, 1}, Type: tagInteger, Value: int64(1)},
+			{OID: OID{1, 3, 6, 1, 2, 1, 1, 9, 0}, Type: tagNoSuchObject},
+		},
+	}
+	b, err := encodeCommunityMessage("public", p)
+	require.NoError(t, err)
+
+	version, err := messageVersion(b)
+	require.NoError(t, err)
+	assert.EqualValues(t, versionV2c, version)
+
+	community, got, err := decodeCommunityMessage(b)
+	require.NoError(t, err)
+	assert.Equal(t, "public", community)
+	assert.Equal(t, p, got)
+	assert.True(t, got.varbinds[7].exception())
+}
+
+func TestResponseError(t *testing.T) {
+	assert.EqualError(t, &ResponseError{Status: errorStatusTooBig, Index: 0}, "agent responded with tooBig at index 0")
+	assert.EqualError(t, &ResponseError{Status: 99, Index: 2}, "agent responded with error 99 at index 2")
+}
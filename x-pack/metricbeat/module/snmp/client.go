@@ -0,0 +1,391 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxGetOIDs is the maximum number of OIDs requested in a GetRequest.
+const maxGetOIDs = 60
+
+// usmStatsPrefix is the prefix of the counters in the reports of the
+// user-based security model, RFC 3414 section 5.
+var usmStatsPrefix = OID{1, 3, 6, 1, 6, 3, 15, 1, 1}
+
+var usmStatsNames = map[uint32]string{
+	1: "unsupportedSecLevels",
+	2: "notInTimeWindows",
+	3: "unknownUserNames",
+	4: "unknownEngineIDs",
+	5: "wrongDigests",
+	6: "decryptionErrors",
+}
+
+// Client queries an SNMP agent over UDP. It is safe for concurrent use, the
+// requests are serialized.
+type Client struct {
+	config Config
+	addr   string
+
+	mu        sync.Mutex
+	conn      net.Conn
+	requestID int32
+	salt      uint64
+	buf       []byte
+
+	// State of the authoritative engine of the agent, for SNMPv3.
+	engineID   []byte
+	boots      int32
+	engineTime int32
+	timeRef    time.Time
+	keys       *usmKeys
+}
+
+// NewClient returns a client of the agent at addr, a host and port. The
+// connection is opened with the first request.
+func NewClient(addr string, config Config) *Client {
+	var seed [12]byte
+	_, _ = rand.Read(seed[:])
+	return &Client{
+		config:    config,
+		addr:      addr,
+		requestID: int32(binary.BigEndian.Uint32(seed[:4]) & 0x7fffffff),
+		salt:      binary.BigEndian.Uint64(seed[4:]),
+		buf:       make([]byte, maxMessageSize),
+	}
+}
+
+// Close closes the connection with the agent.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// Get returns the values of the OIDs. The values of the OIDs unknown to the
+// agent are exceptions.
+func (c *Client) Get(ctx context.Context, oids []OID) ([]Varbind, error) {
+	var result []Varbind
+	for len(oids) > 0 {
+		n := len(oids)
+		if n > maxGetOIDs {
+			n = maxGetOIDs
+		}
+		req := &pdu{typ: pduGetRequest, varbinds: make([]Varbind, n)}
+		for i, oid := range oids[:n] {
+			req.varbinds[i] = Varbind{OID: oid, Type: tagNull}
+		}
+		resp, err := c.request(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.varbinds) != n {
+			return nil, fmt.Errorf("agent returned %d values for %d OIDs", len(resp.varbinds), n)
+		}
+		result = append(result, resp.varbinds...)
+		oids = oids[n:]
+	}
+	return result, nil
+}
+
+// BulkWalk calls fn with the values of the OIDs under root, in
+// lexicographical order, using GetBulkRequests.
+func (c *Client) BulkWalk(ctx context.Context, root OID, fn func(Varbind) error) error {
+	next := root
+	maxRepetitions := c.config.MaxRepetitions
+	for {
+		resp, err := c.request(ctx, &pdu{
+			typ:        pduGetBulkRequest,
+			errorIndex: maxRepetitions,
+			varbinds:   []Varbind{{OID: next, Type: tagNull}},
+		})
+		var respErr *ResponseError
+		if errors.As(err, &respErr) && respErr.Status == errorStatusTooBig && maxRepetitions > 1 {
+			maxRepetitions /= 2
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(resp.varbinds) == 0 {
+			return nil
+		}
+		for _, vb := range resp.varbinds {
+			if vb.Type == tagEndOfMIBView || !vb.OID.HasPrefix(root) {
+				return nil
+			}
+			if vb.OID.Compare(next) <= 0 {
+				return fmt.Errorf("agent returned OID %v out of order after %v", vb.OID, next)
+			}
+			if err := fn(vb); err != nil {
+				return err
+			}
+			next = vb.OID
+		}
+	}
+}
+
+// request sends the PDU and returns the response, retrying when it times
+// out.
+func (c *Client) request(ctx context.Context, req *pdu) (*pdu, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "udp", c.addr)
+		if err != nil {
+			return nil, err
+		}
+		c.conn = conn
+	}
+
+	var (
+		resp *pdu
+		err  error
+	)
+	if c.config.Version == "3" {
+		resp, err = c.requestV3(ctx, req)
+	} else {
+		resp, err = c.exchange(ctx, req, c.encodeV2c, c.decodeV2c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.typ != pduResponse {
+		return nil, fmt.Errorf("unexpected PDU type 0x%02x in response", resp.typ)
+	}
+	if resp.errorStatus != 0 {
+		return nil, &ResponseError{Status: resp.errorStatus, Index: resp.errorIndex}
+	}
+	return resp, nil
+}
+
+func (c *Client) encodeV2c(req *pdu) ([]byte, error) {
+	return encodeCommunityMessage(c.config.Community, req)
+}
+
+func (c *Client) decodeV2c(b []byte) (*pdu, error) {
+	community, resp, err := decodeCommunityMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	if community != c.config.Community {
+		return nil, errors.New("community of the response doesn't match")
+	}
+	return resp, nil
+}
+
+// exchange sends the request until a response with its ID is received, or
+// the retries are exhausted.
+func (c *Client) exchange(ctx context.Context, req *pdu, encode func(*pdu) ([]byte, error), decode func([]byte) (*pdu, error)) (*pdu, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.config.Retries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		c.requestID = (c.requestID + 1) & 0x7fffffff
+		req.requestID = c.requestID
+		msg, err := encode(req)
+		if err != nil {
+			return nil, err
+		}
+
+		deadline := time.Now().Add(c.config.RequestTimeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+		if _, err := c.conn.Write(msg); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.receive(req.requestID, decode)
+		if err == nil {
+			return resp, nil
+		}
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("no response from %s after %d attempts: %w", c.addr, c.config.Retries+1, lastErr)
+}
+
+// receive reads messages until the response to the request is received.
+// Invalid messages, and responses to previous requests, are ignored.
+func (c *Client) receive(requestID int32, decode func([]byte) (*pdu, error)) (*pdu, error) {
+	for {
+		n, err := c.conn.Read(c.buf)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := decode(c.buf[:n])
+		if err != nil || resp.requestID != requestID {
+			continue
+		}
+		return resp, nil
+	}
+}
+
+// requestV3 sends the request with SNMPv3, discovering the engine of the
+// agent on the first request, and when its time window is lost.
+func (c *Client) requestV3(ctx context.Context, req *pdu) (*pdu, error) {
+	if c.keys == nil {
+		if err := c.discover(ctx); err != nil {
+			return nil, fmt.Errorf("discovering engine of %s: %w", c.addr, err)
+		}
+	}
+
+	var resp *pdu
+	for attempt := 0; ; attempt++ {
+		var err error
+		resp, err = c.exchange(ctx, req, c.encodeV3(c.keys.flags()), c.decodeV3)
+		if err != nil {
+			return nil, err
+		}
+		if resp.typ != pduReport {
+			return resp, nil
+		}
+		report := reportName(resp)
+		if attempt > 0 || (report != "notInTimeWindows" && report != "unknownEngineIDs") {
+			return nil, fmt.Errorf("agent reported %s", report)
+		}
+		if report == "unknownEngineIDs" {
+			c.keys = nil
+			if err := c.discover(ctx); err != nil {
+				return nil, fmt.Errorf("discovering engine of %s: %w", c.addr, err)
+			}
+		}
+	}
+}
+
+// discover obtains the ID, boots and time of the engine of the agent, and
+// localizes the keys of the user to it.
+func (c *Client) discover(ctx context.Context) error {
+	c.engineID = nil
+	probe := &pdu{typ: pduGetRequest}
+	keys := &usmKeys{}
+	c.keys = keys
+	defer func() {
+		if c.keys == keys {
+			c.keys = nil
+		}
+	}()
+	resp, err := c.exchange(ctx, probe, c.encodeV3(0), c.decodeV3)
+	if err != nil {
+		return err
+	}
+	if resp.typ != pduReport {
+		return fmt.Errorf("unexpected PDU type 0x%02x in discovery response", resp.typ)
+	}
+	if len(c.engineID) == 0 {
+		return errors.New("agent didn't report its engine ID")
+	}
+
+	if c.config.AuthProtocol == "" {
+		c.keys = &usmKeys{}
+		return nil
+	}
+	auth, err := lookupAuthProtocol(c.config.AuthProtocol)
+	if err != nil {
+		return err
+	}
+	c.keys = &usmKeys{
+		auth:    auth,
+		authKey: passwordToKey(auth.hash, c.config.AuthPassword, c.engineID),
+		hasAuth: true,
+	}
+	if c.config.PrivProtocol != "" {
+		c.keys.priv = strings.ToUpper(c.config.PrivProtocol)
+		c.keys.privKey = passwordToKey(auth.hash, c.config.PrivPassword, c.engineID)
+		c.keys.hasPriv = true
+	}
+	return nil
+}
+
+func (c *Client) encodeV3(flags byte) func(*pdu) ([]byte, error) {
+	return func(req *pdu) ([]byte, error) {
+		m := &v3Message{
+			msgID: req.requestID,
+			flags: flags | flagReportable,
+			params: usmParameters{
+				engineID: c.engineID,
+				boots:    c.boots,
+				time:     c.currentEngineTime(),
+			},
+			contextEngineID: c.engineID,
+			contextName:     c.config.ContextName,
+			pdu:             req,
+		}
+		if flags != 0 || len(c.engineID) > 0 {
+			m.params.userName = c.config.Username
+		}
+		c.salt++
+		return c.keys.sealMessage(m, c.salt)
+	}
+}
+
+func (c *Client) decodeV3(b []byte) (*pdu, error) {
+	m, err := c.keys.openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	if m.pdu.typ != pduReport && m.flags&(flagAuth|flagPriv) != c.keys.flags() {
+		return nil, errors.New("security level of the response doesn't match")
+	}
+	if m.pdu.typ == pduReport {
+		// Reports carry the engine state of the agent, used in the
+		// discovery and to resynchronize the time window.
+		// The message shares the buffer of the client.
+		c.engineID = append([]byte(nil), m.params.engineID...)
+		c.boots = m.params.boots
+		c.engineTime = m.params.time
+		c.timeRef = time.Now()
+		// Reports are not correlated by request ID when the agent
+		// couldn't decode the scoped PDU.
+		if m.pdu.requestID == 0 {
+			m.pdu.requestID = m.msgID
+		}
+	}
+	return m.pdu, nil
+}
+
+func (c *Client) currentEngineTime() int32 {
+	if c.timeRef.IsZero() {
+		return c.engineTime
+	}
+	return c.engineTime + int32(time.Since(c.timeRef)/time.Second)
+}
+
+// reportName returns the name of the counter in a report.
+func reportName(p *pdu) string {
+	for _, vb := range p.varbinds {
+		if vb.OID.HasPrefix(usmStatsPrefix) && len(vb.OID) == len(usmStatsPrefix)+2 {
+			if name, ok := usmStatsNames[vb.OID[len(usmStatsPrefix)]]; ok {
+				return name
+			}
+		}
+		return vb.OID.String()
+	}
+	return "an empty report"
+}
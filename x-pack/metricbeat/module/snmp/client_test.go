@@ -0,0 +1,309 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAgent is an SNMP agent answering Get and GetBulk requests with a
+// fixed set of values.
+type fakeAgent struct {
+	conn      *net.UDPConn
+	community string
+	engineID  []byte
+	keys      *usmKeys
+	values    []Varbind
+	requests  atomic.Int64
+}
+
+// testEngineID is the engine ID of the fake agents.
+var testEngineID = []byte{0x80, 0, 0x1f, 0x88, 0x80, 0xca, 0xfe}
+
+// newFakeAgent starts an agent, answering SNMPv3 requests with the keys.
+func newFakeAgent(t *testing.T, values []Varbind, keys *usmKeys) *fakeAgent {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].OID.Compare(values[j].OID) < 0
+	})
+	a := &fakeAgent{
+		conn:      conn,
+		community: "public",
+		engineID:  testEngineID,
+		keys:      keys,
+		values:    values,
+	}
+	t.Cleanup(func() { conn.Close() })
+	go a.serve()
+	return a
+}
+
+func (a *fakeAgent) addr() string {
+	return a.conn.LocalAddr().String()
+}
+
+func (a *fakeAgent) serve() {
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, from, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		a.requests.Add(1)
+		b := buf[:n]
+		version, err := messageVersion(b)
+		if err != nil {
+			continue
+		}
+		var resp []byte
+		if version == versionV2c {
+			resp, err = a.handleV2c(b)
+		} else {
+			resp, err = a.handleV3(b)
+		}
+		if err != nil || resp == nil {
+			continue
+		}
+		_, _ = a.conn.WriteToUDP(resp, from)
+	}
+}
+
+func (a *fakeAgent) handleV2c(b []byte) ([]byte, error) {
+	community, req, err := decodeCommunityMessage(b)
+	if err != nil || community != a.community {
+		return nil, err
+	}
+	return encodeCommunityMessage(community, a.respond(req))
+}
+
+func (a *fakeAgent) handleV3(b []byte) ([]byte, error) {
+	m, _, err := decodeV3Message(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.params.engineID) == 0 {
+		// Discovery.
+		report := &v3Message{
+			msgID:  m.msgID,
+			params: usmParameters{engineID: a.engineID, boots: 2, time: 3600},
+			pdu: &pdu{
+				typ:       pduReport,
+				requestID: m.pdu.requestID,
+				varbinds:  []Varbind{{OID: usmStatsPrefix.Append(4, 0), Type: tagCounter32, Value: uint64(1)}},
+			},
+			contextEngineID: a.engineID,
+		}
+		return report.encode()
+	}
+	m, err = a.keys.openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v3Message{
+		msgID:           m.msgID,
+		flags:           m.flags &^ flagReportable,
+		params:          usmParameters{engineID: a.engineID, boots: 2, time: 3600, userName: m.params.userName},
+		contextEngineID: a.engineID,
+		contextName:     m.contextName,
+		pdu:             a.respond(m.pdu),
+	}
+	return a.keys.sealMessage(resp, 1)
+}
+
+func (a *fakeAgent) respond(req *pdu) *pdu {
+	resp := &pdu{typ: pduResponse, requestID: req.requestID}
+	switch req.typ {
+	case pduGetRequest:
+		for _, vb := range req.varbinds {
+			value := Varbind{OID: vb.OID, Type: tagNoSuchObject}
+			for _, v := range a.values {
+				if v.OID.Compare(vb.OID) == 0 {
+					value = v
+				}
+			}
+			resp.varbinds = append(resp.varbinds, value)
+		}
+	case pduGetBulkRequest:
+		next := req.varbinds[0].OID
+		for i := 0; i < req.errorIndex; i++ {
+			j := sort.Search(len(a.values), func(j int) bool {
+				return a.values[j].OID.Compare(next) > 0
+			})
+			if j == len(a.values) {
+				resp.varbinds = append(resp.varbinds, Varbind{OID: next, Type: tagEndOfMIBView})
+				break
+			}
+			resp.varbinds = append(resp.varbinds, a.values[j])
+			next = a.values[j].OID
+		}
+	}
+	return resp
+}
+
+var testValues = []Varbind{
+	{OID: OID{1, 3, 6, 1, 2, 1, 1, 1, 0}, Type: tagOctetString, Value: []byte("test router")},
+	{OID: OID{1, 3, 6, 1, 2, 1, 1, 3, 0}, Type: tagTimeTicks, Value: uint64(123456)},
+	{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2, 1}, Type: tagOctetString, Value: []byte("lo")},
+	{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2, 2}, Type: tagOctetString, Value: []byte("eth0")},
+	{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2, 3}, Type: tagOctetString, Value: []byte("eth1")},
+	{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2, 10}, Type: tagOctetString, Value: []byte("eth2")},
+	{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2, 11}, Type: tagOctetString, Value: []byte("eth3")},
+	{OID: OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 3, 1}, Type: tagInteger, Value: int64(24)},
+}
+
+func testConfig() Config {
+	config := defaultConfig()
+	config.RequestTimeout = 100 * time.Millisecond
+	config.Retries = 1
+	config.MaxRepetitions = 2
+	return config
+}
+
+func TestClientGet(t *testing.T) {
+	agent := newFakeAgent(t, testValues, nil)
+	client := NewClient(agent.addr(), testConfig())
+	defer client.Close()
+
+	varbinds, err := client.Get(context.Background(), []OID{
+		{1, 3, 6, 1, 2, 1, 1, 1, 0},
+		{1, 3, 6, 1, 2, 1, 1, 2, 0},
+		{1, 3, 6, 1, 2, 1, 1, 3, 0},
+	})
+	require.NoError(t, err)
+	require.Len(t, varbinds, 3)
+	assert.Equal(t, []byte("test router"), varbinds[0].Value)
+	assert.True(t, varbinds[1].exception())
+	assert.Equal(t, uint64(123456), varbinds[2].Value)
+}
+
+func TestClientBulkWalk(t *testing.T) {
+	agent := newFakeAgent(t, testValues, nil)
+	client := NewClient(agent.addr(), testConfig())
+	defer client.Close()
+
+	var names []string
+	err := client.BulkWalk(context.Background(), OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2}, func(vb Varbind) error {
+		names = append(names, string(vb.Value.([]byte)))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lo", "eth0", "eth1", "eth2", "eth3"}, names)
+	// 2 values per request, the last one returns the next column.
+	assert.EqualValues(t, 3, agent.requests.Load())
+
+	var count int
+	err = client.BulkWalk(context.Background(), OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 3}, func(vb Varbind) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "walk must stop at the end of the MIB view")
+}
+
+func TestClientTimeout(t *testing.T) {
+	agent := newFakeAgent(t, testValues, nil)
+	config := testConfig()
+	config.Community = "private"
+	client := NewClient(agent.addr(), config)
+	defer client.Close()
+
+	_, err := client.Get(context.Background(), []OID{{1, 3, 6, 1, 2, 1, 1, 1, 0}})
+	assert.ErrorContains(t, err, "after 2 attempts")
+	assert.EqualValues(t, 2, agent.requests.Load())
+}
+
+func TestClientV3(t *testing.T) {
+	cases := map[string]struct {
+		auth, priv string
+	}{
+		"noAuthNoPriv": {},
+		"authNoPriv":   {auth: "SHA256"},
+		"authPriv DES": {auth: "MD5", priv: privDES},
+		"authPriv AES": {auth: "SHA", priv: privAES},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			agent := newFakeAgent(t, testValues, testKeys(t, c.auth, c.priv, "authpassword", "privpassword", testEngineID))
+
+			config := testConfig()
+			config.Version = "3"
+			config.Username = "user"
+			config.AuthProtocol = c.auth
+			config.AuthPassword = "authpassword"
+			config.PrivProtocol = c.priv
+			config.PrivPassword = "privpassword"
+			require.NoError(t, config.Validate())
+			client := NewClient(agent.addr(), config)
+			defer client.Close()
+
+			varbinds, err := client.Get(context.Background(), []OID{{1, 3, 6, 1, 2, 1, 1, 1, 0}})
+			require.NoError(t, err)
+			require.Len(t, varbinds, 1)
+			assert.Equal(t, []byte("test router"), varbinds[0].Value)
+			assert.Equal(t, agent.engineID, client.engineID)
+
+			var count int
+			err = client.BulkWalk(context.Background(), OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2}, func(vb Varbind) error {
+				count++
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 5, count)
+		})
+	}
+}
+
+func TestClientV3WrongPassword(t *testing.T) {
+	agent := newFakeAgent(t, testValues, testKeys(t, "SHA", privAES, "authpassword", "privpassword", testEngineID))
+
+	config := testConfig()
+	config.Version = "3"
+	config.Username = "user"
+	config.AuthProtocol = "SHA"
+	config.AuthPassword = "wrongpassword"
+	client := NewClient(agent.addr(), config)
+	defer client.Close()
+
+	_, err := client.Get(context.Background(), []OID{{1, 3, 6, 1, 2, 1, 1, 1, 0}})
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := defaultConfig()
+	assert.NoError(t, config.Validate())
+
+	config.Version = "1"
+	assert.ErrorContains(t, config.Validate(), "not supported")
+
+	config.Version = "3"
+	assert.ErrorContains(t, config.Validate(), "username is required")
+
+	config.Username = "user"
+	assert.NoError(t, config.Validate())
+
+	config.AuthProtocol = "SHA1"
+	assert.ErrorContains(t, config.Validate(), "unsupported authentication protocol")
+
+	config.AuthProtocol = "sha256"
+	config.AuthPassword = "short"
+	assert.ErrorContains(t, config.Validate(), "at least 8 characters")
+
+	config.AuthPassword = "authpassword"
+	config.PrivProtocol = "3DES"
+	assert.ErrorContains(t, config.Validate(), "unsupported privacy protocol")
+
+	config.PrivProtocol = "aes"
+	config.PrivPassword = "privpassword"
+	assert.NoError(t, config.Validate())
+}
@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config is the configuration of the module, shared by its metricsets.
+type Config struct {
+	// Version is the SNMP version used with the agents, 2c or 3.
+	Version   string `config:"version"`
+	Community string `config:"community"`
+
+	// Settings of the user-based security model of SNMPv3.
+	Username     string `config:"username"`
+	AuthProtocol string `config:"auth_protocol"`
+	AuthPassword string `config:"auth_password"`
+	PrivProtocol string `config:"priv_protocol"`
+	PrivPassword string `config:"priv_password"`
+	ContextName  string `config:"context_name"`
+
+	// RequestTimeout is the time to wait for a response before retrying.
+	RequestTimeout time.Duration `config:"request_timeout" validate:"positive,nonzero"`
+	Retries        int           `config:"retries" validate:"min=0"`
+	// MaxRepetitions is the number of values requested in each request of
+	// the walks.
+	MaxRepetitions int `config:"max_repetitions" validate:"min=1"`
+	// MaxConcurrency limits the number of targets queried at the same
+	// time by the module, no limit is applied when it is 0.
+	MaxConcurrency int `config:"max_concurrency" validate:"min=0"`
+
+	// MIBPaths are the files or directories of MIB modules loaded in
+	// addition to the builtin ones.
+	MIBPaths []string `config:"mib_paths"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Version:        "2c",
+		Community:      "public",
+		RequestTimeout: 2 * time.Second,
+		Retries:        2,
+		MaxRepetitions: 10,
+		MaxConcurrency: 10,
+	}
+}
+
+// Validate checks the configuration.
+func (c *Config) Validate() error {
+	switch c.Version {
+	case "2c":
+		if c.Community == "" {
+			return errors.New("community is required with version 2c")
+		}
+	case "3":
+		return c.validateV3()
+	case "1":
+		return errors.New("SNMP version 1 is not supported, use 2c or 3")
+	default:
+		return fmt.Errorf("unsupported SNMP version %q", c.Version)
+	}
+	return nil
+}
+
+func (c *Config) validateV3() error {
+	if c.Username == "" {
+		return errors.New("username is required with version 3")
+	}
+	if c.AuthProtocol == "" {
+		if c.PrivProtocol != "" {
+			return errors.New("priv_protocol requires auth_protocol")
+		}
+		return nil
+	}
+	if _, err := lookupAuthProtocol(c.AuthProtocol); err != nil {
+		return err
+	}
+	if len(c.AuthPassword) < 8 {
+		return errors.New("auth_password must have at least 8 characters")
+	}
+	if c.PrivProtocol == "" {
+		return nil
+	}
+	switch strings.ToUpper(c.PrivProtocol) {
+	case privDES, privAES:
+	default:
+		return fmt.Errorf("unsupported privacy protocol %q", c.PrivProtocol)
+	}
+	if len(c.PrivPassword) < 8 {
+		return errors.New("priv_password must have at least 8 characters")
+	}
+	return nil
+}
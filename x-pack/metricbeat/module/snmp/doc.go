@@ -0,0 +1,7 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package snmp is a Metricbeat module that queries network devices with
+// SNMP, v2c and v3, naming and decoding their values with MIB modules.
+package snmp
@@ -0,0 +1,122 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package get
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/snmp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("snmp", "get", New,
+		mb.WithHostParser(snmp.ParseHost),
+		mb.DefaultMetricSet(),
+	)
+}
+
+type config struct {
+	OIDs []oidConfig `config:"oids" validate:"required"`
+}
+
+// oidConfig is an OID to get, numeric or a MIB object name, and the name of
+// its field.
+type oidConfig struct {
+	OID  string `config:"oid" validate:"required"`
+	Name string `config:"name"`
+}
+
+// MetricSet gets the values of a list of OIDs from an agent, and reports
+// them in an event.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod    snmp.Module
+	client *snmp.Client
+
+	oids    []snmp.OID
+	objects []*snmp.Object
+	names   []string
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The snmp get metricset is beta.")
+
+	mod, ok := base.Module().(snmp.Module)
+	if !ok {
+		return nil, errors.New("must be child of snmp module")
+	}
+
+	var config config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		mod:           mod,
+		client:        mod.NewClient(base.Host()),
+	}
+	for _, c := range config.OIDs {
+		oid, object, err := mod.MIB().Resolve(c.OID)
+		if err != nil {
+			return nil, err
+		}
+		name := c.Name
+		if name == "" {
+			if object == nil {
+				return nil, fmt.Errorf("name is required for OID %s, not found in the MIB", c.OID)
+			}
+			name = fieldName(object, oid)
+		}
+		m.oids = append(m.oids, oid)
+		m.objects = append(m.objects, object)
+		m.names = append(m.names, name)
+	}
+	return m, nil
+}
+
+// fieldName returns the name of the object, followed by the sub-identifiers
+// of the instance, if it isn't a scalar.
+func fieldName(object *snmp.Object, oid snmp.OID) string {
+	instance := oid[len(object.OID):]
+	if len(instance) == 0 || (len(instance) == 1 && instance[0] == 0) {
+		return object.Name
+	}
+	return object.Name + "_" + strings.ReplaceAll(instance.String(), ".", "_")
+}
+
+// Fetch gets the values of the OIDs, the OIDs the agent doesn't have are
+// omitted.
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	if err := m.mod.Acquire(ctx); err != nil {
+		return err
+	}
+	defer m.mod.Release()
+
+	varbinds, err := m.client.Get(ctx, m.oids)
+	if err != nil {
+		return fmt.Errorf("getting OIDs from %s: %w", m.Host(), err)
+	}
+	fields := mapstr.M{}
+	for i, vb := range varbinds {
+		if v := m.objects[i].Value(vb); v != nil {
+			_, _ = fields.Put(m.names[i], v)
+		}
+	}
+	r.Event(mb.Event{MetricSetFields: fields})
+	return nil
+}
+
+// Close closes the connection with the agent.
+func (m *MetricSet) Close() error {
+	return m.client.Close()
+}
@@ -0,0 +1,269 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// PDU types.
+const (
+	pduGetRequest     byte = 0xa0
+	pduGetNextRequest byte = 0xa1
+	pduResponse       byte = 0xa2
+	pduGetBulkRequest byte = 0xa5
+	pduReport         byte = 0xa8
+)
+
+// SNMP versions, as encoded in the messages.
+const (
+	versionV2c = 1
+	versionV3  = 3
+)
+
+// errorStatusNames are the names of the error statuses of the responses.
+var errorStatusNames = []string{
+	"noError", "tooBig", "noSuchName", "badValue", "readOnly", "genErr",
+	"noAccess", "wrongType", "wrongLength", "wrongEncoding", "wrongValue",
+	"noCreation", "inconsistentValue", "resourceUnavailable", "commitFailed",
+	"undoFailed", "authorizationError", "notWritable", "inconsistentName",
+}
+
+const errorStatusTooBig = 1
+
+// Varbind is a variable binding, an OID and its value. The value is an int64
+// for Integer, a []byte for OctetString and Opaque, an OID for object
+// identifiers, a net.IP for IpAddress, an uint64 for the counters, gauges and
+// time ticks, and nil for Null and the exceptions.
+type Varbind struct {
+	OID   OID
+	Type  byte
+	Value interface{}
+}
+
+// exception returns whether the varbind holds noSuchObject, noSuchInstance
+// or endOfMibView instead of a value.
+func (vb Varbind) exception() bool {
+	return vb.Type == tagNoSuchObject || vb.Type == tagNoSuchInstance || vb.Type == tagEndOfMIBView
+}
+
+// pdu is a protocol data unit. For GetBulkRequest, errorStatus and errorIndex
+// hold the non-repeaters and max-repetitions.
+type pdu struct {
+	typ         byte
+	requestID   int32
+	errorStatus int
+	errorIndex  int
+	varbinds    []Varbind
+}
+
+// ResponseError is the error status of a response.
+type ResponseError struct {
+	Status int
+	Index  int
+}
+
+func (e *ResponseError) Error() string {
+	name := fmt.Sprintf("error %d", e.Status)
+	if e.Status < len(errorStatusNames) {
+		name = errorStatusNames[e.Status]
+	}
+	return fmt.Sprintf("agent responded with %s at index %d", name, e.Index)
+}
+
+func (p *pdu) encode() ([]byte, error) {
+	var varbinds []byte
+	for _, vb := range p.varbinds {
+		oid, err := encodeOID(vb.OID)
+		if err != nil {
+			return nil, err
+		}
+		value, err := encodeValue(vb)
+		if err != nil {
+			return nil, fmt.Errorf("encoding value of %v: %w", vb.OID, err)
+		}
+		varbinds = append(varbinds, sequence(tagSequence, oid, value)...)
+	}
+	return sequence(p.typ,
+		encodeInteger(int64(p.requestID)),
+		encodeInteger(int64(p.errorStatus)),
+		encodeInteger(int64(p.errorIndex)),
+		appendTLV(nil, tagSequence, varbinds),
+	), nil
+}
+
+func encodeValue(vb Varbind) ([]byte, error) {
+	switch vb.Type {
+	case tagNull, tagNoSuchObject, tagNoSuchInstance, tagEndOfMIBView:
+		return []byte{vb.Type, 0}, nil
+	case tagInteger:
+		v, ok := vb.Value.(int64)
+		if !ok {
+			return nil, fmt.Errorf("integer value of type %T", vb.Value)
+		}
+		return encodeInteger(v), nil
+	case tagOctetString, tagOpaque:
+		v, ok := vb.Value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("octet string value of type %T", vb.Value)
+		}
+		return appendTLV(nil, vb.Type, v), nil
+	case tagOID:
+		v, ok := vb.Value.(OID)
+		if !ok {
+			return nil, fmt.Errorf("OID value of type %T", vb.Value)
+		}
+		return encodeOID(v)
+	case tagIPAddress:
+		v, ok := vb.Value.(net.IP)
+		if !ok || v.To4() == nil {
+			return nil, fmt.Errorf("invalid IP address %v", vb.Value)
+		}
+		return appendTLV(nil, tagIPAddress, v.To4()), nil
+	case tagCounter32, tagGauge32, tagTimeTicks, tagCounter64:
+		v, ok := vb.Value.(uint64)
+		if !ok {
+			return nil, fmt.Errorf("unsigned value of type %T", vb.Value)
+		}
+		var content []byte
+		for {
+			content = append([]byte{byte(v)}, content...)
+			v >>= 8
+			if v == 0 {
+				break
+			}
+		}
+		if content[0]&0x80 != 0 {
+			content = append([]byte{0}, content...)
+		}
+		return appendTLV(nil, vb.Type, content), nil
+	}
+	return nil, fmt.Errorf("unsupported type 0x%02x", vb.Type)
+}
+
+func decodePDU(r *berReader) (*pdu, error) {
+	typ, content, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	if typ&0xe0 != 0xa0 {
+		return nil, fmt.Errorf("unexpected PDU type 0x%02x", typ)
+	}
+	body := &berReader{data: content}
+	p := &pdu{typ: typ}
+	requestID, err := body.integer()
+	if err != nil {
+		return nil, fmt.Errorf("reading request ID: %w", err)
+	}
+	p.requestID = int32(requestID)
+	status, err := body.integer()
+	if err != nil {
+		return nil, fmt.Errorf("reading error status: %w", err)
+	}
+	p.errorStatus = int(status)
+	index, err := body.integer()
+	if err != nil {
+		return nil, fmt.Errorf("reading error index: %w", err)
+	}
+	p.errorIndex = int(index)
+
+	list, err := body.sequence(tagSequence)
+	if err != nil {
+		return nil, fmt.Errorf("reading varbinds: %w", err)
+	}
+	for !list.empty() {
+		item, err := list.sequence(tagSequence)
+		if err != nil {
+			return nil, fmt.Errorf("reading varbind: %w", err)
+		}
+		vb, err := decodeVarbind(item)
+		if err != nil {
+			return nil, err
+		}
+		p.varbinds = append(p.varbinds, vb)
+	}
+	return p, nil
+}
+
+func decodeVarbind(r *berReader) (Varbind, error) {
+	oid, err := r.oid()
+	if err != nil {
+		return Varbind{}, fmt.Errorf("reading varbind OID: %w", err)
+	}
+	tag, content, err := r.read()
+	if err != nil {
+		return Varbind{}, fmt.Errorf("reading value of %v: %w", oid, err)
+	}
+	vb := Varbind{OID: oid, Type: tag}
+	switch tag {
+	case tagNull, tagNoSuchObject, tagNoSuchInstance, tagEndOfMIBView:
+	case tagInteger:
+		vb.Value, err = decodeInteger(content)
+	case tagOctetString, tagOpaque:
+		vb.Value = append([]byte(nil), content...)
+	case tagOID:
+		vb.Value, err = decodeOID(content)
+	case tagIPAddress:
+		if len(content) != 4 {
+			err = errors.New("invalid IpAddress length")
+		}
+		vb.Value = net.IP(append([]byte(nil), content...))
+	case tagCounter32, tagGauge32, tagTimeTicks, tagCounter64:
+		vb.Value, err = decodeUnsigned(content)
+	default:
+		err = fmt.Errorf("unsupported type 0x%02x", tag)
+	}
+	if err != nil {
+		return Varbind{}, fmt.Errorf("reading value of %v: %w", oid, err)
+	}
+	return vb, nil
+}
+
+// encodeCommunityMessage encodes an SNMPv2c message.
+func encodeCommunityMessage(community string, p *pdu) ([]byte, error) {
+	data, err := p.encode()
+	if err != nil {
+		return nil, err
+	}
+	return sequence(tagSequence,
+		encodeInteger(versionV2c),
+		encodeOctetString([]byte(community)),
+		data,
+	), nil
+}
+
+// decodeCommunityMessage decodes an SNMPv2c message.
+func decodeCommunityMessage(b []byte) (community string, p *pdu, err error) {
+	r := &berReader{data: b}
+	msg, err := r.sequence(tagSequence)
+	if err != nil {
+		return "", nil, err
+	}
+	version, err := msg.integer()
+	if err != nil {
+		return "", nil, err
+	}
+	if version != versionV2c {
+		return "", nil, fmt.Errorf("unexpected message version %d", version)
+	}
+	c, err := msg.octetString()
+	if err != nil {
+		return "", nil, err
+	}
+	p, err = decodePDU(msg)
+	return string(c), p, err
+}
+
+// messageVersion returns the version of an encoded message.
+func messageVersion(b []byte) (int64, error) {
+	r := &berReader{data: b}
+	msg, err := r.sequence(tagSequence)
+	if err != nil {
+		return 0, err
+	}
+	return msg.integer()
+}
@@ -0,0 +1,327 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// builtinMIBs are the MIB modules always loaded, with the system and
+// interfaces groups.
+//
+//go:embed mibs
+var builtinMIBs embed.FS
+
+// baseTypes are the types of the SMI the syntaxes are resolved to.
+var baseTypes = map[string]bool{
+	"INTEGER": true, "Integer32": true, "Unsigned32": true, "Gauge32": true,
+	"Counter32": true, "Counter64": true, "TimeTicks": true, "IpAddress": true,
+	"Opaque": true, "OCTET STRING": true, "OBJECT IDENTIFIER": true, "BITS": true,
+	"Counter": true, "Gauge": true, "NetworkAddress": true,
+}
+
+// roots are the top level arcs of the OID tree.
+var roots = map[string]uint32{"ccitt": 0, "iso": 1, "joint-iso-ccitt": 2}
+
+// Object is an object defined in a MIB module.
+type Object struct {
+	Module string
+	Name   string
+	OID    OID
+
+	// Syntax of the object, resolved to a base type of the SMI, with the
+	// display hint and named numbers of its textual convention.
+	base  string
+	hint  string
+	enums map[int64]string
+}
+
+// MIB is the set of the compiled MIB modules, mapping object names to OIDs
+// and values to their fields.
+type MIB struct {
+	byName   map[string]*Object
+	byOID    map[string]*Object
+	children map[string][]*Object
+}
+
+// LoadMIB compiles the builtin MIB modules and the ones in the paths, files
+// or directories. The modules in the paths replace the builtin modules with
+// the same name.
+func LoadMIB(paths []string) (*MIB, error) {
+	types := map[string]typeDef{}
+	modules := map[string][]oidAssignment{}
+	var order []string
+	add := func(name, src string) error {
+		assignments, err := parseSMI(src, types)
+		if err != nil {
+			return fmt.Errorf("parsing MIB file %s: %w", name, err)
+		}
+		seen := map[string]bool{}
+		for _, a := range assignments {
+			if !seen[a.module] {
+				seen[a.module] = true
+				if _, ok := modules[a.module]; !ok {
+					order = append(order, a.module)
+				}
+				modules[a.module] = nil
+			}
+			modules[a.module] = append(modules[a.module], a)
+		}
+		return nil
+	}
+
+	err := fs.WalkDir(builtinMIBs, "mibs", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		src, err := builtinMIBs.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return add(path, string(src))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		files, err := mibFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("reading MIB file: %w", err)
+			}
+			if err := add(file, string(src)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var assignments []oidAssignment
+	for _, module := range order {
+		assignments = append(assignments, modules[module]...)
+	}
+	return compileMIB(assignments, types), nil
+}
+
+// mibFiles returns the path if it is a file, or the files in it if it is a
+// directory.
+func mibFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading MIB path: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading MIB path: %w", err)
+	}
+	var files []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") {
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+// compileMIB resolves the OIDs and syntaxes of the assignments. Objects
+// whose OID can't be resolved, because their parents are not loaded, are
+// ignored.
+func compileMIB(assignments []oidAssignment, types map[string]typeDef) *MIB {
+	r := &oidResolver{
+		byModule:  map[string]*oidAssignment{},
+		byName:    map[string]*oidAssignment{},
+		resolved:  map[*oidAssignment]OID{},
+		resolving: map[*oidAssignment]bool{},
+	}
+	for i := range assignments {
+		a := &assignments[i]
+		r.byModule[a.module+"::"+a.name] = a
+		if _, ok := r.byName[a.name]; !ok {
+			r.byName[a.name] = a
+		}
+	}
+
+	m := &MIB{
+		byName:   map[string]*Object{},
+		byOID:    map[string]*Object{},
+		children: map[string][]*Object{},
+	}
+	for i := range assignments {
+		a := &assignments[i]
+		oid, ok := r.resolve(a)
+		if !ok {
+			continue
+		}
+		o := &Object{Module: a.module, Name: a.name, OID: oid}
+		if a.syntax != nil {
+			o.base, o.hint, o.enums = resolveSyntax(*a.syntax, types)
+		}
+		m.byName[a.module+"::"+a.name] = o
+		if _, ok := m.byName[a.name]; !ok {
+			m.byName[a.name] = o
+		}
+		key := oid.String()
+		if _, ok := m.byOID[key]; !ok || a.syntax != nil {
+			m.byOID[key] = o
+		}
+	}
+	for _, o := range m.byOID {
+		if len(o.OID) > 1 {
+			parent := o.OID[:len(o.OID)-1].String()
+			m.children[parent] = append(m.children[parent], o)
+		}
+	}
+	for _, children := range m.children {
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].OID.Compare(children[j].OID) < 0
+		})
+	}
+	return m
+}
+
+// oidResolver resolves the OID values of the assignments.
+type oidResolver struct {
+	byModule  map[string]*oidAssignment
+	byName    map[string]*oidAssignment
+	resolved  map[*oidAssignment]OID
+	resolving map[*oidAssignment]bool
+}
+
+func (r *oidResolver) resolve(a *oidAssignment) (OID, bool) {
+	if oid, ok := r.resolved[a]; ok {
+		return oid, oid != nil
+	}
+	if r.resolving[a] {
+		return nil, false
+	}
+	r.resolving[a] = true
+	defer delete(r.resolving, a)
+
+	var oid OID
+	first := a.components[0]
+	switch {
+	case first.number >= 0:
+		oid = OID{uint32(first.number)}
+	case first.name != "":
+		if n, ok := roots[first.name]; ok {
+			oid = OID{n}
+			break
+		}
+		parent, ok := r.byModule[a.module+"::"+first.name]
+		if !ok {
+			parent, ok = r.byName[first.name]
+		}
+		if !ok {
+			r.resolved[a] = nil
+			return nil, false
+		}
+		if oid, ok = r.resolve(parent); !ok {
+			r.resolved[a] = nil
+			return nil, false
+		}
+	}
+	for _, c := range a.components[1:] {
+		if c.number < 0 {
+			r.resolved[a] = nil
+			return nil, false
+		}
+		oid = oid.Append(uint32(c.number))
+	}
+	r.resolved[a] = oid
+	return oid, true
+}
+
+// resolveSyntax resolves the type of a syntax to a base type, and returns
+// it with the display hint and named numbers of the first textual
+// convention defining them.
+func resolveSyntax(s syntax, types map[string]typeDef) (base, hint string, enums map[int64]string) {
+	for depth := 0; depth < 16; depth++ {
+		if enums == nil {
+			enums = s.enums
+		}
+		if baseTypes[s.typ] {
+			break
+		}
+		def, ok := types[s.typ]
+		if !ok {
+			break
+		}
+		if hint == "" {
+			hint = def.hint
+		}
+		s = def.syntax
+	}
+	return s.typ, hint, enums
+}
+
+// Resolve returns the OID of a name, and the object it belongs to, if
+// known. Names are numeric OIDs, or object names, optionally qualified with
+// their module as in MODULE::name, and followed by the sub-identifiers of
+// an instance as in sysDescr.0.
+func (m *MIB) Resolve(name string) (OID, *Object, error) {
+	if name == "" {
+		return nil, nil, errors.New("empty OID")
+	}
+	if c := name[0]; c == '.' || (c >= '0' && c <= '9') {
+		oid, err := ParseOID(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		o, _ := m.Lookup(oid)
+		return oid, o, nil
+	}
+
+	objectName, suffix, _ := strings.Cut(name, ".")
+	o, ok := m.byName[objectName]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown MIB object %q", objectName)
+	}
+	oid := o.OID
+	if suffix != "" {
+		for _, s := range strings.Split(suffix, ".") {
+			n, err := strconv.ParseUint(s, 10, 32)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid OID %q", name)
+			}
+			oid = oid.Append(uint32(n))
+		}
+	}
+	return oid, o, nil
+}
+
+// Lookup returns the object an OID belongs to, with the sub-identifiers
+// that follow the OID of the object, or nil if it is not in the MIB.
+func (m *MIB) Lookup(oid OID) (*Object, OID) {
+	for n := len(oid); n > 0; n-- {
+		if o, ok := m.byOID[oid[:n].String()]; ok {
+			return o, oid[n:]
+		}
+	}
+	return nil, oid
+}
+
+// Columns returns the columns of a table, the children of its entry. It
+// returns nil if the object is not a table.
+func (m *MIB) Columns(table *Object) []*Object {
+	if !strings.HasPrefix(table.base, "SEQUENCE OF ") {
+		return nil
+	}
+	return m.children[table.OID.Append(1).String()]
+}
@@ -0,0 +1,144 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinMIB(t *testing.T) {
+	mib, err := LoadMIB(nil)
+	require.NoError(t, err)
+
+	oid, object, err := mib.Resolve("sysDescr.0")
+	require.NoError(t, err)
+	assert.Equal(t, OID{1, 3, 6, 1, 2, 1, 1, 1, 0}, oid)
+	assert.Equal(t, "SNMPv2-MIB", object.Module)
+	assert.Equal(t, "sysDescr", object.Name)
+
+	oid, object, err = mib.Resolve("IF-MIB::ifHCInOctets")
+	require.NoError(t, err)
+	assert.Equal(t, OID{1, 3, 6, 1, 2, 1, 31, 1, 1, 1, 6}, oid)
+	assert.Equal(t, "Counter64", object.base)
+
+	oid, object, err = mib.Resolve(".1.3.6.1.2.1.2.2.1.8.12")
+	require.NoError(t, err)
+	assert.Equal(t, OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 8, 12}, oid)
+	assert.Equal(t, "ifOperStatus", object.Name)
+
+	_, _, err = mib.Resolve("ifUnknown")
+	assert.ErrorContains(t, err, "unknown MIB object")
+
+	_, table, err := mib.Resolve("ifTable")
+	require.NoError(t, err)
+	columns := mib.Columns(table)
+	require.Len(t, columns, 22)
+	assert.Equal(t, "ifIndex", columns[0].Name)
+	assert.Equal(t, "ifSpecific", columns[21].Name)
+
+	_, table, err = mib.Resolve("ifXTable")
+	require.NoError(t, err)
+	assert.Len(t, mib.Columns(table), 19)
+
+	_, scalar, err := mib.Resolve("sysName")
+	require.NoError(t, err)
+	assert.Nil(t, mib.Columns(scalar))
+
+	object, instance := mib.Lookup(OID{1, 3, 6, 1, 4, 1, 9})
+	assert.Equal(t, "enterprises", object.Name)
+	assert.Equal(t, OID{9}, instance)
+}
+
+func TestLoadMIB(t *testing.T) {
+	mib, err := LoadMIB([]string{"testdata"})
+	require.NoError(t, err)
+
+	oid, table, err := mib.Resolve("EXAMPLE-MIB::sensorTable")
+	require.NoError(t, err)
+	assert.Equal(t, OID{1, 3, 6, 1, 4, 1, 99999, 1, 1}, oid)
+
+	var names []string
+	for _, c := range mib.Columns(table) {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"sensorIndex", "sensorName", "sensorValue", "sensorState", "sensorFlags"}, names)
+
+	_, state, err := mib.Resolve("sensorState")
+	require.NoError(t, err)
+	assert.Equal(t, "critical", state.Value(Varbind{Type: tagInteger, Value: int64(3)}))
+	assert.Equal(t, int64(7), state.Value(Varbind{Type: tagInteger, Value: int64(7)}))
+
+	_, flags, err := mib.Resolve("sensorFlags")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"faulty", "simulated", "15"}, flags.Value(Varbind{Type: tagOctetString, Value: []byte{0x40, 0x41}}))
+
+	_, err = LoadMIB([]string{"testdata/missing.txt"})
+	assert.Error(t, err)
+}
+
+func TestParseSMIErrors(t *testing.T) {
+	_, err := parseSMI("BAD-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT IDENTIFIER ::= { bar 1 }\n", map[string]typeDef{})
+	assert.ErrorContains(t, err, "missing END")
+
+	_, err = parseSMI("BAD-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT IDENTIFIER ::= { }\nEND\n", map[string]typeDef{})
+	assert.ErrorContains(t, err, "empty OID value")
+}
+
+func TestValue(t *testing.T) {
+	mib, err := LoadMIB(nil)
+	require.NoError(t, err)
+	object := func(name string) *Object {
+		_, o, err := mib.Resolve(name)
+		require.NoError(t, err)
+		return o
+	}
+
+	cases := []struct {
+		object   *Object
+		varbind  Varbind
+		expected interface{}
+	}{
+		{object("ifAdminStatus"), Varbind{Type: tagInteger, Value: int64(2)}, "down"},
+		{object("ifType"), Varbind{Type: tagInteger, Value: int64(6)}, "ethernetCsmacd"},
+		{object("ifPromiscuousMode"), Varbind{Type: tagInteger, Value: int64(1)}, "true"},
+		{object("ifPhysAddress"), Varbind{Type: tagOctetString, Value: []byte{0, 0x1b, 0x21, 0xaa, 0xbb, 0x0c}}, "00:1b:21:aa:bb:0c"},
+		{object("ifDescr"), Varbind{Type: tagOctetString, Value: []byte("GigabitEthernet0/1")}, "GigabitEthernet0/1"},
+		{object("ifHCInOctets"), Varbind{Type: tagCounter64, Value: uint64(1 << 40)}, uint64(1 << 40)},
+		{object("sysObjectID"), Varbind{Type: tagOID, Value: OID{1, 3, 6, 1, 4, 1, 9, 1, 1}}, "1.3.6.1.4.1.9.1.1"},
+		{nil, Varbind{Type: tagOctetString, Value: []byte("text\x00")}, "text"},
+		{nil, Varbind{Type: tagOctetString, Value: []byte{0xde, 0xad, 0x00, 0x01}}, "de:ad:00:01"},
+		{nil, Varbind{Type: tagIPAddress, Value: net.IP{192, 168, 1, 1}}, "192.168.1.1"},
+		{nil, Varbind{Type: tagNoSuchInstance}, nil},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.object.Value(c.varbind))
+	}
+}
+
+func TestDisplayHint(t *testing.T) {
+	cases := []struct {
+		hint     string
+		value    []byte
+		expected string
+	}{
+		{"255a", []byte("router"), "router"},
+		{"1x:", []byte{0xaa, 0xbb, 0xcc}, "aa:bb:cc"},
+		{"1d.1d.1d.1d", []byte{10, 0, 0, 1}, "10.0.0.1"},
+		{"2d-1d-1d,1d:1d:1d.1d,1a1d:1d", []byte{0x07, 0xe8, 1, 2, 3, 4, 5, 6, '+', 2, 0}, "2024-1-2,3:4:5.6,+2:0"},
+		{"*1x:/1x", []byte{2, 0xaa, 0xbb, 0xcc}, "aa:bb/cc"},
+	}
+	for _, c := range cases {
+		s, ok := applyDisplayHint(c.hint, c.value)
+		require.True(t, ok, c.hint)
+		assert.Equal(t, c.expected, s, c.hint)
+	}
+
+	_, ok := applyDisplayHint("x", []byte{1})
+	assert.False(t, ok)
+}
@@ -0,0 +1,82 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OID is an object identifier.
+type OID []uint32
+
+// ParseOID parses an OID in dotted notation, with or without a leading dot.
+func ParseOID(s string) (OID, error) {
+	s = strings.TrimPrefix(s, ".")
+	if s == "" {
+		return nil, errors.New("empty OID")
+	}
+	parts := strings.Split(s, ".")
+	oid := make(OID, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID %q", s)
+		}
+		oid[i] = uint32(n)
+	}
+	return oid, nil
+}
+
+func (o OID) String() string {
+	var b strings.Builder
+	for i, n := range o {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.FormatUint(uint64(n), 10))
+	}
+	return b.String()
+}
+
+// HasPrefix returns whether prefix is a prefix of o.
+func (o OID) HasPrefix(prefix OID) bool {
+	if len(prefix) > len(o) {
+		return false
+	}
+	for i, n := range prefix {
+		if o[i] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare returns -1, 0 or 1 when o is lexicographically before, equal to
+// or after other.
+func (o OID) Compare(other OID) int {
+	for i := 0; i < len(o) && i < len(other); i++ {
+		switch {
+		case o[i] < other[i]:
+			return -1
+		case o[i] > other[i]:
+			return 1
+		}
+	}
+	switch {
+	case len(o) < len(other):
+		return -1
+	case len(o) > len(other):
+		return 1
+	}
+	return 0
+}
+
+// Append returns a new OID with the sub-identifiers appended to o.
+func (o OID) Append(subids ...uint32) OID {
+	return append(append(make(OID, 0, len(o)+len(subids)), o...), subids...)
+}
@@ -0,0 +1,442 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// smiParser parses the modules of a MIB file, written with the structure of
+// management information, SMIv1 or SMIv2. Only the definitions required to
+// name and decode the objects are parsed, the rest is skipped.
+type smiParser struct {
+	toks []string
+	pos  int
+
+	module      string
+	assignments []oidAssignment
+	types       map[string]typeDef
+}
+
+// oidAssignment is the assignment of an OID value, like { parent 1 }, to an
+// object. The assignments are resolved when all the files are parsed, as
+// they can refer to objects defined in other files.
+type oidAssignment struct {
+	module     string
+	name       string
+	components []oidComponent
+	syntax     *syntax
+}
+
+// oidComponent is a component of an OID value, a name, a number, or both
+// with the name(number) notation.
+type oidComponent struct {
+	name   string
+	number int64
+}
+
+// syntax is the syntax of an object or a type.
+type syntax struct {
+	typ   string
+	enums map[int64]string
+}
+
+// typeDef is a type assignment or a textual convention.
+type typeDef struct {
+	syntax syntax
+	hint   string
+}
+
+// oidMacros are the macros assigning an OID with the ::= { ... } notation.
+var oidMacros = map[string]bool{
+	"OBJECT-TYPE":        true,
+	"OBJECT-IDENTITY":    true,
+	"MODULE-IDENTITY":    true,
+	"NOTIFICATION-TYPE":  true,
+	"OBJECT-GROUP":       true,
+	"NOTIFICATION-GROUP": true,
+	"MODULE-COMPLIANCE":  true,
+	"AGENT-CAPABILITIES": true,
+	"TRAP-TYPE":          true,
+}
+
+func parseSMI(src string, types map[string]typeDef) ([]oidAssignment, error) {
+	p := &smiParser{toks: tokenizeSMI(src), types: types}
+	for !p.done() {
+		if err := p.parseModule(); err != nil {
+			return nil, err
+		}
+	}
+	return p.assignments, nil
+}
+
+func (p *smiParser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+func (p *smiParser) peek(n int) string {
+	if p.pos+n >= len(p.toks) {
+		return ""
+	}
+	return p.toks[p.pos+n]
+}
+
+func (p *smiParser) next() string {
+	tok := p.peek(0)
+	p.pos++
+	return tok
+}
+
+func (p *smiParser) expect(tok string) error {
+	if got := p.next(); got != tok {
+		return fmt.Errorf("module %s: expected %q, found %q", p.module, tok, got)
+	}
+	return nil
+}
+
+// skipTo skips the tokens until tok, included.
+func (p *smiParser) skipTo(tok string) {
+	for !p.done() && p.next() != tok {
+	}
+}
+
+// skipGroup skips a group of tokens enclosed in brackets, if the next token
+// opens one.
+func (p *smiParser) skipGroup() {
+	closing := map[string]string{"{": "}", "(": ")", "[": "]"}[p.peek(0)]
+	if closing == "" {
+		return
+	}
+	opening := p.next()
+	for depth := 1; depth > 0 && !p.done(); {
+		switch p.next() {
+		case opening:
+			depth++
+		case closing:
+			depth--
+		}
+	}
+}
+
+func (p *smiParser) parseModule() error {
+	name := p.next()
+	if p.peek(0) == "{" {
+		// Module identifier OID.
+		p.skipGroup()
+	}
+	if err := p.expect("DEFINITIONS"); err != nil {
+		return err
+	}
+	p.module = name
+	p.skipTo("::=")
+	if err := p.expect("BEGIN"); err != nil {
+		return err
+	}
+
+	for {
+		if p.done() {
+			return fmt.Errorf("module %s: missing END", p.module)
+		}
+		tok := p.next()
+		switch {
+		case tok == "END":
+			return nil
+		case tok == "IMPORTS" || tok == "EXPORTS":
+			p.skipTo(";")
+		case p.peek(0) == "MACRO":
+			p.skipTo("END")
+		case p.peek(0) == "OBJECT" && p.peek(1) == "IDENTIFIER" && p.peek(2) == "::=":
+			p.pos += 3
+			components, err := p.parseOIDValue()
+			if err != nil {
+				return err
+			}
+			p.assign(tok, components, nil)
+		case oidMacros[p.peek(0)]:
+			if err := p.parseMacro(tok); err != nil {
+				return err
+			}
+		case p.peek(0) == "::=":
+			p.pos++
+			if err := p.parseTypeAssignment(tok); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *smiParser) assign(name string, components []oidComponent, s *syntax) {
+	p.assignments = append(p.assignments, oidAssignment{
+		module:     p.module,
+		name:       name,
+		components: components,
+		syntax:     s,
+	})
+}
+
+// parseMacro parses the invocation of a macro assigning an OID, keeping
+// the syntax of the objects.
+func (p *smiParser) parseMacro(name string) error {
+	macro := p.next()
+	var s *syntax
+	for !p.done() {
+		switch tok := p.peek(0); {
+		case tok == "::=":
+			p.pos++
+			if p.peek(0) != "{" {
+				// Traps of SMIv1 are assigned a number.
+				p.pos++
+				return nil
+			}
+			components, err := p.parseOIDValue()
+			if err != nil {
+				return err
+			}
+			p.assign(name, components, s)
+			return nil
+		case tok == "SYNTAX" && macro == "OBJECT-TYPE":
+			p.pos++
+			parsed, err := p.parseSyntax()
+			if err != nil {
+				return fmt.Errorf("module %s: syntax of %s: %w", p.module, name, err)
+			}
+			s = &parsed
+		default:
+			p.pos++
+			p.skipGroupAfter(tok)
+		}
+	}
+	return fmt.Errorf("module %s: unterminated definition of %s", p.module, name)
+}
+
+// skipGroupAfter skips the groups following the clauses whose value is
+// enclosed in brackets, as they can contain keywords.
+func (p *smiParser) skipGroupAfter(tok string) {
+	switch tok {
+	case "INDEX", "AUGMENTS", "DEFVAL", "OBJECTS", "NOTIFICATIONS", "VARIABLES",
+		"MANDATORY-GROUPS", "INCLUDES", "CREATION-REQUIRES":
+		p.skipGroup()
+	}
+}
+
+func (p *smiParser) parseTypeAssignment(name string) error {
+	switch p.peek(0) {
+	case "TEXTUAL-CONVENTION":
+		p.pos++
+		var def typeDef
+		for !p.done() {
+			tok := p.next()
+			switch tok {
+			case "DISPLAY-HINT":
+				def.hint = strings.Trim(p.next(), `"`)
+			case "SYNTAX":
+				s, err := p.parseSyntax()
+				if err != nil {
+					return fmt.Errorf("module %s: syntax of %s: %w", p.module, name, err)
+				}
+				def.syntax = s
+				p.types[name] = def
+				return nil
+			}
+		}
+		return fmt.Errorf("module %s: textual convention %s without syntax", p.module, name)
+	case "SEQUENCE", "CHOICE":
+		p.pos++
+		p.skipGroup()
+		return nil
+	case "[":
+		// Application types of the SMI, [APPLICATION n] IMPLICIT type.
+		p.skipGroup()
+		if p.peek(0) == "IMPLICIT" {
+			p.pos++
+		}
+	}
+	s, err := p.parseSyntax()
+	if err != nil {
+		return fmt.Errorf("module %s: type %s: %w", p.module, name, err)
+	}
+	p.types[name] = typeDef{syntax: s}
+	return nil
+}
+
+// parseSyntax parses a type, with its enumeration or bits, and skips its
+// constraints.
+func (p *smiParser) parseSyntax() (syntax, error) {
+	var s syntax
+	switch tok := p.next(); tok {
+	case "OCTET", "OBJECT":
+		s.typ = tok + " " + p.next()
+	case "SEQUENCE":
+		if err := p.expect("OF"); err != nil {
+			return s, err
+		}
+		s.typ = "SEQUENCE OF " + p.next()
+		return s, nil
+	case "":
+		return s, errors.New("unexpected end of file")
+	default:
+		s.typ = tok
+	}
+	if p.peek(0) == "{" {
+		enums, err := p.parseEnums()
+		if err != nil {
+			return s, err
+		}
+		s.enums = enums
+	}
+	for p.peek(0) == "(" {
+		p.skipGroup()
+	}
+	return s, nil
+}
+
+// parseEnums parses the named numbers of an enumeration or of bits.
+func (p *smiParser) parseEnums() (map[int64]string, error) {
+	p.pos++
+	enums := map[int64]string{}
+	for {
+		name := p.next()
+		if name == "}" {
+			return enums, nil
+		}
+		if p.next() != "(" {
+			return nil, fmt.Errorf("invalid named number %q", name)
+		}
+		n, err := strconv.ParseInt(p.next(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number of %q: %w", name, err)
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		enums[n] = name
+		switch p.next() {
+		case ",":
+		case "}":
+			return enums, nil
+		default:
+			return nil, errors.New("invalid enumeration")
+		}
+	}
+}
+
+// parseOIDValue parses an OID value, like { parent 1 } or
+// { iso org(3) dod(6) }.
+func (p *smiParser) parseOIDValue() ([]oidComponent, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var components []oidComponent
+	for {
+		tok := p.next()
+		switch {
+		case tok == "}":
+			if len(components) == 0 {
+				return nil, fmt.Errorf("module %s: empty OID value", p.module)
+			}
+			return components, nil
+		case tok == "":
+			return nil, fmt.Errorf("module %s: unterminated OID value", p.module)
+		case isNumber(tok):
+			n, err := strconv.ParseInt(tok, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, oidComponent{number: n})
+		default:
+			c := oidComponent{name: tok, number: -1}
+			if p.peek(0) == "(" {
+				p.pos++
+				n, err := strconv.ParseInt(p.next(), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("module %s: invalid OID component %q: %w", p.module, tok, err)
+				}
+				if err := p.expect(")"); err != nil {
+					return nil, err
+				}
+				c.number = n
+			}
+			components = append(components, c)
+		}
+	}
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenizeSMI splits the source of a MIB in tokens, removing the comments.
+// Quoted strings are kept as single tokens, with their quotes.
+func tokenizeSMI(src string) []string {
+	var toks []string
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case strings.HasPrefix(src[i:], "--"):
+			// Comments end at the end of the line, or at the next --.
+			end := i + 2
+			for end < len(src) && src[end] != '\n' && !strings.HasPrefix(src[end:], "--") {
+				end++
+			}
+			if strings.HasPrefix(src[end:], "--") {
+				end += 2
+			}
+			i = end
+		case c == '"':
+			end := strings.IndexByte(src[i+1:], '"')
+			if end < 0 {
+				toks = append(toks, src[i:])
+				i = len(src)
+				continue
+			}
+			toks = append(toks, src[i:i+end+2])
+			i += end + 2
+		case strings.HasPrefix(src[i:], "::="):
+			toks = append(toks, "::=")
+			i += 3
+		case strings.HasPrefix(src[i:], ".."):
+			toks = append(toks, "..")
+			i += 2
+		case isIdentChar(c) || c == '\'':
+			end := i + 1
+			if c == '\'' {
+				// Binary and hexadecimal strings, like 'ff'H.
+				for end < len(src) && src[end] != '\'' {
+					end++
+				}
+				end += 2
+			}
+			for end < len(src) && isIdentChar(src[end]) && !strings.HasPrefix(src[end:], "--") {
+				end++
+			}
+			if end > len(src) {
+				end = len(src)
+			}
+			toks = append(toks, src[i:end])
+			i = end
+		default:
+			toks = append(toks, string(c))
+			i++
+		}
+	}
+	return toks
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_'
+}
@@ -0,0 +1,106 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/sync/semaphore"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// defaultPort is the port of the agents when it is not set in the hosts.
+const defaultPort = "161"
+
+func init() {
+	if err := mb.Registry.AddModule("snmp", newModule); err != nil {
+		panic(err)
+	}
+}
+
+// Module is the snmp module. Its metricsets, one per target and metricset,
+// share the compiled MIB and the limit of targets queried concurrently.
+type Module interface {
+	mb.Module
+
+	// MIB returns the compiled MIB modules.
+	MIB() *MIB
+	// NewClient returns a client of the agent at addr.
+	NewClient(addr string) *Client
+	// Acquire waits until a target can be queried, it must be followed by
+	// a call to Release.
+	Acquire(ctx context.Context) error
+	Release()
+}
+
+type module struct {
+	mb.BaseModule
+
+	config Config
+	mib    *MIB
+	sem    *semaphore.Weighted
+}
+
+func newModule(base mb.BaseModule) (mb.Module, error) {
+	config := defaultConfig()
+	if err := base.UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	mib, err := LoadMIB(config.MIBPaths)
+	if err != nil {
+		return nil, err
+	}
+	m := &module{
+		BaseModule: base,
+		config:     config,
+		mib:        mib,
+	}
+	if config.MaxConcurrency > 0 {
+		m.sem = semaphore.NewWeighted(int64(config.MaxConcurrency))
+	}
+	return m, nil
+}
+
+func (m *module) MIB() *MIB {
+	return m.mib
+}
+
+func (m *module) NewClient(addr string) *Client {
+	return NewClient(addr, m.config)
+}
+
+func (m *module) Acquire(ctx context.Context) error {
+	if m.sem == nil {
+		return ctx.Err()
+	}
+	return m.sem.Acquire(ctx, 1)
+}
+
+func (m *module) Release() {
+	if m.sem != nil {
+		m.sem.Release(1)
+	}
+}
+
+// ParseHost is the host parser of the metricsets. Hosts are an address with
+// an optional port, 161 by default, and an optional udp:// scheme.
+func ParseHost(_ mb.Module, host string) (mb.HostData, error) {
+	addr := strings.TrimPrefix(host, "udp://")
+	if strings.Contains(addr, "://") {
+		return mb.HostData{}, fmt.Errorf("unsupported scheme in host %q, only udp is supported", host)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(strings.Trim(addr, "[]"), defaultPort)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return mb.HostData{}, fmt.Errorf("invalid host %q: %w", host, err)
+	}
+	uri := "udp://" + addr
+	return mb.HostData{URI: uri, SanitizedURI: uri, Host: addr}, nil
+}
@@ -0,0 +1,437 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des" //nolint:gosec // DES is one of the privacy protocols of SNMPv3.
+	"crypto/hmac"
+	"crypto/md5"  //nolint:gosec // MD5 is one of the authentication protocols of SNMPv3.
+	"crypto/sha1" //nolint:gosec // SHA-1 is one of the authentication protocols of SNMPv3.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// Message flags of SNMPv3.
+const (
+	flagAuth       byte = 0x01
+	flagPriv       byte = 0x02
+	flagReportable byte = 0x04
+)
+
+// securityModelUSM is the user-based security model.
+const securityModelUSM = 3
+
+// maxMessageSize is the largest message accepted.
+const maxMessageSize = 65507
+
+// authProtocol is an authentication protocol of the user-based security
+// model.
+type authProtocol struct {
+	name    string
+	hash    func() hash.Hash
+	macSize int
+}
+
+var authProtocols = map[string]authProtocol{
+	"MD5":    {name: "MD5", hash: md5.New, macSize: 12},
+	"SHA":    {name: "SHA", hash: sha1.New, macSize: 12},
+	"SHA224": {name: "SHA224", hash: sha256.New224, macSize: 16},
+	"SHA256": {name: "SHA256", hash: sha256.New, macSize: 24},
+	"SHA384": {name: "SHA384", hash: sha512.New384, macSize: 32},
+	"SHA512": {name: "SHA512", hash: sha512.New, macSize: 48},
+}
+
+// privacy protocols of the user-based security model.
+const (
+	privDES = "DES"
+	privAES = "AES"
+)
+
+func lookupAuthProtocol(name string) (authProtocol, error) {
+	p, ok := authProtocols[strings.ToUpper(name)]
+	if !ok {
+		return authProtocol{}, fmt.Errorf("unsupported authentication protocol %q", name)
+	}
+	return p, nil
+}
+
+// passwordToKey derives the key of a password localized to the engine, as
+// described in RFC 3414 A.2.
+func passwordToKey(h func() hash.Hash, password string, engineID []byte) []byte {
+	const expanded = 1024 * 1024
+	ku := h()
+	buf := make([]byte, 64)
+	pw := []byte(password)
+	for i := 0; i < expanded; i += len(buf) {
+		for j := range buf {
+			buf[j] = pw[(i+j)%len(pw)]
+		}
+		ku.Write(buf)
+	}
+	key := ku.Sum(nil)
+
+	kul := h()
+	kul.Write(key)
+	kul.Write(engineID)
+	kul.Write(key)
+	return kul.Sum(nil)
+}
+
+// usmParameters are the security parameters of an SNMPv3 message.
+type usmParameters struct {
+	engineID   []byte
+	boots      int32
+	time       int32
+	userName   string
+	authParams []byte
+	privParams []byte
+}
+
+func (p *usmParameters) encode() []byte {
+	return sequence(tagSequence,
+		encodeOctetString(p.engineID),
+		encodeInteger(int64(p.boots)),
+		encodeInteger(int64(p.time)),
+		encodeOctetString([]byte(p.userName)),
+		encodeOctetString(p.authParams),
+		encodeOctetString(p.privParams),
+	)
+}
+
+// v3Message is an SNMPv3 message. The scoped PDU is either in the clear, in
+// contextEngineID, contextName and pdu, or encrypted.
+type v3Message struct {
+	msgID  int32
+	flags  byte
+	params usmParameters
+
+	contextEngineID []byte
+	contextName     string
+	pdu             *pdu
+	encrypted       []byte
+}
+
+func (m *v3Message) scopedPDU() ([]byte, error) {
+	data, err := m.pdu.encode()
+	if err != nil {
+		return nil, err
+	}
+	return sequence(tagSequence,
+		encodeOctetString(m.contextEngineID),
+		encodeOctetString([]byte(m.contextName)),
+		data,
+	), nil
+}
+
+func (m *v3Message) encode() ([]byte, error) {
+	data := appendTLV(nil, tagOctetString, m.encrypted)
+	if m.flags&flagPriv == 0 {
+		var err error
+		if data, err = m.scopedPDU(); err != nil {
+			return nil, err
+		}
+	}
+	return sequence(tagSequence,
+		encodeInteger(versionV3),
+		sequence(tagSequence,
+			encodeInteger(int64(m.msgID)),
+			encodeInteger(maxMessageSize),
+			encodeOctetString([]byte{m.flags}),
+			encodeInteger(securityModelUSM),
+		),
+		encodeOctetString(m.params.encode()),
+		data,
+	), nil
+}
+
+// decodeV3Message decodes an SNMPv3 message. It also returns the offset of
+// the authentication parameters in b, to verify its digest.
+func decodeV3Message(b []byte) (*v3Message, int, error) {
+	r := &berReader{data: b}
+	msg, err := r.sequence(tagSequence)
+	if err != nil {
+		return nil, 0, err
+	}
+	version, err := msg.integer()
+	if err != nil {
+		return nil, 0, err
+	}
+	if version != versionV3 {
+		return nil, 0, fmt.Errorf("unexpected message version %d", version)
+	}
+
+	header, err := msg.sequence(tagSequence)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading header: %w", err)
+	}
+	m := &v3Message{}
+	msgID, err := header.integer()
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading message ID: %w", err)
+	}
+	m.msgID = int32(msgID)
+	if _, err := header.integer(); err != nil {
+		return nil, 0, fmt.Errorf("reading max size: %w", err)
+	}
+	flags, err := header.octetString()
+	if err != nil || len(flags) != 1 {
+		return nil, 0, errors.New("invalid message flags")
+	}
+	m.flags = flags[0]
+	model, err := header.integer()
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading security model: %w", err)
+	}
+	if model != securityModelUSM {
+		return nil, 0, fmt.Errorf("unsupported security model %d", model)
+	}
+
+	paramsData, err := msg.octetString()
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading security parameters: %w", err)
+	}
+	params, err := (&berReader{data: paramsData}).sequence(tagSequence)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading security parameters: %w", err)
+	}
+	if m.params.engineID, err = params.octetString(); err != nil {
+		return nil, 0, err
+	}
+	boots, err := params.integer()
+	if err != nil {
+		return nil, 0, err
+	}
+	engineTime, err := params.integer()
+	if err != nil {
+		return nil, 0, err
+	}
+	m.params.boots, m.params.time = int32(boots), int32(engineTime)
+	user, err := params.octetString()
+	if err != nil {
+		return nil, 0, err
+	}
+	m.params.userName = string(user)
+	if m.params.authParams, err = params.octetString(); err != nil {
+		return nil, 0, err
+	}
+	// The auth parameters share the backing array of b.
+	authOffset := cap(b) - cap(m.params.authParams)
+	if m.params.privParams, err = params.octetString(); err != nil {
+		return nil, 0, err
+	}
+
+	if m.flags&flagPriv != 0 {
+		if m.encrypted, err = msg.octetString(); err != nil {
+			return nil, 0, fmt.Errorf("reading encrypted PDU: %w", err)
+		}
+		return m, authOffset, nil
+	}
+	if err := m.decodeScopedPDU(msg); err != nil {
+		return nil, 0, err
+	}
+	return m, authOffset, nil
+}
+
+func (m *v3Message) decodeScopedPDU(r *berReader) error {
+	scoped, err := r.sequence(tagSequence)
+	if err != nil {
+		return fmt.Errorf("reading scoped PDU: %w", err)
+	}
+	if m.contextEngineID, err = scoped.octetString(); err != nil {
+		return err
+	}
+	name, err := scoped.octetString()
+	if err != nil {
+		return err
+	}
+	m.contextName = string(name)
+	m.pdu, err = decodePDU(scoped)
+	return err
+}
+
+// usmKeys are the keys of a user, localized to an engine.
+type usmKeys struct {
+	auth    authProtocol
+	authKey []byte
+	priv    string
+	privKey []byte
+	hasAuth bool
+	hasPriv bool
+}
+
+func (k *usmKeys) flags() byte {
+	var f byte
+	if k.hasAuth {
+		f |= flagAuth
+	}
+	if k.hasPriv {
+		f |= flagPriv
+	}
+	return f
+}
+
+// digest computes the digest of an encoded message whose auth parameters are
+// zeroed.
+func (k *usmKeys) digest(b []byte) []byte {
+	mac := hmac.New(k.auth.hash, k.authKey)
+	mac.Write(b)
+	return mac.Sum(nil)[:k.auth.macSize]
+}
+
+// verify checks the digest of a received message, whose auth parameters are
+// at offset.
+func (k *usmKeys) verify(b []byte, offset int, digest []byte) error {
+	if len(digest) != k.auth.macSize || offset < 0 || offset+len(digest) > len(b) {
+		return errors.New("invalid authentication parameters")
+	}
+	zeroed := append([]byte(nil), b...)
+	for i := range digest {
+		zeroed[offset+i] = 0
+	}
+	if !hmac.Equal(k.digest(zeroed), digest) {
+		return errors.New("wrong message digest")
+	}
+	return nil
+}
+
+// encrypt encrypts a scoped PDU, returning the ciphertext and the privacy
+// parameters.
+func (k *usmKeys) encrypt(data []byte, boots, engineTime int32, salt uint64) ([]byte, []byte, error) {
+	privParams := make([]byte, 8)
+	switch k.priv {
+	case privDES:
+		binary.BigEndian.PutUint32(privParams, uint32(boots))
+		binary.BigEndian.PutUint32(privParams[4:], uint32(salt))
+		block, err := des.NewCipher(k.privKey[:8]) //nolint:gosec // Required by the protocol.
+		if err != nil {
+			return nil, nil, err
+		}
+		iv := make([]byte, 8)
+		for i := range iv {
+			iv[i] = k.privKey[8+i] ^ privParams[i]
+		}
+		if rem := len(data) % 8; rem != 0 {
+			data = append(data, make([]byte, 8-rem)...)
+		}
+		out := make([]byte, len(data))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(out, data)
+		return out, privParams, nil
+	case privAES:
+		binary.BigEndian.PutUint64(privParams, salt)
+		block, err := aes.NewCipher(k.privKey[:16])
+		if err != nil {
+			return nil, nil, err
+		}
+		out := make([]byte, len(data))
+		cipher.NewCFBEncrypter(block, aesIV(boots, engineTime, privParams)).XORKeyStream(out, data)
+		return out, privParams, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported privacy protocol %q", k.priv)
+}
+
+// decrypt decrypts the scoped PDU of a message.
+func (k *usmKeys) decrypt(data []byte, params usmParameters) ([]byte, error) {
+	if len(params.privParams) != 8 {
+		return nil, errors.New("invalid privacy parameters")
+	}
+	switch k.priv {
+	case privDES:
+		if len(data)%8 != 0 {
+			return nil, errors.New("encrypted PDU is not a multiple of the block size")
+		}
+		block, err := des.NewCipher(k.privKey[:8]) //nolint:gosec // Required by the protocol.
+		if err != nil {
+			return nil, err
+		}
+		iv := make([]byte, 8)
+		for i := range iv {
+			iv[i] = k.privKey[8+i] ^ params.privParams[i]
+		}
+		out := make([]byte, len(data))
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+		return out, nil
+	case privAES:
+		block, err := aes.NewCipher(k.privKey[:16])
+		if err != nil {
+			return nil, err
+		}
+		out := make([]byte, len(data))
+		cipher.NewCFBDecrypter(block, aesIV(params.boots, params.time, params.privParams)).XORKeyStream(out, data)
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported privacy protocol %q", k.priv)
+}
+
+func aesIV(boots, engineTime int32, salt []byte) []byte {
+	iv := make([]byte, 16)
+	binary.BigEndian.PutUint32(iv, uint32(boots))
+	binary.BigEndian.PutUint32(iv[4:], uint32(engineTime))
+	copy(iv[8:], salt)
+	return iv
+}
+
+// sealMessage encodes the message, encrypting its scoped PDU and setting its
+// digest as required by its flags.
+func (k *usmKeys) sealMessage(m *v3Message, salt uint64) ([]byte, error) {
+	if m.flags&flagPriv != 0 {
+		scoped, err := m.scopedPDU()
+		if err != nil {
+			return nil, err
+		}
+		m.encrypted, m.params.privParams, err = k.encrypt(scoped, m.params.boots, m.params.time, salt)
+		if err != nil {
+			return nil, fmt.Errorf("encrypting PDU: %w", err)
+		}
+	}
+	if m.flags&flagAuth == 0 {
+		return m.encode()
+	}
+	m.params.authParams = make([]byte, k.auth.macSize)
+	b, err := m.encode()
+	if err != nil {
+		return nil, err
+	}
+	m.params.authParams = k.digest(b)
+	// The digest has the length of the placeholder, re-encoding only
+	// replaces it.
+	return m.encode()
+}
+
+// openMessage verifies the digest of a received message, and decrypts its
+// scoped PDU, as required by its flags.
+func (k *usmKeys) openMessage(b []byte) (*v3Message, error) {
+	m, authOffset, err := decodeV3Message(b)
+	if err != nil {
+		return nil, err
+	}
+	if m.flags&flagAuth != 0 {
+		if !k.hasAuth {
+			return nil, errors.New("unexpected authenticated message")
+		}
+		if err := k.verify(b, authOffset, m.params.authParams); err != nil {
+			return nil, err
+		}
+	}
+	if m.flags&flagPriv != 0 {
+		if !k.hasPriv {
+			return nil, errors.New("unexpected encrypted message")
+		}
+		scoped, err := k.decrypt(m.encrypted, m.params)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting PDU: %w", err)
+		}
+		if err := m.decodeScopedPDU(&berReader{data: scoped}); err != nil {
+			return nil, fmt.Errorf("decrypting PDU: %w", err)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,100 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordToKey(t *testing.T) {
+	// Test vectors of RFC 3414 A.3.
+	engineID, _ := hex.DecodeString("000000000000000000000002")
+	for name, expected := range map[string]string{
+		"MD5": "526f5eed9fcce26f8964c2930787d82b",
+		"SHA": "6695febc9288e36282235fc7151f128497b38f3f",
+	} {
+		auth, err := lookupAuthProtocol(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, hex.EncodeToString(passwordToKey(auth.hash, "maplesyrup", engineID)), name)
+	}
+}
+
+func TestSealOpenMessage(t *testing.T) {
+	engineID := []byte{0x80, 0, 0x1f, 0x88, 0x80, 1, 2, 3, 4}
+	cases := map[string]struct {
+		auth, priv string
+	}{
+		"noAuthNoPriv": {},
+		"MD5":          {auth: "MD5"},
+		"SHA512":       {auth: "SHA512"},
+		"SHA+DES":      {auth: "SHA", priv: privDES},
+		"SHA256+AES":   {auth: "SHA256", priv: privAES},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			keys := testKeys(t, c.auth, c.priv, "authpassword", "privpassword", engineID)
+			m := &v3Message{
+				msgID: 42,
+				flags: keys.flags() | flagReportable,
+				params: usmParameters{
+					engineID: engineID,
+					boots:    3,
+					time:     1200,
+					userName: "user",
+				},
+				contextEngineID: engineID,
+				pdu: &pdu{
+					typ:       pduGetRequest,
+					requestID: 42,
+					varbinds:  []Varbind{{OID: OID{1, 3, 6, 1, 2, 1, 1, 1, 0}, Type: tagNull}},
+				},
+			}
+			b, err := keys.sealMessage(m, 7)
+			require.NoError(t, err)
+
+			got, err := keys.openMessage(b)
+			require.NoError(t, err)
+			assert.Equal(t, m.pdu, got.pdu)
+			assert.Equal(t, "user", got.params.userName)
+			assert.EqualValues(t, 1200, got.params.time)
+
+			if c.auth == "" {
+				return
+			}
+			wrong := testKeys(t, c.auth, c.priv, "otherpassword", "privpassword", engineID)
+			_, err = wrong.openMessage(b)
+			assert.ErrorContains(t, err, "wrong message digest")
+
+			tampered := append([]byte(nil), b...)
+			tampered[len(tampered)-1] ^= 1
+			_, err = keys.openMessage(tampered)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func testKeys(t *testing.T, authName, priv, authPassword, privPassword string, engineID []byte) *usmKeys {
+	t.Helper()
+	if authName == "" {
+		return &usmKeys{}
+	}
+	auth, err := lookupAuthProtocol(authName)
+	require.NoError(t, err)
+	keys := &usmKeys{
+		auth:    auth,
+		authKey: passwordToKey(auth.hash, authPassword, engineID),
+		hasAuth: true,
+	}
+	if priv != "" {
+		keys.priv = priv
+		keys.privKey = passwordToKey(auth.hash, privPassword, engineID)
+		keys.hasPriv = true
+	}
+	return keys
+}
@@ -0,0 +1,202 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snmp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Value converts the value of a varbind of the object to the value of its
+// field. Named numbers are converted to their names, and octet strings are
+// formatted with the display hint of the object, or as text if they are
+// printable. The object can be nil, for OIDs not found in the MIB.
+// Exceptions are converted to nil.
+func (o *Object) Value(vb Varbind) interface{} {
+	switch v := vb.Value.(type) {
+	case int64:
+		if o != nil {
+			if name, ok := o.enums[v]; ok {
+				return name
+			}
+		}
+		return v
+	case uint64:
+		return v
+	case []byte:
+		if o != nil {
+			if o.base == "BITS" {
+				return bitNames(v, o.enums)
+			}
+			if o.hint != "" {
+				if s, ok := applyDisplayHint(o.hint, v); ok {
+					return s
+				}
+			}
+		}
+		if printable(v) {
+			return string(bytes.TrimRight(v, "\x00"))
+		}
+		return hexString(v, ':')
+	case OID:
+		return v.String()
+	case net.IP:
+		return v.String()
+	}
+	return nil
+}
+
+// bitNames returns the names of the bits set in a BITS value.
+func bitNames(b []byte, names map[int64]string) []string {
+	set := []string{}
+	for i, c := range b {
+		for j := 0; j < 8; j++ {
+			if c&(0x80>>j) == 0 {
+				continue
+			}
+			n := int64(i*8 + j)
+			name, ok := names[n]
+			if !ok {
+				name = strconv.FormatInt(n, 10)
+			}
+			set = append(set, name)
+		}
+	}
+	return set
+}
+
+func printable(b []byte) bool {
+	b = bytes.TrimRight(b, "\x00")
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func hexString(b []byte, sep byte) string {
+	var s strings.Builder
+	for i, c := range b {
+		if i > 0 {
+			s.WriteByte(sep)
+		}
+		s.WriteString(hex.EncodeToString([]byte{c}))
+	}
+	return s.String()
+}
+
+// hintSpec is an octet-format specification of a display hint, as defined
+// in RFC 2579 section 3.1.
+type hintSpec struct {
+	repeat bool
+	length int
+	format byte
+	sep    byte
+	term   byte
+}
+
+// applyDisplayHint formats an octet string with a display hint. It returns
+// false if the hint is invalid.
+func applyDisplayHint(hint string, b []byte) (string, bool) {
+	specs, ok := parseDisplayHint(hint)
+	if !ok {
+		return "", false
+	}
+	var out strings.Builder
+	for i := 0; len(b) > 0; i++ {
+		spec := specs[len(specs)-1]
+		if i < len(specs) {
+			spec = specs[i]
+		}
+		count := 1
+		if spec.repeat {
+			count = int(b[0])
+			b = b[1:]
+		}
+		for j := 0; j < count && len(b) > 0; j++ {
+			n := spec.length
+			if n > len(b) {
+				n = len(b)
+			}
+			formatOctets(&out, spec.format, b[:n])
+			b = b[n:]
+			if len(b) > 0 && spec.sep != 0 && !(spec.repeat && j == count-1 && spec.term != 0) {
+				out.WriteByte(spec.sep)
+			}
+		}
+		if spec.repeat && spec.term != 0 && len(b) > 0 {
+			out.WriteByte(spec.term)
+		}
+	}
+	return out.String(), true
+}
+
+func parseDisplayHint(hint string) ([]hintSpec, bool) {
+	var specs []hintSpec
+	for i := 0; i < len(hint); {
+		var spec hintSpec
+		if hint[i] == '*' {
+			spec.repeat = true
+			i++
+		}
+		start := i
+		for i < len(hint) && hint[i] >= '0' && hint[i] <= '9' {
+			i++
+		}
+		if start == i || i == len(hint) {
+			return nil, false
+		}
+		spec.length, _ = strconv.Atoi(hint[start:i])
+		spec.format = hint[i]
+		i++
+		if !strings.ContainsRune("xdoat", rune(spec.format)) || spec.length == 0 {
+			return nil, false
+		}
+		if i < len(hint) && !isHintSpecStart(hint[i]) {
+			spec.sep = hint[i]
+			i++
+			if spec.repeat && i < len(hint) && !isHintSpecStart(hint[i]) {
+				spec.term = hint[i]
+				i++
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs, len(specs) > 0
+}
+
+func isHintSpecStart(c byte) bool {
+	return c == '*' || (c >= '0' && c <= '9')
+}
+
+func formatOctets(out *strings.Builder, format byte, b []byte) {
+	switch format {
+	case 'a', 't':
+		out.WriteString(strings.ToValidUTF8(string(b), "\uFFFD"))
+		return
+	case 'x':
+		out.WriteString(hex.EncodeToString(b))
+		return
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	switch format {
+	case 'd':
+		out.WriteString(strconv.FormatUint(v, 10))
+	case 'o':
+		out.WriteString(strconv.FormatUint(v, 8))
+	}
+}
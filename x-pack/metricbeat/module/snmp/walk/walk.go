@@ -0,0 +1,200 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package walk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/snmp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("snmp", "walk", New,
+		mb.WithHostParser(snmp.ParseHost),
+	)
+}
+
+type config struct {
+	Tables []tableConfig `config:"tables" validate:"required"`
+}
+
+// tableConfig is a table to walk, numeric or a MIB object name, and the
+// columns to walk, all the columns known in the MIB by default.
+type tableConfig struct {
+	OID     string   `config:"oid" validate:"required"`
+	Name    string   `config:"name"`
+	Columns []string `config:"columns"`
+}
+
+// table is a table to walk, with its resolved columns.
+type table struct {
+	name    string
+	columns []column
+}
+
+type column struct {
+	name   string
+	oid    snmp.OID
+	object *snmp.Object
+}
+
+// MetricSet walks tables of an agent with GetBulkRequests, and reports an
+// event per row.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod    snmp.Module
+	client *snmp.Client
+	tables []table
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The snmp walk metricset is beta.")
+
+	mod, ok := base.Module().(snmp.Module)
+	if !ok {
+		return nil, errors.New("must be child of snmp module")
+	}
+
+	var config config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		mod:           mod,
+		client:        mod.NewClient(base.Host()),
+	}
+	for _, c := range config.Tables {
+		t, err := newTable(mod.MIB(), c)
+		if err != nil {
+			return nil, err
+		}
+		m.tables = append(m.tables, t)
+	}
+	return m, nil
+}
+
+func newTable(mib *snmp.MIB, c tableConfig) (table, error) {
+	oid, object, err := mib.Resolve(c.OID)
+	if err != nil {
+		return table{}, err
+	}
+	t := table{name: c.Name}
+	if t.name == "" {
+		if object == nil || len(object.OID) != len(oid) {
+			return table{}, fmt.Errorf("name is required for table %s, not found in the MIB", c.OID)
+		}
+		t.name = object.Name
+	}
+
+	if len(c.Columns) == 0 {
+		if object == nil || len(object.OID) != len(oid) {
+			return table{}, fmt.Errorf("columns are required for table %s, not found in the MIB", c.OID)
+		}
+		for _, o := range mib.Columns(object) {
+			t.columns = append(t.columns, column{name: o.Name, oid: o.OID, object: o})
+		}
+		if len(t.columns) == 0 {
+			return table{}, fmt.Errorf("%s is not a table with known columns, set its columns", c.OID)
+		}
+		return t, nil
+	}
+
+	for _, name := range c.Columns {
+		columnOID, columnObject, err := mib.Resolve(name)
+		if err != nil {
+			return table{}, fmt.Errorf("column of table %s: %w", c.OID, err)
+		}
+		if !columnOID.HasPrefix(oid) || len(columnOID) == len(oid) {
+			return table{}, fmt.Errorf("column %s is not in table %s", name, c.OID)
+		}
+		col := column{oid: columnOID, object: columnObject}
+		if columnObject != nil && len(columnObject.OID) == len(columnOID) {
+			col.name = columnObject.Name
+		} else {
+			col.name = strings.ReplaceAll(columnOID[len(oid):].String(), ".", "_")
+		}
+		t.columns = append(t.columns, col)
+	}
+	return t, nil
+}
+
+// Fetch walks the columns of the tables, and reports the rows.
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	if err := m.mod.Acquire(ctx); err != nil {
+		return err
+	}
+	defer m.mod.Release()
+
+	for _, t := range m.tables {
+		rows, err := m.walk(ctx, t)
+		if err != nil {
+			return fmt.Errorf("walking table %s of %s: %w", t.name, m.Host(), err)
+		}
+		for _, row := range rows {
+			if !r.Event(mb.Event{MetricSetFields: mapstr.M{
+				"table": t.name,
+				"index": row.index.String(),
+				t.name:  row.fields,
+			}}) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+type row struct {
+	index  snmp.OID
+	fields mapstr.M
+}
+
+// walk walks the columns of a table, and returns its rows ordered by index.
+func (m *MetricSet) walk(ctx context.Context, t table) ([]*row, error) {
+	rows := map[string]*row{}
+	for _, col := range t.columns {
+		err := m.client.BulkWalk(ctx, col.oid, func(vb snmp.Varbind) error {
+			value := col.object.Value(vb)
+			if value == nil {
+				return nil
+			}
+			index := vb.OID[len(col.oid):]
+			key := index.String()
+			rw, ok := rows[key]
+			if !ok {
+				rw = &row{index: index, fields: mapstr.M{}}
+				rows[key] = rw
+			}
+			rw.fields[col.name] = value
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]*row, 0, len(rows))
+	for _, rw := range rows {
+		sorted = append(sorted, rw)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].index.Compare(sorted[j].index) < 0
+	})
+	return sorted, nil
+}
+
+// Close closes the connection with the agent.
+func (m *MetricSet) Close() error {
+	return m.client.Close()
+}